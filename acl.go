@@ -0,0 +1,341 @@
+package hdfs
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	hdfs "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_hdfs"
+	"github.com/golang/protobuf/proto"
+)
+
+// AclEntryType is the kind of principal that an ACL entry applies to.
+type AclEntryType int
+
+const (
+	AclUser AclEntryType = iota
+	AclGroup
+	AclMask
+	AclOther
+)
+
+var aclEntryTypeNames = []string{"user", "group", "mask", "other"}
+
+// String returns the name used for the type in ACL specs, for example "user".
+func (t AclEntryType) String() string {
+	if int(t) < 0 || int(t) >= len(aclEntryTypeNames) {
+		return fmt.Sprintf("AclEntryType(%d)", int(t))
+	}
+
+	return aclEntryTypeNames[t]
+}
+
+// AclEntry is a single entry in the access control list of a file or
+// directory.
+type AclEntry struct {
+	Type AclEntryType
+	// Name is the user or group that the entry applies to. For user and group
+	// entries, an empty name refers to the owner or group of the file.
+	Name string
+	// Permissions is a combination of 4 (read), 2 (write), and 1 (execute).
+	Permissions os.FileMode
+	// Default specifies that the entry is part of the default ACL of a
+	// directory, which new children inherit, rather than the ACL of the
+	// directory itself.
+	Default bool
+}
+
+// String returns the entry in the form used by getfacl and setfacl, for
+// example "default:user:alice:rw-".
+func (e AclEntry) String() string {
+	var b strings.Builder
+	if e.Default {
+		b.WriteString("default:")
+	}
+
+	b.WriteString(e.Type.String())
+	b.WriteByte(':')
+	b.WriteString(e.Name)
+	b.WriteByte(':')
+	for i, c := range "rwx" {
+		if e.Permissions&(4>>i) != 0 {
+			b.WriteRune(c)
+		} else {
+			b.WriteByte('-')
+		}
+	}
+
+	return b.String()
+}
+
+// ParseAclSpec parses a comma-separated list of ACL entries, in the form used
+// by setfacl, for example "user::rwx,user:alice:r-x,group::r-x,other::---".
+// If includePermissions is false, as for RemoveAclEntries, the entries don't
+// have permissions, for example "user:alice,default:group:admins".
+func ParseAclSpec(spec string, includePermissions bool) ([]AclEntry, error) {
+	var entries []AclEntry
+	for _, s := range strings.Split(spec, ",") {
+		entry, err := parseAclEntry(strings.TrimSpace(s), includePermissions)
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+func parseAclEntry(s string, includePermissions bool) (AclEntry, error) {
+	var entry AclEntry
+	parts := strings.Split(s, ":")
+	if len(parts) > 0 && parts[0] == "default" {
+		entry.Default = true
+		parts = parts[1:]
+	}
+
+	switch {
+	case includePermissions && len(parts) == 3:
+	case !includePermissions && (len(parts) == 1 || len(parts) == 2):
+	default:
+		return entry, fmt.Errorf("invalid ACL entry: %q", s)
+	}
+
+	found := false
+	for i, name := range aclEntryTypeNames {
+		if parts[0] == name {
+			entry.Type = AclEntryType(i)
+			found = true
+		}
+	}
+
+	if !found {
+		return entry, fmt.Errorf("invalid ACL entry type: %q", s)
+	}
+
+	if len(parts) > 1 {
+		entry.Name = parts[1]
+	}
+
+	if entry.Name != "" && (entry.Type == AclMask || entry.Type == AclOther) {
+		return entry, fmt.Errorf("invalid ACL entry, %s entries can't have a name: %q", entry.Type, s)
+	}
+
+	if includePermissions {
+		perms := parts[2]
+		if len(perms) != 3 {
+			return entry, fmt.Errorf("invalid ACL permissions: %q", s)
+		}
+
+		for i, c := range "rwx" {
+			switch rune(perms[i]) {
+			case c:
+				entry.Permissions |= 4 >> i
+			case '-':
+			default:
+				return entry, fmt.Errorf("invalid ACL permissions: %q", s)
+			}
+		}
+	}
+
+	return entry, nil
+}
+
+// AclStatus is the access control list of a file or directory, as returned by
+// GetAclStatus.
+type AclStatus struct {
+	Owner  string
+	Group  string
+	Sticky bool
+	// Entries contains only the entries that aren't implied by the permission
+	// bits of the file. If it's empty, the file doesn't have an ACL beyond its
+	// permissions.
+	Entries    []AclEntry
+	Permission os.FileMode
+}
+
+// GetAclStatus returns the access control list of the named file or
+// directory.
+func (c *Client) GetAclStatus(name string) (*AclStatus, error) {
+	req := &hdfs.GetAclStatusRequestProto{Src: proto.String(name)}
+	resp := &hdfs.GetAclStatusResponseProto{}
+
+	err := c.namenode.Execute("getAclStatus", req, resp)
+	if err != nil {
+		return nil, &os.PathError{"getacl", name, interpretException(err)}
+	}
+
+	result := resp.GetResult()
+	status := &AclStatus{
+		Owner:  result.GetOwner(),
+		Group:  result.GetGroup(),
+		Sticky: result.GetSticky(),
+	}
+
+	for _, entry := range result.GetEntries() {
+		status.Entries = append(status.Entries, AclEntry{
+			Type:        AclEntryType(entry.GetType()),
+			Name:        entry.GetName(),
+			Permissions: os.FileMode(entry.GetPermissions()),
+			Default:     entry.GetScope() == hdfs.AclEntryProto_DEFAULT,
+		})
+	}
+
+	// Older namenodes don't include the permission bits, which are needed to
+	// interpret the entries.
+	if result.GetPermission() != nil {
+		status.Permission = os.FileMode(result.GetPermission().GetPerm())
+	} else {
+		info, err := c.Stat(name)
+		if err != nil {
+			return nil, err
+		}
+
+		status.Permission = info.Mode().Perm()
+	}
+
+	return status, nil
+}
+
+// Acl returns the full access control list described by the status, including
+// the entries implied by the permission bits, in a form that can be passed to
+// SetAcl.
+func (s *AclStatus) Acl() []AclEntry {
+	perm := s.Permission
+	acl := []AclEntry{{Type: AclUser, Permissions: (perm >> 6) & 7}}
+	if len(s.Entries) == 0 {
+		return append(acl,
+			AclEntry{Type: AclGroup, Permissions: (perm >> 3) & 7},
+			AclEntry{Type: AclOther, Permissions: perm & 7},
+		)
+	}
+
+	entries := make([]AclEntry, len(s.Entries))
+	copy(entries, s.Entries)
+	sort.SliceStable(entries, func(i, j int) bool {
+		return !entries[i].Default && entries[j].Default
+	})
+
+	// With an extended ACL, the group bits of the permission are the mask,
+	// and the entry for the group of the file is one of the entries.
+	i := 0
+	for ; i < len(entries) && !entries[i].Default; i++ {
+		acl = append(acl, entries[i])
+	}
+
+	acl = append(acl,
+		AclEntry{Type: AclMask, Permissions: (perm >> 3) & 7},
+		AclEntry{Type: AclOther, Permissions: perm & 7},
+	)
+
+	return append(acl, entries[i:]...)
+}
+
+// SetAcl replaces the access control list of the named file or directory.
+// The entries must include the user, group, and other entries that correspond
+// to the permission bits. If they don't include any default entries, the
+// existing default ACL is left as it is.
+func (c *Client) SetAcl(name string, entries []AclEntry) error {
+	req := &hdfs.SetAclRequestProto{
+		Src:     proto.String(name),
+		AclSpec: newAclSpec(entries),
+	}
+	resp := &hdfs.SetAclResponseProto{}
+
+	err := c.namenode.Execute("setAcl", req, resp)
+	if err != nil {
+		return &os.PathError{"setacl", name, interpretException(err)}
+	}
+
+	return nil
+}
+
+// ModifyAclEntries adds entries to the access control list of the named file
+// or directory, replacing any existing entries for the same principals. Other
+// entries are left as they are.
+func (c *Client) ModifyAclEntries(name string, entries []AclEntry) error {
+	req := &hdfs.ModifyAclEntriesRequestProto{
+		Src:     proto.String(name),
+		AclSpec: newAclSpec(entries),
+	}
+	resp := &hdfs.ModifyAclEntriesResponseProto{}
+
+	err := c.namenode.Execute("modifyAclEntries", req, resp)
+	if err != nil {
+		return &os.PathError{"modifyaclentries", name, interpretException(err)}
+	}
+
+	return nil
+}
+
+// RemoveAclEntries removes entries from the access control list of the named
+// file or directory. Only the type, name, and scope of each entry are used to
+// match the entries to remove; the permissions are ignored.
+func (c *Client) RemoveAclEntries(name string, entries []AclEntry) error {
+	req := &hdfs.RemoveAclEntriesRequestProto{
+		Src:     proto.String(name),
+		AclSpec: newAclSpec(entries),
+	}
+	resp := &hdfs.RemoveAclEntriesResponseProto{}
+
+	err := c.namenode.Execute("removeAclEntries", req, resp)
+	if err != nil {
+		return &os.PathError{"removeaclentries", name, interpretException(err)}
+	}
+
+	return nil
+}
+
+// RemoveDefaultAcl removes all of the default entries from the access control
+// list of the named directory.
+func (c *Client) RemoveDefaultAcl(name string) error {
+	req := &hdfs.RemoveDefaultAclRequestProto{Src: proto.String(name)}
+	resp := &hdfs.RemoveDefaultAclResponseProto{}
+
+	err := c.namenode.Execute("removeDefaultAcl", req, resp)
+	if err != nil {
+		return &os.PathError{"removedefaultacl", name, interpretException(err)}
+	}
+
+	return nil
+}
+
+// RemoveAcl removes all of the entries from the access control list of the
+// named file or directory, other than those implied by its permission bits.
+// That includes any default entries.
+func (c *Client) RemoveAcl(name string) error {
+	req := &hdfs.RemoveAclRequestProto{Src: proto.String(name)}
+	resp := &hdfs.RemoveAclResponseProto{}
+
+	err := c.namenode.Execute("removeAcl", req, resp)
+	if err != nil {
+		return &os.PathError{"removeacl", name, interpretException(err)}
+	}
+
+	return nil
+}
+
+func newAclSpec(entries []AclEntry) []*hdfs.AclEntryProto {
+	spec := make([]*hdfs.AclEntryProto, 0, len(entries))
+	for _, entry := range entries {
+		scope := hdfs.AclEntryProto_ACCESS
+		if entry.Default {
+			scope = hdfs.AclEntryProto_DEFAULT
+		}
+
+		e := &hdfs.AclEntryProto{
+			Type:        hdfs.AclEntryProto_AclEntryTypeProto(entry.Type).Enum(),
+			Scope:       scope.Enum(),
+			Permissions: hdfs.AclEntryProto_FsActionProto(entry.Permissions & 7).Enum(),
+		}
+
+		if entry.Name != "" {
+			e.Name = proto.String(entry.Name)
+		}
+
+		spec = append(spec, e)
+	}
+
+	return spec
+}
@@ -0,0 +1,139 @@
+package hdfs
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAcl(t *testing.T) {
+	client := getClient(t)
+
+	baleet(t, "/_test/acl")
+	touchMask(t, "/_test/acl", 0)
+
+	err := client.Chmod("/_test/acl", 0640)
+	require.NoError(t, err)
+
+	status, err := client.GetAclStatus("/_test/acl")
+	require.NoError(t, err)
+	assert.Empty(t, status.Entries)
+	assert.EqualValues(t, 0640, status.Permission)
+
+	acl := status.Acl()
+	assert.Equal(t, []AclEntry{
+		{Type: AclUser, Permissions: 6},
+		{Type: AclGroup, Permissions: 4},
+		{Type: AclOther, Permissions: 0},
+	}, acl)
+
+	acl = append(acl, AclEntry{Type: AclUser, Name: "gohdfs2", Permissions: 7})
+	err = client.SetAcl("/_test/acl", acl)
+	require.NoError(t, err)
+
+	status, err = client.GetAclStatus("/_test/acl")
+	require.NoError(t, err)
+	assert.Equal(t, []AclEntry{
+		{Type: AclUser, Name: "gohdfs2", Permissions: 7},
+		{Type: AclGroup, Permissions: 4},
+	}, status.Entries)
+
+	// The mask defaults to the union of the group and named entries.
+	assert.EqualValues(t, 0670, status.Permission)
+
+	// Setting the same ACL again shouldn't change anything.
+	err = client.SetAcl("/_test/acl", status.Acl())
+	require.NoError(t, err)
+
+	again, err := client.GetAclStatus("/_test/acl")
+	require.NoError(t, err)
+	assert.Equal(t, status, again)
+}
+
+func TestAclNonexistent(t *testing.T) {
+	client := getClient(t)
+
+	baleet(t, "/_test/nonexistent")
+
+	_, err := client.GetAclStatus("/_test/nonexistent")
+	assertPathError(t, err, "getacl", "/_test/nonexistent", os.ErrNotExist)
+
+	err = client.SetAcl("/_test/nonexistent", []AclEntry{
+		{Type: AclUser, Permissions: 7},
+		{Type: AclGroup, Permissions: 5},
+		{Type: AclOther, Permissions: 5},
+	})
+	assertPathError(t, err, "setacl", "/_test/nonexistent", os.ErrNotExist)
+}
+
+func TestModifyAndRemoveAcl(t *testing.T) {
+	client := getClient(t)
+
+	baleet(t, "/_test/aclmodify")
+	mkdirp(t, "/_test/aclmodify")
+
+	err := client.ModifyAclEntries("/_test/aclmodify", []AclEntry{
+		{Type: AclUser, Name: "gohdfs2", Permissions: 5},
+		{Type: AclGroup, Name: "foo", Permissions: 4, Default: true},
+	})
+	require.NoError(t, err)
+
+	status, err := client.GetAclStatus("/_test/aclmodify")
+	require.NoError(t, err)
+	assert.Contains(t, status.Entries, AclEntry{Type: AclUser, Name: "gohdfs2", Permissions: 5})
+	assert.Contains(t, status.Entries, AclEntry{Type: AclGroup, Name: "foo", Permissions: 4, Default: true})
+
+	err = client.RemoveAclEntries("/_test/aclmodify", []AclEntry{{Type: AclUser, Name: "gohdfs2"}})
+	require.NoError(t, err)
+
+	status, err = client.GetAclStatus("/_test/aclmodify")
+	require.NoError(t, err)
+	assert.NotContains(t, status.Entries, AclEntry{Type: AclUser, Name: "gohdfs2", Permissions: 5})
+	assert.Contains(t, status.Entries, AclEntry{Type: AclGroup, Name: "foo", Permissions: 4, Default: true})
+
+	require.NoError(t, client.RemoveDefaultAcl("/_test/aclmodify"))
+	status, err = client.GetAclStatus("/_test/aclmodify")
+	require.NoError(t, err)
+	for _, entry := range status.Entries {
+		assert.False(t, entry.Default)
+	}
+
+	err = client.ModifyAclEntries("/_test/aclmodify", []AclEntry{{Type: AclUser, Name: "gohdfs2", Permissions: 7}})
+	require.NoError(t, err)
+	require.NoError(t, client.RemoveAcl("/_test/aclmodify"))
+
+	status, err = client.GetAclStatus("/_test/aclmodify")
+	require.NoError(t, err)
+	assert.Empty(t, status.Entries)
+}
+
+func TestParseAclSpec(t *testing.T) {
+	entries, err := ParseAclSpec("user::rwx,user:alice:r-x,group::r--,mask::rwx,other::---,default:group:admins:rw-", true)
+	require.NoError(t, err)
+	assert.Equal(t, []AclEntry{
+		{Type: AclUser, Permissions: 7},
+		{Type: AclUser, Name: "alice", Permissions: 5},
+		{Type: AclGroup, Permissions: 4},
+		{Type: AclMask, Permissions: 7},
+		{Type: AclOther, Permissions: 0},
+		{Type: AclGroup, Name: "admins", Permissions: 6, Default: true},
+	}, entries)
+
+	for i, s := range []string{"user::rwx", "user:alice:r-x", "group::r--", "mask::rwx", "other::---", "default:group:admins:rw-"} {
+		assert.Equal(t, s, entries[i].String())
+	}
+
+	entries, err = ParseAclSpec("user:alice,default:group:admins", false)
+	require.NoError(t, err)
+	assert.Equal(t, []AclEntry{
+		{Type: AclUser, Name: "alice"},
+		{Type: AclGroup, Name: "admins", Default: true},
+	}, entries)
+
+	for _, spec := range []string{"", "user:alice", "bogus::rwx", "user::rwz", "user::rw", "other:alice:rwx", "user:alice:rwx:foo"} {
+		_, err := ParseAclSpec(spec, true)
+		assert.Error(t, err, spec)
+	}
+}
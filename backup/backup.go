@@ -0,0 +1,558 @@
+// Package backup makes incremental backups of a directory in HDFS, using
+// snapshots. The first backup copies everything in a snapshot of the source
+// directory to the destination; after that, each backup takes a new snapshot,
+// asks the namenode for the differences from the previous one, and applies
+// only those to the destination, like distcp -diff.
+//
+// The source directory must be snapshottable (see hdfs.Client.AllowSnapshot).
+// The destination can be a directory on another cluster, or on the local
+// filesystem. A manifest recording the snapshots taken is kept at the root of
+// the destination, in a file named ManifestName.
+package backup
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/colinmarc/hdfs/v2"
+)
+
+const (
+	// ManifestName is the name of the manifest file at the root of the
+	// destination.
+	ManifestName = ".hdfs_backup_manifest.json"
+	// tmpName is the name of the directory at the root of the destination
+	// renamed files are moved through.
+	tmpName = ".hdfs_backup_tmp"
+
+	defaultSnapshotPrefix = "backup-"
+	snapshotTimeFormat    = "20060102-150405.000"
+)
+
+// Snapshot is a snapshot of the source directory taken for a backup.
+type Snapshot struct {
+	Name string    `json:"name"`
+	Time time.Time `json:"time"`
+	// Full is true if the backup copied the whole snapshot, rather than just
+	// the changes since the previous one.
+	Full bool `json:"full"`
+}
+
+// Manifest records the state of the backups at a destination.
+type Manifest struct {
+	// Source is the directory being backed up.
+	Source string `json:"source"`
+	// Snapshots are the snapshots of the source that are kept, oldest first.
+	// The destination matches the last one.
+	Snapshots []Snapshot `json:"snapshots"`
+}
+
+// Options specifies how a backup is made.
+type Options struct {
+	// SnapshotPrefix is prepended to the names of the snapshots taken. Only
+	// snapshots with the prefix are ever deleted. If empty, "backup-" is used.
+	SnapshotPrefix string
+	// Retain is the number of snapshots of the source to keep. Older ones are
+	// deleted after each successful backup. The snapshot the destination
+	// matches is always kept, so that the next backup can be incremental.
+	Retain int
+	// Full forces a full backup, even if the destination has a manifest.
+	Full bool
+	// Concurrency is the number of files copied at once. If zero, 4 is used.
+	Concurrency int
+	// Progress, if set, is called for each change applied to the destination.
+	// For a full backup, every file and directory is reported as created. It
+	// may be called from several goroutines at once.
+	Progress func(hdfs.SnapshotDiffEntry)
+}
+
+// Result describes a backup.
+type Result struct {
+	// Snapshot is the snapshot the destination now matches.
+	Snapshot Snapshot
+	// Copied and Bytes are the number of files copied, and their total size.
+	Copied int
+	Bytes  int64
+	// Deleted and Renamed are the number of files and directories deleted and
+	// renamed at the destination.
+	Deleted int
+	Renamed int
+}
+
+// ReadManifest reads the manifest from a destination. If there isn't one, it
+// returns an error satisfying os.IsNotExist.
+func ReadManifest(dst Destination) (*Manifest, error) {
+	b, err := dst.ReadFile(ManifestName)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Manifest{}
+	err = json.Unmarshal(b, m)
+	if err != nil {
+		return nil, fmt.Errorf("reading backup manifest: %s", err)
+	}
+
+	return m, nil
+}
+
+// Backup takes a snapshot of the directory src, using client, and brings dst
+// up to date with it. If dst has a manifest from a previous backup of src,
+// and that backup's snapshot still exists, only the changes since then are
+// copied. Otherwise, the whole snapshot is copied, overwriting any existing
+// files at the destination (but leaving other files that don't exist at the
+// source in place).
+//
+// If the backup fails, the new snapshot is deleted and the manifest is left as
+// it was, so the next backup starts over from the previous snapshot. The
+// destination may have been partially updated in the meantime. If the backup
+// succeeds but an old snapshot can't be deleted, the result is returned along
+// with an *ExpireError.
+func Backup(client *hdfs.Client, src string, dst Destination, options Options) (*Result, error) {
+	src = path.Clean(src)
+	if options.SnapshotPrefix == "" {
+		options.SnapshotPrefix = defaultSnapshotPrefix
+	}
+
+	manifest, err := ReadManifest(dst)
+	if os.IsNotExist(err) {
+		manifest = nil
+	} else if err != nil {
+		return nil, err
+	} else if manifest.Source != src {
+		return nil, fmt.Errorf("destination is a backup of %s, not %s", manifest.Source, src)
+	}
+
+	var prev *Snapshot
+	if manifest != nil && len(manifest.Snapshots) > 0 && !options.Full {
+		prev = &manifest.Snapshots[len(manifest.Snapshots)-1]
+		_, err := client.Stat(snapshotPath(src, prev.Name))
+		if os.IsNotExist(err) {
+			prev = nil
+		} else if err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now().UTC()
+	snap := Snapshot{
+		Name: options.SnapshotPrefix + now.Format(snapshotTimeFormat),
+		Time: now,
+		Full: prev == nil,
+	}
+
+	_, err = client.CreateSnapshot(src, snap.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &backup{
+		client:  client,
+		src:     src,
+		snap:    snap,
+		dst:     dst,
+		options: options,
+		result:  &Result{Snapshot: snap},
+	}
+
+	if prev == nil {
+		err = b.full()
+	} else {
+		err = b.incremental(prev.Name)
+	}
+
+	var expired []Snapshot
+	if err == nil {
+		expired, err = b.writeManifest(manifest)
+	}
+
+	if err != nil {
+		client.DeleteSnapshot(src, snap.Name)
+		return nil, err
+	}
+
+	// The manifest has already been written, so failing to delete an old
+	// snapshot doesn't fail the backup.
+	for _, s := range expired {
+		if !strings.HasPrefix(s.Name, options.SnapshotPrefix) {
+			continue
+		}
+
+		err := client.DeleteSnapshot(src, s.Name)
+		if err != nil && !os.IsNotExist(err) {
+			return b.result, &ExpireError{Snapshot: s.Name, Err: err}
+		}
+	}
+
+	return b.result, nil
+}
+
+func snapshotPath(dir, name string) string {
+	return path.Join(dir, ".snapshot", name)
+}
+
+type backup struct {
+	client  *hdfs.Client
+	src     string
+	snap    Snapshot
+	dst     Destination
+	options Options
+
+	resultLock sync.Mutex
+	result     *Result
+}
+
+// full copies the whole snapshot to the destination.
+func (b *backup) full() error {
+	root := snapshotPath(b.src, b.snap.Name)
+	err := b.dst.MkdirAll("", 0755)
+	if err != nil {
+		return err
+	}
+
+	var files []hdfs.SnapshotDiffEntry
+	err = b.client.Walk(root, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		name := strings.TrimPrefix(strings.TrimPrefix(p, root), "/")
+		if !info.IsDir() {
+			files = append(files, hdfs.SnapshotDiffEntry{Type: hdfs.SnapshotDiffCreate, Path: name})
+			return nil
+		} else if name == "" {
+			return nil
+		}
+
+		err = b.dst.MkdirAll(name, info.Mode().Perm())
+		if err == nil {
+			b.progress(hdfs.SnapshotDiffEntry{Type: hdfs.SnapshotDiffCreate, Path: name})
+		}
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return b.copyFiles(files)
+}
+
+// incremental applies the changes since the previous snapshot to the
+// destination. The entries in the diff report refer to paths as they were in
+// the previous snapshot, except for rename targets, so deletes are applied
+// first, then renames, and finally new and modified files are copied.
+func (b *backup) incremental(prev string) error {
+	report, err := b.client.GetSnapshotDiffReport(b.src, prev, b.snap.Name)
+	if err != nil {
+		return err
+	}
+
+	var deletes, renames, copies []hdfs.SnapshotDiffEntry
+	for _, entry := range report.Entries {
+		switch entry.Type {
+		case hdfs.SnapshotDiffDelete:
+			deletes = append(deletes, entry)
+		case hdfs.SnapshotDiffRename:
+			renames = append(renames, entry)
+		case hdfs.SnapshotDiffCreate, hdfs.SnapshotDiffModify:
+			copies = append(copies, entry)
+		}
+	}
+
+	for _, entry := range deletes {
+		err := b.dst.RemoveAll(entry.Path)
+		if err != nil {
+			return err
+		}
+
+		b.resultLock.Lock()
+		b.result.Deleted++
+		b.resultLock.Unlock()
+		b.progress(entry)
+	}
+
+	err = b.rename(renames)
+	if err != nil {
+		return err
+	}
+
+	// Created and modified files are copied from the new snapshot. Created
+	// directories are copied recursively.
+	var files []hdfs.SnapshotDiffEntry
+	for _, entry := range copies {
+		name := translateRenamed(entry.Path, renames)
+		info, err := b.client.Stat(path.Join(snapshotPath(b.src, b.snap.Name), name))
+		if os.IsNotExist(err) {
+			// It's been deleted or moved since; that's reported separately.
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		if !info.IsDir() {
+			files = append(files, hdfs.SnapshotDiffEntry{Type: entry.Type, Path: name})
+			continue
+		} else if entry.Type == hdfs.SnapshotDiffModify {
+			continue
+		}
+
+		err = b.copyDir(name)
+		if err != nil {
+			return err
+		}
+	}
+
+	return b.copyFiles(files)
+}
+
+// rename applies the renames in a diff report. Since renames can overlap (for
+// example, a file can be moved into a directory that's being moved itself),
+// each source is first moved to a temporary directory, deepest first, and
+// then from there to its target, shallowest first.
+func (b *backup) rename(renames []hdfs.SnapshotDiffEntry) error {
+	if len(renames) == 0 {
+		return nil
+	}
+
+	err := b.dst.RemoveAll(tmpName)
+	if err == nil {
+		err = b.dst.MkdirAll(tmpName, 0755)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	bySource := make([]int, len(renames))
+	for i := range bySource {
+		bySource[i] = i
+	}
+
+	byTarget := make([]int, len(renames))
+	copy(byTarget, bySource)
+
+	sort.SliceStable(bySource, func(i, j int) bool {
+		return depth(renames[bySource[i]].Path) > depth(renames[bySource[j]].Path)
+	})
+
+	sort.SliceStable(byTarget, func(i, j int) bool {
+		return depth(renames[byTarget[i]].Target) < depth(renames[byTarget[j]].Target)
+	})
+
+	for _, i := range bySource {
+		err := b.dst.Rename(renames[i].Path, path.Join(tmpName, fmt.Sprint(i)))
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, i := range byTarget {
+		err := b.dst.MkdirAll(path.Dir(renames[i].Target), 0755)
+		if err == nil {
+			err = b.dst.Rename(path.Join(tmpName, fmt.Sprint(i)), renames[i].Target)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		b.resultLock.Lock()
+		b.result.Renamed++
+		b.resultLock.Unlock()
+		b.progress(renames[i])
+	}
+
+	return b.dst.RemoveAll(tmpName)
+}
+
+// translateRenamed returns the path in the new snapshot of a path in the
+// previous one, given the renames between them.
+func translateRenamed(name string, renames []hdfs.SnapshotDiffEntry) string {
+	best := -1
+	for i, entry := range renames {
+		if (name == entry.Path || strings.HasPrefix(name, entry.Path+"/")) &&
+			(best == -1 || len(entry.Path) > len(renames[best].Path)) {
+			best = i
+		}
+	}
+
+	if best == -1 {
+		return name
+	}
+
+	return renames[best].Target + strings.TrimPrefix(name, renames[best].Path)
+}
+
+func depth(name string) int {
+	return strings.Count(name, "/")
+}
+
+// copyDir copies a directory created since the previous snapshot, along with
+// everything in it.
+func (b *backup) copyDir(name string) error {
+	root := snapshotPath(b.src, b.snap.Name)
+
+	var files []hdfs.SnapshotDiffEntry
+	err := b.client.Walk(path.Join(root, name), func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		name := strings.TrimPrefix(p, root+"/")
+		if !info.IsDir() {
+			files = append(files, hdfs.SnapshotDiffEntry{Type: hdfs.SnapshotDiffCreate, Path: name})
+			return nil
+		}
+
+		err = b.dst.RemoveAll(name)
+		if err == nil {
+			err = b.dst.MkdirAll(name, info.Mode().Perm())
+		}
+
+		if err == nil {
+			b.progress(hdfs.SnapshotDiffEntry{Type: hdfs.SnapshotDiffCreate, Path: name})
+		}
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return b.copyFiles(files)
+}
+
+// copyFiles copies the files from the new snapshot, in parallel.
+func (b *backup) copyFiles(files []hdfs.SnapshotDiffEntry) error {
+	concurrency := b.options.Concurrency
+	if concurrency <= 0 {
+		concurrency = 4
+	}
+
+	root := snapshotPath(b.src, b.snap.Name)
+	jobs := make(chan hdfs.SnapshotDiffEntry)
+	errs := make(chan error, concurrency)
+	for i := 0; i < concurrency; i++ {
+		go func() {
+			var firstErr error
+			for entry := range jobs {
+				if firstErr != nil {
+					continue
+				}
+
+				firstErr = b.copyFile(path.Join(root, entry.Path), entry)
+			}
+
+			errs <- firstErr
+		}()
+	}
+
+	for _, entry := range files {
+		jobs <- entry
+	}
+
+	close(jobs)
+
+	var err error
+	for i := 0; i < concurrency; i++ {
+		if workerErr := <-errs; workerErr != nil && err == nil {
+			err = workerErr
+		}
+	}
+
+	return err
+}
+
+func (b *backup) copyFile(src string, entry hdfs.SnapshotDiffEntry) error {
+	name := entry.Path
+	info, err := b.dst.Stat(name)
+	if err == nil && info.IsDir() {
+		err = b.dst.RemoveAll(name)
+	} else if os.IsNotExist(err) {
+		err = b.dst.MkdirAll(path.Dir(name), 0755)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	srcInfo, err := b.client.Stat(src)
+	if err != nil {
+		return err
+	}
+
+	err = b.dst.CopyFrom(b.client, src, name)
+	if err != nil {
+		return err
+	}
+
+	b.resultLock.Lock()
+	b.result.Copied++
+	b.result.Bytes += srcInfo.Size()
+	b.resultLock.Unlock()
+
+	b.progress(entry)
+	return nil
+}
+
+func (b *backup) progress(entry hdfs.SnapshotDiffEntry) {
+	if b.options.Progress != nil {
+		b.options.Progress(entry)
+	}
+}
+
+// writeManifest adds the new snapshot to the manifest and writes it to the
+// destination. It returns the snapshots that are no longer retained.
+func (b *backup) writeManifest(prev *Manifest) ([]Snapshot, error) {
+	m := &Manifest{Source: b.src}
+	if prev != nil {
+		m.Snapshots = append(m.Snapshots, prev.Snapshots...)
+	}
+
+	m.Snapshots = append(m.Snapshots, b.snap)
+
+	retain := b.options.Retain
+	if retain < 1 {
+		retain = 1
+	}
+
+	var expired []Snapshot
+	if len(m.Snapshots) > retain {
+		expired = m.Snapshots[:len(m.Snapshots)-retain]
+		m.Snapshots = m.Snapshots[len(m.Snapshots)-retain:]
+	}
+
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	err = b.dst.WriteFile(ManifestName, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return expired, nil
+}
+
+// ExpireError is returned by Backup, along with the Result, if the backup
+// succeeded but a snapshot that's no longer retained couldn't be deleted.
+type ExpireError struct {
+	Snapshot string
+	Err      error
+}
+
+func (e *ExpireError) Error() string {
+	return fmt.Sprintf("deleting expired snapshot %s: %s", e.Snapshot, e.Err)
+}
+
+func (e *ExpireError) Unwrap() error {
+	return e.Err
+}
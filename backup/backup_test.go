@@ -0,0 +1,207 @@
+package backup
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/colinmarc/hdfs/v2"
+	"github.com/colinmarc/hdfs/v2/internal/testcluster"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// getClient returns a client for the superuser, since making a directory
+// snapshottable requires it.
+func getClient(t *testing.T) *hdfs.Client {
+	u, err := user.Current()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return testcluster.Client(t, u.Username)
+}
+
+func writeFile(t *testing.T, client *hdfs.Client, name, contents string) {
+	w, err := client.CreateWithOptions(name, hdfs.CreateOptions{Overwrite: true})
+	require.NoError(t, err)
+
+	_, err = w.Write([]byte(contents))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+}
+
+// setupBackup creates a snapshottable source directory, with a few files in
+// it.
+func setupBackup(t *testing.T, name string) (*hdfs.Client, string, string) {
+	client := getClient(t)
+
+	base := "/_test/backup/" + name
+	src := base + "/src"
+	if _, err := client.Stat(src); err == nil {
+		snapshots, err := client.ReadDir(src + "/.snapshot")
+		require.NoError(t, err)
+		for _, s := range snapshots {
+			require.NoError(t, client.DeleteSnapshot(src, s.Name()))
+		}
+	}
+
+	err := client.RemoveAll(base)
+	if err != nil && !os.IsNotExist(err) {
+		t.Fatal(err)
+	}
+
+	require.NoError(t, client.MkdirAll(src+"/dir/nested", 0755))
+	writeFile(t, client, src+"/a.txt", "foo")
+	writeFile(t, client, src+"/dir/b.txt", "bar")
+	writeFile(t, client, src+"/dir/nested/c.txt", "baz")
+	require.NoError(t, client.AllowSnapshot(src))
+
+	return client, src, base + "/dst"
+}
+
+// change makes some changes to the source created by setupBackup.
+func change(t *testing.T, client *hdfs.Client, src string) {
+	writeFile(t, client, src+"/a.txt", "changed")
+	writeFile(t, client, src+"/new.txt", "new")
+	require.NoError(t, client.MkdirAll(src+"/newdir", 0755))
+	writeFile(t, client, src+"/newdir/d.txt", "qux")
+	require.NoError(t, client.Rename(src+"/dir", src+"/renamed"))
+	writeFile(t, client, src+"/renamed/b.txt", "moved")
+	require.NoError(t, client.Remove(src+"/renamed/nested/c.txt"))
+}
+
+func assertBackedUp(t *testing.T, dst Destination, expected map[string]string) {
+	for name, contents := range expected {
+		b, err := dst.ReadFile(name)
+		require.NoError(t, err, name)
+		assert.Equal(t, contents, string(b), name)
+	}
+}
+
+func TestBackup(t *testing.T) {
+	client, src, dstPath := setupBackup(t, "backup")
+	dst := NewHDFSDestination(client, dstPath, hdfs.CopyOptions{})
+
+	res, err := Backup(client, src, dst, Options{})
+	require.NoError(t, err)
+	assert.True(t, res.Snapshot.Full)
+	assert.Equal(t, 3, res.Copied)
+	assertBackedUp(t, dst, map[string]string{
+		"a.txt":            "foo",
+		"dir/b.txt":        "bar",
+		"dir/nested/c.txt": "baz",
+	})
+
+	change(t, client, src)
+	res, err = Backup(client, src, dst, Options{})
+	require.NoError(t, err)
+	assert.False(t, res.Snapshot.Full)
+	assert.Equal(t, 4, res.Copied)
+	assert.Equal(t, 1, res.Renamed)
+	assertBackedUp(t, dst, map[string]string{
+		"a.txt":         "changed",
+		"new.txt":       "new",
+		"newdir/d.txt":  "qux",
+		"renamed/b.txt": "moved",
+	})
+
+	_, err = dst.Stat("dir")
+	assert.True(t, os.IsNotExist(err))
+	_, err = dst.Stat("renamed/nested/c.txt")
+	assert.True(t, os.IsNotExist(err))
+
+	// Only the last snapshot is retained.
+	manifest, err := ReadManifest(dst)
+	require.NoError(t, err)
+	require.Len(t, manifest.Snapshots, 1)
+	assert.Equal(t, res.Snapshot.Name, manifest.Snapshots[0].Name)
+
+	snapshots, err := client.ReadDir(src + "/.snapshot")
+	require.NoError(t, err)
+	require.Len(t, snapshots, 1)
+	assert.Equal(t, res.Snapshot.Name, snapshots[0].Name())
+}
+
+func TestBackupRetain(t *testing.T) {
+	client, src, dstPath := setupBackup(t, "retain")
+	dst := NewHDFSDestination(client, dstPath, hdfs.CopyOptions{})
+
+	for i := 0; i < 3; i++ {
+		writeFile(t, client, src+"/a.txt", fmt.Sprint(i))
+		_, err := Backup(client, src, dst, Options{Retain: 2, SnapshotPrefix: "retain-"})
+		require.NoError(t, err)
+	}
+
+	manifest, err := ReadManifest(dst)
+	require.NoError(t, err)
+	require.Len(t, manifest.Snapshots, 2)
+
+	snapshots, err := client.ReadDir(src + "/.snapshot")
+	require.NoError(t, err)
+	require.Len(t, snapshots, 2)
+	for i, s := range snapshots {
+		assert.True(t, strings.HasPrefix(s.Name(), "retain-"))
+		assert.Equal(t, manifest.Snapshots[i].Name, s.Name())
+	}
+
+	assertBackedUp(t, dst, map[string]string{"a.txt": "2"})
+}
+
+func TestBackupLocal(t *testing.T) {
+	client, src, _ := setupBackup(t, "local")
+	dir, err := ioutil.TempDir("", "hdfs_backup")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	dst := NewLocalDestination(filepath.Join(dir, "dst"))
+	_, err = Backup(client, src, dst, Options{})
+	require.NoError(t, err)
+
+	change(t, client, src)
+	_, err = Backup(client, src, dst, Options{})
+	require.NoError(t, err)
+	assertBackedUp(t, dst, map[string]string{
+		"a.txt":         "changed",
+		"new.txt":       "new",
+		"newdir/d.txt":  "qux",
+		"renamed/b.txt": "moved",
+	})
+
+	_, err = os.Stat(filepath.Join(dir, "dst", "dir"))
+	assert.True(t, os.IsNotExist(err))
+
+	srcInfo, err := client.Stat(src + "/new.txt")
+	require.NoError(t, err)
+	dstInfo, err := dst.Stat("new.txt")
+	require.NoError(t, err)
+	assert.Equal(t, srcInfo.ModTime().Unix(), dstInfo.ModTime().Unix())
+}
+
+func TestBackupWrongSource(t *testing.T) {
+	client, src, dstPath := setupBackup(t, "wrongsource")
+	dst := NewHDFSDestination(client, dstPath, hdfs.CopyOptions{})
+
+	_, err := Backup(client, src, dst, Options{})
+	require.NoError(t, err)
+
+	_, err = Backup(client, "/_test/backup/other", dst, Options{})
+	assert.Error(t, err)
+}
+
+func TestTranslateRenamed(t *testing.T) {
+	renames := []hdfs.SnapshotDiffEntry{
+		{Type: hdfs.SnapshotDiffRename, Path: "a", Target: "b"},
+		{Type: hdfs.SnapshotDiffRename, Path: "a/c", Target: "b/d"},
+	}
+
+	assert.Equal(t, "b", translateRenamed("a", renames))
+	assert.Equal(t, "b/x", translateRenamed("a/x", renames))
+	assert.Equal(t, "b/d/x", translateRenamed("a/c/x", renames))
+	assert.Equal(t, "ab/x", translateRenamed("ab/x", renames))
+	assert.Equal(t, "x", translateRenamed("x", renames))
+}
@@ -0,0 +1,175 @@
+package backup
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/colinmarc/hdfs/v2"
+)
+
+// A Destination is where a backup is written to. Names are slash-separated,
+// and relative to the root of the destination; the empty name refers to the
+// root itself.
+type Destination interface {
+	// Stat returns an os.FileInfo describing the named file or directory.
+	Stat(name string) (os.FileInfo, error)
+	// MkdirAll creates a directory, along with any necessary parents.
+	MkdirAll(name string, perm os.FileMode) error
+	// Rename renames (moves) a file or directory. The new name must not exist.
+	Rename(oldname, newname string) error
+	// RemoveAll removes a file or directory and any children it contains. It
+	// returns nil if the name doesn't exist.
+	RemoveAll(name string) error
+	// CopyFrom copies the file src, using client, to name, replacing any
+	// existing file.
+	CopyFrom(client *hdfs.Client, src, name string) error
+	// ReadFile returns the contents of the named file.
+	ReadFile(name string) ([]byte, error)
+	// WriteFile replaces the contents of the named file, atomically.
+	WriteFile(name string, data []byte) error
+}
+
+type hdfsDestination struct {
+	client  *hdfs.Client
+	root    string
+	options hdfs.CopyOptions
+}
+
+// NewHDFSDestination returns a Destination that writes to the directory root
+// using client, which may be connected to a different cluster than the one
+// being backed up. Files are copied with hdfs.CopyFileBetween, using options;
+// Overwrite is always set.
+func NewHDFSDestination(client *hdfs.Client, root string, options hdfs.CopyOptions) Destination {
+	options.Overwrite = true
+	return &hdfsDestination{client: client, root: root, options: options}
+}
+
+func (d *hdfsDestination) path(name string) string {
+	return path.Join(d.root, name)
+}
+
+func (d *hdfsDestination) Stat(name string) (os.FileInfo, error) {
+	return d.client.Stat(d.path(name))
+}
+
+func (d *hdfsDestination) MkdirAll(name string, perm os.FileMode) error {
+	return d.client.MkdirAll(d.path(name), perm)
+}
+
+func (d *hdfsDestination) Rename(oldname, newname string) error {
+	return d.client.Rename(d.path(oldname), d.path(newname))
+}
+
+func (d *hdfsDestination) RemoveAll(name string) error {
+	return d.client.RemoveAll(d.path(name))
+}
+
+func (d *hdfsDestination) CopyFrom(client *hdfs.Client, src, name string) error {
+	return hdfs.CopyFileBetween(client, src, d.client, d.path(name), d.options)
+}
+
+func (d *hdfsDestination) ReadFile(name string) ([]byte, error) {
+	return d.client.ReadFile(d.path(name))
+}
+
+func (d *hdfsDestination) WriteFile(name string, data []byte) error {
+	return d.client.WriteFileAtomic(d.path(name), bytes.NewReader(data), hdfs.CreateOptions{Overwrite: true})
+}
+
+type localDestination struct {
+	root string
+}
+
+// NewLocalDestination returns a Destination that writes to the directory root
+// on the local filesystem. Copied files have the modification time of the
+// source, like with hdfs.CopyOptions.PreserveTimes.
+func NewLocalDestination(root string) Destination {
+	return &localDestination{root: root}
+}
+
+func (d *localDestination) path(name string) string {
+	return filepath.Join(d.root, filepath.FromSlash(name))
+}
+
+func (d *localDestination) Stat(name string) (os.FileInfo, error) {
+	return os.Stat(d.path(name))
+}
+
+func (d *localDestination) MkdirAll(name string, perm os.FileMode) error {
+	return os.MkdirAll(d.path(name), perm)
+}
+
+func (d *localDestination) Rename(oldname, newname string) error {
+	return os.Rename(d.path(oldname), d.path(newname))
+}
+
+func (d *localDestination) RemoveAll(name string) error {
+	return os.RemoveAll(d.path(name))
+}
+
+func (d *localDestination) CopyFrom(client *hdfs.Client, src, name string) error {
+	r, err := client.Open(src)
+	if err != nil {
+		return err
+	}
+
+	defer r.Close()
+
+	info := r.Stat()
+	err = d.writeFile(name, info.Mode().Perm(), func(w io.Writer) error {
+		_, err := io.Copy(w, r)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return os.Chtimes(d.path(name), info.ModTime(), info.ModTime())
+}
+
+func (d *localDestination) ReadFile(name string) ([]byte, error) {
+	return ioutil.ReadFile(d.path(name))
+}
+
+func (d *localDestination) WriteFile(name string, data []byte) error {
+	return d.writeFile(name, 0644, func(w io.Writer) error {
+		_, err := w.Write(data)
+		return err
+	})
+}
+
+// writeFile writes a temporary file in the same directory as name, and then
+// renames it into place.
+func (d *localDestination) writeFile(name string, perm os.FileMode, write func(io.Writer) error) error {
+	p := d.path(name)
+	f, err := ioutil.TempFile(filepath.Dir(p), "."+filepath.Base(p)+".*.tmp")
+	if err != nil {
+		return err
+	}
+
+	err = write(f)
+	if err == nil {
+		err = f.Chmod(perm)
+	}
+
+	if err == nil {
+		err = f.Close()
+	} else {
+		f.Close()
+	}
+
+	if err == nil {
+		err = os.Rename(f.Name(), p)
+	}
+
+	if err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package hdfs
+
+import "sync"
+
+// runBatch calls fn for each i in [0, n), with up to concurrency calls running
+// at once. Requests to a single namenode connection are sequential, so each
+// concurrent call gets its own worker client, with its own connection; the
+// first one is c itself, and the rest are closed once the batch is done. If an
+// extra connection can't be made, the batch runs with fewer workers. Once fn
+// returns false, no more calls are started.
+func (c *Client) runBatch(n, concurrency int, fn func(worker *Client, i int) bool) {
+	workers := []*Client{c}
+	for len(workers) < concurrency && len(workers) < n {
+		namenode, err := newNamenodeConnection(c.options, c.conns)
+		if err != nil {
+			break
+		}
+
+		defer namenode.Close()
+		workers = append(workers, &Client{namenode: namenode, options: c.options, web: c.web})
+	}
+
+	jobs := make(chan int)
+	stop := make(chan struct{})
+	var stopOnce sync.Once
+	var wg sync.WaitGroup
+	for _, worker := range workers {
+		wg.Add(1)
+		go func(worker *Client) {
+			defer wg.Done()
+			for i := range jobs {
+				if !fn(worker, i) {
+					stopOnce.Do(func() { close(stop) })
+				}
+			}
+		}(worker)
+	}
+
+dispatch:
+	for i := 0; i < n; i++ {
+		select {
+		case jobs <- i:
+		case <-stop:
+			break dispatch
+		}
+	}
+
+	close(jobs)
+	wg.Wait()
+}
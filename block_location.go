@@ -0,0 +1,99 @@
+package hdfs
+
+import (
+	"fmt"
+	"os"
+
+	hdfs "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_hdfs"
+	"github.com/golang/protobuf/proto"
+)
+
+// BlockLocation describes a block of a file, and where its replicas are
+// stored, as returned by GetBlockLocations.
+type BlockLocation struct {
+	// Offset is the offset of the block in the file, and Length is the number
+	// of bytes in it.
+	Offset int64
+	Length int64
+	// Hosts contains the hostnames of the datanodes storing a replica of the
+	// block, and Names contains the corresponding <ip>:<port> addresses. The
+	// datanodes are sorted by the namenode by distance from the client, if it
+	// knows where the client is.
+	Hosts []string
+	Names []string
+	// TopologyPaths contains the network location of each datanode, including
+	// its name, for example "/rack1/10.0.0.1:50010".
+	TopologyPaths []string
+	// StorageTypes contains the storage type of each replica, for example
+	// "DISK" or "SSD". Replicas on "PROVIDED" storage are read by the datanode
+	// from an external store mounted into HDFS.
+	StorageTypes []string
+	// CachedHosts contains the hostnames of the datanodes which have the
+	// block cached in memory.
+	CachedHosts []string
+	// Corrupt is true if every replica of the block is corrupt.
+	Corrupt bool
+	// Block identifies the block itself, so that it can be used with
+	// Client.OpenReplica.
+	Block Block
+}
+
+// GetBlockLocations returns the locations of the blocks of the named file
+// which contain data in the given byte range. This is useful for scheduling
+// work close to the data.
+func (c *Client) GetBlockLocations(name string, offset, length int64) ([]BlockLocation, error) {
+	if offset < 0 || length < 0 {
+		return nil, &os.PathError{"getblocklocations", name, os.ErrInvalid}
+	}
+
+	req := &hdfs.GetBlockLocationsRequestProto{
+		Src:    proto.String(name),
+		Offset: proto.Uint64(uint64(offset)),
+		Length: proto.Uint64(uint64(length)),
+	}
+	resp := &hdfs.GetBlockLocationsResponseProto{}
+
+	err := c.namenode.Execute("getBlockLocations", req, resp)
+	if err != nil {
+		return nil, &os.PathError{"getblocklocations", name, interpretException(err)}
+	} else if resp.GetLocations() == nil {
+		return nil, &os.PathError{"getblocklocations", name, os.ErrNotExist}
+	}
+
+	blocks := resp.GetLocations().GetBlocks()
+	locations := make([]BlockLocation, 0, len(blocks))
+	for _, block := range blocks {
+		locations = append(locations, newBlockLocation(block))
+	}
+
+	return locations, nil
+}
+
+func newBlockLocation(block *hdfs.LocatedBlockProto) BlockLocation {
+	locs := block.GetLocs()
+	b := newBlock(block)
+	bl := BlockLocation{
+		Offset:        int64(block.GetOffset()),
+		Length:        int64(block.GetB().GetNumBytes()),
+		Hosts:         make([]string, len(locs)),
+		Names:         make([]string, len(locs)),
+		TopologyPaths: make([]string, len(locs)),
+		StorageTypes:  b.StorageTypes,
+		Corrupt:       block.GetCorrupt(),
+		Block:         b,
+	}
+
+	for i, loc := range locs {
+		id := loc.GetId()
+		name := fmt.Sprintf("%s:%d", id.GetIpAddr(), id.GetXferPort())
+
+		bl.Hosts[i] = id.GetHostName()
+		bl.Names[i] = name
+		bl.TopologyPaths[i] = loc.GetLocation() + "/" + name
+		if i < len(block.GetIsCached()) && block.GetIsCached()[i] {
+			bl.CachedHosts = append(bl.CachedHosts, id.GetHostName())
+		}
+	}
+
+	return bl
+}
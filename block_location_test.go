@@ -0,0 +1,48 @@
+package hdfs
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetBlockLocations(t *testing.T) {
+	client := getClient(t)
+
+	fi, err := client.Stat("/_test/mobydick.txt")
+	require.NoError(t, err)
+
+	locations, err := client.GetBlockLocations("/_test/mobydick.txt", 0, fi.Size())
+	require.NoError(t, err)
+	require.Len(t, locations, 2)
+
+	var total int64
+	for _, bl := range locations {
+		assert.Equal(t, total, bl.Offset)
+		assert.NotEmpty(t, bl.Hosts)
+		assert.Len(t, bl.Names, len(bl.Hosts))
+		assert.Len(t, bl.TopologyPaths, len(bl.Hosts))
+		assert.False(t, bl.Corrupt)
+		assert.EqualValues(t, bl.Length, bl.Block.NumBytes)
+		total += bl.Length
+	}
+
+	assert.Equal(t, fi.Size(), total)
+
+	// Only the second block overlaps with the end of the file.
+	locations, err = client.GetBlockLocations("/_test/mobydick.txt", fi.Size()-10, 10)
+	require.NoError(t, err)
+	require.Len(t, locations, 1)
+	assert.EqualValues(t, 1048576, locations[0].Offset)
+}
+
+func TestGetBlockLocationsNonexistent(t *testing.T) {
+	client := getClient(t)
+
+	baleet(t, "/_test/nonexistent")
+
+	_, err := client.GetBlockLocations("/_test/nonexistent", 0, 1)
+	assertPathError(t, err, "getblocklocations", "/_test/nonexistent", os.ErrNotExist)
+}
@@ -0,0 +1,286 @@
+package hdfs
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"time"
+
+	hadoop "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_common"
+	hdfs "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_hdfs"
+	"github.com/colinmarc/hdfs/v2/internal/rpc"
+	"github.com/golang/protobuf/proto"
+)
+
+// namenodeProtocol is the RPC protocol the namenode serves to the balancer and
+// other HDFS services.
+const namenodeProtocol = "org.apache.hadoop.hdfs.server.protocol.NamenodeProtocol"
+
+// DatanodeInfo describes a datanode in the cluster, as reported by the
+// namenode.
+type DatanodeInfo struct {
+	UUID      string
+	IPAddr    string
+	Hostname  string
+	XferPort  int
+	InfoPort  int
+	IPCPort   int
+	Location  string
+	Capacity  uint64
+	Used      uint64
+	Remaining uint64
+	// AdminState is the administrative state of the datanode, for example
+	// "NORMAL", "DECOMMISSION_INPROGRESS" or "IN_MAINTENANCE".
+	AdminState string
+	// LastUpdate is the time of the datanode's last heartbeat to the namenode.
+	LastUpdate time.Time
+
+	info *hdfs.DatanodeInfoProto
+}
+
+// Block identifies an HDFS block, as returned by GetBlocks or
+// FileReader.Blocks.
+type Block struct {
+	BlockPoolID     string
+	BlockID         uint64
+	GenerationStamp uint64
+	NumBytes        uint64
+	// DatanodeUUIDs, StorageIDs and StorageTypes describe the locations of the
+	// replicas of the block.
+	DatanodeUUIDs []string
+	StorageIDs    []string
+	StorageTypes  []string
+
+	token *hadoop.TokenProto
+}
+
+// Datanodes returns all the datanodes known to the namenode, including dead
+// ones.
+func (c *Client) Datanodes() ([]DatanodeInfo, error) {
+	req := &hdfs.GetDatanodeReportRequestProto{
+		Type: hdfs.DatanodeReportTypeProto_ALL.Enum(),
+	}
+	resp := &hdfs.GetDatanodeReportResponseProto{}
+
+	err := c.namenode.Execute("getDatanodeReport", req, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	datanodes := make([]DatanodeInfo, 0, len(resp.GetDi()))
+	for _, info := range resp.GetDi() {
+		datanodes = append(datanodes, newDatanodeInfo(info))
+	}
+
+	return datanodes, nil
+}
+
+// GetBlocks returns a list of blocks with replicas on the given datanode,
+// adding up to at least size bytes (or all the blocks on the datanode, if
+// there aren't that many). Like the hdfs balancer, it uses the namenode's
+// NamenodeProtocol, which requires superuser privileges.
+func (c *Client) GetBlocks(datanode DatanodeInfo, size int64) ([]Block, error) {
+	namenode, err := rpc.NewNamenodeConnection(rpc.NamenodeConnectionOptions{
+		Addresses:                    c.options.Addresses,
+		User:                         c.namenode.User,
+		DialFunc:                     newNamenodeDialFunc(c.options),
+		LookupHost:                   newNamenodeLookupHost(c.options),
+		KerberosClient:               c.options.KerberosClient,
+		KerberosServicePrincipleName: c.options.KerberosServicePrincipleName,
+		Token:                        c.options.DelegationToken.proto(),
+		Protocol:                     namenodeProtocol,
+		WireLog:                      c.wireLog,
+		Strict:                       c.options.StrictProtocol,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer namenode.Close()
+
+	versionResp := &hdfs.VersionResponseProto{}
+	err = namenode.Execute("versionRequest", &hdfs.VersionRequestProto{}, versionResp)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &hdfs.GetBlocksRequestProto{
+		Datanode: datanode.info.GetId(),
+		Size:     proto.Uint64(uint64(size)),
+	}
+	resp := &hdfs.GetBlocksResponseProto{}
+
+	err = namenode.Execute("getBlocks", req, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	poolID := versionResp.GetInfo().GetBlockPoolID()
+	blocks := make([]Block, 0, len(resp.GetBlocks().GetBlocks()))
+	for _, b := range resp.GetBlocks().GetBlocks() {
+		storageTypes := make([]string, len(b.GetStorageTypes()))
+		for i, t := range b.GetStorageTypes() {
+			storageTypes[i] = t.String()
+		}
+
+		blocks = append(blocks, Block{
+			BlockPoolID:     poolID,
+			BlockID:         b.GetBlock().GetBlockId(),
+			GenerationStamp: b.GetBlock().GetGenStamp(),
+			NumBytes:        b.GetBlock().GetNumBytes(),
+			DatanodeUUIDs:   b.GetDatanodeUuids(),
+			StorageIDs:      b.GetStorageUuids(),
+			StorageTypes:    storageTypes,
+		})
+	}
+
+	return blocks, nil
+}
+
+// CopyBlock reads the entire replica of block stored on the given datanode,
+// using the same operation datanodes use to copy replicas between each other.
+// The returned ReadCloser must be closed when done.
+//
+// Block access tokens are not supported, so this will fail if
+// dfs.block.access.token.enable is set on the cluster.
+func (c *Client) CopyBlock(block Block, datanode DatanodeInfo) (io.ReadCloser, error) {
+	conn, err := c.dialDatanode(datanode)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := rpc.CopyBlock(conn, block.extendedBlock(), nil)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return &blockCopyReader{Reader: r, conn: conn}, nil
+}
+
+// OpenReplica opens the replica of block stored on the given datanode for
+// reading, bypassing the usual replica selection and failover. The data is
+// verified against the block's checksums as it is read, so a corrupt replica
+// results in a read error. This is mostly useful for tracking down corruption,
+// by comparing the replicas of a block with each other.
+//
+// If dfs.block.access.token.enable is set on the cluster, block must come from
+// FileReader.Blocks, which includes the access token for the block.
+func (c *Client) OpenReplica(block Block, datanode DatanodeInfo) (io.ReadCloser, error) {
+	br := &rpc.BlockReader{
+		ClientName: c.namenode.ClientName,
+		Block: &hdfs.LocatedBlockProto{
+			B:          block.extendedBlock(),
+			Locs:       []*hdfs.DatanodeInfoProto{datanode.info},
+			BlockToken: block.token,
+		},
+		UseDatanodeHostname: c.options.UseDatanodeHostname,
+		DialFunc:            c.dialDataTransfer,
+		ConnectTimeout:      c.options.DatanodeConnectTimeout,
+		WireLog:             c.wireLog,
+		Strict:              c.options.StrictProtocol,
+	}
+
+	return br, nil
+}
+
+// ReplaceBlock asks the target datanode to copy its own replica of block from
+// the source datanode, and then has the namenode delete the replica on the
+// datanode with the UUID delHint (usually the source). The new replica is
+// stored on the target with the given storage type, for example "DISK" or
+// "ARCHIVE". Together with GetBlocks, this is enough to implement a balancer
+// or mover.
+//
+// Block access tokens are not supported, so this will fail if
+// dfs.block.access.token.enable is set on the cluster.
+func (c *Client) ReplaceBlock(block Block, target, source DatanodeInfo, delHint, storageType string) error {
+	st, ok := hdfs.StorageTypeProto_value[storageType]
+	if !ok {
+		return fmt.Errorf("invalid storage type: %s", storageType)
+	}
+
+	conn, err := c.dialDatanode(target)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	return rpc.ReplaceBlock(conn, block.extendedBlock(), nil,
+		delHint, source.info, hdfs.StorageTypeProto(st))
+}
+
+func (c *Client) dialDatanode(datanode DatanodeInfo) (net.Conn, error) {
+	host := datanode.IPAddr
+	if c.options.UseDatanodeHostname {
+		host = datanode.Hostname
+	}
+
+	address := fmt.Sprintf("%s:%d", host, datanode.XferPort)
+	return c.dialDataTransfer(context.Background(), "tcp", address)
+}
+
+func (b Block) extendedBlock() *hdfs.ExtendedBlockProto {
+	return &hdfs.ExtendedBlockProto{
+		PoolId:          proto.String(b.BlockPoolID),
+		BlockId:         proto.Uint64(b.BlockID),
+		GenerationStamp: proto.Uint64(b.GenerationStamp),
+		NumBytes:        proto.Uint64(b.NumBytes),
+	}
+}
+
+func newBlock(block *hdfs.LocatedBlockProto) Block {
+	b := block.GetB()
+	uuids := make([]string, len(block.GetLocs()))
+	for i, loc := range block.GetLocs() {
+		uuids[i] = loc.GetId().GetDatanodeUuid()
+	}
+
+	storageTypes := make([]string, len(block.GetStorageTypes()))
+	for i, t := range block.GetStorageTypes() {
+		storageTypes[i] = t.String()
+	}
+
+	return Block{
+		BlockPoolID:     b.GetPoolId(),
+		BlockID:         b.GetBlockId(),
+		GenerationStamp: b.GetGenerationStamp(),
+		NumBytes:        b.GetNumBytes(),
+		DatanodeUUIDs:   uuids,
+		StorageIDs:      block.GetStorageIDs(),
+		StorageTypes:    storageTypes,
+		token:           block.GetBlockToken(),
+	}
+}
+
+func newDatanodeInfo(info *hdfs.DatanodeInfoProto) DatanodeInfo {
+	id := info.GetId()
+	dn := DatanodeInfo{
+		UUID:       id.GetDatanodeUuid(),
+		IPAddr:     id.GetIpAddr(),
+		Hostname:   id.GetHostName(),
+		XferPort:   int(id.GetXferPort()),
+		InfoPort:   int(id.GetInfoPort()),
+		IPCPort:    int(id.GetIpcPort()),
+		Location:   info.GetLocation(),
+		Capacity:   info.GetCapacity(),
+		Used:       info.GetDfsUsed(),
+		Remaining:  info.GetRemaining(),
+		AdminState: info.GetAdminState().String(),
+		info:       info,
+	}
+
+	if info.GetLastUpdate() > 0 {
+		dn.LastUpdate = time.Unix(0, int64(info.GetLastUpdate())*int64(time.Millisecond))
+	}
+
+	return dn
+}
+
+type blockCopyReader struct {
+	io.Reader
+	conn net.Conn
+}
+
+func (r *blockCopyReader) Close() error {
+	return r.conn.Close()
+}
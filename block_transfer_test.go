@@ -0,0 +1,83 @@
+package hdfs
+
+import (
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatanodes(t *testing.T) {
+	client := getClient(t)
+
+	datanodes, err := client.Datanodes()
+	require.NoError(t, err)
+	require.NotEmpty(t, datanodes)
+
+	for _, dn := range datanodes {
+		assert.NotEmpty(t, dn.UUID)
+		assert.NotZero(t, dn.XferPort)
+	}
+}
+
+func TestGetBlocksAndCopyBlock(t *testing.T) {
+	client := getClientForSuperUser(t)
+
+	datanodes, err := client.Datanodes()
+	require.NoError(t, err)
+	require.NotEmpty(t, datanodes)
+
+	blocks, err := client.GetBlocks(datanodes[0], 1024*1024*1024)
+	require.NoError(t, err)
+	if len(blocks) == 0 {
+		t.Skip("no blocks on datanode")
+	}
+
+	block := blocks[0]
+	assert.NotEmpty(t, block.BlockPoolID)
+	assert.Contains(t, block.DatanodeUUIDs, datanodes[0].UUID)
+
+	r, err := client.CopyBlock(block, datanodes[0])
+	require.NoError(t, err)
+	defer r.Close()
+
+	n, err := io.Copy(ioutil.Discard, r)
+	require.NoError(t, err)
+	assert.EqualValues(t, block.NumBytes, n)
+}
+
+func TestOpenReplica(t *testing.T) {
+	client := getClient(t)
+
+	file, err := client.Open("/_test/mobydick.txt")
+	require.NoError(t, err)
+
+	blocks, err := file.Blocks()
+	require.NoError(t, err)
+	require.Len(t, blocks, 2)
+
+	datanodes, err := client.Datanodes()
+	require.NoError(t, err)
+
+	byUUID := make(map[string]DatanodeInfo)
+	for _, dn := range datanodes {
+		byUUID[dn.UUID] = dn
+	}
+
+	expected := make([]byte, blocks[1].NumBytes)
+	_, err = file.ReadAt(expected, int64(blocks[0].NumBytes))
+	require.NoError(t, err)
+
+	for _, uuid := range blocks[1].DatanodeUUIDs {
+		r, err := client.OpenReplica(blocks[1], byUUID[uuid])
+		require.NoError(t, err)
+
+		replica, err := ioutil.ReadAll(r)
+		require.NoError(t, err)
+		r.Close()
+
+		assert.Equal(t, expected, replica)
+	}
+}
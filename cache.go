@@ -0,0 +1,377 @@
+package hdfs
+
+import (
+	"math"
+	"os"
+	"time"
+
+	hdfs "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_hdfs"
+	"github.com/golang/protobuf/proto"
+)
+
+// maxRelativeExpiryMillis is how the namenode represents a directive that
+// never expires, or a pool with no maximum TTL, as a relative expiry.
+const maxRelativeExpiryMillis = math.MaxInt64 / 4
+
+const (
+	// CachePoolUnlimited, as the Limit of a CachePool, means that there's no
+	// limit on how much can be cached in the pool.
+	CachePoolUnlimited int64 = math.MaxInt64
+	// CachePoolNoMaxTTL, as the MaxTTL of a CachePool, means that directives
+	// in the pool can have any TTL, including none.
+	CachePoolNoMaxTTL time.Duration = math.MaxInt64
+)
+
+// CacheNeverExpires, as the Expiration of a CacheDirective, means that it
+// never expires.
+var CacheNeverExpires = time.Unix(maxRelativeExpiryMillis/1000, 0)
+
+// CacheDirective describes a file or directory that the datanodes are asked
+// to keep cached in memory, as part of HDFS's centralized cache management.
+// For a directory, the files directly in it are cached, but not the ones in
+// its subdirectories.
+type CacheDirective struct {
+	// ID identifies the directive. It's assigned by the namenode when the
+	// directive is added.
+	ID   int64
+	Path string
+	// Pool is the cache pool the directive belongs to, which determines who
+	// can modify it, and how much can be cached.
+	Pool string
+	// Replication is the number of replicas of each block to cache. When
+	// adding a directive, zero means one.
+	Replication int
+	// Expiration is when the directive expires, after which the path is no
+	// longer cached. Directives that never expire have an Expiration of
+	// CacheNeverExpires.
+	Expiration time.Time
+	// Stats describes how much of the path is cached. It's only set by
+	// ListCacheDirectives.
+	Stats CacheDirectiveStats
+}
+
+// CacheDirectiveStats describes how much of the path of a CacheDirective is
+// currently cached.
+type CacheDirectiveStats struct {
+	BytesNeeded int64
+	BytesCached int64
+	FilesNeeded int64
+	FilesCached int64
+	HasExpired  bool
+}
+
+// CachePool is a group of cache directives, with its own permissions and
+// limits.
+type CachePool struct {
+	Name  string
+	Owner string
+	Group string
+	// Mode is the permissions of the pool. Write permission is needed to add
+	// or modify directives in the pool, and read permission to list them.
+	Mode os.FileMode
+	// Limit is the maximum number of bytes that can be cached by the
+	// directives in the pool, or CachePoolUnlimited.
+	Limit int64
+	// MaxTTL is the maximum time until expiry that directives in the pool
+	// can have, or CachePoolNoMaxTTL.
+	MaxTTL time.Duration
+	// Stats describes how much the pool's directives have cached. It's only
+	// set by ListCachePools.
+	Stats CachePoolStats
+}
+
+// CachePoolStats describes how much the directives in a CachePool have
+// cached.
+type CachePoolStats struct {
+	BytesNeeded    int64
+	BytesCached    int64
+	BytesOverlimit int64
+	FilesNeeded    int64
+	FilesCached    int64
+}
+
+// AddCacheDirective adds a directive to cache the path in the given pool,
+// and returns the ID assigned to it. Path and Pool must be set, and ID is
+// ignored. If Expiration isn't set, the directive expires after the pool's
+// MaxTTL, so it only never expires if the pool has no maximum. Unless force
+// is set, the namenode checks that the pool's limit has room for it.
+func (c *Client) AddCacheDirective(directive CacheDirective, force bool) (int64, error) {
+	directive.ID = 0
+	req := &hdfs.AddCacheDirectiveRequestProto{
+		Info:       newCacheDirectiveInfoProto(directive),
+		CacheFlags: cacheFlags(force),
+	}
+	resp := &hdfs.AddCacheDirectiveResponseProto{}
+
+	err := c.namenode.Execute("addCacheDirective", req, resp)
+	if err != nil {
+		return 0, &os.PathError{"addcachedirective", directive.Path, interpretException(err)}
+	}
+
+	return resp.GetId(), nil
+}
+
+// ModifyCacheDirective changes the directive with the given ID. Any other
+// fields that aren't set are left unchanged. Unless force is set, the
+// namenode checks that the limit of the directive's pool has room for it.
+func (c *Client) ModifyCacheDirective(directive CacheDirective, force bool) error {
+	req := &hdfs.ModifyCacheDirectiveRequestProto{
+		Info:       newCacheDirectiveInfoProto(directive),
+		CacheFlags: cacheFlags(force),
+	}
+	resp := &hdfs.ModifyCacheDirectiveResponseProto{}
+
+	err := c.namenode.Execute("modifyCacheDirective", req, resp)
+	if err != nil {
+		return interpretException(err)
+	}
+
+	return nil
+}
+
+// RemoveCacheDirective removes the directive with the given ID.
+func (c *Client) RemoveCacheDirective(id int64) error {
+	req := &hdfs.RemoveCacheDirectiveRequestProto{Id: proto.Int64(id)}
+	resp := &hdfs.RemoveCacheDirectiveResponseProto{}
+
+	err := c.namenode.Execute("removeCacheDirective", req, resp)
+	if err != nil {
+		return interpretException(err)
+	}
+
+	return nil
+}
+
+// ListCacheDirectives returns the cache directives that match filter, along
+// with their stats, in order of ID. Only the ID, Path and Pool of the filter
+// are used; any that are set must match. If the ID is set, but there's no
+// directive with it, the namenode returns an error.
+func (c *Client) ListCacheDirectives(filter CacheDirective) ([]CacheDirective, error) {
+	req := &hdfs.ListCacheDirectivesRequestProto{
+		Filter: newCacheDirectiveInfoProto(CacheDirective{ID: filter.ID, Path: filter.Path, Pool: filter.Pool}),
+	}
+
+	var directives []CacheDirective
+	var prevID int64
+	for {
+		req.PrevId = proto.Int64(prevID)
+		resp := &hdfs.ListCacheDirectivesResponseProto{}
+		err := c.namenode.Execute("listCacheDirectives", req, resp)
+		if err != nil {
+			return nil, interpretException(err)
+		}
+
+		for _, entry := range resp.GetElements() {
+			directive := newCacheDirective(entry)
+			directives = append(directives, directive)
+			prevID = directive.ID
+		}
+
+		if !resp.GetHasMore() || len(resp.GetElements()) == 0 {
+			return directives, nil
+		}
+	}
+}
+
+// AddCachePool adds a cache pool. Name must be set; for the other fields,
+// the namenode's defaults are used if they aren't. It requires superuser
+// privileges.
+func (c *Client) AddCachePool(pool CachePool) error {
+	req := &hdfs.AddCachePoolRequestProto{Info: newCachePoolInfoProto(pool)}
+	resp := &hdfs.AddCachePoolResponseProto{}
+
+	err := c.namenode.Execute("addCachePool", req, resp)
+	if err != nil {
+		return interpretException(err)
+	}
+
+	return nil
+}
+
+// ModifyCachePool changes the cache pool with the given name. Any other fields
+// that aren't set are left unchanged. It requires superuser privileges.
+func (c *Client) ModifyCachePool(pool CachePool) error {
+	req := &hdfs.ModifyCachePoolRequestProto{Info: newCachePoolInfoProto(pool)}
+	resp := &hdfs.ModifyCachePoolResponseProto{}
+
+	err := c.namenode.Execute("modifyCachePool", req, resp)
+	if err != nil {
+		return interpretException(err)
+	}
+
+	return nil
+}
+
+// RemoveCachePool removes a cache pool, along with all of its directives. It
+// requires superuser privileges.
+func (c *Client) RemoveCachePool(name string) error {
+	req := &hdfs.RemoveCachePoolRequestProto{PoolName: proto.String(name)}
+	resp := &hdfs.RemoveCachePoolResponseProto{}
+
+	err := c.namenode.Execute("removeCachePool", req, resp)
+	if err != nil {
+		return interpretException(err)
+	}
+
+	return nil
+}
+
+// ListCachePools returns all the cache pools, along with their stats, in order
+// of name. The owner, group, mode and limits are only included for pools that
+// the user has read permission for.
+func (c *Client) ListCachePools() ([]CachePool, error) {
+	var pools []CachePool
+	req := &hdfs.ListCachePoolsRequestProto{}
+	prev := ""
+	for {
+		req.PrevPoolName = proto.String(prev)
+		resp := &hdfs.ListCachePoolsResponseProto{}
+		err := c.namenode.Execute("listCachePools", req, resp)
+		if err != nil {
+			return nil, interpretException(err)
+		}
+
+		for _, entry := range resp.GetEntries() {
+			pool := newCachePool(entry)
+			pools = append(pools, pool)
+			prev = pool.Name
+		}
+
+		if !resp.GetHasMore() || len(resp.GetEntries()) == 0 {
+			return pools, nil
+		}
+	}
+}
+
+func cacheFlags(force bool) *uint32 {
+	if !force {
+		return nil
+	}
+
+	return proto.Uint32(uint32(hdfs.CacheFlagProto_FORCE))
+}
+
+func newCacheDirectiveInfoProto(directive CacheDirective) *hdfs.CacheDirectiveInfoProto {
+	info := &hdfs.CacheDirectiveInfoProto{}
+	if directive.ID != 0 {
+		info.Id = proto.Int64(directive.ID)
+	}
+
+	if directive.Path != "" {
+		info.Path = proto.String(directive.Path)
+	}
+
+	if directive.Pool != "" {
+		info.Pool = proto.String(directive.Pool)
+	}
+
+	if directive.Replication != 0 {
+		info.Replication = proto.Uint32(uint32(directive.Replication))
+	}
+
+	if directive.Expiration.Equal(CacheNeverExpires) {
+		info.Expiration = &hdfs.CacheDirectiveInfoExpirationProto{
+			Millis:     proto.Int64(maxRelativeExpiryMillis),
+			IsRelative: proto.Bool(true),
+		}
+	} else if !directive.Expiration.IsZero() {
+		info.Expiration = &hdfs.CacheDirectiveInfoExpirationProto{
+			Millis:     proto.Int64(directive.Expiration.UnixNano() / int64(time.Millisecond)),
+			IsRelative: proto.Bool(false),
+		}
+	}
+
+	return info
+}
+
+func newCacheDirective(entry *hdfs.CacheDirectiveEntryProto) CacheDirective {
+	info := entry.GetInfo()
+	stats := entry.GetStats()
+	directive := CacheDirective{
+		ID:          info.GetId(),
+		Path:        info.GetPath(),
+		Pool:        info.GetPool(),
+		Replication: int(info.GetReplication()),
+		Stats: CacheDirectiveStats{
+			BytesNeeded: stats.GetBytesNeeded(),
+			BytesCached: stats.GetBytesCached(),
+			FilesNeeded: stats.GetFilesNeeded(),
+			FilesCached: stats.GetFilesCached(),
+			HasExpired:  stats.GetHasExpired(),
+		},
+	}
+
+	// The namenode lists expirations as absolute times, so the ones that never
+	// expire end up about 73 million years in the future.
+	expiration := info.GetExpiration()
+	millis := expiration.GetMillis()
+	if expiration.GetIsRelative() {
+		if millis < maxRelativeExpiryMillis {
+			directive.Expiration = time.Now().Add(time.Duration(millis) * time.Millisecond)
+		} else {
+			directive.Expiration = CacheNeverExpires
+		}
+	} else if expiration != nil {
+		if millis < maxRelativeExpiryMillis/2 {
+			directive.Expiration = time.Unix(0, millis*int64(time.Millisecond))
+		} else {
+			directive.Expiration = CacheNeverExpires
+		}
+	}
+
+	return directive
+}
+
+func newCachePoolInfoProto(pool CachePool) *hdfs.CachePoolInfoProto {
+	info := &hdfs.CachePoolInfoProto{PoolName: proto.String(pool.Name)}
+	if pool.Owner != "" {
+		info.OwnerName = proto.String(pool.Owner)
+	}
+
+	if pool.Group != "" {
+		info.GroupName = proto.String(pool.Group)
+	}
+
+	if pool.Mode != 0 {
+		info.Mode = proto.Int32(int32(pool.Mode.Perm()))
+	}
+
+	if pool.Limit != 0 {
+		info.Limit = proto.Int64(pool.Limit)
+	}
+
+	if pool.MaxTTL == CachePoolNoMaxTTL {
+		info.MaxRelativeExpiry = proto.Int64(maxRelativeExpiryMillis)
+	} else if pool.MaxTTL != 0 {
+		info.MaxRelativeExpiry = proto.Int64(int64(pool.MaxTTL / time.Millisecond))
+	}
+
+	return info
+}
+
+func newCachePool(entry *hdfs.CachePoolEntryProto) CachePool {
+	info := entry.GetInfo()
+	stats := entry.GetStats()
+	pool := CachePool{
+		Name:  info.GetPoolName(),
+		Owner: info.GetOwnerName(),
+		Group: info.GetGroupName(),
+		Mode:  os.FileMode(info.GetMode()),
+		Limit: info.GetLimit(),
+		Stats: CachePoolStats{
+			BytesNeeded:    stats.GetBytesNeeded(),
+			BytesCached:    stats.GetBytesCached(),
+			BytesOverlimit: stats.GetBytesOverlimit(),
+			FilesNeeded:    stats.GetFilesNeeded(),
+			FilesCached:    stats.GetFilesCached(),
+		},
+	}
+
+	if maxTTL := info.GetMaxRelativeExpiry(); maxTTL >= maxRelativeExpiryMillis {
+		pool.MaxTTL = CachePoolNoMaxTTL
+	} else {
+		pool.MaxTTL = time.Duration(maxTTL) * time.Millisecond
+	}
+
+	return pool
+}
@@ -0,0 +1,133 @@
+package hdfs
+
+import (
+	"math"
+	"os"
+	"testing"
+	"time"
+
+	hdfs "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_hdfs"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCachePools(t *testing.T) {
+	client := getClientForSuperUser(t)
+
+	client.RemoveCachePool("_test_pool")
+	err := client.AddCachePool(CachePool{Name: "_test_pool", Mode: 0750, Limit: 1024 * 1024})
+	require.NoError(t, err)
+	defer client.RemoveCachePool("_test_pool")
+
+	err = client.ModifyCachePool(CachePool{Name: "_test_pool", MaxTTL: time.Hour})
+	require.NoError(t, err)
+
+	pools, err := client.ListCachePools()
+	require.NoError(t, err)
+
+	var found *CachePool
+	for i := range pools {
+		if pools[i].Name == "_test_pool" {
+			found = &pools[i]
+		}
+	}
+
+	require.NotNil(t, found)
+	assert.EqualValues(t, 0750, found.Mode)
+	assert.EqualValues(t, 1024*1024, found.Limit)
+	assert.Equal(t, time.Hour, found.MaxTTL)
+
+	require.NoError(t, client.RemoveCachePool("_test_pool"))
+	err = client.RemoveCachePool("_test_pool")
+	assert.Error(t, err)
+}
+
+func TestCacheDirectives(t *testing.T) {
+	client := getClientForSuperUser(t)
+
+	baleet(t, "/_test/cache")
+	mkdirp(t, "/_test/cache")
+	touch(t, "/_test/cache/foo")
+
+	client.RemoveCachePool("_test_directives")
+	require.NoError(t, client.AddCachePool(CachePool{Name: "_test_directives"}))
+	defer client.RemoveCachePool("_test_directives")
+
+	id, err := client.AddCacheDirective(CacheDirective{Path: "/_test/cache/foo", Pool: "_test_directives"}, true)
+	require.NoError(t, err)
+	assert.NotZero(t, id)
+
+	directives, err := client.ListCacheDirectives(CacheDirective{Pool: "_test_directives"})
+	require.NoError(t, err)
+	require.Len(t, directives, 1)
+	assert.Equal(t, id, directives[0].ID)
+	assert.Equal(t, "/_test/cache/foo", directives[0].Path)
+	assert.Equal(t, 1, directives[0].Replication)
+	assert.Equal(t, CacheNeverExpires, directives[0].Expiration)
+
+	expiration := time.Now().Add(time.Hour).Truncate(time.Millisecond)
+	err = client.ModifyCacheDirective(CacheDirective{ID: id, Replication: 2, Expiration: expiration}, true)
+	require.NoError(t, err)
+
+	directives, err = client.ListCacheDirectives(CacheDirective{ID: id})
+	require.NoError(t, err)
+	require.Len(t, directives, 1)
+	assert.Equal(t, 2, directives[0].Replication)
+	assert.True(t, expiration.Equal(directives[0].Expiration))
+
+	require.NoError(t, client.RemoveCacheDirective(id))
+	directives, err = client.ListCacheDirectives(CacheDirective{Pool: "_test_directives"})
+	require.NoError(t, err)
+	assert.Empty(t, directives)
+
+	_, err = client.ListCacheDirectives(CacheDirective{ID: id})
+	assert.Error(t, err)
+}
+
+func TestAddCacheDirectiveNonexistentPool(t *testing.T) {
+	client := getClient(t)
+
+	touch(t, "/_test/cache_nopool")
+	_, err := client.AddCacheDirective(CacheDirective{Path: "/_test/cache_nopool", Pool: "_test_nonexistent"}, false)
+	assertPathError(t, err, "addcachedirective", "/_test/cache_nopool", nil)
+}
+
+func TestCacheDirectiveExpiration(t *testing.T) {
+	info := newCacheDirectiveInfoProto(CacheDirective{Path: "/foo", Expiration: CacheNeverExpires})
+	assert.EqualValues(t, math.MaxInt64/4, info.GetExpiration().GetMillis())
+	assert.True(t, info.GetExpiration().GetIsRelative())
+	assert.Nil(t, info.Id)
+	assert.Nil(t, info.Pool)
+	assert.Nil(t, info.Replication)
+
+	info = newCacheDirectiveInfoProto(CacheDirective{ID: 3})
+	assert.Nil(t, info.Expiration)
+
+	// The namenode lists expirations as absolute times.
+	listed := newCacheDirective(&hdfs.CacheDirectiveEntryProto{
+		Info: &hdfs.CacheDirectiveInfoProto{
+			Expiration: &hdfs.CacheDirectiveInfoExpirationProto{
+				Millis:     proto.Int64(time.Now().UnixNano()/int64(time.Millisecond) + math.MaxInt64/4),
+				IsRelative: proto.Bool(false),
+			},
+		},
+	})
+	assert.Equal(t, CacheNeverExpires, listed.Expiration)
+
+	expiration := time.Unix(1700000000, 0)
+	info = newCacheDirectiveInfoProto(CacheDirective{Expiration: expiration})
+	listed = newCacheDirective(&hdfs.CacheDirectiveEntryProto{Info: info})
+	assert.True(t, expiration.Equal(listed.Expiration))
+}
+
+func TestCachePoolInfo(t *testing.T) {
+	info := newCachePoolInfoProto(CachePool{Name: "foo", Mode: os.ModeDir | 0755, MaxTTL: CachePoolNoMaxTTL})
+	assert.EqualValues(t, 0755, info.GetMode())
+	assert.EqualValues(t, math.MaxInt64/4, info.GetMaxRelativeExpiry())
+	assert.Nil(t, info.Limit)
+
+	pool := newCachePool(&hdfs.CachePoolEntryProto{Info: info})
+	assert.Equal(t, CachePoolNoMaxTTL, pool.MaxTTL)
+	assert.EqualValues(t, 0755, pool.Mode)
+}
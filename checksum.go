@@ -0,0 +1,203 @@
+package hdfs
+
+import (
+	"crypto/md5"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"hash/crc32"
+)
+
+// ErrChecksumMismatch is returned (wrapped in an os.PathError or a
+// *ChecksumMismatchError) when a file in HDFS doesn't have the checksum it
+// should.
+var ErrChecksumMismatch = errors.New("checksum doesn't match the source")
+
+// ChecksumMismatchError is returned when the checksum of a file in HDFS doesn't
+// match one computed locally from the data written to it. It matches
+// ErrChecksumMismatch with errors.Is.
+type ChecksumMismatchError struct {
+	Name string
+	// Expected is the checksum computed locally.
+	Expected []byte
+	// Actual is the checksum reported by HDFS.
+	Actual []byte
+}
+
+func (e *ChecksumMismatchError) Error() string {
+	return fmt.Sprintf("checksum mismatch for %s: expected %x, got %x", e.Name, e.Expected, e.Actual)
+}
+
+// Is implements errors.Is.
+func (e *ChecksumMismatchError) Is(target error) bool {
+	return target == ErrChecksumMismatch
+}
+
+// md5md5crc computes the "MD5MD5CRC32" checksum returned by
+// FileReader.Checksum from the contents of a file, given the chunk and block
+// size it was written with, and the polynomial of the chunk CRCs.
+type md5md5crc struct {
+	chunkSize int
+	blockSize int64
+
+	crc         hash.Hash32
+	chunkLength int
+	blockMD5    hash.Hash
+	blockLength int64
+	blockMD5s   []byte
+	crcBytes    []byte
+}
+
+func newMD5MD5CRC(chunkSize int, blockSize int64, poly uint32) *md5md5crc {
+	return &md5md5crc{
+		chunkSize: chunkSize,
+		blockSize: blockSize,
+		crc:       crc32.New(crc32.MakeTable(poly)),
+		blockMD5:  md5.New(),
+		crcBytes:  make([]byte, 4),
+	}
+}
+
+// Write implements io.Writer.
+func (c *md5md5crc) Write(b []byte) (int, error) {
+	n := len(b)
+	for len(b) > 0 {
+		size := c.chunkSize - c.chunkLength
+		if remaining := c.blockSize - c.blockLength; int64(size) > remaining {
+			size = int(remaining)
+		}
+
+		if size > len(b) {
+			size = len(b)
+		}
+
+		c.crc.Write(b[:size])
+		c.chunkLength += size
+		c.blockLength += int64(size)
+		b = b[size:]
+
+		if c.chunkLength == c.chunkSize || c.blockLength == c.blockSize {
+			c.finishChunk()
+		}
+
+		if c.blockLength == c.blockSize {
+			c.finishBlock()
+		}
+	}
+
+	return n, nil
+}
+
+func (c *md5md5crc) finishChunk() {
+	binary.BigEndian.PutUint32(c.crcBytes, c.crc.Sum32())
+	c.blockMD5.Write(c.crcBytes)
+	c.crc.Reset()
+	c.chunkLength = 0
+}
+
+func (c *md5md5crc) finishBlock() {
+	c.blockMD5s = c.blockMD5.Sum(c.blockMD5s)
+	c.blockMD5.Reset()
+	c.blockLength = 0
+}
+
+// Sum returns the checksum of everything written so far. It shouldn't be
+// written to afterwards.
+func (c *md5md5crc) Sum() []byte {
+	if c.chunkLength > 0 {
+		c.finishChunk()
+	}
+
+	if c.blockLength > 0 {
+		c.finishBlock()
+	}
+
+	return md5OfBlockMD5s(c.blockMD5s)
+}
+
+// md5OfBlockMD5s returns the MD5 of the concatenated block checksums, the
+// second MD5 in "MD5MD5CRC32".
+//
+// Hadoop calculates this by writing the checksums out to a byte array, which
+// is automatically padded with zeroes out to the next power of 2 (with a
+// minimum of 32)... and then takes the MD5 of that array, including the
+// zeroes. This is pretty shady business, but we want to track the 'hadoop fs
+// -checksum' behavior if possible.
+func md5OfBlockMD5s(blockMD5s []byte) []byte {
+	paddedLength := 32
+	for totalLength := md5.Size; totalLength <= len(blockMD5s); totalLength += md5.Size {
+		if paddedLength < totalLength {
+			paddedLength *= 2
+		}
+	}
+
+	checksum := md5.New()
+	checksum.Write(blockMD5s)
+	checksum.Write(make([]byte, paddedLength-len(blockMD5s)))
+	return checksum.Sum(nil)
+}
+
+// FileChecksum is the checksum of a whole file, in the form that the Java
+// client's FileSystem.getFileChecksum returns it.
+type FileChecksum struct {
+	// Algorithm is the name Hadoop gives the algorithm, for example
+	// "MD5-of-0MD5-of-512CRC32C".
+	Algorithm string
+	// Bytes is the checksum serialized the way Hadoop serializes it, which is
+	// what 'hadoop fs -checksum' prints in hex. For "MD5MD5CRC32", that's the
+	// bytes per CRC and the CRCs per block, followed by the MD5 returned by
+	// FileReader.Checksum.
+	Bytes []byte
+}
+
+// Checksum returns the "MD5MD5CRC32" checksum for the named file, the same
+// way as 'hadoop fs -checksum' does. Like FileReader.Checksum, it's computed
+// by the datanodes from the CRCs stored with each block, so it's much cheaper
+// than reading the file, and can be used to check that two copies of a file
+// are the same, as long as they were written with the same block size and
+// bytes per checksum.
+func (c *Client) Checksum(name string) (*FileChecksum, error) {
+	f, err := c.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	checksums, err := f.BlockChecksums()
+	if err != nil {
+		return nil, err
+	}
+
+	return newMD5MD5CRCFileChecksum(checksums), nil
+}
+
+// newMD5MD5CRCFileChecksum combines block checksums into a FileChecksum. Like
+// the Java client, it takes the bytes per CRC and CRC type from the first
+// block, and only includes the CRCs per block if there's more than one. An
+// empty file has a checksum too, with zero bytes per CRC.
+func newMD5MD5CRCFileChecksum(checksums []BlockChecksum) *FileChecksum {
+	bytesPerCRC, crcPerBlock, crcType := 0, int64(0), "CRC32"
+	if len(checksums) > 0 {
+		bytesPerCRC = checksums[0].BytesPerCRC
+		crcType = checksums[0].ChecksumType
+		if len(checksums) > 1 {
+			crcPerBlock = checksums[0].CRCPerBlock
+		}
+	}
+
+	blockMD5s := make([]byte, 0, len(checksums)*md5.Size)
+	for _, checksum := range checksums {
+		blockMD5s = append(blockMD5s, checksum.Checksum...)
+	}
+
+	b := make([]byte, 12, 12+md5.Size)
+	binary.BigEndian.PutUint32(b, uint32(bytesPerCRC))
+	binary.BigEndian.PutUint64(b[4:], uint64(crcPerBlock))
+	b = append(b, md5OfBlockMD5s(blockMD5s)...)
+
+	return &FileChecksum{
+		Algorithm: fmt.Sprintf("MD5-of-%dMD5-of-%d%s", crcPerBlock, bytesPerCRC, crcType),
+		Bytes:     b,
+	}
+}
@@ -0,0 +1,53 @@
+package hdfs
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChecksumMismatchError(t *testing.T) {
+	err := &ChecksumMismatchError{Name: "/foo", Expected: []byte{1, 2}, Actual: []byte{3, 4}}
+	assert.True(t, errors.Is(err, ErrChecksumMismatch))
+	assert.Equal(t, "checksum mismatch for /foo: expected 0102, got 0304", err.Error())
+	assert.False(t, errors.Is(err, os.ErrNotExist))
+}
+
+func TestMD5MD5CRCFileChecksumEmpty(t *testing.T) {
+	// This is what 'hadoop fs -checksum' prints for an empty file.
+	checksum := newMD5MD5CRCFileChecksum(nil)
+	assert.Equal(t, "MD5-of-0MD5-of-0CRC32", checksum.Algorithm)
+	assert.Equal(t, "000000000000000000000000"+"70bc8f4b72a86921468bf8e8441dce51", hex.EncodeToString(checksum.Bytes))
+}
+
+func TestMD5MD5CRCFileChecksum(t *testing.T) {
+	checksums := []BlockChecksum{
+		{Checksum: bytes.Repeat([]byte{1}, 16), ChecksumType: "CRC32C", BytesPerCRC: 512, CRCPerBlock: 2048},
+		{Checksum: bytes.Repeat([]byte{2}, 16), ChecksumType: "CRC32C", BytesPerCRC: 512, CRCPerBlock: 10},
+	}
+
+	checksum := newMD5MD5CRCFileChecksum(checksums)
+	assert.Equal(t, "MD5-of-2048MD5-of-512CRC32C", checksum.Algorithm)
+	assert.Equal(t, "00000200"+"0000000000000800", hex.EncodeToString(checksum.Bytes[:12]))
+	assert.Equal(t, md5OfBlockMD5s(append(checksums[0].Checksum, checksums[1].Checksum...)), checksum.Bytes[12:])
+
+	checksum = newMD5MD5CRCFileChecksum(checksums[:1])
+	assert.Equal(t, "MD5-of-0MD5-of-512CRC32C", checksum.Algorithm)
+}
+
+func TestClientChecksum(t *testing.T) {
+	client := getClient(t)
+
+	checksum, err := client.Checksum("/_test/foo.txt")
+	require.NoError(t, err)
+	assert.Contains(t, checksum.Algorithm, "MD5-of-0MD5-of-512CRC32")
+	assert.EqualValues(t, testChecksum, hex.EncodeToString(checksum.Bytes[12:]))
+
+	_, err = client.Checksum("/_test/nonexistent")
+	assertPathError(t, err, "open", "/_test/nonexistent", os.ErrNotExist)
+}
@@ -0,0 +1,67 @@
+package hdfs
+
+import (
+	"time"
+
+	"github.com/colinmarc/hdfs/v2/internal/rpc"
+)
+
+// ErrDatanodeCircuitOpen is returned (wrapped) when a block can't be read
+// because the circuit breaker is open for every datanode with a replica. See
+// ClientOptions.DatanodeCircuitBreakerThreshold.
+var ErrDatanodeCircuitOpen = rpc.ErrCircuitOpen
+
+const defaultCircuitBreakerCooldown = 30 * time.Second
+
+// DatanodeCircuitBreaker describes the state of the circuit breaker for a
+// single datanode.
+type DatanodeCircuitBreaker struct {
+	// Address is the address (<host>:<port>) of the datanode.
+	Address string
+	// State is "closed", "open" or "half-open".
+	State string
+	// ConsecutiveFailures is the number of times in a row requests to the
+	// datanode have failed.
+	ConsecutiveFailures int
+	// Trips is the number of times the breaker has opened.
+	Trips int
+	// Rejections is the number of times the datanode was skipped by a read
+	// because the breaker was open.
+	Rejections int
+}
+
+func newCircuitBreaker(options ClientOptions) *rpc.CircuitBreaker {
+	if options.DatanodeCircuitBreakerThreshold <= 0 {
+		return nil
+	}
+
+	cooldown := options.DatanodeCircuitBreakerCooldown
+	if cooldown <= 0 {
+		cooldown = defaultCircuitBreakerCooldown
+	}
+
+	return rpc.NewCircuitBreaker(options.DatanodeCircuitBreakerThreshold, cooldown)
+}
+
+// DatanodeCircuitBreakers returns the state of the circuit breaker for each
+// datanode that the client has had a failure from, sorted by address. It
+// returns nil if ClientOptions.DatanodeCircuitBreakerThreshold isn't set.
+func (c *Client) DatanodeCircuitBreakers() []DatanodeCircuitBreaker {
+	stats := c.breaker.Stats()
+	if stats == nil {
+		return nil
+	}
+
+	breakers := make([]DatanodeCircuitBreaker, len(stats))
+	for i, s := range stats {
+		breakers[i] = DatanodeCircuitBreaker{
+			Address:             s.Address,
+			State:               s.State.String(),
+			ConsecutiveFailures: s.ConsecutiveFailures,
+			Trips:               s.Trips,
+			Rejections:          s.Rejections,
+		}
+	}
+
+	return breakers
+}
@@ -0,0 +1,53 @@
+package hdfs
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/colinmarc/hdfs/v2/hadoopconf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatanodeCircuitBreaker(t *testing.T) {
+	conf, err := hadoopconf.LoadFromEnvironment()
+	if err != nil || conf == nil {
+		t.Fatal("Couldn't load ambient config", err)
+	}
+
+	options := ClientOptionsFromConf(conf)
+	options.User = "gohdfs1"
+	options.DatanodeCircuitBreakerThreshold = 1
+	options.DatanodeCircuitBreakerCooldown = time.Minute
+	options.DatanodeDialFunc = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return nil, errTestDial
+	}
+
+	client, err := NewClient(options)
+	require.NoError(t, err)
+	defer client.Close()
+
+	assert.Empty(t, client.DatanodeCircuitBreakers())
+
+	_, err = client.ReadFile("/_test/foo.txt")
+	assert.True(t, errors.Is(err, errTestDial))
+
+	breakers := client.DatanodeCircuitBreakers()
+	require.NotEmpty(t, breakers)
+	for _, b := range breakers {
+		assert.Equal(t, "open", b.State)
+		assert.Equal(t, 1, b.Trips)
+	}
+
+	// Now the datanodes aren't even tried.
+	_, err = client.ReadFile("/_test/foo.txt")
+	assert.True(t, errors.Is(err, ErrDatanodeCircuitOpen))
+	assert.Equal(t, 1, client.DatanodeCircuitBreakers()[0].Rejections)
+}
+
+func TestDatanodeCircuitBreakersDisabled(t *testing.T) {
+	assert.Nil(t, getClient(t).DatanodeCircuitBreakers())
+}
@@ -1,14 +1,18 @@
 package hdfs
 
 import (
+	"bufio"
 	"context"
 	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net"
+	"net/http"
+	"net/url"
 	"os"
 	"os/user"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -21,7 +25,10 @@ import (
 	krb "gopkg.in/jcmturner/gokrb5.v5/client"
 )
 
-const leaseRenewInterval = 30 * time.Second
+const (
+	leaseRenewInterval = 30 * time.Second
+	defaultUmask       = os.FileMode(022)
+)
 
 type leaseRenewer struct {
 	closeCh chan struct{}
@@ -31,12 +38,41 @@ type leaseRenewer struct {
 	filesWOpen uint64
 }
 
+// ErrClientClosed is returned by operations on a Client, and on its open
+// files, once the Client has been closed. It matches context.Canceled with
+// errors.Is.
+var ErrClientClosed = rpc.ErrClosed
+
 // A Client represents a connection to an HDFS cluster
 type Client struct {
 	namenode *rpc.NamenodeConnection
 	defaults atomic.Value
 	options  ClientOptions
 
+	datanodeDialFunc dialFunc
+	topology         topology
+	breaker          *rpc.CircuitBreaker
+	datanodePool     *rpc.DatanodeConnPool
+	readStats        *rpc.ReadStats
+	wireLog          *rpc.WireLogger
+	memory           *rpc.MemoryLimiter
+	shortCircuit     *rpc.ShortCircuit
+	hedged           *hedgedReads
+	kms              *kmsClient
+	web              *webhdfsClient
+
+	encryptionKeyLock   sync.Mutex
+	encryptionKey       *rpc.DataEncryptionKey
+	encryptionKeyExpiry time.Time
+
+	// conns tracks every connection the client makes, so that they can all be
+	// closed by Close. It's cancelled with ErrClientClosed.
+	conns       *transferContext
+	cancelConns context.CancelCauseFunc
+	closed      int32
+	filesROpen  int64
+	fileClosed  chan struct{}
+
 	leaseRenewer
 }
 
@@ -58,23 +94,273 @@ type Client struct {
 type ClientOptions struct {
 	// Addresses specifies the namenode(s) to connect to.
 	Addresses []string
+	// ResolveNamenodeAddresses specifies that the hostnames in Addresses should
+	// be resolved using DNS, with every IP address returned treated as a
+	// separate namenode. This is useful for namenodes behind round-robin DNS
+	// or a headless Kubernetes service. The addresses are resolved again if
+	// none of the namenodes can be reached.
+	ResolveNamenodeAddresses bool
+	// HedgeNamenodeRequests specifies that, when there are multiple namenodes
+	// and the active one isn't known yet (at startup, or after a failover),
+	// the first request should be sent to all of them at once. The first
+	// namenode to respond is then used for subsequent requests. This avoids
+	// waiting on standby or unreachable namenodes in turn, like Hadoop's
+	// RequestHedgingProxyProvider.
+	HedgeNamenodeRequests bool
+	// NamenodeLatencyProbeInterval, if positive, enables latency-aware
+	// namenode selection: every interval, a cheap read-only RPC is sent to
+	// each namenode, and if one that isn't in standby is consistently at least
+	// 20% faster than the one in use, the client switches to it. This only
+	// helps when several namenodes can serve requests, like the routers of a
+	// Router-based Federation deployment in multiple locations. The switch can
+	// happen between any two requests, even while files are open for writing,
+	// which is fine since leases belong to the client rather than to a
+	// namenode connection. Without it, the client never switches on latency.
+	// See Client.NamenodeLatencies.
+	NamenodeLatencyProbeInterval time.Duration
+	// NamenodeRequestTimeout limits how long each attempt at a namenode RPC can
+	// take. If an attempt times out, the client fails over to the next
+	// namenode. If zero, requests can block indefinitely.
+	NamenodeRequestTimeout time.Duration
+	// NamenodeRetries is the number of times the client retries a request
+	// after every namenode has failed, waiting NamenodeRetryInterval between
+	// attempts. If zero, requests fail once each namenode has been tried.
+	// Requests that aren't idempotent, like creating, renaming, or deleting a
+	// file, aren't retried if the connection is lost or times out while
+	// waiting for the response, since a repeated create or delete could fail
+	// even though the first one succeeded.
+	NamenodeRetries       int
+	NamenodeRetryInterval time.Duration
+	// NamenodeMaxRetryInterval, if larger than NamenodeRetryInterval, makes
+	// the wait between retries grow exponentially (with jitter), starting at
+	// NamenodeRetryInterval, up to this maximum.
+	NamenodeMaxRetryInterval time.Duration
+	// NamenodeRetriableExceptions lists Java exception classes, in addition to
+	// org.apache.hadoop.ipc.RetriableException, that mean the namenode should
+	// be asked again after a wait, instead of failing the request. For
+	// example, adding "org.apache.hadoop.hdfs.server.namenode.SafeModeException"
+	// makes writes wait out safe mode. Like failovers, these retries are
+	// limited by NamenodeRetries, and wait NamenodeRetryInterval in between.
+	NamenodeRetriableExceptions []string
+	// ClientNameTag is appended to the client name that the client uses when
+	// writing files, so that the namenode's audit logs (and the output of
+	// fsck -openforwrite) identify the workload doing the writing. For example,
+	// it could be set to a job ID or service name.
+	ClientNameTag string
 	// User specifies which HDFS user the client will act as. It is required
 	// unless kerberos authentication is enabled, in which case it will be
 	// determined from the provided credentials if empty.
 	User string
 	// UseDatanodeHostname specifies whether the client should connect to the
 	// datanodes via hostname (which is useful in multi-homed setups) or IP
-	// address, which may be required if DNS isn't available.
+	// address, which may be required if DNS isn't available. Either way, if a
+	// datanode advertises a hostname and IP address that differ, the other
+	// one is also tried if the first doesn't connect quickly, and whichever
+	// works is preferred for that datanode from then on.
 	UseDatanodeHostname bool
+	// DatanodeAddressFunc, if provided, is called with the address
+	// (<host>:<port>) of a datanode before each connection to it, and returns
+	// the address that should actually be dialed. This can be used for network
+	// setups where the addresses advertised by the datanodes aren't routable
+	// from the client, like NAT or overlay networks.
+	DatanodeAddressFunc func(address string) string
+	// Umask is applied to the permissions of files and directories created by
+	// the client. For example, a Umask of 022 means that directories created
+	// with 0777 end up with the permissions 0755. If zero, no bits are masked;
+	// ClientOptionsFromConf sets it to 022 by default, like the Java client.
+	Umask os.FileMode
+	// FollowSymlinks specifies that Stat and Open should follow symlinks in the
+	// path they're given, resolving them on the client side, like the Java
+	// client does. Otherwise, they return the error from the namenode. The
+	// FileReader returned by Open refers to the target of the link, under its
+	// resolved path.
+	FollowSymlinks bool
 	// NamenodeDialFunc is used to connect to the datanodes. If nil, then
 	// (&net.Dialer{}).DialContext is used.
 	NamenodeDialFunc func(ctx context.Context, network, addr string) (net.Conn, error)
 	// DatanodeDialFunc is used to connect to the datanodes. If nil, then
 	// (&net.Dialer{}).DialContext is used.
 	DatanodeDialFunc func(ctx context.Context, network, addr string) (net.Conn, error)
+	// DatanodeConnectTimeout limits how long connecting to each datanode can
+	// take when reading, including the initial handshake. If it expires, the
+	// next replica is tried immediately, so that a datanode whose host is down
+	// doesn't hold up reads for the full dial timeout. If zero, only
+	// DatanodeDialFunc and the deadline set on the FileReader limit it.
+	DatanodeConnectTimeout time.Duration
+	// DatanodeCircuitBreakerThreshold, if positive, enables a circuit breaker
+	// for each datanode: once connecting to or reading from a datanode fails
+	// that many times in a row, it's skipped by reads and excluded from new
+	// write pipelines for DatanodeCircuitBreakerCooldown (30 seconds, if
+	// zero). After that, a single request is allowed through as a probe, and
+	// the datanode is used normally again if it succeeds. See
+	// Client.DatanodeCircuitBreakers.
+	DatanodeCircuitBreakerThreshold int
+	DatanodeCircuitBreakerCooldown  time.Duration
+	// DatanodeMaxIdleConns, if positive, enables reusing connections to the
+	// datanodes for reads: once a block has been read to the end, the
+	// connection is kept open for DatanodeIdleConnTimeout (three seconds, if
+	// zero), so that the next read from the same datanode, by any FileReader,
+	// can skip dialing and the handshake. Up to DatanodeMaxIdleConns are kept
+	// for each datanode. This mostly helps with reading many small files. The
+	// timeout should be shorter than the datanodes'
+	// dfs.datanode.socket.reuse.keepalive, which is four seconds by default.
+	DatanodeMaxIdleConns    int
+	DatanodeIdleConnTimeout time.Duration
+	// DatanodeHeartbeatInterval is how often FileWriters send heartbeats to the
+	// datanodes while idle, so that the datanodes don't time out the
+	// connection. It should be less than the datanodes' dfs.client.socket-timeout.
+	// If zero, 30 seconds is used, which is half the default timeout. If
+	// negative, no heartbeats are sent, which is fine for writers that are never
+	// idle for long. It can be overridden for each file with CreateOptions.
+	DatanodeHeartbeatInterval time.Duration
+	// WritePacketSize is the size of the data packets sent to the datanodes
+	// when writing. If zero, the namenode's default is used, which is usually
+	// 64KB. Bigger packets give much better throughput over high-latency links.
+	// It's capped so that whole packets (including checksums) fit in the
+	// datanodes' limit of 16MB. Each writer can buffer several packets' worth
+	// of data while waiting for acks.
+	WritePacketSize int
+	// MaxPacketsInFlight is the most packets each writer can send to the
+	// datanodes before they're acked. If zero, it's five for the default packet
+	// size, and fewer for larger packets, so that no more than 32MB is waiting
+	// at once. Raising it can help over high-latency links, at the cost of
+	// more memory per file being written (which MemoryLimit still bounds).
+	MaxPacketsInFlight int
+	// ReadAheadSize, if positive, is how many bytes each FileReader reads
+	// ahead of the caller in the background, from the datanode it's currently
+	// reading from. Over high-latency links, or when the caller is slow to
+	// process each read, this keeps data flowing from the datanode in the
+	// meantime, at the cost of that much memory per file (which isn't counted
+	// against MemoryLimit). It can be overridden for each file with
+	// FileReader.SetReadAhead.
+	ReadAheadSize int
+	// HedgedReadThreshold, if positive, enables hedged reads for
+	// FileReader.ReadAt: if reading a range from a replica hasn't finished
+	// within the threshold, the same range is read from another replica as
+	// well, and so on every threshold until all the replicas are being tried.
+	// Whichever finishes first is used, and the others are cancelled. This cuts
+	// the tail latency caused by slow or flaky datanodes, at the cost of some
+	// extra reads. HedgedReadMaxRequests, if positive, limits how many hedged
+	// reads can be in flight at once across the client; once it's reached,
+	// reads just wait for the replicas they're already reading from. Hedging
+	// isn't used for erasure coded files, or by Read. See
+	// Client.HedgedReadStats.
+	HedgedReadThreshold   time.Duration
+	HedgedReadMaxRequests int
+	// ChecksumType is the type of checksum used for the data written, either
+	// "CRC32" or "CRC32C" (Castagnoli). If empty, the namenode's default is
+	// used, which is CRC32C on modern clusters. Reads always verify whatever
+	// type the data was written with.
+	ChecksumType string
+	// ShortCircuitReads enables reading blocks directly from the local disk,
+	// rather than over the network, when the client is running on the same
+	// host as a datanode with a replica. The datanode passes the replica's
+	// files to the client over the domain socket at DomainSocketPath, which
+	// must be set too. If a local read isn't possible, the datanodes are read
+	// from as usual. It's only supported on Unix-like platforms.
+	ShortCircuitReads bool
+	// DomainSocketPath is the path of the datanodes' domain socket, used for
+	// short-circuit reads. Any "_PORT" in it is replaced with the datanode's
+	// transfer port.
+	DomainSocketPath string
+	// MemoryLimit, if positive, caps the total size in bytes of the buffers
+	// held by all the client's open FileReaders and FileWriters at once: the
+	// packets waiting to be acked by the datanodes, the stripes of erasure
+	// coded files, and the buffers used by WriteTo and ReadFrom. Once it's
+	// reached, reads and writes block until memory is freed by others. To
+	// avoid deadlocks, each block being written can always have one packet in
+	// flight, and a read that already holds a buffer never waits for another,
+	// so the limit can be exceeded by up to WritePacketSize for each file
+	// being written, and by a stripe for each erasure coded file being read
+	// with WriteTo. See Client.MemoryStats.
+	MemoryLimit int64
+	// WriteHook, if set, is called with events from the write pipeline of
+	// every file written by the client, for instrumenting write latency. It can
+	// be overridden for each file with FileWriter.SetWriteHook.
+	WriteHook WriteHook
+	// Metrics, if set, receives measurements of the client's namenode requests,
+	// datanode reads and writes, and datanode failures, including failed
+	// heartbeats, which are otherwise printed to stderr. See ExpvarMetrics for
+	// an implementation.
+	Metrics Metrics
+	// Logger is used for debug logging, like DebugWire. If nil, the standard
+	// logger from the log package is used.
+	Logger Logger
+	// DebugWire enables logging (to Logger) every RPC sent to the namenode,
+	// with its call ID and size, the header of every data transfer operation
+	// sent to a datanode, and the responses to both. If DebugWireHexDumpBytes
+	// is positive, a hex dump of up to that many bytes of each message is
+	// included as well. This is very verbose, and is meant for debugging
+	// problems at the protocol level.
+	DebugWire             bool
+	DebugWireHexDumpBytes int
+	// StrictProtocol enables validating every RPC response, packet, and ack
+	// read from the namenode and datanodes before it's used: length prefixes
+	// are bounded, so that a corrupt one can't cause a huge allocation, and
+	// sequence numbers, offsets, and required fields are checked. Anything
+	// that doesn't make sense causes a *ProtocolError, instead of corrupt data
+	// or state. This is meant for long-running services that would rather fail
+	// a read or write than trust a malformed response.
+	StrictProtocol bool
+	// Resolver, if set, is used to resolve the hostnames of the namenodes and
+	// datanodes, instead of leaving it to the dial functions (which use the
+	// system resolver by default). Each of the addresses returned is dialed in
+	// turn until one succeeds. A *net.Resolver can be used, for example with
+	// PreferGo set and a Dial function that connects to a local DNS sidecar.
+	Resolver Resolver
+	// ResolverCacheTTL, if positive, specifies how long successful hostname
+	// lookups are cached for, and ResolverNegativeCacheTTL how long failed
+	// ones are. Setting either enables resolution through Resolver (or
+	// net.DefaultResolver, if Resolver is nil). The cache is shared by all the
+	// connections the client makes.
+	ResolverCacheTTL         time.Duration
+	ResolverNegativeCacheTTL time.Duration
+	// NamenodeSocketOptions and DatanodeSocketOptions can be used to tune the
+	// TCP connections made to the namenode(s) and datanodes, respectively.
+	// They are applied to each connection returned by the corresponding dial
+	// function.
+	NamenodeSocketOptions SocketOptions
+	DatanodeSocketOptions SocketOptions
+	// ReplicaOrderFunc, if set, decides the order in which the replicas of each
+	// block are tried when reading. See ReplicaOrderFunc for details.
+	ReplicaOrderFunc ReplicaOrderFunc
+	// TopologyScript is the path to a network topology script, like the one
+	// set by net.topology.script.file.name. If set, it is run once when the
+	// client is created, with the local hostname as an argument, to determine
+	// the rack the client is in. Reads then prefer replicas on the same host,
+	// and then the same rack, unless ReplicaOrderFunc is also set.
+	TopologyScript string
+	// ClientHostname and ClientRack, if set, specify where the client is in
+	// the cluster, as an alternative to TopologyScript. ClientHostname is used
+	// instead of the local hostname, both to find replicas on the same host and
+	// as the argument to the script, and ClientRack is used instead of running
+	// the script at all. For example, a client in a container would set them
+	// to the host and rack of the machine it runs on.
+	ClientHostname string
+	ClientRack     string
+	// StaleDatanodeInterval is how long a datanode can go without sending a
+	// heartbeat to the namenode before it is considered stale. Reads avoid
+	// replicas on stale datanodes, as well as those which are being
+	// decommissioned or are in maintenance, unless there is no other choice.
+	// The interval is measured back from the latest heartbeat of any replica
+	// of the block, so the client's clock doesn't have to agree with the
+	// namenode's. If zero, 30 seconds is used, matching the namenode's
+	// default.
+	StaleDatanodeInterval time.Duration
+	// TrashInterval is how long files are kept in the trash before being
+	// deleted, like fs.trash.interval. Like in the Java client, it's only used if
+	// the namenode doesn't have the trash enabled itself; otherwise, the
+	// namenode's setting takes precedence. See TrashPolicy for details.
+	TrashInterval time.Duration
+	// TrashCheckpointInterval is how often the trash is checkpointed, like
+	// fs.trash.checkpoint.interval. If zero, or greater than the trash
+	// interval, the trash interval is used.
+	TrashCheckpointInterval time.Duration
 	// KerberosClient is used to connect to kerberized HDFS clusters. If provided,
 	// the client will always mutually athenticate when connecting to the
-	// namenode(s).
+	// namenode(s). It can be created from a credentials cache (as written by
+	// kinit) with krb.NewClientFromCCache, or from a keytab with
+	// krb.NewClientWithKeytab, in which case Login must be called first.
 	KerberosClient *krb.Client
 	// KerberosServicePrincipleName specifies the Service Principle Name
 	// (<SERVICE>/<FQDN>) for the namenode(s). Like in the
@@ -83,6 +369,59 @@ type ClientOptions struct {
 	// multi-namenode setup (for example: 'nn/_HOST'). It is required if
 	// KerberosClient is provided.
 	KerberosServicePrincipleName string
+	// DatanodeKerberosServicePrincipleName is like KerberosServicePrincipleName,
+	// but for the datanodes, like dfs.datanode.kerberos.principal. It's only
+	// used for RPCs made directly to a datanode, like those made by
+	// DatanodeAdminClient, or to find the length of a block that's still being
+	// written.
+	DatanodeKerberosServicePrincipleName string
+	// DelegationToken is a delegation token to authenticate with the
+	// namenode(s), instead of Kerberos. If it's set, KerberosClient is ignored
+	// for the namenode connection, and the client acts as the owner of the
+	// token rather than User. See Client.GetDelegationToken and ReadTokenFile.
+	DelegationToken *Token
+	// DataTransferAES specifies that, on clusters that require connections to
+	// the datanodes to be encrypted (with dfs.encrypt.data.transfer), the
+	// AES/CTR cipher suite should be negotiated for the data, like setting
+	// dfs.encrypt.data.transfer.cipher.suites to AES/CTR/NoPadding. That is
+	// much faster than the cipher used by the SASL layer otherwise (3DES or
+	// RC4), but requires Hadoop 2.6 or later.
+	DataTransferAES bool
+	// KeyProviderURI is the URI of the Hadoop KMS used for files in encryption
+	// zones, like "kms://https@kms1;kms2:9600/kms", as in
+	// hadoop.security.key.provider.path. The KMS decrypts the key of each
+	// file, which is then used to encrypt and decrypt its contents
+	// transparently. If it's empty, reading or writing such files returns an
+	// error. The KMS is authenticated with SPNEGO if KerberosClient has
+	// credentials, or as the client's user otherwise.
+	KeyProviderURI string
+	// KMSHTTPClient is used to make requests to the KMS. If nil,
+	// http.DefaultClient is used. For HTTPS, the TLS configuration can be set
+	// on its Transport.
+	KMSHTTPClient *http.Client
+	// WebHDFSAddress, if set, is the address of a WebHDFS endpoint to use
+	// instead of connecting to the namenode and datanodes directly, for
+	// environments where only HTTP is reachable, like from outside a firewall
+	// with an HttpFS gateway. It can be a URL, like "http://httpfs:14000" or
+	// "webhdfs://namenode:9870" (swebhdfs:// for HTTPS), or just "host:port".
+	// Addresses is then ignored.
+	//
+	// Only a subset of the client's functionality is available over WebHDFS:
+	// Stat, ReadDir and the other listings, Open and reading files, Create,
+	// Append and writing files, Rename, Remove, RemoveAll, Mkdir, and
+	// MkdirAll. Everything else returns an error. Written data is streamed in
+	// a single request, so Flush and SetAutoFlush have no effect, and
+	// CreateOptions.Verify isn't supported. Requests are authenticated with
+	// SPNEGO if KerberosClient has credentials, or as User otherwise;
+	// DelegationToken can't be used.
+	WebHDFSAddress string
+	// WebHDFSHTTPClient is used to make requests to WebHDFS. If nil,
+	// http.DefaultClient is used.
+	WebHDFSHTTPClient *http.Client
+	// CloseTimeout is how long Close waits for files that are still open to be
+	// closed, and for namenode requests in progress to finish, before
+	// cancelling them. If zero, Close doesn't wait. See Shutdown for details.
+	CloseTimeout time.Duration
 }
 
 // ClientOptionsFromConf attempts to load any relevant configuration options
@@ -91,12 +430,77 @@ type ClientOptions struct {
 // on the resulting ClientOptions:
 //
 //   // Determined by fs.defaultFS (or the deprecated fs.default.name), or
-//   // fields beginning with dfs.namenode.rpc-address.
+//   // fields beginning with dfs.namenode.rpc-address. If fs.defaultFS names
+//   // a nameservice, its namenodes are used, in the order listed by
+//   // dfs.ha.namenodes.<nameservice>.
 //   Addresses []string
 //
+//   // Determined by dfs.client.failover.resolve-needed.<nameservice>.
+//   ResolveNamenodeAddresses bool
+//
+//   // Set if dfs.client.failover.proxy.provider.<nameservice> is
+//   // RequestHedgingProxyProvider.
+//   HedgeNamenodeRequests bool
+//
+//   // Determined by ipc.client.rpc-timeout.ms.
+//   NamenodeRequestTimeout time.Duration
+//
+//   // Determined by dfs.client.failover.max.attempts,
+//   // dfs.client.failover.sleep.base.millis and
+//   // dfs.client.failover.sleep.max.millis. The attempts are counted per
+//   // request, rather than per namenode.
+//   NamenodeRetries int
+//   NamenodeRetryInterval time.Duration
+//   NamenodeMaxRetryInterval time.Duration
+//
 //   // Determined by dfs.client.use.datanode.hostname.
 //   UseDatanodeHostname bool
 //
+//   // Determined by fs.permissions.umask-mode, which can be either octal
+//   // (for example, "022") or symbolic (for example, "u=rwx,g=rx,o="). If
+//   // unset, it defaults to 022.
+//   Umask os.FileMode
+//
+//   // Determined by dfs.namenode.stale.datanode.interval.
+//   StaleDatanodeInterval time.Duration
+//
+//   // Half of dfs.client.socket-timeout, like the Java client.
+//   DatanodeHeartbeatInterval time.Duration
+//
+//   // Determined by dfs.client-write-packet-size.
+//   WritePacketSize int
+//
+//   // Determined by dfs.client.write.max-packets-in-flight.
+//   MaxPacketsInFlight int
+//
+//   // Determined by dfs.client.socketcache.capacity, which is used as the
+//   // limit for each datanode, and dfs.client.socketcache.expiryMsec.
+//   DatanodeMaxIdleConns int
+//   DatanodeIdleConnTimeout time.Duration
+//
+//   // Determined by dfs.client.hedged.read.threadpool.size and
+//   // dfs.client.hedged.read.threshold.millis (500 by default). Like in the
+//   // Java client, hedged reads are only enabled if the former is positive.
+//   HedgedReadThreshold time.Duration
+//   HedgedReadMaxRequests int
+//
+//   // Determined by dfs.checksum.type.
+//   ChecksumType string
+//
+//   // Determined by dfs.client.read.shortcircuit and dfs.domain.socket.path.
+//   ShortCircuitReads bool
+//   DomainSocketPath string
+//
+//   // Determined by fs.trash.interval and fs.trash.checkpoint.interval, which
+//   // are in minutes.
+//   TrashInterval time.Duration
+//   TrashCheckpointInterval time.Duration
+//
+//   // DisableNoDelay is determined by ipc.client.tcpnodelay, and
+//   // WriteBufferSize by dfs.client.socket.send.buffer.size.
+//   NamenodeSocketOptions SocketOptions
+//   DatanodeSocketOptions SocketOptions
+//
 //   // Set to a non-nil but empty client (without credentials) if the value of
 //   // hadoop.security.authentication is 'kerberos'. It must then be replaced
 //   // with a credentialed Kerberos client.
@@ -106,6 +510,21 @@ type ClientOptions struct {
 //   // (everything after the first '@') chopped off.
 //   KerberosServicePrincipleName string
 //
+//   // Determined by dfs.datanode.kerberos.principal, in the same way.
+//   DatanodeKerberosServicePrincipleName string
+//
+//   // Read from the file named by the HADOOP_TOKEN_FILE_LOCATION environment
+//   // variable, if it's set and has an HDFS delegation token for the
+//   // namenode(s).
+//   DelegationToken *Token
+//
+//   // Set if dfs.encrypt.data.transfer.cipher.suites is AES/CTR/NoPadding.
+//   DataTransferAES bool
+//
+//   // Determined by hadoop.security.key.provider.path, or the deprecated
+//   // dfs.encryption.key.provider.uri.
+//   KeyProviderURI string
+//
 // Because of the way Kerberos can be forced by the Hadoop configuration but not
 // actually configured, you should check for whether KerberosClient is set in
 // the resulting ClientOptions before proceeding:
@@ -117,9 +536,101 @@ type ClientOptions struct {
 //   }
 func ClientOptionsFromConf(conf hadoopconf.HadoopConf) ClientOptions {
 	options := ClientOptions{Addresses: conf.Namenodes()}
+	if defaultFS, err := url.Parse(conf.DefaultFS()); err == nil && defaultFS.Host != "" {
+		for _, ns := range conf.Nameservices() {
+			if ns == defaultFS.Host {
+				options.Addresses = conf.AddressesFor(ns)
+			}
+		}
+	}
+
+	for key, value := range conf {
+		if strings.HasPrefix(key, "dfs.client.failover.resolve-needed.") && value == "true" {
+			options.ResolveNamenodeAddresses = true
+		} else if strings.HasPrefix(key, "dfs.client.failover.proxy.provider.") &&
+			strings.HasSuffix(value, ".RequestHedgingProxyProvider") {
+			options.HedgeNamenodeRequests = true
+		}
+	}
 
 	options.UseDatanodeHostname = (conf["dfs.client.use.datanode.hostname"] == "true")
 
+	if ms, err := strconv.Atoi(conf["ipc.client.rpc-timeout.ms"]); err == nil && ms > 0 {
+		options.NamenodeRequestTimeout = time.Duration(ms) * time.Millisecond
+	}
+
+	if attempts, err := strconv.Atoi(conf["dfs.client.failover.max.attempts"]); err == nil && attempts > 0 {
+		options.NamenodeRetries = attempts
+	}
+
+	if ms, err := strconv.Atoi(conf["dfs.client.failover.sleep.base.millis"]); err == nil && ms > 0 {
+		options.NamenodeRetryInterval = time.Duration(ms) * time.Millisecond
+	}
+
+	if ms, err := strconv.Atoi(conf["dfs.client.failover.sleep.max.millis"]); err == nil && ms > 0 {
+		options.NamenodeMaxRetryInterval = time.Duration(ms) * time.Millisecond
+	}
+
+	options.Umask = defaultUmask
+	if umask, err := parseUmask(conf["fs.permissions.umask-mode"]); err == nil {
+		options.Umask = umask
+	}
+
+	if ms, err := strconv.Atoi(conf["dfs.namenode.stale.datanode.interval"]); err == nil && ms > 0 {
+		options.StaleDatanodeInterval = time.Duration(ms) * time.Millisecond
+	}
+
+	if ms, err := strconv.Atoi(conf["dfs.client.socket-timeout"]); err == nil && ms > 0 {
+		options.DatanodeHeartbeatInterval = time.Duration(ms) * time.Millisecond / 2
+	}
+
+	if size, err := strconv.Atoi(conf["dfs.client-write-packet-size"]); err == nil && size > 0 {
+		options.WritePacketSize = size
+	}
+
+	if n, err := strconv.Atoi(conf["dfs.client.write.max-packets-in-flight"]); err == nil && n > 0 {
+		options.MaxPacketsInFlight = n
+	}
+
+	if n, err := strconv.Atoi(conf["dfs.client.socketcache.capacity"]); err == nil && n > 0 {
+		options.DatanodeMaxIdleConns = n
+	}
+
+	if ms, err := strconv.Atoi(conf["dfs.client.socketcache.expiryMsec"]); err == nil && ms > 0 {
+		options.DatanodeIdleConnTimeout = time.Duration(ms) * time.Millisecond
+	}
+
+	if n, err := strconv.Atoi(conf["dfs.client.hedged.read.threadpool.size"]); err == nil && n > 0 {
+		options.HedgedReadMaxRequests = n
+		options.HedgedReadThreshold = 500 * time.Millisecond
+		if ms, err := strconv.Atoi(conf["dfs.client.hedged.read.threshold.millis"]); err == nil && ms > 0 {
+			options.HedgedReadThreshold = time.Duration(ms) * time.Millisecond
+		}
+	}
+
+	if checksumType := conf["dfs.checksum.type"]; checksumType != "" {
+		options.ChecksumType = checksumType
+	}
+
+	options.ShortCircuitReads = (conf["dfs.client.read.shortcircuit"] == "true")
+	options.DomainSocketPath = conf["dfs.domain.socket.path"]
+
+	if minutes, err := strconv.ParseFloat(conf["fs.trash.interval"], 64); err == nil && minutes > 0 {
+		options.TrashInterval = time.Duration(minutes * float64(time.Minute))
+	}
+
+	if minutes, err := strconv.ParseFloat(conf["fs.trash.checkpoint.interval"], 64); err == nil && minutes > 0 {
+		options.TrashCheckpointInterval = time.Duration(minutes * float64(time.Minute))
+	}
+
+	if conf["ipc.client.tcpnodelay"] == "false" {
+		options.NamenodeSocketOptions.DisableNoDelay = true
+	}
+
+	if size, err := strconv.Atoi(conf["dfs.client.socket.send.buffer.size"]); err == nil && size > 0 {
+		options.DatanodeSocketOptions.WriteBufferSize = size
+	}
+
 	if strings.ToLower(conf["hadoop.security.authentication"]) == "kerberos" {
 		// Set an empty KerberosClient here so that the user is forced to either
 		// unset it (disabling kerberos altogether) or replace it with a valid
@@ -131,11 +642,86 @@ func ClientOptionsFromConf(conf hadoopconf.HadoopConf) ClientOptions {
 		options.KerberosServicePrincipleName = strings.Split(conf["dfs.namenode.kerberos.principal"], "@")[0]
 	}
 
+	if conf["dfs.datanode.kerberos.principal"] != "" {
+		options.DatanodeKerberosServicePrincipleName = strings.Split(conf["dfs.datanode.kerberos.principal"], "@")[0]
+	}
+
+	options.DelegationToken = tokenFromEnvironment(conf, options.Addresses)
+
+	if strings.TrimSpace(conf["dfs.encrypt.data.transfer.cipher.suites"]) == "AES/CTR/NoPadding" {
+		options.DataTransferAES = true
+	}
+
+	options.KeyProviderURI = conf["hadoop.security.key.provider.path"]
+	if options.KeyProviderURI == "" {
+		options.KeyProviderURI = conf["dfs.encryption.key.provider.uri"]
+	}
+
 	return options
 }
 
+// parseUmask parses a umask in the format used by fs.permissions.umask-mode:
+// either an octal number, or a comma-separated list of symbolic permissions
+// to leave unmasked, like "u=rwx,g=rx,o=".
+func parseUmask(s string) (os.FileMode, error) {
+	if s == "" {
+		return 0, errors.New("empty umask")
+	}
+
+	if n, err := strconv.ParseUint(s, 8, 32); err == nil {
+		if n > 0777 {
+			return 0, fmt.Errorf("invalid umask: %s", s)
+		}
+
+		return os.FileMode(n), nil
+	}
+
+	umask := os.FileMode(0777)
+	for _, clause := range strings.Split(s, ",") {
+		parts := strings.SplitN(clause, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return 0, fmt.Errorf("invalid umask: %s", s)
+		}
+
+		var perm os.FileMode
+		for _, r := range parts[1] {
+			switch r {
+			case 'r':
+				perm |= 04
+			case 'w':
+				perm |= 02
+			case 'x':
+				perm |= 01
+			default:
+				return 0, fmt.Errorf("invalid umask: %s", s)
+			}
+		}
+
+		for _, who := range parts[0] {
+			var shift uint
+			switch who {
+			case 'u':
+				shift = 6
+			case 'g':
+				shift = 3
+			case 'o':
+				shift = 0
+			case 'a':
+				umask &^= (perm << 6) | (perm << 3) | perm
+				continue
+			default:
+				return 0, fmt.Errorf("invalid umask: %s", s)
+			}
+
+			umask &^= perm << shift
+		}
+	}
+
+	return umask, nil
+}
+
 func (c *Client) leaseRenew() error {
-	if atomic.LoadUint64(&c.filesWOpen) == 0 {
+	if c.web != nil || atomic.LoadUint64(&c.filesWOpen) == 0 {
 		return nil
 	}
 	req := &hdfs.RenewLeaseRequestProto{
@@ -170,29 +756,74 @@ func (c *Client) leaseRenewerRun() {
 // the client could not be created.
 func NewClient(options ClientOptions) (*Client, error) {
 	var err error
-	if options.KerberosClient != nil && options.KerberosClient.Credentials == nil {
+	kerberos := options.KerberosClient != nil && options.DelegationToken == nil
+	if kerberos && options.KerberosClient.Credentials == nil {
 		return nil, errors.New("kerberos enabled, but kerberos client is missing credentials")
 	}
 
-	if options.KerberosClient != nil && options.KerberosServicePrincipleName == "" {
+	if kerberos && options.KerberosServicePrincipleName == "" {
 		return nil, errors.New("kerberos enabled, but kerberos namenode SPN is not provided")
 	}
 
-	namenode, err := rpc.NewNamenodeConnection(
-		rpc.NamenodeConnectionOptions{
-			Addresses:                    options.Addresses,
-			User:                         options.User,
-			DialFunc:                     options.NamenodeDialFunc,
-			KerberosClient:               options.KerberosClient,
-			KerberosServicePrincipleName: options.KerberosServicePrincipleName,
-		},
-	)
+	if _, err := parseChecksumType(options.ChecksumType); err != nil {
+		return nil, err
+	}
+
+	if options.WebHDFSAddress != "" && options.DelegationToken != nil {
+		return nil, errors.New("delegation tokens aren't supported over WebHDFS")
+	}
+
+	// The resolver (and its cache) is shared by everything derived from the
+	// options.
+	options.Resolver = newResolver(options)
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	conns := newTransferContext(ctx)
+	namenode, err := newNamenodeConnection(options, conns)
+	if err != nil {
+		cancel(ErrClientClosed)
+		return nil, err
+	}
+
+	topology, err := newTopology(options)
+	if err != nil {
+		namenode.Close()
+		cancel(ErrClientClosed)
+		return nil, err
+	}
 
+	kms, err := newKMSClient(options, namenode.User)
 	if err != nil {
+		namenode.Close()
+		cancel(ErrClientClosed)
 		return nil, err
 	}
 
+	var web *webhdfsClient
+	if options.WebHDFSAddress != "" {
+		web, err = newWebHDFSClient(options, namenode.User)
+		if err != nil {
+			namenode.Close()
+			cancel(ErrClientClosed)
+			return nil, err
+		}
+	}
+
 	c := &Client{namenode: namenode, options: options, leaseRenewer: leaseRenewer{closeCh: make(chan struct{}), errCh: make(chan error)}}
+	c.datanodeDialFunc = conns.wrap(newDatanodeDialFunc(options))
+	c.topology = topology
+	c.breaker = newCircuitBreaker(options)
+	c.datanodePool = newDatanodeConnPool(options)
+	c.readStats = newMetricsReadStats(options.Metrics)
+	c.wireLog = newWireLogger(options)
+	c.memory = newMemoryLimiter(options)
+	c.shortCircuit = newShortCircuit(options, namenode.ClientName)
+	c.hedged = newHedgedReads(options)
+	c.kms = kms
+	c.web = web
+	c.conns = conns
+	c.cancelConns = cancel
+	c.fileClosed = make(chan struct{}, 1)
 
 	c.wg.Add(1)
 	go c.leaseRenewerRun()
@@ -200,6 +831,48 @@ func NewClient(options ClientOptions) (*Client, error) {
 	return c, nil
 }
 
+// newNamenodeConnection connects to the namenode(s) specified by options. The
+// connections it makes are tracked by conns, which may be nil.
+func newNamenodeConnection(options ClientOptions, conns *transferContext) (*rpc.NamenodeConnection, error) {
+	// Over WebHDFS, the connection is only used for the user and client name.
+	// Anything that would actually send an RPC fails instead.
+	if options.WebHDFSAddress != "" {
+		return rpc.NewNamenodeConnection(rpc.NamenodeConnectionOptions{
+			Addresses: []string{options.WebHDFSAddress},
+			User:      options.User,
+			DialFunc: func(ctx context.Context, network, addr string) (net.Conn, error) {
+				return nil, errWebHDFSUnsupported
+			},
+			Lazy:           true,
+			ClientNameTag:  options.ClientNameTag,
+			KerberosClient: options.KerberosClient,
+		})
+	}
+
+	return rpc.NewNamenodeConnection(
+		rpc.NamenodeConnectionOptions{
+			Addresses:                    options.Addresses,
+			User:                         options.User,
+			DialFunc:                     conns.wrap(newNamenodeDialFunc(options)),
+			LookupHost:                   newNamenodeLookupHost(options),
+			HedgeRequests:                options.HedgeNamenodeRequests,
+			ClientNameTag:                options.ClientNameTag,
+			RequestTimeout:               options.NamenodeRequestTimeout,
+			Retries:                      options.NamenodeRetries,
+			RetryInterval:                options.NamenodeRetryInterval,
+			MaxRetryInterval:             options.NamenodeMaxRetryInterval,
+			RetriableExceptions:          options.NamenodeRetriableExceptions,
+			RequestHook:                  newNamenodeRequestHook(options),
+			WireLog:                      newWireLogger(options),
+			Strict:                       options.StrictProtocol,
+			LatencyProbeInterval:         options.NamenodeLatencyProbeInterval,
+			KerberosClient:               options.KerberosClient,
+			KerberosServicePrincipleName: options.KerberosServicePrincipleName,
+			Token:                        options.DelegationToken.proto(),
+		},
+	)
+}
+
 // New returns Client connected to the namenode(s) specified by address, or an
 // error if it can't connect. Multiple namenodes can be specified by separating
 // them with commas, for example "nn1:9000,nn2:9000".
@@ -232,8 +905,51 @@ func New(address string) (*Client, error) {
 	return NewClient(options)
 }
 
+// NewForConfiguration returns a Client connected to the cluster named by uri,
+// resolved with the Hadoop configuration present at HADOOP_CONF_DIR or
+// HADOOP_HOME, the way the Java client resolves the URI passed to
+// FileSystem.get. For example, "hdfs://nameservice1" connects to the
+// namenodes configured for that nameservice, and "hdfs://nn1:8020" to a
+// single namenode; the path, if there is one, is ignored. A webhdfs:// or
+// swebhdfs:// URI connects over WebHDFS instead. If uri is empty, fs.defaultFS
+// is used.
+//
+// The user is HADOOP_USER_NAME, if it's set, or the current system user. The
+// other options are loaded as specified by ClientOptionsFromConf. Like New,
+// NewForConfiguration will not attempt any Kerberos authentication; use
+// NewClient if you need that.
+func NewForConfiguration(uri string) (*Client, error) {
+	conf, err := hadoopconf.LoadFromEnvironment()
+	if err != nil {
+		return nil, err
+	}
+
+	options := ClientOptionsFromConf(conf)
+	if u, err := url.Parse(uri); err == nil && (u.Scheme == "webhdfs" || u.Scheme == "swebhdfs") {
+		options.Addresses = nil
+		options.DelegationToken = nil
+		options.WebHDFSAddress = u.Scheme + "://" + u.Host
+	} else if uri != "" {
+		options.Addresses = conf.AddressesFor(uri)
+		options.DelegationToken = tokenFromEnvironment(conf, options.Addresses)
+	}
+
+	options.User = os.Getenv("HADOOP_USER_NAME")
+	if options.User == "" {
+		u, err := user.Current()
+		if err != nil {
+			return nil, err
+		}
+
+		options.User = u.Username
+	}
+
+	return NewClient(options)
+}
+
 // User returns the user that the Client is acting under. This is either the
-// current system user or the kerberos principal.
+// current system user, the kerberos principal, or the owner of the delegation
+// token.
 func (c *Client) User() string {
 	return c.namenode.User
 }
@@ -268,7 +984,90 @@ func (c *Client) CopyToLocal(src string, dst string) error {
 	return err
 }
 
+// CopyToLocalContext is like CopyToLocal, but gives up once ctx is done, in
+// which case dst may have been partially written.
+func (c *Client) CopyToLocalContext(ctx context.Context, src string, dst string) error {
+	remote, err := c.OpenContext(ctx, src)
+	if err != nil {
+		return err
+	}
+	defer remote.Close()
+
+	local, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer local.Close()
+
+	_, err = io.Copy(local, remote)
+	if err != nil {
+		return err
+	}
+
+	return local.Close()
+}
+
+// CopyToLocalOptions represents the options for CopyToLocalWithOptions.
+type CopyToLocalOptions struct {
+	// CRCFile specifies that a Hadoop-compatible .crc sidecar file should be
+	// written next to dst (see CRCFileName), as it's downloaded, so that the
+	// local copy can be verified later by tools that understand Hadoop's
+	// ChecksumFileSystem.
+	CRCFile bool
+	// BytesPerChecksum is the chunk size for the .crc file. If zero,
+	// DefaultCRCFileBytesPerChecksum is used.
+	BytesPerChecksum int
+}
+
+// CopyToLocalWithOptions is like CopyToLocal, but with the given options.
+func (c *Client) CopyToLocalWithOptions(src string, dst string, options CopyToLocalOptions) error {
+	if !options.CRCFile {
+		return c.CopyToLocal(src, dst)
+	}
+
+	remote, err := c.Open(src)
+	if err != nil {
+		return err
+	}
+	defer remote.Close()
+
+	local, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer local.Close()
+
+	crcFile, err := os.Create(CRCFileName(dst))
+	if err != nil {
+		return err
+	}
+	defer crcFile.Close()
+
+	buf := bufio.NewWriter(crcFile)
+	crc := NewCRCFileWriter(buf, options.BytesPerChecksum)
+	_, err = io.Copy(io.MultiWriter(local, crc), remote)
+	if err != nil {
+		return err
+	}
+
+	err = crc.Close()
+	if err == nil {
+		err = buf.Flush()
+	}
+
+	if err == nil {
+		err = crcFile.Close()
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return local.Close()
+}
+
 // CopyToRemote copies the local file specified by src to the HDFS file at dst.
+// If dst already exists, an error wrapping os.ErrExist is returned.
 func (c *Client) CopyToRemote(src string, dst string) error {
 	local, err := os.Open(src)
 	if err != nil {
@@ -282,32 +1081,145 @@ func (c *Client) CopyToRemote(src string, dst string) error {
 	}
 	defer remote.Close()
 
-	_, err = io.Copy(remote, local)
+	// This calls ReadFrom directly, since io.Copy would try os.File's WriteTo
+	// first, and only get to ReadFrom by way of its fallback.
+	_, err = remote.ReadFrom(local)
 	return err
 }
 
-func (c *Client) fetchDefaults() (*hdfs.FsServerDefaultsProto, error) {
-	d := c.defaults.Load()
-	if d != nil {
-		return d.(*hdfs.FsServerDefaultsProto), nil
+// CopyToRemoteWithOptions is like CopyToRemote, but creates dst with the given
+// options. As with CreateWithOptions, an existing dst is only replaced if
+// options.Overwrite is set. With options.Verify, the checksum of the file is
+// checked once it's written; see CreateOptions.
+func (c *Client) CopyToRemoteWithOptions(src string, dst string, options CreateOptions) error {
+	local, err := os.Open(src)
+	if err != nil {
+		return err
 	}
+	defer local.Close()
 
-	req := &hdfs.GetServerDefaultsRequestProto{}
-	resp := &hdfs.GetServerDefaultsResponseProto{}
+	remote, err := c.CreateWithOptions(dst, options)
+	if err != nil {
+		return err
+	}
 
-	err := c.namenode.Execute("getServerDefaults", req, resp)
+	_, err = remote.ReadFrom(local)
 	if err != nil {
-		return nil, err
+		remote.Close()
+		return err
 	}
 
-	r := resp.GetServerDefaults()
-	c.defaults.Store(r)
-	return r, nil
+	return remote.Close()
 }
 
-// Close terminates all underlying socket connections to remote server.
+// Close closes the client and all of its connections, like Shutdown, waiting
+// for at most ClientOptions.CloseTimeout. If CloseTimeout is zero, it doesn't
+// wait: any files still open are cancelled, without that being reported as an
+// error.
 func (c *Client) Close() error {
+	if c.options.CloseTimeout <= 0 {
+		return c.shutdown(nil)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.options.CloseTimeout)
+	defer cancel()
+
+	return c.shutdown(ctx)
+}
+
+// Shutdown gracefully closes the client. New files can no longer be opened
+// or created, but Shutdown waits for the FileReaders and FileWriters that are
+// already open to be closed, and then for any namenode request in progress to
+// finish, before closing the connection to the namenode. If ctx is done
+// first, whatever is left is cancelled: every connection the client has open
+// is closed, aborting any transfers in progress, and subsequent operations
+// (including on the files that weren't closed) return ErrClientClosed.
+//
+// The returned error combines ctx.Err(), if anything had to be cancelled,
+// with any error closing the connections. Subsequent calls to Shutdown or
+// Close return ErrClientClosed.
+func (c *Client) Shutdown(ctx context.Context) error {
+	return c.shutdown(ctx)
+}
+
+// shutdown implements Shutdown. If ctx is nil, it doesn't wait for anything.
+func (c *Client) shutdown(ctx context.Context) error {
+	if !atomic.CompareAndSwapInt32(&c.closed, 0, 1) {
+		return ErrClientClosed
+	}
+
+	var errs []error
+	for ctx != nil && c.openFiles() > 0 && ctx.Err() == nil {
+		select {
+		case <-c.fileClosed:
+		case <-ctx.Done():
+		}
+	}
+
+	if n := c.openFiles(); n > 0 {
+		if ctx != nil {
+			errs = append(errs, fmt.Errorf("cancelled %d open files: %w", n, ctx.Err()))
+		}
+
+		c.cancel()
+	}
+
 	close(c.closeCh)
 	c.wg.Wait()
-	return c.namenode.Close()
+
+	var err error
+	if ctx == nil {
+		err = c.namenode.Close()
+	} else {
+		closed := make(chan error, 1)
+		go func() { closed <- c.namenode.Close() }()
+
+		select {
+		case err = <-closed:
+		case <-ctx.Done():
+			// Abort the request in progress, if there is one.
+			c.cancel()
+			err = <-closed
+		}
+	}
+
+	// If the connection was already closed by cancelling, the error isn't
+	// interesting.
+	if err != nil && c.conns.err() == nil {
+		errs = append(errs, err)
+	}
+
+	// Close anything left over, like the datanode connections of idle files.
+	c.cancel()
+	c.conns.close()
+	c.datanodePool.Close()
+	c.shortCircuit.Close()
+	return errors.Join(errs...)
+}
+
+// cancel closes every connection the client has open, and causes any
+// further ones to fail with ErrClientClosed.
+func (c *Client) cancel() {
+	c.cancelConns(ErrClientClosed)
+	c.conns.closeAll()
+}
+
+// openFiles returns the number of FileReaders and FileWriters that haven't
+// been closed.
+func (c *Client) openFiles() int64 {
+	return atomic.LoadInt64(&c.filesROpen) + int64(atomic.LoadUint64(&c.filesWOpen))
+}
+
+// fileDone records that a FileReader or FileWriter was closed, waking up
+// Shutdown.
+func (c *Client) fileDone() {
+	select {
+	case c.fileClosed <- struct{}{}:
+	default:
+	}
+}
+
+// isClosed returns true if Close or Shutdown has been called.
+func (c *Client) isClosed() bool {
+	return atomic.LoadInt32(&c.closed) != 0
 }
@@ -0,0 +1,206 @@
+package hdfs
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/colinmarc/hdfs/v2/hadoopconf"
+	hdfs "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_hdfs"
+)
+
+// ErrClientCacheClosed is returned by ClientCache.Get after the cache has been
+// closed.
+var ErrClientCacheClosed = errors.New("hdfs: client cache closed")
+
+// ClientCacheOptions represents the configurable options for a ClientCache.
+type ClientCacheOptions struct {
+	// Conf is the Hadoop configuration used to build the options for each new
+	// Client (with ClientOptionsFromConf) and to resolve logical nameservices
+	// to namenode addresses.
+	Conf hadoopconf.HadoopConf
+	// Overrides maps cluster names, as passed to Get, to functions that
+	// customize the options for clients of that cluster. They are applied
+	// after the options are loaded from Conf.
+	Overrides map[string]func(options *ClientOptions)
+	// Configure, if provided, is called with the options for every new Client,
+	// after any Overrides are applied. It can be used, for example, to set up a
+	// Kerberos client with the credentials of the given user. If it returns an
+	// error, Get returns it as well.
+	Configure func(cluster, user string, options *ClientOptions) error
+	// HealthCheckInterval, if nonzero, specifies how often the clients are
+	// checked for health. A cached client which hasn't been checked in that
+	// long is checked by Get before being returned, with a cheap call to the
+	// namenode, and replaced with a new client if the call fails.
+	HealthCheckInterval time.Duration
+}
+
+// A ClientCache hands out shared Clients for any number of clusters and users,
+// creating them lazily as they are first requested. It's intended for services
+// that talk to many clusters, so that they can share connections without
+// having to keep track of them. A ClientCache is safe for concurrent use.
+type ClientCache struct {
+	options ClientCacheOptions
+
+	lock    sync.Mutex
+	clients map[clientCacheKey]*clientCacheEntry
+	closed  bool
+}
+
+type clientCacheKey struct {
+	cluster string
+	user    string
+}
+
+type clientCacheEntry struct {
+	lock    sync.Mutex
+	client  *Client
+	checked time.Time
+	closed  bool
+}
+
+// NewClientCache returns a new, empty ClientCache.
+func NewClientCache(options ClientCacheOptions) *ClientCache {
+	return &ClientCache{
+		options: options,
+		clients: make(map[clientCacheKey]*clientCacheEntry),
+	}
+}
+
+// Get returns the shared Client for the given cluster and user, creating it if
+// necessary. The cluster can be a logical nameservice configured in the
+// Hadoop configuration (for example "ns1"), a comma-separated list of namenode
+// addresses (for example "nn1:9000,nn2:9000"), or an hdfs:// URI containing
+// either. If it's empty, the namenodes are determined by the configuration,
+// as with ClientOptionsFromConf.
+//
+// The returned Client must not be closed by the caller; use Close to close all
+// the clients at once.
+func (cc *ClientCache) Get(cluster, user string) (*Client, error) {
+	cluster = normalizeCluster(cluster)
+	key := clientCacheKey{cluster: cluster, user: user}
+
+	cc.lock.Lock()
+	if cc.closed {
+		cc.lock.Unlock()
+		return nil, ErrClientCacheClosed
+	}
+
+	entry, ok := cc.clients[key]
+	if !ok {
+		entry = &clientCacheEntry{}
+		cc.clients[key] = entry
+	}
+
+	// Hold the lock for the entry, but not the whole cache, while creating or
+	// checking the client, so that slow clusters don't block each other. The
+	// cache lock has to be released before waiting for the entry, which may be
+	// held by another Get for as long as it takes to connect.
+	cc.lock.Unlock()
+	entry.lock.Lock()
+	defer entry.lock.Unlock()
+
+	// The cache could have been closed in between.
+	if entry.closed {
+		return nil, ErrClientCacheClosed
+	}
+
+	if entry.client != nil && cc.options.HealthCheckInterval > 0 &&
+		time.Since(entry.checked) >= cc.options.HealthCheckInterval {
+		if cc.check(entry.client) {
+			entry.checked = time.Now()
+		} else {
+			entry.client.Close()
+			entry.client = nil
+		}
+	}
+
+	if entry.client == nil {
+		client, err := cc.newClient(cluster, user)
+		if err != nil {
+			return nil, err
+		}
+
+		entry.client = client
+		entry.checked = time.Now()
+	}
+
+	return entry.client, nil
+}
+
+// Close closes all the clients in the cache. Any further calls to Get will
+// return ErrClientCacheClosed.
+func (cc *ClientCache) Close() error {
+	cc.lock.Lock()
+	defer cc.lock.Unlock()
+
+	cc.closed = true
+
+	var err error
+	for _, entry := range cc.clients {
+		entry.lock.Lock()
+		if entry.client != nil {
+			if closeErr := entry.client.Close(); closeErr != nil && err == nil {
+				err = closeErr
+			}
+
+			entry.client = nil
+		}
+
+		entry.closed = true
+		entry.lock.Unlock()
+	}
+
+	cc.clients = make(map[clientCacheKey]*clientCacheEntry)
+
+	return err
+}
+
+func (cc *ClientCache) newClient(cluster, user string) (*Client, error) {
+	options := ClientOptionsFromConf(cc.options.Conf)
+	options.User = user
+
+	if cluster != "" {
+		if nns := cc.options.Conf.NameserviceNamenodes(cluster); nns != nil {
+			options.Addresses = nns
+		} else {
+			options.Addresses = strings.Split(cluster, ",")
+		}
+	}
+
+	if override, ok := cc.options.Overrides[cluster]; ok {
+		override(&options)
+	}
+
+	if cc.options.Configure != nil {
+		err := cc.options.Configure(cluster, user, &options)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return NewClient(options)
+}
+
+// check returns whether the client can still talk to the namenode.
+func (cc *ClientCache) check(client *Client) bool {
+	req := &hdfs.GetServerDefaultsRequestProto{}
+	resp := &hdfs.GetServerDefaultsResponseProto{}
+
+	err := client.namenode.Execute("getServerDefaults", req, resp)
+	return err == nil
+}
+
+// normalizeCluster strips the scheme and path from an hdfs:// URI, so that
+// "hdfs://ns1/foo" and "ns1" share a client.
+func normalizeCluster(cluster string) string {
+	if strings.Contains(cluster, "://") {
+		if u, err := url.Parse(cluster); err == nil {
+			return u.Host
+		}
+	}
+
+	return cluster
+}
@@ -0,0 +1,131 @@
+package hdfs
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/colinmarc/hdfs/v2/hadoopconf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeCluster(t *testing.T) {
+	assert.Equal(t, "ns1", normalizeCluster("ns1"))
+	assert.Equal(t, "ns1", normalizeCluster("hdfs://ns1/foo/bar"))
+	assert.Equal(t, "nn1:9000", normalizeCluster("hdfs://nn1:9000"))
+	assert.Equal(t, "nn1:9000,nn2:9000", normalizeCluster("nn1:9000,nn2:9000"))
+}
+
+func TestClientCacheConfigure(t *testing.T) {
+	conf := hadoopconf.HadoopConf{
+		"dfs.ha.namenodes.ns1":             "nn1,nn2",
+		"dfs.namenode.rpc-address.ns1.nn1": "namenode1:8020",
+		"dfs.namenode.rpc-address.ns1.nn2": "namenode2:8020",
+	}
+
+	errConfigure := errors.New("configure")
+	var got ClientOptions
+	cc := NewClientCache(ClientCacheOptions{
+		Conf: conf,
+		Overrides: map[string]func(*ClientOptions){
+			"ns1": func(options *ClientOptions) {
+				options.UseDatanodeHostname = true
+			},
+		},
+		Configure: func(cluster, user string, options *ClientOptions) error {
+			assert.Equal(t, "ns1", cluster)
+			assert.Equal(t, "foo", user)
+			got = *options
+			return errConfigure
+		},
+	})
+
+	_, err := cc.Get("hdfs://ns1/", "foo")
+	assert.Equal(t, errConfigure, err)
+	assert.Equal(t, []string{"namenode1:8020", "namenode2:8020"}, got.Addresses)
+	assert.Equal(t, "foo", got.User)
+	assert.True(t, got.UseDatanodeHostname)
+
+	require.NoError(t, cc.Close())
+	_, err = cc.Get("ns1", "foo")
+	assert.Equal(t, ErrClientCacheClosed, err)
+}
+
+func TestClientCacheSlowClient(t *testing.T) {
+	errConfigure := errors.New("configure")
+	started := make(chan struct{})
+	var startedOnce sync.Once
+	unblock := make(chan struct{})
+	cc := NewClientCache(ClientCacheOptions{
+		Configure: func(cluster, user string, options *ClientOptions) error {
+			if user == "slow" {
+				startedOnce.Do(func() { close(started) })
+				<-unblock
+			}
+
+			return errConfigure
+		},
+	})
+
+	done := make(chan struct{})
+	go func() {
+		cc.Get("nn1:9000", "slow")
+		close(done)
+	}()
+
+	<-started
+
+	// A second Get for the slow client waits for the first, but shouldn't hold
+	// up Gets for other clients while it does.
+	waiting := make(chan struct{})
+	go func() {
+		cc.Get("nn1:9000", "slow")
+		close(waiting)
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	fast := make(chan error, 1)
+	go func() {
+		_, err := cc.Get("nn2:9000", "fast")
+		fast <- err
+	}()
+
+	select {
+	case err := <-fast:
+		assert.Equal(t, errConfigure, err)
+	case <-time.After(time.Second):
+		t.Error("Get for another client blocked on a slow client")
+	}
+
+	close(unblock)
+	<-done
+	<-waiting
+}
+
+func TestClientCacheGet(t *testing.T) {
+	conf, err := hadoopconf.LoadFromEnvironment()
+	if err != nil || conf == nil {
+		t.Fatal("Couldn't load ambient config", err)
+	}
+
+	cc := NewClientCache(ClientCacheOptions{Conf: conf})
+	defer cc.Close()
+
+	client, err := cc.Get("", "gohdfs1")
+	if err != nil {
+		t.Skip("Couldn't create client:", err)
+	}
+
+	again, err := cc.Get("", "gohdfs1")
+	require.NoError(t, err)
+	assert.True(t, client == again, "should return the same client")
+
+	other, err := cc.Get("", "gohdfs2")
+	require.NoError(t, err)
+	assert.False(t, client == other, "should return a different client for a different user")
+
+	_, err = client.Stat("/_test")
+	assert.NoError(t, err)
+}
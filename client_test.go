@@ -1,14 +1,20 @@
 package hdfs
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io/ioutil"
+	"log"
 	"os"
 	"os/user"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/colinmarc/hdfs/v2/hadoopconf"
+	hdfs "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_hdfs"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	krb "gopkg.in/jcmturner/gokrb5.v5/client"
@@ -36,6 +42,19 @@ func getClientForUser(t *testing.T, username string) *Client {
 		return c
 	}
 
+	client := newClientForUser(t, username)
+	cachedClients[username] = client
+	return client
+}
+
+// newClientForUser returns a new, uncached client, for tests that close it.
+func newClientForUser(t *testing.T, username string) *Client {
+	return newClientWithOptions(t, username, nil)
+}
+
+// newClientWithOptions is like newClientForUser, but calls configure, if it's
+// set, to change the options before the client is created.
+func newClientWithOptions(t *testing.T, username string, configure func(*ClientOptions)) *Client {
 	conf, err := hadoopconf.LoadFromEnvironment()
 	if err != nil || conf == nil {
 		t.Fatal("Couldn't load ambient config", err)
@@ -52,12 +71,15 @@ func getClientForUser(t *testing.T, username string) *Client {
 		options.User = username
 	}
 
+	if configure != nil {
+		configure(&options)
+	}
+
 	client, err := NewClient(options)
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	cachedClients[username] = client
 	return client
 }
 
@@ -161,6 +183,75 @@ func TestNewWithFailingNode(t *testing.T) {
 	assert.NotNil(t, err)
 }
 
+func TestClientClose(t *testing.T) {
+	client := newClientForUser(t, "gohdfs1")
+
+	r, err := client.Open("/_test/foo.txt")
+	require.NoError(t, err)
+
+	// With no CloseTimeout, the open reader is cancelled immediately.
+	err = client.Close()
+	assert.NoError(t, err)
+
+	_, err = r.Read(make([]byte, 4))
+	assert.True(t, errors.Is(err, ErrClientClosed))
+	assert.True(t, errors.Is(err, context.Canceled))
+
+	_, err = client.Stat("/_test/foo.txt")
+	assertPathError(t, err, "stat", "/_test/foo.txt", ErrClientClosed)
+
+	_, err = client.Open("/_test/foo.txt")
+	assertPathError(t, err, "open", "/_test/foo.txt", ErrClientClosed)
+
+	assert.Equal(t, ErrClientClosed, client.Close())
+}
+
+func TestClientShutdownWaitsForFiles(t *testing.T) {
+	client := newClientForUser(t, "gohdfs1")
+	baleet(t, "/_test/shutdown.txt")
+
+	w, err := client.Create("/_test/shutdown.txt")
+	require.NoError(t, err)
+
+	done := make(chan error)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		done <- client.Shutdown(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		t.Fatal("Shutdown returned with a file open:", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	_, err = w.Write([]byte("foo"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	require.NoError(t, <-done)
+
+	bytes, err := getClient(t).ReadFile("/_test/shutdown.txt")
+	require.NoError(t, err)
+	assert.Equal(t, "foo", string(bytes))
+}
+
+func TestClientShutdownTimeout(t *testing.T) {
+	client := newClientForUser(t, "gohdfs1")
+
+	r, err := client.Open("/_test/foo.txt")
+	require.NoError(t, err)
+	defer r.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err = client.Shutdown(ctx)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.True(t, time.Since(start) < 10*time.Second)
+}
+
 func TestReadFile(t *testing.T) {
 	client := getClient(t)
 
@@ -196,3 +287,281 @@ func TestCopyToRemote(t *testing.T) {
 
 	assert.EqualValues(t, "bar\n", string(bytes))
 }
+
+func TestCopyToRemoteWithOptions(t *testing.T) {
+	client := getClient(t)
+
+	baleet(t, "/_test/copytoremote2.txt")
+	err := client.CopyToRemoteWithOptions("testdata/mobydick.txt", "/_test/copytoremote2.txt",
+		CreateOptions{BlockSize: 1048576, Verify: true})
+	require.NoError(t, err)
+
+	fi, err := client.Stat("/_test/copytoremote2.txt")
+	require.NoError(t, err)
+	assert.EqualValues(t, 1257276, fi.Size())
+	assert.EqualValues(t, 1048576, fi.(*FileInfo).BlockSize())
+
+	err = client.CopyToRemoteWithOptions("testdata/foo.txt", "/_test/copytoremote2.txt", CreateOptions{})
+	assertPathError(t, err, "create", "/_test/copytoremote2.txt", os.ErrExist)
+
+	err = client.CopyToRemoteWithOptions("testdata/foo.txt", "/_test/copytoremote2.txt", CreateOptions{Overwrite: true})
+	require.NoError(t, err)
+
+	fi, err = client.Stat("/_test/copytoremote2.txt")
+	require.NoError(t, err)
+	assert.EqualValues(t, 4, fi.Size())
+}
+
+func TestParseUmask(t *testing.T) {
+	for s, expected := range map[string]os.FileMode{
+		"022":           022,
+		"0077":          077,
+		"u=rwx,g=rx,o=": 027,
+		"u=rwx,go=":     077,
+		"a=rx":          0222,
+	} {
+		umask, err := parseUmask(s)
+		require.NoError(t, err, s)
+		assert.EqualValues(t, expected, umask, s)
+	}
+
+	for _, s := range []string{"", "1000", "u=rwz", "rwx", "q=r"} {
+		_, err := parseUmask(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestClientOptionsFromConfUmask(t *testing.T) {
+	options := ClientOptionsFromConf(hadoopconf.HadoopConf{})
+	assert.EqualValues(t, 022, options.Umask)
+
+	options = ClientOptionsFromConf(hadoopconf.HadoopConf{"fs.permissions.umask-mode": "077"})
+	assert.EqualValues(t, 077, options.Umask)
+}
+
+func TestClientOptionsFromConfTrash(t *testing.T) {
+	options := ClientOptionsFromConf(hadoopconf.HadoopConf{})
+	assert.EqualValues(t, 0, options.TrashInterval)
+	assert.EqualValues(t, 0, options.TrashCheckpointInterval)
+
+	options = ClientOptionsFromConf(hadoopconf.HadoopConf{
+		"fs.trash.interval":            "1440",
+		"fs.trash.checkpoint.interval": "0.5",
+	})
+	assert.Equal(t, 24*time.Hour, options.TrashInterval)
+	assert.Equal(t, 30*time.Second, options.TrashCheckpointInterval)
+}
+
+func TestClientOptionsFromConfHeartbeat(t *testing.T) {
+	options := ClientOptionsFromConf(hadoopconf.HadoopConf{})
+	assert.EqualValues(t, 0, options.DatanodeHeartbeatInterval)
+
+	options = ClientOptionsFromConf(hadoopconf.HadoopConf{"dfs.client.socket-timeout": "20000"})
+	assert.Equal(t, 10*time.Second, options.DatanodeHeartbeatInterval)
+}
+
+func TestClientOptionsFromConfFailover(t *testing.T) {
+	options := ClientOptionsFromConf(hadoopconf.HadoopConf{})
+	assert.Equal(t, 0, options.NamenodeRetries)
+	assert.EqualValues(t, 0, options.NamenodeRetryInterval)
+	assert.EqualValues(t, 0, options.NamenodeMaxRetryInterval)
+
+	options = ClientOptionsFromConf(hadoopconf.HadoopConf{
+		"dfs.client.failover.max.attempts":      "15",
+		"dfs.client.failover.sleep.base.millis": "500",
+		"dfs.client.failover.sleep.max.millis":  "15000",
+	})
+	assert.Equal(t, 15, options.NamenodeRetries)
+	assert.Equal(t, 500*time.Millisecond, options.NamenodeRetryInterval)
+	assert.Equal(t, 15*time.Second, options.NamenodeMaxRetryInterval)
+}
+
+func TestClientOptionsFromConfNameservice(t *testing.T) {
+	conf := hadoopconf.HadoopConf{
+		"fs.defaultFS":                     "hdfs://ns1",
+		"dfs.nameservices":                 "ns1",
+		"dfs.ha.namenodes.ns1":             "nn2,nn1",
+		"dfs.namenode.rpc-address.ns1.nn1": "namenode1:8020",
+		"dfs.namenode.rpc-address.ns1.nn2": "namenode2:8020",
+		"dfs.namenode.rpc-address.ns2.nn1": "namenode3:8020",
+	}
+
+	options := ClientOptionsFromConf(conf)
+	assert.Equal(t, []string{"namenode2:8020", "namenode1:8020"}, options.Addresses)
+
+	// Without dfs.nameservices, fs.defaultFS isn't known to be a nameservice.
+	conf["dfs.nameservices"] = ""
+	options = ClientOptionsFromConf(conf)
+	assert.Equal(t, []string{"namenode1:8020", "namenode2:8020", "namenode3:8020"}, options.Addresses)
+}
+
+func TestNewForConfigurationWebHDFS(t *testing.T) {
+	srv := newFakeWebHDFS(t)
+	t.Setenv("HADOOP_CONF_DIR", t.TempDir())
+	t.Setenv("HADOOP_USER_NAME", "gohdfs1")
+
+	client, err := NewForConfiguration("webhdfs://" + strings.TrimPrefix(srv.URL, "http://") + "/user/gohdfs1")
+	require.NoError(t, err)
+	defer client.Close()
+
+	assert.Equal(t, "gohdfs1", client.User())
+	info, err := client.Stat("/")
+	require.NoError(t, err)
+	assert.True(t, info.IsDir())
+}
+
+func TestClientOptionsFromConfChecksumType(t *testing.T) {
+	options := ClientOptionsFromConf(hadoopconf.HadoopConf{})
+	assert.Equal(t, "", options.ChecksumType)
+
+	options = ClientOptionsFromConf(hadoopconf.HadoopConf{"dfs.checksum.type": "CRC32C"})
+	assert.Equal(t, "CRC32C", options.ChecksumType)
+}
+
+func TestClientOptionsFromConfShortCircuit(t *testing.T) {
+	options := ClientOptionsFromConf(hadoopconf.HadoopConf{})
+	assert.False(t, options.ShortCircuitReads)
+
+	options = ClientOptionsFromConf(hadoopconf.HadoopConf{
+		"dfs.client.read.shortcircuit": "true",
+		"dfs.domain.socket.path":       "/var/lib/hadoop-hdfs/dn_socket",
+	})
+	assert.True(t, options.ShortCircuitReads)
+	assert.Equal(t, "/var/lib/hadoop-hdfs/dn_socket", options.DomainSocketPath)
+}
+
+func TestClientOptionsFromConfKeyProvider(t *testing.T) {
+	options := ClientOptionsFromConf(hadoopconf.HadoopConf{
+		"dfs.encryption.key.provider.uri": "kms://http@old:16000/kms",
+	})
+	assert.Equal(t, "kms://http@old:16000/kms", options.KeyProviderURI)
+
+	options = ClientOptionsFromConf(hadoopconf.HadoopConf{
+		"dfs.encryption.key.provider.uri":   "kms://http@old:16000/kms",
+		"hadoop.security.key.provider.path": "kms://https@kms1;kms2:9600/kms",
+	})
+	assert.Equal(t, "kms://https@kms1;kms2:9600/kms", options.KeyProviderURI)
+}
+
+// writeTokenFile writes a version 0 credentials file with a token for each of
+// the given services.
+func writeTokenFile(t *testing.T, kind string, services ...string) string {
+	text := func(s string) []byte { return append([]byte{byte(len(s))}, s...) }
+
+	b := []byte{'H', 'D', 'T', 'S', 0, byte(len(services))}
+	for _, service := range services {
+		b = append(b, text(service)...)
+		b = append(b, text("\x00\x05alice\x04yarn\x00")...)
+		b = append(b, text("password")...)
+		b = append(b, text(kind)...)
+		b = append(b, text(service)...)
+	}
+
+	name := filepath.Join(t.TempDir(), "container_tokens")
+	require.NoError(t, os.WriteFile(name, append(b, 0), 0600))
+	return name
+}
+
+func TestClientOptionsFromConfDelegationToken(t *testing.T) {
+	conf := hadoopconf.HadoopConf{
+		"dfs.ha.namenodes.cluster":             "nn1,nn2",
+		"dfs.namenode.rpc-address.cluster.nn1": "nn1:8020",
+		"dfs.namenode.rpc-address.cluster.nn2": "nn2:8020",
+	}
+
+	t.Setenv("HADOOP_TOKEN_FILE_LOCATION", "")
+	assert.Nil(t, ClientOptionsFromConf(conf).DelegationToken)
+
+	t.Setenv("HADOOP_TOKEN_FILE_LOCATION", writeTokenFile(t, "HDFS_DELEGATION_TOKEN", "other:8020", "ha-hdfs:cluster"))
+	token := ClientOptionsFromConf(conf).DelegationToken
+	require.NotNil(t, token)
+	assert.Equal(t, "ha-hdfs:cluster", token.Service)
+	assert.Equal(t, []byte("password"), token.Password)
+
+	owner, err := token.Owner()
+	require.NoError(t, err)
+	assert.Equal(t, "alice", owner)
+
+	// A token for another cluster isn't used, even if it's the only one.
+	t.Setenv("HADOOP_TOKEN_FILE_LOCATION", writeTokenFile(t, "HDFS_DELEGATION_TOKEN", "10.0.0.1:8020"))
+	assert.Nil(t, ClientOptionsFromConf(conf).DelegationToken)
+
+	// A service can also be named by the IP address of a namenode.
+	t.Setenv("HADOOP_TOKEN_FILE_LOCATION", writeTokenFile(t, "HDFS_DELEGATION_TOKEN", "127.0.0.1:8020"))
+	token = tokenFromEnvironment(nil, []string{"localhost:8020"})
+	require.NotNil(t, token)
+	assert.Equal(t, "127.0.0.1:8020", token.Service)
+
+	t.Setenv("HADOOP_TOKEN_FILE_LOCATION", writeTokenFile(t, "HDFS_DELEGATION_TOKEN", "a:8020", "b:8020"))
+	assert.Nil(t, ClientOptionsFromConf(conf).DelegationToken)
+
+	t.Setenv("HADOOP_TOKEN_FILE_LOCATION", writeTokenFile(t, "YARN_AM_RM_TOKEN", "ha-hdfs:cluster"))
+	assert.Nil(t, ClientOptionsFromConf(conf).DelegationToken)
+
+	t.Setenv("HADOOP_TOKEN_FILE_LOCATION", filepath.Join(t.TempDir(), "missing"))
+	assert.Nil(t, ClientOptionsFromConf(conf).DelegationToken)
+}
+
+func TestParseChecksumType(t *testing.T) {
+	for name, expected := range map[string]hdfs.ChecksumTypeProto{
+		"":       hdfs.ChecksumTypeProto_CHECKSUM_NULL,
+		"CRC32":  hdfs.ChecksumTypeProto_CHECKSUM_CRC32,
+		"crc32c": hdfs.ChecksumTypeProto_CHECKSUM_CRC32C,
+	} {
+		checksumType, err := parseChecksumType(name)
+		require.NoError(t, err)
+		assert.Equal(t, expected, checksumType)
+	}
+
+	_, err := parseChecksumType("MD5")
+	assert.Error(t, err)
+
+	_, err = NewClient(ClientOptions{Addresses: []string{"localhost:9000"}, ChecksumType: "MD5"})
+	assert.Error(t, err)
+}
+
+func TestClientOptionsFromConfWritePacketSize(t *testing.T) {
+	options := ClientOptionsFromConf(hadoopconf.HadoopConf{})
+	assert.Equal(t, 0, options.WritePacketSize)
+
+	options = ClientOptionsFromConf(hadoopconf.HadoopConf{"dfs.client-write-packet-size": "1048576"})
+	assert.Equal(t, 1048576, options.WritePacketSize)
+}
+
+func TestClientOptionsFromConfMaxPacketsInFlight(t *testing.T) {
+	options := ClientOptionsFromConf(hadoopconf.HadoopConf{})
+	assert.Equal(t, 0, options.MaxPacketsInFlight)
+
+	options = ClientOptionsFromConf(hadoopconf.HadoopConf{"dfs.client.write.max-packets-in-flight": "80"})
+	assert.Equal(t, 80, options.MaxPacketsInFlight)
+}
+
+func TestClientOptionsFromConfDatanodeKerberos(t *testing.T) {
+	options := ClientOptionsFromConf(hadoopconf.HadoopConf{
+		"dfs.datanode.kerberos.principal": "dn/_HOST@EXAMPLE.COM",
+	})
+	assert.Equal(t, "dn/_HOST", options.DatanodeKerberosServicePrincipleName)
+}
+
+func TestClientOptionsFromConfDataTransferAES(t *testing.T) {
+	options := ClientOptionsFromConf(hadoopconf.HadoopConf{})
+	assert.False(t, options.DataTransferAES)
+
+	options = ClientOptionsFromConf(hadoopconf.HadoopConf{
+		"dfs.encrypt.data.transfer.cipher.suites": "AES/CTR/NoPadding",
+	})
+	assert.True(t, options.DataTransferAES)
+}
+
+func TestNewWireLogger(t *testing.T) {
+	assert.Nil(t, newWireLogger(ClientOptions{}))
+
+	wl := newWireLogger(ClientOptions{DebugWire: true, DebugWireHexDumpBytes: 64})
+	require.NotNil(t, wl)
+	assert.Equal(t, log.Default(), wl.Logger)
+	assert.Equal(t, 64, wl.HexDumpBytes)
+
+	logger := log.New(ioutil.Discard, "", 0)
+	wl = newWireLogger(ClientOptions{DebugWire: true, Logger: logger})
+	assert.Equal(t, logger, wl.Logger)
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/colinmarc/hdfs/v2"
+)
+
+func getfacl(args []string) {
+	expanded, client, err := getClientAndExpandedPaths(args)
+	if err != nil {
+		fatal(err)
+	} else if len(expanded) == 0 {
+		printHelp()
+	}
+
+	for _, p := range expanded {
+		status, err := client.GetAclStatus(p)
+		if err != nil {
+			printError(err)
+			continue
+		}
+
+		printAcl(p, status)
+	}
+}
+
+// printAcl prints an ACL in the same format as 'hdfs dfs -getfacl'. Entries
+// that are limited by the mask have their effective permissions noted.
+func printAcl(p string, status *hdfs.AclStatus) {
+	fmt.Printf("# file: %s\n", p)
+	fmt.Printf("# owner: %s\n", status.Owner)
+	fmt.Printf("# group: %s\n", status.Group)
+	if status.Sticky {
+		fmt.Println("# flags: --t")
+	}
+
+	acl := status.Acl()
+	masks := make(map[bool]hdfs.AclEntry)
+	for _, entry := range acl {
+		if entry.Type == hdfs.AclMask {
+			masks[entry.Default] = entry
+		}
+	}
+
+	for _, entry := range acl {
+		mask, ok := masks[entry.Default]
+		limited := entry.Type == hdfs.AclGroup || (entry.Type == hdfs.AclUser && entry.Name != "")
+		if ok && limited && entry.Permissions&^mask.Permissions != 0 {
+			fmt.Printf("%s\t#effective:%s\n", entry, formatAclPermissions(entry.Permissions&mask.Permissions))
+		} else {
+			fmt.Println(entry)
+		}
+	}
+
+	fmt.Println()
+}
+
+// formatAclPermissions formats permissions the same way as the end of an ACL
+// entry, for example "r-x".
+func formatAclPermissions(perm os.FileMode) string {
+	b := []byte("---")
+	for i, c := range "rwx" {
+		if perm&(4>>i) != 0 {
+			b[i] = byte(c)
+		}
+	}
+
+	return string(b)
+}
+
+func setfacl(args []string, modify, remove, set string, removeAll, removeDefault bool) {
+	var n int
+	for _, specified := range []bool{modify != "", remove != "", set != "", removeAll, removeDefault} {
+		if specified {
+			n++
+		}
+	}
+
+	if n != 1 {
+		fatalWithUsage("Exactly one of -m, -x, -b, -k, and --set must be specified")
+	}
+
+	var entries []hdfs.AclEntry
+	var err error
+	switch {
+	case modify != "":
+		entries, err = hdfs.ParseAclSpec(modify, true)
+	case set != "":
+		entries, err = hdfs.ParseAclSpec(set, true)
+	case remove != "":
+		entries, err = hdfs.ParseAclSpec(remove, false)
+	}
+
+	if err != nil {
+		fatal(err)
+	}
+
+	expanded, client, err := getClientAndExpandedPaths(args)
+	if err != nil {
+		fatal(err)
+	} else if len(expanded) == 0 {
+		printHelp()
+	}
+
+	for _, p := range expanded {
+		switch {
+		case modify != "":
+			err = client.ModifyAclEntries(p, entries)
+		case set != "":
+			err = client.SetAcl(p, entries)
+		case remove != "":
+			err = client.RemoveAclEntries(p, entries)
+		case removeAll:
+			err = client.RemoveAcl(p)
+		case removeDefault:
+			err = client.RemoveDefaultAcl(p)
+		}
+
+		if err != nil {
+			printError(err)
+		}
+	}
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/colinmarc/hdfs/v2"
+)
+
+var (
+	bwlimit          string
+	bwlimitPerWorker string
+
+	transferLimiterOnce sync.Once
+	transferLimiter     *rateLimiter
+)
+
+// rateLimiter is a token bucket, which limits the rate at which bytes are
+// transferred. It's safe for concurrent use, so a single rateLimiter can
+// limit the aggregate bandwidth of several workers. Each worker can also have
+// its own rateLimiter, with the shared one as its parent.
+type rateLimiter struct {
+	rate   float64
+	burst  float64
+	parent *rateLimiter
+
+	lock   sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+func newRateLimiter(bytesPerSecond int64, parent *rateLimiter) *rateLimiter {
+	// Allow bursts of up to a tenth of a second's worth of data, but not less
+	// than a typical packet.
+	burst := float64(bytesPerSecond) / 10
+	if burst < 64*1024 {
+		burst = 64 * 1024
+	}
+
+	return &rateLimiter{
+		rate:   float64(bytesPerSecond),
+		burst:  burst,
+		parent: parent,
+		tokens: burst,
+		last:   time.Now(),
+	}
+}
+
+// wait blocks until n bytes can be transferred. The tokens are taken
+// immediately, even if that puts the bucket in debt, so that concurrent
+// callers are served in order. It's safe to call on a nil rateLimiter.
+func (l *rateLimiter) wait(n int) {
+	if l == nil {
+		return
+	}
+
+	l.lock.Lock()
+	now := time.Now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+
+	l.last = now
+	l.tokens -= float64(n)
+
+	var delay time.Duration
+	if l.tokens < 0 {
+		delay = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	l.lock.Unlock()
+
+	time.Sleep(delay)
+	l.parent.wait(n)
+}
+
+// reader wraps r so that reads from it are limited. If l is nil, r is
+// returned as is. An *hdfs.FileReader keeps its io.WriterTo fast path, with
+// the writes limited instead.
+func (l *rateLimiter) reader(r io.Reader) io.Reader {
+	if l == nil {
+		return r
+	}
+
+	lr := &limitedReader{r: r, limiter: l}
+	if _, ok := r.(*hdfs.FileReader); ok {
+		return &limitedFileReader{lr}
+	}
+
+	return lr
+}
+
+type limitedReader struct {
+	r       io.Reader
+	limiter *rateLimiter
+}
+
+func (lr *limitedReader) Read(b []byte) (int, error) {
+	if len(b) > int(lr.limiter.burst) {
+		b = b[:int(lr.limiter.burst)]
+	}
+
+	n, err := lr.r.Read(b)
+	lr.limiter.wait(n)
+	return n, err
+}
+
+// limitedFileReader is a limitedReader for an *hdfs.FileReader. It implements
+// io.WriterTo, so that io.Copy still reads through FileReader.WriteTo, in
+// large chunks, rather than in burst-sized reads.
+type limitedFileReader struct {
+	*limitedReader
+}
+
+func (lr *limitedFileReader) WriteTo(w io.Writer) (int64, error) {
+	return lr.r.(*hdfs.FileReader).WriteTo(&limitedWriter{w: w, limiter: lr.limiter})
+}
+
+// limitedWriter limits writes to w, splitting them up so that no more than a
+// burst is written at once.
+type limitedWriter struct {
+	w       io.Writer
+	limiter *rateLimiter
+}
+
+func (lw *limitedWriter) Write(b []byte) (int, error) {
+	var written int
+	for len(b) > 0 {
+		chunk := b
+		if len(chunk) > int(lw.limiter.burst) {
+			chunk = chunk[:int(lw.limiter.burst)]
+		}
+
+		lw.limiter.wait(len(chunk))
+		n, err := lw.w.Write(chunk)
+		written += n
+		if err != nil {
+			return written, err
+		} else if n < len(chunk) {
+			return written, io.ErrShortWrite
+		}
+
+		b = b[n:]
+	}
+
+	return written, nil
+}
+
+// workerLimiter returns a rateLimiter for a single transfer worker, based on
+// the --bwlimit and --bwlimit-per-worker flags. The --bwlimit limit is shared
+// by every worker. It returns nil if neither flag is set.
+func workerLimiter() *rateLimiter {
+	transferLimiterOnce.Do(func() {
+		if bwlimit != "" {
+			transferLimiter = newRateLimiter(mustParseRate(bwlimit), nil)
+		}
+	})
+
+	if bwlimitPerWorker != "" {
+		return newRateLimiter(mustParseRate(bwlimitPerWorker), transferLimiter)
+	}
+
+	return transferLimiter
+}
+
+// parseRate parses a bandwidth limit in bytes per second, with an optional
+// K, M, G, or T suffix (in powers of 1024, like the sizes printed by ls -h).
+func parseRate(rate string) (int64, error) {
+	n, err := parseBytes(rate)
+	if err != nil {
+		return 0, fmt.Errorf("invalid bandwidth limit: %s", rate)
+	}
+
+	return n, nil
+}
+
+func mustParseRate(s string) int64 {
+	rate, err := parseRate(s)
+	if err != nil {
+		fatal(err)
+	}
+
+	return rate
+}
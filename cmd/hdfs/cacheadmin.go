@@ -0,0 +1,346 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/colinmarc/hdfs/v2"
+)
+
+const cacheExpiryFormat = "2006-01-02T15:04:05-0700"
+
+// cacheadmin takes its flags in the same form as 'hdfs cacheadmin', for
+// example:
+//
+//	hdfs cacheadmin -addDirective -path /tables/hot -pool hot -ttl 7d
+func cacheadmin(args []string) {
+	if len(args) == 0 {
+		printHelp()
+	}
+
+	subcommand := args[0]
+	flags, positional := parseCacheadminFlags(args[1:])
+
+	var nn string
+	if p, ok := flags["-path"]; ok {
+		paths, namenode, err := normalizePaths([]string{p})
+		if err != nil {
+			fatal(err)
+		}
+
+		flags["-path"], nn = paths[0], namenode
+	}
+
+	client, err := getClient(nn)
+	if err != nil {
+		fatal(err)
+	}
+
+	switch subcommand {
+	case "-addDirective":
+		directive := cacheDirectiveFromFlags(flags)
+		if directive.Path == "" || directive.Pool == "" {
+			fatalWithUsage("Both -path and -pool are required.")
+		}
+
+		id, err := client.AddCacheDirective(directive, flags["-force"] != "")
+		if err != nil {
+			fatal(err)
+		}
+
+		fmt.Println("Added cache directive", id)
+	case "-modifyDirective":
+		directive := cacheDirectiveFromFlags(flags)
+		if directive.ID == 0 {
+			fatalWithUsage("Missing -id")
+		}
+
+		err = client.ModifyCacheDirective(directive, flags["-force"] != "")
+		if err != nil {
+			fatal(err)
+		}
+
+		fmt.Println("Modified cache directive", directive.ID)
+	case "-removeDirective":
+		if len(positional) != 1 {
+			fatalWithUsage()
+		}
+
+		id := parseCacheDirectiveID(positional[0])
+		err = client.RemoveCacheDirective(id)
+		if err != nil {
+			fatal(err)
+		}
+
+		fmt.Println("Removed cache directive", id)
+	case "-removeDirectives":
+		p := flags["-path"]
+		if p == "" {
+			fatalWithUsage("Missing -path")
+		}
+
+		removeCacheDirectives(client, p)
+	case "-listDirectives":
+		listCacheDirectives(client, cacheDirectiveFromFlags(flags), flags["-stats"] != "")
+	case "-addPool", "-modifyPool":
+		if len(positional) != 1 {
+			fatalWithUsage()
+		}
+
+		pool := cachePoolFromFlags(positional[0], flags)
+		if subcommand == "-addPool" {
+			err = client.AddCachePool(pool)
+		} else {
+			err = client.ModifyCachePool(pool)
+		}
+
+		if err != nil {
+			fatal(err)
+		}
+	case "-removePool":
+		if len(positional) != 1 {
+			fatalWithUsage()
+		}
+
+		err = client.RemoveCachePool(positional[0])
+		if err != nil {
+			fatal(err)
+		}
+	case "-listPools":
+		if len(positional) > 1 {
+			fatalWithUsage()
+		}
+
+		listCachePools(client, positional, flags["-stats"] != "")
+	default:
+		fatalWithUsage("Unknown cacheadmin command:", subcommand)
+	}
+}
+
+// parseCacheadminFlags splits args into flags with values, like -pool, and
+// positional arguments. The boolean flags, -force and -stats, are set to
+// "true" if they're present.
+func parseCacheadminFlags(args []string) (map[string]string, []string) {
+	flags := make(map[string]string)
+	var positional []string
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "-force", "-stats":
+			flags[args[i]] = "true"
+		case "-path", "-pool", "-replication", "-ttl", "-id",
+			"-owner", "-group", "-mode", "-limit", "-maxTtl":
+			if i+1 >= len(args) {
+				fatalWithUsage("Missing value for", args[i])
+			}
+
+			flags[args[i]] = args[i+1]
+			i++
+		default:
+			if strings.HasPrefix(args[i], "-") {
+				fatalWithUsage("Unknown flag:", args[i])
+			}
+
+			positional = append(positional, args[i])
+		}
+	}
+
+	return flags, positional
+}
+
+func cacheDirectiveFromFlags(flags map[string]string) hdfs.CacheDirective {
+	directive := hdfs.CacheDirective{
+		Path: flags["-path"],
+		Pool: flags["-pool"],
+	}
+
+	if id, ok := flags["-id"]; ok {
+		directive.ID = parseCacheDirectiveID(id)
+	}
+
+	if s, ok := flags["-replication"]; ok {
+		replication, err := strconv.Atoi(s)
+		if err != nil || replication <= 0 {
+			fatalWithUsage("Invalid value for -replication:", s)
+		}
+
+		directive.Replication = replication
+	}
+
+	if s, ok := flags["-ttl"]; ok {
+		ttl, never := parseTTL(s)
+		if never {
+			directive.Expiration = hdfs.CacheNeverExpires
+		} else {
+			directive.Expiration = time.Now().Add(ttl)
+		}
+	}
+
+	return directive
+}
+
+func cachePoolFromFlags(name string, flags map[string]string) hdfs.CachePool {
+	pool := hdfs.CachePool{
+		Name:  name,
+		Owner: flags["-owner"],
+		Group: flags["-group"],
+	}
+
+	if s, ok := flags["-mode"]; ok {
+		mode, err := strconv.ParseUint(s, 8, 32)
+		if err != nil {
+			fatalWithUsage("Invalid value for -mode:", s)
+		}
+
+		pool.Mode = os.FileMode(mode)
+	}
+
+	if s, ok := flags["-limit"]; ok {
+		if s == "unlimited" {
+			pool.Limit = hdfs.CachePoolUnlimited
+		} else {
+			limit, err := parseBytes(s)
+			if err != nil {
+				fatalWithUsage("Invalid value for -limit:", s)
+			}
+
+			pool.Limit = limit
+		}
+	}
+
+	if s, ok := flags["-maxTtl"]; ok {
+		ttl, never := parseTTL(s)
+		if never {
+			pool.MaxTTL = hdfs.CachePoolNoMaxTTL
+		} else {
+			pool.MaxTTL = ttl
+		}
+	}
+
+	return pool
+}
+
+func parseCacheDirectiveID(s string) int64 {
+	id, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || id <= 0 {
+		fatalWithUsage("Invalid cache directive ID:", s)
+	}
+
+	return id
+}
+
+// parseTTL parses a TTL like "30m" or "7d". It returns true instead if the TTL
+// is "never".
+func parseTTL(s string) (time.Duration, bool) {
+	if s == "never" {
+		return 0, true
+	}
+
+	var ttl time.Duration
+	var err error
+	if days := strings.TrimSuffix(s, "d"); days != s {
+		var n int64
+		n, err = strconv.ParseInt(days, 10, 64)
+		ttl = time.Duration(n) * 24 * time.Hour
+	} else {
+		ttl, err = time.ParseDuration(s)
+	}
+
+	if err != nil || ttl <= 0 {
+		fatalWithUsage("Invalid TTL:", s)
+	}
+
+	return ttl, false
+}
+
+func removeCacheDirectives(client *hdfs.Client, p string) {
+	directives, err := client.ListCacheDirectives(hdfs.CacheDirective{Path: p})
+	if err != nil {
+		fatal(err)
+	}
+
+	for _, directive := range directives {
+		err := client.RemoveCacheDirective(directive.ID)
+		if err != nil {
+			printError(err)
+			continue
+		}
+
+		fmt.Println("Removed cache directive", directive.ID)
+	}
+}
+
+func listCacheDirectives(client *hdfs.Client, filter hdfs.CacheDirective, stats bool) {
+	directives, err := client.ListCacheDirectives(filter)
+	if err != nil {
+		fatal(err)
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 3, 8, 1, ' ', 0)
+	fmt.Fprintf(tw, "ID\tPool\tReplication\tExpiry\tPath")
+	if stats {
+		fmt.Fprintf(tw, "\tBytes Needed\tBytes Cached\tFiles Needed\tFiles Cached")
+	}
+
+	fmt.Fprintln(tw)
+	for _, d := range directives {
+		expiry := "never"
+		if !d.Expiration.Equal(hdfs.CacheNeverExpires) {
+			expiry = d.Expiration.Format(cacheExpiryFormat)
+		}
+
+		fmt.Fprintf(tw, "%d\t%s\t%d\t%s\t%s", d.ID, d.Pool, d.Replication, expiry, d.Path)
+		if stats {
+			fmt.Fprintf(tw, "\t%d\t%d\t%d\t%d",
+				d.Stats.BytesNeeded, d.Stats.BytesCached, d.Stats.FilesNeeded, d.Stats.FilesCached)
+		}
+
+		fmt.Fprintln(tw)
+	}
+
+	tw.Flush()
+}
+
+func listCachePools(client *hdfs.Client, names []string, stats bool) {
+	pools, err := client.ListCachePools()
+	if err != nil {
+		fatal(err)
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 3, 8, 1, ' ', 0)
+	fmt.Fprintf(tw, "Name\tOwner\tGroup\tMode\tLimit\tMax TTL")
+	if stats {
+		fmt.Fprintf(tw, "\tBytes Needed\tBytes Cached\tBytes Overlimit\tFiles Needed\tFiles Cached")
+	}
+
+	fmt.Fprintln(tw)
+	for _, p := range pools {
+		if len(names) > 0 && p.Name != names[0] {
+			continue
+		}
+
+		limit := "unlimited"
+		if p.Limit != hdfs.CachePoolUnlimited {
+			limit = strconv.FormatInt(p.Limit, 10)
+		}
+
+		maxTTL := "never"
+		if p.MaxTTL != hdfs.CachePoolNoMaxTTL {
+			maxTTL = p.MaxTTL.String()
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s",
+			p.Name, p.Owner, p.Group, p.Mode.String()[1:], limit, maxTTL)
+		if stats {
+			fmt.Fprintf(tw, "\t%d\t%d\t%d\t%d\t%d", p.Stats.BytesNeeded, p.Stats.BytesCached,
+				p.Stats.BytesOverlimit, p.Stats.FilesNeeded, p.Stats.FilesCached)
+		}
+
+		fmt.Fprintln(tw)
+	}
+
+	tw.Flush()
+}
@@ -2,17 +2,30 @@ package main
 
 import (
 	"bufio"
+	"context"
 	"errors"
 	"fmt"
 	"io"
 	"os"
+	"time"
 
 	"github.com/colinmarc/hdfs/v2"
 )
 
-const tailSearchSize int64 = 16384
+const (
+	tailSearchSize     int64 = 16384
+	tailFollowInterval       = time.Second
+)
+
+// cat copies the files to stdout, one after another. If offset or length are
+// set, only that range of each file is read; length is -1 to read to the end.
+func cat(paths []string, offset, length int64) {
+	if offset < 0 {
+		fatalWithUsage("Invalid value for --offset:", offset)
+	} else if length < -1 {
+		fatalWithUsage("Invalid value for --length:", length)
+	}
 
-func cat(paths []string) {
 	expanded, client, err := getClientAndExpandedPaths(paths)
 	if err != nil {
 		fatal(err)
@@ -27,7 +40,16 @@ func cat(paths []string) {
 			fatal(&os.PathError{"cat", p, errors.New("file is a directory")})
 		}
 
-		readers = append(readers, file)
+		n := length
+		if remaining := file.Stat().Size() - offset; n == -1 || n > remaining {
+			n = remaining
+		}
+
+		if offset == 0 && n == file.Stat().Size() {
+			readers = append(readers, file)
+		} else if n > 0 {
+			readers = append(readers, io.NewSectionReader(file, offset, n))
+		}
 	}
 
 	_, err = io.Copy(os.Stdout, io.MultiReader(readers...))
@@ -36,16 +58,26 @@ func cat(paths []string) {
 	}
 }
 
-func printSection(paths []string, numLines, numBytes int64, fromEnd bool) {
+// printSection prints the beginning or end of each file. For tail, the end is
+// the visible length, so that it includes what's been flushed to files that
+// are still being written to. If follow is set, it then keeps printing
+// whatever is appended to the file, like tail -f, until it's interrupted.
+func printSection(paths []string, numLines, numBytes int64, fromEnd, follow bool) {
 	if numLines != -1 && numBytes != -1 {
 		fatal("You can't specify both -n and -c.")
 	} else if numLines == -1 && numBytes == -1 {
 		numLines = 10
 	}
 
+	if follow && !fromEnd {
+		fatalWithUsage("Only tail can follow a file.")
+	}
+
 	expanded, client, err := getClientAndExpandedPaths(paths)
 	if err != nil {
 		fatal(err)
+	} else if follow && len(expanded) > 1 {
+		fatal("Only one file can be followed at a time.")
 	}
 
 	for _, p := range expanded {
@@ -55,8 +87,7 @@ func printSection(paths []string, numLines, numBytes int64, fromEnd bool) {
 				err = &os.PathError{"open", p, errors.New("file is a directory")}
 			}
 
-			fmt.Fprintln(os.Stderr, err)
-			status = 1
+			printError(err)
 			continue
 		}
 
@@ -64,59 +95,78 @@ func printSection(paths []string, numLines, numBytes int64, fromEnd bool) {
 			fmt.Fprintf(os.Stderr, "%s:\n", file.Name())
 		}
 
+		var length int64
+		if fromEnd {
+			length, err = file.VisibleLength()
+			if err != nil {
+				printError(err)
+				file.Close()
+				continue
+			}
+		}
+
 		if numLines != -1 {
 			if fromEnd {
-				tailLines(file, numLines)
+				tailLines(file, length, numLines)
 			} else {
 				headLines(file, numLines)
 			}
-		} else {
-			var offset int64
-			if fromEnd {
-				offset = file.Stat().Size() - numBytes
+		} else if fromEnd {
+			offset := length - numBytes
+			if offset < 0 {
+				offset = 0
+			}
+
+			_, err = file.Seek(offset, io.SeekStart)
+			if err != nil {
+				fatal(err)
 			}
 
-			reader := io.NewSectionReader(file, offset, numBytes)
-			io.Copy(os.Stdout, reader)
+			io.Copy(os.Stdout, file)
+		} else {
+			io.CopyN(os.Stdout, file, numBytes)
+		}
+
+		if follow {
+			_, err = io.Copy(os.Stdout, file.Follow(context.Background(), tailFollowInterval))
+			if err != nil {
+				fatal(err)
+			}
 		}
+
+		file.Close()
 	}
 }
 
+// headLines copies lines from the beginning of the file to stdout, stopping as
+// soon as it has seen enough, so that only the necessary prefix of the file is
+// read from the datanodes.
 func headLines(file *hdfs.FileReader, numLines int64) {
 	reader := bufio.NewReader(file)
+	writer := bufio.NewWriter(os.Stdout)
+	defer writer.Flush()
 
-	var newlines, offset int64
+	var newlines int64
 	for newlines < numLines {
-		b, err := reader.ReadByte()
+		line, err := reader.ReadSlice('\n')
+		if len(line) > 0 {
+			writer.Write(line)
+			if line[len(line)-1] == '\n' {
+				newlines++
+			}
+		}
+
 		if err == io.EOF {
-			offset = -1
 			break
-		} else if err != nil {
+		} else if err != nil && err != bufio.ErrBufferFull {
+			writer.Flush()
 			fatal(err)
 		}
-
-		if b == '\n' {
-			newlines++
-		}
-
-		offset++
-	}
-
-	_, err := file.Seek(0, 0)
-	if err != nil {
-		fatal(err)
-	}
-
-	if offset < 0 {
-		io.Copy(os.Stdout, file)
-	} else {
-		io.CopyN(os.Stdout, file, offset)
 	}
 }
 
-func tailLines(file *hdfs.FileReader, numLines int64) {
-	fileSize := file.Stat().Size()
-	searchPoint := file.Stat().Size() - tailSearchSize
+func tailLines(file *hdfs.FileReader, fileSize, numLines int64) {
+	searchPoint := fileSize - tailSearchSize
 	if searchPoint < 0 {
 		searchPoint = 0
 	}
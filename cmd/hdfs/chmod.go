@@ -1,7 +1,6 @@
 package main
 
 import (
-	"fmt"
 	"os"
 	"strconv"
 )
@@ -25,8 +24,7 @@ func chmod(args []string, recursive bool) {
 		err = client.Chmod(p, os.FileMode(mode))
 
 		if err != nil {
-			fmt.Fprintln(os.Stderr, err)
-			status = 1
+			printError(err)
 			return err
 		}
 		return nil
@@ -36,8 +34,7 @@ func chmod(args []string, recursive bool) {
 		if recursive {
 			err = client.Walk(p, visit)
 			if err != nil {
-				fmt.Fprintln(os.Stderr, err)
-				status = 1
+				printError(err)
 			}
 		} else {
 			info, err := client.Stat(p)
@@ -1,7 +1,6 @@
 package main
 
 import (
-	"fmt"
 	"os"
 	"strings"
 )
@@ -34,8 +33,7 @@ func chown(args []string, recursive bool) {
 		err = client.Chown(p, owner, group)
 
 		if err != nil {
-			fmt.Fprintln(os.Stderr, err)
-			status = 1
+			printError(err)
 			return err
 		}
 		return nil
@@ -45,8 +43,7 @@ func chown(args []string, recursive bool) {
 		if recursive {
 			err = client.Walk(p, visit)
 			if err != nil {
-				fmt.Fprintln(os.Stderr, err)
-				status = 1
+				printError(err)
 			}
 		} else {
 			info, err := client.Stat(p)
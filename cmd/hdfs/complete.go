@@ -12,19 +12,40 @@ var knownCommands = []string{
 	"ls",
 	"rm",
 	"mv",
+	"cp",
 	"mkdir",
 	"touch",
+	"touchz",
 	"chmod",
 	"chown",
 	"cat",
 	"head",
 	"tail",
 	"du",
+	"count",
 	"checksum",
 	"get",
 	"getmerge",
 	"put",
+	"distcp",
+	"concat",
+	"truncate",
+	"storagepolicies",
+	"ec",
+	"cacheadmin",
+	"getfacl",
+	"setfacl",
+	"getfattr",
+	"setfattr",
+	"snapshot",
+	"setquota",
+	"clrquota",
+	"setspacequota",
+	"clrspacequota",
 	"df",
+	"s3gateway",
+	"sftpgateway",
+	"serve",
 }
 
 func complete(args []string) {
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"sort"
+)
+
+func concat(args []string, sortSources bool) {
+	if len(args) < 2 {
+		printHelp()
+	}
+
+	if hasGlob(args[0]) {
+		fatal("The target must be a single path.")
+	}
+
+	expanded, client, err := getClientAndExpandedPaths(args)
+	if err != nil {
+		fatal(err)
+	}
+
+	target, sources := expanded[0], expanded[1:]
+	if sortSources {
+		sort.Strings(sources)
+	}
+
+	err = client.Concat(target, sources)
+	if err != nil {
+		fatal(err)
+	}
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/colinmarc/hdfs/v2"
+)
+
+// countOptions holds the flags passed to count.
+type countOptions struct {
+	quotas, humanReadable, header bool
+}
+
+func count(args []string, opts countOptions) {
+	if len(args) == 0 {
+		printHelp()
+	}
+
+	expanded, client, err := getClientAndExpandedPaths(args)
+	if err != nil {
+		fatal(err)
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 3, 8, 1, ' ', tabwriter.AlignRight)
+	defer tw.Flush()
+
+	if opts.header {
+		if opts.quotas {
+			fmt.Fprint(tw, "QUOTA\tREM_QUOTA\tSPACE_QUOTA\tREM_SPACE_QUOTA\t")
+		}
+
+		fmt.Fprint(tw, "DIR_COUNT\tFILE_COUNT\tCONTENT_SIZE\t PATHNAME\n")
+	}
+
+	for _, p := range expanded {
+		cs, err := client.GetContentSummary(p)
+		if err != nil {
+			printError(err)
+			continue
+		}
+
+		if opts.quotas {
+			fmt.Fprint(tw, formatQuotas(cs, opts.humanReadable))
+		}
+
+		fmt.Fprintf(tw, "%d\t%d\t%s\t %s\n",
+			cs.DirectoryCount(), cs.FileCount(), formatCountSize(cs.Size(), opts.humanReadable), p)
+	}
+}
+
+// formatQuotas returns the quota columns for count -q. Like 'hadoop fs
+// -count', it shows "none" and "inf" for quotas that aren't set.
+func formatQuotas(cs *hdfs.ContentSummary, humanReadable bool) string {
+	quota, remaining := "none", "inf"
+	if cs.NameQuota() >= 0 {
+		quota = strconv.Itoa(cs.NameQuota())
+		remaining = strconv.Itoa(cs.NameQuota() - cs.FileCount() - cs.DirectoryCount())
+	}
+
+	spaceQuota, spaceRemaining := "none", "inf"
+	if cs.SpaceQuota() >= 0 {
+		spaceQuota = formatCountSize(cs.SpaceQuota(), humanReadable)
+		spaceRemaining = formatCountSize(cs.SpaceQuota()-cs.SizeAfterReplication(), humanReadable)
+	}
+
+	return fmt.Sprintf("%s\t%s\t%s\t%s\t", quota, remaining, spaceQuota, spaceRemaining)
+}
+
+func formatCountSize(size int64, humanReadable bool) string {
+	if humanReadable && size >= 0 {
+		return formatBytes(uint64(size))
+	}
+
+	return strconv.FormatInt(size, 10)
+}
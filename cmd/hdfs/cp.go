@@ -0,0 +1,408 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/colinmarc/hdfs/v2"
+)
+
+const (
+	cpWorkers    = 4
+	cpBufferSize = 1024 * 1024
+	cpBuffers    = 4
+)
+
+// copier copies files and directory trees, potentially between two clusters.
+type copier struct {
+	src, dst *hdfs.Client
+	preserve preserveOptions
+	// verify specifies that the checksum of each copied file should be checked
+	// against the source.
+	verify bool
+
+	lock   sync.Mutex
+	failed bool
+}
+
+type cpJob struct {
+	source, dest string
+	info         *hdfs.FileInfo
+}
+
+func cp(paths []string, preserve preserveOptions) {
+	paths, nn, err := normalizePaths(paths)
+	if err != nil {
+		fatal(err)
+	}
+
+	if len(paths) < 2 {
+		fatalWithUsage("Both a source and destination are required.")
+	} else if hasGlob(paths[len(paths)-1]) {
+		fatal("The destination must be a single path.")
+	}
+
+	client, err := getClient(nn)
+	if err != nil {
+		fatal(err)
+	}
+
+	dest := paths[len(paths)-1]
+	sources, err := expandPaths(client, paths[:len(paths)-1])
+	if err != nil {
+		fatal(err)
+	}
+
+	destInfo, err := client.Stat(dest)
+	if err != nil && !os.IsNotExist(err) {
+		fatal(err)
+	}
+
+	intoDir := err == nil && destInfo.IsDir()
+	if !intoDir && len(sources) > 1 {
+		fatal("Can't copy multiple sources into the same place.")
+	}
+
+	c := &copier{src: client, dst: client, preserve: preserve}
+	for _, source := range sources {
+		fullDest := dest
+		if intoDir {
+			fullDest = path.Join(dest, path.Base(source))
+		}
+
+		c.copyTree(source, fullDest)
+	}
+}
+
+// cpPreserveOptions returns the attributes to preserve, given the -p and
+// --preserve flags. Since both sides of the copy are in HDFS, -p includes
+// extended attributes.
+func cpPreserveOptions(p bool, list string) preserveOptions {
+	var opts preserveOptions
+	if list != "" {
+		var err error
+		opts, err = parsePreserve(list)
+		if err != nil {
+			fatalWithUsage(err)
+		}
+	}
+
+	if p {
+		opts.mode = true
+		opts.ownership = true
+		opts.timestamps = true
+		opts.xattrs = true
+	}
+
+	return opts
+}
+
+// copyTree copies source to dest, recursively if source is a directory, and
+// returns false if anything couldn't be copied.
+func (c *copier) copyTree(source, dest string) bool {
+	c.failed = false
+	if c.src == c.dst && (dest == source || strings.HasPrefix(dest, source+"/")) {
+		c.error(&os.PathError{"cp", dest, os.ErrInvalid})
+		return false
+	}
+
+	jobs := make(chan cpJob)
+	var wg sync.WaitGroup
+	for i := 0; i < cpWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				if err := c.copyFile(job, nil); err != nil {
+					c.error(err)
+				}
+			}
+		}()
+	}
+
+	// Directories are created as they're walked, but their attributes are only
+	// copied once everything inside them has been, so that the mtimes aren't
+	// clobbered.
+	var dirs []cpJob
+	c.src.Walk(source, func(p string, fi os.FileInfo, err error) error {
+		if err != nil {
+			c.error(err)
+			return nil
+		}
+
+		job := cpJob{
+			source: p,
+			dest:   path.Join(dest, strings.TrimPrefix(p, source)),
+			info:   fi.(*hdfs.FileInfo),
+		}
+
+		if !fi.IsDir() {
+			jobs <- job
+			return nil
+		}
+
+		err = c.dst.Mkdir(job.dest, 0755|os.ModeDir)
+		if err != nil && !os.IsExist(err) {
+			c.error(err)
+			return nil
+		}
+
+		dirs = append(dirs, job)
+		return nil
+	})
+
+	close(jobs)
+	wg.Wait()
+
+	if c.preserve.any() {
+		for i := len(dirs) - 1; i >= 0; i-- {
+			c.preserveAttributes(dirs[i])
+		}
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return !c.failed
+}
+
+func (c *copier) error(err error) {
+	c.lock.Lock()
+	c.failed = true
+	c.lock.Unlock()
+
+	printError(err)
+}
+
+// copyFile copies a single file, with the same block size and replication as
+// the source. If the copy fails, the partial destination file is removed. The
+// limiter, if not nil, limits the rate the file is read at.
+func (c *copier) copyFile(job cpJob, limiter *rateLimiter) error {
+	reader, err := c.src.Open(job.source)
+	if err != nil {
+		return err
+	}
+
+	defer reader.Close()
+
+	_, err = c.dst.Stat(job.dest)
+	if err == nil {
+		return &os.PathError{"cp", job.dest, os.ErrExist}
+	}
+
+	writer, err := c.dst.CreateFile(job.dest, job.info.Replication(), job.info.BlockSize(), 0644)
+	if err != nil {
+		return err
+	}
+
+	_, err = pipelinedCopy(writer, limiter.reader(reader))
+	if err == nil {
+		err = writer.Close()
+	} else {
+		writer.Close()
+	}
+
+	if err == nil && c.verify {
+		err = c.verifyChecksum(job)
+	}
+
+	if err != nil {
+		c.dst.Remove(job.dest)
+		return err
+	}
+
+	if c.preserve.any() {
+		c.preserveAttributes(job)
+	}
+
+	return nil
+}
+
+// verifyChecksum checks that the copy has the same checksum as the source.
+// The regular checksum depends on the block size, which the copy shares, but
+// also on the checksum type configured on each cluster; if those differ, the
+// composite checksum, which doesn't, is compared instead.
+func (c *copier) verifyChecksum(job cpJob) error {
+	srcReader, err := c.src.Open(job.source)
+	if err != nil {
+		return err
+	}
+
+	defer srcReader.Close()
+
+	dstReader, err := c.dst.Open(job.dest)
+	if err != nil {
+		return err
+	}
+
+	defer dstReader.Close()
+
+	match, err := checksumsMatch(srcReader, dstReader)
+	if err != nil {
+		return err
+	} else if !match {
+		return &os.PathError{"cp", job.dest, errors.New("checksum doesn't match the source")}
+	}
+
+	return nil
+}
+
+// checksumsMatch returns true if two files in HDFS, potentially on different
+// clusters, have the same checksum, comparing the composite checksums if the
+// regular ones differ. The checksums of files in an encryption zone cover the
+// stored ciphertext, so those are read and compared instead.
+func checksumsMatch(src, dst *hdfs.FileReader) (bool, error) {
+	if src.Stat().(*hdfs.FileInfo).Encrypted() || dst.Stat().(*hdfs.FileInfo).Encrypted() {
+		return readersMatch(src, dst)
+	}
+
+	srcChecksum, err := src.Checksum()
+	if err != nil {
+		return false, err
+	}
+
+	dstChecksum, err := dst.Checksum()
+	if err != nil {
+		return false, err
+	} else if bytes.Equal(srcChecksum, dstChecksum) {
+		return true, nil
+	}
+
+	srcChecksum, err = src.CompositeChecksum()
+	if err == nil {
+		dstChecksum, err = dst.CompositeChecksum()
+	}
+
+	return err == nil && bytes.Equal(srcChecksum, dstChecksum), nil
+}
+
+// pipelinedCopy copies from src to dst like io.Copy, but reads ahead in a
+// separate goroutine, so that reading from one datanode and writing to
+// another happen at the same time. The read error is sent along with the data,
+// and pipelinedCopy doesn't return until the goroutine has stopped reading
+// from src.
+func pipelinedCopy(dst io.Writer, src io.Reader) (int64, error) {
+	free := make(chan []byte, cpBuffers)
+	for i := 0; i < cpBuffers; i++ {
+		free <- make([]byte, cpBufferSize)
+	}
+
+	type chunk struct {
+		buf []byte
+		err error
+	}
+
+	full := make(chan chunk, cpBuffers)
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer close(full)
+		for {
+			var buf []byte
+			select {
+			case buf = <-free:
+			case <-done:
+				return
+			}
+
+			// Both channels may be ready, once the copy has been given up on.
+			select {
+			case <-done:
+				return
+			default:
+			}
+
+			n, err := io.ReadFull(src, buf)
+			eof := err == io.EOF || err == io.ErrUnexpectedEOF
+			if eof {
+				err = nil
+			}
+
+			if n > 0 || err != nil {
+				full <- chunk{buf[:n], err}
+			}
+
+			if eof || err != nil {
+				return
+			}
+		}
+	}()
+
+	defer wg.Wait()
+	defer close(done)
+
+	var written int64
+	for c := range full {
+		if len(c.buf) > 0 {
+			n, err := dst.Write(c.buf)
+			written += int64(n)
+			if err != nil {
+				return written, err
+			}
+		}
+
+		if c.err != nil {
+			return written, c.err
+		}
+
+		free <- c.buf[:cap(c.buf)]
+	}
+
+	return written, nil
+}
+
+// preserveAttributes copies the attributes of the source to the destination.
+// Failures are reported, but don't count as failing to copy the file.
+func (c *copier) preserveAttributes(job cpJob) {
+	if c.preserve.mode {
+		err := c.dst.Chmod(job.dest, job.info.Mode().Perm())
+		if err != nil {
+			printError(err)
+		}
+	}
+
+	if c.preserve.ownership {
+		err := c.dst.Chown(job.dest, job.info.Owner(), job.info.OwnerGroup())
+		if err != nil {
+			c.preserve.ownershipError(err)
+		}
+	}
+
+	if c.preserve.xattrs {
+		xattrs, err := c.src.GetXAttrs(job.source)
+		if err != nil {
+			printError(err)
+		}
+
+		for key, value := range xattrs {
+			err = c.dst.SetXAttr(job.dest, key, value)
+			if err != nil {
+				printError(err)
+			}
+		}
+	}
+
+	if c.preserve.acls {
+		status, err := c.src.GetAclStatus(job.source)
+		if err == nil && len(status.Entries) > 0 {
+			err = c.dst.SetAcl(job.dest, status.Acl())
+		}
+
+		if err != nil {
+			printError(err)
+		}
+	}
+
+	if c.preserve.timestamps {
+		err := c.dst.Chtimes(job.dest, job.info.AccessTime(), job.info.ModTime())
+		if err != nil {
+			printError(err)
+		}
+	}
+}
@@ -0,0 +1,492 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/colinmarc/hdfs/v2"
+)
+
+const defaultDistcpWorkers = 16
+
+// distcpOptions holds the flags passed to distcp.
+type distcpOptions struct {
+	preserve preserveOptions
+	// update specifies that files which already exist at the destination are
+	// replaced, unless they have the same size and checksum as the source, in
+	// which case they're skipped. Otherwise, existing files are errors.
+	update  bool
+	workers int
+	// manifest is the name of a local file in which to record every file
+	// that's been copied, so that an interrupted copy can be resumed.
+	manifest string
+}
+
+// distcpCopier copies trees of files between the local filesystem and HDFS, or
+// between two clusters, with a pool of workers. Unlike cp, which copies each
+// tree with a few workers in turn, the workers are shared by all the sources,
+// so that there are always enough copies in flight to hide the latency of
+// creating and closing each file.
+type distcpCopier struct {
+	// src and dst are nil for the local filesystem.
+	src, dst *hdfs.Client
+	opts     distcpOptions
+	manifest *distcpManifest
+	copier   *copier
+
+	lock            sync.Mutex
+	copied, skipped int
+	bytes           int64
+}
+
+type distcpJob struct {
+	source, dest string
+	info         os.FileInfo
+}
+
+func distcp(paths []string, opts distcpOptions) {
+	if len(paths) < 2 {
+		fatalWithUsage("Both a source and destination are required.")
+	} else if opts.workers <= 0 {
+		fatalWithUsage("Invalid value for --workers:", opts.workers)
+	}
+
+	d := &distcpCopier{opts: opts}
+	sources, srcClient := distcpPaths(paths[:len(paths)-1])
+	dests, dstClient := distcpPaths(paths[len(paths)-1:])
+	if srcClient == nil && dstClient == nil {
+		fatal("At least one of the source and destination must be in HDFS.")
+	}
+
+	d.src, d.dst = srcClient, dstClient
+	if d.src != nil && d.dst != nil {
+		d.copier = &copier{src: d.src, dst: d.dst, preserve: opts.preserve}
+	}
+
+	dest := dests[0]
+	if len(dests) > 1 {
+		fatal("The destination must be a single path.")
+	}
+
+	destInfo, err := d.statDest(dest)
+	if err != nil && !os.IsNotExist(err) {
+		fatal(err)
+	}
+
+	intoDir := err == nil && destInfo.IsDir()
+	if !intoDir && len(sources) > 1 {
+		fatal("Can't copy multiple sources into the same place.")
+	}
+
+	// The manifest only applies to the destination it was written for: the
+	// same files with a different destination have to be copied again.
+	destination := dest + " (local)"
+	if d.dst != nil {
+		destination = dest + " on " + clientCluster(d.dst)
+	}
+
+	d.manifest, err = openDistcpManifest(opts.manifest, destination)
+	if err != nil {
+		fatal(err)
+	}
+
+	defer d.manifest.Close()
+
+	jobs := make(chan distcpJob)
+	var wg sync.WaitGroup
+	for i := 0; i < opts.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			limiter := workerLimiter()
+			for job := range jobs {
+				if err := d.copyFile(job, limiter); err != nil {
+					printError(err)
+				}
+			}
+		}()
+	}
+
+	var dirs []distcpJob
+	for _, source := range sources {
+		fullDest := dest
+		if intoDir {
+			fullDest = d.joinDest(dest, path.Base(filepath.ToSlash(source)))
+		}
+
+		// getClient returns the same client for the same cluster, however
+		// it's named, so this catches copying a tree into itself even if the
+		// namenode is only given on one side.
+		if d.src == d.dst && (fullDest == source || strings.HasPrefix(fullDest, source+"/")) {
+			printError(&os.PathError{"distcp", fullDest, os.ErrInvalid})
+			continue
+		}
+
+		dirs = append(dirs, d.walk(source, fullDest, jobs)...)
+	}
+
+	close(jobs)
+	wg.Wait()
+
+	// Like cp, the attributes of directories are only copied once everything
+	// inside them has been, so that the mtimes aren't clobbered.
+	if opts.preserve.any() {
+		for i := len(dirs) - 1; i >= 0; i-- {
+			d.preserveAttributes(dirs[i])
+		}
+	}
+
+	fmt.Printf("Copied %d files (%s), skipped %d up-to-date files.\n",
+		d.copied, formatBytes(uint64(d.bytes)), d.skipped)
+}
+
+// distcpPaths parses the paths on one side of a distcp. Paths starting with
+// file:// are local; anything else is in HDFS, and the client for it is
+// returned. Globs are expanded either way.
+func distcpPaths(paths []string) ([]string, *hdfs.Client) {
+	var local, remote []string
+	for _, p := range paths {
+		if strings.HasPrefix(p, "file://") {
+			local = append(local, strings.TrimPrefix(p, "file://"))
+		} else {
+			remote = append(remote, p)
+		}
+	}
+
+	if len(local) > 0 && len(remote) > 0 {
+		fatal("The sources must either all be local, or all be in HDFS.")
+	}
+
+	var expanded []string
+	if len(local) > 0 {
+		for _, p := range local {
+			p, err := filepath.Abs(p)
+			if err != nil {
+				fatal(err)
+			}
+
+			matches, err := filepath.Glob(p)
+			if err != nil {
+				fatal(err)
+			} else if len(matches) == 0 && hasGlob(p) {
+				fatal(&os.PathError{"stat", p, os.ErrNotExist})
+			} else if len(matches) == 0 {
+				matches = []string{p}
+			}
+
+			expanded = append(expanded, matches...)
+		}
+
+		return expanded, nil
+	}
+
+	remote, nn, err := normalizePaths(remote)
+	if err != nil {
+		fatal(err)
+	}
+
+	client, err := getClient(nn)
+	if err != nil {
+		fatal(err)
+	}
+
+	expanded, err = expandPaths(client, remote)
+	if err != nil {
+		fatal(err)
+	}
+
+	return expanded, client
+}
+
+// walk walks the tree at source, creating each directory at the destination
+// and sending each file to the workers. It returns the directories, so that
+// their attributes can be copied at the end.
+func (d *distcpCopier) walk(source, dest string, jobs chan<- distcpJob) []distcpJob {
+	var dirs []distcpJob
+	walkFn := func(p string, fi os.FileInfo, err error) error {
+		if err != nil {
+			printError(err)
+			return nil
+		}
+
+		var rel string
+		if d.src == nil {
+			rel, err = filepath.Rel(source, p)
+			if err != nil {
+				printError(err)
+				return nil
+			}
+
+			rel = filepath.ToSlash(rel)
+		} else {
+			rel = strings.TrimPrefix(p, source)
+		}
+
+		job := distcpJob{source: p, dest: d.joinDest(dest, rel), info: fi}
+		if !fi.IsDir() {
+			if d.manifest.has(p) {
+				d.lock.Lock()
+				d.skipped++
+				d.lock.Unlock()
+			} else {
+				jobs <- job
+			}
+
+			return nil
+		}
+
+		err = d.mkdirDest(job.dest)
+		if err != nil && !os.IsExist(err) {
+			printError(err)
+			return filepath.SkipDir
+		}
+
+		dirs = append(dirs, job)
+		return nil
+	}
+
+	if d.src == nil {
+		filepath.Walk(source, walkFn)
+	} else {
+		d.src.Walk(source, walkFn)
+	}
+
+	return dirs
+}
+
+// copyFile copies a single file. If it already exists at the destination, it's
+// skipped if it's up to date and --update was passed, and an error otherwise.
+func (d *distcpCopier) copyFile(job distcpJob, limiter *rateLimiter) error {
+	destInfo, err := d.statDest(job.dest)
+	if err == nil {
+		if destInfo.IsDir() || !d.opts.update {
+			return &os.PathError{"distcp", job.dest, os.ErrExist}
+		}
+
+		upToDate, err := d.upToDate(job, destInfo)
+		if err != nil {
+			return err
+		} else if upToDate {
+			d.lock.Lock()
+			d.skipped++
+			d.lock.Unlock()
+			return d.manifest.add(job.source)
+		}
+
+		err = d.removeDest(job.dest)
+		if err != nil {
+			return err
+		}
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	switch {
+	case d.src != nil && d.dst != nil:
+		err = d.copier.copyFile(cpJob{source: job.source, dest: job.dest, info: job.info.(*hdfs.FileInfo)}, limiter)
+	case d.src == nil:
+		err = putFile(d.dst, job.source, job.dest, putOptions{}, limiter)
+		if err != nil {
+			d.dst.Remove(job.dest)
+		}
+	default:
+		err = copyToLocal(d.src, job.source, job.dest, limiter, false)
+		if err != nil {
+			os.Remove(job.dest)
+		}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if d.copier == nil && d.opts.preserve.any() {
+		d.preserveAttributes(job)
+	}
+
+	d.lock.Lock()
+	d.copied++
+	d.bytes += job.info.Size()
+	d.lock.Unlock()
+	return d.manifest.add(job.source)
+}
+
+// upToDate returns true if the existing destination file has the same size
+// and checksum as the source.
+func (d *distcpCopier) upToDate(job distcpJob, destInfo os.FileInfo) (bool, error) {
+	if destInfo.Size() != job.info.Size() {
+		return false, nil
+	}
+
+	if d.src != nil && d.dst != nil {
+		src, err := d.src.Open(job.source)
+		if err != nil {
+			return false, err
+		}
+
+		defer src.Close()
+
+		dst, err := d.dst.Open(job.dest)
+		if err != nil {
+			return false, err
+		}
+
+		defer dst.Close()
+		return checksumsMatch(src, dst)
+	}
+
+	client, remoteName, localName := d.dst, job.dest, job.source
+	if d.dst == nil {
+		client, remoteName, localName = d.src, job.source, job.dest
+	}
+
+	remote, err := client.Open(remoteName)
+	if err != nil {
+		return false, err
+	}
+
+	defer remote.Close()
+
+	local, err := os.Open(localName)
+	if err != nil {
+		return false, err
+	}
+
+	defer local.Close()
+	return localMatches(remote, local, job.info.Size())
+}
+
+func (d *distcpCopier) preserveAttributes(job distcpJob) {
+	switch {
+	case d.copier != nil:
+		d.copier.preserveAttributes(cpJob{source: job.source, dest: job.dest, info: job.info.(*hdfs.FileInfo)})
+	case d.src == nil:
+		d.opts.preserve.preserveRemote(d.dst, job.dest, job.info)
+	default:
+		d.opts.preserve.preserveLocal(job.dest, job.info.(*hdfs.FileInfo))
+	}
+}
+
+func (d *distcpCopier) joinDest(dest, rel string) string {
+	if d.dst == nil {
+		return filepath.Join(dest, filepath.FromSlash(rel))
+	}
+
+	return path.Join(dest, rel)
+}
+
+func (d *distcpCopier) statDest(name string) (os.FileInfo, error) {
+	if d.dst == nil {
+		return os.Stat(name)
+	}
+
+	return d.dst.Stat(name)
+}
+
+func (d *distcpCopier) mkdirDest(name string) error {
+	if d.dst == nil {
+		return os.Mkdir(name, 0755)
+	}
+
+	return d.dst.Mkdir(name, 0755|os.ModeDir)
+}
+
+func (d *distcpCopier) removeDest(name string) error {
+	if d.dst == nil {
+		return os.Remove(name)
+	}
+
+	return d.dst.Remove(name)
+}
+
+// distcpManifest records the source of every file that's been copied, or found
+// to be up to date, one per line. If distcp is interrupted and run again with
+// the same arguments and manifest, those files are skipped without even being
+// checked, which matters when there are millions of them. The first line
+// records the destination, and the manifest can't be used with any other. A
+// nil *distcpManifest records nothing.
+type distcpManifest struct {
+	lock sync.Mutex
+	file *os.File
+	done map[string]bool
+}
+
+func openDistcpManifest(name, destination string) (*distcpManifest, error) {
+	if name == "" {
+		return nil, nil
+	}
+
+	f, err := os.OpenFile(name, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	header := distcpManifestHeader + destination
+	m := &distcpManifest{file: f, done: make(map[string]bool)}
+	scanner := bufio.NewScanner(f)
+	if scanner.Scan() {
+		if scanner.Text() != header {
+			f.Close()
+			return nil, fmt.Errorf("The manifest %s is for a different destination: %s",
+				name, strings.TrimPrefix(scanner.Text(), distcpManifestHeader))
+		}
+	} else if scanner.Err() == nil {
+		_, err = fmt.Fprintln(f, header)
+		if err != nil {
+			f.Close()
+			return nil, err
+		}
+	}
+
+	for scanner.Scan() {
+		m.done[scanner.Text()] = true
+	}
+
+	if err := scanner.Err(); err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// distcpManifestHeader starts the first line of a manifest, which is followed
+// by the destination.
+const distcpManifestHeader = "# destination: "
+
+func (m *distcpManifest) has(source string) bool {
+	if m == nil {
+		return false
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.done[source]
+}
+
+func (m *distcpManifest) add(source string) error {
+	if m == nil {
+		return nil
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.done[source] = true
+	_, err := fmt.Fprintln(m.file, source)
+	return err
+}
+
+func (m *distcpManifest) Close() error {
+	if m == nil {
+		return nil
+	}
+
+	return m.file.Close()
+}
@@ -1,20 +1,52 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"os"
 	"path"
+	"path/filepath"
 	"text/tabwriter"
 
 	"github.com/colinmarc/hdfs/v2"
 )
 
-func du(args []string, summarize, humanReadable bool) {
+// duWalkConcurrency is the number of directories listed at once when adding
+// up the size of a tree.
+const duWalkConcurrency = 8
+
+// duOptions holds the flags passed to du.
+type duOptions struct {
+	summarize, humanReadable bool
+	// maxDepth is the deepest level at which sizes are printed, where the
+	// arguments themselves are at depth 0. If negative, there's no limit.
+	maxDepth int
+	// excludes is a list of glob patterns. Files and directories matching any
+	// of them are skipped entirely, and don't count towards the size of their
+	// parents.
+	excludes []string
+}
+
+func du(args []string, opts duOptions) {
 	if len(args) == 0 {
 		printHelp()
 	}
 
+	if opts.summarize {
+		if opts.maxDepth > 0 {
+			fatalWithUsage("Can't use -s with --max-depth greater than 0.")
+		}
+
+		opts.maxDepth = 0
+	}
+
+	for _, pattern := range opts.excludes {
+		if _, err := path.Match(pattern, ""); err != nil {
+			fatalWithUsage("Invalid pattern for --exclude:", pattern)
+		}
+	}
+
 	expanded, client, err := getClientAndExpandedPaths(args)
 	if err != nil {
 		fatal(err)
@@ -24,39 +56,51 @@ func du(args []string, summarize, humanReadable bool) {
 	defer tw.Flush()
 
 	for _, p := range expanded {
+		if opts.excluded(p) {
+			continue
+		}
+
 		info, err := client.Stat(p)
 		if err != nil {
-			fmt.Fprintln(os.Stderr, err)
-			status = 1
+			printError(err)
 			continue
 		}
 
 		var size int64
 		if info.IsDir() {
-			if summarize {
-				cs, err := client.GetContentSummary(p)
-				if err != nil {
-					fmt.Fprintln(os.Stderr, err)
-					status = 1
-					continue
-				}
-
-				size = cs.Size()
-			} else {
-				size = duDir(client, tw, p, humanReadable)
-			}
+			size = duDir(client, tw, p, 0, opts)
 		} else {
 			size = info.Size()
 		}
 
-		printSize(tw, size, p, humanReadable)
+		printSize(tw, size, p, opts.humanReadable)
 	}
 }
 
-func duDir(client *hdfs.Client, tw *tabwriter.Writer, dir string, humanReadable bool) int64 {
+// duDir returns the total size of dir, which is at the given depth, and
+// prints the size of anything inside it that isn't deeper than the max depth.
+func duDir(client *hdfs.Client, tw *tabwriter.Writer, dir string, depth int, opts duOptions) int64 {
+	// If nothing inside the directory will be printed or excluded, the
+	// namenode can add up the size by itself. Otherwise, if nothing inside it
+	// will be printed, the order doesn't matter, and the tree can be walked in
+	// parallel.
+	if depth == opts.maxDepth {
+		if len(opts.excludes) == 0 {
+			cs, err := client.GetContentSummary(dir)
+			if err != nil {
+				printError(err)
+				return 0
+			}
+
+			return cs.Size()
+		}
+
+		return duWalk(client, dir, opts)
+	}
+
 	dirReader, err := client.Open(dir)
 	if err != nil {
-		fmt.Fprintln(os.Stderr, err)
+		printError(err)
 		return 0
 	}
 
@@ -64,26 +108,27 @@ func duDir(client *hdfs.Client, tw *tabwriter.Writer, dir string, humanReadable
 	var dirSize int64
 	for ; err != io.EOF; partial, err = dirReader.Readdir(100) {
 		if err != nil {
-			fmt.Fprintln(os.Stderr, err)
+			printError(err)
 			return dirSize
 		}
 
 		for _, child := range partial {
 			childPath := path.Join(dir, child.Name())
-			info, err := client.Stat(childPath)
-			if err != nil {
-				fmt.Fprintln(os.Stderr, err)
-				return 0
+			if opts.excluded(childPath) {
+				continue
 			}
 
 			var size int64
-			if info.IsDir() {
-				size = duDir(client, tw, childPath, humanReadable)
+			if child.IsDir() {
+				size = duDir(client, tw, childPath, depth+1, opts)
 			} else {
-				size = info.Size()
+				size = child.Size()
+			}
+
+			if opts.maxDepth < 0 || depth < opts.maxDepth {
+				printSize(tw, size, childPath, opts.humanReadable)
 			}
 
-			printSize(tw, size, childPath, humanReadable)
 			dirSize += size
 		}
 	}
@@ -91,6 +136,49 @@ func duDir(client *hdfs.Client, tw *tabwriter.Writer, dir string, humanReadable
 	return dirSize
 }
 
+// duWalk returns the total size of dir, leaving out anything excluded.
+func duWalk(client *hdfs.Client, dir string, opts duOptions) int64 {
+	var size int64
+	walkOpts := hdfs.WalkOptions{Concurrency: duWalkConcurrency}
+	client.WalkContext(context.Background(), dir, walkOpts, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			printError(err)
+			return nil
+		}
+
+		if p != dir && opts.excluded(p) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if !info.IsDir() {
+			size += info.Size()
+		}
+
+		return nil
+	})
+
+	return size
+}
+
+// excluded returns true if either the full path or the name of p match any of
+// the exclude patterns.
+func (opts duOptions) excluded(p string) bool {
+	name := path.Base(p)
+	for _, pattern := range opts.excludes {
+		if match, _ := path.Match(pattern, p); match {
+			return true
+		} else if match, _ := path.Match(pattern, name); match {
+			return true
+		}
+	}
+
+	return false
+}
+
 func printSize(tw *tabwriter.Writer, size int64, name string, humanReadable bool) {
 	if humanReadable {
 		formattedSize := formatBytes(uint64(size))
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+)
+
+// ec takes its flags in the same form as 'hdfs ec', for example:
+//
+//	hdfs ec -setPolicy -path /archive -policy RS-6-3-1024k
+func ec(args []string) {
+	if len(args) == 0 {
+		printHelp()
+	}
+
+	subcommand := args[0]
+	var p, policy string
+	for i := 1; i < len(args); i += 2 {
+		if i+1 >= len(args) {
+			fatalWithUsage("Missing value for", args[i])
+		}
+
+		switch args[i] {
+		case "-path":
+			p = args[i+1]
+		case "-policy":
+			policy = args[i+1]
+		default:
+			fatalWithUsage("Unknown flag:", args[i])
+		}
+	}
+
+	if subcommand == "-listPolicies" {
+		listErasureCodingPolicies()
+		return
+	}
+
+	if p == "" {
+		fatalWithUsage("Missing -path")
+	}
+
+	paths, nn, err := normalizePaths([]string{p})
+	if err != nil {
+		fatal(err)
+	}
+
+	client, err := getClient(nn)
+	if err != nil {
+		fatal(err)
+	}
+
+	p = paths[0]
+	switch subcommand {
+	case "-getPolicy":
+		current, err := client.GetErasureCodingPolicy(p)
+		if err != nil {
+			fatal(err)
+		} else if current == nil {
+			fmt.Println("The erasure coding policy of", p, "is unspecified")
+		} else {
+			fmt.Println(current.Name)
+		}
+	case "-setPolicy":
+		err = client.SetErasureCodingPolicy(p, policy)
+	case "-unsetPolicy":
+		err = client.UnsetErasureCodingPolicy(p)
+	default:
+		fatalWithUsage("Unknown ec command:", subcommand)
+	}
+
+	if err != nil {
+		fatal(err)
+	}
+}
+
+func listErasureCodingPolicies() {
+	client, err := getClient("")
+	if err != nil {
+		fatal(err)
+	}
+
+	policies, err := client.GetErasureCodingPolicies()
+	if err != nil {
+		fatal(err)
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 3, 8, 1, ' ', 0)
+	fmt.Fprintf(tw, "ID\tName\tCodec\tData Units\tParity Units\tCell Size\tState\n")
+	for _, p := range policies {
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%d\t%d\t%d\t%s\n",
+			p.ID, p.Name, p.Codec, p.DataUnits, p.ParityUnits, p.CellSize, p.State)
+	}
+
+	tw.Flush()
+}
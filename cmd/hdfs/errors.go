@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"syscall"
+)
+
+// These match the exit codes used by 'hadoop fs': 1 if a command fails, and
+// -1 (which the shell sees as 255) if it was called incorrectly. Hadoop
+// doesn't distinguish between different kinds of failure, such as a missing
+// file or a permission error, so neither do we.
+const (
+	exitError = 1
+	exitUsage = 255
+)
+
+// command is the name of the command being run, which prefixes every error
+// message.
+var command string
+
+// formatError formats an error the way 'hadoop fs' does, so that scripts that
+// match on its output keep working. For example:
+//
+//	ls: `/foo': No such file or directory
+func formatError(err error) string {
+	prefix := "hdfs"
+	if command != "" {
+		prefix = command
+	}
+
+	if pathErr, ok := err.(*os.PathError); ok {
+		return fmt.Sprintf("%s: `%s': %s", prefix, pathErr.Path, describeError(pathErr.Err))
+	}
+
+	return fmt.Sprintf("%s: %s", prefix, err)
+}
+
+func describeError(err error) string {
+	switch {
+	case os.IsNotExist(err):
+		return "No such file or directory"
+	case os.IsExist(err):
+		return "File exists"
+	case os.IsPermission(err):
+		return "Permission denied"
+	case err == syscall.ENOTEMPTY:
+		return "Directory is not empty"
+	}
+
+	switch err.Error() {
+	case "file is a directory", "is a directory":
+		return "Is a directory"
+	}
+
+	return err.Error()
+}
+
+var printErrorLock sync.Mutex
+
+// printError prints an error without exiting, and makes sure the command
+// exits with a non-zero status when it's done. It's safe to call from multiple
+// goroutines.
+func printError(err error) {
+	printErrorLock.Lock()
+	defer printErrorLock.Unlock()
+
+	fmt.Fprintln(os.Stderr, formatError(err))
+	status = exitError
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pborman/getopt"
+)
+
+// These are set by the global flags, which come before the command.
+var (
+	timeout       time.Duration
+	retries       int
+	retryInterval = time.Second
+
+	globalFlagsSet = make(map[string]bool)
+)
+
+// parseGlobalFlags consumes any global flags at the start of args, and returns
+// the rest, starting with the command. Both "--flag value" and "--flag=value"
+// are accepted.
+func parseGlobalFlags(args []string) []string {
+	for len(args) > 0 && strings.HasPrefix(args[0], "--") {
+		name, value := args[0], ""
+		hasValue := false
+		if i := strings.Index(name, "="); i >= 0 {
+			name, value = name[:i], name[i+1:]
+			hasValue = true
+		}
+
+		switch name {
+		case "--cluster", "--timeout", "--retries", "--retry-interval":
+		default:
+			// Not a global flag, so it's the command (for example, --help).
+			return args
+		}
+
+		if !hasValue {
+			if len(args) < 2 {
+				fatalWithUsage("Missing value for", name)
+			}
+
+			value = args[1]
+			args = args[1:]
+		}
+
+		args = args[1:]
+
+		var err error
+		switch name {
+		case "--cluster":
+			cluster = value
+		case "--timeout":
+			timeout, err = time.ParseDuration(value)
+		case "--retries":
+			retries, err = strconv.Atoi(value)
+		case "--retry-interval":
+			retryInterval, err = time.ParseDuration(value)
+		}
+
+		if err != nil {
+			fatalWithUsage("Invalid value for", name+":", value)
+		}
+
+		globalFlagsSet[name] = true
+	}
+
+	return args
+}
+
+// stringList is a command option that can be passed multiple times, and
+// collects every value. Unlike getopt's List, it doesn't split values on
+// commas, so that they can contain globs like {a,b}.
+type stringList []string
+
+func (l *stringList) Set(value string, opt getopt.Option) error {
+	*l = append(*l, value)
+	return nil
+}
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+// withIdleTimeout wraps dial so that every read or write on the connections
+// it returns fails if it doesn't complete within the timeout.
+func withIdleTimeout(dial func(ctx context.Context, network, addr string) (net.Conn, error), timeout time.Duration) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		conn, err := dial(ctx, network, addr)
+		if err != nil {
+			return nil, err
+		}
+
+		return &idleTimeoutConn{Conn: conn, timeout: timeout}, nil
+	}
+}
+
+type idleTimeoutConn struct {
+	net.Conn
+	timeout time.Duration
+}
+
+func (c *idleTimeoutConn) Read(b []byte) (int, error) {
+	c.Conn.SetReadDeadline(time.Now().Add(c.timeout))
+	return c.Conn.Read(b)
+}
+
+func (c *idleTimeoutConn) Write(b []byte) (int, error) {
+	c.Conn.SetWriteDeadline(time.Now().Add(c.timeout))
+	return c.Conn.Write(b)
+}
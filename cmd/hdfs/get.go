@@ -1,15 +1,36 @@
 package main
 
 import (
+	"bufio"
 	"bytes"
+	"crypto/md5"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"hash/crc32"
 	"io"
+	"math"
 	"os"
 	"path"
 	"path/filepath"
 	"strings"
+
+	"github.com/colinmarc/hdfs/v2"
 )
 
-func get(args []string) {
+// getOptions holds the flags passed to get.
+type getOptions struct {
+	resume, preserve bool
+	// verify specifies that each downloaded file should be checked against
+	// the checksum of the source.
+	verify bool
+	// crc specifies that a Hadoop-compatible .crc file should be written next
+	// to each downloaded file.
+	crc bool
+}
+
+func get(args []string, opts getOptions) {
 	if len(args) == 0 || len(args) > 2 {
 		printHelp()
 	}
@@ -38,21 +59,48 @@ func get(args []string) {
 		fatal(err)
 	}
 
+	// The attributes of directories are set once everything inside them has
+	// been downloaded, so that the mtimes aren't clobbered.
+	var dirs []string
+	var dirInfos []*hdfs.FileInfo
+
+	limiter := workerLimiter()
 	err = client.Walk(source, func(p string, fi os.FileInfo, err error) error {
 		fullDest := filepath.Join(dest, strings.TrimPrefix(p, source))
 
 		if fi.IsDir() {
 			err = os.Mkdir(fullDest, 0755)
-			if err != nil {
+			if err != nil && !(opts.resume && os.IsExist(err)) {
 				fatal(err)
 			}
+
+			if opts.preserve {
+				dirs = append(dirs, fullDest)
+				dirInfos = append(dirInfos, fi.(*hdfs.FileInfo))
+			}
 		} else {
-			err = client.CopyToLocal(p, fullDest)
+			if opts.resume {
+				err = resumeGet(client, p, fi, fullDest, limiter)
+				if err == nil && opts.crc {
+					err = writeCRCFile(fullDest)
+				}
+			} else {
+				err = copyToLocal(client, p, fullDest, limiter, opts.crc)
+			}
+
+			if err == nil && opts.verify {
+				err = verifyLocal(client, p, fullDest)
+			}
+
 			if pathErr, ok := err.(*os.PathError); ok {
 				fatal(pathErr)
 			} else if err != nil {
 				fatal(err)
 			}
+
+			if opts.preserve {
+				defaultPreserve.preserveLocal(fullDest, fi.(*hdfs.FileInfo))
+			}
 		}
 		return nil
 	})
@@ -60,6 +108,364 @@ func get(args []string) {
 	if err != nil {
 		fatal(err)
 	}
+
+	for i := len(dirs) - 1; i >= 0; i-- {
+		defaultPreserve.preserveLocal(dirs[i], dirInfos[i])
+	}
+}
+
+// copyToLocal is like Client.CopyToLocal, but applies the bandwidth limit. If
+// crc is set, the .crc file is computed as the file is downloaded.
+func copyToLocal(client *hdfs.Client, source, dest string, limiter *rateLimiter, crc bool) error {
+	remote, err := client.Open(source)
+	if err != nil {
+		return err
+	}
+	defer remote.Close()
+
+	local, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+	defer local.Close()
+
+	var w io.Writer = local
+	var crcFile *crcFileWriter
+	if crc {
+		crcFile, err = createCRCFile(dest)
+		if err != nil {
+			return err
+		}
+		defer crcFile.file.Close()
+
+		w = io.MultiWriter(local, crcFile)
+	}
+
+	_, err = io.Copy(w, limiter.reader(remote))
+	if err != nil {
+		return err
+	}
+
+	if crcFile != nil {
+		err = crcFile.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	return local.Close()
+}
+
+// crcFileWriter writes a .crc file for a local file.
+type crcFileWriter struct {
+	*hdfs.CRCFileWriter
+	file *os.File
+	buf  *bufio.Writer
+}
+
+func createCRCFile(name string) (*crcFileWriter, error) {
+	f, err := os.Create(hdfs.CRCFileName(name))
+	if err != nil {
+		return nil, err
+	}
+
+	buf := bufio.NewWriter(f)
+	return &crcFileWriter{
+		CRCFileWriter: hdfs.NewCRCFileWriter(buf, 0),
+		file:          f,
+		buf:           buf,
+	}, nil
+}
+
+// Close finishes writing the .crc file, and closes it.
+func (cw *crcFileWriter) Close() error {
+	err := cw.CRCFileWriter.Close()
+	if err == nil {
+		err = cw.buf.Flush()
+	}
+
+	if err != nil {
+		cw.file.Close()
+		return err
+	}
+
+	return cw.file.Close()
+}
+
+// writeCRCFile writes the .crc file for a local file that's already been
+// downloaded.
+func writeCRCFile(name string) error {
+	local, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer local.Close()
+
+	crcFile, err := createCRCFile(name)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(crcFile, local)
+	if err != nil {
+		crcFile.file.Close()
+		return err
+	}
+
+	return crcFile.Close()
+}
+
+// resumeGet copies the file at source to dest, picking up where a previous
+// download left off. A file with the same size and modification time as the
+// source is assumed to be complete. Otherwise, any whole blocks at the start
+// of the local file are checked against the block checksums of the source, and
+// the download resumes from the end of the last one that matches. Encrypted
+// files are downloaded again from the start.
+func resumeGet(client *hdfs.Client, source string, info os.FileInfo, dest string, limiter *rateLimiter) error {
+	local, err := os.OpenFile(dest, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+	defer local.Close()
+
+	localInfo, err := local.Stat()
+	if err != nil {
+		return err
+	}
+
+	if localInfo.Size() == info.Size() && localInfo.ModTime().Equal(info.ModTime()) {
+		return nil
+	}
+
+	remote, err := client.Open(source)
+	if err != nil {
+		return err
+	}
+	defer remote.Close()
+
+	// The checksums of an encrypted file cover the stored ciphertext, so they
+	// can't be used to check what's already been downloaded, and it's
+	// downloaded again from the start.
+	var offset int64
+	if localInfo.Size() > 0 && !remote.Stat().(*hdfs.FileInfo).Encrypted() {
+		checksums, err := remote.BlockChecksums()
+		if err != nil {
+			return err
+		}
+
+		offset, err = verifiedPrefix(checksums, local, localInfo.Size())
+		if err != nil {
+			return err
+		}
+	}
+
+	err = local.Truncate(offset)
+	if err != nil {
+		return err
+	}
+
+	_, err = local.Seek(offset, 0)
+	if err != nil {
+		return err
+	}
+
+	_, err = remote.Seek(offset, 0)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(local, limiter.reader(remote))
+	if err != nil {
+		return err
+	}
+
+	err = local.Close()
+	if err != nil {
+		return err
+	}
+
+	// Set the modification time to match, so that we can tell the file is
+	// complete next time.
+	return os.Chtimes(dest, info.ModTime(), info.ModTime())
+}
+
+// verifyLocal checks a downloaded file against the checksum of the source,
+// and removes it if they don't match. The block checksums of the source are
+// compared first; if those don't match (for example, because the file is
+// erasure-coded, which changes how they're computed), the composite CRC is
+// compared instead, if the namenode supports it. Encrypted files are read back
+// and compared byte by byte.
+func verifyLocal(client *hdfs.Client, source, dest string) error {
+	remote, err := client.Open(source)
+	if err != nil {
+		return err
+	}
+	defer remote.Close()
+
+	local, err := os.Open(dest)
+	if err != nil {
+		return err
+	}
+	defer local.Close()
+
+	localInfo, err := local.Stat()
+	if err != nil {
+		return err
+	}
+
+	match, err := localMatches(remote, local, localInfo.Size())
+	if err != nil {
+		return err
+	} else if !match {
+		os.Remove(dest)
+		return &os.PathError{"get", dest, errors.New("checksum doesn't match the source")}
+	}
+
+	return nil
+}
+
+// localMatches returns true if the local file, of the given size, has the
+// same contents as remote, as far as their checksums can tell.
+func localMatches(remote *hdfs.FileReader, local *os.File, size int64) (bool, error) {
+	if size != remote.Stat().Size() {
+		return false, nil
+	} else if size == 0 {
+		return true, nil
+	}
+
+	// In an encryption zone, the checksums cover the stored ciphertext, so the
+	// file is read back instead, through the decrypting reader.
+	if remote.Stat().(*hdfs.FileInfo).Encrypted() {
+		return readersMatch(remote, io.NewSectionReader(local, 0, size))
+	}
+
+	checksums, err := remote.BlockChecksums()
+	if err != nil {
+		return false, err
+	} else if len(checksums) == 0 {
+		return false, nil
+	}
+
+	prefix, err := verifiedPrefix(checksums, local, size)
+	if err != nil {
+		return false, err
+	} else if prefix == size {
+		return true, nil
+	}
+
+	remoteCRC, err := remote.CompositeChecksum()
+	if err == nil {
+		var localCRC []byte
+		localCRC, err = localCompositeChecksum(local, checksums[0].ChecksumType)
+		if err == nil && bytes.Equal(localCRC, remoteCRC) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// readersMatch reads a and b to the end, or until they differ, and returns
+// true if they have the same contents.
+func readersMatch(a, b io.Reader) (bool, error) {
+	bufA := make([]byte, 1024*1024)
+	bufB := make([]byte, len(bufA))
+	for {
+		n, err := io.ReadFull(a, bufA)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return false, err
+		}
+
+		m, err := io.ReadFull(b, bufB)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return false, err
+		}
+
+		// A short read means that both have ended, if they match.
+		if !bytes.Equal(bufA[:n], bufB[:m]) {
+			return false, nil
+		} else if n < len(bufA) {
+			return true, nil
+		}
+	}
+}
+
+// localCompositeChecksum computes the same COMPOSITE-CRC checksum for a local
+// file as FileReader.CompositeChecksum does for a file in HDFS.
+func localCompositeChecksum(local *os.File, checksumType string) ([]byte, error) {
+	var h hash.Hash32
+	switch checksumType {
+	case "CRC32":
+		h = crc32.NewIEEE()
+	case "CRC32C":
+		h = crc32.New(crc32.MakeTable(crc32.Castagnoli))
+	default:
+		return nil, fmt.Errorf("unsupported checksum type: %s", checksumType)
+	}
+
+	_, err := io.Copy(h, io.NewSectionReader(local, 0, math.MaxInt64))
+	if err != nil {
+		return nil, err
+	}
+
+	return h.Sum(nil), nil
+}
+
+// verifiedPrefix returns the length of the longest run of blocks at the start
+// of the local file (of the given size) that match the checksums of the
+// corresponding blocks of the source.
+func verifiedPrefix(checksums []hdfs.BlockChecksum, local *os.File, size int64) (int64, error) {
+	var prefix int64
+	for _, cs := range checksums {
+		if cs.Offset+cs.Length > size {
+			break
+		}
+
+		checksum, err := localBlockChecksum(io.NewSectionReader(local, cs.Offset, cs.Length), cs)
+		if err != nil {
+			return 0, err
+		} else if !bytes.Equal(checksum, cs.Checksum) {
+			break
+		}
+
+		prefix = cs.Offset + cs.Length
+	}
+
+	return prefix, nil
+}
+
+// localBlockChecksum computes the same MD5CRC checksum for a block of a local
+// file as a datanode would for cs.
+func localBlockChecksum(r io.Reader, cs hdfs.BlockChecksum) ([]byte, error) {
+	var tab *crc32.Table
+	switch cs.ChecksumType {
+	case "CRC32":
+		tab = crc32.IEEETable
+	case "CRC32C":
+		tab = crc32.MakeTable(crc32.Castagnoli)
+	default:
+		// We can't verify the block, so it'll have to be downloaded again.
+		return nil, nil
+	}
+
+	checksum := md5.New()
+	chunk := make([]byte, cs.BytesPerCRC)
+	crc := make([]byte, 4)
+	for {
+		n, err := io.ReadFull(r, chunk)
+		if n > 0 {
+			binary.BigEndian.PutUint32(crc, crc32.Checksum(chunk[:n], tab))
+			checksum.Write(crc)
+		}
+
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+	}
+
+	return checksum.Sum(nil), nil
 }
 
 func getmerge(args []string, addNewlines bool) {
@@ -107,7 +513,7 @@ func getmerge(args []string, addNewlines bool) {
 		}
 	}
 
-	_, err = io.Copy(local, io.MultiReader(readers...))
+	_, err = io.Copy(local, workerLimiter().reader(io.MultiReader(readers...)))
 	if err != nil {
 		fatal(err)
 	}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"os/user"
@@ -9,13 +10,21 @@ import (
 	krb "gopkg.in/jcmturner/gokrb5.v5/client"
 	"gopkg.in/jcmturner/gokrb5.v5/config"
 	"gopkg.in/jcmturner/gokrb5.v5/credentials"
+	"gopkg.in/jcmturner/gokrb5.v5/keytab"
 )
 
 // TODO: Write a kerberos_windows.go and move this to kerberos_unix.go. This
 // assumes MIT kerberos on unix.
 
-func getKerberosClient() (*krb.Client, error) {
-	configPath := os.Getenv("KRB5_CONFIG")
+// getKerberosClient loads a kerberos client using the given krb5.conf and
+// credentials cache, either of which may be empty to use the environment or
+// the defaults. If a keytab is given, the client logs in as the principal
+// with it instead, and the credentials cache isn't used.
+func getKerberosClient(configPath, ccachePath, keytabPath, principal string) (*krb.Client, error) {
+	if configPath == "" {
+		configPath = os.Getenv("KRB5_CONFIG")
+	}
+
 	if configPath == "" {
 		configPath = "/etc/krb5.conf"
 	}
@@ -25,9 +34,16 @@ func getKerberosClient() (*krb.Client, error) {
 		return nil, err
 	}
 
+	if keytabPath != "" {
+		return loginWithKeytab(cfg, keytabPath, principal)
+	}
+
 	// Determine the ccache location from the environment, falling back to the
 	// default location.
-	ccachePath := os.Getenv("KRB5CCNAME")
+	if ccachePath == "" {
+		ccachePath = os.Getenv("KRB5CCNAME")
+	}
+
 	if strings.Contains(ccachePath, ":") {
 		if strings.HasPrefix(ccachePath, "FILE:") {
 			ccachePath = strings.SplitN(ccachePath, ":", 2)[1]
@@ -55,3 +71,30 @@ func getKerberosClient() (*krb.Client, error) {
 
 	return client.WithConfig(cfg), nil
 }
+
+// loginWithKeytab logs in as the principal, which is either "user@REALM" or
+// just "user" for the default realm, using the keys in the keytab.
+func loginWithKeytab(cfg *config.Config, keytabPath, principal string) (*krb.Client, error) {
+	if principal == "" {
+		return nil, errors.New("a principal is required to log in with a keytab")
+	}
+
+	kt, err := keytab.Load(keytabPath)
+	if err != nil {
+		return nil, err
+	}
+
+	username, realm := principal, cfg.LibDefaults.DefaultRealm
+	if i := strings.LastIndex(principal, "@"); i >= 0 {
+		username, realm = principal[:i], principal[i+1:]
+	}
+
+	client := krb.NewClientWithKeytab(username, realm, kt)
+	client.WithConfig(cfg)
+	err = client.Login()
+	if err != nil {
+		return nil, err
+	}
+
+	return &client, nil
+}
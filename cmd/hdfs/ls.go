@@ -5,6 +5,7 @@ import (
 	"io"
 	"os"
 	"path"
+	"sort"
 	"strconv"
 	"strings"
 	"text/tabwriter"
@@ -13,7 +14,31 @@ import (
 	"github.com/colinmarc/hdfs/v2"
 )
 
-func ls(paths []string, long, all, humanReadable bool) {
+// lsOptions holds the flags passed to ls.
+type lsOptions struct {
+	long, all, humanReadable bool
+	// namesOnly overrides long.
+	namesOnly bool
+	// sortByTime takes precedence over sortBySize.
+	sortByTime, sortBySize, reverse bool
+	// accessTime specifies that the access time should be shown, and used to
+	// sort with sortByTime, instead of the modification time.
+	accessTime bool
+	// extended adds the block size, replication, and storage policy to the
+	// long format, and implies long.
+	extended bool
+	policies *lsStoragePolicies
+}
+
+type lsEntry struct {
+	name string
+	// parent is the directory containing the entry, which it inherits a
+	// storage policy from if it doesn't have its own.
+	parent string
+	info   os.FileInfo
+}
+
+func ls(paths []string, opts lsOptions) {
 	paths, client, err := getClientAndExpandedPaths(paths)
 	if err != nil {
 		fatal(err)
@@ -23,8 +48,16 @@ func ls(paths []string, long, all, humanReadable bool) {
 		paths = []string{userDir(client)}
 	}
 
-	files := make([]string, 0, len(paths))
-	fileInfos := make([]os.FileInfo, 0, len(paths))
+	if opts.extended {
+		opts.long = true
+		opts.policies = &lsStoragePolicies{client: client}
+	}
+
+	if opts.namesOnly {
+		opts.long = false
+	}
+
+	files := make([]lsEntry, 0, len(paths))
 	dirs := make([]string, 0, len(paths))
 	for _, p := range paths {
 		fi, err := client.Stat(p)
@@ -35,25 +68,23 @@ func ls(paths []string, long, all, humanReadable bool) {
 		if fi.IsDir() {
 			dirs = append(dirs, p)
 		} else {
-			files = append(files, p)
-			fileInfos = append(fileInfos, fi)
+			files = append(files, lsEntry{p, path.Dir(p), fi})
 		}
 	}
 
 	if len(files) == 0 && len(dirs) == 1 {
-		printDir(client, dirs[0], long, all, humanReadable)
+		printDir(client, dirs[0], opts)
 	} else {
-		if long {
-			tw := lsTabWriter()
-			for i, p := range files {
-				printLong(tw, p, fileInfos[i], humanReadable)
-			}
+		opts.sort(files)
 
+		var tw *tabwriter.Writer
+		if opts.long {
+			tw = lsTabWriter()
+		}
+
+		printFiles(tw, files, opts)
+		if opts.long {
 			tw.Flush()
-		} else {
-			for _, p := range files {
-				fmt.Println(p)
-			}
 		}
 
 		for i, dir := range dirs {
@@ -62,25 +93,20 @@ func ls(paths []string, long, all, humanReadable bool) {
 			}
 
 			fmt.Printf("%s/:\n", dir)
-			printDir(client, dir, long, all, humanReadable)
+			printDir(client, dir, opts)
 		}
 	}
 }
 
-func printDir(client *hdfs.Client, dir string, long, all, humanReadable bool) {
-	dirReader, err := client.Open(dir)
-	if err != nil {
-		fatal(err)
-	}
-
+func printDir(client *hdfs.Client, dir string, opts lsOptions) {
 	var tw *tabwriter.Writer
-	if long {
+	if opts.long {
 		tw = lsTabWriter()
 		defer tw.Flush()
 	}
 
-	if all {
-		if long {
+	if opts.all {
+		if opts.long {
 			dirInfo, err := client.Stat(dir)
 			if err != nil {
 				fatal(err)
@@ -92,54 +118,111 @@ func printDir(client *hdfs.Client, dir string, long, all, humanReadable bool) {
 				fatal(err)
 			}
 
-			printLong(tw, ".", dirInfo, humanReadable)
-			printLong(tw, "..", parentInfo, humanReadable)
+			printLong(tw, lsEntry{".", path.Dir(dir), dirInfo}, opts)
+			printLong(tw, lsEntry{"..", path.Dir(parentPath), parentInfo}, opts)
 		} else {
 			fmt.Println(".")
 			fmt.Println("..")
 		}
 	}
 
-	var partial []os.FileInfo
-	for ; err != io.EOF; partial, err = dirReader.Readdir(100) {
-		if err != nil {
+	// The namenode returns entries sorted by name, so unless they need to be
+	// sorted some other way, they can be printed as they come in.
+	var sorted []lsEntry
+	it := client.ListStatusIterator(dir)
+	for {
+		partial, err := it.NextBatch()
+		if err == io.EOF {
+			break
+		} else if err != nil {
 			fatal(err)
 		}
 
-		printFiles(tw, partial, long, all, humanReadable)
+		entries := make([]lsEntry, 0, len(partial))
+		for _, fi := range partial {
+			if opts.all || !strings.HasPrefix(fi.Name(), ".") {
+				entries = append(entries, lsEntry{fi.Name(), dir, fi})
+			}
+		}
+
+		if opts.sorted() {
+			sorted = append(sorted, entries...)
+		} else {
+			printFiles(tw, entries, opts)
+		}
+	}
+
+	if opts.sorted() {
+		opts.sort(sorted)
+		printFiles(tw, sorted, opts)
 	}
 
-	if long {
+	if opts.long {
 		tw.Flush()
 	}
 }
 
-func printFiles(tw *tabwriter.Writer, files []os.FileInfo, long, all, humanReadable bool) {
+func printFiles(tw *tabwriter.Writer, files []lsEntry, opts lsOptions) {
 	for _, file := range files {
-		if !all && strings.HasPrefix(file.Name(), ".") {
-			continue
+		if opts.long {
+			printLong(tw, file, opts)
+		} else {
+			fmt.Println(file.name)
 		}
+	}
+}
 
-		if long {
-			printLong(tw, file.Name(), file, humanReadable)
-		} else {
-			fmt.Println(file.Name())
+func (opts lsOptions) sorted() bool {
+	return opts.sortByTime || opts.sortBySize || opts.reverse
+}
+
+// sort sorts the entries according to the options. Entries that are otherwise
+// equal stay sorted by name.
+func (opts lsOptions) sort(entries []lsEntry) {
+	if !opts.sorted() {
+		return
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i].info, entries[j].info
+		switch {
+		case opts.sortByTime:
+			return opts.time(a).After(opts.time(b))
+		case opts.sortBySize:
+			return a.Size() > b.Size()
+		default:
+			return false
 		}
+	})
+
+	if opts.reverse {
+		for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+			entries[i], entries[j] = entries[j], entries[i]
+		}
+	}
+}
+
+func (opts lsOptions) time(info os.FileInfo) time.Time {
+	if opts.accessTime {
+		return info.(*hdfs.FileInfo).AccessTime()
 	}
+
+	return info.ModTime()
 }
 
-func printLong(tw *tabwriter.Writer, name string, info os.FileInfo, humanReadable bool) {
-	fi := info.(*hdfs.FileInfo)
-	// mode owner group size date(\w tab) time/year name
+func printLong(tw *tabwriter.Writer, entry lsEntry, opts lsOptions) {
+	fi := entry.info.(*hdfs.FileInfo)
+	// mode owner group size [blocksize replication policy] date(\w tab)
+	// time/year name
 	mode := fi.Mode().String()
 	owner := fi.Owner()
 	group := fi.OwnerGroup()
 	size := strconv.FormatInt(fi.Size(), 10)
-	if humanReadable {
+	if opts.humanReadable {
 		size = formatBytes(uint64(fi.Size()))
 	}
 
-	modtime := fi.ModTime()
+	modtime := opts.time(fi)
 	date := modtime.Format("Jan _2")
 	var timeOrYear string
 	if modtime.Year() == time.Now().Year() {
@@ -148,8 +231,77 @@ func printLong(tw *tabwriter.Writer, name string, info os.FileInfo, humanReadabl
 		timeOrYear = modtime.Format("2006")
 	}
 
+	if opts.extended {
+		blockSize, replication := "-", "-"
+		if !fi.IsDir() {
+			blockSize = strconv.FormatInt(fi.BlockSize(), 10)
+			if opts.humanReadable {
+				blockSize = formatBytes(uint64(fi.BlockSize()))
+			}
+
+			replication = strconv.Itoa(fi.Replication())
+		}
+
+		policy := opts.policies.name(fi, entry.parent)
+		size = fmt.Sprintf("%s \t %s \t %s \t %s", size, blockSize, replication, policy)
+	}
+
 	fmt.Fprintf(tw, "%s \t%s \t %s \t %s \t%s \t%s \t%s\n",
-		mode, owner, group, size, date, timeOrYear, name)
+		mode, owner, group, size, date, timeOrYear, entry.name)
+}
+
+// lsStoragePolicies looks up the names of storage policies for ls -e, making
+// as few calls to the namenode as possible.
+type lsStoragePolicies struct {
+	client    *hdfs.Client
+	names     map[int]string
+	inherited map[string]string
+}
+
+// name returns the name of the storage policy that applies to the file: its
+// own, if it has one, or otherwise the one it inherits from its parent. If
+// the policy can't be determined, for example because the namenode doesn't
+// support storage policies, it returns "-".
+func (p *lsStoragePolicies) name(fi *hdfs.FileInfo, parent string) string {
+	id := fi.StoragePolicyID()
+	if id == 0 {
+		return p.inheritedName(parent)
+	}
+
+	if p.names == nil {
+		p.names = make(map[int]string)
+		policies, err := p.client.GetStoragePolicies()
+		if err == nil {
+			for _, policy := range policies {
+				p.names[policy.ID] = policy.Name
+			}
+		}
+	}
+
+	if name, ok := p.names[id]; ok {
+		return name
+	}
+
+	return strconv.Itoa(id)
+}
+
+func (p *lsStoragePolicies) inheritedName(dir string) string {
+	if p.inherited == nil {
+		p.inherited = make(map[string]string)
+	}
+
+	if name, ok := p.inherited[dir]; ok {
+		return name
+	}
+
+	name := "-"
+	policy, err := p.client.GetStoragePolicy(dir)
+	if err == nil {
+		name = policy.Name
+	}
+
+	p.inherited[dir] = name
+	return name
 }
 
 func lsTabWriter() *tabwriter.Writer {
@@ -6,56 +6,122 @@ import (
 	"net"
 	"os"
 	"os/user"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/colinmarc/hdfs/v2"
 	"github.com/colinmarc/hdfs/v2/hadoopconf"
 	"github.com/pborman/getopt"
+	krb "gopkg.in/jcmturner/gokrb5.v5/client"
 )
 
 // TODO: cp, tree, test, trash
 
 var (
 	version string
-	usage   = fmt.Sprintf(`Usage: %s COMMAND
+	usage   = fmt.Sprintf(`Usage: %s [--cluster NAME] [--timeout DURATION] [--retries N] [--retry-interval DURATION] COMMAND
 The flags available are a subset of the POSIX ones, but should behave similarly.
 
+The --cluster flag selects a named cluster from ~/.hdfs/config. Otherwise, the
+configuration in HADOOP_CONF_DIR is used.
+
+The global flags limit how long each request to the namenode (or read or write
+from a datanode) can take, and how many times to retry, waiting the retry
+interval in between, once every namenode has failed. Durations are written
+like "30s" or "1m".
+
 Valid commands:
-  ls [-lah] [FILE]...
-  rm [-rf] FILE...
+  ls [-lahtSruCe] [FILE]...
+  rm [-rf] [--skipTrash] FILE...
   mv [-nT] SOURCE... DEST
+  cp [-p] [--preserve ATTR,...] SOURCE... DEST
   mkdir [-p] FILE...
   touch [-amc] FILE...
+  touchz FILE...
   chmod [-R] OCTAL-MODE FILE...
   chown [-R] OWNER[:GROUP] FILE...
-  cat SOURCE...
+  cat [--offset BYTES] [--length BYTES] SOURCE...
   head [-n LINES | -c BYTES] SOURCE...
-  tail [-n LINES | -c BYTES] SOURCE...
-  du [-sh] FILE...
+  tail [-f] [-n LINES | -c BYTES] SOURCE...
+  du [-sh] [--max-depth N] [--exclude PATTERN]... FILE...
+  count [-qhv] FILE...
   checksum FILE...
-  get SOURCE [DEST]
-  getmerge SOURCE DEST
-  put SOURCE DEST
+  get [-cp] [--verify] [--crc] [--bwlimit RATE] [--bwlimit-per-worker RATE] SOURCE [DEST]
+  getmerge [-n] [--bwlimit RATE] [--bwlimit-per-worker RATE] SOURCE DEST
+  put [-p] [--blocksize SIZE] [--replication N] [--bwlimit RATE] [--bwlimit-per-worker RATE] SOURCE DEST
+  distcp [-pu] [--preserve ATTR,...] [-m WORKERS] [--manifest FILE] [--bwlimit RATE] [--bwlimit-per-worker RATE] SOURCE... DEST
+  concat [--sort] TARGET SOURCE...
+  truncate [-w] LENGTH FILE...
+  storagepolicies list
+  storagepolicies get FILE...
+  storagepolicies set [-s] POLICY FILE...
+  storagepolicies unset FILE...
+  storagepolicies satisfy FILE...
+  ec -listPolicies
+  ec -getPolicy -path FILE
+  ec -setPolicy -path FILE [-policy POLICY]
+  ec -unsetPolicy -path FILE
+  cacheadmin -addDirective -path FILE -pool POOL [-force] [-replication N] [-ttl TTL]
+  cacheadmin -modifyDirective -id ID [-path FILE] [-pool POOL] [-force] [-replication N] [-ttl TTL]
+  cacheadmin -listDirectives [-stats] [-path FILE] [-pool POOL] [-id ID]
+  cacheadmin -removeDirective ID
+  cacheadmin -removeDirectives -path FILE
+  cacheadmin -addPool|-modifyPool NAME [-owner OWNER] [-group GROUP] [-mode MODE] [-limit LIMIT] [-maxTtl TTL]
+  cacheadmin -removePool NAME
+  cacheadmin -listPools [-stats] [NAME]
+  getfacl FILE...
+  setfacl -m|-x ACLSPEC FILE...
+  setfacl --set ACLSPEC FILE...
+  setfacl -b|-k FILE...
+  getfattr [-d] [-n NAME] FILE...
+  setfattr -n NAME [-v VALUE] FILE...
+  setfattr -x NAME FILE...
+  snapshot allow|disallow DIR
+  snapshot create DIR [NAME]
+  snapshot delete DIR NAME
+  snapshot rename DIR OLD NEW
+  snapshot diff DIR FROM [TO]
+  setquota N DIR...
+  clrquota DIR...
+  setspacequota [--storage-type TYPE] SIZE DIR...
+  clrspacequota [--storage-type TYPE] DIR...
   df [-h]
+  s3gateway [--listen ADDR] --credentials FILE ROOT
+  sftpgateway [--listen ADDR] --host-key FILE --authorized-keys FILE ROOT
+  serve [--listen ADDR]
 `, os.Args[0])
 
 	lsOpts = getopt.New()
 	lsl    = lsOpts.Bool('l')
 	lsa    = lsOpts.Bool('a')
 	lsh    = lsOpts.Bool('h')
+	lst    = lsOpts.Bool('t')
+	lsS    = lsOpts.Bool('S')
+	lsr    = lsOpts.Bool('r')
+	lsu    = lsOpts.Bool('u')
+	lsC    = lsOpts.Bool('C')
+	lse    = lsOpts.Bool('e')
 
 	rmOpts = getopt.New()
 	rmr    = rmOpts.Bool('r')
 	rmf    = rmOpts.Bool('f')
+	rmSkip = rmOpts.BoolLong("skipTrash", 0)
 
 	mvOpts = getopt.New()
 	mvn    = mvOpts.Bool('n')
 	mvT    = mvOpts.Bool('T')
 
+	cpOpts     = getopt.New()
+	cpp        = cpOpts.Bool('p')
+	cpPreserve = cpOpts.StringLong("preserve", 0, "")
+
 	mkdirOpts = getopt.New()
 	mkdirp    = mkdirOpts.Bool('p')
 
 	touchOpts = getopt.New()
+	toucha    = touchOpts.Bool('a')
+	touchm    = touchOpts.Bool('m')
 	touchc    = touchOpts.Bool('c')
 
 	chmodOpts = getopt.New()
@@ -64,20 +130,90 @@ Valid commands:
 	chownOpts = getopt.New()
 	chownR    = chownOpts.Bool('R')
 
+	catOpts   = getopt.New()
+	catOffset = catOpts.Int64Long("offset", 0, 0)
+	catLength = catOpts.Int64Long("length", 0, -1)
+
 	headTailOpts = getopt.New()
 	headtailn    = headTailOpts.Int64('n', -1)
 	headtailc    = headTailOpts.Int64('c', -1)
+	headtailf    = headTailOpts.Bool('f')
+
+	duOpts     = getopt.New()
+	dus        = duOpts.Bool('s')
+	duh        = duOpts.Bool('h')
+	duMaxDepth = duOpts.IntLong("max-depth", 0, -1)
+	duExcludes stringList
 
-	duOpts = getopt.New()
-	dus    = duOpts.Bool('s')
-	duh    = duOpts.Bool('h')
+	countOpts = getopt.New()
+	countq    = countOpts.Bool('q')
+	counth    = countOpts.Bool('h')
+	countv    = countOpts.Bool('v')
+
+	getOpts = getopt.New()
+	getc    = getOpts.BoolLong("continue", 'c')
+	getp    = getOpts.Bool('p')
+	getv    = getOpts.BoolLong("verify", 0)
+	getcrc  = getOpts.BoolLong("crc", 0)
 
 	getmergeOpts = getopt.New()
 	getmergen    = getmergeOpts.Bool('n')
 
+	putOpts        = getopt.New()
+	putp           = putOpts.Bool('p')
+	putBlockSize   = putOpts.StringLong("blocksize", 0, "")
+	putReplication = putOpts.IntLong("replication", 0, 0)
+
+	distcpOpts         = getopt.New()
+	distcpp            = distcpOpts.Bool('p')
+	distcpPreserve     = distcpOpts.StringLong("preserve", 0, "")
+	distcpu            = distcpOpts.BoolLong("update", 'u')
+	distcpWorkers      = distcpOpts.IntLong("workers", 'm', defaultDistcpWorkers)
+	distcpManifestFile = distcpOpts.StringLong("manifest", 0, "")
+
+	concatOpts = getopt.New()
+	concatSort = concatOpts.BoolLong("sort", 0)
+
+	storagePoliciesOpts    = getopt.New()
+	storagePoliciesSatisfy = storagePoliciesOpts.BoolLong("satisfy", 's')
+
+	setfaclOpts = getopt.New()
+	setfaclm    = setfaclOpts.String('m', "")
+	setfaclx    = setfaclOpts.String('x', "")
+	setfaclSet  = setfaclOpts.StringLong("set", 0, "")
+	setfaclb    = setfaclOpts.Bool('b')
+	setfaclk    = setfaclOpts.Bool('k')
+
+	getfattrOpts = getopt.New()
+	getfattrd    = getfattrOpts.Bool('d')
+	getfattrn    = getfattrOpts.String('n', "")
+
+	setfattrOpts = getopt.New()
+	setfattrn    = setfattrOpts.String('n', "")
+	setfattrv    = setfattrOpts.String('v', "")
+	setfattrx    = setfattrOpts.String('x', "")
+
+	truncateOpts = getopt.New()
+	truncatew    = truncateOpts.Bool('w')
+
+	spaceQuotaOpts        = getopt.New()
+	spaceQuotaStorageType = spaceQuotaOpts.StringLong("storage-type", 0, "")
+
 	dfOpts = getopt.New()
 	dfh    = dfOpts.Bool('h')
 
+	s3gatewayOpts        = getopt.New()
+	s3gatewayListen      = s3gatewayOpts.StringLong("listen", 0, ":9000")
+	s3gatewayCredentials = s3gatewayOpts.StringLong("credentials", 0, "")
+
+	sftpgatewayOpts           = getopt.New()
+	sftpgatewayListen         = sftpgatewayOpts.StringLong("listen", 0, ":2022")
+	sftpgatewayHostKey        = sftpgatewayOpts.StringLong("host-key", 0, "")
+	sftpgatewayAuthorizedKeys = sftpgatewayOpts.StringLong("authorized-keys", 0, "")
+
+	serveOpts   = getopt.New()
+	serveListen = serveOpts.StringLong("listen", 0, "localhost:50051")
+
 	cachedClients map[string]*hdfs.Client = make(map[string]*hdfs.Client)
 	status                                = 0
 )
@@ -86,13 +222,36 @@ func init() {
 	lsOpts.SetUsage(printHelp)
 	rmOpts.SetUsage(printHelp)
 	mvOpts.SetUsage(printHelp)
+	cpOpts.SetUsage(printHelp)
 	touchOpts.SetUsage(printHelp)
 	chmodOpts.SetUsage(printHelp)
 	chownOpts.SetUsage(printHelp)
+	catOpts.SetUsage(printHelp)
 	headTailOpts.SetUsage(printHelp)
 	duOpts.SetUsage(printHelp)
+	countOpts.SetUsage(printHelp)
+	getOpts.SetUsage(printHelp)
 	getmergeOpts.SetUsage(printHelp)
+	putOpts.SetUsage(printHelp)
+	distcpOpts.SetUsage(printHelp)
+	concatOpts.SetUsage(printHelp)
+	setfaclOpts.SetUsage(printHelp)
+	getfattrOpts.SetUsage(printHelp)
+	setfattrOpts.SetUsage(printHelp)
+	truncateOpts.SetUsage(printHelp)
+	storagePoliciesOpts.SetUsage(printHelp)
+	spaceQuotaOpts.SetUsage(printHelp)
+
+	for _, opts := range []*getopt.Set{getOpts, getmergeOpts, putOpts, distcpOpts} {
+		opts.StringVarLong(&bwlimit, "bwlimit", 0)
+		opts.StringVarLong(&bwlimitPerWorker, "bwlimit-per-worker", 0)
+	}
+
+	duOpts.VarLong(&duExcludes, "exclude", 0)
 	dfOpts.SetUsage(printHelp)
+	s3gatewayOpts.SetUsage(printHelp)
+	sftpgatewayOpts.SetUsage(printHelp)
+	serveOpts.SetUsage(printHelp)
 }
 
 func main() {
@@ -100,26 +259,62 @@ func main() {
 		printHelp()
 	}
 
-	command := os.Args[1]
-	argv := os.Args[1:]
+	argv := parseGlobalFlags(os.Args[1:])
+	if len(argv) == 0 {
+		printHelp()
+	}
+
+	if cluster != "" {
+		var err error
+		profile, err = loadProfile(cluster)
+		if err != nil {
+			fatal("Problem loading cluster profile:", err)
+		}
+
+		profile.applyDefaults()
+	}
+
+	command = argv[0]
 	switch command {
 	case "-v", "--version":
 		fatal("gohdfs version", version)
 	case "ls":
 		lsOpts.Parse(argv)
-		ls(lsOpts.Args(), *lsl, *lsa, *lsh)
+		ls(lsOpts.Args(), lsOptions{
+			long:          *lsl,
+			all:           *lsa,
+			humanReadable: *lsh,
+			namesOnly:     *lsC,
+			sortByTime:    *lst,
+			sortBySize:    *lsS,
+			reverse:       *lsr,
+			accessTime:    *lsu,
+			extended:      *lse,
+		})
 	case "rm":
+		// Accept -skipTrash, like 'hadoop fs -rm'.
+		for i, arg := range argv {
+			if arg == "-skipTrash" {
+				argv[i] = "--skipTrash"
+			}
+		}
+
 		rmOpts.Parse(argv)
-		rm(rmOpts.Args(), *rmr, *rmf)
+		rm(rmOpts.Args(), *rmr, *rmf, *rmSkip)
 	case "mv":
 		mvOpts.Parse(argv)
 		mv(mvOpts.Args(), !*mvn, *mvT)
+	case "cp":
+		cpOpts.Parse(argv)
+		cp(cpOpts.Args(), cpPreserveOptions(*cpp, *cpPreserve))
 	case "mkdir":
 		mkdirOpts.Parse(argv)
 		mkdir(mkdirOpts.Args(), *mkdirp)
 	case "touch":
 		touchOpts.Parse(argv)
-		touch(touchOpts.Args(), *touchc)
+		touch(touchOpts.Args(), *toucha, *touchm, *touchc)
+	case "touchz":
+		touchz(argv[1:])
 	case "chown":
 		chownOpts.Parse(argv)
 		chown(chownOpts.Args(), *chownR)
@@ -127,25 +322,84 @@ func main() {
 		chmodOpts.Parse(argv)
 		chmod(chmodOpts.Args(), *chmodR)
 	case "cat":
-		cat(argv[1:])
+		catOpts.Parse(argv)
+		cat(catOpts.Args(), *catOffset, *catLength)
 	case "head", "tail":
 		headTailOpts.Parse(argv)
-		printSection(headTailOpts.Args(), *headtailn, *headtailc, (command == "tail"))
+		printSection(headTailOpts.Args(), *headtailn, *headtailc, (command == "tail"), *headtailf)
 	case "du":
 		duOpts.Parse(argv)
-		du(duOpts.Args(), *dus, *duh)
+		du(duOpts.Args(), duOptions{
+			summarize:     *dus,
+			humanReadable: *duh,
+			maxDepth:      *duMaxDepth,
+			excludes:      duExcludes,
+		})
+	case "count":
+		countOpts.Parse(argv)
+		count(countOpts.Args(), countOptions{quotas: *countq, humanReadable: *counth, header: *countv})
 	case "checksum":
 		checksum(argv[1:])
 	case "get":
-		get(argv[1:])
+		getOpts.Parse(argv)
+		get(getOpts.Args(), getOptions{resume: *getc, preserve: *getp, verify: *getv, crc: *getcrc})
 	case "getmerge":
 		getmergeOpts.Parse(argv)
 		getmerge(getmergeOpts.Args(), *getmergen)
 	case "put":
-		put(argv[1:])
+		putOpts.Parse(argv)
+		put(putOpts.Args(), newPutOptions(*putp, *putBlockSize, *putReplication))
+	case "distcp":
+		distcpOpts.Parse(argv)
+		distcp(distcpOpts.Args(), distcpOptions{
+			preserve: cpPreserveOptions(*distcpp, *distcpPreserve),
+			update:   *distcpu,
+			workers:  *distcpWorkers,
+			manifest: *distcpManifestFile,
+		})
+	case "concat":
+		concatOpts.Parse(argv)
+		concat(concatOpts.Args(), *concatSort)
+	case "storagepolicies":
+		storagePoliciesOpts.Parse(argv)
+		storagePolicies(storagePoliciesOpts.Args(), *storagePoliciesSatisfy)
+	case "ec":
+		ec(argv[1:])
+	case "cacheadmin":
+		cacheadmin(argv[1:])
+	case "getfacl":
+		getfacl(argv[1:])
+	case "setfacl":
+		setfaclOpts.Parse(argv)
+		setfacl(setfaclOpts.Args(), *setfaclm, *setfaclx, *setfaclSet, *setfaclb, *setfaclk)
+	case "getfattr":
+		getfattrOpts.Parse(argv)
+		getfattr(getfattrOpts.Args(), *getfattrn, *getfattrd)
+	case "setfattr":
+		setfattrOpts.Parse(argv)
+		setfattr(setfattrOpts.Args(), *setfattrn, *setfattrv, *setfattrx)
+	case "snapshot":
+		snapshot(argv[1:])
+	case "setquota", "clrquota":
+		setQuota(argv[1:], command == "clrquota")
+	case "setspacequota", "clrspacequota":
+		spaceQuotaOpts.Parse(argv)
+		setSpaceQuota(spaceQuotaOpts.Args(), command == "clrspacequota", *spaceQuotaStorageType)
+	case "truncate":
+		truncateOpts.Parse(argv)
+		truncate(truncateOpts.Args(), *truncatew)
 	case "df":
 		dfOpts.Parse(argv)
 		df(*dfh)
+	case "s3gateway":
+		s3gatewayOpts.Parse(argv)
+		serveS3Gateway(s3gatewayOpts.Args(), *s3gatewayListen, *s3gatewayCredentials)
+	case "sftpgateway":
+		sftpgatewayOpts.Parse(argv)
+		serveSFTPGateway(sftpgatewayOpts.Args(), *sftpgatewayListen, *sftpgatewayHostKey, *sftpgatewayAuthorizedKeys)
+	case "serve":
+		serveOpts.Parse(argv)
+		serve(serveOpts.Args(), *serveListen)
 	// it's a seeeeecret command
 	case "complete":
 		complete(argv)
@@ -164,45 +418,159 @@ func printHelp() {
 }
 
 func fatal(msg ...interface{}) {
+	if len(msg) == 1 {
+		if err, ok := msg[0].(error); ok {
+			msg[0] = formatError(err)
+		}
+	}
+
 	fmt.Fprintln(os.Stderr, msg...)
-	os.Exit(1)
+	os.Exit(exitError)
 }
 
 func fatalWithUsage(msg ...interface{}) {
 	msg = append(msg, "\n"+usage)
-	fatal(msg...)
+	fmt.Fprintln(os.Stderr, msg...)
+	os.Exit(exitUsage)
 }
 
+// getClient returns a client for the given namenode, or the configured one if
+// namenode is empty. Clients are cached by the addresses the namenode resolves
+// to, so that the same client is returned for every way of naming a cluster
+// (like the configured namenode and an explicit hdfs://<namenode>), and
+// comparing two clients tells whether they're for the same cluster.
 func getClient(namenode string) (*hdfs.Client, error) {
-	if cachedClients[namenode] != nil {
-		return cachedClients[namenode], nil
+	options, err := namenodeOptions(namenode)
+	if err != nil {
+		return nil, err
+	}
+
+	key := options.WebHDFSAddress
+	if key == "" {
+		addresses := append([]string(nil), options.Addresses...)
+		sort.Strings(addresses)
+		key = strings.Join(addresses, ",")
+	}
+
+	if cachedClients[key] != nil {
+		return cachedClients[key], nil
+	}
+
+	c, err := newClientWithOptions(options, "")
+	if err != nil {
+		return nil, err
+	}
+
+	cachedClients[key] = c
+	return c, nil
+}
+
+// clientCluster returns the addresses of the cluster a client returned by
+// getClient is for, as it's cached.
+func clientCluster(c *hdfs.Client) string {
+	for key, cached := range cachedClients {
+		if cached == c {
+			return key
+		}
 	}
 
-	if namenode == "" {
+	return ""
+}
+
+// newClient creates a client for the given namenode, or the configured one if
+// namenode is empty. If asUser isn't empty, the client acts as that user;
+// that's only possible without kerberos, or if it's the user of the kerberos
+// principal.
+func newClient(namenode, asUser string) (*hdfs.Client, error) {
+	options, err := namenodeOptions(namenode)
+	if err != nil {
+		return nil, err
+	}
+
+	return newClientWithOptions(options, asUser)
+}
+
+// namenodeOptions loads the configuration, and returns the client options for
+// it, with the addresses of the given namenode, or of the configured one if
+// namenode is empty.
+func namenodeOptions(namenode string) (hdfs.ClientOptions, error) {
+	if namenode == "" && profile == nil {
 		namenode = os.Getenv("HADOOP_NAMENODE")
 	}
 
-	conf, err := hadoopconf.LoadFromEnvironment()
+	var conf hadoopconf.HadoopConf
+	var err error
+	if profile != nil && profile.hadoopConfDir != "" {
+		conf, err = hadoopconf.Load(profile.hadoopConfDir)
+	} else {
+		conf, err = hadoopconf.LoadFromEnvironment()
+	}
+
 	if err != nil {
-		return nil, fmt.Errorf("Problem loading configuration: %s", err)
+		return hdfs.ClientOptions{}, fmt.Errorf("Problem loading configuration: %s", err)
 	}
 
 	options := hdfs.ClientOptionsFromConf(conf)
-	if namenode != "" {
-		options.Addresses = []string{namenode}
+	if isWebHDFS(namenode) {
+		options.WebHDFSAddress = namenode
+		options.DelegationToken = nil
+	} else if namenode != "" {
+		// The namenode may be a nameservice from the configuration, as in
+		// hdfs://nameservice1/path.
+		options.Addresses = conf.AddressesFor(namenode)
+	} else if profile != nil && len(profile.namenodes) > 0 {
+		options.Addresses = profile.namenodes
 	}
 
-	if options.Addresses == nil {
-		return nil, errors.New("Couldn't find a namenode to connect to. You should specify hdfs://<namenode>:<port> in your paths. Alternatively, set HADOOP_NAMENODE or HADOOP_CONF_DIR in your environment.")
+	if options.Addresses == nil && options.WebHDFSAddress == "" {
+		return hdfs.ClientOptions{}, errors.New("Couldn't find a namenode to connect to. You should specify hdfs://<namenode>:<port> in your paths. Alternatively, set HADOOP_NAMENODE or HADOOP_CONF_DIR in your environment.")
 	}
 
-	if options.KerberosClient != nil {
-		options.KerberosClient, err = getKerberosClient()
+	return options, nil
+}
+
+// newClientWithOptions creates a client from options returned by
+// namenodeOptions, with the credentials from the environment and the profile,
+// and the command's timeouts. asUser is the same as for newClient.
+func newClientWithOptions(options hdfs.ClientOptions, asUser string) (*hdfs.Client, error) {
+	var err error
+	var krbConfig, krbCCache, krbKeytab, krbPrincipal string
+	if profile != nil {
+		if profile.set["kerberos"] {
+			if profile.kerberos {
+				options.KerberosClient = &krb.Client{}
+			} else {
+				options.KerberosClient = nil
+			}
+		}
+
+		if profile.kerberosServicePrincipal != "" {
+			options.KerberosServicePrincipleName = profile.kerberosServicePrincipal
+		}
+
+		krbConfig = profile.kerberosConfig
+		krbCCache = profile.kerberosCCache
+		krbKeytab = profile.kerberosKeytab
+		krbPrincipal = profile.kerberosPrincipal
+	}
+
+	// A delegation token, from HADOOP_TOKEN_FILE_LOCATION, is used instead of
+	// kerberos credentials.
+	if options.KerberosClient != nil && options.DelegationToken == nil {
+		options.KerberosClient, err = getKerberosClient(krbConfig, krbCCache, krbKeytab, krbPrincipal)
 		if err != nil {
 			return nil, fmt.Errorf("Problem with kerberos authentication: %s", err)
 		}
 	} else {
 		options.User = os.Getenv("HADOOP_USER_NAME")
+		if profile != nil && profile.user != "" {
+			options.User = profile.user
+		}
+
+		if asUser != "" {
+			options.User = asUser
+		}
+
 		if options.User == "" {
 			u, err := user.Current()
 			if err != nil {
@@ -214,20 +582,36 @@ func getClient(namenode string) (*hdfs.Client, error) {
 	}
 
 	// Set some basic defaults.
+	dialTimeout := 5 * time.Second
+	if timeout > 0 && timeout < dialTimeout {
+		dialTimeout = timeout
+	}
+
 	dialFunc := (&net.Dialer{
-		Timeout:   5 * time.Second,
+		Timeout:   dialTimeout,
 		KeepAlive: 5 * time.Second,
 		DualStack: true,
 	}).DialContext
 
 	options.NamenodeDialFunc = dialFunc
 	options.DatanodeDialFunc = dialFunc
+	if timeout > 0 {
+		options.NamenodeRequestTimeout = timeout
+		options.DatanodeDialFunc = withIdleTimeout(dialFunc, timeout)
+	}
+
+	options.NamenodeRetries = retries
+	options.NamenodeRetryInterval = retryInterval
 
 	c, err := hdfs.NewClient(options)
 	if err != nil {
 		return nil, fmt.Errorf("Couldn't connect to namenode: %s", err)
 	}
 
-	cachedClients[namenode] = c
+	if asUser != "" && c.User() != asUser {
+		c.Close()
+		return nil, fmt.Errorf("Can't act as %s with kerberos authentication (the principal is for %s)", asUser, c.User())
+	}
+
 	return c, nil
 }
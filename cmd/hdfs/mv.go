@@ -1,62 +1,95 @@
 package main
 
 import (
+	"fmt"
+	"math/rand"
 	"os"
 	"path"
+	"strings"
 
 	"github.com/colinmarc/hdfs/v2"
 )
 
+// These are fragments of the errors that namenodes (or routers, with
+// router-based federation) return when a rename would cross a boundary that
+// files can't be renamed across, like an encryption zone or a mount point.
+var crossMountErrors = []string{
+	"can't be moved from",
+	"can't be moved into",
+	"is not allowed, no eligible destination",
+	"across mount points",
+	"across namespaces",
+}
+
 func mv(paths []string, force, treatDestAsFile bool) {
-	paths, nn, err := normalizePaths(paths)
+	if len(paths) < 2 {
+		fatalWithUsage("Both a source and destination are required.")
+	}
+
+	// The destination may be on a different cluster than the sources, in which
+	// case the files are copied and then removed.
+	sourcePaths, sourceNN, err := normalizePaths(paths[:len(paths)-1])
 	if err != nil {
 		fatal(err)
 	}
 
-	if len(paths) < 2 {
-		fatalWithUsage("Both a source and destination are required.")
-	} else if hasGlob(paths[len(paths)-1]) {
+	destPaths, destNN, err := normalizePaths(paths[len(paths)-1:])
+	if err != nil {
+		fatal(err)
+	}
+
+	dest := destPaths[0]
+	if hasGlob(dest) {
 		fatal("The destination must be a single path.")
 	}
 
-	client, err := getClient(nn)
+	client, err := getClient(sourceNN)
 	if err != nil {
 		fatal(err)
 	}
 
-	dest := paths[len(paths)-1]
-	sources, err := expandPaths(client, paths[:len(paths)-1])
+	// The clients are the same if both sides resolve to the same cluster, even
+	// if it's named differently.
+	destClient := client
+	if destNN != "" {
+		destClient, err = getClient(destNN)
+		if err != nil {
+			fatal(err)
+		}
+	}
+
+	sources, err := expandPaths(client, sourcePaths)
 	if err != nil {
 		fatal(err)
 	}
 
-	destInfo, err := client.Stat(dest)
+	destInfo, err := destClient.Stat(dest)
 	if err != nil && !os.IsNotExist(err) {
 		fatal(err)
 	}
 
 	exists := !os.IsNotExist(err)
 	if exists && !treatDestAsFile && destInfo.IsDir() {
-		moveInto(client, sources, dest, force)
+		moveInto(client, destClient, sources, dest, force)
 	} else {
 		if len(sources) > 1 {
 			fatal("Can't move multiple sources into the same place.")
 		}
 
-		moveTo(client, sources[0], dest, force)
+		moveTo(client, destClient, sources[0], dest, force)
 	}
 }
 
-func moveInto(client *hdfs.Client, sources []string, dest string, force bool) {
+func moveInto(client, destClient *hdfs.Client, sources []string, dest string, force bool) {
 	for _, source := range sources {
 		_, name := path.Split(source)
 
 		fullDest := path.Join(dest, name)
-		moveTo(client, source, fullDest, force)
+		moveTo(client, destClient, source, fullDest, force)
 	}
 }
 
-func moveTo(client *hdfs.Client, source, dest string, force bool) {
+func moveTo(client, destClient *hdfs.Client, source, dest string, force bool) {
 	sourceInfo, err := client.Stat(source)
 	if err != nil {
 		if pathErr, ok := err.(*os.PathError); ok {
@@ -66,7 +99,7 @@ func moveTo(client *hdfs.Client, source, dest string, force bool) {
 		fatal(err)
 	}
 
-	destInfo, err := client.Stat(dest)
+	destInfo, err := destClient.Stat(dest)
 	if err == nil {
 		if destInfo.IsDir() && !sourceInfo.IsDir() {
 			fatal("Can't replace directory with non-directory.")
@@ -77,8 +110,89 @@ func moveTo(client *hdfs.Client, source, dest string, force bool) {
 		fatal(err)
 	}
 
+	if client != destClient {
+		copyThenRemove(client, destClient, source, dest, err == nil)
+		return
+	}
+
 	err = client.Rename(source, dest)
+	if err != nil && isCrossMountError(err) {
+		copyThenRemove(client, destClient, source, dest, destInfo != nil)
+	} else if err != nil {
+		fatal(err)
+	}
+}
+
+// copyThenRemove moves a file or directory in cases where it can't be
+// renamed, by copying it and then removing the original. The original is only
+// removed if everything was copied, and the checksum of every copied file
+// matches. If dest is being replaced, the copy is made under a temporary name
+// next to it first, and then renamed over it, so that a failed copy leaves the
+// existing dest in place.
+func copyThenRemove(client, destClient *hdfs.Client, source, dest string, replace bool) {
+	target := dest
+	if replace {
+		dir, base := path.Split(dest)
+		target = path.Join(dir, fmt.Sprintf(".%s.%016x.tmp", base, rand.Uint64()))
+	}
+
+	preserve := preserveOptions{mode: true, ownership: true, timestamps: true, xattrs: true, acls: true}
+	c := &copier{src: client, dst: destClient, preserve: preserve, verify: true}
+	if !c.copyTree(source, target) {
+		if replace {
+			destClient.RemoveAll(target)
+		}
+
+		fatal(fmt.Sprintf("Not removing %s, because it couldn't be copied to %s.", source, dest))
+	}
+
+	if replace {
+		// This matches the behavior of rename, which can replace files and
+		// empty directories, but not directories with anything in them. A
+		// file can't be renamed over with a directory, though, so that has to
+		// be removed first.
+		targetInfo, targetErr := destClient.Stat(target)
+		destInfo, destErr := destClient.Stat(dest)
+		if targetErr == nil && destErr == nil && targetInfo.IsDir() && !destInfo.IsDir() {
+			err := destClient.Remove(dest)
+			if err != nil {
+				destClient.RemoveAll(target)
+				fatal(err)
+			}
+		}
+
+		err := destClient.Rename(target, dest)
+		if err != nil {
+			destClient.RemoveAll(target)
+			if pathErr, ok := err.(*os.PathError); ok {
+				pathErr.Path = dest
+			}
+
+			fatal(err)
+		}
+	}
+
+	err := client.RemoveAll(source)
 	if err != nil {
 		fatal(err)
 	}
 }
+
+func isCrossMountError(err error) bool {
+	if pathErr, ok := err.(*os.PathError); ok {
+		err = pathErr.Err
+	}
+
+	remoteErr, ok := err.(hdfs.Error)
+	if !ok {
+		return false
+	}
+
+	for _, fragment := range crossMountErrors {
+		if strings.Contains(remoteErr.Message(), fragment) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,11 @@
+//go:build windows || plan9
+
+package main
+
+import "os"
+
+// localOwner returns the names of the user and group that own a local file,
+// which isn't supported on this platform.
+func localOwner(info os.FileInfo) (string, string, bool) {
+	return "", "", false
+}
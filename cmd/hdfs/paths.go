@@ -33,11 +33,18 @@ func normalizePaths(paths []string) ([]string, string, error) {
 		}
 
 		if url.Host != "" {
-			if namenode != "" && namenode != url.Host {
+			// WebHDFS URLs keep their scheme, so that newClient knows to use
+			// WebHDFS for them.
+			host := url.Host
+			if isWebHDFS(rawurl) {
+				host = url.Scheme + "://" + url.Host
+			}
+
+			if namenode != "" && namenode != host {
 				return nil, "", errMultipleNamenodeUrls
 			}
 
-			namenode = url.Host
+			namenode = host
 		}
 
 		cleanPaths = append(cleanPaths, path.Clean(url.Path))
@@ -46,6 +53,11 @@ func normalizePaths(paths []string) ([]string, string, error) {
 	return cleanPaths, namenode, nil
 }
 
+// isWebHDFS returns true if the URL has the webhdfs:// or swebhdfs:// scheme.
+func isWebHDFS(rawurl string) bool {
+	return strings.HasPrefix(rawurl, "webhdfs://") || strings.HasPrefix(rawurl, "swebhdfs://")
+}
+
 func getClientAndExpandedPaths(paths []string) ([]string, *hdfs.Client, error) {
 	paths, nn, err := normalizePaths(paths)
 	if err != nil {
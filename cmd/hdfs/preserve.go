@@ -0,0 +1,133 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/user"
+	"strconv"
+	"strings"
+
+	"github.com/colinmarc/hdfs/v2"
+)
+
+// preserveOptions specifies which attributes are copied along with the
+// contents of a file.
+type preserveOptions struct {
+	mode, ownership, timestamps, xattrs, acls bool
+	// strict specifies that failing to preserve ownership is an error. With
+	// -p, it's silently skipped instead, since only superusers can give files
+	// away.
+	strict bool
+}
+
+// defaultPreserve is what -p preserves.
+var defaultPreserve = preserveOptions{mode: true, ownership: true, timestamps: true}
+
+// parsePreserve parses the argument to --preserve, which is a comma-separated
+// list of attributes.
+func parsePreserve(list string) (preserveOptions, error) {
+	opts := preserveOptions{strict: true}
+	for _, attr := range strings.Split(list, ",") {
+		switch strings.TrimSpace(attr) {
+		case "mode":
+			opts.mode = true
+		case "ownership":
+			opts.ownership = true
+		case "timestamps":
+			opts.timestamps = true
+		case "xattr":
+			opts.xattrs = true
+		case "acl":
+			opts.acls = true
+		case "all":
+			opts = preserveOptions{
+				mode:       true,
+				ownership:  true,
+				timestamps: true,
+				xattrs:     true,
+				acls:       true,
+				strict:     true,
+			}
+		default:
+			return opts, fmt.Errorf("invalid attribute for --preserve: %s", attr)
+		}
+	}
+
+	return opts, nil
+}
+
+func (p preserveOptions) any() bool {
+	return p.mode || p.ownership || p.timestamps || p.xattrs || p.acls
+}
+
+// ownershipError reports a failure to preserve ownership, unless it's because
+// of permissions and ownership wasn't asked for explicitly.
+func (p preserveOptions) ownershipError(err error) {
+	if p.strict || !os.IsPermission(err) {
+		printError(err)
+	}
+}
+
+// preserveLocal copies the attributes of a file in HDFS to a local copy of it.
+// Ownership can only be preserved if the owner and group also exist locally.
+func (p preserveOptions) preserveLocal(dest string, info *hdfs.FileInfo) {
+	if p.mode {
+		err := os.Chmod(dest, info.Mode().Perm())
+		if err != nil {
+			printError(err)
+		}
+	}
+
+	if p.ownership {
+		uid, gid := -1, -1
+		if u, err := user.Lookup(info.Owner()); err == nil {
+			uid, _ = strconv.Atoi(u.Uid)
+		}
+
+		if g, err := user.LookupGroup(info.OwnerGroup()); err == nil {
+			gid, _ = strconv.Atoi(g.Gid)
+		}
+
+		if uid != -1 || gid != -1 {
+			err := os.Lchown(dest, uid, gid)
+			if err != nil {
+				p.ownershipError(err)
+			}
+		}
+	}
+
+	if p.timestamps {
+		err := os.Chtimes(dest, info.AccessTime(), info.ModTime())
+		if err != nil {
+			printError(err)
+		}
+	}
+}
+
+// preserveRemote copies the attributes of a local file to a copy of it in
+// HDFS. The access time isn't preserved, since the local file was just read
+// to upload it; the modification time is used for both instead.
+func (p preserveOptions) preserveRemote(client *hdfs.Client, dest string, info os.FileInfo) {
+	if p.mode {
+		err := client.Chmod(dest, info.Mode().Perm())
+		if err != nil {
+			printError(err)
+		}
+	}
+
+	if p.ownership {
+		if owner, group, ok := localOwner(info); ok {
+			err := client.Chown(dest, owner, group)
+			if err != nil {
+				p.ownershipError(err)
+			}
+		}
+	}
+
+	if p.timestamps {
+		err := client.Chtimes(dest, info.ModTime(), info.ModTime())
+		if err != nil {
+			printError(err)
+		}
+	}
+}
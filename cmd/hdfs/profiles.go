@@ -0,0 +1,237 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cluster is the name of the cluster profile selected with --cluster, and
+// profile is the profile itself, once it's loaded.
+var (
+	cluster string
+	profile *clusterProfile
+)
+
+// clusterProfile is a named cluster defined in ~/.hdfs/config. The config
+// file is a simple subset of TOML, with one section per cluster:
+//
+//	[prod-eu]
+//	namenodes = ["nn1.prod-eu:8020", "nn2.prod-eu:8020"]
+//	hadoop_conf_dir = "/etc/hadoop/prod-eu"
+//	user = "etl"
+//	kerberos = true
+//	kerberos_config = "/etc/krb5.prod-eu.conf"
+//	kerberos_ccache = "/tmp/krb5cc_prod_eu"
+//	kerberos_keytab = "/etc/security/keytabs/etl.keytab"
+//	kerberos_principal = "etl@PROD-EU.EXAMPLE.COM"
+//	kerberos_service_principal = "nn/_HOST"
+//	timeout = "30s"
+//	retries = 3
+//	retry_interval = "5s"
+//
+// Every setting is optional. Anything that isn't set falls back to the
+// configuration in HADOOP_CONF_DIR and the usual environment variables, and
+// flags passed on the commandline take precedence over the profile.
+type clusterProfile struct {
+	name string
+
+	namenodes                []string
+	hadoopConfDir            string
+	user                     string
+	kerberos                 bool
+	kerberosConfig           string
+	kerberosCCache           string
+	kerberosKeytab           string
+	kerberosPrincipal        string
+	kerberosServicePrincipal string
+
+	timeout       time.Duration
+	retries       int
+	retryInterval time.Duration
+	set           map[string]bool
+}
+
+func profileConfigPath() (string, error) {
+	home := os.Getenv("HOME")
+	if home == "" {
+		u, err := user.Current()
+		if err != nil {
+			return "", err
+		}
+
+		home = u.HomeDir
+	}
+
+	return filepath.Join(home, ".hdfs", "config"), nil
+}
+
+// loadProfile reads the named cluster profile from ~/.hdfs/config.
+func loadProfile(name string) (*clusterProfile, error) {
+	configPath, err := profileConfigPath()
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	profiles, err := parseProfiles(f.Name(), bufio.NewScanner(f))
+	if err != nil {
+		return nil, err
+	}
+
+	profile, ok := profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("%s: no cluster named %q", configPath, name)
+	}
+
+	return profile, nil
+}
+
+func parseProfiles(filename string, scanner *bufio.Scanner) (map[string]*clusterProfile, error) {
+	profiles := make(map[string]*clusterProfile)
+
+	var current *clusterProfile
+	for lineno := 1; scanner.Scan(); lineno++ {
+		line := strings.TrimSpace(stripComment(scanner.Text()))
+		if line == "" {
+			continue
+		}
+
+		fail := func(format string, args ...interface{}) error {
+			return fmt.Errorf("%s:%d: %s", filename, lineno, fmt.Sprintf(format, args...))
+		}
+
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") {
+				return nil, fail("invalid section: %s", line)
+			}
+
+			name := unquote(strings.TrimSpace(line[1 : len(line)-1]))
+			current = &clusterProfile{name: name, set: make(map[string]bool)}
+			profiles[name] = current
+			continue
+		}
+
+		kv := strings.SplitN(line, "=", 2)
+		if len(kv) != 2 {
+			return nil, fail("expected key = value: %s", line)
+		} else if current == nil {
+			return nil, fail("setting outside of a [cluster] section: %s", line)
+		}
+
+		key := strings.TrimSpace(kv[0])
+		value := strings.TrimSpace(kv[1])
+		err := current.setValue(key, value)
+		if err != nil {
+			return nil, fail("%s: %s", key, err)
+		}
+	}
+
+	return profiles, scanner.Err()
+}
+
+func (p *clusterProfile) setValue(key, value string) error {
+	var err error
+	switch key {
+	case "namenodes":
+		p.namenodes, err = parseStringList(value)
+	case "hadoop_conf_dir":
+		p.hadoopConfDir = unquote(value)
+	case "user":
+		p.user = unquote(value)
+	case "kerberos":
+		p.kerberos, err = strconv.ParseBool(value)
+	case "kerberos_config":
+		p.kerberosConfig = unquote(value)
+	case "kerberos_ccache":
+		p.kerberosCCache = unquote(value)
+	case "kerberos_keytab":
+		p.kerberosKeytab = unquote(value)
+	case "kerberos_principal":
+		p.kerberosPrincipal = unquote(value)
+	case "kerberos_service_principal":
+		p.kerberosServicePrincipal = unquote(value)
+	case "timeout":
+		p.timeout, err = time.ParseDuration(unquote(value))
+	case "retries":
+		p.retries, err = strconv.Atoi(value)
+	case "retry_interval":
+		p.retryInterval, err = time.ParseDuration(unquote(value))
+	default:
+		return fmt.Errorf("unknown setting")
+	}
+
+	if err != nil {
+		return err
+	}
+
+	p.set[key] = true
+	return nil
+}
+
+// applyDefaults sets any of the global flags that weren't passed on the
+// commandline from the profile.
+func (p *clusterProfile) applyDefaults() {
+	if p.set["timeout"] && !globalFlagsSet["--timeout"] {
+		timeout = p.timeout
+	}
+
+	if p.set["retries"] && !globalFlagsSet["--retries"] {
+		retries = p.retries
+	}
+
+	if p.set["retry_interval"] && !globalFlagsSet["--retry-interval"] {
+		retryInterval = p.retryInterval
+	}
+}
+
+func stripComment(line string) string {
+	var quote rune
+	for i, c := range line {
+		switch {
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote == 0 && (c == '"' || c == '\''):
+			quote = c
+		case quote == 0 && c == '#':
+			return line[:i]
+		}
+	}
+
+	return line
+}
+
+func unquote(s string) string {
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+
+	return s
+}
+
+func parseStringList(value string) ([]string, error) {
+	if !strings.HasPrefix(value, "[") || !strings.HasSuffix(value, "]") {
+		return nil, fmt.Errorf("expected a list, like [\"a\", \"b\"]")
+	}
+
+	var res []string
+	for _, s := range strings.Split(value[1:len(value)-1], ",") {
+		s = strings.TrimSpace(s)
+		if s != "" {
+			res = append(res, unquote(s))
+		}
+	}
+
+	return res, nil
+}
@@ -1,8 +1,6 @@
 package main
 
 import (
-	"fmt"
-	"io"
 	"os"
 	"path"
 	"path/filepath"
@@ -10,7 +8,33 @@ import (
 	"github.com/colinmarc/hdfs/v2"
 )
 
-func put(args []string) {
+// putOptions holds the flags passed to put.
+type putOptions struct {
+	preserve bool
+	// blockSize and replication are used for the new files. If zero, the
+	// namenode's defaults are used.
+	blockSize   int64
+	replication int
+}
+
+func newPutOptions(preserve bool, blockSize string, replication int) putOptions {
+	opts := putOptions{preserve: preserve, replication: replication}
+	if blockSize != "" {
+		var err error
+		opts.blockSize, err = parseBytes(blockSize)
+		if err != nil {
+			fatalWithUsage("Invalid value for --blocksize:", blockSize)
+		}
+	}
+
+	if replication < 0 {
+		fatalWithUsage("Invalid value for --replication:", replication)
+	}
+
+	return opts
+}
+
+func put(args []string, opts putOptions) {
 	if len(args) != 2 {
 		printHelp()
 	}
@@ -32,13 +56,15 @@ func put(args []string) {
 	}
 
 	if filepath.Base(source) == "-" {
-		putFromStdin(client, dest)
+		putFromStdin(client, dest, opts)
 	} else {
-		putFromFile(client, source, dest)
+		putFromFile(client, source, dest, opts)
 	}
 }
 
-func putFromStdin(client *hdfs.Client, dest string) {
+// putFromStdin streams stdin into a new file. If reading or writing fails
+// partway through, the partial file is removed.
+func putFromStdin(client *hdfs.Client, dest string, opts putOptions) {
 	// If the destination exists, regardless of what it is, bail out.
 	_, err := client.Stat(dest)
 	if err == nil {
@@ -55,16 +81,27 @@ func putFromStdin(client *hdfs.Client, dest string) {
 		}
 	}
 
-	writer, err := client.Create(dest)
+	writer, err := opts.create(client, dest)
 	if err != nil {
 		fatal(err)
 	}
-	defer writer.Close()
 
-	io.Copy(writer, os.Stdin)
+	// This reads ahead while the previous chunk is written, so that whatever
+	// is writing to stdin isn't held up waiting for the datanodes.
+	_, err = pipelinedCopy(writer, workerLimiter().reader(os.Stdin))
+	if err == nil {
+		err = writer.Close()
+	} else {
+		writer.Close()
+	}
+
+	if err != nil {
+		client.Remove(dest)
+		fatal(&os.PathError{"put", dest, err})
+	}
 }
 
-func putFromFile(client *hdfs.Client, source string, dest string) {
+func putFromFile(client *hdfs.Client, source string, dest string, opts putOptions) {
 	// If the destination is an existing directory, place it inside. Otherwise,
 	// the destination is really the parent directory, and we need to rename the
 	// source directory as we copy.
@@ -80,42 +117,74 @@ func putFromFile(client *hdfs.Client, source string, dest string) {
 	}
 
 	mode := 0755 | os.ModeDir
+	limiter := workerLimiter()
+
+	// The attributes of directories are set once everything inside them has
+	// been uploaded, so that the mtimes aren't clobbered.
+	var dirs []string
+	var dirInfos []os.FileInfo
 	err = filepath.Walk(source, func(p string, fi os.FileInfo, err error) error {
 		if err != nil {
-			fmt.Fprintln(os.Stderr, err)
+			printError(err)
 			return nil
 		}
 
 		rel, err := filepath.Rel(source, p)
 		if err != nil {
-			fmt.Fprintln(os.Stderr, err)
+			printError(err)
 			return nil
 		}
 
 		fullDest := path.Join(dest, rel)
 		if fi.IsDir() {
 			client.Mkdir(fullDest, mode)
-		} else {
-			writer, err := client.Create(fullDest)
-			if err != nil {
-				fmt.Fprintln(os.Stderr, err)
-				return nil
-			}
-
-			defer writer.Close()
-			reader, err := os.Open(p)
-			if err != nil {
-				fmt.Fprintln(os.Stderr, err)
-				return nil
+			if opts.preserve {
+				dirs = append(dirs, fullDest)
+				dirInfos = append(dirInfos, fi)
 			}
-
-			defer reader.Close()
-			_, err = io.Copy(writer, reader)
+		} else {
+			err = putFile(client, p, fullDest, opts, limiter)
 			if err != nil {
-				fmt.Fprintln(os.Stderr, err)
+				printError(err)
+			} else if opts.preserve {
+				defaultPreserve.preserveRemote(client, fullDest, fi)
 			}
 		}
 
 		return nil
 	})
+
+	for i := len(dirs) - 1; i >= 0; i-- {
+		defaultPreserve.preserveRemote(client, dirs[i], dirInfos[i])
+	}
+}
+
+func putFile(client *hdfs.Client, source, dest string, opts putOptions, limiter *rateLimiter) error {
+	writer, err := opts.create(client, dest)
+	if err != nil {
+		return err
+	}
+
+	defer writer.Close()
+	reader, err := os.Open(source)
+	if err != nil {
+		return err
+	}
+
+	defer reader.Close()
+	// Like Client.CopyToRemote, this calls ReadFrom directly, rather than
+	// leaving it to io.Copy, which would try os.File's WriteTo first.
+	_, err = writer.ReadFrom(limiter.reader(reader))
+	if err != nil {
+		return err
+	}
+
+	return writer.Close()
+}
+
+func (opts putOptions) create(client *hdfs.Client, name string) (*hdfs.FileWriter, error) {
+	return client.CreateWithOptions(name, hdfs.CreateOptions{
+		BlockSize:   opts.blockSize,
+		Replication: opts.replication,
+	})
 }
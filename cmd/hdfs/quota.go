@@ -0,0 +1,80 @@
+package main
+
+import (
+	"strconv"
+)
+
+// setQuota sets the name quota on each directory, or clears it if clear is
+// true, for setquota and clrquota. Unless clear is true, the first argument
+// is the quota.
+func setQuota(args []string, clear bool) {
+	quota := int64(-1)
+	if !clear {
+		if len(args) == 0 {
+			printHelp()
+		}
+
+		n, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil || n <= 0 {
+			fatal("invalid quota:", args[0])
+		}
+
+		quota, args = n, args[1:]
+	}
+
+	if len(args) == 0 {
+		printHelp()
+	}
+
+	expanded, client, err := getClientAndExpandedPaths(args)
+	if err != nil {
+		fatal(err)
+	}
+
+	for _, p := range expanded {
+		err := client.SetQuota(p, quota)
+		if err != nil {
+			printError(err)
+		}
+	}
+}
+
+// setSpaceQuota is like setQuota, but for the space quota, for setspacequota
+// and clrspacequota. If storageType is set, only the quota for that type of
+// storage is changed.
+func setSpaceQuota(args []string, clear bool, storageType string) {
+	quota := int64(-1)
+	if !clear {
+		if len(args) == 0 {
+			printHelp()
+		}
+
+		n, err := parseBytes(args[0])
+		if err != nil {
+			fatal("invalid space quota:", args[0])
+		}
+
+		quota, args = n, args[1:]
+	}
+
+	if len(args) == 0 {
+		printHelp()
+	}
+
+	expanded, client, err := getClientAndExpandedPaths(args)
+	if err != nil {
+		fatal(err)
+	}
+
+	for _, p := range expanded {
+		if storageType != "" {
+			err = client.SetStorageTypeQuota(p, storageType, quota)
+		} else {
+			err = client.SetSpaceQuota(p, quota)
+		}
+
+		if err != nil {
+			printError(err)
+		}
+	}
+}
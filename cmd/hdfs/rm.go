@@ -6,7 +6,7 @@ import (
 	"os"
 )
 
-func rm(paths []string, recursive bool, force bool) {
+func rm(paths []string, recursive, force, skipTrash bool) {
 	expanded, client, err := getClientAndExpandedPaths(paths)
 	if err != nil {
 		fatal(err)
@@ -23,17 +23,25 @@ func rm(paths []string, recursive bool, force bool) {
 				pathErr.Op = "remove"
 			}
 
-			fmt.Fprintln(os.Stderr, err)
-			status = 1
+			printError(err)
 			continue
 		}
 
 		if !recursive && info.IsDir() {
-			fmt.Fprintln(os.Stderr, &os.PathError{"remove", p, errors.New("file is a directory")})
-			status = 1
+			printError(&os.PathError{"remove", p, errors.New("file is a directory")})
 			continue
 		}
 
+		if !skipTrash {
+			dst, err := client.MoveToTrash(p)
+			if err != nil {
+				fatal(err)
+			} else if dst != "" {
+				fmt.Printf("Moved: '%s' to trash at: %s\n", p, dst)
+				continue
+			}
+		}
+
 		err = client.RemoveAll(p)
 		if err != nil {
 			fatal(err)
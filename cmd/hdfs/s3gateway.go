@@ -0,0 +1,96 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/colinmarc/hdfs/v2"
+	"github.com/colinmarc/hdfs/v2/s3gateway"
+)
+
+func serveS3Gateway(args []string, listen, credentialsFile string) {
+	if len(args) != 1 || credentialsFile == "" {
+		printHelp()
+	}
+
+	paths, namenode, err := normalizePaths(args)
+	if err != nil {
+		fatal(err)
+	}
+
+	credentials, err := loadS3Credentials(credentialsFile)
+	if err != nil {
+		fatal("Problem loading credentials:", err)
+	}
+
+	// Check the configuration and the root before starting to listen.
+	client, err := getClient(namenode)
+	if err != nil {
+		fatal(err)
+	}
+
+	info, err := client.Stat(paths[0])
+	if err != nil {
+		fatal(err)
+	} else if !info.IsDir() {
+		fatal(paths[0], "is not a directory")
+	}
+
+	server, err := s3gateway.New(s3gateway.Options{
+		Root:        paths[0],
+		Credentials: credentials,
+		Client: func(user string) (*hdfs.Client, error) {
+			return newClient(namenode, user)
+		},
+	})
+	if err != nil {
+		fatal(err)
+	}
+	defer server.Close()
+
+	fmt.Fprintf(os.Stderr, "Serving %s on %s\n", paths[0], listen)
+	err = http.ListenAndServe(listen, server)
+	if err != nil {
+		fatal(err)
+	}
+}
+
+// loadS3Credentials reads access keys from a file with a key on each line, as
+// the access key ID, secret access key, and HDFS user, separated by
+// whitespace. Empty lines and lines starting with '#' are ignored.
+func loadS3Credentials(name string) ([]s3gateway.Credential, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var credentials []s3gateway.Credential
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("%s:%d: expected ACCESS_KEY_ID SECRET_ACCESS_KEY USER", name, n)
+		}
+
+		credentials = append(credentials, s3gateway.Credential{
+			AccessKeyID:     fields[0],
+			SecretAccessKey: fields[1],
+			User:            fields[2],
+		})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return credentials, nil
+}
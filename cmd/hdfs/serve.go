@@ -0,0 +1,28 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/colinmarc/hdfs/v2/proxy"
+)
+
+func serve(args []string, listen string) {
+	if len(args) != 0 {
+		printHelp()
+	}
+
+	client, err := getClient("")
+	if err != nil {
+		fatal(err)
+	}
+
+	server := proxy.New(client)
+	defer server.Close()
+
+	fmt.Fprintf(os.Stderr, "Serving gRPC on %s\n", listen)
+	err = server.ListenAndServe(listen)
+	if err != nil {
+		fatal(err)
+	}
+}
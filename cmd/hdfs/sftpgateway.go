@@ -0,0 +1,109 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/colinmarc/hdfs/v2"
+	"github.com/colinmarc/hdfs/v2/sftpgateway"
+	"golang.org/x/crypto/ssh"
+)
+
+func serveSFTPGateway(args []string, listen, hostKeyFile, authorizedKeysFile string) {
+	if len(args) != 1 || hostKeyFile == "" || authorizedKeysFile == "" {
+		printHelp()
+	}
+
+	paths, namenode, err := normalizePaths(args)
+	if err != nil {
+		fatal(err)
+	}
+
+	b, err := ioutil.ReadFile(hostKeyFile)
+	if err != nil {
+		fatal("Problem loading host key:", err)
+	}
+
+	hostKey, err := ssh.ParsePrivateKey(b)
+	if err != nil {
+		fatal("Problem loading host key:", err)
+	}
+
+	authorizedKeys, err := loadAuthorizedKeys(authorizedKeysFile)
+	if err != nil {
+		fatal("Problem loading authorized keys:", err)
+	}
+
+	// Check the configuration and the root before starting to listen.
+	client, err := getClient(namenode)
+	if err != nil {
+		fatal(err)
+	}
+
+	info, err := client.Stat(paths[0])
+	if err != nil {
+		fatal(err)
+	} else if !info.IsDir() {
+		fatal(paths[0], "is not a directory")
+	}
+
+	server, err := sftpgateway.New(sftpgateway.Options{
+		Root:           paths[0],
+		HostKeys:       []ssh.Signer{hostKey},
+		AuthorizedKeys: authorizedKeys,
+		Client: func(user string) (*hdfs.Client, error) {
+			return newClient(namenode, user)
+		},
+	})
+	if err != nil {
+		fatal(err)
+	}
+	defer server.Close()
+
+	fmt.Fprintf(os.Stderr, "Serving %s on %s\n", paths[0], listen)
+	err = server.ListenAndServe(listen)
+	if err != nil {
+		fatal(err)
+	}
+}
+
+// loadAuthorizedKeys reads public keys from a file with a key on each line,
+// as the HDFS user followed by the key in the authorized_keys format used by
+// OpenSSH. Empty lines and lines starting with '#' are ignored.
+func loadAuthorizedKeys(name string) ([]sftpgateway.AuthorizedKey, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var keys []sftpgateway.AuthorizedKey
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.SplitN(line, " ", 2)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("%s:%d: expected USER KEY", name, n)
+		}
+
+		key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(strings.TrimSpace(fields[1])))
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %s", name, n, err)
+		}
+
+		keys = append(keys, sftpgateway.AuthorizedKey{User: fields[0], PublicKey: key})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/colinmarc/hdfs/v2"
+)
+
+func snapshot(args []string) {
+	if len(args) < 2 {
+		printHelp()
+	}
+
+	subcommand, dir, args := args[0], args[1], args[2:]
+	var nargs int
+	switch subcommand {
+	case "allow", "disallow":
+		nargs = 0
+	case "create":
+		if len(args) == 0 {
+			args = append(args, "")
+		}
+
+		nargs = 1
+	case "delete":
+		nargs = 1
+	case "diff":
+		if len(args) == 1 {
+			args = append(args, "")
+		}
+
+		nargs = 2
+	case "rename":
+		nargs = 2
+	default:
+		fatalWithUsage("Unknown snapshot command:", subcommand)
+	}
+
+	if len(args) != nargs {
+		printHelp()
+	}
+
+	paths, nn, err := normalizePaths([]string{dir})
+	if err != nil {
+		fatal(err)
+	}
+
+	client, err := getClient(nn)
+	if err != nil {
+		fatal(err)
+	}
+
+	dir = paths[0]
+	switch subcommand {
+	case "allow":
+		err = client.AllowSnapshot(dir)
+	case "disallow":
+		err = client.DisallowSnapshot(dir)
+	case "create":
+		var p string
+		p, err = client.CreateSnapshot(dir, args[0])
+		if err == nil {
+			fmt.Println(p)
+		}
+	case "delete":
+		err = client.DeleteSnapshot(dir, args[0])
+	case "rename":
+		err = client.RenameSnapshot(dir, args[0], args[1])
+	case "diff":
+		err = snapshotDiff(client, dir, args[0], args[1])
+	}
+
+	if err != nil {
+		fatal(err)
+	}
+}
+
+// snapshotDiff prints the changes between two snapshots in the same format as
+// 'hdfs snapshotDiff', with paths relative to the snapshot root.
+func snapshotDiff(client *hdfs.Client, dir, from, to string) error {
+	report, err := client.GetSnapshotDiffReport(dir, from, to)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range report.Entries {
+		if entry.Type == hdfs.SnapshotDiffRename {
+			fmt.Printf("%s\t%s -> %s\n", entry.Type, path.Join(".", entry.Path), path.Join(".", entry.Target))
+		} else {
+			fmt.Printf("%s\t%s\n", entry.Type, path.Join(".", entry.Path))
+		}
+	}
+
+	return nil
+}
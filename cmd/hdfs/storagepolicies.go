@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+)
+
+func storagePolicies(args []string, satisfy bool) {
+	if len(args) == 0 {
+		printHelp()
+	}
+
+	subcommand, args := args[0], args[1:]
+	switch subcommand {
+	case "list":
+		listStoragePolicies()
+		return
+	case "get", "set", "unset", "satisfy":
+	default:
+		fatalWithUsage("Unknown storagepolicies command:", subcommand)
+	}
+
+	var policy string
+	if subcommand == "set" {
+		if len(args) == 0 {
+			printHelp()
+		}
+
+		policy, args = args[0], args[1:]
+	}
+
+	expanded, client, err := getClientAndExpandedPaths(args)
+	if err != nil {
+		fatal(err)
+	} else if len(expanded) == 0 {
+		printHelp()
+	}
+
+	for _, p := range expanded {
+		switch subcommand {
+		case "get":
+			sp, err := client.GetStoragePolicy(p)
+			if err != nil {
+				printError(err)
+				continue
+			}
+
+			fmt.Printf("%s\t%s\n", p, sp.Name)
+		case "set":
+			err = client.SetStoragePolicy(p, policy)
+			if err == nil && satisfy {
+				err = client.SatisfyStoragePolicy(p)
+			}
+		case "unset":
+			err = client.UnsetStoragePolicy(p)
+		case "satisfy":
+			err = client.SatisfyStoragePolicy(p)
+		}
+
+		if err != nil {
+			printError(err)
+		}
+	}
+}
+
+func listStoragePolicies() {
+	client, err := getClient("")
+	if err != nil {
+		fatal(err)
+	}
+
+	policies, err := client.GetStoragePolicies()
+	if err != nil {
+		fatal(err)
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 3, 8, 1, ' ', 0)
+	fmt.Fprintf(tw, "ID\tName\tStorage Types\tCreation Fallbacks\tReplication Fallbacks\n")
+	for _, p := range policies {
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\n",
+			p.ID,
+			p.Name,
+			strings.Join(p.StorageTypes, ","),
+			strings.Join(p.CreationFallbacks, ","),
+			strings.Join(p.ReplicationFallbacks, ","))
+	}
+
+	tw.Flush()
+}
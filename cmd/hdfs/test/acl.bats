@@ -0,0 +1,74 @@
+#!/usr/bin/env bats
+
+load helper
+
+setup() {
+  $HDFS mkdir -p /_test_cmd/acl
+  $HDFS chmod 750 /_test_cmd/acl
+}
+
+@test "getfacl without an acl" {
+  run $HDFS getfacl /_test_cmd/acl
+  assert_success
+  [[ "${lines[0]}" == "# file: /_test_cmd/acl" ]]
+  [[ "${lines[3]}" == "user::rwx" ]]
+  [[ "${lines[4]}" == "group::r-x" ]]
+  [[ "${lines[5]}" == "other::---" ]]
+}
+
+@test "setfacl modify and remove" {
+  run $HDFS setfacl -m user:gohdfs2:rwx,default:group:foo:r-x /_test_cmd/acl
+  assert_success
+
+  run $HDFS getfacl /_test_cmd/acl
+  assert_success
+  [[ "$output" == *"user:gohdfs2:rwx"* ]]
+  [[ "$output" == *"default:group:foo:r-x"* ]]
+
+  run $HDFS setfacl -x user:gohdfs2 /_test_cmd/acl
+  assert_success
+
+  run $HDFS getfacl /_test_cmd/acl
+  assert_success
+  [[ "$output" != *"user:gohdfs2"* ]]
+
+  run $HDFS setfacl -k /_test_cmd/acl
+  assert_success
+
+  run $HDFS getfacl /_test_cmd/acl
+  assert_success
+  [[ "$output" != *"default:"* ]]
+}
+
+@test "setfacl effective permissions" {
+  run $HDFS setfacl --set user::rwx,user:gohdfs2:rwx,group::r-x,mask::r--,other::--- /_test_cmd/acl
+  assert_success
+
+  run $HDFS getfacl /_test_cmd/acl
+  assert_success
+  [[ "$output" == *"$(printf 'user:gohdfs2:rwx\t#effective:r--')"* ]]
+
+  run $HDFS setfacl -b /_test_cmd/acl
+  assert_success
+
+  run $HDFS getfacl /_test_cmd/acl
+  assert_success
+  [[ "$output" != *"user:gohdfs2"* ]]
+}
+
+@test "setfacl invalid spec" {
+  run $HDFS setfacl -m user:gohdfs2:rwz /_test_cmd/acl
+  assert_failure
+}
+
+@test "getfacl nonexistent" {
+  run $HDFS getfacl /_test_cmd/nonexistent
+  assert_failure
+  assert_output <<OUT
+getfacl: \`/_test_cmd/nonexistent': No such file or directory
+OUT
+}
+
+teardown() {
+  $HDFS rm -r /_test_cmd/acl
+}
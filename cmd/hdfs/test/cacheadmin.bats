@@ -0,0 +1,72 @@
+#!/usr/bin/env bats
+
+load helper
+
+setup() {
+  $HDFS mkdir -p /_test_cmd/cacheadmin
+  $HDFS put $ROOT_TEST_DIR/testdata/foo.txt /_test_cmd/cacheadmin/foo.txt
+  $HDFS cacheadmin -removePool _test_cmd_pool > /dev/null 2>&1 || true
+}
+
+@test "cacheadmin pools" {
+  run $HDFS cacheadmin -addPool _test_cmd_pool -mode 750 -limit 1M -maxTtl 7d
+  assert_success
+
+  run $HDFS cacheadmin -listPools _test_cmd_pool
+  assert_success
+  [[ "${lines[1]}" =~ ^_test_cmd_pool\ +[^\ ]+\ +[^\ ]+\ +rwxr-x---\ +1048576\ +168h0m0s$ ]]
+
+  run $HDFS cacheadmin -modifyPool _test_cmd_pool -limit unlimited -maxTtl never
+  assert_success
+
+  run $HDFS cacheadmin -listPools _test_cmd_pool
+  assert_success
+  [[ "${lines[1]}" =~ unlimited\ +never$ ]]
+
+  run $HDFS cacheadmin -removePool _test_cmd_pool
+  assert_success
+
+  run $HDFS cacheadmin -listPools _test_cmd_pool
+  assert_success
+  assert_equal 1 "${#lines[@]}"
+}
+
+@test "cacheadmin directives" {
+  $HDFS cacheadmin -addPool _test_cmd_pool
+
+  run $HDFS cacheadmin -addDirective -path /_test_cmd/cacheadmin/foo.txt -pool _test_cmd_pool -force
+  assert_success
+  [[ "$output" =~ ^"Added cache directive "([0-9]+)$ ]]
+  id=${BASH_REMATCH[1]}
+
+  run $HDFS cacheadmin -listDirectives -pool _test_cmd_pool
+  assert_success
+  [[ "${lines[1]}" =~ ^$id\ +_test_cmd_pool\ +1\ +never\ +/_test_cmd/cacheadmin/foo.txt$ ]]
+
+  run $HDFS cacheadmin -modifyDirective -id $id -replication 2 -force
+  assert_success
+  assert_output "Modified cache directive $id"
+
+  run $HDFS cacheadmin -listDirectives -id $id
+  assert_success
+  [[ "${lines[1]}" =~ ^$id\ +_test_cmd_pool\ +2\  ]]
+
+  run $HDFS cacheadmin -removeDirectives -path /_test_cmd/cacheadmin/foo.txt
+  assert_success
+  assert_output "Removed cache directive $id"
+}
+
+@test "cacheadmin nonexistent pool" {
+  run $HDFS cacheadmin -addDirective -path /_test_cmd/cacheadmin/foo.txt -pool _test_cmd_nonexistent
+  assert_failure
+}
+
+@test "cacheadmin unknown command" {
+  run $HDFS cacheadmin -foo
+  assert_failure
+}
+
+teardown() {
+  $HDFS cacheadmin -removePool _test_cmd_pool > /dev/null 2>&1 || true
+  $HDFS rm -r /_test_cmd/cacheadmin
+}
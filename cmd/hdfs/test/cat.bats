@@ -16,10 +16,28 @@ load helper
   assert_equal $SHA `shasum < $BATS_TMPDIR/mobydick_test.txt | awk '{ print $1 }'`
 }
 
+@test "cat range" {
+  run $HDFS cat --offset 1048570 --length 20 /_test/mobydick.txt
+  assert_success
+  assert_output "$(tail -c +1048571 $ROOT_TEST_DIR/testdata/mobydick.txt | head -c 20)"
+}
+
+@test "cat range to end" {
+  run $HDFS cat --offset 1 /_test/foo.txt
+  assert_success
+  assert_output "ar"
+}
+
+@test "cat range past end" {
+  run $HDFS cat --offset 100 /_test/foo.txt
+  assert_success
+  assert_output ""
+}
+
 @test "cat nonexistent" {
   run $HDFS cat /_test_cmd/nonexistent
   assert_failure
   assert_output <<OUT
-open /_test_cmd/nonexistent: file does not exist
+cat: \`/_test_cmd/nonexistent': No such file or directory
 OUT
 }
@@ -17,10 +17,10 @@ OUT
 }
 
 @test "checksum nonexistent" {
-  run $HDFS cat /_test_cmd/nonexistent
+  run $HDFS checksum /_test_cmd/nonexistent
   assert_failure
   assert_output <<OUT
-open /_test_cmd/nonexistent: file does not exist
+checksum: \`/_test_cmd/nonexistent': No such file or directory
 OUT
 }
 
@@ -0,0 +1,52 @@
+#!/usr/bin/env bats
+
+load helper
+
+setup() {
+  $HDFS mkdir -p /_test_cmd/concat
+  echo target | $HDFS put - /_test_cmd/concat/target
+  echo part0 | $HDFS put - /_test_cmd/concat/part-00000
+  echo part1 | $HDFS put - /_test_cmd/concat/part-00001
+}
+
+@test "concat" {
+  run $HDFS concat /_test_cmd/concat/target /_test_cmd/concat/part-00001 /_test_cmd/concat/part-00000
+  assert_success
+
+  run $HDFS cat /_test_cmd/concat/target
+  assert_success
+  assert_output <<OUT
+target
+part1
+part0
+OUT
+
+  run $HDFS ls /_test_cmd/concat
+  assert_success
+  assert_output "target"
+}
+
+@test "concat sorted" {
+  run $HDFS concat --sort /_test_cmd/concat/target /_test_cmd/concat/part-*
+  assert_success
+
+  run $HDFS cat /_test_cmd/concat/target
+  assert_success
+  assert_output <<OUT
+target
+part0
+part1
+OUT
+}
+
+@test "concat nonexistent" {
+  run $HDFS concat /_test_cmd/nonexistent /_test_cmd/concat/part-00000
+  assert_failure
+  assert_output <<OUT
+concat: \`/_test_cmd/nonexistent': No such file or directory
+OUT
+}
+
+teardown() {
+  $HDFS rm -r /_test_cmd/concat
+}
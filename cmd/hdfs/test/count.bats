@@ -0,0 +1,63 @@
+#!/usr/bin/env bats
+
+load helper
+
+setup() {
+  $HDFS mkdir -p /_test_cmd/count/dir
+  $HADOOP_FS -cp hdfs://$HADOOP_NAMENODE/_test/foo.txt hdfs://$HADOOP_NAMENODE/_test_cmd/count/foo.txt
+}
+
+@test "count" {
+  run $HDFS count -v /_test_cmd/count
+  assert_success
+  assert_output <<OUT
+ DIR_COUNT FILE_COUNT CONTENT_SIZE PATHNAME
+         2          1            4 /_test_cmd/count
+OUT
+}
+
+@test "count quotas" {
+  run $HDFS count -q /_test_cmd/count
+  assert_success
+  assert_output <<OUT
+ none inf none inf  2  1  4 /_test_cmd/count
+OUT
+
+  run $HDFS setquota 10 /_test_cmd/count
+  assert_success
+
+  run $HDFS setspacequota 1G /_test_cmd/count
+  assert_success
+
+  run $HDFS count -qh /_test_cmd/count
+  assert_success
+  assert_output <<OUT
+ 10  7 1024.0M 1024.0M  2  1 4B /_test_cmd/count
+OUT
+
+  run $HDFS clrquota /_test_cmd/count
+  assert_success
+
+  run $HDFS clrspacequota /_test_cmd/count
+  assert_success
+
+  run $HDFS count -q /_test_cmd/count
+  assert_success
+  assert_output <<OUT
+ none inf none inf  2  1  4 /_test_cmd/count
+OUT
+}
+
+@test "setquota invalid" {
+  run $HDFS setquota foo /_test_cmd/count
+  assert_failure
+}
+
+@test "count nonexistent" {
+  run $HDFS count /_test_cmd/nonexistent
+  assert_failure
+}
+
+teardown() {
+  $HDFS rm -r /_test_cmd/count
+}
@@ -0,0 +1,100 @@
+#!/usr/bin/env bats
+
+load helper
+
+setup() {
+  $HDFS mkdir -p /_test_cmd/cp/dir/sub
+  $HDFS put $ROOT_TEST_DIR/testdata/mobydick.txt /_test_cmd/cp/mobydick.txt
+  $HDFS put $ROOT_TEST_DIR/testdata/foo.txt /_test_cmd/cp/dir/foo.txt
+  $HDFS put $ROOT_TEST_DIR/testdata/foo.txt /_test_cmd/cp/dir/sub/foo.txt
+}
+
+@test "cp" {
+  run $HDFS cp /_test_cmd/cp/mobydick.txt /_test_cmd/cp/copied.txt
+  assert_success
+  assert_output ""
+
+  run $HDFS checksum /_test_cmd/cp/copied.txt
+  assert_success
+  original=$($HDFS checksum /_test_cmd/cp/mobydick.txt | awk '{ print $1 }')
+  [[ "$output" == "$original"* ]]
+}
+
+@test "cp into dir" {
+  run $HDFS cp /_test_cmd/cp/mobydick.txt /_test_cmd/cp/dir
+  assert_success
+
+  run $HDFS cat /_test_cmd/cp/dir/mobydick.txt
+  assert_success
+  assert_output "$(cat $ROOT_TEST_DIR/testdata/mobydick.txt)"
+}
+
+@test "cp dir" {
+  run $HDFS cp /_test_cmd/cp/dir /_test_cmd/cp/dir2
+  assert_success
+
+  run $HDFS cat /_test_cmd/cp/dir2/foo.txt /_test_cmd/cp/dir2/sub/foo.txt
+  assert_success
+  assert_output <<OUT
+bar
+bar
+OUT
+}
+
+@test "cp preserve" {
+  $HDFS chmod 600 /_test_cmd/cp/mobydick.txt
+  run $HDFS cp -p /_test_cmd/cp/mobydick.txt /_test_cmd/cp/preserved.txt
+  assert_success
+
+  run $HDFS ls -l /_test_cmd/cp/preserved.txt
+  assert_success
+  [[ "$output" == *-rw-------* ]]
+  original=$($HDFS ls -l /_test_cmd/cp/mobydick.txt | awk '{ print $4, $5, $6, $7 }')
+  assert_equal "$original" "$(echo $output | awk '{ print $4, $5, $6, $7 }')"
+}
+
+@test "cp preserve list" {
+  $HDFS chmod 600 /_test_cmd/cp/mobydick.txt
+  run $HDFS cp --preserve=mode /_test_cmd/cp/mobydick.txt /_test_cmd/cp/preserved.txt
+  assert_success
+
+  run $HDFS ls -l /_test_cmd/cp/preserved.txt
+  assert_success
+  [[ "$output" == *-rw-------* ]]
+}
+
+@test "cp preserve acl" {
+  $HADOOP_FS -setfacl -m user:gohdfs2:r-x hdfs://$HADOOP_NAMENODE/_test_cmd/cp/mobydick.txt
+  run $HDFS cp --preserve=acl,xattr /_test_cmd/cp/mobydick.txt /_test_cmd/cp/preserved.txt
+  assert_success
+
+  run $HADOOP_FS -getfacl hdfs://$HADOOP_NAMENODE/_test_cmd/cp/preserved.txt
+  assert_success
+  assert_line "user:gohdfs2:r-x"
+}
+
+@test "cp preserve invalid" {
+  run $HDFS cp --preserve=bogus /_test_cmd/cp/mobydick.txt /_test_cmd/cp/preserved.txt
+  assert_failure
+  [ "$status" -eq 255 ]
+}
+
+@test "cp existing" {
+  run $HDFS cp /_test_cmd/cp/mobydick.txt /_test_cmd/cp/dir/foo.txt
+  assert_failure
+  assert_output <<OUT
+cp: \`/_test_cmd/cp/dir/foo.txt': File exists
+OUT
+}
+
+@test "cp nonexistent" {
+  run $HDFS cp /_test_cmd/nonexistent /_test_cmd/cp/nonexistent
+  assert_failure
+  assert_output <<OUT
+cp: \`/_test_cmd/nonexistent': No such file or directory
+OUT
+}
+
+teardown() {
+  $HDFS rm -r /_test_cmd/cp
+}
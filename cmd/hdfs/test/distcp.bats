@@ -0,0 +1,115 @@
+#!/usr/bin/env bats
+
+load helper
+
+setup() {
+  $HDFS mkdir -p /_test_cmd/distcp/src/sub
+  $HDFS put $ROOT_TEST_DIR/testdata/mobydick.txt /_test_cmd/distcp/src/mobydick.txt
+  $HDFS put $ROOT_TEST_DIR/testdata/foo.txt /_test_cmd/distcp/src/sub/foo.txt
+
+  rm -rf $BATS_TMPDIR/distcp
+  mkdir -p $BATS_TMPDIR/distcp/src/sub
+  cp $ROOT_TEST_DIR/testdata/mobydick.txt $BATS_TMPDIR/distcp/src/mobydick.txt
+  cp $ROOT_TEST_DIR/testdata/foo.txt $BATS_TMPDIR/distcp/src/sub/foo.txt
+}
+
+@test "distcp hdfs to hdfs" {
+  run $HDFS distcp /_test_cmd/distcp/src /_test_cmd/distcp/dst
+  assert_success
+  assert_output "Copied 2 files (1.2M), skipped 0 up-to-date files."
+
+  run $HDFS cat /_test_cmd/distcp/dst/sub/foo.txt
+  assert_success
+  assert_output "bar"
+
+  original=$($HDFS checksum /_test_cmd/distcp/src/mobydick.txt | awk '{ print $1 }')
+  copied=$($HDFS checksum /_test_cmd/distcp/dst/mobydick.txt | awk '{ print $1 }')
+  assert_equal "$original" "$copied"
+}
+
+@test "distcp local to hdfs" {
+  run $HDFS distcp -m 2 file://$BATS_TMPDIR/distcp/src /_test_cmd/distcp/dst
+  assert_success
+
+  run $HDFS cat /_test_cmd/distcp/dst/sub/foo.txt
+  assert_success
+  assert_output "bar"
+}
+
+@test "distcp hdfs to local" {
+  run $HDFS distcp /_test_cmd/distcp/src file://$BATS_TMPDIR/distcp/dst
+  assert_success
+
+  run cat $BATS_TMPDIR/distcp/dst/sub/foo.txt
+  assert_output "bar"
+  assert_equal "$(shasum < $ROOT_TEST_DIR/testdata/mobydick.txt)" "$(shasum < $BATS_TMPDIR/distcp/dst/mobydick.txt)"
+}
+
+@test "distcp existing" {
+  $HDFS distcp /_test_cmd/distcp/src /_test_cmd/distcp/dst
+  run $HDFS distcp /_test_cmd/distcp/src/mobydick.txt /_test_cmd/distcp/dst/mobydick.txt
+  assert_failure
+}
+
+@test "distcp update" {
+  $HDFS distcp /_test_cmd/distcp/src /_test_cmd/distcp/dst
+  $HDFS rm /_test_cmd/distcp/dst/sub/foo.txt
+  $HDFS put $ROOT_TEST_DIR/testdata/mobydick.txt /_test_cmd/distcp/dst/sub/foo.txt
+
+  run $HDFS distcp -u /_test_cmd/distcp/src /_test_cmd/distcp/dst
+  assert_success
+  assert_output "Copied 1 files (4B), skipped 1 up-to-date files."
+
+  run $HDFS cat /_test_cmd/distcp/dst/sub/foo.txt
+  assert_output "bar"
+
+  run $HDFS distcp -u file://$BATS_TMPDIR/distcp/src /_test_cmd/distcp/dst
+  assert_success
+  assert_output "Copied 0 files (0B), skipped 2 up-to-date files."
+}
+
+@test "distcp manifest" {
+  run $HDFS distcp --manifest $BATS_TMPDIR/distcp/manifest /_test_cmd/distcp/src /_test_cmd/distcp/dst
+  assert_success
+
+  run cat $BATS_TMPDIR/distcp/manifest
+  assert_line "/_test_cmd/distcp/src/mobydick.txt"
+  assert_line "/_test_cmd/distcp/src/sub/foo.txt"
+
+  # Files in the manifest aren't checked again, so this doesn't fail even
+  # without --update.
+  run $HDFS distcp --manifest $BATS_TMPDIR/distcp/manifest /_test_cmd/distcp/src /_test_cmd/distcp/dst
+  assert_success
+  assert_output "Copied 0 files (0B), skipped 2 up-to-date files."
+}
+
+@test "distcp manifest with a different destination" {
+  run $HDFS distcp --manifest $BATS_TMPDIR/distcp/manifest /_test_cmd/distcp/src /_test_cmd/distcp/dst
+  assert_success
+
+  run $HDFS distcp --manifest $BATS_TMPDIR/distcp/manifest /_test_cmd/distcp/src /_test_cmd/distcp/other
+  assert_failure
+
+  run $HDFS ls /_test_cmd/distcp/other
+  assert_failure
+}
+
+@test "distcp preserve" {
+  $HDFS chmod 600 /_test_cmd/distcp/src/mobydick.txt
+  run $HDFS distcp -p /_test_cmd/distcp/src /_test_cmd/distcp/dst
+  assert_success
+
+  run $HDFS ls -l /_test_cmd/distcp/dst/mobydick.txt
+  assert_success
+  [[ "$output" == *-rw-------* ]]
+}
+
+@test "distcp local to local" {
+  run $HDFS distcp file://$BATS_TMPDIR/distcp/src file://$BATS_TMPDIR/distcp/dst
+  assert_failure
+}
+
+teardown() {
+  $HDFS rm -r /_test_cmd/distcp
+  rm -rf $BATS_TMPDIR/distcp
+}
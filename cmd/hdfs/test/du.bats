@@ -43,11 +43,55 @@ OUT
 OUT
 }
 
+@test "du max depth" {
+  $HDFS mkdir -p /_test_cmd/du/dir3/sub
+  echo foo | $HDFS put - /_test_cmd/du/dir3/sub/foo.txt
+
+  run $HDFS du --max-depth 1 /_test_cmd/du/dir3
+  assert_success
+  assert_output <<OUT
+4       /_test_cmd/du/dir3/sub
+4       /_test_cmd/du/dir3
+OUT
+
+  run $HDFS du --max-depth=0 /_test_cmd/du/dir3
+  assert_success
+  assert_output <<OUT
+4       /_test_cmd/du/dir3
+OUT
+}
+
+@test "du exclude" {
+  $HDFS mkdir -p /_test_cmd/du/dir3/.Trash /_test_cmd/du/dir3/tmp
+  echo foo | $HDFS put - /_test_cmd/du/dir3/foo.txt
+  echo foo | $HDFS put - /_test_cmd/du/dir3/.Trash/foo.txt
+  echo foo | $HDFS put - /_test_cmd/du/dir3/tmp/foo.txt
+
+  run $HDFS du -s --exclude .Trash --exclude '/_test_cmd/du/*/tmp' /_test_cmd/du/dir3
+  assert_success
+  assert_output <<OUT
+4       /_test_cmd/du/dir3
+OUT
+
+  run $HDFS du --exclude '*.txt' /_test_cmd/du/dir3
+  assert_success
+  assert_output <<OUT
+0       /_test_cmd/du/dir3/.Trash
+0       /_test_cmd/du/dir3/tmp
+0       /_test_cmd/du/dir3
+OUT
+}
+
+@test "du summary with max depth" {
+  run $HDFS du -s --max-depth 1 /_test_cmd/du/dir1
+  assert_failure
+}
+
 @test "du nonexistent" {
   run $HDFS du /_test_cmd/nonexistent
   assert_failure
   assert_output <<OUT
-stat /_test_cmd/nonexistent: file does not exist
+du: \`/_test_cmd/nonexistent': No such file or directory
 OUT
 }
 
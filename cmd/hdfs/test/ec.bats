@@ -0,0 +1,47 @@
+#!/usr/bin/env bats
+
+load helper
+
+setup() {
+  if ! $HDFS ec -listPolicies > /dev/null 2>&1; then
+    skip "erasure coding isn't supported by the cluster"
+  fi
+
+  $HDFS mkdir -p /_test_cmd/ec
+}
+
+@test "ec list policies" {
+  run $HDFS ec -listPolicies
+  assert_success
+  [[ "$output" == *RS-6-3-1024k* ]]
+}
+
+@test "ec set and get policy" {
+  run $HDFS ec -getPolicy -path /_test_cmd/ec
+  assert_success
+  assert_output "The erasure coding policy of /_test_cmd/ec is unspecified"
+
+  run $HDFS ec -setPolicy -path /_test_cmd/ec -policy RS-6-3-1024k
+  assert_success
+
+  run $HDFS ec -getPolicy -path /_test_cmd/ec
+  assert_success
+  assert_output "RS-6-3-1024k"
+
+  run $HDFS ec -unsetPolicy -path /_test_cmd/ec
+  assert_success
+
+  run $HDFS ec -getPolicy -path /_test_cmd/ec
+  assert_success
+  assert_output "The erasure coding policy of /_test_cmd/ec is unspecified"
+}
+
+@test "ec missing path" {
+  run $HDFS ec -getPolicy
+  assert_failure
+  assert_line 0 "Missing -path "
+}
+
+teardown() {
+  $HDFS rm -r /_test_cmd/ec
+}
@@ -0,0 +1,20 @@
+#!/usr/bin/env bats
+
+load helper
+
+@test "exit status for a missing file" {
+  run $HDFS ls /_test_cmd/nonexistent
+  [ "$status" -eq 1 ]
+  assert_output "ls: \`/_test_cmd/nonexistent': No such file or directory"
+}
+
+@test "exit status for an unknown command" {
+  run $HDFS frobnicate
+  [ "$status" -eq 255 ]
+  assert_line 0 "Unknown command: frobnicate "
+}
+
+@test "exit status for a partial failure" {
+  run $HDFS cat /_test/foo.txt /_test_cmd/nonexistent
+  [ "$status" -eq 1 ]
+}
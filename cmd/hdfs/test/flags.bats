@@ -0,0 +1,20 @@
+#!/usr/bin/env bats
+
+load helper
+
+@test "global flags" {
+  run $HDFS --timeout 10s --retries 2 --retry-interval=100ms cat /_test/foo.txt
+  assert_success
+  assert_output "bar"
+}
+
+@test "global flags with an unreachable namenode" {
+  HADOOP_NAMENODE=localhost:1 run $HDFS --timeout 1s --retries 1 --retry-interval 10ms cat /_test/foo.txt
+  assert_failure
+}
+
+@test "global flags with an invalid value" {
+  run $HDFS --timeout soon cat /_test/foo.txt
+  assert_failure
+  assert_line 0 "Invalid value for --timeout: soon"
+}
@@ -0,0 +1,107 @@
+#!/usr/bin/env bats
+
+load helper
+
+setup() {
+  mkdir -p $BATS_TMPDIR/get
+}
+
+@test "get" {
+  run $HDFS get /_test/foo.txt $BATS_TMPDIR/get/foo.txt
+  assert_success
+
+  run cat $BATS_TMPDIR/get/foo.txt
+  assert_output "bar"
+}
+
+@test "get continue" {
+  head -c 1100000 $ROOT_TEST_DIR/testdata/mobydick.txt > $BATS_TMPDIR/get/mobydick.txt
+
+  run $HDFS get -c /_test/mobydick.txt $BATS_TMPDIR/get/mobydick.txt
+  assert_success
+
+  SHA=`shasum < $ROOT_TEST_DIR/testdata/mobydick.txt | awk '{ print $1 }'`
+  assert_equal $SHA `shasum < $BATS_TMPDIR/get/mobydick.txt | awk '{ print $1 }'`
+
+  run $HDFS get --continue /_test/mobydick.txt $BATS_TMPDIR/get/mobydick.txt
+  assert_success
+  assert_equal $SHA `shasum < $BATS_TMPDIR/get/mobydick.txt | awk '{ print $1 }'`
+}
+
+@test "get continue corrupt" {
+  (printf 'X'; tail -c +2 $ROOT_TEST_DIR/testdata/mobydick.txt | head -c 1099999) > $BATS_TMPDIR/get/mobydick.txt
+
+  run $HDFS get -c /_test/mobydick.txt $BATS_TMPDIR/get/mobydick.txt
+  assert_success
+
+  SHA=`shasum < $ROOT_TEST_DIR/testdata/mobydick.txt | awk '{ print $1 }'`
+  assert_equal $SHA `shasum < $BATS_TMPDIR/get/mobydick.txt | awk '{ print $1 }'`
+}
+
+@test "get verify" {
+  run $HDFS get --verify /_test/mobydick.txt $BATS_TMPDIR/get/mobydick.txt
+  assert_success
+  assert_output ""
+
+  SHA=`shasum < $ROOT_TEST_DIR/testdata/mobydick.txt | awk '{ print $1 }'`
+  assert_equal $SHA `shasum < $BATS_TMPDIR/get/mobydick.txt | awk '{ print $1 }'`
+}
+
+@test "get continue verify" {
+  head -c 1100000 $ROOT_TEST_DIR/testdata/mobydick.txt > $BATS_TMPDIR/get/mobydick.txt
+
+  run $HDFS get -c --verify /_test/mobydick.txt $BATS_TMPDIR/get/mobydick.txt
+  assert_success
+
+  SHA=`shasum < $ROOT_TEST_DIR/testdata/mobydick.txt | awk '{ print $1 }'`
+  assert_equal $SHA `shasum < $BATS_TMPDIR/get/mobydick.txt | awk '{ print $1 }'`
+}
+
+@test "get crc" {
+  run $HDFS get --crc /_test/mobydick.txt $BATS_TMPDIR/get/mobydick.txt
+  assert_success
+  assert_output ""
+
+  SIZE=`wc -c < $ROOT_TEST_DIR/testdata/mobydick.txt`
+  assert_equal $((8 + 4 * ((SIZE + 511) / 512))) `wc -c < $BATS_TMPDIR/get/.mobydick.txt.crc`
+  assert_equal "crc" `head -c 3 $BATS_TMPDIR/get/.mobydick.txt.crc`
+}
+
+@test "get continue crc" {
+  head -c 1100000 $ROOT_TEST_DIR/testdata/mobydick.txt > $BATS_TMPDIR/get/mobydick.txt
+
+  run $HDFS get -c --crc /_test/mobydick.txt $BATS_TMPDIR/get/mobydick.txt
+  assert_success
+
+  SIZE=`wc -c < $ROOT_TEST_DIR/testdata/mobydick.txt`
+  assert_equal $((8 + 4 * ((SIZE + 511) / 512))) `wc -c < $BATS_TMPDIR/get/.mobydick.txt.crc`
+}
+
+@test "get preserve" {
+  $HDFS put $ROOT_TEST_DIR/testdata/foo.txt /_test_cmd/get_preserve.txt
+  $HDFS chmod 640 /_test_cmd/get_preserve.txt
+
+  run $HDFS get -p /_test_cmd/get_preserve.txt $BATS_TMPDIR/get/preserved.txt
+  assert_success
+
+  run ls -l $BATS_TMPDIR/get/preserved.txt
+  [[ "$output" == -rw-r-----* ]]
+
+  expected=$($HDFS ls -l /_test_cmd/get_preserve.txt | awk '{ print $5, $6, $7 }')
+  actual=$(ls -l $BATS_TMPDIR/get/preserved.txt | awk '{ print $6, $7, $8 }')
+  assert_equal "$expected" "$actual"
+
+  $HDFS rm /_test_cmd/get_preserve.txt
+}
+
+@test "get with bwlimit" {
+  run $HDFS get --bwlimit 4M /_test/mobydick.txt $BATS_TMPDIR/get/mobydick.txt
+  assert_success
+
+  SHA=`shasum < $ROOT_TEST_DIR/testdata/mobydick.txt | awk '{ print $1 }'`
+  assert_equal $SHA `shasum < $BATS_TMPDIR/get/mobydick.txt | awk '{ print $1 }'`
+}
+
+teardown() {
+  rm -rf $BATS_TMPDIR/get
+}
@@ -101,7 +101,7 @@ OUT
   run $HDFS ls /_test_cmd/nonexistent*
   assert_failure
   assert_output <<OUT
-stat /_test_cmd/nonexistent*: file does not exist
+ls: \`/_test_cmd/nonexistent*': No such file or directory
 OUT
 }
 
@@ -20,10 +20,22 @@ load helper
   assert_output "$(head -c 10 $ROOT_TEST_DIR/testdata/mobydick.txt)"
 }
 
+@test "head lines" {
+  run $HDFS head -n 3 /_test/mobydick.txt
+  assert_success
+  assert_output "$(head -n 3 $ROOT_TEST_DIR/testdata/mobydick.txt)"
+}
+
+@test "head more lines than the file" {
+  run $HDFS head -n 100 /_test/foo.txt
+  assert_success
+  assert_output "bar"
+}
+
 @test "head nonexistent" {
   run $HDFS head /_test_cmd/nonexistent
   assert_failure
   assert_output <<OUT
-open /_test_cmd/nonexistent: file does not exist
+head: \`/_test_cmd/nonexistent': No such file or directory
 OUT
 }
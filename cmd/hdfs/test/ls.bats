@@ -35,10 +35,84 @@ OUT
   run $HDFS ls /_test_cmd/nonexistent
   assert_failure
   assert_output <<OUT
-stat /_test_cmd/nonexistent: file does not exist
+ls: \`/_test_cmd/nonexistent': No such file or directory
 OUT
 }
 
+@test "ls -r" {
+  run $HDFS ls -r /_test_cmd/ls/dir1
+  assert_success
+  assert_output <<OUT
+c
+b
+a
+OUT
+}
+
+@test "ls -S" {
+  $HDFS mkdir -p /_test_cmd/ls/sizes
+  echo foo | $HDFS put - /_test_cmd/ls/sizes/small
+  echo foobarbaz | $HDFS put - /_test_cmd/ls/sizes/big
+  $HDFS touch /_test_cmd/ls/sizes/empty
+
+  run $HDFS ls -S /_test_cmd/ls/sizes
+  assert_success
+  assert_output <<OUT
+big
+small
+empty
+OUT
+
+  run $HDFS ls -Sr /_test_cmd/ls/sizes
+  assert_success
+  assert_output <<OUT
+empty
+small
+big
+OUT
+}
+
+@test "ls -t" {
+  $HDFS mkdir -p /_test_cmd/ls/times
+  $HDFS touch /_test_cmd/ls/times/a /_test_cmd/ls/times/b /_test_cmd/ls/times/c
+  sleep 1
+  $HDFS touch -m /_test_cmd/ls/times/c
+  sleep 1
+  $HDFS touch -m /_test_cmd/ls/times/a
+
+  run $HDFS ls -t /_test_cmd/ls/times
+  assert_success
+  assert_output <<OUT
+a
+c
+b
+OUT
+}
+
+@test "ls -C" {
+  run $HDFS ls -lC /_test_cmd/ls/dir1
+  assert_success
+  assert_output <<OUT
+a
+b
+c
+OUT
+}
+
+@test "ls extended" {
+  echo foo | $HDFS put --blocksize 2M --replication 2 - /_test_cmd/ls/dir2/e
+  $HDFS storagepolicies set COLD /_test_cmd/ls/dir2
+
+  run $HDFS ls -e /_test_cmd/ls/dir2
+  assert_success
+  [[ "${lines[0]}" =~ " COLD " ]]
+  [[ "${lines[1]}" =~ " 4 "\ +"2097152 "\ +"2 "\ +"COLD " ]]
+
+  run $HDFS ls -eh /_test_cmd/ls/dir2/e
+  assert_success
+  [[ "$output" =~ " 4B "\ +"2.0M "\ +"2 "\ +"COLD " ]]
+}
+
 teardown() {
   $HDFS rm -r /_test_cmd/ls
 }
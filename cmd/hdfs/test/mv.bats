@@ -26,7 +26,7 @@ setup() {
   run $HDFS mv /_test_cmd/nonexistent /_test_cmd/nonexistent2
   assert_failure
   assert_output <<OUT
-rename /_test_cmd/nonexistent: file does not exist
+mv: \`/_test_cmd/nonexistent': No such file or directory
 OUT
 }
 
@@ -58,7 +58,7 @@ OUT
   run $HDFS mv -n /_test_cmd/mv/a /_test_cmd/mv/b
   assert_failure
   assert_output <<OUT
-rename /_test_cmd/mv/b: file already exists
+mv: \`/_test_cmd/mv/b': File exists
 OUT
 }
 
@@ -130,10 +130,51 @@ OUT
   run $HDFS mv -nT /_test_cmd/mv/dir1 /_test_cmd/mv/dir2
   assert_failure
   assert_output <<OUT
-rename /_test_cmd/mv/dir2: file already exists
+mv: \`/_test_cmd/mv/dir2': File exists
 OUT
 }
 
+@test "mv to the same cluster named explicitly" {
+  if [ -z "$HADOOP_NAMENODE" ]; then
+    skip "HADOOP_NAMENODE isn't set"
+  fi
+
+  $HDFS put $ROOT_TEST_DIR/testdata/mobydick.txt /_test_cmd/mv/dir1/mobydick.txt
+  run $HDFS mv /_test_cmd/mv/dir1 hdfs://$HADOOP_NAMENODE/_test_cmd/mv/copied
+  assert_success
+
+  run $HDFS ls /_test_cmd/mv/dir1
+  assert_failure
+
+  run $HDFS cat /_test_cmd/mv/copied/mobydick.txt
+  assert_success
+  assert_output "$(cat $ROOT_TEST_DIR/testdata/mobydick.txt)"
+
+  run $HDFS ls /_test_cmd/mv/copied/c
+  assert_success
+}
+
+@test "mv to the same cluster named explicitly over an existing file" {
+  if [ -z "$HADOOP_NAMENODE" ]; then
+    skip "HADOOP_NAMENODE isn't set"
+  fi
+
+  $HDFS put $ROOT_TEST_DIR/testdata/foo.txt /_test_cmd/mv/replaced.txt
+  run $HDFS mv /_test_cmd/mv/a hdfs://$HADOOP_NAMENODE/_test_cmd/mv/replaced.txt
+  assert_success
+
+  run $HDFS cat /_test_cmd/mv/replaced.txt
+  assert_success
+  assert_output ""
+
+  run $HDFS ls /_test_cmd/mv/a
+  assert_failure
+
+  run $HDFS ls -a /_test_cmd/mv
+  assert_success
+  [[ "$output" != *.tmp* ]]
+}
+
 teardown() {
   $HDFS rm -r /_test_cmd/mv
 }
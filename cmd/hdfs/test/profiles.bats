@@ -0,0 +1,44 @@
+#!/usr/bin/env bats
+
+load helper
+
+setup() {
+  export HOME=$BATS_TMPDIR/profiles_home
+  mkdir -p $HOME/.hdfs
+  cat > $HOME/.hdfs/config <<CONFIG
+# A comment.
+[test]
+timeout = "10s"
+
+[unreachable]
+namenodes = ["localhost:1"] # nothing listens here
+retries = 0
+CONFIG
+}
+
+@test "cluster profile" {
+  run $HDFS --cluster test cat /_test/foo.txt
+  assert_success
+  assert_output "bar"
+}
+
+@test "cluster profile overrides HADOOP_NAMENODE" {
+  HADOOP_NAMENODE=localhost:1 run $HDFS --cluster test cat /_test/foo.txt
+  assert_success
+  assert_output "bar"
+}
+
+@test "cluster profile namenodes" {
+  run $HDFS --cluster unreachable cat /_test/foo.txt
+  assert_failure
+}
+
+@test "unknown cluster profile" {
+  run $HDFS --cluster nonexistent cat /_test/foo.txt
+  assert_failure
+  assert_output "Problem loading cluster profile: $HOME/.hdfs/config: no cluster named \"nonexistent\""
+}
+
+teardown() {
+  rm -rf $BATS_TMPDIR/profiles_home
+}
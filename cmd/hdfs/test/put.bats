@@ -60,7 +60,7 @@ setup() {
   run $HDFS put $ROOT_TEST_DIR/testdata /_test_cmd/put/existing.txt
     assert_failure
     assert_output <<OUT
-mkdir /_test_cmd/put/existing.txt: file already exists
+put: \`/_test_cmd/put/existing.txt': File exists
 OUT
 }
 
@@ -83,11 +83,32 @@ OUT
   assert_equal $SHA `shasum < $BATS_TMPDIR/mobydick_stdin_test.txt | awk '{ print $1 }'`
 }
 
+@test "put stdin with blocksize and replication" {
+  run bash -c "cat $ROOT_TEST_DIR/testdata/mobydick.txt | $HDFS put --blocksize 1M --replication 1 - /_test_cmd/put/mobydick_blocks.txt"
+  assert_success
+
+  run $HADOOP_FS -stat "%o %r" hdfs://$HADOOP_NAMENODE/_test_cmd/put/mobydick_blocks.txt
+  assert_success
+  assert_output "1048576 1"
+
+  run bash -c "$HDFS cat /_test_cmd/put/mobydick_blocks.txt > $BATS_TMPDIR/mobydick_blocks_test.txt"
+  assert_success
+
+  SHA=`shasum < $ROOT_TEST_DIR/testdata/mobydick.txt | awk '{ print $1 }'`
+  assert_equal $SHA `shasum < $BATS_TMPDIR/mobydick_blocks_test.txt | awk '{ print $1 }'`
+}
+
+@test "put with invalid blocksize" {
+  run $HDFS put --blocksize big $ROOT_TEST_DIR/testdata/foo.txt /_test_cmd/put/blocksize.txt
+  assert_failure
+  [ "$status" -eq 255 ]
+}
+
 @test "put stdin into file" {
   run bash -c "echo 'foo bar baz' | $HDFS put - /_test_cmd/put/existing.txt"
   assert_failure
   assert_output <<OUT
-put /_test_cmd/put/existing.txt: file already exists
+put: \`/_test_cmd/put/existing.txt': File exists
 OUT
 }
 
@@ -95,10 +116,46 @@ OUT
   run bash -c "echo 'foo bar baz' | $HDFS put - /_test_cmd/put/1"
   assert_failure
   assert_output <<OUT
-put /_test_cmd/put/1: file already exists
+put: \`/_test_cmd/put/1': File exists
+OUT
+}
+
+@test "put with bwlimit" {
+  run $HDFS put --bwlimit 4M $ROOT_TEST_DIR/testdata/mobydick.txt /_test_cmd/put/bwlimit.txt
+  assert_success
+
+  run bash -c "$HDFS cat /_test_cmd/put/bwlimit.txt > $BATS_TMPDIR/mobydick_test.txt"
+  assert_success
+
+  SHA=`shasum < $ROOT_TEST_DIR/testdata/mobydick.txt | awk '{ print $1 }'`
+  assert_equal $SHA `shasum < $BATS_TMPDIR/mobydick_test.txt | awk '{ print $1 }'`
+}
+
+@test "put with invalid bwlimit" {
+  run $HDFS put --bwlimit fast $ROOT_TEST_DIR/testdata/foo.txt /_test_cmd/put/bwlimit2.txt
+  assert_failure
+  assert_output <<OUT
+invalid bandwidth limit: fast
 OUT
 }
 
+@test "put preserve" {
+  mkdir -p $BATS_TMPDIR/put_preserve
+  cp $ROOT_TEST_DIR/testdata/foo.txt $BATS_TMPDIR/put_preserve/foo.txt
+  chmod 600 $BATS_TMPDIR/put_preserve/foo.txt
+  touch -t 201001011200 $BATS_TMPDIR/put_preserve/foo.txt
+
+  run $HDFS put -p $BATS_TMPDIR/put_preserve/foo.txt /_test_cmd/put/preserved.txt
+  assert_success
+
+  run $HDFS ls -l /_test_cmd/put/preserved.txt
+  assert_success
+  [[ "$output" == -rw-------* ]]
+  [[ "$output" == *"Jan  1  2010"* ]]
+
+  rm -rf $BATS_TMPDIR/put_preserve
+}
+
 teardown() {
   $HDFS rm -r /_test_cmd/put
 }
@@ -30,21 +30,21 @@ setup() {
 @test "rm dir without -r" {
   run $HDFS rm /_test_cmd/rm/dir
   assert_failure
-  assert_output "remove /_test_cmd/rm/dir: file is a directory"
+  assert_output "rm: \`/_test_cmd/rm/dir': Is a directory"
 }
 
 @test "rm dir without -r, but with -f" {
   run $HDFS rm -f /_test_cmd/rm/dir
   assert_failure
-  assert_output "remove /_test_cmd/rm/dir: file is a directory"
+  assert_output "rm: \`/_test_cmd/rm/dir': Is a directory"
 }
 
 @test "rm nonexistent" {
   run $HDFS rm /_test_cmd/nonexistent /_test_cmd/nonexistent2
   assert_failure
   assert_output <<OUT
-remove /_test_cmd/nonexistent: file does not exist
-remove /_test_cmd/nonexistent2: file does not exist
+rm: \`/_test_cmd/nonexistent': No such file or directory
+rm: \`/_test_cmd/nonexistent2': No such file or directory
 OUT
 }
 
@@ -54,6 +54,19 @@ OUT
   assert_output ""
 }
 
+@test "rm with -skipTrash" {
+  run $HDFS rm -skipTrash /_test_cmd/rm/a
+  assert_success
+  assert_output ""
+
+  run $HDFS rm --skipTrash -r /_test_cmd/rm/dir
+  assert_success
+  assert_output ""
+
+  run $HDFS ls /_test_cmd/rm/a
+  assert_failure
+}
+
 teardown() {
   $HDFS rm -r /_test_cmd/rm
 }
@@ -0,0 +1,28 @@
+#!/usr/bin/env bats
+
+load helper
+
+setup() {
+  $HDFS mkdir -p /_test_cmd/s3gateway
+  $HDFS touchz /_test_cmd/s3gateway/file
+  export CREDENTIALS=$(mktemp -t s3gateway.XXXXXX)
+}
+
+@test "s3gateway with a malformed credentials file" {
+  printf "# comment\n\nkey secret\n" > $CREDENTIALS
+  run $HDFS s3gateway --credentials $CREDENTIALS /_test_cmd/s3gateway
+  assert_failure
+  assert_output "Problem loading credentials: $CREDENTIALS:3: expected ACCESS_KEY_ID SECRET_ACCESS_KEY USER"
+}
+
+@test "s3gateway with a file as the root" {
+  printf "key secret gohdfs1\n" > $CREDENTIALS
+  run $HDFS s3gateway --credentials $CREDENTIALS /_test_cmd/s3gateway/file
+  assert_failure
+  assert_output "/_test_cmd/s3gateway/file is not a directory"
+}
+
+teardown() {
+  rm -f $CREDENTIALS
+  $HDFS rm -r /_test_cmd/s3gateway
+}
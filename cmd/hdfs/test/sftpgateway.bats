@@ -0,0 +1,62 @@
+#!/usr/bin/env bats
+
+load helper
+
+setup() {
+  $HDFS mkdir -p /_test_cmd/sftpgateway
+  export KEYDIR=$(mktemp -d -t sftpgateway.XXXXXX)
+}
+
+generate_keys() {
+  command -v ssh-keygen >/dev/null || skip "ssh-keygen isn't installed"
+  ssh-keygen -q -t ed25519 -N "" -f $KEYDIR/host_key
+  ssh-keygen -q -t ed25519 -N "" -f $KEYDIR/user_key
+  echo "gohdfs1 $(cat $KEYDIR/user_key.pub)" > $KEYDIR/authorized_keys
+}
+
+@test "sftpgateway with an invalid host key" {
+  echo "foo" > $KEYDIR/host_key
+  touch $KEYDIR/authorized_keys
+  run $HDFS sftpgateway --host-key $KEYDIR/host_key --authorized-keys $KEYDIR/authorized_keys /_test_cmd/sftpgateway
+  assert_failure
+  assert_output "Problem loading host key: ssh: no key found"
+}
+
+@test "sftpgateway with a malformed authorized keys file" {
+  generate_keys
+  printf "# comment\n\ngohdfs1\n" > $KEYDIR/authorized_keys
+  run $HDFS sftpgateway --host-key $KEYDIR/host_key --authorized-keys $KEYDIR/authorized_keys /_test_cmd/sftpgateway
+  assert_failure
+  assert_output "Problem loading authorized keys: $KEYDIR/authorized_keys:3: expected USER KEY"
+}
+
+@test "sftpgateway put and get" {
+  command -v sftp >/dev/null || skip "sftp isn't installed"
+  generate_keys
+
+  $HDFS sftpgateway --listen 127.0.0.1:2022 --host-key $KEYDIR/host_key \
+    --authorized-keys $KEYDIR/authorized_keys /_test_cmd/sftpgateway 2>/dev/null &
+  echo $! > $KEYDIR/pid
+  sleep 1
+
+  echo "foo bar" > $KEYDIR/upload.txt
+  printf "put $KEYDIR/upload.txt\nget upload.txt $KEYDIR/download.txt\nls\n" > $KEYDIR/batch
+  run sftp -q -b $KEYDIR/batch -P 2022 -i $KEYDIR/user_key \
+    -o StrictHostKeyChecking=no -o UserKnownHostsFile=/dev/null gohdfs1@127.0.0.1
+  assert_success
+
+  run $HDFS cat /_test_cmd/sftpgateway/upload.txt
+  assert_output "foo bar"
+
+  run cat $KEYDIR/download.txt
+  assert_output "foo bar"
+}
+
+teardown() {
+  if [ -f $KEYDIR/pid ]; then
+    kill $(cat $KEYDIR/pid)
+  fi
+
+  rm -rf $KEYDIR
+  $HDFS rm -r /_test_cmd/sftpgateway
+}
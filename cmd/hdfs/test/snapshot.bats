@@ -0,0 +1,57 @@
+#!/usr/bin/env bats
+
+load helper
+
+setup() {
+  $HDFS mkdir -p /_test_cmd/snapshot
+  if ! $HDFS snapshot allow /_test_cmd/snapshot > /dev/null 2>&1; then
+    skip "allowing snapshots requires superuser privileges"
+  fi
+}
+
+@test "snapshot create, rename, and delete" {
+  run $HDFS snapshot create /_test_cmd/snapshot s1
+  assert_success
+  assert_output "/_test_cmd/snapshot/.snapshot/s1"
+
+  run $HDFS snapshot rename /_test_cmd/snapshot s1 s2
+  assert_success
+
+  run $HDFS ls /_test_cmd/snapshot/.snapshot
+  assert_success
+  assert_output "s2"
+
+  run $HDFS snapshot delete /_test_cmd/snapshot s2
+  assert_success
+
+  run $HDFS ls /_test_cmd/snapshot/.snapshot
+  assert_success
+  assert_output ""
+}
+
+@test "snapshot diff" {
+  run $HDFS snapshot create /_test_cmd/snapshot s1
+  assert_success
+
+  $HDFS touchz /_test_cmd/snapshot/foo
+
+  run $HDFS snapshot diff /_test_cmd/snapshot s1
+  assert_success
+  assert_output <<OUT
+M	.
++	./foo
+OUT
+
+  run $HDFS snapshot delete /_test_cmd/snapshot s1
+  assert_success
+}
+
+@test "snapshot unknown command" {
+  run $HDFS snapshot frobnicate /_test_cmd/snapshot
+  assert_failure
+}
+
+teardown() {
+  $HDFS snapshot disallow /_test_cmd/snapshot > /dev/null 2>&1
+  $HDFS rm -r /_test_cmd/snapshot
+}
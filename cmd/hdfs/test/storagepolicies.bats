@@ -0,0 +1,52 @@
+#!/usr/bin/env bats
+
+load helper
+
+setup() {
+  $HDFS mkdir -p /_test_cmd/storagepolicies
+}
+
+@test "storagepolicies list" {
+  run $HDFS storagepolicies list
+  assert_success
+  [[ "${lines[0]}" == ID* ]]
+  [[ "$output" == *HOT* ]]
+}
+
+@test "storagepolicies set and get" {
+  run $HDFS storagepolicies set COLD /_test_cmd/storagepolicies
+  assert_success
+
+  run $HDFS storagepolicies get /_test_cmd/storagepolicies
+  assert_success
+  assert_output "$(printf '/_test_cmd/storagepolicies\tCOLD')"
+
+  run $HDFS storagepolicies unset /_test_cmd/storagepolicies
+  assert_success
+
+  run $HDFS storagepolicies get /_test_cmd/storagepolicies
+  assert_success
+  assert_output "$(printf '/_test_cmd/storagepolicies\tHOT')"
+}
+
+@test "storagepolicies satisfy nonexistent" {
+  run $HDFS storagepolicies satisfy /_test_cmd/nonexistent
+  assert_failure
+}
+
+@test "storagepolicies get nonexistent" {
+  run $HDFS storagepolicies get /_test_cmd/nonexistent
+  assert_failure
+  assert_output <<OUT
+storagepolicies: \`/_test_cmd/nonexistent': No such file or directory
+OUT
+}
+
+@test "storagepolicies unknown command" {
+  run $HDFS storagepolicies frobnicate /_test_cmd/storagepolicies
+  assert_failure
+}
+
+teardown() {
+  $HDFS rm -r /_test_cmd/storagepolicies
+}
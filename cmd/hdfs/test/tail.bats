@@ -20,10 +20,21 @@ load helper
   assert_output "$(tail -c 10 $ROOT_TEST_DIR/testdata/mobydick.txt)"
 }
 
+@test "tail follow" {
+  run timeout 3 $HDFS tail -f -n 1 /_test/foo.txt
+  [ "$status" -eq 124 ]
+  assert_output "bar"
+}
+
+@test "tail follow multiple" {
+  run $HDFS tail -f /_test/foo.txt /_test/mobydick.txt
+  assert_failure
+}
+
 @test "tail nonexistent" {
   run $HDFS tail /_test_cmd/nonexistent
   assert_failure
   assert_output <<OUT
-open /_test_cmd/nonexistent: file does not exist
+tail: \`/_test_cmd/nonexistent': No such file or directory
 OUT
 }
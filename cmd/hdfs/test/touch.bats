@@ -23,7 +23,47 @@ setup() {
   run $HDFS touch /_test_cmd/nonexistent/a
   assert_failure
   assert_output <<OUT
-create /_test_cmd/nonexistent/a: file does not exist
+touch: \`/_test_cmd/nonexistent/a': No such file or directory
+OUT
+}
+
+@test "touch -c nonexistent" {
+  run $HDFS touch -c /_test_cmd/touch/c
+  assert_success
+  assert_output ""
+
+  run $HDFS ls /_test_cmd/touch/c
+  assert_failure
+}
+
+@test "touchz" {
+  run $HDFS touchz /_test_cmd/touch/z /_test_cmd/touch/existing
+  assert_success
+  assert_output ""
+
+  run $HDFS du /_test_cmd/touch/z
+  assert_success
+  assert_output <<OUT
+0       /_test_cmd/touch/z
+OUT
+}
+
+@test "touchz non-empty" {
+  run $HDFS put $ROOT_TEST_DIR/testdata/foo.txt /_test_cmd/touch/nonempty
+  assert_success
+
+  run $HDFS touchz /_test_cmd/touch/nonempty
+  assert_failure
+  assert_output <<OUT
+touchz: \`/_test_cmd/touch/nonempty': Not a zero-length file
+OUT
+}
+
+@test "touchz directory" {
+  run $HDFS touchz /_test_cmd/touch
+  assert_failure
+  assert_output <<OUT
+touchz: \`/_test_cmd/touch': Is a directory
 OUT
 }
 
@@ -0,0 +1,45 @@
+#!/usr/bin/env bats
+
+load helper
+
+setup() {
+  $HDFS mkdir -p /_test_cmd/truncate
+  $HDFS put $ROOT_TEST_DIR/testdata/mobydick.txt /_test_cmd/truncate/mobydick.txt
+}
+
+@test "truncate" {
+  run $HDFS truncate -w 10 /_test_cmd/truncate/mobydick.txt
+  assert_success
+
+  run $HDFS cat /_test_cmd/truncate/mobydick.txt
+  assert_success
+  assert_output "$(head -c 10 $ROOT_TEST_DIR/testdata/mobydick.txt)"
+}
+
+@test "truncate to zero" {
+  run $HDFS truncate 0 /_test_cmd/truncate/mobydick.txt
+  assert_success
+  assert_output ""
+
+  run $HDFS cat /_test_cmd/truncate/mobydick.txt
+  assert_success
+  assert_output ""
+}
+
+@test "truncate invalid length" {
+  run $HDFS truncate foo /_test_cmd/truncate/mobydick.txt
+  assert_failure
+  assert_output "invalid length: foo"
+}
+
+@test "truncate nonexistent" {
+  run $HDFS truncate 0 /_test_cmd/nonexistent
+  assert_failure
+  assert_output <<OUT
+truncate: \`/_test_cmd/nonexistent': No such file or directory
+OUT
+}
+
+teardown() {
+  $HDFS rm -r /_test_cmd/truncate
+}
@@ -0,0 +1,60 @@
+#!/usr/bin/env bats
+
+load helper
+
+setup() {
+  $HDFS mkdir -p /_test_cmd/xattr
+  $HDFS touchz /_test_cmd/xattr/foo
+}
+
+@test "setfattr and getfattr" {
+  run $HDFS setfattr -n user.foo -v bar /_test_cmd/xattr/foo
+  assert_success
+
+  run $HDFS setfattr -n user.baz -v qux /_test_cmd/xattr/foo
+  assert_success
+
+  run $HDFS getfattr -d /_test_cmd/xattr/foo
+  assert_success
+  assert_output <<OUT
+# file: /_test_cmd/xattr/foo
+user.baz="qux"
+user.foo="bar"
+OUT
+
+  run $HDFS getfattr -n user.foo /_test_cmd/xattr/foo
+  assert_success
+  assert_output <<OUT
+# file: /_test_cmd/xattr/foo
+user.foo="bar"
+OUT
+}
+
+@test "setfattr remove" {
+  run $HDFS setfattr -n user.foo -v bar /_test_cmd/xattr/foo
+  assert_success
+
+  run $HDFS setfattr -x user.foo /_test_cmd/xattr/foo
+  assert_success
+
+  run $HDFS getfattr /_test_cmd/xattr/foo
+  assert_success
+  assert_output "# file: /_test_cmd/xattr/foo"
+}
+
+@test "setfattr invalid flags" {
+  run $HDFS setfattr -n user.foo -x user.foo /_test_cmd/xattr/foo
+  assert_failure
+}
+
+@test "getfattr nonexistent" {
+  run $HDFS getfattr -d /_test_cmd/nonexistent
+  assert_failure
+  assert_output <<OUT
+getfattr: \`/_test_cmd/nonexistent': No such file or directory
+OUT
+}
+
+teardown() {
+  $HDFS rm -r /_test_cmd/xattr
+}
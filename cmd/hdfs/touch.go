@@ -1,11 +1,14 @@
 package main
 
 import (
+	"errors"
 	"os"
 	"time"
+
+	"github.com/colinmarc/hdfs/v2"
 )
 
-func touch(paths []string, noCreate bool) {
+func touch(paths []string, accessOnly, modOnly, noCreate bool) {
 	paths, nn, err := normalizePaths(paths)
 	if err != nil {
 		fatal(err)
@@ -22,27 +25,79 @@ func touch(paths []string, noCreate bool) {
 
 	for _, p := range paths {
 		if hasGlob(p) {
-			fatal(&os.PathError{"mkdir", p, os.ErrNotExist})
+			fatal(&os.PathError{"touch", p, os.ErrNotExist})
 		}
 
-		_, err := client.Stat(p)
-		exists := !os.IsNotExist(err)
-		if (err != nil && exists) || (!exists && noCreate) {
+		info, err := client.Stat(p)
+		if os.IsNotExist(err) {
+			if noCreate {
+				continue
+			}
+
+			err = client.CreateEmptyFile(p)
+			if err != nil {
+				fatal(err)
+			}
+
+			continue
+		} else if err != nil {
 			fatal(err)
 		}
 
-		if exists {
-			now := time.Now()
-			mtime := now
-			atime := now
-
-			err = client.Chtimes(p, mtime, atime)
-		} else {
-			err = client.CreateEmptyFile(p)
+		// With -a or -m, only one of the times is changed, and the other is
+		// kept as it is.
+		now := time.Now()
+		mtime := now
+		atime := now
+		if accessOnly && !modOnly {
+			mtime = info.ModTime()
+		} else if modOnly && !accessOnly {
+			atime = info.(*hdfs.FileInfo).AccessTime()
 		}
 
+		err = client.Chtimes(p, atime, mtime)
 		if err != nil {
 			fatal(err)
 		}
 	}
 }
+
+func touchz(paths []string) {
+	paths, nn, err := normalizePaths(paths)
+	if err != nil {
+		fatal(err)
+	}
+
+	if len(paths) == 0 {
+		printHelp()
+	}
+
+	client, err := getClient(nn)
+	if err != nil {
+		fatal(err)
+	}
+
+	for _, p := range paths {
+		if hasGlob(p) {
+			fatal(&os.PathError{"touchz", p, os.ErrNotExist})
+		}
+
+		info, err := client.Stat(p)
+		if os.IsNotExist(err) {
+			err = client.CreateEmptyFile(p)
+			if err != nil {
+				fatal(err)
+			}
+
+			continue
+		} else if err != nil {
+			fatal(err)
+		}
+
+		if info.IsDir() {
+			fatal(&os.PathError{"touchz", p, errors.New("file is a directory")})
+		} else if info.Size() != 0 {
+			fatal(&os.PathError{"touchz", p, errors.New("Not a zero-length file")})
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+)
+
+func truncate(args []string, wait bool) {
+	if len(args) < 2 {
+		printHelp()
+	}
+
+	size, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil || size < 0 {
+		fatal("invalid length:", args[0])
+	}
+
+	paths, nn, err := normalizePaths(args[1:])
+	if err != nil {
+		fatal(err)
+	}
+
+	client, err := getClient(nn)
+	if err != nil {
+		fatal(err)
+	}
+
+	for _, p := range paths {
+		if hasGlob(p) {
+			fatal(&os.PathError{"truncate", p, os.ErrNotExist})
+		}
+
+		if wait {
+			err := client.TruncateWait(context.Background(), p, size)
+			if err != nil {
+				fatal(err)
+			}
+
+			continue
+		}
+
+		done, err := client.Truncate(p, size)
+		if err != nil {
+			fatal(err)
+		}
+
+		if !done {
+			fmt.Printf("Truncating %s to length: %d. Wait for block recovery to complete before further updating this file.\n", p, size)
+		}
+	}
+}
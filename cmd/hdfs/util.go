@@ -1,7 +1,10 @@
 package main
 
 import (
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 )
 
 func formatBytes(i uint64) string {
@@ -18,3 +21,34 @@ func formatBytes(i uint64) string {
 		return fmt.Sprintf("%dB", i)
 	}
 }
+
+// parseBytes parses a positive size in bytes, with an optional K, M, G, or T
+// suffix (in powers of 1024), the inverse of formatBytes.
+func parseBytes(size string) (int64, error) {
+	s := strings.TrimSuffix(strings.ToUpper(size), "B")
+
+	multiplier := int64(1)
+	if len(s) > 0 {
+		switch s[len(s)-1] {
+		case 'K':
+			multiplier = 1024
+		case 'M':
+			multiplier = 1024 * 1024
+		case 'G':
+			multiplier = 1024 * 1024 * 1024
+		case 'T':
+			multiplier = 1024 * 1024 * 1024 * 1024
+		}
+
+		if multiplier != 1 {
+			s = s[:len(s)-1]
+		}
+	}
+
+	n, err := strconv.ParseFloat(s, 64)
+	if err != nil || n <= 0 {
+		return 0, errors.New("invalid size")
+	}
+
+	return int64(n * float64(multiplier)), nil
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+func getfattr(args []string, name string, dump bool) {
+	if dump && name != "" {
+		fatalWithUsage("-d and -n can't be used together")
+	}
+
+	expanded, client, err := getClientAndExpandedPaths(args)
+	if err != nil {
+		fatal(err)
+	} else if len(expanded) == 0 {
+		printHelp()
+	}
+
+	for _, p := range expanded {
+		var xattrs map[string]string
+		if name != "" {
+			xattrs, err = client.GetXAttrs(p, name)
+		} else {
+			xattrs, err = client.GetXAttrs(p)
+		}
+
+		if err != nil {
+			printError(err)
+			continue
+		}
+
+		keys := make([]string, 0, len(xattrs))
+		for key := range xattrs {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+		fmt.Printf("# file: %s\n", p)
+		for _, key := range keys {
+			fmt.Printf("%s=%s\n", key, strconv.Quote(xattrs[key]))
+		}
+	}
+}
+
+func setfattr(args []string, name, value, remove string) {
+	if (name == "") == (remove == "") {
+		fatalWithUsage("Exactly one of -n and -x must be specified")
+	} else if remove != "" && value != "" {
+		fatalWithUsage("-v can't be used with -x")
+	}
+
+	expanded, client, err := getClientAndExpandedPaths(args)
+	if err != nil {
+		fatal(err)
+	} else if len(expanded) == 0 {
+		printHelp()
+	}
+
+	for _, p := range expanded {
+		if remove != "" {
+			err = client.RemoveXAttr(p, remove)
+		} else {
+			err = client.SetXAttr(p, name, value)
+		}
+
+		if err != nil {
+			printError(err)
+		}
+	}
+}
@@ -0,0 +1,275 @@
+package hdfs
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash"
+	"hash/crc32"
+	"io"
+	"os"
+)
+
+// CompareMode specifies which signals FilesDiffer and FileDiffersFromLocal use
+// to decide whether two files differ.
+type CompareMode int
+
+const (
+	// CompareSizeAndTime treats files as the same if they have the same size
+	// and modification time (to the second). This is the default, and the
+	// cheapest check after CompareSize.
+	CompareSizeAndTime CompareMode = iota
+	// CompareSize treats files as the same if they have the same size.
+	CompareSize
+	// CompareChecksum treats files as the same if they have the same size and
+	// their contents match, as determined by ContentsDiffer.
+	CompareChecksum
+)
+
+// CompareOptions specifies how two files are compared.
+type CompareOptions struct {
+	Mode CompareMode
+	// TrustModTime specifies, with CompareChecksum, that files with the same
+	// size and modification time should be treated as the same without
+	// comparing their contents, so that only files that look like they've
+	// changed are checksummed.
+	TrustModTime bool
+}
+
+// FilesDiffer reports whether the file src (using srcClient) differs from the
+// file dst (using dstClient). The clients can be connected to different
+// clusters. The cheapest signals are checked first: files with different
+// sizes always differ, and the contents are only compared (using
+// ContentsDiffer) with CompareChecksum.
+func FilesDiffer(srcClient *Client, src string, dstClient *Client, dst string, options CompareOptions) (bool, error) {
+	srcInfo, err := srcClient.Stat(src)
+	if err != nil {
+		return false, err
+	}
+
+	dstInfo, err := dstClient.Stat(dst)
+	if err != nil {
+		return false, err
+	}
+
+	differ, decided := quickCompare(srcInfo, dstInfo, options)
+	if decided {
+		return differ, nil
+	}
+
+	return ContentsDiffer(srcClient, src, dstClient, dst)
+}
+
+// FileDiffersFromLocal is like FilesDiffer, but compares the file name in HDFS
+// with the file localName on the local filesystem.
+func FileDiffersFromLocal(client *Client, name, localName string, options CompareOptions) (bool, error) {
+	info, err := client.Stat(name)
+	if err != nil {
+		return false, err
+	}
+
+	localInfo, err := os.Stat(localName)
+	if err != nil {
+		return false, err
+	}
+
+	differ, decided := quickCompare(info, localInfo, options)
+	if decided {
+		return differ, nil
+	}
+
+	r, err := client.Open(name)
+	if err != nil {
+		return false, err
+	}
+
+	defer r.Close()
+
+	local, err := os.Open(localName)
+	if err != nil {
+		return false, err
+	}
+
+	defer local.Close()
+
+	// If the cluster supports composite CRCs, the local file can be checksummed
+	// instead of reading the remote one. That doesn't work in an encryption
+	// zone, where the checksums cover the stored ciphertext.
+	if r.Stat().(*FileInfo).Encrypted() {
+		return readersDiffer(r, local)
+	}
+
+	checksums, err := r.BlockChecksums()
+	if err != nil {
+		return false, err
+	}
+
+	if composite, err := r.CompositeChecksum(); err == nil && len(checksums) > 0 {
+		var crc hash.Hash32
+		switch checksums[0].ChecksumType {
+		case "CRC32":
+			crc = crc32.NewIEEE()
+		case "CRC32C":
+			crc = crc32.New(crc32.MakeTable(crc32.Castagnoli))
+		}
+
+		if crc != nil {
+			_, err = io.Copy(crc, local)
+			if err != nil {
+				return false, err
+			}
+
+			return binary.BigEndian.Uint32(composite) != crc.Sum32(), nil
+		}
+	}
+
+	return readersDiffer(r, local)
+}
+
+// quickCompare compares the size and modification time of two files. It
+// returns whether they differ, and whether that's conclusive.
+func quickCompare(a, b os.FileInfo, options CompareOptions) (bool, bool) {
+	if a.IsDir() || b.IsDir() {
+		return a.IsDir() != b.IsDir(), true
+	} else if a.Size() != b.Size() {
+		return true, true
+	} else if a.Size() == 0 {
+		return false, true
+	}
+
+	sameTime := a.ModTime().Unix() == b.ModTime().Unix()
+	switch options.Mode {
+	case CompareSize:
+		return false, true
+	case CompareChecksum:
+		if options.TrustModTime && sameTime {
+			return false, true
+		}
+
+		return false, false
+	default:
+		return !sameTime, true
+	}
+}
+
+// ContentsDiffer reports whether the contents of the file src (using
+// srcClient) differ from those of dst (using dstClient), escalating from
+// cheaper to more expensive comparisons as needed:
+//
+//   - If the files have the same block layout and chunk checksums, their
+//     block checksums are compared, which only requires the datanodes to read
+//     the stored CRCs.
+//   - Otherwise, if the cluster supports it (see FileReader.CompositeChecksum)
+//     and the files were written with the same type of CRC, their composite
+//     CRCs are compared.
+//   - Failing that, both files are read and compared byte by byte.
+//
+// Files in an encryption zone are always read, since their checksums cover the
+// stored ciphertext rather than the contents.
+func ContentsDiffer(srcClient *Client, src string, dstClient *Client, dst string) (bool, error) {
+	srcReader, err := srcClient.Open(src)
+	if err != nil {
+		return false, err
+	}
+
+	defer srcReader.Close()
+
+	dstReader, err := dstClient.Open(dst)
+	if err != nil {
+		return false, err
+	}
+
+	defer dstReader.Close()
+
+	if srcReader.Stat().Size() != dstReader.Stat().Size() {
+		return true, nil
+	} else if srcReader.Stat().(*FileInfo).Encrypted() || dstReader.Stat().(*FileInfo).Encrypted() {
+		return readersDiffer(srcReader, dstReader)
+	}
+
+	srcChecksums, err := srcReader.BlockChecksums()
+	if err != nil {
+		return false, err
+	}
+
+	dstChecksums, err := dstReader.BlockChecksums()
+	if err != nil {
+		return false, err
+	}
+
+	if sameLayout(srcReader, srcChecksums, dstReader, dstChecksums) {
+		for i := range srcChecksums {
+			if !bytes.Equal(srcChecksums[i].Checksum, dstChecksums[i].Checksum) {
+				return true, nil
+			}
+		}
+
+		return false, nil
+	}
+
+	if len(srcChecksums) > 0 && len(dstChecksums) > 0 &&
+		srcChecksums[0].ChecksumType == dstChecksums[0].ChecksumType {
+		srcComposite, err := srcReader.CompositeChecksum()
+		if err == nil {
+			dstComposite, err := dstReader.CompositeChecksum()
+			if err == nil {
+				return !bytes.Equal(srcComposite, dstComposite), nil
+			}
+		}
+	}
+
+	return readersDiffer(srcReader, dstReader)
+}
+
+// sameLayout returns true if the block checksums of two files are comparable,
+// because the blocks have the same lengths and chunk checksums.
+func sameLayout(a *FileReader, aChecksums []BlockChecksum, b *FileReader, bChecksums []BlockChecksum) bool {
+	if len(aChecksums) != len(bChecksums) ||
+		a.Stat().(*FileInfo).ErasureCodingPolicy() != b.Stat().(*FileInfo).ErasureCodingPolicy() {
+		return false
+	}
+
+	for i := range aChecksums {
+		ac, bc := aChecksums[i], bChecksums[i]
+		if ac.Length != bc.Length || ac.ChecksumType != bc.ChecksumType ||
+			ac.BytesPerCRC != bc.BytesPerCRC {
+			return false
+		}
+	}
+
+	return true
+}
+
+// readersDiffer reads a and b to the end, or until they differ.
+func readersDiffer(a, b io.Reader) (bool, error) {
+	bufA := make([]byte, 1024*1024)
+	bufB := make([]byte, len(bufA))
+	for {
+		n, errA := io.ReadFull(a, bufA)
+		if errA != nil && errA != io.EOF && errA != io.ErrUnexpectedEOF {
+			return false, errA
+		}
+
+		m, errB := io.ReadFull(b, bufB[:n])
+		if errB == io.ErrUnexpectedEOF || (errB == io.EOF && n > 0) {
+			return true, nil
+		} else if errB != nil && errB != io.EOF {
+			return false, errB
+		}
+
+		if !bytes.Equal(bufA[:n], bufB[:m]) {
+			return true, nil
+		}
+
+		if errA != nil {
+			// a is finished; b must be too.
+			extra, err := b.Read(bufB[:1])
+			if extra > 0 {
+				return true, nil
+			} else if err != nil && err != io.EOF {
+				return false, err
+			}
+
+			return false, nil
+		}
+	}
+}
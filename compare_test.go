@@ -0,0 +1,123 @@
+package hdfs
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestFile(t *testing.T, client *Client, name string, options CreateOptions, contents string) {
+	options.Overwrite = true
+	w, err := client.CreateWithOptions(name, options)
+	require.NoError(t, err)
+
+	_, err = w.Write([]byte(contents))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+}
+
+func TestFilesDiffer(t *testing.T) {
+	client := getClient(t)
+
+	mkdirp(t, "/_test/compare")
+	writeTestFile(t, client, "/_test/compare/a", CreateOptions{}, "foo")
+	writeTestFile(t, client, "/_test/compare/b", CreateOptions{}, "foo")
+	writeTestFile(t, client, "/_test/compare/c", CreateOptions{}, "bar")
+	writeTestFile(t, client, "/_test/compare/d", CreateOptions{}, "foobar")
+
+	mtime := time.Now().Add(-time.Hour)
+	for _, name := range []string{"a", "b", "c", "d"} {
+		require.NoError(t, client.Chtimes("/_test/compare/"+name, mtime, mtime))
+	}
+
+	differ, err := FilesDiffer(client, "/_test/compare/a", client, "/_test/compare/d", CompareOptions{Mode: CompareChecksum})
+	require.NoError(t, err)
+	assert.True(t, differ)
+
+	differ, err = FilesDiffer(client, "/_test/compare/a", client, "/_test/compare/c", CompareOptions{})
+	require.NoError(t, err)
+	assert.False(t, differ)
+
+	differ, err = FilesDiffer(client, "/_test/compare/a", client, "/_test/compare/c", CompareOptions{Mode: CompareChecksum})
+	require.NoError(t, err)
+	assert.True(t, differ)
+
+	differ, err = FilesDiffer(client, "/_test/compare/a", client, "/_test/compare/c", CompareOptions{Mode: CompareChecksum, TrustModTime: true})
+	require.NoError(t, err)
+	assert.False(t, differ)
+
+	differ, err = FilesDiffer(client, "/_test/compare/a", client, "/_test/compare/b", CompareOptions{Mode: CompareChecksum})
+	require.NoError(t, err)
+	assert.False(t, differ)
+}
+
+func TestContentsDifferBlockSize(t *testing.T) {
+	client := getClient(t)
+
+	contents := string(bytes.Repeat([]byte("foobar"), 500000))
+	mkdirp(t, "/_test/compare")
+	writeTestFile(t, client, "/_test/compare/small_blocks", CreateOptions{BlockSize: 1048576}, contents)
+	writeTestFile(t, client, "/_test/compare/large_blocks", CreateOptions{BlockSize: 2097152}, contents)
+
+	// The block checksums can't be compared, so the composite CRCs (or the
+	// contents) are.
+	differ, err := ContentsDiffer(client, "/_test/compare/small_blocks", client, "/_test/compare/large_blocks")
+	require.NoError(t, err)
+	assert.False(t, differ)
+
+	writeTestFile(t, client, "/_test/compare/large_blocks", CreateOptions{BlockSize: 2097152}, contents[:len(contents)-1]+"x")
+	differ, err = ContentsDiffer(client, "/_test/compare/small_blocks", client, "/_test/compare/large_blocks")
+	require.NoError(t, err)
+	assert.True(t, differ)
+}
+
+func TestFileDiffersFromLocal(t *testing.T) {
+	client := getClient(t)
+
+	dir, err := ioutil.TempDir("", "hdfs_compare")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	local := filepath.Join(dir, "foo.txt")
+	require.NoError(t, ioutil.WriteFile(local, []byte("bar\n"), 0644))
+
+	differ, err := FileDiffersFromLocal(client, "/_test/foo.txt", local, CompareOptions{Mode: CompareChecksum})
+	require.NoError(t, err)
+	assert.False(t, differ)
+
+	require.NoError(t, ioutil.WriteFile(local, []byte("baz\n"), 0644))
+	differ, err = FileDiffersFromLocal(client, "/_test/foo.txt", local, CompareOptions{Mode: CompareChecksum})
+	require.NoError(t, err)
+	assert.True(t, differ)
+
+	differ, err = FileDiffersFromLocal(client, "/_test/foo.txt", local, CompareOptions{Mode: CompareSize})
+	require.NoError(t, err)
+	assert.False(t, differ)
+}
+
+func TestReadersDiffer(t *testing.T) {
+	data := bytes.Repeat([]byte("foobar"), 500000)
+
+	cases := []struct {
+		a, b   []byte
+		differ bool
+	}{
+		{data, data, false},
+		{nil, nil, false},
+		{data, data[:len(data)-1], true},
+		{data[:len(data)-1], data, true},
+		{data, append(data[:len(data)-1:len(data)-1], 'x'), true},
+	}
+
+	for i, c := range cases {
+		differ, err := readersDiffer(bytes.NewReader(c.a), bytes.NewReader(c.b))
+		require.NoError(t, err)
+		assert.Equal(t, c.differ, differ, "case %d", i)
+	}
+}
@@ -0,0 +1,48 @@
+package hdfs
+
+// These implement the CRC composition used by HDFS's COMPOSITE_CRC checksums,
+// which allows the CRC of a concatenation of two pieces of data to be computed
+// from their individual CRCs and lengths. The polynomials are represented the
+// same way as in hash/crc32 (reversed, so that the top bit is x^0), which is
+// also the representation used by Hadoop.
+//
+// See: https://github.com/apache/hadoop/blob/branch-3.1/hadoop-common-project/hadoop-common/src/main/java/org/apache/hadoop/util/CrcUtil.java
+
+// gfMultiply multiplies p by q, modulo poly.
+func gfMultiply(p, q, poly uint32) uint32 {
+	var product uint32
+	for term := uint32(1 << 31); term != 0; term >>= 1 {
+		if q&term != 0 {
+			product ^= p
+		}
+
+		if p&1 != 0 {
+			p = (p >> 1) ^ poly
+		} else {
+			p >>= 1
+		}
+	}
+
+	return product
+}
+
+// crcMonomial returns x^(8*length) modulo poly.
+func crcMonomial(length int64, poly uint32) uint32 {
+	product := uint32(1 << 31)    // 1
+	multiplier := uint32(1 << 23) // x^8
+	for ; length > 0; length >>= 1 {
+		if length&1 != 0 {
+			product = gfMultiply(product, multiplier, poly)
+		}
+
+		multiplier = gfMultiply(multiplier, multiplier, poly)
+	}
+
+	return product
+}
+
+// composeCRC returns the CRC of A concatenated with B, given the CRCs of A and
+// B and the length of B in bytes.
+func composeCRC(crcA, crcB uint32, lengthB int64, poly uint32) uint32 {
+	return gfMultiply(crcA, crcMonomial(lengthB, poly), poly) ^ crcB
+}
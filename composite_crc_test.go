@@ -0,0 +1,25 @@
+package hdfs
+
+import (
+	"hash/crc32"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComposeCRC(t *testing.T) {
+	for _, poly := range []uint32{crc32.IEEE, crc32.Castagnoli} {
+		table := crc32.MakeTable(poly)
+		for _, length := range []int{0, 1, 7, 512, 4096 + 13} {
+			a := make([]byte, rand.Intn(1000))
+			b := make([]byte, length)
+			rand.Read(a)
+			rand.Read(b)
+
+			expected := crc32.Checksum(append(a, b...), table)
+			composed := composeCRC(crc32.Checksum(a, table), crc32.Checksum(b, table), int64(len(b)), poly)
+			assert.Equal(t, expected, composed, "poly %x, length %d", poly, length)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package hdfs
+
+import (
+	"os"
+
+	hdfs "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_hdfs"
+	"github.com/golang/protobuf/proto"
+)
+
+// Concat appends the blocks of the source files to the end of target, in the
+// order given, and then removes the source files. No data is copied; the
+// namenode just moves the blocks from one file to the other.
+//
+// The target must already exist, and the sources must all be in the same
+// directory as the target and have the same block size and replication.
+func (c *Client) Concat(target string, sources []string) error {
+	req := &hdfs.ConcatRequestProto{
+		Trg:  proto.String(target),
+		Srcs: sources,
+	}
+	resp := &hdfs.ConcatResponseProto{}
+
+	err := c.namenode.Execute("concat", req, resp)
+	if err != nil {
+		return &os.PathError{"concat", target, interpretException(err)}
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package hdfs
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeConcatTestFile(t *testing.T, client *Client, name, contents string) {
+	baleet(t, name)
+
+	writer, err := client.Create(name)
+	require.NoError(t, err)
+
+	_, err = writer.Write([]byte(contents))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+}
+
+func TestConcat(t *testing.T) {
+	client := getClient(t)
+
+	mkdirp(t, "/_test/concat")
+	writeConcatTestFile(t, client, "/_test/concat/target", "foo")
+	writeConcatTestFile(t, client, "/_test/concat/part1", "bar")
+	writeConcatTestFile(t, client, "/_test/concat/part2", "baz")
+
+	err := client.Concat("/_test/concat/target", []string{"/_test/concat/part1", "/_test/concat/part2"})
+	require.NoError(t, err)
+
+	reader, err := client.Open("/_test/concat/target")
+	require.NoError(t, err)
+
+	bytes, err := ioutil.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Equal(t, "foobarbaz", string(bytes))
+
+	_, err = client.Stat("/_test/concat/part1")
+	assertPathError(t, err, "stat", "/_test/concat/part1", os.ErrNotExist)
+}
+
+func TestConcatNonexistent(t *testing.T) {
+	client := getClient(t)
+
+	mkdirp(t, "/_test/concat")
+	baleet(t, "/_test/concat/nonexistent")
+	writeConcatTestFile(t, client, "/_test/concat/part3", "bar")
+
+	err := client.Concat("/_test/concat/nonexistent", []string{"/_test/concat/part3"})
+	assertPathError(t, err, "concat", "/_test/concat/nonexistent", os.ErrNotExist)
+}
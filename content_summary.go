@@ -17,7 +17,8 @@ type ContentSummary struct {
 
 // GetContentSummary returns a ContentSummary representing the named file or
 // directory. The summary contains information about the entire tree rooted
-// in the named file; for instance, it can return the total size of all
+// in the named file; for instance, it can return the total size of all the
+// files in it, and the quotas set on it.
 func (c *Client) GetContentSummary(name string) (*ContentSummary, error) {
 	cs, err := c.getContentSummary(name)
 	if err != nil {
@@ -64,16 +65,34 @@ func (cs *ContentSummary) DirectoryCount() int {
 	return int(cs.contentSummary.GetDirectoryCount())
 }
 
-// NameQuota returns the HDFS configured "name quota" for the named path. The
-// name quota is a hard limit on the number of directories and files inside a
-// directory; see http://goo.gl/sOSJmJ for more information.
+// NameQuota returns the HDFS configured "name quota" for the named path, or -1
+// if there is none. The name quota is a hard limit on the number of
+// directories and files inside a directory; see http://goo.gl/sOSJmJ for more
+// information.
 func (cs *ContentSummary) NameQuota() int {
 	return int(cs.contentSummary.GetQuota())
 }
 
-// SpaceQuota returns the HDFS configured "name quota" for the named path. The
-// name quota is a hard limit on the number of directories and files inside
-// a directory; see http://goo.gl/sOSJmJ for more information.
+// SpaceQuota returns the HDFS configured "space quota" for the named path, or
+// -1 if there is none. The space quota is a hard limit on the total size of
+// the files inside a directory, counting every replica, so it can be compared
+// to SizeAfterReplication; see http://goo.gl/sOSJmJ for more information.
 func (cs *ContentSummary) SpaceQuota() int64 {
 	return int64(cs.contentSummary.GetSpaceQuota())
 }
+
+// StorageTypeQuotas returns the quotas set on the named path for specific
+// types of storage, with the space consumed on each.
+func (cs *ContentSummary) StorageTypeQuotas() []StorageTypeQuota {
+	infos := cs.contentSummary.GetTypeQuotaInfos().GetTypeQuotaInfo()
+	quotas := make([]StorageTypeQuota, 0, len(infos))
+	for _, info := range infos {
+		quotas = append(quotas, StorageTypeQuota{
+			StorageType: info.GetType().String(),
+			Quota:       int64(info.GetQuota()),
+			Consumed:    int64(info.GetConsumed()),
+		})
+	}
+
+	return quotas
+}
@@ -0,0 +1,243 @@
+package hdfs
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash"
+	"hash/crc32"
+	"io"
+	"os"
+)
+
+// CopyOptions specifies how a file is copied by CopyFile and
+// CopyFileBetween.
+type CopyOptions struct {
+	// Overwrite specifies that if the destination already exists, it should be
+	// replaced. Otherwise, an error wrapping os.ErrExist is returned.
+	Overwrite bool
+	// Replication is the replication factor of the copy. If zero, the
+	// replication of the source is used.
+	Replication int
+	// BlockSize is the block size of the copy. If zero, the block size of the
+	// source is used.
+	BlockSize int64
+	// Verify specifies that once the copy is written, its checksum should be
+	// compared to that of the data read from the source. If they don't match,
+	// the copy is removed, and a *ChecksumMismatchError is returned.
+	Verify bool
+
+	// These specify which attributes of the source are copied along with its
+	// contents. The modification and access times are preserved with
+	// PreserveTimes.
+	PreserveMode      bool
+	PreserveOwnership bool
+	PreserveTimes     bool
+	PreserveXAttrs    bool
+	PreserveACL       bool
+}
+
+// CopyFile copies the contents of the file src to dst, with the given options.
+// If copying the contents fails, the partial copy is removed. If preserving
+// the attributes of the file fails, the copy is left in place, and the first
+// error is returned.
+func (c *Client) CopyFile(src, dst string, options CopyOptions) error {
+	return CopyFileBetween(c, src, c, dst, options)
+}
+
+// CopyFileBetween is like CopyFile, but reads the file src using srcClient,
+// and writes dst using dstClient. The two can be connected to different
+// clusters.
+func CopyFileBetween(srcClient *Client, src string, dstClient *Client, dst string, options CopyOptions) error {
+	r, err := srcClient.Open(src)
+	if err != nil {
+		return err
+	}
+
+	defer r.Close()
+
+	info := r.Stat().(*FileInfo)
+	if info.IsDir() {
+		return &os.PathError{"copy", src, errors.New("is a directory")}
+	}
+
+	createOptions := CreateOptions{
+		Replication: options.Replication,
+		BlockSize:   options.BlockSize,
+		Overwrite:   options.Overwrite,
+	}
+
+	if createOptions.Replication == 0 {
+		createOptions.Replication = info.Replication()
+	}
+
+	if createOptions.BlockSize == 0 {
+		createOptions.BlockSize = info.BlockSize()
+	}
+
+	if options.PreserveMode {
+		createOptions.Perm = info.Mode().Perm()
+	}
+
+	w, err := dstClient.CreateWithOptions(dst, createOptions)
+	if err != nil {
+		return err
+	}
+
+	// The CRC of the data as it's read is compared to the copy afterwards. The
+	// writer uses the cluster's default checksum type (or
+	// ClientOptions.ChecksumType) for the chunk checksums, which isn't known
+	// until the copy is written, so both kinds of CRC are computed.
+	crcs := newCopyCRCs()
+	var source io.Reader = r
+	if options.Verify {
+		source = io.TeeReader(r, crcs)
+	}
+
+	_, err = io.Copy(w, source)
+	if err == nil {
+		err = w.Close()
+	} else {
+		w.Close()
+	}
+
+	if err == nil && options.Verify {
+		err = verifyCopy(r, dstClient, dst, crcs)
+	}
+
+	if err != nil {
+		dstClient.Remove(dst)
+		return err
+	}
+
+	return preserveAttributes(srcClient, src, info, dstClient, dst, options)
+}
+
+// copyCRCs computes the CRC32 and CRC32C of the data copied by
+// CopyFileBetween at the same time.
+type copyCRCs struct {
+	ieee, castagnoli hash.Hash32
+}
+
+func newCopyCRCs() *copyCRCs {
+	return &copyCRCs{
+		ieee:       crc32.NewIEEE(),
+		castagnoli: crc32.New(crc32.MakeTable(crc32.Castagnoli)),
+	}
+}
+
+func (c *copyCRCs) Write(b []byte) (int, error) {
+	c.ieee.Write(b)
+	return c.castagnoli.Write(b)
+}
+
+// forType returns the CRC with the given checksum type, as reported by
+// FileReader.BlockChecksums, and a new, empty hash of the same kind.
+func (c *copyCRCs) forType(checksumType string) (uint32, hash.Hash32) {
+	if checksumType == "CRC32C" {
+		return c.castagnoli.Sum32(), crc32.New(crc32.MakeTable(crc32.Castagnoli))
+	}
+
+	return c.ieee.Sum32(), crc32.NewIEEE()
+}
+
+// verifyCopy checks that dst has the CRC of the data copied. It uses the
+// composite checksum of dst if it can, or compares the regular checksum of the
+// source and dst, which only match if they were written with the same block
+// size and checksum type. Failing that, it reads back dst and computes the CRC
+// itself.
+func verifyCopy(r *FileReader, dstClient *Client, dst string, crcs *copyCRCs) error {
+	dstReader, err := dstClient.Open(dst)
+	if err != nil {
+		return err
+	}
+
+	defer dstReader.Close()
+
+	// The checksum type is taken from the block checksums of dst. Without them
+	// (over WebHDFS, for example), dst can only be read back, which works with
+	// either kind of CRC. An empty file has no blocks, but then both CRCs are
+	// zero anyway. In an encryption zone, the checksums cover the stored
+	// ciphertext, so dst is always read back, through the decrypting reader.
+	encrypted := dstReader.Stat().(*FileInfo).Encrypted()
+	checksumType := "CRC32"
+	checksums, checksumsErr := dstReader.BlockChecksums()
+	if checksumsErr == nil && len(checksums) > 0 {
+		checksumType = checksums[0].ChecksumType
+	}
+
+	expected, crc := crcs.forType(checksumType)
+	expectedBytes := make([]byte, 4)
+	binary.BigEndian.PutUint32(expectedBytes, expected)
+
+	if checksumsErr == nil && !encrypted {
+		composite, err := dstReader.CompositeChecksum()
+		if err == nil {
+			if bytes.Equal(composite, expectedBytes) {
+				return nil
+			}
+
+			return &ChecksumMismatchError{Name: dst, Expected: expectedBytes, Actual: composite}
+		}
+
+		srcChecksum, err := r.Checksum()
+		if err == nil {
+			dstChecksum, err := dstReader.Checksum()
+			if err == nil && bytes.Equal(srcChecksum, dstChecksum) {
+				return nil
+			}
+		}
+	}
+
+	_, err = io.Copy(crc, dstReader)
+	if err != nil {
+		return err
+	} else if actual := crc.Sum(nil); !bytes.Equal(actual, expectedBytes) {
+		return &ChecksumMismatchError{Name: dst, Expected: expectedBytes, Actual: actual}
+	}
+
+	return nil
+}
+
+func preserveAttributes(srcClient *Client, src string, info *FileInfo, dstClient *Client, dst string, options CopyOptions) error {
+	var firstErr error
+	setErr := func(err error) {
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	// The mode was given to the file when it was created, but the umask may
+	// have been applied.
+	if options.PreserveMode {
+		setErr(dstClient.Chmod(dst, info.Mode().Perm()))
+	}
+
+	if options.PreserveOwnership {
+		setErr(dstClient.Chown(dst, info.Owner(), info.OwnerGroup()))
+	}
+
+	if options.PreserveXAttrs {
+		xattrs, err := srcClient.GetXAttrs(src)
+		setErr(err)
+		for key, value := range xattrs {
+			setErr(dstClient.SetXAttr(dst, key, value))
+		}
+	}
+
+	if options.PreserveACL {
+		status, err := srcClient.GetAclStatus(src)
+		if err == nil && len(status.Entries) > 0 {
+			err = dstClient.SetAcl(dst, status.Acl())
+		}
+
+		setErr(err)
+	}
+
+	// This has to be last, since the other changes could update the times.
+	if options.PreserveTimes {
+		setErr(dstClient.Chtimes(dst, info.AccessTime(), info.ModTime()))
+	}
+
+	return firstErr
+}
@@ -0,0 +1,153 @@
+package hdfs
+
+import (
+	"errors"
+	"hash/crc32"
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func assertMobydick(t *testing.T, client *Client, name string) {
+	reader, err := client.Open(name)
+	require.NoError(t, err)
+	defer reader.Close()
+
+	hash := crc32.NewIEEE()
+	n, err := io.Copy(hash, reader)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1257276, n)
+	assert.EqualValues(t, 0x199d1ae6, hash.Sum32())
+}
+
+func TestCopyFile(t *testing.T) {
+	client := getClient(t)
+
+	mkdirp(t, "/_test/copy")
+	err := client.CopyFile("/_test/mobydick.txt", "/_test/copy/1.txt", CopyOptions{Verify: true})
+	require.NoError(t, err)
+	assertMobydick(t, client, "/_test/copy/1.txt")
+
+	src, err := client.Stat("/_test/mobydick.txt")
+	require.NoError(t, err)
+
+	dst, err := client.Stat("/_test/copy/1.txt")
+	require.NoError(t, err)
+	assert.Equal(t, src.(*FileInfo).BlockSize(), dst.(*FileInfo).BlockSize())
+	assert.Equal(t, src.(*FileInfo).Replication(), dst.(*FileInfo).Replication())
+}
+
+func TestCopyFileMultipleBlocks(t *testing.T) {
+	client := getClient(t)
+
+	mkdirp(t, "/_test/copy")
+	err := client.CopyFile("/_test/mobydick.txt", "/_test/copy/2.txt",
+		CopyOptions{BlockSize: 1048576, Replication: 1, Verify: true})
+	require.NoError(t, err)
+	assertMobydick(t, client, "/_test/copy/2.txt")
+
+	dst, err := client.Stat("/_test/copy/2.txt")
+	require.NoError(t, err)
+	assert.EqualValues(t, 1048576, dst.(*FileInfo).BlockSize())
+	assert.EqualValues(t, 1, dst.(*FileInfo).Replication())
+}
+
+func TestCopyFileCRC32C(t *testing.T) {
+	client := newClientWithOptions(t, "gohdfs1", func(options *ClientOptions) {
+		options.ChecksumType = "CRC32C"
+	})
+	defer client.Close()
+
+	mkdirp(t, "/_test/copy")
+	baleet(t, "/_test/copy/crc32c.txt")
+	err := client.CopyFile("/_test/mobydick.txt", "/_test/copy/crc32c.txt", CopyOptions{Verify: true})
+	require.NoError(t, err)
+	assertMobydick(t, client, "/_test/copy/crc32c.txt")
+
+	r, err := client.Open("/_test/copy/crc32c.txt")
+	require.NoError(t, err)
+	defer r.Close()
+
+	checksums, err := r.BlockChecksums()
+	require.NoError(t, err)
+	require.NotEmpty(t, checksums)
+	assert.Equal(t, "CRC32C", checksums[0].ChecksumType)
+}
+
+func TestCopyCRCs(t *testing.T) {
+	crcs := newCopyCRCs()
+	crcs.Write([]byte("foo bar baz"))
+
+	expected, crc := crcs.forType("CRC32C")
+	assert.Equal(t, crc32.Checksum([]byte("foo bar baz"), crc32.MakeTable(crc32.Castagnoli)), expected)
+	crc.Write([]byte("foo bar baz"))
+	assert.Equal(t, expected, crc.Sum32())
+
+	expected, crc = crcs.forType("CRC32")
+	assert.Equal(t, crc32.ChecksumIEEE([]byte("foo bar baz")), expected)
+	crc.Write([]byte("foo bar baz"))
+	assert.Equal(t, expected, crc.Sum32())
+}
+
+func TestCopyFileExists(t *testing.T) {
+	client := getClient(t)
+
+	mkdirp(t, "/_test/copy")
+	touch(t, "/_test/copy/3.txt")
+
+	err := client.CopyFile("/_test/mobydick.txt", "/_test/copy/3.txt", CopyOptions{})
+	assertPathError(t, err, "create", "/_test/copy/3.txt", os.ErrExist)
+
+	err = client.CopyFile("/_test/mobydick.txt", "/_test/copy/3.txt", CopyOptions{Overwrite: true})
+	require.NoError(t, err)
+	assertMobydick(t, client, "/_test/copy/3.txt")
+}
+
+func TestCopyFilePreserve(t *testing.T) {
+	client := getClient(t)
+
+	mkdirp(t, "/_test/copy")
+	writeConcatTestFile(t, client, "/_test/copy/src.txt", "foo")
+
+	mtime := time.Now().Add(-time.Hour).Truncate(time.Second)
+	require.NoError(t, client.Chmod("/_test/copy/src.txt", 0600))
+	require.NoError(t, client.Chtimes("/_test/copy/src.txt", mtime, mtime))
+	require.NoError(t, client.SetXAttr("/_test/copy/src.txt", "user.foo", "bar"))
+
+	err := client.CopyFile("/_test/copy/src.txt", "/_test/copy/4.txt", CopyOptions{
+		PreserveMode:   true,
+		PreserveTimes:  true,
+		PreserveXAttrs: true,
+	})
+	require.NoError(t, err)
+
+	fi, err := client.Stat("/_test/copy/4.txt")
+	require.NoError(t, err)
+	assert.EqualValues(t, 0600, fi.Mode().Perm())
+	assert.Equal(t, mtime, fi.ModTime())
+
+	xattrs, err := client.GetXAttrs("/_test/copy/4.txt", "user.foo")
+	require.NoError(t, err)
+	assert.Equal(t, "bar", xattrs["user.foo"])
+}
+
+func TestCopyFileDir(t *testing.T) {
+	client := getClient(t)
+
+	mkdirp(t, "/_test/copy")
+	err := client.CopyFile("/_test/copy", "/_test/copy2", CopyOptions{})
+	assertPathError(t, err, "copy", "/_test/copy", errors.New("is a directory"))
+}
+
+func TestCopyFileNonexistent(t *testing.T) {
+	client := getClient(t)
+
+	baleet(t, "/_test/nonexistent")
+
+	err := client.CopyFile("/_test/nonexistent", "/_test/copy/5.txt", CopyOptions{})
+	assertPathError(t, err, "open", "/_test/nonexistent", os.ErrNotExist)
+}
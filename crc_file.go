@@ -0,0 +1,128 @@
+package hdfs
+
+import (
+	"encoding/binary"
+	"hash"
+	"hash/crc32"
+	"io"
+	"path/filepath"
+)
+
+// DefaultCRCFileBytesPerChecksum is the chunk size used for .crc files by
+// Hadoop's LocalFileSystem, unless file.bytes-per-checksum is set.
+const DefaultCRCFileBytesPerChecksum = 512
+
+var crcFileMagic = []byte{'c', 'r', 'c', 0}
+
+// CRCFileName returns the name of the .crc sidecar file for the local file
+// with the given name, as used by Hadoop's ChecksumFileSystem (and therefore
+// LocalFileSystem): ".<name>.crc", in the same directory.
+func CRCFileName(name string) string {
+	dir, base := filepath.Split(name)
+	return filepath.Join(dir, "."+base+".crc")
+}
+
+// A CRCFileWriter computes a Hadoop-compatible .crc file for the data written
+// to it, and writes it to an underlying writer. The format is the one used by
+// ChecksumFileSystem: the magic bytes "crc\x00", the number of bytes per
+// checksum as a big-endian uint32, and then a big-endian CRC32 for every
+// chunk of data, the last of which may be partial.
+//
+// Close must be called once all the data has been written, to write the
+// checksum of the last chunk.
+type CRCFileWriter struct {
+	w                io.Writer
+	bytesPerChecksum int
+	crc              hash.Hash32
+	chunkLength      int
+	started          bool
+	closed           bool
+	err              error
+}
+
+// NewCRCFileWriter returns a CRCFileWriter that writes the .crc file to w,
+// with a checksum for every bytesPerChecksum bytes of data. If
+// bytesPerChecksum isn't positive, DefaultCRCFileBytesPerChecksum is used.
+func NewCRCFileWriter(w io.Writer, bytesPerChecksum int) *CRCFileWriter {
+	if bytesPerChecksum <= 0 {
+		bytesPerChecksum = DefaultCRCFileBytesPerChecksum
+	}
+
+	return &CRCFileWriter{
+		w:                w,
+		bytesPerChecksum: bytesPerChecksum,
+		crc:              crc32.NewIEEE(),
+	}
+}
+
+// Write implements io.Writer. It always consumes all of b, unless writing to
+// the underlying writer fails.
+func (cw *CRCFileWriter) Write(b []byte) (int, error) {
+	if cw.closed {
+		return 0, io.ErrClosedPipe
+	} else if err := cw.writeHeader(); err != nil {
+		return 0, err
+	}
+
+	n := 0
+	for n < len(b) {
+		size := cw.bytesPerChecksum - cw.chunkLength
+		if size > len(b)-n {
+			size = len(b) - n
+		}
+
+		cw.crc.Write(b[n : n+size])
+		cw.chunkLength += size
+		n += size
+
+		if cw.chunkLength == cw.bytesPerChecksum {
+			if err := cw.writeChecksum(); err != nil {
+				return n, err
+			}
+		}
+	}
+
+	return n, nil
+}
+
+// Close writes the checksum for the last partial chunk, if there is one. It
+// doesn't close the underlying writer.
+func (cw *CRCFileWriter) Close() error {
+	if cw.closed {
+		return cw.err
+	}
+
+	cw.closed = true
+	if err := cw.writeHeader(); err != nil {
+		return err
+	}
+
+	if cw.chunkLength > 0 {
+		return cw.writeChecksum()
+	}
+
+	return nil
+}
+
+func (cw *CRCFileWriter) writeHeader() error {
+	if cw.err != nil || cw.started {
+		return cw.err
+	}
+
+	cw.started = true
+	header := make([]byte, 8)
+	copy(header, crcFileMagic)
+	binary.BigEndian.PutUint32(header[4:], uint32(cw.bytesPerChecksum))
+	_, cw.err = cw.w.Write(header)
+	return cw.err
+}
+
+func (cw *CRCFileWriter) writeChecksum() error {
+	checksum := make([]byte, 4)
+	binary.BigEndian.PutUint32(checksum, cw.crc.Sum32())
+	cw.crc.Reset()
+	cw.chunkLength = 0
+
+	_, cw.err = cw.w.Write(checksum)
+	return cw.err
+}
@@ -0,0 +1,73 @@
+package hdfs
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCRCFileName(t *testing.T) {
+	assert.Equal(t, "/tmp/.foo.txt.crc", CRCFileName("/tmp/foo.txt"))
+	assert.Equal(t, ".foo.txt.crc", CRCFileName("foo.txt"))
+}
+
+func TestCRCFileWriter(t *testing.T) {
+	var buf bytes.Buffer
+	cw := NewCRCFileWriter(&buf, 4)
+
+	// Write across chunk boundaries.
+	for _, s := range []string{"fo", "obarb", "az", "q"} {
+		n, err := cw.Write([]byte(s))
+		require.NoError(t, err)
+		assert.Equal(t, len(s), n)
+	}
+
+	require.NoError(t, cw.Close())
+
+	expected := []byte{'c', 'r', 'c', 0, 0, 0, 0, 4}
+	for _, chunk := range []string{"foob", "arba", "zq"} {
+		expected = binary.BigEndian.AppendUint32(expected, crc32.ChecksumIEEE([]byte(chunk)))
+	}
+
+	assert.Equal(t, expected, buf.Bytes())
+}
+
+func TestCRCFileWriterEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	cw := NewCRCFileWriter(&buf, 0)
+	require.NoError(t, cw.Close())
+
+	assert.Equal(t, []byte{'c', 'r', 'c', 0, 0, 0, 2, 0}, buf.Bytes())
+}
+
+func TestCopyToLocalWithCRCFile(t *testing.T) {
+	client := getClient(t)
+
+	dir, err := ioutil.TempDir("", "hdfs-crc")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	dst := filepath.Join(dir, "mobydick.txt")
+	err = client.CopyToLocalWithOptions("/_test/mobydick.txt", dst, CopyToLocalOptions{CRCFile: true})
+	require.NoError(t, err)
+
+	data, err := ioutil.ReadFile(dst)
+	require.NoError(t, err)
+
+	var expected bytes.Buffer
+	cw := NewCRCFileWriter(&expected, 0)
+	cw.Write(data)
+	cw.Close()
+
+	crc, err := ioutil.ReadFile(filepath.Join(dir, ".mobydick.txt.crc"))
+	require.NoError(t, err)
+	assert.Equal(t, expected.Bytes(), crc)
+	assert.Equal(t, 8+4*((len(data)+511)/512), len(crc))
+}
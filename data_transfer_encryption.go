@@ -0,0 +1,97 @@
+package hdfs
+
+import (
+	"context"
+	"errors"
+	"net"
+	"time"
+
+	hdfs "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_hdfs"
+	"github.com/colinmarc/hdfs/v2/internal/rpc"
+)
+
+// dataEncryptionKeyMargin is how long before a data encryption key expires
+// that a new one is fetched, so that a key isn't used just as it expires.
+const dataEncryptionKeyMargin = time.Minute
+
+// dialDataTransfer connects to a datanode's data transfer port. If the cluster
+// requires it (with dfs.encrypt.data.transfer), it also performs the SASL
+// handshake for the connection, and returns a connection that encrypts
+// everything sent over it.
+func (c *Client) dialDataTransfer(ctx context.Context, network, addr string) (net.Conn, error) {
+	conn, err := c.datanodeDialFunc(ctx, network, addr)
+	if err != nil {
+		return nil, err
+	}
+
+	defaults, err := c.fetchDefaults()
+	if err != nil {
+		conn.Close()
+		return nil, err
+	} else if !defaults.GetEncryptDataTransfer() {
+		return conn, nil
+	}
+
+	encrypted, err := c.encryptDataTransfer(ctx, conn, false)
+	if err == rpc.ErrInvalidEncryptionKey {
+		// The key probably expired, or the namenode restarted. Like the Java
+		// client, fetch a new key and try again, once.
+		conn, err = c.datanodeDialFunc(ctx, network, addr)
+		if err != nil {
+			return nil, err
+		}
+
+		encrypted, err = c.encryptDataTransfer(ctx, conn, true)
+	}
+
+	return encrypted, err
+}
+
+func (c *Client) encryptDataTransfer(ctx context.Context, conn net.Conn, refresh bool) (net.Conn, error) {
+	key, err := c.dataEncryptionKey(refresh)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	encrypted, err := rpc.NegotiateDataTransferEncryption(conn, key, c.options.DataTransferAES)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	conn.SetDeadline(time.Time{})
+	return encrypted, nil
+}
+
+// dataEncryptionKey returns the key to use for encrypting connections to the
+// datanodes, fetching a new one from the namenode if the cached one is about
+// to expire, or if refresh is set.
+func (c *Client) dataEncryptionKey(refresh bool) (*rpc.DataEncryptionKey, error) {
+	c.encryptionKeyLock.Lock()
+	defer c.encryptionKeyLock.Unlock()
+
+	if !refresh && c.encryptionKey != nil && time.Until(c.encryptionKeyExpiry) > dataEncryptionKeyMargin {
+		return c.encryptionKey, nil
+	}
+
+	req := &hdfs.GetDataEncryptionKeyRequestProto{}
+	resp := &hdfs.GetDataEncryptionKeyResponseProto{}
+	err := c.namenode.Execute("getDataEncryptionKey", req, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	key := resp.GetDataEncryptionKey()
+	if key == nil {
+		return nil, errors.New("namenode didn't return a data encryption key")
+	}
+
+	c.encryptionKey = rpc.NewDataEncryptionKey(key)
+	c.encryptionKeyExpiry = time.Unix(0, int64(key.GetExpiryDate())*int64(time.Millisecond))
+	return c.encryptionKey, nil
+}
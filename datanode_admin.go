@@ -0,0 +1,174 @@
+package hdfs
+
+import (
+	"errors"
+	"time"
+
+	hdfs "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_hdfs"
+	"github.com/colinmarc/hdfs/v2/internal/rpc"
+	"github.com/golang/protobuf/proto"
+)
+
+// DatanodeAdminClient makes administrative requests directly to a single
+// datanode, over its IPC port, like the datanode subcommands of 'hdfs
+// dfsadmin'. Most of them require superuser privileges.
+type DatanodeAdminClient struct {
+	address string
+	conn    *rpc.NamenodeConnection
+}
+
+// DatanodeLocalInfo describes the software running on a datanode, as reported
+// by the datanode itself.
+type DatanodeLocalInfo struct {
+	SoftwareVersion string
+	ConfigVersion   string
+	Uptime          time.Duration
+}
+
+// DatanodeVolumeInfo describes a single volume (data directory) of a
+// datanode.
+type DatanodeVolumeInfo struct {
+	Path string
+	// StorageType is the type of storage the volume is on, for example "DISK"
+	// or "SSD".
+	StorageType string
+	Used        uint64
+	Free        uint64
+	Reserved    uint64
+	// ReservedForReplicas is the space reserved for replicas that are being
+	// written.
+	ReservedForReplicas uint64
+	NumBlocks           uint64
+}
+
+// DatanodeAdmin connects to the datanode at the given address, which should be
+// its IPC address (<host>:<ipc port>), for example using the IPCPort of a
+// DatanodeInfo returned by Datanodes. The connection is made as the same user
+// as the client, and with the same options for connecting to datanodes.
+func (c *Client) DatanodeAdmin(address string) (*DatanodeAdminClient, error) {
+	kerberosClient := c.options.KerberosClient
+	if kerberosClient != nil && c.options.DatanodeKerberosServicePrincipleName == "" {
+		return nil, errors.New("kerberos enabled, but kerberos datanode SPN is not provided")
+	}
+
+	conn, err := rpc.NewNamenodeConnection(rpc.NamenodeConnectionOptions{
+		Addresses:                    []string{address},
+		User:                         c.namenode.User,
+		DialFunc:                     c.datanodeDialFunc,
+		Protocol:                     clientDatanodeProtocol,
+		WireLog:                      c.wireLog,
+		Strict:                       c.options.StrictProtocol,
+		KerberosClient:               kerberosClient,
+		KerberosServicePrincipleName: c.options.DatanodeKerberosServicePrincipleName,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &DatanodeAdminClient{address: address, conn: conn}, nil
+}
+
+// Address returns the address the client is connected to.
+func (d *DatanodeAdminClient) Address() string {
+	return d.address
+}
+
+// GetDatanodeInfo returns the software version, configuration version and
+// uptime of the datanode.
+func (d *DatanodeAdminClient) GetDatanodeInfo() (DatanodeLocalInfo, error) {
+	req := &hdfs.GetDatanodeInfoRequestProto{}
+	resp := &hdfs.GetDatanodeInfoResponseProto{}
+
+	err := d.conn.Execute("getDatanodeInfo", req, resp)
+	if err != nil {
+		return DatanodeLocalInfo{}, err
+	}
+
+	info := resp.GetLocalInfo()
+	return DatanodeLocalInfo{
+		SoftwareVersion: info.GetSoftwareVersion(),
+		ConfigVersion:   info.GetConfigVersion(),
+		Uptime:          time.Duration(info.GetUptime()) * time.Second,
+	}, nil
+}
+
+// GetVolumeReport returns the state of each of the datanode's volumes. It
+// requires Hadoop 3.
+func (d *DatanodeAdminClient) GetVolumeReport() ([]DatanodeVolumeInfo, error) {
+	req := &hdfs.GetVolumeReportRequestProto{}
+	resp := &hdfs.GetVolumeReportResponseProto{}
+
+	err := d.conn.Execute("getVolumeReport", req, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	volumes := make([]DatanodeVolumeInfo, 0, len(resp.GetVolumeInfo()))
+	for _, v := range resp.GetVolumeInfo() {
+		volumes = append(volumes, DatanodeVolumeInfo{
+			Path:                v.GetPath(),
+			StorageType:         v.GetStorageType().String(),
+			Used:                v.GetUsedSpace(),
+			Free:                v.GetFreeSpace(),
+			Reserved:            v.GetReservedSpace(),
+			ReservedForReplicas: v.GetReservedSpaceForReplicas(),
+			NumBlocks:           v.GetNumBlocks(),
+		})
+	}
+
+	return volumes, nil
+}
+
+// ShutdownDatanode asks the datanode to shut down. If forUpgrade is true, the
+// datanode tells clients writing to it to wait for it to restart, rather than
+// moving on to another datanode in the pipeline, as in a rolling upgrade.
+func (d *DatanodeAdminClient) ShutdownDatanode(forUpgrade bool) error {
+	req := &hdfs.ShutdownDatanodeRequestProto{ForUpgrade: proto.Bool(forUpgrade)}
+	resp := &hdfs.ShutdownDatanodeResponseProto{}
+
+	return d.conn.Execute("shutdownDatanode", req, resp)
+}
+
+// EvictWriters makes the datanode close all the connections of clients
+// writing to it, so that they move on to other datanodes. This is useful for
+// speeding up decommissioning.
+func (d *DatanodeAdminClient) EvictWriters() error {
+	req := &hdfs.EvictWritersRequestProto{}
+	resp := &hdfs.EvictWritersResponseProto{}
+
+	return d.conn.Execute("evictWriters", req, resp)
+}
+
+// TriggerBlockReport makes the datanode send a block report to the namenodes
+// immediately, rather than waiting for the next scheduled one. If incremental
+// is true, only the changes since the last report are sent. If namenode is
+// set, the report is only sent to that namenode (<host>:<port>), which
+// requires Hadoop 3.3.
+func (d *DatanodeAdminClient) TriggerBlockReport(incremental bool, namenode string) error {
+	req := &hdfs.TriggerBlockReportRequestProto{Incremental: proto.Bool(incremental)}
+	if namenode != "" {
+		req.NnAddress = proto.String(namenode)
+	}
+	resp := &hdfs.TriggerBlockReportResponseProto{}
+
+	return d.conn.Execute("triggerBlockReport", req, resp)
+}
+
+// GetBalancerBandwidth returns the maximum bandwidth the datanode uses for
+// balancing, in bytes per second.
+func (d *DatanodeAdminClient) GetBalancerBandwidth() (int64, error) {
+	req := &hdfs.GetBalancerBandwidthRequestProto{}
+	resp := &hdfs.GetBalancerBandwidthResponseProto{}
+
+	err := d.conn.Execute("getBalancerBandwidth", req, resp)
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(resp.GetBandwidth()), nil
+}
+
+// Close closes the connection to the datanode.
+func (d *DatanodeAdminClient) Close() error {
+	return d.conn.Close()
+}
@@ -0,0 +1,67 @@
+package hdfs
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getDatanodeAdmin(t *testing.T) *DatanodeAdminClient {
+	client := getClientForSuperUser(t)
+
+	datanodes, err := client.Datanodes()
+	require.NoError(t, err)
+	require.NotEmpty(t, datanodes)
+
+	dn := datanodes[0]
+	admin, err := client.DatanodeAdmin(fmt.Sprintf("%s:%d", dn.IPAddr, dn.IPCPort))
+	require.NoError(t, err)
+
+	return admin
+}
+
+func TestDatanodeAdminGetDatanodeInfo(t *testing.T) {
+	admin := getDatanodeAdmin(t)
+	defer admin.Close()
+
+	info, err := admin.GetDatanodeInfo()
+	require.NoError(t, err)
+	assert.NotEmpty(t, info.SoftwareVersion)
+	assert.NotZero(t, info.Uptime)
+}
+
+func TestDatanodeAdminGetBalancerBandwidth(t *testing.T) {
+	admin := getDatanodeAdmin(t)
+	defer admin.Close()
+
+	bandwidth, err := admin.GetBalancerBandwidth()
+	require.NoError(t, err)
+	assert.True(t, bandwidth > 0)
+}
+
+func TestDatanodeAdminTriggerBlockReport(t *testing.T) {
+	admin := getDatanodeAdmin(t)
+	defer admin.Close()
+
+	err := admin.TriggerBlockReport(true, "")
+	require.NoError(t, err)
+}
+
+func TestDatanodeAdminGetVolumeReport(t *testing.T) {
+	admin := getDatanodeAdmin(t)
+	defer admin.Close()
+
+	volumes, err := admin.GetVolumeReport()
+	if remoteErr, ok := err.(Error); ok && remoteErr.Exception() == "org.apache.hadoop.ipc.RpcNoSuchMethodException" {
+		t.Skip("getVolumeReport isn't supported by this version of Hadoop")
+	}
+
+	require.NoError(t, err)
+	require.NotEmpty(t, volumes)
+	for _, v := range volumes {
+		assert.NotEmpty(t, v.Path)
+		assert.NotEmpty(t, v.StorageType)
+	}
+}
@@ -0,0 +1,36 @@
+package hdfs
+
+import (
+	"time"
+
+	"github.com/colinmarc/hdfs/v2/internal/rpc"
+)
+
+// defaultDatanodeIdleConnTimeout is the same as the Java client's
+// dfs.client.socketcache.expiryMsec.
+const defaultDatanodeIdleConnTimeout = 3 * time.Second
+
+func newDatanodeConnPool(options ClientOptions) *rpc.DatanodeConnPool {
+	if options.DatanodeMaxIdleConns <= 0 {
+		return nil
+	}
+
+	timeout := options.DatanodeIdleConnTimeout
+	if timeout <= 0 {
+		timeout = defaultDatanodeIdleConnTimeout
+	}
+
+	return rpc.NewDatanodeConnPool(options.DatanodeMaxIdleConns, timeout)
+}
+
+// connPool returns the pool of datanode connections to use for block reads,
+// or nil if connections shouldn't be reused. Connections opened while the
+// FileReader is bound to a context are tracked by it, so they can't be shared
+// with other readers.
+func (f *FileReader) connPool() *rpc.DatanodeConnPool {
+	if f.tc != nil {
+		return nil
+	}
+
+	return f.client.datanodePool
+}
@@ -0,0 +1,58 @@
+package hdfs
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/colinmarc/hdfs/v2/hadoopconf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatanodeConnPoolReuse(t *testing.T) {
+	conf, err := hadoopconf.LoadFromEnvironment()
+	if err != nil || conf == nil {
+		t.Fatal("Couldn't load ambient config", err)
+	}
+
+	var dials int32
+	options := ClientOptionsFromConf(conf)
+	options.User = "gohdfs1"
+	options.DatanodeMaxIdleConns = 4
+	options.DatanodeDialFunc = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		atomic.AddInt32(&dials, 1)
+		return (&net.Dialer{}).DialContext(ctx, network, addr)
+	}
+
+	client, err := NewClient(options)
+	require.NoError(t, err)
+	defer client.Close()
+
+	for i := 0; i < 5; i++ {
+		b, err := client.ReadFile("/_test/foo.txt")
+		require.NoError(t, err)
+		assert.Equal(t, "bar\n", string(b))
+	}
+
+	// Each replica is dialed at most once.
+	assert.True(t, atomic.LoadInt32(&dials) < 5)
+	assert.EqualValues(t, 5-atomic.LoadInt32(&dials), client.datanodePool.Reused())
+}
+
+func TestDatanodeConnPoolOptions(t *testing.T) {
+	assert.Nil(t, newDatanodeConnPool(ClientOptions{}))
+
+	options := ClientOptionsFromConf(hadoopconf.HadoopConf{
+		"dfs.client.socketcache.capacity":   "16",
+		"dfs.client.socketcache.expiryMsec": "2000",
+	})
+	assert.Equal(t, 16, options.DatanodeMaxIdleConns)
+	assert.Equal(t, 2*time.Second, options.DatanodeIdleConnTimeout)
+
+	pool := newDatanodeConnPool(ClientOptions{DatanodeMaxIdleConns: 2})
+	defer pool.Close()
+	assert.Equal(t, defaultDatanodeIdleConnTimeout, pool.IdleTimeout)
+}
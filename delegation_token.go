@@ -0,0 +1,196 @@
+package hdfs
+
+import (
+	"errors"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/colinmarc/hdfs/v2/hadoopconf"
+	hadoop "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_common"
+	"github.com/colinmarc/hdfs/v2/internal/rpc"
+	"github.com/golang/protobuf/proto"
+)
+
+// delegationTokenKind is the kind of the delegation tokens issued by the
+// namenode.
+const delegationTokenKind = "HDFS_DELEGATION_TOKEN"
+
+// Token is a Hadoop delegation token, which can be used to authenticate with
+// the namenode instead of Kerberos, with ClientOptions.DelegationToken. It's
+// typically fetched by a process that does have Kerberos credentials, and
+// handed to one that doesn't, like a YARN container.
+type Token struct {
+	Identifier []byte
+	Password   []byte
+	// Kind is the kind of token, for example "HDFS_DELEGATION_TOKEN".
+	Kind string
+	// Service identifies the namenode(s) that the token is for, either as an
+	// address ("10.0.0.1:8020") or as a nameservice ("ha-hdfs:mycluster").
+	Service string
+}
+
+// Owner returns the user that the token was issued to, which is the user that
+// a client using it acts as.
+func (t *Token) Owner() (string, error) {
+	return rpc.DelegationTokenOwner(t.Identifier)
+}
+
+func (t *Token) proto() *hadoop.TokenProto {
+	if t == nil {
+		return nil
+	}
+
+	return &hadoop.TokenProto{
+		Identifier: t.Identifier,
+		Password:   t.Password,
+		Kind:       proto.String(t.Kind),
+		Service:    proto.String(t.Service),
+	}
+}
+
+func newToken(t *hadoop.TokenProto) *Token {
+	return &Token{
+		Identifier: t.GetIdentifier(),
+		Password:   t.GetPassword(),
+		Kind:       t.GetKind(),
+		Service:    t.GetService(),
+	}
+}
+
+// GetDelegationToken fetches a new delegation token from the namenode, which
+// can be renewed by the given user (usually the YARN resource manager).
+// Delegation tokens are only issued to clients that authenticated with
+// Kerberos.
+func (c *Client) GetDelegationToken(renewer string) (*Token, error) {
+	req := &hadoop.GetDelegationTokenRequestProto{Renewer: proto.String(renewer)}
+	resp := &hadoop.GetDelegationTokenResponseProto{}
+
+	err := c.namenode.Execute("getDelegationToken", req, resp)
+	if err != nil {
+		return nil, interpretException(err)
+	}
+
+	if resp.GetToken() == nil {
+		return nil, errors.New("namenode didn't issue a delegation token, which requires kerberos authentication")
+	}
+
+	return newToken(resp.GetToken()), nil
+}
+
+// RenewDelegationToken extends the lifetime of a delegation token, and
+// returns when it will next expire. Only the renewer named when the token was
+// fetched can renew it, and not beyond its maximum lifetime.
+func (c *Client) RenewDelegationToken(token *Token) (time.Time, error) {
+	req := &hadoop.RenewDelegationTokenRequestProto{Token: token.proto()}
+	resp := &hadoop.RenewDelegationTokenResponseProto{}
+
+	err := c.namenode.Execute("renewDelegationToken", req, resp)
+	if err != nil {
+		return time.Time{}, interpretException(err)
+	}
+
+	return time.Unix(0, int64(resp.GetNewExpiryTime())*int64(time.Millisecond)), nil
+}
+
+// CancelDelegationToken revokes a delegation token, so that it can no longer
+// be used.
+func (c *Client) CancelDelegationToken(token *Token) error {
+	req := &hadoop.CancelDelegationTokenRequestProto{Token: token.proto()}
+	resp := &hadoop.CancelDelegationTokenResponseProto{}
+
+	err := c.namenode.Execute("cancelDelegationToken", req, resp)
+	if err != nil {
+		return interpretException(err)
+	}
+
+	return nil
+}
+
+// ReadTokenFile reads the tokens from a Hadoop credentials file, like the one
+// named by the HADOOP_TOKEN_FILE_LOCATION environment variable in YARN
+// containers, or written by 'hdfs fetchdt'.
+func ReadTokenFile(name string) ([]*Token, error) {
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	protos, err := rpc.ReadTokenStorage(b)
+	if err != nil {
+		return nil, &os.PathError{"readtokenfile", name, err}
+	}
+
+	tokens := make([]*Token, len(protos))
+	for i, t := range protos {
+		tokens[i] = newToken(t)
+	}
+
+	return tokens, nil
+}
+
+// tokenFromEnvironment returns the delegation token for the configured
+// namenodes from the file named by HADOOP_TOKEN_FILE_LOCATION, if there is
+// one. The token's service has to match either one of the namenode addresses
+// (or the same port on an IP address it resolves to, since that's how Hadoop
+// names services by default), or one of the configured nameservices. Tokens
+// for other clusters are ignored, so that they don't take the place of
+// Kerberos.
+func tokenFromEnvironment(conf hadoopconf.HadoopConf, addresses []string) *Token {
+	name := os.Getenv("HADOOP_TOKEN_FILE_LOCATION")
+	if name == "" {
+		return nil
+	}
+
+	tokens, err := ReadTokenFile(name)
+	if err != nil {
+		return nil
+	}
+
+	services := make(map[string]bool)
+	for _, address := range addresses {
+		services[address] = true
+	}
+
+	for key := range conf {
+		if strings.HasPrefix(key, "dfs.ha.namenodes.") {
+			services["ha-hdfs:"+strings.TrimPrefix(key, "dfs.ha.namenodes.")] = true
+		}
+	}
+
+	if token := matchToken(tokens, services); token != nil {
+		return token
+	}
+
+	resolved := make(map[string]bool)
+	for _, address := range addresses {
+		host, port, err := net.SplitHostPort(address)
+		if err != nil {
+			continue
+		}
+
+		ips, err := net.LookupHost(host)
+		if err != nil {
+			continue
+		}
+
+		for _, ip := range ips {
+			resolved[net.JoinHostPort(ip, port)] = true
+		}
+	}
+
+	return matchToken(tokens, resolved)
+}
+
+// matchToken returns the first delegation token with one of the given
+// services.
+func matchToken(tokens []*Token, services map[string]bool) *Token {
+	for _, token := range tokens {
+		if token.Kind == delegationTokenKind && services[token.Service] {
+			return token
+		}
+	}
+
+	return nil
+}
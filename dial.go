@@ -0,0 +1,253 @@
+package hdfs
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+)
+
+type dialFunc func(ctx context.Context, network, addr string) (net.Conn, error)
+
+// Resolver resolves hostnames to IP addresses. It's implemented by
+// *net.Resolver, so a resolver using a particular DNS server (or a custom
+// Dial function) can be used as-is.
+type Resolver interface {
+	LookupHost(ctx context.Context, host string) ([]string, error)
+}
+
+// cachingResolver caches the results of another Resolver. Failed lookups are
+// cached separately, for negativeTTL, so that a missing host doesn't result in
+// a DNS request for every connection attempt.
+type cachingResolver struct {
+	resolver    Resolver
+	ttl         time.Duration
+	negativeTTL time.Duration
+
+	mut   sync.Mutex
+	cache map[string]resolverCacheEntry
+}
+
+type resolverCacheEntry struct {
+	addrs   []string
+	err     error
+	expires time.Time
+}
+
+func newCachingResolver(resolver Resolver, ttl, negativeTTL time.Duration) *cachingResolver {
+	return &cachingResolver{
+		resolver:    resolver,
+		ttl:         ttl,
+		negativeTTL: negativeTTL,
+		cache:       make(map[string]resolverCacheEntry),
+	}
+}
+
+func (r *cachingResolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	r.mut.Lock()
+	entry, ok := r.cache[host]
+	r.mut.Unlock()
+	if ok && time.Now().Before(entry.expires) {
+		return entry.addrs, entry.err
+	}
+
+	addrs, err := r.resolver.LookupHost(ctx, host)
+	ttl := r.ttl
+	if err != nil {
+		ttl = r.negativeTTL
+		// Don't cache the failure if it was caused by the caller giving up.
+		if ctx.Err() != nil {
+			ttl = 0
+		}
+	}
+
+	if ttl > 0 {
+		r.mut.Lock()
+		r.cache[host] = resolverCacheEntry{addrs: addrs, err: err, expires: time.Now().Add(ttl)}
+		r.mut.Unlock()
+	}
+
+	return addrs, err
+}
+
+// newResolver returns the Resolver configured by the options, wrapped in a
+// cache if that's enabled, or nil if the system resolver should be used by
+// the dialers directly.
+func newResolver(options ClientOptions) Resolver {
+	resolver := options.Resolver
+	if options.ResolverCacheTTL > 0 || options.ResolverNegativeCacheTTL > 0 {
+		if resolver == nil {
+			resolver = net.DefaultResolver
+		}
+
+		resolver = newCachingResolver(resolver, options.ResolverCacheTTL, options.ResolverNegativeCacheTTL)
+	}
+
+	return resolver
+}
+
+// resolvingDialFunc returns a dialFunc which resolves the host part of each
+// address with resolver, and then dials each of the resulting IP addresses in
+// turn until one succeeds.
+func resolvingDialFunc(resolver Resolver, dial dialFunc) dialFunc {
+	if resolver == nil {
+		return dial
+	}
+
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(addr)
+		if err != nil || net.ParseIP(host) != nil {
+			return dial(ctx, network, addr)
+		}
+
+		ips, err := resolver.LookupHost(ctx, host)
+		if err != nil {
+			return nil, &net.OpError{Op: "dial", Net: network, Err: err}
+		}
+
+		err = &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+		for _, ip := range ips {
+			var conn net.Conn
+			conn, err = dial(ctx, network, net.JoinHostPort(ip, port))
+			if err == nil {
+				return conn, nil
+			} else if ctx.Err() != nil {
+				break
+			}
+		}
+
+		return nil, err
+	}
+}
+
+// SocketOptions represents TCP-level tuning for the connections a Client
+// makes. The zero value leaves the defaults of the operating system (and of
+// the net package, which enables TCP_NODELAY) in place.
+type SocketOptions struct {
+	// DisableNoDelay turns off TCP_NODELAY, enabling Nagle's algorithm.
+	DisableNoDelay bool
+	// KeepAlive sets the period between TCP keepalive probes. If zero, the
+	// keepalive setting of the dialer is left alone. If negative, keepalives
+	// are disabled.
+	KeepAlive time.Duration
+	// ReadBufferSize sets the size of the socket receive buffer (SO_RCVBUF).
+	// If zero, the operating system default is used.
+	ReadBufferSize int
+	// WriteBufferSize sets the size of the socket send buffer (SO_SNDBUF). If
+	// zero, the operating system default is used.
+	WriteBufferSize int
+}
+
+func (o SocketOptions) isZero() bool {
+	return o == SocketOptions{}
+}
+
+// apply sets the options on conn, if it is a TCP connection.
+func (o SocketOptions) apply(conn net.Conn) error {
+	tcpConn, ok := conn.(*net.TCPConn)
+	if !ok {
+		return nil
+	}
+
+	if o.DisableNoDelay {
+		if err := tcpConn.SetNoDelay(false); err != nil {
+			return err
+		}
+	}
+
+	if o.KeepAlive < 0 {
+		if err := tcpConn.SetKeepAlive(false); err != nil {
+			return err
+		}
+	} else if o.KeepAlive > 0 {
+		if err := tcpConn.SetKeepAlive(true); err != nil {
+			return err
+		}
+
+		if err := tcpConn.SetKeepAlivePeriod(o.KeepAlive); err != nil {
+			return err
+		}
+	}
+
+	if o.ReadBufferSize > 0 {
+		if err := tcpConn.SetReadBuffer(o.ReadBufferSize); err != nil {
+			return err
+		}
+	}
+
+	if o.WriteBufferSize > 0 {
+		if err := tcpConn.SetWriteBuffer(o.WriteBufferSize); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// wrap returns a dialFunc which applies the options to every connection
+// made with dial.
+func (o SocketOptions) wrap(dial dialFunc) dialFunc {
+	if o.isZero() {
+		return dial
+	}
+
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		conn, err := dial(ctx, network, addr)
+		if err != nil {
+			return nil, err
+		}
+
+		err = o.apply(conn)
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+
+		return conn, nil
+	}
+}
+
+// newNamenodeDialFunc builds the function used to connect to namenodes,
+// layering hostname resolution and socket options on top of the configured
+// NamenodeDialFunc.
+func newNamenodeDialFunc(options ClientOptions) dialFunc {
+	dial := dialFunc(options.NamenodeDialFunc)
+	if dial == nil {
+		dial = (&net.Dialer{}).DialContext
+	}
+
+	return resolvingDialFunc(options.Resolver, options.NamenodeSocketOptions.wrap(dial))
+}
+
+// newNamenodeLookupHost returns the function used to resolve namenode
+// hostnames, or nil if they shouldn't be resolved.
+func newNamenodeLookupHost(options ClientOptions) func(ctx context.Context, host string) ([]string, error) {
+	if !options.ResolveNamenodeAddresses {
+		return nil
+	} else if options.Resolver != nil {
+		return options.Resolver.LookupHost
+	}
+
+	return net.DefaultResolver.LookupHost
+}
+
+// newDatanodeDialFunc builds the function used to connect to datanodes,
+// layering address rewriting, hostname resolution and socket options on top
+// of the configured DatanodeDialFunc.
+func newDatanodeDialFunc(options ClientOptions) dialFunc {
+	dial := dialFunc(options.DatanodeDialFunc)
+	if dial == nil {
+		dial = (&net.Dialer{}).DialContext
+	}
+
+	dial = resolvingDialFunc(options.Resolver, options.DatanodeSocketOptions.wrap(dial))
+	if options.DatanodeAddressFunc != nil {
+		rewrite := options.DatanodeAddressFunc
+		inner := dial
+		dial = func(ctx context.Context, network, addr string) (net.Conn, error) {
+			return inner(ctx, network, rewrite(addr))
+		}
+	}
+
+	return dial
+}
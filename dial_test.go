@@ -0,0 +1,152 @@
+package hdfs
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errTestDial = errors.New("test dial")
+
+func TestDatanodeAddressFunc(t *testing.T) {
+	var dialed string
+	options := ClientOptions{
+		DatanodeDialFunc: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			dialed = addr
+			return nil, errTestDial
+		},
+		DatanodeAddressFunc: func(addr string) string {
+			return "gateway.example.com:" + addr[len("10.0.0.1:"):]
+		},
+	}
+
+	dial := newDatanodeDialFunc(options)
+	_, err := dial(context.Background(), "tcp", "10.0.0.1:50010")
+	assert.Equal(t, errTestDial, err)
+	assert.Equal(t, "gateway.example.com:50010", dialed)
+}
+
+func TestDatanodeAddressFuncUnset(t *testing.T) {
+	var dialed string
+	options := ClientOptions{
+		DatanodeDialFunc: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			dialed = addr
+			return nil, errTestDial
+		},
+	}
+
+	dial := newDatanodeDialFunc(options)
+	dial(context.Background(), "tcp", "10.0.0.1:50010")
+	assert.Equal(t, "10.0.0.1:50010", dialed)
+}
+
+func TestSocketOptions(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skip("Couldn't listen on localhost:", err)
+	}
+	defer l.Close()
+
+	go func() {
+		conn, err := l.Accept()
+		if err == nil {
+			conn.Close()
+		}
+	}()
+
+	options := ClientOptions{
+		NamenodeSocketOptions: SocketOptions{
+			DisableNoDelay:  true,
+			KeepAlive:       10 * time.Second,
+			ReadBufferSize:  1 << 20,
+			WriteBufferSize: 1 << 20,
+		},
+	}
+
+	dial := newNamenodeDialFunc(options)
+	conn, err := dial(context.Background(), "tcp", l.Addr().String())
+	require.NoError(t, err)
+	conn.Close()
+}
+
+func TestSocketOptionsNonTCP(t *testing.T) {
+	client, server := net.Pipe()
+	defer server.Close()
+
+	opts := SocketOptions{ReadBufferSize: 1 << 20}
+	assert.NoError(t, opts.apply(client))
+}
+
+type testResolver struct {
+	lookups int
+	hosts   map[string][]string
+}
+
+func (r *testResolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	r.lookups++
+	if addrs, ok := r.hosts[host]; ok {
+		return addrs, nil
+	}
+
+	return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+}
+
+func TestResolver(t *testing.T) {
+	resolver := &testResolver{hosts: map[string][]string{"dn1.example.com": {"10.0.0.1", "10.0.0.2"}}}
+
+	var dialed []string
+	options := ClientOptions{
+		Resolver: resolver,
+		DatanodeDialFunc: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			dialed = append(dialed, addr)
+			return nil, errTestDial
+		},
+	}
+
+	dial := newDatanodeDialFunc(options)
+	_, err := dial(context.Background(), "tcp", "dn1.example.com:9866")
+	assert.Equal(t, errTestDial, err)
+	assert.Equal(t, []string{"10.0.0.1:9866", "10.0.0.2:9866"}, dialed)
+
+	// IP addresses aren't resolved.
+	dialed = nil
+	dial(context.Background(), "tcp", "10.0.0.3:9866")
+	assert.Equal(t, []string{"10.0.0.3:9866"}, dialed)
+	assert.Equal(t, 1, resolver.lookups)
+
+	_, err = dial(context.Background(), "tcp", "dn2.example.com:9866")
+	var dnsErr *net.DNSError
+	assert.True(t, errors.As(err, &dnsErr))
+}
+
+func TestResolverCache(t *testing.T) {
+	resolver := &testResolver{hosts: map[string][]string{"dn1.example.com": {"10.0.0.1"}}}
+	cache := newResolver(ClientOptions{
+		Resolver:                 resolver,
+		ResolverCacheTTL:         time.Minute,
+		ResolverNegativeCacheTTL: time.Minute,
+	})
+
+	for i := 0; i < 3; i++ {
+		addrs, err := cache.LookupHost(context.Background(), "dn1.example.com")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"10.0.0.1"}, addrs)
+
+		_, err = cache.LookupHost(context.Background(), "dn2.example.com")
+		assert.Error(t, err)
+	}
+
+	assert.Equal(t, 2, resolver.lookups)
+
+	cache = newResolver(ClientOptions{Resolver: resolver, ResolverCacheTTL: time.Minute})
+	for i := 0; i < 3; i++ {
+		cache.LookupHost(context.Background(), "dn2.example.com")
+	}
+
+	assert.Equal(t, 5, resolver.lookups)
+}
@@ -0,0 +1,216 @@
+package hdfs
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	hdfs "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_hdfs"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseKeyProviderURI(t *testing.T) {
+	urls, err := parseKeyProviderURI("kms://https@kms1;kms2:9600/kms")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://kms1:9600/kms", "https://kms2:9600/kms"}, urls)
+
+	urls, err = parseKeyProviderURI("kms://http@localhost:9600/kms")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"http://localhost:9600/kms"}, urls)
+
+	for _, uri := range []string{"jceks://file/tmp/keys", "kms://ftp@kms1:9600/kms", "kms://http@;kms2:9600/kms"} {
+		_, err = parseKeyProviderURI(uri)
+		assert.Error(t, err, uri)
+	}
+}
+
+func TestFileCipherOffsets(t *testing.T) {
+	key := bytes.Repeat([]byte{0x42}, 16)
+	// An IV that overflows the low 64 bits after a few blocks.
+	iv := []byte{0, 0, 0, 0, 0, 0, 0, 1, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xfe}
+
+	plaintext := make([]byte, 1000)
+	for i := range plaintext {
+		plaintext[i] = byte(i)
+	}
+
+	block, err := aes.NewCipher(key)
+	require.NoError(t, err)
+
+	expected := make([]byte, len(plaintext))
+	cipher.NewCTR(block, iv).XORKeyStream(expected, plaintext)
+
+	fc, err := newAESCTRCipher(key, iv)
+	require.NoError(t, err)
+
+	// Encrypt out of order, in pieces that don't line up with AES blocks.
+	actual := append([]byte(nil), plaintext...)
+	for _, piece := range [][2]int{{500, 517}, {0, 3}, {3, 33}, {517, 1000}, {33, 500}} {
+		fc.xorAt(actual[piece[0]:piece[1]], int64(piece[0]))
+	}
+
+	assert.Equal(t, expected, actual)
+
+	fc.xorAt(actual[10:900], 10)
+	assert.Equal(t, plaintext[10:900], actual[10:900])
+
+	_, err = newAESCTRCipher(key, iv[:8])
+	assert.Error(t, err)
+}
+
+func TestNewFileCipherWithKMS(t *testing.T) {
+	key := bytes.Repeat([]byte{0x13}, 32)
+	var requests []*http.Request
+	var bodies []kmsKeyVersion
+	kms := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r)
+
+		var body kmsKeyVersion
+		json.NewDecoder(r.Body).Decode(&body)
+		bodies = append(bodies, body)
+
+		// Fail the first request, to check that the next KMS is tried.
+		if len(requests) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"RemoteException":{"message":"try again"}}`))
+			return
+		}
+
+		json.NewEncoder(w).Encode(kmsKeyVersion{
+			Name:     "EEK",
+			Material: base64.RawURLEncoding.EncodeToString(key),
+		})
+	}))
+	defer kms.Close()
+
+	info := &hdfs.FileEncryptionInfoProto{
+		Suite:                 hdfs.CipherSuiteProto_AES_CTR_NOPADDING.Enum(),
+		CryptoProtocolVersion: hdfs.CryptoProtocolVersionProto_ENCRYPTION_ZONES.Enum(),
+		Key:                   []byte("edek"),
+		Iv:                    bytes.Repeat([]byte{1}, 16),
+		KeyName:               proto.String("zonekey"),
+		EzKeyVersionName:      proto.String("zonekey@0"),
+	}
+
+	kmsClient, err := newKMSClient(ClientOptions{}, "gohdfs1")
+	require.NoError(t, err)
+	assert.Nil(t, kmsClient)
+
+	// Both hosts are the test server, which fails the first request.
+	client := &Client{}
+	client.kms, err = newKMSClient(ClientOptions{
+		KeyProviderURI: "kms://http@127.0.0.1;" + strings.TrimPrefix(kms.URL, "http://") + "/kms",
+	}, "gohdfs1")
+	require.NoError(t, err)
+	require.Len(t, client.kms.urls, 2)
+
+	_, err = client.kms.decryptEDEKFrom(client.kms.urls[0], "zonekey@0", []byte("{}"))
+	assert.EqualError(t, err, "kms: decrypting key: 503 Service Unavailable: try again")
+
+	fc, err := client.newFileCipher(info)
+	require.NoError(t, err)
+	require.NotNil(t, fc)
+
+	require.Len(t, requests, 2)
+	assert.Equal(t, "POST", requests[1].Method)
+	assert.Equal(t, "/kms/v1/keyversion/zonekey@0/_eek", requests[1].URL.Path)
+	assert.Equal(t, "decrypt", requests[1].URL.Query().Get("eek_op"))
+	assert.Equal(t, "gohdfs1", requests[1].URL.Query().Get("user.name"))
+	assert.Equal(t, "zonekey", bodies[1].Name)
+	assert.Equal(t, base64.StdEncoding.EncodeToString([]byte("edek")), bodies[1].Material)
+
+	decrypted := make([]byte, 20)
+	fc.xorAt(decrypted, 0)
+
+	block, _ := aes.NewCipher(key)
+	expected := make([]byte, 20)
+	cipher.NewCTR(block, info.Iv).XORKeyStream(expected, expected)
+	assert.Equal(t, expected, decrypted)
+
+	fc, err = client.newFileCipher(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, fc)
+
+	client.kms = nil
+	_, err = client.newFileCipher(info)
+	assert.Equal(t, errNoKeyProvider, err)
+}
+
+func TestDecodeKMSBase64(t *testing.T) {
+	b := []byte{0xfb, 0xff, 0xfe, 0x01}
+	for _, s := range []string{
+		base64.StdEncoding.EncodeToString(b),
+		base64.RawStdEncoding.EncodeToString(b),
+		base64.URLEncoding.EncodeToString(b),
+		base64.RawURLEncoding.EncodeToString(b),
+	} {
+		decoded, err := decodeKMSBase64(s)
+		require.NoError(t, err)
+		assert.Equal(t, b, decoded, s)
+	}
+}
+
+// createEncryptionZone makes dir an encryption zone with the key "testkey",
+// skipping the test if the cluster doesn't have a KMS with that key.
+func createEncryptionZone(t *testing.T, dir string) {
+	client := getClientForSuperUser(t)
+
+	baleet(t, dir)
+	mkdirp(t, dir)
+	req := &hdfs.CreateEncryptionZoneRequestProto{
+		Src:     proto.String(dir),
+		KeyName: proto.String("testkey"),
+	}
+
+	err := client.namenode.Execute("createEncryptionZone", req, &hdfs.CreateEncryptionZoneResponseProto{})
+	if err != nil {
+		t.Skip("Couldn't create an encryption zone:", err)
+	}
+
+	require.NoError(t, client.Chmod(dir, 0777))
+}
+
+func TestCopyAndCompareEncrypted(t *testing.T) {
+	createEncryptionZone(t, "/_test/ez")
+	client := getClient(t)
+
+	// The checksums of the copy cover the ciphertext, so they don't match the
+	// CRC of the data copied, or the checksums of the original.
+	err := client.CopyFile("/_test/mobydick.txt", "/_test/ez/mobydick.txt", CopyOptions{Verify: true})
+	require.NoError(t, err)
+	assertMobydick(t, client, "/_test/ez/mobydick.txt")
+
+	info, err := client.Stat("/_test/ez/mobydick.txt")
+	require.NoError(t, err)
+	assert.True(t, info.(*FileInfo).Encrypted())
+
+	differ, err := ContentsDiffer(client, "/_test/mobydick.txt", client, "/_test/ez/mobydick.txt")
+	require.NoError(t, err)
+	assert.False(t, differ)
+
+	differ, err = FileDiffersFromLocal(client, "/_test/ez/mobydick.txt", "testdata/mobydick.txt",
+		CompareOptions{Mode: CompareChecksum})
+	require.NoError(t, err)
+	assert.False(t, differ)
+
+	err = client.CopyFile("/_test/ez/mobydick.txt", "/_test/ez/mobydick2.txt", CopyOptions{Verify: true})
+	require.NoError(t, err)
+
+	differ, err = ContentsDiffer(client, "/_test/ez/mobydick.txt", client, "/_test/ez/mobydick2.txt")
+	require.NoError(t, err)
+	assert.False(t, differ)
+
+	touch(t, "/_test/ez/foo.txt")
+	differ, err = FileDiffersFromLocal(client, "/_test/ez/foo.txt", "testdata/foo.txt",
+		CompareOptions{Mode: CompareChecksum})
+	require.NoError(t, err)
+	assert.True(t, differ)
+}
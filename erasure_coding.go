@@ -0,0 +1,108 @@
+package hdfs
+
+import (
+	"os"
+
+	hdfs "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_hdfs"
+	"github.com/golang/protobuf/proto"
+)
+
+// ErasureCodingPolicy describes an erasure coding policy, which determines how
+// the blocks of a file are striped across datanodes. For example, with the
+// "RS-6-3-1024k" policy, data is striped in 1MB cells across six datanodes,
+// and three more hold Reed-Solomon parity. Erasure coding requires Hadoop 3.0
+// or later.
+type ErasureCodingPolicy struct {
+	ID          int
+	Name        string
+	Codec       string
+	DataUnits   int
+	ParityUnits int
+	CellSize    int
+	// State is either "ENABLED", "DISABLED", or "REMOVED". Only enabled
+	// policies can be set on a directory.
+	State string
+}
+
+func newErasureCodingPolicy(p *hdfs.ErasureCodingPolicyProto, state hdfs.ErasureCodingPolicyState) *ErasureCodingPolicy {
+	return &ErasureCodingPolicy{
+		ID:          int(p.GetId()),
+		Name:        p.GetName(),
+		Codec:       p.GetSchema().GetCodecName(),
+		DataUnits:   int(p.GetSchema().GetDataUnits()),
+		ParityUnits: int(p.GetSchema().GetParityUnits()),
+		CellSize:    int(p.GetCellSize()),
+		State:       state.String(),
+	}
+}
+
+// GetErasureCodingPolicies returns all the erasure coding policies known to
+// the cluster, including disabled ones.
+func (c *Client) GetErasureCodingPolicies() ([]*ErasureCodingPolicy, error) {
+	req := &hdfs.GetErasureCodingPoliciesRequestProto{}
+	resp := &hdfs.GetErasureCodingPoliciesResponseProto{}
+
+	err := c.namenode.Execute("getErasureCodingPolicies", req, resp)
+	if err != nil {
+		return nil, interpretException(err)
+	}
+
+	policies := make([]*ErasureCodingPolicy, 0, len(resp.GetEcPolicies()))
+	for _, info := range resp.GetEcPolicies() {
+		policies = append(policies, newErasureCodingPolicy(info.GetPolicy(), info.GetState()))
+	}
+
+	return policies, nil
+}
+
+// GetErasureCodingPolicy returns the erasure coding policy for the named file
+// or directory, which may be inherited from one of its parents. If the file
+// is replicated rather than erasure coded, it returns nil.
+func (c *Client) GetErasureCodingPolicy(name string) (*ErasureCodingPolicy, error) {
+	req := &hdfs.GetErasureCodingPolicyRequestProto{Src: proto.String(name)}
+	resp := &hdfs.GetErasureCodingPolicyResponseProto{}
+
+	err := c.namenode.Execute("getErasureCodingPolicy", req, resp)
+	if err != nil {
+		return nil, &os.PathError{"geterasurecodingpolicy", name, interpretException(err)}
+	}
+
+	if resp.EcPolicy == nil {
+		return nil, nil
+	}
+
+	return newErasureCodingPolicy(resp.EcPolicy, resp.EcPolicy.GetState()), nil
+}
+
+// SetErasureCodingPolicy sets the erasure coding policy for the named
+// directory, which must be empty or only contain directories. If policy is
+// empty, the cluster's default policy is used. Only new files are erasure
+// coded; existing files keep their current layout.
+func (c *Client) SetErasureCodingPolicy(name string, policy string) error {
+	req := &hdfs.SetErasureCodingPolicyRequestProto{Src: proto.String(name)}
+	if policy != "" {
+		req.EcPolicyName = proto.String(policy)
+	}
+	resp := &hdfs.SetErasureCodingPolicyResponseProto{}
+
+	err := c.namenode.Execute("setErasureCodingPolicy", req, resp)
+	if err != nil {
+		return &os.PathError{"seterasurecodingpolicy", name, interpretException(err)}
+	}
+
+	return nil
+}
+
+// UnsetErasureCodingPolicy removes the erasure coding policy from the named
+// directory, so that it inherits the policy of its parent.
+func (c *Client) UnsetErasureCodingPolicy(name string) error {
+	req := &hdfs.UnsetErasureCodingPolicyRequestProto{Src: proto.String(name)}
+	resp := &hdfs.UnsetErasureCodingPolicyResponseProto{}
+
+	err := c.namenode.Execute("unsetErasureCodingPolicy", req, resp)
+	if err != nil {
+		return &os.PathError{"unseterasurecodingpolicy", name, interpretException(err)}
+	}
+
+	return nil
+}
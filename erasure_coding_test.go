@@ -0,0 +1,134 @@
+package hdfs
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func skipWithoutErasureCoding(t *testing.T, client *Client) []*ErasureCodingPolicy {
+	policies, err := client.GetErasureCodingPolicies()
+	if err != nil {
+		t.Skip("Erasure coding isn't supported by the cluster:", err)
+	}
+
+	return policies
+}
+
+// skipWithoutRSPolicy returns an enabled reed-solomon policy that fits on the
+// cluster, or skips the test if there isn't one.
+func skipWithoutRSPolicy(t *testing.T, client *Client) *ErasureCodingPolicy {
+	policies := skipWithoutErasureCoding(t, client)
+
+	datanodes, err := client.Datanodes()
+	require.NoError(t, err)
+
+	for _, p := range policies {
+		if p.State == "ENABLED" && p.Codec == "rs" && p.DataUnits+p.ParityUnits <= len(datanodes) {
+			return p
+		}
+	}
+
+	t.Skip("No reed-solomon erasure coding policies are enabled that fit on the cluster")
+	return nil
+}
+
+func TestGetErasureCodingPolicies(t *testing.T) {
+	client := getClient(t)
+	policies := skipWithoutErasureCoding(t, client)
+
+	var found bool
+	for _, p := range policies {
+		if p.Name == "RS-6-3-1024k" {
+			found = true
+			assert.Equal(t, "rs", p.Codec)
+			assert.Equal(t, 6, p.DataUnits)
+			assert.Equal(t, 3, p.ParityUnits)
+			assert.Equal(t, 1024*1024, p.CellSize)
+		}
+	}
+
+	assert.True(t, found)
+}
+
+func TestSetErasureCodingPolicy(t *testing.T) {
+	client := getClient(t)
+	policies := skipWithoutErasureCoding(t, client)
+
+	var enabled string
+	for _, p := range policies {
+		if p.State == "ENABLED" {
+			enabled = p.Name
+			break
+		}
+	}
+
+	if enabled == "" {
+		t.Skip("No erasure coding policies are enabled on the cluster")
+	}
+
+	baleet(t, "/_test/ec")
+	mkdirp(t, "/_test/ec")
+
+	policy, err := client.GetErasureCodingPolicy("/_test/ec")
+	require.NoError(t, err)
+	assert.Nil(t, policy)
+
+	err = client.SetErasureCodingPolicy("/_test/ec", enabled)
+	require.NoError(t, err)
+
+	policy, err = client.GetErasureCodingPolicy("/_test/ec")
+	require.NoError(t, err)
+	require.NotNil(t, policy)
+	assert.Equal(t, enabled, policy.Name)
+
+	err = client.UnsetErasureCodingPolicy("/_test/ec")
+	require.NoError(t, err)
+
+	policy, err = client.GetErasureCodingPolicy("/_test/ec")
+	require.NoError(t, err)
+	assert.Nil(t, policy)
+}
+
+func TestErasureCodedWriteAndRead(t *testing.T) {
+	client := getClient(t)
+	policy := skipWithoutRSPolicy(t, client)
+
+	baleet(t, "/_test/ecwrite")
+	mkdirp(t, "/_test/ecwrite")
+	err := client.SetErasureCodingPolicy("/_test/ecwrite", policy.Name)
+	require.NoError(t, err)
+
+	// Write two and a half stripes.
+	data := make([]byte, policy.CellSize*policy.DataUnits*5/2+1000)
+	for i := range data {
+		data[i] = byte(i % 251)
+	}
+
+	w, err := client.Create("/_test/ecwrite/foo")
+	require.NoError(t, err)
+
+	_, err = w.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	fi, err := client.Stat("/_test/ecwrite/foo")
+	require.NoError(t, err)
+	assert.EqualValues(t, len(data), fi.Size())
+
+	b, err := client.ReadFile("/_test/ecwrite/foo")
+	require.NoError(t, err)
+	assert.True(t, bytes.Equal(data, b))
+
+	r, err := client.Open("/_test/ecwrite/foo")
+	require.NoError(t, err)
+	defer r.Close()
+
+	buf := make([]byte, 100)
+	off := int64(policy.CellSize + 10)
+	_, err = r.ReadAt(buf, off)
+	require.NoError(t, err)
+	assert.Equal(t, data[off:off+100], buf)
+}
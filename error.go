@@ -3,12 +3,20 @@ package hdfs
 import (
 	"os"
 	"syscall"
+
+	"github.com/colinmarc/hdfs/v2/internal/rpc"
 )
 
 const (
 	fileNotFoundException      = "java.io.FileNotFoundException"
 	permissionDeniedException  = "org.apache.hadoop.security.AccessControlException"
 	pathIsNotEmptyDirException = "org.apache.hadoop.fs.PathIsNotEmptyDirectoryException"
+	fileAlreadyExistsException = "org.apache.hadoop.fs.FileAlreadyExistsException"
+
+	alreadyBeingCreatedException = "org.apache.hadoop.hdfs.protocol.AlreadyBeingCreatedException"
+	recoveryInProgressException  = "org.apache.hadoop.hdfs.protocol.RecoveryInProgressException"
+	unresolvedLinkException      = "org.apache.hadoop.fs.UnresolvedLinkException"
+	unresolvedPathException      = "org.apache.hadoop.hdfs.protocol.UnresolvedPathException"
 )
 
 // Error represents a remote java exception from an HDFS namenode or datanode.
@@ -25,6 +33,12 @@ type Error interface {
 	Message() string
 }
 
+// ProtocolError is returned when ClientOptions.StrictProtocol is set and a
+// message from the namenode or a datanode is malformed, truncated, or contains
+// values that don't make sense. It records which namenode or datanode sent the
+// message, which message it was, and what was wrong with it.
+type ProtocolError = rpc.ProtocolError
+
 func interpretException(err error) error {
 	var exception string
 	if remoteErr, ok := err.(Error); ok {
@@ -38,6 +52,8 @@ func interpretException(err error) error {
 		return os.ErrPermission
 	case pathIsNotEmptyDirException:
 		return syscall.ENOTEMPTY
+	case fileAlreadyExistsException:
+		return os.ErrExist
 	default:
 		return err
 	}
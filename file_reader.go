@@ -1,11 +1,18 @@
 package hdfs
 
 import (
-	"crypto/md5"
+	"context"
+	"encoding/binary"
 	"errors"
 	"fmt"
+	"hash/crc32"
 	"io"
+	"math"
+	"net"
 	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	hdfs "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_hdfs"
@@ -13,36 +20,97 @@ import (
 	"github.com/golang/protobuf/proto"
 )
 
+// writeToBufferSize is the size of the buffer used by FileReader.WriteTo.
+const writeToBufferSize = 1024 * 1024
+
 // A FileReader represents an existing file or directory in HDFS. It implements
-// io.Reader, io.ReaderAt, io.Seeker, and io.Closer, and can only be used for
-// reads. For writes, see FileWriter and Client.Create.
+// io.Reader, io.ReaderAt, io.Seeker, io.WriterTo, and io.Closer, and can only
+// be used for reads. For writes, see FileWriter and Client.Create.
+//
+// Like an os.File, a FileReader isn't safe for concurrent use, with the
+// exception of ReadAt, which can be called from multiple goroutines at once
+// to read different parts of a file in parallel.
 type FileReader struct {
 	client *Client
 	name   string
 	info   os.FileInfo
 
-	blocks      []*hdfs.LocatedBlockProto
-	blockReader *rpc.BlockReader
-	deadline    time.Time
-	offset      int64
+	blocks       []*hdfs.LocatedBlockProto
+	blockReader  *rpc.BlockReader
+	buffers      rpc.BlockBuffers
+	deadline     time.Time
+	offset       int64
+	length       int64
+	skipChecksum bool
+	readAhead    int
+	ecPolicy     *hdfs.ErasureCodingPolicyProto
+	cipher       *fileCipher
+	progress     ProgressFunc
+	bytesRead    int64
+	tc           *transferContext
+
+	// memoryOwned is the memory held by WriteTo and the current block
+	// reader, as counted by MemoryLimiter.AcquireOwned.
+	memoryOwned int64
+
+	// blocksLock guards blocks, length, ecPolicy and cipher being set by
+	// getBlocks, for concurrent calls to ReadAt.
+	blocksLock sync.Mutex
 
 	readdirLast string
 
+	// webBody is the response being read from by Read, over WebHDFS.
+	webBody io.ReadCloser
+
 	closed bool
 }
 
 // Open returns an FileReader which can be used for reading.
 func (c *Client) Open(name string) (*FileReader, error) {
-	info, err := c.getFileInfo(name)
+	return c.open(context.Background(), name)
+}
+
+// OpenContext is like Open, but gives up once ctx is done. The returned
+// FileReader is also bound to ctx, as with SetContext, so that reading from
+// it is aborted once ctx is done.
+func (c *Client) OpenContext(ctx context.Context, name string) (*FileReader, error) {
+	f, err := c.open(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	f.SetContext(ctx)
+	return f, nil
+}
+
+func (c *Client) open(ctx context.Context, name string) (*FileReader, error) {
+	if c.isClosed() {
+		return nil, &os.PathError{"open", name, ErrClientClosed}
+	}
+
+	info, err := c.getFileInfoContext(ctx, name)
+	if err != nil {
+		var resolved string
+		resolved, err = c.followSymlinks(ctx, name, err)
+		if err == nil {
+			// The blocks have to be fetched using the resolved path, too.
+			info, err = c.getFileInfoContext(ctx, resolved)
+			name = resolved
+		}
+	}
+
 	if err != nil {
 		return nil, &os.PathError{"open", name, interpretException(err)}
 	}
 
+	atomic.AddInt64(&c.filesROpen, 1)
 	return &FileReader{
-		client: c,
-		name:   name,
-		info:   info,
-		closed: false,
+		client:    c,
+		name:      name,
+		info:      info,
+		length:    info.Size(),
+		readAhead: c.options.ReadAheadSize,
+		closed:    false,
 	}, nil
 }
 
@@ -68,6 +136,40 @@ func (f *FileReader) SetDeadline(t time.Time) error {
 	return nil
 }
 
+// SetContext binds the FileReader to ctx for future Read, ReadAt, Readdir,
+// and Checksum calls, including the requests they make to the namenode. Once
+// ctx is done, any datanode connections are closed, aborting reads in
+// progress, and subsequent calls return ctx.Err(). Passing nil unbinds the
+// FileReader from any previous context.
+//
+// The connection Read was using, if any, was made under the previous context,
+// so it's closed, and the next Read reopens it under the new one.
+func (f *FileReader) SetContext(ctx context.Context) {
+	if f.blockReader != nil {
+		f.blockReader.Close()
+		f.blockReader = nil
+	}
+
+	f.tc.close()
+	f.tc = nil
+	if ctx != nil {
+		f.tc = newTransferContext(ctx)
+	}
+}
+
+// dialDatanode connects to a datanode, using the context bound with
+// SetContext, if any.
+func (f *FileReader) dialDatanode(ctx context.Context, network, addr string) (net.Conn, error) {
+	return f.tc.wrap(f.client.dialDataTransfer)(ctx, network, addr)
+}
+
+// SetProgressFunc registers a function to be called with the cumulative
+// number of bytes read, and the current block and datanode, after each
+// successful read. Passing nil removes it.
+func (f *FileReader) SetProgressFunc(fn ProgressFunc) {
+	f.progress = fn
+}
+
 // Checksum returns HDFS's internal "MD5MD5CRC32C" checksum for a given file.
 //
 // Internally to HDFS, it works by calculating the MD5 of all the CRCs (which
@@ -89,40 +191,292 @@ func (f *FileReader) Checksum() ([]byte, error) {
 		}
 	}
 
-	// Hadoop calculates this by writing the checksums out to a byte array, which
-	// is automatically padded with zeroes out to the next  power of 2
-	// (with a minimum of 32)... and then takes the MD5 of that array, including
-	// the zeroes. This is pretty shady business, but we want to track
-	// the 'hadoop fs -checksum' behavior if possible.
-	paddedLength := 32
-	totalLength := 0
-	checksum := md5.New()
+	var blockMD5s []byte
 	for _, block := range f.blocks {
-		cr := &rpc.ChecksumReader{
-			Block:               block,
-			UseDatanodeHostname: f.client.options.UseDatanodeHostname,
-			DialFunc:            f.client.options.DatanodeDialFunc,
+		cr, err := f.newChecksumReader(block, false)
+		if err != nil {
+			return nil, err
 		}
 
-		err := cr.SetDeadline(f.deadline)
+		blockChecksum, err := cr.ReadChecksum()
 		if err != nil {
 			return nil, err
 		}
 
-		blockChecksum, err := cr.ReadChecksum()
+		blockMD5s = append(blockMD5s, blockChecksum...)
+	}
+
+	return md5OfBlockMD5s(blockMD5s), nil
+}
+
+// CompositeChecksum returns HDFS's "COMPOSITE-CRC" checksum for a given file,
+// which is what 'hadoop fs -checksum' returns when dfs.checksum.combine.mode is
+// set to COMPOSITE_CRC. It requires Hadoop 3.1 or higher.
+//
+// Unlike the checksum returned by Checksum, it doesn't depend on the block size
+// of the file: it's equal to the CRC (either CRC32 or CRC32C, depending on how
+// the file was written) of the entire file contents, as four big-endian bytes.
+// This makes it suitable for comparing files across clusters with different
+// block sizes.
+func (f *FileReader) CompositeChecksum() ([]byte, error) {
+	if f.info.IsDir() {
+		return nil, &os.PathError{
+			"checksum",
+			f.name,
+			errors.New("is a directory"),
+		}
+	}
+
+	if f.blocks == nil {
+		err := f.getBlocks()
 		if err != nil {
 			return nil, err
 		}
+	}
+
+	var crc, poly uint32
+	var crcType hdfs.ChecksumTypeProto
+	for i, block := range f.blocks {
+		cr, err := f.newChecksumReader(block, true)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := cr.ReadChecksumResponse()
+		if err != nil {
+			return nil, err
+		}
+
+		if i == 0 {
+			crcType = resp.GetCrcType()
+			switch crcType {
+			case hdfs.ChecksumTypeProto_CHECKSUM_CRC32:
+				poly = crc32.IEEE
+			case hdfs.ChecksumTypeProto_CHECKSUM_CRC32C:
+				poly = crc32.Castagnoli
+			default:
+				return nil, fmt.Errorf("unsupported checksum type: %s", crcType)
+			}
+		} else if resp.GetCrcType() != crcType {
+			return nil, errors.New("blocks have different checksum types")
+		}
+
+		blockChecksum := resp.GetBlockChecksum()
+		if len(blockChecksum) != 4 {
+			return nil, errors.New("invalid block checksum")
+		}
+
+		blockCRC := binary.BigEndian.Uint32(blockChecksum)
+		if i == 0 {
+			crc = blockCRC
+		} else {
+			crc = composeCRC(crc, blockCRC, int64(block.GetB().GetNumBytes()), poly)
+		}
+	}
+
+	checksum := make([]byte, 4)
+	binary.BigEndian.PutUint32(checksum, crc)
+	return checksum, nil
+}
+
+// BlockChecksum is the checksum of a single block of a file, as computed by
+// one of the datanodes storing it.
+type BlockChecksum struct {
+	// Offset is the offset of the start of the block in the file.
+	Offset int64
+	// Length is the length of the block.
+	Length int64
+	// Checksum is the MD5 of the CRCs of each chunk of the block (HDFS calls
+	// this "MD5CRC"). These are the values combined by FileReader.Checksum.
+	Checksum []byte
+	// ChecksumType is the type of the chunk CRCs, either "CRC32" or "CRC32C".
+	ChecksumType string
+	// BytesPerCRC is the number of bytes covered by each chunk CRC.
+	BytesPerCRC int
+	// CRCPerBlock is the number of chunk CRCs in the block.
+	CRCPerBlock int64
+}
+
+// BlockChecksums returns the individual checksums of each block of the file.
+// Comparing these against the block checksums of another copy of the file
+// (with the same block size) can be used to narrow down where two files differ,
+// or which block is corrupted.
+//
+// For erasure-coded files, each BlockChecksum covers a whole block group.
+func (f *FileReader) BlockChecksums() ([]BlockChecksum, error) {
+	if f.info.IsDir() {
+		return nil, &os.PathError{
+			"checksum",
+			f.name,
+			errors.New("is a directory"),
+		}
+	}
+
+	if f.blocks == nil {
+		err := f.getBlocks()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	checksums := make([]BlockChecksum, 0, len(f.blocks))
+	for _, block := range f.blocks {
+		cr, err := f.newChecksumReader(block, false)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := cr.ReadChecksumResponse()
+		if err != nil {
+			return nil, err
+		}
+
+		checksums = append(checksums, BlockChecksum{
+			Offset:       int64(block.GetOffset()),
+			Length:       int64(block.GetB().GetNumBytes()),
+			Checksum:     resp.GetBlockChecksum(),
+			ChecksumType: strings.TrimPrefix(resp.GetCrcType().String(), "CHECKSUM_"),
+			BytesPerCRC:  int(resp.GetBytesPerCrc()),
+			CRCPerBlock:  int64(resp.GetCrcPerBlock()),
+		})
+	}
+
+	return checksums, nil
+}
+
+// Blocks returns the blocks that make up the file, along with the locations
+// of their replicas. Together with Client.OpenReplica, this can be used to
+// read and compare the individual replicas of a block.
+func (f *FileReader) Blocks() ([]Block, error) {
+	if f.blocks == nil {
+		err := f.getBlocks()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	blocks := make([]Block, 0, len(f.blocks))
+	for _, block := range f.blocks {
+		blocks = append(blocks, newBlock(block))
+	}
+
+	return blocks, nil
+}
+
+func (f *FileReader) newChecksumReader(block *hdfs.LocatedBlockProto, composite bool) (*rpc.ChecksumReader, error) {
+	cr := &rpc.ChecksumReader{
+		Block:               block,
+		UseCompositeCRC:     composite,
+		ECPolicy:            f.ecPolicy,
+		UseDatanodeHostname: f.client.options.UseDatanodeHostname,
+		DialFunc:            f.dialDatanode,
+		CircuitBreaker:      f.client.breaker,
+		WireLog:             f.client.wireLog,
+		Strict:              f.client.options.StrictProtocol,
+	}
+
+	err := cr.SetDeadline(f.deadline)
+	if err != nil {
+		return nil, err
+	}
+
+	return cr, nil
+}
+
+// SetVerifyChecksum controls whether the data read is verified against the
+// checksums stored on the datanodes. Verification is enabled by default; if
+// disabled, the datanodes are asked not to send checksums at all, which saves
+// some bandwidth and CPU for callers doing their own end-to-end validation.
+// This is similar to setting dfs.client.read.checksum.skip in the Java client.
+func (f *FileReader) SetVerifyChecksum(verify bool) {
+	if f.skipChecksum == !verify {
+		return
+	}
+
+	f.skipChecksum = !verify
+
+	// Start a new block read, so that the setting takes effect immediately.
+	if f.blockReader != nil {
+		f.blockReader.Close()
+		f.blockReader = nil
+	}
+}
+
+// SetReadAhead sets how many bytes are read ahead of the caller in the
+// background by subsequent calls to Read, overriding
+// ClientOptions.ReadAheadSize. Zero or negative disables reading ahead.
+// ReadAt never reads ahead, since it only fetches the ranges requested.
+func (f *FileReader) SetReadAhead(size int) {
+	if f.readAhead == size {
+		return
+	}
+
+	f.readAhead = size
+
+	// Start a new block read, so that the setting takes effect immediately.
+	if f.blockReader != nil {
+		f.blockReader.Close()
+		f.blockReader = nil
+	}
+}
+
+// Refresh fetches the block locations and length of the file again. For files
+// that are still being written to, this picks up any data the writer has
+// flushed since the file was opened (or since the last call to Refresh), so
+// that subsequent reads can continue past what was previously the end of the
+// file.
+func (f *FileReader) Refresh() error {
+	if f.closed {
+		return io.ErrClosedPipe
+	}
+
+	if f.info.IsDir() {
+		return nil
+	}
+
+	if f.client.web != nil {
+		info, err := f.client.getFileInfo(f.name)
+		if err != nil {
+			return &os.PathError{"refresh", f.name, interpretException(err)}
+		}
+
+		f.length = info.Size()
+		f.closeWebBody()
+		return nil
+	}
+
+	err := f.getBlocks()
+	if err != nil {
+		return err
+	}
+
+	// The current block reader may have a stale idea of how long its block is.
+	if f.blockReader != nil {
+		f.blockReader.Close()
+		f.blockReader = nil
+	}
+
+	return nil
+}
+
+// VisibleLength returns the number of bytes of the file that can currently be
+// read. For a file that's still being written to, this includes whatever the
+// writer has flushed to the last block, as reported by the datanodes holding
+// it, which Stat().Size() doesn't; the namenode only learns the length of a
+// block once it's complete. The length is updated by Refresh.
+func (f *FileReader) VisibleLength() (int64, error) {
+	if f.closed {
+		return 0, io.ErrClosedPipe
+	}
 
-		checksum.Write(blockChecksum)
-		totalLength += len(blockChecksum)
-		if paddedLength < totalLength {
-			paddedLength *= 2
+	if f.blocks == nil && !f.info.IsDir() && f.client.web == nil {
+		err := f.getBlocks()
+		if err != nil {
+			return 0, err
 		}
 	}
 
-	checksum.Write(make([]byte, paddedLength-totalLength))
-	return checksum.Sum(nil), nil
+	return f.length, nil
 }
 
 // Seek implements io.Seeker.
@@ -133,18 +487,25 @@ func (f *FileReader) Seek(offset int64, whence int) (int64, error) {
 		return 0, io.ErrClosedPipe
 	}
 
+	if f.blocks == nil && !f.info.IsDir() && f.client.web == nil {
+		err := f.getBlocks()
+		if err != nil {
+			return f.offset, err
+		}
+	}
+
 	var off int64
 	if whence == 0 {
 		off = offset
 	} else if whence == 1 {
 		off = f.offset + offset
 	} else if whence == 2 {
-		off = f.info.Size() + offset
+		off = f.length + offset
 	} else {
 		return f.offset, fmt.Errorf("invalid whence: %d", whence)
 	}
 
-	if off < 0 || off > f.info.Size() {
+	if off < 0 || off > f.length {
 		return f.offset, fmt.Errorf("invalid resulting offset: %d", off)
 	}
 
@@ -154,16 +515,26 @@ func (f *FileReader) Seek(offset int64, whence int) (int64, error) {
 			f.blockReader.Close()
 			f.blockReader = nil
 		}
+
+		f.closeWebBody()
 	}
 	return f.offset, nil
 }
 
 // Read implements io.Reader.
+//
+// Files that are still being written can be read up to the length which was
+// visible on the datanodes when the file was opened. After reaching the end,
+// call Refresh to continue reading any data flushed since.
 func (f *FileReader) Read(b []byte) (int, error) {
 	if f.closed {
 		return 0, io.ErrClosedPipe
 	}
 
+	if err := f.tc.err(); err != nil {
+		return 0, err
+	}
+
 	if f.info.IsDir() {
 		return 0, &os.PathError{
 			"read",
@@ -172,12 +543,8 @@ func (f *FileReader) Read(b []byte) (int, error) {
 		}
 	}
 
-	if f.offset >= f.info.Size() {
-		return 0, io.EOF
-	}
-
-	if len(b) == 0 {
-		return 0, nil
+	if f.client.web != nil {
+		return f.readWeb(b)
 	}
 
 	if f.blocks == nil {
@@ -187,6 +554,14 @@ func (f *FileReader) Read(b []byte) (int, error) {
 		}
 	}
 
+	if f.offset >= f.length {
+		return 0, io.EOF
+	}
+
+	if len(b) == 0 {
+		return 0, nil
+	}
+
 	if f.blockReader == nil {
 		err := f.getNewBlockReader()
 		if err != nil {
@@ -196,9 +571,15 @@ func (f *FileReader) Read(b []byte) (int, error) {
 
 	for {
 		n, err := f.blockReader.Read(b)
+		if f.cipher != nil {
+			f.cipher.xorAt(b[:n], f.offset)
+		}
+
 		f.offset += int64(n)
+		f.reportProgress(f.blockReader, n)
 
 		if err != nil && err != io.EOF {
+			err = interpretProvidedError(f.name, f.blockReader.Block, err)
 			f.blockReader.Close()
 			f.blockReader = nil
 			return n, err
@@ -211,7 +592,17 @@ func (f *FileReader) Read(b []byte) (int, error) {
 	}
 }
 
-// ReadAt implements io.ReaderAt.
+// ReadAt implements io.ReaderAt. It doesn't affect the offset used by Read
+// and Seek.
+//
+// Each call reads from its own connections to the datanodes, so it's safe to
+// call ReadAt from multiple goroutines at once, for example to fetch several
+// ranges of a large file in parallel. That means, however, that each call has
+// to set up a new connection for each block it reads from; for reading a file
+// sequentially, Read is faster.
+//
+// If ClientOptions.HedgedReadThreshold is set, reads from a replica that's
+// slow to respond are hedged with reads from the others.
 func (f *FileReader) ReadAt(b []byte, off int64) (int, error) {
 	if f.closed {
 		return 0, io.ErrClosedPipe
@@ -221,20 +612,134 @@ func (f *FileReader) ReadAt(b []byte, off int64) (int, error) {
 		return 0, &os.PathError{"readat", f.name, errors.New("negative offset")}
 	}
 
-	_, err := f.Seek(off, 0)
+	if err := f.tc.err(); err != nil {
+		return 0, err
+	}
+
+	if f.info.IsDir() {
+		return 0, &os.PathError{"readat", f.name, errors.New("is a directory")}
+	}
+
+	if f.client.web != nil {
+		return f.readAtWeb(b, off)
+	}
+
+	f.blocksLock.Lock()
+	var err error
+	if f.blocks == nil {
+		err = f.getBlocksLocked()
+	}
+
+	blocks, length, ecPolicy, fc := f.blocks, f.length, f.ecPolicy, f.cipher.clone()
+	f.blocksLock.Unlock()
 	if err != nil {
 		return 0, err
 	}
 
-	n, err := io.ReadFull(f, b)
+	n := 0
+	for n < len(b) && off+int64(n) < length {
+		br, err := f.newBlockReader(blocks, ecPolicy, off+int64(n))
+		if err != nil {
+			return n, err
+		}
+
+		br.ReadAhead = 0
+
+		end := len(b)
+		if remaining := br.Block.GetB().GetNumBytes() - uint64(br.Offset); uint64(end-n) > remaining {
+			end = n + int(remaining)
+		}
+
+		var m int
+		if f.client.hedged != nil && ecPolicy == nil && len(br.Block.GetLocs()) > 1 {
+			m, br, err = f.readHedged(br, b[n:end])
+		} else {
+			m, err = io.ReadFull(br, b[n:end])
+		}
+
+		if fc != nil {
+			fc.xorAt(b[n:n+m], off+int64(n))
+		}
+
+		f.reportProgress(br, m)
+		n += m
+		br.Close()
+		if err != nil {
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				err = io.ErrUnexpectedEOF
+			}
+
+			return n, interpretProvidedError(f.name, br.Block, err)
+		}
+	}
 
-	// For some reason, os.File.ReadAt returns io.EOF in this case instead of
-	// io.ErrUnexpectedEOF.
-	if err == io.ErrUnexpectedEOF {
-		err = io.EOF
+	// Like os.File.ReadAt, return io.EOF if the read stops short at the end of
+	// the file.
+	if n < len(b) {
+		return n, io.EOF
 	}
 
-	return n, err
+	return n, nil
+}
+
+// reportProgress calls the ProgressFunc, if there is one, after n bytes were
+// read from br.
+func (f *FileReader) reportProgress(br *rpc.BlockReader, n int) {
+	total := atomic.AddInt64(&f.bytesRead, int64(n))
+	if n > 0 && f.progress != nil {
+		f.progress(Progress{
+			Bytes:    total,
+			BlockID:  br.Block.GetB().GetBlockId(),
+			Datanode: br.Datanode(),
+		})
+	}
+}
+
+// WriteTo implements io.WriterTo, which io.Copy uses in preference to Read.
+//
+// Data is read from the datanodes directly into a single large buffer, which
+// is written to w whenever it fills up. Compared to the 32KB buffer io.Copy
+// would otherwise use, this makes for far fewer (and larger) writes, which
+// matters when w is a socket or a file.
+func (f *FileReader) WriteTo(w io.Writer) (int64, error) {
+	if f.closed {
+		return 0, io.ErrClosedPipe
+	}
+
+	// The buffer is counted along with the stripes of an erasure coded file.
+	// Once it's acquired, reading a stripe never waits for memory, so that
+	// WriteTo can't hold its buffer while waiting on another reader that's
+	// doing the same.
+	f.client.memory.AcquireOwned(&f.memoryOwned, writeToBufferSize)
+	defer f.client.memory.ReleaseOwned(&f.memoryOwned, writeToBufferSize)
+
+	buf := make([]byte, writeToBufferSize)
+	var written int64
+	for {
+		var n int
+		var readErr error
+		for n < len(buf) && readErr == nil {
+			var nr int
+			nr, readErr = f.Read(buf[n:])
+			n += nr
+		}
+
+		if n > 0 {
+			nw, err := w.Write(buf[:n])
+			written += int64(nw)
+			if err != nil {
+				return written, err
+			} else if nw < n {
+				return written, io.ErrShortWrite
+			}
+		}
+
+		if readErr == io.EOF {
+			return written, nil
+		} else if readErr != nil {
+			return written, readErr
+		}
+	}
 }
 
 // Readdir reads the contents of the directory associated with file and returns
@@ -279,17 +784,20 @@ func (f *FileReader) Readdir(n int) ([]os.FileInfo, error) {
 
 	res := make([]os.FileInfo, 0)
 	for {
-		batch, remaining, err := f.readdir()
+		batch, remaining, err := f.client.getListing(f.tc.boundContext(), f.name, []byte(f.readdirLast), false)
 		if err != nil {
 			return nil, &os.PathError{"readdir", f.name, interpretException(err)}
 		}
 
-		if len(batch) > 0 {
-			f.readdirLast = batch[len(batch)-1].Name()
+		for _, status := range batch {
+			res = append(res, newFileInfo(status, ""))
+		}
+
+		if len(res) > 0 {
+			f.readdirLast = res[len(res)-1].Name()
 		}
 
-		res = append(res, batch...)
-		if remaining == 0 || (n > 0 && len(res) >= n) {
+		if !remaining || (n > 0 && len(res) >= n) {
 			break
 		}
 	}
@@ -308,31 +816,6 @@ func (f *FileReader) Readdir(n int) ([]os.FileInfo, error) {
 	return res, nil
 }
 
-func (f *FileReader) readdir() ([]os.FileInfo, int, error) {
-	req := &hdfs.GetListingRequestProto{
-		Src:          proto.String(f.name),
-		StartAfter:   []byte(f.readdirLast),
-		NeedLocation: proto.Bool(false),
-	}
-	resp := &hdfs.GetListingResponseProto{}
-
-	err := f.client.namenode.Execute("getListing", req, resp)
-	if err != nil {
-		return nil, 0, err
-	} else if resp.GetDirList() == nil {
-		return nil, 0, os.ErrNotExist
-	}
-
-	list := resp.GetDirList().GetPartialListing()
-	res := make([]os.FileInfo, 0, len(list))
-	for _, status := range list {
-		res = append(res, newFileInfo(status, ""))
-	}
-
-	remaining := int(resp.GetDirList().GetRemainingEntries())
-	return res, remaining, nil
-}
-
 // Readdirnames reads and returns a slice of names from the directory f.
 //
 // If n > 0, Readdirnames returns at most n names. In this case, if Readdirnames
@@ -364,50 +847,209 @@ func (f *FileReader) Readdirnames(n int) ([]string, error) {
 
 // Close implements io.Closer.
 func (f *FileReader) Close() error {
+	if !f.closed {
+		atomic.AddInt64(&f.client.filesROpen, -1)
+		defer f.client.fileDone()
+	}
+
 	f.closed = true
 
 	if f.blockReader != nil {
 		f.blockReader.Close()
 	}
 
+	f.closeWebBody()
+	f.buffers.Release()
+	f.tc.close()
+	f.tc = nil
+
 	return nil
 }
 
 func (f *FileReader) getBlocks() error {
+	f.blocksLock.Lock()
+	defer f.blocksLock.Unlock()
+
+	return f.getBlocksLocked()
+}
+
+func (f *FileReader) getBlocksLocked() error {
+	// The length in the file info doesn't include the last block if the file
+	// is still being written to, so we just ask for all of the blocks.
 	req := &hdfs.GetBlockLocationsRequestProto{
 		Src:    proto.String(f.name),
 		Offset: proto.Uint64(0),
-		Length: proto.Uint64(uint64(f.info.Size())),
+		Length: proto.Uint64(math.MaxInt64),
 	}
 	resp := &hdfs.GetBlockLocationsResponseProto{}
 
-	err := f.client.namenode.Execute("getBlockLocations", req, resp)
+	err := f.client.namenode.ExecuteContext(f.tc.boundContext(), "getBlockLocations", req, resp)
 	if err != nil {
 		return err
 	}
 
-	f.blocks = resp.GetLocations().GetBlocks()
+	locs := resp.GetLocations()
+	blocks := locs.GetBlocks()
+	length := int64(locs.GetFileLength())
+
+	// If the file is under construction, the namenode doesn't know how much of
+	// the last block has been written; we have to ask a datanode.
+	last := locs.GetLastBlock()
+	if locs.GetUnderConstruction() && !locs.GetIsLastBlockComplete() && last != nil {
+		visibleLength, err := f.client.getReplicaVisibleLength(last)
+		if err != nil {
+			return err
+		}
+
+		last.B.NumBytes = proto.Uint64(uint64(visibleLength))
+		length += visibleLength
+
+		found := false
+		for i, block := range blocks {
+			if block.GetB().GetBlockId() == last.GetB().GetBlockId() {
+				blocks[i] = last
+				found = true
+			}
+		}
+
+		if !found {
+			blocks = append(blocks, last)
+		}
+	}
+
+	for _, block := range blocks {
+		f.client.orderReplicas(block)
+	}
+
+	// Files in an encryption zone are decrypted as they're read, with a key
+	// from the KMS.
+	if info := locs.GetFileEncryptionInfo(); info != nil && f.cipher == nil {
+		f.cipher, err = f.client.newFileCipher(info)
+		if err != nil {
+			return &os.PathError{"open", f.name, err}
+		}
+	}
+
+	f.blocks = blocks
+	f.length = length
+	f.ecPolicy = locs.GetEcPolicy()
 	return nil
 }
 
+// getReplicaVisibleLength asks the datanodes holding the given block how much
+// of it is visible to readers. This is only necessary for the last block of a
+// file that is under construction.
+func (c *Client) getReplicaVisibleLength(block *hdfs.LocatedBlockProto) (int64, error) {
+	// If the block has no locations, the writer hasn't started writing it yet.
+	locs := block.GetLocs()
+	if len(locs) == 0 {
+		return 0, nil
+	}
+
+	var err error
+	for _, loc := range locs {
+		var length int64
+		length, err = c.getReplicaVisibleLengthFrom(loc.GetId(), block.GetB())
+		if err == nil {
+			return length, nil
+		}
+	}
+
+	return 0, fmt.Errorf("couldn't determine length of block under construction: %s", err)
+}
+
+func (c *Client) getReplicaVisibleLengthFrom(dn *hdfs.DatanodeIDProto, block *hdfs.ExtendedBlockProto) (int64, error) {
+	host := dn.GetIpAddr()
+	if c.options.UseDatanodeHostname {
+		host = dn.GetHostName()
+	}
+
+	// The datanode's IPC port is authenticated the same way as for
+	// DatanodeAdmin, with the datanode's service principal.
+	kerberosClient := c.options.KerberosClient
+	if kerberosClient != nil && c.options.DatanodeKerberosServicePrincipleName == "" {
+		return 0, errors.New("kerberos enabled, but kerberos datanode SPN is not provided")
+	}
+
+	conn, err := rpc.NewNamenodeConnection(rpc.NamenodeConnectionOptions{
+		Addresses:                    []string{fmt.Sprintf("%s:%d", host, dn.GetIpcPort())},
+		User:                         c.namenode.User,
+		DialFunc:                     c.datanodeDialFunc,
+		Protocol:                     clientDatanodeProtocol,
+		WireLog:                      c.wireLog,
+		Strict:                       c.options.StrictProtocol,
+		KerberosClient:               kerberosClient,
+		KerberosServicePrincipleName: c.options.DatanodeKerberosServicePrincipleName,
+	})
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	req := &hdfs.GetReplicaVisibleLengthRequestProto{Block: block}
+	resp := &hdfs.GetReplicaVisibleLengthResponseProto{}
+
+	err = conn.Execute("getReplicaVisibleLength", req, resp)
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(resp.GetLength()), nil
+}
+
+// clientDatanodeProtocol is the RPC protocol the datanodes serve on their IPC
+// port.
+const clientDatanodeProtocol = "org.apache.hadoop.hdfs.protocol.ClientDatanodeProtocol"
+
 func (f *FileReader) getNewBlockReader() error {
-	off := uint64(f.offset)
-	for _, block := range f.blocks {
+	br, err := f.newBlockReader(f.blocks, f.ecPolicy, f.offset)
+	if err != nil {
+		return err
+	}
+
+	// Unlike the readers used by ReadAt, this one's stripes can be read while
+	// WriteTo holds its buffer.
+	br.MemoryOwned = &f.memoryOwned
+	f.blockReader = br
+	return nil
+}
+
+// newBlockReader returns a BlockReader for the block containing off.
+func (f *FileReader) newBlockReader(blocks []*hdfs.LocatedBlockProto, ecPolicy *hdfs.ErasureCodingPolicyProto, offset int64) (*rpc.BlockReader, error) {
+	off := uint64(offset)
+	for _, block := range blocks {
 		start := block.GetOffset()
 		end := start + block.GetB().GetNumBytes()
 
 		if start <= off && off < end {
-			f.blockReader = &rpc.BlockReader{
+			br := &rpc.BlockReader{
 				ClientName:          f.client.namenode.ClientName,
 				Block:               block,
 				Offset:              int64(off - start),
 				UseDatanodeHostname: f.client.options.UseDatanodeHostname,
-				DialFunc:            f.client.options.DatanodeDialFunc,
+				SkipChecksum:        f.skipChecksum,
+				DialFunc:            f.dialDatanode,
+				ConnectTimeout:      f.client.options.DatanodeConnectTimeout,
+				CircuitBreaker:      f.client.breaker,
+				Stats:               f.client.readStats,
+				WireLog:             f.client.wireLog,
+				Strict:              f.client.options.StrictProtocol,
+				Memory:              f.client.memory,
+				ECPolicy:            ecPolicy,
+				Buffers:             &f.buffers,
+				ShortCircuit:        f.client.shortCircuit,
+				ReadAhead:           f.readAhead,
+				ConnPool:            f.connPool(),
+			}
+
+			err := br.SetDeadline(f.deadline)
+			if err != nil {
+				return nil, err
 			}
 
-			return f.SetDeadline(f.deadline)
+			return br, nil
 		}
 	}
 
-	return errors.New("invalid offset")
+	return nil, errors.New("invalid offset")
 }
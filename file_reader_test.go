@@ -2,6 +2,8 @@ package hdfs
 
 import (
 	"bytes"
+	"context"
+	"encoding/binary"
 	"encoding/hex"
 	"fmt"
 	"hash/crc32"
@@ -9,6 +11,7 @@ import (
 	"io/ioutil"
 	"math/rand"
 	"os"
+	"sync"
 	"testing"
 	"time"
 
@@ -83,6 +86,68 @@ func TestFileBigRead(t *testing.T) {
 	assert.EqualValues(t, 0x199d1ae6, hash.Sum32())
 }
 
+func TestFileReadProgress(t *testing.T) {
+	client := getClient(t)
+
+	file, err := client.Open("/_test/mobydick.txt")
+	require.NoError(t, err)
+
+	var last Progress
+	blocks := make(map[uint64]bool)
+	file.SetProgressFunc(func(p Progress) {
+		assert.True(t, p.Bytes > last.Bytes)
+		assert.NotEmpty(t, p.Datanode)
+		blocks[p.BlockID] = true
+		last = p
+	})
+
+	n, err := io.Copy(ioutil.Discard, file)
+	assert.NoError(t, err)
+	assert.EqualValues(t, n, last.Bytes)
+	assert.Len(t, blocks, 2)
+}
+
+func TestFileBigReadSkipChecksum(t *testing.T) {
+	client := getClient(t)
+
+	file, err := client.Open("/_test/mobydick.txt")
+	require.NoError(t, err)
+	file.SetVerifyChecksum(false)
+
+	hash := crc32.NewIEEE()
+	n, err := io.Copy(hash, file)
+	assert.NoError(t, err)
+	assert.EqualValues(t, n, 1257276)
+	assert.EqualValues(t, 0x199d1ae6, hash.Sum32())
+}
+
+func TestFileBigReadAhead(t *testing.T) {
+	client := getClient(t)
+
+	file, err := client.Open("/_test/mobydick.txt")
+	require.NoError(t, err)
+	defer file.Close()
+	file.SetReadAhead(256 * 1024)
+
+	hash := crc32.NewIEEE()
+	n, err := io.Copy(hash, file)
+	assert.NoError(t, err)
+	assert.EqualValues(t, n, 1257276)
+	assert.EqualValues(t, 0x199d1ae6, hash.Sum32())
+
+	// Seeking back discards whatever was read ahead.
+	_, err = file.Seek(1048570, 0)
+	require.NoError(t, err)
+
+	expected, err := ioutil.ReadFile("testdata/mobydick.txt")
+	require.NoError(t, err)
+
+	buf := make([]byte, 100)
+	_, err = io.ReadFull(file, buf)
+	require.NoError(t, err)
+	assert.Equal(t, expected[1048570:1048670], buf)
+}
+
 func TestFileBigReadWeirdSizes(t *testing.T) {
 	client := getClient(t)
 
@@ -113,6 +178,59 @@ func TestFileBigReadN(t *testing.T) {
 	assert.EqualValues(t, n, 1000000)
 }
 
+func TestFileWriteTo(t *testing.T) {
+	client := getClient(t)
+
+	file, err := client.Open("/_test/mobydick.txt")
+	require.NoError(t, err)
+
+	hash := crc32.NewIEEE()
+	n, err := file.WriteTo(hash)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1257276, n)
+	assert.EqualValues(t, 0x199d1ae6, hash.Sum32())
+
+	// The file should be at EOF afterwards, like after io.Copy.
+	n, err = file.WriteTo(hash)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 0, n)
+}
+
+func TestFileWriteToAfterSeek(t *testing.T) {
+	client := getClient(t)
+
+	file, err := client.Open("/_test/mobydick.txt")
+	require.NoError(t, err)
+
+	_, err = file.Seek(1048576, 0)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	n, err := io.Copy(&buf, file)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1257276-1048576, n)
+
+	expected, err := ioutil.ReadFile("testdata/mobydick.txt")
+	require.NoError(t, err)
+	assert.Equal(t, expected[1048576:], buf.Bytes())
+}
+
+type shortWriter struct{}
+
+func (shortWriter) Write(b []byte) (int, error) {
+	return len(b) / 2, nil
+}
+
+func TestFileWriteToShortWrite(t *testing.T) {
+	client := getClient(t)
+
+	file, err := client.Open("/_test/mobydick.txt")
+	require.NoError(t, err)
+
+	_, err = file.WriteTo(shortWriter{})
+	assert.Equal(t, io.ErrShortWrite, err)
+}
+
 func TestFileReadNil(t *testing.T) {
 	client := getClient(t)
 
@@ -165,6 +283,55 @@ func TestFileReadAtEOF(t *testing.T) {
 	assert.Equal(t, err, io.EOF)
 }
 
+func TestFileReadAtConcurrent(t *testing.T) {
+	client := getClient(t)
+
+	expected, err := client.ReadFile("/_test/mobydick.txt")
+	require.NoError(t, err)
+
+	file, err := client.Open("/_test/mobydick.txt")
+	require.NoError(t, err)
+	defer file.Close()
+
+	// Start a sequential read, which ReadAt shouldn't disturb.
+	buf := make([]byte, 100)
+	_, err = io.ReadFull(file, buf)
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 16)
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			// Read ranges that span block boundaries (the file has 1MB blocks).
+			off := int64(i)*int64(len(expected))/16 + 12345
+			b := make([]byte, 1100000)
+			n, err := file.ReadAt(b, off)
+			if err == io.EOF && off+int64(n) == int64(len(expected)) {
+				err = nil
+			}
+
+			if err != nil {
+				errs <- err
+			} else if !bytes.Equal(expected[off:off+int64(n)], b[:n]) {
+				errs <- fmt.Errorf("wrong data at offset %d", off)
+			}
+		}(i)
+	}
+
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+
+	_, err = io.ReadFull(file, buf)
+	require.NoError(t, err)
+	assert.Equal(t, expected[100:200], buf)
+}
+
 func TestFileSeek(t *testing.T) {
 	client := getClient(t)
 
@@ -309,6 +476,47 @@ func TestFileChecksum(t *testing.T) {
 	assert.EqualValues(t, testChecksum, hex.EncodeToString(checksum))
 }
 
+func TestFileCompositeChecksum(t *testing.T) {
+	client := getClient(t)
+
+	file, err := client.Open("/_test/mobydick.txt")
+	require.NoError(t, err)
+
+	checksum, err := file.CompositeChecksum()
+	require.NoError(t, err)
+
+	file.Seek(0, 0)
+	data, err := ioutil.ReadAll(file)
+	require.NoError(t, err)
+
+	// The result depends on which CRC the cluster is configured to use.
+	ieee := crc32.ChecksumIEEE(data)
+	castagnoli := crc32.Checksum(data, crc32.MakeTable(crc32.Castagnoli))
+	crc := binary.BigEndian.Uint32(checksum)
+	assert.True(t, crc == ieee || crc == castagnoli, "composite crc should match the crc of the file")
+}
+
+func TestFileBlockChecksums(t *testing.T) {
+	client := getClient(t)
+
+	file, err := client.Open("/_test/mobydick.txt")
+	require.NoError(t, err)
+
+	checksums, err := file.BlockChecksums()
+	require.NoError(t, err)
+	require.NotEmpty(t, checksums)
+
+	var offset int64
+	for _, checksum := range checksums {
+		assert.Equal(t, offset, checksum.Offset)
+		assert.Len(t, checksum.Checksum, 16)
+		assert.Contains(t, []string{"CRC32", "CRC32C"}, checksum.ChecksumType)
+		offset += checksum.Length
+	}
+
+	assert.EqualValues(t, 1257276, offset)
+}
+
 func TestFileReadDeadline(t *testing.T) {
 	client := getClient(t)
 
@@ -360,3 +568,104 @@ func TestFileChecksumDeadlineBefore(t *testing.T) {
 	_, err = file.Checksum()
 	assert.NotNil(t, err)
 }
+
+func TestFileReadUnderConstruction(t *testing.T) {
+	client := getClient(t)
+
+	baleet(t, "/_test/underconstruction.txt")
+	writer, err := client.Create("/_test/underconstruction.txt")
+	require.NoError(t, err)
+	defer writer.Close()
+
+	_, err = writer.Write([]byte("foo"))
+	require.NoError(t, err)
+	require.NoError(t, writer.Flush())
+
+	file, err := client.Open("/_test/underconstruction.txt")
+	require.NoError(t, err)
+
+	bytes, err := ioutil.ReadAll(file)
+	require.NoError(t, err)
+	assert.EqualValues(t, "foo", string(bytes))
+
+	_, err = writer.Write([]byte("bar"))
+	require.NoError(t, err)
+	require.NoError(t, writer.Flush())
+
+	err = file.Refresh()
+	require.NoError(t, err)
+
+	bytes, err = ioutil.ReadAll(file)
+	require.NoError(t, err)
+	assert.EqualValues(t, "bar", string(bytes))
+}
+
+func TestOpenContext(t *testing.T) {
+	client := getClient(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	file, err := client.OpenContext(ctx, "/_test/mobydick.txt")
+	require.NoError(t, err)
+	defer file.Close()
+
+	_, err = file.Read(make([]byte, 1024))
+	require.NoError(t, err)
+
+	cancel()
+	_, err = file.Read(make([]byte, 1024))
+	assert.Equal(t, context.Canceled, err)
+
+	_, err = client.OpenContext(ctx, "/_test/mobydick.txt")
+	assertPathError(t, err, "open", "/_test/mobydick.txt", context.Canceled)
+}
+
+func TestFileReadContextCancel(t *testing.T) {
+	client := getClient(t)
+
+	file, err := client.Open("/_test/mobydick.txt")
+	require.NoError(t, err)
+	defer file.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	file.SetContext(ctx)
+
+	_, err = file.Read(make([]byte, 1024))
+	require.NoError(t, err)
+
+	cancel()
+	_, err = file.Read(make([]byte, 1024))
+	assert.Equal(t, context.Canceled, err)
+
+	file.SetContext(nil)
+	_, err = file.Read(make([]byte, 1024))
+	assert.NoError(t, err)
+}
+
+func TestFileReadSetContextAfterRead(t *testing.T) {
+	client := getClient(t)
+
+	file, err := client.Open("/_test/mobydick.txt")
+	require.NoError(t, err)
+	defer file.Close()
+
+	// The connection opened by this read, before there was a context, is
+	// still subject to the one set afterwards.
+	_, err = file.Read(make([]byte, 1024))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	file.SetContext(ctx)
+	cancel()
+
+	_, err = file.Read(make([]byte, 1024))
+	assert.Equal(t, context.Canceled, err)
+
+	file.SetContext(nil)
+	b := make([]byte, 1024)
+	_, err = io.ReadFull(file, b)
+	require.NoError(t, err)
+
+	expected, err := ioutil.ReadFile("testdata/mobydick.txt")
+	require.NoError(t, err)
+	assert.Equal(t, expected[1024:2048], b)
+}
@@ -1,8 +1,16 @@
 package hdfs
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"hash"
+	"hash/crc32"
 	"io"
+	"net"
 	"os"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -11,18 +19,56 @@ import (
 	"github.com/golang/protobuf/proto"
 )
 
+// readFromBufferSize is the size of the buffers that FileWriter.ReadFrom reads
+// into.
+const readFromBufferSize = 1024 * 1024
+
+var errSeekWriter = errors.New("can't seek in a file open for writing")
+
 // A FileWriter represents a writer for an open file in HDFS. It implements
-// Writer and Closer, and can only be used for writes. For reads, see
-// FileReader and Client.Open.
+// io.Writer, io.ReaderFrom, and io.Closer, and can only be used for writes. For
+// reads, see FileReader and Client.Open.
 type FileWriter struct {
 	client      *Client
 	name        string
 	replication int
 	blockSize   int64
+	syncBlock   bool
+	// favoredNodes are the datanodes the namenode is asked to place new blocks
+	// on, if it can.
+	favoredNodes []string
+
+	heartbeatInterval time.Duration
+	writeHook         func(rpc.WriteEvent)
+
+	blockWriter  *rpc.BlockWriter
+	blockOffset  int64
+	buffers      rpc.BlockBuffers
+	deadline     time.Time
+	progress     ProgressFunc
+	bytesWritten int64
+	tc           *transferContext
+	lastBlock    *hdfs.ExtendedBlockProto
+	ecPolicy     *hdfs.ErasureCodingPolicyProto
+	cipher       *fileCipher
+	closed       bool
+
+	// web is the request the data is streamed to, over WebHDFS.
+	web *webhdfsUpload
 
-	blockWriter *rpc.BlockWriter
-	deadline    time.Time
-	closed      bool
+	// checksum is computed from the data written, if it's to be verified on
+	// Close. For erasure-coded files, whose block checksums depend on how the
+	// data is striped, crc is computed instead, to compare with the composite
+	// CRC.
+	checksum *md5md5crc
+	crc      hash.Hash32
+
+	// lock synchronizes with the auto-flush goroutine started by SetAutoFlush.
+	lock       sync.Mutex
+	flushBytes int64
+	unflushed  int64
+	flushErr   error
+	stopFlush  chan struct{}
 }
 
 // Create opens a new file in HDFS with the default replication, block size,
@@ -50,18 +96,177 @@ func (c *Client) Create(name string) (*FileWriter, error) {
 }
 
 // CreateFile opens a new file in HDFS with the given replication, block size,
-// and permissions, and returns an io.WriteCloser for writing to it. Because of
-// the way that HDFS writes are buffered and acknowledged asynchronously, it is
-// very important that Close is called after all data has been written.
+// and permissions (with the client's Umask applied), and returns an
+// io.WriteCloser for writing to it. Because of the way that HDFS writes are
+// buffered and acknowledged asynchronously, it is very important that Close is
+// called after all data has been written. For any other options, use
+// CreateWithOptions.
 func (c *Client) CreateFile(name string, replication int, blockSize int64, perm os.FileMode) (*FileWriter, error) {
+	return c.create(name, uint32(hdfs.CreateFlagProto_CREATE), replication, blockSize, perm, false)
+}
+
+// CreateOptions represents the options available when creating a file with
+// CreateWithOptions.
+type CreateOptions struct {
+	// Replication is the replication factor of the file. If zero, the
+	// namenode's default is used.
+	Replication int
+	// BlockSize is the block size of the file. If zero, the namenode's default
+	// is used.
+	BlockSize int64
+	// Perm specifies the permissions of the file. If zero, 0644 is used.
+	Perm os.FileMode
+	// Overwrite specifies that if the file already exists, it should be
+	// truncated and replaced. Otherwise, which is the default here and for
+	// every function that takes CreateOptions, an error wrapping os.ErrExist
+	// is returned.
+	Overwrite bool
+	// Append specifies that if the file already exists, it should be opened
+	// for appending, as with Append. It can't be combined with Overwrite.
+	Append bool
+	// NewBlock specifies that, when appending, data should be written to a new
+	// block rather than to the end of the last block of the file.
+	NewBlock bool
+	// RecoverLease specifies that, when appending, if the file is still open
+	// for writing by another client (usually because it died without closing
+	// it), the lease should be recovered with RecoverLease, and the append
+	// retried once the namenode has closed the file. This waits for up to a
+	// minute. If the other client is in fact still writing, it will fail.
+	RecoverLease bool
+	// LazyPersist specifies that the file should be written to memory on the
+	// datanodes (with the LAZY_PERSIST storage policy) and only persisted to
+	// disk asynchronously. This is faster, but data may be lost if a datanode
+	// restarts before the file is persisted.
+	LazyPersist bool
+	// SyncBlock specifies that each block should be synced to disk by the
+	// datanodes once it's finished, rather than being left to the OS.
+	SyncBlock bool
+	// Verify specifies that Close should fetch the checksum of the file from
+	// HDFS once it's written, and compare it to one computed locally from the
+	// data written, returning a *ChecksumMismatchError if they differ. It
+	// can't be used to append to an existing file. For erasure-coded files,
+	// the composite CRC is compared (see FileReader.CompositeChecksum), which
+	// requires Hadoop 3.1 or later.
+	Verify bool
+	// HeartbeatInterval overrides ClientOptions.DatanodeHeartbeatInterval for
+	// this file, if nonzero. A negative value disables heartbeats.
+	HeartbeatInterval time.Duration
+	// FavoredNodes are datanodes, as "host:port" transfer addresses, that the
+	// namenode should place the replicas of each new block on, if it can; for
+	// example, to keep the data close to the process that will read it. The
+	// namenode falls back to its usual placement for any that aren't
+	// available. It's ignored over WebHDFS.
+	FavoredNodes []string
+}
+
+// CreateWithOptions opens a file in HDFS for writing, creating it if it
+// doesn't exist, with the given options. Because of the way that HDFS writes
+// are buffered and acknowledged asynchronously, it is very important that
+// Close is called after all data has been written.
+func (c *Client) CreateWithOptions(name string, options CreateOptions) (*FileWriter, error) {
+	if options.Overwrite && options.Append {
+		return nil, &os.PathError{"create", name, errors.New("can't both overwrite and append")}
+	} else if options.Verify && c.web != nil {
+		return nil, &os.PathError{"create", name, errWebHDFSUnsupported}
+	}
+
+	if options.Append {
+		_, err := c.getFileInfo(name)
+		err = interpretException(err)
+		if err == nil && options.Verify {
+			return nil, &os.PathError{"create", name, errors.New("can't verify an append")}
+		} else if err == nil {
+			f, err := c.append(name, options.NewBlock, options.SyncBlock, options.RecoverLease)
+			if err != nil {
+				return nil, err
+			}
+
+			f.setHeartbeatInterval(options.HeartbeatInterval)
+			f.favoredNodes = options.FavoredNodes
+			return f, nil
+		} else if !os.IsNotExist(err) {
+			return nil, &os.PathError{"create", name, err}
+		}
+	}
+
+	replication := options.Replication
+	blockSize := options.BlockSize
+	if replication == 0 || blockSize == 0 {
+		defaults, err := c.fetchDefaults()
+		if err != nil {
+			return nil, err
+		}
+
+		if replication == 0 {
+			replication = int(defaults.GetReplication())
+		}
+
+		if blockSize == 0 {
+			blockSize = int64(defaults.GetBlockSize())
+		}
+	}
+
+	perm := options.Perm
+	if perm == 0 {
+		perm = 0644
+	}
+
+	flags := uint32(hdfs.CreateFlagProto_CREATE)
+	if options.Overwrite {
+		flags |= uint32(hdfs.CreateFlagProto_OVERWRITE)
+	}
+
+	if options.LazyPersist {
+		flags |= uint32(hdfs.CreateFlagProto_LAZY_PERSIST)
+	}
+
+	f, err := c.create(name, flags, replication, blockSize, perm, options.SyncBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	f.setHeartbeatInterval(options.HeartbeatInterval)
+	f.favoredNodes = options.FavoredNodes
+
+	if options.Verify {
+		defaults, err := c.fetchDefaults()
+		if err != nil {
+			f.Close()
+			return nil, err
+		}
+
+		poly := checksumPoly(c.checksumType(defaults))
+		if f.ecPolicy != nil {
+			f.crc = crc32.New(crc32.MakeTable(poly))
+		} else {
+			f.checksum = newMD5MD5CRC(int(defaults.GetBytesPerChecksum()), blockSize, poly)
+		}
+	}
+
+	return f, nil
+}
+
+func (c *Client) create(name string, flags uint32, replication int, blockSize int64, perm os.FileMode, syncBlock bool) (*FileWriter, error) {
+	if c.isClosed() {
+		return nil, &os.PathError{"create", name, ErrClientClosed}
+	}
+
+	if c.web != nil {
+		overwrite := flags&uint32(hdfs.CreateFlagProto_OVERWRITE) != 0
+		return c.createWeb(name, overwrite, replication, blockSize, perm)
+	}
+
 	createReq := &hdfs.CreateRequestProto{
 		Src:          proto.String(name),
-		Masked:       &hdfs.FsPermissionProto{Perm: proto.Uint32(uint32(perm))},
+		Masked:       &hdfs.FsPermissionProto{Perm: proto.Uint32(uint32(perm &^ c.options.Umask.Perm()))},
 		ClientName:   proto.String(c.namenode.ClientName),
-		CreateFlag:   proto.Uint32(1),
+		CreateFlag:   proto.Uint32(flags),
 		CreateParent: proto.Bool(false),
 		Replication:  proto.Uint32(uint32(replication)),
 		BlockSize:    proto.Uint64(uint64(blockSize)),
+		// Without this, the namenode refuses to create files in encryption
+		// zones.
+		CryptoProtocolVersion: []hdfs.CryptoProtocolVersionProto{hdfs.CryptoProtocolVersionProto_ENCRYPTION_ZONES},
 	}
 	createResp := &hdfs.CreateResponseProto{}
 
@@ -72,31 +277,92 @@ func (c *Client) CreateFile(name string, replication int, blockSize int64, perm
 
 	atomic.AddUint64(&c.filesWOpen, 1)
 
-	return &FileWriter{
+	f := &FileWriter{
 		client:      c,
 		name:        name,
 		replication: replication,
 		blockSize:   blockSize,
-	}, nil
+		syncBlock:   syncBlock,
+		ecPolicy:    createResp.GetFs().GetEcPolicy(),
+
+		heartbeatInterval: c.options.DatanodeHeartbeatInterval,
+		writeHook:         rpcWriteHook(c.options.WriteHook, c.options.Metrics),
+	}
+
+	err = f.setupCipher(createResp.GetFs().GetFileEncryptionInfo())
+	if err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// setupCipher sets up encryption for a file in an encryption zone, with a key
+// from the KMS. If that fails, the file is closed.
+func (f *FileWriter) setupCipher(info *hdfs.FileEncryptionInfoProto) error {
+	var err error
+	f.cipher, err = f.client.newFileCipher(info)
+	if err != nil {
+		f.Close()
+		return &os.PathError{"create", f.name, err}
+	}
+
+	return nil
 }
 
 // Append opens an existing file in HDFS and returns an io.WriteCloser for
 // writing to it. Because of the way that HDFS writes are buffered and
 // acknowledged asynchronously, it is very important that Close is called after
 // all data has been written.
+//
+// If the last block of the file isn't full, the data is written to the end of
+// it. If the namenode is still recovering that block, because the file was
+// truncated or its lease was recovered, Append waits up to a minute for it to
+// finish. A file still open for writing by another client can't be appended
+// to without CreateOptions.RecoverLease.
 func (c *Client) Append(name string) (*FileWriter, error) {
 	_, err := c.getFileInfo(name)
 	if err != nil {
 		return nil, &os.PathError{"append", name, interpretException(err)}
 	}
 
+	return c.append(name, false, false, false)
+}
+
+func (c *Client) append(name string, newBlock, syncBlock, recoverLease bool) (*FileWriter, error) {
+	if c.isClosed() {
+		return nil, &os.PathError{"append", name, ErrClientClosed}
+	}
+
+	if c.web != nil {
+		return c.appendWeb(name)
+	}
+
 	appendReq := &hdfs.AppendRequestProto{
 		Src:        proto.String(name),
 		ClientName: proto.String(c.namenode.ClientName),
 	}
+	if newBlock {
+		appendReq.Flag = proto.Uint32(uint32(hdfs.CreateFlagProto_APPEND | hdfs.CreateFlagProto_NEW_BLOCK))
+	}
 	appendResp := &hdfs.AppendResponseProto{}
 
-	err = c.namenode.Execute("append", appendReq, appendResp)
+	// If the last block is still under construction, because the namenode is
+	// recovering it (for example, after a truncate), the file can be appended
+	// to once that's finished.
+	err := c.namenode.Execute("append", appendReq, appendResp)
+	if err != nil && isRecoveryInProgress(err) {
+		err = c.waitForRecovery(name)
+		if err == nil {
+			err = c.namenode.Execute("append", appendReq, appendResp)
+		}
+	} else if err != nil && recoverLease && isLeaseHeld(err) {
+		err = c.recoverLeaseAndWait(name)
+		if err == nil {
+			err = c.namenode.Execute("append", appendReq, appendResp)
+		}
+	}
+
 	if err != nil {
 		return nil, &os.PathError{"append", name, interpretException(err)}
 	}
@@ -106,24 +372,60 @@ func (c *Client) Append(name string) (*FileWriter, error) {
 		name:        name,
 		replication: int(appendResp.Stat.GetBlockReplication()),
 		blockSize:   int64(appendResp.Stat.GetBlocksize()),
+		syncBlock:   syncBlock,
+		ecPolicy:    appendResp.Stat.GetEcPolicy(),
+
+		heartbeatInterval: c.options.DatanodeHeartbeatInterval,
+		writeHook:         rpcWriteHook(c.options.WriteHook, c.options.Metrics),
 	}
 
 	atomic.AddUint64(&c.filesWOpen, 1)
+	err = f.setupCipher(appendResp.Stat.GetFileEncryptionInfo())
+	if err != nil {
+		return nil, err
+	}
+
 	// This returns nil if there are no blocks (it's an empty file) or if the
-	// last block is full (so we have to start a fresh block).
+	// last block is full (so we have to start a fresh block). Otherwise, the
+	// pipeline for the last block is set up again when the first packet is
+	// written, with a new generation stamp (see rpc.BlockWriter.UpdateBlockForPipeline).
 	block := appendResp.GetBlock()
 	if block == nil {
+		f.blockOffset = int64(appendResp.Stat.GetLength())
 		return f, nil
 	}
 
+	f.blockOffset = int64(appendResp.Stat.GetLength() - block.B.GetNumBytes())
+
+	defaults, err := c.fetchDefaults()
+	if err != nil {
+		return nil, err
+	}
+
 	f.blockWriter = &rpc.BlockWriter{
 		ClientName:          f.client.namenode.ClientName,
 		Block:               block,
 		BlockSize:           f.blockSize,
 		Offset:              int64(block.B.GetNumBytes()),
 		Append:              true,
+		BytesPerChecksum:    int(defaults.GetBytesPerChecksum()),
+		ChecksumType:        f.client.checksumType(defaults),
+		WritePacketSize:     f.client.writePacketSize(defaults),
+		MaxPacketsInFlight:  f.client.options.MaxPacketsInFlight,
+		SyncBlock:           f.syncBlock,
 		UseDatanodeHostname: f.client.options.UseDatanodeHostname,
-		DialFunc:            f.client.options.DatanodeDialFunc,
+		DialFunc:            f.dialDatanode,
+		CircuitBreaker:      f.client.breaker,
+		HeartbeatInterval:   f.heartbeatInterval,
+		Hook:                f.writeHook,
+		WireLog:             f.client.wireLog,
+		Strict:              f.client.options.StrictProtocol,
+		Memory:              f.client.memory,
+		ECPolicy:            f.ecPolicy,
+		Buffers:             &f.buffers,
+
+		UpdateBlockForPipeline: f.updateBlockForPipeline,
+		UpdatePipeline:         f.updatePipeline,
 	}
 
 	err = f.blockWriter.SetDeadline(f.deadline)
@@ -134,6 +436,19 @@ func (c *Client) Append(name string) (*FileWriter, error) {
 	return f, nil
 }
 
+// setHeartbeatInterval overrides the client's heartbeat interval, if d is
+// nonzero.
+func (f *FileWriter) setHeartbeatInterval(d time.Duration) {
+	if d == 0 {
+		return
+	}
+
+	f.heartbeatInterval = d
+	if f.blockWriter != nil {
+		f.blockWriter.HeartbeatInterval = d
+	}
+}
+
 // CreateEmptyFile creates a empty file at the given name, with the
 // permissions 0644.
 func (c *Client) CreateEmptyFile(name string) error {
@@ -160,15 +475,196 @@ func (f *FileWriter) SetDeadline(t time.Time) error {
 	return nil
 }
 
+// SetContext binds the FileWriter to ctx for future Write, Flush, and Close
+// calls, including the requests they make to the namenode for new blocks.
+// Once ctx is done, any datanode connections are closed, aborting writes in
+// progress, and subsequent calls return ctx.Err(). Close then abandons the
+// block being written, if it's a new one, and closes the file with only the
+// blocks that were already complete. Passing nil unbinds the FileWriter from
+// any previous context.
+func (f *FileWriter) SetContext(ctx context.Context) {
+	f.tc.close()
+	f.tc = nil
+	if ctx != nil {
+		f.tc = newTransferContext(ctx)
+	}
+}
+
+// dialDatanode connects to a datanode, using the context bound with
+// SetContext, if any.
+func (f *FileWriter) dialDatanode(ctx context.Context, network, addr string) (net.Conn, error) {
+	return f.tc.wrap(f.client.dialDataTransfer)(ctx, network, addr)
+}
+
+// SetProgressFunc registers a function to be called with the cumulative
+// number of bytes written, and the current block and datanode, after each
+// successful write. Passing nil removes it.
+func (f *FileWriter) SetProgressFunc(fn ProgressFunc) {
+	f.progress = fn
+}
+
+// SetAutoFlush sets the FileWriter to flush written data out to the datanodes
+// automatically, as with Flush, after every bytes bytes written, and at least
+// every interval while there's unflushed data. This makes slowly-written
+// files, like logs, visible to readers continuously. Either can be zero to
+// disable it; passing zero for both turns auto-flushing off.
+//
+// Flushing in the background means that a failure can't be returned
+// immediately. Instead, it's returned from the next call to Write, Flush, or
+// Close.
+func (f *FileWriter) SetAutoFlush(bytes int64, interval time.Duration) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if f.stopFlush != nil {
+		close(f.stopFlush)
+		f.stopFlush = nil
+	}
+
+	f.flushBytes = bytes
+	if interval > 0 && !f.closed {
+		f.stopFlush = make(chan struct{})
+		go f.autoFlush(interval, f.stopFlush)
+	}
+}
+
+// autoFlush is meant to run in the background, flushing any unflushed data
+// every interval until stop is closed.
+func (f *FileWriter) autoFlush(interval time.Duration, stop chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-stop:
+			return
+		}
+
+		f.lock.Lock()
+		if f.closed || f.flushErr != nil {
+			f.lock.Unlock()
+			return
+		}
+
+		if f.unflushed > 0 {
+			f.flushErr = f.flush()
+		}
+
+		f.lock.Unlock()
+	}
+}
+
 // Write implements io.Writer for writing to a file in HDFS. Internally, it
 // writes data to an internal buffer first, and then later out to HDFS. Because
 // of this, it is important that Close is called after all data has been
 // written.
 func (f *FileWriter) Write(b []byte) (int, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
 	if f.closed {
 		return 0, io.ErrClosedPipe
 	}
 
+	if err := f.tc.err(); err != nil {
+		return 0, err
+	} else if f.flushErr != nil {
+		return 0, f.flushErr
+	}
+
+	return f.write(b, false)
+}
+
+// ReadFrom implements io.ReaderFrom, so that io.Copy writes straight from r
+// into the packets sent to the datanodes, rather than copying everything into
+// an internal buffer first. It also reads from r in the background, so that
+// reading the next part of the source overlaps with writing the last one.
+func (f *FileWriter) ReadFrom(r io.Reader) (int64, error) {
+	f.lock.Lock()
+	closed, flushErr := f.closed, f.flushErr
+	f.lock.Unlock()
+
+	if closed {
+		return 0, io.ErrClosedPipe
+	}
+
+	if err := f.tc.err(); err != nil {
+		return 0, err
+	} else if flushErr != nil {
+		return 0, flushErr
+	}
+
+	// Each buffer is handed off to the block writer as-is, and is referenced by
+	// packets until they're acknowledged, so they can't be reused. They should
+	// be at least a packet long, so that the packets sent are full-sized.
+	bufSize := readFromBufferSize
+	if f.client.options.WritePacketSize > bufSize {
+		bufSize = f.client.options.WritePacketSize
+	}
+
+	bufs := make(chan []byte, 1)
+	done := make(chan struct{})
+	var readErr error
+	go func() {
+		defer close(bufs)
+		for {
+			f.client.memory.Acquire(int64(bufSize))
+			buf := make([]byte, bufSize)
+			n, err := io.ReadFull(r, buf)
+			if n > 0 {
+				select {
+				case bufs <- buf[:n]:
+				case <-done:
+					f.client.memory.Release(int64(bufSize))
+					return
+				}
+			} else {
+				f.client.memory.Release(int64(bufSize))
+			}
+
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				return
+			} else if err != nil {
+				readErr = err
+				return
+			}
+		}
+	}()
+
+	// Don't return until the goroutine is done with r.
+	defer func() {
+		close(done)
+		for range bufs {
+			f.client.memory.Release(int64(bufSize))
+		}
+	}()
+
+	// Once a buffer is handed to the block writer, the memory it holds is
+	// accounted for by the packets referencing it instead.
+	var written int64
+	for buf := range bufs {
+		f.client.memory.Release(int64(bufSize))
+		f.lock.Lock()
+		n, err := f.write(buf, true)
+		f.lock.Unlock()
+
+		written += int64(n)
+		if err != nil {
+			return written, err
+		}
+	}
+
+	return written, readErr
+}
+
+func (f *FileWriter) write(b []byte, noCopy bool) (int, error) {
+	if f.web != nil {
+		n, err := f.web.Write(b)
+		f.bytesWritten += int64(n)
+		return n, err
+	}
+
 	if f.blockWriter == nil {
 		err := f.startNewBlock()
 		if err != nil {
@@ -176,10 +672,44 @@ func (f *FileWriter) Write(b []byte) (int, error) {
 		}
 	}
 
+	// Files in an encryption zone are encrypted before they're written. For
+	// Write, that means they have to be copied first, but the copy can then be
+	// sent as-is.
+	if f.cipher != nil {
+		if !noCopy {
+			b = append([]byte(nil), b...)
+			noCopy = true
+		}
+
+		f.cipher.xorAt(b, f.blockOffset+f.blockWriter.Offset)
+	}
+
 	off := 0
 	for off < len(b) {
-		n, err := f.blockWriter.Write(b[off:])
+		var n int
+		var err error
+		if noCopy {
+			n, err = f.blockWriter.WriteNoCopy(b[off:])
+		} else {
+			n, err = f.blockWriter.Write(b[off:])
+		}
+
+		if f.checksum != nil {
+			f.checksum.Write(b[off : off+n])
+		} else if f.crc != nil {
+			f.crc.Write(b[off : off+n])
+		}
+
 		off += n
+		f.bytesWritten += int64(n)
+		if n > 0 && f.progress != nil {
+			f.progress(Progress{
+				Bytes:    f.bytesWritten,
+				BlockID:  f.blockWriter.Block.GetB().GetBlockId(),
+				Datanode: f.blockWriter.Datanode(),
+			})
+		}
+
 		if err == rpc.ErrEndOfBlock {
 			err = f.startNewBlock()
 		}
@@ -189,39 +719,190 @@ func (f *FileWriter) Write(b []byte) (int, error) {
 		}
 	}
 
+	f.unflushed += int64(off)
+	if f.flushBytes > 0 && f.unflushed >= f.flushBytes {
+		err := f.flush()
+		if err != nil {
+			return off, err
+		}
+	}
+
 	return off, nil
 }
 
-// Flush flushes any buffered data out to the datanodes. Even immediately after
-// a call to Flush, it is still necessary to call Close once all data has been
-// written.
+// Flush flushes any buffered data out to the datanodes, and waits for it to be
+// acknowledged, like hflush in the Java client. Once it returns, the data is
+// visible to new readers (see VisibleLength). Even immediately after a call to
+// Flush, it is still necessary to call Close once all data has been written.
+//
+// For erasure-coded files, data is written out a stripe at a time, so Flush
+// has no effect on a partial stripe.
 func (f *FileWriter) Flush() error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
 	if f.closed {
 		return io.ErrClosedPipe
 	}
 
+	if err := f.tc.err(); err != nil {
+		return err
+	} else if f.flushErr != nil {
+		return f.flushErr
+	}
+
+	return f.flush()
+}
+
+func (f *FileWriter) flush() error {
 	if f.blockWriter != nil {
-		return f.blockWriter.Flush()
+		err := f.blockWriter.Flush()
+		if err != nil {
+			return err
+		}
 	}
 
+	f.unflushed = 0
 	return nil
 }
 
+// Sync is like Flush, but additionally waits for the datanodes to sync the data
+// to disk, and updates the length of the file recorded by the namenode, like
+// hsync (with the UPDATE_LENGTH flag) in the Java client. Once it returns, the
+// data survives the datanodes crashing, and the new length is reported by Stat
+// and ReadDir, not just to readers that open the file.
+//
+// For erasure-coded files, Sync has no effect on a partial stripe, like Flush,
+// and the length recorded by the namenode only includes the full stripes.
+func (f *FileWriter) Sync() error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if f.closed {
+		return io.ErrClosedPipe
+	}
+
+	if err := f.tc.err(); err != nil {
+		return err
+	} else if f.flushErr != nil {
+		return f.flushErr
+	}
+
+	fsyncReq := &hdfs.FsyncRequestProto{
+		Src:    proto.String(f.name),
+		Client: proto.String(f.client.namenode.ClientName),
+	}
+
+	if f.blockWriter != nil {
+		err := f.blockWriter.Sync()
+		if err != nil {
+			return err
+		}
+
+		fsyncReq.LastBlockLength = proto.Int64(f.blockWriter.SyncedLength())
+	}
+
+	fsyncResp := &hdfs.FsyncResponseProto{}
+	err := f.client.namenode.ExecuteContext(f.tc.boundContext(), "fsync", fsyncReq, fsyncResp)
+	if err != nil {
+		return &os.PathError{"sync", f.name, interpretException(err)}
+	}
+
+	f.unflushed = 0
+	return nil
+}
+
+// Seek implements io.Seeker, but since HDFS files can only be appended to, the
+// only seeks that work are the ones that stay where they are, like
+// Seek(0, io.SeekCurrent) or Seek(0, io.SeekEnd), which return the length of
+// the file, including anything written but not yet flushed. Seeking anywhere
+// else returns an error.
+func (f *FileWriter) Seek(offset int64, whence int) (int64, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if f.closed {
+		return 0, io.ErrClosedPipe
+	}
+
+	var length int64
+	if f.web != nil {
+		length = f.blockOffset + f.bytesWritten
+	} else if f.blockWriter != nil {
+		length = f.blockOffset + f.blockWriter.Offset
+	} else {
+		length = f.blockOffset
+	}
+
+	var off int64
+	switch whence {
+	case io.SeekStart:
+		off = offset
+	case io.SeekCurrent, io.SeekEnd:
+		off = length + offset
+	default:
+		return length, fmt.Errorf("invalid whence: %d", whence)
+	}
+
+	if off != length {
+		return length, &os.PathError{"seek", f.name, errSeekWriter}
+	}
+
+	return length, nil
+}
+
+// VisibleLength returns the number of bytes of the file that are guaranteed
+// to be visible to new readers, because they have been acknowledged by every
+// datanode in the pipeline. After a successful call to Flush, this includes
+// everything written so far.
+func (f *FileWriter) VisibleLength() int64 {
+	if f.blockWriter != nil {
+		return f.blockOffset + f.blockWriter.AckedOffset()
+	}
+
+	return f.blockOffset
+}
+
 // Close closes the file, writing any remaining data out to disk and waiting
 // for acknowledgements from the datanodes. It is important that Close is called
 // after all data has been written.
 func (f *FileWriter) Close() error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
 	if f.closed {
 		return io.ErrClosedPipe
 	}
 
 	f.closed = true
-	atomic.AddUint64(&f.client.filesWOpen, ^uint64(0))
+	defer f.client.fileDone()
+	defer atomic.AddUint64(&f.client.filesWOpen, ^uint64(0))
+	defer func() {
+		f.tc.close()
+		f.tc = nil
+	}()
 
-	var lastBlock *hdfs.ExtendedBlockProto
-	if f.blockWriter != nil {
-		lastBlock = f.blockWriter.Block.GetB()
+	if f.stopFlush != nil {
+		close(f.stopFlush)
+		f.stopFlush = nil
+	}
+
+	if err := f.tc.err(); err != nil {
+		return f.abandon(err)
+	} else if f.flushErr != nil {
+		return f.abandon(f.flushErr)
+	}
+
+	if f.web != nil {
+		err := f.web.close()
+		if err != nil {
+			return &os.PathError{"create", f.name, interpretException(err)}
+		}
+
+		return nil
+	}
 
+	if f.blockWriter != nil {
 		// Close the blockWriter, flushing any buffered packets.
 		err := f.finalizeBlock()
 		if err != nil {
@@ -229,10 +910,89 @@ func (f *FileWriter) Close() error {
 		}
 	}
 
+	err := f.complete()
+	if err == nil && (f.checksum != nil || f.crc != nil) {
+		err = f.verify()
+	}
+
+	return err
+}
+
+// verify compares the checksum of the file in HDFS with the one computed from
+// the data written.
+func (f *FileWriter) verify() error {
+	r, err := f.client.Open(f.name)
+	if err != nil {
+		return err
+	}
+
+	defer r.Close()
+
+	var expected, actual []byte
+	if f.crc != nil {
+		expected = f.crc.Sum(nil)
+		actual, err = r.CompositeChecksum()
+	} else {
+		expected = f.checksum.Sum()
+		actual, err = r.Checksum()
+	}
+
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(expected, actual) {
+		return &ChecksumMismatchError{Name: f.name, Expected: expected, Actual: actual}
+	}
+
+	return nil
+}
+
+// abandon is called by Close if the context bound with SetContext is done, or
+// if a background flush failed. It abandons the current block and then closes
+// the file with the blocks that were finalized before it. If the current block
+// is an existing one that was being appended to, it can't be abandoned, so the
+// file is left for the namenode to recover once the lease expires. Either way,
+// it returns ctxErr unless there's another error.
+func (f *FileWriter) abandon(ctxErr error) error {
+	if f.web != nil {
+		f.web.abort(ctxErr)
+		return ctxErr
+	}
+
+	if f.blockWriter != nil {
+		f.blockWriter.Close()
+		if f.blockWriter.Append {
+			return ctxErr
+		}
+
+		abandonReq := &hdfs.AbandonBlockRequestProto{
+			B:      f.blockWriter.Block.GetB(),
+			Src:    proto.String(f.name),
+			Holder: proto.String(f.client.namenode.ClientName),
+		}
+		abandonResp := &hdfs.AbandonBlockResponseProto{}
+
+		err := f.client.namenode.Execute("abandonBlock", abandonReq, abandonResp)
+		if err != nil {
+			return &os.PathError{"create", f.name, interpretException(err)}
+		}
+
+		f.blockWriter = nil
+	}
+
+	err := f.complete()
+	if err != nil {
+		return err
+	}
+
+	return ctxErr
+}
+
+func (f *FileWriter) complete() error {
 	completeReq := &hdfs.CompleteRequestProto{
 		Src:        proto.String(f.name),
 		ClientName: proto.String(f.client.namenode.ClientName),
-		Last:       lastBlock,
+		Last:       f.lastBlock,
 	}
 	completeResp := &hdfs.CompleteResponseProto{}
 
@@ -258,28 +1018,89 @@ func (f *FileWriter) startNewBlock() error {
 	}
 
 	addBlockReq := &hdfs.AddBlockRequestProto{
-		Src:        proto.String(f.name),
-		ClientName: proto.String(f.client.namenode.ClientName),
-		Previous:   previous,
+		Src:          proto.String(f.name),
+		ClientName:   proto.String(f.client.namenode.ClientName),
+		Previous:     previous,
+		ExcludeNodes: f.client.breaker.ExcludedNodes(),
+		FavoredNodes: f.favoredNodes,
 	}
 	addBlockResp := &hdfs.AddBlockResponseProto{}
 
-	err := f.client.namenode.Execute("addBlock", addBlockReq, addBlockResp)
+	err := f.client.namenode.ExecuteContext(f.tc.boundContext(), "addBlock", addBlockReq, addBlockResp)
 	if err != nil {
 		return &os.PathError{"create", f.name, interpretException(err)}
 	}
 
+	defaults, err := f.client.fetchDefaults()
+	if err != nil {
+		return err
+	}
+
 	f.blockWriter = &rpc.BlockWriter{
 		ClientName:          f.client.namenode.ClientName,
 		Block:               addBlockResp.GetBlock(),
 		BlockSize:           f.blockSize,
+		BytesPerChecksum:    int(defaults.GetBytesPerChecksum()),
+		ChecksumType:        f.client.checksumType(defaults),
+		WritePacketSize:     f.client.writePacketSize(defaults),
+		MaxPacketsInFlight:  f.client.options.MaxPacketsInFlight,
+		SyncBlock:           f.syncBlock,
 		UseDatanodeHostname: f.client.options.UseDatanodeHostname,
-		DialFunc:            f.client.options.DatanodeDialFunc,
+		DialFunc:            f.dialDatanode,
+		CircuitBreaker:      f.client.breaker,
+		HeartbeatInterval:   f.heartbeatInterval,
+		Hook:                f.writeHook,
+		WireLog:             f.client.wireLog,
+		Strict:              f.client.options.StrictProtocol,
+		Memory:              f.client.memory,
+		ECPolicy:            f.ecPolicy,
+		Buffers:             &f.buffers,
+
+		UpdateBlockForPipeline: f.updateBlockForPipeline,
+		UpdatePipeline:         f.updatePipeline,
 	}
 
 	return f.blockWriter.SetDeadline(f.deadline)
 }
 
+// updateBlockForPipeline gets a new generation stamp and access token for a
+// block that's being written, for its BlockWriter to set up a pipeline with.
+func (f *FileWriter) updateBlockForPipeline(block *hdfs.ExtendedBlockProto) (*hdfs.LocatedBlockProto, error) {
+	updateReq := &hdfs.UpdateBlockForPipelineRequestProto{
+		Block:      block,
+		ClientName: proto.String(f.client.namenode.ClientName),
+	}
+	updateResp := &hdfs.UpdateBlockForPipelineResponseProto{}
+
+	err := f.client.namenode.ExecuteContext(f.tc.boundContext(), "updateBlockForPipeline", updateReq, updateResp)
+	if err != nil {
+		return nil, interpretException(err)
+	}
+
+	return updateResp.GetBlock(), nil
+}
+
+// updatePipeline tells the namenode about the new generation stamp of a block
+// that's being written, and the datanodes in its new pipeline.
+func (f *FileWriter) updatePipeline(oldBlock, newBlock *hdfs.ExtendedBlockProto, pipeline []*hdfs.DatanodeInfoProto, storageIDs []string) error {
+	nodes := make([]*hdfs.DatanodeIDProto, 0, len(pipeline))
+	for _, dn := range pipeline {
+		nodes = append(nodes, dn.GetId())
+	}
+
+	updateReq := &hdfs.UpdatePipelineRequestProto{
+		ClientName: proto.String(f.client.namenode.ClientName),
+		OldBlock:   oldBlock,
+		NewBlock:   newBlock,
+		NewNodes:   nodes,
+		StorageIDs: storageIDs,
+	}
+	updateResp := &hdfs.UpdatePipelineResponseProto{}
+
+	err := f.client.namenode.ExecuteContext(f.tc.boundContext(), "updatePipeline", updateReq, updateResp)
+	return interpretException(err)
+}
+
 func (f *FileWriter) finalizeBlock() error {
 	err := f.blockWriter.Close()
 	if err != nil {
@@ -300,6 +1121,8 @@ func (f *FileWriter) finalizeBlock() error {
 		return err
 	}
 
+	f.blockOffset += f.blockWriter.Offset
+	f.lastBlock = lastBlock
 	f.blockWriter = nil
 	return nil
 }
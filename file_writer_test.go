@@ -1,12 +1,16 @@
 package hdfs
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"hash/crc32"
 	"io"
 	"io/ioutil"
 	"math/rand"
 	"os"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -42,6 +46,31 @@ func TestFileWrite(t *testing.T) {
 	assert.Equal(t, "foobar", string(bytes))
 }
 
+func TestFileWriteProgress(t *testing.T) {
+	client := getClient(t)
+
+	baleet(t, "/_test/create/progress.txt")
+	mkdirp(t, "/_test/create")
+	writer, err := client.CreateFile("/_test/create/progress.txt", 1, 1048576, 0644)
+	require.NoError(t, err)
+
+	var last Progress
+	blocks := make(map[uint64]bool)
+	writer.SetProgressFunc(func(p Progress) {
+		assert.True(t, p.Bytes > last.Bytes)
+		assert.NotEmpty(t, p.Datanode)
+		blocks[p.BlockID] = true
+		last = p
+	})
+
+	_, err = writer.Write(make([]byte, 1500000))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	assert.EqualValues(t, 1500000, last.Bytes)
+	assert.Len(t, blocks, 2)
+}
+
 func TestFileBigWrite(t *testing.T) {
 	client := getClient(t)
 
@@ -150,6 +179,134 @@ func TestFileBigWriteReplication(t *testing.T) {
 	assert.EqualValues(t, 0x199d1ae6, hash.Sum32())
 }
 
+func TestFileReadFrom(t *testing.T) {
+	client := getClient(t)
+
+	mkdirp(t, "/_test/create")
+	writer, err := client.CreateFile("/_test/create/readfrom.txt", 1, 1048576, 0644)
+	require.NoError(t, err)
+
+	mobydick, err := os.Open("testdata/mobydick.txt")
+	require.NoError(t, err)
+
+	n, err := writer.ReadFrom(mobydick)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1257276, n)
+
+	err = writer.Close()
+	require.NoError(t, err)
+
+	reader, err := client.Open("/_test/create/readfrom.txt")
+	require.NoError(t, err)
+
+	hash := crc32.NewIEEE()
+	n, err = io.Copy(hash, reader)
+	assert.Nil(t, err)
+	assert.EqualValues(t, 1257276, n)
+	assert.EqualValues(t, 0x199d1ae6, hash.Sum32())
+}
+
+func TestFileReadFromAfterWrite(t *testing.T) {
+	client := getClient(t)
+
+	mkdirp(t, "/_test/create")
+	writer, err := client.CreateFile("/_test/create/readfrom2.txt", 1, 1048576, 0644)
+	require.NoError(t, err)
+
+	mobydick, err := os.Open("testdata/mobydick.txt")
+	require.NoError(t, err)
+
+	// Write an odd number of bytes first, so that ReadFrom starts in the
+	// middle of a chunk.
+	buf := make([]byte, 1001)
+	_, err = io.ReadFull(mobydick, buf)
+	require.NoError(t, err)
+
+	_, err = writer.Write(buf)
+	require.NoError(t, err)
+
+	n, err := writer.ReadFrom(mobydick)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1257276-1001, n)
+
+	err = writer.Close()
+	require.NoError(t, err)
+
+	reader, err := client.Open("/_test/create/readfrom2.txt")
+	require.NoError(t, err)
+
+	hash := crc32.NewIEEE()
+	n, err = io.Copy(hash, reader)
+	assert.Nil(t, err)
+	assert.EqualValues(t, 1257276, n)
+	assert.EqualValues(t, 0x199d1ae6, hash.Sum32())
+}
+
+func TestFileReadFromEmpty(t *testing.T) {
+	client := getClient(t)
+
+	mkdirp(t, "/_test/create")
+	writer, err := client.Create("/_test/create/readfrom3.txt")
+	require.NoError(t, err)
+
+	n, err := writer.ReadFrom(strings.NewReader(""))
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, n)
+
+	err = writer.Close()
+	require.NoError(t, err)
+
+	fi, err := client.Stat("/_test/create/readfrom3.txt")
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, fi.Size())
+}
+
+func TestFileWriteVerify(t *testing.T) {
+	client := getClient(t)
+
+	mkdirp(t, "/_test/create")
+	for _, blockSize := range []int64{1048576, 1050000} {
+		writer, err := client.CreateWithOptions("/_test/create/verify.txt", CreateOptions{
+			Replication: 1,
+			BlockSize:   blockSize,
+			Overwrite:   true,
+			Verify:      true,
+		})
+		require.NoError(t, err)
+
+		mobydick, err := os.Open("testdata/mobydick.txt")
+		require.NoError(t, err)
+
+		_, err = io.Copy(writer, mobydick)
+		require.NoError(t, err)
+		mobydick.Close()
+
+		err = writer.Close()
+		require.NoError(t, err, "block size %d", blockSize)
+	}
+}
+
+func TestFileWriteVerifyEmpty(t *testing.T) {
+	client := getClient(t)
+
+	mkdirp(t, "/_test/create")
+	writer, err := client.CreateWithOptions("/_test/create/verify2.txt", CreateOptions{Verify: true})
+	require.NoError(t, err)
+
+	err = writer.Close()
+	require.NoError(t, err)
+}
+
+func TestFileWriteVerifyAppend(t *testing.T) {
+	client := getClient(t)
+
+	mkdirp(t, "/_test/create")
+	touch(t, "/_test/create/verify3.txt")
+
+	_, err := client.CreateWithOptions("/_test/create/verify3.txt", CreateOptions{Append: true, Verify: true})
+	assertPathError(t, err, "create", "/_test/create/verify3.txt", errors.New("can't verify an append"))
+}
+
 func TestFileWriteSmallFlushes(t *testing.T) {
 	client := getClient(t)
 
@@ -195,6 +352,116 @@ func TestFileWriteSmallFlushes(t *testing.T) {
 	assert.Equal(t, expected, string(bytes))
 }
 
+func TestFileWriteVisibleLength(t *testing.T) {
+	client := getClient(t)
+
+	mkdirp(t, "/_test/create")
+	writer, err := client.CreateFile("/_test/create/visible.txt", 1, 1048576, 0644)
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, writer.VisibleLength())
+
+	// Write enough to span a couple of blocks.
+	_, err = writer.Write(make([]byte, 1048576+1024))
+	require.NoError(t, err)
+
+	err = writer.Flush()
+	require.NoError(t, err)
+	assert.EqualValues(t, 1048576+1024, writer.VisibleLength())
+
+	_, err = writer.Write([]byte("foo"))
+	require.NoError(t, err)
+
+	err = writer.Flush()
+	require.NoError(t, err)
+	assert.EqualValues(t, 1048576+1027, writer.VisibleLength())
+
+	err = writer.Close()
+	require.NoError(t, err)
+}
+
+func TestFileWriteSync(t *testing.T) {
+	client := getClient(t)
+
+	mkdirp(t, "/_test/create")
+	writer, err := client.CreateFile("/_test/create/sync.txt", 1, 1048576, 0644)
+	require.NoError(t, err)
+
+	_, err = writer.Write(make([]byte, 1048576+1024))
+	require.NoError(t, err)
+
+	err = writer.Sync()
+	require.NoError(t, err)
+	assert.EqualValues(t, 1048576+1024, writer.VisibleLength())
+
+	// Unlike Flush, Sync updates the length recorded by the namenode.
+	fi, err := client.Stat("/_test/create/sync.txt")
+	require.NoError(t, err)
+	assert.EqualValues(t, 1048576+1024, fi.Size())
+
+	err = writer.Sync()
+	require.NoError(t, err)
+
+	err = writer.Close()
+	require.NoError(t, err)
+
+	err = writer.Sync()
+	assert.Equal(t, io.ErrClosedPipe, err)
+}
+
+func TestFileWriteAutoFlushBytes(t *testing.T) {
+	client := getClient(t)
+
+	mkdirp(t, "/_test/create")
+	writer, err := client.CreateFile("/_test/create/autoflush.txt", 1, 1048576, 0644)
+	require.NoError(t, err)
+
+	writer.SetAutoFlush(10, 0)
+
+	_, err = writer.Write([]byte("foo"))
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, writer.VisibleLength())
+
+	_, err = writer.Write([]byte("barbazqux"))
+	require.NoError(t, err)
+	assert.EqualValues(t, 12, writer.VisibleLength())
+
+	reader, err := client.Open("/_test/create/autoflush.txt")
+	require.NoError(t, err)
+
+	bytes, err := ioutil.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Equal(t, "foobarbazqux", string(bytes))
+
+	err = writer.Close()
+	require.NoError(t, err)
+}
+
+func TestFileWriteAutoFlushInterval(t *testing.T) {
+	client := getClient(t)
+
+	mkdirp(t, "/_test/create")
+	writer, err := client.CreateFile("/_test/create/autoflush2.txt", 1, 1048576, 0644)
+	require.NoError(t, err)
+
+	writer.SetAutoFlush(0, 100*time.Millisecond)
+
+	_, err = writer.Write([]byte("foo"))
+	require.NoError(t, err)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for writer.VisibleLength() < 3 && time.Now().Before(deadline) {
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	assert.EqualValues(t, 3, writer.VisibleLength())
+
+	err = writer.Close()
+	require.NoError(t, err)
+
+	_, err = writer.Write([]byte("bar"))
+	assert.Equal(t, io.ErrClosedPipe, err)
+}
+
 func TestCreateEmptyFile(t *testing.T) {
 	client := getClient(t)
 
@@ -237,6 +504,138 @@ func TestCreateEmptyFileWithoutPermission(t *testing.T) {
 	assertPathError(t, err, "stat", "/_test/accessdenied/emptyfile", os.ErrNotExist)
 }
 
+func TestCreateWithOptionsExists(t *testing.T) {
+	client := getClient(t)
+
+	baleet(t, "/_test/create/exists.txt")
+	mkdirp(t, "/_test/create")
+	touch(t, "/_test/create/exists.txt")
+
+	_, err := client.CreateWithOptions("/_test/create/exists.txt", CreateOptions{})
+	assertPathError(t, err, "create", "/_test/create/exists.txt", os.ErrExist)
+}
+
+func TestCreateWithOptionsOverwrite(t *testing.T) {
+	client := getClient(t)
+
+	baleet(t, "/_test/create/overwrite.txt")
+	mkdirp(t, "/_test/create")
+	touch(t, "/_test/create/overwrite.txt")
+
+	writer, err := client.CreateWithOptions("/_test/create/overwrite.txt", CreateOptions{
+		Overwrite: true,
+		Perm:      0600,
+		SyncBlock: true,
+	})
+	require.NoError(t, err)
+
+	_, err = writer.Write([]byte("foo"))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	bytes, err := client.ReadFile("/_test/create/overwrite.txt")
+	require.NoError(t, err)
+	assert.Equal(t, "foo", string(bytes))
+
+	fi, err := client.Stat("/_test/create/overwrite.txt")
+	require.NoError(t, err)
+	assert.EqualValues(t, 0600, fi.Mode().Perm())
+}
+
+func TestCreateWithOptionsAppend(t *testing.T) {
+	client := getClient(t)
+
+	baleet(t, "/_test/create/append.txt")
+	mkdirp(t, "/_test/create")
+
+	for _, s := range []string{"foo", "bar"} {
+		writer, err := client.CreateWithOptions("/_test/create/append.txt", CreateOptions{
+			Append:   true,
+			NewBlock: true,
+		})
+		require.NoError(t, err)
+
+		_, err = writer.Write([]byte(s))
+		require.NoError(t, err)
+		require.NoError(t, writer.Close())
+	}
+
+	reader, err := client.Open("/_test/create/append.txt")
+	require.NoError(t, err)
+
+	bytes, err := ioutil.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Equal(t, "foobar", string(bytes))
+
+	blocks, err := reader.Blocks()
+	require.NoError(t, err)
+	assert.Len(t, blocks, 2)
+}
+
+func TestCreateWithOptionsFavoredNodes(t *testing.T) {
+	client := getClient(t)
+
+	baleet(t, "/_test/create/favored.txt")
+	mkdirp(t, "/_test/create")
+
+	datanodes, err := client.Datanodes()
+	require.NoError(t, err)
+	require.NotEmpty(t, datanodes)
+	dn := datanodes[len(datanodes)-1]
+
+	writer, err := client.CreateWithOptions("/_test/create/favored.txt", CreateOptions{
+		Replication:  1,
+		FavoredNodes: []string{fmt.Sprintf("%s:%d", dn.IPAddr, dn.XferPort)},
+	})
+	require.NoError(t, err)
+
+	_, err = writer.Write([]byte("foo"))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	reader, err := client.Open("/_test/create/favored.txt")
+	require.NoError(t, err)
+	defer reader.Close()
+
+	blocks, err := reader.Blocks()
+	require.NoError(t, err)
+	require.Len(t, blocks, 1)
+	assert.Equal(t, []string{dn.UUID}, blocks[0].DatanodeUUIDs)
+}
+
+func TestFileWriterSeek(t *testing.T) {
+	client := getClient(t)
+
+	baleet(t, "/_test/create/seek.txt")
+	mkdirp(t, "/_test/create")
+
+	writer, err := client.Create("/_test/create/seek.txt")
+	require.NoError(t, err)
+
+	_, err = writer.Write([]byte("foo"))
+	require.NoError(t, err)
+
+	off, err := writer.Seek(0, io.SeekCurrent)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, off)
+
+	off, err = writer.Seek(3, io.SeekStart)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, off)
+
+	_, err = writer.Seek(0, io.SeekStart)
+	assertPathError(t, err, "seek", "/_test/create/seek.txt", errSeekWriter)
+	require.NoError(t, writer.Close())
+
+	writer, err = client.Append("/_test/create/seek.txt")
+	require.NoError(t, err)
+	defer writer.Close()
+
+	off, err = writer.Seek(0, io.SeekEnd)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, off)
+}
+
 func TestFileAppend(t *testing.T) {
 	client := getClient(t)
 
@@ -512,3 +911,101 @@ func TestFileWriteAfterIdleTime(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "foobar", string(bytes))
 }
+
+func TestCreateFileUmask(t *testing.T) {
+	client := newClientWithOptions(t, "gohdfs1", func(options *ClientOptions) {
+		options.Umask = 027
+	})
+	defer client.Close()
+
+	baleet(t, "/_test/create/umask.txt")
+	mkdirp(t, "/_test/create")
+
+	writer, err := client.CreateFile("/_test/create/umask.txt", 1, 1048576, 0777)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	fi, err := client.Stat("/_test/create/umask.txt")
+	require.NoError(t, err)
+	assert.EqualValues(t, 0750, fi.Mode().Perm())
+}
+
+func TestFileWriteContextCancel(t *testing.T) {
+	client := getClient(t)
+
+	baleet(t, "/_test/create/cancel.txt")
+	mkdirp(t, "/_test/create")
+	writer, err := client.CreateFile("/_test/create/cancel.txt", 1, 1048576, 0644)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	writer.SetContext(ctx)
+
+	_, err = writer.Write(make([]byte, 1500000))
+	require.NoError(t, err)
+
+	cancel()
+	_, err = writer.Write([]byte("foo"))
+	assert.Equal(t, context.Canceled, err)
+	assert.Equal(t, context.Canceled, writer.Close())
+
+	// The second block should have been abandoned.
+	fi, err := client.Stat("/_test/create/cancel.txt")
+	require.NoError(t, err)
+	assert.EqualValues(t, 1048576, fi.Size())
+}
+
+func TestFileWriterHeartbeatDisabled(t *testing.T) {
+	client := getClient(t)
+	baleet(t, "/_test/create/heartbeat.txt")
+	mkdirp(t, "/_test/create")
+
+	writer, err := client.CreateWithOptions("/_test/create/heartbeat.txt", CreateOptions{HeartbeatInterval: -1})
+	require.NoError(t, err)
+
+	_, err = writer.Write([]byte("foo"))
+	require.NoError(t, err)
+	assert.EqualValues(t, -1, writer.blockWriter.HeartbeatInterval)
+	require.NoError(t, writer.Close())
+
+	bytes, err := client.ReadFile("/_test/create/heartbeat.txt")
+	require.NoError(t, err)
+	assert.Equal(t, "foo", string(bytes))
+}
+
+func TestFileWriterWriteHook(t *testing.T) {
+	client := getClient(t)
+	baleet(t, "/_test/create/hook.txt")
+	mkdirp(t, "/_test/create")
+
+	writer, err := client.Create("/_test/create/hook.txt")
+	require.NoError(t, err)
+
+	var lock sync.Mutex
+	counts := make(map[WriteEventType]int)
+	writer.SetWriteHook(func(ev WriteEvent) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		counts[ev.Type]++
+		assert.NotEmpty(t, ev.Pipeline)
+		assert.NotZero(t, ev.BlockID)
+		if ev.Type == WriteAckReceived {
+			assert.True(t, ev.Latency >= ev.DownstreamLatency)
+		}
+	})
+
+	_, err = writer.Write([]byte("foo"))
+	require.NoError(t, err)
+	require.NoError(t, writer.Flush())
+	require.NoError(t, writer.Close())
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	// One packet for the data, and the empty last packet.
+	assert.Equal(t, 1, counts[WritePipelineBuilt])
+	assert.Equal(t, 2, counts[WritePacketSent])
+	assert.Equal(t, 2, counts[WriteAckReceived])
+	assert.Equal(t, 0, counts[WritePipelineFailed])
+}
@@ -1,16 +1,21 @@
 module github.com/colinmarc/hdfs/v2
 
+go 1.24
+
 require (
-	github.com/davecgh/go-spew v1.1.0 // indirect
 	github.com/golang/protobuf v1.1.0
+	github.com/pborman/getopt v0.0.0-20180729010549-6fdd0a2c7117
+	github.com/stretchr/testify v1.2.2
+	golang.org/x/crypto v0.0.0-20180723164146-c126467f60eb
+	gopkg.in/jcmturner/gokrb5.v5 v5.3.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.0 // indirect
 	github.com/hashicorp/go-uuid v0.0.0-20180228145832-27454136f036 // indirect
 	github.com/jcmturner/gofork v0.0.0-20180107083740-2aebee971930 // indirect
-	github.com/pborman/getopt v0.0.0-20180729010549-6fdd0a2c7117
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	github.com/stretchr/testify v1.2.2
-	golang.org/x/crypto v0.0.0-20180723164146-c126467f60eb // indirect
 	gopkg.in/jcmturner/aescts.v1 v1.0.1 // indirect
 	gopkg.in/jcmturner/dnsutils.v1 v1.0.1 // indirect
-	gopkg.in/jcmturner/gokrb5.v5 v5.3.0
 	gopkg.in/jcmturner/rpc.v0 v0.0.2 // indirect
 )
@@ -0,0 +1,46 @@
+package hdfs
+
+import (
+	hadoop "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_common"
+	"github.com/colinmarc/hdfs/v2/internal/rpc"
+	"github.com/golang/protobuf/proto"
+)
+
+const getUserMappingsProtocol = "org.apache.hadoop.tools.GetUserMappingsProtocol"
+
+// GetGroupsForUser returns the groups the namenode thinks the given user is
+// in, like 'hdfs groups'. Those are the groups used for permission checks,
+// which can differ from the ones on the local machine. If user is empty, the
+// user the client is acting as is used.
+func (c *Client) GetGroupsForUser(user string) ([]string, error) {
+	if user == "" {
+		user = c.User()
+	}
+
+	conn, err := rpc.NewNamenodeConnection(rpc.NamenodeConnectionOptions{
+		Addresses:                    c.options.Addresses,
+		User:                         c.namenode.User,
+		DialFunc:                     newNamenodeDialFunc(c.options),
+		LookupHost:                   newNamenodeLookupHost(c.options),
+		KerberosClient:               c.options.KerberosClient,
+		KerberosServicePrincipleName: c.options.KerberosServicePrincipleName,
+		Token:                        c.options.DelegationToken.proto(),
+		Protocol:                     getUserMappingsProtocol,
+		WireLog:                      c.wireLog,
+		Strict:                       c.options.StrictProtocol,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	req := &hadoop.GetGroupsForUserRequestProto{User: proto.String(user)}
+	resp := &hadoop.GetGroupsForUserResponseProto{}
+
+	err = conn.Execute("getGroupsForUser", req, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.GetGroups(), nil
+}
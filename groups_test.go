@@ -0,0 +1,27 @@
+package hdfs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetGroupsForUser(t *testing.T) {
+	client := getClient(t)
+
+	groups, err := client.GetGroupsForUser("")
+	require.NoError(t, err)
+
+	other, err := client.GetGroupsForUser(client.User())
+	require.NoError(t, err)
+	assert.Equal(t, groups, other)
+}
+
+func TestGetGroupsForNonexistentUser(t *testing.T) {
+	client := getClient(t)
+
+	groups, err := client.GetGroupsForUser("gohdfs-nonexistent")
+	require.NoError(t, err)
+	assert.Empty(t, groups)
+}
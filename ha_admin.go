@@ -0,0 +1,128 @@
+package hdfs
+
+import (
+	hadoop "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_common"
+	"github.com/colinmarc/hdfs/v2/internal/rpc"
+)
+
+// haServiceProtocol is the RPC protocol the namenodes serve for managing
+// failover.
+const haServiceProtocol = "org.apache.hadoop.ha.HAServiceProtocol"
+
+// HARequestSource specifies who is asking for an HA state transition, which
+// the namenode uses to decide whether to allow it.
+type HARequestSource int
+
+const (
+	// HARequestByUser is a transition requested manually. If automatic
+	// failover is enabled, the namenode refuses it.
+	HARequestByUser HARequestSource = iota
+	// HARequestByUserForced is a transition requested manually, which is
+	// allowed even if automatic failover is enabled, like 'hdfs haadmin
+	// -forcemanual'. This can lead to a split brain if the failover
+	// controllers aren't stopped first.
+	HARequestByUserForced
+	// HARequestByZKFC is a transition requested by a failover controller.
+	HARequestByZKFC
+)
+
+// HAServiceStatus describes the HA state of a namenode.
+type HAServiceStatus struct {
+	// State is "INITIALIZING", "ACTIVE" or "STANDBY".
+	State string
+	// ReadyToBecomeActive is true if the namenode is a standby which can be
+	// transitioned to active. If not, NotReadyReason explains why.
+	ReadyToBecomeActive bool
+	NotReadyReason      string
+}
+
+// HAAdminClient makes HA administrative requests to a single namenode, like
+// 'hdfs haadmin'. The transitions require superuser privileges.
+type HAAdminClient struct {
+	address string
+	conn    *rpc.NamenodeConnection
+}
+
+// HAAdmin connects to the namenode at the given address (<host>:<port>), which
+// should be one of the namenodes the client is configured with, or a service
+// RPC address of one. Unlike the client itself, it doesn't fail over to other
+// namenodes. The connection is made with the same user, credentials and
+// options as the client.
+func (c *Client) HAAdmin(address string) (*HAAdminClient, error) {
+	conn, err := rpc.NewNamenodeConnection(rpc.NamenodeConnectionOptions{
+		Addresses:                    []string{address},
+		User:                         c.namenode.User,
+		DialFunc:                     newNamenodeDialFunc(c.options),
+		KerberosClient:               c.options.KerberosClient,
+		KerberosServicePrincipleName: c.options.KerberosServicePrincipleName,
+		Token:                        c.options.DelegationToken.proto(),
+		Protocol:                     haServiceProtocol,
+		WireLog:                      c.wireLog,
+		Strict:                       c.options.StrictProtocol,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &HAAdminClient{address: address, conn: conn}, nil
+}
+
+// Address returns the address the client is connected to.
+func (h *HAAdminClient) Address() string {
+	return h.address
+}
+
+// GetServiceStatus returns the HA state of the namenode.
+func (h *HAAdminClient) GetServiceStatus() (HAServiceStatus, error) {
+	req := &hadoop.GetServiceStatusRequestProto{}
+	resp := &hadoop.GetServiceStatusResponseProto{}
+
+	err := h.conn.Execute("getServiceStatus", req, resp)
+	if err != nil {
+		return HAServiceStatus{}, err
+	}
+
+	return HAServiceStatus{
+		State:               resp.GetState().String(),
+		ReadyToBecomeActive: resp.GetReadyToBecomeActive(),
+		NotReadyReason:      resp.GetNotReadyReason(),
+	}, nil
+}
+
+// MonitorHealth returns an error if the namenode is unhealthy, for example
+// because it's low on disk space for its metadata.
+func (h *HAAdminClient) MonitorHealth() error {
+	req := &hadoop.MonitorHealthRequestProto{}
+	resp := &hadoop.MonitorHealthResponseProto{}
+
+	return h.conn.Execute("monitorHealth", req, resp)
+}
+
+// TransitionToActive makes the namenode the active one. This doesn't fence
+// the current active namenode or transition it to standby; that has to be
+// done first, to avoid having two active namenodes.
+func (h *HAAdminClient) TransitionToActive(source HARequestSource) error {
+	req := &hadoop.TransitionToActiveRequestProto{ReqInfo: newHAStateChangeRequestInfo(source)}
+	resp := &hadoop.TransitionToActiveResponseProto{}
+
+	return h.conn.Execute("transitionToActive", req, resp)
+}
+
+// TransitionToStandby makes the namenode a standby.
+func (h *HAAdminClient) TransitionToStandby(source HARequestSource) error {
+	req := &hadoop.TransitionToStandbyRequestProto{ReqInfo: newHAStateChangeRequestInfo(source)}
+	resp := &hadoop.TransitionToStandbyResponseProto{}
+
+	return h.conn.Execute("transitionToStandby", req, resp)
+}
+
+// Close closes the connection to the namenode.
+func (h *HAAdminClient) Close() error {
+	return h.conn.Close()
+}
+
+func newHAStateChangeRequestInfo(source HARequestSource) *hadoop.HAStateChangeRequestInfoProto {
+	return &hadoop.HAStateChangeRequestInfoProto{
+		ReqSource: hadoop.HARequestSource(source).Enum(),
+	}
+}
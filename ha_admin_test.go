@@ -0,0 +1,40 @@
+package hdfs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getHAAdmin(t *testing.T) *HAAdminClient {
+	client := getClientForSuperUser(t)
+
+	admin, err := client.HAAdmin(client.options.Addresses[0])
+	require.NoError(t, err)
+
+	return admin
+}
+
+func TestHAAdminGetServiceStatus(t *testing.T) {
+	admin := getHAAdmin(t)
+	defer admin.Close()
+
+	status, err := admin.GetServiceStatus()
+	require.NoError(t, err)
+	assert.Contains(t, []string{"ACTIVE", "STANDBY"}, status.State)
+}
+
+func TestHAAdminMonitorHealth(t *testing.T) {
+	admin := getHAAdmin(t)
+	defer admin.Close()
+
+	err := admin.MonitorHealth()
+	require.NoError(t, err)
+}
+
+func TestHAStateChangeRequestInfo(t *testing.T) {
+	assert.Equal(t, "REQUEST_BY_USER", newHAStateChangeRequestInfo(HARequestByUser).GetReqSource().String())
+	assert.Equal(t, "REQUEST_BY_USER_FORCED", newHAStateChangeRequestInfo(HARequestByUserForced).GetReqSource().String())
+	assert.Equal(t, "REQUEST_BY_ZKFC", newHAStateChangeRequestInfo(HARequestByZKFC).GetReqSource().String())
+}
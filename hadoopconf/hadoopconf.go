@@ -6,6 +6,7 @@ import (
 	"encoding/xml"
 	"fmt"
 	"io/ioutil"
+	"net"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -24,6 +25,10 @@ type propertyList struct {
 
 var confFiles = []string{"core-site.xml", "hdfs-site.xml", "mapred-site.xml"}
 
+// defaultNamenodePort is the port namenodes listen on if an address doesn't
+// specify one, like in "hdfs://namenode/".
+const defaultNamenodePort = "8020"
+
 // HadoopConf represents a map of all the key value configutation
 // pairs found in a user's hadoop configuration files.
 type HadoopConf map[string]string
@@ -130,3 +135,97 @@ func (conf HadoopConf) Namenodes() []string {
 	sort.Strings(keys)
 	return keys
 }
+
+// NameserviceNamenodes returns the namenode addresses for the given logical
+// nameservice, as configured by dfs.ha.namenodes.<nameservice> and
+// dfs.namenode.rpc-address.<nameservice>.<namenode>. The addresses are
+// returned in the order the namenodes are listed.
+//
+// If the nameservice isn't configured, NameserviceNamenodes returns a nil
+// slice.
+func (conf HadoopConf) NameserviceNamenodes(nameservice string) []string {
+	var nns []string
+	for _, nn := range strings.Split(conf["dfs.ha.namenodes."+nameservice], ",") {
+		nn = strings.TrimSpace(nn)
+		if nn == "" {
+			continue
+		}
+
+		address := conf["dfs.namenode.rpc-address."+nameservice+"."+nn]
+		if address != "" {
+			nns = append(nns, address)
+		}
+	}
+
+	return nns
+}
+
+// DefaultFS returns the default filesystem URL, from fs.defaultFS (or the
+// deprecated fs.default.name), for example "hdfs://nameservice1".
+func (conf HadoopConf) DefaultFS() string {
+	if fs := conf["fs.defaultFS"]; fs != "" {
+		return fs
+	}
+
+	return conf["fs.default.name"]
+}
+
+// Nameservices returns the logical nameservices listed in dfs.nameservices.
+func (conf HadoopConf) Nameservices() []string {
+	var nameservices []string
+	for _, ns := range strings.Split(conf["dfs.nameservices"], ",") {
+		if ns = strings.TrimSpace(ns); ns != "" {
+			nameservices = append(nameservices, ns)
+		}
+	}
+
+	return nameservices
+}
+
+// AddressesFor returns the namenode addresses for a cluster, the way the Java
+// client resolves the authority of an hdfs:// URL. The cluster can be a URL
+// like "hdfs://nameservice1/user/foo", or just the part after the scheme.
+//
+// A logical nameservice is resolved to its namenodes, as configured by
+// dfs.ha.namenodes.<nameservice>, or for a nameservice without HA,
+// dfs.namenode.rpc-address.<nameservice>. Anything else is taken to be a
+// comma-separated list of namenode addresses, with the default port, 8020,
+// added to any that don't have one. If cluster is empty, DefaultFS is used,
+// and if that's unset too, AddressesFor returns Namenodes.
+func (conf HadoopConf) AddressesFor(cluster string) []string {
+	if cluster == "" {
+		cluster = conf.DefaultFS()
+		if cluster == "" {
+			return conf.Namenodes()
+		}
+	}
+
+	if strings.Contains(cluster, "://") {
+		u, err := url.Parse(cluster)
+		if err != nil {
+			return nil
+		}
+
+		cluster = u.Host
+	}
+
+	if nns := conf.NameserviceNamenodes(cluster); nns != nil {
+		return nns
+	} else if address := conf["dfs.namenode.rpc-address."+cluster]; address != "" {
+		return []string{address}
+	}
+
+	var addresses []string
+	for _, address := range strings.Split(cluster, ",") {
+		address = strings.TrimSpace(address)
+		if address == "" {
+			continue
+		} else if _, _, err := net.SplitHostPort(address); err != nil {
+			address = net.JoinHostPort(address, defaultNamenodePort)
+		}
+
+		addresses = append(addresses, address)
+	}
+
+	return addresses
+}
@@ -35,3 +35,48 @@ func TestConfFallback(t *testing.T) {
 	os.Setenv("HADOOP_HOME", oldHome)
 	os.Setenv("HADOOP_CONF_DIR", oldConfDir)
 }
+
+func TestNameserviceNamenodes(t *testing.T) {
+	conf := HadoopConf{
+		"dfs.ha.namenodes.ns1":               "nn2, nn1",
+		"dfs.namenode.rpc-address.ns1.nn1":   "namenode1:8020",
+		"dfs.namenode.rpc-address.ns1.nn2":   "namenode2:8020",
+		"dfs.namenode.rpc-address.other.nn1": "namenode3:8020",
+	}
+
+	assert.EqualValues(t, []string{"namenode2:8020", "namenode1:8020"}, conf.NameserviceNamenodes("ns1"))
+	assert.Nil(t, conf.NameserviceNamenodes("other"))
+	assert.Nil(t, conf.NameserviceNamenodes("missing"))
+}
+
+func TestAddressesFor(t *testing.T) {
+	conf := HadoopConf{
+		"fs.defaultFS":                       "hdfs://ns1",
+		"dfs.nameservices":                   "ns1, ns2",
+		"dfs.ha.namenodes.ns1":               "nn2,nn1",
+		"dfs.namenode.rpc-address.ns1.nn1":   "namenode1:8020",
+		"dfs.namenode.rpc-address.ns1.nn2":   "namenode2:8020",
+		"dfs.namenode.rpc-address.ns2":       "namenode3:8020",
+		"dfs.namenode.rpc-address.other.nn1": "namenode4:8020",
+	}
+
+	assert.Equal(t, "hdfs://ns1", conf.DefaultFS())
+	assert.Equal(t, []string{"ns1", "ns2"}, conf.Nameservices())
+
+	for cluster, expected := range map[string][]string{
+		"":                          {"namenode2:8020", "namenode1:8020"},
+		"ns1":                       {"namenode2:8020", "namenode1:8020"},
+		"hdfs://ns1/user/foo":       {"namenode2:8020", "namenode1:8020"},
+		"hdfs://ns2":                {"namenode3:8020"},
+		"namenode5":                 {"namenode5:8020"},
+		"hdfs://namenode5:9000/foo": {"namenode5:9000"},
+		"nn1:9000,nn2":              {"nn1:9000", "nn2:8020"},
+	} {
+		assert.Equal(t, expected, conf.AddressesFor(cluster), cluster)
+	}
+
+	// Without fs.defaultFS, every configured namenode is returned.
+	conf = HadoopConf{"dfs.namenode.rpc-address.ns1": "namenode1:8020"}
+	assert.Equal(t, []string{"namenode1:8020"}, conf.AddressesFor(""))
+	assert.Nil(t, HadoopConf(nil).AddressesFor(""))
+}
@@ -0,0 +1,85 @@
+// Package hdfstest provides utilities for testing code that uses the hdfs
+// package. Its fault injector can delay, drop, corrupt, or fail specific RPC
+// responses, packets, and acks, so that retry, failover, and recovery code can
+// be exercised deterministically against a real cluster:
+//
+//	inj := hdfstest.NewFaultInjector(hdfstest.FaultRule{
+//		Target: hdfstest.NamenodeResponse,
+//		Method: "getFileInfo",
+//		Times:  1,
+//		Action: hdfstest.Drop,
+//	})
+//
+//	options := hdfs.ClientOptions{...}
+//	hdfstest.InjectFaults(&options, inj)
+//	client, err := hdfs.NewClient(options)
+package hdfstest
+
+import (
+	"github.com/colinmarc/hdfs/v2"
+	"github.com/colinmarc/hdfs/v2/internal/faultinject"
+)
+
+// A FaultInjector injects faults into the connections a client makes,
+// according to a set of rules. For each message, the rules are checked in the
+// order they were added, and the first one that matches is applied. Heartbeat
+// packets and their acks are never matched.
+type FaultInjector = faultinject.Injector
+
+// A FaultRule describes which messages to inject a fault into, and what the
+// fault is.
+type FaultRule = faultinject.Rule
+
+// A FaultMessage is a single message on a connection, as it's passed to
+// FaultRule.Match.
+type FaultMessage = faultinject.Message
+
+// FaultTarget is the kind of message a FaultRule applies to.
+type FaultTarget = faultinject.Target
+
+// FaultAction is the fault a FaultRule injects.
+type FaultAction = faultinject.Action
+
+const (
+	// NamenodeResponse is an RPC response from the namenode.
+	NamenodeResponse = faultinject.NamenodeResponse
+	// DatanodeWritePacket is a packet of block data sent to a datanode by a
+	// writer.
+	DatanodeWritePacket = faultinject.DatanodeWritePacket
+	// DatanodeAck is an ack for a written packet, sent back by a datanode.
+	DatanodeAck = faultinject.DatanodeAck
+	// DatanodeReadPacket is a packet of block data sent by a datanode to a
+	// reader.
+	DatanodeReadPacket = faultinject.DatanodeReadPacket
+)
+
+const (
+	// Delay holds the message back for FaultRule.Delay before delivering it.
+	// Read deadlines still apply, so a long enough delay causes a timeout.
+	Delay = faultinject.Delay
+	// Drop discards the message, as if it was never sent.
+	Drop = faultinject.Drop
+	// Corrupt inverts the bits of the last byte of the message, so that the
+	// checksum of a data packet no longer matches.
+	Corrupt = faultinject.Corrupt
+	// Disconnect closes the connection instead of delivering the message.
+	Disconnect = faultinject.Disconnect
+	// Fail replaces a NamenodeResponse with an error, as if the namenode had
+	// thrown FaultRule.Exception.
+	Fail = faultinject.Fail
+)
+
+// NewFaultInjector returns a FaultInjector with the given rules. More can be
+// added later with Add, or all of them removed with Clear.
+func NewFaultInjector(rules ...FaultRule) *FaultInjector {
+	return faultinject.New(rules...)
+}
+
+// InjectFaults sets up options so that a client created with them has faults
+// injected into its connections by inj. It wraps the NamenodeDialFunc and
+// DatanodeDialFunc already set, if any, so it should be called after those
+// are.
+func InjectFaults(options *hdfs.ClientOptions, inj *FaultInjector) {
+	options.NamenodeDialFunc = inj.WrapNamenodeDial(options.NamenodeDialFunc)
+	options.DatanodeDialFunc = inj.WrapDatanodeDial(options.DatanodeDialFunc)
+}
@@ -0,0 +1,118 @@
+package hdfstest
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/colinmarc/hdfs/v2"
+	"github.com/colinmarc/hdfs/v2/hadoopconf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getClient(t *testing.T, inj *FaultInjector) *hdfs.Client {
+	conf, err := hadoopconf.LoadFromEnvironment()
+	if err != nil || conf == nil {
+		t.Fatal("Couldn't load ambient config", err)
+	}
+
+	options := hdfs.ClientOptionsFromConf(conf)
+	if options.Addresses == nil {
+		t.Fatal("Missing namenode addresses in ambient config")
+	}
+
+	// With wire encryption, the messages can't be told apart.
+	if options.KerberosClient != nil {
+		t.Skip("Fault injection doesn't work with kerberos")
+	}
+
+	options.User = "gohdfs1"
+	options.NamenodeRequestTimeout = time.Second
+	options.NamenodeRetries = 1
+	options.NamenodeRetryInterval = 10 * time.Millisecond
+	InjectFaults(&options, inj)
+
+	client, err := hdfs.NewClient(options)
+	require.NoError(t, err)
+
+	return client
+}
+
+func TestDroppedNamenodeResponseIsRetried(t *testing.T) {
+	inj := NewFaultInjector(FaultRule{
+		Target: NamenodeResponse,
+		Method: "getFileInfo",
+		Times:  1,
+		Action: Drop,
+	})
+
+	client := getClient(t, inj)
+	defer client.Close()
+
+	fi, err := client.Stat("/_test/foo.txt")
+	require.NoError(t, err)
+	assert.EqualValues(t, 4, fi.Size())
+	assert.Equal(t, 1, inj.Injected())
+}
+
+func TestRetriableExceptionIsRetried(t *testing.T) {
+	inj := NewFaultInjector(FaultRule{
+		Target:    NamenodeResponse,
+		Method:    "getFileInfo",
+		Times:     1,
+		Action:    Fail,
+		Exception: "org.apache.hadoop.ipc.RetriableException",
+	})
+
+	client := getClient(t, inj)
+	defer client.Close()
+
+	fi, err := client.Stat("/_test/foo.txt")
+	require.NoError(t, err)
+	assert.EqualValues(t, 4, fi.Size())
+	assert.Equal(t, 1, inj.Injected())
+
+	// Other exceptions are returned as is.
+	inj.Add(FaultRule{
+		Target:    NamenodeResponse,
+		Method:    "getFileInfo",
+		Times:     1,
+		Action:    Fail,
+		Exception: "java.io.FileNotFoundException",
+	})
+
+	_, err = client.Stat("/_test/foo.txt")
+	assert.True(t, os.IsNotExist(err), "%v", err)
+}
+
+func TestDelayedAcks(t *testing.T) {
+	inj := NewFaultInjector(FaultRule{
+		Target: DatanodeAck,
+		Action: Delay,
+		Delay:  100 * time.Millisecond,
+	})
+
+	client := getClient(t, inj)
+	defer client.Close()
+	client.Remove("/_test/hdfstest/delayed_acks.txt")
+	require.NoError(t, client.MkdirAll("/_test/hdfstest", 0777))
+
+	w, err := client.Create("/_test/hdfstest/delayed_acks.txt")
+	require.NoError(t, err)
+
+	_, err = w.Write([]byte("foobar"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	assert.True(t, inj.Injected() > 0)
+
+	inj.Clear()
+	r, err := client.Open("/_test/hdfstest/delayed_acks.txt")
+	require.NoError(t, err)
+	defer r.Close()
+
+	b, err := ioutil.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, "foobar", string(b))
+}
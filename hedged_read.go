@@ -0,0 +1,253 @@
+package hdfs
+
+import (
+	"context"
+	"io"
+	"sync/atomic"
+	"time"
+
+	hdfs "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_hdfs"
+	"github.com/colinmarc/hdfs/v2/internal/rpc"
+)
+
+// HedgedReadStats counts the hedged reads made by a client. See
+// ClientOptions.HedgedReadThreshold.
+type HedgedReadStats struct {
+	// Hedged is the number of extra reads started because the first replica
+	// tried was slow to respond.
+	Hedged int64
+	// Wins is how many of those finished before the read they were hedging.
+	Wins int64
+}
+
+// hedgedReads starts extra reads of the same range from other replicas, once
+// a read has taken longer than threshold. slots limits how many can be in
+// flight at once across the client; if it's nil, there's no limit.
+type hedgedReads struct {
+	hedged int64
+	wins   int64
+
+	threshold time.Duration
+	slots     chan struct{}
+}
+
+func newHedgedReads(options ClientOptions) *hedgedReads {
+	if options.HedgedReadThreshold <= 0 {
+		return nil
+	}
+
+	h := &hedgedReads{threshold: options.HedgedReadThreshold}
+	if options.HedgedReadMaxRequests > 0 {
+		h.slots = make(chan struct{}, options.HedgedReadMaxRequests)
+	}
+
+	return h
+}
+
+// HedgedReadStats returns the number of hedged reads the client has made. It
+// returns the zero value if ClientOptions.HedgedReadThreshold isn't set.
+func (c *Client) HedgedReadStats() HedgedReadStats {
+	if c.hedged == nil {
+		return HedgedReadStats{}
+	}
+
+	return HedgedReadStats{
+		Hedged: atomic.LoadInt64(&c.hedged.hedged),
+		Wins:   atomic.LoadInt64(&c.hedged.wins),
+	}
+}
+
+func (h *hedgedReads) acquire() bool {
+	if h.slots == nil {
+		return true
+	}
+
+	select {
+	case h.slots <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+func (h *hedgedReads) release() {
+	if h.slots != nil {
+		<-h.slots
+	}
+}
+
+type hedgedAttempt struct {
+	index int
+	err   error
+}
+
+// read fills b by calling fn, first with attempt 0 reading into b itself.
+// Each time threshold passes without any attempt finishing, another is
+// started with its own buffer, up to attempts in total, as long as there's a
+// slot free and none has failed yet. The first to fill its buffer wins, and
+// the contexts of the others are cancelled. It returns the index of the
+// winning attempt, or the error from the last one to fail, once they all
+// have.
+//
+// fn must return promptly once its context is done. If a hedged attempt wins,
+// attempt 0 is waited for before its result is copied into b, since they
+// share the buffer. Any other attempt that still fills its buffer
+// successfully is passed to discard, if it's set, so that it can be cleaned
+// up; that may happen after read returns.
+func (h *hedgedReads) read(ctx context.Context, b []byte, attempts int,
+	fn func(ctx context.Context, attempt int, buf []byte) error, discard func(attempt int)) (int, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan hedgedAttempt, attempts)
+	bufs := make([][]byte, attempts)
+	start := func(i int) {
+		buf := b
+		if i > 0 {
+			buf = make([]byte, len(b))
+		}
+
+		bufs[i] = buf
+		go func() {
+			err := fn(ctx, i, buf)
+			if i > 0 {
+				h.release()
+			}
+
+			results <- hedgedAttempt{index: i, err: err}
+		}()
+	}
+
+	lost := func(res hedgedAttempt) {
+		if res.err == nil && discard != nil {
+			discard(res.index)
+		}
+	}
+
+	start(0)
+	started, running := 1, 1
+	firstDone := false
+
+	timer := time.NewTimer(h.threshold)
+	defer timer.Stop()
+
+	var err error
+	for running > 0 {
+		select {
+		case res := <-results:
+			running--
+			if res.index == 0 {
+				firstDone = true
+			}
+
+			if res.err != nil {
+				err = res.err
+				continue
+			}
+
+			cancel()
+			if res.index > 0 {
+				atomic.AddInt64(&h.wins, 1)
+				for !firstDone {
+					other := <-results
+					running--
+					if other.index == 0 {
+						firstDone = true
+					}
+
+					lost(other)
+				}
+
+				copy(b, bufs[res.index])
+			}
+
+			go func(running int) {
+				for ; running > 0; running-- {
+					lost(<-results)
+				}
+			}(running)
+
+			return res.index, nil
+		case <-timer.C:
+			// Each attempt fails over to the other replicas by itself, so
+			// there's no point in starting another once one has failed.
+			if err != nil {
+				continue
+			}
+
+			if started < attempts && h.acquire() {
+				start(started)
+				started++
+				running++
+				atomic.AddInt64(&h.hedged, 1)
+			}
+
+			timer.Reset(h.threshold)
+		}
+	}
+
+	return -1, err
+}
+
+// readHedged reads len(b) bytes from the block that br is positioned in,
+// hedging the read against the block's other replicas. Each attempt starts
+// from a different replica, and fails over to the rest as usual. It returns
+// the BlockReader that won, which the caller should close; the others are
+// closed once they've been cancelled.
+func (f *FileReader) readHedged(br *rpc.BlockReader, b []byte) (int, *rpc.BlockReader, error) {
+	locs := br.Block.GetLocs()
+	readers := make([]*rpc.BlockReader, len(locs))
+	readers[0] = br
+	for i := 1; i < len(readers); i++ {
+		copied := *br
+		copied.Block = rotateLocs(br.Block, i)
+		copied.ShortCircuit = nil
+		readers[i] = &copied
+	}
+
+	fn := func(ctx context.Context, attempt int, buf []byte) error {
+		r := readers[attempt]
+		tc := newTransferContext(ctx)
+		defer tc.close()
+		r.DialFunc = tc.wrap(r.DialFunc)
+		r.ConnPool = nil
+
+		_, err := io.ReadFull(r, buf)
+		if ctx.Err() != nil && err == nil {
+			err = ctx.Err()
+		}
+
+		if err != nil && attempt > 0 {
+			r.Close()
+		}
+
+		return err
+	}
+
+	// Attempt 0 is br, which is closed below if it loses.
+	discard := func(attempt int) {
+		if attempt > 0 {
+			readers[attempt].Close()
+		}
+	}
+
+	winner, err := f.client.hedged.read(f.tc.boundContext(), b, len(locs), fn, discard)
+	if err != nil {
+		return 0, br, err
+	}
+
+	if winner > 0 {
+		br.Close()
+	}
+
+	return len(b), readers[winner], nil
+}
+
+// rotateLocs returns a copy of block, with its replicas rotated by n, so that
+// a BlockReader for it starts with a different one.
+func rotateLocs(block *hdfs.LocatedBlockProto, n int) *hdfs.LocatedBlockProto {
+	locs := block.GetLocs()
+	rotated := *block
+	rotated.Locs = append(append([]*hdfs.DatanodeInfoProto(nil), locs[n%len(locs):]...), locs[:n%len(locs)]...)
+	return &rotated
+}
@@ -0,0 +1,194 @@
+package hdfs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/colinmarc/hdfs/v2/hadoopconf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// slowAttempts returns a function for hedgedReads.read which fills the buffer
+// with the attempt number, after the given delay (or never, if it's
+// negative).
+func slowAttempts(delays ...time.Duration) func(ctx context.Context, attempt int, buf []byte) error {
+	return func(ctx context.Context, attempt int, buf []byte) error {
+		delay := delays[attempt]
+		if delay < 0 {
+			<-ctx.Done()
+			return ctx.Err()
+		}
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		for i := range buf {
+			buf[i] = byte('0' + attempt)
+		}
+
+		return nil
+	}
+}
+
+func TestHedgedRead(t *testing.T) {
+	h := newHedgedReads(ClientOptions{HedgedReadThreshold: 10 * time.Millisecond})
+	b := make([]byte, 3)
+
+	winner, err := h.read(context.Background(), b, 3, slowAttempts(0, 0, 0), nil)
+	require.NoError(t, err)
+	assert.Equal(t, 0, winner)
+	assert.Equal(t, "000", string(b))
+	assert.EqualValues(t, 0, h.hedged)
+
+	// The first replica hangs, so the second is tried, and then the third.
+	winner, err = h.read(context.Background(), b, 3, slowAttempts(-1, time.Second, 0), nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, winner)
+	assert.Equal(t, "222", string(b))
+	assert.EqualValues(t, 2, h.hedged)
+	assert.EqualValues(t, 1, h.wins)
+}
+
+func TestHedgedReadFailure(t *testing.T) {
+	h := newHedgedReads(ClientOptions{HedgedReadThreshold: 10 * time.Millisecond})
+	b := make([]byte, 3)
+
+	failure := errors.New("replica down")
+	fn := func(ctx context.Context, attempt int, buf []byte) error {
+		if attempt == 0 {
+			time.Sleep(20 * time.Millisecond)
+		}
+
+		return failure
+	}
+
+	_, err := h.read(context.Background(), b, 2, fn, nil)
+	assert.Equal(t, failure, err)
+
+	// Once the first attempt has failed, the hedge can still succeed.
+	fn = func(ctx context.Context, attempt int, buf []byte) error {
+		if attempt == 0 {
+			time.Sleep(20 * time.Millisecond)
+			return failure
+		}
+
+		return slowAttempts(0, 40*time.Millisecond)(ctx, attempt, buf)
+	}
+
+	winner, err := h.read(context.Background(), b, 2, fn, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, winner)
+	assert.Equal(t, "111", string(b))
+}
+
+func TestHedgedReadNoAttemptAfterFailure(t *testing.T) {
+	h := newHedgedReads(ClientOptions{HedgedReadThreshold: 10 * time.Millisecond})
+	b := make([]byte, 3)
+
+	// The hedge fails straight away, which means the other replicas have
+	// already been tried, so no more are started while waiting for the first.
+	failure := errors.New("replica down")
+	fn := func(ctx context.Context, attempt int, buf []byte) error {
+		switch attempt {
+		case 0:
+			return slowAttempts(50*time.Millisecond)(ctx, attempt, buf)
+		case 1:
+			return failure
+		default:
+			t.Error("started another attempt after a failure")
+			return failure
+		}
+	}
+
+	winner, err := h.read(context.Background(), b, 3, fn, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 0, winner)
+	assert.EqualValues(t, 1, h.hedged)
+}
+
+func TestHedgedReadDiscard(t *testing.T) {
+	h := newHedgedReads(ClientOptions{HedgedReadThreshold: 10 * time.Millisecond})
+	b := make([]byte, 3)
+
+	// The first two attempts ignore their contexts, and finish successfully
+	// after the third has won.
+	unblock := make(chan struct{})
+	fn := func(ctx context.Context, attempt int, buf []byte) error {
+		if attempt < 2 {
+			<-unblock
+		} else {
+			close(unblock)
+		}
+
+		for i := range buf {
+			buf[i] = byte('0' + attempt)
+		}
+
+		return nil
+	}
+
+	discarded := make(chan int, 3)
+	discard := func(attempt int) { discarded <- attempt }
+
+	winner, err := h.read(context.Background(), b, 3, fn, discard)
+	require.NoError(t, err)
+	assert.Equal(t, 2, winner)
+	assert.Equal(t, "222", string(b))
+
+	var losers []int
+	for len(losers) < 2 {
+		select {
+		case attempt := <-discarded:
+			losers = append(losers, attempt)
+		case <-time.After(time.Second):
+			t.Fatal("losing attempts weren't discarded:", losers)
+		}
+	}
+
+	assert.ElementsMatch(t, []int{0, 1}, losers)
+}
+
+func TestHedgedReadMaxRequests(t *testing.T) {
+	h := newHedgedReads(ClientOptions{
+		HedgedReadThreshold:   20 * time.Millisecond,
+		HedgedReadMaxRequests: 1,
+	})
+
+	// With the only slot taken, the read just waits for the first replica.
+	require.True(t, h.acquire())
+	b := make([]byte, 3)
+	winner, err := h.read(context.Background(), b, 3, slowAttempts(60*time.Millisecond, 0, 0), nil)
+	require.NoError(t, err)
+	assert.Equal(t, 0, winner)
+	assert.EqualValues(t, 0, h.hedged)
+
+	// Otherwise, only one hedge can be in flight at a time.
+	h.release()
+	winner, err = h.read(context.Background(), b, 3, slowAttempts(-1, 5*time.Millisecond, 0), nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, winner)
+	assert.Equal(t, "111", string(b))
+	assert.EqualValues(t, 1, h.hedged)
+}
+
+func TestClientOptionsFromConfHedgedReads(t *testing.T) {
+	options := ClientOptionsFromConf(hadoopconf.HadoopConf{"dfs.client.hedged.read.threshold.millis": "100"})
+	assert.Equal(t, time.Duration(0), options.HedgedReadThreshold)
+	assert.Nil(t, newHedgedReads(options))
+
+	options = ClientOptionsFromConf(hadoopconf.HadoopConf{"dfs.client.hedged.read.threadpool.size": "4"})
+	assert.Equal(t, 500*time.Millisecond, options.HedgedReadThreshold)
+	assert.Equal(t, 4, options.HedgedReadMaxRequests)
+
+	options = ClientOptionsFromConf(hadoopconf.HadoopConf{
+		"dfs.client.hedged.read.threadpool.size":  "4",
+		"dfs.client.hedged.read.threshold.millis": "100",
+	})
+	assert.Equal(t, 100*time.Millisecond, options.HedgedReadThreshold)
+}
@@ -0,0 +1,525 @@
+package faultinject
+
+import (
+	"encoding/binary"
+	"io"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	hadoop "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_common"
+	hdfs "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_hdfs"
+	"github.com/golang/protobuf/proto"
+)
+
+const (
+	writeBlockOp   = 0x50
+	readBlockOp    = 0x51
+	copyBlockOp    = 0x54
+	heartbeatSeqno = -1
+)
+
+// frameKind is the way a stream of messages is framed on the wire.
+type frameKind int
+
+const (
+	// rawFrames means the stream isn't understood, and is passed through as-is.
+	rawFrames frameKind = iota
+	// handshakeFrame is the 7-byte connection header sent to the namenode.
+	handshakeFrame
+	// rpcFrames are RPC requests and responses, each prefixed with a uint32
+	// length.
+	rpcFrames
+	// opRequestFrame is a data transfer request, with a 3-byte header and a
+	// varint-prefixed message, and opResponseFrame is the response to it.
+	opRequestFrame
+	opResponseFrame
+	// varintFrames are varint-prefixed messages, like pipeline acks.
+	varintFrames
+	// packetFrames are packets of block data.
+	packetFrames
+)
+
+// frameLength returns the length of the frame at the start of buf, and whether
+// buf contains all of it yet.
+func frameLength(kind frameKind, buf []byte) (int, bool) {
+	switch kind {
+	case handshakeFrame:
+		return 7, len(buf) >= 7
+	case rpcFrames:
+		if len(buf) < 4 {
+			return 0, false
+		}
+
+		n := 4 + int(binary.BigEndian.Uint32(buf))
+		return n, len(buf) >= n
+	case opRequestFrame:
+		if len(buf) < 3 {
+			return 0, false
+		}
+
+		n, ok := varintFrameLength(buf[3:])
+		return 3 + n, ok
+	case opResponseFrame, varintFrames:
+		return varintFrameLength(buf)
+	case packetFrames:
+		// The length in the packet includes itself, but not the header.
+		if len(buf) < 6 {
+			return 0, false
+		}
+
+		n := 6 + int(binary.BigEndian.Uint16(buf[4:])) + int(binary.BigEndian.Uint32(buf)) - 4
+		return n, len(buf) >= n
+	default:
+		return len(buf), len(buf) > 0
+	}
+}
+
+func varintFrameLength(buf []byte) (int, bool) {
+	length, n := binary.Uvarint(buf)
+	if n == 0 {
+		return 0, false
+	} else if n < 0 {
+		// Malformed, so just pass along what we have.
+		return len(buf), true
+	}
+
+	total := n + int(length)
+	return total, len(buf) >= total
+}
+
+// conn wraps a connection to the namenode or a datanode, splitting the data
+// sent each way into messages, and injecting faults into them.
+type conn struct {
+	net.Conn
+	inj      *Injector
+	addr     string
+	namenode bool
+
+	lock         sync.Mutex
+	op           byte
+	nextOp       bool
+	methods      map[uint32]string
+	readDeadline time.Time
+	closeCh      chan struct{}
+	closeOnce    sync.Once
+
+	wlock sync.Mutex
+	wkind frameKind
+	wbuf  []byte
+
+	rlock    sync.Mutex
+	rkind    frameKind
+	rbuf     []byte
+	rout     []byte
+	rdelayed []byte
+	rdue     time.Time
+}
+
+func newConn(c net.Conn, inj *Injector, addr string, namenode bool) *conn {
+	fc := &conn{
+		Conn:     c,
+		inj:      inj,
+		addr:     addr,
+		namenode: namenode,
+		methods:  make(map[uint32]string),
+		closeCh:  make(chan struct{}),
+		wkind:    opRequestFrame,
+		rkind:    opResponseFrame,
+	}
+
+	if namenode {
+		fc.wkind = handshakeFrame
+		fc.rkind = rpcFrames
+	}
+
+	return fc
+}
+
+func (c *conn) Write(b []byte) (int, error) {
+	c.wlock.Lock()
+	defer c.wlock.Unlock()
+
+	c.wbuf = append(c.wbuf, b...)
+	for {
+		n, ok := frameLength(c.wkind, c.wbuf)
+		if !ok {
+			break
+		}
+
+		frame := c.wbuf[:n:n]
+		c.wbuf = c.wbuf[n:]
+		kind := c.wkind
+		c.advanceWrite(frame)
+
+		msg, ok := c.classifyWrite(kind, frame)
+		err := c.send(frame, msg, ok)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if len(c.wbuf) == 0 {
+		c.wbuf = nil
+	}
+
+	return len(b), nil
+}
+
+// advanceWrite works out how the next frame written is framed.
+func (c *conn) advanceWrite(frame []byte) {
+	switch c.wkind {
+	case handshakeFrame:
+		c.wkind = rpcFrames
+	case opRequestFrame:
+		c.lock.Lock()
+		c.op = frame[2]
+		c.lock.Unlock()
+
+		c.wkind = rawFrames
+		switch c.op {
+		case writeBlockOp:
+			c.wkind = packetFrames
+		case readBlockOp:
+			// After reading the whole block, the client says whether the
+			// checksums matched, and may then reuse the connection for
+			// another block.
+			c.wkind = varintFrames
+		}
+	case varintFrames:
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if c.op == readBlockOp {
+			c.wkind = opRequestFrame
+			c.nextOp = true
+		}
+	}
+}
+
+// send writes a frame to the connection, after applying any matching rule.
+func (c *conn) send(frame []byte, msg Message, ok bool) error {
+	var rule Rule
+	if ok {
+		rule, ok = c.inj.match(msg)
+	}
+
+	if ok {
+		switch rule.Action {
+		case Drop:
+			return nil
+		case Corrupt:
+			frame = corrupt(frame)
+		case Disconnect:
+			c.Close()
+			return net.ErrClosed
+		case Delay:
+			err := c.wait(time.Now().Add(rule.Delay), time.Time{})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	_, err := c.Conn.Write(frame)
+	return err
+}
+
+func (c *conn) Read(b []byte) (int, error) {
+	c.rlock.Lock()
+	defer c.rlock.Unlock()
+
+	for len(c.rout) == 0 {
+		if c.rdelayed == nil {
+			kind, frame, err := c.readFrame()
+			if err != nil {
+				return 0, err
+			}
+
+			msg, ok := c.classifyRead(kind, frame)
+			var rule Rule
+			if ok {
+				rule, ok = c.inj.match(msg)
+			}
+
+			if !ok {
+				c.rout = frame
+				break
+			}
+
+			switch rule.Action {
+			case Drop:
+				continue
+			case Corrupt:
+				c.rout = corrupt(frame)
+				continue
+			case Fail:
+				c.rout = fail(frame, rule.Exception)
+				continue
+			case Disconnect:
+				c.Close()
+				return 0, net.ErrClosed
+			case Delay:
+				c.rdelayed = frame
+				c.rdue = time.Now().Add(rule.Delay)
+			}
+		}
+
+		// A delayed frame stays where it is if the read times out, so it can be
+		// delivered by the next Read.
+		c.lock.Lock()
+		deadline := c.readDeadline
+		c.lock.Unlock()
+
+		err := c.wait(c.rdue, deadline)
+		if err != nil {
+			return 0, err
+		}
+
+		c.rout = c.rdelayed
+		c.rdelayed = nil
+	}
+
+	n := copy(b, c.rout)
+	c.rout = c.rout[n:]
+	return n, nil
+}
+
+// readFrame reads a whole frame from the connection, and returns it along with
+// how it was framed.
+func (c *conn) readFrame() (frameKind, []byte, error) {
+	// A pooled connection is reused once the client has finished with a block,
+	// so the next thing the datanode sends is the response to a new op.
+	c.lock.Lock()
+	if c.nextOp {
+		c.nextOp = false
+		c.rkind = opResponseFrame
+	}
+	c.lock.Unlock()
+
+	for {
+		kind := c.rkind
+		n, ok := frameLength(kind, c.rbuf)
+		if ok {
+			frame := c.rbuf[:n:n]
+			c.rbuf = c.rbuf[n:]
+			c.advanceRead()
+			return kind, frame, nil
+		}
+
+		buf := make([]byte, 32*1024)
+		m, err := c.Conn.Read(buf)
+		c.rbuf = append(c.rbuf, buf[:m]...)
+		if err != nil {
+			if len(c.rbuf) > 0 && err == io.EOF {
+				// Pass along the partial frame; the next read will return EOF.
+				frame := c.rbuf
+				c.rbuf = nil
+				return kind, frame, nil
+			}
+
+			return kind, nil, err
+		}
+	}
+}
+
+// advanceRead works out how the next frame read is framed.
+func (c *conn) advanceRead() {
+	if c.rkind != opResponseFrame {
+		return
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	switch c.op {
+	case writeBlockOp:
+		c.rkind = varintFrames
+	case readBlockOp, copyBlockOp:
+		c.rkind = packetFrames
+	default:
+		c.rkind = rawFrames
+	}
+}
+
+// classifyWrite returns the message that frame represents, if it's one that
+// rules can apply to. It also records the method for each RPC request, so that
+// the responses can be matched to them.
+func (c *conn) classifyWrite(kind frameKind, frame []byte) (Message, bool) {
+	switch kind {
+	case rpcFrames:
+		rrh := &hadoop.RpcRequestHeaderProto{}
+		rh := &hadoop.RequestHeaderProto{}
+		if parseRPC(frame, rrh, rh) {
+			c.lock.Lock()
+			c.methods[uint32(rrh.GetCallId())] = rh.GetMethodName()
+			c.lock.Unlock()
+		}
+	case packetFrames:
+		if seqno, ok := packetSeqno(frame); ok {
+			return Message{Target: DatanodeWritePacket, Addr: c.addr, Seqno: seqno, Data: frame}, true
+		}
+	}
+
+	return Message{}, false
+}
+
+// classifyRead returns the message that frame represents, if it's one that
+// rules can apply to.
+func (c *conn) classifyRead(kind frameKind, frame []byte) (Message, bool) {
+	switch kind {
+	case rpcFrames:
+		rrh := &hadoop.RpcResponseHeaderProto{}
+		if !parseRPC(frame, rrh) {
+			break
+		}
+
+		c.lock.Lock()
+		method := c.methods[rrh.GetCallId()]
+		delete(c.methods, rrh.GetCallId())
+		c.lock.Unlock()
+
+		return Message{Target: NamenodeResponse, Addr: c.addr, Method: method, Data: frame}, true
+	case varintFrames:
+		length, n := binary.Uvarint(frame)
+		if n <= 0 || n+int(length) > len(frame) {
+			break
+		}
+
+		ack := &hdfs.PipelineAckProto{}
+		if proto.Unmarshal(frame[n:n+int(length)], ack) != nil || ack.GetSeqno() == heartbeatSeqno {
+			break
+		}
+
+		return Message{Target: DatanodeAck, Addr: c.addr, Seqno: ack.GetSeqno(), Data: frame}, true
+	case packetFrames:
+		if seqno, ok := packetSeqno(frame); ok {
+			return Message{Target: DatanodeReadPacket, Addr: c.addr, Seqno: seqno, Data: frame}, true
+		}
+	}
+
+	return Message{}, false
+}
+
+// wait blocks until t, or returns an error if deadline comes first or the
+// connection is closed.
+func (c *conn) wait(t, deadline time.Time) error {
+	var err error
+	if !deadline.IsZero() && deadline.Before(t) {
+		t = deadline
+		err = os.ErrDeadlineExceeded
+	}
+
+	timer := time.NewTimer(time.Until(t))
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return err
+	case <-c.closeCh:
+		return net.ErrClosed
+	}
+}
+
+func (c *conn) SetDeadline(t time.Time) error {
+	c.lock.Lock()
+	c.readDeadline = t
+	c.lock.Unlock()
+
+	return c.Conn.SetDeadline(t)
+}
+
+func (c *conn) SetReadDeadline(t time.Time) error {
+	c.lock.Lock()
+	c.readDeadline = t
+	c.lock.Unlock()
+
+	return c.Conn.SetReadDeadline(t)
+}
+
+func (c *conn) Close() error {
+	c.closeOnce.Do(func() { close(c.closeCh) })
+	return c.Conn.Close()
+}
+
+// parseRPC parses the varint-prefixed messages in an RPC frame, returning
+// false if they can't be.
+func parseRPC(frame []byte, msgs ...proto.Message) bool {
+	b := frame[4:]
+	for _, msg := range msgs {
+		length, n := binary.Uvarint(b)
+		if n <= 0 || n+int(length) > len(b) {
+			return false
+		}
+
+		if proto.Unmarshal(b[n:n+int(length)], msg) != nil {
+			return false
+		}
+
+		b = b[n+int(length):]
+	}
+
+	return true
+}
+
+// packetSeqno returns the sequence number of a packet, or false if it can't be
+// parsed or it's a heartbeat.
+func packetSeqno(frame []byte) (int64, bool) {
+	if len(frame) < 6 {
+		return 0, false
+	}
+
+	headerLength := int(binary.BigEndian.Uint16(frame[4:]))
+	if 6+headerLength > len(frame) {
+		return 0, false
+	}
+
+	header := &hdfs.PacketHeaderProto{}
+	if proto.Unmarshal(frame[6:6+headerLength], header) != nil || header.GetSeqno() == heartbeatSeqno {
+		return 0, false
+	}
+
+	return header.GetSeqno(), true
+}
+
+// corrupt returns a copy of frame with the bits of the last byte inverted.
+func corrupt(frame []byte) []byte {
+	corrupted := make([]byte, len(frame))
+	copy(corrupted, frame)
+	if len(corrupted) > 0 {
+		corrupted[len(corrupted)-1] ^= 0xff
+	}
+
+	return corrupted
+}
+
+// fail returns an error response to the same call as the RPC response in
+// frame, with the given exception class. If frame can't be parsed, it's
+// returned as is.
+func fail(frame []byte, exception string) []byte {
+	header := &hadoop.RpcResponseHeaderProto{}
+	if !parseRPC(frame, header) {
+		return frame
+	}
+
+	if exception == "" {
+		exception = "java.io.IOException"
+	}
+
+	b, err := proto.Marshal(&hadoop.RpcResponseHeaderProto{
+		CallId:              header.CallId,
+		Status:              hadoop.RpcResponseHeaderProto_ERROR.Enum(),
+		ServerIpcVersionNum: header.ServerIpcVersionNum,
+		ExceptionClassName:  proto.String(exception),
+		ErrorMsg:            proto.String("injected by faultinject"),
+		ErrorDetail:         hadoop.RpcResponseHeaderProto_ERROR_APPLICATION.Enum(),
+		ClientId:            header.ClientId,
+	})
+	if err != nil {
+		return frame
+	}
+
+	body := append(binary.AppendUvarint(nil, uint64(len(b))), b...)
+	return append(binary.BigEndian.AppendUint32(nil, uint32(len(body))), body...)
+}
@@ -0,0 +1,216 @@
+// Package faultinject implements a layer for injecting faults into the
+// connections a client makes to the namenode and datanodes, for testing retry,
+// failover, and recovery code. It's exported by the hdfstest package.
+package faultinject
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+)
+
+// Target is the kind of message a Rule applies to.
+type Target int
+
+const (
+	// NamenodeResponse is an RPC response from the namenode.
+	NamenodeResponse Target = iota
+	// DatanodeWritePacket is a packet of block data sent to a datanode by a
+	// writer.
+	DatanodeWritePacket
+	// DatanodeAck is an ack for a written packet, sent back by a datanode.
+	DatanodeAck
+	// DatanodeReadPacket is a packet of block data sent by a datanode to a
+	// reader.
+	DatanodeReadPacket
+)
+
+func (t Target) String() string {
+	switch t {
+	case NamenodeResponse:
+		return "namenode response"
+	case DatanodeWritePacket:
+		return "datanode write packet"
+	case DatanodeAck:
+		return "datanode ack"
+	case DatanodeReadPacket:
+		return "datanode read packet"
+	default:
+		return "unknown"
+	}
+}
+
+// Action is the fault a Rule injects.
+type Action int
+
+const (
+	// Delay holds the message back for Rule.Delay before delivering it. Read
+	// deadlines set on the connection still apply, so a long enough delay
+	// causes a timeout.
+	Delay Action = iota
+	// Drop discards the message, as if it was never sent.
+	Drop
+	// Corrupt inverts the bits of the last byte of the message. For a packet
+	// with data, that's part of the data, so the checksum no longer matches.
+	Corrupt
+	// Disconnect closes the connection instead of delivering the message.
+	Disconnect
+	// Fail replaces a NamenodeResponse with an error response, as if the
+	// namenode had thrown Rule.Exception. It has no effect on other targets.
+	Fail
+)
+
+// A Message is a single message on a connection, as it's passed to
+// Rule.Match.
+type Message struct {
+	Target Target
+	// Addr is the address the connection was dialed to.
+	Addr string
+	// Method is the RPC method the message is a response to, for
+	// NamenodeResponse.
+	Method string
+	// Seqno is the sequence number of the packet, for DatanodeWritePacket,
+	// DatanodeAck, and DatanodeReadPacket.
+	Seqno int64
+	// Data is the whole message as it's sent on the wire, including any
+	// headers and length prefixes. It must not be modified.
+	Data []byte
+}
+
+// A Rule describes which messages to inject a fault into, and what the fault
+// is.
+type Rule struct {
+	Target Target
+	// Addr, if set, restricts the rule to connections dialed to that address.
+	Addr string
+	// Method, if set, restricts the rule to responses to that RPC method.
+	Method string
+	// Match, if set, is called with each message that the rule applies to
+	// otherwise, and the rule is only applied if it returns true. It's called
+	// with the injector's lock held, so it shouldn't block.
+	Match func(Message) bool
+	// Skip is the number of matching messages to let through before the fault
+	// is first injected.
+	Skip int
+	// Times is the number of matching messages to inject the fault into, after
+	// Skip. If zero, the fault is injected into every one.
+	Times int
+	// Action is the fault to inject, and Delay is how long to delay the
+	// message for, if Action is Delay.
+	Action Action
+	Delay  time.Duration
+	// Exception is the Java class of the exception to respond with, if Action
+	// is Fail, for example "org.apache.hadoop.ipc.RetriableException". If
+	// empty, it's "java.io.IOException".
+	Exception string
+}
+
+// DialFunc is a function used to connect to the namenode or datanodes, like
+// (&net.Dialer{}).DialContext.
+type DialFunc func(ctx context.Context, network, addr string) (net.Conn, error)
+
+// An Injector injects faults into connections, according to a set of rules.
+// For each message, the rules are checked in the order they were added, and
+// the first one that matches is applied. Heartbeat packets and their acks are
+// never matched.
+type Injector struct {
+	lock     sync.Mutex
+	rules    []*ruleState
+	injected int
+}
+
+type ruleState struct {
+	Rule
+	seen    int
+	applied int
+}
+
+// New returns an Injector with the given rules.
+func New(rules ...Rule) *Injector {
+	inj := &Injector{}
+	for _, r := range rules {
+		inj.Add(r)
+	}
+
+	return inj
+}
+
+// Add adds a rule, which is checked after any existing ones.
+func (inj *Injector) Add(r Rule) {
+	inj.lock.Lock()
+	defer inj.lock.Unlock()
+
+	inj.rules = append(inj.rules, &ruleState{Rule: r})
+}
+
+// Clear removes all the rules. Messages that are already being delayed are
+// still delivered late.
+func (inj *Injector) Clear() {
+	inj.lock.Lock()
+	defer inj.lock.Unlock()
+
+	inj.rules = nil
+}
+
+// Injected returns the number of faults that have been injected so far.
+func (inj *Injector) Injected() int {
+	inj.lock.Lock()
+	defer inj.lock.Unlock()
+
+	return inj.injected
+}
+
+// WrapNamenodeDial returns a dial function for connecting to the namenode,
+// which injects faults into the connections returned by dial. If dial is nil,
+// (&net.Dialer{}).DialContext is used.
+func (inj *Injector) WrapNamenodeDial(dial DialFunc) DialFunc {
+	return inj.wrap(dial, true)
+}
+
+// WrapDatanodeDial returns a dial function for connecting to datanodes, like
+// WrapNamenodeDial.
+func (inj *Injector) WrapDatanodeDial(dial DialFunc) DialFunc {
+	return inj.wrap(dial, false)
+}
+
+func (inj *Injector) wrap(dial DialFunc, namenode bool) DialFunc {
+	if dial == nil {
+		dial = (&net.Dialer{}).DialContext
+	}
+
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		c, err := dial(ctx, network, addr)
+		if err != nil {
+			return nil, err
+		}
+
+		return newConn(c, inj, addr, namenode), nil
+	}
+}
+
+// match returns the rule to apply to msg, if any.
+func (inj *Injector) match(msg Message) (Rule, bool) {
+	inj.lock.Lock()
+	defer inj.lock.Unlock()
+
+	for _, r := range inj.rules {
+		if r.Target != msg.Target ||
+			(r.Addr != "" && r.Addr != msg.Addr) ||
+			(r.Method != "" && r.Method != msg.Method) ||
+			(r.Match != nil && !r.Match(msg)) {
+			continue
+		}
+
+		r.seen++
+		if r.seen <= r.Skip || (r.Times > 0 && r.applied >= r.Times) {
+			continue
+		}
+
+		r.applied++
+		inj.injected++
+		return r.Rule, true
+	}
+
+	return Rule{}, false
+}
@@ -0,0 +1,327 @@
+package faultinject
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	hadoop "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_common"
+	hdfs "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_hdfs"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func varintPrefixed(msgs ...proto.Message) []byte {
+	var b []byte
+	for _, msg := range msgs {
+		msgBytes, err := proto.Marshal(msg)
+		if err != nil {
+			panic(err)
+		}
+
+		b = binary.AppendUvarint(b, uint64(len(msgBytes)))
+		b = append(b, msgBytes...)
+	}
+
+	return b
+}
+
+func rpcFrame(msgs ...proto.Message) []byte {
+	body := varintPrefixed(msgs...)
+	return append(binary.BigEndian.AppendUint32(nil, uint32(len(body))), body...)
+}
+
+func packetFrame(seqno int64, data []byte) []byte {
+	header, _ := proto.Marshal(&hdfs.PacketHeaderProto{
+		OffsetInBlock:     proto.Int64(0),
+		Seqno:             proto.Int64(seqno),
+		LastPacketInBlock: proto.Bool(false),
+		DataLen:           proto.Int32(int32(len(data))),
+	})
+
+	b := binary.BigEndian.AppendUint32(nil, uint32(len(data)+4))
+	b = binary.BigEndian.AppendUint16(b, uint16(len(header)))
+	b = append(b, header...)
+	return append(b, data...)
+}
+
+func opFrame(op byte) []byte {
+	return append([]byte{0x00, 0x1c, op}, varintPrefixed(&hdfs.DataTransferEncryptorMessageProto{
+		Status: hdfs.DataTransferEncryptorMessageProto_SUCCESS.Enum(),
+	})...)
+}
+
+// pipeDial returns a dial function that connects to serve over a net.Pipe.
+func pipeDial(serve func(net.Conn)) DialFunc {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		client, server := net.Pipe()
+		go serve(server)
+		return client, nil
+	}
+}
+
+func readFull(t *testing.T, r io.Reader, n int) []byte {
+	b := make([]byte, n)
+	_, err := io.ReadFull(r, b)
+	require.NoError(t, err)
+	return b
+}
+
+func TestDropNamenodeResponse(t *testing.T) {
+	response := func(callID uint32) []byte {
+		return rpcFrame(&hadoop.RpcResponseHeaderProto{
+			CallId: proto.Uint32(callID),
+			Status: hadoop.RpcResponseHeaderProto_SUCCESS.Enum(),
+		})
+	}
+
+	inj := New(Rule{Target: NamenodeResponse, Method: "getFileInfo", Times: 1, Action: Drop})
+	dial := inj.WrapNamenodeDial(pipeDial(func(conn net.Conn) {
+		defer conn.Close()
+		go io.Copy(io.Discard, conn)
+		conn.Write(response(1))
+		conn.Write(response(2))
+		conn.Write(response(3))
+	}))
+
+	conn, err := dial(context.Background(), "tcp", "namenode:8020")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	_, err = conn.Write([]byte("hrpc\x09\x00\x00"))
+	require.NoError(t, err)
+
+	for i, method := range []string{"getFileInfo", "getFileInfo", "mkdirs"} {
+		_, err = conn.Write(rpcFrame(
+			&hadoop.RpcRequestHeaderProto{CallId: proto.Int32(int32(i + 1)), ClientId: []byte("client")},
+			&hadoop.RequestHeaderProto{
+				MethodName:                 proto.String(method),
+				DeclaringClassProtocolName: proto.String("protocol"),
+				ClientProtocolVersion:      proto.Uint64(1),
+			},
+		))
+		require.NoError(t, err)
+	}
+
+	// The first response is dropped, so the second one is read first.
+	for _, expected := range []uint32{2, 3} {
+		b := readFull(t, conn, len(response(expected)))
+		assert.Equal(t, response(expected), b)
+	}
+
+	assert.Equal(t, 1, inj.Injected())
+}
+
+func TestFailNamenodeResponse(t *testing.T) {
+	inj := New(Rule{
+		Target:    NamenodeResponse,
+		Method:    "getFileInfo",
+		Times:     1,
+		Action:    Fail,
+		Exception: "org.apache.hadoop.ipc.RetriableException",
+	})
+
+	dial := inj.WrapNamenodeDial(pipeDial(func(conn net.Conn) {
+		defer conn.Close()
+		go io.Copy(io.Discard, conn)
+		conn.Write(rpcFrame(
+			&hadoop.RpcResponseHeaderProto{
+				CallId: proto.Uint32(1),
+				Status: hadoop.RpcResponseHeaderProto_SUCCESS.Enum(),
+			},
+			&hdfs.GetFileInfoResponseProto{},
+		))
+	}))
+
+	conn, err := dial(context.Background(), "tcp", "namenode:8020")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	_, err = conn.Write([]byte("hrpc\x09\x00\x00"))
+	require.NoError(t, err)
+
+	_, err = conn.Write(rpcFrame(
+		&hadoop.RpcRequestHeaderProto{CallId: proto.Int32(1), ClientId: []byte("client")},
+		&hadoop.RequestHeaderProto{
+			MethodName:                 proto.String("getFileInfo"),
+			DeclaringClassProtocolName: proto.String("protocol"),
+			ClientProtocolVersion:      proto.Uint64(1),
+		},
+	))
+	require.NoError(t, err)
+
+	length := binary.BigEndian.Uint32(readFull(t, conn, 4))
+	frame := append(binary.BigEndian.AppendUint32(nil, length), readFull(t, conn, int(length))...)
+
+	header := &hadoop.RpcResponseHeaderProto{}
+	require.True(t, parseRPC(frame, header))
+	assert.EqualValues(t, 1, header.GetCallId())
+	assert.Equal(t, hadoop.RpcResponseHeaderProto_ERROR, header.GetStatus())
+	assert.Equal(t, "org.apache.hadoop.ipc.RetriableException", header.GetExceptionClassName())
+	assert.Equal(t, 1, inj.Injected())
+}
+
+func TestCorruptWritePacket(t *testing.T) {
+	received := make(chan []byte, 10)
+	dial := pipeDial(func(conn net.Conn) {
+		defer close(received)
+		defer conn.Close()
+
+		readFull(t, conn, len(opFrame(writeBlockOp)))
+		for {
+			b := make([]byte, len(packetFrame(1, []byte("foo"))))
+			if _, err := io.ReadFull(conn, b); err != nil {
+				return
+			}
+
+			received <- b
+		}
+	})
+
+	inj := New(Rule{
+		Target: DatanodeWritePacket,
+		Match:  func(msg Message) bool { return msg.Seqno == 2 },
+		Action: Corrupt,
+	})
+
+	conn, err := inj.WrapDatanodeDial(dial)(context.Background(), "tcp", "datanode:9866")
+	require.NoError(t, err)
+
+	_, err = conn.Write(opFrame(writeBlockOp))
+	require.NoError(t, err)
+
+	// Write the packets in pieces, like the BlockWriter does.
+	for _, seqno := range []int64{heartbeatSeqno, 1, 2} {
+		packet := packetFrame(seqno, []byte("foo"))
+		_, err = conn.Write(packet[:10])
+		require.NoError(t, err)
+		_, err = conn.Write(packet[10:])
+		require.NoError(t, err)
+	}
+
+	conn.Close()
+
+	assert.Equal(t, packetFrame(heartbeatSeqno, []byte("foo")), <-received)
+	assert.Equal(t, packetFrame(1, []byte("foo")), <-received)
+	assert.Equal(t, packetFrame(2, []byte("fo\x90")), <-received)
+	assert.Equal(t, 1, inj.Injected())
+}
+
+func TestDelayReadPacket(t *testing.T) {
+	packet := packetFrame(1, []byte("foo"))
+	opResp := varintPrefixed(&hdfs.BlockOpResponseProto{Status: hdfs.Status_SUCCESS.Enum()})
+	dial := pipeDial(func(conn net.Conn) {
+		defer conn.Close()
+		readFull(t, conn, len(opFrame(readBlockOp)))
+		conn.Write(opResp)
+		conn.Write(packet)
+		io.Copy(io.Discard, conn)
+	})
+
+	inj := New(Rule{Target: DatanodeReadPacket, Action: Delay, Delay: 200 * time.Millisecond})
+	conn, err := inj.WrapDatanodeDial(dial)(context.Background(), "tcp", "datanode:9866")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	_, err = conn.Write(opFrame(readBlockOp))
+	require.NoError(t, err)
+	assert.Equal(t, opResp, readFull(t, conn, len(opResp)))
+
+	// The read times out while the packet is delayed, but it's still delivered
+	// afterwards.
+	conn.SetDeadline(time.Now().Add(10 * time.Millisecond))
+	_, err = conn.Read(make([]byte, 1))
+	assert.True(t, errors.Is(err, os.ErrDeadlineExceeded))
+
+	conn.SetDeadline(time.Time{})
+	assert.Equal(t, packet, readFull(t, conn, len(packet)))
+}
+
+func TestReusedReadConnection(t *testing.T) {
+	opResp := varintPrefixed(&hdfs.BlockOpResponseProto{Status: hdfs.Status_SUCCESS.Enum()})
+	packet := packetFrame(0, []byte("foo"))
+	status := varintPrefixed(&hdfs.ClientReadStatusProto{Status: hdfs.Status_CHECKSUM_OK.Enum()})
+	dial := pipeDial(func(conn net.Conn) {
+		defer conn.Close()
+		for i := 0; i < 2; i++ {
+			readFull(t, conn, len(opFrame(readBlockOp)))
+			conn.Write(opResp)
+			conn.Write(packet)
+			readFull(t, conn, len(status))
+		}
+	})
+
+	// Only the packet for the second block is corrupted, which means the
+	// second op on the connection was recognized.
+	inj := New(Rule{Target: DatanodeReadPacket, Action: Corrupt, Skip: 1})
+	conn, err := inj.WrapDatanodeDial(dial)(context.Background(), "tcp", "datanode:9866")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	for i := 0; i < 2; i++ {
+		_, err = conn.Write(opFrame(readBlockOp))
+		require.NoError(t, err)
+		assert.Equal(t, opResp, readFull(t, conn, len(opResp)))
+
+		expected := packet
+		if i == 1 {
+			expected = corrupt(packet)
+		}
+
+		assert.Equal(t, expected, readFull(t, conn, len(packet)))
+		_, err = conn.Write(status)
+		require.NoError(t, err)
+	}
+}
+
+func TestDisconnectAck(t *testing.T) {
+	ack := func(seqno int64) []byte {
+		return varintPrefixed(&hdfs.PipelineAckProto{
+			Seqno: proto.Int64(seqno),
+			Reply: []hdfs.Status{hdfs.Status_SUCCESS},
+		})
+	}
+
+	opResp := varintPrefixed(&hdfs.BlockOpResponseProto{Status: hdfs.Status_SUCCESS.Enum()})
+	dial := pipeDial(func(conn net.Conn) {
+		defer conn.Close()
+		readFull(t, conn, len(opFrame(writeBlockOp)))
+		conn.Write(opResp)
+		conn.Write(ack(heartbeatSeqno))
+		conn.Write(ack(1))
+		conn.Write(ack(2))
+		io.Copy(io.Discard, conn)
+	})
+
+	inj := New(Rule{Target: DatanodeAck, Addr: "datanode:9866", Skip: 1, Action: Disconnect})
+	conn, err := inj.WrapDatanodeDial(dial)(context.Background(), "tcp", "datanode:9866")
+	require.NoError(t, err)
+
+	_, err = conn.Write(opFrame(writeBlockOp))
+	require.NoError(t, err)
+
+	assert.Equal(t, opResp, readFull(t, conn, len(opResp)))
+	assert.Equal(t, ack(heartbeatSeqno), readFull(t, conn, len(ack(heartbeatSeqno))))
+	assert.Equal(t, ack(1), readFull(t, conn, len(ack(1))))
+
+	_, err = conn.Read(make([]byte, 1))
+	assert.True(t, errors.Is(err, net.ErrClosed))
+	assert.Equal(t, 1, inj.Injected())
+}
+
+func TestRulesOtherAddress(t *testing.T) {
+	inj := New(Rule{Target: DatanodeAck, Addr: "other:9866", Action: Drop})
+	_, ok := inj.match(Message{Target: DatanodeAck, Addr: "datanode:9866"})
+	assert.False(t, ok)
+
+	inj.Clear()
+	inj.Add(Rule{Target: DatanodeAck, Action: Drop})
+	_, ok = inj.match(Message{Target: DatanodeAck, Addr: "datanode:9866"})
+	assert.True(t, ok)
+}
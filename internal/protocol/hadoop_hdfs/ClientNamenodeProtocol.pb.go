@@ -3407,6 +3407,36 @@ func (m *GetEditsFromTxidResponseProto) GetEventsList() *EventsListProto {
 	return nil
 }
 
+type SatisfyStoragePolicyRequestProto struct {
+	Src              *string `protobuf:"bytes,1,req,name=src" json:"src,omitempty"`
+	XXX_unrecognized []byte  `json:"-"`
+}
+
+func (m *SatisfyStoragePolicyRequestProto) Reset()         { *m = SatisfyStoragePolicyRequestProto{} }
+func (m *SatisfyStoragePolicyRequestProto) String() string { return proto.CompactTextString(m) }
+func (*SatisfyStoragePolicyRequestProto) ProtoMessage()    {}
+func (*SatisfyStoragePolicyRequestProto) Descriptor() ([]byte, []int) {
+	return fileDescriptor4, []int{149}
+}
+
+func (m *SatisfyStoragePolicyRequestProto) GetSrc() string {
+	if m != nil && m.Src != nil {
+		return *m.Src
+	}
+	return ""
+}
+
+type SatisfyStoragePolicyResponseProto struct {
+	XXX_unrecognized []byte `json:"-"`
+}
+
+func (m *SatisfyStoragePolicyResponseProto) Reset()         { *m = SatisfyStoragePolicyResponseProto{} }
+func (m *SatisfyStoragePolicyResponseProto) String() string { return proto.CompactTextString(m) }
+func (*SatisfyStoragePolicyResponseProto) ProtoMessage()    {}
+func (*SatisfyStoragePolicyResponseProto) Descriptor() ([]byte, []int) {
+	return fileDescriptor4, []int{150}
+}
+
 func init() {
 	proto.RegisterType((*GetBlockLocationsRequestProto)(nil), "hadoop.hdfs.GetBlockLocationsRequestProto")
 	proto.RegisterType((*GetBlockLocationsResponseProto)(nil), "hadoop.hdfs.GetBlockLocationsResponseProto")
@@ -3557,6 +3587,8 @@ func init() {
 	proto.RegisterType((*GetCurrentEditLogTxidResponseProto)(nil), "hadoop.hdfs.GetCurrentEditLogTxidResponseProto")
 	proto.RegisterType((*GetEditsFromTxidRequestProto)(nil), "hadoop.hdfs.GetEditsFromTxidRequestProto")
 	proto.RegisterType((*GetEditsFromTxidResponseProto)(nil), "hadoop.hdfs.GetEditsFromTxidResponseProto")
+	proto.RegisterType((*SatisfyStoragePolicyRequestProto)(nil), "hadoop.hdfs.SatisfyStoragePolicyRequestProto")
+	proto.RegisterType((*SatisfyStoragePolicyResponseProto)(nil), "hadoop.hdfs.SatisfyStoragePolicyResponseProto")
 	proto.RegisterEnum("hadoop.hdfs.CreateFlagProto", CreateFlagProto_name, CreateFlagProto_value)
 	proto.RegisterEnum("hadoop.hdfs.DatanodeReportTypeProto", DatanodeReportTypeProto_name, DatanodeReportTypeProto_value)
 	proto.RegisterEnum("hadoop.hdfs.SafeModeActionProto", SafeModeActionProto_name, SafeModeActionProto_value)
@@ -3568,343 +3600,343 @@ func init() { proto.RegisterFile("ClientNamenodeProtocol.proto", fileDescriptor4
 
 var fileDescriptor4 = []byte{
 	// 5410 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x5c, 0x5b, 0x73, 0xdc, 0x46,
-	0x76, 0xae, 0x19, 0x0e, 0x29, 0xf2, 0x48, 0xa2, 0xc6, 0x10, 0x29, 0x8e, 0x86, 0x94, 0x34, 0x82,
-	0x2e, 0xa6, 0xb5, 0x36, 0x65, 0xd3, 0x5e, 0x97, 0x22, 0x3b, 0xeb, 0x1d, 0x91, 0x43, 0x2e, 0x57,
-	0x14, 0x49, 0x63, 0x28, 0x6b, 0xad, 0x5d, 0x17, 0x17, 0x02, 0x7a, 0x86, 0x08, 0x31, 0xc0, 0x04,
-	0xc0, 0xf0, 0xe2, 0xdd, 0xaa, 0xd4, 0xee, 0x43, 0xe2, 0xaa, 0x54, 0xa5, 0x92, 0xaa, 0x3c, 0xe4,
-	0x31, 0x2f, 0x79, 0xc8, 0xcf, 0xc8, 0xed, 0x07, 0xe4, 0x27, 0xe4, 0x3d, 0x7f, 0x20, 0x6f, 0xa9,
-	0xbe, 0x60, 0xd0, 0x37, 0x00, 0x63, 0xcb, 0xa9, 0x3c, 0x71, 0x70, 0xfa, 0x9c, 0xd3, 0xa7, 0x6f,
-	0xa7, 0x4f, 0xf7, 0xf9, 0x9a, 0xb0, 0xb2, 0xe1, 0x7b, 0x28, 0x48, 0xf6, 0xec, 0x01, 0x0a, 0x42,
-	0x17, 0x1d, 0x44, 0x61, 0x12, 0x3a, 0xa1, 0xbf, 0x36, 0xc4, 0x3f, 0x8c, 0xcb, 0xc7, 0xb6, 0x1b,
-	0x86, 0xc3, 0xb5, 0x63, 0xb7, 0x17, 0x37, 0xe7, 0xbb, 0xc8, 0x19, 0x45, 0x5e, 0x72, 0x41, 0x0b,
-	0x9b, 0x80, 0xa9, 0xec, 0xf7, 0x9c, 0xed, 0x30, 0x99, 0xe6, 0xe5, 0x73, 0x3b, 0x49, 0x22, 0xf6,
-	0x51, 0x47, 0x81, 0x13, 0x5d, 0x0c, 0x13, 0x2f, 0x0c, 0x18, 0xe5, 0xaa, 0x17, 0x84, 0x89, 0xd7,
-	0x4b, 0x95, 0x5c, 0x47, 0x91, 0x1d, 0x8f, 0x22, 0xe4, 0x84, 0xae, 0x17, 0xf4, 0x29, 0xd1, 0xb4,
-	0xe1, 0xd6, 0x36, 0x4a, 0x9e, 0xf9, 0xa1, 0x73, 0xb2, 0x1b, 0x3a, 0x36, 0x96, 0x8e, 0x2d, 0xf4,
-	0xe7, 0x23, 0x14, 0x27, 0xc4, 0x40, 0xa3, 0x0e, 0x53, 0x71, 0xe4, 0x34, 0x2a, 0xad, 0xea, 0xea,
-	0x9c, 0x85, 0x7f, 0x1a, 0x37, 0x60, 0x26, 0xec, 0xf5, 0x62, 0x94, 0x34, 0xaa, 0xad, 0xea, 0x6a,
-	0xcd, 0x62, 0x5f, 0x98, 0xee, 0xa3, 0xa0, 0x9f, 0x1c, 0x37, 0xa6, 0x28, 0x9d, 0x7e, 0x99, 0x47,
-	0x70, 0x5b, 0x53, 0x45, 0x3c, 0x0c, 0x83, 0x98, 0x76, 0x82, 0xf1, 0xa7, 0x30, 0xe7, 0xa7, 0x25,
-	0x8d, 0x4a, 0xab, 0xb2, 0x7a, 0x79, 0xfd, 0xce, 0x1a, 0xd7, 0x1f, 0x6b, 0x44, 0x0e, 0xb9, 0x44,
-	0x47, 0x4c, 0x64, 0xac, 0x4c, 0xc2, 0xbc, 0x43, 0xda, 0xd0, 0x45, 0xd1, 0x29, 0x8a, 0x36, 0x51,
-	0xcf, 0x1e, 0xf9, 0x89, 0xd0, 0x06, 0xd3, 0x27, 0x16, 0xc8, 0x0c, 0xbc, 0x05, 0xbf, 0x84, 0xf9,
-	0x58, 0x28, 0x26, 0x0d, 0xbe, 0xbc, 0x6e, 0x0a, 0x66, 0x6c, 0xc5, 0xa2, 0x0e, 0x6a, 0x89, 0x24,
-	0x69, 0xfe, 0x57, 0x15, 0x8c, 0x8d, 0x08, 0xd9, 0x09, 0x2a, 0xe9, 0xc8, 0x4f, 0x61, 0x66, 0x60,
-	0xc7, 0x27, 0xc8, 0x25, 0x1d, 0x79, 0x79, 0xfd, 0xb6, 0x54, 0xd9, 0x01, 0x8a, 0x06, 0x5e, 0x1c,
-	0x7b, 0x61, 0x40, 0x2b, 0x62, 0xdc, 0xc6, 0x6d, 0x00, 0x67, 0x3c, 0x95, 0x48, 0x67, 0xcf, 0x59,
-	0x1c, 0x85, 0x94, 0x93, 0xfa, 0xb7, 0x7c, 0xbb, 0xdf, 0xa8, 0xb5, 0xaa, 0xab, 0x57, 0x2d, 0x8e,
-	0x62, 0x98, 0x70, 0x85, 0x7e, 0x1d, 0xd8, 0x11, 0x0a, 0x92, 0xc6, 0x74, 0xab, 0xba, 0x3a, 0x6b,
-	0x09, 0x34, 0xa3, 0x05, 0x97, 0x23, 0x34, 0xf4, 0x3d, 0xda, 0xc7, 0x8d, 0x19, 0xa2, 0x84, 0x27,
-	0x19, 0x2b, 0x30, 0xf7, 0x06, 0x8f, 0x47, 0xd7, 0xfb, 0x16, 0x35, 0x2e, 0x91, 0x11, 0xcf, 0x08,
-	0xc6, 0x37, 0xb0, 0x48, 0x66, 0x63, 0x98, 0x4e, 0xf3, 0xaf, 0x50, 0x84, 0x5b, 0xd2, 0x98, 0x6d,
-	0x4d, 0xad, 0xce, 0xaf, 0xbf, 0x2b, 0x34, 0x75, 0x43, 0xc7, 0x49, 0xdb, 0xac, 0xd7, 0x62, 0x6e,
-	0xc3, 0xf5, 0xb4, 0x8b, 0xf9, 0x61, 0xfc, 0x10, 0xaa, 0xbd, 0x74, 0x06, 0xb5, 0x84, 0x2a, 0x7e,
-	0xe1, 0xf6, 0xe2, 0x2d, 0xcf, 0x47, 0xdd, 0xc4, 0x4e, 0x46, 0x6c, 0xe0, 0xaa, 0xbd, 0xd8, 0x7c,
-	0x0d, 0x46, 0x7b, 0x38, 0x44, 0x81, 0x5b, 0x32, 0x56, 0x62, 0x9f, 0x57, 0x95, 0x3e, 0x37, 0xa0,
-	0xd6, 0xc3, 0xbd, 0x3d, 0xd5, 0xaa, 0xac, 0x5e, 0xb5, 0xc8, 0x6f, 0xf3, 0x0f, 0x15, 0xb8, 0x9e,
-	0x2a, 0xe7, 0xad, 0xfc, 0x04, 0xa6, 0x49, 0x47, 0x31, 0x43, 0x6f, 0xe7, 0x4e, 0x75, 0x6a, 0x26,
-	0x65, 0x36, 0x3e, 0x81, 0x5a, 0x9c, 0xd8, 0x78, 0xd1, 0x4d, 0xd6, 0x3a, 0xc2, 0x6d, 0x1e, 0x40,
-	0xb3, 0x8b, 0x12, 0x2b, 0x1b, 0xb7, 0x92, 0x76, 0x4a, 0xe3, 0x5e, 0x55, 0xc6, 0xdd, 0xfc, 0x29,
-	0x2c, 0xcb, 0x1a, 0xf9, 0xc6, 0xdd, 0x80, 0x99, 0x08, 0xc5, 0x23, 0x3f, 0x21, 0x5a, 0x67, 0x2d,
-	0xf6, 0x65, 0x1e, 0xc0, 0x4a, 0x17, 0x25, 0xdd, 0x24, 0x8c, 0xec, 0x3e, 0x3a, 0x08, 0x7d, 0xcf,
-	0xb9, 0x28, 0xef, 0xf2, 0x21, 0xe1, 0xe3, 0xbb, 0x3c, 0xa3, 0xe0, 0x65, 0xaf, 0x6a, 0xe4, 0x4c,
-	0x31, 0xd7, 0xe1, 0xf6, 0xcb, 0x20, 0xfe, 0x5e, 0x95, 0x9a, 0x77, 0xe1, 0x8e, 0x4e, 0x46, 0x54,
-	0xbb, 0xb2, 0x5d, 0xa4, 0xd4, 0x80, 0xda, 0xd0, 0x4e, 0x8e, 0x99, 0x56, 0xf2, 0xdb, 0x3c, 0xa1,
-	0x2e, 0x2a, 0x57, 0xa9, 0xf1, 0x4b, 0xb8, 0x1a, 0xf3, 0xa5, 0xcc, 0xff, 0xdc, 0x17, 0x86, 0x99,
-	0x4c, 0x0a, 0x41, 0x09, 0x1d, 0x6a, 0x51, 0xd4, 0x6c, 0x51, 0x77, 0xc7, 0xd1, 0x3c, 0x24, 0x3a,
-	0x44, 0x07, 0xee, 0xe8, 0x38, 0x78, 0x83, 0x7e, 0x0e, 0xb3, 0x43, 0x56, 0xd0, 0xa8, 0xb4, 0xa6,
-	0x26, 0xb6, 0x65, 0x2c, 0x65, 0x0e, 0xe0, 0x66, 0x17, 0x25, 0x99, 0x13, 0x2b, 0x19, 0xee, 0x9f,
-	0x01, 0x0c, 0xc7, 0xbc, 0x13, 0x7a, 0x44, 0x4e, 0xc2, 0x5c, 0x21, 0x33, 0x9d, 0xaf, 0x8e, 0x1f,
-	0xb4, 0x37, 0xb0, 0xd0, 0x45, 0xc9, 0xfe, 0x59, 0x80, 0xa2, 0x12, 0x3b, 0x9a, 0x30, 0x3b, 0x8a,
-	0x51, 0x14, 0xd0, 0x49, 0x57, 0x59, 0x9d, 0xb3, 0xc6, 0xdf, 0xd8, 0xe7, 0xf5, 0xa3, 0x70, 0x34,
-	0x0c, 0xa8, 0xe3, 0xc5, 0x85, 0x19, 0xc1, 0x5c, 0x82, 0xc5, 0xac, 0x0e, 0xbe, 0xf2, 0xbf, 0xa9,
-	0x40, 0xa3, 0xfd, 0xc6, 0x0e, 0xdc, 0x30, 0x20, 0xdd, 0x26, 0x58, 0xf0, 0x01, 0x54, 0xde, 0xb0,
-	0xd1, 0x16, 0x37, 0xbd, 0xce, 0x79, 0x82, 0x02, 0x57, 0x70, 0x05, 0x95, 0x37, 0xa9, 0xc1, 0x55,
-	0x61, 0x3f, 0x3e, 0x0e, 0x7d, 0x17, 0x45, 0x6c, 0x2b, 0x60, 0x5f, 0xc6, 0x4d, 0x98, 0xe9, 0x79,
-	0x3e, 0xda, 0x71, 0x1b, 0xb5, 0x56, 0x65, 0xb5, 0xf6, 0xb4, 0xf2, 0xa1, 0xc5, 0x08, 0xe6, 0x32,
-	0xdc, 0x14, 0xed, 0xe1, 0xad, 0xfd, 0xae, 0x0a, 0x0b, 0x6d, 0xd7, 0x55, 0x2d, 0xfd, 0xfe, 0x5e,
-	0xf1, 0x33, 0x98, 0x1d, 0x46, 0xe8, 0xd4, 0x0b, 0x47, 0x31, 0xe9, 0xae, 0x09, 0x9a, 0x38, 0x16,
-	0x30, 0x9e, 0xc1, 0x15, 0x74, 0xee, 0xf8, 0x23, 0x17, 0xed, 0x85, 0x2e, 0x8a, 0x1b, 0x35, 0x32,
-	0x0b, 0xc5, 0x29, 0xb1, 0x69, 0x27, 0x36, 0x0e, 0xa6, 0x76, 0x82, 0x1e, 0xdd, 0x23, 0x2c, 0x41,
-	0x86, 0xeb, 0x83, 0x69, 0xa9, 0x0f, 0xf0, 0x2e, 0xd8, 0xb3, 0x4f, 0xc3, 0x08, 0xb9, 0x54, 0xfd,
-	0x4c, 0x6b, 0x6a, 0x75, 0xce, 0x12, 0x68, 0xe6, 0x0b, 0x58, 0xcc, 0x7a, 0x22, 0xc7, 0x85, 0x57,
-	0x27, 0x76, 0xe1, 0xe6, 0x1f, 0xa6, 0xe0, 0xee, 0x36, 0x4a, 0xda, 0xae, 0xeb, 0x61, 0xc7, 0x69,
-	0xfb, 0xa9, 0xf9, 0x25, 0xdd, 0xfc, 0x11, 0x4c, 0xbd, 0xf1, 0x4f, 0xd8, 0x9a, 0x28, 0xed, 0x41,
-	0xcc, 0x6b, 0x7c, 0x0e, 0x73, 0xe8, 0xdc, 0x8b, 0x13, 0x2f, 0xe8, 0xe3, 0xae, 0x9f, 0xa4, 0xe7,
-	0x32, 0x01, 0xe3, 0x29, 0xcc, 0xb2, 0x6e, 0x9c, 0xb4, 0xdb, 0xc7, 0xfc, 0xc6, 0x1a, 0x18, 0xc1,
-	0x68, 0x90, 0xb5, 0x91, 0xf6, 0xee, 0x34, 0xd9, 0x48, 0x34, 0x25, 0xd2, 0x1c, 0x9a, 0x51, 0xe6,
-	0xd0, 0x3a, 0x2c, 0xa4, 0x86, 0x31, 0x77, 0xf3, 0x72, 0xe4, 0xb9, 0x71, 0xe3, 0x12, 0x19, 0x2f,
-	0x6d, 0x19, 0x37, 0xec, 0xb3, 0xf2, 0xd4, 0x7f, 0x0d, 0x66, 0xce, 0x10, 0xbc, 0xfd, 0xf8, 0xfe,
-	0x43, 0x05, 0x16, 0x36, 0xc2, 0xc1, 0xd0, 0x47, 0xa5, 0xb1, 0x5f, 0xd9, 0xca, 0xf9, 0x18, 0x6a,
-	0xbe, 0x1d, 0x27, 0x93, 0xae, 0x1a, 0xc2, 0x5c, 0xb4, 0xe2, 0x1f, 0xc3, 0x62, 0x66, 0xd9, 0x24,
-	0xfb, 0xf5, 0x4b, 0x58, 0xb6, 0xd0, 0x30, 0x8c, 0x92, 0x67, 0x36, 0x8b, 0xbb, 0x85, 0x16, 0x7d,
-	0x0a, 0x33, 0xa4, 0xcd, 0xe9, 0xe6, 0x50, 0xd6, 0x43, 0x8c, 0xdb, 0xbc, 0x0d, 0x2b, 0x8a, 0x5a,
-	0xde, 0xf9, 0x3c, 0x05, 0x63, 0x23, 0x0c, 0x1c, 0x3b, 0x91, 0xfb, 0x2f, 0x89, 0xfa, 0x69, 0xff,
-	0x25, 0x51, 0x1f, 0x6f, 0xb2, 0x71, 0xe4, 0xc4, 0x8d, 0x2a, 0x99, 0x05, 0xe4, 0xb7, 0xb9, 0x08,
-	0xd7, 0x53, 0x59, 0x5e, 0x65, 0x0f, 0x16, 0x0e, 0xa3, 0x11, 0xa6, 0x97, 0x0d, 0xca, 0x0a, 0xcc,
-	0x05, 0xe8, 0x6c, 0x97, 0x1e, 0x62, 0xe8, 0xe1, 0x26, 0x23, 0x94, 0x85, 0xdd, 0xb8, 0x8b, 0xb3,
-	0x7a, 0x26, 0xe9, 0xe2, 0x27, 0x60, 0x58, 0x08, 0xef, 0x1c, 0x25, 0x66, 0xd5, 0x61, 0xca, 0x8d,
-	0x93, 0xd4, 0xe5, 0xbb, 0x71, 0x62, 0x7e, 0x00, 0xd7, 0x53, 0xc9, 0x49, 0x2a, 0x3a, 0x4a, 0xd9,
-	0xd7, 0xbf, 0x6f, 0x4d, 0xc6, 0x7d, 0xb8, 0x1a, 0x9e, 0xa2, 0xe8, 0x2c, 0xf2, 0x12, 0xb4, 0x89,
-	0xc8, 0x84, 0xc4, 0x9a, 0x45, 0xa2, 0x79, 0x03, 0x16, 0xc6, 0x15, 0xf0, 0x5d, 0xbf, 0x09, 0xc6,
-	0x26, 0x9a, 0x60, 0x35, 0xac, 0xc0, 0x5c, 0x84, 0x4f, 0xbc, 0xb1, 0x77, 0x4a, 0x17, 0xc3, 0xac,
-	0x95, 0x11, 0x70, 0x6b, 0x53, 0x2d, 0x93, 0xb4, 0xf6, 0x8f, 0x15, 0x30, 0x5e, 0x9c, 0xb8, 0x5e,
-	0x14, 0xff, 0x1f, 0x9d, 0xbf, 0xe4, 0xf3, 0xd3, 0x94, 0x7a, 0x7e, 0xc2, 0x36, 0xa7, 0x36, 0x4c,
-	0x62, 0x73, 0x00, 0x37, 0xb6, 0x51, 0xb2, 0x4b, 0x5d, 0x59, 0xb9, 0xeb, 0x88, 0x13, 0x3b, 0x4a,
-	0xda, 0xbd, 0x04, 0x45, 0xc4, 0xf4, 0x2b, 0x16, 0x47, 0xc1, 0xe6, 0x05, 0x08, 0xb9, 0xe9, 0x59,
-	0x3b, 0x35, 0x8f, 0xa7, 0x99, 0xaf, 0x60, 0x89, 0xaf, 0x8f, 0x37, 0xf1, 0x73, 0xb8, 0xe4, 0x7a,
-	0x11, 0x2e, 0x62, 0xe7, 0x13, 0xf1, 0x0c, 0xbc, 0xe9, 0x45, 0xc8, 0x49, 0xc2, 0xe8, 0x82, 0x09,
-	0xd3, 0xae, 0x49, 0x45, 0xcc, 0x55, 0x78, 0x88, 0x23, 0xcb, 0xc0, 0x1e, 0xc6, 0xc7, 0x61, 0x92,
-	0xd8, 0x6f, 0x7c, 0xb4, 0x49, 0x8b, 0xa4, 0x86, 0xe1, 0xa0, 0xe8, 0xdd, 0x22, 0x56, 0xde, 0x26,
-	0x07, 0x16, 0x62, 0x0d, 0x1f, 0x33, 0xf0, 0xb1, 0x60, 0xa0, 0xac, 0x50, 0x63, 0xad, 0x56, 0x99,
-	0xf9, 0xd7, 0x15, 0xb2, 0x3b, 0xa7, 0xf2, 0x9b, 0x5e, 0xaf, 0x47, 0x5d, 0x95, 0x30, 0x1e, 0x26,
-	0x5c, 0x49, 0xa5, 0xad, 0x30, 0x4c, 0xd8, 0xc0, 0x08, 0x34, 0x12, 0x5a, 0x44, 0xe1, 0x20, 0xd5,
-	0xc4, 0xd6, 0x93, 0x40, 0xc3, 0xa3, 0x98, 0x84, 0x63, 0x0e, 0xe6, 0x4d, 0x32, 0x8a, 0xf9, 0x67,
-	0x64, 0x9f, 0xd2, 0x19, 0xc3, 0x77, 0xcc, 0x26, 0x80, 0x3b, 0x2e, 0xd2, 0x9e, 0x19, 0x54, 0x0d,
-	0x2c, 0x74, 0xce, 0xe4, 0xcc, 0x27, 0x70, 0xc3, 0x42, 0xc4, 0xd1, 0xd9, 0xb1, 0xb8, 0x54, 0x45,
-	0x9f, 0x57, 0x51, 0x7c, 0xde, 0x4d, 0x58, 0xe2, 0x25, 0xf9, 0xb5, 0xbf, 0x0b, 0x0d, 0x0b, 0x39,
-	0xd8, 0x4f, 0xa8, 0x6a, 0xbf, 0xf7, 0x7e, 0x68, 0x7e, 0x0c, 0x37, 0x45, 0x6d, 0x93, 0xac, 0xaa,
-	0x9b, 0x64, 0x96, 0x6f, 0xc5, 0xf4, 0x58, 0x2c, 0xcc, 0xbe, 0xff, 0xa9, 0x72, 0x65, 0xd2, 0x22,
-	0x6d, 0xc2, 0xac, 0x63, 0x0f, 0x6d, 0xc7, 0x4b, 0xe8, 0x31, 0xac, 0x66, 0x8d, 0xbf, 0xf1, 0xbe,
-	0x33, 0x8a, 0x99, 0xc7, 0xa8, 0x59, 0xe4, 0x37, 0xf5, 0x5e, 0x03, 0xdb, 0x0b, 0xbc, 0xa0, 0xcf,
-	0xee, 0xbe, 0x32, 0x82, 0xf1, 0x1e, 0xd4, 0x47, 0x81, 0x8b, 0xa2, 0xa3, 0xf4, 0x10, 0x8d, 0x5c,
-	0x72, 0x27, 0x53, 0xb3, 0xae, 0x11, 0xba, 0x35, 0x26, 0x1b, 0x0f, 0x60, 0xde, 0x09, 0xa3, 0x68,
-	0x34, 0x4c, 0x8e, 0xd8, 0xe6, 0x3a, 0x4d, 0x18, 0xaf, 0x32, 0x2a, 0xdd, 0x30, 0x31, 0x1b, 0xf1,
-	0x4b, 0x41, 0x3f, 0x65, 0x9b, 0xa1, 0x6c, 0x8c, 0xca, 0xd8, 0x7e, 0x0a, 0x4b, 0x29, 0x1b, 0xae,
-	0xfa, 0x28, 0x0c, 0x50, 0xca, 0x7f, 0x09, 0x87, 0x07, 0xd6, 0x02, 0x2b, 0xc6, 0x16, 0xec, 0x07,
-	0x88, 0x89, 0xad, 0x42, 0x9d, 0x72, 0x1d, 0x79, 0xc1, 0x51, 0x6f, 0x94, 0x8c, 0x22, 0x44, 0xa3,
-	0x28, 0x6b, 0x9e, 0xd2, 0x77, 0x82, 0x2d, 0x42, 0x35, 0x3e, 0x85, 0xa5, 0x21, 0x0a, 0x5c, 0x5c,
-	0x81, 0x8b, 0xfd, 0xb3, 0x17, 0x06, 0x69, 0x05, 0x73, 0x44, 0x60, 0x91, 0x15, 0x6f, 0xb2, 0x52,
-	0x5a, 0x83, 0xf9, 0x35, 0x39, 0x0c, 0x67, 0x81, 0x97, 0xb2, 0xc6, 0x9e, 0x40, 0x2d, 0xb9, 0x18,
-	0xd2, 0xf9, 0x36, 0x2f, 0xcd, 0x67, 0x51, 0xec, 0xf0, 0x62, 0x88, 0x58, 0x04, 0x84, 0x25, 0xcc,
-	0x03, 0x72, 0xf4, 0x95, 0x55, 0xf3, 0x83, 0xbb, 0x06, 0x55, 0xd7, 0xd3, 0x06, 0x2d, 0x6a, 0x50,
-	0x5b, 0x75, 0x3d, 0xf3, 0xb7, 0x70, 0x9f, 0xd3, 0xc8, 0xa2, 0xcc, 0x1f, 0xd5, 0xe6, 0x7f, 0xae,
-	0x40, 0x53, 0xab, 0x9f, 0x2a, 0x7e, 0x06, 0x57, 0x5c, 0xce, 0x32, 0x6d, 0x44, 0xaa, 0x39, 0x06,
-	0xf1, 0x32, 0xc6, 0x36, 0xcc, 0xc7, 0xbc, 0x66, 0x1a, 0x37, 0xc9, 0x71, 0xa5, 0x5a, 0xb9, 0x25,
-	0x89, 0x99, 0xdf, 0x55, 0xe0, 0x41, 0x7e, 0x77, 0xf0, 0xfd, 0x7c, 0x04, 0x37, 0x5c, 0x1d, 0x57,
-	0x1a, 0x30, 0xbe, 0xab, 0x6d, 0x80, 0xc6, 0x84, 0x1c, 0x35, 0xe6, 0x17, 0xc4, 0x5b, 0x1f, 0x44,
-	0xa8, 0x87, 0xa2, 0x88, 0xc5, 0x9a, 0x5d, 0xef, 0x5b, 0xd1, 0xd1, 0x34, 0x61, 0x16, 0x07, 0xc0,
-	0x41, 0xe6, 0xbd, 0xc6, 0xdf, 0xe6, 0x53, 0xe2, 0x61, 0x75, 0x0a, 0xf8, 0x76, 0x2c, 0xc0, 0xf4,
-	0x9b, 0xd8, 0xfb, 0x16, 0x31, 0x4f, 0x40, 0x3f, 0xcc, 0x04, 0x96, 0xba, 0x28, 0xe9, 0xda, 0x3d,
-	0xf4, 0x42, 0x3e, 0xbe, 0x3d, 0x81, 0x19, 0xdb, 0x21, 0x1b, 0x2f, 0x9d, 0x0a, 0xe2, 0x4d, 0x5d,
-	0x2a, 0xd2, 0x26, 0x2c, 0x2c, 0xae, 0xa0, 0xfc, 0xc6, 0x1d, 0xb8, 0xe4, 0x1c, 0x23, 0x87, 0x06,
-	0x24, 0x95, 0xd5, 0xd9, 0xa7, 0xd3, 0x3d, 0xdb, 0x8f, 0x91, 0x95, 0x52, 0xcd, 0x75, 0x68, 0x08,
-	0xb5, 0x4e, 0xe2, 0x03, 0x5f, 0xc1, 0xcd, 0xae, 0x7d, 0x8a, 0xb0, 0x13, 0x8d, 0x87, 0xb6, 0x23,
-	0xda, 0x7a, 0x17, 0x20, 0xf1, 0x06, 0xe8, 0x95, 0x17, 0xb8, 0xe1, 0x19, 0xd9, 0x4d, 0xc9, 0xa1,
-	0x81, 0x23, 0x1a, 0x4b, 0x30, 0x9d, 0x9c, 0x6f, 0xdb, 0x43, 0x62, 0x12, 0x29, 0xa5, 0xdf, 0xe6,
-	0x13, 0x68, 0x4a, 0x8a, 0x45, 0x1f, 0x3a, 0x1d, 0xdb, 0xa7, 0xc8, 0x25, 0x4a, 0x67, 0x9f, 0xd6,
-	0x92, 0x68, 0x84, 0x2c, 0x4a, 0x32, 0x97, 0x60, 0xd1, 0x0a, 0x7d, 0xbf, 0xe3, 0x7a, 0xd2, 0x3d,
-	0xfd, 0xcf, 0xe1, 0x06, 0x57, 0xc0, 0xab, 0x7b, 0x08, 0xf3, 0x01, 0x3a, 0xeb, 0xa2, 0xfe, 0x00,
-	0x05, 0xc9, 0xe1, 0xf9, 0x8e, 0xcb, 0x86, 0x43, 0xa2, 0x9a, 0x9f, 0x40, 0xcb, 0x42, 0x78, 0xce,
-	0xa2, 0x2d, 0xdb, 0xf3, 0x91, 0x3b, 0x9e, 0x33, 0xe2, 0xe6, 0x63, 0x67, 0x87, 0x09, 0x3b, 0xea,
-	0x9b, 0x9f, 0xc1, 0x5d, 0xbd, 0xd4, 0x24, 0x1d, 0xdc, 0xc4, 0xfb, 0x5c, 0x2f, 0x42, 0xf1, 0x31,
-	0x39, 0xcf, 0x0a, 0x0d, 0x5a, 0xc6, 0xbb, 0x16, 0x5f, 0xc6, 0x6f, 0x90, 0xb7, 0x60, 0x79, 0xcb,
-	0x0b, 0x6c, 0xdf, 0xfb, 0x16, 0xbd, 0x1c, 0xf6, 0x23, 0x5b, 0x9c, 0x47, 0xf8, 0xa4, 0xa4, 0x14,
-	0xf3, 0xe2, 0xbf, 0x81, 0x26, 0xee, 0x2c, 0x2f, 0xe8, 0x6b, 0xa4, 0x8d, 0x9f, 0x49, 0xb3, 0xf0,
-	0xa1, 0x30, 0x0b, 0x45, 0x41, 0xcd, 0x5c, 0x34, 0xff, 0xb3, 0x02, 0x4b, 0x22, 0xd7, 0xd8, 0xb7,
-	0x60, 0xdd, 0x31, 0xd9, 0x51, 0x99, 0x2f, 0x2a, 0xd2, 0xcd, 0xdf, 0x48, 0x33, 0x29, 0xbc, 0x5f,
-	0x92, 0x70, 0xf5, 0xd0, 0x63, 0x5b, 0x7d, 0xcd, 0xca, 0x08, 0x24, 0x78, 0x62, 0xed, 0x26, 0x0c,
-	0x74, 0x43, 0x15, 0x68, 0xc6, 0x27, 0xb0, 0x48, 0xa3, 0x6d, 0x17, 0xd7, 0xf6, 0xc6, 0x76, 0x4e,
-	0x76, 0x06, 0x76, 0x9f, 0x5c, 0x56, 0xe0, 0xa1, 0xd1, 0x17, 0x9a, 0x31, 0x2c, 0xcb, 0x3d, 0xc6,
-	0x0f, 0xf0, 0x21, 0x18, 0x91, 0xd2, 0x62, 0x16, 0x62, 0xde, 0x2f, 0x68, 0x62, 0xe6, 0x74, 0x35,
-	0xf2, 0xe6, 0x3e, 0xdc, 0xc5, 0xd1, 0xe5, 0x06, 0xdd, 0xc1, 0xb7, 0x3c, 0x1f, 0x69, 0x4e, 0xd3,
-	0x9a, 0x2b, 0x63, 0x3c, 0xdf, 0x9c, 0x30, 0x3c, 0xf1, 0xd2, 0x5b, 0x48, 0xf6, 0x65, 0x3a, 0x60,
-	0xe6, 0x28, 0x14, 0x53, 0x6a, 0x97, 0x58, 0xd0, 0xc0, 0x06, 0xe9, 0x9e, 0x98, 0x71, 0x91, 0xa5,
-	0x59, 0x18, 0xcf, 0x64, 0xcc, 0x75, 0x58, 0x78, 0x81, 0x12, 0x1b, 0x2f, 0xf0, 0x89, 0xfd, 0xe9,
-	0x12, 0x2c, 0x66, 0x32, 0xfc, 0x4c, 0xfd, 0x09, 0x0d, 0xb5, 0x3c, 0x9f, 0xf4, 0x48, 0xc9, 0x05,
-	0xfc, 0x2e, 0x34, 0x04, 0xe6, 0xb7, 0x4b, 0xef, 0xbc, 0x0f, 0x8d, 0x1d, 0x52, 0xb0, 0xe1, 0x87,
-	0x31, 0x2a, 0x49, 0xf2, 0xe0, 0x20, 0x53, 0xe4, 0x9e, 0x64, 0xfd, 0xff, 0x5b, 0x05, 0x96, 0x36,
-	0x6c, 0xe7, 0x98, 0x9d, 0x35, 0xbc, 0x53, 0x6e, 0xa5, 0xcc, 0x43, 0xd5, 0xa3, 0x2e, 0x70, 0xca,
-	0xaa, 0x7a, 0xee, 0x78, 0x9c, 0xe9, 0x88, 0xd2, 0x71, 0x96, 0x32, 0x2e, 0x34, 0x81, 0x24, 0x64,
-	0xda, 0xb0, 0x54, 0x18, 0xfa, 0xe4, 0x52, 0x07, 0x4b, 0x85, 0xa1, 0x6f, 0xec, 0x01, 0xa0, 0xf3,
-	0xa1, 0x17, 0x51, 0xa1, 0x69, 0xd2, 0x25, 0x6b, 0xe2, 0x10, 0x2b, 0x36, 0x75, 0xc6, 0x02, 0xec,
-	0x08, 0x90, 0x69, 0x30, 0x7f, 0x0d, 0x77, 0x4b, 0x05, 0x70, 0x17, 0x0c, 0x3c, 0xdf, 0xf7, 0xe8,
-	0xc2, 0x9f, 0xb2, 0xd8, 0x17, 0x0e, 0xde, 0xbd, 0xd8, 0x42, 0xbe, 0x9d, 0x64, 0xe7, 0x77, 0x8e,
-	0x62, 0xfe, 0x6b, 0x05, 0x1a, 0xa2, 0x76, 0x12, 0x75, 0x53, 0xa5, 0x2d, 0xb8, 0xfc, 0xe6, 0x22,
-	0x41, 0xf1, 0x1e, 0x42, 0x2e, 0x72, 0x99, 0x66, 0x9e, 0x34, 0xe6, 0x20, 0x2a, 0x68, 0xe8, 0x9d,
-	0x72, 0x50, 0x12, 0xe6, 0xc0, 0xd3, 0x30, 0xd5, 0x31, 0x45, 0x39, 0x38, 0xd2, 0x98, 0x83, 0xe9,
-	0xa8, 0x71, 0x1c, 0x4c, 0xc7, 0x6d, 0x80, 0x63, 0x3b, 0x26, 0x4d, 0x46, 0x2e, 0xcb, 0x89, 0x72,
-	0x14, 0xf3, 0x02, 0x6e, 0xb5, 0x5d, 0x57, 0x6c, 0x86, 0x1c, 0x01, 0x7a, 0x59, 0x80, 0x76, 0xbf,
-	0x64, 0x30, 0x58, 0x04, 0x88, 0x25, 0xc8, 0xe1, 0x07, 0x33, 0x6c, 0xf9, 0x76, 0x3f, 0x26, 0x93,
-	0xe3, 0xaa, 0xc5, 0x51, 0xcc, 0x0f, 0xe1, 0xb6, 0xa6, 0x6a, 0x7e, 0x72, 0xa6, 0x13, 0xad, 0x4a,
-	0x27, 0x9a, 0xf9, 0x7b, 0x68, 0xbd, 0x08, 0x5d, 0xaf, 0x77, 0xf1, 0xff, 0x62, 0xef, 0x3d, 0xb8,
-	0xab, 0xaf, 0x5d, 0x4c, 0xa3, 0xb5, 0x2c, 0x34, 0x08, 0x4f, 0x51, 0x81, 0x89, 0x72, 0xb3, 0xee,
-	0xe1, 0x8d, 0x5a, 0x27, 0xc3, 0x2b, 0x3e, 0x83, 0x3b, 0xc4, 0x41, 0x0a, 0x2c, 0xa2, 0xbf, 0xbd,
-	0x01, 0x33, 0xc3, 0x08, 0x9d, 0xee, 0xa4, 0xba, 0xd9, 0x97, 0xf1, 0x39, 0xb9, 0x40, 0x4d, 0xaf,
-	0x55, 0x26, 0xed, 0x14, 0x26, 0x63, 0xfe, 0x9d, 0x32, 0xcd, 0x3b, 0x41, 0x12, 0x5d, 0xbc, 0x6d,
-	0x6f, 0x7f, 0x06, 0xd3, 0x78, 0xe3, 0x8c, 0x99, 0x4d, 0x0f, 0x0a, 0x44, 0xb3, 0x65, 0x65, 0x51,
-	0x19, 0xf3, 0x2f, 0xa0, 0xa5, 0xed, 0x0c, 0x7e, 0xf2, 0xb4, 0x61, 0x16, 0xf9, 0x08, 0xc7, 0x50,
-	0x69, 0x70, 0x5e, 0x54, 0x47, 0xd6, 0x26, 0x6b, 0x2c, 0x66, 0x34, 0xe0, 0xd2, 0xb1, 0x1d, 0xbf,
-	0x08, 0xa3, 0x74, 0xf9, 0xa7, 0x9f, 0xe6, 0xbf, 0x57, 0xc0, 0x20, 0x0a, 0x0e, 0xc2, 0xd0, 0xcf,
-	0x3c, 0x63, 0x13, 0x66, 0xb1, 0x1f, 0x63, 0xd7, 0x0a, 0x24, 0xcb, 0x96, 0x7e, 0xe3, 0xf8, 0x20,
-	0x3c, 0x0b, 0x50, 0xb4, 0x97, 0xa5, 0xe0, 0x32, 0xc2, 0x38, 0x07, 0xb7, 0x27, 0xe7, 0xe0, 0xd2,
-	0x3c, 0xfc, 0x20, 0x74, 0x11, 0xf1, 0x95, 0xd3, 0x16, 0xf9, 0x8d, 0x43, 0x78, 0xdf, 0x1b, 0x78,
-	0x09, 0x71, 0x93, 0x53, 0x16, 0xfd, 0x30, 0xde, 0x87, 0x77, 0x06, 0xf6, 0x79, 0xea, 0xa3, 0xc8,
-	0x2a, 0xbf, 0x68, 0xcc, 0x10, 0x0e, 0xb5, 0xc0, 0xfc, 0x8f, 0x0a, 0x5c, 0x1f, 0x37, 0xe3, 0x47,
-	0xf6, 0x5e, 0x0f, 0x61, 0x9e, 0x7c, 0xee, 0x9f, 0xa2, 0x88, 0x1a, 0x4a, 0x1d, 0x98, 0x44, 0x95,
-	0xbd, 0x5c, 0xad, 0xd4, 0xcb, 0x4d, 0x2b, 0x5e, 0xce, 0xdc, 0x87, 0x46, 0xea, 0x4a, 0x70, 0x4b,
-	0x84, 0x55, 0xf1, 0xb1, 0x30, 0x45, 0xef, 0xa8, 0x73, 0x40, 0x18, 0x42, 0x3a, 0x3b, 0x49, 0x2a,
-	0x51, 0x50, 0xc8, 0x2f, 0x45, 0x0b, 0x96, 0x39, 0x47, 0xf0, 0xe3, 0x54, 0x78, 0x1b, 0x56, 0x14,
-	0x9d, 0x7c, 0x9d, 0x7f, 0x02, 0xcb, 0x9c, 0x8f, 0x50, 0xea, 0x14, 0x27, 0x5e, 0x95, 0x9f, 0x78,
-	0x34, 0x39, 0x21, 0x89, 0xf2, 0xaa, 0x7f, 0x0e, 0xcd, 0xf1, 0x62, 0xc2, 0xa5, 0xb1, 0x7c, 0x33,
-	0x88, 0xdd, 0xc8, 0x81, 0xa8, 0x5d, 0xa0, 0xe1, 0x10, 0x54, 0xd6, 0xc0, 0xaf, 0xc4, 0xa7, 0x70,
-	0x09, 0x05, 0x49, 0x94, 0xe5, 0xdc, 0x5b, 0xfa, 0x3e, 0xe1, 0xd6, 0x60, 0x2a, 0x50, 0xb0, 0x04,
-	0xff, 0xc8, 0xcf, 0x5d, 0xce, 0x25, 0xfd, 0x90, 0xee, 0x37, 0x3e, 0x15, 0xbd, 0x51, 0x8e, 0x81,
-	0xaa, 0x23, 0x7a, 0x0c, 0xcb, 0x2c, 0xae, 0xdb, 0xf5, 0x82, 0x93, 0x09, 0x02, 0xc1, 0x03, 0x02,
-	0xb3, 0x10, 0x05, 0xde, 0x2e, 0x18, 0xfc, 0x98, 0xdc, 0x3b, 0x6d, 0x84, 0x41, 0x82, 0x82, 0xa4,
-	0x3b, 0x1a, 0x0c, 0xec, 0xa8, 0x1c, 0xb9, 0xf1, 0x1b, 0x72, 0xa3, 0x24, 0x0b, 0x49, 0x83, 0x16,
-	0x53, 0x3a, 0xeb, 0x49, 0xa9, 0x4f, 0x04, 0x51, 0x36, 0x68, 0x4c, 0xc0, 0x7c, 0x0c, 0x37, 0xb7,
-	0x51, 0xf2, 0xe5, 0x28, 0x4c, 0xec, 0x97, 0xb1, 0x7c, 0x50, 0xd5, 0x99, 0x73, 0x00, 0x4d, 0x49,
-	0x80, 0x37, 0x65, 0x1d, 0xa6, 0x47, 0x98, 0xca, 0x0c, 0x59, 0x11, 0x0c, 0xc9, 0x84, 0xd8, 0xc0,
-	0x10, 0x56, 0xf3, 0x5f, 0x2a, 0x04, 0x1a, 0x41, 0x4a, 0x4b, 0x0f, 0x25, 0xf8, 0x1c, 0x9e, 0x1e,
-	0xf8, 0x89, 0x04, 0x3b, 0xbf, 0x49, 0x54, 0xec, 0x5c, 0xd9, 0xcd, 0x11, 0xc7, 0x4a, 0x4f, 0x72,
-	0x6a, 0x81, 0xf1, 0x05, 0x5c, 0x66, 0xc4, 0xc3, 0x8b, 0x21, 0xf5, 0xdd, 0xf3, 0xeb, 0xb7, 0x74,
-	0x77, 0x53, 0xd9, 0xdd, 0x19, 0x2f, 0xc1, 0x90, 0x17, 0xac, 0x09, 0xfc, 0x8a, 0xfd, 0xcb, 0x0a,
-	0xbc, 0xb3, 0x15, 0x5f, 0x04, 0x4e, 0x39, 0xa6, 0x91, 0x5e, 0x36, 0xb3, 0xab, 0x67, 0xf6, 0x65,
-	0xbc, 0x0f, 0xd7, 0x7c, 0x3b, 0x66, 0xe0, 0xc5, 0x14, 0xdc, 0x58, 0x59, 0x35, 0x9e, 0x56, 0x3f,
-	0xf8, 0xc8, 0x92, 0x8b, 0x8a, 0xf2, 0xaf, 0x0b, 0x60, 0x30, 0x3b, 0x78, 0xf3, 0x0e, 0x49, 0xd7,
-	0xe3, 0x23, 0x6d, 0x59, 0xae, 0x6a, 0x01, 0xa6, 0x07, 0x49, 0x76, 0x5e, 0xa6, 0x1f, 0x98, 0x6a,
-	0x27, 0xd9, 0x21, 0x99, 0x7e, 0xb0, 0xde, 0x60, 0x5a, 0xf9, 0xea, 0xfe, 0xa9, 0x02, 0x37, 0x29,
-	0x6c, 0xae, 0x7b, 0x31, 0xf0, 0xbd, 0xe0, 0x44, 0x0e, 0x8a, 0x12, 0x3b, 0xea, 0xa3, 0x34, 0xa7,
-	0xc1, 0xbe, 0xf0, 0x3c, 0xc0, 0xbc, 0xac, 0x67, 0xc8, 0x6f, 0xe3, 0x09, 0x49, 0x12, 0x1d, 0xa0,
-	0x68, 0x40, 0xaa, 0x2e, 0xcf, 0x9d, 0xa5, 0xec, 0x4a, 0xf2, 0xac, 0xa6, 0x49, 0x9e, 0xad, 0x40,
-	0x53, 0x32, 0x93, 0x6f, 0x05, 0x5d, 0x33, 0xd8, 0x29, 0x1c, 0x12, 0x03, 0x4b, 0xd7, 0xcc, 0xe7,
-	0x64, 0xcd, 0xf0, 0x02, 0xfc, 0x9a, 0xb9, 0x0d, 0x40, 0x1b, 0x7a, 0x40, 0xe5, 0x2a, 0x24, 0x11,
-	0x33, 0xa6, 0x98, 0xbf, 0x03, 0xf3, 0xe5, 0xd0, 0xb5, 0x13, 0x7a, 0x50, 0xde, 0x0a, 0xa3, 0x03,
-	0x6f, 0x88, 0x7c, 0x2f, 0x10, 0xd7, 0xea, 0x4f, 0x45, 0xc0, 0x40, 0x69, 0xc2, 0x9e, 0x81, 0xfa,
-	0xca, 0xd2, 0x1e, 0xbf, 0x86, 0x7b, 0x79, 0x95, 0xbf, 0x3d, 0x5c, 0xe1, 0x6f, 0xab, 0xd0, 0xa4,
-	0xda, 0xb5, 0x4d, 0x2a, 0xc9, 0xfd, 0x18, 0x9f, 0xc1, 0x6c, 0xe8, 0x53, 0xb5, 0x93, 0x42, 0x53,
-	0xc6, 0x02, 0x58, 0x38, 0x40, 0x67, 0x54, 0x78, 0x6a, 0x42, 0xe1, 0x54, 0xc0, 0x78, 0x42, 0x84,
-	0x79, 0x54, 0xd0, 0x8a, 0xfe, 0x3a, 0x7c, 0x33, 0x93, 0x1c, 0x83, 0x4d, 0x98, 0xdf, 0xd8, 0xd9,
-	0x8c, 0x1b, 0xd3, 0x04, 0x3c, 0xc0, 0x51, 0xcc, 0x5b, 0xb0, 0x2c, 0xf7, 0x88, 0x18, 0x00, 0xb4,
-	0xba, 0x28, 0x79, 0x66, 0xfb, 0x76, 0xe0, 0xa0, 0xe8, 0x99, 0x1d, 0xb8, 0x67, 0x9e, 0x9b, 0x1c,
-	0x0b, 0xdd, 0xb6, 0x02, 0x73, 0x6f, 0xd2, 0x02, 0x16, 0x0f, 0x66, 0x04, 0x7c, 0x82, 0xd1, 0x6b,
-	0xe0, 0xab, 0x31, 0xa1, 0xc5, 0x2e, 0xd9, 0x3b, 0x63, 0x4c, 0xf7, 0x73, 0x24, 0xec, 0x55, 0xe6,
-	0x29, 0xb9, 0xfe, 0xd6, 0xf0, 0xf0, 0xf3, 0xe2, 0x4b, 0x78, 0xc7, 0x95, 0x39, 0xd8, 0x96, 0x79,
-	0x4f, 0xe9, 0x31, 0x81, 0x8b, 0x76, 0x9c, 0x2a, 0x6d, 0xba, 0xe3, 0xb5, 0x99, 0x66, 0x3c, 0x7f,
-	0x40, 0x76, 0x34, 0xfd, 0xe6, 0x22, 0x7c, 0x81, 0x66, 0xb6, 0x61, 0x59, 0xae, 0x85, 0x6f, 0x17,
-	0xa7, 0xe2, 0x20, 0x5b, 0xed, 0x02, 0xcd, 0xfc, 0xfb, 0x0a, 0x34, 0x29, 0x28, 0xe1, 0x07, 0x5b,
-	0xba, 0x0a, 0xd7, 0xd2, 0xef, 0x7d, 0xdf, 0xe5, 0x96, 0xa8, 0x4c, 0xe6, 0x39, 0xf7, 0xd0, 0x19,
-	0x07, 0x10, 0x91, 0xc9, 0x78, 0x86, 0xc9, 0x56, 0xf1, 0x43, 0xff, 0x05, 0xdc, 0x6c, 0xfb, 0x7e,
-	0x78, 0xf6, 0x43, 0x6d, 0xc6, 0xbe, 0x53, 0x52, 0xc0, 0xab, 0x7f, 0x06, 0x2b, 0x9b, 0x5e, 0x6c,
-	0xbf, 0x55, 0x0d, 0x77, 0xe0, 0x96, 0xaa, 0x83, 0xaf, 0xc4, 0x85, 0x26, 0xc5, 0x6b, 0xfc, 0x88,
-	0x53, 0xa4, 0xaa, 0x4c, 0x91, 0x5b, 0xb0, 0x2c, 0xd7, 0xc2, 0x1b, 0x71, 0x02, 0x4b, 0x1b, 0xc7,
-	0xc8, 0x39, 0x69, 0x3b, 0x0e, 0x8a, 0xcb, 0x6f, 0x5b, 0x3f, 0x67, 0xe7, 0xc6, 0x2a, 0xb9, 0x2d,
-	0x5f, 0x15, 0x16, 0x47, 0xdb, 0xe1, 0xa2, 0xe6, 0xb5, 0xad, 0x98, 0xbf, 0x2f, 0x27, 0x52, 0x66,
-	0x13, 0x1a, 0x42, 0x65, 0xbc, 0x21, 0xf7, 0xc8, 0x42, 0xdd, 0x18, 0x45, 0x78, 0x6b, 0xeb, 0xb8,
-	0x5e, 0xb2, 0x1b, 0xf6, 0x0f, 0xcf, 0x3d, 0xe1, 0x2e, 0xd2, 0x7c, 0x42, 0x72, 0x51, 0x3a, 0x26,
-	0x7e, 0xda, 0x1b, 0x50, 0x4b, 0xce, 0xc7, 0x17, 0x22, 0xe4, 0x37, 0x43, 0x23, 0x93, 0x94, 0xc9,
-	0x56, 0x14, 0x0e, 0x64, 0xcd, 0x5a, 0x99, 0x6f, 0x48, 0x20, 0x2c, 0xc9, 0x88, 0x18, 0x10, 0x40,
-	0xa7, 0xf8, 0x60, 0xcf, 0x50, 0x16, 0x6a, 0x30, 0xd9, 0x19, 0x17, 0xa7, 0x77, 0x89, 0x63, 0xc2,
-	0xa3, 0xaf, 0xe1, 0xda, 0xc6, 0xf8, 0xb5, 0x01, 0x55, 0x08, 0x30, 0xb3, 0x61, 0x75, 0xda, 0x87,
-	0x9d, 0x7a, 0xc5, 0xb8, 0x0a, 0x73, 0xfb, 0x5f, 0x75, 0xac, 0x57, 0xd6, 0xce, 0x61, 0xa7, 0x5e,
-	0xc5, 0x45, 0xed, 0x83, 0x83, 0xce, 0xde, 0x66, 0xbd, 0x66, 0xd4, 0xe1, 0xca, 0x6e, 0xfb, 0xf5,
-	0xd7, 0x47, 0x07, 0x1d, 0xab, 0xbb, 0xd3, 0x3d, 0xac, 0xd7, 0x31, 0xf3, 0x5e, 0xe7, 0xd5, 0xd1,
-	0xb3, 0xdd, 0xfd, 0x8d, 0xe7, 0xf5, 0xd6, 0xa3, 0xe7, 0xb0, 0x94, 0x93, 0x4c, 0x35, 0x2e, 0xc1,
-	0x54, 0x7b, 0x77, 0xb7, 0x5e, 0x31, 0x66, 0xa1, 0xb6, 0xbb, 0xf3, 0x15, 0x56, 0x3d, 0x0b, 0xb5,
-	0xcd, 0x4e, 0x7b, 0xb3, 0x3e, 0x65, 0x5c, 0x87, 0x6b, 0x9b, 0x9d, 0x8d, 0xfd, 0x17, 0x2f, 0x76,
-	0xba, 0xdd, 0x9d, 0xfd, 0xbd, 0x9d, 0xbd, 0xed, 0x7a, 0xed, 0xd1, 0x31, 0x5c, 0xd7, 0xa4, 0xe3,
-	0x0c, 0x03, 0xe6, 0xbb, 0xed, 0xad, 0xce, 0x8b, 0xfd, 0xcd, 0xce, 0xd1, 0x6e, 0xa7, 0xfd, 0x15,
-	0xb6, 0x99, 0xa7, 0x75, 0xf6, 0x0e, 0x3b, 0x56, 0xbd, 0x8a, 0x8d, 0x1d, 0xd3, 0xb6, 0x3b, 0x87,
-	0xf5, 0x29, 0x63, 0x09, 0xae, 0x8f, 0x29, 0x5b, 0xfb, 0xd6, 0x46, 0xe7, 0xa8, 0xf3, 0xab, 0x9d,
-	0xc3, 0x7a, 0xed, 0xd1, 0x17, 0x70, 0x33, 0x37, 0xe5, 0x62, 0xcc, 0xc1, 0xf4, 0x97, 0x2f, 0x3b,
-	0xd6, 0xd7, 0xf5, 0x0a, 0xfe, 0xd9, 0x3d, 0x6c, 0x5b, 0x87, 0xf5, 0xaa, 0x71, 0x05, 0x66, 0xb7,
-	0x76, 0xf6, 0xda, 0xbb, 0x3b, 0xaf, 0x3b, 0xf5, 0xa9, 0x47, 0xcb, 0x30, 0xbf, 0x91, 0xde, 0xaf,
-	0x8d, 0xa5, 0x48, 0x15, 0xf5, 0xca, 0xfa, 0x7f, 0x6f, 0xc1, 0x0d, 0xfd, 0xdb, 0x22, 0xc3, 0x87,
-	0x77, 0xfa, 0xf2, 0xdb, 0x1b, 0xe3, 0x91, 0x30, 0x92, 0x85, 0xcf, 0x7f, 0x9a, 0x3f, 0x29, 0xe3,
-	0xe5, 0xa7, 0x0d, 0xad, 0x4d, 0x7c, 0x23, 0xa3, 0xd6, 0x96, 0xff, 0x50, 0x47, 0xad, 0xad, 0xe8,
-	0xcd, 0xce, 0x73, 0x98, 0xa1, 0x51, 0xa3, 0x21, 0x1d, 0x5d, 0x95, 0xb7, 0x37, 0xcd, 0x96, 0x96,
-	0x41, 0x52, 0x66, 0x93, 0xa7, 0x1a, 0x92, 0x32, 0xf5, 0x71, 0x88, 0xa4, 0x4c, 0xf7, 0xc0, 0xc3,
-	0x81, 0xf9, 0x58, 0x78, 0x22, 0x61, 0x88, 0xd9, 0xee, 0xfc, 0x17, 0x19, 0xcd, 0xd5, 0x42, 0x46,
-	0xbe, 0x12, 0x0f, 0xea, 0xf2, 0x3b, 0x05, 0xe3, 0x3d, 0x59, 0x3a, 0xf7, 0x95, 0x42, 0xf3, 0x51,
-	0x09, 0x2b, 0x5f, 0x55, 0x08, 0xc6, 0x48, 0x79, 0x14, 0x61, 0x88, 0x83, 0x55, 0xfc, 0xd2, 0xa2,
-	0xf9, 0x7e, 0x29, 0xb3, 0xd4, 0xb6, 0x7e, 0x71, 0xdb, 0xb6, 0x27, 0x6f, 0xdb, 0x76, 0x59, 0xdb,
-	0xfa, 0xca, 0x53, 0x08, 0xe3, 0x27, 0x45, 0x1a, 0xa4, 0xd7, 0x14, 0x52, 0xdb, 0xca, 0x1e, 0x56,
-	0xfc, 0x16, 0xae, 0xc6, 0xfc, 0x3b, 0x05, 0xe3, 0xa1, 0x3c, 0x12, 0xfa, 0x27, 0x13, 0xcd, 0x77,
-	0x8b, 0xf8, 0xc4, 0xa8, 0x6f, 0x36, 0x66, 0xef, 0x10, 0x8c, 0xbb, 0xb2, 0x90, 0xf2, 0x04, 0xa2,
-	0x69, 0xe6, 0xb0, 0xf0, 0x2a, 0xbf, 0x81, 0x2b, 0x36, 0xf7, 0x60, 0xc0, 0x10, 0x2f, 0x88, 0xf3,
-	0xde, 0x36, 0x34, 0x1f, 0x16, 0xb0, 0x49, 0x16, 0xdb, 0x0c, 0x67, 0x2f, 0x59, 0xac, 0x7b, 0x88,
-	0x20, 0x59, 0xac, 0x47, 0xe8, 0x9f, 0xc3, 0x62, 0x5f, 0x87, 0xf3, 0x36, 0xd6, 0xe4, 0xd1, 0x2a,
-	0x86, 0xe3, 0x37, 0x1f, 0x4f, 0xc2, 0x2f, 0x35, 0xc6, 0x61, 0x50, 0x6b, 0xa9, 0x31, 0x3a, 0x6c,
-	0xb8, 0xd4, 0x18, 0x3d, 0x48, 0xbb, 0x07, 0xd7, 0x22, 0x11, 0x35, 0x6d, 0x88, 0x8e, 0xa2, 0x00,
-	0xaa, 0xdd, 0x7c, 0xaf, 0x98, 0x53, 0x76, 0xa9, 0x04, 0x41, 0x2d, 0xbb, 0x54, 0x05, 0x92, 0xdd,
-	0x6c, 0x69, 0x19, 0xa4, 0x7e, 0x48, 0x18, 0x1e, 0x5a, 0xea, 0x07, 0x1d, 0x1c, 0x5b, 0xea, 0x07,
-	0x3d, 0x92, 0xfa, 0x39, 0xcc, 0x44, 0x24, 0x78, 0x96, 0xec, 0x53, 0x61, 0xd4, 0x92, 0x7d, 0x3a,
-	0xb4, 0xf4, 0x1e, 0x5c, 0xa2, 0xca, 0xd6, 0x0d, 0x1d, 0xb3, 0x80, 0x95, 0x6e, 0xde, 0xd5, 0x73,
-	0x48, 0xc6, 0x11, 0x18, 0x9c, 0x6c, 0x9c, 0x8a, 0x80, 0x96, 0x8c, 0xd3, 0x81, 0x9b, 0x9f, 0xc3,
-	0xcc, 0x80, 0xe0, 0x87, 0x25, 0x65, 0x2a, 0xb0, 0x59, 0x52, 0xa6, 0x43, 0x1d, 0xbf, 0x02, 0xe8,
-	0x8f, 0xd1, 0xbe, 0xc6, 0x3d, 0x79, 0x42, 0x6b, 0xd0, 0xb9, 0xcd, 0xfb, 0xb9, 0x4c, 0x92, 0xe2,
-	0x68, 0x0c, 0xff, 0x94, 0x14, 0xeb, 0x11, 0xa5, 0x92, 0xe2, 0x1c, 0xf0, 0x28, 0xf6, 0x37, 0x11,
-	0x07, 0xf7, 0x94, 0xfc, 0x4d, 0x1e, 0xae, 0x54, 0xf2, 0x37, 0xf9, 0x80, 0xd1, 0x5f, 0x91, 0x0e,
-	0x61, 0xe0, 0x4f, 0x43, 0x69, 0xab, 0x0e, 0x31, 0xda, 0xcc, 0xe1, 0xd2, 0x86, 0x40, 0x62, 0x8c,
-	0xaa, 0x86, 0x40, 0xf9, 0xd8, 0x47, 0x35, 0x04, 0x2a, 0x02, 0x33, 0xfe, 0xa1, 0x02, 0x8d, 0x7e,
-	0x0e, 0x1c, 0xcf, 0xf8, 0x28, 0x4f, 0x53, 0x2e, 0x88, 0xb1, 0xb9, 0x3e, 0xa1, 0x88, 0xea, 0x68,
-	0x55, 0x18, 0x9d, 0xea, 0x68, 0x8b, 0xb1, 0x7a, 0xaa, 0xa3, 0x2d, 0x83, 0xe6, 0xbd, 0x86, 0xcb,
-	0x71, 0x06, 0x87, 0x93, 0x86, 0x31, 0x07, 0x9e, 0xd7, 0x7c, 0x90, 0xcf, 0x25, 0xef, 0xd2, 0x3c,
-	0xba, 0x4d, 0xde, 0xa5, 0xf3, 0x20, 0x75, 0xf2, 0x2e, 0x9d, 0x8f, 0x90, 0x3b, 0x84, 0xb9, 0x28,
-	0x05, 0xbb, 0x19, 0xa6, 0x82, 0x2f, 0x52, 0xd0, 0x71, 0xcd, 0x7b, 0x79, 0x3c, 0xbc, 0xd6, 0x11,
-	0x2c, 0x44, 0x1a, 0x28, 0x9b, 0xf1, 0x81, 0xb4, 0x32, 0x8a, 0x31, 0x72, 0xcd, 0xb5, 0x09, 0xd8,
-	0x95, 0xf5, 0x9a, 0x01, 0xdd, 0x94, 0xf5, 0xaa, 0xc7, 0xc7, 0x29, 0xeb, 0x35, 0x07, 0x2a, 0x87,
-	0xf7, 0xbf, 0x9e, 0x88, 0x85, 0x93, 0xf6, 0xbf, 0x02, 0x20, 0x9d, 0xb4, 0xff, 0x15, 0x61, 0xea,
-	0x70, 0xe0, 0x2e, 0x42, 0xb8, 0xa4, 0xc0, 0x3d, 0x1f, 0x70, 0x27, 0x05, 0xee, 0x45, 0x38, 0xb3,
-	0x73, 0x58, 0xf4, 0x75, 0x00, 0x2e, 0x69, 0xc1, 0x94, 0xa2, 0xc6, 0xa4, 0x05, 0x33, 0x01, 0x28,
-	0xec, 0x4b, 0x98, 0x1d, 0x30, 0x84, 0x96, 0xb4, 0x23, 0xeb, 0xc0, 0x5e, 0xd2, 0x8e, 0xac, 0xc5,
-	0x76, 0xe1, 0x35, 0xd8, 0xcf, 0xe0, 0x5a, 0x1a, 0x57, 0xaa, 0x41, 0x7d, 0x49, 0x6b, 0x30, 0x17,
-	0xee, 0xe5, 0xc3, 0x3b, 0xb6, 0x0c, 0x7b, 0x91, 0x7c, 0x69, 0x21, 0x22, 0x47, 0xf2, 0xa5, 0x25,
-	0x10, 0x9a, 0x11, 0x2c, 0x0c, 0x34, 0xa0, 0x15, 0x69, 0xe5, 0x94, 0xa1, 0x6a, 0xa4, 0x95, 0x53,
-	0x0a, 0x83, 0xa1, 0x0b, 0x56, 0x85, 0xb4, 0x28, 0x0b, 0xb6, 0x18, 0x29, 0xa3, 0x2c, 0xd8, 0x12,
-	0x90, 0x8c, 0x11, 0xc1, 0x75, 0x5f, 0xc5, 0x85, 0x18, 0xef, 0xab, 0x53, 0x2a, 0x1f, 0x46, 0xd3,
-	0xfc, 0xa0, 0x9c, 0x5b, 0x3e, 0x44, 0x70, 0x50, 0x01, 0xf9, 0x10, 0x91, 0x03, 0x4b, 0x90, 0x0f,
-	0x11, 0x79, 0x60, 0x03, 0xec, 0x24, 0x06, 0x22, 0x30, 0x40, 0x72, 0x12, 0x05, 0x50, 0x04, 0xc9,
-	0x49, 0x14, 0x01, 0x0c, 0x68, 0x30, 0x2e, 0xa0, 0x04, 0x94, 0x60, 0x3c, 0x17, 0x7e, 0xa0, 0x04,
-	0xe3, 0xf9, 0x68, 0x03, 0xec, 0x8c, 0x7c, 0x01, 0x2b, 0x20, 0x39, 0xa3, 0x7c, 0x28, 0x82, 0xe4,
-	0x8c, 0x8a, 0x10, 0x07, 0x3d, 0xb8, 0xd6, 0x17, 0xb3, 0xec, 0x52, 0x63, 0x0a, 0x92, 0xf6, 0xcd,
-	0xf7, 0x8a, 0x39, 0xd5, 0xb8, 0x48, 0xcc, 0x85, 0xab, 0x71, 0x51, 0x7e, 0x6e, 0x5e, 0x8d, 0x8b,
-	0x8a, 0x52, 0xf2, 0xf4, 0x04, 0x4c, 0x93, 0xcb, 0xca, 0x09, 0x58, 0xc9, 0x74, 0xab, 0x27, 0x60,
-	0x35, 0x93, 0x6c, 0xfc, 0x02, 0xa6, 0x7b, 0xf1, 0x45, 0xe0, 0x18, 0x72, 0xa6, 0x53, 0x4a, 0x2e,
-	0x37, 0xef, 0xe8, 0xca, 0x55, 0xe3, 0x48, 0x7a, 0x56, 0x35, 0x4e, 0xc9, 0x05, 0xab, 0xc6, 0xa9,
-	0x89, 0x5d, 0x1c, 0xad, 0x38, 0x7c, 0xc2, 0x54, 0x8a, 0x56, 0x72, 0x73, 0xbe, 0xcd, 0x77, 0x8b,
-	0xf8, 0xa4, 0x1a, 0xfa, 0x7c, 0x0e, 0x55, 0xaa, 0x21, 0x37, 0x21, 0x2b, 0xd5, 0x50, 0x90, 0x87,
-	0xfd, 0x1d, 0xdc, 0x18, 0x69, 0x53, 0x9d, 0x86, 0xb8, 0xcf, 0x95, 0x27, 0x63, 0x9b, 0x1f, 0x4e,
-	0x24, 0x20, 0xad, 0xb5, 0x91, 0x90, 0xf7, 0x93, 0xd6, 0x5a, 0x7e, 0x9a, 0x54, 0x5a, 0x6b, 0x05,
-	0xd9, 0x43, 0x23, 0x26, 0x57, 0x4d, 0xf8, 0xb4, 0xd7, 0x27, 0xf7, 0x79, 0x87, 0xe1, 0x09, 0x0a,
-	0x32, 0x47, 0xef, 0x84, 0x83, 0x41, 0x18, 0x90, 0xa8, 0x5b, 0x64, 0xd1, 0x3b, 0xfa, 0x02, 0x76,
-	0xbe, 0xd2, 0x0b, 0xbc, 0xbf, 0x04, 0xe8, 0x4c, 0xae, 0xf6, 0xb1, 0xa4, 0xc7, 0xd2, 0x30, 0xe9,
-	0x3b, 0xb5, 0x50, 0x80, 0xaf, 0xfa, 0xf7, 0xb0, 0xe8, 0xd8, 0x81, 0x83, 0x7c, 0xb9, 0x6e, 0x59,
-	0xd5, 0x86, 0x8e, 0x4b, 0xa8, 0xfc, 0xa3, 0xc9, 0x24, 0xa4, 0x8d, 0x35, 0xd6, 0x64, 0x5a, 0xa5,
-	0x8d, 0xb5, 0x2c, 0x9d, 0x2b, 0x6d, 0xac, 0xa5, 0xb9, 0x5b, 0x5c, 0x6d, 0x5f, 0x93, 0x97, 0x95,
-	0xaa, 0x2d, 0x4b, 0xef, 0x36, 0xd7, 0x26, 0x60, 0x97, 0x26, 0xb0, 0x23, 0x24, 0x4c, 0x0d, 0xed,
-	0xd2, 0xd6, 0x24, 0xe4, 0xa4, 0x09, 0x5c, 0x94, 0x76, 0xc5, 0xe1, 0xb1, 0x90, 0xbb, 0x94, 0xc3,
-	0xe3, 0xdc, 0x74, 0xab, 0x1c, 0x1e, 0xe7, 0x67, 0x40, 0xb1, 0xa7, 0x11, 0x72, 0x8b, 0x92, 0xa7,
-	0xc9, 0xcd, 0x8e, 0x4a, 0x9e, 0x26, 0x3f, 0x09, 0x6a, 0x78, 0x50, 0x77, 0xa5, 0x04, 0xa6, 0x74,
-	0xbb, 0x5c, 0x94, 0x23, 0x95, 0x6e, 0x97, 0x0b, 0x53, 0xa1, 0xc6, 0x5f, 0x55, 0xa0, 0xd9, 0xcf,
-	0x7d, 0xe4, 0x6c, 0x7c, 0xac, 0xdc, 0x1c, 0x97, 0x3f, 0x9c, 0x6e, 0x7e, 0x32, 0xb1, 0x90, 0x34,
-	0x76, 0xae, 0x90, 0x2e, 0x95, 0xc6, 0x2e, 0x3f, 0x63, 0x2b, 0x8d, 0x5d, 0x41, 0xd2, 0x95, 0xdd,
-	0x05, 0xa8, 0x4f, 0x8e, 0xd5, 0xbb, 0x80, 0xe2, 0x57, 0xd6, 0xea, 0x5d, 0x40, 0xd9, 0x43, 0xe8,
-	0x6f, 0xe0, 0x8a, 0xc7, 0x3d, 0xdd, 0x90, 0x62, 0xcb, 0xbc, 0x37, 0x20, 0x52, 0x6c, 0x99, 0xff,
-	0xf8, 0xc3, 0x83, 0x3a, 0x8d, 0x2d, 0x59, 0x2e, 0xd8, 0x43, 0xb1, 0xa1, 0x0b, 0x19, 0xb3, 0xe2,
-	0x82, 0x29, 0xa3, 0xb2, 0x4a, 0x55, 0xd1, 0xf0, 0x32, 0xb7, 0x2a, 0x4b, 0x2a, 0x2e, 0xa8, 0x4a,
-	0x65, 0xd5, 0x56, 0xc5, 0x12, 0x6c, 0x6d, 0xc7, 0xd7, 0x56, 0x95, 0x15, 0x97, 0x56, 0xc5, 0xb3,
-	0xca, 0xb7, 0x1d, 0xa9, 0x2d, 0xf2, 0x6d, 0x47, 0x4a, 0x2f, 0xba, 0xed, 0xc8, 0x78, 0xa4, 0x5b,
-	0xd2, 0x18, 0x11, 0xb3, 0xef, 0xc8, 0x6e, 0x5a, 0xd6, 0xd7, 0xd2, 0x32, 0x48, 0x53, 0xa8, 0x4f,
-	0xc8, 0xf4, 0xf2, 0xcf, 0x50, 0x4e, 0xa9, 0xe3, 0xa2, 0x82, 0x29, 0x24, 0xb2, 0xa9, 0x61, 0xdf,
-	0xaf, 0xda, 0x49, 0xa2, 0xc9, 0xca, 0x10, 0x72, 0x71, 0xd8, 0xc7, 0x58, 0xa4, 0x4e, 0xed, 0xb3,
-	0x02, 0xf9, 0x0a, 0x69, 0x3b, 0xa5, 0x17, 0x74, 0x2a, 0xc7, 0x23, 0x5d, 0xea, 0xe2, 0x73, 0x07,
-	0x53, 0x7b, 0x4f, 0x39, 0x4a, 0x68, 0xf4, 0xde, 0xcf, 0x65, 0x92, 0xee, 0x0a, 0xe8, 0x1c, 0xa0,
-	0x9d, 0x70, 0x5f, 0x33, 0xc2, 0x6a, 0x3f, 0x3c, 0xc8, 0xe7, 0x92, 0x74, 0x3b, 0x19, 0x02, 0x43,
-	0xd2, 0x9d, 0x03, 0x04, 0x91, 0x74, 0xe7, 0x21, 0x38, 0xf0, 0x96, 0x4e, 0xf7, 0xd6, 0x6c, 0xff,
-	0x7d, 0x1d, 0x06, 0xf2, 0x11, 0x7d, 0x43, 0xc3, 0x52, 0xb0, 0xa5, 0xeb, 0xd9, 0x35, 0x47, 0x74,
-	0x91, 0x45, 0x77, 0x44, 0x97, 0x38, 0x4a, 0x8e, 0xe8, 0x0a, 0xb7, 0xba, 0x06, 0x3a, 0xaf, 0x71,
-	0xa0, 0x6c, 0x27, 0xc7, 0xea, 0x1a, 0x18, 0x17, 0x15, 0xaf, 0x01, 0x8e, 0x4d, 0x8a, 0xf1, 0x63,
-	0x94, 0x74, 0xe8, 0xff, 0x21, 0xdd, 0x20, 0xff, 0x87, 0x94, 0x65, 0x77, 0x1f, 0xcb, 0xd3, 0x5d,
-	0xc3, 0x54, 0x10, 0xe3, 0xe7, 0x09, 0xa8, 0x9b, 0x93, 0x8a, 0xb1, 0x51, 0x37, 0xa7, 0x62, 0xb0,
-	0x8e, 0xba, 0x39, 0x95, 0xe1, 0x76, 0x68, 0x3a, 0x5b, 0xc0, 0xdb, 0xa8, 0xe9, 0xec, 0x5c, 0x08,
-	0x8f, 0x9a, 0xce, 0x2e, 0x40, 0xee, 0xb0, 0x8c, 0x80, 0xda, 0x19, 0x78, 0x1b, 0x51, 0x32, 0x02,
-	0x5a, 0xb6, 0xe2, 0x8c, 0x40, 0x8e, 0x88, 0x34, 0xca, 0xfd, 0x49, 0x46, 0x79, 0xfb, 0xfb, 0x8e,
-	0xf2, 0xf6, 0x04, 0xa3, 0x4c, 0x0f, 0xaa, 0x19, 0xd6, 0x5d, 0x3d, 0xa8, 0xea, 0xd1, 0xf6, 0xea,
-	0x41, 0x35, 0x07, 0x64, 0xff, 0xec, 0x39, 0x3c, 0x08, 0xa3, 0xfe, 0x9a, 0x3d, 0xb4, 0x9d, 0x63,
-	0x24, 0x08, 0x0d, 0x85, 0x7f, 0xe9, 0xfb, 0x2c, 0xe7, 0x1f, 0xfe, 0x92, 0xbf, 0xf1, 0x77, 0x95,
-	0xca, 0x3f, 0x56, 0x2a, 0xff, 0x1b, 0x00, 0x00, 0xff, 0xff, 0x97, 0x20, 0x5c, 0x2c, 0x15, 0x58,
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x5c, 0x4b, 0x73, 0xdc, 0x56,
+	0x76, 0xae, 0x6e, 0x36, 0x29, 0xf2, 0x48, 0xa2, 0x68, 0x88, 0x12, 0x9b, 0x20, 0x25, 0xb5, 0x20,
+	0x89, 0x6c, 0x3d, 0x4c, 0xd9, 0xb4, 0xc7, 0xa5, 0x68, 0x9c, 0xb1, 0x5b, 0x64, 0x93, 0x66, 0x4c,
+	0x91, 0x34, 0x48, 0x59, 0xb6, 0x66, 0x5c, 0x1c, 0xa8, 0x71, 0xbb, 0x89, 0x08, 0x0d, 0x74, 0x00,
+	0x34, 0x25, 0x7a, 0xa6, 0x2a, 0xe5, 0x59, 0x24, 0xae, 0x4a, 0xd5, 0x54, 0x52, 0x95, 0x45, 0x96,
+	0xd9, 0x64, 0x91, 0x9f, 0x91, 0xd7, 0x0f, 0xc8, 0x4f, 0xc8, 0x2a, 0xbf, 0x21, 0xbb, 0xd4, 0x7d,
+	0xa0, 0x71, 0x5f, 0x00, 0x5a, 0x96, 0x53, 0x59, 0xb1, 0x71, 0xf0, 0x9d, 0xc7, 0x7d, 0x9d, 0x7b,
+	0xee, 0x3d, 0x07, 0x84, 0xe5, 0x0d, 0xdf, 0x43, 0x41, 0xb2, 0xe7, 0xf4, 0x51, 0x10, 0xba, 0xe8,
+	0x20, 0x0a, 0x93, 0xb0, 0x13, 0xfa, 0x6b, 0x03, 0xfc, 0xc3, 0x38, 0x7f, 0xe2, 0xb8, 0x61, 0x38,
+	0x58, 0x3b, 0x71, 0xbb, 0xb1, 0x39, 0x7b, 0x88, 0x3a, 0xc3, 0xc8, 0x4b, 0xce, 0xe8, 0x4b, 0x13,
+	0x30, 0x95, 0xfd, 0x9e, 0x71, 0x3a, 0x8c, 0xc7, 0x3c, 0xff, 0xc6, 0x49, 0x92, 0x88, 0x3d, 0xcc,
+	0xa1, 0xa0, 0x13, 0x9d, 0x0d, 0x12, 0x2f, 0x0c, 0x18, 0xe5, 0xa2, 0x17, 0x84, 0x89, 0xd7, 0x4d,
+	0x85, 0x5c, 0x46, 0x91, 0x13, 0x0f, 0x23, 0xd4, 0x09, 0x5d, 0x2f, 0xe8, 0x51, 0xa2, 0xe5, 0xc0,
+	0xb5, 0x6d, 0x94, 0x3c, 0xf1, 0xc3, 0xce, 0xab, 0xdd, 0xb0, 0xe3, 0x60, 0xee, 0xd8, 0x46, 0x7f,
+	0x31, 0x44, 0x71, 0x42, 0x0c, 0x34, 0xe6, 0x60, 0x22, 0x8e, 0x3a, 0xf5, 0x4a, 0xa3, 0xda, 0x9c,
+	0xb1, 0xf1, 0x4f, 0xe3, 0x2a, 0x4c, 0x85, 0xdd, 0x6e, 0x8c, 0x92, 0x7a, 0xb5, 0x51, 0x6d, 0xd6,
+	0x6c, 0xf6, 0x84, 0xe9, 0x3e, 0x0a, 0x7a, 0xc9, 0x49, 0x7d, 0x82, 0xd2, 0xe9, 0x93, 0x75, 0x0c,
+	0xd7, 0x35, 0x2a, 0xe2, 0x41, 0x18, 0xc4, 0xb4, 0x13, 0x8c, 0x3f, 0x85, 0x19, 0x3f, 0x7d, 0x53,
+	0xaf, 0x34, 0x2a, 0xcd, 0xf3, 0xeb, 0x37, 0xd6, 0xb8, 0xfe, 0x58, 0x23, 0x7c, 0xc8, 0x25, 0x32,
+	0x62, 0xc2, 0x63, 0x67, 0x1c, 0xd6, 0x0d, 0xd2, 0x86, 0x43, 0x14, 0x9d, 0xa2, 0x68, 0x13, 0x75,
+	0x9d, 0xa1, 0x9f, 0x08, 0x6d, 0xb0, 0x7c, 0xb8, 0xae, 0x01, 0xf0, 0x16, 0xfc, 0x19, 0xcc, 0xc6,
+	0xc2, 0x6b, 0xd2, 0xe0, 0xf3, 0xeb, 0x96, 0x60, 0xc6, 0x56, 0x2c, 0xca, 0xa0, 0x96, 0x48, 0x9c,
+	0xd6, 0x7f, 0x55, 0xc1, 0xd8, 0x88, 0x90, 0x93, 0xa0, 0x92, 0x8e, 0xfc, 0x04, 0xa6, 0xfa, 0x4e,
+	0xfc, 0x0a, 0xb9, 0xa4, 0x23, 0xcf, 0xaf, 0x5f, 0x97, 0x94, 0x1d, 0xa0, 0xa8, 0xef, 0xc5, 0xb1,
+	0x17, 0x06, 0x54, 0x11, 0x43, 0x1b, 0xd7, 0x01, 0x3a, 0xa3, 0xa9, 0x44, 0x3a, 0x7b, 0xc6, 0xe6,
+	0x28, 0xe4, 0x3d, 0xd1, 0xbf, 0xe5, 0x3b, 0xbd, 0x7a, 0xad, 0x51, 0x6d, 0x5e, 0xb4, 0x39, 0x8a,
+	0x61, 0xc1, 0x05, 0xfa, 0x74, 0xe0, 0x44, 0x28, 0x48, 0xea, 0x93, 0x8d, 0x6a, 0x73, 0xda, 0x16,
+	0x68, 0x46, 0x03, 0xce, 0x47, 0x68, 0xe0, 0x7b, 0xb4, 0x8f, 0xeb, 0x53, 0x44, 0x08, 0x4f, 0x32,
+	0x96, 0x61, 0xe6, 0x25, 0x1e, 0x8f, 0x43, 0xef, 0x7b, 0x54, 0x3f, 0x47, 0x46, 0x3c, 0x23, 0x18,
+	0xdf, 0xc1, 0x15, 0x32, 0x1b, 0xc3, 0x74, 0x9a, 0x7f, 0x8d, 0x22, 0xdc, 0x92, 0xfa, 0x74, 0x63,
+	0xa2, 0x39, 0xbb, 0xbe, 0x2a, 0x34, 0x75, 0x43, 0x87, 0xa4, 0x6d, 0xd6, 0x4b, 0xb1, 0xb6, 0xe1,
+	0x72, 0xda, 0xc5, 0xfc, 0x30, 0x7e, 0x00, 0xd5, 0x6e, 0x3a, 0x83, 0x1a, 0x82, 0x8a, 0x2f, 0xdc,
+	0x6e, 0xbc, 0xe5, 0xf9, 0xe8, 0x30, 0x71, 0x92, 0x21, 0x1b, 0xb8, 0x6a, 0x37, 0xb6, 0x5e, 0x80,
+	0xd1, 0x1a, 0x0c, 0x50, 0xe0, 0x96, 0x8c, 0x95, 0xd8, 0xe7, 0x55, 0xa5, 0xcf, 0x0d, 0xa8, 0x75,
+	0x71, 0x6f, 0x4f, 0x34, 0x2a, 0xcd, 0x8b, 0x36, 0xf9, 0x6d, 0xfd, 0x50, 0x81, 0xcb, 0xa9, 0x70,
+	0xde, 0xca, 0x8f, 0x61, 0x92, 0x74, 0x14, 0x33, 0xf4, 0x7a, 0xee, 0x54, 0xa7, 0x66, 0x52, 0xb0,
+	0xf1, 0x31, 0xd4, 0xe2, 0xc4, 0xc1, 0x8b, 0x6e, 0xbc, 0xd6, 0x11, 0xb4, 0x75, 0x00, 0xe6, 0x21,
+	0x4a, 0xec, 0x6c, 0xdc, 0x4a, 0xda, 0x29, 0x8d, 0x7b, 0x55, 0x19, 0x77, 0xeb, 0x17, 0xb0, 0x24,
+	0x4b, 0xe4, 0x1b, 0x77, 0x15, 0xa6, 0x22, 0x14, 0x0f, 0xfd, 0x84, 0x48, 0x9d, 0xb6, 0xd9, 0x93,
+	0x75, 0x00, 0xcb, 0x87, 0x28, 0x39, 0x4c, 0xc2, 0xc8, 0xe9, 0xa1, 0x83, 0xd0, 0xf7, 0x3a, 0x67,
+	0xe5, 0x5d, 0x3e, 0x20, 0x38, 0xbe, 0xcb, 0x33, 0x0a, 0x5e, 0xf6, 0xaa, 0x44, 0xce, 0x14, 0x6b,
+	0x1d, 0xae, 0x3f, 0x0b, 0xe2, 0xb7, 0x52, 0x6a, 0xdd, 0x84, 0x1b, 0x3a, 0x1e, 0x51, 0xec, 0xf2,
+	0x76, 0x91, 0x50, 0x03, 0x6a, 0x03, 0x27, 0x39, 0x61, 0x52, 0xc9, 0x6f, 0xeb, 0x15, 0x5c, 0x53,
+	0x79, 0x44, 0x07, 0x74, 0x31, 0xe6, 0xdf, 0x32, 0xff, 0x73, 0x5b, 0x18, 0x66, 0x32, 0x29, 0x04,
+	0x21, 0x74, 0xa8, 0x45, 0x56, 0xab, 0x41, 0xdd, 0x1d, 0x47, 0xf3, 0x90, 0xe8, 0x10, 0x3b, 0x70,
+	0x43, 0x87, 0xe0, 0x0d, 0xfa, 0x1c, 0xa6, 0x07, 0xec, 0x45, 0xbd, 0xd2, 0x98, 0x18, 0xdb, 0x96,
+	0x11, 0x97, 0xd5, 0x87, 0xc5, 0x43, 0x94, 0x64, 0x4e, 0xac, 0x64, 0xb8, 0x7f, 0x05, 0x30, 0x18,
+	0x61, 0xc7, 0xf4, 0x88, 0x1c, 0x87, 0xb5, 0x4c, 0x66, 0x3a, 0xaf, 0x8e, 0x1f, 0xb4, 0x97, 0x30,
+	0x7f, 0x88, 0x92, 0xfd, 0xd7, 0x01, 0x8a, 0x4a, 0xec, 0x30, 0x61, 0x7a, 0x18, 0xa3, 0x28, 0xa0,
+	0x93, 0xae, 0xd2, 0x9c, 0xb1, 0x47, 0xcf, 0xd8, 0xe7, 0xf5, 0xa2, 0x70, 0x38, 0x08, 0xa8, 0xe3,
+	0xc5, 0x2f, 0x33, 0x82, 0xb5, 0x00, 0x57, 0x32, 0x1d, 0xbc, 0xf2, 0x3f, 0x56, 0xa0, 0xde, 0x7a,
+	0xe9, 0x04, 0x6e, 0x18, 0x90, 0x6e, 0x13, 0x2c, 0x78, 0x1f, 0x2a, 0x2f, 0xd9, 0x68, 0x8b, 0x9b,
+	0x5e, 0xfb, 0x4d, 0x82, 0x02, 0x57, 0x70, 0x05, 0x95, 0x97, 0xa9, 0xc1, 0x55, 0x61, 0x3f, 0x3e,
+	0x09, 0x7d, 0x17, 0x45, 0x6c, 0x2b, 0x60, 0x4f, 0xc6, 0x22, 0x4c, 0x75, 0x3d, 0x1f, 0xed, 0xb8,
+	0xf5, 0x5a, 0xa3, 0xd2, 0xac, 0x3d, 0xae, 0x7c, 0x60, 0x33, 0x82, 0xb5, 0x04, 0x8b, 0xa2, 0x3d,
+	0xbc, 0xb5, 0x3f, 0x56, 0x61, 0xbe, 0xe5, 0xba, 0xaa, 0xa5, 0x6f, 0xef, 0x15, 0x7f, 0x09, 0xd3,
+	0x83, 0x08, 0x9d, 0x7a, 0xe1, 0x30, 0x26, 0xdd, 0x35, 0x46, 0x13, 0x47, 0x0c, 0xc6, 0x13, 0xb8,
+	0x80, 0xde, 0x74, 0xfc, 0xa1, 0x8b, 0xf6, 0x42, 0x17, 0xc5, 0xf5, 0x5a, 0x63, 0x42, 0x99, 0x12,
+	0x9b, 0x4e, 0xe2, 0x04, 0xa1, 0x8b, 0x76, 0x82, 0x2e, 0xdd, 0x23, 0x6c, 0x81, 0x87, 0xeb, 0x83,
+	0x49, 0xa9, 0x0f, 0xf0, 0x2e, 0xd8, 0x75, 0x4e, 0xc3, 0x08, 0xb9, 0x54, 0xfc, 0x54, 0x63, 0xa2,
+	0x39, 0x63, 0x0b, 0x34, 0xeb, 0x29, 0x5c, 0xc9, 0x7a, 0x22, 0xc7, 0x85, 0x57, 0xc7, 0x76, 0xe1,
+	0xd6, 0x0f, 0x13, 0x70, 0x73, 0x1b, 0x25, 0x2d, 0xd7, 0xf5, 0xb0, 0xe3, 0x74, 0xfc, 0xd4, 0xfc,
+	0x92, 0x6e, 0xfe, 0x10, 0x26, 0x5e, 0xfa, 0xaf, 0xd8, 0x9a, 0x28, 0xed, 0x41, 0x8c, 0x35, 0x3e,
+	0x85, 0x19, 0xf4, 0xc6, 0x8b, 0x13, 0x2f, 0xe8, 0xe1, 0xae, 0x1f, 0xa7, 0xe7, 0x32, 0x06, 0xe3,
+	0x31, 0x4c, 0xb3, 0x6e, 0x1c, 0xb7, 0xdb, 0x47, 0x78, 0x63, 0x0d, 0x8c, 0x60, 0xd8, 0xcf, 0xda,
+	0x48, 0x7b, 0x77, 0x92, 0x6c, 0x24, 0x9a, 0x37, 0xd2, 0x1c, 0x9a, 0x52, 0xe6, 0xd0, 0x3a, 0xcc,
+	0xa7, 0x86, 0x31, 0x77, 0xf3, 0x6c, 0xe8, 0xb9, 0x71, 0xfd, 0x1c, 0x19, 0x2f, 0xed, 0x3b, 0x6e,
+	0xd8, 0xa7, 0xe5, 0xa9, 0xff, 0x02, 0xac, 0x9c, 0x21, 0x78, 0xf7, 0xf1, 0xfd, 0x87, 0x0a, 0xcc,
+	0x6f, 0x84, 0xfd, 0x81, 0x8f, 0x4a, 0x63, 0xbf, 0xb2, 0x95, 0xf3, 0x11, 0xd4, 0x7c, 0x27, 0x4e,
+	0xc6, 0x5d, 0x35, 0x04, 0x5c, 0xb4, 0xe2, 0x1f, 0xc2, 0x95, 0xcc, 0xb2, 0x71, 0xf6, 0xeb, 0x67,
+	0xb0, 0x64, 0xa3, 0x41, 0x18, 0x25, 0x4f, 0x1c, 0xaa, 0x48, 0x3c, 0x16, 0x7c, 0x02, 0x53, 0xa4,
+	0xcd, 0xe9, 0xe6, 0x50, 0xd6, 0x43, 0x0c, 0x6d, 0x5d, 0x87, 0x65, 0x45, 0x2c, 0xef, 0x7c, 0x1e,
+	0x83, 0xb1, 0x11, 0x06, 0x1d, 0x27, 0x91, 0xfb, 0x2f, 0x89, 0x7a, 0x69, 0xff, 0x25, 0x51, 0x0f,
+	0x6f, 0xb2, 0x71, 0xd4, 0x89, 0xeb, 0x55, 0x32, 0x0b, 0xc8, 0x6f, 0xeb, 0x0a, 0x5c, 0x4e, 0x79,
+	0x79, 0x91, 0x5d, 0x98, 0x3f, 0x8a, 0x86, 0x98, 0x5e, 0x36, 0x28, 0xcb, 0x30, 0x13, 0xa0, 0xd7,
+	0xbb, 0xf4, 0x10, 0x43, 0x0f, 0x37, 0x19, 0xa1, 0x2c, 0xec, 0xc6, 0x5d, 0x9c, 0xe9, 0x19, 0xa7,
+	0x8b, 0x1f, 0x81, 0x61, 0x23, 0xbc, 0x73, 0x94, 0x98, 0x35, 0x07, 0x13, 0x6e, 0x9c, 0xa4, 0x2e,
+	0xdf, 0x8d, 0x13, 0xeb, 0x7d, 0xb8, 0x9c, 0x72, 0x8e, 0xa3, 0xe8, 0x38, 0x85, 0xaf, 0xbf, 0xad,
+	0x26, 0xe3, 0x36, 0x5c, 0x0c, 0x4f, 0x51, 0xf4, 0x3a, 0xf2, 0x12, 0xb4, 0x89, 0xc8, 0x84, 0xc4,
+	0x92, 0x45, 0xa2, 0x75, 0x15, 0xe6, 0x47, 0x0a, 0xf8, 0xae, 0xdf, 0x04, 0x63, 0x13, 0x8d, 0xb1,
+	0x1a, 0x96, 0x61, 0x26, 0xc2, 0x27, 0xde, 0xd8, 0x3b, 0xa5, 0x8b, 0x61, 0xda, 0xce, 0x08, 0xb8,
+	0xb5, 0xa9, 0x94, 0x71, 0x5a, 0xfb, 0x87, 0x0a, 0x18, 0x4f, 0x5f, 0xb9, 0x5e, 0x14, 0xff, 0x1f,
+	0x9d, 0xbf, 0xe4, 0xf3, 0xd3, 0x84, 0x7a, 0x7e, 0xc2, 0x36, 0xa7, 0x36, 0x8c, 0x63, 0x73, 0x00,
+	0x57, 0xb7, 0x51, 0xb2, 0x4b, 0x5d, 0x59, 0xb9, 0xeb, 0x88, 0x13, 0x27, 0x4a, 0x5a, 0xdd, 0x04,
+	0x45, 0xc4, 0xf4, 0x0b, 0x36, 0x47, 0xc1, 0xe6, 0x05, 0x08, 0xb9, 0xe9, 0x59, 0x3b, 0x35, 0x8f,
+	0xa7, 0x59, 0xcf, 0x61, 0x81, 0xd7, 0xc7, 0x9b, 0xf8, 0x29, 0x9c, 0x73, 0xbd, 0x08, 0xbf, 0x62,
+	0xe7, 0x13, 0xf1, 0x0c, 0xbc, 0xe9, 0x45, 0xa8, 0x93, 0x84, 0xd1, 0x19, 0x63, 0xa6, 0x5d, 0x93,
+	0xb2, 0x58, 0x4d, 0x58, 0xc1, 0x91, 0x65, 0xe0, 0x0c, 0xe2, 0x93, 0x30, 0x49, 0x9c, 0x97, 0x3e,
+	0xda, 0xf4, 0xa2, 0x91, 0x22, 0x2e, 0x06, 0xfd, 0x63, 0x05, 0x56, 0x8b, 0xa0, 0xbc, 0x4d, 0x1d,
+	0x98, 0x8f, 0x35, 0x38, 0x66, 0xe0, 0x43, 0xc1, 0x40, 0x59, 0xa0, 0xc6, 0x5a, 0xad, 0x30, 0xeb,
+	0x6f, 0x2a, 0x64, 0x77, 0x4e, 0xf9, 0x37, 0xbd, 0x6e, 0x97, 0xba, 0x2a, 0x61, 0x3c, 0x2c, 0xb8,
+	0x90, 0x72, 0xdb, 0x61, 0x98, 0xb0, 0x81, 0x11, 0x68, 0x18, 0xd3, 0x8d, 0xc2, 0x7e, 0x2a, 0x89,
+	0xad, 0x27, 0x81, 0x86, 0x47, 0x31, 0x09, 0x47, 0x08, 0xe6, 0x4d, 0x32, 0x8a, 0xf5, 0xe7, 0x64,
+	0x9f, 0xd2, 0x19, 0xc3, 0x77, 0xcc, 0x26, 0x80, 0x3b, 0x7a, 0xa5, 0x3d, 0x33, 0xa8, 0x12, 0x58,
+	0xe8, 0x9c, 0xf1, 0x59, 0x8f, 0xe0, 0xaa, 0x8d, 0x88, 0xa3, 0x73, 0x62, 0x71, 0xa9, 0x8a, 0x3e,
+	0xaf, 0xa2, 0xf8, 0xbc, 0x45, 0x58, 0xe0, 0x39, 0xf9, 0xb5, 0xbf, 0x0b, 0x75, 0x1b, 0x75, 0xb0,
+	0x9f, 0x50, 0xc5, 0xbe, 0xf5, 0x7e, 0x68, 0x7d, 0x04, 0x8b, 0xa2, 0xb4, 0x71, 0x56, 0xd5, 0x22,
+	0x99, 0xe5, 0x5b, 0x31, 0x3d, 0x16, 0x0b, 0xb3, 0xef, 0x7f, 0xaa, 0xdc, 0x3b, 0x69, 0x91, 0x9a,
+	0x30, 0xdd, 0x71, 0x06, 0x4e, 0xc7, 0x4b, 0xe8, 0x31, 0xac, 0x66, 0x8f, 0x9e, 0xf1, 0xbe, 0x33,
+	0x8c, 0x99, 0xc7, 0xa8, 0xd9, 0xe4, 0x37, 0xf5, 0x5e, 0x7d, 0xc7, 0x0b, 0xbc, 0xa0, 0xc7, 0xee,
+	0xbe, 0x32, 0x82, 0x71, 0x17, 0xe6, 0x86, 0x81, 0x8b, 0xa2, 0xe3, 0xf4, 0x10, 0x8d, 0x5c, 0x72,
+	0x27, 0x53, 0xb3, 0x2f, 0x11, 0xba, 0x3d, 0x22, 0x1b, 0x77, 0x60, 0xb6, 0x13, 0x46, 0xd1, 0x70,
+	0x90, 0x1c, 0xb3, 0xcd, 0x75, 0x92, 0x00, 0x2f, 0x32, 0x2a, 0xdd, 0x30, 0x31, 0x8c, 0xf8, 0xa5,
+	0xa0, 0x97, 0xc2, 0xa6, 0x28, 0x8c, 0x51, 0x19, 0xec, 0x17, 0xb0, 0x90, 0xc2, 0xb0, 0xea, 0xe3,
+	0x30, 0x40, 0x29, 0xfe, 0x1c, 0x0e, 0x0f, 0xec, 0x79, 0xf6, 0x1a, 0x5b, 0xb0, 0x1f, 0x20, 0xc6,
+	0xd6, 0x84, 0x39, 0x8a, 0x3a, 0xf6, 0x82, 0xe3, 0xee, 0x30, 0x19, 0x46, 0x88, 0x46, 0x51, 0xf6,
+	0x2c, 0xa5, 0xef, 0x04, 0x5b, 0x84, 0x6a, 0x7c, 0x02, 0x0b, 0xf8, 0x72, 0x03, 0x2b, 0x70, 0xb1,
+	0x7f, 0xf6, 0xc2, 0x20, 0x55, 0x30, 0x43, 0x18, 0xae, 0xb0, 0xd7, 0x9b, 0xec, 0x2d, 0xd5, 0x60,
+	0x7d, 0x4b, 0x0e, 0xc3, 0x59, 0xe0, 0xa5, 0xac, 0xb1, 0x47, 0x50, 0x4b, 0xce, 0x06, 0x74, 0xbe,
+	0xcd, 0x4a, 0xf3, 0x59, 0x64, 0x3b, 0x3a, 0x1b, 0x20, 0x16, 0x01, 0x61, 0x0e, 0xeb, 0x80, 0x1c,
+	0x7d, 0x65, 0xd1, 0xfc, 0xe0, 0xae, 0x41, 0xd5, 0xf5, 0xb4, 0x41, 0x8b, 0x1a, 0xd4, 0x56, 0x5d,
+	0xcf, 0xfa, 0x2d, 0xdc, 0xe6, 0x24, 0xb2, 0x28, 0xf3, 0x67, 0xb5, 0xf9, 0x9f, 0x2b, 0x60, 0x6a,
+	0xe5, 0x53, 0xc1, 0x4f, 0xe0, 0x82, 0xcb, 0x59, 0xa6, 0x8d, 0x48, 0x35, 0xc7, 0x20, 0x9e, 0xc7,
+	0xd8, 0x86, 0xd9, 0x98, 0x97, 0x4c, 0xe3, 0x26, 0x39, 0xae, 0x54, 0x95, 0xdb, 0x12, 0x9b, 0xf5,
+	0x63, 0x05, 0xee, 0xe4, 0x77, 0x07, 0xdf, 0xcf, 0xc7, 0x70, 0xd5, 0xd5, 0xa1, 0xd2, 0x80, 0x71,
+	0x55, 0xdb, 0x00, 0x8d, 0x09, 0x39, 0x62, 0xac, 0xcf, 0x88, 0xb7, 0x3e, 0x88, 0x50, 0x17, 0x45,
+	0x11, 0x8b, 0x35, 0xf1, 0xd5, 0xa3, 0x30, 0x2a, 0x26, 0x4c, 0xe3, 0x00, 0x38, 0xc8, 0xbc, 0xd7,
+	0xe8, 0xd9, 0x7a, 0x0c, 0x56, 0x8e, 0x00, 0xbe, 0x1d, 0xf3, 0x30, 0xf9, 0x32, 0xf6, 0xbe, 0xa7,
+	0xec, 0x35, 0x9b, 0x3e, 0x58, 0x09, 0x2c, 0xe0, 0xbb, 0x27, 0xa7, 0x8b, 0x9e, 0xca, 0xc7, 0xb7,
+	0x47, 0x30, 0xe5, 0x74, 0xc8, 0xc6, 0x4b, 0xa7, 0x82, 0x78, 0x53, 0x97, 0xb2, 0xb4, 0x08, 0x84,
+	0xc5, 0x15, 0x14, 0x6f, 0xdc, 0x80, 0x73, 0x9d, 0x13, 0xd4, 0xa1, 0x01, 0x49, 0xa5, 0x39, 0xfd,
+	0x78, 0xb2, 0xeb, 0xf8, 0x31, 0xb2, 0x53, 0xaa, 0xb5, 0x0e, 0x75, 0x41, 0xeb, 0x38, 0x3e, 0xf0,
+	0x39, 0x2c, 0x1e, 0x3a, 0xa7, 0x08, 0x3b, 0xd1, 0x78, 0xe0, 0x74, 0x44, 0x5b, 0x6f, 0x02, 0x24,
+	0x5e, 0x1f, 0x3d, 0xf7, 0x02, 0x37, 0x7c, 0x5d, 0xaf, 0xa4, 0x87, 0x06, 0x8e, 0x68, 0x2c, 0xc0,
+	0x64, 0xf2, 0x66, 0xdb, 0x19, 0xd4, 0xab, 0xe9, 0x5b, 0xfa, 0x6c, 0x3d, 0x02, 0x53, 0x12, 0x2c,
+	0xfa, 0xd0, 0xc9, 0xd8, 0x39, 0x45, 0x2e, 0x11, 0x3a, 0xfd, 0xb8, 0x96, 0x44, 0x43, 0x64, 0x53,
+	0x12, 0xbe, 0x27, 0xb1, 0x43, 0xdf, 0x6f, 0xbb, 0x9e, 0x74, 0x4f, 0xff, 0x39, 0x5c, 0xe5, 0x5e,
+	0xf0, 0xe2, 0x56, 0x60, 0x36, 0x40, 0xaf, 0x0f, 0x51, 0xaf, 0x8f, 0x82, 0xe4, 0xe8, 0xcd, 0x8e,
+	0xcb, 0x86, 0x43, 0xa2, 0x5a, 0x1f, 0x43, 0xc3, 0x46, 0x78, 0xce, 0xa2, 0x2d, 0xc7, 0xf3, 0x91,
+	0x3b, 0x9a, 0x33, 0xe2, 0xe6, 0xe3, 0x64, 0x87, 0x09, 0x27, 0xea, 0x59, 0xbf, 0x84, 0x9b, 0x7a,
+	0xae, 0x71, 0x3a, 0xd8, 0xc4, 0xfb, 0x5c, 0x37, 0x42, 0xf1, 0x09, 0x39, 0xcf, 0x0a, 0x0d, 0x5a,
+	0x82, 0x45, 0xf1, 0x1d, 0xbf, 0x41, 0x5e, 0x83, 0xa5, 0x2d, 0x2f, 0x70, 0x7c, 0xef, 0x7b, 0xf4,
+	0x6c, 0xd0, 0x8b, 0x1c, 0x71, 0x1e, 0xe1, 0x93, 0x92, 0xf2, 0x9a, 0x67, 0xff, 0x0d, 0x98, 0xb8,
+	0xb3, 0xbc, 0xa0, 0xa7, 0xe1, 0x36, 0x7e, 0x25, 0xcd, 0xc2, 0x15, 0x61, 0x16, 0x8a, 0x8c, 0x9a,
+	0xb9, 0x68, 0xfd, 0x67, 0x05, 0x16, 0x44, 0xd4, 0xc8, 0xb7, 0x60, 0xd9, 0x31, 0xd9, 0x51, 0x99,
+	0x2f, 0x2a, 0x92, 0xcd, 0xdf, 0x48, 0x33, 0x2e, 0xbc, 0x5f, 0x92, 0x70, 0xf5, 0xc8, 0x63, 0x5b,
+	0x7d, 0xcd, 0xce, 0x08, 0x24, 0x78, 0x62, 0xed, 0x26, 0x00, 0xba, 0xa1, 0x0a, 0x34, 0xe3, 0x63,
+	0xb8, 0x42, 0xa3, 0x6d, 0x17, 0x6b, 0x7b, 0xe9, 0x74, 0x5e, 0xed, 0xf4, 0x9d, 0x1e, 0xb9, 0xac,
+	0xc0, 0x43, 0xa3, 0x7f, 0x69, 0xc5, 0xb0, 0x24, 0xf7, 0x18, 0x3f, 0xc0, 0x47, 0x60, 0x44, 0x4a,
+	0x8b, 0x59, 0x88, 0x79, 0xbb, 0xa0, 0x89, 0x99, 0xd3, 0xd5, 0xf0, 0x5b, 0xfb, 0x70, 0x13, 0x47,
+	0x97, 0x1b, 0x74, 0x07, 0xc7, 0x97, 0xf4, 0x9a, 0xd3, 0xb4, 0xe6, 0xca, 0x18, 0xcf, 0xb7, 0x4e,
+	0x18, 0xbe, 0xf2, 0xd2, 0x5b, 0x48, 0xf6, 0x64, 0x75, 0xc0, 0xca, 0x11, 0x28, 0xa6, 0xd4, 0xce,
+	0xb1, 0xa0, 0x81, 0x0d, 0xd2, 0x2d, 0x31, 0xe3, 0x22, 0x73, 0xb3, 0x30, 0x9e, 0xf1, 0x58, 0xeb,
+	0x30, 0xff, 0x14, 0x25, 0x0e, 0x5e, 0xe0, 0x63, 0xfb, 0xd3, 0x05, 0xb8, 0x92, 0xf1, 0xf0, 0x33,
+	0xf5, 0x3e, 0x0d, 0xb5, 0xf0, 0x45, 0x44, 0xd0, 0x0d, 0x4b, 0x2e, 0xe0, 0x77, 0xa1, 0x2e, 0x80,
+	0xdf, 0x2d, 0xbd, 0xf3, 0x00, 0xea, 0x3b, 0xe4, 0xc5, 0x86, 0x1f, 0xc6, 0xa8, 0x24, 0xc9, 0x83,
+	0x83, 0x4c, 0x11, 0x3d, 0xce, 0xfa, 0xff, 0xb7, 0x0a, 0x2c, 0x6c, 0x38, 0x9d, 0x13, 0x76, 0xd6,
+	0xf0, 0x4e, 0xb9, 0x95, 0x32, 0x0b, 0x55, 0x8f, 0xba, 0xc0, 0x09, 0xbb, 0xea, 0xb9, 0xa3, 0x71,
+	0xa6, 0x23, 0x4a, 0xc7, 0x59, 0xca, 0xb8, 0xd0, 0x04, 0x12, 0x4f, 0x22, 0x5c, 0x61, 0xe8, 0xd7,
+	0x6b, 0x8c, 0x2b, 0x0c, 0x7d, 0x63, 0x0f, 0x00, 0xbd, 0x19, 0x78, 0x11, 0x65, 0x9a, 0x24, 0x5d,
+	0xb2, 0x26, 0x0e, 0xb1, 0x62, 0x53, 0x7b, 0xc4, 0xc0, 0x8e, 0x00, 0x99, 0x04, 0xeb, 0xd7, 0x70,
+	0xb3, 0x94, 0x01, 0x77, 0x41, 0xdf, 0xf3, 0x7d, 0x8f, 0x2e, 0xfc, 0x09, 0x9b, 0x3d, 0xe1, 0xe0,
+	0xdd, 0x8b, 0x6d, 0xe4, 0x3b, 0x49, 0x76, 0x7e, 0xe7, 0x28, 0xd6, 0xbf, 0x56, 0xa0, 0x2e, 0x4a,
+	0x27, 0x51, 0x37, 0x15, 0xda, 0x80, 0xf3, 0x2f, 0xcf, 0x12, 0x14, 0xef, 0x21, 0xe4, 0x22, 0x97,
+	0x49, 0xe6, 0x49, 0x23, 0x04, 0x11, 0x41, 0x43, 0xef, 0x09, 0x9b, 0x27, 0x61, 0x04, 0x9e, 0x86,
+	0xa9, 0x8c, 0x09, 0x8a, 0xe0, 0x48, 0x23, 0x04, 0x93, 0x51, 0xe3, 0x10, 0x4c, 0xc6, 0x75, 0x80,
+	0x13, 0x27, 0x26, 0x4d, 0x46, 0x2e, 0xcb, 0x89, 0x72, 0x14, 0xeb, 0x0c, 0xae, 0xb5, 0x5c, 0x57,
+	0x6c, 0x86, 0x1c, 0x01, 0x7a, 0x59, 0x80, 0x76, 0xbb, 0x64, 0x30, 0x58, 0x04, 0x88, 0x39, 0xb0,
+	0xea, 0x0e, 0x06, 0xe0, 0xec, 0x6c, 0x4c, 0x26, 0xc7, 0x45, 0x9b, 0xa3, 0x58, 0x1f, 0xc0, 0x75,
+	0x8d, 0x6a, 0x7e, 0x72, 0xa6, 0x13, 0xad, 0x4a, 0x27, 0x9a, 0xf5, 0x7b, 0x68, 0x3c, 0x0d, 0x5d,
+	0xaf, 0x7b, 0xf6, 0xff, 0x62, 0xef, 0x2d, 0xb8, 0xa9, 0xd7, 0x2e, 0xa6, 0xd1, 0x1a, 0x36, 0xea,
+	0x87, 0xa7, 0xa8, 0xc0, 0x44, 0xb9, 0x59, 0xb7, 0xe0, 0xa6, 0x9e, 0x87, 0x17, 0xfc, 0x1a, 0x6e,
+	0x10, 0x07, 0x29, 0x40, 0x44, 0x7f, 0x7b, 0x15, 0xa6, 0x70, 0x9a, 0x61, 0x27, 0x95, 0xcd, 0x9e,
+	0x8c, 0x4f, 0xc9, 0x05, 0x6a, 0x7a, 0xad, 0x32, 0x6e, 0xa7, 0x30, 0x1e, 0xeb, 0xef, 0x94, 0x69,
+	0xde, 0x0e, 0x92, 0xe8, 0xec, 0x5d, 0x7b, 0xfb, 0x97, 0x30, 0x89, 0x37, 0xce, 0x98, 0xd9, 0x74,
+	0xa7, 0x80, 0x35, 0x5b, 0x56, 0x36, 0xe5, 0xb1, 0xfe, 0x12, 0x1a, 0xda, 0xce, 0xe0, 0x27, 0x4f,
+	0x0b, 0xa6, 0x91, 0x8f, 0x70, 0x0c, 0x95, 0x06, 0xe7, 0x45, 0x3a, 0xb2, 0x36, 0xd9, 0x23, 0x36,
+	0xa3, 0x0e, 0xe7, 0x4e, 0x9c, 0xf8, 0x69, 0x18, 0xa5, 0xcb, 0x3f, 0x7d, 0xb4, 0xfe, 0xbd, 0x02,
+	0x06, 0x11, 0x70, 0x10, 0x86, 0x7e, 0xe6, 0x19, 0x4d, 0x9c, 0x5e, 0x0c, 0x7d, 0x76, 0xad, 0x40,
+	0xb2, 0x6c, 0xe9, 0x33, 0x8e, 0x0f, 0x42, 0x9c, 0x44, 0xdb, 0xcb, 0x52, 0x70, 0x19, 0x61, 0x94,
+	0x83, 0xdb, 0x93, 0x73, 0x70, 0x69, 0x1e, 0xbe, 0x1f, 0xba, 0x88, 0xf8, 0xca, 0x49, 0x9b, 0xfc,
+	0xc6, 0x21, 0xbc, 0xef, 0xf5, 0xbd, 0x84, 0xb8, 0xc9, 0x09, 0x9b, 0x3e, 0x18, 0x0f, 0xe0, 0xbd,
+	0xbe, 0xf3, 0x26, 0xf5, 0x51, 0x64, 0x95, 0x9f, 0xd5, 0xa7, 0x08, 0x42, 0x7d, 0x61, 0xfd, 0x47,
+	0x05, 0x2e, 0x8f, 0x9a, 0xf1, 0x33, 0x7b, 0xaf, 0x15, 0x98, 0x25, 0x8f, 0xfb, 0xa7, 0x28, 0xa2,
+	0x86, 0x52, 0x07, 0x26, 0x51, 0x65, 0x2f, 0x57, 0x2b, 0xf5, 0x72, 0x93, 0x8a, 0x97, 0xb3, 0xf6,
+	0xa1, 0x9e, 0xba, 0x12, 0xdc, 0x12, 0x61, 0x55, 0x7c, 0x24, 0x4c, 0xd1, 0x1b, 0xea, 0x1c, 0x10,
+	0x86, 0x90, 0xce, 0x4e, 0x92, 0x4a, 0x14, 0x04, 0xf2, 0x4b, 0xd1, 0x86, 0x25, 0xce, 0x11, 0xfc,
+	0x3c, 0x0a, 0xaf, 0xc3, 0xb2, 0x22, 0x93, 0xd7, 0xf9, 0x27, 0xb0, 0xc4, 0xf9, 0x08, 0x45, 0xa7,
+	0x38, 0xf1, 0xaa, 0xfc, 0xc4, 0xa3, 0xc9, 0x09, 0x89, 0x95, 0x17, 0xfd, 0x39, 0x98, 0xa3, 0xc5,
+	0x84, 0xdf, 0xc6, 0xf2, 0xcd, 0x20, 0x76, 0x23, 0x07, 0xa2, 0x74, 0x81, 0x86, 0x43, 0x50, 0x59,
+	0x02, 0xbf, 0x12, 0x1f, 0xc3, 0x39, 0x14, 0x24, 0x51, 0x96, 0x73, 0x6f, 0xe8, 0xfb, 0x84, 0x5b,
+	0x83, 0x29, 0x43, 0xc1, 0x12, 0xfc, 0x03, 0x3f, 0x77, 0x33, 0xd6, 0x9f, 0xd4, 0xfd, 0xc6, 0x27,
+	0xa2, 0x37, 0xca, 0x31, 0x50, 0x75, 0x44, 0x0f, 0x61, 0x89, 0xc5, 0x75, 0xbb, 0x5e, 0xf0, 0x6a,
+	0x8c, 0x40, 0xf0, 0x00, 0x96, 0x15, 0x86, 0x77, 0x0b, 0x06, 0x3f, 0x22, 0xf7, 0x4e, 0x1b, 0x61,
+	0x90, 0xa0, 0x20, 0x39, 0x1c, 0xf6, 0xfb, 0x4e, 0x54, 0x5e, 0xb9, 0xf1, 0x1b, 0xb8, 0xae, 0x61,
+	0x92, 0x06, 0x2d, 0xa6, 0x74, 0xd6, 0x93, 0x52, 0x9f, 0x08, 0xac, 0x6c, 0xd0, 0x18, 0x83, 0xf5,
+	0x10, 0x16, 0xb7, 0x51, 0xf2, 0xd5, 0x30, 0x4c, 0x9c, 0x67, 0xb1, 0x7c, 0x50, 0xd5, 0x99, 0x73,
+	0x00, 0xa6, 0xc4, 0xc0, 0x9b, 0xb2, 0x0e, 0x93, 0x43, 0x4c, 0x65, 0x86, 0x2c, 0x0b, 0x86, 0x64,
+	0x4c, 0x6c, 0x60, 0x08, 0xd4, 0xfa, 0x97, 0x0a, 0x29, 0x8d, 0x20, 0x6f, 0x4b, 0x0f, 0x25, 0xf8,
+	0x1c, 0x9e, 0x1e, 0xf8, 0x09, 0x07, 0x3b, 0xbf, 0x49, 0x54, 0xec, 0x5c, 0xd9, 0xcd, 0x11, 0x07,
+	0xa5, 0x27, 0x39, 0xf5, 0x85, 0xf1, 0x19, 0x9c, 0x67, 0x44, 0x7c, 0x37, 0x46, 0x7c, 0xf7, 0xec,
+	0xfa, 0x35, 0xdd, 0xdd, 0x54, 0x76, 0x77, 0xc6, 0x73, 0xb0, 0xca, 0x0b, 0xd6, 0x04, 0x7e, 0xc5,
+	0xfe, 0x55, 0x05, 0xde, 0xdb, 0x8a, 0xcf, 0x82, 0x4e, 0x79, 0x4d, 0x23, 0xbd, 0x6c, 0x66, 0x57,
+	0xcf, 0xec, 0xc9, 0x78, 0x00, 0x97, 0x7c, 0x27, 0x66, 0xc5, 0x8b, 0x69, 0x71, 0x63, 0xa5, 0x69,
+	0x3c, 0xae, 0xbe, 0xff, 0xa1, 0x2d, 0xbf, 0x2a, 0xca, 0xbf, 0xce, 0x83, 0xc1, 0xec, 0xe0, 0xcd,
+	0x3b, 0x22, 0x5d, 0x8f, 0x8f, 0xb4, 0x65, 0xb9, 0xaa, 0x79, 0x98, 0xec, 0x27, 0xd9, 0x79, 0x99,
+	0x3e, 0x60, 0xaa, 0x93, 0x64, 0x87, 0x64, 0xfa, 0xc0, 0x7a, 0x83, 0x49, 0xe5, 0xd5, 0xfd, 0x53,
+	0x05, 0x16, 0x69, 0xd9, 0xdc, 0xe1, 0x59, 0xdf, 0xf7, 0x82, 0x57, 0x72, 0x50, 0x94, 0x38, 0x51,
+	0x0f, 0xa5, 0x39, 0x0d, 0xf6, 0x84, 0xe7, 0x01, 0xc6, 0xb2, 0x9e, 0x21, 0xbf, 0x8d, 0x47, 0x24,
+	0x49, 0x84, 0x13, 0x64, 0xf5, 0x09, 0xcd, 0x7d, 0xa4, 0x9a, 0x3b, 0x4b, 0xe1, 0x4a, 0xf2, 0xac,
+	0xa6, 0x49, 0x9e, 0x2d, 0x83, 0x29, 0x99, 0xc9, 0xb7, 0x82, 0xae, 0x19, 0xec, 0x14, 0x8e, 0x88,
+	0x81, 0xa5, 0x6b, 0xe6, 0x53, 0x30, 0x25, 0x06, 0x7e, 0xcd, 0xe0, 0x44, 0x0c, 0x21, 0x1f, 0x50,
+	0xbe, 0x0a, 0x49, 0xc4, 0x8c, 0x28, 0xd6, 0xef, 0xc0, 0x7a, 0x36, 0x70, 0x9d, 0x84, 0x1e, 0x94,
+	0xb7, 0xc2, 0xe8, 0xc0, 0x1b, 0x20, 0xdf, 0x0b, 0xc4, 0xb5, 0xfa, 0x0b, 0xb1, 0x60, 0xa0, 0x34,
+	0x61, 0x4f, 0xd1, 0xa5, 0x69, 0x8f, 0x5f, 0xc3, 0xad, 0x3c, 0xe5, 0xef, 0x5e, 0xae, 0xf0, 0xb7,
+	0x55, 0x30, 0xa9, 0x74, 0x6d, 0x93, 0x4a, 0x72, 0x3f, 0xb8, 0xb8, 0x27, 0xf4, 0xa9, 0xd8, 0x71,
+	0x4b, 0x53, 0x46, 0x0c, 0x98, 0x39, 0x40, 0xaf, 0x29, 0xf3, 0xc4, 0x98, 0xcc, 0x29, 0x83, 0xf1,
+	0x88, 0x30, 0xf3, 0x55, 0x41, 0xcb, 0xfa, 0xeb, 0xf0, 0xcd, 0x8c, 0x73, 0x54, 0x6c, 0xc2, 0xfc,
+	0xc6, 0xce, 0x26, 0xce, 0xae, 0xe0, 0xe2, 0x01, 0x8e, 0x82, 0xef, 0xe4, 0xe4, 0x1e, 0x11, 0x03,
+	0x80, 0xc6, 0x21, 0x4a, 0x9e, 0x38, 0xbe, 0x13, 0x74, 0x50, 0xf4, 0xc4, 0x09, 0xdc, 0xd7, 0x9e,
+	0x9b, 0x9c, 0x08, 0xdd, 0x86, 0xeb, 0x62, 0xd3, 0x17, 0x2c, 0x1e, 0xcc, 0x08, 0xf8, 0x04, 0xa3,
+	0x97, 0xc0, 0xab, 0xb1, 0xa0, 0xc1, 0x2e, 0xd9, 0xdb, 0xa3, 0x9a, 0xee, 0x2f, 0x91, 0xb0, 0x57,
+	0x59, 0xa7, 0x70, 0x53, 0x8f, 0xe1, 0xe7, 0xc5, 0x57, 0xf0, 0x9e, 0x2b, 0x23, 0xd8, 0x96, 0x79,
+	0x4b, 0xe9, 0x31, 0x01, 0x45, 0x3b, 0x4e, 0xe5, 0xb6, 0xdc, 0xd1, 0xda, 0x4c, 0x33, 0x9e, 0x3f,
+	0x21, 0x3b, 0x9a, 0x3e, 0x73, 0x11, 0xbe, 0x40, 0xb3, 0x5a, 0xb0, 0x24, 0x6b, 0xe1, 0xdb, 0xc5,
+	0x89, 0x38, 0xc8, 0x56, 0xbb, 0x40, 0xb3, 0xfe, 0xbe, 0x02, 0x26, 0x2d, 0x4a, 0xf8, 0xc9, 0x96,
+	0x36, 0xe1, 0x52, 0xfa, 0xbc, 0xef, 0xbb, 0xdc, 0x12, 0x95, 0xc9, 0x3c, 0x72, 0x0f, 0xbd, 0xe6,
+	0x0a, 0x44, 0x64, 0x32, 0x9e, 0x61, 0xb2, 0x55, 0xfc, 0xd0, 0x7f, 0x06, 0x8b, 0x2d, 0xdf, 0x0f,
+	0x5f, 0xff, 0x54, 0x9b, 0xb1, 0xef, 0x94, 0x04, 0xf0, 0xe2, 0x9f, 0xc0, 0xf2, 0xa6, 0x17, 0x3b,
+	0xef, 0xa4, 0xe1, 0x06, 0x5c, 0x53, 0x65, 0xf0, 0x4a, 0x5c, 0x30, 0x69, 0xbd, 0xc6, 0xcf, 0x38,
+	0x45, 0xaa, 0xca, 0x14, 0xb9, 0x06, 0x4b, 0xb2, 0x16, 0xde, 0x88, 0x57, 0xb0, 0xb0, 0x81, 0xd3,
+	0x26, 0xad, 0x4e, 0x07, 0xc5, 0xe5, 0xb7, 0xad, 0x9f, 0xb2, 0x73, 0x63, 0x95, 0xdc, 0x96, 0x37,
+	0x85, 0xc5, 0xd1, 0xea, 0x70, 0x51, 0xf3, 0xda, 0x56, 0xcc, 0xdf, 0x97, 0x13, 0x2e, 0x9c, 0x03,
+	0x10, 0x94, 0xf1, 0x86, 0xdc, 0x22, 0x0b, 0x75, 0x63, 0x18, 0xe1, 0xad, 0x0d, 0xa7, 0x36, 0x76,
+	0xc3, 0xde, 0xd1, 0x1b, 0x4f, 0xb8, 0x8b, 0xb4, 0x1e, 0x81, 0x95, 0x03, 0xe2, 0xa7, 0xbd, 0x01,
+	0xb5, 0xe4, 0xcd, 0xe8, 0x42, 0x84, 0xfc, 0x66, 0xd5, 0xc8, 0x98, 0x25, 0xde, 0x8a, 0xc2, 0xbe,
+	0x2c, 0x59, 0xcb, 0xf3, 0x1d, 0x5c, 0x53, 0x79, 0xc4, 0x1a, 0x10, 0x40, 0xa7, 0x28, 0x48, 0x62,
+	0x56, 0x65, 0xa1, 0x06, 0x93, 0xed, 0xd1, 0xeb, 0xf4, 0x2e, 0x71, 0x44, 0xc0, 0x49, 0x98, 0x43,
+	0x27, 0xf1, 0xe2, 0xee, 0xd9, 0xdb, 0x54, 0x5e, 0x63, 0xcf, 0xa8, 0xe5, 0xe2, 0x0c, 0xbb, 0xf7,
+	0x2d, 0x5c, 0xda, 0x18, 0x7d, 0xc8, 0x40, 0x25, 0x01, 0x4c, 0x6d, 0xd8, 0xed, 0xd6, 0x51, 0x7b,
+	0xae, 0x62, 0x5c, 0x84, 0x99, 0xfd, 0xaf, 0xdb, 0xf6, 0x73, 0x7b, 0xe7, 0xa8, 0x3d, 0x57, 0xc5,
+	0xaf, 0x5a, 0x07, 0x07, 0xed, 0xbd, 0xcd, 0xb9, 0x9a, 0x31, 0x07, 0x17, 0x76, 0x5b, 0x2f, 0xbe,
+	0x3d, 0x3e, 0x68, 0xdb, 0x87, 0x3b, 0x87, 0x47, 0x73, 0x73, 0x18, 0xbc, 0xd7, 0x7e, 0x7e, 0xfc,
+	0x64, 0x77, 0x7f, 0xe3, 0xcb, 0xb9, 0xc6, 0xbd, 0x2f, 0x61, 0x21, 0x27, 0x4f, 0x6b, 0x9c, 0x83,
+	0x89, 0xd6, 0xee, 0xee, 0x5c, 0xc5, 0x98, 0x86, 0xda, 0xee, 0xce, 0xd7, 0x58, 0xf4, 0x34, 0xd4,
+	0x36, 0xdb, 0xad, 0xcd, 0xb9, 0x09, 0xe3, 0x32, 0x5c, 0xda, 0x6c, 0x6f, 0xec, 0x3f, 0x7d, 0xba,
+	0x73, 0x78, 0xb8, 0xb3, 0xbf, 0xb7, 0xb3, 0xb7, 0x3d, 0x57, 0xbb, 0x77, 0x02, 0x97, 0x35, 0x99,
+	0x3e, 0xc3, 0x80, 0xd9, 0xc3, 0xd6, 0x56, 0xfb, 0xe9, 0xfe, 0x66, 0xfb, 0x78, 0xb7, 0xdd, 0xfa,
+	0x1a, 0xdb, 0xcc, 0xd3, 0xda, 0x7b, 0x47, 0x6d, 0x7b, 0xae, 0x8a, 0x8d, 0x1d, 0xd1, 0xb6, 0xdb,
+	0x47, 0x73, 0x13, 0xc6, 0x02, 0x5c, 0x1e, 0x51, 0xb6, 0xf6, 0xed, 0x8d, 0xf6, 0x71, 0xfb, 0x9b,
+	0x9d, 0xa3, 0xb9, 0xda, 0xbd, 0xcf, 0x60, 0x31, 0x37, 0x9b, 0x63, 0xcc, 0xc0, 0xe4, 0x57, 0xcf,
+	0xda, 0xf6, 0xb7, 0x73, 0x15, 0xfc, 0xf3, 0xf0, 0xa8, 0x65, 0x1f, 0xcd, 0x55, 0x8d, 0x0b, 0x30,
+	0xbd, 0xb5, 0xb3, 0xd7, 0xda, 0xdd, 0x79, 0xd1, 0x9e, 0x9b, 0xb8, 0xb7, 0x04, 0xb3, 0x1b, 0xe9,
+	0xd5, 0xdd, 0x88, 0x8b, 0xa8, 0x98, 0xab, 0xac, 0xff, 0xf7, 0x17, 0x70, 0x55, 0xff, 0xd9, 0x92,
+	0xe1, 0xc3, 0x7b, 0x3d, 0xf9, 0xb3, 0x1e, 0xe3, 0x9e, 0x30, 0x49, 0x0a, 0xbf, 0x2c, 0x32, 0xef,
+	0x97, 0x61, 0xf9, 0x19, 0x49, 0xb5, 0x89, 0x9f, 0xdf, 0xa8, 0xda, 0xf2, 0xbf, 0x01, 0x32, 0xef,
+	0x97, 0x61, 0x79, 0x6d, 0x5f, 0xc2, 0x14, 0x0d, 0x48, 0x0d, 0xe9, 0x54, 0xac, 0x7c, 0xd6, 0x63,
+	0x36, 0xb4, 0x00, 0x49, 0x98, 0x43, 0xbe, 0x02, 0x91, 0x84, 0xa9, 0xdf, 0x9d, 0x98, 0x0d, 0x2d,
+	0x40, 0xac, 0x84, 0x9a, 0x8d, 0x85, 0xaf, 0x2f, 0x0c, 0x31, 0x91, 0x9e, 0xff, 0xb1, 0x87, 0xd9,
+	0x2c, 0x04, 0xf2, 0x4a, 0x3c, 0x98, 0x93, 0x3f, 0x81, 0x30, 0xee, 0xca, 0xdc, 0xb9, 0x6b, 0xdb,
+	0xbc, 0x57, 0x02, 0xe5, 0x55, 0x85, 0x60, 0x0c, 0x95, 0xef, 0x2d, 0x0c, 0x71, 0xb0, 0x8a, 0x3f,
+	0xe2, 0x30, 0x1f, 0x94, 0x82, 0xa5, 0xb6, 0xf5, 0x8a, 0xdb, 0xb6, 0x3d, 0x7e, 0xdb, 0xb6, 0xcb,
+	0xda, 0xd6, 0x53, 0xbe, 0xb2, 0x30, 0xee, 0x17, 0x49, 0x90, 0x3e, 0xd4, 0x30, 0x1f, 0x94, 0x82,
+	0x79, 0x85, 0xbf, 0x85, 0x8b, 0x31, 0xff, 0x09, 0x84, 0xb1, 0x22, 0x8f, 0x84, 0xfe, 0x6b, 0x0c,
+	0x73, 0xb5, 0x08, 0x27, 0x06, 0x94, 0xd3, 0x31, 0xfb, 0xc4, 0xc1, 0xb8, 0x29, 0x33, 0x29, 0x5f,
+	0x57, 0x98, 0x56, 0x0e, 0x84, 0x17, 0xf9, 0x1d, 0x5c, 0x70, 0xb8, 0x6f, 0x11, 0x0c, 0xf1, 0xee,
+	0x39, 0xef, 0xb3, 0x09, 0x73, 0xa5, 0x00, 0x26, 0x59, 0xec, 0xb0, 0x12, 0x7e, 0xc9, 0x62, 0xdd,
+	0x37, 0x0e, 0xa6, 0x95, 0x03, 0xe1, 0x45, 0xbe, 0x81, 0x2b, 0x3d, 0x5d, 0x09, 0xb9, 0xb1, 0x26,
+	0x8f, 0x56, 0x71, 0xa5, 0xbf, 0xf9, 0x70, 0x1c, 0xbc, 0xd4, 0x98, 0x0e, 0xab, 0xe2, 0x96, 0x1a,
+	0xa3, 0x2b, 0x3b, 0x37, 0xad, 0x1c, 0x08, 0x2f, 0xb2, 0x0b, 0x97, 0x22, 0xb1, 0x20, 0xdb, 0x10,
+	0x1d, 0x45, 0x41, 0x15, 0xb8, 0x79, 0xb7, 0x18, 0x29, 0xbb, 0x54, 0x52, 0x9c, 0x2d, 0xbb, 0x54,
+	0xa5, 0xda, 0xdb, 0x6c, 0x68, 0x01, 0x52, 0x3f, 0x24, 0xac, 0xd4, 0x5a, 0xea, 0x07, 0x5d, 0xa5,
+	0xb7, 0x69, 0xe5, 0x40, 0x24, 0xfb, 0x22, 0x12, 0x97, 0x4b, 0xf6, 0xa9, 0x15, 0xda, 0x66, 0x43,
+	0x0b, 0xe0, 0x85, 0xed, 0xc1, 0x39, 0x2a, 0x6c, 0xdd, 0xd0, 0x81, 0x85, 0x32, 0x6c, 0xf3, 0xa6,
+	0x1e, 0x21, 0x19, 0x47, 0x2a, 0xec, 0x64, 0xe3, 0xd4, 0xe2, 0x6a, 0xb3, 0xa1, 0x05, 0x48, 0xc2,
+	0xfa, 0xa4, 0x34, 0x59, 0x12, 0xa6, 0xd6, 0x4c, 0x9b, 0x0d, 0x2d, 0x80, 0x17, 0xf6, 0x1c, 0xa0,
+	0x37, 0x2a, 0x24, 0x36, 0x6e, 0xc9, 0x13, 0x5a, 0x53, 0xf8, 0x6b, 0xde, 0xce, 0x05, 0x49, 0x82,
+	0xa3, 0x51, 0x65, 0xa9, 0x24, 0x58, 0x5f, 0xac, 0x6a, 0xde, 0xce, 0x05, 0x49, 0xfe, 0x26, 0xe2,
+	0x2a, 0x49, 0x25, 0x7f, 0x93, 0x57, 0xb2, 0x6a, 0xae, 0x14, 0xc0, 0x78, 0xf1, 0xdf, 0x90, 0x0e,
+	0x61, 0x75, 0xa5, 0x86, 0xd2, 0x56, 0x5d, 0x31, 0xaa, 0x99, 0x83, 0xd2, 0x86, 0x40, 0x62, 0x8c,
+	0xaa, 0x86, 0x40, 0xf9, 0x65, 0x95, 0xe6, 0xfd, 0x32, 0x2c, 0xaf, 0xed, 0x87, 0x0a, 0xd4, 0x7b,
+	0x39, 0x95, 0x7e, 0xc6, 0x87, 0x79, 0x92, 0x72, 0xeb, 0x23, 0xcd, 0xf5, 0x31, 0x59, 0x54, 0x47,
+	0xab, 0x56, 0xe8, 0xa9, 0x8e, 0xb6, 0xb8, 0x0c, 0xd0, 0x7c, 0x38, 0x0e, 0x9e, 0xd7, 0xfc, 0x02,
+	0xce, 0xc7, 0x59, 0xa5, 0x9d, 0x71, 0x5b, 0x89, 0x68, 0x34, 0x95, 0x7f, 0xe6, 0x9d, 0x7c, 0x94,
+	0xbc, 0x4b, 0xf3, 0x85, 0x73, 0xf2, 0x2e, 0x9d, 0x57, 0xad, 0x67, 0xae, 0x16, 0xe1, 0xc4, 0x4a,
+	0xa6, 0x99, 0x28, 0xad, 0xa3, 0x33, 0x2c, 0xa5, 0x74, 0x49, 0x29, 0xbc, 0x33, 0x6f, 0xe5, 0x61,
+	0x78, 0xa9, 0x43, 0x98, 0x8f, 0x34, 0x55, 0x72, 0xc6, 0xfb, 0x22, 0x73, 0x49, 0xf9, 0x9d, 0xb9,
+	0x36, 0x06, 0x5c, 0x59, 0xaf, 0x59, 0x0d, 0x9d, 0xb2, 0x5e, 0xf5, 0xa5, 0x77, 0xe6, 0x4a, 0x01,
+	0x4c, 0xda, 0xff, 0xba, 0x62, 0x99, 0x9d, 0xb4, 0xff, 0x15, 0xd4, 0xe8, 0x99, 0x77, 0x8b, 0x91,
+	0x52, 0xe0, 0x2e, 0x56, 0x87, 0x19, 0xab, 0x4a, 0xa7, 0xeb, 0x6b, 0xf9, 0xcc, 0x66, 0x21, 0x50,
+	0x5a, 0x30, 0xbe, 0xae, 0x36, 0x4c, 0x5a, 0x30, 0xa5, 0x05, 0x69, 0xe6, 0xc3, 0x71, 0xf0, 0xd2,
+	0x8e, 0xdc, 0x67, 0xc5, 0x5f, 0xd2, 0x8e, 0xac, 0xab, 0x23, 0x33, 0xad, 0x1c, 0x88, 0xb4, 0x06,
+	0x7b, 0x59, 0x25, 0x98, 0xc6, 0x95, 0x6a, 0x0a, 0xca, 0xcc, 0x3b, 0xf9, 0x28, 0xc9, 0x97, 0x3a,
+	0x72, 0x45, 0x8d, 0xe4, 0x4b, 0x0b, 0x8b, 0x7d, 0xcc, 0xfb, 0x65, 0x58, 0x69, 0xe5, 0xf4, 0x35,
+	0xf5, 0x30, 0xd2, 0xca, 0x29, 0x2b, 0xd8, 0x31, 0xd7, 0xc6, 0x80, 0x2b, 0x0b, 0x56, 0xad, 0x96,
+	0x51, 0x16, 0x6c, 0x71, 0x11, 0x8e, 0xb9, 0x36, 0x06, 0x9c, 0x57, 0x1b, 0xc1, 0x65, 0x5f, 0x2d,
+	0x39, 0x31, 0x1e, 0xa8, 0x53, 0x2a, 0xbf, 0x42, 0xc7, 0x7c, 0xbf, 0x1c, 0x2d, 0x1f, 0x22, 0xb8,
+	0x2a, 0x04, 0xf9, 0x10, 0x91, 0x53, 0xf1, 0x60, 0xae, 0x14, 0xc0, 0x24, 0x27, 0xd1, 0x17, 0x6b,
+	0x0e, 0x8c, 0x66, 0xde, 0x60, 0x28, 0x4a, 0xee, 0x16, 0x23, 0x95, 0x60, 0x5c, 0x28, 0x40, 0x50,
+	0x82, 0xf1, 0xdc, 0xca, 0x06, 0xf3, 0x6e, 0x31, 0x52, 0x72, 0x46, 0xbe, 0x50, 0x86, 0x60, 0xac,
+	0xea, 0xfb, 0x5b, 0xa9, 0x72, 0x30, 0x9b, 0x85, 0x40, 0xa9, 0x31, 0x3d, 0x31, 0x81, 0x6f, 0x34,
+	0x75, 0xab, 0x53, 0x57, 0x0f, 0x60, 0xde, 0x2d, 0x46, 0xaa, 0x71, 0x91, 0x98, 0x66, 0x57, 0xe3,
+	0xa2, 0xfc, 0xb4, 0xbf, 0x79, 0xbf, 0x0c, 0xab, 0x9e, 0x80, 0x69, 0xde, 0x5a, 0x39, 0x01, 0x2b,
+	0x49, 0x74, 0xd3, 0xca, 0x81, 0xf0, 0x22, 0xbf, 0x80, 0xc9, 0x2e, 0xce, 0x0d, 0x1b, 0x72, 0x12,
+	0x55, 0xca, 0x5b, 0x9b, 0x37, 0x74, 0xef, 0x55, 0xe3, 0x48, 0xe6, 0x57, 0x35, 0x4e, 0x49, 0x33,
+	0x9b, 0x56, 0x0e, 0x44, 0x8a, 0x56, 0x3a, 0x7c, 0x2e, 0xd6, 0x58, 0xd1, 0x5c, 0x78, 0x69, 0xd2,
+	0xc9, 0xe6, 0x6a, 0x11, 0x4e, 0xd2, 0xd0, 0xe3, 0xd3, 0xb3, 0xc6, 0x8a, 0x7a, 0x40, 0xd0, 0xe5,
+	0x7a, 0xcd, 0xd5, 0x22, 0x1c, 0xaf, 0xe1, 0x77, 0x70, 0x75, 0xa8, 0xcd, 0xa2, 0x1a, 0xe2, 0x3e,
+	0x57, 0x9e, 0xe7, 0x35, 0x3f, 0x18, 0x8b, 0x41, 0x5a, 0x6b, 0x43, 0x21, 0xa5, 0x68, 0xac, 0x6a,
+	0x64, 0x68, 0x95, 0x35, 0x0b, 0x81, 0xbc, 0x92, 0x98, 0x5c, 0x35, 0xe1, 0xd3, 0x5e, 0x8f, 0xdc,
+	0xe7, 0x1d, 0x85, 0xaf, 0x50, 0x90, 0x39, 0xfa, 0x4e, 0xd8, 0xef, 0x87, 0xc1, 0xda, 0xb6, 0x02,
+	0xd1, 0x3b, 0xfa, 0x02, 0x38, 0xaf, 0xf4, 0x0c, 0xef, 0x2f, 0x01, 0x7a, 0x2d, 0xab, 0x7d, 0x28,
+	0xc9, 0xb1, 0x35, 0x20, 0x7d, 0xa7, 0x16, 0x32, 0xf0, 0xaa, 0x7f, 0x0f, 0x57, 0x3a, 0x38, 0x83,
+	0xea, 0xcb, 0xba, 0x65, 0x51, 0x1b, 0x3a, 0x94, 0xa0, 0xfc, 0xc3, 0xf1, 0x38, 0xa4, 0x8d, 0x35,
+	0xd6, 0x24, 0x71, 0xa5, 0x8d, 0xb5, 0x2c, 0x53, 0x6c, 0xae, 0x8d, 0x01, 0x97, 0xd4, 0xf6, 0x34,
+	0x29, 0x5f, 0x49, 0x6d, 0x59, 0xe6, 0xd8, 0x5c, 0x1b, 0x03, 0x2e, 0x4d, 0xe0, 0x8e, 0x90, 0x8b,
+	0x35, 0xb4, 0x4b, 0x5b, 0x93, 0xeb, 0x33, 0x9b, 0x85, 0x40, 0x49, 0x49, 0x24, 0xa4, 0x45, 0x8d,
+	0x55, 0xcd, 0xb5, 0xc8, 0x18, 0x4a, 0x0a, 0x92, 0xab, 0xd8, 0xd3, 0x08, 0x69, 0x4b, 0xc9, 0xd3,
+	0xe4, 0x26, 0x5e, 0xcd, 0xd5, 0x22, 0x9c, 0x74, 0xbb, 0xec, 0x4a, 0xb9, 0x51, 0xe9, 0x76, 0xb9,
+	0x28, 0xfd, 0x6a, 0xde, 0x2b, 0x81, 0xf2, 0xaa, 0xfe, 0xba, 0x02, 0x66, 0x2f, 0xf7, 0xfb, 0x69,
+	0xe3, 0x23, 0xe5, 0xe6, 0xb8, 0xfc, 0x9b, 0x6c, 0xf3, 0xe3, 0xb1, 0x99, 0xa4, 0xb1, 0x73, 0x85,
+	0x4c, 0xac, 0x34, 0x76, 0xf9, 0xc9, 0x60, 0xb3, 0x59, 0x08, 0x54, 0xef, 0x02, 0xd4, 0xaf, 0x99,
+	0xd5, 0xbb, 0x80, 0xe2, 0x0f, 0xb8, 0xcd, 0x87, 0xe3, 0xe0, 0xa5, 0xd8, 0xd2, 0xe3, 0xbe, 0x0a,
+	0x91, 0x62, 0xcb, 0xbc, 0xcf, 0x4b, 0xcc, 0x95, 0x02, 0x98, 0x34, 0x65, 0x68, 0x6c, 0xc9, 0xd2,
+	0xcc, 0x38, 0x47, 0xa0, 0x0b, 0x19, 0xb3, 0xd7, 0x05, 0x53, 0x46, 0x85, 0x4a, 0xaa, 0x68, 0x78,
+	0x99, 0xab, 0xca, 0x96, 0x5e, 0x17, 0xa8, 0x52, 0xa1, 0x5a, 0x55, 0x2c, 0xc1, 0xd6, 0xea, 0xf8,
+	0x5a, 0x55, 0xd9, 0xeb, 0x52, 0x55, 0x3c, 0x54, 0xbe, 0xed, 0x48, 0x6d, 0x91, 0x6f, 0x3b, 0x52,
+	0x7a, 0xd1, 0x6d, 0x47, 0x86, 0x91, 0x6e, 0x49, 0x63, 0x44, 0xcc, 0xbe, 0x21, 0xbb, 0x69, 0x59,
+	0x5e, 0x43, 0x0b, 0x90, 0xa6, 0x50, 0x8f, 0x90, 0xe9, 0xe5, 0x9f, 0xa1, 0x9c, 0x52, 0x47, 0xaf,
+	0x0a, 0xa6, 0x90, 0x08, 0x53, 0xc3, 0xbe, 0x6f, 0x5a, 0x49, 0xa2, 0xc9, 0xca, 0x10, 0x72, 0x71,
+	0xd8, 0xc7, 0x20, 0x52, 0xa7, 0xf6, 0xd8, 0x0b, 0xf9, 0x0a, 0x69, 0x3b, 0xa5, 0x17, 0x74, 0x2a,
+	0x87, 0x91, 0x2e, 0x75, 0xf1, 0xb9, 0x83, 0x89, 0xbd, 0xa5, 0x1c, 0x25, 0x34, 0x72, 0x6f, 0xe7,
+	0x82, 0xa4, 0xbb, 0x02, 0x3a, 0x07, 0x68, 0x27, 0xdc, 0xd6, 0x8c, 0xb0, 0xda, 0x0f, 0x77, 0xf2,
+	0x51, 0x92, 0xec, 0x4e, 0x56, 0xdc, 0x21, 0xc9, 0xce, 0xa9, 0x31, 0x31, 0xef, 0xe4, 0xa3, 0xa4,
+	0x2d, 0x9d, 0xee, 0xad, 0xd9, 0xfe, 0xfb, 0x22, 0x0c, 0xe4, 0x23, 0xfa, 0x86, 0x06, 0x52, 0xb0,
+	0xa5, 0xeb, 0xe1, 0x9a, 0x23, 0xba, 0x08, 0xd1, 0x1d, 0xd1, 0x25, 0x44, 0xc9, 0x11, 0x5d, 0x41,
+	0xab, 0x6b, 0xa0, 0xfd, 0x02, 0x07, 0xca, 0xb8, 0xe2, 0x46, 0x59, 0x03, 0xa3, 0x57, 0xc5, 0x6b,
+	0x80, 0x83, 0x49, 0x31, 0x7e, 0x8c, 0x92, 0x36, 0xfd, 0x17, 0xa7, 0x1b, 0xe4, 0x5f, 0x9c, 0xb2,
+	0xec, 0xee, 0x43, 0x79, 0xba, 0x6b, 0x40, 0x05, 0x31, 0x7e, 0x1e, 0x83, 0xba, 0x39, 0xa9, 0xe5,
+	0x3b, 0xea, 0xe6, 0x54, 0x5c, 0x07, 0x64, 0x3e, 0x1c, 0x07, 0xaf, 0xa6, 0xb3, 0x85, 0x52, 0x1e,
+	0x35, 0x9d, 0x9d, 0x5b, 0x1d, 0x64, 0xde, 0x2b, 0x81, 0x6a, 0x32, 0x02, 0x6a, 0x67, 0xe0, 0x6d,
+	0x44, 0xc9, 0x08, 0x68, 0x61, 0xc5, 0x19, 0x81, 0x1c, 0x16, 0x69, 0x94, 0x7b, 0xe3, 0x8c, 0xf2,
+	0xf6, 0xdb, 0x8e, 0xf2, 0xf6, 0x18, 0xa3, 0x4c, 0x0f, 0xaa, 0x59, 0x19, 0xbd, 0x7a, 0x50, 0xd5,
+	0x17, 0xf2, 0x9b, 0xab, 0x45, 0x38, 0xf9, 0x60, 0xa1, 0xa9, 0x81, 0x92, 0x0f, 0x16, 0x25, 0xc5,
+	0x55, 0xe6, 0xda, 0x18, 0x70, 0x79, 0x64, 0x49, 0x15, 0x86, 0xae, 0x63, 0x3f, 0x54, 0xcb, 0x2b,
+	0xca, 0xba, 0x76, 0x7d, 0x4c, 0x16, 0xce, 0x86, 0x27, 0x5f, 0xc2, 0x9d, 0x30, 0xea, 0xad, 0x39,
+	0x03, 0x7c, 0x9b, 0x24, 0xf0, 0x0f, 0x84, 0x7f, 0x94, 0xfc, 0x24, 0xe7, 0xdf, 0x28, 0x93, 0xbf,
+	0xf1, 0x8f, 0x95, 0xca, 0x3f, 0x56, 0x2a, 0xff, 0x3b, 0x00, 0xcf, 0x25, 0xc0, 0xc6, 0x6b, 0x59,
 	0x00, 0x00,
 }
@@ -15,6 +15,8 @@ It is generated from these files:
 	inotify.proto
 	hdfs.proto
 	acl.proto
+	HdfsServer.proto
+	NamenodeProtocol.proto
 
 It has these top-level messages:
 	StartReconfigurationRequestProto
@@ -42,6 +44,8 @@ It has these top-level messages:
 	GetEZForPathResponseProto
 	SetErasureCodingPolicyRequestProto
 	SetErasureCodingPolicyResponseProto
+	UnsetErasureCodingPolicyRequestProto
+	UnsetErasureCodingPolicyResponseProto
 	GetErasureCodingPoliciesRequestProto
 	GetErasureCodingPoliciesResponseProto
 	GetErasureCodingPolicyRequestProto
@@ -196,6 +200,8 @@ It has these top-level messages:
 	GetCurrentEditLogTxidResponseProto
 	GetEditsFromTxidRequestProto
 	GetEditsFromTxidResponseProto
+	SatisfyStoragePolicyRequestProto
+	SatisfyStoragePolicyResponseProto
 	DataTransferEncryptorMessageProto
 	BaseHeaderProto
 	DataTransferTraceInfoProto
@@ -242,6 +248,9 @@ It has these top-level messages:
 	TriggerBlockReportResponseProto
 	GetBalancerBandwidthRequestProto
 	GetBalancerBandwidthResponseProto
+	GetVolumeReportRequestProto
+	GetVolumeReportResponseProto
+	DatanodeVolumeInfoProto
 	EventProto
 	EventBatchProto
 	CreateEventProto
@@ -277,7 +286,9 @@ It has these top-level messages:
 	ECSchemaOptionEntryProto
 	ECSchemaProto
 	ErasureCodingPolicyProto
+	ErasureCodingPolicyInfoProto
 	HdfsFileStatusProto
+	BlockChecksumOptionsProto
 	FsServerDefaultsProto
 	DirectoryListingProto
 	SnapshottableDirectoryStatusProto
@@ -302,6 +313,14 @@ It has these top-level messages:
 	SetAclResponseProto
 	GetAclStatusRequestProto
 	GetAclStatusResponseProto
+	BlockWithLocationsProto
+	BlocksWithLocationsProto
+	StorageInfoProto
+	NamespaceInfoProto
+	VersionRequestProto
+	VersionResponseProto
+	GetBlocksRequestProto
+	GetBlocksResponseProto
 */
 package hadoop_hdfs
 
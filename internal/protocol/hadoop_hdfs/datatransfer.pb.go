@@ -680,8 +680,9 @@ func (m *OpCopyBlockProto) GetHeader() *BaseHeaderProto {
 }
 
 type OpBlockChecksumProto struct {
-	Header           *BaseHeaderProto `protobuf:"bytes,1,req,name=header" json:"header,omitempty"`
-	XXX_unrecognized []byte           `json:"-"`
+	Header               *BaseHeaderProto           `protobuf:"bytes,1,req,name=header" json:"header,omitempty"`
+	BlockChecksumOptions *BlockChecksumOptionsProto `protobuf:"bytes,2,opt,name=blockChecksumOptions" json:"blockChecksumOptions,omitempty"`
+	XXX_unrecognized     []byte                     `json:"-"`
 }
 
 func (m *OpBlockChecksumProto) Reset()                    { *m = OpBlockChecksumProto{} }
@@ -696,13 +697,23 @@ func (m *OpBlockChecksumProto) GetHeader() *BaseHeaderProto {
 	return nil
 }
 
+func (m *OpBlockChecksumProto) GetBlockChecksumOptions() *BlockChecksumOptionsProto {
+	if m != nil {
+		return m.BlockChecksumOptions
+	}
+	return nil
+}
+
 type OpBlockGroupChecksumProto struct {
 	Header    *BaseHeaderProto    `protobuf:"bytes,1,req,name=header" json:"header,omitempty"`
 	Datanodes *DatanodeInfosProto `protobuf:"bytes,2,req,name=datanodes" json:"datanodes,omitempty"`
 	// each internal block has a block token
-	BlockTokens      []*hadoop_common.TokenProto `protobuf:"bytes,3,rep,name=blockTokens" json:"blockTokens,omitempty"`
-	EcPolicy         *ErasureCodingPolicyProto   `protobuf:"bytes,4,req,name=ecPolicy" json:"ecPolicy,omitempty"`
-	XXX_unrecognized []byte                      `json:"-"`
+	BlockTokens          []*hadoop_common.TokenProto `protobuf:"bytes,3,rep,name=blockTokens" json:"blockTokens,omitempty"`
+	EcPolicy             *ErasureCodingPolicyProto   `protobuf:"bytes,4,req,name=ecPolicy" json:"ecPolicy,omitempty"`
+	BlockIndices         []uint32                    `protobuf:"varint,5,rep,name=blockIndices" json:"blockIndices,omitempty"`
+	RequestedNumBytes    *uint64                     `protobuf:"varint,6,req,name=requestedNumBytes" json:"requestedNumBytes,omitempty"`
+	BlockChecksumOptions *BlockChecksumOptionsProto  `protobuf:"bytes,7,opt,name=blockChecksumOptions" json:"blockChecksumOptions,omitempty"`
+	XXX_unrecognized     []byte                      `json:"-"`
 }
 
 func (m *OpBlockGroupChecksumProto) Reset()                    { *m = OpBlockGroupChecksumProto{} }
@@ -738,6 +749,27 @@ func (m *OpBlockGroupChecksumProto) GetEcPolicy() *ErasureCodingPolicyProto {
 	return nil
 }
 
+func (m *OpBlockGroupChecksumProto) GetBlockIndices() []uint32 {
+	if m != nil {
+		return m.BlockIndices
+	}
+	return nil
+}
+
+func (m *OpBlockGroupChecksumProto) GetRequestedNumBytes() uint64 {
+	if m != nil && m.RequestedNumBytes != nil {
+		return *m.RequestedNumBytes
+	}
+	return 0
+}
+
+func (m *OpBlockGroupChecksumProto) GetBlockChecksumOptions() *BlockChecksumOptionsProto {
+	if m != nil {
+		return m.BlockChecksumOptions
+	}
+	return nil
+}
+
 // *
 // An ID uniquely identifying a shared memory segment.
 type ShortCircuitShmIdProto struct {
@@ -1192,11 +1224,12 @@ func (m *DNTransferAckProto) GetStatus() Status {
 }
 
 type OpBlockChecksumResponseProto struct {
-	BytesPerCrc      *uint32            `protobuf:"varint,1,req,name=bytesPerCrc" json:"bytesPerCrc,omitempty"`
-	CrcPerBlock      *uint64            `protobuf:"varint,2,req,name=crcPerBlock" json:"crcPerBlock,omitempty"`
-	Md5              []byte             `protobuf:"bytes,3,req,name=md5" json:"md5,omitempty"`
-	CrcType          *ChecksumTypeProto `protobuf:"varint,4,opt,name=crcType,enum=hadoop.hdfs.ChecksumTypeProto" json:"crcType,omitempty"`
-	XXX_unrecognized []byte             `json:"-"`
+	BytesPerCrc          *uint32                    `protobuf:"varint,1,req,name=bytesPerCrc" json:"bytesPerCrc,omitempty"`
+	CrcPerBlock          *uint64                    `protobuf:"varint,2,req,name=crcPerBlock" json:"crcPerBlock,omitempty"`
+	BlockChecksum        []byte                     `protobuf:"bytes,3,req,name=blockChecksum" json:"blockChecksum,omitempty"`
+	CrcType              *ChecksumTypeProto         `protobuf:"varint,4,opt,name=crcType,enum=hadoop.hdfs.ChecksumTypeProto" json:"crcType,omitempty"`
+	BlockChecksumOptions *BlockChecksumOptionsProto `protobuf:"bytes,5,opt,name=blockChecksumOptions" json:"blockChecksumOptions,omitempty"`
+	XXX_unrecognized     []byte                     `json:"-"`
 }
 
 func (m *OpBlockChecksumResponseProto) Reset()                    { *m = OpBlockChecksumResponseProto{} }
@@ -1218,9 +1251,9 @@ func (m *OpBlockChecksumResponseProto) GetCrcPerBlock() uint64 {
 	return 0
 }
 
-func (m *OpBlockChecksumResponseProto) GetMd5() []byte {
+func (m *OpBlockChecksumResponseProto) GetBlockChecksum() []byte {
 	if m != nil {
-		return m.Md5
+		return m.BlockChecksum
 	}
 	return nil
 }
@@ -1232,6 +1265,13 @@ func (m *OpBlockChecksumResponseProto) GetCrcType() ChecksumTypeProto {
 	return ChecksumTypeProto_CHECKSUM_NULL
 }
 
+func (m *OpBlockChecksumResponseProto) GetBlockChecksumOptions() *BlockChecksumOptionsProto {
+	if m != nil {
+		return m.BlockChecksumOptions
+	}
+	return nil
+}
+
 type OpCustomProto struct {
 	CustomId         *string `protobuf:"bytes,1,req,name=customId" json:"customId,omitempty"`
 	XXX_unrecognized []byte  `json:"-"`
@@ -1287,133 +1327,137 @@ func init() {
 func init() { proto.RegisterFile("datatransfer.proto", fileDescriptor5) }
 
 var fileDescriptor5 = []byte{
-	// 2039 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x58, 0x4b, 0x73, 0xdc, 0xc6,
-	0xf1, 0x17, 0xf6, 0x41, 0xee, 0xf6, 0x92, 0x14, 0x38, 0x96, 0x68, 0x88, 0xd2, 0x5f, 0xa2, 0x20,
-	0xcb, 0x7f, 0x5a, 0x4e, 0x31, 0x31, 0x6d, 0xb9, 0x6c, 0xc5, 0x4e, 0x6a, 0x1f, 0x90, 0xb4, 0x21,
-	0xb5, 0xd8, 0x1a, 0x2c, 0xa9, 0x3c, 0x0e, 0x5b, 0x23, 0x60, 0xc8, 0x45, 0x11, 0x0b, 0xc0, 0x98,
-	0xd9, 0x58, 0xab, 0x53, 0x0e, 0x39, 0xe4, 0x98, 0x53, 0xce, 0x39, 0xe5, 0x96, 0x7c, 0x82, 0x1c,
-	0xf2, 0x05, 0xf2, 0x1d, 0x72, 0xc8, 0x25, 0x55, 0x39, 0xa6, 0x72, 0x4e, 0xcd, 0x0c, 0xb0, 0x04,
-	0x96, 0x4b, 0x32, 0xa2, 0x75, 0xc8, 0x0d, 0xd3, 0xd3, 0xdd, 0xe8, 0xfe, 0x4d, 0x4f, 0x3f, 0x06,
-	0x90, 0x47, 0x38, 0xe1, 0x09, 0x09, 0xd9, 0x11, 0x4d, 0x76, 0xe2, 0x24, 0xe2, 0x11, 0x6a, 0x8c,
-	0x88, 0x17, 0x45, 0xf1, 0xce, 0xc8, 0x3b, 0x62, 0x9b, 0x6b, 0x0e, 0x75, 0x27, 0x89, 0xcf, 0xa7,
-	0x6a, 0x73, 0x13, 0x04, 0x55, 0x7d, 0x9b, 0x7f, 0x2d, 0xc1, 0xfd, 0x0e, 0xe1, 0x64, 0x90, 0xca,
-	0x5b, 0xa1, 0x9b, 0x4c, 0x63, 0x1e, 0x25, 0x2f, 0x28, 0x63, 0xe4, 0x98, 0xf6, 0xa5, 0xba, 0x57,
-	0xb0, 0xc4, 0x38, 0xe1, 0x13, 0x66, 0x68, 0x5b, 0xa5, 0xed, 0xb5, 0xdd, 0x9f, 0xec, 0xe4, 0xf4,
-	0xef, 0x5c, 0x2a, 0xbf, 0x98, 0xc3, 0x91, 0x1a, 0x71, 0xaa, 0x19, 0x19, 0xb0, 0x1c, 0x93, 0x69,
-	0x10, 0x11, 0xcf, 0x28, 0x6d, 0x69, 0xdb, 0x2b, 0x38, 0x5b, 0x8a, 0x9d, 0xb1, 0xd2, 0x66, 0x94,
-	0xb7, 0xb4, 0xed, 0x3a, 0xce, 0x96, 0xa8, 0x05, 0x2b, 0xae, 0x1f, 0x8f, 0x68, 0x62, 0xc7, 0xdc,
-	0x8f, 0x42, 0xa3, 0xb2, 0x55, 0xde, 0x6e, 0xec, 0xde, 0x2d, 0x58, 0xd7, 0xce, 0x31, 0x48, 0x6b,
-	0x70, 0x41, 0xc6, 0xdc, 0x87, 0xdb, 0x17, 0x98, 0x87, 0x1a, 0xb0, 0xec, 0x1c, 0xb4, 0xdb, 0x96,
-	0xe3, 0xe8, 0xd7, 0xd0, 0x4d, 0x58, 0xb7, 0x30, 0xb6, 0xf1, 0xf0, 0xa0, 0xb7, 0xd7, 0xb3, 0x5f,
-	0xf6, 0x86, 0x7b, 0xd6, 0xcf, 0x74, 0x0d, 0xd5, 0xa1, 0x2a, 0xc9, 0x7a, 0xc9, 0xfc, 0x8b, 0x06,
-	0xd7, 0x5b, 0x84, 0xd1, 0xe7, 0x94, 0x78, 0x34, 0x51, 0xe8, 0x3d, 0x86, 0xea, 0xab, 0x20, 0x72,
-	0x4f, 0x24, 0x78, 0x8d, 0xdd, 0x7b, 0x05, 0xf3, 0xac, 0xd7, 0x9c, 0x86, 0x1e, 0xf5, 0x5a, 0x82,
-	0x43, 0xd9, 0xa7, 0xb8, 0xd1, 0xf7, 0xa1, 0xca, 0xa3, 0x13, 0x1a, 0x4a, 0x38, 0x1a, 0xbb, 0xb7,
-	0x32, 0x31, 0x37, 0x1a, 0x8f, 0xa3, 0x70, 0x67, 0x20, 0xf6, 0x52, 0x01, 0xc9, 0x87, 0x2c, 0xa8,
-	0xf3, 0x84, 0xb8, 0xb4, 0x1b, 0x1e, 0x45, 0x12, 0xa9, 0xc6, 0xee, 0xff, 0x9f, 0x7b, 0x50, 0x83,
-	0x8c, 0x53, 0xa9, 0x38, 0x95, 0x34, 0x31, 0x6c, 0x9e, 0xcf, 0x28, 0x0e, 0x43, 0xb1, 0x7a, 0xd2,
-	0x9d, 0x0a, 0xce, 0x96, 0x68, 0x13, 0x6a, 0x31, 0x49, 0x68, 0xc8, 0xbb, 0xe2, 0x04, 0xc5, 0xd6,
-	0x6c, 0x6d, 0xbe, 0x81, 0xcd, 0x76, 0xe0, 0xd3, 0x90, 0xdb, 0x31, 0x4d, 0x88, 0xc0, 0x3d, 0x0f,
-	0xd0, 0x57, 0x00, 0xaf, 0x66, 0x98, 0xa5, 0x28, 0xdd, 0x29, 0x58, 0x3e, 0x07, 0x29, 0xce, 0xf1,
-	0xa3, 0xbb, 0x00, 0xae, 0xd4, 0xdd, 0x23, 0x63, 0x2a, 0xff, 0x5c, 0xc7, 0x39, 0x8a, 0x39, 0x80,
-	0x1b, 0x6d, 0xe2, 0x8e, 0xfc, 0xf0, 0xd8, 0xe1, 0x09, 0xe1, 0xf4, 0x78, 0xaa, 0xfe, 0x7a, 0x17,
-	0xc0, 0x4b, 0xa2, 0xb8, 0x45, 0x47, 0x7e, 0x28, 0x9c, 0xd1, 0xb6, 0x6b, 0x38, 0x47, 0x41, 0x77,
-	0xa0, 0x9e, 0x50, 0xe2, 0x91, 0x11, 0x4d, 0x43, 0xb2, 0x8c, 0x4f, 0x09, 0xe6, 0xbf, 0x35, 0xd0,
-	0xed, 0x18, 0x53, 0x92, 0x3b, 0x39, 0xf4, 0x63, 0x58, 0x1a, 0xe5, 0x9d, 0x28, 0xc2, 0x7f, 0x3e,
-	0x02, 0x38, 0x15, 0x43, 0x1b, 0xb0, 0x14, 0x1d, 0x1d, 0x31, 0xca, 0x53, 0x04, 0xd3, 0x15, 0xd2,
-	0xa1, 0x1c, 0xd0, 0xd0, 0x28, 0x4b, 0xa2, 0xf8, 0x44, 0x8f, 0x60, 0x95, 0xd1, 0xd0, 0x6b, 0x8f,
-	0xa8, 0x7b, 0xc2, 0x26, 0x63, 0x66, 0x54, 0x84, 0x03, 0x4f, 0x2a, 0x3c, 0x99, 0x50, 0x5c, 0xdc,
-	0x42, 0x7b, 0x70, 0xdd, 0x2d, 0x22, 0x60, 0x54, 0x65, 0x78, 0xdc, 0x2f, 0xda, 0xb7, 0x00, 0x25,
-	0x3c, 0x2f, 0x69, 0x46, 0xb0, 0x9a, 0x69, 0x56, 0x4e, 0xef, 0x42, 0x85, 0x4f, 0x63, 0x9a, 0xa6,
-	0x86, 0xb9, 0xcb, 0x97, 0x72, 0x0e, 0xa6, 0xb1, 0x4a, 0x05, 0x58, 0xf2, 0xa2, 0x47, 0xa0, 0xbf,
-	0x9a, 0x72, 0xca, 0xfa, 0x34, 0xc9, 0x58, 0xa4, 0xc7, 0xab, 0xf8, 0x0c, 0xdd, 0xfc, 0x47, 0x0d,
-	0xd6, 0xed, 0xf8, 0x65, 0xe2, 0x73, 0xfa, 0x2e, 0xa1, 0xfe, 0x02, 0x96, 0x39, 0x49, 0x8e, 0x29,
-	0x67, 0x46, 0x69, 0x41, 0xda, 0x10, 0x57, 0x20, 0x8c, 0xbc, 0xdc, 0x15, 0xc9, 0xd8, 0xd1, 0xe7,
-	0xb0, 0xc4, 0xa2, 0x49, 0xe2, 0xd2, 0xf4, 0x92, 0x5d, 0x26, 0x98, 0x72, 0xa3, 0x3d, 0xa8, 0x32,
-	0x2e, 0xb2, 0x58, 0x45, 0x22, 0xf5, 0xb8, 0x20, 0x76, 0xc6, 0xc3, 0x1d, 0xf9, 0xd9, 0x8e, 0x42,
-	0xc6, 0x93, 0x89, 0x2b, 0xbc, 0x70, 0x84, 0x30, 0x56, 0x3a, 0x90, 0x09, 0x2b, 0xb1, 0x1f, 0xd3,
-	0xc0, 0x0f, 0xa9, 0xe3, 0xbf, 0xa1, 0x46, 0x55, 0xa2, 0x57, 0xa0, 0x09, 0x9e, 0xb1, 0x1f, 0xb6,
-	0x04, 0xa0, 0xd8, 0xfd, 0xa5, 0x67, 0x2c, 0xc9, 0xf0, 0x29, 0xd0, 0x24, 0x0f, 0x79, 0x7d, 0xca,
-	0xb3, 0x9c, 0xf2, 0xe4, 0x68, 0xe8, 0x33, 0xb8, 0x19, 0x10, 0x4e, 0x19, 0x7f, 0x46, 0xc3, 0x14,
-	0x51, 0x87, 0x93, 0x71, 0x6c, 0xd4, 0x24, 0xf3, 0xe2, 0x4d, 0xf4, 0x1c, 0xd6, 0x13, 0xfa, 0xcd,
-	0x84, 0x32, 0x4e, 0x67, 0xb1, 0x68, 0xd4, 0xe5, 0x61, 0x6d, 0x2e, 0x0c, 0x12, 0x85, 0xd6, 0x59,
-	0xa1, 0x45, 0xf1, 0x0b, 0x57, 0x8d, 0x5f, 0x64, 0x41, 0x83, 0xf1, 0x28, 0x21, 0xc7, 0x54, 0x04,
-	0xa5, 0xd1, 0xd8, 0xd2, 0xb6, 0xd7, 0x76, 0xff, 0xaf, 0xa0, 0xc8, 0x39, 0xdd, 0x97, 0x4a, 0x9e,
-	0x54, 0x3a, 0x5d, 0x67, 0x0f, 0xe7, 0xe5, 0xd0, 0x0b, 0x40, 0x2a, 0x1e, 0x72, 0xcc, 0xcc, 0x58,
-	0xd9, 0x2a, 0x5f, 0xaa, 0x0d, 0x2f, 0x10, 0x44, 0x9f, 0x80, 0x4e, 0x82, 0x20, 0xfa, 0x76, 0x9f,
-	0xbc, 0x99, 0xf6, 0x69, 0xc2, 0x7c, 0xc6, 0x8d, 0x55, 0x79, 0xa3, 0xab, 0x47, 0x24, 0x60, 0x14,
-	0x9f, 0xd9, 0x46, 0xf7, 0x60, 0x39, 0xf6, 0xc3, 0xd0, 0x0f, 0x8f, 0x8d, 0xb5, 0x3c, 0x67, 0x46,
-	0x45, 0x1f, 0xc2, 0x9a, 0xfa, 0x53, 0x5f, 0x11, 0x98, 0x71, 0x7d, 0xab, 0xbc, 0x5d, 0xc3, 0x73,
-	0x54, 0xf3, 0x37, 0x25, 0xd8, 0x58, 0x1c, 0x6c, 0xe8, 0x16, 0xdc, 0xec, 0x77, 0xfb, 0xd6, 0x7e,
-	0xb7, 0x67, 0x0d, 0x1d, 0x6b, 0x70, 0xd0, 0x1f, 0x36, 0xfb, 0x7d, 0xab, 0xd7, 0xd1, 0xaf, 0x21,
-	0x13, 0xee, 0x2e, 0xdc, 0x1a, 0x62, 0xab, 0x6d, 0x1f, 0x5a, 0x58, 0x14, 0x46, 0x04, 0x6b, 0x9d,
-	0xe6, 0xa0, 0x39, 0x74, 0x06, 0xd8, 0x6a, 0xbe, 0xe8, 0xf6, 0x9e, 0xe9, 0x25, 0xf4, 0x10, 0xee,
-	0xcf, 0xc9, 0xcd, 0x76, 0x4f, 0x45, 0xcb, 0x42, 0x74, 0xc6, 0xd6, 0xde, 0xb7, 0x1d, 0x4b, 0xaf,
-	0xa0, 0xdb, 0xf0, 0x7e, 0x91, 0x76, 0x2a, 0x50, 0x5d, 0x60, 0x6a, 0x1b, 0x5b, 0xcd, 0x81, 0xa5,
-	0x2f, 0x21, 0x1d, 0x56, 0x06, 0xb8, 0xd9, 0x73, 0x9e, 0x5a, 0x78, 0x88, 0x5b, 0x2f, 0xf5, 0x65,
-	0xb4, 0x01, 0x68, 0x46, 0x79, 0xda, 0xed, 0x35, 0xf7, 0xbb, 0x3f, 0xb7, 0x3a, 0x7a, 0xcd, 0xfc,
-	0x9b, 0x06, 0x37, 0xec, 0x38, 0x2b, 0x7d, 0xff, 0x1b, 0xe9, 0x66, 0x71, 0xa4, 0x95, 0xaf, 0x18,
-	0x69, 0xe6, 0xdf, 0x35, 0x78, 0x4f, 0x14, 0xae, 0x38, 0x20, 0x6e, 0x3e, 0xa1, 0x7e, 0x36, 0xe7,
-	0xe1, 0xc5, 0x05, 0x38, 0x73, 0xcb, 0x80, 0x65, 0x8f, 0x06, 0xcf, 0xfd, 0x90, 0xa7, 0x95, 0x37,
-	0x5b, 0x16, 0xb2, 0x64, 0xe9, 0x2d, 0xb2, 0xe4, 0xdc, 0xfd, 0xac, 0x5c, 0xed, 0x7e, 0x9a, 0xcf,
-	0x45, 0x79, 0x6e, 0x47, 0xf1, 0xf4, 0xbb, 0xba, 0x68, 0xee, 0x8b, 0x90, 0x50, 0xf7, 0xa3, 0x50,
-	0xf7, 0xae, 0xa6, 0xed, 0xb7, 0x25, 0xb8, 0x95, 0xaa, 0x7b, 0x96, 0x44, 0x93, 0xf8, 0x1d, 0xe8,
-	0x44, 0x5f, 0x43, 0xdd, 0x4b, 0xf1, 0x64, 0xf2, 0x18, 0xe6, 0x9b, 0xcc, 0x3c, 0xda, 0x2c, 0x6d,
-	0xf8, 0x66, 0x12, 0xe8, 0x87, 0xd0, 0x90, 0x1d, 0xa7, 0xec, 0x28, 0x55, 0x64, 0x5d, 0xd8, 0x6e,
-	0xe6, 0xb9, 0x51, 0x13, 0x6a, 0xd4, 0xed, 0x47, 0x81, 0xef, 0x4e, 0x65, 0x5d, 0x6b, 0xec, 0x3e,
-	0x2c, 0xf6, 0xb7, 0x09, 0x61, 0x93, 0x84, 0xb6, 0x23, 0xcf, 0x0f, 0x8f, 0x15, 0x9f, 0xd2, 0x32,
-	0x13, 0x33, 0xbf, 0x80, 0x0d, 0x67, 0x14, 0x25, 0xbc, 0xed, 0x27, 0xee, 0xc4, 0xe7, 0xce, 0x68,
-	0xdc, 0xf5, 0x14, 0x1c, 0x6b, 0x50, 0x1a, 0xf9, 0x12, 0x8a, 0x32, 0x2e, 0x8d, 0x7c, 0xb1, 0x0e,
-	0x22, 0xe9, 0x61, 0x19, 0x97, 0x82, 0xc8, 0x8c, 0xc0, 0x98, 0x93, 0x74, 0x82, 0x88, 0x2b, 0xd9,
-	0x2f, 0xa1, 0xca, 0x84, 0xa6, 0x14, 0xc9, 0x07, 0xc5, 0x08, 0x5a, 0xf8, 0x3f, 0xac, 0x24, 0x44,
-	0x50, 0xb3, 0x20, 0xe2, 0x5d, 0xef, 0xb5, 0xfc, 0x57, 0x15, 0x67, 0x4b, 0xf3, 0x57, 0x25, 0xd8,
-	0x12, 0x97, 0x47, 0x56, 0xa8, 0xbc, 0x92, 0xa6, 0xeb, 0x52, 0xc6, 0xbe, 0xcb, 0x21, 0xde, 0x05,
-	0x18, 0x93, 0xd7, 0x87, 0x22, 0xb9, 0x47, 0x61, 0xda, 0x0c, 0xe5, 0x28, 0xe8, 0x6b, 0x58, 0x52,
-	0x56, 0xa4, 0x5d, 0xc7, 0xc3, 0x8b, 0x1c, 0x9a, 0xc1, 0x80, 0x53, 0x21, 0xf4, 0x0c, 0x6e, 0xb3,
-	0x49, 0x1c, 0x47, 0x09, 0x67, 0x98, 0xba, 0xd4, 0x8f, 0xf9, 0x21, 0x4d, 0xfc, 0x23, 0xdf, 0x25,
-	0xe9, 0xe4, 0x94, 0xab, 0x20, 0x17, 0x71, 0x9a, 0x7f, 0xd4, 0xe0, 0x21, 0xa6, 0x01, 0x25, 0x8c,
-	0x9e, 0x05, 0x20, 0x45, 0x46, 0xe1, 0x70, 0x6a, 0xb1, 0xb6, 0x20, 0x30, 0x2e, 0xb5, 0xb8, 0x30,
-	0xce, 0x94, 0xae, 0x3c, 0xce, 0x9c, 0xc0, 0x87, 0x17, 0x98, 0xcb, 0xe2, 0x28, 0x64, 0xe9, 0x94,
-	0xfb, 0xf1, 0xdc, 0x94, 0xfb, 0xde, 0x5c, 0xd2, 0x29, 0x8c, 0xab, 0x37, 0xa0, 0x4a, 0x93, 0x24,
-	0x4a, 0xa4, 0x65, 0x75, 0xac, 0x16, 0xe6, 0xaf, 0x35, 0xb8, 0x3d, 0xe7, 0x58, 0x01, 0x92, 0xe2,
-	0xac, 0xa2, 0xcd, 0xcf, 0x2a, 0xef, 0xca, 0xe7, 0xdf, 0x69, 0x70, 0xe7, 0x8c, 0x19, 0xef, 0xd6,
-	0x55, 0xf4, 0x29, 0x94, 0xfc, 0x2c, 0x16, 0xff, 0xab, 0xcb, 0x55, 0xf2, 0x3d, 0xf3, 0xcf, 0x1a,
-	0xac, 0xf7, 0x89, 0x7b, 0x42, 0x79, 0x7e, 0xfe, 0xfb, 0x00, 0x56, 0xd5, 0x9c, 0xd3, 0x0d, 0x5b,
-	0xb3, 0x41, 0x59, 0xc7, 0x45, 0xa2, 0x30, 0x83, 0xd1, 0x6f, 0x42, 0x75, 0xff, 0x75, 0xac, 0x16,
-	0xe8, 0x7b, 0xb0, 0x1e, 0x10, 0xc6, 0x95, 0xd2, 0x4c, 0x5e, 0x54, 0x9c, 0x1a, 0x3e, 0xbb, 0x21,
-	0xcb, 0x15, 0xe1, 0x64, 0x9f, 0x86, 0x32, 0x59, 0x5d, 0xc7, 0xd9, 0x12, 0x3d, 0x80, 0x3a, 0x9b,
-	0x86, 0xae, 0x92, 0xaf, 0xe6, 0x6f, 0xc3, 0x29, 0xdd, 0xfc, 0x83, 0x06, 0x7a, 0x3f, 0xed, 0xb0,
-	0x9b, 0x59, 0x55, 0x99, 0xd9, 0x25, 0xac, 0x46, 0x99, 0x5d, 0x1f, 0x41, 0x35, 0xa1, 0x71, 0x30,
-	0x95, 0xd5, 0xfe, 0x1c, 0x80, 0x15, 0x07, 0xfa, 0x12, 0x36, 0xbc, 0xe8, 0x5b, 0xd1, 0x7a, 0x51,
-	0x32, 0x6e, 0xba, 0x27, 0x03, 0x7f, 0x4c, 0x7b, 0x24, 0x8c, 0x98, 0x44, 0xb7, 0xf2, 0x44, 0xfb,
-	0x01, 0x3e, 0x87, 0x01, 0x6d, 0x40, 0xe5, 0x28, 0x20, 0xc7, 0xf2, 0xe1, 0x63, 0xb5, 0x55, 0xd2,
-	0x35, 0x2c, 0xd7, 0x26, 0x83, 0xf7, 0xc5, 0x68, 0x6a, 0xcf, 0xca, 0xcb, 0xe9, 0x00, 0xff, 0x39,
-	0xd4, 0xdc, 0xac, 0x1b, 0xd7, 0x2e, 0xed, 0xc6, 0x67, 0xbc, 0x68, 0x0b, 0x1a, 0xee, 0x68, 0x12,
-	0x9e, 0xd8, 0xf9, 0xf9, 0x34, 0x4f, 0x32, 0xff, 0x59, 0x82, 0x1b, 0x12, 0x27, 0x91, 0x21, 0xaf,
-	0x1c, 0x6d, 0x26, 0xac, 0x1c, 0xf9, 0x09, 0xe3, 0x2d, 0xe2, 0xed, 0xfb, 0xe1, 0x49, 0x1a, 0x74,
-	0x05, 0x1a, 0x3a, 0x00, 0x3d, 0xb3, 0x2b, 0xfb, 0x53, 0x1a, 0x89, 0x1f, 0xcd, 0x0d, 0x55, 0x85,
-	0xba, 0x5d, 0xb0, 0x0a, 0x9f, 0x51, 0x81, 0x06, 0x80, 0x92, 0x33, 0xa8, 0xc9, 0xd4, 0xd8, 0xd8,
-	0xfd, 0xa0, 0xa0, 0xf8, 0x1c, 0x70, 0xf1, 0x02, 0xf9, 0xfc, 0xf3, 0x55, 0xb5, 0xf8, 0x7c, 0xf5,
-	0x15, 0xdc, 0x62, 0x67, 0x72, 0x52, 0x56, 0x01, 0x96, 0xb6, 0xb4, 0xed, 0x55, 0x7c, 0x3e, 0x83,
-	0xd9, 0x81, 0x9b, 0xaa, 0xef, 0x14, 0xc6, 0x28, 0x10, 0xdf, 0x1e, 0x6e, 0xb3, 0x09, 0xa8, 0xd3,
-	0xcb, 0x32, 0xca, 0x2c, 0xa6, 0xdf, 0x4a, 0xc5, 0x9f, 0x34, 0xb8, 0x73, 0x11, 0xd2, 0x22, 0x74,
-	0x66, 0x53, 0x7d, 0xe2, 0x4a, 0x95, 0xab, 0x38, 0x4f, 0x92, 0xc1, 0x95, 0xb8, 0xfd, 0xb4, 0xe5,
-	0x9e, 0x05, 0xd7, 0x29, 0x09, 0xe9, 0x50, 0x1e, 0x7b, 0x8f, 0xe5, 0xcd, 0x5e, 0xc1, 0xe2, 0x53,
-	0x74, 0xd4, 0x6e, 0xe2, 0xe6, 0x9a, 0xc4, 0xcb, 0x9e, 0x1e, 0x32, 0x76, 0xf3, 0x63, 0x58, 0xb5,
-	0xe3, 0xf6, 0x84, 0xf1, 0x28, 0x6d, 0xbb, 0x36, 0xa1, 0xe6, 0xca, 0x65, 0x5a, 0xab, 0xea, 0x78,
-	0xb6, 0x7e, 0xf4, 0x2f, 0x0d, 0x96, 0x16, 0xbd, 0x05, 0xce, 0x1e, 0xfd, 0xe4, 0x98, 0xa3, 0x9e,
-	0x05, 0xdb, 0xcf, 0xad, 0xf6, 0x9e, 0x73, 0xf0, 0x42, 0x2f, 0xa1, 0x75, 0x58, 0x55, 0xb4, 0x6e,
-	0xef, 0xb0, 0xb9, 0xdf, 0xed, 0xe8, 0x65, 0x31, 0x86, 0x28, 0x92, 0xf5, 0xd3, 0xae, 0x33, 0x70,
-	0xf4, 0x8a, 0x18, 0x43, 0x14, 0xa5, 0x29, 0xb5, 0x0e, 0x07, 0xf6, 0x9e, 0xd5, 0xd3, 0xab, 0xe8,
-	0x3a, 0x34, 0x32, 0x55, 0x43, 0x7b, 0x4f, 0x5f, 0xca, 0x3f, 0x3c, 0x3a, 0x07, 0xfd, 0xbe, 0x8d,
-	0x07, 0x56, 0x47, 0x5f, 0x16, 0x7c, 0xb6, 0xdd, 0x1a, 0x62, 0xcb, 0x19, 0x34, 0xf1, 0x40, 0xaf,
-	0x89, 0xbf, 0xa6, 0x04, 0x0b, 0x1f, 0x5a, 0x9d, 0x4f, 0xf4, 0xfa, 0x3c, 0x69, 0x57, 0x87, 0x79,
-	0xd2, 0xa7, 0x7a, 0x43, 0x68, 0xea, 0xf6, 0x86, 0x7d, 0x6c, 0x3f, 0xc3, 0xc2, 0xbd, 0x95, 0x47,
-	0xbf, 0x28, 0x36, 0x65, 0x4f, 0xbd, 0xd9, 0x2d, 0x79, 0x00, 0xf7, 0x3a, 0xf6, 0xb0, 0x67, 0x0f,
-	0x86, 0x07, 0x6a, 0x02, 0xb3, 0xba, 0xfd, 0xc1, 0xf0, 0xd0, 0xc2, 0xdd, 0xa7, 0xdd, 0x76, 0x73,
-	0xd0, 0xb5, 0x7b, 0xfa, 0x35, 0x74, 0x07, 0x8c, 0x73, 0x77, 0xb5, 0xd6, 0x8f, 0xe0, 0x61, 0x94,
-	0x1c, 0xef, 0x90, 0x98, 0xb8, 0x23, 0x5a, 0x38, 0x35, 0xf9, 0x2a, 0xed, 0x46, 0x81, 0xfa, 0x68,
-	0xa1, 0x7c, 0xbd, 0x93, 0x67, 0xc5, 0x7e, 0xaf, 0x69, 0xff, 0x09, 0x00, 0x00, 0xff, 0xff, 0xfa,
-	0x1e, 0x11, 0x57, 0xf3, 0x16, 0x00, 0x00,
+	// 2101 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x59, 0xcd, 0x72, 0xdb, 0xc8,
+	0xf1, 0x37, 0xf8, 0x21, 0x91, 0x4d, 0x49, 0x86, 0x67, 0x6d, 0x2d, 0x2c, 0xfb, 0x6f, 0xd3, 0xf0,
+	0xda, 0x7f, 0xad, 0x37, 0xa5, 0x64, 0xb5, 0x1f, 0xb5, 0xeb, 0xec, 0x26, 0xc5, 0x0f, 0xd8, 0x66,
+	0x24, 0x13, 0xac, 0x01, 0x25, 0x27, 0x9b, 0x03, 0x6b, 0x0c, 0x8c, 0x44, 0x94, 0x40, 0x00, 0x8b,
+	0x19, 0x66, 0x4d, 0x9f, 0x72, 0xc8, 0x21, 0x4f, 0x90, 0x63, 0x2a, 0xa7, 0x54, 0x2e, 0x79, 0x83,
+	0x54, 0x2a, 0x2f, 0x90, 0x77, 0xc8, 0x21, 0x97, 0x54, 0xe5, 0x98, 0xca, 0x39, 0x35, 0x03, 0x80,
+	0x04, 0xf8, 0x21, 0xc5, 0xb6, 0x0e, 0xb9, 0x71, 0x7a, 0x7e, 0xdd, 0xe8, 0xf9, 0x4d, 0xcf, 0x74,
+	0xf7, 0x10, 0x90, 0x43, 0x38, 0xe1, 0x11, 0xf1, 0xd9, 0x09, 0x8d, 0xf6, 0xc2, 0x28, 0xe0, 0x01,
+	0xaa, 0x0d, 0x89, 0x13, 0x04, 0xe1, 0xde, 0xd0, 0x39, 0x61, 0x3b, 0x5b, 0x16, 0xb5, 0xc7, 0x91,
+	0xcb, 0x27, 0xf1, 0xe4, 0x0e, 0x08, 0x69, 0xfc, 0x5b, 0xff, 0x6b, 0x01, 0xee, 0xb5, 0x09, 0x27,
+	0xfd, 0x44, 0xdf, 0xf0, 0xed, 0x68, 0x12, 0xf2, 0x20, 0x7a, 0x4e, 0x19, 0x23, 0xa7, 0xb4, 0x27,
+	0xcd, 0xbd, 0x84, 0x35, 0xc6, 0x09, 0x1f, 0x33, 0x4d, 0xa9, 0x17, 0x76, 0xb7, 0xf6, 0x7f, 0xb2,
+	0x97, 0xb1, 0xbf, 0x77, 0xa1, 0xfe, 0x72, 0x84, 0x25, 0x2d, 0xe2, 0xc4, 0x32, 0xd2, 0x60, 0x3d,
+	0x24, 0x13, 0x2f, 0x20, 0x8e, 0x56, 0xa8, 0x2b, 0xbb, 0x1b, 0x38, 0x1d, 0x8a, 0x99, 0x51, 0x6c,
+	0x4d, 0x2b, 0xd6, 0x95, 0xdd, 0x2a, 0x4e, 0x87, 0xa8, 0x09, 0x1b, 0xb6, 0x1b, 0x0e, 0x69, 0x64,
+	0x86, 0xdc, 0x0d, 0x7c, 0xad, 0x54, 0x2f, 0xee, 0xd6, 0xf6, 0xef, 0xe4, 0xbc, 0x6b, 0x65, 0x00,
+	0xd2, 0x1b, 0x9c, 0xd3, 0xd1, 0x0f, 0xe1, 0xd6, 0x39, 0xee, 0xa1, 0x1a, 0xac, 0x5b, 0x47, 0xad,
+	0x96, 0x61, 0x59, 0xea, 0x15, 0x74, 0x03, 0xae, 0x19, 0x18, 0x9b, 0x78, 0x70, 0xd4, 0x3d, 0xe8,
+	0x9a, 0x2f, 0xba, 0x83, 0x03, 0xe3, 0x67, 0xaa, 0x82, 0xaa, 0x50, 0x96, 0x62, 0xb5, 0xa0, 0xff,
+	0x45, 0x81, 0xab, 0x4d, 0xc2, 0xe8, 0x33, 0x4a, 0x1c, 0x1a, 0xc5, 0xec, 0x7d, 0x06, 0xe5, 0x97,
+	0x5e, 0x60, 0x9f, 0x49, 0xf2, 0x6a, 0xfb, 0x77, 0x73, 0xee, 0x19, 0xaf, 0x38, 0xf5, 0x1d, 0xea,
+	0x34, 0x05, 0x22, 0xf6, 0x2f, 0x46, 0xa3, 0xef, 0x43, 0x99, 0x07, 0x67, 0xd4, 0x97, 0x74, 0xd4,
+	0xf6, 0x6f, 0xa6, 0x6a, 0x76, 0x30, 0x1a, 0x05, 0xfe, 0x5e, 0x5f, 0xcc, 0x25, 0x0a, 0x12, 0x87,
+	0x0c, 0xa8, 0xf2, 0x88, 0xd8, 0xb4, 0xe3, 0x9f, 0x04, 0x92, 0xa9, 0xda, 0xfe, 0xff, 0xaf, 0xdc,
+	0xa8, 0x7e, 0x8a, 0x8c, 0x4d, 0xcc, 0x34, 0x75, 0x0c, 0x3b, 0xab, 0x81, 0x62, 0x33, 0x62, 0xa8,
+	0x23, 0x97, 0x53, 0xc2, 0xe9, 0x10, 0xed, 0x40, 0x25, 0x24, 0x11, 0xf5, 0x79, 0x47, 0xec, 0xa0,
+	0x98, 0x9a, 0x8e, 0xf5, 0xd7, 0xb0, 0xd3, 0xf2, 0x5c, 0xea, 0x73, 0x33, 0xa4, 0x11, 0x11, 0xbc,
+	0x67, 0x09, 0xfa, 0x0a, 0xe0, 0xe5, 0x94, 0xb3, 0x84, 0xa5, 0xdb, 0x39, 0xcf, 0xe7, 0x28, 0xc5,
+	0x19, 0x3c, 0xba, 0x03, 0x60, 0x4b, 0xdb, 0x5d, 0x32, 0xa2, 0xf2, 0xcb, 0x55, 0x9c, 0x91, 0xe8,
+	0x7d, 0xb8, 0xde, 0x22, 0xf6, 0xd0, 0xf5, 0x4f, 0x2d, 0x1e, 0x11, 0x4e, 0x4f, 0x27, 0xf1, 0x57,
+	0xef, 0x00, 0x38, 0x51, 0x10, 0x36, 0xe9, 0xd0, 0xf5, 0xc5, 0x62, 0x94, 0xdd, 0x0a, 0xce, 0x48,
+	0xd0, 0x6d, 0xa8, 0x46, 0x94, 0x38, 0x64, 0x48, 0x93, 0x90, 0x2c, 0xe2, 0x99, 0x40, 0xff, 0xb7,
+	0x02, 0xaa, 0x19, 0x62, 0x4a, 0x32, 0x3b, 0x87, 0x7e, 0x0c, 0x6b, 0xc3, 0xec, 0x22, 0xf2, 0xf4,
+	0xaf, 0x66, 0x00, 0x27, 0x6a, 0x68, 0x1b, 0xd6, 0x82, 0x93, 0x13, 0x46, 0x79, 0xc2, 0x60, 0x32,
+	0x42, 0x2a, 0x14, 0x3d, 0xea, 0x6b, 0x45, 0x29, 0x14, 0x3f, 0xd1, 0x23, 0xd8, 0x64, 0xd4, 0x77,
+	0x5a, 0x43, 0x6a, 0x9f, 0xb1, 0xf1, 0x88, 0x69, 0x25, 0xb1, 0x80, 0xc7, 0x25, 0x1e, 0x8d, 0x29,
+	0xce, 0x4f, 0xa1, 0x03, 0xb8, 0x6a, 0xe7, 0x19, 0xd0, 0xca, 0x32, 0x3c, 0xee, 0xe5, 0xfd, 0x5b,
+	0xc2, 0x12, 0x9e, 0xd7, 0xd4, 0x03, 0xd8, 0x4c, 0x2d, 0xc7, 0x8b, 0xde, 0x87, 0x12, 0x9f, 0x84,
+	0x34, 0xb9, 0x1a, 0xe6, 0x0e, 0x5f, 0x82, 0xec, 0x4f, 0xc2, 0xf8, 0x2a, 0xc0, 0x12, 0x8b, 0x1e,
+	0x81, 0xfa, 0x72, 0xc2, 0x29, 0xeb, 0xd1, 0x28, 0x85, 0xc8, 0x15, 0x6f, 0xe2, 0x05, 0xb9, 0xfe,
+	0x8f, 0x0a, 0x5c, 0x33, 0xc3, 0x17, 0x91, 0xcb, 0xe9, 0x65, 0x52, 0xfd, 0x05, 0xac, 0x73, 0x12,
+	0x9d, 0x52, 0xce, 0xb4, 0xc2, 0x92, 0x6b, 0x43, 0x1c, 0x01, 0x3f, 0x70, 0x32, 0x47, 0x24, 0x85,
+	0xa3, 0xcf, 0x61, 0x8d, 0x05, 0xe3, 0xc8, 0xa6, 0xc9, 0x21, 0xbb, 0x48, 0x31, 0x41, 0xa3, 0x03,
+	0x28, 0x33, 0x2e, 0x6e, 0xb1, 0x92, 0x64, 0xea, 0xb3, 0x9c, 0xda, 0xc2, 0x0a, 0xf7, 0xe4, 0xcf,
+	0x56, 0xe0, 0x33, 0x1e, 0x8d, 0x6d, 0xb1, 0x0a, 0x4b, 0x28, 0xe3, 0xd8, 0x06, 0xd2, 0x61, 0x23,
+	0x74, 0x43, 0xea, 0xb9, 0x3e, 0xb5, 0xdc, 0xd7, 0x54, 0x2b, 0x4b, 0xf6, 0x72, 0x32, 0x81, 0x19,
+	0xb9, 0x7e, 0x53, 0x10, 0x8a, 0xed, 0x5f, 0x38, 0xda, 0x9a, 0x0c, 0x9f, 0x9c, 0x4c, 0x62, 0xc8,
+	0xab, 0x19, 0x66, 0x3d, 0xc1, 0x64, 0x64, 0xe8, 0x53, 0xb8, 0xe1, 0x11, 0x4e, 0x19, 0x7f, 0x4a,
+	0xfd, 0x84, 0x51, 0x8b, 0x93, 0x51, 0xa8, 0x55, 0x24, 0x78, 0xf9, 0x24, 0x7a, 0x06, 0xd7, 0x22,
+	0xfa, 0xed, 0x98, 0x32, 0x4e, 0xa7, 0xb1, 0xa8, 0x55, 0xe5, 0x66, 0xed, 0x2c, 0x0d, 0x92, 0x98,
+	0xad, 0x45, 0xa5, 0x65, 0xf1, 0x0b, 0x6f, 0x1b, 0xbf, 0xc8, 0x80, 0x1a, 0xe3, 0x41, 0x44, 0x4e,
+	0xa9, 0x08, 0x4a, 0xad, 0x56, 0x57, 0x76, 0xb7, 0xf6, 0xff, 0x2f, 0x67, 0xc8, 0x9a, 0xcd, 0x4b,
+	0x23, 0x8f, 0x4b, 0xed, 0x8e, 0x75, 0x80, 0xb3, 0x7a, 0xe8, 0x39, 0xa0, 0x38, 0x1e, 0x32, 0x60,
+	0xa6, 0x6d, 0xd4, 0x8b, 0x17, 0x5a, 0xc3, 0x4b, 0x14, 0xd1, 0xc7, 0xa0, 0x12, 0xcf, 0x0b, 0xbe,
+	0x3b, 0x24, 0xaf, 0x27, 0x3d, 0x1a, 0x31, 0x97, 0x71, 0x6d, 0x53, 0x9e, 0xe8, 0xf2, 0x09, 0xf1,
+	0x18, 0xc5, 0x0b, 0xd3, 0xe8, 0x2e, 0xac, 0x87, 0xae, 0xef, 0xbb, 0xfe, 0xa9, 0xb6, 0x95, 0x45,
+	0xa6, 0x52, 0xf4, 0x10, 0xb6, 0xe2, 0x2f, 0xf5, 0x62, 0x01, 0xd3, 0xae, 0xd6, 0x8b, 0xbb, 0x15,
+	0x3c, 0x27, 0xd5, 0x7f, 0x5d, 0x80, 0xed, 0xe5, 0xc1, 0x86, 0x6e, 0xc2, 0x8d, 0x5e, 0xa7, 0x67,
+	0x1c, 0x76, 0xba, 0xc6, 0xc0, 0x32, 0xfa, 0x47, 0xbd, 0x41, 0xa3, 0xd7, 0x33, 0xba, 0x6d, 0xf5,
+	0x0a, 0xd2, 0xe1, 0xce, 0xd2, 0xa9, 0x01, 0x36, 0x5a, 0xe6, 0xb1, 0x81, 0x45, 0x62, 0x44, 0xb0,
+	0xd5, 0x6e, 0xf4, 0x1b, 0x03, 0xab, 0x8f, 0x8d, 0xc6, 0xf3, 0x4e, 0xf7, 0xa9, 0x5a, 0x40, 0x0f,
+	0xe0, 0xde, 0x9c, 0xde, 0x74, 0x76, 0xa6, 0x5a, 0x14, 0xaa, 0x53, 0x58, 0xeb, 0xd0, 0xb4, 0x0c,
+	0xb5, 0x84, 0x6e, 0xc1, 0xfb, 0x79, 0xd9, 0x4c, 0xa1, 0xbc, 0xc4, 0xd5, 0x16, 0x36, 0x1a, 0x7d,
+	0x43, 0x5d, 0x43, 0x2a, 0x6c, 0xf4, 0x71, 0xa3, 0x6b, 0x3d, 0x31, 0xf0, 0x00, 0x37, 0x5f, 0xa8,
+	0xeb, 0x68, 0x1b, 0xd0, 0x54, 0xf2, 0xa4, 0xd3, 0x6d, 0x1c, 0x76, 0xbe, 0x31, 0xda, 0x6a, 0x45,
+	0xff, 0x9b, 0x02, 0xd7, 0xcd, 0x30, 0x4d, 0x7d, 0xff, 0x1b, 0xd7, 0xcd, 0xf2, 0x48, 0x2b, 0xbe,
+	0x65, 0xa4, 0xe9, 0x7f, 0x57, 0xe0, 0x3d, 0x91, 0xb8, 0x42, 0x8f, 0xd8, 0xd9, 0x0b, 0xf5, 0xd3,
+	0xb9, 0x15, 0x9e, 0x9f, 0x80, 0xd3, 0x65, 0x69, 0xb0, 0xee, 0x50, 0xef, 0x99, 0xeb, 0xf3, 0x24,
+	0xf3, 0xa6, 0xc3, 0xdc, 0x2d, 0x59, 0x78, 0x83, 0x5b, 0x72, 0xee, 0x7c, 0x96, 0xde, 0xee, 0x7c,
+	0xea, 0xcf, 0x44, 0x7a, 0x6e, 0x05, 0xe1, 0xe4, 0x5d, 0x97, 0xa8, 0xff, 0x41, 0xc6, 0x44, 0x7c,
+	0x40, 0x72, 0x89, 0xef, 0xed, 0x18, 0xfb, 0x06, 0xae, 0xbf, 0xcc, 0xda, 0x8a, 0xcb, 0x50, 0x96,
+	0x54, 0x79, 0x0f, 0xf3, 0x36, 0x96, 0x00, 0x63, 0x6b, 0x4b, 0x6d, 0xe8, 0x7f, 0x2e, 0xc2, 0xcd,
+	0xc4, 0xd5, 0xa7, 0x51, 0x30, 0x0e, 0x2f, 0xc3, 0xdf, 0xaf, 0xa1, 0xea, 0x24, 0x9b, 0xc5, 0xb4,
+	0xc2, 0x92, 0x0a, 0x36, 0xbb, 0x95, 0x89, 0x77, 0x33, 0x0d, 0xf4, 0x43, 0xa8, 0x49, 0x57, 0x65,
+	0xb9, 0x1a, 0x87, 0xed, 0xb9, 0xb5, 0x6c, 0x16, 0x8d, 0x1a, 0x50, 0xa1, 0x76, 0x2f, 0xf0, 0x5c,
+	0x7b, 0x22, 0x93, 0x66, 0x6d, 0xff, 0x41, 0xbe, 0x78, 0x8e, 0x08, 0x1b, 0x47, 0xb4, 0x15, 0x38,
+	0xae, 0x7f, 0x1a, 0xe3, 0x62, 0x2b, 0x53, 0x35, 0x91, 0xdf, 0xa4, 0xc5, 0x8e, 0xef, 0xb8, 0x36,
+	0x65, 0x5a, 0xb9, 0x5e, 0x14, 0x79, 0x32, 0x2b, 0x43, 0xdf, 0xcb, 0x64, 0xaa, 0xee, 0x78, 0x24,
+	0x13, 0x5f, 0x92, 0x2c, 0x17, 0x27, 0x56, 0x6e, 0xe0, 0xfa, 0x25, 0x6c, 0xe0, 0x17, 0xb0, 0x6d,
+	0x0d, 0x83, 0x88, 0xb7, 0xdc, 0xc8, 0x1e, 0xbb, 0xdc, 0x1a, 0x8e, 0x3a, 0x4e, 0xbc, 0x79, 0x5b,
+	0x50, 0x18, 0xba, 0x72, 0xe3, 0x8a, 0xb8, 0x30, 0x74, 0xc5, 0xd8, 0x0b, 0xe4, 0x7e, 0x14, 0x71,
+	0xc1, 0x0b, 0xf4, 0x00, 0xb4, 0x39, 0x4d, 0xcb, 0x0b, 0x78, 0xac, 0xfb, 0x25, 0x94, 0x99, 0xb0,
+	0x94, 0xec, 0xfb, 0xfd, 0xfc, 0x61, 0x5a, 0xfa, 0x3d, 0x1c, 0x6b, 0x88, 0xf3, 0xcd, 0xbc, 0x80,
+	0x77, 0x9c, 0x57, 0xf2, 0x5b, 0x65, 0x9c, 0x0e, 0xf5, 0x5f, 0x16, 0xa0, 0x2e, 0xee, 0x11, 0x49,
+	0x4f, 0xd6, 0x48, 0xc3, 0xb6, 0x29, 0x63, 0xef, 0x12, 0x72, 0x77, 0x00, 0x46, 0xe4, 0xd5, 0xb1,
+	0xc8, 0x73, 0x81, 0x9f, 0xd4, 0x85, 0x19, 0x09, 0xfa, 0x1a, 0xd6, 0x62, 0x2f, 0x92, 0x02, 0xec,
+	0xc1, 0x79, 0x0b, 0x9a, 0xd2, 0x80, 0x13, 0x25, 0xf4, 0x14, 0x6e, 0xb1, 0x71, 0x18, 0x06, 0x11,
+	0x67, 0x98, 0xda, 0xd4, 0x0d, 0xf9, 0x31, 0x8d, 0xdc, 0x13, 0xd7, 0x26, 0x49, 0x13, 0x99, 0x49,
+	0xa6, 0xe7, 0x21, 0xf5, 0x3f, 0x2a, 0xf0, 0x00, 0x53, 0x8f, 0x12, 0x46, 0x17, 0x09, 0x48, 0x98,
+	0x89, 0x79, 0x98, 0x79, 0xac, 0x2c, 0x09, 0xe3, 0x0b, 0x3d, 0xce, 0x75, 0x76, 0x85, 0xb7, 0xee,
+	0xec, 0xce, 0xe0, 0xe1, 0x39, 0xee, 0xb2, 0x30, 0xf0, 0x59, 0xd2, 0xf0, 0x7f, 0x34, 0xd7, 0xf0,
+	0xbf, 0x37, 0x77, 0xff, 0xe6, 0x3a, 0xf7, 0xeb, 0x50, 0xa6, 0x51, 0x14, 0x44, 0xd2, 0xb3, 0x2a,
+	0x8e, 0x07, 0xfa, 0xaf, 0x14, 0xb8, 0x35, 0xb7, 0xb0, 0x1c, 0x25, 0xf9, 0xb6, 0x4d, 0x99, 0x6f,
+	0xdb, 0x2e, 0x6b, 0xcd, 0xbf, 0x51, 0xe0, 0xf6, 0x82, 0x1b, 0x97, 0xbb, 0x54, 0xf4, 0x09, 0x14,
+	0xdc, 0x34, 0x16, 0xff, 0xab, 0xc3, 0x55, 0x70, 0x1d, 0xfd, 0x4f, 0x0a, 0x5c, 0xeb, 0x11, 0xfb,
+	0x8c, 0xf2, 0x6c, 0x2b, 0xfc, 0x01, 0x6c, 0xc6, 0x2d, 0x5f, 0xc7, 0x6f, 0x4e, 0xdf, 0x0c, 0x54,
+	0x9c, 0x17, 0x0a, 0x37, 0x18, 0xfd, 0xd6, 0x8f, 0xcf, 0xbf, 0x8a, 0xe3, 0x81, 0xb8, 0xc6, 0x3c,
+	0xc2, 0x78, 0x6c, 0x34, 0xd5, 0x17, 0xc9, 0xb7, 0x82, 0x17, 0x27, 0x64, 0xe6, 0x26, 0x9c, 0x1c,
+	0x52, 0x5f, 0x5e, 0xad, 0x57, 0x71, 0x3a, 0x44, 0xf7, 0xa1, 0xca, 0x26, 0xbe, 0x1d, 0xeb, 0x97,
+	0xb3, 0xa7, 0x61, 0x26, 0xd7, 0x7f, 0xaf, 0x80, 0xda, 0x4b, 0x9a, 0x8d, 0x46, 0x9a, 0x60, 0xa7,
+	0x7e, 0x09, 0xaf, 0x51, 0xea, 0xd7, 0x87, 0x50, 0x8e, 0x68, 0xe8, 0x4d, 0x64, 0xe1, 0xb3, 0x82,
+	0xe0, 0x18, 0x81, 0xbe, 0x84, 0x6d, 0x27, 0xf8, 0x4e, 0x54, 0xa1, 0x94, 0x8c, 0x1a, 0xf6, 0x59,
+	0xdf, 0x1d, 0xd1, 0x2e, 0xf1, 0x03, 0x26, 0xd9, 0x2d, 0x3d, 0x56, 0x7e, 0x80, 0x57, 0x00, 0xd0,
+	0x36, 0x94, 0x4e, 0x3c, 0x72, 0x2a, 0xdf, 0x80, 0x36, 0x9b, 0x05, 0x55, 0xc1, 0x72, 0xac, 0x33,
+	0x78, 0x5f, 0x74, 0xe9, 0xe6, 0x34, 0x19, 0xce, 0xde, 0x32, 0x3e, 0x87, 0x8a, 0x9d, 0x08, 0x35,
+	0xe5, 0xc2, 0xc6, 0x64, 0x8a, 0x45, 0x75, 0xa8, 0xd9, 0xc3, 0xb1, 0x7f, 0x66, 0x66, 0x5b, 0xf5,
+	0xac, 0x48, 0xff, 0x67, 0x01, 0xae, 0x4b, 0x9e, 0xcc, 0xf0, 0x1d, 0xa2, 0x4d, 0x87, 0x8d, 0x13,
+	0x37, 0x62, 0xbc, 0x49, 0x9c, 0x43, 0xd7, 0x3f, 0x4b, 0x82, 0x2e, 0x27, 0x43, 0x47, 0xa0, 0xa6,
+	0x7e, 0xa5, 0x5f, 0x4a, 0x22, 0xf1, 0xc3, 0xb9, 0xfe, 0x32, 0x97, 0x8b, 0x72, 0x5e, 0xe1, 0x05,
+	0x13, 0xa8, 0x0f, 0x28, 0x5a, 0x60, 0x4d, 0x5e, 0x8d, 0xb5, 0xfd, 0x0f, 0x72, 0x86, 0x57, 0x90,
+	0x8b, 0x97, 0xe8, 0x67, 0x5f, 0xf2, 0xca, 0xf9, 0x97, 0xbc, 0xaf, 0xe0, 0x26, 0x5b, 0xb8, 0x93,
+	0xd2, 0x0c, 0xb0, 0x56, 0x57, 0x76, 0x37, 0xf1, 0x6a, 0x80, 0xde, 0x86, 0x1b, 0x71, 0x09, 0x2e,
+	0x9c, 0x89, 0x49, 0x7c, 0x73, 0xba, 0xf5, 0x06, 0xa0, 0x76, 0x37, 0xbd, 0x51, 0xa6, 0x31, 0xfd,
+	0x46, 0x26, 0x7e, 0x5b, 0x80, 0xdb, 0xe7, 0x31, 0x2d, 0x42, 0x67, 0xfa, 0xc0, 0x11, 0xd9, 0xd2,
+	0xe4, 0x26, 0xce, 0x8a, 0x64, 0x70, 0x45, 0x76, 0x2f, 0xe9, 0x3e, 0xa6, 0xc1, 0x35, 0x13, 0x89,
+	0x3b, 0x22, 0x57, 0x3c, 0xc8, 0x33, 0xbe, 0x81, 0xf3, 0x42, 0xd1, 0x70, 0xd8, 0x91, 0x9d, 0xa9,
+	0xa1, 0x2f, 0x7a, 0x99, 0x49, 0xe1, 0x2b, 0x0b, 0x9c, 0xf2, 0x25, 0x14, 0x38, 0x1f, 0xc1, 0xa6,
+	0x19, 0xb6, 0xc6, 0x8c, 0x07, 0x49, 0x51, 0xba, 0x03, 0x15, 0x5b, 0x0e, 0x93, 0xdc, 0x58, 0xc5,
+	0xd3, 0xf1, 0xa3, 0x7f, 0x29, 0xb0, 0xb6, 0xec, 0x19, 0x76, 0xfa, 0xde, 0x2a, 0x3b, 0x4c, 0xf9,
+	0x73, 0xd0, 0x7a, 0x66, 0xb4, 0x0e, 0xac, 0xa3, 0xe7, 0x6a, 0x01, 0x5d, 0x83, 0xcd, 0x58, 0xd6,
+	0xe9, 0x1e, 0x37, 0x0e, 0x3b, 0x6d, 0xb5, 0x28, 0x3a, 0xc0, 0x58, 0x64, 0xfc, 0xb4, 0x63, 0xf5,
+	0x2d, 0xb5, 0x24, 0x3a, 0xc0, 0x58, 0xd2, 0x90, 0x56, 0x07, 0x7d, 0xf3, 0xc0, 0xe8, 0xaa, 0x65,
+	0x74, 0x15, 0x6a, 0xa9, 0xa9, 0x81, 0x79, 0xa0, 0xae, 0x65, 0xdf, 0x7c, 0xad, 0xa3, 0x5e, 0xcf,
+	0xc4, 0x7d, 0xa3, 0xad, 0xae, 0x0b, 0x9c, 0x69, 0x36, 0x07, 0xd8, 0xb0, 0xfa, 0x0d, 0xdc, 0x57,
+	0x2b, 0xe2, 0xab, 0x89, 0xc0, 0xc0, 0xc7, 0x46, 0xfb, 0x63, 0xb5, 0x3a, 0x2f, 0xda, 0x57, 0x61,
+	0x5e, 0xf4, 0x89, 0x5a, 0x13, 0x96, 0x3a, 0xdd, 0x41, 0x0f, 0x9b, 0x4f, 0xb1, 0x58, 0xde, 0xc6,
+	0xa3, 0x9f, 0xe7, 0x8b, 0xc0, 0x27, 0xce, 0xf4, 0x54, 0xde, 0x87, 0xbb, 0x6d, 0x73, 0xd0, 0x35,
+	0xfb, 0x83, 0xa3, 0xb8, 0xf9, 0x35, 0x3a, 0xbd, 0xfe, 0xe0, 0xd8, 0xc0, 0x9d, 0x27, 0x9d, 0x56,
+	0xa3, 0xdf, 0x31, 0xbb, 0xea, 0x15, 0x74, 0x1b, 0xb4, 0x95, 0xb3, 0x4a, 0xf3, 0x47, 0xf0, 0x20,
+	0x88, 0x4e, 0xf7, 0x48, 0x48, 0xec, 0x21, 0xcd, 0x6d, 0xa5, 0xfc, 0x43, 0xc0, 0x0e, 0xbc, 0xf8,
+	0x47, 0x13, 0x65, 0xf3, 0xab, 0xdc, 0x2b, 0xf6, 0x3b, 0x45, 0xf9, 0xcf, 0x00, 0xb6, 0x8b, 0x31,
+	0xa7, 0x6e, 0x18, 0x00, 0x00,
 }
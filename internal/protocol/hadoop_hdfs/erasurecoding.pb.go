@@ -13,9 +13,9 @@ var _ = fmt.Errorf
 var _ = math.Inf
 
 type SetErasureCodingPolicyRequestProto struct {
-	Src              *string                   `protobuf:"bytes,1,req,name=src" json:"src,omitempty"`
-	EcPolicy         *ErasureCodingPolicyProto `protobuf:"bytes,2,opt,name=ecPolicy" json:"ecPolicy,omitempty"`
-	XXX_unrecognized []byte                    `json:"-"`
+	Src              *string `protobuf:"bytes,1,req,name=src" json:"src,omitempty"`
+	EcPolicyName     *string `protobuf:"bytes,2,opt,name=ecPolicyName" json:"ecPolicyName,omitempty"`
+	XXX_unrecognized []byte  `json:"-"`
 }
 
 func (m *SetErasureCodingPolicyRequestProto) Reset()         { *m = SetErasureCodingPolicyRequestProto{} }
@@ -32,11 +32,11 @@ func (m *SetErasureCodingPolicyRequestProto) GetSrc() string {
 	return ""
 }
 
-func (m *SetErasureCodingPolicyRequestProto) GetEcPolicy() *ErasureCodingPolicyProto {
-	if m != nil {
-		return m.EcPolicy
+func (m *SetErasureCodingPolicyRequestProto) GetEcPolicyName() string {
+	if m != nil && m.EcPolicyName != nil {
+		return *m.EcPolicyName
 	}
-	return nil
+	return ""
 }
 
 type SetErasureCodingPolicyResponseProto struct {
@@ -50,6 +50,36 @@ func (*SetErasureCodingPolicyResponseProto) Descriptor() ([]byte, []int) {
 	return fileDescriptor3, []int{1}
 }
 
+type UnsetErasureCodingPolicyRequestProto struct {
+	Src              *string `protobuf:"bytes,1,req,name=src" json:"src,omitempty"`
+	XXX_unrecognized []byte  `json:"-"`
+}
+
+func (m *UnsetErasureCodingPolicyRequestProto) Reset()         { *m = UnsetErasureCodingPolicyRequestProto{} }
+func (m *UnsetErasureCodingPolicyRequestProto) String() string { return proto.CompactTextString(m) }
+func (*UnsetErasureCodingPolicyRequestProto) ProtoMessage()    {}
+func (*UnsetErasureCodingPolicyRequestProto) Descriptor() ([]byte, []int) {
+	return fileDescriptor3, []int{2}
+}
+
+func (m *UnsetErasureCodingPolicyRequestProto) GetSrc() string {
+	if m != nil && m.Src != nil {
+		return *m.Src
+	}
+	return ""
+}
+
+type UnsetErasureCodingPolicyResponseProto struct {
+	XXX_unrecognized []byte `json:"-"`
+}
+
+func (m *UnsetErasureCodingPolicyResponseProto) Reset()         { *m = UnsetErasureCodingPolicyResponseProto{} }
+func (m *UnsetErasureCodingPolicyResponseProto) String() string { return proto.CompactTextString(m) }
+func (*UnsetErasureCodingPolicyResponseProto) ProtoMessage()    {}
+func (*UnsetErasureCodingPolicyResponseProto) Descriptor() ([]byte, []int) {
+	return fileDescriptor3, []int{3}
+}
+
 type GetErasureCodingPoliciesRequestProto struct {
 	XXX_unrecognized []byte `json:"-"`
 }
@@ -58,22 +88,22 @@ func (m *GetErasureCodingPoliciesRequestProto) Reset()         { *m = GetErasure
 func (m *GetErasureCodingPoliciesRequestProto) String() string { return proto.CompactTextString(m) }
 func (*GetErasureCodingPoliciesRequestProto) ProtoMessage()    {}
 func (*GetErasureCodingPoliciesRequestProto) Descriptor() ([]byte, []int) {
-	return fileDescriptor3, []int{2}
+	return fileDescriptor3, []int{4}
 }
 
 type GetErasureCodingPoliciesResponseProto struct {
-	EcPolicies       []*ErasureCodingPolicyProto `protobuf:"bytes,1,rep,name=ecPolicies" json:"ecPolicies,omitempty"`
-	XXX_unrecognized []byte                      `json:"-"`
+	EcPolicies       []*ErasureCodingPolicyInfoProto `protobuf:"bytes,1,rep,name=ecPolicies" json:"ecPolicies,omitempty"`
+	XXX_unrecognized []byte                          `json:"-"`
 }
 
 func (m *GetErasureCodingPoliciesResponseProto) Reset()         { *m = GetErasureCodingPoliciesResponseProto{} }
 func (m *GetErasureCodingPoliciesResponseProto) String() string { return proto.CompactTextString(m) }
 func (*GetErasureCodingPoliciesResponseProto) ProtoMessage()    {}
 func (*GetErasureCodingPoliciesResponseProto) Descriptor() ([]byte, []int) {
-	return fileDescriptor3, []int{3}
+	return fileDescriptor3, []int{5}
 }
 
-func (m *GetErasureCodingPoliciesResponseProto) GetEcPolicies() []*ErasureCodingPolicyProto {
+func (m *GetErasureCodingPoliciesResponseProto) GetEcPolicies() []*ErasureCodingPolicyInfoProto {
 	if m != nil {
 		return m.EcPolicies
 	}
@@ -89,7 +119,7 @@ func (m *GetErasureCodingPolicyRequestProto) Reset()         { *m = GetErasureCo
 func (m *GetErasureCodingPolicyRequestProto) String() string { return proto.CompactTextString(m) }
 func (*GetErasureCodingPolicyRequestProto) ProtoMessage()    {}
 func (*GetErasureCodingPolicyRequestProto) Descriptor() ([]byte, []int) {
-	return fileDescriptor3, []int{4}
+	return fileDescriptor3, []int{6}
 }
 
 func (m *GetErasureCodingPolicyRequestProto) GetSrc() string {
@@ -108,7 +138,7 @@ func (m *GetErasureCodingPolicyResponseProto) Reset()         { *m = GetErasureC
 func (m *GetErasureCodingPolicyResponseProto) String() string { return proto.CompactTextString(m) }
 func (*GetErasureCodingPolicyResponseProto) ProtoMessage()    {}
 func (*GetErasureCodingPolicyResponseProto) Descriptor() ([]byte, []int) {
-	return fileDescriptor3, []int{5}
+	return fileDescriptor3, []int{7}
 }
 
 func (m *GetErasureCodingPolicyResponseProto) GetEcPolicy() *ErasureCodingPolicyProto {
@@ -134,7 +164,7 @@ type BlockECReconstructionInfoProto struct {
 func (m *BlockECReconstructionInfoProto) Reset()                    { *m = BlockECReconstructionInfoProto{} }
 func (m *BlockECReconstructionInfoProto) String() string            { return proto.CompactTextString(m) }
 func (*BlockECReconstructionInfoProto) ProtoMessage()               {}
-func (*BlockECReconstructionInfoProto) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{6} }
+func (*BlockECReconstructionInfoProto) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{8} }
 
 func (m *BlockECReconstructionInfoProto) GetBlock() *ExtendedBlockProto {
 	if m != nil {
@@ -188,6 +218,8 @@ func (m *BlockECReconstructionInfoProto) GetEcPolicy() *ErasureCodingPolicyProto
 func init() {
 	proto.RegisterType((*SetErasureCodingPolicyRequestProto)(nil), "hadoop.hdfs.SetErasureCodingPolicyRequestProto")
 	proto.RegisterType((*SetErasureCodingPolicyResponseProto)(nil), "hadoop.hdfs.SetErasureCodingPolicyResponseProto")
+	proto.RegisterType((*UnsetErasureCodingPolicyRequestProto)(nil), "hadoop.hdfs.UnsetErasureCodingPolicyRequestProto")
+	proto.RegisterType((*UnsetErasureCodingPolicyResponseProto)(nil), "hadoop.hdfs.UnsetErasureCodingPolicyResponseProto")
 	proto.RegisterType((*GetErasureCodingPoliciesRequestProto)(nil), "hadoop.hdfs.GetErasureCodingPoliciesRequestProto")
 	proto.RegisterType((*GetErasureCodingPoliciesResponseProto)(nil), "hadoop.hdfs.GetErasureCodingPoliciesResponseProto")
 	proto.RegisterType((*GetErasureCodingPolicyRequestProto)(nil), "hadoop.hdfs.GetErasureCodingPolicyRequestProto")
@@ -198,31 +230,33 @@ func init() {
 func init() { proto.RegisterFile("erasurecoding.proto", fileDescriptor3) }
 
 var fileDescriptor3 = []byte{
-	// 404 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x93, 0xcf, 0x6e, 0xd4, 0x30,
-	0x10, 0x87, 0x95, 0x5d, 0xca, 0x9f, 0x59, 0x90, 0xaa, 0xf4, 0x12, 0x71, 0x28, 0x91, 0x4b, 0xd0,
-	0x8a, 0x43, 0x0e, 0x95, 0xe0, 0x8a, 0xd8, 0x36, 0x5a, 0xf5, 0x82, 0xaa, 0x14, 0x1e, 0xc0, 0xd8,
-	0xd3, 0xc4, 0x22, 0xf2, 0x04, 0xdb, 0x41, 0xec, 0xdb, 0xf0, 0x90, 0x3c, 0x00, 0x8a, 0x0d, 0x28,
-	0x56, 0xb3, 0xa2, 0xec, 0x65, 0x65, 0x8d, 0x7f, 0xdf, 0x37, 0xb3, 0x63, 0x05, 0x4e, 0xd0, 0x70,
-	0x3b, 0x18, 0x14, 0x24, 0x95, 0x6e, 0xca, 0xde, 0x90, 0xa3, 0x74, 0xd5, 0x72, 0x49, 0xd4, 0x97,
-	0xad, 0xbc, 0xb5, 0xcf, 0x61, 0xfc, 0x0d, 0x17, 0x6c, 0x07, 0xec, 0x06, 0x5d, 0x15, 0x90, 0x0b,
-	0x8f, 0x5c, 0x53, 0xa7, 0xc4, 0xae, 0xc6, 0xaf, 0x03, 0x5a, 0x77, 0xed, 0xf1, 0x63, 0x58, 0x5a,
-	0x23, 0xb2, 0x24, 0x5f, 0xac, 0x9f, 0xd4, 0xe3, 0x31, 0x7d, 0x0f, 0x8f, 0x51, 0x84, 0x64, 0xb6,
-	0xc8, 0x93, 0xf5, 0xea, 0xbc, 0x28, 0x27, 0x3d, 0xca, 0x19, 0xa3, 0x57, 0xd5, 0x7f, 0x31, 0x56,
-	0xc0, 0xd9, 0xbe, 0xd6, 0xb6, 0x27, 0x6d, 0xd1, 0x03, 0xec, 0x15, 0xbc, 0xdc, 0xce, 0xc5, 0x14,
-	0xda, 0xe9, 0x8c, 0x4c, 0x43, 0xb1, 0x3f, 0x37, 0x11, 0xa6, 0x15, 0xc0, 0xef, 0x19, 0x14, 0xda,
-	0x2c, 0xc9, 0x97, 0xf7, 0x1f, 0x7e, 0x02, 0xb2, 0xb7, 0xc0, 0xb6, 0x07, 0x6c, 0x8e, 0xb5, 0x70,
-	0xb6, 0xfd, 0xf7, 0xdf, 0x8e, 0x16, 0x9c, 0x1c, 0xb6, 0xe0, 0x9f, 0x4b, 0x38, 0xdd, 0x74, 0x24,
-	0xbe, 0x54, 0x17, 0x35, 0x0a, 0xd2, 0xd6, 0x99, 0x41, 0x38, 0x45, 0xfa, 0x4a, 0xdf, 0x52, 0xe8,
-	0xf2, 0x06, 0x8e, 0x3e, 0x8f, 0x09, 0x3f, 0xe0, 0xea, 0xfc, 0x45, 0xdc, 0xe2, 0xbb, 0x43, 0x2d,
-	0x51, 0x7a, 0x47, 0x90, 0x87, 0x74, 0x5a, 0xc1, 0x33, 0x4b, 0x83, 0x11, 0x78, 0xe9, 0x5d, 0x36,
-	0x5b, 0xcc, 0xe0, 0x97, 0xdc, 0x71, 0x4d, 0x12, 0x7d, 0x22, 0xe0, 0x31, 0x35, 0x6a, 0x1c, 0x37,
-	0x0d, 0xba, 0x3f, 0x9a, 0xe5, 0x3d, 0x35, 0x11, 0x95, 0x7e, 0x80, 0x34, 0x14, 0x6e, 0x1c, 0x19,
-	0xde, 0xe0, 0xa7, 0x41, 0x49, 0x9b, 0x3d, 0xf0, 0xae, 0xd3, 0xc8, 0x35, 0x0d, 0x04, 0xd5, 0x0c,
-	0x79, 0xc7, 0xf7, 0x71, 0xd7, 0xa3, 0xcd, 0x8e, 0xf6, 0xfb, 0x7c, 0x60, 0xce, 0xe7, 0x2f, 0xd2,
-	0xd7, 0x70, 0xdc, 0xa9, 0x6f, 0xe8, 0xd7, 0x78, 0xa5, 0xa5, 0x12, 0x68, 0xb3, 0x87, 0xf9, 0x62,
-	0xfd, 0xb4, 0xbe, 0x53, 0x8f, 0x9e, 0xfd, 0x91, 0xef, 0xf8, 0xbf, 0xcf, 0xbe, 0x79, 0x07, 0x05,
-	0x99, 0xa6, 0xe4, 0x3d, 0x17, 0x2d, 0x46, 0xb0, 0xff, 0xe4, 0x05, 0x75, 0xe1, 0xb0, 0x39, 0x89,
-	0x65, 0x63, 0xcd, 0xfe, 0x48, 0x92, 0x5f, 0x01, 0x00, 0x00, 0xff, 0xff, 0x01, 0xdd, 0x67, 0x30,
-	0x42, 0x04, 0x00, 0x00,
+	// 434 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x94, 0x41, 0x6f, 0xd3, 0x30,
+	0x14, 0xc7, 0x95, 0x94, 0x01, 0x7b, 0x1d, 0xd2, 0xe4, 0x5d, 0x22, 0x0e, 0x23, 0xf2, 0x16, 0x08,
+	0x1c, 0x72, 0xa8, 0x04, 0xe2, 0x86, 0xe8, 0x56, 0x55, 0xbd, 0x4c, 0x53, 0xc6, 0x2e, 0xdc, 0x8c,
+	0xf3, 0xd6, 0x46, 0x14, 0x3b, 0xf8, 0x39, 0x88, 0x7d, 0x1b, 0x3e, 0x24, 0x1f, 0x00, 0xc5, 0x66,
+	0x53, 0xac, 0xa5, 0xea, 0xd4, 0x4b, 0x64, 0x3d, 0xff, 0x7f, 0x3f, 0xbf, 0x67, 0x4b, 0x81, 0x23,
+	0x34, 0x82, 0x5a, 0x83, 0x52, 0x57, 0xb5, 0x5a, 0x16, 0x8d, 0xd1, 0x56, 0xb3, 0xf1, 0x4a, 0x54,
+	0x5a, 0x37, 0xc5, 0xaa, 0xba, 0xa1, 0x97, 0xd0, 0x7d, 0xfd, 0x06, 0xff, 0x0a, 0xfc, 0x0a, 0xed,
+	0xcc, 0x23, 0x67, 0x0e, 0xb9, 0xd4, 0xeb, 0x5a, 0xde, 0x96, 0xf8, 0xb3, 0x45, 0xb2, 0x97, 0x0e,
+	0x3f, 0x84, 0x11, 0x19, 0x99, 0x44, 0x69, 0x9c, 0xef, 0x97, 0xdd, 0x92, 0x71, 0x38, 0x40, 0xe9,
+	0x93, 0x17, 0xe2, 0x07, 0x26, 0x71, 0x1a, 0xe5, 0xfb, 0x65, 0x50, 0xe3, 0x19, 0x9c, 0x6c, 0x72,
+	0x53, 0xa3, 0x15, 0xa1, 0x93, 0xf3, 0x8f, 0x70, 0x7a, 0xad, 0x68, 0x87, 0x26, 0xf8, 0x1b, 0xc8,
+	0x36, 0x93, 0xfd, 0x23, 0x5e, 0xc3, 0xe9, 0x7c, 0x28, 0x56, 0x23, 0xf5, 0x8f, 0xe0, 0x06, 0xb2,
+	0xcd, 0xb9, 0x9e, 0x90, 0x2d, 0x00, 0xfe, 0x8f, 0x5a, 0x23, 0x25, 0x51, 0x3a, 0xca, 0xc7, 0x93,
+	0xb7, 0x45, 0xef, 0x92, 0x8b, 0x81, 0x9e, 0x16, 0xea, 0x46, 0x3b, 0xbc, 0xec, 0xc1, 0xfc, 0x03,
+	0xf0, 0xf9, 0x2e, 0xc3, 0xaf, 0xe0, 0x64, 0xbe, 0x7d, 0x74, 0xf6, 0x19, 0x9e, 0xdf, 0x3d, 0x4a,
+	0x12, 0xa5, 0x51, 0x3e, 0x9e, 0x64, 0xdb, 0xfa, 0xf4, 0x3d, 0xde, 0x63, 0xfc, 0xef, 0x08, 0x8e,
+	0xa7, 0x6b, 0x2d, 0xbf, 0xcf, 0xce, 0x4a, 0x94, 0x5a, 0x91, 0x35, 0xad, 0xb4, 0xb5, 0x56, 0xf7,
+	0x03, 0xb1, 0xf7, 0xb0, 0xf7, 0xad, 0x4b, 0xb8, 0x06, 0xc7, 0x93, 0x57, 0xe1, 0x11, 0xbf, 0x2d,
+	0xaa, 0x0a, 0x2b, 0xe7, 0xf0, 0x72, 0x9f, 0x66, 0x33, 0x78, 0x41, 0xba, 0x35, 0x12, 0xcf, 0x9d,
+	0x8b, 0x92, 0x78, 0x00, 0x3f, 0x17, 0x56, 0x28, 0x5d, 0xa1, 0x4b, 0x78, 0x3c, 0xa4, 0x3a, 0x8d,
+	0x15, 0x66, 0x89, 0xf6, 0x4e, 0x33, 0x7a, 0xa4, 0x26, 0xa0, 0xd8, 0x05, 0x30, 0x5f, 0xb8, 0xb2,
+	0xda, 0x88, 0x25, 0x5e, 0xb7, 0x75, 0x45, 0xc9, 0x13, 0xe7, 0x3a, 0x0e, 0x5c, 0xfd, 0x80, 0x57,
+	0x0d, 0x90, 0x0f, 0x7c, 0x5f, 0x6e, 0x1b, 0xa4, 0x64, 0x6f, 0xb3, 0xcf, 0x05, 0x86, 0x7c, 0x6e,
+	0x83, 0xbd, 0x83, 0xc3, 0x75, 0xfd, 0x0b, 0xdd, 0x35, 0x2e, 0x54, 0x55, 0x4b, 0xa4, 0xe4, 0x69,
+	0x1a, 0xe7, 0x07, 0xe5, 0x83, 0x7a, 0xf0, 0xec, 0xcf, 0xd2, 0x78, 0x87, 0x67, 0x9f, 0x7e, 0x82,
+	0x4c, 0x9b, 0x65, 0x21, 0x1a, 0x21, 0x57, 0x18, 0xc0, 0xee, 0xd7, 0x21, 0xf5, 0xda, 0x2f, 0xa6,
+	0x47, 0xa1, 0xac, 0xab, 0xd1, 0x9f, 0x28, 0xfa, 0x37, 0x00, 0x4a, 0x96, 0x08, 0x9d, 0x8a, 0x04,
+	0x00, 0x00,
 }
@@ -22,6 +22,7 @@ const (
 	StorageTypeProto_SSD      StorageTypeProto = 2
 	StorageTypeProto_ARCHIVE  StorageTypeProto = 3
 	StorageTypeProto_RAM_DISK StorageTypeProto = 4
+	StorageTypeProto_PROVIDED StorageTypeProto = 5
 )
 
 var StorageTypeProto_name = map[int32]string{
@@ -29,12 +30,14 @@ var StorageTypeProto_name = map[int32]string{
 	2: "SSD",
 	3: "ARCHIVE",
 	4: "RAM_DISK",
+	5: "PROVIDED",
 }
 var StorageTypeProto_value = map[string]int32{
 	"DISK":     1,
 	"SSD":      2,
 	"ARCHIVE":  3,
 	"RAM_DISK": 4,
+	"PROVIDED": 5,
 }
 
 func (x StorageTypeProto) Enum() *StorageTypeProto {
@@ -127,6 +130,43 @@ func (x *CryptoProtocolVersionProto) UnmarshalJSON(data []byte) error {
 }
 func (CryptoProtocolVersionProto) EnumDescriptor() ([]byte, []int) { return fileDescriptor8, []int{2} }
 
+type ErasureCodingPolicyState int32
+
+const (
+	ErasureCodingPolicyState_DISABLED ErasureCodingPolicyState = 1
+	ErasureCodingPolicyState_ENABLED  ErasureCodingPolicyState = 2
+	ErasureCodingPolicyState_REMOVED  ErasureCodingPolicyState = 3
+)
+
+var ErasureCodingPolicyState_name = map[int32]string{
+	1: "DISABLED",
+	2: "ENABLED",
+	3: "REMOVED",
+}
+var ErasureCodingPolicyState_value = map[string]int32{
+	"DISABLED": 1,
+	"ENABLED":  2,
+	"REMOVED":  3,
+}
+
+func (x ErasureCodingPolicyState) Enum() *ErasureCodingPolicyState {
+	p := new(ErasureCodingPolicyState)
+	*p = x
+	return p
+}
+func (x ErasureCodingPolicyState) String() string {
+	return proto.EnumName(ErasureCodingPolicyState_name, int32(x))
+}
+func (x *ErasureCodingPolicyState) UnmarshalJSON(data []byte) error {
+	value, err := proto.UnmarshalJSONEnum(ErasureCodingPolicyState_value, data, "ErasureCodingPolicyState")
+	if err != nil {
+		return err
+	}
+	*x = ErasureCodingPolicyState(value)
+	return nil
+}
+func (ErasureCodingPolicyState) EnumDescriptor() ([]byte, []int) { return fileDescriptor8, []int{3} }
+
 // *
 // Checksum algorithms/types used in HDFS
 // Make sure this enum's integer values match enum values' id properties defined
@@ -166,7 +206,44 @@ func (x *ChecksumTypeProto) UnmarshalJSON(data []byte) error {
 	*x = ChecksumTypeProto(value)
 	return nil
 }
-func (ChecksumTypeProto) EnumDescriptor() ([]byte, []int) { return fileDescriptor8, []int{3} }
+func (ChecksumTypeProto) EnumDescriptor() ([]byte, []int) { return fileDescriptor8, []int{4} }
+
+// *
+// Algorithms/types denoting how block-level checksums are computed using
+// lower-level chunk checksums/CRCs.
+type BlockChecksumTypeProto int32
+
+const (
+	BlockChecksumTypeProto_MD5CRC        BlockChecksumTypeProto = 1
+	BlockChecksumTypeProto_COMPOSITE_CRC BlockChecksumTypeProto = 2
+)
+
+var BlockChecksumTypeProto_name = map[int32]string{
+	1: "MD5CRC",
+	2: "COMPOSITE_CRC",
+}
+var BlockChecksumTypeProto_value = map[string]int32{
+	"MD5CRC":        1,
+	"COMPOSITE_CRC": 2,
+}
+
+func (x BlockChecksumTypeProto) Enum() *BlockChecksumTypeProto {
+	p := new(BlockChecksumTypeProto)
+	*p = x
+	return p
+}
+func (x BlockChecksumTypeProto) String() string {
+	return proto.EnumName(BlockChecksumTypeProto_name, int32(x))
+}
+func (x *BlockChecksumTypeProto) UnmarshalJSON(data []byte) error {
+	value, err := proto.UnmarshalJSONEnum(BlockChecksumTypeProto_value, data, "BlockChecksumTypeProto")
+	if err != nil {
+		return err
+	}
+	*x = BlockChecksumTypeProto(value)
+	return nil
+}
+func (BlockChecksumTypeProto) EnumDescriptor() ([]byte, []int) { return fileDescriptor8, []int{5} }
 
 type DatanodeInfoProto_AdminState int32
 
@@ -174,17 +251,23 @@ const (
 	DatanodeInfoProto_NORMAL                  DatanodeInfoProto_AdminState = 0
 	DatanodeInfoProto_DECOMMISSION_INPROGRESS DatanodeInfoProto_AdminState = 1
 	DatanodeInfoProto_DECOMMISSIONED          DatanodeInfoProto_AdminState = 2
+	DatanodeInfoProto_ENTERING_MAINTENANCE    DatanodeInfoProto_AdminState = 3
+	DatanodeInfoProto_IN_MAINTENANCE          DatanodeInfoProto_AdminState = 4
 )
 
 var DatanodeInfoProto_AdminState_name = map[int32]string{
 	0: "NORMAL",
 	1: "DECOMMISSION_INPROGRESS",
 	2: "DECOMMISSIONED",
+	3: "ENTERING_MAINTENANCE",
+	4: "IN_MAINTENANCE",
 }
 var DatanodeInfoProto_AdminState_value = map[string]int32{
 	"NORMAL":                  0,
 	"DECOMMISSION_INPROGRESS": 1,
 	"DECOMMISSIONED":          2,
+	"ENTERING_MAINTENANCE":    3,
+	"IN_MAINTENANCE":          4,
 }
 
 func (x DatanodeInfoProto_AdminState) Enum() *DatanodeInfoProto_AdminState {
@@ -279,7 +362,7 @@ func (x *HdfsFileStatusProto_FileType) UnmarshalJSON(data []byte) error {
 	return nil
 }
 func (HdfsFileStatusProto_FileType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor8, []int{25, 0}
+	return fileDescriptor8, []int{26, 0}
 }
 
 // *
@@ -1449,11 +1532,12 @@ func (m *ECSchemaProto) GetOptions() []*ECSchemaOptionEntryProto {
 }
 
 type ErasureCodingPolicyProto struct {
-	Name             *string        `protobuf:"bytes,1,req,name=name" json:"name,omitempty"`
-	Schema           *ECSchemaProto `protobuf:"bytes,2,req,name=schema" json:"schema,omitempty"`
-	CellSize         *uint32        `protobuf:"varint,3,req,name=cellSize" json:"cellSize,omitempty"`
-	Id               *uint32        `protobuf:"varint,4,req,name=id" json:"id,omitempty"`
-	XXX_unrecognized []byte         `json:"-"`
+	Name             *string                   `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Schema           *ECSchemaProto            `protobuf:"bytes,2,opt,name=schema" json:"schema,omitempty"`
+	CellSize         *uint32                   `protobuf:"varint,3,opt,name=cellSize" json:"cellSize,omitempty"`
+	Id               *uint32                   `protobuf:"varint,4,req,name=id" json:"id,omitempty"`
+	State            *ErasureCodingPolicyState `protobuf:"varint,5,opt,name=state,enum=hadoop.hdfs.ErasureCodingPolicyState,def=2" json:"state,omitempty"`
+	XXX_unrecognized []byte                    `json:"-"`
 }
 
 func (m *ErasureCodingPolicyProto) Reset()                    { *m = ErasureCodingPolicyProto{} }
@@ -1461,6 +1545,8 @@ func (m *ErasureCodingPolicyProto) String() string            { return proto.Com
 func (*ErasureCodingPolicyProto) ProtoMessage()               {}
 func (*ErasureCodingPolicyProto) Descriptor() ([]byte, []int) { return fileDescriptor8, []int{24} }
 
+const Default_ErasureCodingPolicyProto_State ErasureCodingPolicyState = ErasureCodingPolicyState_ENABLED
+
 func (m *ErasureCodingPolicyProto) GetName() string {
 	if m != nil && m.Name != nil {
 		return *m.Name
@@ -1489,6 +1575,40 @@ func (m *ErasureCodingPolicyProto) GetId() uint32 {
 	return 0
 }
 
+func (m *ErasureCodingPolicyProto) GetState() ErasureCodingPolicyState {
+	if m != nil && m.State != nil {
+		return *m.State
+	}
+	return Default_ErasureCodingPolicyProto_State
+}
+
+type ErasureCodingPolicyInfoProto struct {
+	Policy           *ErasureCodingPolicyProto `protobuf:"bytes,1,req,name=policy" json:"policy,omitempty"`
+	State            *ErasureCodingPolicyState `protobuf:"varint,2,req,name=state,enum=hadoop.hdfs.ErasureCodingPolicyState,def=2" json:"state,omitempty"`
+	XXX_unrecognized []byte                    `json:"-"`
+}
+
+func (m *ErasureCodingPolicyInfoProto) Reset()                    { *m = ErasureCodingPolicyInfoProto{} }
+func (m *ErasureCodingPolicyInfoProto) String() string            { return proto.CompactTextString(m) }
+func (*ErasureCodingPolicyInfoProto) ProtoMessage()               {}
+func (*ErasureCodingPolicyInfoProto) Descriptor() ([]byte, []int) { return fileDescriptor8, []int{25} }
+
+const Default_ErasureCodingPolicyInfoProto_State ErasureCodingPolicyState = ErasureCodingPolicyState_ENABLED
+
+func (m *ErasureCodingPolicyInfoProto) GetPolicy() *ErasureCodingPolicyProto {
+	if m != nil {
+		return m.Policy
+	}
+	return nil
+}
+
+func (m *ErasureCodingPolicyInfoProto) GetState() ErasureCodingPolicyState {
+	if m != nil && m.State != nil {
+		return *m.State
+	}
+	return Default_ErasureCodingPolicyInfoProto_State
+}
+
 // *
 // Status of a file, directory or symlink
 // Optionally includes a file's block locations if requested by client on the rpc call.
@@ -1521,7 +1641,7 @@ type HdfsFileStatusProto struct {
 func (m *HdfsFileStatusProto) Reset()                    { *m = HdfsFileStatusProto{} }
 func (m *HdfsFileStatusProto) String() string            { return proto.CompactTextString(m) }
 func (*HdfsFileStatusProto) ProtoMessage()               {}
-func (*HdfsFileStatusProto) Descriptor() ([]byte, []int) { return fileDescriptor8, []int{25} }
+func (*HdfsFileStatusProto) Descriptor() ([]byte, []int) { return fileDescriptor8, []int{26} }
 
 const Default_HdfsFileStatusProto_BlockReplication uint32 = 0
 const Default_HdfsFileStatusProto_Blocksize uint64 = 0
@@ -1648,6 +1768,40 @@ func (m *HdfsFileStatusProto) GetEcPolicy() *ErasureCodingPolicyProto {
 	return nil
 }
 
+// *
+// Algorithms/types denoting how block-level checksums are computed using
+// lower-level chunk checksums/CRCs.
+type BlockChecksumOptionsProto struct {
+	BlockChecksumType *BlockChecksumTypeProto `protobuf:"varint,1,opt,name=blockChecksumType,enum=hadoop.hdfs.BlockChecksumTypeProto,def=1" json:"blockChecksumType,omitempty"`
+	// Only used if blockChecksumType specifies a striped format, such as
+	// COMPOSITE_CRC. If so, then the blockChecksum in the response is expected
+	// to be the concatenation of N crcs, where
+	// N == ((requestedLength - 1) / stripedLength) + 1
+	StripeLength     *uint64 `protobuf:"varint,2,opt,name=stripeLength" json:"stripeLength,omitempty"`
+	XXX_unrecognized []byte  `json:"-"`
+}
+
+func (m *BlockChecksumOptionsProto) Reset()                    { *m = BlockChecksumOptionsProto{} }
+func (m *BlockChecksumOptionsProto) String() string            { return proto.CompactTextString(m) }
+func (*BlockChecksumOptionsProto) ProtoMessage()               {}
+func (*BlockChecksumOptionsProto) Descriptor() ([]byte, []int) { return fileDescriptor8, []int{27} }
+
+const Default_BlockChecksumOptionsProto_BlockChecksumType BlockChecksumTypeProto = BlockChecksumTypeProto_MD5CRC
+
+func (m *BlockChecksumOptionsProto) GetBlockChecksumType() BlockChecksumTypeProto {
+	if m != nil && m.BlockChecksumType != nil {
+		return *m.BlockChecksumType
+	}
+	return Default_BlockChecksumOptionsProto_BlockChecksumType
+}
+
+func (m *BlockChecksumOptionsProto) GetStripeLength() uint64 {
+	if m != nil && m.StripeLength != nil {
+		return *m.StripeLength
+	}
+	return 0
+}
+
 // *
 // HDFS Server Defaults
 type FsServerDefaultsProto struct {
@@ -1665,7 +1819,7 @@ type FsServerDefaultsProto struct {
 func (m *FsServerDefaultsProto) Reset()                    { *m = FsServerDefaultsProto{} }
 func (m *FsServerDefaultsProto) String() string            { return proto.CompactTextString(m) }
 func (*FsServerDefaultsProto) ProtoMessage()               {}
-func (*FsServerDefaultsProto) Descriptor() ([]byte, []int) { return fileDescriptor8, []int{26} }
+func (*FsServerDefaultsProto) Descriptor() ([]byte, []int) { return fileDescriptor8, []int{28} }
 
 const Default_FsServerDefaultsProto_EncryptDataTransfer bool = false
 const Default_FsServerDefaultsProto_TrashInterval uint64 = 0
@@ -1738,7 +1892,7 @@ type DirectoryListingProto struct {
 func (m *DirectoryListingProto) Reset()                    { *m = DirectoryListingProto{} }
 func (m *DirectoryListingProto) String() string            { return proto.CompactTextString(m) }
 func (*DirectoryListingProto) ProtoMessage()               {}
-func (*DirectoryListingProto) Descriptor() ([]byte, []int) { return fileDescriptor8, []int{27} }
+func (*DirectoryListingProto) Descriptor() ([]byte, []int) { return fileDescriptor8, []int{29} }
 
 func (m *DirectoryListingProto) GetPartialListing() []*HdfsFileStatusProto {
 	if m != nil {
@@ -1771,7 +1925,7 @@ func (m *SnapshottableDirectoryStatusProto) Reset()         { *m = Snapshottable
 func (m *SnapshottableDirectoryStatusProto) String() string { return proto.CompactTextString(m) }
 func (*SnapshottableDirectoryStatusProto) ProtoMessage()    {}
 func (*SnapshottableDirectoryStatusProto) Descriptor() ([]byte, []int) {
-	return fileDescriptor8, []int{28}
+	return fileDescriptor8, []int{30}
 }
 
 func (m *SnapshottableDirectoryStatusProto) GetDirStatus() *HdfsFileStatusProto {
@@ -1813,7 +1967,7 @@ func (m *SnapshottableDirectoryListingProto) Reset()         { *m = Snapshottabl
 func (m *SnapshottableDirectoryListingProto) String() string { return proto.CompactTextString(m) }
 func (*SnapshottableDirectoryListingProto) ProtoMessage()    {}
 func (*SnapshottableDirectoryListingProto) Descriptor() ([]byte, []int) {
-	return fileDescriptor8, []int{29}
+	return fileDescriptor8, []int{31}
 }
 
 func (m *SnapshottableDirectoryListingProto) GetSnapshottableDirListing() []*SnapshottableDirectoryStatusProto {
@@ -1835,7 +1989,7 @@ type SnapshotDiffReportEntryProto struct {
 func (m *SnapshotDiffReportEntryProto) Reset()                    { *m = SnapshotDiffReportEntryProto{} }
 func (m *SnapshotDiffReportEntryProto) String() string            { return proto.CompactTextString(m) }
 func (*SnapshotDiffReportEntryProto) ProtoMessage()               {}
-func (*SnapshotDiffReportEntryProto) Descriptor() ([]byte, []int) { return fileDescriptor8, []int{30} }
+func (*SnapshotDiffReportEntryProto) Descriptor() ([]byte, []int) { return fileDescriptor8, []int{32} }
 
 func (m *SnapshotDiffReportEntryProto) GetFullpath() []byte {
 	if m != nil {
@@ -1872,7 +2026,7 @@ type SnapshotDiffReportProto struct {
 func (m *SnapshotDiffReportProto) Reset()                    { *m = SnapshotDiffReportProto{} }
 func (m *SnapshotDiffReportProto) String() string            { return proto.CompactTextString(m) }
 func (*SnapshotDiffReportProto) ProtoMessage()               {}
-func (*SnapshotDiffReportProto) Descriptor() ([]byte, []int) { return fileDescriptor8, []int{31} }
+func (*SnapshotDiffReportProto) Descriptor() ([]byte, []int) { return fileDescriptor8, []int{33} }
 
 func (m *SnapshotDiffReportProto) GetSnapshotRoot() string {
 	if m != nil && m.SnapshotRoot != nil {
@@ -1920,7 +2074,7 @@ type BlockProto struct {
 func (m *BlockProto) Reset()                    { *m = BlockProto{} }
 func (m *BlockProto) String() string            { return proto.CompactTextString(m) }
 func (*BlockProto) ProtoMessage()               {}
-func (*BlockProto) Descriptor() ([]byte, []int) { return fileDescriptor8, []int{32} }
+func (*BlockProto) Descriptor() ([]byte, []int) { return fileDescriptor8, []int{34} }
 
 const Default_BlockProto_NumBytes uint64 = 0
 
@@ -1961,7 +2115,7 @@ type SnapshotInfoProto struct {
 func (m *SnapshotInfoProto) Reset()                    { *m = SnapshotInfoProto{} }
 func (m *SnapshotInfoProto) String() string            { return proto.CompactTextString(m) }
 func (*SnapshotInfoProto) ProtoMessage()               {}
-func (*SnapshotInfoProto) Descriptor() ([]byte, []int) { return fileDescriptor8, []int{33} }
+func (*SnapshotInfoProto) Descriptor() ([]byte, []int) { return fileDescriptor8, []int{35} }
 
 func (m *SnapshotInfoProto) GetSnapshotName() string {
 	if m != nil && m.SnapshotName != nil {
@@ -2016,7 +2170,7 @@ type RollingUpgradeStatusProto struct {
 func (m *RollingUpgradeStatusProto) Reset()                    { *m = RollingUpgradeStatusProto{} }
 func (m *RollingUpgradeStatusProto) String() string            { return proto.CompactTextString(m) }
 func (*RollingUpgradeStatusProto) ProtoMessage()               {}
-func (*RollingUpgradeStatusProto) Descriptor() ([]byte, []int) { return fileDescriptor8, []int{34} }
+func (*RollingUpgradeStatusProto) Descriptor() ([]byte, []int) { return fileDescriptor8, []int{36} }
 
 const Default_RollingUpgradeStatusProto_Finalized bool = false
 
@@ -2044,7 +2198,7 @@ type StorageUuidsProto struct {
 func (m *StorageUuidsProto) Reset()                    { *m = StorageUuidsProto{} }
 func (m *StorageUuidsProto) String() string            { return proto.CompactTextString(m) }
 func (*StorageUuidsProto) ProtoMessage()               {}
-func (*StorageUuidsProto) Descriptor() ([]byte, []int) { return fileDescriptor8, []int{35} }
+func (*StorageUuidsProto) Descriptor() ([]byte, []int) { return fileDescriptor8, []int{37} }
 
 func (m *StorageUuidsProto) GetStorageUuids() []string {
 	if m != nil {
@@ -2079,7 +2233,9 @@ func init() {
 	proto.RegisterType((*ECSchemaOptionEntryProto)(nil), "hadoop.hdfs.ECSchemaOptionEntryProto")
 	proto.RegisterType((*ECSchemaProto)(nil), "hadoop.hdfs.ECSchemaProto")
 	proto.RegisterType((*ErasureCodingPolicyProto)(nil), "hadoop.hdfs.ErasureCodingPolicyProto")
+	proto.RegisterType((*ErasureCodingPolicyInfoProto)(nil), "hadoop.hdfs.ErasureCodingPolicyInfoProto")
 	proto.RegisterType((*HdfsFileStatusProto)(nil), "hadoop.hdfs.HdfsFileStatusProto")
+	proto.RegisterType((*BlockChecksumOptionsProto)(nil), "hadoop.hdfs.BlockChecksumOptionsProto")
 	proto.RegisterType((*FsServerDefaultsProto)(nil), "hadoop.hdfs.FsServerDefaultsProto")
 	proto.RegisterType((*DirectoryListingProto)(nil), "hadoop.hdfs.DirectoryListingProto")
 	proto.RegisterType((*SnapshottableDirectoryStatusProto)(nil), "hadoop.hdfs.SnapshottableDirectoryStatusProto")
@@ -2093,7 +2249,9 @@ func init() {
 	proto.RegisterEnum("hadoop.hdfs.StorageTypeProto", StorageTypeProto_name, StorageTypeProto_value)
 	proto.RegisterEnum("hadoop.hdfs.CipherSuiteProto", CipherSuiteProto_name, CipherSuiteProto_value)
 	proto.RegisterEnum("hadoop.hdfs.CryptoProtocolVersionProto", CryptoProtocolVersionProto_name, CryptoProtocolVersionProto_value)
+	proto.RegisterEnum("hadoop.hdfs.ErasureCodingPolicyState", ErasureCodingPolicyState_name, ErasureCodingPolicyState_value)
 	proto.RegisterEnum("hadoop.hdfs.ChecksumTypeProto", ChecksumTypeProto_name, ChecksumTypeProto_value)
+	proto.RegisterEnum("hadoop.hdfs.BlockChecksumTypeProto", BlockChecksumTypeProto_name, BlockChecksumTypeProto_value)
 	proto.RegisterEnum("hadoop.hdfs.DatanodeInfoProto_AdminState", DatanodeInfoProto_AdminState_name, DatanodeInfoProto_AdminState_value)
 	proto.RegisterEnum("hadoop.hdfs.DatanodeStorageProto_StorageState", DatanodeStorageProto_StorageState_name, DatanodeStorageProto_StorageState_value)
 	proto.RegisterEnum("hadoop.hdfs.HdfsFileStatusProto_FileType", HdfsFileStatusProto_FileType_name, HdfsFileStatusProto_FileType_value)
@@ -2102,188 +2260,200 @@ func init() {
 func init() { proto.RegisterFile("hdfs.proto", fileDescriptor8) }
 
 var fileDescriptor8 = []byte{
-	// 2923 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x59, 0xcd, 0x73, 0x1b, 0xc7,
-	0xb1, 0xf7, 0x2e, 0x00, 0x12, 0x68, 0x92, 0x20, 0x38, 0xfa, 0x82, 0x68, 0x59, 0xa6, 0xf7, 0x49,
-	0x16, 0xad, 0x67, 0xb3, 0x6c, 0xea, 0x95, 0x5d, 0x4f, 0xf6, 0xf3, 0x0b, 0x09, 0x80, 0x16, 0x4a,
-	0x10, 0x40, 0x0f, 0x48, 0xb9, 0xec, 0x4a, 0x0a, 0xb5, 0xdc, 0x1d, 0x10, 0x1b, 0x2e, 0x76, 0x36,
-	0xbb, 0x0b, 0x5a, 0xf0, 0x29, 0xc7, 0x54, 0xa5, 0x92, 0x9c, 0x72, 0xcb, 0xc1, 0x55, 0xc9, 0x39,
-	0xff, 0x86, 0xff, 0x87, 0x54, 0x2a, 0xc7, 0xe4, 0x9a, 0x43, 0xee, 0x49, 0x4d, 0xcf, 0xec, 0x17,
-	0x3e, 0x44, 0xc5, 0x3e, 0xe5, 0x86, 0xfe, 0x4d, 0x77, 0xef, 0x74, 0xcf, 0xf4, 0xc7, 0x34, 0x00,
-	0x46, 0xf6, 0x30, 0xdc, 0xf3, 0x03, 0x1e, 0x71, 0xb2, 0x36, 0x32, 0x6d, 0xce, 0xfd, 0x3d, 0x01,
-	0x6d, 0x57, 0xfb, 0xcc, 0x9a, 0x04, 0x4e, 0x34, 0x95, 0x8b, 0xc6, 0x6f, 0x34, 0x20, 0xad, 0x17,
-	0x11, 0xf3, 0x6c, 0x66, 0x1f, 0xba, 0xdc, 0xba, 0x38, 0x46, 0x99, 0x9b, 0xb0, 0xe2, 0x73, 0xee,
-	0xb6, 0xed, 0xba, 0xb6, 0xa3, 0xef, 0x56, 0xa8, 0xa2, 0x48, 0x1d, 0x56, 0xcf, 0x04, 0x57, 0xdb,
-	0xae, 0xeb, 0x3b, 0xfa, 0x6e, 0x91, 0xc6, 0x24, 0xd9, 0x85, 0xcd, 0x73, 0xe6, 0xb1, 0xc0, 0x8c,
-	0x1c, 0xee, 0xf5, 0x23, 0x73, 0xec, 0xd7, 0x0b, 0xc8, 0x31, 0x0b, 0x93, 0x37, 0xa0, 0xec, 0x4d,
-	0xc6, 0x87, 0xd3, 0x88, 0x85, 0xf5, 0xe2, 0x8e, 0xb6, 0x5b, 0x7c, 0xac, 0xbd, 0x4f, 0x13, 0xc8,
-	0xf8, 0xab, 0x06, 0x9b, 0x4d, 0x33, 0x32, 0x3d, 0x6e, 0xb3, 0x76, 0x33, 0xd9, 0x8e, 0xe3, 0x1f,
-	0xd8, 0x76, 0x10, 0x6f, 0x47, 0x52, 0x64, 0x1b, 0xca, 0x23, 0x1e, 0x46, 0x5d, 0x73, 0xcc, 0x70,
-	0x3f, 0x15, 0x9a, 0xd0, 0xc4, 0x80, 0x75, 0x5b, 0xa9, 0x39, 0x9d, 0x38, 0x36, 0xee, 0xa6, 0x42,
-	0x73, 0x98, 0x90, 0x7f, 0x31, 0x64, 0xc1, 0x31, 0x0f, 0xa2, 0x7a, 0x71, 0x47, 0xdf, 0xdd, 0xa0,
-	0x09, 0x2d, 0xd6, 0x1c, 0x6f, 0xc8, 0x71, 0xad, 0x24, 0xd7, 0x62, 0x5a, 0xb8, 0xc1, 0xf1, 0x2d,
-	0x5c, 0x5a, 0xc1, 0xa5, 0x98, 0x24, 0xef, 0x40, 0x55, 0x70, 0xa1, 0x97, 0x19, 0x32, 0xac, 0xee,
-	0x68, 0xbb, 0x1b, 0xc2, 0xc4, 0x99, 0x05, 0xe3, 0xe7, 0x1a, 0xdc, 0x8c, 0x0d, 0xed, 0x70, 0xcb,
-	0x74, 0xdb, 0x42, 0x3d, 0xda, 0xbb, 0x0b, 0x9b, 0x21, 0x1f, 0x46, 0x5f, 0x9b, 0x01, 0x7b, 0xce,
-	0x82, 0xd0, 0xe1, 0x9e, 0x32, 0x7c, 0x16, 0x26, 0xf7, 0x60, 0xc3, 0xe2, 0xde, 0xd0, 0x39, 0x8f,
-	0xf9, 0xa4, 0x1b, 0xf2, 0xa0, 0xf0, 0xdf, 0xc4, 0x8f, 0x9c, 0x31, 0x53, 0x67, 0xa2, 0x28, 0x83,
-	0x02, 0x49, 0x5c, 0xed, 0x0d, 0x79, 0x28, 0xbf, 0xfe, 0x09, 0x54, 0x62, 0x2f, 0x85, 0x75, 0x6d,
-	0xa7, 0xb0, 0xbb, 0xb6, 0x7f, 0x77, 0x2f, 0x73, 0x89, 0xf6, 0xb2, 0x32, 0x28, 0x42, 0x53, 0x01,
-	0xe3, 0xef, 0x45, 0xd8, 0x9a, 0x63, 0x20, 0xef, 0x82, 0xee, 0xc8, 0xcb, 0xb4, 0xb6, 0x7f, 0x67,
-	0xb1, 0x32, 0x79, 0xd6, 0x54, 0x77, 0x6c, 0x71, 0x45, 0x2c, 0xd3, 0x37, 0x2d, 0x27, 0x9a, 0xd6,
-	0xf5, 0xe4, 0x8a, 0xc4, 0x10, 0x79, 0x1d, 0x56, 0xed, 0x61, 0x78, 0x1a, 0x32, 0x71, 0xaa, 0x6a,
-	0x35, 0x46, 0xc8, 0x9b, 0x50, 0x09, 0xd8, 0xd8, 0x74, 0x3c, 0xc7, 0x3b, 0x4f, 0xef, 0x57, 0x8a,
-	0x91, 0x07, 0xb0, 0x81, 0x97, 0xf6, 0x98, 0x73, 0x17, 0x75, 0x94, 0x62, 0xa6, 0x3c, 0x4e, 0xde,
-	0x02, 0x70, 0xcd, 0x30, 0x3a, 0xf5, 0x6d, 0x33, 0x62, 0xf5, 0x95, 0x98, 0x2b, 0x03, 0x92, 0xfb,
-	0xb0, 0xfe, 0xc2, 0x62, 0xce, 0x25, 0x0b, 0x1a, 0x7c, 0xe2, 0x65, 0x0e, 0x3b, 0x07, 0x8b, 0xbb,
-	0xe4, 0x72, 0x0b, 0x63, 0xa0, 0x5e, 0xde, 0xd1, 0xc4, 0x3d, 0x8d, 0x69, 0xf2, 0x39, 0x80, 0x69,
-	0x8f, 0x1d, 0x11, 0x1c, 0x11, 0xab, 0xc3, 0x8e, 0xb6, 0x5b, 0xdd, 0x7f, 0xe7, 0xe5, 0xee, 0xde,
-	0x3b, 0x48, 0x04, 0x1e, 0xaf, 0x74, 0x7b, 0xf4, 0xd9, 0x41, 0x87, 0x66, 0x94, 0x08, 0x0b, 0x2d,
-	0xd3, 0x1a, 0xb1, 0x46, 0xec, 0xc3, 0xb5, 0xc4, 0xc2, 0x1c, 0x2e, 0x7c, 0x85, 0x00, 0xba, 0x61,
-	0x3d, 0xf1, 0x55, 0x82, 0x91, 0x47, 0x70, 0x2d, 0xb5, 0xf6, 0x19, 0xf7, 0x78, 0xc4, 0x3d, 0xc7,
-	0xaa, 0x6f, 0xc4, 0xac, 0x8b, 0x56, 0xc5, 0x9d, 0x9c, 0xf8, 0xe7, 0x81, 0x69, 0xb3, 0x26, 0x17,
-	0x4e, 0xaf, 0x57, 0xd1, 0xe4, 0x3c, 0x68, 0xb4, 0x01, 0x52, 0x33, 0x08, 0x80, 0x32, 0xa4, 0xf6,
-	0x1a, 0x79, 0x1d, 0x6e, 0x35, 0x5b, 0x8d, 0xde, 0xb3, 0x67, 0xed, 0x7e, 0xbf, 0xdd, 0xeb, 0x0e,
-	0xda, 0xdd, 0x63, 0xda, 0xfb, 0x8c, 0xb6, 0xfa, 0xfd, 0x9a, 0x46, 0x08, 0x54, 0xb3, 0x8b, 0xad,
-	0x66, 0x4d, 0x37, 0xfe, 0xa9, 0xc1, 0xf5, 0xd8, 0x49, 0xfd, 0x88, 0x07, 0xe6, 0x39, 0x93, 0xb7,
-	0x6e, 0x07, 0xd6, 0x42, 0x49, 0x63, 0x0a, 0x90, 0x31, 0x94, 0x85, 0x48, 0x07, 0x4a, 0x21, 0x3a,
-	0x5e, 0x47, 0xc7, 0xef, 0x2d, 0x74, 0x7c, 0x56, 0xe7, 0x9e, 0x22, 0xf2, 0xde, 0x97, 0x4a, 0x48,
-	0x2b, 0xf9, 0xde, 0xc9, 0xd4, 0x67, 0x78, 0x39, 0xab, 0xfb, 0x6f, 0xe4, 0x74, 0xf6, 0xd3, 0x75,
-	0xd4, 0xf7, 0xb8, 0xd8, 0x6c, 0xf7, 0x9f, 0xd2, 0xac, 0x9c, 0xf1, 0x3e, 0xac, 0x67, 0xbf, 0x92,
-	0x73, 0xce, 0x75, 0xa8, 0xd1, 0xd6, 0x41, 0x73, 0xd0, 0xeb, 0x76, 0xbe, 0x1c, 0xf4, 0x9f, 0x1c,
-	0xd0, 0x56, 0xb3, 0xa6, 0x19, 0xbf, 0xd3, 0x81, 0x28, 0x11, 0xca, 0x7c, 0x1e, 0x44, 0xd2, 0xfe,
-	0x7b, 0x0b, 0xec, 0x3f, 0xd4, 0xeb, 0x5a, 0xde, 0x07, 0x6f, 0xc0, 0xca, 0xd0, 0x74, 0x5c, 0x66,
-	0xa3, 0x13, 0xca, 0x8f, 0x4b, 0x43, 0xd3, 0x0d, 0x19, 0x55, 0x60, 0x2e, 0x18, 0x0b, 0x2f, 0x0d,
-	0xc6, 0xe2, 0xcb, 0x83, 0xb1, 0xf4, 0x2a, 0xc1, 0xb8, 0xb2, 0x24, 0x18, 0x3f, 0x86, 0x55, 0xb5,
-	0x67, 0x0c, 0xb2, 0xb5, 0xfd, 0xb7, 0xae, 0x3c, 0x2a, 0x1a, 0x4b, 0x18, 0xdf, 0xea, 0x70, 0xad,
-	0xc1, 0xbd, 0x88, 0x79, 0x51, 0x7f, 0x32, 0x1e, 0x9b, 0xc1, 0x34, 0xa9, 0x2b, 0x2e, 0xf3, 0xce,
-	0xa3, 0x11, 0xba, 0xa6, 0x48, 0x15, 0x45, 0xee, 0x40, 0x65, 0xe8, 0xb8, 0x4c, 0xc6, 0xb4, 0x2c,
-	0x74, 0x29, 0x40, 0xde, 0x86, 0xaa, 0xed, 0x04, 0xcc, 0x8a, 0x78, 0x30, 0x95, 0x2c, 0x32, 0xab,
-	0xce, 0xa0, 0xe4, 0x3a, 0x94, 0x7e, 0x36, 0xe1, 0x91, 0x89, 0xa5, 0xa5, 0x48, 0x25, 0x21, 0xa2,
-	0x23, 0xf4, 0x4d, 0x8b, 0x35, 0xb8, 0x17, 0x4e, 0xc6, 0x98, 0x7e, 0xc4, 0x6a, 0x1e, 0x24, 0x77,
-	0x01, 0x10, 0xf8, 0x1c, 0x15, 0xac, 0x20, 0x4b, 0x06, 0x21, 0x3d, 0xa8, 0x46, 0x53, 0x5f, 0x12,
-	0x98, 0xba, 0x95, 0x57, 0x1e, 0x2c, 0xbb, 0x6c, 0x29, 0xa7, 0xf4, 0xcd, 0x8c, 0xb8, 0xf1, 0x0f,
-	0x0d, 0x36, 0x91, 0x3c, 0x0d, 0x93, 0xf0, 0xf9, 0x1f, 0xb8, 0x21, 0xac, 0x3e, 0xf0, 0xec, 0x66,
-	0xde, 0x5e, 0xe9, 0xad, 0xc5, 0x8b, 0xa9, 0xd9, 0xfa, 0x4b, 0xcd, 0x2e, 0x5c, 0x6d, 0x76, 0xf1,
-	0x15, 0xcc, 0x2e, 0xfd, 0x30, 0xb3, 0x7f, 0x0a, 0xdb, 0xcb, 0xb9, 0x49, 0x07, 0x36, 0x72, 0xfc,
-	0xaa, 0x1a, 0xbe, 0x7d, 0xe5, 0xd7, 0xe4, 0xc7, 0xf2, 0xc2, 0xa2, 0xe0, 0xdf, 0x5e, 0xca, 0x4c,
-	0x3e, 0x80, 0xa2, 0x60, 0x47, 0xdf, 0x5e, 0x95, 0x34, 0x28, 0xb2, 0x2e, 0xf1, 0xf4, 0x36, 0x94,
-	0xad, 0xbc, 0x93, 0x13, 0xda, 0x38, 0x82, 0x9b, 0x0d, 0x1e, 0x04, 0x13, 0x3f, 0x3a, 0x72, 0x5c,
-	0x86, 0x0d, 0x9f, 0x32, 0xf5, 0x3a, 0x94, 0xc4, 0x71, 0xca, 0x82, 0x5f, 0xa1, 0x92, 0x10, 0x01,
-	0x62, 0x71, 0x7e, 0xe1, 0xc4, 0xed, 0x95, 0xa2, 0x8c, 0x07, 0xb0, 0x75, 0x14, 0x1e, 0xb3, 0x60,
-	0xec, 0x84, 0xa2, 0xc1, 0x90, 0x2a, 0x08, 0x14, 0x7d, 0x16, 0x8c, 0xd1, 0x82, 0x0d, 0x8a, 0xbf,
-	0x8d, 0xe7, 0xb0, 0x95, 0xd9, 0xbc, 0xfa, 0xd6, 0x01, 0xac, 0x67, 0xd2, 0x9d, 0xfc, 0xe4, 0x95,
-	0x26, 0xe7, 0x44, 0x8c, 0xef, 0x74, 0xb8, 0x85, 0xdb, 0x8f, 0x03, 0x9e, 0xbb, 0x8e, 0xa5, 0xa2,
-	0x7a, 0x1b, 0xca, 0x3e, 0x92, 0xaa, 0x7d, 0xdd, 0xa0, 0x09, 0x2d, 0xf6, 0xe8, 0xa5, 0xdd, 0x22,
-	0xfe, 0x26, 0x47, 0x50, 0xb5, 0x02, 0x86, 0xd5, 0x58, 0xaa, 0x41, 0xb7, 0xcd, 0x36, 0x3d, 0x73,
-	0x66, 0xd0, 0x19, 0x29, 0xf2, 0x1c, 0x6e, 0xc6, 0xc8, 0x91, 0xe9, 0xba, 0x67, 0xa6, 0x48, 0x5f,
-	0xa8, 0xaf, 0x88, 0x97, 0xf4, 0x2a, 0x7d, 0x4b, 0xa4, 0xc9, 0x8f, 0xe1, 0x76, 0xc0, 0x7c, 0xd7,
-	0xb1, 0x16, 0xa9, 0x2e, 0xbd, 0x92, 0xea, 0xe5, 0x0a, 0x8c, 0xef, 0x0a, 0xb0, 0x25, 0xda, 0xcf,
-	0x28, 0xf7, 0x00, 0x78, 0x0f, 0xb4, 0x33, 0xd5, 0xae, 0xbd, 0x99, 0xd3, 0x3d, 0xff, 0x58, 0xa0,
-	0xda, 0x99, 0xb8, 0x27, 0x7c, 0x38, 0x0c, 0x59, 0x9c, 0x2d, 0x15, 0x45, 0xf6, 0xa1, 0xe8, 0x72,
-	0x2b, 0xac, 0x17, 0x5e, 0xa9, 0x8b, 0x44, 0x5e, 0xd1, 0x5c, 0x5b, 0xf2, 0x8e, 0x62, 0x02, 0x28,
-	0xd3, 0x98, 0x24, 0xff, 0x0b, 0x80, 0x45, 0xe1, 0x84, 0x5f, 0x30, 0x0f, 0xf3, 0xe6, 0xda, 0xfe,
-	0xed, 0x58, 0xa7, 0xc5, 0xc7, 0x63, 0xee, 0xed, 0xe1, 0x9a, 0x54, 0x97, 0x61, 0x26, 0x77, 0xa1,
-	0xec, 0x84, 0x0d, 0xd1, 0xd7, 0x88, 0x12, 0x53, 0xd8, 0x2d, 0x1f, 0xea, 0x35, 0x8d, 0x26, 0xd8,
-	0xdc, 0x95, 0x5c, 0xfd, 0xb7, 0xaf, 0x24, 0xe6, 0x2e, 0x49, 0xb7, 0x9b, 0x61, 0xbd, 0x8c, 0x61,
-	0x94, 0x41, 0xc4, 0x83, 0x44, 0x3e, 0x96, 0x3c, 0xdb, 0xb1, 0x58, 0x58, 0xaf, 0xec, 0x68, 0xbb,
-	0xeb, 0x34, 0x87, 0x91, 0x8f, 0x61, 0x2d, 0xdd, 0x74, 0x58, 0x07, 0x74, 0xdb, 0x4b, 0x4c, 0xcc,
-	0x72, 0x1b, 0x7f, 0x51, 0x0f, 0x8a, 0x96, 0x67, 0x05, 0x53, 0x5f, 0x1c, 0xf5, 0x53, 0x36, 0x4d,
-	0xa2, 0xfb, 0x82, 0xa5, 0xf1, 0x20, 0x09, 0xd1, 0x1e, 0x25, 0x45, 0x56, 0xbd, 0xe8, 0x2a, 0x34,
-	0x0b, 0x09, 0x39, 0x8f, 0x7b, 0x96, 0x7c, 0x37, 0xac, 0x53, 0x49, 0x88, 0x5c, 0xce, 0xb2, 0xdf,
-	0xc0, 0x73, 0x5a, 0xa7, 0x79, 0x50, 0xf8, 0x83, 0xbd, 0xf0, 0x9d, 0x60, 0xda, 0x14, 0xfd, 0x95,
-	0xac, 0x72, 0x19, 0x84, 0xbc, 0x0f, 0xd7, 0x52, 0x81, 0x03, 0xf7, 0x9c, 0x07, 0x4e, 0x34, 0x1a,
-	0x63, 0x03, 0x50, 0xa1, 0x8b, 0x96, 0x8c, 0xdf, 0xea, 0x70, 0x4b, 0xe4, 0xad, 0xd4, 0xc0, 0x34,
-	0x7d, 0x3e, 0x82, 0x52, 0x38, 0x71, 0xa2, 0xc5, 0xf9, 0xb3, 0xe1, 0xf8, 0x23, 0x16, 0xf4, 0xc5,
-	0xba, 0xf4, 0x9b, 0xe4, 0x25, 0x3f, 0x81, 0x1b, 0xa8, 0x49, 0xea, 0xb0, 0xb8, 0x9b, 0x7d, 0x45,
-	0x55, 0x67, 0xaa, 0x4a, 0x63, 0x11, 0xa7, 0x54, 0xb7, 0x58, 0x0b, 0xa9, 0x41, 0xe1, 0x82, 0x4d,
-	0x95, 0xef, 0xc4, 0x4f, 0x52, 0x05, 0xdd, 0xb9, 0x54, 0xee, 0xd2, 0x9d, 0x4b, 0x71, 0xd7, 0x2f,
-	0xd8, 0x14, 0xdf, 0xaf, 0x25, 0xf4, 0x7e, 0x4c, 0x92, 0x87, 0x50, 0x63, 0xdf, 0x3c, 0x65, 0x53,
-	0xa5, 0x0b, 0x59, 0x56, 0x90, 0x65, 0x0e, 0x17, 0x45, 0xec, 0x98, 0x05, 0xcb, 0x3c, 0xa3, 0x76,
-	0xa1, 0xcd, 0xee, 0x42, 0x4f, 0x76, 0xb1, 0xe8, 0x5b, 0x85, 0x25, 0xdf, 0xfa, 0x4e, 0x83, 0x5b,
-	0x5f, 0x71, 0xef, 0x3f, 0xe6, 0x0c, 0x32, 0x1e, 0x2e, 0xe4, 0x3c, 0x6c, 0x7c, 0xab, 0xc1, 0x96,
-	0xdc, 0x54, 0x0f, 0xed, 0xf8, 0x01, 0x36, 0x5c, 0x87, 0x92, 0x83, 0x81, 0xa0, 0x63, 0x4c, 0x4b,
-	0x42, 0xd4, 0x1a, 0xc7, 0x6b, 0x5f, 0x62, 0xd3, 0xbc, 0x4e, 0xf1, 0x37, 0x26, 0xca, 0x49, 0x24,
-	0x63, 0x46, 0xa0, 0x8a, 0x12, 0x1a, 0xf8, 0x24, 0x6a, 0x5f, 0x62, 0x3e, 0x5f, 0xa7, 0x92, 0x30,
-	0x7e, 0x5f, 0x00, 0x92, 0xcd, 0xcd, 0xaa, 0x7e, 0xde, 0x05, 0x10, 0xe5, 0xb9, 0x93, 0x6d, 0x5d,
-	0x33, 0x08, 0xf9, 0x10, 0x56, 0x30, 0x88, 0xc3, 0xba, 0xbe, 0x20, 0xef, 0xce, 0x25, 0x7b, 0xaa,
-	0xb8, 0xc9, 0xbb, 0xb0, 0x35, 0xf1, 0x6c, 0xf1, 0x68, 0xf5, 0xc2, 0x28, 0x98, 0x58, 0xf8, 0x5e,
-	0x2d, 0x60, 0x0e, 0x9e, 0x5f, 0x20, 0x9f, 0x40, 0x45, 0xbc, 0xfe, 0x50, 0xcf, 0xc2, 0x0a, 0x37,
-	0xff, 0xa1, 0x54, 0x40, 0x44, 0xbf, 0x13, 0x76, 0x62, 0xb2, 0xc1, 0xc7, 0xbe, 0xcb, 0x54, 0x9a,
-	0x28, 0xd3, 0x45, 0x4b, 0xe4, 0x04, 0xc8, 0x70, 0xee, 0x8a, 0x63, 0xba, 0x58, 0xdb, 0xbf, 0x97,
-	0xfb, 0xf0, 0x92, 0x48, 0xa0, 0x0b, 0xe4, 0xc9, 0x01, 0x94, 0x99, 0xa5, 0x6a, 0xa9, 0x6c, 0xa1,
-	0xef, 0xe7, 0xeb, 0x5d, 0x60, 0x86, 0x93, 0x80, 0x35, 0xb8, 0xed, 0x78, 0xe7, 0x99, 0x2e, 0x83,
-	0x26, 0x62, 0xc6, 0x21, 0xd4, 0x5b, 0x8d, 0xbe, 0x35, 0x62, 0x63, 0x53, 0xde, 0xa4, 0x96, 0x17,
-	0xc5, 0x2f, 0x8c, 0x4c, 0xf0, 0x55, 0x64, 0xf0, 0x5d, 0x87, 0xd2, 0xa5, 0xe9, 0x4e, 0xe2, 0x16,
-	0x44, 0x12, 0xc6, 0x1f, 0x35, 0xd8, 0x88, 0x95, 0x48, 0xc9, 0x3b, 0x50, 0xb1, 0xb8, 0xcd, 0x2c,
-	0xbc, 0xba, 0x52, 0x3e, 0x05, 0xc4, 0xaa, 0x6d, 0x46, 0xe6, 0xa9, 0xe7, 0x44, 0x21, 0x6a, 0xda,
-	0xa0, 0x29, 0x20, 0x12, 0xbb, 0x6f, 0x06, 0x4e, 0x34, 0x95, 0xeb, 0x05, 0x5c, 0xcf, 0x42, 0xe4,
-	0xff, 0x61, 0x95, 0xe3, 0x5e, 0xc3, 0x7a, 0x11, 0xef, 0xc8, 0x8c, 0xd5, 0x4b, 0xec, 0xa1, 0xb1,
-	0x94, 0xf1, 0x6b, 0x0d, 0xea, 0xcb, 0x7c, 0x93, 0x74, 0x59, 0x5a, 0xa6, 0xcb, 0xda, 0x87, 0x95,
-	0x10, 0x75, 0xe2, 0x76, 0xd7, 0xf6, 0xb7, 0x17, 0x7e, 0x50, 0x5d, 0x48, 0xc9, 0x89, 0xad, 0x2c,
-	0x73, 0xdd, 0xbe, 0xf3, 0x0d, 0x53, 0x46, 0x24, 0x34, 0x26, 0x31, 0x5b, 0x4d, 0xed, 0x74, 0xc7,
-	0x36, 0xfe, 0xb0, 0x02, 0xd7, 0x9e, 0xd8, 0xc3, 0x50, 0x1c, 0xbe, 0x78, 0x36, 0x4f, 0x54, 0xb0,
-	0xb4, 0xa0, 0x2c, 0x8e, 0xfd, 0x24, 0xed, 0xad, 0xf3, 0xd3, 0x95, 0x05, 0x32, 0x78, 0x81, 0x84,
-	0x00, 0x4d, 0x44, 0xb1, 0xb9, 0x35, 0xa3, 0x91, 0xca, 0x9a, 0xf8, 0x3b, 0xf3, 0x7c, 0x2c, 0xe4,
-	0x9e, 0x8f, 0x9f, 0x02, 0xf8, 0x49, 0x6f, 0x8c, 0x5b, 0x9c, 0x0d, 0x8d, 0xb9, 0xe6, 0x99, 0x66,
-	0x24, 0x30, 0x19, 0x7c, 0xed, 0xb1, 0x40, 0xd5, 0x04, 0x49, 0x08, 0xf4, 0x3c, 0xe0, 0x13, 0x5f,
-	0x95, 0x01, 0x49, 0x90, 0xff, 0x86, 0xad, 0x31, 0xb7, 0x9d, 0xa1, 0x6a, 0xee, 0x06, 0x38, 0xe5,
-	0x5b, 0xc5, 0xed, 0xd4, 0xb2, 0x0b, 0x27, 0xce, 0x98, 0x91, 0x37, 0x61, 0xcd, 0xb4, 0x2c, 0x16,
-	0x86, 0x92, 0xad, 0x2c, 0x33, 0x87, 0x84, 0x90, 0xa1, 0x0e, 0xab, 0xe1, 0x74, 0xec, 0x3a, 0xde,
-	0x85, 0x6a, 0x4f, 0x62, 0x92, 0xec, 0xc1, 0x16, 0x66, 0x89, 0x41, 0xa6, 0x93, 0xc4, 0x69, 0x15,
-	0x8e, 0xbb, 0x6a, 0xb8, 0x46, 0xd3, 0x25, 0xf1, 0xf2, 0x97, 0x59, 0x45, 0x9c, 0x5d, 0x32, 0x7f,
-	0x4a, 0x31, 0xf2, 0x7f, 0x50, 0x89, 0x67, 0x60, 0x21, 0xce, 0x9e, 0x66, 0x3b, 0xcd, 0xf9, 0xc4,
-	0x47, 0x53, 0x09, 0x72, 0x1b, 0x56, 0xc4, 0xd9, 0xb4, 0xed, 0x74, 0x18, 0xa5, 0x00, 0x72, 0x0f,
-	0xd6, 0xac, 0x91, 0xe3, 0xda, 0x01, 0xf3, 0xba, 0x93, 0x31, 0x4e, 0x9f, 0x4a, 0x8f, 0xf5, 0xf7,
-	0x3e, 0xa0, 0x59, 0x78, 0x49, 0x3a, 0xd9, 0xfc, 0x81, 0xe9, 0xe4, 0x01, 0x6c, 0x84, 0xd9, 0x17,
-	0x49, 0xbd, 0x16, 0xbb, 0x28, 0x8f, 0xe7, 0xf2, 0xce, 0xd6, 0xf7, 0xcb, 0x3b, 0x8f, 0xa0, 0x1c,
-	0x5f, 0x54, 0x02, 0xb0, 0xd2, 0xee, 0x0f, 0x9a, 0x6d, 0x5a, 0xd3, 0xc8, 0x1a, 0xac, 0xb6, 0xfb,
-	0x83, 0xa3, 0x76, 0xa7, 0x55, 0xd3, 0x49, 0x15, 0xa0, 0xdd, 0x1f, 0xf4, 0xbf, 0x7c, 0xd6, 0x69,
-	0x77, 0x9f, 0xd6, 0x0a, 0xc6, 0x2f, 0x0b, 0x70, 0xe3, 0x28, 0xec, 0xb3, 0xe0, 0x92, 0x05, 0x4d,
-	0x36, 0x34, 0x27, 0x6e, 0x14, 0x26, 0x09, 0x07, 0x4f, 0x07, 0xa3, 0x4d, 0x16, 0x95, 0x14, 0x10,
-	0x3d, 0xc2, 0xd9, 0x34, 0x62, 0xe2, 0xde, 0x36, 0x46, 0xcc, 0xba, 0x08, 0x27, 0x63, 0x95, 0x77,
-	0xe6, 0x70, 0xb2, 0x0b, 0x9b, 0x5f, 0x07, 0xa2, 0x46, 0x9a, 0xd6, 0x05, 0x8b, 0x32, 0xd1, 0x3b,
-	0x0b, 0x8b, 0x44, 0x95, 0xbd, 0x4f, 0x32, 0x9a, 0xb3, 0x10, 0x79, 0x1b, 0xaa, 0xc2, 0xcd, 0x87,
-	0x93, 0xe1, 0x90, 0x05, 0xa8, 0x4a, 0x0e, 0xe3, 0x67, 0x50, 0xf2, 0x51, 0xd2, 0x4d, 0x8a, 0x16,
-	0xf8, 0x24, 0x30, 0xbd, 0x70, 0xc8, 0x02, 0x2c, 0x0f, 0xc9, 0x44, 0x6b, 0x11, 0x87, 0x38, 0xb1,
-	0x28, 0x30, 0xc3, 0x51, 0xdb, 0x8b, 0x58, 0x70, 0x69, 0xba, 0x58, 0x05, 0xe4, 0x04, 0x2a, 0x87,
-	0x13, 0x0a, 0xeb, 0x96, 0xb2, 0x10, 0x93, 0x49, 0x19, 0xa7, 0x7b, 0xf9, 0xb8, 0x6e, 0x64, 0x18,
-	0xe4, 0x78, 0xaf, 0xda, 0x78, 0xd2, 0x6a, 0x3c, 0xed, 0x9f, 0x3e, 0x1b, 0x34, 0x68, 0xe3, 0xd1,
-	0x3e, 0xcd, 0xe9, 0x30, 0x7e, 0xa5, 0xc1, 0x8d, 0x64, 0x7c, 0xd2, 0x71, 0xc2, 0x48, 0x1c, 0x36,
-	0x9e, 0xc6, 0x13, 0xa8, 0xfa, 0x66, 0x10, 0x39, 0xa6, 0xab, 0x60, 0x35, 0x7b, 0xd8, 0xb9, 0x2a,
-	0x79, 0xd1, 0x19, 0x39, 0x71, 0x72, 0xc9, 0xbc, 0x4d, 0x64, 0x72, 0x87, 0xc5, 0x15, 0x63, 0x0e,
-	0x37, 0xfe, 0xac, 0xc1, 0x5b, 0x7d, 0xcf, 0xf4, 0xc3, 0x11, 0x8f, 0x22, 0xf3, 0xcc, 0x65, 0xc9,
-	0xe6, 0xb2, 0x29, 0xf5, 0x53, 0xa8, 0xd8, 0x4e, 0x20, 0x11, 0xf5, 0x48, 0xbc, 0x7a, 0x5b, 0xa9,
-	0x08, 0xb9, 0x0f, 0xd5, 0x50, 0x7d, 0x64, 0x90, 0x0e, 0x30, 0x36, 0xe8, 0x46, 0x8c, 0xca, 0x61,
-	0xcf, 0x03, 0xd8, 0x4c, 0xd8, 0xbc, 0xc9, 0xf8, 0x8c, 0x05, 0xea, 0x1a, 0x25, 0xd2, 0x5d, 0x44,
-	0x05, 0xa3, 0x6f, 0x06, 0xcc, 0x8b, 0x06, 0xc3, 0x89, 0xeb, 0x62, 0x9a, 0x96, 0x2d, 0x76, 0x55,
-	0xc2, 0x47, 0x0a, 0x15, 0x45, 0xcb, 0x58, 0x6c, 0x5e, 0xce, 0xf7, 0x23, 0xb8, 0x15, 0xce, 0x70,
-	0xe5, 0x0f, 0x21, 0x3f, 0x26, 0xbe, 0xd2, 0x61, 0x74, 0x99, 0x3a, 0xe3, 0x17, 0x1a, 0xdc, 0x89,
-	0xc5, 0x9b, 0xce, 0x70, 0x28, 0x87, 0xb7, 0x99, 0xfe, 0x61, 0x1b, 0xca, 0x89, 0x4d, 0xb2, 0x83,
-	0x4f, 0x68, 0xd1, 0xae, 0x65, 0x33, 0x7c, 0xc7, 0x3c, 0x63, 0xae, 0xea, 0x2a, 0xe6, 0x17, 0x44,
-	0xd3, 0x18, 0x99, 0xc1, 0x39, 0x8b, 0x8e, 0x4d, 0x2c, 0x58, 0x22, 0xbb, 0x67, 0x10, 0xe3, 0x4f,
-	0x1a, 0xdc, 0x9a, 0xdf, 0x8a, 0xdc, 0x85, 0x01, 0xeb, 0xb1, 0x05, 0x94, 0xf3, 0x48, 0xd5, 0xf5,
-	0x1c, 0x26, 0x78, 0x86, 0x01, 0x1f, 0xc7, 0x2a, 0xd4, 0x46, 0x72, 0x18, 0xee, 0x81, 0x27, 0x1c,
-	0xb2, 0x1f, 0xcf, 0x20, 0xe4, 0x0b, 0xd8, 0xb2, 0x73, 0x5e, 0x70, 0x58, 0xdc, 0x9f, 0xbc, 0xb3,
-	0xd0, 0xe5, 0x8b, 0x7c, 0x46, 0xe7, 0x75, 0x18, 0x26, 0x40, 0x66, 0xb8, 0x91, 0xf9, 0x17, 0x53,
-	0xcb, 0xff, 0x8b, 0xb9, 0x0d, 0xe5, 0x73, 0xa6, 0xfe, 0xbe, 0x94, 0x93, 0x8c, 0x84, 0xce, 0xfd,
-	0x6f, 0x59, 0x98, 0xff, 0xdf, 0xf2, 0x6f, 0x1a, 0x6c, 0xc5, 0xdb, 0x4a, 0x9f, 0x44, 0x19, 0xcf,
-	0x65, 0x1a, 0xb9, 0x1c, 0x36, 0xe7, 0x5d, 0x7d, 0x81, 0x77, 0xf3, 0x2d, 0x45, 0xe1, 0xfb, 0xb7,
-	0x14, 0xc5, 0x85, 0x2d, 0x45, 0x29, 0xdb, 0x52, 0xdc, 0x05, 0xc0, 0x49, 0x14, 0x13, 0x2d, 0x81,
-	0xea, 0x36, 0x32, 0x88, 0x71, 0x06, 0xb7, 0x29, 0x77, 0x5d, 0xc7, 0x3b, 0x3f, 0x95, 0xff, 0xe8,
-	0x64, 0x73, 0xc3, 0xcc, 0x4c, 0x41, 0x9b, 0x9f, 0x29, 0xfc, 0x17, 0x54, 0x86, 0x8e, 0x67, 0xba,
-	0xce, 0x37, 0xb3, 0xff, 0x38, 0xa4, 0xb8, 0xf1, 0x51, 0x32, 0x37, 0x3c, 0x9d, 0x38, 0x76, 0x98,
-	0x3a, 0x33, 0x03, 0xaa, 0x51, 0x65, 0x0e, 0x7b, 0xf8, 0x23, 0xa8, 0xcd, 0xce, 0x69, 0x48, 0x19,
-	0xf0, 0x4f, 0x96, 0x9a, 0x46, 0x56, 0xa1, 0xd0, 0xef, 0x37, 0x6b, 0xba, 0xa8, 0x91, 0x07, 0xb4,
-	0xf1, 0xa4, 0xfd, 0xbc, 0x55, 0x2b, 0x90, 0x75, 0x28, 0xd3, 0x83, 0x67, 0x03, 0xe4, 0x29, 0x3e,
-	0xfc, 0x10, 0x6a, 0xb3, 0xef, 0x3c, 0xc1, 0x7e, 0xda, 0x7d, 0xda, 0xed, 0x7d, 0xd1, 0xad, 0x69,
-	0xe4, 0x06, 0x6c, 0x1d, 0xb4, 0xfa, 0x83, 0xc6, 0x09, 0x1d, 0x74, 0x7b, 0xc7, 0x07, 0xcd, 0x66,
-	0xbb, 0xfb, 0x59, 0x4d, 0x7f, 0x78, 0x0c, 0xdb, 0xcb, 0x9f, 0xa7, 0xe4, 0x0e, 0xd4, 0x95, 0x86,
-	0xc1, 0x31, 0xed, 0x9d, 0xf4, 0x1a, 0xbd, 0xce, 0xe0, 0x79, 0x8b, 0xf6, 0xdb, 0x3d, 0xa1, 0xf2,
-	0x3a, 0xd4, 0x5a, 0xdd, 0x06, 0xfd, 0xf2, 0xf8, 0xa4, 0xdd, 0xeb, 0x0e, 0xbe, 0xea, 0x75, 0x5b,
-	0xfd, 0x9a, 0xfe, 0xb0, 0x07, 0x5b, 0x73, 0x05, 0x85, 0x6c, 0xc1, 0x46, 0x52, 0x52, 0xba, 0xa7,
-	0x9d, 0x4e, 0xed, 0x35, 0x42, 0x60, 0xa6, 0xca, 0xd4, 0x34, 0x72, 0x0d, 0x36, 0xf3, 0x58, 0xa3,
-	0xa6, 0x1f, 0x7e, 0x08, 0xf7, 0x79, 0x70, 0xbe, 0x67, 0xfa, 0xa6, 0x35, 0x62, 0xb9, 0xdb, 0xe3,
-	0xab, 0x3d, 0xcb, 0x1f, 0x87, 0x20, 0x32, 0x38, 0x7e, 0x2f, 0xfc, 0x56, 0xd3, 0xfe, 0x15, 0x00,
-	0x00, 0xff, 0xff, 0xd4, 0x8c, 0x02, 0x21, 0x4f, 0x20, 0x00, 0x00,
+	// 3110 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x59, 0xdd, 0x6f, 0x1b, 0xc7,
+	0x11, 0xef, 0x1d, 0x49, 0x89, 0x1c, 0x89, 0xf4, 0x69, 0xfd, 0x75, 0x56, 0x1c, 0x47, 0xb9, 0xc4,
+	0xb1, 0xe2, 0x26, 0x42, 0x22, 0xb7, 0x09, 0xea, 0x34, 0x29, 0x28, 0x92, 0x8a, 0x09, 0x53, 0x24,
+	0xb3, 0x94, 0x1c, 0x38, 0x68, 0x41, 0x9c, 0xee, 0x96, 0xe2, 0x55, 0xc7, 0xdb, 0xeb, 0xdd, 0x51,
+	0x36, 0xf3, 0xd4, 0xc7, 0x02, 0x45, 0xdb, 0xa7, 0xbe, 0x14, 0x45, 0x11, 0x20, 0x7d, 0xee, 0xbf,
+	0x91, 0x7f, 0xa0, 0x4f, 0x45, 0xd1, 0xc7, 0xf6, 0x1f, 0xe8, 0x7b, 0x8b, 0xfd, 0xb8, 0x2f, 0x7e,
+	0x58, 0x6e, 0xf2, 0xd4, 0xb7, 0x9b, 0xdf, 0xce, 0xcc, 0xed, 0xce, 0xce, 0xce, 0xcc, 0xce, 0x02,
+	0x8c, 0xed, 0x51, 0xb8, 0xe7, 0x07, 0x34, 0xa2, 0x68, 0x63, 0x6c, 0xda, 0x94, 0xfa, 0x7b, 0x0c,
+	0xda, 0xae, 0x0d, 0x88, 0x35, 0x0d, 0x9c, 0x68, 0x26, 0x06, 0x8d, 0xdf, 0x29, 0x80, 0x5a, 0xcf,
+	0x23, 0xe2, 0xd9, 0xc4, 0x3e, 0x70, 0xa9, 0x75, 0xde, 0xe7, 0x32, 0x37, 0x60, 0xcd, 0xa7, 0xd4,
+	0x6d, 0xdb, 0xba, 0xb2, 0xa3, 0xee, 0x56, 0xb0, 0xa4, 0x90, 0x0e, 0xeb, 0xa7, 0x8c, 0xab, 0x6d,
+	0xeb, 0xea, 0x8e, 0xba, 0x5b, 0xc4, 0x31, 0x89, 0x76, 0xe1, 0xca, 0x19, 0xf1, 0x48, 0x60, 0x46,
+	0x0e, 0xf5, 0x06, 0x91, 0x39, 0xf1, 0xf5, 0x02, 0xe7, 0x98, 0x87, 0xd1, 0xab, 0x50, 0xf6, 0xa6,
+	0x93, 0x83, 0x59, 0x44, 0x42, 0xbd, 0xb8, 0xa3, 0xec, 0x16, 0x1f, 0x2a, 0xef, 0xe1, 0x04, 0x32,
+	0xfe, 0xa9, 0xc0, 0x95, 0xa6, 0x19, 0x99, 0x1e, 0xb5, 0x49, 0xbb, 0x99, 0x4c, 0xc7, 0xf1, 0xeb,
+	0xb6, 0x1d, 0xc4, 0xd3, 0x11, 0x14, 0xda, 0x86, 0xf2, 0x98, 0x86, 0x51, 0xd7, 0x9c, 0x10, 0x3e,
+	0x9f, 0x0a, 0x4e, 0x68, 0x64, 0xc0, 0xa6, 0x2d, 0xd5, 0x9c, 0x4c, 0x1d, 0x9b, 0xcf, 0xa6, 0x82,
+	0x73, 0x18, 0x93, 0x7f, 0x3e, 0x22, 0x41, 0x9f, 0x06, 0x91, 0x5e, 0xdc, 0x51, 0x77, 0xab, 0x38,
+	0xa1, 0xd9, 0x98, 0xe3, 0x8d, 0x28, 0x1f, 0x2b, 0x89, 0xb1, 0x98, 0x66, 0x66, 0x70, 0x7c, 0x8b,
+	0x0f, 0xad, 0xf1, 0xa1, 0x98, 0x44, 0x6f, 0x43, 0x8d, 0x71, 0x71, 0x2b, 0x13, 0xce, 0xb0, 0xbe,
+	0xa3, 0xec, 0x56, 0xd9, 0x12, 0xe7, 0x06, 0x8c, 0x5f, 0x2a, 0x70, 0x23, 0x5e, 0x68, 0x87, 0x5a,
+	0xa6, 0xdb, 0x66, 0xea, 0xf9, 0x7a, 0x77, 0xe1, 0x4a, 0x48, 0x47, 0xd1, 0x33, 0x33, 0x20, 0x4f,
+	0x48, 0x10, 0x3a, 0xd4, 0x93, 0x0b, 0x9f, 0x87, 0xd1, 0x9b, 0x50, 0xb5, 0xa8, 0x37, 0x72, 0xce,
+	0x62, 0x3e, 0x61, 0x86, 0x3c, 0xc8, 0xec, 0x37, 0xf5, 0x23, 0x67, 0x42, 0xe4, 0x9e, 0x48, 0xca,
+	0xc0, 0x80, 0x12, 0x53, 0x7b, 0x23, 0x1a, 0x8a, 0xbf, 0xff, 0x18, 0x2a, 0xb1, 0x95, 0x42, 0x5d,
+	0xd9, 0x29, 0xec, 0x6e, 0xec, 0xdf, 0xd9, 0xcb, 0x38, 0xd1, 0x5e, 0x56, 0x86, 0x8b, 0xe0, 0x54,
+	0xc0, 0xf8, 0x53, 0x09, 0xb6, 0x16, 0x18, 0xd0, 0x3b, 0xa0, 0x3a, 0xc2, 0x99, 0x36, 0xf6, 0x6f,
+	0x2f, 0x57, 0x26, 0xf6, 0x1a, 0xab, 0x8e, 0xcd, 0x5c, 0xc4, 0x32, 0x7d, 0xd3, 0x72, 0xa2, 0x99,
+	0xae, 0x26, 0x2e, 0x12, 0x43, 0xe8, 0x15, 0x58, 0xb7, 0x47, 0xe1, 0x49, 0x48, 0xd8, 0xae, 0xca,
+	0xd1, 0x18, 0x41, 0xaf, 0x41, 0x25, 0x20, 0x13, 0xd3, 0xf1, 0x1c, 0xef, 0x2c, 0xf5, 0xaf, 0x14,
+	0x43, 0xf7, 0xa0, 0xca, 0x9d, 0xb6, 0x4f, 0xa9, 0xcb, 0x75, 0x94, 0x62, 0xa6, 0x3c, 0x8e, 0x5e,
+	0x07, 0x70, 0xcd, 0x30, 0x3a, 0xf1, 0x6d, 0x33, 0x22, 0xfa, 0x5a, 0xcc, 0x95, 0x01, 0xd1, 0x5d,
+	0xd8, 0x7c, 0x6e, 0x11, 0xe7, 0x82, 0x04, 0x0d, 0x3a, 0xf5, 0x32, 0x9b, 0x9d, 0x83, 0x99, 0x2f,
+	0xb9, 0xd4, 0xe2, 0x67, 0x40, 0x2f, 0xef, 0x28, 0xcc, 0x4f, 0x63, 0x1a, 0x7d, 0x06, 0x60, 0xda,
+	0x13, 0x87, 0x1d, 0x8e, 0x88, 0xe8, 0xb0, 0xa3, 0xec, 0xd6, 0xf6, 0xdf, 0x7e, 0xb1, 0xb9, 0xf7,
+	0xea, 0x89, 0xc0, 0xc3, 0xb5, 0x6e, 0x0f, 0x1f, 0xd5, 0x3b, 0x38, 0xa3, 0x84, 0xad, 0xd0, 0x32,
+	0xad, 0x31, 0x69, 0xc4, 0x36, 0xdc, 0x48, 0x56, 0x98, 0xc3, 0x99, 0xad, 0x38, 0xc0, 0xcd, 0xb0,
+	0x99, 0xd8, 0x2a, 0xc1, 0xd0, 0x03, 0xb8, 0x9a, 0xae, 0xf6, 0x88, 0x7a, 0x34, 0xa2, 0x9e, 0x63,
+	0xe9, 0xd5, 0x98, 0x75, 0xd9, 0x28, 0xf3, 0xc9, 0xa9, 0x7f, 0x16, 0x98, 0x36, 0x69, 0x52, 0x66,
+	0x74, 0xbd, 0xc6, 0x97, 0x9c, 0x07, 0x8d, 0x67, 0x00, 0xe9, 0x32, 0x10, 0x80, 0x5c, 0x88, 0xf6,
+	0x3d, 0xf4, 0x0a, 0xdc, 0x6c, 0xb6, 0x1a, 0xbd, 0xa3, 0xa3, 0xf6, 0x60, 0xd0, 0xee, 0x75, 0x87,
+	0xed, 0x6e, 0x1f, 0xf7, 0x3e, 0xc5, 0xad, 0xc1, 0x40, 0x53, 0x10, 0x82, 0x5a, 0x76, 0xb0, 0xd5,
+	0xd4, 0x54, 0xa4, 0xc3, 0xb5, 0x56, 0xf7, 0xb8, 0x85, 0xdb, 0xdd, 0x4f, 0x87, 0x47, 0xf5, 0x76,
+	0xf7, 0xb8, 0xd5, 0xad, 0x77, 0x1b, 0x2d, 0xad, 0xc0, 0xb8, 0xdb, 0xdd, 0x1c, 0x56, 0x34, 0xfe,
+	0xa3, 0xc0, 0xb5, 0xd8, 0xa4, 0x83, 0x88, 0x06, 0xe6, 0x19, 0x11, 0x3e, 0xba, 0x03, 0x1b, 0xa1,
+	0xa0, 0x4f, 0xa6, 0xd2, 0x59, 0x2b, 0x38, 0x0b, 0xa1, 0x0e, 0x94, 0x42, 0xbe, 0x4d, 0x2a, 0xdf,
+	0xa6, 0xbd, 0xa5, 0xdb, 0x94, 0xd5, 0xb9, 0x27, 0x89, 0xfc, 0x5e, 0x09, 0x25, 0xa8, 0x95, 0xfc,
+	0xef, 0x78, 0xe6, 0x13, 0xee, 0xca, 0xb5, 0xfd, 0x57, 0x73, 0x3a, 0x07, 0xe9, 0x38, 0xd7, 0xf7,
+	0xb0, 0xd8, 0x6c, 0x0f, 0x1e, 0xe3, 0xac, 0x9c, 0xf1, 0x1e, 0x6c, 0x66, 0xff, 0x92, 0x33, 0xe5,
+	0x35, 0xd0, 0x70, 0xab, 0xde, 0x1c, 0xf6, 0xba, 0x9d, 0xa7, 0xc3, 0xc1, 0xa3, 0x3a, 0x6e, 0x35,
+	0x35, 0xc5, 0xf8, 0xa3, 0x0a, 0x48, 0x8a, 0x60, 0xe2, 0xd3, 0x20, 0x12, 0xeb, 0x7f, 0x73, 0xc9,
+	0xfa, 0x0f, 0x54, 0x5d, 0xc9, 0xdb, 0xe0, 0x55, 0x58, 0x1b, 0x99, 0x8e, 0x4b, 0x6c, 0x6e, 0x84,
+	0xf2, 0xc3, 0xd2, 0xc8, 0x74, 0x43, 0x82, 0x25, 0x98, 0x3b, 0xba, 0x85, 0x17, 0x1e, 0xdd, 0xe2,
+	0x8b, 0x8f, 0x6e, 0xe9, 0x65, 0x8e, 0xee, 0xda, 0x8a, 0xa3, 0xfb, 0x11, 0xac, 0xcb, 0x39, 0xf3,
+	0x23, 0xb9, 0xb1, 0xff, 0xfa, 0xa5, 0x5b, 0x85, 0x63, 0x09, 0xe3, 0x2b, 0x15, 0xae, 0x36, 0xa8,
+	0x17, 0x11, 0x2f, 0x1a, 0x4c, 0x27, 0x13, 0x33, 0x98, 0x25, 0x59, 0xc8, 0x25, 0xde, 0x59, 0x34,
+	0xe6, 0xa6, 0x29, 0x62, 0x49, 0xa1, 0xdb, 0x50, 0x19, 0x39, 0x2e, 0x11, 0x11, 0x40, 0xa4, 0xc5,
+	0x14, 0x40, 0x6f, 0x41, 0xcd, 0x76, 0x02, 0x62, 0x45, 0x34, 0x98, 0x09, 0x16, 0x11, 0x83, 0xe7,
+	0x50, 0x74, 0x0d, 0x4a, 0xbf, 0x98, 0xd2, 0xc8, 0xe4, 0x89, 0xa8, 0x88, 0x05, 0xc1, 0xce, 0x52,
+	0xe8, 0x9b, 0x16, 0x69, 0x50, 0x2f, 0x9c, 0x4e, 0x78, 0xb0, 0x62, 0xa3, 0x79, 0x10, 0xdd, 0x01,
+	0xe0, 0xc0, 0x67, 0x5c, 0xc1, 0x1a, 0x67, 0xc9, 0x20, 0xa8, 0x07, 0xb5, 0x68, 0xe6, 0x0b, 0x82,
+	0x07, 0x7a, 0x69, 0x95, 0x7b, 0xab, 0x9c, 0x2d, 0xe5, 0x14, 0xb6, 0x99, 0x13, 0x37, 0xfe, 0xad,
+	0xc0, 0x15, 0x4e, 0x9e, 0x84, 0xc9, 0xf1, 0xf9, 0x01, 0x5c, 0x67, 0xab, 0xae, 0x7b, 0x76, 0x33,
+	0xbf, 0x5e, 0x61, 0xad, 0xe5, 0x83, 0xe9, 0xb2, 0xd5, 0x17, 0x2e, 0xbb, 0x70, 0xf9, 0xb2, 0x8b,
+	0x2f, 0xb1, 0xec, 0xd2, 0x77, 0x5b, 0xf6, 0xcf, 0x61, 0x7b, 0x35, 0x37, 0xea, 0x40, 0x35, 0xc7,
+	0x2f, 0x73, 0xe7, 0x5b, 0x97, 0xfe, 0x4d, 0xfc, 0x2c, 0x2f, 0xcc, 0xca, 0x83, 0x5b, 0x2b, 0x99,
+	0xd1, 0xfb, 0x50, 0x64, 0xec, 0xdc, 0xb6, 0x97, 0x05, 0x0d, 0xcc, 0x59, 0x57, 0x58, 0x7a, 0x1b,
+	0xca, 0x56, 0xde, 0xc8, 0x09, 0x6d, 0x1c, 0xc2, 0x8d, 0x06, 0x0d, 0x82, 0xa9, 0x1f, 0x1d, 0x3a,
+	0x2e, 0xe1, 0xe5, 0xa1, 0x5c, 0xea, 0x35, 0x28, 0xb1, 0xed, 0x14, 0xe5, 0x41, 0x05, 0x0b, 0x82,
+	0x1d, 0x10, 0x8b, 0xd2, 0x73, 0x27, 0x2e, 0xc6, 0x24, 0x65, 0xdc, 0x83, 0xad, 0xc3, 0xb0, 0x4f,
+	0x82, 0x89, 0x13, 0xb2, 0x72, 0x44, 0xa8, 0x40, 0x50, 0xf4, 0x49, 0x30, 0xe1, 0x2b, 0xa8, 0x62,
+	0xfe, 0x6d, 0x3c, 0x81, 0xad, 0xcc, 0xe4, 0xe5, 0xbf, 0xea, 0xb0, 0x99, 0x09, 0x77, 0xe2, 0x97,
+	0x97, 0x2e, 0x39, 0x27, 0x62, 0x7c, 0xa3, 0xc2, 0x4d, 0x3e, 0xfd, 0xf8, 0xc0, 0x53, 0xd7, 0xb1,
+	0xe4, 0xa9, 0xde, 0x86, 0xb2, 0xcf, 0x49, 0x59, 0xec, 0x56, 0x71, 0x42, 0xb3, 0x39, 0x7a, 0x69,
+	0x6d, 0xc9, 0xbf, 0xd1, 0x21, 0xd4, 0xac, 0x80, 0xf0, 0xdc, 0x2d, 0xd4, 0x70, 0xb3, 0xcd, 0x97,
+	0x48, 0x0b, 0xcb, 0xc0, 0x73, 0x52, 0xe8, 0x09, 0xdc, 0x88, 0x91, 0x43, 0xd3, 0x75, 0x4f, 0x4d,
+	0xeb, 0x5c, 0x8c, 0xf0, 0xc0, 0x78, 0xb9, 0xbe, 0x15, 0xd2, 0xe8, 0xa7, 0x70, 0x2b, 0x20, 0xbe,
+	0xeb, 0x58, 0xcb, 0x54, 0x97, 0x5e, 0x4a, 0xf5, 0x6a, 0x05, 0xc6, 0x37, 0x05, 0xd8, 0x62, 0xc5,
+	0x6a, 0x94, 0xbb, 0x2e, 0xbc, 0x0b, 0xca, 0xa9, 0x2c, 0xee, 0x5e, 0xcb, 0xe9, 0x5e, 0xbc, 0x5a,
+	0x60, 0xe5, 0x94, 0xf9, 0x09, 0x1d, 0x8d, 0x42, 0x12, 0x47, 0x4b, 0x49, 0xa1, 0x7d, 0x28, 0xba,
+	0xd4, 0x0a, 0xf5, 0xc2, 0x4b, 0xd5, 0x9c, 0x9c, 0x97, 0x95, 0xe2, 0x96, 0xf0, 0x51, 0x1e, 0x00,
+	0xca, 0x38, 0x26, 0xd1, 0x8f, 0x00, 0x78, 0x52, 0x38, 0xa6, 0xe7, 0xc4, 0xe3, 0x71, 0x73, 0x63,
+	0xff, 0x56, 0xac, 0xd3, 0xa2, 0x93, 0x09, 0xf5, 0xf6, 0xf8, 0x98, 0x50, 0x97, 0x61, 0x46, 0x77,
+	0xa0, 0xec, 0x84, 0x0d, 0x56, 0x05, 0xb1, 0x14, 0x53, 0xd8, 0x2d, 0x1f, 0xa8, 0x9a, 0x82, 0x13,
+	0x6c, 0xc1, 0x25, 0xd7, 0xff, 0x67, 0x97, 0xe4, 0xb1, 0x4b, 0xd0, 0xed, 0x66, 0xa8, 0x97, 0xf9,
+	0x31, 0xca, 0x20, 0xec, 0xfa, 0x22, 0xae, 0x56, 0x9e, 0xed, 0x58, 0x24, 0xd4, 0x2b, 0x3b, 0xca,
+	0xee, 0x26, 0xce, 0x61, 0xe8, 0x23, 0xd8, 0x48, 0x27, 0x1d, 0xea, 0xb0, 0x53, 0x78, 0xf1, 0x12,
+	0xb3, 0xdc, 0xc6, 0x3f, 0xe4, 0xf5, 0xa3, 0xe5, 0x59, 0xc1, 0xcc, 0x67, 0x5b, 0xfd, 0x98, 0xcc,
+	0x92, 0xd3, 0x7d, 0x4e, 0xd2, 0xf3, 0x20, 0x08, 0x56, 0x1e, 0x25, 0x49, 0x56, 0xde, 0xff, 0x2a,
+	0x38, 0x0b, 0x31, 0x39, 0x8f, 0x7a, 0x96, 0xb8, 0x65, 0x6c, 0x62, 0x41, 0xb0, 0x58, 0x4e, 0xb2,
+	0xff, 0xe0, 0xfb, 0xb4, 0x89, 0xf3, 0x20, 0xb3, 0x07, 0x79, 0xee, 0x3b, 0xc1, 0xac, 0xc9, 0xea,
+	0x2b, 0x91, 0xe5, 0x32, 0x08, 0x7a, 0x0f, 0xae, 0xa6, 0x02, 0x75, 0xf7, 0x8c, 0x06, 0x4e, 0x34,
+	0x9e, 0xf0, 0x02, 0xa0, 0x82, 0x97, 0x0d, 0x19, 0xbf, 0x57, 0xe1, 0x26, 0x8b, 0x5b, 0xe9, 0x02,
+	0xd3, 0xf0, 0xf9, 0x00, 0x4a, 0xe1, 0xd4, 0x89, 0x96, 0xc7, 0xcf, 0x86, 0xe3, 0x8f, 0x49, 0x30,
+	0x60, 0xe3, 0xc2, 0x6e, 0x82, 0x17, 0xfd, 0x0c, 0xae, 0x73, 0x4d, 0x42, 0x87, 0x45, 0xdd, 0xec,
+	0x9d, 0xab, 0x36, 0x97, 0x55, 0x1a, 0xcb, 0x38, 0x85, 0xba, 0xe5, 0x5a, 0x90, 0x06, 0x85, 0x73,
+	0x32, 0x93, 0xb6, 0x63, 0x9f, 0xa8, 0x06, 0xaa, 0x73, 0x21, 0xcd, 0xa5, 0x3a, 0x17, 0xcc, 0xd7,
+	0xcf, 0xc9, 0x8c, 0xdf, 0x76, 0x4b, 0xdc, 0xfa, 0x31, 0x89, 0xee, 0x83, 0x46, 0xbe, 0x7c, 0x4c,
+	0x66, 0x52, 0x17, 0x67, 0x59, 0xe3, 0x2c, 0x0b, 0x38, 0x4b, 0x62, 0x7d, 0x12, 0xac, 0xb2, 0x8c,
+	0x9c, 0x85, 0x32, 0x3f, 0x0b, 0x35, 0x99, 0xc5, 0xb2, 0x7f, 0x15, 0x56, 0xfc, 0xeb, 0x1b, 0x05,
+	0x6e, 0x7e, 0x41, 0xbd, 0xff, 0x9b, 0x3d, 0xc8, 0x58, 0xb8, 0x90, 0xb3, 0xb0, 0xf1, 0x95, 0x02,
+	0x5b, 0x62, 0x52, 0x3d, 0xbe, 0x8e, 0xef, 0xb0, 0x86, 0x6b, 0x50, 0x72, 0xf8, 0x41, 0x50, 0xf9,
+	0x99, 0x16, 0x04, 0xcb, 0x35, 0x8e, 0xd7, 0xbe, 0xe0, 0x45, 0xf3, 0x26, 0xe6, 0xdf, 0x3c, 0x50,
+	0x4e, 0x23, 0x71, 0x66, 0x18, 0x2a, 0x29, 0xa6, 0x81, 0x4e, 0xa3, 0xf6, 0x05, 0x8f, 0xe7, 0x9b,
+	0x58, 0x10, 0xc6, 0xd7, 0x05, 0x40, 0xd9, 0xd8, 0x2c, 0xf3, 0xe7, 0x1d, 0x00, 0x96, 0x9e, 0x3b,
+	0xd9, 0xd2, 0x35, 0x83, 0xa0, 0x0f, 0x60, 0x8d, 0x1f, 0xe2, 0x50, 0x57, 0x97, 0xc4, 0xdd, 0x85,
+	0x60, 0x8f, 0x25, 0x37, 0x7a, 0x07, 0xb6, 0xa6, 0x9e, 0xcd, 0xae, 0xb8, 0x5e, 0x18, 0x05, 0x53,
+	0x8b, 0xdf, 0x6e, 0x0b, 0x3c, 0x06, 0x2f, 0x0e, 0xb0, 0xa6, 0x02, 0xbb, 0x2b, 0x72, 0x3d, 0x4b,
+	0x33, 0xdc, 0xe2, 0x8f, 0x52, 0x01, 0x76, 0xfa, 0x9d, 0xb0, 0x13, 0x93, 0x0d, 0x3a, 0xf1, 0x5d,
+	0x22, 0xc3, 0x44, 0x19, 0x2f, 0x1b, 0x42, 0xc7, 0x80, 0x46, 0x0b, 0x2e, 0xce, 0xc3, 0xc5, 0xc6,
+	0xfe, 0x9b, 0xb9, 0x1f, 0xaf, 0x38, 0x09, 0x78, 0x89, 0x3c, 0xaa, 0x43, 0x99, 0x58, 0x32, 0x97,
+	0x8a, 0x12, 0xfa, 0x6e, 0x3e, 0xdf, 0x05, 0x66, 0x38, 0x0d, 0x48, 0x83, 0xda, 0x8e, 0x77, 0x96,
+	0xa9, 0x32, 0x70, 0x22, 0x66, 0x1c, 0x80, 0xde, 0x6a, 0x0c, 0xac, 0x31, 0x99, 0x98, 0xc2, 0x93,
+	0x5a, 0x5e, 0x14, 0xcc, 0x16, 0x0e, 0x5f, 0x45, 0x1c, 0xbe, 0x6b, 0x50, 0xba, 0x30, 0xdd, 0x69,
+	0x5c, 0x82, 0x08, 0xc2, 0xf8, 0x8b, 0x02, 0xd5, 0x58, 0x89, 0x90, 0xbc, 0x0d, 0x15, 0x8b, 0xda,
+	0xc4, 0xe2, 0xae, 0x2b, 0xe4, 0x53, 0x80, 0x8d, 0xb2, 0x06, 0xcd, 0x89, 0xe7, 0x44, 0x21, 0xd7,
+	0x54, 0xc5, 0x29, 0xc0, 0x02, 0xbb, 0x6f, 0xb2, 0x9e, 0xa0, 0x18, 0x2f, 0xf0, 0xf1, 0x2c, 0x84,
+	0x7e, 0x02, 0xeb, 0x94, 0xcf, 0x95, 0x75, 0xec, 0x0a, 0x8b, 0xab, 0x5e, 0xb1, 0x1e, 0x1c, 0x4b,
+	0x19, 0x7f, 0x55, 0x40, 0x5f, 0x65, 0x9b, 0xa4, 0xca, 0x52, 0x78, 0x2c, 0xe7, 0xdf, 0x68, 0x1f,
+	0xd6, 0x42, 0xae, 0x93, 0x1f, 0x92, 0x8d, 0xfd, 0xed, 0xa5, 0x3f, 0x94, 0x0e, 0x29, 0x38, 0x79,
+	0x29, 0x4b, 0x5c, 0x77, 0xe0, 0x7c, 0x29, 0x2e, 0xd3, 0x55, 0x9c, 0xd0, 0x3c, 0x88, 0xd9, 0xb2,
+	0xc7, 0xc7, 0x3a, 0x4c, 0xcd, 0xf8, 0x26, 0x5f, 0xe2, 0xb7, 0xee, 0x4b, 0x77, 0x51, 0x5c, 0xe0,
+	0xd7, 0x5b, 0xdd, 0xfa, 0x41, 0xa7, 0xd5, 0x94, 0x37, 0x78, 0xe3, 0x6b, 0x05, 0x6e, 0x2f, 0x61,
+	0x4e, 0x63, 0xdc, 0xc7, 0xac, 0x8f, 0xca, 0xbd, 0x45, 0x54, 0x47, 0x2f, 0xe9, 0x2d, 0x52, 0x28,
+	0x9d, 0xa5, 0x88, 0x6e, 0xdf, 0x72, 0x96, 0x7f, 0x5e, 0x83, 0xab, 0x8f, 0xec, 0x51, 0xc8, 0x1c,
+	0x9d, 0x71, 0x4c, 0x65, 0x60, 0x68, 0x41, 0x99, 0xb9, 0xf8, 0x71, 0x7a, 0x8f, 0xc8, 0xf7, 0x9d,
+	0x96, 0xc8, 0xec, 0x1d, 0x4a, 0x01, 0x9c, 0x88, 0xf2, 0x42, 0xde, 0x8c, 0xc6, 0x32, 0x43, 0xf0,
+	0xef, 0xcc, 0x55, 0xb9, 0x90, 0xbb, 0x2a, 0x7f, 0x02, 0xe0, 0x27, 0xf7, 0x00, 0xbe, 0x1d, 0xf3,
+	0x61, 0x60, 0xe1, 0xa2, 0x80, 0x33, 0x12, 0x3c, 0xf0, 0x3d, 0xf3, 0x48, 0x20, 0xf3, 0x9f, 0x20,
+	0x18, 0x7a, 0x16, 0xd0, 0xa9, 0x2f, 0x53, 0x9e, 0x20, 0xd0, 0xf7, 0x61, 0x6b, 0x42, 0x6d, 0x67,
+	0x24, 0x0b, 0xd9, 0x21, 0xef, 0x7f, 0xae, 0xf3, 0xe9, 0x68, 0xd9, 0x81, 0x63, 0x67, 0x42, 0xd0,
+	0x6b, 0xb0, 0x61, 0x5a, 0x16, 0x09, 0x43, 0xc1, 0x56, 0xe6, 0x6c, 0x20, 0x20, 0xce, 0xa0, 0xc3,
+	0x7a, 0x38, 0x9b, 0xb8, 0x8e, 0x77, 0x2e, 0x4b, 0xb1, 0x98, 0x44, 0x7b, 0xb0, 0xc5, 0x23, 0xe2,
+	0x30, 0x53, 0x35, 0xeb, 0x10, 0x37, 0x02, 0x35, 0x3e, 0x86, 0xd3, 0x21, 0xd6, 0xe5, 0xe0, 0x58,
+	0xc8, 0xfc, 0x34, 0xe9, 0xcc, 0xa5, 0x18, 0xfa, 0x18, 0x2a, 0x71, 0x77, 0x30, 0xe4, 0x5d, 0xb9,
+	0xf9, 0xaa, 0x7a, 0x31, 0xc8, 0xe3, 0x54, 0x02, 0xdd, 0x82, 0x35, 0xb6, 0x37, 0x6d, 0x3b, 0x6d,
+	0xd3, 0x49, 0x80, 0x75, 0x78, 0xac, 0xb1, 0xe3, 0xda, 0x01, 0xf1, 0xba, 0xd3, 0x09, 0xef, 0xcb,
+	0x95, 0x1e, 0xaa, 0xef, 0xbe, 0x8f, 0xb3, 0xf0, 0x8a, 0xd0, 0x79, 0xe5, 0x3b, 0x86, 0xce, 0x7b,
+	0x50, 0x0d, 0xb3, 0xb7, 0x2f, 0x5d, 0x8b, 0x4d, 0x94, 0xc7, 0x73, 0x31, 0x76, 0xeb, 0xdb, 0xc5,
+	0xd8, 0x07, 0x50, 0x8e, 0x1d, 0x95, 0xb5, 0xc3, 0xda, 0x83, 0x61, 0xb3, 0x8d, 0x35, 0x05, 0x6d,
+	0xc0, 0x7a, 0x7b, 0x30, 0x3c, 0x6c, 0x77, 0x5a, 0x9a, 0x8a, 0x6a, 0x00, 0xed, 0xc1, 0x70, 0xf0,
+	0xf4, 0xa8, 0xd3, 0xee, 0x3e, 0xd6, 0x0a, 0xc6, 0x1f, 0x14, 0xb8, 0x25, 0x72, 0xc8, 0x98, 0x58,
+	0xe7, 0xe1, 0x74, 0x22, 0xc2, 0x99, 0x3c, 0x2c, 0x4f, 0xe5, 0x2e, 0xc7, 0x83, 0xf2, 0xd4, 0xb0,
+	0xe0, 0xf1, 0x46, 0x6e, 0x7a, 0x07, 0xf3, 0x5c, 0xa2, 0x71, 0xb7, 0x76, 0xd4, 0xfc, 0x61, 0x03,
+	0x37, 0xf0, 0xa2, 0x16, 0x56, 0xea, 0x87, 0x51, 0xe0, 0xf8, 0x71, 0x8a, 0xe6, 0x1d, 0x6f, 0x9c,
+	0xc3, 0x8c, 0x5f, 0x17, 0xe0, 0xfa, 0x61, 0x38, 0x20, 0xc1, 0x05, 0x09, 0x9a, 0x64, 0x64, 0x4e,
+	0xdd, 0x28, 0x4c, 0x22, 0x3f, 0x57, 0xc9, 0xc3, 0x9e, 0xc8, 0xee, 0x29, 0xc0, 0x8a, 0xb5, 0x53,
+	0xf6, 0xac, 0xd2, 0x27, 0x41, 0xfc, 0x4f, 0x99, 0x00, 0x16, 0x70, 0xf6, 0xea, 0xf0, 0x2c, 0x60,
+	0xc5, 0x8a, 0x69, 0x9d, 0x93, 0x48, 0x86, 0x51, 0xc6, 0x3a, 0x0f, 0xb3, 0x8c, 0x91, 0x75, 0x76,
+	0x11, 0x56, 0xb3, 0x10, 0xeb, 0x7a, 0x31, 0x1f, 0x38, 0x98, 0x8e, 0x46, 0x24, 0xe0, 0xaa, 0xc4,
+	0x1b, 0xca, 0x1c, 0x8a, 0x3e, 0x4c, 0xca, 0x7a, 0x76, 0x17, 0x39, 0x0e, 0x4c, 0x2f, 0x1c, 0x91,
+	0x40, 0x5f, 0xcb, 0xb6, 0x16, 0x97, 0x71, 0x30, 0x77, 0x8a, 0x02, 0x33, 0x1c, 0xb7, 0xbd, 0x88,
+	0x04, 0x17, 0xa6, 0xab, 0xaf, 0xc7, 0xce, 0x9e, 0xc7, 0x11, 0x86, 0x4d, 0x2b, 0xbb, 0x67, 0x65,
+	0xbe, 0x67, 0xf9, 0xa0, 0xb3, 0xb8, 0x5d, 0xb5, 0xc6, 0xa3, 0x56, 0xe3, 0xf1, 0xe0, 0xe4, 0x68,
+	0xd8, 0xc0, 0x8d, 0x07, 0xfb, 0x38, 0xa7, 0xc3, 0xf8, 0x8d, 0x02, 0xd7, 0x93, 0x3e, 0x56, 0xc7,
+	0x09, 0x23, 0xe6, 0x89, 0x7c, 0x37, 0x1e, 0x41, 0xcd, 0x37, 0x83, 0xc8, 0x31, 0x5d, 0x09, 0xcb,
+	0x26, 0xd0, 0xce, 0x65, 0x91, 0x15, 0xcf, 0xc9, 0xb1, 0x9d, 0x4b, 0x1a, 0x9f, 0x2c, 0xa5, 0x3a,
+	0x24, 0x4e, 0xdd, 0x0b, 0xb8, 0xf1, 0x77, 0x05, 0x5e, 0x1f, 0x78, 0xa6, 0x1f, 0x8e, 0x69, 0x14,
+	0x99, 0xa7, 0x2e, 0x49, 0x26, 0x97, 0x8d, 0xf7, 0x9f, 0x40, 0xc5, 0x76, 0x02, 0x81, 0xc8, 0x7c,
+	0x74, 0xf9, 0xb4, 0x52, 0x11, 0x74, 0x17, 0x6a, 0xa1, 0xfc, 0xc9, 0x30, 0xed, 0x24, 0x55, 0x71,
+	0x35, 0x46, 0x45, 0xd7, 0xed, 0x1e, 0x5c, 0x49, 0xd8, 0xbc, 0xe9, 0xe4, 0x94, 0x04, 0xd2, 0x8d,
+	0x12, 0xe9, 0x2e, 0x47, 0x19, 0xa3, 0x6f, 0x06, 0xc4, 0x8b, 0x86, 0xa3, 0xa9, 0xeb, 0xf2, 0x1c,
+	0x22, 0xee, 0x3a, 0x35, 0x01, 0x1f, 0x4a, 0xd4, 0xf8, 0xad, 0x02, 0xc6, 0xf2, 0xe5, 0xe5, 0x6c,
+	0x3f, 0x86, 0x9b, 0xe1, 0x1c, 0x57, 0x7e, 0x13, 0xf2, 0xfd, 0xfa, 0x4b, 0x0d, 0x86, 0x57, 0xa9,
+	0x33, 0x7e, 0xa5, 0xc0, 0xed, 0x58, 0xbc, 0xe9, 0x8c, 0x46, 0xa2, 0x8b, 0x9e, 0x29, 0xe4, 0xb6,
+	0xa1, 0x9c, 0xac, 0x49, 0x5c, 0xa5, 0x12, 0x9a, 0xd5, 0xcd, 0xd9, 0xf4, 0xd3, 0x31, 0x4f, 0x89,
+	0x2b, 0xcb, 0xbb, 0xc5, 0x01, 0x56, 0xbd, 0x47, 0x66, 0x70, 0x46, 0xa2, 0xbe, 0xc9, 0xb3, 0x29,
+	0x4b, 0x3d, 0x19, 0xc4, 0xf8, 0x9b, 0x02, 0x37, 0x17, 0xa7, 0x22, 0x66, 0xc1, 0x02, 0x8b, 0x1c,
+	0xc2, 0x94, 0x46, 0xb2, 0x2e, 0xcc, 0x61, 0x8c, 0x67, 0x14, 0xd0, 0x49, 0xac, 0x42, 0x4e, 0x24,
+	0x87, 0xf1, 0x39, 0xd0, 0x84, 0x43, 0x5c, 0x8c, 0x32, 0x08, 0xfa, 0x1c, 0xb6, 0xec, 0x9c, 0x15,
+	0x1c, 0x12, 0x17, 0x8a, 0x6f, 0x2f, 0x35, 0xf9, 0x32, 0x9b, 0xe1, 0x45, 0x1d, 0x86, 0x09, 0x90,
+	0xe9, 0x32, 0x65, 0x1e, 0x9f, 0x95, 0xfc, 0xe3, 0xf3, 0x36, 0x94, 0xcf, 0x88, 0x7c, 0x75, 0x16,
+	0x2d, 0xa5, 0x84, 0xce, 0x3d, 0x37, 0x17, 0x16, 0x9f, 0x9b, 0xff, 0xa5, 0xc0, 0x56, 0x3c, 0xad,
+	0xb4, 0x6e, 0xcb, 0x58, 0x2e, 0x53, 0x51, 0xe7, 0xb0, 0x05, 0xeb, 0xaa, 0x4b, 0xac, 0x9b, 0xaf,
+	0x77, 0x0a, 0xdf, 0xbe, 0xde, 0x29, 0x2e, 0xad, 0x77, 0x4a, 0xd9, 0x7a, 0xe7, 0x0e, 0x00, 0x6f,
+	0x09, 0x12, 0x56, 0xaf, 0xc8, 0x52, 0x28, 0x83, 0x18, 0xa7, 0x70, 0x0b, 0x53, 0xd7, 0x75, 0xbc,
+	0xb3, 0x13, 0xf1, 0x10, 0x97, 0x8d, 0x0d, 0x73, 0xcd, 0x1d, 0x65, 0xb1, 0xb9, 0xf3, 0x06, 0x7b,
+	0xe5, 0xf0, 0x4c, 0xd7, 0xf9, 0x72, 0xfe, 0xe9, 0x27, 0xc5, 0x8d, 0x0f, 0x93, 0x06, 0x2e, 0x7b,
+	0x2b, 0x0a, 0x53, 0x63, 0x66, 0x40, 0xd9, 0x33, 0xce, 0x61, 0xf7, 0xbb, 0xa0, 0xcd, 0x37, 0xcc,
+	0x50, 0x19, 0xf8, 0x6b, 0x97, 0xa6, 0xa0, 0x75, 0x28, 0x0c, 0x06, 0xec, 0xa5, 0x6f, 0x03, 0xd6,
+	0xeb, 0xb8, 0xf1, 0xa8, 0xfd, 0x84, 0x3d, 0xee, 0x6d, 0x42, 0x19, 0xd7, 0x8f, 0x86, 0x9c, 0xa7,
+	0xc8, 0xa8, 0x3e, 0xee, 0x3d, 0x69, 0x37, 0x5b, 0x4d, 0xad, 0x74, 0xff, 0x03, 0xd0, 0xe6, 0xaf,
+	0xdf, 0x4c, 0xf8, 0xa4, 0xfb, 0xb8, 0xdb, 0xfb, 0xbc, 0xab, 0x29, 0xe8, 0x3a, 0x6c, 0xd5, 0x5b,
+	0x83, 0x61, 0xe3, 0x18, 0x0f, 0xbb, 0xbd, 0x7e, 0xbd, 0xd9, 0x6c, 0x77, 0x3f, 0xd5, 0xd4, 0xfb,
+	0x7d, 0xd8, 0x5e, 0xdd, 0x35, 0x40, 0xb7, 0x41, 0x97, 0x1a, 0x86, 0x7d, 0xdc, 0x3b, 0xee, 0x35,
+	0x7a, 0x9d, 0xe1, 0x93, 0x16, 0x66, 0x0f, 0x91, 0x9a, 0xc2, 0x1e, 0xdb, 0x5a, 0xdd, 0x06, 0x7e,
+	0xda, 0x3f, 0x66, 0xaf, 0x96, 0x5f, 0xf4, 0xba, 0xad, 0x81, 0xa6, 0xde, 0x3f, 0x58, 0x7a, 0xf3,
+	0x11, 0x4f, 0x75, 0x9b, 0x50, 0x6e, 0xb6, 0x07, 0xbc, 0x58, 0x17, 0xd5, 0x89, 0xac, 0xdc, 0xc5,
+	0x4a, 0x71, 0xeb, 0xa8, 0xf7, 0xa4, 0xd5, 0xd4, 0x0a, 0xf7, 0x7b, 0xb0, 0xb5, 0x90, 0xa2, 0xd0,
+	0x16, 0x54, 0x93, 0x24, 0xd5, 0x3d, 0xe9, 0xb0, 0xe7, 0x3e, 0x04, 0x73, 0x79, 0x4b, 0x53, 0xd0,
+	0x55, 0xb8, 0x92, 0xc7, 0x1a, 0x9a, 0x7a, 0xff, 0x43, 0xb8, 0xb1, 0xbc, 0x4e, 0x61, 0xe5, 0x92,
+	0xa8, 0x54, 0x34, 0x85, 0xff, 0xa1, 0x77, 0xd4, 0xef, 0x0d, 0xda, 0xc7, 0x2d, 0x26, 0xab, 0xa9,
+	0x07, 0x1f, 0xc0, 0x5d, 0x1a, 0x9c, 0xed, 0xb1, 0xe7, 0xbc, 0x31, 0xc9, 0x39, 0xb2, 0x2f, 0x0d,
+	0x26, 0x3e, 0x0e, 0x80, 0x25, 0x13, 0xae, 0x32, 0xfc, 0x4a, 0x51, 0xfe, 0x3b, 0x00, 0x67, 0xcf,
+	0x92, 0x7a, 0x91, 0x22, 0x00, 0x00,
 }
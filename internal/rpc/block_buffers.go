@@ -0,0 +1,135 @@
+package rpc
+
+import (
+	"bufio"
+	"bytes"
+	"hash/crc32"
+	"io"
+	"sync"
+)
+
+// BlockBuffers holds buffers that can be carried over from one block to the
+// next, by a FileReader or FileWriter working through a file's blocks in
+// order. Setting the same BlockBuffers on each BlockReader (or BlockWriter)
+// saves allocating the buffers and stream state again for every block. They're
+// handed to one reader or writer at a time, and given back when it's closed,
+// so sharing a BlockBuffers between readers or writers that are open at the
+// same time just means some of them allocate their own. A nil *BlockBuffers is
+// valid, in which case read streams come from (and go back to) a pool shared
+// by the whole process, and nothing else is reused.
+type BlockBuffers struct {
+	lock       sync.Mutex
+	readStream *blockReadStream
+	ackReader  *bufio.Reader
+	writeBuf   []byte
+}
+
+// readStreamPool holds read streams given back by readers that are done with
+// them, for the next reader that doesn't have one of its own to reuse.
+var readStreamPool sync.Pool
+
+// getReadStream returns a stream for reading packets from r, reusing the last
+// one returned with putReadStream, if there is one, or else one from the
+// shared pool.
+func (b *BlockBuffers) getReadStream(r io.Reader, chunkSize int, checksumTab *crc32.Table) *blockReadStream {
+	var s *blockReadStream
+	if b != nil {
+		b.lock.Lock()
+		s = b.readStream
+		b.readStream = nil
+		b.lock.Unlock()
+	}
+
+	if s == nil {
+		pooled, ok := readStreamPool.Get().(*blockReadStream)
+		if !ok {
+			return newBlockReadStream(r, chunkSize, checksumTab)
+		}
+
+		s = pooled
+	}
+
+	s.reset(r, chunkSize, checksumTab)
+	return s
+}
+
+// putReadStream gives back a stream that's no longer in use.
+func (b *BlockBuffers) putReadStream(s *blockReadStream) {
+	if s == nil {
+		return
+	}
+
+	s.reader = nil
+	if b == nil {
+		readStreamPool.Put(s)
+		return
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.readStream != nil {
+		readStreamPool.Put(b.readStream)
+	}
+
+	b.readStream = s
+}
+
+// Release gives the buffers held for reading to the shared pool, for other
+// readers to use. It should be called once there are no more blocks to read,
+// such as when the file is closed; the BlockBuffers can still be used
+// afterwards.
+func (b *BlockBuffers) Release() {
+	if b == nil {
+		return
+	}
+
+	b.lock.Lock()
+	s := b.readStream
+	b.readStream = nil
+	b.lock.Unlock()
+
+	if s != nil {
+		readStreamPool.Put(s)
+	}
+}
+
+// getWriteBuffers returns a reader for acks from conn, and the storage for a
+// write stream's buffer, if there's one to reuse.
+func (b *BlockBuffers) getWriteBuffers(conn io.Reader) (*bufio.Reader, []byte) {
+	if b == nil {
+		return bufio.NewReader(conn), nil
+	}
+
+	b.lock.Lock()
+	ackReader, buf := b.ackReader, b.writeBuf
+	b.ackReader, b.writeBuf = nil, nil
+	b.lock.Unlock()
+
+	if ackReader == nil {
+		return bufio.NewReader(conn), buf
+	}
+
+	ackReader.Reset(conn)
+	return ackReader, buf
+}
+
+// putWriteStream takes back the buffers from a finished write stream, which
+// mustn't use them afterwards.
+func (b *BlockBuffers) putWriteStream(s *blockWriteStream) {
+	if b == nil {
+		return
+	}
+
+	s.buf.Reset()
+	ackReader, buf := s.ackReader, s.buf.Bytes()
+	s.ackReader = nil
+	s.buf = bytes.Buffer{}
+	ackReader.Reset(nil)
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.ackReader = ackReader
+	b.writeBuf = buf
+}
@@ -0,0 +1,104 @@
+package rpc
+
+import (
+	"bufio"
+	"bytes"
+	"hash/crc32"
+	"io"
+	"net"
+	"testing"
+
+	hdfs "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_hdfs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readAllStream(t *testing.T, s *blockReadStream) string {
+	var out []byte
+	b := make([]byte, 100)
+	for {
+		n, err := s.Read(b)
+		out = append(out, b[:n]...)
+		if err == io.EOF {
+			return string(out)
+		}
+
+		require.NoError(t, err)
+	}
+}
+
+func TestBlockBuffersReuseReadStream(t *testing.T) {
+	var buffers BlockBuffers
+
+	var buf bytes.Buffer
+	writeTestPacket(&buf, 0, 0, []byte("foo"), 0)
+	writeTestPacket(&buf, 1, 3, nil, 0)
+
+	s := buffers.getReadStream(&buf, 512, crc32.IEEETable)
+	assert.Equal(t, "foo", readAllStream(t, s))
+	buffers.putReadStream(s)
+
+	buf.Reset()
+	writeTestPacket(&buf, 0, 0, []byte("barbaz"), 0)
+	writeTestPacket(&buf, 1, 6, nil, 0)
+
+	s2 := buffers.getReadStream(&buf, 512, crc32.IEEETable)
+	assert.True(t, s == s2, "expected the stream to be reused")
+	assert.Equal(t, "barbaz", readAllStream(t, s2))
+
+	// The stream is handed out once; until it's given back, a new one is
+	// allocated.
+	s3 := buffers.getReadStream(&buf, 512, crc32.IEEETable)
+	assert.True(t, s2 != s3, "expected a new stream")
+}
+
+func TestBlockBuffersReuseWriteStream(t *testing.T) {
+	var buffers BlockBuffers
+
+	var ackReader *bufio.Reader
+	for _, data := range []string{"foo", "barbaz"} {
+		client, server := net.Pipe()
+		packets := make(chan outboundPacket, 10)
+		go recordingDatanode(server, hdfs.Status_SUCCESS, packets)
+
+		bws := newBlockWriteStreamBuffers(client, 0, outboundChunkSize, outboundPacketSize, 0, &buffers)
+		if ackReader != nil {
+			assert.True(t, ackReader == bws.ackReader, "expected the ack reader to be reused")
+		}
+		ackReader = bws.ackReader
+
+		_, err := bws.Write([]byte(data))
+		require.NoError(t, err)
+		require.NoError(t, bws.finish())
+		client.Close()
+
+		var received []byte
+		for p := range packets {
+			received = append(received, p.data...)
+		}
+
+		assert.Equal(t, data, string(received))
+		assert.NotNil(t, buffers.ackReader)
+	}
+}
+
+func TestBlockBuffersNil(t *testing.T) {
+	var buffers *BlockBuffers
+
+	var buf bytes.Buffer
+	writeTestPacket(&buf, 0, 0, []byte("foo"), 0)
+	writeTestPacket(&buf, 1, 3, nil, 0)
+
+	s := buffers.getReadStream(&buf, 512, crc32.IEEETable)
+	assert.Equal(t, "foo", readAllStream(t, s))
+	buffers.putReadStream(s)
+
+	client, server := net.Pipe()
+	go fakeDatanode(server, hdfs.Status_SUCCESS)
+
+	bws := newBlockWriteStreamBuffers(client, 0, outboundChunkSize, outboundPacketSize, 0, buffers)
+	_, err := bws.Write([]byte("foo"))
+	require.NoError(t, err)
+	require.NoError(t, bws.finish())
+	client.Close()
+}
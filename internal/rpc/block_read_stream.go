@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/binary"
 	"errors"
+	"fmt"
 	"hash/crc32"
 	"io"
 	"math"
@@ -15,7 +16,8 @@ import (
 var errInvalidChecksum = errors.New("invalid checksum")
 
 // blockReadStream implements io.Reader for reading a packet stream for a single
-// block from a single datanode.
+// block from a single datanode. If checksumTab is nil, the packets are assumed
+// to contain no checksums, and the data isn't verified.
 type blockReadStream struct {
 	reader      io.Reader
 	checksumTab *crc32.Table
@@ -28,6 +30,22 @@ type blockReadStream struct {
 	chunkIndex   int
 	numChunks    int
 	lastPacket   bool
+
+	payloadLength uint32
+
+	// strict specifies that packet headers should be validated, with errors
+	// attributed to addr. lastSeqno and nextOffset are what's expected of the
+	// next packet, once one has been read.
+	strict     bool
+	addr       string
+	started    bool
+	lastSeqno  int64
+	nextOffset int64
+
+	// lengthBytes, headerBytes, and header are reused for each packet header.
+	lengthBytes [6]byte
+	headerBytes []byte
+	header      hdfs.PacketHeaderProto
 }
 
 func newBlockReadStream(reader io.Reader, chunkSize int, checksumTab *crc32.Table) *blockReadStream {
@@ -38,7 +56,36 @@ func newBlockReadStream(reader io.Reader, chunkSize int, checksumTab *crc32.Tabl
 	}
 }
 
+// reset prepares the stream to read a new packet stream, as if it had just
+// been created by newBlockReadStream, but keeps its buffers.
+func (s *blockReadStream) reset(reader io.Reader, chunkSize int, checksumTab *crc32.Table) {
+	s.reader = reader
+	s.chunkSize = chunkSize
+	s.checksumTab = checksumTab
+	s.checksums.Reset()
+	s.chunk.Reset()
+	s.packetLength = 0
+	s.chunkIndex = 0
+	s.numChunks = 0
+	s.lastPacket = false
+	s.payloadLength = 0
+	s.strict = false
+	s.addr = ""
+	s.started = false
+	s.lastSeqno = 0
+	s.nextOffset = 0
+}
+
 func (s *blockReadStream) Read(b []byte) (int, error) {
+	// For small reads, we need to buffer a single chunk. If we did that
+	// previously, read the rest of the buffer first, so we're aligned back on a
+	// chunk boundary. This has to happen before moving on to the next packet,
+	// since the buffered chunk may be the last one in the block.
+	if s.chunk.Len() > 0 {
+		n, _ := s.chunk.Read(b)
+		return n, nil
+	}
+
 	if s.chunkIndex == s.numChunks {
 		if s.lastPacket {
 			return 0, io.EOF
@@ -52,13 +99,7 @@ func (s *blockReadStream) Read(b []byte) (int, error) {
 
 	remainingInPacket := (s.packetLength - (s.chunkIndex * s.chunkSize))
 
-	// For small reads, we need to buffer a single chunk. If we did that
-	// previously, read the rest of the buffer, so we're aligned back on a
-	// chunk boundary.
-	if s.chunk.Len() > 0 {
-		n, _ := s.chunk.Read(b)
-		return n, nil
-	} else if len(b) < s.chunkSize {
+	if len(b) < s.chunkSize {
 		chunkSize := s.chunkSize
 		if chunkSize > remainingInPacket {
 			chunkSize = remainingInPacket
@@ -122,7 +163,28 @@ func (s *blockReadStream) Read(b []byte) (int, error) {
 	return n, err
 }
 
+// finish reads the empty packet that marks the end of the block, if it hasn't
+// been read already. It reports whether the stream ended cleanly, leaving the
+// connection ready for the client's read status.
+func (s *blockReadStream) finish() bool {
+	if s.chunk.Len() > 0 || s.chunkIndex < s.numChunks {
+		return false
+	}
+
+	if !s.lastPacket {
+		if s.startPacket() != nil || !s.lastPacket {
+			return false
+		}
+	}
+
+	return s.packetLength == 0
+}
+
 func (s *blockReadStream) validateChecksum(b []byte) error {
+	if s.checksumTab == nil {
+		return nil
+	}
+
 	checksumOffset := 4 * s.chunkIndex
 	checksumBytes := s.checksums.Bytes()[checksumOffset : checksumOffset+4]
 	checksum := binary.BigEndian.Uint32(checksumBytes)
@@ -146,6 +208,16 @@ func (s *blockReadStream) startPacket() error {
 
 	// TODO don't assume checksum size is 4
 	checksumsLength := numChunks * 4
+	if s.checksumTab == nil {
+		checksumsLength = 0
+	}
+
+	if s.strict {
+		err = s.validatePacketHeader(header, checksumsLength)
+		if err != nil {
+			return protocolError(s.addr, "packet header", err)
+		}
+	}
 	s.checksums.Reset()
 	s.checksums.Grow(checksumsLength)
 	_, err = io.CopyN(&s.checksums, s.reader, int64(checksumsLength))
@@ -162,22 +234,73 @@ func (s *blockReadStream) startPacket() error {
 }
 
 func (s *blockReadStream) readPacketHeader() (*hdfs.PacketHeaderProto, error) {
-	lengthBytes := make([]byte, 6)
+	lengthBytes := s.lengthBytes[:]
 	_, err := io.ReadFull(s.reader, lengthBytes)
 	if err != nil {
+		if s.strict {
+			err = protocolError(s.addr, "packet header", err)
+		}
+
 		return nil, err
 	}
 
-	// We don't actually care about the total length.
+	// We don't actually care about the total length, except in strict mode.
+	s.payloadLength = binary.BigEndian.Uint32(lengthBytes)
 	packetHeaderLength := binary.BigEndian.Uint16(lengthBytes[4:])
-	packetHeaderBytes := make([]byte, packetHeaderLength)
+	if s.strict && packetHeaderLength > maxPacketHeaderLength {
+		return nil, protocolError(s.addr, "packet header",
+			lengthError{uint64(packetHeaderLength), maxPacketHeaderLength})
+	}
+
+	if cap(s.headerBytes) < int(packetHeaderLength) {
+		s.headerBytes = make([]byte, packetHeaderLength)
+	}
+
+	packetHeaderBytes := s.headerBytes[:packetHeaderLength]
 	_, err = io.ReadFull(s.reader, packetHeaderBytes)
 	if err != nil {
+		if s.strict {
+			err = protocolError(s.addr, "packet header", err)
+		}
+
 		return nil, err
 	}
 
-	packetHeader := &hdfs.PacketHeaderProto{}
+	packetHeader := &s.header
 	err = proto.Unmarshal(packetHeaderBytes, packetHeader)
+	if err != nil && s.strict {
+		return nil, protocolError(s.addr, "packet header", err)
+	}
 
 	return packetHeader, nil
 }
+
+// validatePacketHeader checks that a packet header makes sense, in strict
+// mode.
+func (s *blockReadStream) validatePacketHeader(header *hdfs.PacketHeaderProto, checksumsLength int) error {
+	dataLength := header.GetDataLen()
+	if dataLength < 0 || dataLength > maxPacketDataLength {
+		return fmt.Errorf("data length of %d bytes is out of range", dataLength)
+	} else if header.GetOffsetInBlock() < 0 {
+		return fmt.Errorf("negative offset %d", header.GetOffsetInBlock())
+	}
+
+	// The payload length includes the four bytes of the length itself.
+	if expected := 4 + uint32(checksumsLength) + uint32(dataLength); s.payloadLength != expected {
+		return fmt.Errorf("payload length of %d bytes doesn't match the header (expected %d)",
+			s.payloadLength, expected)
+	}
+
+	if s.started {
+		if header.GetSeqno() != s.lastSeqno+1 {
+			return fmt.Errorf("seqno %d is out of order (expected %d)", header.GetSeqno(), s.lastSeqno+1)
+		} else if header.GetOffsetInBlock() != s.nextOffset {
+			return fmt.Errorf("offset %d is out of order (expected %d)", header.GetOffsetInBlock(), s.nextOffset)
+		}
+	}
+
+	s.started = true
+	s.lastSeqno = header.GetSeqno()
+	s.nextOffset = header.GetOffsetInBlock() + int64(dataLength)
+	return nil
+}
@@ -28,22 +28,78 @@ type BlockReader struct {
 	// UseDatanodeHostname specifies whether the datanodes should be connected to
 	// via their hostnames (if true) or IP addresses (if false).
 	UseDatanodeHostname bool
+	// SkipChecksum specifies that the datanode should not send checksums for
+	// the block data, and that the data read should not be verified.
+	SkipChecksum bool
 	// DialFunc is used to connect to the datanodes. If nil, then
 	// (&net.Dialer{}).DialContext is used.
 	DialFunc func(ctx context.Context, network, addr string) (net.Conn, error)
+	// ConnectTimeout limits how long connecting to each datanode can take,
+	// including the initial handshake. If it expires, the next datanode is
+	// tried. If zero, only the DialFunc and the deadline limit it.
+	ConnectTimeout time.Duration
+	// CircuitBreaker, if set, keeps track of datanodes that fail repeatedly, so
+	// that they can be skipped.
+	CircuitBreaker *CircuitBreaker
+	// Stats, if set, records the bytes read, errors, and read latency for each
+	// datanode.
+	Stats *ReadStats
+	// WireLog, if set, is used to log the data transfer requests and responses.
+	WireLog *WireLogger
+	// Strict specifies that the response and packets from the datanode should
+	// be validated as they're read, with a ProtocolError returned if they
+	// don't make sense.
+	Strict bool
+	// Buffers, if set, is used to reuse buffers from the previous block read,
+	// and is given this block's buffers once it's closed. It isn't used for
+	// erasure coded blocks.
+	Buffers *BlockBuffers
+	// Memory, if set, limits the memory used for buffering erasure coded
+	// stripes, across all the readers (and writers) sharing it.
+	Memory *MemoryLimiter
+	// MemoryOwned, if set, counts the memory held by the stripes along with
+	// any other buffers of the same owner, such as a file reader, as with
+	// MemoryLimiter.AcquireOwned.
+	MemoryOwned *int64
+	// ECPolicy is the erasure coding policy of the file, if it's erasure coded.
+	// In that case, Block is a striped block group, and the internal blocks are
+	// read from several datanodes at once. Any missing data is reconstructed
+	// from the parity blocks.
+	ECPolicy *hdfs.ErasureCodingPolicyProto
+	// ShortCircuit, if set, is used to read the block directly from the local
+	// disk, if one of the datanodes is on this host. If that fails, the block is
+	// read from the datanodes instead.
+	ShortCircuit *ShortCircuit
+	// ReadAhead, if positive, is the number of bytes to read ahead from the
+	// datanode in the background, so that the network is kept busy while the
+	// data already received is verified and returned. It isn't used for erasure
+	// coded blocks or short-circuit reads.
+	ReadAhead int
+	// ConnPool, if set, is where the connection to the datanode is returned
+	// once the whole block has been read, and where connections are taken
+	// from before dialing a new one. Connections used for reading ahead
+	// aren't returned to it.
+	ConnPool *DatanodeConnPool
 
-	datanodes *datanodeFailover
-	stream    *blockReadStream
-	conn      net.Conn
-	deadline  time.Time
-	closed    bool
+	datanodes  *datanodeFailover
+	local      *localReplica
+	localAddr  string
+	triedLocal bool
+	striped    *stripedBlockReader
+	stream     *blockReadStream
+	readAhead  *readAhead
+	conn       net.Conn
+	deadline   time.Time
+	closed     bool
 }
 
 // SetDeadline sets the deadline for future Read calls. A zero value for t
 // means Read will not time out.
 func (br *BlockReader) SetDeadline(t time.Time) error {
 	br.deadline = t
-	if br.conn != nil {
+	if br.striped != nil {
+		return br.striped.setDeadline(t)
+	} else if br.conn != nil {
 		return br.conn.SetDeadline(t)
 	}
 
@@ -66,16 +122,30 @@ func (br *BlockReader) Read(b []byte) (int, error) {
 	} else if uint64(br.Offset) >= br.Block.GetB().GetNumBytes() {
 		br.Close()
 		return 0, io.EOF
+	} else if len(b) == 0 {
+		// Don't let an empty read look like a short one, which would be taken
+		// as a failure of the local replica or the datanode.
+		return 0, nil
 	}
 
-	if br.datanodes == nil {
-		locs := br.Block.GetLocs()
-		datanodes := make([]string, len(locs))
-		for i, loc := range locs {
-			datanodes[i] = getDatanodeAddress(loc.GetId(), br.UseDatanodeHostname)
+	if br.ECPolicy != nil {
+		return br.readStriped(b)
+	}
+
+	if !br.triedLocal && br.ShortCircuit != nil {
+		br.triedLocal = true
+		br.local, br.localAddr = br.ShortCircuit.open(br.Block, br.SkipChecksum)
+	}
+
+	if br.local != nil {
+		n, err := br.readLocal(b)
+		if err == nil || n > 0 {
+			return n, nil
 		}
+	}
 
-		br.datanodes = newDatanodeFailover(datanodes)
+	if br.datanodes == nil {
+		br.datanodes = newBlockFailover(br.Block.GetLocs(), br.UseDatanodeHostname, br.CircuitBreaker)
 	}
 
 	// This is the main retry loop.
@@ -85,17 +155,27 @@ func (br *BlockReader) Read(b []byte) (int, error) {
 		if br.stream == nil {
 			err := br.connectNext()
 			if err != nil {
+				br.Stats.recordError(br.datanodes.currentDatanode, err)
 				br.datanodes.recordFailure(err)
 				continue
 			}
+
+			br.datanodes.recordSuccess()
 		}
 
 		// Then, try to read. If we fail here after reading some bytes, we return
 		// a partial read (n < len(b)).
+		start := time.Now()
 		n, err := br.stream.Read(b)
 		br.Offset += int64(n)
+		if n > 0 {
+			br.Stats.recordRead(br.datanodes.currentDatanode, n, time.Since(start))
+		}
+
 		if err != nil && err != io.EOF {
+			br.closeConn()
 			br.stream = nil
+			br.Stats.recordError(br.datanodes.currentDatanode, err)
 			br.datanodes.recordFailure(err)
 			if n > 0 {
 				return n, nil
@@ -115,18 +195,146 @@ func (br *BlockReader) Read(b []byte) (int, error) {
 	return 0, err
 }
 
+// readLocal reads from the local replica. If that fails, the replica is
+// closed, and subsequent reads go to the datanodes instead.
+func (br *BlockReader) readLocal(b []byte) (int, error) {
+	var err error
+	if !br.local.isValid() {
+		err = errLocalReplicaInvalid
+	} else {
+		if remaining := int64(br.Block.GetB().GetNumBytes()) - br.Offset; int64(len(b)) > remaining {
+			b = b[:remaining]
+		}
+
+		var n int
+		start := time.Now()
+		n, err = br.local.readAt(b, br.Offset)
+		br.Offset += int64(n)
+		if n > 0 {
+			br.Stats.recordRead(br.localAddr, n, time.Since(start))
+		}
+
+		if err == nil {
+			return n, nil
+		} else if n > 0 {
+			br.closeLocal()
+			return n, nil
+		}
+	}
+
+	br.Stats.recordError(br.localAddr, err)
+	br.closeLocal()
+	return 0, err
+}
+
+func (br *BlockReader) closeLocal() {
+	if br.local != nil {
+		br.local.close()
+		br.local = nil
+	}
+}
+
+// readStriped reads from a striped block group.
+func (br *BlockReader) readStriped(b []byte) (int, error) {
+	if br.striped == nil {
+		striped, err := newStripedBlockReader(br)
+		if err != nil {
+			return 0, err
+		}
+
+		br.striped = striped
+	}
+
+	n, err := br.striped.Read(b)
+	br.Offset += int64(n)
+	return n, err
+}
+
+// Datanode returns the address of the datanode currently being read from, or
+// an empty string if the BlockReader hasn't connected to one yet. For striped
+// block groups, which are read from several datanodes at once, it's always
+// empty.
+func (br *BlockReader) Datanode() string {
+	if br.local != nil {
+		return br.localAddr
+	} else if br.datanodes == nil {
+		return ""
+	}
+
+	return br.datanodes.currentDatanode
+}
+
 // Close implements io.Closer.
 func (br *BlockReader) Close() error {
 	br.closed = true
+	br.closeLocal()
+	if br.striped != nil {
+		br.striped.Close()
+	}
+
+	if !br.releaseConn() {
+		br.closeConn()
+	}
+
+	br.Buffers.putReadStream(br.stream)
+	br.stream = nil
+	return nil
+}
+
+// releaseConn returns the connection to the current datanode to ConnPool, if
+// the whole block has been read from it. It reports whether it did; if not,
+// the connection should be closed.
+func (br *BlockReader) releaseConn() bool {
+	if br.ConnPool == nil || br.conn == nil || br.stream == nil || br.readAhead != nil ||
+		uint64(br.Offset) < br.Block.GetB().GetNumBytes() {
+		return false
+	}
+
+	// The datanode follows the block with an empty packet, and then waits for
+	// the client to say whether the checksums matched, before it'll read
+	// another request from the connection.
+	br.conn.SetDeadline(time.Now().Add(datanodeReleaseTimeout))
+	if !br.stream.finish() {
+		return false
+	}
+
+	status := hdfs.Status_CHECKSUM_OK
+	if br.SkipChecksum {
+		status = hdfs.Status_SUCCESS
+	}
+
+	b, err := makePrefixedMessage(&hdfs.ClientReadStatusProto{Status: status.Enum()})
+	if err != nil {
+		return false
+	}
+
+	_, err = br.conn.Write(b)
+	if err != nil {
+		return false
+	}
+
+	br.conn.SetDeadline(time.Time{})
+	br.ConnPool.put(br.datanodes.currentDatanode, br.conn)
+	br.conn = nil
+	return true
+}
+
+// closeConn closes the connection to the current datanode, if there is one,
+// and stops reading ahead from it.
+func (br *BlockReader) closeConn() {
 	if br.conn != nil {
 		br.conn.Close()
+		br.conn = nil
 	}
 
-	return nil
+	if br.readAhead != nil {
+		br.readAhead.close()
+		br.readAhead = nil
+	}
 }
 
 // connectNext pops a datanode from the list based on previous failures, and
-// connects to it.
+// connects to it, reusing a connection from ConnPool if there is one.
 func (br *BlockReader) connectNext() error {
 	address := br.datanodes.next()
 
@@ -134,39 +342,98 @@ func (br *BlockReader) connectNext() error {
 		br.DialFunc = (&net.Dialer{}).DialContext
 	}
 
-	conn, err := br.DialFunc(context.Background(), "tcp", address)
+	ctx := context.Background()
+	if br.ConnectTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, br.ConnectTimeout)
+		defer cancel()
+	}
+
+	// The datanode may have closed the pooled connection since it was used,
+	// so if it doesn't work, a new one is dialed without counting it as a
+	// failure.
+	if conn := br.ConnPool.get(address); conn != nil {
+		if br.startRead(ctx, conn, address) == nil {
+			return nil
+		}
+	}
+
+	var conn net.Conn
+	var err error
+	if dn := datanodeForAddress(br.Block.GetLocs(), address, br.UseDatanodeHostname); dn != nil {
+		conn, err = dialDatanode(ctx, br.DialFunc, dn, br.UseDatanodeHostname)
+	} else {
+		conn, err = br.DialFunc(ctx, "tcp", address)
+	}
+
 	if err != nil {
 		return err
 	}
 
-	err = br.writeBlockReadRequest(conn)
+	return br.startRead(ctx, conn, address)
+}
+
+// startRead sends the read request on conn, and sets up the stream to read
+// the block from it. If it fails, conn is closed.
+func (br *BlockReader) startRead(ctx context.Context, conn net.Conn, address string) error {
+	// The handshake is bounded by the connect timeout, too, so that a datanode
+	// that accepts connections but doesn't respond is skipped quickly.
+	if deadline, ok := ctx.Deadline(); ok {
+		if br.deadline.IsZero() || deadline.Before(br.deadline) {
+			conn.SetDeadline(deadline)
+		} else {
+			conn.SetDeadline(br.deadline)
+		}
+	}
+
+	err := br.writeBlockReadRequest(conn)
 	if err != nil {
+		conn.Close()
 		return err
 	}
 
-	resp, err := readBlockOpResponse(conn)
+	var max uint64
+	if br.Strict {
+		max = maxDataTransferMessageLength
+	}
+
+	resp, err := br.WireLog.readBlockOpResponse(conn, max)
+	if br.Strict {
+		if err == nil {
+			err = br.validateResponse(resp)
+		}
+
+		err = protocolError(address, "read response", err)
+	}
+
 	if err != nil {
+		conn.Close()
 		return err
 	} else if resp.GetStatus() != hdfs.Status_SUCCESS {
-		return fmt.Errorf("read failed: %s (%s)", resp.GetStatus().String(), resp.GetMessage())
+		conn.Close()
+		return newDatanodeError("read", resp)
 	}
 
 	readInfo := resp.GetReadOpChecksumInfo()
 	checksumInfo := readInfo.GetChecksum()
 
-	var checksumTab *crc32.Table
-	checksumType := checksumInfo.GetType()
-	switch checksumType {
-	case hdfs.ChecksumTypeProto_CHECKSUM_CRC32:
-		checksumTab = crc32.IEEETable
-	case hdfs.ChecksumTypeProto_CHECKSUM_CRC32C:
-		checksumTab = crc32.MakeTable(crc32.Castagnoli)
-	default:
-		return fmt.Errorf("unsupported checksum type: %d", checksumType)
+	checksumTab, err := getChecksumTable(checksumInfo)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+
+	var r io.Reader = conn
+	var ra *readAhead
+	if br.ReadAhead > 0 {
+		ra = newReadAhead(conn, br.ReadAhead)
+		r = ra
 	}
 
 	chunkSize := int(checksumInfo.GetBytesPerChecksum())
-	stream := newBlockReadStream(conn, chunkSize, checksumTab)
+	stream := br.Buffers.getReadStream(r, chunkSize, checksumTab)
+	stream.strict = br.Strict
+	stream.addr = address
 
 	// The read will start aligned to a chunk boundary, so we need to seek forward
 	// to the requested offset.
@@ -179,11 +446,16 @@ func (br *BlockReader) connectNext() error {
 			}
 
 			conn.Close()
+			if ra != nil {
+				ra.close()
+			}
+
 			return err
 		}
 	}
 
 	br.stream = stream
+	br.readAhead = ra
 	br.conn = conn
 	err = br.conn.SetDeadline(br.deadline)
 	if err != nil {
@@ -193,6 +465,47 @@ func (br *BlockReader) connectNext() error {
 	return nil
 }
 
+// validateResponse checks that a successful response to a read request makes
+// sense, in strict mode.
+func (br *BlockReader) validateResponse(resp *hdfs.BlockOpResponseProto) error {
+	if resp.GetStatus() != hdfs.Status_SUCCESS {
+		return nil
+	}
+
+	readInfo := resp.GetReadOpChecksumInfo()
+	if readInfo == nil {
+		return errors.New("missing checksum info")
+	}
+
+	chunkSize := int64(readInfo.GetChecksum().GetBytesPerChecksum())
+	chunkOffset := int64(readInfo.GetChunkOffset())
+	if chunkSize <= 0 || chunkSize > maxPacketDataLength {
+		return fmt.Errorf("bytes per checksum of %d is out of range", chunkSize)
+	} else if chunkOffset > br.Offset || br.Offset-chunkOffset >= chunkSize {
+		return fmt.Errorf("chunk offset %d doesn't contain the requested offset %d", chunkOffset, br.Offset)
+	}
+
+	return nil
+}
+
+// getChecksumTable returns the CRC table for the checksum type the datanode
+// responded with, or nil if there are no checksums.
+func getChecksumTable(checksumInfo *hdfs.ChecksumProto) (*crc32.Table, error) {
+	checksumType := checksumInfo.GetType()
+	switch checksumType {
+	case hdfs.ChecksumTypeProto_CHECKSUM_CRC32:
+		return crc32.IEEETable, nil
+	case hdfs.ChecksumTypeProto_CHECKSUM_CRC32C:
+		return crc32.MakeTable(crc32.Castagnoli), nil
+	case hdfs.ChecksumTypeProto_CHECKSUM_NULL:
+		// This is what the datanode responds with if we asked it not to send
+		// checksums.
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unsupported checksum type: %d", checksumType)
+	}
+}
+
 // A read request to a datanode:
 // +-----------------------------------------------------------+
 // |  Data Transfer Protocol Version, int16                    |
@@ -211,9 +524,10 @@ func (br *BlockReader) writeBlockReadRequest(w io.Writer) error {
 			},
 			ClientName: proto.String(br.ClientName),
 		},
-		Offset: proto.Uint64(uint64(br.Offset)),
-		Len:    proto.Uint64(needed),
+		Offset:        proto.Uint64(uint64(br.Offset)),
+		Len:           proto.Uint64(needed),
+		SendChecksums: proto.Bool(!br.SkipChecksum),
 	}
 
-	return writeBlockOpRequest(w, readBlockOp, op)
+	return br.WireLog.writeBlockOpRequest(w, readBlockOp, op)
 }
@@ -0,0 +1,97 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	hadoop "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_common"
+	hdfs "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_hdfs"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testBlock(hosts ...string) *hdfs.LocatedBlockProto {
+	locs := make([]*hdfs.DatanodeInfoProto, len(hosts))
+	for i, host := range hosts {
+		locs[i] = &hdfs.DatanodeInfoProto{
+			Id: &hdfs.DatanodeIDProto{
+				IpAddr:       proto.String(host),
+				HostName:     proto.String(host),
+				DatanodeUuid: proto.String(host),
+				XferPort:     proto.Uint32(9866),
+			},
+		}
+	}
+
+	return &hdfs.LocatedBlockProto{
+		B: &hdfs.ExtendedBlockProto{
+			PoolId:          proto.String("pool"),
+			BlockId:         proto.Uint64(1),
+			GenerationStamp: proto.Uint64(1),
+			NumBytes:        proto.Uint64(1024),
+		},
+		Offset:  proto.Uint64(0),
+		Locs:    locs,
+		Corrupt: proto.Bool(false),
+		BlockToken: &hadoop.TokenProto{
+			Identifier: []byte{},
+			Password:   []byte{},
+			Kind:       proto.String(""),
+			Service:    proto.String(""),
+		},
+	}
+}
+
+func TestBlockReaderConnectTimeout(t *testing.T) {
+	var dialed []string
+	var servers []net.Conn
+	br := &BlockReader{
+		Block:          testBlock("10.0.0.1", "10.0.0.2"),
+		ConnectTimeout: 50 * time.Millisecond,
+		DialFunc: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			dialed = append(dialed, addr)
+			if addr == "10.0.0.2:9866" {
+				return nil, errors.New("connection refused")
+			}
+
+			// The first datanode accepts the connection, but never responds.
+			_, ok := ctx.Deadline()
+			assert.True(t, ok)
+
+			client, server := net.Pipe()
+			servers = append(servers, server)
+			return client, nil
+		},
+	}
+
+	defer func() {
+		for _, server := range servers {
+			server.Close()
+		}
+	}()
+
+	start := time.Now()
+	_, err := br.Read(make([]byte, 1))
+	require.Error(t, err)
+	assert.True(t, time.Since(start) < 5*time.Second)
+	assert.Equal(t, []string{"10.0.0.1:9866", "10.0.0.2:9866"}, dialed)
+}
+
+func TestDatanodeError(t *testing.T) {
+	err := newDatanodeError("read", &hdfs.BlockOpResponseProto{
+		Status:  hdfs.Status_ERROR.Enum(),
+		Message: proto.String("opReadBlock BP-1:blk_1073741825_1001 received exception java.nio.file.AccessDeniedException: s3a://bucket/key: 403 Forbidden"),
+	})
+
+	assert.Equal(t, "readBlock", err.Method())
+	assert.Equal(t, "ERROR", err.Desc())
+	assert.Equal(t, "java.nio.file.AccessDeniedException", err.Exception())
+
+	err = newDatanodeError("write", &hdfs.BlockOpResponseProto{Status: hdfs.Status_ERROR_ACCESS_TOKEN.Enum()})
+	assert.Equal(t, "", err.Exception())
+	assert.Equal(t, "write failed: ERROR_ACCESS_TOKEN ()", err.Error())
+}
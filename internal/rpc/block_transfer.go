@@ -0,0 +1,90 @@
+package rpc
+
+import (
+	"fmt"
+	"io"
+
+	hadoop "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_common"
+	hdfs "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_hdfs"
+	"github.com/golang/protobuf/proto"
+)
+
+// CopyBlock requests a copy of an entire block replica from the datanode on
+// the other end of conn, and returns a reader for the block data. The data is
+// verified against the checksums sent by the datanode as it is read.
+//
+// This is the operation datanodes use to fetch a replica from each other when
+// moving blocks around the cluster, and it's subject to the datanode's
+// balancing bandwidth limits and concurrent mover thread limit. Most clients
+// should use a BlockReader instead.
+func CopyBlock(conn io.ReadWriter, block *hdfs.ExtendedBlockProto, token *hadoop.TokenProto) (io.Reader, error) {
+	op := &hdfs.OpCopyBlockProto{
+		Header: &hdfs.BaseHeaderProto{
+			Block: block,
+			Token: token,
+		},
+	}
+
+	err := writeBlockOpRequest(conn, copyBlockOp, op)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := readBlockOpResponse(conn)
+	if err != nil {
+		return nil, err
+	} else if resp.GetStatus() != hdfs.Status_SUCCESS {
+		return nil, fmt.Errorf("copy failed: %s (%s)", resp.GetStatus().String(), resp.GetMessage())
+	}
+
+	checksumInfo := resp.GetReadOpChecksumInfo().GetChecksum()
+	checksumTab, err := getChecksumTable(checksumInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	chunkSize := int(checksumInfo.GetBytesPerChecksum())
+	return newBlockReadStream(conn, chunkSize, checksumTab), nil
+}
+
+// ReplaceBlock asks the datanode on the other end of conn to copy a block
+// replica from source (using CopyBlock), store it as the given storage type,
+// and then tell the namenode that the replica on the datanode with the UUID
+// delHint can be deleted. Like the hdfs balancer, this can be used to move
+// replicas between datanodes, or between storages on the same datanode.
+//
+// The target datanode periodically sends IN_PROGRESS responses while the copy
+// is ongoing, so this blocks until the replacement is done or has failed.
+func ReplaceBlock(conn io.ReadWriter, block *hdfs.ExtendedBlockProto, token *hadoop.TokenProto,
+	delHint string, source *hdfs.DatanodeInfoProto, storageType hdfs.StorageTypeProto) error {
+	op := &hdfs.OpReplaceBlockProto{
+		Header: &hdfs.BaseHeaderProto{
+			Block: block,
+			Token: token,
+		},
+		DelHint:     proto.String(delHint),
+		Source:      source,
+		StorageType: storageType.Enum(),
+	}
+
+	err := writeBlockOpRequest(conn, replaceBlockOp, op)
+	if err != nil {
+		return err
+	}
+
+	for {
+		resp, err := readBlockOpResponse(conn)
+		if err != nil {
+			return err
+		}
+
+		switch resp.GetStatus() {
+		case hdfs.Status_IN_PROGRESS:
+			continue
+		case hdfs.Status_SUCCESS:
+			return nil
+		default:
+			return fmt.Errorf("replace failed: %s (%s)", resp.GetStatus().String(), resp.GetMessage())
+		}
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"hash/crc32"
 	"io"
 	"math"
+	"net"
 	"os"
 	"sync"
 	"time"
@@ -22,17 +23,29 @@ const (
 	outboundChunkSize  = 512
 	maxPacketsInQueue  = 5
 	heartBeatSeqno     = -1
-	heartBeatTimeout   = 30 * time.Second
+	heartBeatInterval  = 30 * time.Second
+
+	// maxPacketSize is the largest packet datanodes accept, including the
+	// header and checksums.
+	maxPacketSize = 16 * 1024 * 1024
+	// maxBytesInQueue limits how much packet data can be waiting for acks at
+	// once, when the packets are large.
+	maxBytesInQueue = 32 * 1024 * 1024
 )
 
 // blockWriteStream writes data out to a datanode, and reads acks back.
 type blockWriteStream struct {
 	block *hdfs.LocatedBlockProto
 
-	conn   io.ReadWriter
-	buf    bytes.Buffer
-	offset int64
-	closed bool
+	conn       io.ReadWriter
+	buf        bytes.Buffer
+	offset     int64
+	closed     bool
+	chunkSize  int
+	packetSize int
+	syncBlock  bool
+	// checksumTab is used for the chunk checksums. If nil, they're CRC32.
+	checksumTab *crc32.Table
 
 	packets chan outboundPacket
 	seqno   int
@@ -41,17 +54,102 @@ type blockWriteStream struct {
 	acksDone        chan struct{}
 	lastPacketSeqno int
 
+	// ackedOffset is the offset in the block up to which all packets have been
+	// acknowledged by the whole pipeline. It's protected by ackLock, and ackCond
+	// is signalled whenever it changes or the ack loop stops.
+	ackedOffset int64
+	ackStopped  bool
+	ackLock     sync.Mutex
+	ackCond     *sync.Cond
+
 	lock sync.Mutex // to synchronize with heartbeat thread
 
 	closeCh chan struct{}
+
+	// events is called with write events, if set. Acks are reported from the
+	// background ack goroutine. pipeline is the addresses of the datanodes, for
+	// reporting which one failed and validating acks.
+	events   func(WriteEvent)
+	pipeline []string
+
+	// strict specifies that acks should be validated.
+	strict bool
+
+	// ackReader buffers the acks read from conn. header and headerBuf are
+	// reused to marshal each packet header. buffers, if set, gets ackReader and
+	// buf back to reuse once the stream is finished.
+	ackReader *bufio.Reader
+	header    proto.Buffer
+	headerBuf []byte
+	buffers   *BlockBuffers
+
+	// iov holds the header, checksums, and data of the packet being written,
+	// so that they go out in a single writev where the connection supports it.
+	iov [3][]byte
+
+	// memory, if set, accounts for the packets waiting to be acked. queued is
+	// how much of it they hold, and is protected by memory's lock.
+	memory *MemoryLimiter
+	queued int64
+
+	// keepUnacked specifies that the packets which aren't acked once acking
+	// fails should be kept in unacked, so that they can be sent again to a
+	// recovered pipeline. Their memory is released either way, so that the
+	// writer doesn't wait on it while the pipeline is down.
+	keepUnacked bool
+	unacked     []outboundPacket
+	stopped     bool
 }
 
 type outboundPacket struct {
 	seqno     int
 	offset    int64
 	last      bool
+	sync      bool
 	checksums []byte
 	data      []byte
+	sent      time.Time
+	// pooled and pooledChecksums are set if data or checksums came from the
+	// packet buffer pool, and should be returned to it once the packet is
+	// acked.
+	pooled          bool
+	pooledChecksums bool
+}
+
+// size returns the number of bytes the packet holds in memory.
+func (p outboundPacket) size() int64 {
+	return int64(len(p.data) + len(p.checksums))
+}
+
+// release gives the packet's pooled buffers back, once it's been acked.
+func (p outboundPacket) release() {
+	if p.pooled {
+		putPacketBuffer(p.data)
+	}
+
+	if p.pooledChecksums {
+		putPacketBuffer(p.checksums)
+	}
+}
+
+// packetQueueLength returns how many packets can be waiting for acks at once.
+// If maxPackets is positive, that's used as-is. Otherwise, large packets get a
+// shorter queue, so that the amount of data held doesn't grow with the packet
+// size, but there are always at least two, so that the pipeline doesn't stall
+// waiting for each ack.
+func packetQueueLength(packetSize, maxPackets int) int {
+	if maxPackets > 0 {
+		return maxPackets
+	}
+
+	n := maxBytesInQueue / packetSize
+	if n > maxPacketsInQueue {
+		n = maxPacketsInQueue
+	} else if n < 2 {
+		n = 2
+	}
+
+	return n
 }
 
 type ackError struct {
@@ -66,14 +164,33 @@ func (ae ackError) Error() string {
 
 var ErrInvalidSeqno = errors.New("invalid ack sequence number")
 
-func newBlockWriteStream(conn io.ReadWriter, offset int64) *blockWriteStream {
+func newBlockWriteStream(conn io.ReadWriter, offset int64, chunkSize, packetSize int) *blockWriteStream {
+	return newBlockWriteStreamBuffers(conn, offset, chunkSize, packetSize, 0, nil)
+}
+
+// newBlockWriteStreamBuffers is like newBlockWriteStream, but allows up to
+// maxPackets packets to be waiting for acks (see packetQueueLength), and reuses
+// the buffers from buffers, if it has any, giving them back once the stream is
+// finished.
+func newBlockWriteStreamBuffers(conn io.ReadWriter, offset int64, chunkSize, packetSize, maxPackets int, buffers *BlockBuffers) *blockWriteStream {
 	s := &blockWriteStream{
-		conn:     conn,
-		offset:   offset,
-		seqno:    1,
-		packets:  make(chan outboundPacket, maxPacketsInQueue),
-		acksDone: make(chan struct{}),
-		closeCh:  make(chan struct{}),
+		conn:        conn,
+		offset:      offset,
+		chunkSize:   chunkSize,
+		packetSize:  packetSize,
+		seqno:       1,
+		packets:     make(chan outboundPacket, packetQueueLength(packetSize, maxPackets)),
+		acksDone:    make(chan struct{}),
+		closeCh:     make(chan struct{}),
+		ackedOffset: offset,
+		buffers:     buffers,
+	}
+	s.ackCond = sync.NewCond(&s.ackLock)
+
+	var buf []byte
+	s.ackReader, buf = buffers.getWriteBuffers(conn)
+	if buf != nil {
+		s.buf = *bytes.NewBuffer(buf)
 	}
 
 	// Ack packets in the background.
@@ -82,25 +199,9 @@ func newBlockWriteStream(conn io.ReadWriter, offset int64) *blockWriteStream {
 		close(s.acksDone)
 	}()
 
-	go s.sendHeartBeats()
-
 	return s
 }
 
-// func newBlockWriteStreamForRecovery(conn io.ReadWriter, oldWriteStream *blockWriteStream) {
-// 	s := &blockWriteStream{
-// 		conn: conn,
-// 		buf: oldWriteStream.buf,
-// 		packets: oldWriteStream.packets,
-// 		offset: oldWriteStream.offset,
-// 		seqno: oldWriteStream.seqno,
-// 		packets
-// 	}
-
-// 	go s.ackPackets()
-// 	return s
-// }
-
 func (s *blockWriteStream) Write(b []byte) (int, error) {
 	if s.closed {
 		return 0, io.ErrClosedPipe
@@ -119,7 +220,7 @@ func (s *blockWriteStream) Write(b []byte) (int, error) {
 // packet signifying the end of the block.
 func (s *blockWriteStream) finish() (err error) {
 	if s.closed {
-		return nil
+		return s.getAckError()
 	}
 	s.closed = true
 
@@ -129,11 +230,16 @@ func (s *blockWriteStream) finish() (err error) {
 
 		// Wait for the ack loop to finish.
 		<-s.acksDone
-
 		if err == nil {
 			// Check one more time for any ack errors.
 			err = s.getAckError()
 		}
+
+		// If the stream failed, the buffered data may still be needed to
+		// recover it.
+		if err == nil {
+			s.buffers.putWriteStream(s)
+		}
 	}()
 
 	if err := s.getAckError(); err != nil {
@@ -149,13 +255,12 @@ func (s *blockWriteStream) finish() (err error) {
 		seqno:     s.seqno,
 		offset:    s.offset,
 		last:      true,
+		sync:      s.syncBlock,
 		checksums: []byte{},
 		data:      []byte{},
 	}
 
-	s.packets <- lastPacket
-
-	return s.writePacket(lastPacket)
+	return s.send(lastPacket)
 }
 
 // flush parcels out the buffered bytes into packets, which it then flushes to
@@ -166,13 +271,8 @@ func (s *blockWriteStream) flush(force bool) error {
 		return err
 	}
 
-	for s.buf.Len() > 0 && (force || s.buf.Len() >= outboundPacketSize) {
-		packet := s.makePacket()
-		s.packets <- packet
-		s.offset += int64(len(packet.data))
-		s.seqno++
-
-		err := s.writePacket(packet)
+	for s.buf.Len() > 0 && (force || s.buf.Len() >= s.packetSize) {
+		err := s.send(s.makePacket())
 		if err != nil {
 			return err
 		}
@@ -181,9 +281,212 @@ func (s *blockWriteStream) flush(force bool) error {
 	return nil
 }
 
+// sync is like flush(true), but marks the last packet sent with SyncBlock, so
+// that the datanodes sync the block to disk once they've written it, like
+// hsync in the Java client. If nothing is buffered, an empty packet is sent
+// to carry the flag.
+func (s *blockWriteStream) sync() error {
+	if err := s.getAckError(); err != nil {
+		return err
+	}
+
+	packet := s.newPacket([]byte{})
+	for s.buf.Len() > 0 {
+		packet = s.makePacket()
+		if s.buf.Len() == 0 {
+			break
+		}
+
+		err := s.send(packet)
+		if err != nil {
+			return err
+		}
+	}
+
+	packet.sync = true
+	return s.send(packet)
+}
+
+// writeNoCopy is like Write, but uses b directly as packet data wherever it
+// can, rather than copying it into the buffer first. Only a partial chunk at
+// either end of b is buffered. Since the packets keep a reference to b in case
+// they need to be sent again, the caller must not modify it afterwards.
+func (s *blockWriteStream) writeNoCopy(b []byte) (int, error) {
+	if s.closed {
+		return 0, io.ErrClosedPipe
+	}
+
+	if err := s.getAckError(); err != nil {
+		return 0, err
+	}
+
+	// Anything already buffered has to be sent first. Complete the last chunk
+	// of it from b, so that the rest of b starts on a chunk boundary.
+	n := 0
+	if s.buf.Len() > 0 || int(s.offset)%s.chunkSize != 0 {
+		alignment := int(s.offset+int64(s.buf.Len())) % s.chunkSize
+		if alignment > 0 {
+			n = s.chunkSize - alignment
+			if n > len(b) {
+				n = len(b)
+			}
+
+			s.buf.Write(b[:n])
+			if n < s.chunkSize-alignment {
+				return n, nil
+			}
+		}
+
+		if err := s.flush(true); err != nil {
+			return n, err
+		}
+	}
+
+	for len(b)-n >= s.chunkSize {
+		size := len(b) - n
+		if size > s.packetSize {
+			size = s.packetSize
+		}
+
+		size -= size % s.chunkSize
+		err := s.send(s.newPacket(b[n : n+size]))
+		n += size
+		if err != nil {
+			// The packet is queued even if it couldn't be written, so it
+			// counts as written, to be sent again if the pipeline is
+			// recovered.
+			return n, err
+		}
+	}
+
+	s.buf.Write(b[n:])
+	return len(b), nil
+}
+
+// send queues up a packet to be acked, and then writes it to the datanode.
+func (s *blockWriteStream) send(packet outboundPacket) error {
+	s.seqno++
+	return s.queue(packet, PacketSent)
+}
+
+// queue queues up a packet to be acked, writes it to the datanode, and then
+// reports it with an event of type typ.
+func (s *blockWriteStream) queue(packet outboundPacket, typ WriteEventType) error {
+	s.memory.acquireQueued(&s.queued, packet.size())
+	packet.sent = time.Now()
+	s.packets <- packet
+	s.offset += int64(len(packet.data))
+
+	err := s.writePacket(packet)
+	if err == nil {
+		s.event(WriteEvent{
+			Type:   typ,
+			Seqno:  int64(packet.seqno),
+			Offset: packet.offset,
+			Bytes:  len(packet.data),
+		})
+	}
+
+	return err
+}
+
+// stop shuts down a stream that failed, if finish hasn't already, and returns
+// the packets that weren't acked, if keepUnacked is set. The connection
+// must be closed first, so that the ack loop isn't left waiting on it. From
+// then on, the stream only returns errors.
+func (s *blockWriteStream) stop() []outboundPacket {
+	if !s.closed {
+		s.closed = true
+		close(s.closeCh)
+		close(s.packets)
+	}
+
+	<-s.acksDone
+	s.stopped = true
+	if s.ackError == nil {
+		s.ackError = io.ErrClosedPipe
+	}
+
+	unacked := s.unacked
+	s.unacked = nil
+	return unacked
+}
+
+// resume picks up where old, a stream that failed, left off: the packets it
+// didn't get acks for are sent again, and whatever it had buffered is carried
+// over. The stream must have been created at the offset old has acks up to,
+// and unacked is what old.stop returned. The last packet, if it's there, is
+// left for finish to send again, with the same seqno.
+func (s *blockWriteStream) resume(old *blockWriteStream, unacked []outboundPacket) error {
+	s.seqno = old.seqno
+	s.buf.Write(old.buf.Bytes())
+
+	// If writing one fails, the rest are still queued, so that they're kept
+	// along with it in case the pipeline is recovered again.
+	var err error
+	for _, p := range unacked {
+		if p.last {
+			s.seqno = p.seqno
+			continue
+		}
+
+		if queueErr := s.queue(p, PacketResent); err == nil {
+			err = queueErr
+		}
+	}
+
+	return err
+}
+
+func (s *blockWriteStream) event(ev WriteEvent) {
+	if s.events != nil {
+		s.events(ev)
+	}
+}
+
+// waitForAcks blocks until every packet written so far has been acknowledged
+// by the pipeline, or until acking fails.
+func (s *blockWriteStream) waitForAcks() error {
+	s.ackLock.Lock()
+	defer s.ackLock.Unlock()
+
+	for s.ackedOffset < s.offset && !s.ackStopped {
+		s.ackCond.Wait()
+	}
+
+	if s.ackedOffset >= s.offset {
+		return nil
+	} else if s.ackError != nil {
+		return s.ackError
+	}
+
+	return io.ErrClosedPipe
+}
+
+// acked returns the offset in the block up to which all data has been
+// acknowledged by the pipeline.
+func (s *blockWriteStream) acked() int64 {
+	s.ackLock.Lock()
+	defer s.ackLock.Unlock()
+
+	return s.ackedOffset
+}
+
+func (s *blockWriteStream) setAcked(offset int64, stopped bool) {
+	s.ackLock.Lock()
+	defer s.ackLock.Unlock()
+
+	if offset > s.ackedOffset {
+		s.ackedOffset = offset
+	}
+
+	s.ackStopped = s.ackStopped || stopped
+	s.ackCond.Broadcast()
+}
+
 func (s *blockWriteStream) makePacket() outboundPacket {
-	packetLength := outboundPacketSize
-	if s.buf.Len() < outboundPacketSize {
+	packetLength := s.packetSize
+	if s.buf.Len() < s.packetSize {
 		packetLength = s.buf.Len()
 	}
 
@@ -191,33 +494,59 @@ func (s *blockWriteStream) makePacket() outboundPacket {
 	// gets unhappy unless we first align to a chunk boundary with a small packet.
 	// Otherwise it yells at us with "a partial chunk must be sent in an
 	// individual packet" or just complains about a corrupted block.
-	alignment := int(s.offset) % outboundChunkSize
-	if alignment > 0 && packetLength > (outboundChunkSize-alignment) {
-		packetLength = outboundChunkSize - alignment
+	alignment := int(s.offset) % s.chunkSize
+	if alignment > 0 && packetLength > (s.chunkSize-alignment) {
+		packetLength = s.chunkSize - alignment
+	}
+
+	// Full-sized packets are the common case, so their buffers are pooled.
+	var data []byte
+	pooled := packetLength == s.packetSize
+	if pooled {
+		data = getPacketBuffer(packetLength)
+	} else {
+		data = make([]byte, packetLength)
 	}
 
-	numChunks := int(math.Ceil(float64(packetLength) / float64(outboundChunkSize)))
+	io.ReadFull(&s.buf, data)
+
+	packet := s.newPacket(data)
+	packet.pooled = pooled
+	return packet
+}
+
+// newPacket creates a packet with the given data, to be sent at the current
+// offset, and fills in the checksum for each chunk of it. The checksums of
+// full-sized packets are pooled, like their data.
+func (s *blockWriteStream) newPacket(data []byte) outboundPacket {
+	numChunks := int(math.Ceil(float64(len(data)) / float64(s.chunkSize)))
 	packet := outboundPacket{
-		seqno:     s.seqno,
-		offset:    s.offset,
-		last:      false,
-		checksums: make([]byte, numChunks*4),
-		data:      make([]byte, packetLength),
+		seqno:  s.seqno,
+		offset: s.offset,
+		last:   false,
+		data:   data,
 	}
 
-	// TODO: we shouldn't actually need this extra copy. We should also be able
-	// to "reuse" packets.
-	io.ReadFull(&s.buf, packet.data)
+	if len(data) == s.packetSize {
+		packet.checksums = getPacketBuffer(numChunks * 4)
+		packet.pooledChecksums = true
+	} else {
+		packet.checksums = make([]byte, numChunks*4)
+	}
+
+	tab := s.checksumTab
+	if tab == nil {
+		tab = crc32.IEEETable
+	}
 
-	// Fill in the checksum for each chunk of data.
 	for i := 0; i < numChunks; i++ {
-		chunkOff := i * outboundChunkSize
-		chunkEnd := chunkOff + outboundChunkSize
+		chunkOff := i * s.chunkSize
+		chunkEnd := chunkOff + s.chunkSize
 		if chunkEnd >= len(packet.data) {
 			chunkEnd = len(packet.data)
 		}
 
-		checksum := crc32.Checksum(packet.data[chunkOff:chunkEnd], crc32.IEEETable)
+		checksum := crc32.Checksum(packet.data[chunkOff:chunkEnd], tab)
 		binary.BigEndian.PutUint32(packet.checksums[i*4:], checksum)
 	}
 
@@ -227,22 +556,26 @@ func (s *blockWriteStream) makePacket() outboundPacket {
 // ackPackets is meant to run in the background, reading acks and setting
 // ackError if one fails.
 func (s *blockWriteStream) ackPackets() {
-	reader := bufio.NewReader(s.conn)
+	reader := s.ackReader
 
+	var p outboundPacket
+	var ok bool
 L:
 	for {
-		p, ok := <-s.packets
+		p, ok = <-s.packets
 		if !ok {
 			// All packets all acked.
+			s.setAcked(0, true)
 			return
 		}
 
 		var seqno int
+		var ack *hdfs.PipelineAckProto
 		for {
 			// If we fail to read the ack at all, that counts as a failure from the
 			// first datanode (the one we're connected to).
-			ack := &hdfs.PipelineAckProto{}
-			err := readPrefixedMessage(reader, ack)
+			ack = &hdfs.PipelineAckProto{}
+			err := s.readAck(reader, ack)
 			if err != nil {
 				s.ackError = err
 				break L
@@ -264,15 +597,109 @@ L:
 
 		if seqno != p.seqno {
 			s.ackError = ErrInvalidSeqno
+			if s.strict {
+				s.ackError = protocolError(s.pipeline[0], "pipeline ack",
+					fmt.Errorf("%w: expected %d, got %d", ErrInvalidSeqno, p.seqno, seqno))
+			}
+
 			break
 		}
+
+		s.setAcked(p.offset+int64(len(p.data)), false)
+		s.memory.releaseQueued(&s.queued, p.size())
+		p.release()
+
+		s.event(WriteEvent{
+			Type:              AckReceived,
+			Seqno:             int64(p.seqno),
+			Offset:            p.offset,
+			Bytes:             len(p.data),
+			Latency:           time.Since(p.sent),
+			DownstreamLatency: time.Duration(ack.GetDownstreamAckTimeNanos()),
+		})
 	}
 
+	// Wake up anyone waiting in waitForAcks; nothing else is going to be acked.
+	s.failedPacket(p)
+	s.setAcked(0, true)
+	s.ackFailed()
+
 	// Once we've seen an error, just keep reading packets off the channel (but
 	// not off the socket) until the writing thread figures it out. If we don't,
 	// the upstream thread could deadlock waiting for the channel to have space.
-	for _ = range s.packets {
+	for p := range s.packets {
+		s.failedPacket(p)
+	}
+}
+
+// failedPacket releases the memory held by a packet that won't be acked, and
+// keeps it in unacked, if keepUnacked is set.
+func (s *blockWriteStream) failedPacket(p outboundPacket) {
+	s.memory.releaseQueued(&s.queued, p.size())
+	if s.keepUnacked {
+		s.unacked = append(s.unacked, p)
+	}
+}
+
+// readAck reads an ack from the datanode, validating it in strict mode.
+func (s *blockWriteStream) readAck(r io.Reader, ack *hdfs.PipelineAckProto) error {
+	if !s.strict {
+		return readPrefixedMessage(r, ack)
+	}
+
+	err := readPrefixedMessageMax(r, maxDataTransferMessageLength, ack)
+	if err == nil {
+		if ack.GetSeqno() < heartBeatSeqno {
+			err = fmt.Errorf("negative seqno %d", ack.GetSeqno())
+		} else if len(ack.GetReply()) != len(s.pipeline) {
+			err = fmt.Errorf("%d replies for a pipeline of %d datanodes", len(ack.GetReply()), len(s.pipeline))
+		}
+	}
+
+	return protocolError(s.pipeline[0], "pipeline ack", err)
+}
+
+// ackFailed reports a PipelineFailed event for ackError. If the ack couldn't
+// be read at all, that's blamed on the first datanode.
+func (s *blockWriteStream) ackFailed() {
+	if s.events == nil {
+		return
+	}
+
+	index := s.failedIndex()
+	var failed string
+	if index < len(s.pipeline) {
+		failed = s.pipeline[index]
+	}
+
+	s.event(WriteEvent{Type: PipelineFailed, FailedDatanode: failed, Err: s.ackError})
+}
+
+// failedIndex returns the index in the pipeline of the datanode that caused
+// ackError: the one that sent a failed status, or, if the ack couldn't be
+// read at all, the first one.
+func (s *blockWriteStream) failedIndex() int {
+	if ae, ok := s.ackError.(ackError); ok {
+		return ae.pipelineIndex
 	}
+
+	return 0
+}
+
+// heartbeatFailed reports a HeartbeatFailed event for err, or, if there's no
+// hook to report it to, prints it.
+func (s *blockWriteStream) heartbeatFailed(err error) {
+	if s.events == nil {
+		fmt.Fprintf(os.Stderr, "hdfs datanode heartbeat error: %v\n", err)
+		return
+	}
+
+	var failed string
+	if len(s.pipeline) > 0 {
+		failed = s.pipeline[0]
+	}
+
+	s.event(WriteEvent{Type: HeartbeatFailed, FailedDatanode: failed, Err: err})
 }
 
 func (s *blockWriteStream) getAckError() error {
@@ -307,40 +734,39 @@ func (s *blockWriteStream) writePacket(p outboundPacket) error {
 		DataLen:           proto.Int32(int32(len(p.data))),
 	}
 
-	header := make([]byte, 6)
-	infoBytes, err := proto.Marshal(headerInfo)
+	if p.sync {
+		headerInfo.SyncBlock = proto.Bool(true)
+	}
+
+	// The header proto is marshaled after space for the lengths, into the same
+	// buffer every time.
+	s.header.SetBuf(append(s.headerBuf[:0], 0, 0, 0, 0, 0, 0))
+	err := s.header.Marshal(headerInfo)
 	if err != nil {
 		return err
 	}
 
+	header := s.header.Bytes()
+	s.headerBuf = header
+
 	// Don't ask me why this doesn't include the header proto...
 	totalLength := len(p.data) + len(p.checksums) + 4
 	binary.BigEndian.PutUint32(header, uint32(totalLength))
-	binary.BigEndian.PutUint16(header[4:], uint16(len(infoBytes)))
-	header = append(header, infoBytes...)
+	binary.BigEndian.PutUint16(header[4:], uint16(len(header)-6))
 
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
-	_, err = s.conn.Write(header)
-	if err != nil {
-		return err
-	}
-
-	_, err = s.conn.Write(p.checksums)
-	if err != nil {
-		return err
-	}
-
-	_, err = s.conn.Write(p.data)
-	if err != nil {
-		return err
-	}
-
-	return nil
+	// The data is written straight from the packet (and so, for writeNoCopy,
+	// from the caller's buffer), without copying it in with the header.
+	s.iov = [3][]byte{header, p.checksums, p.data}
+	iov := net.Buffers(s.iov[:])
+	_, err = iov.WriteTo(s.conn)
+	s.iov = [3][]byte{}
+	return err
 }
 
-//hadoop-hdfs-project/hadoop-hdfs-client/src/main/java/org/apache/hadoop/hdfs/DataStreamer.java:createHeartbeatPacket()
+// hadoop-hdfs-project/hadoop-hdfs-client/src/main/java/org/apache/hadoop/hdfs/DataStreamer.java:createHeartbeatPacket()
 func (s *blockWriteStream) writeHeartBeatPacket() error {
 	var o, h int64 = 0, heartBeatSeqno
 	l := false
@@ -369,14 +795,17 @@ func (s *blockWriteStream) writeHeartBeatPacket() error {
 	return err
 }
 
-func (s *blockWriteStream) sendHeartBeats() {
-	ticker := time.NewTicker(heartBeatTimeout)
+// sendHeartBeats sends a heartbeat packet every interval, until the stream is
+// closed, so that the datanodes don't time out the connection while the
+// writer is idle.
+func (s *blockWriteStream) sendHeartBeats(interval time.Duration) {
+	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
 	for {
 		select {
 		case <-ticker.C:
 			if err := s.writeHeartBeatPacket(); err != nil {
-				fmt.Fprintf(os.Stderr, "hdfs datanode heartbeat error: %v\n", err)
+				s.heartbeatFailed(err)
 			}
 		case <-s.closeCh:
 			return
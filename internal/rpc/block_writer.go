@@ -3,9 +3,11 @@ package rpc
 import (
 	"context"
 	"errors"
-	"fmt"
+	"hash/crc32"
 	"io"
 	"net"
+	"os"
+	"slices"
 	"time"
 
 	hdfs "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_hdfs"
@@ -31,24 +33,102 @@ type BlockWriter struct {
 	Offset int64
 	// Append indicates whether this is an append operation on an existing block.
 	Append bool
+	// UpdateBlockForPipeline, if set, gets a new generation stamp and access
+	// token for the block from the namenode, with the updateBlockForPipeline
+	// RPC. When appending, the pipeline is set up with the new generation
+	// stamp, as the Java client does, so that a replica that misses the
+	// appended data can be told apart from the others. It's also what allows
+	// the pipeline to be recovered when a datanode in it fails: the rest of
+	// the pipeline is set up again with a new generation stamp, and the
+	// packets that weren't acknowledged are sent again.
+	UpdateBlockForPipeline func(block *hdfs.ExtendedBlockProto) (*hdfs.LocatedBlockProto, error)
+	// UpdatePipeline, if set, tells the namenode the new generation stamp of
+	// the block and the datanodes in its pipeline, with the updatePipeline
+	// RPC, once a pipeline set up with a generation stamp from
+	// UpdateBlockForPipeline is connected. Block is then replaced with the
+	// updated block.
+	UpdatePipeline func(oldBlock, newBlock *hdfs.ExtendedBlockProto, pipeline []*hdfs.DatanodeInfoProto, storageIDs []string) error
+	// BytesPerChecksum is the number of bytes covered by each checksum sent
+	// to the datanode. If zero, 512 is used.
+	BytesPerChecksum int
+	// ChecksumType is the type of checksum sent to the datanode. If it's not
+	// CRC32C, CRC32 is used.
+	ChecksumType hdfs.ChecksumTypeProto
+	// WritePacketSize is the maximum size of the data packets sent to the
+	// datanode. If zero, 64KB is used. It's rounded down to a multiple of
+	// BytesPerChecksum, and capped so that whole packets fit in the datanode's
+	// limit of 16MB.
+	WritePacketSize int
+	// MaxPacketsInFlight is the most packets that can be sent to the datanode
+	// before it acks them. If zero, it's five for small packets, and fewer for
+	// large ones, so that no more than 32MB is waiting at once.
+	MaxPacketsInFlight int
+	// SyncBlock specifies that the datanodes should sync the block to disk
+	// once it's finished, rather than leaving it to the OS.
+	SyncBlock bool
 	// UseDatanodeHostname indicates whether the datanodes will be connected to
 	// via hostname (if true) or IP address (if false).
 	UseDatanodeHostname bool
 	// DialFunc is used to connect to the datanodes. If nil, then
 	// (&net.Dialer{}).DialContext is used.
 	DialFunc func(ctx context.Context, network, addr string) (net.Conn, error)
+	// HeartbeatInterval is how often heartbeat packets are sent to the
+	// datanodes while the writer is idle, so that they don't time out the
+	// connection. It should be less than the datanodes' dfs.client.socket-timeout.
+	// If zero, 30 seconds is used. If negative, no heartbeats are sent.
+	HeartbeatInterval time.Duration
+	// CircuitBreaker, if set, records whether connecting to the first datanode
+	// in the pipeline succeeds. It's up to the caller to exclude datanodes with
+	// open breakers when allocating blocks.
+	CircuitBreaker *CircuitBreaker
+	// Hook, if set, is called with events as the block is written: when the
+	// pipeline is built, when each packet is sent and acknowledged, and when
+	// the pipeline fails. Acks are reported from a background goroutine, so the
+	// hook must be safe to call concurrently with the writer, and should return
+	// quickly. Changing it only affects the next connection to the pipeline.
+	Hook func(WriteEvent)
+	// WireLog, if set, is used to log the data transfer requests and responses.
+	WireLog *WireLogger
+	// Strict specifies that the response and acks from the datanode should be
+	// validated as they're read, with a ProtocolError returned if they don't
+	// make sense.
+	Strict bool
+	// Buffers, if set, is used to reuse buffers from the previous block
+	// written, and is given this block's buffers once it's closed.
+	Buffers *BlockBuffers
+	// Memory, if set, limits the memory used by the packets waiting to be
+	// acked, across all the writers (and readers) sharing it.
+	Memory *MemoryLimiter
+	// ECPolicy is the erasure coding policy of the file, if it's erasure coded.
+	// In that case, Block is a striped block group, and BlockSize is the size
+	// of each internal block, so the group holds BlockSize times the number of
+	// data units. The data and parity are written to the internal blocks in
+	// parallel.
+	ECPolicy *hdfs.ErasureCodingPolicyProto
 
 	conn     net.Conn
 	deadline time.Time
 	stream   *blockWriteStream
+	striped  *stripedBlockWriter
 	closed   bool
+	recovery *pipelineRecovery
+}
+
+// pipelineRecovery describes the stream a pipeline is being recovered from.
+type pipelineRecovery struct {
+	// acked is the offset in the block up to which the whole pipeline
+	// acknowledged the data, and sent is how much was sent.
+	acked int64
+	sent  int64
 }
 
 // SetDeadline sets the deadline for future Write, Flush, and Close calls. A
 // zero value for t means those calls will not time out.
 func (bw *BlockWriter) SetDeadline(t time.Time) error {
 	bw.deadline = t
-	if bw.conn != nil {
+	if bw.striped != nil {
+		return bw.striped.setDeadline(t)
+	} else if bw.conn != nil {
 		return bw.conn.SetDeadline(t)
 	}
 
@@ -58,30 +138,93 @@ func (bw *BlockWriter) SetDeadline(t time.Time) error {
 
 // Write implements io.Writer.
 //
-// Unlike BlockReader, BlockWriter currently has no ability to recover from
-// write failures (timeouts, datanode failure, etc). Once it returns an error
-// from Write or Close, it may be in an invalid state.
-//
-// This will hopefully be fixed in a future release.
+// If a datanode in the pipeline fails, and UpdateBlockForPipeline is set, the
+// pipeline is recovered without it, as long as there's at least one datanode
+// left; datanodes aren't added to replace the ones that fail. Otherwise, or
+// for timeouts, or for striped block groups, once BlockWriter returns an error
+// from Write, Flush, or Close, it may be in an invalid state.
 func (bw *BlockWriter) Write(b []byte) (int, error) {
+	return bw.write(b, false)
+}
+
+// WriteNoCopy is like Write, but sends b to the datanode as-is wherever it
+// can, instead of copying it into an internal buffer first. The caller must
+// not modify b once it's been passed in.
+func (bw *BlockWriter) WriteNoCopy(b []byte) (int, error) {
+	return bw.write(b, true)
+}
+
+func (bw *BlockWriter) write(b []byte, noCopy bool) (int, error) {
+	blockSize := bw.BlockSize
+	if bw.ECPolicy != nil {
+		blockSize *= int64(bw.ECPolicy.GetSchema().GetDataUnits())
+	}
+
 	var blockFull bool
-	if bw.Offset >= bw.BlockSize {
+	if bw.Offset >= blockSize {
 		return 0, ErrEndOfBlock
-	} else if (bw.Offset + int64(len(b))) > bw.BlockSize {
+	} else if (bw.Offset + int64(len(b))) > blockSize {
 		blockFull = true
-		b = b[:bw.BlockSize-bw.Offset]
+		b = b[:blockSize-bw.Offset]
+	}
+
+	if bw.ECPolicy != nil {
+		return bw.writeStriped(b, blockFull)
 	}
 
 	if bw.stream == nil {
 		err := bw.connectNext()
-		// TODO: handle failures, set up recovery pipeline
 		if err != nil {
 			return 0, err
 		}
 	}
 
-	// TODO: handle failures, set up recovery pipeline
-	n, err := bw.stream.Write(b)
+	var n int
+	for {
+		var written int
+		var err error
+		if noCopy {
+			written, err = bw.stream.writeNoCopy(b[n:])
+		} else {
+			written, err = bw.stream.Write(b[n:])
+		}
+
+		n += written
+		bw.Offset += int64(written)
+		if err != nil {
+			err = bw.recoverPipeline(err)
+		}
+
+		if err != nil {
+			return n, err
+		} else if n == len(b) {
+			break
+		}
+	}
+
+	if blockFull {
+		return n, ErrEndOfBlock
+	}
+
+	return n, nil
+}
+
+// writeStriped writes to a striped block group.
+func (bw *BlockWriter) writeStriped(b []byte, blockFull bool) (int, error) {
+	if bw.Append {
+		return 0, errors.New("appending to a striped block group is not supported")
+	}
+
+	if bw.striped == nil {
+		striped, err := newStripedBlockWriter(bw)
+		if err != nil {
+			return 0, err
+		}
+
+		bw.striped = striped
+	}
+
+	n, err := bw.striped.Write(b)
 	bw.Offset += int64(n)
 	if err == nil && blockFull {
 		err = ErrEndOfBlock
@@ -90,28 +233,114 @@ func (bw *BlockWriter) Write(b []byte) (int, error) {
 	return n, err
 }
 
-// Flush flushes any unwritten packets out to the datanode.
+// Flush flushes any unwritten packets out to the datanode, and waits for
+// them to be acknowledged by the pipeline. Once it returns, the data is
+// visible to new readers.
+//
+// For striped block groups, Flush does nothing, since a partial stripe can't
+// be written out until it's complete.
 func (bw *BlockWriter) Flush() error {
-	if bw.stream != nil {
-		return bw.stream.flush(true)
+	for bw.stream != nil {
+		err := bw.stream.flush(true)
+		if err == nil {
+			err = bw.stream.waitForAcks()
+		}
+
+		if err == nil {
+			return nil
+		} else if err = bw.recoverPipeline(err); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Sync is like Flush, but additionally has the datanodes sync the data
+// written so far to disk, like hsync in the Java client.
+//
+// For striped block groups, a partial stripe isn't written out, like with
+// Flush, but the full stripes already written are synced.
+func (bw *BlockWriter) Sync() error {
+	if bw.striped != nil {
+		return bw.striped.sync()
+	}
+
+	for bw.stream != nil {
+		err := bw.stream.sync()
+		if err == nil {
+			err = bw.stream.waitForAcks()
+		}
+
+		if err == nil {
+			return nil
+		} else if err = bw.recoverPipeline(err); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
+// AckedOffset returns the offset in the block up to which all written data
+// has been acknowledged by every datanode in the pipeline.
+func (bw *BlockWriter) AckedOffset() int64 {
+	if bw.striped != nil {
+		return bw.striped.ackedOffset()
+	} else if bw.stream != nil {
+		return bw.stream.acked()
+	}
+
+	return bw.Offset
+}
+
+// SyncedLength returns the length of the block that Sync made durable, to be
+// reported to the namenode. For striped block groups, that's the length of the
+// block group up to the last full stripe, the same way its length is counted
+// when the block group is completed.
+func (bw *BlockWriter) SyncedLength() int64 {
+	if bw.striped != nil {
+		return bw.Offset - int64(bw.striped.stripeLength)
+	}
+
+	return bw.AckedOffset()
+}
+
+// Datanode returns the address of the first datanode in the write pipeline,
+// which is the one the BlockWriter sends data to. For striped block groups,
+// which are written to several datanodes at once, it's always empty.
+func (bw *BlockWriter) Datanode() string {
+	pipeline := bw.currentPipeline()
+	if len(pipeline) == 0 || bw.ECPolicy != nil {
+		return ""
+	}
+
+	return getDatanodeAddress(pipeline[0].GetId(), bw.UseDatanodeHostname)
+}
+
 // Close implements io.Closer. It flushes any unwritten packets out to the
 // datanode, and sends a final packet indicating the end of the block. The
 // block must still be finalized with the namenode.
 func (bw *BlockWriter) Close() error {
 	bw.closed = true
+	if bw.ECPolicy != nil {
+		if bw.striped == nil {
+			return nil
+		}
+
+		return bw.striped.Close()
+	}
+
 	if bw.conn != nil {
-		defer bw.conn.Close()
+		// The connection is replaced if the pipeline is recovered.
+		defer func() { bw.conn.Close() }()
 	}
 
-	if bw.stream != nil {
-		// TODO: handle failures, set up recovery pipeline
+	for bw.stream != nil {
 		err := bw.stream.finish()
-		if err != nil {
+		if err == nil {
+			return nil
+		} else if err = bw.recoverPipeline(err); err != nil {
 			return err
 		}
 	}
@@ -119,14 +348,197 @@ func (bw *BlockWriter) Close() error {
 	return nil
 }
 
+// recoverPipeline sets up the pipeline again after the stream fails with
+// err, without the datanode that failed, like the Java client does for
+// dfs.client.block.write.replace-datanode-on-failure.policy=NEVER. The block
+// gets a new generation stamp, so that the datanode left out can't report
+// its replica as valid, and the packets that weren't acknowledged are sent
+// again. It returns nil once the new pipeline is ready for the write to be
+// retried, or err if the pipeline can't be recovered.
+func (bw *BlockWriter) recoverPipeline(err error) error {
+	if !bw.canRecover(err) {
+		return err
+	}
+
+	old := bw.stream
+	bw.conn.Close()
+	unacked := old.stop()
+	failed := old.failedIndex()
+
+	bw.recovery = &pipelineRecovery{acked: old.acked(), sent: old.offset}
+	defer func() { bw.recovery = nil }()
+
+	for {
+		bw.removeDatanode(failed)
+		if len(bw.currentPipeline()) == 0 {
+			break
+		}
+
+		address, connectErr := bw.connectPipeline()
+		if connectErr == nil {
+			resumeErr := bw.stream.resume(old, unacked)
+			if resumeErr != nil {
+				return bw.recoverPipeline(resumeErr)
+			}
+
+			return nil
+		} else if address == "" || !recoverable(connectErr) {
+			err = connectErr
+			break
+		}
+
+		failed = indexOf(bw.pipelineAddresses(), address)
+	}
+
+	// If the pipeline was set up, but the namenode couldn't be told about it,
+	// it's no use either. The old stream is left to keep returning its error.
+	if bw.stream != old {
+		bw.stream.stop()
+		bw.stream = old
+	}
+
+	for _, p := range unacked {
+		p.release()
+	}
+
+	return err
+}
+
+// canRecover returns true if the pipeline can be recovered from err. Once
+// recovering it fails, the stream is left stopped, and it isn't tried again.
+func (bw *BlockWriter) canRecover(err error) bool {
+	return bw.UpdateBlockForPipeline != nil && bw.ECPolicy == nil &&
+		bw.stream != nil && !bw.stream.stopped &&
+		len(bw.currentPipeline()) > 1 && recoverable(err)
+}
+
+// recoverable returns false for the errors that recovering the pipeline
+// won't help with, because the deadline passed or the write was canceled.
+func recoverable(err error) bool {
+	return !errors.Is(err, os.ErrDeadlineExceeded) &&
+		!errors.Is(err, context.DeadlineExceeded) &&
+		!errors.Is(err, context.Canceled)
+}
+
+// removeDatanode takes the datanode at index i out of the pipeline, along
+// with its storage.
+func (bw *BlockWriter) removeDatanode(i int) {
+	block := proto.Clone(bw.Block).(*hdfs.LocatedBlockProto)
+	if i < 0 || i >= len(block.Locs) {
+		i = 0
+	}
+
+	if len(block.StorageIDs) == len(block.Locs) {
+		block.StorageIDs = slices.Delete(block.StorageIDs, i, i+1)
+	}
+
+	if len(block.StorageTypes) == len(block.Locs) {
+		block.StorageTypes = slices.Delete(block.StorageTypes, i, i+1)
+	}
+
+	if len(block.IsCached) == len(block.Locs) {
+		block.IsCached = slices.Delete(block.IsCached, i, i+1)
+	}
+
+	block.Locs = slices.Delete(block.Locs, i, i+1)
+	bw.Block = block
+}
+
+// indexOf returns the index of address in pipeline, or -1.
+func indexOf(pipeline []string, address string) int {
+	for i, addr := range pipeline {
+		if addr == address {
+			return i
+		}
+	}
+
+	return -1
+}
+
 func (bw *BlockWriter) connectNext() error {
-	address := getDatanodeAddress(bw.currentPipeline()[0].GetId(), bw.UseDatanodeHostname)
+	_, err := bw.connectPipeline()
+	return err
+}
+
+// connectPipeline connects to the first datanode in the pipeline, and sets up
+// the rest. If that fails because of a datanode, its address is returned
+// along with the error.
+func (bw *BlockWriter) connectPipeline() (string, error) {
+	var updated *hdfs.LocatedBlockProto
+	if (bw.Append || bw.recovery != nil) && bw.UpdateBlockForPipeline != nil {
+		var err error
+		updated, err = bw.UpdateBlockForPipeline(bw.Block.GetB())
+		if err != nil {
+			return "", err
+		}
+	}
+
+	dn := bw.currentPipeline()[0].GetId()
+	pipeline := bw.pipelineAddresses()
+	address := pipeline[0]
+
+	err := bw.connect(dn, pipeline, updated)
+	if err == nil {
+		bw.CircuitBreaker.recordSuccess(address)
+		if updated != nil {
+			err = bw.updatePipeline(updated)
+			if err != nil {
+				bw.conn.Close()
+			}
+		}
+
+		return "", err
+	} else if !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded) {
+		bw.CircuitBreaker.recordFailure(address, dn)
+	}
+
+	// If a datanode further down the pipeline failed, the first one tells us
+	// which.
+	failed := address
+	var dnErr *DatanodeError
+	if errors.As(err, &dnErr) && dnErr.firstBadLink != "" {
+		failed = dnErr.firstBadLink
+	}
+
+	bw.failedEvent(pipeline, failed, err)
+	return failed, err
+}
+
+// updatePipeline tells the namenode that the block has the generation stamp
+// of updated, and is being written to the current pipeline, and then replaces
+// bw.Block with it.
+func (bw *BlockWriter) updatePipeline(updated *hdfs.LocatedBlockProto) error {
+	oldBlock := bw.Block.GetB()
+	newBlock := proto.Clone(oldBlock).(*hdfs.ExtendedBlockProto)
+	newBlock.GenerationStamp = proto.Uint64(updated.GetB().GetGenerationStamp())
+	newBlock.NumBytes = proto.Uint64(uint64(bw.Offset))
+	if bw.recovery != nil {
+		newBlock.NumBytes = proto.Uint64(uint64(bw.recovery.acked))
+	}
+
+	if bw.UpdatePipeline != nil {
+		err := bw.UpdatePipeline(oldBlock, newBlock, bw.currentPipeline(), bw.Block.GetStorageIDs())
+		if err != nil {
+			return err
+		}
+	}
+
+	block := proto.Clone(bw.Block).(*hdfs.LocatedBlockProto)
+	block.B = newBlock
+	block.BlockToken = updated.GetBlockToken()
+	bw.Block = block
+	return nil
+}
 
+// connect sets up the pipeline, starting with dn. If updated is set, it's the
+// block with the new generation stamp and token to set it up with.
+func (bw *BlockWriter) connect(dn *hdfs.DatanodeIDProto, pipeline []string, updated *hdfs.LocatedBlockProto) error {
 	if bw.DialFunc == nil {
 		bw.DialFunc = (&net.Dialer{}).DialContext
 	}
 
-	conn, err := bw.DialFunc(context.Background(), "tcp", address)
+	start := time.Now()
+	conn, err := dialDatanode(context.Background(), bw.DialFunc, dn, bw.UseDatanodeHostname)
 	if err != nil {
 		return err
 	}
@@ -136,45 +548,106 @@ func (bw *BlockWriter) connectNext() error {
 		return err
 	}
 
-	err = bw.writeBlockWriteRequest(conn)
+	err = bw.writeBlockWriteRequest(conn, updated)
 	if err != nil {
 		return err
 	}
 
-	resp, err := readBlockOpResponse(conn)
+	var max uint64
+	if bw.Strict {
+		max = maxDataTransferMessageLength
+	}
+
+	resp, err := bw.WireLog.readBlockOpResponse(conn, max)
+	if bw.Strict {
+		err = protocolError(pipeline[0], "write response", err)
+	}
+
 	if err != nil {
 		return err
 	} else if resp.GetStatus() != hdfs.Status_SUCCESS {
-		return fmt.Errorf("write failed: %s (%s)", resp.GetStatus().String(), resp.GetMessage())
+		return newDatanodeError("write", resp)
+	}
+
+	// A recovered pipeline starts from the last acknowledged packet.
+	offset := bw.Offset
+	if bw.recovery != nil {
+		offset = bw.recovery.acked
 	}
 
 	bw.conn = conn
-	bw.stream = newBlockWriteStream(conn, bw.Offset)
+	bw.stream = newBlockWriteStreamBuffers(conn, offset, bw.chunkSize(), bw.packetSize(), bw.MaxPacketsInFlight, bw.Buffers)
+	bw.stream.memory = bw.Memory
+	bw.stream.keepUnacked = bw.UpdateBlockForPipeline != nil && bw.ECPolicy == nil
+	bw.stream.syncBlock = bw.SyncBlock
+	if bw.checksumType() == hdfs.ChecksumTypeProto_CHECKSUM_CRC32C {
+		bw.stream.checksumTab = crc32.MakeTable(crc32.Castagnoli)
+	}
+	bw.stream.strict = bw.Strict
+	bw.stream.pipeline = pipeline
+	bw.startEvents(bw.stream, pipeline)
+	bw.stream.event(WriteEvent{Type: PipelineBuilt, Latency: time.Since(start)})
+	if interval := bw.heartbeatInterval(); interval > 0 {
+		go bw.stream.sendHeartBeats(interval)
+	}
+
 	return nil
 }
 
-func (bw *BlockWriter) currentPipeline() []*hdfs.DatanodeInfoProto {
-	// TODO: we need to be able to reconfigure the pipeline when a node fails.
-	//
-	// targets := make([]*hdfs.DatanodeInfoProto, 0, len(br.pipeline))
-	// for _, loc := range s.block.GetLocs() {
-	// 	addr := getDatanodeAddress(loc)
-	// 	for _, pipelineAddr := range br.pipeline {
-	// 		if ipAddr == addr {
-	// 			append(targets, loc)
-	// 		}
-	// 	}
-	// }
-	//
-	// return targets
+func (bw *BlockWriter) heartbeatInterval() time.Duration {
+	if bw.HeartbeatInterval == 0 {
+		return heartBeatInterval
+	}
+
+	return bw.HeartbeatInterval
+}
 
+func (bw *BlockWriter) chunkSize() int {
+	if bw.BytesPerChecksum > 0 {
+		return bw.BytesPerChecksum
+	}
+
+	return outboundChunkSize
+}
+
+func (bw *BlockWriter) checksumType() hdfs.ChecksumTypeProto {
+	if bw.ChecksumType == hdfs.ChecksumTypeProto_CHECKSUM_CRC32C {
+		return bw.ChecksumType
+	}
+
+	return hdfs.ChecksumTypeProto_CHECKSUM_CRC32
+}
+
+func (bw *BlockWriter) packetSize() int {
+	if bw.WritePacketSize <= 0 {
+		return outboundPacketSize
+	}
+
+	// Packets have to be made of whole chunks, each with a 4-byte checksum, and
+	// there needs to be room for the header.
+	chunkSize := bw.chunkSize()
+	chunks := bw.WritePacketSize / chunkSize
+	if max := (maxPacketSize - maxPacketHeaderLength) / (chunkSize + 4); chunks > max {
+		chunks = max
+	} else if chunks < 1 {
+		chunks = 1
+	}
+
+	return chunks * chunkSize
+}
+
+// currentPipeline returns the datanodes in the pipeline. The ones that fail
+// are taken out of Block when the pipeline is recovered.
+func (bw *BlockWriter) currentPipeline() []*hdfs.DatanodeInfoProto {
 	return bw.Block.GetLocs()
 }
 
 func (bw *BlockWriter) currentStage() hdfs.OpWriteBlockProto_BlockConstructionStage {
-	// TODO: this should be PIPELINE_SETUP_STREAMING_RECOVERY or
-	// PIPELINE_SETUP_APPEND_RECOVERY for recovery.
-	if bw.Append {
+	// Data has been streamed to the pipeline being recovered, whether it was
+	// set up to create the block or to append to it.
+	if bw.recovery != nil {
+		return hdfs.OpWriteBlockProto_PIPELINE_SETUP_STREAMING_RECOVERY
+	} else if bw.Append {
 		return hdfs.OpWriteBlockProto_PIPELINE_SETUP_APPEND
 	}
 
@@ -198,30 +671,45 @@ func (bw *BlockWriter) generationTimestamp() int64 {
 // the block's expected size. The field "MaxBytesRcvd" is used only in the case
 // of PIPELINE_SETUP_STREAMING_RECOVERY.
 //
+// If updated is set, the block keeps its current generation stamp in the
+// header, and the one from updated is sent as the latest, along with its
+// token.
+//
 // See: https://github.com/apache/hadoop/blob/6314843881b4c67d08215e60293f8b33242b9416/hadoop-hdfs-project/hadoop-hdfs/src/main/java/org/apache/hadoop/hdfs/server/datanode/BlockReceiver.java#L216
 // And: https://github.com/apache/hadoop/blob/6314843881b4c67d08215e60293f8b33242b9416/hadoop-hdfs-project/hadoop-hdfs/src/main/java/org/apache/hadoop/hdfs/server/datanode/fsdataset/impl/FsDatasetImpl.java#L1462
-func (bw *BlockWriter) writeBlockWriteRequest(w io.Writer) error {
+func (bw *BlockWriter) writeBlockWriteRequest(w io.Writer, updated *hdfs.LocatedBlockProto) error {
 	targets := bw.currentPipeline()[1:]
+	token := bw.Block.GetBlockToken()
+	generationStamp := uint64(bw.generationTimestamp())
+	if updated != nil {
+		token = updated.GetBlockToken()
+		generationStamp = updated.GetB().GetGenerationStamp()
+	}
+
+	minBytes, maxBytes := bw.Block.GetB().GetNumBytes(), uint64(bw.Offset)
+	if bw.recovery != nil {
+		minBytes, maxBytes = uint64(bw.recovery.acked), uint64(bw.recovery.sent)
+	}
 
 	op := &hdfs.OpWriteBlockProto{
 		Header: &hdfs.ClientOperationHeaderProto{
 			BaseHeader: &hdfs.BaseHeaderProto{
 				Block: bw.Block.GetB(),
-				Token: bw.Block.GetBlockToken(),
+				Token: token,
 			},
 			ClientName: proto.String(bw.ClientName),
 		},
 		Targets:               targets,
 		Stage:                 bw.currentStage().Enum(),
 		PipelineSize:          proto.Uint32(uint32(len(targets))),
-		MinBytesRcvd:          proto.Uint64(bw.Block.GetB().GetNumBytes()),
-		MaxBytesRcvd:          proto.Uint64(uint64(bw.Offset)),
-		LatestGenerationStamp: proto.Uint64(uint64(bw.generationTimestamp())),
+		MinBytesRcvd:          proto.Uint64(minBytes),
+		MaxBytesRcvd:          proto.Uint64(maxBytes),
+		LatestGenerationStamp: proto.Uint64(generationStamp),
 		RequestedChecksum: &hdfs.ChecksumProto{
-			Type:             hdfs.ChecksumTypeProto_CHECKSUM_CRC32.Enum(),
-			BytesPerChecksum: proto.Uint32(outboundChunkSize),
+			Type:             bw.checksumType().Enum(),
+			BytesPerChecksum: proto.Uint32(uint32(bw.chunkSize())),
 		},
 	}
 
-	return writeBlockOpRequest(w, writeBlockOp, op)
+	return bw.WireLog.writeBlockOpRequest(w, writeBlockOp, op)
 }
@@ -1,13 +1,26 @@
 package rpc
 
 import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"io"
+	"net"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	hdfs "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_hdfs"
+	"github.com/golang/protobuf/proto"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestPacketSize(t *testing.T) {
-	bws := &blockWriteStream{}
+	bws := &blockWriteStream{chunkSize: outboundChunkSize, packetSize: outboundPacketSize}
 	bws.buf.Write(make([]byte, outboundPacketSize*3))
 	packet := bws.makePacket()
 
@@ -15,7 +28,7 @@ func TestPacketSize(t *testing.T) {
 }
 
 func TestPacketSizeUndersize(t *testing.T) {
-	bws := &blockWriteStream{}
+	bws := &blockWriteStream{chunkSize: outboundChunkSize, packetSize: outboundPacketSize}
 	bws.buf.Write(make([]byte, outboundPacketSize-5))
 	packet := bws.makePacket()
 
@@ -23,7 +36,7 @@ func TestPacketSizeUndersize(t *testing.T) {
 }
 
 func TestPacketSizeAlignment(t *testing.T) {
-	bws := &blockWriteStream{}
+	bws := &blockWriteStream{chunkSize: outboundChunkSize, packetSize: outboundPacketSize}
 	bws.buf.Write(make([]byte, outboundPacketSize*3))
 
 	bws.offset = 5
@@ -31,3 +44,748 @@ func TestPacketSizeAlignment(t *testing.T) {
 
 	assert.EqualValues(t, outboundChunkSize-5, len(packet.data))
 }
+
+func TestBlockWriterPacketSize(t *testing.T) {
+	bw := &BlockWriter{}
+	assert.Equal(t, outboundPacketSize, bw.packetSize())
+
+	bw.WritePacketSize = 1024*1024 + 100
+	assert.Equal(t, 1024*1024, bw.packetSize())
+
+	bw.WritePacketSize = 100
+	assert.Equal(t, outboundChunkSize, bw.packetSize())
+
+	// The checksums and header have to fit, too.
+	bw.WritePacketSize = 64 * 1024 * 1024
+	size := bw.packetSize()
+	assert.True(t, size < maxPacketSize)
+	assert.True(t, size+(size/outboundChunkSize)*4+maxPacketHeaderLength <= maxPacketSize)
+	assert.Equal(t, 0, size%outboundChunkSize)
+}
+
+func TestPacketQueueLength(t *testing.T) {
+	assert.Equal(t, maxPacketsInQueue, packetQueueLength(outboundPacketSize, 0))
+	assert.Equal(t, 4, packetQueueLength(8*1024*1024, 0))
+	assert.Equal(t, 2, packetQueueLength(maxPacketSize, 0))
+	assert.Equal(t, 80, packetQueueLength(outboundPacketSize, 80))
+	assert.Equal(t, 1, packetQueueLength(maxPacketSize, 1))
+}
+
+func TestWritePooledPacketChecksums(t *testing.T) {
+	client, server := net.Pipe()
+	packets := make(chan outboundPacket, 100)
+	go recordingDatanode(server, hdfs.Status_SUCCESS, packets)
+
+	data := make([]byte, outboundPacketSize*20+700)
+	for i := range data {
+		data[i] = byte(i % 253)
+	}
+
+	// The full-sized packets reuse the checksum buffers of the ones already
+	// acked, and writeNoCopy sends data straight from b.
+	bws := newBlockWriteStreamBuffers(client, 0, outboundChunkSize, outboundPacketSize, 1, nil)
+	assert.Equal(t, 1, cap(bws.packets))
+	_, err := bws.Write(data[:outboundPacketSize*10+100])
+	require.NoError(t, err)
+	_, err = bws.writeNoCopy(data[outboundPacketSize*10+100:])
+	require.NoError(t, err)
+	require.NoError(t, bws.finish())
+	client.Close()
+
+	var received []byte
+	for p := range packets {
+		for i := 0; i < len(p.data); i += outboundChunkSize {
+			end := i + outboundChunkSize
+			if end > len(p.data) {
+				end = len(p.data)
+			}
+
+			checksum := binary.BigEndian.Uint32(p.checksums[i/outboundChunkSize*4:])
+			require.Equal(t, crc32.ChecksumIEEE(p.data[i:end]), checksum, "packet %d, chunk %d", p.seqno, i/outboundChunkSize)
+		}
+
+		received = append(received, p.data...)
+	}
+
+	assert.Equal(t, data, received)
+}
+
+func TestWriteLargePackets(t *testing.T) {
+	client, server := net.Pipe()
+	packets := make(chan outboundPacket, 100)
+	go recordingDatanode(server, hdfs.Status_SUCCESS, packets)
+
+	packetSize := 4 * 1024 * 1024
+	data := make([]byte, packetSize*3+1000)
+	for i := range data {
+		data[i] = byte(i % 251)
+	}
+
+	bws := newBlockWriteStream(client, 0, outboundChunkSize, packetSize)
+	for off := 0; off < len(data); off += 100000 {
+		end := off + 100000
+		if end > len(data) {
+			end = len(data)
+		}
+
+		_, err := bws.Write(data[off:end])
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, bws.finish())
+	client.Close()
+
+	var received []byte
+	var sizes []int
+	for p := range packets {
+		received = append(received, p.data...)
+		sizes = append(sizes, len(p.data))
+	}
+
+	assert.Equal(t, []int{packetSize, packetSize, packetSize, 1000, 0}, sizes)
+	assert.Equal(t, data, received)
+}
+
+// fakeDatanode reads packets off of conn, and acks each of them with the
+// given status.
+func fakeDatanode(conn net.Conn, status hdfs.Status) {
+	recordingDatanode(conn, status, nil)
+}
+
+// recordingDatanode is like fakeDatanode, but also sends each packet it reads
+// to packets, if it's not nil. The channel is closed once conn is.
+func recordingDatanode(conn net.Conn, status hdfs.Status, packets chan<- outboundPacket) {
+	defer conn.Close()
+	if packets != nil {
+		defer close(packets)
+	}
+
+	for {
+		lengthBytes := make([]byte, 6)
+		_, err := io.ReadFull(conn, lengthBytes)
+		if err != nil {
+			return
+		}
+
+		packetLength := int(binary.BigEndian.Uint32(lengthBytes))
+		headerLength := int(binary.BigEndian.Uint16(lengthBytes[4:]))
+		headerBytes := make([]byte, headerLength)
+		_, err = io.ReadFull(conn, headerBytes)
+		if err != nil {
+			return
+		}
+
+		header := &hdfs.PacketHeaderProto{}
+		err = proto.Unmarshal(headerBytes, header)
+		if err != nil {
+			return
+		}
+
+		body := make([]byte, packetLength-4)
+		_, err = io.ReadFull(conn, body)
+		if err != nil {
+			return
+		}
+
+		if packets != nil && header.GetSeqno() != heartBeatSeqno {
+			dataLen := int(header.GetDataLen())
+			packets <- outboundPacket{
+				seqno:     int(header.GetSeqno()),
+				offset:    header.GetOffsetInBlock(),
+				last:      header.GetLastPacketInBlock(),
+				sync:      header.GetSyncBlock(),
+				checksums: body[:len(body)-dataLen],
+				data:      body[len(body)-dataLen:],
+			}
+		}
+
+		ack := &hdfs.PipelineAckProto{
+			Seqno: header.Seqno,
+			Reply: []hdfs.Status{status},
+		}
+
+		b, _ := makePrefixedMessage(ack)
+		_, err = conn.Write(b)
+		if err != nil {
+			return
+		}
+	}
+}
+
+func TestWaitForAcks(t *testing.T) {
+	client, server := net.Pipe()
+	go fakeDatanode(server, hdfs.Status_SUCCESS)
+
+	bws := newBlockWriteStream(client, 0, outboundChunkSize, outboundPacketSize)
+	_, err := bws.Write(make([]byte, outboundPacketSize+10))
+	require.NoError(t, err)
+
+	require.NoError(t, bws.flush(true))
+	require.NoError(t, bws.waitForAcks())
+	assert.EqualValues(t, outboundPacketSize+10, bws.acked())
+
+	require.NoError(t, bws.finish())
+}
+
+func TestWaitForAcksError(t *testing.T) {
+	client, server := net.Pipe()
+	go fakeDatanode(server, hdfs.Status_ERROR)
+
+	bws := newBlockWriteStream(client, 0, outboundChunkSize, outboundPacketSize)
+	_, err := bws.Write(make([]byte, 10))
+	require.NoError(t, err)
+
+	require.NoError(t, bws.flush(true))
+	assert.Error(t, bws.waitForAcks())
+	assert.EqualValues(t, 0, bws.acked())
+}
+
+func TestSync(t *testing.T) {
+	client, server := net.Pipe()
+	packets := make(chan outboundPacket, 10)
+	go recordingDatanode(server, hdfs.Status_SUCCESS, packets)
+
+	bws := newBlockWriteStream(client, 0, outboundChunkSize, outboundPacketSize)
+	_, err := bws.Write(make([]byte, outboundPacketSize+10))
+	require.NoError(t, err)
+
+	require.NoError(t, bws.sync())
+	require.NoError(t, bws.waitForAcks())
+	assert.EqualValues(t, outboundPacketSize+10, bws.acked())
+
+	first, second := <-packets, <-packets
+	assert.Equal(t, outboundPacketSize, len(first.data))
+	assert.False(t, first.sync)
+	assert.Equal(t, 10, len(second.data))
+	assert.True(t, second.sync)
+
+	// With nothing buffered, an empty packet carries the flag.
+	require.NoError(t, bws.sync())
+	require.NoError(t, bws.waitForAcks())
+	empty := <-packets
+	assert.Equal(t, 0, len(empty.data))
+	assert.True(t, empty.sync)
+
+	require.NoError(t, bws.finish())
+}
+
+func TestWriteNoCopy(t *testing.T) {
+	client, server := net.Pipe()
+	packets := make(chan outboundPacket, 100)
+	go recordingDatanode(server, hdfs.Status_SUCCESS, packets)
+
+	data := make([]byte, outboundPacketSize*3+1000)
+	for i := range data {
+		data[i] = byte(i % 251)
+	}
+
+	// Start with something already buffered and a partial chunk, so that
+	// writeNoCopy has to align the rest of the data itself.
+	bws := newBlockWriteStream(client, 0, outboundChunkSize, outboundPacketSize)
+	_, err := bws.Write(data[:5])
+	require.NoError(t, err)
+
+	n, err := bws.writeNoCopy(data[5 : len(data)-10])
+	require.NoError(t, err)
+	assert.Equal(t, len(data)-15, n)
+
+	n, err = bws.writeNoCopy(data[len(data)-10:])
+	require.NoError(t, err)
+	assert.Equal(t, 10, n)
+
+	require.NoError(t, bws.finish())
+	client.Close()
+
+	var received []byte
+	for p := range packets {
+		require.EqualValues(t, len(received), p.offset)
+		require.True(t, len(p.data) <= outboundPacketSize)
+		received = append(received, p.data...)
+
+		for i := 0; i*outboundChunkSize < len(p.data); i++ {
+			end := (i + 1) * outboundChunkSize
+			if end > len(p.data) {
+				end = len(p.data)
+			}
+
+			checksum := crc32.Checksum(p.data[i*outboundChunkSize:end], crc32.IEEETable)
+			assert.Equal(t, checksum, binary.BigEndian.Uint32(p.checksums[i*4:]))
+		}
+
+		// A packet that doesn't end on a chunk boundary has to be the last one.
+		if !p.last && (p.offset+int64(len(p.data)))%outboundChunkSize != 0 {
+			assert.EqualValues(t, len(data), p.offset+int64(len(p.data)))
+		}
+	}
+
+	assert.True(t, bytes.Equal(data, received))
+}
+
+func TestWriteCRC32C(t *testing.T) {
+	client, server := net.Pipe()
+	packets := make(chan outboundPacket, 10)
+	go recordingDatanode(server, hdfs.Status_SUCCESS, packets)
+
+	bws := newBlockWriteStream(client, 0, outboundChunkSize, outboundPacketSize)
+	bws.checksumTab = crc32.MakeTable(crc32.Castagnoli)
+
+	data := []byte(strings.Repeat("foo", 1000))
+	_, err := bws.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, bws.finish())
+
+	p := <-packets
+	checksum := crc32.Checksum(p.data[:outboundChunkSize], crc32.MakeTable(crc32.Castagnoli))
+	assert.Equal(t, checksum, binary.BigEndian.Uint32(p.checksums))
+}
+
+func TestBlockWriterChecksumType(t *testing.T) {
+	bw := &BlockWriter{}
+	assert.Equal(t, hdfs.ChecksumTypeProto_CHECKSUM_CRC32, bw.checksumType())
+
+	bw.ChecksumType = hdfs.ChecksumTypeProto_CHECKSUM_CRC32C
+	assert.Equal(t, hdfs.ChecksumTypeProto_CHECKSUM_CRC32C, bw.checksumType())
+}
+
+func TestHeartbeats(t *testing.T) {
+	client, server := net.Pipe()
+	seqnos := make(chan int64, 1)
+	go func() {
+		// Read the first packet, which should be a heartbeat, and ack it.
+		lengthBytes := make([]byte, 6)
+		if _, err := io.ReadFull(server, lengthBytes); err != nil {
+			close(seqnos)
+			return
+		}
+
+		packetLength := int(binary.BigEndian.Uint32(lengthBytes))
+		headerBytes := make([]byte, int(binary.BigEndian.Uint16(lengthBytes[4:])))
+		io.ReadFull(server, headerBytes)
+		io.ReadFull(server, make([]byte, packetLength-4))
+
+		header := &hdfs.PacketHeaderProto{}
+		proto.Unmarshal(headerBytes, header)
+		seqnos <- header.GetSeqno()
+
+		ack, _ := makePrefixedMessage(&hdfs.PipelineAckProto{
+			Seqno: header.Seqno,
+			Reply: []hdfs.Status{hdfs.Status_SUCCESS},
+		})
+		server.Write(ack)
+
+		fakeDatanode(server, hdfs.Status_SUCCESS)
+	}()
+
+	bws := newBlockWriteStream(client, 0, outboundChunkSize, outboundPacketSize)
+	go bws.sendHeartBeats(10 * time.Millisecond)
+
+	select {
+	case seqno := <-seqnos:
+		assert.EqualValues(t, heartBeatSeqno, seqno)
+	case <-time.After(5 * time.Second):
+		t.Fatal("No heartbeat sent")
+	}
+
+	require.NoError(t, bws.finish())
+}
+
+func TestHeartbeatIntervalDefault(t *testing.T) {
+	assert.Equal(t, heartBeatInterval, (&BlockWriter{}).heartbeatInterval())
+	assert.Equal(t, -time.Second, (&BlockWriter{HeartbeatInterval: -time.Second}).heartbeatInterval())
+}
+
+// eventRecorder collects the events sent to a write hook.
+type eventRecorder struct {
+	lock   sync.Mutex
+	events []WriteEvent
+}
+
+func (er *eventRecorder) hook(ev WriteEvent) {
+	er.lock.Lock()
+	defer er.lock.Unlock()
+
+	er.events = append(er.events, ev)
+}
+
+func (er *eventRecorder) ofType(typ WriteEventType) []WriteEvent {
+	er.lock.Lock()
+	defer er.lock.Unlock()
+
+	var events []WriteEvent
+	for _, ev := range er.events {
+		if ev.Type == typ {
+			events = append(events, ev)
+		}
+	}
+
+	return events
+}
+
+func TestWriteEvents(t *testing.T) {
+	client, server := net.Pipe()
+	go fakeDatanode(server, hdfs.Status_SUCCESS)
+
+	er := &eventRecorder{}
+	bws := newBlockWriteStream(client, 0, outboundChunkSize, outboundPacketSize)
+	bws.events = er.hook
+
+	_, err := bws.Write(make([]byte, outboundPacketSize+10))
+	require.NoError(t, err)
+	require.NoError(t, bws.finish())
+
+	// Two data packets, and then the empty last packet.
+	sent := er.ofType(PacketSent)
+	acked := er.ofType(AckReceived)
+	require.Len(t, sent, 3)
+	require.Len(t, acked, 3)
+	assert.Empty(t, er.ofType(PipelineFailed))
+
+	for i, bytes := range []int{outboundPacketSize, 10, 0} {
+		assert.EqualValues(t, i+1, sent[i].Seqno)
+		assert.Equal(t, bytes, sent[i].Bytes)
+		assert.Equal(t, sent[i].Seqno, acked[i].Seqno)
+		assert.Equal(t, sent[i].Offset, acked[i].Offset)
+		assert.True(t, acked[i].Latency > 0)
+	}
+}
+
+func TestWriteEventsAckError(t *testing.T) {
+	client, server := net.Pipe()
+	go fakeDatanode(server, hdfs.Status_ERROR)
+
+	er := &eventRecorder{}
+	bws := newBlockWriteStream(client, 0, outboundChunkSize, outboundPacketSize)
+	bws.events = er.hook
+	bws.pipeline = []string{"dn1:9866"}
+
+	_, err := bws.Write(make([]byte, 10))
+	require.NoError(t, err)
+	require.NoError(t, bws.flush(true))
+	require.Error(t, bws.waitForAcks())
+
+	failed := er.ofType(PipelineFailed)
+	require.Len(t, failed, 1)
+	assert.Equal(t, "dn1:9866", failed[0].FailedDatanode)
+	assert.Equal(t, ackError{status: hdfs.Status_ERROR, seqno: 1}, failed[0].Err)
+	assert.Empty(t, er.ofType(AckReceived))
+}
+
+func TestWriteEventsFirstBadLink(t *testing.T) {
+	block := testBlock("dn1", "dn2")
+	block.B.NumBytes = proto.Uint64(0)
+	for _, loc := range block.Locs {
+		loc.Id.InfoPort = proto.Uint32(9864)
+		loc.Id.IpcPort = proto.Uint32(9867)
+	}
+
+	dial := func(ctx context.Context, network, addr string) (net.Conn, error) {
+		client, server := net.Pipe()
+		go func() {
+			defer server.Close()
+			io.ReadFull(server, make([]byte, 3))
+			readPrefixedMessage(server, &hdfs.OpWriteBlockProto{})
+
+			b, _ := makePrefixedMessage(&hdfs.BlockOpResponseProto{
+				Status:       hdfs.Status_ERROR.Enum(),
+				FirstBadLink: proto.String("dn2:9866"),
+			})
+			server.Write(b)
+		}()
+
+		return client, nil
+	}
+
+	er := &eventRecorder{}
+	bw := &BlockWriter{
+		Block:     block,
+		BlockSize: 1024,
+		DialFunc:  dial,
+		Hook:      er.hook,
+	}
+
+	_, err := bw.Write([]byte("foo"))
+	require.Error(t, err)
+
+	failed := er.ofType(PipelineFailed)
+	require.Len(t, failed, 1)
+	assert.Equal(t, []string{"dn1:9866", "dn2:9866"}, failed[0].Pipeline)
+	assert.Equal(t, "dn2:9866", failed[0].FailedDatanode)
+	assert.Equal(t, err, failed[0].Err)
+	assert.EqualValues(t, 1, failed[0].Block.GetBlockId())
+	assert.Empty(t, er.ofType(PipelineBuilt))
+}
+
+func TestBlockWriterAppendGenerationStamp(t *testing.T) {
+	block := testBlock("dn1")
+	block.B.NumBytes = proto.Uint64(10)
+
+	ops := make(chan *hdfs.OpWriteBlockProto, 1)
+	dial := func(ctx context.Context, network, addr string) (net.Conn, error) {
+		client, server := net.Pipe()
+		go func() {
+			io.ReadFull(server, make([]byte, 3))
+			op := &hdfs.OpWriteBlockProto{}
+			readPrefixedMessage(server, op)
+			ops <- op
+
+			b, _ := makePrefixedMessage(&hdfs.BlockOpResponseProto{Status: hdfs.Status_SUCCESS.Enum()})
+			server.Write(b)
+			fakeDatanode(server, hdfs.Status_SUCCESS)
+		}()
+
+		return client, nil
+	}
+
+	updated := testBlock("dn1")
+	updated.B.GenerationStamp = proto.Uint64(2)
+	updated.BlockToken.Identifier = []byte("new")
+
+	var oldBlock, newBlock *hdfs.ExtendedBlockProto
+	var pipeline []*hdfs.DatanodeInfoProto
+	bw := &BlockWriter{
+		Block:     block,
+		BlockSize: 1024,
+		Offset:    10,
+		Append:    true,
+		DialFunc:  dial,
+		UpdateBlockForPipeline: func(b *hdfs.ExtendedBlockProto) (*hdfs.LocatedBlockProto, error) {
+			assert.EqualValues(t, 1, b.GetGenerationStamp())
+			return updated, nil
+		},
+		UpdatePipeline: func(o, n *hdfs.ExtendedBlockProto, p []*hdfs.DatanodeInfoProto, storageIDs []string) error {
+			oldBlock, newBlock, pipeline = o, n, p
+			return nil
+		},
+	}
+
+	_, err := bw.Write([]byte("foo"))
+	require.NoError(t, err)
+	require.NoError(t, bw.Close())
+
+	// The pipeline is set up for the block as it is, but with the new
+	// generation stamp and token.
+	op := <-ops
+	assert.Equal(t, hdfs.OpWriteBlockProto_PIPELINE_SETUP_APPEND, op.GetStage())
+	assert.EqualValues(t, 1, op.GetHeader().GetBaseHeader().GetBlock().GetGenerationStamp())
+	assert.EqualValues(t, 2, op.GetLatestGenerationStamp())
+	assert.Equal(t, []byte("new"), op.GetHeader().GetBaseHeader().GetToken().GetIdentifier())
+
+	// And then the namenode is told about it.
+	require.NotNil(t, newBlock)
+	assert.EqualValues(t, 1, oldBlock.GetGenerationStamp())
+	assert.EqualValues(t, 2, newBlock.GetGenerationStamp())
+	assert.EqualValues(t, 10, newBlock.GetNumBytes())
+	assert.Len(t, pipeline, 1)
+	assert.EqualValues(t, 2, bw.Block.GetB().GetGenerationStamp())
+	assert.Equal(t, []byte("new"), bw.Block.GetBlockToken().GetIdentifier())
+}
+
+// failingDatanode acks the first packet it reads, and then fails the second
+// one, blaming the datanode at index failed in the pipeline. It carries on
+// reading packets until conn is closed.
+func failingDatanode(conn net.Conn, failed int) {
+	defer conn.Close()
+
+	for packets := 0; ; packets++ {
+		lengthBytes := make([]byte, 6)
+		_, err := io.ReadFull(conn, lengthBytes)
+		if err != nil {
+			return
+		}
+
+		packetLength := int(binary.BigEndian.Uint32(lengthBytes))
+		headerBytes := make([]byte, int(binary.BigEndian.Uint16(lengthBytes[4:])))
+		_, err = io.ReadFull(conn, headerBytes)
+		if err != nil {
+			return
+		}
+
+		header := &hdfs.PacketHeaderProto{}
+		proto.Unmarshal(headerBytes, header)
+		_, err = io.ReadFull(conn, make([]byte, packetLength-4))
+		if err != nil {
+			return
+		} else if packets > 1 {
+			continue
+		}
+
+		reply := []hdfs.Status{hdfs.Status_SUCCESS, hdfs.Status_SUCCESS, hdfs.Status_SUCCESS}
+		if packets == 1 {
+			reply[failed] = hdfs.Status_ERROR
+		}
+
+		b, _ := makePrefixedMessage(&hdfs.PipelineAckProto{Seqno: header.Seqno, Reply: reply})
+		conn.Write(b)
+	}
+}
+
+func TestBlockWriterRecovery(t *testing.T) {
+	block := testBlock("dn1", "dn2", "dn3")
+	block.B.NumBytes = proto.Uint64(0)
+	block.StorageIDs = []string{"s1", "s2", "s3"}
+	for _, loc := range block.Locs {
+		loc.Id.InfoPort = proto.Uint32(9864)
+		loc.Id.IpcPort = proto.Uint32(9867)
+	}
+
+	ops := make(chan *hdfs.OpWriteBlockProto, 2)
+	packets := make(chan outboundPacket, 10)
+	var dials int
+	dial := func(ctx context.Context, network, addr string) (net.Conn, error) {
+		dials++
+		first := dials == 1
+
+		client, server := net.Pipe()
+		go func() {
+			io.ReadFull(server, make([]byte, 3))
+			op := &hdfs.OpWriteBlockProto{}
+			readPrefixedMessage(server, op)
+			ops <- op
+
+			b, _ := makePrefixedMessage(&hdfs.BlockOpResponseProto{Status: hdfs.Status_SUCCESS.Enum()})
+			server.Write(b)
+			if first {
+				failingDatanode(server, 1)
+			} else {
+				recordingDatanode(server, hdfs.Status_SUCCESS, packets)
+			}
+		}()
+
+		return client, nil
+	}
+
+	updated := proto.Clone(block).(*hdfs.LocatedBlockProto)
+	updated.B.GenerationStamp = proto.Uint64(2)
+
+	var updates int
+	var newBlock *hdfs.ExtendedBlockProto
+	var pipeline []*hdfs.DatanodeInfoProto
+	var storageIDs []string
+	er := &eventRecorder{}
+	bw := &BlockWriter{
+		Block:           block,
+		BlockSize:       1048576,
+		WritePacketSize: 512,
+		DialFunc:        dial,
+		Hook:            er.hook,
+		UpdateBlockForPipeline: func(b *hdfs.ExtendedBlockProto) (*hdfs.LocatedBlockProto, error) {
+			updates++
+			return updated, nil
+		},
+		UpdatePipeline: func(o, n *hdfs.ExtendedBlockProto, p []*hdfs.DatanodeInfoProto, s []string) error {
+			newBlock, pipeline, storageIDs = n, p, s
+			return nil
+		},
+	}
+
+	data := make([]byte, 1536)
+	for i := range data {
+		data[i] = byte(i)
+	}
+
+	_, err := bw.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, bw.Close())
+
+	// The pipeline is set up again without dn2, from the first packet that
+	// wasn't acked.
+	<-ops
+	op := <-ops
+	assert.Equal(t, hdfs.OpWriteBlockProto_PIPELINE_SETUP_STREAMING_RECOVERY, op.GetStage())
+	assert.EqualValues(t, 512, op.GetMinBytesRcvd())
+	assert.EqualValues(t, 1536, op.GetMaxBytesRcvd())
+	assert.EqualValues(t, 2, op.GetLatestGenerationStamp())
+	require.Len(t, op.GetTargets(), 1)
+	assert.Equal(t, "dn3", op.GetTargets()[0].GetId().GetIpAddr())
+
+	assert.Equal(t, 1, updates)
+	require.NotNil(t, newBlock)
+	assert.EqualValues(t, 2, newBlock.GetGenerationStamp())
+	assert.EqualValues(t, 512, newBlock.GetNumBytes())
+	assert.Len(t, pipeline, 2)
+	assert.Equal(t, []string{"s1", "s3"}, storageIDs)
+	assert.Equal(t, []string{"dn1:9866", "dn3:9866"}, bw.pipelineAddresses())
+	assert.EqualValues(t, 2, bw.Block.GetB().GetGenerationStamp())
+
+	// The new pipeline gets the rest of the data, with the same seqnos.
+	var resent []byte
+	var seqnos []int
+	for p := range packets {
+		resent = append(resent, p.data...)
+		seqnos = append(seqnos, p.seqno)
+	}
+
+	assert.Equal(t, data[512:], resent)
+	assert.Equal(t, []int{2, 3, 4}, seqnos)
+
+	failed := er.ofType(PipelineFailed)
+	require.Len(t, failed, 1)
+	assert.Equal(t, "dn2:9866", failed[0].FailedDatanode)
+	assert.Len(t, er.ofType(PacketResent), 2)
+}
+
+func TestBlockWriterRecoveryFails(t *testing.T) {
+	block := testBlock("dn1", "dn2")
+	block.B.NumBytes = proto.Uint64(0)
+	for _, loc := range block.Locs {
+		loc.Id.InfoPort = proto.Uint32(9864)
+		loc.Id.IpcPort = proto.Uint32(9867)
+	}
+
+	dial := func(ctx context.Context, network, addr string) (net.Conn, error) {
+		client, server := net.Pipe()
+		go func() {
+			io.ReadFull(server, make([]byte, 3))
+			readPrefixedMessage(server, &hdfs.OpWriteBlockProto{})
+
+			b, _ := makePrefixedMessage(&hdfs.BlockOpResponseProto{Status: hdfs.Status_SUCCESS.Enum()})
+			server.Write(b)
+			failingDatanode(server, 1)
+		}()
+
+		return client, nil
+	}
+
+	updateErr := errors.New("updateBlockForPipeline failed")
+	bw := &BlockWriter{
+		Block:           block,
+		BlockSize:       1048576,
+		WritePacketSize: 512,
+		DialFunc:        dial,
+		UpdateBlockForPipeline: func(b *hdfs.ExtendedBlockProto) (*hdfs.LocatedBlockProto, error) {
+			return nil, updateErr
+		},
+	}
+
+	_, err := bw.Write(make([]byte, 1536))
+	if err == nil {
+		err = bw.Close()
+	}
+
+	assert.Equal(t, updateErr, err)
+
+	// It isn't tried again, and the data that wasn't acked isn't forgotten.
+	assert.Error(t, bw.Close())
+}
+
+func TestWriteEventsHeartbeatError(t *testing.T) {
+	client, server := net.Pipe()
+	server.Close()
+
+	er := &eventRecorder{}
+	bws := newBlockWriteStream(client, 0, outboundChunkSize, outboundPacketSize)
+	bws.events = er.hook
+	bws.pipeline = []string{"dn1:9866"}
+	go bws.sendHeartBeats(10 * time.Millisecond)
+	defer close(bws.closeCh)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for len(er.ofType(HeartbeatFailed)) == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	failed := er.ofType(HeartbeatFailed)
+	require.NotEmpty(t, failed)
+	assert.Equal(t, "dn1:9866", failed[0].FailedDatanode)
+	assert.Error(t, failed[0].Err)
+}
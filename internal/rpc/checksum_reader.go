@@ -3,11 +3,13 @@ package rpc
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"net"
 	"time"
 
 	hdfs "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_hdfs"
+	"github.com/golang/protobuf/proto"
 )
 
 // ChecksumReader provides an interface for reading the "MD5CRC32" checksums of
@@ -16,12 +18,29 @@ import (
 type ChecksumReader struct {
 	// Block is the block location provided by the namenode.
 	Block *hdfs.LocatedBlockProto
+	// UseCompositeCRC specifies that the datanode should compute a
+	// "COMPOSITE_CRC" checksum for the block - the CRC of the whole block, as
+	// four big-endian bytes - rather than the MD5 of the chunk CRCs. This is
+	// only supported by Hadoop 3.1 or higher.
+	UseCompositeCRC bool
+	// ECPolicy is the erasure coding policy of the file, if it's erasure-coded.
+	// In that case Block is a block group, and the checksum is computed by one
+	// of the datanodes from the internal blocks of the group.
+	ECPolicy *hdfs.ErasureCodingPolicyProto
 	// UseDatanodeHostname specifies whether the datanodes should be connected to
 	// via their hostnames (if true) or IP addresses (if false).
 	UseDatanodeHostname bool
 	// DialFunc is used to connect to the datanodes. If nil, then
 	// (&net.Dialer{}).DialContext is used.
 	DialFunc func(ctx context.Context, network, addr string) (net.Conn, error)
+	// CircuitBreaker, if set, keeps track of datanodes that fail repeatedly, so
+	// that they can be skipped.
+	CircuitBreaker *CircuitBreaker
+	// WireLog, if set, is used to log the data transfer requests and responses.
+	WireLog *WireLogger
+	// Strict specifies that the response from the datanode should be validated,
+	// with a ProtocolError returned if it doesn't make sense.
+	Strict bool
 
 	deadline  time.Time
 	datanodes *datanodeFailover
@@ -37,42 +56,54 @@ func (cr *ChecksumReader) SetDeadline(t time.Time) error {
 
 // ReadChecksum returns the checksum of the block.
 func (cr *ChecksumReader) ReadChecksum() ([]byte, error) {
-	if cr.datanodes == nil {
-		locs := cr.Block.GetLocs()
-		datanodes := make([]string, len(locs))
-		for i, loc := range locs {
-			dn := loc.GetId()
-			datanodes[i] = getDatanodeAddress(dn, cr.UseDatanodeHostname)
-		}
+	resp, err := cr.ReadChecksumResponse()
+	if err != nil {
+		return nil, err
+	}
 
-		cr.datanodes = newDatanodeFailover(datanodes)
+	return resp.GetBlockChecksum(), nil
+}
+
+// ReadChecksumResponse returns the checksum of the block, along with the
+// checksum type and parameters reported by the datanode.
+func (cr *ChecksumReader) ReadChecksumResponse() (*hdfs.OpBlockChecksumResponseProto, error) {
+	if cr.datanodes == nil {
+		cr.datanodes = newBlockFailover(cr.Block.GetLocs(), cr.UseDatanodeHostname, cr.CircuitBreaker)
 	}
 
 	for cr.datanodes.numRemaining() > 0 {
 		address := cr.datanodes.next()
-		checksum, err := cr.readChecksum(address)
+		resp, err := cr.readChecksum(address)
 		if err != nil {
 			cr.datanodes.recordFailure(err)
 			continue
 		}
 
-		return checksum, nil
+		cr.datanodes.recordSuccess()
+		return resp, nil
 	}
 
 	err := cr.datanodes.lastError()
-	if err != nil {
+	if err == nil {
 		err = errors.New("No available datanodes for block.")
 	}
 
 	return nil, err
 }
 
-func (cr *ChecksumReader) readChecksum(address string) ([]byte, error) {
+func (cr *ChecksumReader) readChecksum(address string) (*hdfs.OpBlockChecksumResponseProto, error) {
 	if cr.DialFunc == nil {
 		cr.DialFunc = (&net.Dialer{}).DialContext
 	}
 
-	conn, err := cr.DialFunc(context.Background(), "tcp", address)
+	var conn net.Conn
+	var err error
+	if dn := datanodeForAddress(cr.Block.GetLocs(), address, cr.UseDatanodeHostname); dn != nil {
+		conn, err = dialDatanode(context.Background(), cr.DialFunc, dn, cr.UseDatanodeHostname)
+	} else {
+		conn, err = cr.DialFunc(context.Background(), "tcp", address)
+	}
+
 	if err != nil {
 		return nil, err
 	}
@@ -88,37 +119,56 @@ func (cr *ChecksumReader) readChecksum(address string) ([]byte, error) {
 	}
 
 	resp, err := cr.readBlockChecksumResponse(conn)
+	if cr.Strict {
+		if err == nil && resp.GetStatus() == hdfs.Status_SUCCESS && len(resp.GetChecksumResponse().GetBlockChecksum()) == 0 {
+			err = errors.New("missing block checksum")
+		}
+
+		err = protocolError(address, "checksum response", err)
+	}
+
 	if err != nil {
 		return nil, err
+	} else if resp.GetStatus() != hdfs.Status_SUCCESS {
+		return nil, fmt.Errorf("checksum failed: %s (%s)", resp.GetStatus().String(), resp.GetMessage())
+	}
+
+	checksumResp := resp.GetChecksumResponse()
+	if cr.UseCompositeCRC &&
+		checksumResp.GetBlockChecksumOptions().GetBlockChecksumType() != hdfs.BlockChecksumTypeProto_COMPOSITE_CRC {
+		return nil, errors.New("datanode doesn't support COMPOSITE_CRC checksums")
 	}
 
-	return resp.GetChecksumResponse().GetMd5(), nil
+	return checksumResp, nil
 }
 
 // A checksum request to a datanode:
 // +-----------------------------------------------------------+
 // |  Data Transfer Protocol Version, int16                    |
 // +-----------------------------------------------------------+
-// |  Op code, 1 byte (CHECKSUM_BLOCK = 0x55)                  |
+// |  Op code, 1 byte (CHECKSUM_BLOCK = 0x55, or               |
+// |  CHECKSUM_BLOCK_GROUP = 0x5a)                             |
 // +-----------------------------------------------------------+
-// |  varint length + OpReadBlockProto                         |
+// |  varint length + OpBlockChecksumProto (or                 |
+// |  OpBlockGroupChecksumProto)                               |
 // +-----------------------------------------------------------+
 func (cr *ChecksumReader) writeBlockChecksumRequest(w io.Writer) error {
-	header := []byte{0x00, dataTransferVersion, checksumBlockOp}
-
-	op := newChecksumBlockOp(cr.Block)
-	opBytes, err := makePrefixedMessage(op)
-	if err != nil {
-		return err
+	var options *hdfs.BlockChecksumOptionsProto
+	if cr.UseCompositeCRC {
+		options = &hdfs.BlockChecksumOptionsProto{
+			BlockChecksumType: hdfs.BlockChecksumTypeProto_COMPOSITE_CRC.Enum(),
+		}
 	}
 
-	req := append(header, opBytes...)
-	_, err = w.Write(req)
-	if err != nil {
-		return err
+	if cr.ECPolicy != nil {
+		op := newChecksumGroupOp(cr.Block, cr.ECPolicy)
+		op.BlockChecksumOptions = options
+		return cr.WireLog.writeBlockOpRequest(w, checksumGroupOp, op)
 	}
 
-	return nil
+	op := newChecksumBlockOp(cr.Block)
+	op.BlockChecksumOptions = options
+	return cr.WireLog.writeBlockOpRequest(w, checksumBlockOp, op)
 }
 
 // The response from the datanode:
@@ -126,9 +176,12 @@ func (cr *ChecksumReader) writeBlockChecksumRequest(w io.Writer) error {
 // |  varint length + BlockOpResponseProto                     |
 // +-----------------------------------------------------------+
 func (cr *ChecksumReader) readBlockChecksumResponse(r io.Reader) (*hdfs.BlockOpResponseProto, error) {
-	resp := &hdfs.BlockOpResponseProto{}
-	err := readPrefixedMessage(r, resp)
-	return resp, err
+	var max uint64
+	if cr.Strict {
+		max = maxDataTransferMessageLength
+	}
+
+	return cr.WireLog.readBlockOpResponse(r, max)
 }
 
 func newChecksumBlockOp(block *hdfs.LocatedBlockProto) *hdfs.OpBlockChecksumProto {
@@ -139,3 +192,22 @@ func newChecksumBlockOp(block *hdfs.LocatedBlockProto) *hdfs.OpBlockChecksumProt
 		},
 	}
 }
+
+func newChecksumGroupOp(block *hdfs.LocatedBlockProto, ecPolicy *hdfs.ErasureCodingPolicyProto) *hdfs.OpBlockGroupChecksumProto {
+	indices := make([]uint32, len(block.GetBlockIndices()))
+	for i, index := range block.GetBlockIndices() {
+		indices[i] = uint32(index)
+	}
+
+	return &hdfs.OpBlockGroupChecksumProto{
+		Header: &hdfs.BaseHeaderProto{
+			Block: block.GetB(),
+			Token: block.GetBlockToken(),
+		},
+		Datanodes:         &hdfs.DatanodeInfosProto{Datanodes: block.GetLocs()},
+		BlockTokens:       block.GetBlockTokens(),
+		EcPolicy:          ecPolicy,
+		BlockIndices:      indices,
+		RequestedNumBytes: proto.Uint64(block.GetB().GetNumBytes()),
+	}
+}
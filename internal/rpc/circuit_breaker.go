@@ -0,0 +1,249 @@
+package rpc
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	hdfs "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_hdfs"
+)
+
+// ErrCircuitOpen is returned when every datanode for a block has been skipped,
+// because its circuit breaker is open.
+var ErrCircuitOpen = errors.New("datanode circuit breaker open")
+
+// CircuitBreakerState is the state of the circuit breaker for a datanode.
+type CircuitBreakerState int
+
+const (
+	// CircuitClosed means the datanode is used normally.
+	CircuitClosed CircuitBreakerState = iota
+	// CircuitOpen means the datanode has failed too many times in a row, and
+	// is skipped until the cooldown expires.
+	CircuitOpen
+	// CircuitHalfOpen means the cooldown has expired, and a single request is
+	// being allowed through to see whether the datanode has recovered.
+	CircuitHalfOpen
+)
+
+func (s CircuitBreakerState) String() string {
+	switch s {
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+// CircuitBreakerStats describes the circuit breaker for a single datanode.
+type CircuitBreakerStats struct {
+	Address string
+	State   CircuitBreakerState
+	// ConsecutiveFailures is the number of failures since the last success.
+	ConsecutiveFailures int
+	// Trips is the number of times the breaker has opened.
+	Trips int
+	// Rejections is the number of times the datanode was skipped because the
+	// breaker was open.
+	Rejections int
+}
+
+// A CircuitBreaker keeps track of consecutive failures for each datanode, and
+// stops using a datanode once it's failed Threshold times in a row. After
+// Cooldown, a single request is let through as a probe; if it succeeds, the
+// datanode is used normally again, and if it fails, the breaker stays open
+// for another Cooldown.
+//
+// Unlike the failures recorded for datanode failover, which only affect the
+// order replicas are tried in, an open breaker means the datanode isn't tried
+// at all. A nil *CircuitBreaker is valid, and never opens.
+type CircuitBreaker struct {
+	Threshold int
+	Cooldown  time.Duration
+
+	lock  sync.Mutex
+	nodes map[string]*circuitState
+}
+
+type circuitState struct {
+	id       *hdfs.DatanodeIDProto
+	state    CircuitBreakerState
+	openedAt time.Time
+	probing  bool
+
+	failures   int
+	trips      int
+	rejections int
+}
+
+// NewCircuitBreaker returns a CircuitBreaker which opens after threshold
+// consecutive failures, for cooldown.
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{
+		Threshold: threshold,
+		Cooldown:  cooldown,
+		nodes:     make(map[string]*circuitState),
+	}
+}
+
+// permits returns true if the datanode at address can be used, recording a
+// rejection if not.
+func (cb *CircuitBreaker) permits(address string) bool {
+	if cb == nil {
+		return true
+	}
+
+	cb.lock.Lock()
+	defer cb.lock.Unlock()
+
+	s, ok := cb.nodes[address]
+	if !ok {
+		return true
+	}
+
+	switch s.state {
+	case CircuitOpen:
+		if time.Since(s.openedAt) >= cb.Cooldown {
+			return true
+		}
+	case CircuitHalfOpen:
+		if !s.probing {
+			return true
+		}
+	default:
+		return true
+	}
+
+	s.rejections++
+	return false
+}
+
+// acquire is called when a datanode is about to be used. If its breaker is
+// open and the cooldown has expired, the request becomes the probe.
+func (cb *CircuitBreaker) acquire(address string) {
+	if cb == nil {
+		return
+	}
+
+	cb.lock.Lock()
+	defer cb.lock.Unlock()
+
+	s, ok := cb.nodes[address]
+	if ok && s.state != CircuitClosed {
+		s.state = CircuitHalfOpen
+		s.probing = true
+	}
+}
+
+func (cb *CircuitBreaker) recordSuccess(address string) {
+	if cb == nil {
+		return
+	}
+
+	cb.lock.Lock()
+	defer cb.lock.Unlock()
+
+	if s, ok := cb.nodes[address]; ok {
+		s.state = CircuitClosed
+		s.failures = 0
+		s.probing = false
+	}
+}
+
+// release is called when a request to a datanode ended without saying anything
+// about the datanode, for example because it was cancelled. If the request was
+// the probe, the breaker goes back to open, with the cooldown still expired, so
+// that the next request can probe it instead.
+func (cb *CircuitBreaker) release(address string) {
+	if cb == nil {
+		return
+	}
+
+	cb.lock.Lock()
+	defer cb.lock.Unlock()
+
+	if s, ok := cb.nodes[address]; ok && s.state == CircuitHalfOpen && s.probing {
+		s.state = CircuitOpen
+		s.probing = false
+	}
+}
+
+func (cb *CircuitBreaker) recordFailure(address string, id *hdfs.DatanodeIDProto) {
+	if cb == nil {
+		return
+	}
+
+	cb.lock.Lock()
+	defer cb.lock.Unlock()
+
+	s, ok := cb.nodes[address]
+	if !ok {
+		s = &circuitState{}
+		cb.nodes[address] = s
+	}
+
+	if id != nil {
+		s.id = id
+	}
+
+	s.failures++
+	if s.state == CircuitHalfOpen || (s.state == CircuitClosed && s.failures >= cb.Threshold) {
+		s.state = CircuitOpen
+		s.openedAt = time.Now()
+		s.probing = false
+		s.trips++
+	}
+}
+
+// ExcludedNodes returns the datanodes which shouldn't currently be used, for
+// passing to the namenode as the excludeNodes of an addBlock request.
+func (cb *CircuitBreaker) ExcludedNodes() []*hdfs.DatanodeInfoProto {
+	if cb == nil {
+		return nil
+	}
+
+	cb.lock.Lock()
+	defer cb.lock.Unlock()
+
+	var excluded []*hdfs.DatanodeInfoProto
+	for _, s := range cb.nodes {
+		if s.id == nil {
+			continue
+		}
+
+		open := s.state == CircuitOpen && time.Since(s.openedAt) < cb.Cooldown
+		if open || (s.state == CircuitHalfOpen && s.probing) {
+			excluded = append(excluded, &hdfs.DatanodeInfoProto{Id: s.id})
+		}
+	}
+
+	return excluded
+}
+
+// Stats returns the state of the breaker for every datanode that has failed
+// at least once, sorted by address.
+func (cb *CircuitBreaker) Stats() []CircuitBreakerStats {
+	if cb == nil {
+		return nil
+	}
+
+	cb.lock.Lock()
+	defer cb.lock.Unlock()
+
+	stats := make([]CircuitBreakerStats, 0, len(cb.nodes))
+	for address, s := range cb.nodes {
+		stats = append(stats, CircuitBreakerStats{
+			Address:             address,
+			State:               s.state,
+			ConsecutiveFailures: s.failures,
+			Trips:               s.trips,
+			Rejections:          s.rejections,
+		})
+	}
+
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Address < stats[j].Address })
+	return stats
+}
@@ -0,0 +1,126 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	hdfs "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_hdfs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCircuitBreakerOpens(t *testing.T) {
+	cb := NewCircuitBreaker(2, time.Minute)
+	id := testDatanodeID("breaker", "10.0.0.1", "10.0.0.1")
+
+	cb.recordFailure("10.0.0.1:9866", id)
+	assert.True(t, cb.permits("10.0.0.1:9866"))
+	assert.Empty(t, cb.ExcludedNodes())
+
+	cb.recordFailure("10.0.0.1:9866", id)
+	assert.False(t, cb.permits("10.0.0.1:9866"))
+	assert.True(t, cb.permits("10.0.0.2:9866"))
+
+	excluded := cb.ExcludedNodes()
+	require.Len(t, excluded, 1)
+	assert.Equal(t, "breaker", excluded[0].GetId().GetDatanodeUuid())
+
+	assert.Equal(t, []CircuitBreakerStats{{
+		Address:             "10.0.0.1:9866",
+		State:               CircuitOpen,
+		ConsecutiveFailures: 2,
+		Trips:               1,
+		Rejections:          1,
+	}}, cb.Stats())
+}
+
+func TestCircuitBreakerSuccessResets(t *testing.T) {
+	cb := NewCircuitBreaker(2, time.Minute)
+
+	cb.recordFailure("10.0.0.1:9866", nil)
+	cb.recordSuccess("10.0.0.1:9866")
+	cb.recordFailure("10.0.0.1:9866", nil)
+	assert.True(t, cb.permits("10.0.0.1:9866"))
+}
+
+func TestCircuitBreakerHalfOpen(t *testing.T) {
+	cb := NewCircuitBreaker(1, time.Millisecond)
+
+	cb.recordFailure("10.0.0.1:9866", nil)
+	time.Sleep(2 * time.Millisecond)
+
+	// Once the cooldown is over, a single probe is allowed.
+	require.True(t, cb.permits("10.0.0.1:9866"))
+	cb.acquire("10.0.0.1:9866")
+	assert.Equal(t, CircuitHalfOpen, cb.Stats()[0].State)
+	assert.False(t, cb.permits("10.0.0.1:9866"))
+
+	// If it fails, the breaker opens again.
+	cb.recordFailure("10.0.0.1:9866", nil)
+	assert.Equal(t, CircuitOpen, cb.Stats()[0].State)
+	assert.Equal(t, 2, cb.Stats()[0].Trips)
+
+	time.Sleep(2 * time.Millisecond)
+	cb.acquire("10.0.0.1:9866")
+	cb.recordSuccess("10.0.0.1:9866")
+	assert.Equal(t, CircuitClosed, cb.Stats()[0].State)
+	assert.True(t, cb.permits("10.0.0.1:9866"))
+}
+
+func TestCircuitBreakerCancelledProbe(t *testing.T) {
+	cb := NewCircuitBreaker(1, time.Millisecond)
+	locs := []*hdfs.DatanodeInfoProto{
+		{Id: testDatanodeID("a", "10.0.0.1", "10.0.0.1")},
+	}
+
+	cb.recordFailure("10.0.0.1:9866", nil)
+	time.Sleep(2 * time.Millisecond)
+
+	df := newBlockFailover(locs, false, cb)
+	require.Equal(t, "10.0.0.1:9866", df.next())
+	assert.False(t, cb.permits("10.0.0.1:9866"))
+
+	// A cancelled probe doesn't count as a failure, but it lets another
+	// request probe the datanode.
+	df.recordFailure(fmt.Errorf("reading block: %w", context.Canceled))
+	assert.Equal(t, CircuitOpen, cb.Stats()[0].State)
+	assert.Equal(t, 1, cb.Stats()[0].Trips)
+	assert.True(t, cb.permits("10.0.0.1:9866"))
+
+	df = newBlockFailover(locs, false, cb)
+	require.Equal(t, "10.0.0.1:9866", df.next())
+	df.recordSuccess()
+	assert.Equal(t, CircuitClosed, cb.Stats()[0].State)
+}
+
+func TestCircuitBreakerNil(t *testing.T) {
+	var cb *CircuitBreaker
+	cb.recordFailure("10.0.0.1:9866", nil)
+	assert.True(t, cb.permits("10.0.0.1:9866"))
+	assert.Nil(t, cb.ExcludedNodes())
+	assert.Nil(t, cb.Stats())
+}
+
+func TestBlockFailoverSkipsOpenCircuits(t *testing.T) {
+	cb := NewCircuitBreaker(1, time.Minute)
+	locs := []*hdfs.DatanodeInfoProto{
+		{Id: testDatanodeID("a", "10.0.0.1", "10.0.0.1")},
+		{Id: testDatanodeID("b", "10.0.0.2", "10.0.0.2")},
+	}
+
+	df := newBlockFailover(locs, false, cb)
+	assert.Equal(t, "10.0.0.1:9866", df.next())
+	df.recordFailure(errors.New("disk failed"))
+
+	df = newBlockFailover(locs, false, cb)
+	assert.Equal(t, 1, df.numRemaining())
+	assert.Equal(t, "10.0.0.2:9866", df.next())
+	df.recordFailure(errors.New("disk failed"))
+
+	df = newBlockFailover(locs, false, cb)
+	assert.Equal(t, 0, df.numRemaining())
+	assert.True(t, errors.Is(df.lastError(), ErrCircuitOpen))
+}
@@ -0,0 +1,334 @@
+package rpc
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"testing"
+
+	hdfs "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_hdfs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// This is the example exchange from section 4 of RFC 2831.
+func TestDigestMD5RFCExample(t *testing.T) {
+	d := &digestMD5Client{
+		username: "chris",
+		password: "secret",
+		protocol: "imap",
+		server:   "elwood.innosoft.com",
+		qops:     []string{qopAuth},
+		cnonce:   "OA6MHXh6VqTrRk",
+	}
+
+	resp, err := d.challengeResponse([]byte(`realm="elwood.innosoft.com",nonce="OA6MG9tEQGm2hh",qop="auth",algorithm=md5-sess,charset=utf-8`))
+	require.NoError(t, err)
+
+	params, err := parseDigestChallenge(string(resp))
+	require.NoError(t, err)
+	assert.Equal(t, "d388dad90d4bbd760a152321f2143af7", params["response"])
+	assert.Equal(t, "chris", params["username"])
+	assert.Equal(t, "elwood.innosoft.com", params["realm"])
+	assert.Equal(t, "imap/elwood.innosoft.com", params["digest-uri"])
+
+	assert.NoError(t, d.verifyServer([]byte("rspauth=ea40f60335c427b5527b84dbabcdfffd")))
+	assert.Equal(t, errDigestMD5Mutual, d.verifyServer([]byte("rspauth=ea40f60335c427b5527b84dbabcdfffe")))
+
+	security, err := d.security()
+	assert.NoError(t, err)
+	assert.Nil(t, security)
+}
+
+func TestDigestMD5ChallengeErrors(t *testing.T) {
+	for _, challenge := range []string{
+		`nonce="abc",qop="auth-conf",cipher="rc4"`,
+		`nonce="abc",qop="auth",algorithm=md5-sess`,
+		`nonce="abc",qop="auth-conf",cipher="des,rc4-40",algorithm=md5-sess`,
+		`realm="0",qop="auth-conf",cipher="rc4",algorithm=md5-sess`,
+		`nonce="abc,algorithm=md5-sess`,
+	} {
+		d := &digestMD5Client{qops: []string{qopAuthConf}}
+		_, err := d.challengeResponse([]byte(challenge))
+		assert.Error(t, err, challenge)
+	}
+}
+
+func TestParseDigestChallenge(t *testing.T) {
+	params, err := parseDigestChallenge(`realm="0",nonce="a\"b,c",qop="auth-conf,auth",charset=utf-8, cipher="3des,rc4",algorithm=md5-sess`)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"realm":     "0",
+		"nonce":     `a"b,c`,
+		"qop":       "auth-conf,auth",
+		"charset":   "utf-8",
+		"cipher":    "3des,rc4",
+		"algorithm": "md5-sess",
+	}, params)
+}
+
+func TestAddDESParity(t *testing.T) {
+	// Each 7 bits of the input end up in the top of a byte, with odd parity.
+	b := addDESParity([]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff})
+	assert.Equal(t, []byte{0xfe, 0xfe, 0xfe, 0xfe, 0xfe, 0xfe, 0xfe, 0xfe}, b)
+
+	b = addDESParity([]byte{0, 0, 0, 0, 0, 0, 0})
+	assert.Equal(t, []byte{1, 1, 1, 1, 1, 1, 1, 1}, b)
+
+	b = addDESParity([]byte{0x80, 0, 0, 0, 0, 0, 1})
+	assert.Equal(t, []byte{0x80, 1, 1, 1, 1, 1, 1, 0x02}, b)
+}
+
+// fakeSASLDatanode plays the datanode's side of the handshake, computing
+// everything from the client's perspective and swapping the directions.
+type fakeSASLDatanode struct {
+	key     *DataEncryptionKey
+	ciphers string
+	aes     bool
+	// unknownKey makes the datanode reject the key.
+	unknownKey bool
+}
+
+func (f *fakeSASLDatanode) serve(conn net.Conn) (*digestSecurity, *hdfs.CipherOptionProto, *bufio.Reader, error) {
+	br := bufio.NewReader(conn)
+	var magic [4]byte
+	_, err := io.ReadFull(br, magic[:])
+	if err != nil {
+		return nil, nil, nil, err
+	} else if binary.BigEndian.Uint32(magic[:]) != saslTransferMagicNumber {
+		return nil, nil, nil, fmt.Errorf("bad magic number: %x", magic)
+	}
+
+	msg := &hdfs.DataTransferEncryptorMessageProto{}
+	err = readPrefixedMessage(br, msg)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if f.unknownKey {
+		b, _ := makePrefixedMessage(&hdfs.DataTransferEncryptorMessageProto{
+			Status: hdfs.DataTransferEncryptorMessageProto_ERROR_UNKNOWN_KEY.Enum(),
+		})
+		conn.Write(b)
+		return nil, nil, nil, nil
+	}
+
+	challenge := fmt.Sprintf(`realm="0",nonce="srvnonce",qop="auth-conf",charset=utf-8,cipher="%s",algorithm=md5-sess`, f.ciphers)
+	err = writeSASLMessage(conn, &hdfs.DataTransferEncryptorMessageProto{Payload: []byte(challenge)})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	msg = &hdfs.DataTransferEncryptorMessageProto{}
+	err = readPrefixedMessage(br, msg)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	params, err := parseDigestChallenge(string(msg.GetPayload()))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	// Recompute the exchange with the client's cnonce, to check the response.
+	d := &digestMD5Client{
+		username: params["username"],
+		password: "",
+		protocol: "hdfs",
+		server:   "0",
+		qops:     []string{params["qop"]},
+		ciphers:  []string{params["cipher"]},
+		cnonce:   params["cnonce"],
+	}
+
+	if params["username"] != fmt.Sprintf("%d %s %s", f.key.KeyID, f.key.BlockPoolID, "bm9uY2U=") {
+		return nil, nil, nil, fmt.Errorf("unexpected username: %q", params["username"])
+	}
+
+	d.password = "a2V5a2V5a2V5a2V5a2V5"
+	_, err = d.challengeResponse([]byte(challenge))
+	if err != nil {
+		return nil, nil, nil, err
+	} else if params["response"] != d.responseValue("AUTHENTICATE") {
+		return nil, nil, nil, fmt.Errorf("bad response")
+	}
+
+	client, err := d.security()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	server := &digestSecurity{
+		sendKey:   client.recvKey,
+		recvKey:   client.sendKey,
+		maxRaw:    client.maxRaw,
+		blockSize: client.blockSize,
+	}
+
+	// The cipher state has to start fresh for each direction.
+	reverse, _ := d.security()
+	switch d.cipher {
+	case "rc4":
+		server.enc, server.dec = reverse.dec, reverse.enc
+	case "3des":
+		sendSeal := digestKey(d.hA1, serverSealingMagic)
+		recvSeal := digestKey(d.hA1, clientSealingMagic)
+		server.encBlock, _ = newDigestTripleDES(sendSeal, true)
+		server.decBlock, _ = newDigestTripleDES(recvSeal, false)
+	}
+
+	resp := &hdfs.DataTransferEncryptorMessageProto{Payload: []byte("rspauth=" + d.responseValue(""))}
+	var option *hdfs.CipherOptionProto
+	if f.aes && len(msg.GetCipherOption()) > 0 {
+		option = &hdfs.CipherOptionProto{
+			Suite:  hdfs.CipherSuiteProto_AES_CTR_NOPADDING.Enum(),
+			InKey:  randomBytes(16),
+			InIv:   randomBytes(16),
+			OutKey: randomBytes(16),
+			OutIv:  randomBytes(16),
+		}
+
+		resp.CipherOption = []*hdfs.CipherOptionProto{{
+			Suite:  option.Suite,
+			InKey:  server.wrap(option.InKey),
+			InIv:   option.InIv,
+			OutKey: server.wrap(option.OutKey),
+			OutIv:  option.OutIv,
+		}}
+	}
+
+	err = writeSASLMessage(conn, resp)
+	return server, option, br, err
+}
+
+func randomBytes(n int) []byte {
+	b := make([]byte, n)
+	rand.Read(b)
+	return b
+}
+
+func testDataTransferEncryption(t *testing.T, f *fakeSASLDatanode) {
+	f.key = &DataEncryptionKey{
+		KeyID:       42,
+		BlockPoolID: "BP-1",
+		Nonce:       []byte("nonce"),
+		Key:         []byte("keykeykeykeykey"),
+	}
+
+	clientConn, serverConn := net.Pipe()
+	defer clientConn.Close()
+	defer serverConn.Close()
+
+	// The server echoes everything back, after decrypting and re-encrypting
+	// it.
+	errs := make(chan error, 1)
+	go func() {
+		security, option, br, err := f.serve(serverConn)
+		if err != nil || f.unknownKey {
+			errs <- err
+			return
+		}
+
+		var conn net.Conn
+		if option != nil {
+			// The server's in is the client's out.
+			enc, _ := newAESCTR(option.OutKey, option.OutIv)
+			dec, _ := newAESCTR(option.InKey, option.InIv)
+			conn = &aesConn{Conn: serverConn, r: br, enc: enc, dec: dec}
+		} else {
+			conn = &saslConn{Conn: serverConn, r: br, security: security}
+		}
+
+		errs <- nil
+		io.Copy(conn, conn)
+	}()
+
+	conn, err := NegotiateDataTransferEncryption(clientConn, f.key, f.aes)
+	if f.unknownKey {
+		assert.Equal(t, ErrInvalidEncryptionKey, err)
+		return
+	}
+
+	require.NoError(t, err)
+	require.NoError(t, <-errs)
+
+	if f.aes {
+		assert.IsType(t, &aesConn{}, conn)
+	} else {
+		assert.IsType(t, &saslConn{}, conn)
+	}
+
+	// Send more than one frame's worth, to check the chunking.
+	msg := strings.Repeat("the quick brown fox jumps over the lazy dog ", 4000)
+	go conn.Write([]byte(msg))
+
+	buf := make([]byte, len(msg))
+	_, err = io.ReadFull(conn, buf)
+	require.NoError(t, err)
+	assert.Equal(t, msg, string(buf))
+}
+
+func TestDataTransferEncryptionRC4(t *testing.T) {
+	testDataTransferEncryption(t, &fakeSASLDatanode{ciphers: "rc4"})
+}
+
+func TestDataTransferEncryption3DES(t *testing.T) {
+	testDataTransferEncryption(t, &fakeSASLDatanode{ciphers: "3des,rc4,des,rc4-56,rc4-40"})
+}
+
+func TestDataTransferEncryptionAES(t *testing.T) {
+	testDataTransferEncryption(t, &fakeSASLDatanode{ciphers: "3des,rc4", aes: true})
+}
+
+func TestDataTransferEncryptionAESNotSupported(t *testing.T) {
+	// The datanode doesn't have to agree to AES, in which case the SASL layer
+	// is used.
+	f := &fakeSASLDatanode{ciphers: "rc4"}
+	f.key = &DataEncryptionKey{KeyID: 42, BlockPoolID: "BP-1", Nonce: []byte("nonce"), Key: []byte("keykeykeykeykey")}
+
+	clientConn, serverConn := net.Pipe()
+	defer clientConn.Close()
+	defer serverConn.Close()
+
+	go f.serve(serverConn)
+	conn, err := NegotiateDataTransferEncryption(clientConn, f.key, true)
+	require.NoError(t, err)
+	assert.IsType(t, &saslConn{}, conn)
+}
+
+func TestDataTransferEncryptionUnknownKey(t *testing.T) {
+	testDataTransferEncryption(t, &fakeSASLDatanode{ciphers: "rc4", unknownKey: true})
+}
+
+func TestDigestSecurityRejectsTampering(t *testing.T) {
+	d := &digestMD5Client{username: "u", password: "p", protocol: "hdfs", server: "0", qops: []string{qopAuthConf}}
+	_, err := d.challengeResponse([]byte(`realm="0",nonce="n",qop="auth-conf",cipher="3des",algorithm=md5-sess`))
+	require.NoError(t, err)
+
+	client, err := d.security()
+	require.NoError(t, err)
+
+	// Loop the client's output back to itself, by swapping its keys.
+	client.recvKey = client.sendKey
+	client.decBlock, _ = newDigestTripleDES(digestKey(d.hA1, clientSealingMagic), false)
+
+	wrapped := client.wrap([]byte("hello"))
+	tampered := append([]byte(nil), wrapped...)
+	tampered[0] ^= 1
+
+	_, err = client.unwrap(tampered)
+	assert.Error(t, err)
+
+	client.decBlock, _ = newDigestTripleDES(digestKey(d.hA1, clientSealingMagic), false)
+	msg, err := client.unwrap(wrapped)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(msg))
+
+	client.wrap([]byte("skipped"))
+	_, err = client.unwrap(client.wrap([]byte("out of order")))
+	assert.Error(t, err)
+}
@@ -0,0 +1,206 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	hdfs "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_hdfs"
+)
+
+// datanodeDialStagger is how long dialDatanode waits for a connection to one
+// of a datanode's addresses before trying the next one in parallel, like the
+// "happy eyeballs" algorithm (RFC 8305).
+var datanodeDialStagger = 300 * time.Millisecond
+
+// datanodeWorkingAddresses is a global map of datanode UUID to the address
+// the last successful connection to it was made on. It's shared by every
+// client, since datanode UUIDs are unique across clusters, but entries expire
+// after workingAddressTTL, and it holds at most maxWorkingAddresses of them.
+var datanodeWorkingAddresses = make(map[string]workingAddress)
+var datanodeWorkingAddressesLock sync.Mutex
+
+const (
+	workingAddressTTL   = 10 * time.Minute
+	maxWorkingAddresses = 10000
+)
+
+type workingAddress struct {
+	addr    string
+	expires time.Time
+}
+
+func getWorkingAddress(uuid string) string {
+	datanodeWorkingAddressesLock.Lock()
+	defer datanodeWorkingAddressesLock.Unlock()
+
+	working, ok := datanodeWorkingAddresses[uuid]
+	if !ok {
+		return ""
+	} else if time.Now().After(working.expires) {
+		delete(datanodeWorkingAddresses, uuid)
+		return ""
+	}
+
+	return working.addr
+}
+
+// setWorkingAddress records the address that worked for the datanode. If the
+// map is full, the expired entries are removed first, and if none have
+// expired, an arbitrary one is.
+func setWorkingAddress(uuid, addr string) {
+	datanodeWorkingAddressesLock.Lock()
+	defer datanodeWorkingAddressesLock.Unlock()
+
+	now := time.Now()
+	if _, ok := datanodeWorkingAddresses[uuid]; !ok && len(datanodeWorkingAddresses) >= maxWorkingAddresses {
+		for k, v := range datanodeWorkingAddresses {
+			if now.After(v.expires) {
+				delete(datanodeWorkingAddresses, k)
+			}
+		}
+
+		for k := range datanodeWorkingAddresses {
+			if len(datanodeWorkingAddresses) < maxWorkingAddresses {
+				break
+			}
+
+			delete(datanodeWorkingAddresses, k)
+		}
+	}
+
+	datanodeWorkingAddresses[uuid] = workingAddress{addr: addr, expires: now.Add(workingAddressTTL)}
+}
+
+type dialFunc func(ctx context.Context, network, addr string) (net.Conn, error)
+
+// datanodeAddresses returns the addresses the datanode can be reached at,
+// in order of preference: its IP address and its hostname, or the other way
+// around if useHostname is set. If the datanode has been connected to before,
+// the address that worked comes first.
+func datanodeAddresses(datanode *hdfs.DatanodeIDProto, useHostname bool) []string {
+	primary := getDatanodeAddress(datanode, useHostname)
+	addrs := []string{primary}
+
+	alternateHost := datanode.GetHostName()
+	if useHostname {
+		alternateHost = datanode.GetIpAddr()
+	}
+
+	alternate := fmt.Sprintf("%s:%d", alternateHost, datanode.GetXferPort())
+	if alternateHost != "" && alternate != primary {
+		addrs = append(addrs, alternate)
+	}
+
+	if len(addrs) > 1 && getWorkingAddress(datanode.GetDatanodeUuid()) == addrs[1] {
+		addrs[0], addrs[1] = addrs[1], addrs[0]
+	}
+
+	return addrs
+}
+
+// dialDatanode connects to a datanode using dial. If the datanode advertises
+// more than one address, the first is tried, and then, if it hasn't connected
+// (or failed) within datanodeDialStagger, the next is tried in parallel. The
+// first connection to succeed is used, and the address it was made on is
+// remembered for the next time.
+func dialDatanode(ctx context.Context, dial dialFunc, datanode *hdfs.DatanodeIDProto, useHostname bool) (net.Conn, error) {
+	addrs := datanodeAddresses(datanode, useHostname)
+	if len(addrs) == 1 {
+		return dial(ctx, "tcp", addrs[0])
+	}
+
+	conn, addr, err := dialStaggered(ctx, dial, addrs, datanodeDialStagger)
+	if err != nil {
+		return nil, err
+	}
+
+	if uuid := datanode.GetDatanodeUuid(); uuid != "" {
+		setWorkingAddress(uuid, addr)
+	}
+
+	return conn, nil
+}
+
+// datanodeForAddress returns the ID of the datanode in locs with the given
+// primary address, or nil if there isn't one.
+func datanodeForAddress(locs []*hdfs.DatanodeInfoProto, address string, useHostname bool) *hdfs.DatanodeIDProto {
+	for _, loc := range locs {
+		if getDatanodeAddress(loc.GetId(), useHostname) == address {
+			return loc.GetId()
+		}
+	}
+
+	return nil
+}
+
+type dialResult struct {
+	conn net.Conn
+	addr string
+	err  error
+}
+
+// dialStaggered dials each of addrs in order, starting the next one whenever
+// the previous attempt fails or stagger passes, whichever is first. It returns
+// the first successful connection, and the address it was made on. The
+// remaining attempts are cancelled, and any other connections that succeed
+// are closed.
+func dialStaggered(ctx context.Context, dial dialFunc, addrs []string, stagger time.Duration) (net.Conn, string, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan dialResult, len(addrs))
+	next := 0
+	pending := 0
+	start := func() {
+		addr := addrs[next]
+		next++
+		pending++
+		go func() {
+			conn, err := dial(ctx, "tcp", addr)
+			results <- dialResult{conn, addr, err}
+		}()
+	}
+
+	timer := time.NewTimer(stagger)
+	defer timer.Stop()
+
+	start()
+	var lastErr error
+	for pending > 0 {
+		select {
+		case res := <-results:
+			pending--
+			if res.err == nil {
+				go closeDialResults(results, pending)
+				return res.conn, res.addr, nil
+			}
+
+			lastErr = res.err
+			if next < len(addrs) {
+				start()
+				timer.Reset(stagger)
+			}
+		case <-timer.C:
+			if next < len(addrs) {
+				start()
+				timer.Reset(stagger)
+			}
+		}
+	}
+
+	return nil, "", lastErr
+}
+
+// closeDialResults waits for n outstanding dial attempts, and closes any
+// connections they made.
+func closeDialResults(results chan dialResult, n int) {
+	for i := 0; i < n; i++ {
+		res := <-results
+		if res.conn != nil {
+			res.conn.Close()
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	hdfs "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_hdfs"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errTestDial = errors.New("test dial")
+
+func testDatanodeID(uuid, ip, hostname string) *hdfs.DatanodeIDProto {
+	return &hdfs.DatanodeIDProto{
+		IpAddr:       proto.String(ip),
+		HostName:     proto.String(hostname),
+		DatanodeUuid: proto.String(uuid),
+		XferPort:     proto.Uint32(9866),
+		InfoPort:     proto.Uint32(9864),
+		IpcPort:      proto.Uint32(9867),
+	}
+}
+
+// testDialer returns a pipe for addresses in ok, after the given delay, and
+// fails for all other addresses.
+type testDialer struct {
+	ok    map[string]time.Duration
+	mu    sync.Mutex
+	addrs []string
+}
+
+func (d *testDialer) dial(ctx context.Context, network, addr string) (net.Conn, error) {
+	d.mu.Lock()
+	d.addrs = append(d.addrs, addr)
+	d.mu.Unlock()
+
+	delay, ok := d.ok[addr]
+	if !ok {
+		return nil, errTestDial
+	}
+
+	select {
+	case <-time.After(delay):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	client, server := net.Pipe()
+	server.Close()
+	return client, nil
+}
+
+func (d *testDialer) dialed() []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]string(nil), d.addrs...)
+}
+
+func TestDatanodeAddresses(t *testing.T) {
+	id := testDatanodeID("addresses", "10.0.0.1", "dn1.example.com")
+	assert.Equal(t, []string{"10.0.0.1:9866", "dn1.example.com:9866"}, datanodeAddresses(id, false))
+	assert.Equal(t, []string{"dn1.example.com:9866", "10.0.0.1:9866"}, datanodeAddresses(id, true))
+
+	id = testDatanodeID("addresses-single", "10.0.0.1", "10.0.0.1")
+	assert.Equal(t, []string{"10.0.0.1:9866"}, datanodeAddresses(id, false))
+}
+
+func TestDialDatanodeFallsBack(t *testing.T) {
+	id := testDatanodeID("fallback", "10.0.0.1", "dn1.example.com")
+	d := &testDialer{ok: map[string]time.Duration{"dn1.example.com:9866": 0}}
+
+	conn, err := dialDatanode(context.Background(), d.dial, id, false)
+	require.NoError(t, err)
+	conn.Close()
+	assert.Equal(t, []string{"10.0.0.1:9866", "dn1.example.com:9866"}, d.dialed())
+
+	// The address that worked is tried first next time.
+	d.addrs = nil
+	conn, err = dialDatanode(context.Background(), d.dial, id, false)
+	require.NoError(t, err)
+	conn.Close()
+	assert.Equal(t, []string{"dn1.example.com:9866"}, d.dialed())
+}
+
+func TestDialDatanodeStaggered(t *testing.T) {
+	id := testDatanodeID("staggered", "10.0.0.1", "dn1.example.com")
+	d := &testDialer{ok: map[string]time.Duration{
+		"10.0.0.1:9866":        time.Minute,
+		"dn1.example.com:9866": 0,
+	}}
+
+	start := time.Now()
+	conn, err := dialDatanode(context.Background(), d.dial, id, false)
+	require.NoError(t, err)
+	conn.Close()
+
+	assert.True(t, time.Since(start) < time.Minute)
+	assert.Equal(t, []string{"10.0.0.1:9866", "dn1.example.com:9866"}, d.dialed())
+}
+
+func TestDialDatanodeAllFail(t *testing.T) {
+	id := testDatanodeID("allfail", "10.0.0.1", "dn1.example.com")
+	d := &testDialer{}
+
+	_, err := dialDatanode(context.Background(), d.dial, id, false)
+	assert.Equal(t, errTestDial, err)
+	assert.Equal(t, []string{"10.0.0.1:9866", "dn1.example.com:9866"}, d.dialed())
+}
+
+func TestWorkingAddressesBounded(t *testing.T) {
+	defer func() {
+		datanodeWorkingAddressesLock.Lock()
+		datanodeWorkingAddresses = make(map[string]workingAddress)
+		datanodeWorkingAddressesLock.Unlock()
+	}()
+
+	for i := 0; i < maxWorkingAddresses+100; i++ {
+		setWorkingAddress(fmt.Sprintf("bounded-%d", i), "dn1.example.com:9866")
+	}
+
+	datanodeWorkingAddressesLock.Lock()
+	n := len(datanodeWorkingAddresses)
+	datanodeWorkingAddressesLock.Unlock()
+	assert.True(t, n <= maxWorkingAddresses, "%d addresses remembered", n)
+
+	id := testDatanodeID("expired", "10.0.0.1", "dn1.example.com")
+	datanodeWorkingAddressesLock.Lock()
+	datanodeWorkingAddresses["expired"] = workingAddress{addr: "dn1.example.com:9866", expires: time.Now().Add(-time.Second)}
+	datanodeWorkingAddressesLock.Unlock()
+	assert.Equal(t, []string{"10.0.0.1:9866", "dn1.example.com:9866"}, datanodeAddresses(id, false))
+}
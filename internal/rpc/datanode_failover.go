@@ -1,8 +1,14 @@
 package rpc
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
 	"sync"
 	"time"
+
+	hdfs "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_hdfs"
 )
 
 // datanodeFailures is a global map of address to the last recorded failure
@@ -15,6 +21,9 @@ type datanodeFailover struct {
 	datanodes       []string
 	currentDatanode string
 	err             error
+
+	breaker *CircuitBreaker
+	ids     map[string]*hdfs.DatanodeIDProto
 }
 
 func newDatanodeFailover(datanodes []string) *datanodeFailover {
@@ -25,12 +34,56 @@ func newDatanodeFailover(datanodes []string) *datanodeFailover {
 	}
 }
 
+// newBlockFailover returns a datanodeFailover for the replicas of a block.
+// Datanodes whose circuit breaker is open are left out.
+func newBlockFailover(locs []*hdfs.DatanodeInfoProto, useHostname bool, breaker *CircuitBreaker) *datanodeFailover {
+	df := &datanodeFailover{
+		datanodes: make([]string, 0, len(locs)),
+		breaker:   breaker,
+		ids:       make(map[string]*hdfs.DatanodeIDProto, len(locs)),
+	}
+
+	var skipped []string
+	for _, loc := range locs {
+		address := getDatanodeAddress(loc.GetId(), useHostname)
+		if !breaker.permits(address) {
+			skipped = append(skipped, address)
+			continue
+		}
+
+		df.datanodes = append(df.datanodes, address)
+		df.ids[address] = loc.GetId()
+	}
+
+	if len(df.datanodes) == 0 && len(skipped) > 0 {
+		df.err = fmt.Errorf("%w: %s", ErrCircuitOpen, strings.Join(skipped, ", "))
+	}
+
+	return df
+}
+
 func (df *datanodeFailover) recordFailure(err error) {
+	df.err = err
+
+	// If the operation was cancelled, it's not the datanode's fault. If it was
+	// the probe for an open breaker, another request has to probe it instead.
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		df.breaker.release(df.currentDatanode)
+		return
+	}
+
+	df.breaker.recordFailure(df.currentDatanode, df.ids[df.currentDatanode])
+
 	datanodeFailuresLock.Lock()
 	defer datanodeFailuresLock.Unlock()
 
 	datanodeFailures[df.currentDatanode] = time.Now()
-	df.err = err
+}
+
+// recordSuccess records that the current datanode was connected to
+// successfully.
+func (df *datanodeFailover) recordSuccess() {
+	df.breaker.recordSuccess(df.currentDatanode)
 }
 
 func (df *datanodeFailover) next() string {
@@ -59,6 +112,7 @@ func (df *datanodeFailover) next() string {
 	df.datanodes = append(df.datanodes[:picked], df.datanodes[picked+1:]...)
 
 	df.currentDatanode = address
+	df.breaker.acquire(address)
 	return address
 }
 
@@ -0,0 +1,205 @@
+package rpc
+
+import (
+	"net"
+	"sync"
+	"time"
+)
+
+// datanodeReleaseTimeout limits how long finishing a block read, so that its
+// connection can be returned to a DatanodeConnPool, can take.
+const datanodeReleaseTimeout = time.Second
+
+// A DatanodeConnPool keeps connections to datanodes open once a block has
+// been read from them, so that they can be reused for the next read from the
+// same datanode, like the Java client's PeerCache. This saves the TCP
+// handshake (and any SASL negotiation) for each block, which dominates the
+// latency of reading small files.
+//
+// The datanode closes idle connections after dfs.datanode.socket.reuse.keepalive
+// (four seconds, by default), so IdleTimeout should be shorter than that. If a
+// pooled connection turns out to have been closed anyway, a new one is
+// dialed. A nil *DatanodeConnPool is valid, and never keeps any connections.
+type DatanodeConnPool struct {
+	// MaxIdlePerHost is the number of idle connections kept for each datanode.
+	// Once there are that many, the oldest is closed to make room.
+	MaxIdlePerHost int
+	// IdleTimeout is how long a connection is kept before it's closed, if it
+	// isn't reused.
+	IdleTimeout time.Duration
+
+	lock   sync.Mutex
+	idle   map[string][]idleConn
+	timer  *time.Timer
+	closed bool
+	reused int
+}
+
+type idleConn struct {
+	conn    net.Conn
+	expires time.Time
+}
+
+// NewDatanodeConnPool returns a DatanodeConnPool which keeps up to
+// maxIdlePerHost connections to each datanode, for idleTimeout.
+func NewDatanodeConnPool(maxIdlePerHost int, idleTimeout time.Duration) *DatanodeConnPool {
+	return &DatanodeConnPool{
+		MaxIdlePerHost: maxIdlePerHost,
+		IdleTimeout:    idleTimeout,
+		idle:           make(map[string][]idleConn),
+	}
+}
+
+// get returns the most recently used idle connection to the datanode at
+// address, or nil if there isn't one.
+func (p *DatanodeConnPool) get(address string) net.Conn {
+	if p == nil {
+		return nil
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	conns := p.idle[address]
+	for len(conns) > 0 {
+		idle := conns[len(conns)-1]
+		conns = conns[:len(conns)-1]
+		if time.Now().Before(idle.expires) {
+			p.setIdle(address, conns)
+			p.reused++
+			return idle.conn
+		}
+
+		idle.conn.Close()
+	}
+
+	p.setIdle(address, nil)
+	return nil
+}
+
+// put adds conn to the pool, as an idle connection to the datanode at
+// address. It's closed instead if the pool is full or closed.
+func (p *DatanodeConnPool) put(address string, conn net.Conn) {
+	if p == nil || p.MaxIdlePerHost <= 0 {
+		conn.Close()
+		return
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.closed {
+		conn.Close()
+		return
+	}
+
+	conns := p.idle[address]
+	if len(conns) >= p.MaxIdlePerHost {
+		conns[0].conn.Close()
+		conns = conns[1:]
+	}
+
+	p.setIdle(address, append(conns, idleConn{conn: conn, expires: time.Now().Add(p.IdleTimeout)}))
+	if p.timer == nil {
+		p.timer = time.AfterFunc(p.IdleTimeout, p.expire)
+	}
+}
+
+// expire closes the connections that have been idle for longer than
+// IdleTimeout. It runs in the background while there are any idle
+// connections, so that they aren't left open after the datanode has given up
+// on them.
+func (p *DatanodeConnPool) expire() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.timer = nil
+	if p.closed {
+		return
+	}
+
+	now := time.Now()
+	var next time.Time
+	for address, conns := range p.idle {
+		// The connections are in the order they were added, so the ones that
+		// have expired are at the start.
+		i := 0
+		for ; i < len(conns) && !now.Before(conns[i].expires); i++ {
+			conns[i].conn.Close()
+		}
+
+		conns = conns[i:]
+		p.setIdle(address, conns)
+		if len(conns) > 0 && (next.IsZero() || conns[0].expires.Before(next)) {
+			next = conns[0].expires
+		}
+	}
+
+	if !next.IsZero() {
+		p.timer = time.AfterFunc(next.Sub(now), p.expire)
+	}
+}
+
+// setIdle replaces the idle connections for address, removing it from the map
+// if there are none.
+func (p *DatanodeConnPool) setIdle(address string, conns []idleConn) {
+	if len(conns) == 0 {
+		delete(p.idle, address)
+	} else {
+		p.idle[address] = conns
+	}
+}
+
+// Idle returns the number of idle connections currently in the pool.
+func (p *DatanodeConnPool) Idle() int {
+	if p == nil {
+		return 0
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	n := 0
+	for _, conns := range p.idle {
+		n += len(conns)
+	}
+
+	return n
+}
+
+// Reused returns the number of times a connection has been taken from the
+// pool, instead of dialing a new one.
+func (p *DatanodeConnPool) Reused() int {
+	if p == nil {
+		return 0
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	return p.reused
+}
+
+// Close closes every idle connection, and any that are added later.
+func (p *DatanodeConnPool) Close() {
+	if p == nil {
+		return
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.closed = true
+	if p.timer != nil {
+		p.timer.Stop()
+		p.timer = nil
+	}
+
+	for _, conns := range p.idle {
+		for _, idle := range conns {
+			idle.conn.Close()
+		}
+	}
+
+	p.idle = make(map[string][]idleConn)
+}
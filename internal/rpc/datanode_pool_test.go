@@ -0,0 +1,179 @@
+package rpc
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	hdfs "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_hdfs"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeKeepaliveDatanode is like fakeReadDatanode, but keeps serving read
+// requests on conn, as long as the client reports the read status after each
+// one, like a real datanode.
+func fakeKeepaliveDatanode(conn net.Conn, blocks map[uint64][]byte, statuses chan<- hdfs.Status) {
+	defer conn.Close()
+
+	for {
+		header := make([]byte, 3)
+		_, err := io.ReadFull(conn, header)
+		if err != nil {
+			return
+		}
+
+		op := &hdfs.OpReadBlockProto{}
+		err = readPrefixedMessage(conn, op)
+		if err != nil {
+			return
+		}
+
+		data := blocks[op.GetHeader().GetBaseHeader().GetBlock().GetBlockId()]
+		start, end := op.GetOffset(), op.GetOffset()+op.GetLen()
+		resp := &hdfs.BlockOpResponseProto{
+			Status: hdfs.Status_SUCCESS.Enum(),
+			ReadOpChecksumInfo: &hdfs.ReadOpChecksumInfoProto{
+				Checksum: &hdfs.ChecksumProto{
+					Type:             hdfs.ChecksumTypeProto_CHECKSUM_NULL.Enum(),
+					BytesPerChecksum: proto.Uint32(512),
+				},
+				ChunkOffset: proto.Uint64(start),
+			},
+		}
+
+		b, _ := makePrefixedMessage(resp)
+		_, err = conn.Write(b)
+		if err != nil {
+			return
+		}
+
+		writeFakePacket(conn, start, 0, data[start:end], false)
+		writeFakePacket(conn, end, 1, nil, true)
+
+		status := &hdfs.ClientReadStatusProto{}
+		err = readPrefixedMessage(conn, status)
+		if err != nil {
+			return
+		}
+
+		statuses <- status.GetStatus()
+	}
+}
+
+func TestDatanodeConnPool(t *testing.T) {
+	pool := NewDatanodeConnPool(2, time.Hour)
+	conns := make([]net.Conn, 3)
+	for i := range conns {
+		conns[i], _ = net.Pipe()
+		pool.put("dn1:9866", conns[i])
+	}
+
+	// The oldest connection was closed to make room.
+	assert.Equal(t, 2, pool.Idle())
+	_, err := conns[0].Write([]byte{0})
+	assert.Equal(t, io.ErrClosedPipe, err)
+
+	assert.Nil(t, pool.get("dn2:9866"))
+	assert.Equal(t, conns[2], pool.get("dn1:9866"))
+	assert.Equal(t, conns[1], pool.get("dn1:9866"))
+	assert.Nil(t, pool.get("dn1:9866"))
+	assert.Equal(t, 2, pool.Reused())
+	assert.Equal(t, 0, pool.Idle())
+
+	pool.put("dn1:9866", conns[1])
+	pool.Close()
+	assert.Equal(t, 0, pool.Idle())
+	_, err = conns[1].Write([]byte{0})
+	assert.Equal(t, io.ErrClosedPipe, err)
+
+	// Connections added after Close are closed straight away.
+	pool.put("dn1:9866", conns[2])
+	assert.Equal(t, 0, pool.Idle())
+}
+
+func TestDatanodeConnPoolIdleTimeout(t *testing.T) {
+	pool := NewDatanodeConnPool(2, 20*time.Millisecond)
+	defer pool.Close()
+
+	conn, _ := net.Pipe()
+	pool.put("dn1:9866", conn)
+	assert.Equal(t, 1, pool.Idle())
+
+	// The connection is closed in the background once it expires, even if
+	// the pool isn't used again.
+	deadline := time.Now().Add(time.Second)
+	for pool.Idle() > 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	assert.Equal(t, 0, pool.Idle())
+	_, err := conn.Write([]byte{0})
+	assert.Equal(t, io.ErrClosedPipe, err)
+	assert.Nil(t, pool.get("dn1:9866"))
+}
+
+func TestBlockReaderConnPool(t *testing.T) {
+	data := make([]byte, 10000)
+	rand.Read(data)
+
+	block := testBlock("10.4.0.1")
+	block.B.NumBytes = proto.Uint64(uint64(len(data)))
+	blocks := map[uint64][]byte{block.B.GetBlockId(): data}
+
+	var lock sync.Mutex
+	var dials int
+	statuses := make(chan hdfs.Status, 10)
+	dial := func(ctx context.Context, network, addr string) (net.Conn, error) {
+		lock.Lock()
+		dials++
+		lock.Unlock()
+
+		client, server := net.Pipe()
+		go fakeKeepaliveDatanode(server, blocks, statuses)
+		return client, nil
+	}
+
+	pool := NewDatanodeConnPool(1, time.Hour)
+	defer pool.Close()
+
+	for _, off := range []int64{0, 100, 9999} {
+		br := &BlockReader{Block: block, Offset: off, DialFunc: dial, ConnPool: pool}
+		b, err := ioutil.ReadAll(br)
+		require.NoError(t, err)
+		assert.Equal(t, data[off:], b, "offset %d", off)
+		br.Close()
+
+		assert.Equal(t, hdfs.Status_CHECKSUM_OK, <-statuses)
+		assert.Equal(t, 1, pool.Idle())
+	}
+
+	assert.Equal(t, 1, dials)
+	assert.Equal(t, 2, pool.Reused())
+
+	// A reader closed before the end of the block doesn't return its
+	// connection, since the rest of the block is still on the way.
+	br := &BlockReader{Block: block, DialFunc: dial, ConnPool: pool}
+	_, err := io.ReadFull(br, make([]byte, 100))
+	require.NoError(t, err)
+	br.Close()
+	assert.Equal(t, 0, pool.Idle())
+
+	// If the datanode has closed a pooled connection, a new one is dialed.
+	client, server := net.Pipe()
+	server.Close()
+	pool.put(getDatanodeAddress(block.GetLocs()[0].GetId(), false), client)
+
+	br = &BlockReader{Block: block, DialFunc: dial, ConnPool: pool}
+	b, err := ioutil.ReadAll(br)
+	require.NoError(t, err)
+	assert.Equal(t, data, b)
+	br.Close()
+	assert.Equal(t, 2, dials)
+}
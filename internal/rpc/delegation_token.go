@@ -0,0 +1,198 @@
+package rpc
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+
+	hadoop "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_common"
+)
+
+var errTokenNotSupported = errors.New("token authentication not supported by namenode")
+
+// doTokenHandshake authenticates the connection with a delegation token, using
+// the DIGEST-MD5 SASL mechanism. The token's identifier and password, base64
+// encoded, are the username and password. Only the "auth" QOP is supported,
+// so the namenode's hadoop.rpc.protection must be "authentication".
+func (c *NamenodeConnection) doTokenHandshake() error {
+	c.currentRequestID = saslRpcCallId
+
+	err := c.writeSaslRequest(&hadoop.RpcSaslProto{State: hadoop.RpcSaslProto_NEGOTIATE.Enum()})
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.readSaslResponse(hadoop.RpcSaslProto_NEGOTIATE)
+	if err != nil {
+		return err
+	}
+
+	var mechanism *hadoop.RpcSaslProto_SaslAuth
+	for _, m := range resp.GetAuths() {
+		if m.GetMethod() == "TOKEN" && m.GetMechanism() == "DIGEST-MD5" {
+			mechanism = m
+		}
+	}
+
+	if mechanism == nil {
+		return errTokenNotSupported
+	}
+
+	digest := &digestMD5Client{
+		username: base64.StdEncoding.EncodeToString(c.token.GetIdentifier()),
+		password: base64.StdEncoding.EncodeToString(c.token.GetPassword()),
+		protocol: mechanism.GetProtocol(),
+		server:   mechanism.GetServerId(),
+		qops:     []string{qopAuth},
+	}
+
+	// The namenode usually includes the challenge with the list of mechanisms.
+	// If it doesn't, it sends it in response to an empty initial message.
+	challenge := mechanism.GetChallenge()
+	state := hadoop.RpcSaslProto_INITIATE
+	auth := &hadoop.RpcSaslProto_SaslAuth{
+		Method:    mechanism.Method,
+		Mechanism: mechanism.Mechanism,
+		Protocol:  mechanism.Protocol,
+		ServerId:  mechanism.ServerId,
+	}
+
+	if challenge == nil {
+		err = c.writeSaslRequest(&hadoop.RpcSaslProto{
+			State: hadoop.RpcSaslProto_INITIATE.Enum(),
+			Auths: []*hadoop.RpcSaslProto_SaslAuth{auth},
+		})
+		if err != nil {
+			return err
+		}
+
+		resp, err = c.readSaslResponse(hadoop.RpcSaslProto_CHALLENGE)
+		if err != nil {
+			return err
+		}
+
+		challenge = resp.GetToken()
+		state = hadoop.RpcSaslProto_RESPONSE
+		auth = nil
+	}
+
+	response, err := digest.challengeResponse(challenge)
+	if err != nil {
+		return err
+	}
+
+	req := &hadoop.RpcSaslProto{State: state.Enum(), Token: response}
+	if auth != nil {
+		req.Auths = []*hadoop.RpcSaslProto_SaslAuth{auth}
+	}
+
+	err = c.writeSaslRequest(req)
+	if err != nil {
+		return err
+	}
+
+	// The final response includes rspauth, which proves that the namenode
+	// knows the token's password too.
+	resp, err = c.readSaslResponse(hadoop.RpcSaslProto_SUCCESS)
+	if err != nil {
+		return err
+	}
+
+	return digest.verifyServer(resp.GetToken())
+}
+
+// DelegationTokenOwner returns the owner of a delegation token, from its
+// identifier. That's the user that a connection authenticated with the token
+// acts as.
+//
+// The identifier is serialized as a Hadoop Writable:
+// +-----------------------------------------------------------+
+// |  Version, 1 byte (0x00)                                   |
+// +-----------------------------------------------------------+
+// |  vint length + owner                                      |
+// +-----------------------------------------------------------+
+// |  vint length + renewer                                    |
+// +-----------------------------------------------------------+
+// |  vint length + real user                                  |
+// +-----------------------------------------------------------+
+// |  vlong issue date, vlong max date, vint sequence number,  |
+// |  vint master key ID                                       |
+// +-----------------------------------------------------------+
+func DelegationTokenOwner(identifier []byte) (string, error) {
+	r := bytes.NewReader(identifier)
+	version, err := r.ReadByte()
+	if err != nil {
+		return "", errMalformedTokenIdentifier
+	} else if version != 0 {
+		return "", fmt.Errorf("unsupported token identifier version: %d", version)
+	}
+
+	owner, err := readWritableText(r)
+	if err != nil {
+		return "", errMalformedTokenIdentifier
+	}
+
+	return owner, nil
+}
+
+var errMalformedTokenIdentifier = errors.New("malformed token identifier")
+
+// readWritableVLong reads a variable-length integer, as written by Hadoop's
+// WritableUtils.writeVLong. Values from -112 to 127 take a single byte;
+// otherwise, the first byte encodes the sign and the number of bytes that
+// follow, big-endian.
+func readWritableVLong(r io.ByteReader) (int64, error) {
+	first, err := r.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+
+	v := int8(first)
+	if v >= -112 {
+		return int64(v), nil
+	}
+
+	negative := v < -120
+	n := int(-112 - v)
+	if negative {
+		n = int(-120 - v)
+	}
+
+	var i int64
+	for j := 0; j < n; j++ {
+		b, err := r.ReadByte()
+		if err != nil {
+			return 0, io.ErrUnexpectedEOF
+		}
+
+		i = i<<8 | int64(b)
+	}
+
+	if negative {
+		i = ^i
+	}
+
+	return i, nil
+}
+
+// readWritableBytes reads a vint length followed by that many bytes, which
+// is how Hadoop serializes Text and byte arrays.
+func readWritableBytes(r *bytes.Reader) ([]byte, error) {
+	length, err := readWritableVLong(r)
+	if err != nil {
+		return nil, err
+	} else if length < 0 || length > int64(r.Len()) {
+		return nil, io.ErrUnexpectedEOF
+	}
+
+	b := make([]byte, length)
+	_, err = io.ReadFull(r, b)
+	return b, err
+}
+
+func readWritableText(r *bytes.Reader) (string, error) {
+	b, err := readWritableBytes(r)
+	return string(b), err
+}
@@ -0,0 +1,275 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+
+	hadoop "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_common"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writableText(s string) []byte {
+	return append([]byte{byte(len(s))}, s...)
+}
+
+func testTokenIdentifier(owner string) []byte {
+	b := []byte{0}
+	b = append(b, writableText(owner)...)
+	b = append(b, writableText("yarn")...)
+	b = append(b, writableText("")...)
+	// The issue and max dates, which need more than one byte.
+	b = append(b, 0x8a, 0x01, 0x8f, 0x00, 0x00, 0x00, 0x00)
+	b = append(b, 0x8a, 0x01, 0x8f, 0x10, 0x00, 0x00, 0x00)
+	return append(b, 0x05, 0x02)
+}
+
+func TestReadWritableVLong(t *testing.T) {
+	cases := []struct {
+		b []byte
+		v int64
+	}{
+		{[]byte{0x00}, 0},
+		{[]byte{0x7f}, 127},
+		{[]byte{0x90}, -112},
+		{[]byte{0x8f, 0x80}, 128},
+		{[]byte{0x8e, 0x01, 0x00}, 256},
+		{[]byte{0x87, 0x70}, -113},
+		{[]byte{0x80, 0x7f, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, -1 << 63},
+	}
+
+	for _, c := range cases {
+		v, err := readWritableVLong(bytes.NewReader(c.b))
+		require.NoError(t, err)
+		assert.Equal(t, c.v, v, "decoding %x", c.b)
+	}
+
+	_, err := readWritableVLong(bytes.NewReader([]byte{0x8e, 0x01}))
+	assert.Equal(t, io.ErrUnexpectedEOF, err)
+}
+
+func TestDelegationTokenOwner(t *testing.T) {
+	owner, err := DelegationTokenOwner(testTokenIdentifier("alice"))
+	require.NoError(t, err)
+	assert.Equal(t, "alice", owner)
+
+	_, err = DelegationTokenOwner([]byte{0, 10, 'a'})
+	assert.Equal(t, errMalformedTokenIdentifier, err)
+
+	_, err = DelegationTokenOwner([]byte{1})
+	assert.Error(t, err)
+}
+
+func TestReadTokenStorage(t *testing.T) {
+	identifier := testTokenIdentifier("alice")
+	password := []byte("password")
+
+	v0 := []byte("HDTS\x00\x01")
+	v0 = append(v0, writableText("ha-hdfs:cluster")...)
+	v0 = append(v0, writableText(string(identifier))...)
+	v0 = append(v0, writableText(string(password))...)
+	v0 = append(v0, writableText("HDFS_DELEGATION_TOKEN")...)
+	v0 = append(v0, writableText("ha-hdfs:cluster")...)
+	v0 = append(v0, 0x00)
+
+	tokens, err := ReadTokenStorage(v0)
+	require.NoError(t, err)
+	require.Len(t, tokens, 1)
+	assert.Equal(t, identifier, tokens[0].GetIdentifier())
+	assert.Equal(t, password, tokens[0].GetPassword())
+	assert.Equal(t, "HDFS_DELEGATION_TOKEN", tokens[0].GetKind())
+	assert.Equal(t, "ha-hdfs:cluster", tokens[0].GetService())
+
+	_, err = ReadTokenStorage(v0[:len(v0)-10])
+	assert.Equal(t, errMalformedTokenStorage, err)
+
+	creds := &credentialsProto{
+		Tokens: []*credentialsKVProto{{
+			Alias: proto.String("10.0.0.1:8020"),
+			Token: &hadoop.TokenProto{
+				Identifier: identifier,
+				Password:   password,
+				Kind:       proto.String("HDFS_DELEGATION_TOKEN"),
+				Service:    proto.String("10.0.0.1:8020"),
+			},
+		}},
+		Secrets: []*credentialsKVProto{{
+			Alias:  proto.String("secret"),
+			Secret: []byte("shh"),
+		}},
+	}
+
+	msg, err := makePrefixedMessage(creds)
+	require.NoError(t, err)
+
+	tokens, err = ReadTokenStorage(append([]byte("HDTS\x01"), msg...))
+	require.NoError(t, err)
+	require.Len(t, tokens, 1)
+	assert.Equal(t, identifier, tokens[0].GetIdentifier())
+	assert.Equal(t, "10.0.0.1:8020", tokens[0].GetService())
+
+	_, err = ReadTokenStorage([]byte("HDTS\x02"))
+	assert.Error(t, err)
+
+	_, err = ReadTokenStorage([]byte("nope"))
+	assert.Equal(t, errMalformedTokenStorage, err)
+}
+
+// fakeTokenNamenode plays the namenode's side of the DIGEST-MD5 handshake, and
+// returns the user from the connection context.
+func fakeTokenNamenode(conn net.Conn, token *hadoop.TokenProto, challengeFirst bool) (string, error) {
+	defer conn.Close()
+
+	header := make([]byte, 7)
+	_, err := io.ReadFull(conn, header)
+	if err != nil {
+		return "", err
+	} else if header[6] != saslAuthProtocol {
+		return "", fmt.Errorf("unexpected auth protocol: %d", header[6])
+	}
+
+	callID := int32(saslRpcCallId)
+	respond := func(msg *hadoop.RpcSaslProto) error {
+		packet, err := makeRPCPacket(&hadoop.RpcResponseHeaderProto{
+			CallId: proto.Uint32(uint32(callID)),
+			Status: hadoop.RpcResponseHeaderProto_SUCCESS.Enum(),
+		}, msg)
+		if err != nil {
+			return err
+		}
+
+		_, err = conn.Write(packet)
+		return err
+	}
+
+	read := func(expected hadoop.RpcSaslProto_SaslState) (*hadoop.RpcSaslProto, error) {
+		msg := &hadoop.RpcSaslProto{}
+		err := readRPCPacket(conn, &hadoop.RpcRequestHeaderProto{}, msg)
+		if err != nil {
+			return nil, err
+		} else if msg.GetState() != expected {
+			return nil, fmt.Errorf("unexpected state: %s", msg.GetState())
+		}
+
+		return msg, nil
+	}
+
+	_, err = read(hadoop.RpcSaslProto_NEGOTIATE)
+	if err != nil {
+		return "", err
+	}
+
+	challenge := []byte(`realm="default",nonce="srvnonce",qop="auth",charset=utf-8,algorithm=md5-sess`)
+	auth := &hadoop.RpcSaslProto_SaslAuth{
+		Method:    proto.String("TOKEN"),
+		Mechanism: proto.String("DIGEST-MD5"),
+		Protocol:  proto.String(""),
+		ServerId:  proto.String("default"),
+	}
+
+	if challengeFirst {
+		auth.Challenge = challenge
+	}
+
+	err = respond(&hadoop.RpcSaslProto{
+		State: hadoop.RpcSaslProto_NEGOTIATE.Enum(),
+		Auths: []*hadoop.RpcSaslProto_SaslAuth{auth},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	msg, err := read(hadoop.RpcSaslProto_INITIATE)
+	if err != nil {
+		return "", err
+	}
+
+	if !challengeFirst {
+		err = respond(&hadoop.RpcSaslProto{State: hadoop.RpcSaslProto_CHALLENGE.Enum(), Token: challenge})
+		if err != nil {
+			return "", err
+		}
+
+		msg, err = read(hadoop.RpcSaslProto_RESPONSE)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	params, err := parseDigestChallenge(string(msg.GetToken()))
+	if err != nil {
+		return "", err
+	}
+
+	d := &digestMD5Client{
+		username: base64.StdEncoding.EncodeToString(token.GetIdentifier()),
+		password: base64.StdEncoding.EncodeToString(token.GetPassword()),
+		protocol: "",
+		server:   "default",
+		qops:     []string{qopAuth},
+		cnonce:   params["cnonce"],
+	}
+
+	_, err = d.challengeResponse(challenge)
+	if err != nil {
+		return "", err
+	} else if params["username"] != d.username || params["response"] != d.responseValue("AUTHENTICATE") {
+		return "", errors.New("bad response")
+	}
+
+	err = respond(&hadoop.RpcSaslProto{
+		State: hadoop.RpcSaslProto_SUCCESS.Enum(),
+		Token: []byte("rspauth=" + d.responseValue("")),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	cc := &hadoop.IpcConnectionContextProto{}
+	err = readRPCPacket(conn, &hadoop.RpcRequestHeaderProto{}, cc)
+	return cc.GetUserInfo().GetEffectiveUser(), err
+}
+
+func TestNamenodeTokenHandshake(t *testing.T) {
+	for _, challengeFirst := range []bool{true, false} {
+		t.Run(fmt.Sprintf("challengeFirst=%v", challengeFirst), func(t *testing.T) {
+			token := &hadoop.TokenProto{
+				Identifier: testTokenIdentifier("alice"),
+				Password:   []byte("password"),
+				Kind:       proto.String("HDFS_DELEGATION_TOKEN"),
+				Service:    proto.String("ha-hdfs:cluster"),
+			}
+
+			errs := make(chan error, 1)
+			users := make(chan string, 1)
+			c, err := NewNamenodeConnection(NamenodeConnectionOptions{
+				Addresses: []string{"nn:8020"},
+				User:      "ignored",
+				Token:     token,
+				DialFunc: func(ctx context.Context, network, addr string) (net.Conn, error) {
+					client, server := net.Pipe()
+					go func() {
+						user, err := fakeTokenNamenode(server, token, challengeFirst)
+						users <- user
+						errs <- err
+					}()
+
+					return client, nil
+				},
+			})
+			require.NoError(t, err)
+			defer c.Close()
+
+			assert.Equal(t, "alice", c.User)
+			assert.Equal(t, "alice", <-users)
+			assert.NoError(t, <-errs)
+		})
+	}
+}
@@ -0,0 +1,445 @@
+package rpc
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"crypto/des"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/rc4"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// This is a client for the DIGEST-MD5 SASL mechanism (RFC 2831), which is
+// what the datanodes use to authenticate data transfer connections. It only
+// implements what's needed to interoperate with the Java implementation, as
+// used by Hadoop: the md5-sess algorithm, and the "auth", "auth-int", and
+// "auth-conf" QOPs, with the 3des and rc4 ciphers.
+
+const (
+	qopAuth     = "auth"
+	qopAuthInt  = "auth-int"
+	qopAuthConf = "auth-conf"
+
+	digestMaxBuf = 65536
+
+	clientIntegrityMagic = "Digest session key to client-to-server signing key magic constant"
+	serverIntegrityMagic = "Digest session key to server-to-client signing key magic constant"
+	clientSealingMagic   = "Digest H(A1) to client-to-server sealing key magic constant"
+	serverSealingMagic   = "Digest H(A1) to server-to-client sealing key magic constant"
+)
+
+// digestCiphers are the supported ciphers, in order of preference.
+var digestCiphers = []string{"3des", "rc4"}
+
+var errDigestMD5Mutual = errors.New("DIGEST-MD5: server failed to authenticate")
+
+// digestMD5Client holds the state of a DIGEST-MD5 exchange.
+type digestMD5Client struct {
+	username, password string
+	protocol, server   string
+	qops               []string
+	// ciphers are the acceptable ciphers for auth-conf, in order of
+	// preference. If it's nil, digestCiphers is used.
+	ciphers []string
+
+	nonce, cnonce, realm string
+	qop, cipher          string
+	hA1                  []byte
+	maxBuf               int
+}
+
+// challengeResponse computes the response to the server's initial challenge.
+func (d *digestMD5Client) challengeResponse(challenge []byte) ([]byte, error) {
+	params, err := parseDigestChallenge(string(challenge))
+	if err != nil {
+		return nil, err
+	}
+
+	if alg := params["algorithm"]; alg != "md5-sess" {
+		return nil, fmt.Errorf("DIGEST-MD5: unsupported algorithm: %q", alg)
+	}
+
+	d.nonce = params["nonce"]
+	if d.nonce == "" {
+		return nil, errors.New("DIGEST-MD5: missing nonce in challenge")
+	}
+
+	// The Java client uses the first realm offered, if any.
+	if realms := params["realm"]; realms != "" {
+		d.realm = strings.Split(realms, ",")[0]
+	}
+
+	d.qop = chooseToken(d.qops, params["qop"], qopAuth)
+	if d.qop == "" {
+		return nil, fmt.Errorf("DIGEST-MD5: no acceptable qop in %q", params["qop"])
+	}
+
+	if d.qop == qopAuthConf {
+		ciphers := d.ciphers
+		if ciphers == nil {
+			ciphers = digestCiphers
+		}
+
+		d.cipher = chooseToken(ciphers, params["cipher"], "")
+		if d.cipher == "" {
+			return nil, fmt.Errorf("DIGEST-MD5: no supported cipher in %q", params["cipher"])
+		}
+	}
+
+	d.maxBuf = digestMaxBuf
+	if s := params["maxbuf"]; s != "" {
+		d.maxBuf, err = strconv.Atoi(s)
+		if err != nil || d.maxBuf <= 0 {
+			return nil, fmt.Errorf("DIGEST-MD5: invalid maxbuf: %q", s)
+		}
+	}
+
+	if d.cnonce == "" {
+		b := make([]byte, 24)
+		_, err = rand.Read(b)
+		if err != nil {
+			return nil, err
+		}
+
+		d.cnonce = base64.StdEncoding.EncodeToString(b)
+	}
+
+	// A1 = { H( { username-value, ":", realm-value, ":", passwd } ),
+	//        ":", nonce-value, ":", cnonce-value }
+	userHash := md5.Sum([]byte(d.username + ":" + d.realm + ":" + d.password))
+	a1 := append(userHash[:], ":"+d.nonce+":"+d.cnonce...)
+	hA1 := md5.Sum(a1)
+	d.hA1 = hA1[:]
+
+	var resp bytes.Buffer
+	if params["charset"] == "utf-8" {
+		resp.WriteString("charset=utf-8,")
+	}
+
+	fmt.Fprintf(&resp, `username="%s",`, quoteDigestValue(d.username))
+	if d.realm != "" {
+		fmt.Fprintf(&resp, `realm="%s",`, quoteDigestValue(d.realm))
+	}
+
+	fmt.Fprintf(&resp, `nonce="%s",nc=00000001,cnonce="%s",digest-uri="%s",maxbuf=%d,response=%s,qop=%s`,
+		quoteDigestValue(d.nonce), d.cnonce, d.digestURI(), digestMaxBuf, d.responseValue("AUTHENTICATE"), d.qop)
+	if d.cipher != "" {
+		fmt.Fprintf(&resp, `,cipher="%s"`, d.cipher)
+	}
+
+	return resp.Bytes(), nil
+}
+
+// verifyServer checks the rspauth value in the server's final challenge.
+func (d *digestMD5Client) verifyServer(challenge []byte) error {
+	params, err := parseDigestChallenge(string(challenge))
+	if err != nil {
+		return err
+	}
+
+	rspauth := params["rspauth"]
+	if !hmac.Equal([]byte(rspauth), []byte(d.responseValue(""))) {
+		return errDigestMD5Mutual
+	}
+
+	return nil
+}
+
+func (d *digestMD5Client) digestURI() string {
+	return d.protocol + "/" + d.server
+}
+
+// responseValue computes the response directive (or, with an empty method,
+// the expected rspauth directive from the server).
+func (d *digestMD5Client) responseValue(method string) string {
+	a2 := method + ":" + d.digestURI()
+	if d.qop == qopAuthInt || d.qop == qopAuthConf {
+		a2 += ":00000000000000000000000000000000"
+	}
+
+	hA2 := md5.Sum([]byte(a2))
+	kd := hex.EncodeToString(d.hA1) + ":" + d.nonce + ":00000001:" + d.cnonce + ":" + d.qop + ":" + hex.EncodeToString(hA2[:])
+	sum := md5.Sum([]byte(kd))
+	return hex.EncodeToString(sum[:])
+}
+
+// security returns the security layer negotiated by the exchange, or nil if
+// none was (with the "auth" QOP).
+func (d *digestMD5Client) security() (*digestSecurity, error) {
+	if d.qop == qopAuth {
+		return nil, nil
+	}
+
+	s := &digestSecurity{
+		sendKey: digestKey(d.hA1, clientIntegrityMagic),
+		recvKey: digestKey(d.hA1, serverIntegrityMagic),
+		// Leave room for the MAC, message type, and sequence number, and any
+		// padding.
+		maxRaw: d.maxBuf - 16,
+	}
+
+	if d.qop != qopAuthConf {
+		return s, nil
+	}
+
+	// The whole of H(A1) is used for the sealing keys, since neither of the
+	// ciphers supported is one of the weakened ones (rc4-40 and rc4-56).
+	s.maxRaw -= 10
+	sendSeal := digestKey(d.hA1, clientSealingMagic)
+	recvSeal := digestKey(d.hA1, serverSealingMagic)
+
+	var err error
+	switch d.cipher {
+	case "rc4":
+		s.enc, err = rc4.NewCipher(sendSeal)
+		if err == nil {
+			s.dec, err = rc4.NewCipher(recvSeal)
+		}
+	case "3des":
+		s.blockSize = des.BlockSize
+		s.encBlock, err = newDigestTripleDES(sendSeal, true)
+		if err == nil {
+			s.decBlock, err = newDigestTripleDES(recvSeal, false)
+		}
+	default:
+		err = fmt.Errorf("DIGEST-MD5: unsupported cipher: %q", d.cipher)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func digestKey(hA1 []byte, magic string) []byte {
+	sum := md5.Sum(append(append([]byte(nil), hA1...), magic...))
+	return sum[:]
+}
+
+// newDigestTripleDES sets up 3DES in CBC mode, as the Java implementation
+// does: the key is two DES keys (k1, k2, k1) made from the first 14 bytes of
+// the sealing key, and the IV is its last 8 bytes.
+func newDigestTripleDES(sealKey []byte, encrypt bool) (cipher.BlockMode, error) {
+	k1 := addDESParity(sealKey[0:7])
+	k2 := addDESParity(sealKey[7:14])
+	key := append(append(append([]byte(nil), k1...), k2...), k1...)
+
+	block, err := des.NewTripleDESCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	iv := sealKey[8:16]
+	if encrypt {
+		return cipher.NewCBCEncrypter(block, iv), nil
+	}
+
+	return cipher.NewCBCDecrypter(block, iv), nil
+}
+
+// addDESParity spreads 56 bits of key material over 8 bytes, with an odd
+// parity bit at the bottom of each.
+func addDESParity(b []byte) []byte {
+	var n uint64
+	for _, c := range b {
+		n = n<<8 | uint64(c)
+	}
+
+	res := make([]byte, 8)
+	for i := 7; i >= 0; i-- {
+		c := byte(n&0x7f) << 1
+		n >>= 7
+
+		ones := 0
+		for x := c; x != 0; x >>= 1 {
+			ones += int(x & 1)
+		}
+
+		if ones%2 == 0 {
+			c |= 1
+		}
+
+		res[i] = c
+	}
+
+	return res
+}
+
+// digestSecurity is the security layer of a completed DIGEST-MD5 exchange,
+// which adds a MAC to each message and, with the auth-conf QOP, encrypts it.
+type digestSecurity struct {
+	sendKey, recvKey []byte
+	sendSeq, recvSeq uint32
+	maxRaw           int
+
+	// Exactly one of these pairs is set, for auth-conf.
+	enc, dec           cipher.Stream
+	encBlock, decBlock cipher.BlockMode
+	blockSize          int
+}
+
+func (s *digestSecurity) mac(key []byte, seq uint32, msg []byte) []byte {
+	var seqBytes [4]byte
+	binary.BigEndian.PutUint32(seqBytes[:], seq)
+	h := hmac.New(md5.New, key)
+	h.Write(seqBytes[:])
+	h.Write(msg)
+	return h.Sum(nil)[:10]
+}
+
+// wrap protects a message to be sent to the server.
+func (s *digestSecurity) wrap(msg []byte) []byte {
+	mac := s.mac(s.sendKey, s.sendSeq, msg)
+
+	var res []byte
+	if s.enc == nil && s.encBlock == nil {
+		res = append(append([]byte(nil), msg...), mac...)
+	} else {
+		// CIPHER(Kc, {msg, pad, HMAC(Ki, {SeqNum, msg})[0..9]})
+		res = make([]byte, 0, len(msg)+s.blockSize+16)
+		res = append(res, msg...)
+		if s.blockSize > 1 {
+			pad := s.blockSize - (len(msg)+10)%s.blockSize
+			res = append(res, bytes.Repeat([]byte{byte(pad)}, pad)...)
+		}
+
+		res = append(res, mac...)
+		if s.enc != nil {
+			s.enc.XORKeyStream(res, res)
+		} else {
+			s.encBlock.CryptBlocks(res, res)
+		}
+	}
+
+	// The message type is always 1, followed by the sequence number.
+	res = append(res, 0, 1, 0, 0, 0, 0)
+	binary.BigEndian.PutUint32(res[len(res)-4:], s.sendSeq)
+	s.sendSeq++
+	return res
+}
+
+// unwrap checks and decrypts a message received from the server.
+func (s *digestSecurity) unwrap(b []byte) ([]byte, error) {
+	if len(b) < 16 {
+		return nil, errors.New("DIGEST-MD5: message is too short")
+	}
+
+	body, trailer := b[:len(b)-6], b[len(b)-6:]
+	seq := binary.BigEndian.Uint32(trailer[2:])
+	if trailer[0] != 0 || trailer[1] != 1 {
+		return nil, errors.New("DIGEST-MD5: invalid message type")
+	} else if seq != s.recvSeq {
+		return nil, fmt.Errorf("DIGEST-MD5: out of sequence message: %d, expected %d", seq, s.recvSeq)
+	}
+
+	body = append([]byte(nil), body...)
+	if s.dec != nil {
+		s.dec.XORKeyStream(body, body)
+	} else if s.decBlock != nil {
+		if len(body)%s.blockSize != 0 {
+			return nil, errors.New("DIGEST-MD5: invalid message length")
+		}
+
+		s.decBlock.CryptBlocks(body, body)
+	}
+
+	msg, mac := body[:len(body)-10], body[len(body)-10:]
+	if s.decBlock != nil {
+		pad := 0
+		if len(msg) > 0 {
+			pad = int(msg[len(msg)-1])
+		}
+
+		if pad < 1 || pad > s.blockSize || pad > len(msg) {
+			return nil, errors.New("DIGEST-MD5: invalid padding")
+		}
+
+		msg = msg[:len(msg)-pad]
+	}
+
+	if !hmac.Equal(mac, s.mac(s.recvKey, seq, msg)) {
+		return nil, errors.New("DIGEST-MD5: invalid MAC")
+	}
+
+	s.recvSeq++
+	return msg, nil
+}
+
+// parseDigestChallenge parses a comma-separated list of directives, where
+// each value is either a token or a quoted string.
+func parseDigestChallenge(s string) (map[string]string, error) {
+	params := make(map[string]string)
+	for {
+		s = strings.TrimLeft(s, " \t,")
+		if s == "" {
+			return params, nil
+		}
+
+		eq := strings.IndexByte(s, '=')
+		if eq <= 0 {
+			return nil, fmt.Errorf("DIGEST-MD5: invalid challenge: %q", s)
+		}
+
+		key := strings.ToLower(strings.TrimSpace(s[:eq]))
+		s = strings.TrimLeft(s[eq+1:], " \t")
+
+		var value strings.Builder
+		if strings.HasPrefix(s, `"`) {
+			i := 1
+			for ; i < len(s) && s[i] != '"'; i++ {
+				if s[i] == '\\' && i+1 < len(s) {
+					i++
+				}
+
+				value.WriteByte(s[i])
+			}
+
+			if i == len(s) {
+				return nil, errors.New("DIGEST-MD5: unterminated quoted string in challenge")
+			}
+
+			s = s[i+1:]
+		} else {
+			end := strings.IndexByte(s, ',')
+			if end == -1 {
+				end = len(s)
+			}
+
+			value.WriteString(strings.TrimSpace(s[:end]))
+			s = s[end:]
+		}
+
+		params[key] = value.String()
+	}
+}
+
+// chooseToken returns the first of preferred that's in the comma-separated
+// list offered, which defaults to def if it's empty.
+func chooseToken(preferred []string, offered, def string) string {
+	if offered == "" {
+		offered = def
+	}
+
+	for _, p := range preferred {
+		for _, o := range strings.Split(offered, ",") {
+			if strings.TrimSpace(o) == p {
+				return p
+			}
+		}
+	}
+
+	return ""
+}
+
+func quoteDigestValue(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s)
+}
@@ -2,8 +2,10 @@ package rpc
 
 import (
 	"fmt"
+	"strings"
 
 	hadoop "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_common"
+	hdfs "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_hdfs"
 )
 
 // NamenodeError represents an interepreted error from the Namenode, including
@@ -40,5 +42,55 @@ func (err *NamenodeError) Error() string {
 	return s
 }
 
+// DatanodeError represents an error response from a datanode to a data
+// transfer operation, like reading or writing a block. It implements
+// hdfs.Error.
 type DatanodeError struct {
+	op           string
+	status       hdfs.Status
+	message      string
+	firstBadLink string
+}
+
+func newDatanodeError(op string, resp *hdfs.BlockOpResponseProto) *DatanodeError {
+	return &DatanodeError{
+		op:           op,
+		status:       resp.GetStatus(),
+		message:      resp.GetMessage(),
+		firstBadLink: resp.GetFirstBadLink(),
+	}
+}
+
+func (err *DatanodeError) Method() string {
+	return err.op + "Block"
+}
+
+func (err *DatanodeError) Desc() string {
+	return err.status.String()
+}
+
+// Exception returns the java exception class name from the message, if the
+// datanode included one. Datanodes report exceptions with messages like
+// "opReadBlock <block> received exception <class>: <message>".
+func (err *DatanodeError) Exception() string {
+	const marker = "received exception "
+	i := strings.Index(err.message, marker)
+	if i == -1 {
+		return ""
+	}
+
+	exception := err.message[i+len(marker):]
+	if end := strings.IndexAny(exception, ": \n"); end != -1 {
+		exception = exception[:end]
+	}
+
+	return exception
+}
+
+func (err *DatanodeError) Message() string {
+	return err.message
+}
+
+func (err *DatanodeError) Error() string {
+	return fmt.Sprintf("%s failed: %s (%s)", err.op, err.status.String(), err.message)
 }
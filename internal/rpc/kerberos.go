@@ -126,7 +126,11 @@ func (c *NamenodeConnection) readSaslResponse(expectedState hadoop.RpcSaslProto_
 // getKerberosTicket returns an initial kerberos negotiation token and the
 // paired session key, along with an error if any occured.
 func (c *NamenodeConnection) getKerberosTicket() (gssapi.NegTokenInit, krbtypes.EncryptionKey, error) {
-	host, _, _ := net.SplitHostPort(c.host.address)
+	host := c.host.hostname
+	if host == "" {
+		host, _, _ = net.SplitHostPort(c.host.address)
+	}
+
 	spn := replaceSPNHostWildcard(c.kerberosServicePrincipleName, host)
 
 	ticket, key, err := c.kerberosClient.GetServiceTicket(spn)
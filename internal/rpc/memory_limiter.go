@@ -0,0 +1,186 @@
+package rpc
+
+import "sync"
+
+// MemoryLimiterStats describes the memory held by the buffers accounted to a
+// MemoryLimiter.
+type MemoryLimiterStats struct {
+	// Limit is the number of bytes the limiter allows.
+	Limit int64
+	// InUse is the number of bytes currently held.
+	InUse int64
+	// Peak is the most bytes that have been held at once.
+	Peak int64
+	// Waits is the number of times a buffer had to wait for memory to be
+	// released.
+	Waits int
+}
+
+// A MemoryLimiter caps the total size of the buffers held by a set of readers
+// and writers, such as the packets waiting to be acked, the stripes of
+// erasure-coded reads, and data read ahead of being written. Once the limit is
+// reached, acquiring memory blocks until enough of it has been released.
+//
+// To make sure transfers can't deadlock waiting on each other, the limit is
+// soft in three ways: a single buffer may always be acquired if nothing else is
+// held, no matter its size, an owner that already holds memory may always
+// acquire more (see AcquireOwned), and a block being written may always have
+// at least one packet in flight. The limit can therefore be exceeded by up to
+// a packet for each block being written at once, plus whatever the owners
+// acquire beyond their first buffer.
+//
+// A nil *MemoryLimiter is valid, and doesn't limit anything.
+type MemoryLimiter struct {
+	limit int64
+
+	lock  sync.Mutex
+	cond  *sync.Cond
+	used  int64
+	peak  int64
+	waits int
+}
+
+// NewMemoryLimiter returns a MemoryLimiter that allows limit bytes to be held
+// at once.
+func NewMemoryLimiter(limit int64) *MemoryLimiter {
+	m := &MemoryLimiter{limit: limit}
+	m.cond = sync.NewCond(&m.lock)
+	return m
+}
+
+// Acquire blocks until n bytes can be held without going over the limit, or
+// until nothing else is held, and then accounts for them. They must be given
+// back with Release.
+func (m *MemoryLimiter) Acquire(n int64) {
+	if m == nil {
+		return
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.wait(n, func() bool { return m.used == 0 })
+	m.add(n)
+}
+
+// Release gives back n bytes acquired with Acquire.
+func (m *MemoryLimiter) Release(n int64) {
+	if m == nil || n == 0 {
+		return
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.used -= n
+	m.cond.Broadcast()
+}
+
+// AcquireOwned is like Acquire, for one of several buffers belonging to the
+// same reader or writer, which holds *owned bytes in total. It only blocks if
+// the owner holds nothing yet: an owner never waits while holding memory, so
+// two owners can't end up each waiting for the other to release theirs. owned
+// is protected by the limiter's lock, and must only be changed by AcquireOwned
+// and ReleaseOwned. If owned is nil, it's the same as Acquire.
+func (m *MemoryLimiter) AcquireOwned(owned *int64, n int64) {
+	if m == nil {
+		return
+	} else if owned == nil {
+		m.Acquire(n)
+		return
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.wait(n, func() bool { return *owned > 0 || m.used == 0 })
+	m.add(n)
+	*owned += n
+}
+
+// ReleaseOwned gives back n bytes acquired by AcquireOwned.
+func (m *MemoryLimiter) ReleaseOwned(owned *int64, n int64) {
+	if m == nil || n == 0 {
+		return
+	} else if owned == nil {
+		m.Release(n)
+		return
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.used -= n
+	*owned -= n
+	m.cond.Broadcast()
+}
+
+// acquireQueued is like Acquire, for a queue that holds *queued bytes. It only
+// blocks if the queue already holds something, so that there's always room
+// for at least one buffer in it. queued is protected by the limiter's lock,
+// and must only be changed by acquireQueued and releaseQueued.
+func (m *MemoryLimiter) acquireQueued(queued *int64, n int64) {
+	if m == nil {
+		return
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.wait(n, func() bool { return *queued == 0 })
+	m.add(n)
+	*queued += n
+}
+
+// releaseQueued gives back n bytes acquired by acquireQueued.
+func (m *MemoryLimiter) releaseQueued(queued *int64, n int64) {
+	if m == nil || n == 0 {
+		return
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.used -= n
+	*queued -= n
+	m.cond.Broadcast()
+}
+
+// wait waits until n more bytes fit under the limit, or free returns true. It
+// must be called with the lock held.
+func (m *MemoryLimiter) wait(n int64, free func() bool) {
+	waited := false
+	for m.used+n > m.limit && !free() {
+		if !waited {
+			m.waits++
+			waited = true
+		}
+
+		m.cond.Wait()
+	}
+}
+
+func (m *MemoryLimiter) add(n int64) {
+	m.used += n
+	if m.used > m.peak {
+		m.peak = m.used
+	}
+}
+
+// Stats returns the current state of the limiter. It returns the zero value
+// for a nil *MemoryLimiter.
+func (m *MemoryLimiter) Stats() MemoryLimiterStats {
+	if m == nil {
+		return MemoryLimiterStats{}
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	return MemoryLimiterStats{
+		Limit: m.limit,
+		InUse: m.used,
+		Peak:  m.peak,
+		Waits: m.waits,
+	}
+}
@@ -0,0 +1,153 @@
+package rpc
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	hdfs "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_hdfs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryLimiterBlocks(t *testing.T) {
+	m := NewMemoryLimiter(100)
+	m.Acquire(60)
+
+	acquired := make(chan struct{})
+	go func() {
+		m.Acquire(60)
+		close(acquired)
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("acquired more than the limit")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	m.Release(60)
+	<-acquired
+
+	stats := m.Stats()
+	assert.EqualValues(t, 60, stats.InUse)
+	assert.EqualValues(t, 60, stats.Peak)
+	assert.Equal(t, 1, stats.Waits)
+}
+
+func TestMemoryLimiterOversized(t *testing.T) {
+	m := NewMemoryLimiter(100)
+
+	// A buffer bigger than the limit is allowed if nothing else is held.
+	m.Acquire(1000)
+	assert.EqualValues(t, 1000, m.Stats().InUse)
+	m.Release(1000)
+
+	// A queue can always hold one buffer, even if others are using up the
+	// limit.
+	m.Acquire(100)
+	var queued int64
+	m.acquireQueued(&queued, 50)
+	assert.EqualValues(t, 50, queued)
+	assert.EqualValues(t, 150, m.Stats().InUse)
+
+	m.releaseQueued(&queued, 50)
+	m.Release(100)
+	assert.EqualValues(t, 0, queued)
+	assert.EqualValues(t, 0, m.Stats().InUse)
+}
+
+func TestMemoryLimiterOwned(t *testing.T) {
+	m := NewMemoryLimiter(100)
+
+	// An owner never waits for its own buffers, no matter how far over the
+	// limit they go.
+	var owned int64
+	m.AcquireOwned(&owned, 80)
+	m.AcquireOwned(&owned, 80)
+	assert.EqualValues(t, 160, owned)
+	assert.EqualValues(t, 160, m.Stats().InUse)
+
+	// Or for anyone else's, once it holds something.
+	m.ReleaseOwned(&owned, 160)
+	m.Acquire(50)
+	m.AcquireOwned(&owned, 50)
+	m.AcquireOwned(&owned, 80)
+	assert.EqualValues(t, 130, owned)
+	assert.EqualValues(t, 180, m.Stats().InUse)
+	m.ReleaseOwned(&owned, 130)
+	assert.EqualValues(t, 0, owned)
+
+	// But an owner that holds nothing waits like anyone else.
+	acquired := make(chan struct{})
+	go func() {
+		m.AcquireOwned(&owned, 80)
+		close(acquired)
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("acquired more than the limit")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	m.Release(50)
+	<-acquired
+	assert.EqualValues(t, 80, owned)
+
+	m.ReleaseOwned(&owned, 80)
+	assert.EqualValues(t, 0, owned)
+	assert.EqualValues(t, 0, m.Stats().InUse)
+
+	// Without an owner, it's the same as Acquire.
+	m.AcquireOwned(nil, 50)
+	m.ReleaseOwned(nil, 50)
+	assert.EqualValues(t, 0, m.Stats().InUse)
+}
+
+func TestMemoryLimiterNil(t *testing.T) {
+	var m *MemoryLimiter
+	m.Acquire(100)
+	m.Release(100)
+	assert.Equal(t, MemoryLimiterStats{}, m.Stats())
+}
+
+func TestMemoryLimiterWriteStream(t *testing.T) {
+	client, server := net.Pipe()
+	go fakeDatanode(server, hdfs.Status_SUCCESS)
+
+	// The limit only allows one packet in flight at a time.
+	m := NewMemoryLimiter(outboundPacketSize)
+	bws := newBlockWriteStream(client, 0, outboundChunkSize, outboundPacketSize)
+	bws.memory = m
+
+	for i := 0; i < 10; i++ {
+		_, err := bws.Write(make([]byte, outboundPacketSize))
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, bws.finish())
+	client.Close()
+
+	stats := m.Stats()
+	assert.EqualValues(t, 0, stats.InUse)
+	assert.EqualValues(t, outboundPacketSize+outboundPacketSize/outboundChunkSize*4, stats.Peak)
+	assert.True(t, stats.Waits > 0)
+}
+
+func TestMemoryLimiterWriteStreamFailed(t *testing.T) {
+	client, server := net.Pipe()
+	go fakeDatanode(server, hdfs.Status_ERROR)
+
+	m := NewMemoryLimiter(outboundPacketSize)
+	bws := newBlockWriteStream(client, 0, outboundChunkSize, outboundPacketSize)
+	bws.memory = m
+
+	_, err := bws.Write(make([]byte, 10))
+	require.NoError(t, err)
+	require.NoError(t, bws.flush(true))
+	require.Error(t, bws.waitForAcks())
+
+	// The packet that failed is released too.
+	assert.EqualValues(t, 0, m.Stats().InUse)
+}
@@ -1,13 +1,17 @@
 package rpc
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"io"
+	"math/rand"
 	"net"
 	"os"
 	"sync"
+	"sync/atomic"
+	"syscall"
 	"time"
 
 	hadoop "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_common"
@@ -16,18 +20,64 @@ import (
 )
 
 const (
-	rpcVersion            byte = 0x09
-	serviceClass          byte = 0x0
-	noneAuthProtocol      byte = 0x0
-	saslAuthProtocol      byte = 0xdf
-	protocolClass              = "org.apache.hadoop.hdfs.protocol.ClientProtocol"
-	protocolClassVersion       = 1
-	handshakeCallID            = -3
-	standbyExceptionClass      = "org.apache.hadoop.ipc.StandbyException"
+	rpcVersion              byte = 0x09
+	serviceClass            byte = 0x0
+	noneAuthProtocol        byte = 0x0
+	saslAuthProtocol        byte = 0xdf
+	protocolClass                = "org.apache.hadoop.hdfs.protocol.ClientProtocol"
+	protocolClassVersion         = 1
+	handshakeCallID              = -3
+	standbyExceptionClass        = "org.apache.hadoop.ipc.StandbyException"
+	retriableExceptionClass      = "org.apache.hadoop.ipc.RetriableException"
 )
 
 const backoffDuration = time.Second * 5
 
+// nonIdempotentMethods are the ClientProtocol methods which aren't safe to
+// send twice (the ones the Java client annotates with @AtMostOnce). The
+// namenode's retry cache usually catches a repeated request, but not if the
+// namenode restarted or failed over in between, so if the connection is lost
+// or times out while waiting for the response to one of these, it isn't
+// retried.
+var nonIdempotentMethods = map[string]bool{
+	"create":                    true,
+	"append":                    true,
+	"rename":                    true,
+	"rename2":                   true,
+	"concat":                    true,
+	"delete":                    true,
+	"createSymlink":             true,
+	"updatePipeline":            true,
+	"createSnapshot":            true,
+	"deleteSnapshot":            true,
+	"renameSnapshot":            true,
+	"addCacheDirective":         true,
+	"modifyCacheDirective":      true,
+	"removeCacheDirective":      true,
+	"addCachePool":              true,
+	"modifyCachePool":           true,
+	"removeCachePool":           true,
+	"setXAttr":                  true,
+	"removeXAttr":               true,
+	"createEncryptionZone":      true,
+	"setErasureCodingPolicy":    true,
+	"unsetErasureCodingPolicy":  true,
+	"satisfyStoragePolicy":      true,
+	"addErasureCodingPolicies":  true,
+	"removeErasureCodingPolicy": true,
+}
+
+// ErrClosed is returned by Execute once the connection has been closed. It
+// matches context.Canceled with errors.Is, since it's the result of the
+// caller cancelling what it was doing.
+var ErrClosed error = closedError{}
+
+type closedError struct{}
+
+func (closedError) Error() string { return "hdfs: client closed" }
+
+func (closedError) Is(target error) bool { return target == context.Canceled }
+
 // NamenodeConnection represents an open connection to a namenode.
 type NamenodeConnection struct {
 	ClientID   []byte
@@ -39,13 +89,36 @@ type NamenodeConnection struct {
 	kerberosClient               *krb.Client
 	kerberosServicePrincipleName string
 	kerberosRealm                string
-
-	dialFunc func(ctx context.Context, network, addr string) (net.Conn, error)
-	conn     net.Conn
-	host     *namenodeHost
-	hostList []*namenodeHost
+	token                        *hadoop.TokenProto
+
+	protocol   string
+	dialFunc   func(ctx context.Context, network, addr string) (net.Conn, error)
+	lookupHost func(ctx context.Context, host string) ([]string, error)
+	addresses  []string
+	hedge      bool
+	timeout    time.Duration
+	retries    int
+	retryWait  time.Duration
+	maxWait    time.Duration
+	retriable  map[string]bool
+	hook       func(method string, latency time.Duration, err error)
+	wireLog    *WireLogger
+	strict     bool
+	conn       net.Conn
+	host       *namenodeHost
+	hostList   []*namenodeHost
+
+	// preferred is the namenode to try first when connecting, if the latency
+	// probes found it to be the fastest. latencies and switchTo are protected
+	// by latencyLock, and are updated by the background probes.
+	preferred   *namenodeHost
+	latencyLock sync.Mutex
+	latencies   map[string]*latencyStats
+	switchTo    *namenodeHost
+	stopProbes  chan struct{}
 
 	reqLock sync.Mutex
+	closed  int32
 }
 
 // NamenodeConnectionOptions represents the configurable options available
@@ -71,10 +144,89 @@ type NamenodeConnectionOptions struct {
 	// setup (for example: 'nn/_HOST@EXAMPLE.COM'). It is required if
 	// KerberosClient is provided.
 	KerberosServicePrincipleName string
+	// Token, if set, is a delegation token used to authenticate with the
+	// namenode(s), instead of Kerberos. The connection acts as the owner of
+	// the token, regardless of User.
+	Token *hadoop.TokenProto
+	// Protocol specifies the name of the RPC protocol class to use, for
+	// example org.apache.hadoop.hdfs.protocol.ClientDatanodeProtocol. This
+	// allows the connection to be used with other Hadoop RPC services. If
+	// empty, ClientProtocol (the namenode client protocol) is used.
+	Protocol string
+	// LookupHost, if provided, is used to resolve the host part of each
+	// address to a list of IP addresses, each of which is then treated as a
+	// separate namenode. This supports namenodes behind round-robin DNS or
+	// a headless Kubernetes service. If every namenode fails, the addresses
+	// are resolved again, in case the set of namenodes has changed.
+	LookupHost func(ctx context.Context, host string) ([]string, error)
+	// HedgeRequests specifies that, when the active namenode isn't known yet
+	// (or after a failover), the first request should be sent to all the
+	// namenodes at once, with the first to respond being used from then on.
+	// This is similar to Hadoop's RequestHedgingProxyProvider. If it's set,
+	// NewNamenodeConnection doesn't connect eagerly.
+	HedgeRequests bool
+	// Lazy specifies that NewNamenodeConnection shouldn't connect eagerly, so
+	// that the first namenode is only dialed for the first request.
+	Lazy bool
+	// ClientNameTag, if set, is appended to the generated client name, which
+	// the namenode records as the holder of the lease on files being written
+	// and includes in its audit logs. This can be used to identify the
+	// workload that owns a connection, for example by setting it to a job ID.
+	ClientNameTag string
+	// RequestTimeout, if set, limits how long each attempt at an RPC can take,
+	// including writing the request and reading the response. If an attempt
+	// times out, the namenode is marked as failed, and the request is retried
+	// against the next one.
+	RequestTimeout time.Duration
+	// Retries is the number of times to retry a request after every namenode
+	// has failed, waiting RetryInterval between each attempt. By default, the
+	// request fails as soon as every namenode has been tried once. If the
+	// connection is lost while waiting for a response, the request is sent
+	// again straight away, but only once; after that, each attempt counts as
+	// a retry. Requests that aren't idempotent, like create or delete, are
+	// never sent again if the connection is lost or times out while waiting
+	// for the response, since they may have been processed already.
+	Retries       int
+	RetryInterval time.Duration
+	// MaxRetryInterval, if larger than RetryInterval, enables exponential
+	// backoff: the wait doubles after each retry, up to MaxRetryInterval, with
+	// some random jitter, like Hadoop's FailoverOnNetworkExceptionRetry.
+	MaxRetryInterval time.Duration
+	// RetriableExceptions lists the Java classes of exceptions which, like
+	// org.apache.hadoop.ipc.RetriableException, mean the namenode couldn't
+	// handle the request for now, but might later. For example,
+	// "org.apache.hadoop.hdfs.server.namenode.SafeModeException". Requests
+	// that fail with one are retried against the same namenode, up to Retries
+	// times, waiting as above in between.
+	RetriableExceptions []string
+	// RequestHook, if set, is called after each request made with Execute or
+	// ExecuteContext, with the method, how long it took (including any
+	// retries and failovers), and the error, if any.
+	RequestHook func(method string, latency time.Duration, err error)
+	// WireLog, if set, is used to log every request and response.
+	WireLog *WireLogger
+	// Strict specifies that every response should be validated before it's
+	// used, with a ProtocolError returned if it's malformed. In that case the
+	// namenode is marked as failed, as for a timeout.
+	Strict bool
+	// LatencyProbeInterval, if positive, enables latency-aware namenode
+	// selection. Each interval, a getFileInfo request for "/" is sent to every
+	// namenode over a separate connection, and if one of those that answers
+	// (which excludes namenodes in standby) is consistently and significantly
+	// faster than the current one, the connection switches to it. This is
+	// only useful if more than one namenode can serve requests, for example
+	// with Router-based Federation, and only works with ClientProtocol. The
+	// switch happens between any two requests, including those for files
+	// open for writing, since leases are held by the client name rather than
+	// by the connection.
+	LatencyProbeInterval time.Duration
 }
 
 type namenodeHost struct {
-	address     string
+	address string
+	// hostname is the name the address was resolved from, if any, and is used
+	// in place of the address for kerberos.
+	hostname    string
 	lastError   error
 	lastErrorAt time.Time
 	writeError  bool
@@ -83,15 +235,16 @@ type namenodeHost struct {
 // NewNamenodeConnectionWithOptions creates a new connection to a namenode with
 // the given options and performs an initial handshake.
 func NewNamenodeConnection(options NamenodeConnectionOptions) (*NamenodeConnection, error) {
-	// Build the list of hosts to be used for failover.
-	hostList := make([]*namenodeHost, len(options.Addresses))
-	for i, addr := range options.Addresses {
-		hostList[i] = &namenodeHost{address: addr}
-	}
-
 	var user, realm string
 	user = options.User
-	if user == "" {
+	if options.Token != nil {
+		owner, err := DelegationTokenOwner(options.Token.GetIdentifier())
+		if err != nil {
+			return nil, err
+		}
+
+		user = owner
+	} else if user == "" {
 		if options.KerberosClient != nil {
 			creds := options.KerberosClient.Credentials
 			user = creds.Username
@@ -103,21 +256,59 @@ func NewNamenodeConnection(options NamenodeConnectionOptions) (*NamenodeConnecti
 
 	// The ClientID is reused here both in the RPC headers (which requires a
 	// "globally unique" ID) and as the "client name" in various requests.
+	protocol := options.Protocol
+	if protocol == "" {
+		protocol = protocolClass
+	}
+
 	clientId := newClientID()
+	clientName := "go-hdfs-" + string(clientId)
+	if options.ClientNameTag != "" {
+		clientName += "_" + options.ClientNameTag
+	}
+
 	c := &NamenodeConnection{
 		ClientID:   clientId,
-		ClientName: "go-hdfs-" + string(clientId),
+		ClientName: clientName,
 		User:       user,
 
 		kerberosClient:               options.KerberosClient,
 		kerberosServicePrincipleName: options.KerberosServicePrincipleName,
 		kerberosRealm:                realm,
+		token:                        options.Token,
+
+		protocol:   protocol,
+		dialFunc:   options.DialFunc,
+		lookupHost: options.LookupHost,
+		addresses:  options.Addresses,
+		hedge:      options.HedgeRequests,
+		timeout:    options.RequestTimeout,
+		retries:    options.Retries,
+		retryWait:  options.RetryInterval,
+		maxWait:    options.MaxRetryInterval,
+		wireLog:    options.WireLog,
+		strict:     options.Strict,
+		retriable:  map[string]bool{retriableExceptionClass: true},
+		hook:       options.RequestHook,
+	}
+
+	for _, exception := range options.RetriableExceptions {
+		c.retriable[exception] = true
+	}
 
-		dialFunc: options.DialFunc,
-		hostList: hostList,
+	// Build the list of hosts to be used for failover.
+	c.resolveHosts()
+	if options.LatencyProbeInterval > 0 && c.protocol == protocolClass && len(c.hostList) > 1 {
+		c.latencies = make(map[string]*latencyStats)
+		c.stopProbes = make(chan struct{})
+		go c.probeLatencies(options.LatencyProbeInterval)
 	}
 
-	err := c.resolveConnection()
+	if options.Lazy || (c.hedge && len(c.hostList) > 1) {
+		return c, nil
+	}
+
+	err := c.resolveConnection(context.Background())
 	if err != nil {
 		return nil, err
 	}
@@ -125,7 +316,7 @@ func NewNamenodeConnection(options NamenodeConnectionOptions) (*NamenodeConnecti
 	return c, nil
 }
 
-func (c *NamenodeConnection) resolveConnection() error {
+func (c *NamenodeConnection) resolveConnection(ctx context.Context) error {
 	if c.conn != nil {
 		return nil
 	}
@@ -135,7 +326,33 @@ func (c *NamenodeConnection) resolveConnection() error {
 		err = c.host.lastError
 	}
 
-	for _, host := range c.hostList {
+	err = c.connectAny(ctx, err)
+
+	// If all the namenodes failed, they may have been replaced. Check DNS for
+	// new ones before giving up.
+	if c.conn == nil && ctx.Err() == nil && c.lookupHost != nil && c.resolveHosts() {
+		err = c.connectAny(ctx, err)
+	}
+
+	if c.conn == nil {
+		return fmt.Errorf("no available namenodes: %w", err)
+	}
+
+	return nil
+}
+
+// connectAny tries each of the hosts that isn't backing off in turn, until it
+// manages to connect to one. It returns the most recent error, or err if no
+// connection was attempted. It stops early if ctx is done, without recording
+// a failure for the host it was connecting to.
+func (c *NamenodeConnection) connectAny(ctx context.Context, err error) error {
+	hosts := c.hostList
+	if c.preferred != nil {
+		hosts = append([]*namenodeHost{c.preferred}, hosts...)
+		c.preferred = nil
+	}
+
+	for _, host := range hosts {
 		if host.lastErrorAt.After(time.Now().Add(-backoffDuration)) {
 			continue
 		}
@@ -145,14 +362,23 @@ func (c *NamenodeConnection) resolveConnection() error {
 		}
 
 		c.host = host
-		c.conn, err = c.dialFunc(context.Background(), "tcp", host.address)
+		c.conn, err = c.dialFunc(ctx, "tcp", host.address)
 		if err != nil {
+			if ctx.Err() != nil {
+				return context.Cause(ctx)
+			}
+
 			c.markFailure(err)
 			continue
 		}
 
+		interrupted := interruptOnDone(ctx, c.conn)
 		err = c.doNamenodeHandshake()
-		if err != nil {
+		if interrupted() {
+			c.conn.Close()
+			c.conn = nil
+			return context.Cause(ctx)
+		} else if err != nil {
 			c.markFailure(err)
 			continue
 		}
@@ -160,11 +386,58 @@ func (c *NamenodeConnection) resolveConnection() error {
 		break
 	}
 
-	if c.conn == nil {
-		return fmt.Errorf("no available namenodes: %s", err)
+	return err
+}
+
+// resolveHosts (re)builds the list of hosts used for failover from the
+// configured addresses, looking each of them up with lookupHost if it's set.
+// The state of hosts which were already in the list is preserved. It returns
+// true if any new hosts were added.
+func (c *NamenodeConnection) resolveHosts() bool {
+	existing := make(map[string]*namenodeHost, len(c.hostList))
+	for _, host := range c.hostList {
+		existing[host.address] = host
 	}
 
-	return nil
+	added := false
+	seen := make(map[string]bool)
+	hostList := make([]*namenodeHost, 0, len(c.addresses))
+	add := func(address, hostname string) {
+		if seen[address] {
+			return
+		}
+
+		host, ok := existing[address]
+		if !ok {
+			host = &namenodeHost{address: address, hostname: hostname}
+			added = true
+		}
+
+		seen[address] = true
+		hostList = append(hostList, host)
+	}
+
+	for _, address := range c.addresses {
+		name, port, err := net.SplitHostPort(address)
+		if c.lookupHost == nil || err != nil || net.ParseIP(name) != nil {
+			add(address, "")
+			continue
+		}
+
+		ips, err := c.lookupHost(context.Background(), name)
+		if err != nil || len(ips) == 0 {
+			// Fall back to letting the dialer resolve the name.
+			add(address, "")
+			continue
+		}
+
+		for _, ip := range ips {
+			add(net.JoinHostPort(ip, port), name)
+		}
+	}
+
+	c.hostList = hostList
+	return added
 }
 
 func (c *NamenodeConnection) markFailureLow(err error, rw bool) {
@@ -192,45 +465,226 @@ func (c *NamenodeConnection) markTransientFailure(err error) {
 // Execute performs an rpc call. It does this by sending req over the wire and
 // unmarshaling the result into resp.
 func (c *NamenodeConnection) Execute(method string, req proto.Message, resp proto.Message) error {
+	return c.ExecuteContext(context.Background(), method, req, resp)
+}
+
+// ExecuteContext is like Execute, but gives up once ctx is done, returning
+// the context's error. That includes connecting to the namenodes, failing
+// over between them, and waiting between retries. If ctx is done after the
+// request has been sent, the connection is closed, since the response could
+// still arrive on it; the namenode may or may not have processed the request.
+//
+// Waiting for another request in progress on the same connection is not
+// interrupted.
+func (c *NamenodeConnection) ExecuteContext(ctx context.Context, method string, req proto.Message, resp proto.Message) error {
+	if c.hook == nil {
+		return c.execute(ctx, method, req, resp)
+	}
+
+	start := time.Now()
+	err := c.execute(ctx, method, req, resp)
+	c.hook(method, time.Since(start), err)
+	return err
+}
+
+func (c *NamenodeConnection) execute(ctx context.Context, method string, req proto.Message, resp proto.Message) error {
 	c.reqLock.Lock()
 	defer c.reqLock.Unlock()
 
 	c.currentRequestID++
 
+	if atomic.LoadInt32(&c.closed) != 0 {
+		return ErrClosed
+	} else if ctx.Err() != nil {
+		return context.Cause(ctx)
+	}
+
+	c.switchFastest()
+	if c.hedge && c.conn == nil && c.preferred == nil {
+		if done, err := c.executeHedged(ctx, method, req, resp); done {
+			return err
+		}
+	}
+
+	retries, resets := 0, 0
 	for {
-		err := c.resolveConnection()
+		if atomic.LoadInt32(&c.closed) != 0 {
+			return ErrClosed
+		}
+
+		err := c.resolveConnection(ctx)
 		if err != nil {
+			if ctx.Err() != nil {
+				return context.Cause(ctx)
+			}
+
+			if retries < c.retries {
+				if err := c.waitToRetry(ctx, retries); err != nil {
+					return err
+				}
+
+				retries++
+				c.resetBackoff()
+				continue
+			}
+
 			return err
 		}
 
+		if c.timeout > 0 {
+			c.conn.SetDeadline(time.Now().Add(c.timeout))
+		}
+
+		interrupted := interruptOnDone(ctx, c.conn)
 		err = c.writeRequest(method, req)
-		if err != nil {
+		writeFailed := err != nil
+		if !writeFailed {
+			c.host.writeError = false
+			err = c.readResponse(method, resp)
+		}
+
+		if interrupted() {
+			c.conn.Close()
+			c.conn = nil
+			return context.Cause(ctx)
+		} else if writeFailed {
 			c.markTransientFailure(err)
 			continue
 		}
-		c.host.writeError = false
 
-		err = c.readResponse(method, resp)
 		if err != nil {
-			if err == io.EOF {
+			// The connection was closed or reset, which happens when the
+			// namenode restarts. The request may or may not have been
+			// processed, so it's only sent again if that's safe.
+			if err == io.EOF || err == io.ErrUnexpectedEOF || errors.Is(err, syscall.ECONNRESET) {
 				c.markTransientFailure(err)
+				if c.protocol == protocolClass && nonIdempotentMethods[method] {
+					return err
+				}
+
+				// The first time, the request is just sent again on a new
+				// connection. After that, the namenode is failing over and
+				// over, so each attempt counts as a retry.
+				if resets > 0 {
+					if retries >= c.retries {
+						return err
+					}
+
+					if err := c.waitToRetry(ctx, retries); err != nil {
+						return err
+					}
+
+					retries++
+				}
+
+				resets++
+				continue
+			}
+
+			// The namenode asked for the request to be retried later, for
+			// example because it's still starting up.
+			if nerr, ok := err.(*NamenodeError); ok && c.retriable[nerr.exception] && retries < c.retries {
+				if err := c.waitToRetry(ctx, retries); err != nil {
+					return err
+				}
+
+				retries++
 				continue
 			}
-			// Only retry on a standby exception.
+
+			// Otherwise, only retry on a standby exception, or if the request
+			// timed out.
 			if nerr, ok := err.(*NamenodeError); ok && nerr.exception == standbyExceptionClass {
 				c.markFailure(err)
 				continue
+			} else if nerr, ok := err.(net.Error); ok && nerr.Timeout() {
+				c.markFailure(err)
+				if c.protocol == protocolClass && nonIdempotentMethods[method] {
+					return err
+				}
+
+				continue
+			} else if _, ok := err.(*ProtocolError); ok {
+				c.markFailure(err)
+				continue
 			}
 
 			return err
 		}
 
+		if c.timeout > 0 {
+			c.conn.SetDeadline(time.Time{})
+		}
+
 		break
 	}
 
 	return nil
 }
 
+// interruptOnDone makes reads and writes on conn fail as soon as ctx is done.
+// The returned function stops watching ctx, and reports whether conn was
+// interrupted, in which case it's no longer usable. It must be called exactly
+// once.
+func interruptOnDone(ctx context.Context, conn net.Conn) func() bool {
+	stop := context.AfterFunc(ctx, func() {
+		conn.SetDeadline(time.Unix(1, 0))
+	})
+
+	return func() bool { return !stop() }
+}
+
+// waitToRetry waits before the given (zero-indexed) retry, returning early
+// if ctx is done.
+func (c *NamenodeConnection) waitToRetry(ctx context.Context, retry int) error {
+	timer := time.NewTimer(c.retryDelay(retry))
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return context.Cause(ctx)
+	}
+}
+
+// retryDelay returns how long to wait before the given (zero-indexed) retry.
+func (c *NamenodeConnection) retryDelay(retry int) time.Duration {
+	if c.maxWait <= c.retryWait {
+		return c.retryWait
+	}
+
+	// Double the delay for each retry, stopping once it reaches maxWait, so
+	// that it can't overflow however many retries there are.
+	delay := c.retryWait
+	for i := 0; i < retry && delay < c.maxWait; i++ {
+		delay *= 2
+	}
+
+	if delay > c.maxWait {
+		delay = c.maxWait
+	}
+
+	// Add up to 50% in either direction, so that clients which failed at the
+	// same time don't all retry at the same time.
+	jittered := time.Duration(float64(delay) * (0.5 + rand.Float64()))
+	if jittered > c.maxWait {
+		jittered = c.maxWait
+	}
+
+	return jittered
+}
+
+// resetBackoff clears the recorded failures of every namenode, so that they
+// can all be tried again.
+func (c *NamenodeConnection) resetBackoff() {
+	for _, host := range c.hostList {
+		host.lastError = nil
+		host.lastErrorAt = time.Time{}
+		host.writeError = false
+	}
+}
+
 // RPC definitions
 
 // A request packet:
@@ -245,13 +699,14 @@ func (c *NamenodeConnection) Execute(method string, req proto.Message, resp prot
 // +-----------------------------------------------------------+
 func (c *NamenodeConnection) writeRequest(method string, req proto.Message) error {
 	rrh := newRPCRequestHeader(c.currentRequestID, c.ClientID)
-	rh := newRequestHeader(method)
+	rh := newRequestHeader(method, c.protocol)
 
 	reqBytes, err := makeRPCPacket(rrh, rh, req)
 	if err != nil {
 		return err
 	}
 
+	c.wireLog.logRequest(remoteAddr(c.conn), method, c.currentRequestID, reqBytes)
 	_, err = c.conn.Write(reqBytes)
 	return err
 }
@@ -266,7 +721,31 @@ func (c *NamenodeConnection) writeRequest(method string, req proto.Message) erro
 // +-----------------------------------------------------------+
 func (c *NamenodeConnection) readResponse(method string, resp proto.Message) error {
 	rrh := &hadoop.RpcResponseHeaderProto{}
-	err := readRPCPacket(c.conn, rrh, resp)
+
+	var r io.Reader = c.conn
+	var buf bytes.Buffer
+	if c.wireLog != nil {
+		r = io.TeeReader(c.conn, &buf)
+	}
+
+	var max uint32
+	if c.strict {
+		max = maxRPCResponseLength
+	}
+
+	n, err := readRPCPacketMax(r, max, rrh, resp)
+	if c.wireLog != nil {
+		c.wireLog.logResponse(remoteAddr(c.conn), method, rrh, buf.Bytes(), err)
+	}
+
+	if c.strict {
+		if err == nil {
+			err = c.validateResponse(rrh, n)
+		}
+
+		err = protocolError(remoteAddr(c.conn), method+" response", err)
+	}
+
 	if err != nil {
 		return err
 	} else if int32(rrh.GetCallId()) != c.currentRequestID {
@@ -283,6 +762,31 @@ func (c *NamenodeConnection) readResponse(method string, resp proto.Message) err
 	return nil
 }
 
+// validateResponse checks the header of a response, in strict mode. n is the
+// number of messages in the response, including the header.
+func (c *NamenodeConnection) validateResponse(rrh *hadoop.RpcResponseHeaderProto, n int) error {
+	if int32(rrh.GetCallId()) != c.currentRequestID {
+		return fmt.Errorf("call ID %d doesn't match the request (%d)", int32(rrh.GetCallId()), c.currentRequestID)
+	} else if rrh.ClientId != nil && !bytes.Equal(rrh.ClientId, c.ClientID) {
+		return fmt.Errorf("client ID %q doesn't match ours (%q)", rrh.ClientId, c.ClientID)
+	}
+
+	switch rrh.GetStatus() {
+	case hadoop.RpcResponseHeaderProto_SUCCESS:
+		if n < 2 {
+			return errors.New("missing response message")
+		}
+	case hadoop.RpcResponseHeaderProto_ERROR, hadoop.RpcResponseHeaderProto_FATAL:
+		if rrh.GetExceptionClassName() == "" {
+			return fmt.Errorf("%s status without an exception class", rrh.GetStatus())
+		}
+	default:
+		return fmt.Errorf("unknown status %d", rrh.GetStatus())
+	}
+
+	return nil
+}
+
 // A handshake packet:
 // +-----------------------------------------------------------+
 // |  Header, 4 bytes ("hrpc")                                 |
@@ -307,9 +811,9 @@ func (c *NamenodeConnection) readResponse(method string, resp proto.Message) err
 func (c *NamenodeConnection) doNamenodeHandshake() error {
 	authProtocol := noneAuthProtocol
 	kerberos := false
-	if c.kerberosClient != nil {
+	if c.token != nil || c.kerberosClient != nil {
 		authProtocol = saslAuthProtocol
-		kerberos = true
+		kerberos = c.token == nil
 	}
 
 	rpcHeader := []byte{
@@ -322,8 +826,13 @@ func (c *NamenodeConnection) doNamenodeHandshake() error {
 		return err
 	}
 
-	if kerberos {
-		err = c.doKerberosHandshake()
+	if authProtocol == saslAuthProtocol {
+		if kerberos {
+			err = c.doKerberosHandshake()
+		} else {
+			err = c.doTokenHandshake()
+		}
+
 		if err != nil {
 			return fmt.Errorf("SASL handshake: %s", err)
 		}
@@ -333,7 +842,7 @@ func (c *NamenodeConnection) doNamenodeHandshake() error {
 	}
 
 	rrh := newRPCRequestHeader(handshakeCallID, c.ClientID)
-	cc := newConnectionContext(c.User, c.kerberosRealm)
+	cc := newConnectionContext(c.User, c.kerberosRealm, c.protocol)
 	packet, err := makeRPCPacket(rrh, cc)
 	if err != nil {
 		return err
@@ -343,10 +852,21 @@ func (c *NamenodeConnection) doNamenodeHandshake() error {
 	return err
 }
 
-// Close terminates all underlying socket connections to remote server.
+// Close terminates all underlying socket connections to remote server. It
+// waits for any request in progress to finish; subsequent requests return
+// ErrClosed.
 func (c *NamenodeConnection) Close() error {
+	if atomic.SwapInt32(&c.closed, 1) == 0 && c.stopProbes != nil {
+		close(c.stopProbes)
+	}
+
+	c.reqLock.Lock()
+	defer c.reqLock.Unlock()
+
 	if c.conn != nil {
-		return c.conn.Close()
+		err := c.conn.Close()
+		c.conn = nil
+		return err
 	}
 	return nil
 }
@@ -360,15 +880,15 @@ func newRPCRequestHeader(id int32, clientID []byte) *hadoop.RpcRequestHeaderProt
 	}
 }
 
-func newRequestHeader(methodName string) *hadoop.RequestHeaderProto {
+func newRequestHeader(methodName, protocol string) *hadoop.RequestHeaderProto {
 	return &hadoop.RequestHeaderProto{
 		MethodName:                 proto.String(methodName),
-		DeclaringClassProtocolName: proto.String(protocolClass),
+		DeclaringClassProtocolName: proto.String(protocol),
 		ClientProtocolVersion:      proto.Uint64(uint64(protocolClassVersion)),
 	}
 }
 
-func newConnectionContext(user, kerberosRealm string) *hadoop.IpcConnectionContextProto {
+func newConnectionContext(user, kerberosRealm, protocol string) *hadoop.IpcConnectionContextProto {
 	if kerberosRealm != "" {
 		user = user + "@" + kerberosRealm
 	}
@@ -377,6 +897,6 @@ func newConnectionContext(user, kerberosRealm string) *hadoop.IpcConnectionConte
 		UserInfo: &hadoop.UserInformationProto{
 			EffectiveUser: proto.String(user),
 		},
-		Protocol: proto.String(protocolClass),
+		Protocol: proto.String(protocol),
 	}
 }
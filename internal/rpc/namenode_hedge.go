@@ -0,0 +1,151 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+)
+
+type hedgedResult struct {
+	host *namenodeHost
+	conn *NamenodeConnection
+	resp proto.Message
+	err  error
+}
+
+// executeHedged sends the request to all the namenodes that aren't backing
+// off at once, and keeps the connection to the first one that responds as
+// the active namenode. This is much faster than trying the namenodes one at a
+// time when some of them are in standby, or unreachable.
+//
+// It returns false if none of the namenodes returned a response (or all of
+// them are in standby), in which case the failures have been recorded and the
+// caller should proceed as usual. If ctx is done first, it returns true and
+// the context's error.
+func (c *NamenodeConnection) executeHedged(ctx context.Context, method string, req proto.Message, resp proto.Message) (bool, error) {
+	var hosts []*namenodeHost
+	for _, host := range c.hostList {
+		if host.lastErrorAt.After(time.Now().Add(-backoffDuration)) {
+			continue
+		}
+
+		hosts = append(hosts, host)
+	}
+
+	if len(hosts) < 2 {
+		return false, nil
+	}
+
+	if c.dialFunc == nil {
+		c.dialFunc = (&net.Dialer{}).DialContext
+	}
+
+	results := make(chan hedgedResult, len(hosts))
+	for _, host := range hosts {
+		hc := c.hedgedConnection(host)
+		hresp := proto.Clone(resp)
+		hresp.Reset()
+
+		go func(host *namenodeHost) {
+			err := hc.executeOnce(ctx, method, req, hresp)
+			results <- hedgedResult{host: host, conn: hc, resp: hresp, err: err}
+		}(host)
+	}
+
+	for i := range hosts {
+		res := <-results
+		if ctx.Err() != nil {
+			// Don't hold the failures against the namenodes.
+			res.conn.close()
+			go func(remaining int) {
+				for j := 0; j < remaining; j++ {
+					(<-results).conn.close()
+				}
+			}(len(hosts) - i - 1)
+
+			return true, context.Cause(ctx)
+		}
+
+		if res.err != nil {
+			if nerr, ok := res.err.(*NamenodeError); !ok || nerr.exception == standbyExceptionClass {
+				res.conn.close()
+				c.host = res.host
+				c.markFailure(res.err)
+				continue
+			}
+		}
+
+		// We have a winner. Clean up the rest of the connections as they finish.
+		go func(remaining int) {
+			for j := 0; j < remaining; j++ {
+				(<-results).conn.close()
+			}
+		}(len(hosts) - i - 1)
+
+		c.conn = res.conn.conn
+		c.host = res.host
+		c.currentRequestID = res.conn.currentRequestID
+		c.host.writeError = false
+
+		proto.Merge(resp, res.resp)
+		return true, res.err
+	}
+
+	return false, nil
+}
+
+// hedgedConnection returns a copy of the connection for sending a hedged
+// request to the given host. It shares the client ID, so that the namenode
+// treats the requests as coming from the same client.
+func (c *NamenodeConnection) hedgedConnection(host *namenodeHost) *NamenodeConnection {
+	return &NamenodeConnection{
+		ClientID:         c.ClientID,
+		ClientName:       c.ClientName,
+		User:             c.User,
+		currentRequestID: c.currentRequestID,
+
+		kerberosClient:               c.kerberosClient,
+		kerberosServicePrincipleName: c.kerberosServicePrincipleName,
+		kerberosRealm:                c.kerberosRealm,
+		token:                        c.token,
+
+		protocol: c.protocol,
+		dialFunc: c.dialFunc,
+		wireLog:  c.wireLog,
+		strict:   c.strict,
+		host:     &namenodeHost{address: host.address, hostname: host.hostname},
+	}
+}
+
+// executeOnce connects to the connection's host, and sends a single request.
+func (c *NamenodeConnection) executeOnce(ctx context.Context, method string, req proto.Message, resp proto.Message) error {
+	var err error
+	c.conn, err = c.dialFunc(ctx, "tcp", c.host.address)
+	if err != nil {
+		return err
+	}
+
+	interrupted := interruptOnDone(ctx, c.conn)
+	err = c.doNamenodeHandshake()
+	if err == nil {
+		err = c.writeRequest(method, req)
+	}
+
+	if err == nil {
+		err = c.readResponse(method, resp)
+	}
+
+	if interrupted() {
+		return context.Cause(ctx)
+	}
+
+	return err
+}
+
+func (c *NamenodeConnection) close() {
+	if c.conn != nil {
+		c.conn.Close()
+	}
+}
@@ -0,0 +1,278 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	hdfs "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_hdfs"
+	"github.com/golang/protobuf/proto"
+)
+
+const (
+	// latencyWeight is the weight given to each new sample in the moving
+	// average of a namenode's latency.
+	latencyWeight = 0.3
+	// latencySwitchRatio and minLatencyDifference are the hysteresis for
+	// switching namenodes: another namenode has to be this much faster than
+	// the current one, in both relative and absolute terms.
+	latencySwitchRatio   = 0.8
+	minLatencyDifference = time.Millisecond
+	// minLatencySamples is how many probes a namenode has to have answered
+	// before it's switched to.
+	minLatencySamples = 2
+	// defaultProbeTimeout limits how long each probe can take, if the
+	// connection has no request timeout.
+	defaultProbeTimeout = 10 * time.Second
+)
+
+// NamenodeLatency describes the latency measured for a single namenode, when
+// latency probing is enabled.
+type NamenodeLatency struct {
+	Address string
+	// Latency is the moving average of the round-trip time of the probe RPC.
+	// It's zero if the namenode hasn't answered a probe yet.
+	Latency time.Duration
+	// Err is the error from the last probe, if it failed. Namenodes that
+	// returned an error (including a StandbyException) aren't switched to.
+	Err error
+}
+
+// latencyStats holds the results of the latency probes for a namenode. It's
+// protected by the connection's latencyLock.
+type latencyStats struct {
+	latency time.Duration
+	samples int
+	err     error
+}
+
+// probeLatencies is meant to run in the background, sending a cheap read-only
+// RPC to every namenode each interval, over a separate connection, and keeping
+// a moving average of the round-trip time for each. If a namenode other than
+// the current one is consistently faster (and isn't in standby), the next
+// request is sent to it instead.
+//
+// Only namenodes that successfully answer the probe, which is a getFileInfo
+// for "/", are considered, so that standby namenodes are never switched to.
+func (c *NamenodeConnection) probeLatencies(interval time.Duration) {
+	conns := make(map[string]*NamenodeConnection)
+	defer func() {
+		for _, pc := range conns {
+			pc.close()
+		}
+	}()
+
+	timeout := c.timeout
+	if timeout <= 0 {
+		timeout = defaultProbeTimeout
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-c.stopProbes:
+			return
+		}
+
+		// Take a snapshot of the hosts, and set up connections for any new
+		// ones, with the lock held.
+		c.reqLock.Lock()
+		hosts := append([]*namenodeHost(nil), c.hostList...)
+		for _, host := range hosts {
+			if conns[host.address] == nil {
+				conns[host.address] = c.hedgedConnection(host)
+			}
+		}
+		current := c.host
+		c.reqLock.Unlock()
+
+		// Probe all the namenodes at once, so that one that's unreachable
+		// doesn't hold up the rest.
+		errs := make([]error, len(hosts))
+		var wg sync.WaitGroup
+		for i, host := range hosts {
+			wg.Add(1)
+			go func(i int, host *namenodeHost, pc *NamenodeConnection) {
+				defer wg.Done()
+				latency, err := pc.probe(timeout)
+				c.recordLatency(host.address, latency, err)
+				errs[i] = err
+			}(i, host, conns[host.address])
+		}
+
+		wg.Wait()
+
+		// Connections that failed are set up again next time.
+		for i, host := range hosts {
+			if errs[i] != nil {
+				conns[host.address].close()
+				delete(conns, host.address)
+			}
+		}
+
+		c.chooseFastest(current, hosts)
+	}
+}
+
+// probe sends a single probe RPC, connecting first if necessary, and returns
+// how long the RPC took, not counting the connection setup.
+func (c *NamenodeConnection) probe(timeout time.Duration) (time.Duration, error) {
+	if c.conn == nil {
+		if c.dialFunc == nil {
+			c.dialFunc = (&net.Dialer{}).DialContext
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		conn, err := c.dialFunc(ctx, "tcp", c.host.address)
+		cancel()
+		if err != nil {
+			return 0, err
+		}
+
+		c.conn = conn
+		conn.SetDeadline(time.Now().Add(timeout))
+		err = c.doNamenodeHandshake()
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	c.conn.SetDeadline(time.Now().Add(timeout))
+	c.currentRequestID++
+
+	req := &hdfs.GetFileInfoRequestProto{Src: proto.String("/")}
+	resp := &hdfs.GetFileInfoResponseProto{}
+	start := time.Now()
+	err := c.writeRequest("getFileInfo", req)
+	if err == nil {
+		err = c.readResponse("getFileInfo", resp)
+	}
+
+	return time.Since(start), err
+}
+
+func (c *NamenodeConnection) recordLatency(address string, latency time.Duration, err error) {
+	c.latencyLock.Lock()
+	defer c.latencyLock.Unlock()
+
+	stats := c.latencies[address]
+	if stats == nil {
+		stats = &latencyStats{}
+		c.latencies[address] = stats
+	}
+
+	if err != nil {
+		stats.err = err
+		stats.samples = 0
+		stats.latency = 0
+		return
+	}
+
+	stats.err = nil
+	if stats.samples == 0 {
+		stats.latency = latency
+	} else {
+		stats.latency = time.Duration(latencyWeight*float64(latency) + (1-latencyWeight)*float64(stats.latency))
+	}
+
+	stats.samples++
+}
+
+// chooseFastest picks the namenode that the next request should be sent to,
+// if it should be switched from current.
+func (c *NamenodeConnection) chooseFastest(current *namenodeHost, hosts []*namenodeHost) {
+	if current == nil {
+		return
+	}
+
+	c.latencyLock.Lock()
+	defer c.latencyLock.Unlock()
+
+	currentStats := c.latencies[current.address]
+	if currentStats == nil || currentStats.err != nil || currentStats.samples == 0 {
+		return
+	}
+
+	var fastest *namenodeHost
+	best := currentStats.latency
+	for _, host := range hosts {
+		stats := c.latencies[host.address]
+		if host == current || stats == nil || stats.err != nil || stats.samples < minLatencySamples {
+			continue
+		}
+
+		if stats.latency < best {
+			fastest = host
+			best = stats.latency
+		}
+	}
+
+	if fastest == nil {
+		return
+	}
+
+	if float64(best) < latencySwitchRatio*float64(currentStats.latency) &&
+		currentStats.latency-best >= minLatencyDifference {
+		c.switchTo = fastest
+	}
+}
+
+// switchFastest closes the current connection, if the latency probes have
+// found a faster namenode, so that the next attempt connects to that one
+// first. It must be called with reqLock held.
+//
+// This happens before any request, including those for files that are open
+// for writing. That's safe, since a lease belongs to the client name rather
+// than to the connection, and whichever namenode serves the request either
+// shares the lease state (like the routers of a federation) or rejects it as
+// a standby, in which case the usual failover applies.
+func (c *NamenodeConnection) switchFastest() {
+	// Without LatencyProbeInterval, nothing is measured, and the connection
+	// never switches.
+	if c.latencies == nil {
+		return
+	}
+
+	c.latencyLock.Lock()
+	next := c.switchTo
+	c.switchTo = nil
+	c.latencyLock.Unlock()
+
+	if next == nil || next == c.host {
+		return
+	}
+
+	c.preferred = next
+	if c.conn != nil {
+		c.conn.Close()
+		c.conn = nil
+	}
+}
+
+// Latencies returns the latency measured for each namenode, sorted by
+// address. It returns nil if latency probing isn't enabled.
+func (c *NamenodeConnection) Latencies() []NamenodeLatency {
+	if c.latencies == nil {
+		return nil
+	}
+
+	c.latencyLock.Lock()
+	defer c.latencyLock.Unlock()
+
+	res := make([]NamenodeLatency, 0, len(c.latencies))
+	for address, stats := range c.latencies {
+		res = append(res, NamenodeLatency{
+			Address: address,
+			Latency: stats.latency,
+			Err:     stats.err,
+		})
+	}
+
+	sort.Slice(res, func(i, j int) bool { return res[i].Address < res[j].Address })
+	return res
+}
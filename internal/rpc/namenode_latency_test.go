@@ -0,0 +1,117 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	hdfs "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_hdfs"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// slowConn delays every write, to simulate a distant namenode.
+type slowConn struct {
+	net.Conn
+	delay time.Duration
+}
+
+func (c slowConn) Write(b []byte) (int, error) {
+	time.Sleep(c.delay)
+	return c.Conn.Write(b)
+}
+
+func latencyTestConnection(t *testing.T, standby string) *NamenodeConnection {
+	c, err := NewNamenodeConnection(NamenodeConnectionOptions{
+		Addresses:            []string{"slow:8020", "fast:8020"},
+		User:                 "gohdfs1",
+		LatencyProbeInterval: 10 * time.Millisecond,
+		DialFunc: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			client, server := net.Pipe()
+
+			var conn net.Conn = server
+			if addr == "slow:8020" {
+				conn = slowConn{server, 20 * time.Millisecond}
+			}
+
+			go fakeNamenode(conn, addr == standby,
+				&hdfs.GetFileInfoRequestProto{}, &hdfs.GetFileInfoResponseProto{})
+			return client, nil
+		},
+	})
+	require.NoError(t, err)
+	return c
+}
+
+// waitForProbes executes requests until every namenode has answered enough
+// probes (or failed one) to be considered.
+func waitForProbes(t *testing.T, c *NamenodeConnection) {
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		req := &hdfs.GetFileInfoRequestProto{Src: proto.String("/")}
+		require.NoError(t, c.Execute("getFileInfo", req, &hdfs.GetFileInfoResponseProto{}))
+
+		c.latencyLock.Lock()
+		ready := len(c.latencies) == 2
+		for _, stats := range c.latencies {
+			if stats.err == nil && stats.samples < minLatencySamples+1 {
+				ready = false
+			}
+		}
+		c.latencyLock.Unlock()
+
+		if ready {
+			return
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	t.Fatal("timed out waiting for latency probes")
+}
+
+func TestNamenodeLatencySwitch(t *testing.T) {
+	c := latencyTestConnection(t, "")
+	defer c.Close()
+	assert.Equal(t, "slow:8020", c.host.address)
+
+	waitForProbes(t, c)
+	req := &hdfs.GetFileInfoRequestProto{Src: proto.String("/")}
+	require.NoError(t, c.Execute("getFileInfo", req, &hdfs.GetFileInfoResponseProto{}))
+	assert.Equal(t, "fast:8020", c.host.address)
+
+	latencies := c.Latencies()
+	require.Len(t, latencies, 2)
+	assert.Equal(t, "fast:8020", latencies[0].Address)
+	assert.Equal(t, "slow:8020", latencies[1].Address)
+	assert.True(t, latencies[0].Latency < latencies[1].Latency)
+}
+
+func TestNamenodeLatencyIgnoresStandby(t *testing.T) {
+	c := latencyTestConnection(t, "fast:8020")
+	defer c.Close()
+
+	waitForProbes(t, c)
+	req := &hdfs.GetFileInfoRequestProto{Src: proto.String("/")}
+	require.NoError(t, c.Execute("getFileInfo", req, &hdfs.GetFileInfoResponseProto{}))
+	assert.Equal(t, "slow:8020", c.host.address)
+
+	latencies := c.Latencies()
+	require.Len(t, latencies, 2)
+	assert.Error(t, latencies[0].Err)
+	assert.NoError(t, latencies[1].Err)
+}
+
+func TestNamenodeLatencyDisabled(t *testing.T) {
+	c, err := NewNamenodeConnection(NamenodeConnectionOptions{
+		Addresses:     []string{"nn1:8020", "nn2:8020"},
+		User:          "gohdfs1",
+		HedgeRequests: true,
+	})
+	require.NoError(t, err)
+	defer c.Close()
+
+	assert.Nil(t, c.Latencies())
+}
@@ -0,0 +1,451 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	hadoop "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_common"
+	hdfs "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_hdfs"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNamenodeLookupHost(t *testing.T) {
+	lookups := [][]string{
+		{"10.0.0.1", "10.0.0.2"},
+		{"10.0.0.2", "10.0.0.3", "10.0.0.1"},
+	}
+
+	var dialed []string
+	_, err := NewNamenodeConnection(NamenodeConnectionOptions{
+		Addresses: []string{"namenode:8020", "10.0.0.9:8020"},
+		User:      "gohdfs1",
+		LookupHost: func(ctx context.Context, host string) ([]string, error) {
+			require.Equal(t, "namenode", host)
+			require.NotEmpty(t, lookups, "too many lookups")
+
+			ips := lookups[0]
+			lookups = lookups[1:]
+			return ips, nil
+		},
+		DialFunc: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			dialed = append(dialed, addr)
+			return nil, errors.New("connection refused")
+		},
+	})
+
+	require.Error(t, err)
+	assert.Empty(t, lookups)
+
+	// The namenodes which already failed are backing off, so only the new one
+	// should be tried after the second lookup.
+	assert.Equal(t, []string{"10.0.0.1:8020", "10.0.0.2:8020", "10.0.0.9:8020", "10.0.0.3:8020"}, dialed)
+}
+
+// fakeNamenode speaks just enough of the RPC protocol to answer every request
+// (of the same type as req) on conn, either with resp or, if standby is set,
+// a StandbyException.
+func fakeNamenode(conn net.Conn, standby bool, req, resp proto.Message) {
+	fakeNamenodeWithErrors(conn, func() string {
+		if standby {
+			return standbyExceptionClass
+		}
+
+		return ""
+	}, req, resp)
+}
+
+// fakeNamenodeWithErrors is like fakeNamenode, but calls exception before
+// answering each request, and if it returns a class name, responds with that
+// exception instead.
+func fakeNamenodeWithErrors(conn net.Conn, exception func() string, req, resp proto.Message) {
+	defer conn.Close()
+
+	header := make([]byte, 7)
+	_, err := io.ReadFull(conn, header)
+	if err != nil {
+		return
+	}
+
+	err = readRPCPacket(conn, &hadoop.RpcRequestHeaderProto{}, &hadoop.IpcConnectionContextProto{})
+	if err != nil {
+		return
+	}
+
+	for {
+		rrh := &hadoop.RpcRequestHeaderProto{}
+		err = readRPCPacket(conn, rrh, &hadoop.RequestHeaderProto{}, req)
+		if err != nil {
+			return
+		}
+
+		respHeader := &hadoop.RpcResponseHeaderProto{
+			CallId: proto.Uint32(uint32(rrh.GetCallId())),
+			Status: hadoop.RpcResponseHeaderProto_SUCCESS.Enum(),
+		}
+
+		msgs := []proto.Message{respHeader, resp}
+		if class := exception(); class == standbyExceptionClass {
+			respHeader.Status = hadoop.RpcResponseHeaderProto_ERROR.Enum()
+			respHeader.ExceptionClassName = proto.String(standbyExceptionClass)
+			respHeader.ErrorMsg = proto.String("Operation category READ is not supported in state standby")
+			msgs = msgs[:1]
+		} else if class != "" {
+			respHeader.Status = hadoop.RpcResponseHeaderProto_ERROR.Enum()
+			respHeader.ExceptionClassName = proto.String(class)
+			respHeader.ErrorMsg = proto.String("try again later")
+			msgs = msgs[:1]
+		}
+
+		packet, err := makeRPCPacket(msgs...)
+		if err != nil {
+			panic(err)
+		}
+
+		_, err = conn.Write(packet)
+		if err != nil {
+			return
+		}
+	}
+}
+
+func TestNamenodeHedgeRequests(t *testing.T) {
+	var dialed []string
+	var dialLock sync.Mutex
+
+	c, err := NewNamenodeConnection(NamenodeConnectionOptions{
+		Addresses:     []string{"standby:8020", "active:8020"},
+		User:          "gohdfs1",
+		HedgeRequests: true,
+		DialFunc: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			dialLock.Lock()
+			dialed = append(dialed, addr)
+			dialLock.Unlock()
+
+			client, server := net.Pipe()
+			go fakeNamenode(server, addr == "standby:8020",
+				&hdfs.GetPreferredBlockSizeRequestProto{},
+				&hdfs.GetPreferredBlockSizeResponseProto{Bsize: proto.Uint64(1024)})
+			return client, nil
+		},
+	})
+	require.NoError(t, err)
+	defer c.Close()
+
+	for i := 0; i < 3; i++ {
+		req := &hdfs.GetPreferredBlockSizeRequestProto{Filename: proto.String("/foo")}
+		resp := &hdfs.GetPreferredBlockSizeResponseProto{}
+		err = c.Execute("getPreferredBlockSize", req, resp)
+		require.NoError(t, err)
+		assert.EqualValues(t, 1024, resp.GetBsize())
+	}
+
+	// Both namenodes should only have been dialed once, for the first request.
+	dialLock.Lock()
+	defer dialLock.Unlock()
+	assert.ElementsMatch(t, []string{"standby:8020", "active:8020"}, dialed)
+}
+
+func TestNamenodeClientNameTag(t *testing.T) {
+	c, err := NewNamenodeConnection(NamenodeConnectionOptions{
+		Addresses:     []string{"nn1:8020", "nn2:8020"},
+		User:          "gohdfs1",
+		HedgeRequests: true,
+		ClientNameTag: "job_1234",
+	})
+	require.NoError(t, err)
+	defer c.Close()
+
+	assert.Equal(t, "go-hdfs-"+string(c.ClientID)+"_job_1234", c.ClientName)
+}
+
+func TestNamenodeRequestTimeoutAndRetries(t *testing.T) {
+	var dials int
+	c, err := NewNamenodeConnection(NamenodeConnectionOptions{
+		Addresses:      []string{"namenode:8020"},
+		User:           "gohdfs1",
+		RequestTimeout: 50 * time.Millisecond,
+		Retries:        2,
+		RetryInterval:  10 * time.Millisecond,
+		DialFunc: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			dials++
+
+			// This namenode reads requests, but never responds.
+			client, server := net.Pipe()
+			go io.Copy(ioutil.Discard, server)
+			return client, nil
+		},
+	})
+	require.NoError(t, err)
+	defer c.Close()
+
+	req := &hdfs.GetPreferredBlockSizeRequestProto{Filename: proto.String("/foo")}
+	resp := &hdfs.GetPreferredBlockSizeResponseProto{}
+	err = c.Execute("getPreferredBlockSize", req, resp)
+	require.Error(t, err)
+
+	// One connection for the first attempt, and one for each retry.
+	assert.Equal(t, 3, dials)
+}
+
+func TestNamenodeRetriableExceptions(t *testing.T) {
+	var lock sync.Mutex
+	var dials int
+	exceptions := []string{
+		retriableExceptionClass,
+		"org.apache.hadoop.hdfs.server.namenode.SafeModeException",
+		"", // Success.
+		"org.apache.hadoop.hdfs.server.namenode.SafeModeException",
+		"org.apache.hadoop.hdfs.server.namenode.SafeModeException",
+		"org.apache.hadoop.hdfs.server.namenode.SafeModeException",
+		"java.io.FileNotFoundException",
+	}
+
+	c, err := NewNamenodeConnection(NamenodeConnectionOptions{
+		Addresses:           []string{"namenode:8020"},
+		User:                "gohdfs1",
+		Retries:             2,
+		RetryInterval:       time.Millisecond,
+		RetriableExceptions: []string{"org.apache.hadoop.hdfs.server.namenode.SafeModeException"},
+		DialFunc: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			dials++
+			client, server := net.Pipe()
+			go fakeNamenodeWithErrors(server, func() string {
+				lock.Lock()
+				defer lock.Unlock()
+
+				class := exceptions[0]
+				exceptions = exceptions[1:]
+				return class
+			}, &hdfs.GetPreferredBlockSizeRequestProto{},
+				&hdfs.GetPreferredBlockSizeResponseProto{Bsize: proto.Uint64(1024)})
+			return client, nil
+		},
+	})
+	require.NoError(t, err)
+	defer c.Close()
+
+	req := &hdfs.GetPreferredBlockSizeRequestProto{Filename: proto.String("/foo")}
+	resp := &hdfs.GetPreferredBlockSizeResponseProto{}
+	err = c.Execute("getPreferredBlockSize", req, resp)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1024, resp.GetBsize())
+
+	// The retries are used up after the third SafeModeException.
+	err = c.Execute("getPreferredBlockSize", req, resp)
+	var nerr *NamenodeError
+	require.True(t, errors.As(err, &nerr), "%v", err)
+	assert.Equal(t, "org.apache.hadoop.hdfs.server.namenode.SafeModeException", nerr.Exception())
+
+	// Other exceptions aren't retried.
+	err = c.Execute("getPreferredBlockSize", req, resp)
+	require.True(t, errors.As(err, &nerr), "%v", err)
+	assert.Equal(t, "java.io.FileNotFoundException", nerr.Exception())
+
+	// Every retry was on the same connection.
+	assert.Equal(t, 1, dials)
+	assert.Empty(t, exceptions)
+}
+
+func TestNamenodeConnectionReset(t *testing.T) {
+	var dials int
+	c, err := NewNamenodeConnection(NamenodeConnectionOptions{
+		Addresses: []string{"namenode:8020"},
+		User:      "gohdfs1",
+		DialFunc: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			dials++
+			client, server := net.Pipe()
+			if dials == 1 {
+				// The first connection is reset after the handshake, as if
+				// the namenode had restarted.
+				go func() {
+					io.ReadFull(server, make([]byte, 7))
+					readRPCPacket(server, &hadoop.RpcRequestHeaderProto{}, &hadoop.IpcConnectionContextProto{})
+					readRPCPacket(server, &hadoop.RpcRequestHeaderProto{}, &hadoop.RequestHeaderProto{},
+						&hdfs.GetPreferredBlockSizeRequestProto{})
+					server.Close()
+				}()
+
+				return &resetConn{client}, nil
+			}
+
+			go fakeNamenode(server, false, &hdfs.GetPreferredBlockSizeRequestProto{},
+				&hdfs.GetPreferredBlockSizeResponseProto{Bsize: proto.Uint64(1024)})
+			return client, nil
+		},
+	})
+	require.NoError(t, err)
+	defer c.Close()
+
+	req := &hdfs.GetPreferredBlockSizeRequestProto{Filename: proto.String("/foo")}
+	resp := &hdfs.GetPreferredBlockSizeResponseProto{}
+	err = c.Execute("getPreferredBlockSize", req, resp)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1024, resp.GetBsize())
+	assert.Equal(t, 2, dials)
+}
+
+func TestNamenodeConnectionResetRetries(t *testing.T) {
+	var dials int
+	c, err := NewNamenodeConnection(NamenodeConnectionOptions{
+		Addresses:     []string{"namenode:8020"},
+		User:          "gohdfs1",
+		Retries:       2,
+		RetryInterval: time.Millisecond,
+		DialFunc: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			dials++
+
+			// Every connection is reset after the request is read.
+			client, server := net.Pipe()
+			go func() {
+				io.ReadFull(server, make([]byte, 7))
+				readRPCPacket(server, &hadoop.RpcRequestHeaderProto{}, &hadoop.IpcConnectionContextProto{})
+				readRPCPacket(server, &hadoop.RpcRequestHeaderProto{}, &hadoop.RequestHeaderProto{},
+					&hdfs.GetPreferredBlockSizeRequestProto{})
+				server.Close()
+			}()
+
+			return &resetConn{client}, nil
+		},
+	})
+	require.NoError(t, err)
+	defer c.Close()
+
+	req := &hdfs.GetPreferredBlockSizeRequestProto{Filename: proto.String("/foo")}
+	resp := &hdfs.GetPreferredBlockSizeResponseProto{}
+	err = c.Execute("getPreferredBlockSize", req, resp)
+	assert.True(t, errors.Is(err, syscall.ECONNRESET), "%v", err)
+
+	// One connection for the first attempt, one to resend it, and one for
+	// each retry.
+	assert.Equal(t, 4, dials)
+}
+
+func TestNamenodeConnectionResetNotIdempotent(t *testing.T) {
+	var dials int
+	c, err := NewNamenodeConnection(NamenodeConnectionOptions{
+		Addresses: []string{"namenode:8020"},
+		User:      "gohdfs1",
+		Retries:   2,
+		DialFunc: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			dials++
+
+			// The connection is reset after the request is read, so it may
+			// have been processed.
+			client, server := net.Pipe()
+			go func() {
+				io.ReadFull(server, make([]byte, 7))
+				readRPCPacket(server, &hadoop.RpcRequestHeaderProto{}, &hadoop.IpcConnectionContextProto{})
+				readRPCPacket(server, &hadoop.RpcRequestHeaderProto{}, &hadoop.RequestHeaderProto{},
+					&hdfs.DeleteRequestProto{})
+				server.Close()
+			}()
+
+			return &resetConn{client}, nil
+		},
+	})
+	require.NoError(t, err)
+	defer c.Close()
+
+	req := &hdfs.DeleteRequestProto{Src: proto.String("/foo"), Recursive: proto.Bool(false)}
+	resp := &hdfs.DeleteResponseProto{}
+	err = c.Execute("delete", req, resp)
+	assert.True(t, errors.Is(err, syscall.ECONNRESET), "%v", err)
+	assert.Equal(t, 1, dials)
+}
+
+// resetConn turns a closed connection into a connection reset.
+type resetConn struct {
+	net.Conn
+}
+
+func (c *resetConn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+	if err == io.EOF {
+		err = &net.OpError{Op: "read", Net: "tcp", Err: os.NewSyscallError("read", syscall.ECONNRESET)}
+	}
+
+	return n, err
+}
+
+func TestNamenodeExecuteContext(t *testing.T) {
+	var dials int
+	c, err := NewNamenodeConnection(NamenodeConnectionOptions{
+		Addresses:     []string{"namenode:8020"},
+		User:          "gohdfs1",
+		Retries:       5,
+		RetryInterval: time.Hour,
+		DialFunc: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			dials++
+			if dials > 1 {
+				return nil, errors.New("namenode is down")
+			}
+
+			// This namenode accepts the handshake, but never responds.
+			client, server := net.Pipe()
+			go io.Copy(ioutil.Discard, server)
+			return client, nil
+		},
+	})
+	require.NoError(t, err)
+	defer c.Close()
+
+	req := &hdfs.GetPreferredBlockSizeRequestProto{Filename: proto.String("/foo")}
+	resp := &hdfs.GetPreferredBlockSizeResponseProto{}
+
+	// Waiting for a response is interrupted.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err = c.ExecuteContext(ctx, "getPreferredBlockSize", req, resp)
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.Nil(t, c.host.lastError, "the namenode shouldn't be marked as failed")
+
+	// So is waiting to retry.
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err = c.ExecuteContext(ctx, "getPreferredBlockSize", req, resp)
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.True(t, time.Since(start) < time.Minute)
+	assert.Equal(t, 2, dials)
+
+	err = c.ExecuteContext(ctx, "getPreferredBlockSize", req, resp)
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.Equal(t, 2, dials)
+}
+
+func TestNamenodeRetryDelay(t *testing.T) {
+	c := &NamenodeConnection{retryWait: 100 * time.Millisecond}
+	assert.Equal(t, 100*time.Millisecond, c.retryDelay(0))
+	assert.Equal(t, 100*time.Millisecond, c.retryDelay(5))
+
+	c.maxWait = time.Second
+	for retry, base := range []time.Duration{100, 200, 400, 800, 1000, 1000} {
+		base *= time.Millisecond
+		for i := 0; i < 20; i++ {
+			delay := c.retryDelay(retry)
+			assert.True(t, delay >= base/2, "retry %d: %s is too short", retry, delay)
+			assert.True(t, delay <= time.Second, "retry %d: %s is too long", retry, delay)
+		}
+	}
+
+	assert.True(t, c.retryDelay(100) >= 500*time.Millisecond)
+
+	// Shifting these would overflow.
+	c.retryWait = time.Hour
+	c.maxWait = 1000 * time.Hour
+	for _, retry := range []int{20, 31, 40, 1000} {
+		delay := c.retryDelay(retry)
+		assert.True(t, delay >= 500*time.Hour, "retry %d: %s is too short", retry, delay)
+		assert.True(t, delay <= 1000*time.Hour, "retry %d: %s is too long", retry, delay)
+	}
+}
@@ -0,0 +1,35 @@
+package rpc
+
+import "sync"
+
+// packetPools holds a *sync.Pool of packet data buffers for each packet size
+// in use, so that writers using large packets don't allocate a new buffer for
+// every one.
+var packetPools sync.Map
+
+func packetPool(size int) *sync.Pool {
+	if pool, ok := packetPools.Load(size); ok {
+		return pool.(*sync.Pool)
+	}
+
+	pool, _ := packetPools.LoadOrStore(size, &sync.Pool{
+		New: func() interface{} {
+			b := make([]byte, size)
+			return &b
+		},
+	})
+
+	return pool.(*sync.Pool)
+}
+
+// getPacketBuffer returns a buffer of the given size from the pool. Its
+// contents are undefined.
+func getPacketBuffer(size int) []byte {
+	return *packetPool(size).Get().(*[]byte)
+}
+
+// putPacketBuffer returns a buffer obtained from getPacketBuffer to the pool.
+// It must not be used afterwards.
+func putPacketBuffer(b []byte) {
+	packetPool(len(b)).Put(&b)
+}
@@ -0,0 +1,124 @@
+package rpc
+
+import "io"
+
+// readAheadBufferSize is the size of the buffers a readAhead fills, which is
+// the default packet size.
+const readAheadBufferSize = 64 * 1024
+
+// readAhead implements io.Reader, reading from a datanode connection in the
+// background, so that the next packets are already on their way while the
+// current ones are being verified and copied out. The buffers come from the
+// packet pool, and go back to it once they've been read, so a long stream of
+// reads doesn't allocate.
+type readAhead struct {
+	chunks chan readAheadChunk
+	done   chan struct{}
+
+	current readAheadChunk
+	pos     int
+}
+
+type readAheadChunk struct {
+	buf []byte
+	n   int
+	err error
+}
+
+// newReadAhead starts reading ahead from r, by up to size bytes (rounded up to
+// a whole buffer).
+func newReadAhead(r io.Reader, size int) *readAhead {
+	buffers := (size + readAheadBufferSize - 1) / readAheadBufferSize
+	if buffers < 1 {
+		buffers = 1
+	}
+
+	// One buffer is held by fill while it waits to send it.
+	ra := &readAhead{
+		chunks: make(chan readAheadChunk, buffers-1),
+		done:   make(chan struct{}),
+	}
+
+	go ra.fill(r)
+	return ra
+}
+
+func (ra *readAhead) fill(r io.Reader) {
+	defer close(ra.chunks)
+
+	for {
+		buf := getPacketBuffer(readAheadBufferSize)
+		n, err := r.Read(buf)
+		if n == 0 && err == nil {
+			putPacketBuffer(buf)
+			continue
+		}
+
+		select {
+		case ra.chunks <- readAheadChunk{buf: buf, n: n, err: err}:
+		case <-ra.done:
+			putPacketBuffer(buf)
+			return
+		}
+
+		if err != nil {
+			return
+		}
+	}
+}
+
+// Read implements io.Reader. Any error from the underlying reader is returned
+// once the data read before it has been.
+func (ra *readAhead) Read(b []byte) (int, error) {
+	if len(b) == 0 {
+		return 0, nil
+	}
+
+	for ra.pos == ra.current.n {
+		if ra.current.err != nil {
+			return 0, ra.current.err
+		}
+
+		ra.release()
+		chunk, ok := <-ra.chunks
+		if !ok {
+			return 0, io.ErrClosedPipe
+		}
+
+		ra.current, ra.pos = chunk, 0
+	}
+
+	n := copy(b, ra.current.buf[ra.pos:ra.current.n])
+	ra.pos += n
+	return n, nil
+}
+
+func (ra *readAhead) release() {
+	if ra.current.buf != nil {
+		putPacketBuffer(ra.current.buf)
+		ra.current.buf = nil
+	}
+}
+
+// close stops reading ahead, and gives back the buffers that are ready. The
+// underlying reader should be closed first, so that the background read
+// returns.
+func (ra *readAhead) close() {
+	close(ra.done)
+	ra.release()
+	ra.current = readAheadChunk{err: io.ErrClosedPipe}
+	ra.pos = 0
+
+	for {
+		select {
+		case chunk, ok := <-ra.chunks:
+			if !ok {
+				return
+			}
+
+			putPacketBuffer(chunk.buf)
+		default:
+			return
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net"
+	"testing"
+	"testing/iotest"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadAhead(t *testing.T) {
+	data := make([]byte, 3*readAheadBufferSize+100)
+	rand.Read(data)
+
+	for _, size := range []int{1, readAheadBufferSize, 10 * readAheadBufferSize} {
+		ra := newReadAhead(iotest.HalfReader(bytes.NewReader(data)), size)
+		b, err := ioutil.ReadAll(iotest.OneByteReader(io.LimitReader(ra, 1000)))
+		require.NoError(t, err)
+		assert.Equal(t, data[:1000], b, "size %d", size)
+
+		b, err = ioutil.ReadAll(ra)
+		require.NoError(t, err)
+		assert.Equal(t, data[1000:], b, "size %d", size)
+		ra.close()
+	}
+}
+
+func TestReadAheadError(t *testing.T) {
+	failure := errors.New("connection reset")
+	r := io.MultiReader(bytes.NewReader([]byte("foo")), iotest.ErrReader(failure))
+	ra := newReadAhead(r, readAheadBufferSize)
+	defer ra.close()
+
+	b, err := ioutil.ReadAll(ra)
+	assert.Equal(t, failure, err)
+	assert.Equal(t, "foo", string(b))
+
+	_, err = ra.Read(make([]byte, 10))
+	assert.Equal(t, failure, err)
+}
+
+func TestReadAheadClose(t *testing.T) {
+	client, server := net.Pipe()
+	go server.Write(bytes.Repeat([]byte("x"), readAheadBufferSize))
+
+	ra := newReadAhead(client, readAheadBufferSize)
+	b := make([]byte, 10)
+	_, err := io.ReadFull(ra, b)
+	require.NoError(t, err)
+
+	client.Close()
+	ra.close()
+	server.Close()
+
+	_, err = ra.Read(b)
+	assert.Equal(t, io.ErrClosedPipe, err)
+}
+
+func TestBlockReaderReadAhead(t *testing.T) {
+	data := make([]byte, 5*readAheadBufferSize+17)
+	rand.Read(data)
+
+	block := testBlock("10.3.0.1")
+	block.B.NumBytes = proto.Uint64(uint64(len(data)))
+	blocks := map[uint64][]byte{block.B.GetBlockId(): data}
+	dial := func(ctx context.Context, network, addr string) (net.Conn, error) {
+		client, server := net.Pipe()
+		go fakeReadDatanode(server, blocks)
+		return client, nil
+	}
+
+	for _, off := range []int64{0, 1, 3 * readAheadBufferSize} {
+		br := &BlockReader{
+			Block:     block,
+			Offset:    off,
+			DialFunc:  dial,
+			ReadAhead: 2 * readAheadBufferSize,
+		}
+
+		b, err := ioutil.ReadAll(br)
+		br.Close()
+		require.NoError(t, err)
+		assert.Equal(t, data[off:], b, "offset %d", off)
+		assert.Nil(t, br.readAhead)
+	}
+}
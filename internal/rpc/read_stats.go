@@ -0,0 +1,145 @@
+package rpc
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// readLatencySamples is how many of the most recent read latencies are kept
+// for each datanode, to compute percentiles from.
+const readLatencySamples = 1024
+
+// DatanodeReadStats describes the reads a client has made from a single
+// datanode.
+type DatanodeReadStats struct {
+	Address string
+	// Bytes is the number of bytes read from the datanode.
+	Bytes int64
+	// Reads is the number of successful reads from the datanode.
+	Reads int64
+	// Errors is the number of reads and connection attempts that failed.
+	Errors int64
+	// LatencyP50, LatencyP90, and LatencyP99 are percentiles of how long each
+	// read from the datanode took, over the most recent reads.
+	LatencyP50 time.Duration
+	LatencyP90 time.Duration
+	LatencyP99 time.Duration
+}
+
+// ReadStats keeps track of the bytes read, errors, and read latency for each
+// datanode. A nil *ReadStats is valid, and records nothing.
+type ReadStats struct {
+	// OnRead and OnError, if set, are called with each read and error as it's
+	// recorded.
+	OnRead  func(address string, n int, latency time.Duration)
+	OnError func(address string, err error)
+
+	lock  sync.Mutex
+	nodes map[string]*datanodeReadStats
+}
+
+type datanodeReadStats struct {
+	bytes  int64
+	reads  int64
+	errors int64
+
+	// latencies is a ring buffer of the most recent read latencies.
+	latencies []time.Duration
+	next      int
+}
+
+// NewReadStats returns an empty ReadStats.
+func NewReadStats() *ReadStats {
+	return &ReadStats{nodes: make(map[string]*datanodeReadStats)}
+}
+
+func (rs *ReadStats) get(address string) *datanodeReadStats {
+	s, ok := rs.nodes[address]
+	if !ok {
+		s = &datanodeReadStats{}
+		rs.nodes[address] = s
+	}
+
+	return s
+}
+
+func (rs *ReadStats) recordRead(address string, n int, latency time.Duration) {
+	if rs == nil {
+		return
+	} else if rs.OnRead != nil {
+		rs.OnRead(address, n, latency)
+	}
+
+	rs.lock.Lock()
+	defer rs.lock.Unlock()
+
+	s := rs.get(address)
+	s.bytes += int64(n)
+	s.reads++
+	if len(s.latencies) < readLatencySamples {
+		s.latencies = append(s.latencies, latency)
+	} else {
+		s.latencies[s.next] = latency
+		s.next = (s.next + 1) % readLatencySamples
+	}
+}
+
+func (rs *ReadStats) recordError(address string, err error) {
+	if rs == nil {
+		return
+	} else if rs.OnError != nil {
+		rs.OnError(address, err)
+	}
+
+	rs.lock.Lock()
+	defer rs.lock.Unlock()
+
+	rs.get(address).errors++
+}
+
+// Stats returns the statistics for every datanode that has been read from,
+// sorted by address.
+func (rs *ReadStats) Stats() []DatanodeReadStats {
+	if rs == nil {
+		return nil
+	}
+
+	rs.lock.Lock()
+	defer rs.lock.Unlock()
+
+	stats := make([]DatanodeReadStats, 0, len(rs.nodes))
+	for address, s := range rs.nodes {
+		latencies := make([]time.Duration, len(s.latencies))
+		copy(latencies, s.latencies)
+		sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+
+		stats = append(stats, DatanodeReadStats{
+			Address:    address,
+			Bytes:      s.bytes,
+			Reads:      s.reads,
+			Errors:     s.errors,
+			LatencyP50: percentile(latencies, 50),
+			LatencyP90: percentile(latencies, 90),
+			LatencyP99: percentile(latencies, 99),
+		})
+	}
+
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Address < stats[j].Address })
+	return stats
+}
+
+// percentile returns the pth percentile of sorted, using the nearest-rank
+// method, or zero if it's empty.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+
+	return sorted[rank-1]
+}
@@ -0,0 +1,72 @@
+package rpc
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadStats(t *testing.T) {
+	rs := NewReadStats()
+	for i := 1; i <= 100; i++ {
+		rs.recordRead("10.0.0.2:9866", 10, time.Duration(i)*time.Millisecond)
+	}
+
+	rs.recordError("10.0.0.1:9866", errors.New("connection refused"))
+	rs.recordError("10.0.0.2:9866", errors.New("connection refused"))
+
+	assert.Equal(t, []DatanodeReadStats{
+		{Address: "10.0.0.1:9866", Errors: 1},
+		{
+			Address:    "10.0.0.2:9866",
+			Bytes:      1000,
+			Reads:      100,
+			Errors:     1,
+			LatencyP50: 50 * time.Millisecond,
+			LatencyP90: 90 * time.Millisecond,
+			LatencyP99: 99 * time.Millisecond,
+		},
+	}, rs.Stats())
+}
+
+func TestReadStatsRecentLatencies(t *testing.T) {
+	rs := NewReadStats()
+	for i := 0; i < readLatencySamples; i++ {
+		rs.recordRead("10.0.0.1:9866", 1, time.Second)
+	}
+
+	// Once the old samples have been replaced, they no longer count.
+	for i := 0; i < readLatencySamples; i++ {
+		rs.recordRead("10.0.0.1:9866", 1, time.Millisecond)
+	}
+
+	stats := rs.Stats()
+	require.Len(t, stats, 1)
+	assert.EqualValues(t, 2*readLatencySamples, stats[0].Reads)
+	assert.Equal(t, time.Millisecond, stats[0].LatencyP99)
+}
+
+func TestReadStatsHooks(t *testing.T) {
+	var bytes int
+	var failed []string
+	rs := NewReadStats()
+	rs.OnRead = func(address string, n int, latency time.Duration) { bytes += n }
+	rs.OnError = func(address string, err error) { failed = append(failed, address+": "+err.Error()) }
+
+	rs.recordRead("10.0.0.1:9866", 10, time.Millisecond)
+	rs.recordRead("10.0.0.2:9866", 5, time.Millisecond)
+	rs.recordError("10.0.0.1:9866", errors.New("connection refused"))
+
+	assert.Equal(t, 15, bytes)
+	assert.Equal(t, []string{"10.0.0.1:9866: connection refused"}, failed)
+}
+
+func TestReadStatsNil(t *testing.T) {
+	var rs *ReadStats
+	rs.recordRead("10.0.0.1:9866", 1, time.Second)
+	rs.recordError("10.0.0.1:9866", errors.New("connection refused"))
+	assert.Nil(t, rs.Stats())
+}
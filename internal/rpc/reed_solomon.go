@@ -0,0 +1,215 @@
+package rpc
+
+import (
+	"errors"
+	"fmt"
+)
+
+// gfExp and gfLog are the exponent and logarithm tables for GF(2^8), with the
+// primitive polynomial x^8 + x^4 + x^3 + x^2 + 1 (0x11d). This is the field
+// Hadoop's "rs" codec (and ISA-L) uses.
+var gfExp, gfLog = makeGFTables()
+
+func makeGFTables() ([512]byte, [256]byte) {
+	var exp [512]byte
+	var log [256]byte
+
+	x := 1
+	for i := 0; i < 255; i++ {
+		exp[i] = byte(x)
+		log[x] = byte(i)
+		x <<= 1
+		if x&0x100 != 0 {
+			x ^= 0x11d
+		}
+	}
+
+	// Doubling the exponent table means gfMul doesn't need to reduce the sum of
+	// the logarithms mod 255.
+	for i := 255; i < 512; i++ {
+		exp[i] = exp[i-255]
+	}
+
+	return exp, log
+}
+
+func gfMul(a, b byte) byte {
+	if a == 0 || b == 0 {
+		return 0
+	}
+
+	return gfExp[int(gfLog[a])+int(gfLog[b])]
+}
+
+func gfInv(a byte) byte {
+	if a == 0 {
+		return 0
+	}
+
+	return gfExp[255-int(gfLog[a])]
+}
+
+// rsCodec implements the Reed-Solomon erasure code used by Hadoop's "rs" codec
+// (RSRawEncoder and RSRawDecoder, not the older "rs-legacy" one). The encoding
+// matrix is an identity matrix for the data units, followed by a Cauchy matrix
+// for the parity units.
+type rsCodec struct {
+	dataUnits   int
+	parityUnits int
+	matrix      [][]byte
+}
+
+func newRSCodec(dataUnits, parityUnits int) (*rsCodec, error) {
+	if dataUnits <= 0 || parityUnits < 0 || dataUnits+parityUnits > 256 {
+		return nil, fmt.Errorf("invalid reed-solomon schema: %d data units, %d parity units",
+			dataUnits, parityUnits)
+	}
+
+	matrix := make([][]byte, dataUnits+parityUnits)
+	for i := range matrix {
+		matrix[i] = make([]byte, dataUnits)
+		if i < dataUnits {
+			matrix[i][i] = 1
+			continue
+		}
+
+		for j := range matrix[i] {
+			matrix[i][j] = gfInv(byte(i ^ j))
+		}
+	}
+
+	return &rsCodec{
+		dataUnits:   dataUnits,
+		parityUnits: parityUnits,
+		matrix:      matrix,
+	}, nil
+}
+
+// encode computes the parity shards from the data shards. shards must have
+// length dataUnits+parityUnits, and all the shards must be the same size.
+func (rs *rsCodec) encode(shards [][]byte) {
+	for p := rs.dataUnits; p < len(shards); p++ {
+		out := shards[p]
+		for i := range out {
+			out[i] = 0
+		}
+
+		for j := 0; j < rs.dataUnits; j++ {
+			mulAdd(out, shards[j], rs.matrix[p][j])
+		}
+	}
+}
+
+// reconstruct fills in the missing data shards, which must be allocated but are
+// otherwise ignored, using any dataUnits of the available ones. Missing parity
+// shards are not reconstructed.
+func (rs *rsCodec) reconstruct(shards [][]byte, available []bool) error {
+	var missing []int
+	for i := 0; i < rs.dataUnits; i++ {
+		if !available[i] {
+			missing = append(missing, i)
+		}
+	}
+
+	if len(missing) == 0 {
+		return nil
+	}
+
+	// Pick dataUnits available shards, preferring data shards, and invert the
+	// corresponding rows of the encoding matrix.
+	inputs := make([]int, 0, rs.dataUnits)
+	for i := 0; i < len(shards) && len(inputs) < rs.dataUnits; i++ {
+		if available[i] {
+			inputs = append(inputs, i)
+		}
+	}
+
+	if len(inputs) < rs.dataUnits {
+		return errors.New("not enough shards to reconstruct")
+	}
+
+	sub := make([][]byte, rs.dataUnits)
+	for i, input := range inputs {
+		sub[i] = append([]byte(nil), rs.matrix[input]...)
+	}
+
+	inv, err := invertMatrix(sub)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range missing {
+		out := shards[m]
+		for i := range out {
+			out[i] = 0
+		}
+
+		for i, input := range inputs {
+			mulAdd(out, shards[input], inv[m][i])
+		}
+	}
+
+	return nil
+}
+
+// mulAdd sets out[i] += c * in[i], for each byte.
+func mulAdd(out, in []byte, c byte) {
+	if c == 0 {
+		return
+	}
+
+	logC := int(gfLog[c])
+	for i, b := range in {
+		if b != 0 {
+			out[i] ^= gfExp[logC+int(gfLog[b])]
+		}
+	}
+}
+
+// invertMatrix inverts a square matrix over GF(2^8) using Gauss-Jordan
+// elimination. The input is modified.
+func invertMatrix(m [][]byte) ([][]byte, error) {
+	n := len(m)
+	inv := make([][]byte, n)
+	for i := range inv {
+		inv[i] = make([]byte, n)
+		inv[i][i] = 1
+	}
+
+	for col := 0; col < n; col++ {
+		pivot := -1
+		for row := col; row < n; row++ {
+			if m[row][col] != 0 {
+				pivot = row
+				break
+			}
+		}
+
+		if pivot == -1 {
+			return nil, errors.New("matrix is singular")
+		}
+
+		m[col], m[pivot] = m[pivot], m[col]
+		inv[col], inv[pivot] = inv[pivot], inv[col]
+
+		scale := gfInv(m[col][col])
+		for j := 0; j < n; j++ {
+			m[col][j] = gfMul(m[col][j], scale)
+			inv[col][j] = gfMul(inv[col][j], scale)
+		}
+
+		for row := 0; row < n; row++ {
+			if row == col || m[row][col] == 0 {
+				continue
+			}
+
+			factor := m[row][col]
+			for j := 0; j < n; j++ {
+				m[row][j] ^= gfMul(factor, m[col][j])
+				inv[row][j] ^= gfMul(factor, inv[col][j])
+			}
+		}
+	}
+
+	return inv, nil
+}
@@ -0,0 +1,62 @@
+package rpc
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGFInverse(t *testing.T) {
+	for i := 1; i < 256; i++ {
+		assert.EqualValues(t, 1, gfMul(byte(i), gfInv(byte(i))), "inverse of %d", i)
+	}
+}
+
+func TestRSReconstruct(t *testing.T) {
+	rs, err := newRSCodec(6, 3)
+	require.NoError(t, err)
+
+	shards := make([][]byte, 9)
+	for i := range shards {
+		shards[i] = make([]byte, 100)
+		if i < 6 {
+			rand.Read(shards[i])
+		}
+	}
+
+	rs.encode(shards)
+	expected := make([][]byte, 6)
+	for i := range expected {
+		expected[i] = append([]byte(nil), shards[i]...)
+	}
+
+	for _, lost := range [][]int{{0}, {5}, {0, 1, 2}, {1, 4, 8}, {3, 6}} {
+		available := make([]bool, 9)
+		for i := range available {
+			available[i] = true
+		}
+
+		for _, i := range lost {
+			available[i] = false
+			shards[i] = make([]byte, 100)
+		}
+
+		require.NoError(t, rs.reconstruct(shards, available))
+		assert.Equal(t, expected, shards[:6], "lost %v", lost)
+	}
+}
+
+func TestRSReconstructTooFewShards(t *testing.T) {
+	rs, err := newRSCodec(3, 2)
+	require.NoError(t, err)
+
+	shards := make([][]byte, 5)
+	for i := range shards {
+		shards[i] = make([]byte, 10)
+	}
+
+	err = rs.reconstruct(shards, []bool{false, true, false, false, true})
+	assert.Error(t, err)
+}
@@ -15,10 +15,16 @@ import (
 )
 
 const (
-	dataTransferVersion = 0x1c
-	writeBlockOp        = 0x50
-	readBlockOp         = 0x51
-	checksumBlockOp     = 0x55
+	dataTransferVersion      = 0x1c
+	writeBlockOp             = 0x50
+	readBlockOp              = 0x51
+	replaceBlockOp           = 0x53
+	copyBlockOp              = 0x54
+	checksumBlockOp          = 0x55
+	requestShortCircuitFdsOp = 0x57
+	releaseShortCircuitFdsOp = 0x58
+	requestShortCircuitShmOp = 0x59
+	checksumGroupOp          = 0x5a
 )
 
 var errMalformedRPCMessage = errors.New("malformed RPC message")
@@ -56,35 +62,45 @@ func makeRPCPacket(msgs ...proto.Message) ([]byte, error) {
 }
 
 func readRPCPacket(r io.Reader, msgs ...proto.Message) error {
+	_, err := readRPCPacketMax(r, 0, msgs...)
+	return err
+}
+
+// readRPCPacketMax is like readRPCPacket, but fails if the packet is longer than
+// max (if max is nonzero). It returns the number of messages that were
+// present in the packet.
+func readRPCPacketMax(r io.Reader, max uint32, msgs ...proto.Message) (int, error) {
 	var packetLength uint32
 	err := binary.Read(r, binary.BigEndian, &packetLength)
 	if err != nil {
-		return err
+		return 0, err
+	} else if max > 0 && packetLength > max {
+		return 0, lengthError{uint64(packetLength), uint64(max)}
 	}
 
 	packet := make([]byte, packetLength)
 	_, err = io.ReadFull(r, packet)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	for _, msg := range msgs {
+	for i, msg := range msgs {
 		// HDFS doesn't send all the response messages all the time (for example, if
 		// the RpcResponseHeaderProto contains an error).
 		if len(packet) == 0 {
-			return nil
+			return i, nil
 		}
 
 		msgLength, n := binary.Uvarint(packet)
 		if n <= 0 || msgLength > uint64(len(packet)) {
-			return errMalformedRPCMessage
+			return i, errMalformedRPCMessage
 		}
 
 		packet = packet[n:]
 		if msgLength != 0 {
 			err = proto.Unmarshal(packet[:msgLength], msg)
 			if err != nil {
-				return err
+				return i, err
 			}
 
 			packet = packet[msgLength:]
@@ -92,10 +108,10 @@ func readRPCPacket(r io.Reader, msgs ...proto.Message) error {
 	}
 
 	if len(packet) > 0 {
-		return errMalformedRPCMessage
+		return len(msgs), errMalformedRPCMessage
 	}
 
-	return nil
+	return len(msgs), nil
 }
 
 func makePrefixedMessage(msg proto.Message) ([]byte, error) {
@@ -110,6 +126,12 @@ func makePrefixedMessage(msg proto.Message) ([]byte, error) {
 }
 
 func readPrefixedMessage(r io.Reader, msg proto.Message) error {
+	return readPrefixedMessageMax(r, 0, msg)
+}
+
+// readPrefixedMessageMax is like readPrefixedMessage, but fails if the message
+// is longer than max (if max is nonzero).
+func readPrefixedMessageMax(r io.Reader, max uint64, msg proto.Message) error {
 	varintBytes := make([]byte, binary.MaxVarintLen32)
 	_, err := io.ReadAtLeast(r, varintBytes, 1)
 	if err != nil {
@@ -119,6 +141,8 @@ func readPrefixedMessage(r io.Reader, msg proto.Message) error {
 	respLength, varintLength := binary.Uvarint(varintBytes)
 	if varintLength < 1 {
 		return io.ErrUnexpectedEOF
+	} else if max > 0 && respLength > max {
+		return lengthError{respLength, max}
 	}
 
 	// We may have grabbed too many bytes when reading the varint.
@@ -161,8 +185,14 @@ func writeBlockOpRequest(w io.Writer, op uint8, msg proto.Message) error {
 // |  varint length + BlockOpResponseProto                     |
 // +-----------------------------------------------------------+
 func readBlockOpResponse(r io.Reader) (*hdfs.BlockOpResponseProto, error) {
+	return readBlockOpResponseMax(r, 0)
+}
+
+// readBlockOpResponseMax is like readBlockOpResponse, but fails if the response
+// is longer than max (if max is nonzero).
+func readBlockOpResponseMax(r io.Reader, max uint64) (*hdfs.BlockOpResponseProto, error) {
 	resp := &hdfs.BlockOpResponseProto{}
-	err := readPrefixedMessage(r, resp)
+	err := readPrefixedMessageMax(r, max, resp)
 
 	return resp, err
 }
@@ -0,0 +1,402 @@
+package rpc
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	hdfs "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_hdfs"
+	"github.com/golang/protobuf/proto"
+)
+
+const (
+	// shortCircuitDisableDuration is how long short-circuit reads are disabled
+	// for a domain socket after it fails, like the Java client's
+	// dfs.client.domain.socket.disable.interval.seconds.
+	shortCircuitDisableDuration = 10 * time.Minute
+	// shortCircuitTimeout limits each request over the domain socket.
+	shortCircuitTimeout = 10 * time.Second
+	// shortCircuitMaxVersion is the highest version of the on-disk block format
+	// that we understand.
+	shortCircuitMaxVersion = 1
+	// blockMetadataHeaderLength is the length of the header of a replica's
+	// .meta file: a 2-byte version, then the checksum type (1 byte) and the
+	// number of bytes per checksum (4 bytes). The checksums follow it.
+	blockMetadataHeaderLength = 7
+	// maxLocalRead caps how much is read from a local replica by each call to
+	// Read, since the chunks are read into a buffer to verify them.
+	maxLocalRead = 1024 * 1024
+)
+
+var (
+	errShortCircuitUnsupported = errors.New("short-circuit reads aren't supported on this platform")
+	errLocalReplicaInvalid     = errors.New("local replica is no longer valid")
+)
+
+// ShortCircuit reads blocks directly from the local disk, bypassing the
+// datanode's data transfer protocol, when the client is running on the same
+// host as a datanode with a replica. This is the equivalent of the Java
+// client's short-circuit local reads (dfs.client.read.shortcircuit).
+//
+// The datanode passes open file descriptors for the replica's data and
+// metadata files over a Unix domain socket. It also shares a segment of memory
+// with the client, with a slot for each replica the client has open, which it
+// uses to tell the client when a replica is no longer valid (because it was
+// deleted, for example).
+//
+// If a local replica can't be read for any reason, BlockReader falls back to
+// reading from the datanodes as usual. A ShortCircuit is safe for concurrent
+// use, and should be shared by all the readers of a client. It's only
+// supported on Unix-like platforms.
+type ShortCircuit struct {
+	// SocketPath is the path of the datanodes' domain socket, as configured by
+	// dfs.domain.socket.path. Any "_PORT" in it is replaced with the
+	// datanode's transfer port.
+	SocketPath string
+	// ClientName is sent to the datanode when requesting shared memory, for
+	// its logs.
+	ClientName string
+
+	lock     sync.Mutex
+	segments map[string][]*shmSegment
+	disabled map[string]time.Time
+	closed   bool
+}
+
+// Close releases the shared memory segments. Any local replicas still open
+// fall back to reading from the datanodes. It's a no-op on a nil ShortCircuit.
+func (sc *ShortCircuit) Close() error {
+	if sc == nil {
+		return nil
+	}
+
+	sc.lock.Lock()
+	defer sc.lock.Unlock()
+
+	sc.closed = true
+	for _, segments := range sc.segments {
+		for _, segment := range segments {
+			segment.close()
+		}
+	}
+
+	sc.segments = nil
+	return nil
+}
+
+// open tries to open a local replica of the block. It returns nil if none of
+// the block's datanodes is on this host, or if the replica can't be opened,
+// along with the address of the datanode.
+func (sc *ShortCircuit) open(block *hdfs.LocatedBlockProto, skipChecksum bool) (*localReplica, string) {
+	dn := localDatanode(block.GetLocs())
+	if dn == nil {
+		return nil, ""
+	}
+
+	path := sc.socketPath(dn)
+	if !sc.usable(path) {
+		return nil, ""
+	}
+
+	replica, err := sc.openReplica(path, block, skipChecksum)
+	if err != nil {
+		// Errors specific to the block, like a replica that isn't finalized yet,
+		// don't mean that short-circuit reads won't work for other blocks.
+		var dnErr *DatanodeError
+		if !errors.As(err, &dnErr) || dnErr.status == hdfs.Status_ERROR_UNSUPPORTED {
+			sc.disable(path)
+		}
+
+		return nil, ""
+	}
+
+	return replica, getDatanodeAddress(dn, false)
+}
+
+func (sc *ShortCircuit) openReplica(path string, block *hdfs.LocatedBlockProto, skipChecksum bool) (*localReplica, error) {
+	// If the datanode doesn't support shared memory, we can still read the
+	// replica, and just won't hear about it being invalidated.
+	slot, _ := sc.allocSlot(path)
+
+	data, meta, err := requestReplicaFiles(path, block, slot)
+	if err != nil {
+		if slot != nil {
+			// If the request didn't get as far as a response, we can't know
+			// whether the datanode registered the slot, so the segment can't be
+			// used for any more.
+			var dnErr *DatanodeError
+			if !errors.As(err, &dnErr) {
+				slot.segment.markBroken()
+			}
+
+			sc.freeSlot(slot)
+		}
+
+		return nil, err
+	}
+
+	replica, err := newLocalReplica(data, meta, skipChecksum)
+	if err != nil {
+		data.Close()
+		meta.Close()
+		if slot != nil {
+			go sc.releaseSlot(path, slot)
+		}
+
+		return nil, err
+	}
+
+	if slot != nil {
+		replica.valid = slot.valid
+		replica.release = func() { sc.releaseSlot(path, slot) }
+	}
+
+	return replica, nil
+}
+
+// releaseSlot tells the datanode that the replica in slot is no longer in
+// use, and then frees the slot.
+func (sc *ShortCircuit) releaseSlot(path string, slot *shmSlot) {
+	requestReleaseSlot(path, slot)
+	sc.freeSlot(slot)
+}
+
+func (sc *ShortCircuit) socketPath(dn *hdfs.DatanodeIDProto) string {
+	return strings.ReplaceAll(sc.SocketPath, "_PORT", strconv.Itoa(int(dn.GetXferPort())))
+}
+
+func (sc *ShortCircuit) usable(path string) bool {
+	sc.lock.Lock()
+	defer sc.lock.Unlock()
+
+	return !sc.closed && time.Since(sc.disabled[path]) > shortCircuitDisableDuration
+}
+
+func (sc *ShortCircuit) disable(path string) {
+	sc.lock.Lock()
+	defer sc.lock.Unlock()
+
+	if sc.disabled == nil {
+		sc.disabled = make(map[string]time.Time)
+	}
+
+	sc.disabled[path] = time.Now()
+}
+
+// localDatanode returns the first of the datanodes that's running on this
+// host, or nil if none are.
+func localDatanode(locs []*hdfs.DatanodeInfoProto) *hdfs.DatanodeIDProto {
+	for _, loc := range locs {
+		if isLocalAddress(loc.GetId().GetIpAddr()) {
+			return loc.GetId()
+		}
+	}
+
+	return nil
+}
+
+var (
+	localAddressesOnce sync.Once
+	localAddresses     map[string]bool
+)
+
+// isLocalAddress returns true if ip belongs to one of the host's network
+// interfaces.
+func isLocalAddress(ip string) bool {
+	localAddressesOnce.Do(func() {
+		localAddresses = make(map[string]bool)
+		addrs, _ := net.InterfaceAddrs()
+		for _, addr := range addrs {
+			if ipNet, ok := addr.(*net.IPNet); ok {
+				localAddresses[ipNet.IP.String()] = true
+			}
+		}
+	})
+
+	parsed := net.ParseIP(ip)
+	return parsed != nil && (parsed.IsLoopback() || localAddresses[parsed.String()])
+}
+
+// localReplica reads a replica from the files passed by the datanode,
+// verifying the data against the checksums in the metadata file.
+type localReplica struct {
+	data        *os.File
+	meta        *os.File
+	chunkSize   int
+	checksumTab *crc32.Table
+	buf         []byte
+	sums        []byte
+
+	// valid reports whether the datanode still considers the replica valid,
+	// and release is called once it's closed. Both are nil if the replica
+	// doesn't have a shared memory slot.
+	valid   func() bool
+	release func()
+}
+
+func newLocalReplica(data, meta *os.File, skipChecksum bool) (*localReplica, error) {
+	header := make([]byte, blockMetadataHeaderLength)
+	_, err := meta.ReadAt(header, 0)
+	if err != nil {
+		return nil, fmt.Errorf("reading block metadata header: %w", err)
+	}
+
+	if version := binary.BigEndian.Uint16(header); version != shortCircuitMaxVersion {
+		return nil, fmt.Errorf("unsupported block metadata version: %d", version)
+	}
+
+	checksumTab, err := getChecksumTable(&hdfs.ChecksumProto{
+		Type: hdfs.ChecksumTypeProto(header[2]).Enum(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	chunkSize := int(binary.BigEndian.Uint32(header[3:]))
+	if chunkSize <= 0 {
+		return nil, fmt.Errorf("invalid bytes per checksum: %d", chunkSize)
+	}
+
+	replica := &localReplica{
+		data:        data,
+		meta:        meta,
+		chunkSize:   chunkSize,
+		checksumTab: checksumTab,
+	}
+
+	if skipChecksum {
+		replica.checksumTab = nil
+	}
+
+	return replica, nil
+}
+
+// isValid returns false if the datanode has invalidated the replica.
+func (r *localReplica) isValid() bool {
+	return r.valid == nil || r.valid()
+}
+
+// readAt reads up to len(b) bytes from off in the replica. It returns
+// io.ErrUnexpectedEOF if the replica is shorter than expected.
+func (r *localReplica) readAt(b []byte, off int64) (int, error) {
+	if len(b) > maxLocalRead {
+		b = b[:maxLocalRead]
+	}
+
+	if r.checksumTab == nil {
+		n, err := r.data.ReadAt(b, off)
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+
+		return n, err
+	}
+
+	// Read whole chunks, so that they can be verified.
+	chunkSize := int64(r.chunkSize)
+	start := off - off%chunkSize
+	end := off + int64(len(b))
+	if rem := end % chunkSize; rem != 0 {
+		end += chunkSize - rem
+	}
+
+	if cap(r.buf) < int(end-start) {
+		r.buf = make([]byte, end-start)
+	}
+
+	// The last chunk of the replica can be partial.
+	n, err := r.data.ReadAt(r.buf[:end-start], start)
+	if err != nil && err != io.EOF {
+		return 0, err
+	}
+
+	data := r.buf[:n]
+	chunks := (len(data) + r.chunkSize - 1) / r.chunkSize
+	if cap(r.sums) < chunks*4 {
+		r.sums = make([]byte, chunks*4)
+	}
+
+	sums := r.sums[:chunks*4]
+	_, err = r.meta.ReadAt(sums, blockMetadataHeaderLength+(start/chunkSize)*4)
+	if err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+
+		return 0, err
+	}
+
+	for i := 0; i < chunks; i++ {
+		chunk := data[i*r.chunkSize:]
+		if len(chunk) > r.chunkSize {
+			chunk = chunk[:r.chunkSize]
+		}
+
+		if crc32.Checksum(chunk, r.checksumTab) != binary.BigEndian.Uint32(sums[i*4:]) {
+			return 0, errInvalidChecksum
+		}
+	}
+
+	if int64(len(data)) <= off-start {
+		return 0, io.ErrUnexpectedEOF
+	}
+
+	copied := copy(b, data[off-start:])
+	if copied < len(b) {
+		return copied, io.ErrUnexpectedEOF
+	}
+
+	return copied, nil
+}
+
+func (r *localReplica) close() {
+	r.data.Close()
+	r.meta.Close()
+	if r.release != nil {
+		go r.release()
+	}
+}
+
+// readUnbufferedPrefixedMessage is like readPrefixedMessage, but never reads
+// past the end of the message. Over a domain socket, the datanode sends file
+// descriptors right after the message, which would be lost if the bytes they
+// were attached to were read along with it.
+func readUnbufferedPrefixedMessage(r io.Reader, msg proto.Message) error {
+	var length uint64
+	var b [1]byte
+	for shift := uint(0); ; shift += 7 {
+		if shift >= 64 {
+			return errMalformedRPCMessage
+		}
+
+		_, err := io.ReadFull(r, b[:])
+		if err != nil {
+			return err
+		}
+
+		length |= uint64(b[0]&0x7f) << shift
+		if b[0] < 0x80 {
+			break
+		}
+	}
+
+	if length > maxDataTransferMessageLength {
+		return lengthError{length, maxDataTransferMessageLength}
+	}
+
+	msgBytes := make([]byte, length)
+	_, err := io.ReadFull(r, msgBytes)
+	if err != nil {
+		return err
+	}
+
+	return proto.Unmarshal(msgBytes, msg)
+}
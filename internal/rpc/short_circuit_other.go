@@ -0,0 +1,34 @@
+//go:build !unix
+
+package rpc
+
+import (
+	"os"
+
+	hdfs "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_hdfs"
+)
+
+// Short-circuit reads need Unix domain sockets that can pass file descriptors,
+// and shared memory, so on other platforms the datanodes are always read from
+// over the network.
+
+type shmSegment struct{}
+
+type shmSlot struct {
+	segment *shmSegment
+}
+
+func (s *shmSlot) valid() bool            { return false }
+func (s *shmSegment) markBroken()         {}
+func (s *shmSegment) close()              {}
+func requestReleaseSlot(string, *shmSlot) {}
+
+func (sc *ShortCircuit) allocSlot(path string) (*shmSlot, error) {
+	return nil, errShortCircuitUnsupported
+}
+
+func (sc *ShortCircuit) freeSlot(slot *shmSlot) {}
+
+func requestReplicaFiles(path string, block *hdfs.LocatedBlockProto, slot *shmSlot) (*os.File, *os.File, error) {
+	return nil, nil, errShortCircuitUnsupported
+}
@@ -0,0 +1,164 @@
+package rpc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	hdfs "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_hdfs"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeTestReplica writes data and a metadata file with CRC32C checksums for
+// it, in the datanode's on-disk format.
+func writeTestReplica(t *testing.T, data []byte, chunkSize int) (string, string) {
+	dir := t.TempDir()
+	dataPath := filepath.Join(dir, "blk_1")
+	metaPath := filepath.Join(dir, "blk_1_1.meta")
+
+	meta := make([]byte, blockMetadataHeaderLength)
+	binary.BigEndian.PutUint16(meta, 1)
+	meta[2] = byte(hdfs.ChecksumTypeProto_CHECKSUM_CRC32C)
+	binary.BigEndian.PutUint32(meta[3:], uint32(chunkSize))
+
+	tab := crc32.MakeTable(crc32.Castagnoli)
+	for off := 0; off < len(data); off += chunkSize {
+		end := off + chunkSize
+		if end > len(data) {
+			end = len(data)
+		}
+
+		meta = binary.BigEndian.AppendUint32(meta, crc32.Checksum(data[off:end], tab))
+	}
+
+	require.NoError(t, os.WriteFile(dataPath, data, 0644))
+	require.NoError(t, os.WriteFile(metaPath, meta, 0644))
+	return dataPath, metaPath
+}
+
+func openTestReplica(t *testing.T, dataPath, metaPath string, skipChecksum bool) (*localReplica, error) {
+	data, err := os.Open(dataPath)
+	require.NoError(t, err)
+	meta, err := os.Open(metaPath)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		data.Close()
+		meta.Close()
+	})
+
+	return newLocalReplica(data, meta, skipChecksum)
+}
+
+func TestLocalReplicaRead(t *testing.T) {
+	data := make([]byte, 10000)
+	rand.New(rand.NewSource(1)).Read(data)
+	dataPath, metaPath := writeTestReplica(t, data, 512)
+
+	replica, err := openTestReplica(t, dataPath, metaPath, false)
+	require.NoError(t, err)
+	assert.Equal(t, 512, replica.chunkSize)
+
+	for _, tc := range []struct{ off, length int }{
+		{0, 512},
+		{0, 10000},
+		{100, 1000},
+		{511, 2},
+		{9990, 10},
+	} {
+		b := make([]byte, tc.length)
+		n, err := replica.readAt(b, int64(tc.off))
+		require.NoError(t, err)
+		assert.Equal(t, tc.length, n)
+		assert.Equal(t, data[tc.off:tc.off+tc.length], b)
+	}
+
+	// The replica is shorter than requested.
+	n, err := replica.readAt(make([]byte, 20), 9990)
+	assert.Equal(t, 10, n)
+	assert.Equal(t, io.ErrUnexpectedEOF, err)
+}
+
+func TestLocalReplicaCorrupt(t *testing.T) {
+	data := bytes.Repeat([]byte("foo"), 1000)
+	dataPath, metaPath := writeTestReplica(t, data, 512)
+
+	corrupt := append([]byte{}, data...)
+	corrupt[1000] ^= 0xff
+	require.NoError(t, os.WriteFile(dataPath, corrupt, 0644))
+
+	replica, err := openTestReplica(t, dataPath, metaPath, false)
+	require.NoError(t, err)
+
+	// Chunks before the corrupt one are fine.
+	b := make([]byte, 512)
+	_, err = replica.readAt(b, 0)
+	require.NoError(t, err)
+
+	_, err = replica.readAt(b, 900)
+	assert.Equal(t, errInvalidChecksum, err)
+
+	// Unless checksums are skipped.
+	replica, err = openTestReplica(t, dataPath, metaPath, true)
+	require.NoError(t, err)
+
+	_, err = replica.readAt(b, 900)
+	require.NoError(t, err)
+	assert.Equal(t, corrupt[900:1412], b)
+}
+
+func TestLocalReplicaBadHeader(t *testing.T) {
+	dataPath, metaPath := writeTestReplica(t, []byte("foo"), 512)
+	require.NoError(t, os.WriteFile(metaPath, []byte{0, 2, 2, 0, 0, 2, 0}, 0644))
+
+	_, err := openTestReplica(t, dataPath, metaPath, false)
+	assert.Error(t, err)
+}
+
+func TestShortCircuitSocketPath(t *testing.T) {
+	sc := &ShortCircuit{SocketPath: "/var/run/hdfs/dn._PORT"}
+	dn := &hdfs.DatanodeIDProto{XferPort: proto.Uint32(9866)}
+	assert.Equal(t, "/var/run/hdfs/dn.9866", sc.socketPath(dn))
+}
+
+func TestShortCircuitRemoteDatanodes(t *testing.T) {
+	sc := &ShortCircuit{SocketPath: filepath.Join(t.TempDir(), "dn_socket")}
+	replica, _ := sc.open(testBlock("192.0.2.1", "192.0.2.2"), false)
+	assert.Nil(t, replica)
+
+	// A local datanode without a socket disables short-circuit reads for a
+	// while.
+	replica, _ = sc.open(testBlock("127.0.0.1"), false)
+	assert.Nil(t, replica)
+	assert.False(t, sc.usable(sc.SocketPath))
+}
+
+func TestReadUnbufferedPrefixedMessage(t *testing.T) {
+	msg := &hdfs.BlockOpResponseProto{
+		Status:  hdfs.Status_SUCCESS.Enum(),
+		Message: proto.String("foo"),
+	}
+
+	b, err := makePrefixedMessage(msg)
+	require.NoError(t, err)
+
+	r := bytes.NewReader(append(b, 1))
+	resp := &hdfs.BlockOpResponseProto{}
+	require.NoError(t, readUnbufferedPrefixedMessage(r, resp))
+	assert.Equal(t, "foo", resp.GetMessage())
+
+	// The byte after the message, which would carry the file descriptors,
+	// hasn't been read.
+	assert.Equal(t, 1, r.Len())
+
+	r = bytes.NewReader([]byte{0xff, 0xff, 0xff, 0x7f})
+	err = readUnbufferedPrefixedMessage(r, resp)
+	assert.Equal(t, lengthError{0xfffffff, maxDataTransferMessageLength}, err)
+}
@@ -0,0 +1,402 @@
+//go:build unix
+
+package rpc
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sync/atomic"
+	"syscall"
+	"time"
+	"unsafe"
+
+	hdfs "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_hdfs"
+	"github.com/golang/protobuf/proto"
+)
+
+const (
+	// shmSlotSize is the size of each slot in a shared memory segment. The
+	// first 8 bytes of each slot hold its flags.
+	shmSlotSize = 64
+	// shmSlotValid is the flag that the datanode clears when the replica
+	// using the slot is no longer valid.
+	shmSlotValid = 1 << 63
+)
+
+// shmSegment is a segment of memory shared with a datanode, split into
+// slots. The domain socket it was requested over stays open as long as the
+// segment is in use; when it's closed, the datanode frees the segment.
+type shmSegment struct {
+	id     *hdfs.ShortCircuitShmIdProto
+	conn   *net.UnixConn
+	mem    []byte
+	used   []bool
+	inUse  int
+	broken atomic.Bool
+}
+
+// shmSlot is a slot in a shmSegment, assigned to one replica.
+type shmSlot struct {
+	segment *shmSegment
+	index   int
+	flags   *uint64
+}
+
+func (s *shmSlot) valid() bool {
+	return !s.segment.broken.Load() && atomic.LoadUint64(s.flags)&shmSlotValid != 0
+}
+
+func (s *shmSlot) proto() *hdfs.ShortCircuitShmSlotProto {
+	return &hdfs.ShortCircuitShmSlotProto{
+		ShmId:   s.segment.id,
+		SlotIdx: proto.Int32(int32(s.index)),
+	}
+}
+
+func (s *shmSegment) markBroken() {
+	s.broken.Store(true)
+}
+
+// close closes the segment's socket, and unmaps the memory if none of the
+// slots are in use. It must be called with the ShortCircuit's lock held.
+func (s *shmSegment) close() {
+	s.markBroken()
+	s.conn.Close()
+	if s.inUse == 0 && s.mem != nil {
+		syscall.Munmap(s.mem)
+		s.mem = nil
+	}
+}
+
+// watch waits for the datanode to close the segment's socket, which it does if
+// it shuts down, and then marks the segment broken.
+func (s *shmSegment) watch() {
+	io.Copy(io.Discard, s.conn)
+	s.markBroken()
+	s.conn.Close()
+}
+
+// allocSlot assigns a slot for a replica, requesting a new segment from the
+// datanode if none of the existing ones have space.
+func (sc *ShortCircuit) allocSlot(path string) (*shmSlot, error) {
+	sc.lock.Lock()
+	segments := sc.segments[path][:0]
+	var slot *shmSlot
+	for _, segment := range sc.segments[path] {
+		if segment.broken.Load() && segment.inUse == 0 {
+			segment.close()
+			continue
+		}
+
+		if slot == nil {
+			slot = segment.alloc()
+		}
+
+		segments = append(segments, segment)
+	}
+
+	if sc.segments != nil {
+		sc.segments[path] = segments
+	}
+
+	sc.lock.Unlock()
+	if slot != nil {
+		return slot, nil
+	}
+
+	segment, err := requestSegment(path, sc.ClientName)
+	if err != nil {
+		return nil, err
+	}
+
+	sc.lock.Lock()
+	defer sc.lock.Unlock()
+
+	if sc.closed {
+		segment.close()
+		return nil, errors.New("short-circuit reads are closed")
+	}
+
+	if sc.segments == nil {
+		sc.segments = make(map[string][]*shmSegment)
+	}
+
+	sc.segments[path] = append(sc.segments[path], segment)
+	return segment.alloc(), nil
+}
+
+// alloc returns a free slot in the segment, marked valid, or nil if there
+// aren't any. It must be called with the ShortCircuit's lock held.
+func (s *shmSegment) alloc() *shmSlot {
+	if s.broken.Load() {
+		return nil
+	}
+
+	for i, used := range s.used {
+		if !used {
+			s.used[i] = true
+			s.inUse++
+			slot := &shmSlot{
+				segment: s,
+				index:   i,
+				flags:   (*uint64)(unsafe.Pointer(&s.mem[i*shmSlotSize])),
+			}
+
+			atomic.StoreUint64(slot.flags, shmSlotValid)
+			return slot
+		}
+	}
+
+	return nil
+}
+
+// freeSlot returns the slot to its segment. Once a broken segment has no slots
+// in use, it's closed and dropped.
+func (sc *ShortCircuit) freeSlot(slot *shmSlot) {
+	sc.lock.Lock()
+	defer sc.lock.Unlock()
+
+	segment := slot.segment
+	atomic.StoreUint64(slot.flags, 0)
+	segment.used[slot.index] = false
+	segment.inUse--
+	if segment.inUse > 0 || !segment.broken.Load() {
+		return
+	}
+
+	segment.close()
+	for path, segments := range sc.segments {
+		for i, s := range segments {
+			if s == segment {
+				sc.segments[path] = append(segments[:i], segments[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// A request for a shared memory segment:
+// +-----------------------------------------------------------+
+// |  Data Transfer Protocol Version, int16                    |
+// +-----------------------------------------------------------+
+// |  Op code, 1 byte (REQUEST_SHORT_CIRCUIT_SHM = 0x59)       |
+// +-----------------------------------------------------------+
+// |  varint length + ShortCircuitShmRequestProto              |
+// +-----------------------------------------------------------+
+//
+// The datanode responds with a ShortCircuitShmResponseProto, followed by a
+// single byte carrying the file descriptor of the segment.
+func requestSegment(path, clientName string) (*shmSegment, error) {
+	conn, err := dialDomainSocket(path)
+	if err != nil {
+		return nil, err
+	}
+
+	segment, err := readSegment(conn, clientName)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	conn.SetDeadline(time.Time{})
+	go segment.watch()
+	return segment, nil
+}
+
+func readSegment(conn *net.UnixConn, clientName string) (*shmSegment, error) {
+	req := &hdfs.ShortCircuitShmRequestProto{ClientName: proto.String(clientName)}
+	err := writeBlockOpRequest(conn, requestShortCircuitShmOp, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &hdfs.ShortCircuitShmResponseProto{}
+	err = readUnbufferedPrefixedMessage(conn, resp)
+	if err != nil {
+		return nil, err
+	} else if resp.GetStatus() != hdfs.Status_SUCCESS {
+		return nil, fmt.Errorf("requesting shared memory: %s: %s", resp.GetStatus(), resp.GetError())
+	}
+
+	files, err := receiveFiles(conn, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	shm := files[0]
+	defer shm.Close()
+
+	info, err := shm.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	size := int(info.Size()) - int(info.Size())%shmSlotSize
+	if size <= 0 {
+		return nil, fmt.Errorf("shared memory segment is too small: %d bytes", info.Size())
+	}
+
+	mem, err := syscall.Mmap(int(shm.Fd()), 0, size, syscall.PROT_READ|syscall.PROT_WRITE, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, os.NewSyscallError("mmap", err)
+	}
+
+	return &shmSegment{
+		id:   resp.GetId(),
+		conn: conn,
+		mem:  mem,
+		used: make([]bool, size/shmSlotSize),
+	}, nil
+}
+
+// A request for a replica's file descriptors:
+// +-----------------------------------------------------------+
+// |  Data Transfer Protocol Version, int16                    |
+// +-----------------------------------------------------------+
+// |  Op code, 1 byte (REQUEST_SHORT_CIRCUIT_FDS = 0x57)       |
+// +-----------------------------------------------------------+
+// |  varint length + OpRequestShortCircuitAccessProto         |
+// +-----------------------------------------------------------+
+//
+// The datanode responds with a BlockOpResponseProto, followed by a single byte
+// carrying the file descriptors of the data and metadata files. If that byte
+// asks for it, we confirm that we received them by sending a zero byte back.
+func requestReplicaFiles(path string, block *hdfs.LocatedBlockProto, slot *shmSlot) (*os.File, *os.File, error) {
+	conn, err := dialDomainSocket(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer conn.Close()
+
+	req := &hdfs.OpRequestShortCircuitAccessProto{
+		Header: &hdfs.BaseHeaderProto{
+			Block: block.GetB(),
+			Token: block.GetBlockToken(),
+		},
+		MaxVersion:                  proto.Uint32(shortCircuitMaxVersion),
+		SupportsReceiptVerification: proto.Bool(true),
+	}
+
+	if slot != nil {
+		req.SlotId = slot.proto()
+	}
+
+	err = writeBlockOpRequest(conn, requestShortCircuitFdsOp, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp := &hdfs.BlockOpResponseProto{}
+	err = readUnbufferedPrefixedMessage(conn, resp)
+	if err != nil {
+		return nil, nil, err
+	} else if resp.GetStatus() != hdfs.Status_SUCCESS {
+		return nil, nil, newDatanodeError("request short-circuit access", resp)
+	}
+
+	files, receipt, err := receiveFilesWithByte(conn, 2)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if receipt == byte(hdfs.ShortCircuitFdResponse_USE_RECEIPT_VERIFICATION) {
+		_, err = conn.Write([]byte{0})
+		if err != nil {
+			files[0].Close()
+			files[1].Close()
+			return nil, nil, err
+		}
+	}
+
+	return files[0], files[1], nil
+}
+
+// A request to release a slot:
+// +-----------------------------------------------------------+
+// |  Data Transfer Protocol Version, int16                    |
+// +-----------------------------------------------------------+
+// |  Op code, 1 byte (RELEASE_SHORT_CIRCUIT_FDS = 0x58)       |
+// +-----------------------------------------------------------+
+// |  varint length + ReleaseShortCircuitAccessRequestProto    |
+// +-----------------------------------------------------------+
+//
+// Errors are ignored; if the datanode doesn't hear about it, it frees the
+// slot once the segment is closed.
+func requestReleaseSlot(path string, slot *shmSlot) {
+	if slot.segment.broken.Load() {
+		return
+	}
+
+	conn, err := dialDomainSocket(path)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	req := &hdfs.ReleaseShortCircuitAccessRequestProto{SlotId: slot.proto()}
+	err = writeBlockOpRequest(conn, releaseShortCircuitFdsOp, req)
+	if err != nil {
+		return
+	}
+
+	resp := &hdfs.ReleaseShortCircuitAccessResponseProto{}
+	readUnbufferedPrefixedMessage(conn, resp)
+}
+
+func dialDomainSocket(path string) (*net.UnixConn, error) {
+	conn, err := net.DialTimeout("unix", path, shortCircuitTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	unixConn := conn.(*net.UnixConn)
+	unixConn.SetDeadline(time.Now().Add(shortCircuitTimeout))
+	return unixConn, nil
+}
+
+func receiveFiles(conn *net.UnixConn, n int) ([]*os.File, error) {
+	files, _, err := receiveFilesWithByte(conn, n)
+	return files, err
+}
+
+// receiveFilesWithByte reads a single byte from the socket, along with the n
+// file descriptors attached to it.
+func receiveFilesWithByte(conn *net.UnixConn, n int) ([]*os.File, byte, error) {
+	b := make([]byte, 1)
+	oob := make([]byte, syscall.CmsgSpace(n*4))
+	_, oobn, _, _, err := conn.ReadMsgUnix(b, oob)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	msgs, err := syscall.ParseSocketControlMessage(oob[:oobn])
+	if err != nil {
+		return nil, 0, os.NewSyscallError("recvmsg", err)
+	}
+
+	var fds []int
+	for _, msg := range msgs {
+		rights, err := syscall.ParseUnixRights(&msg)
+		if err == nil {
+			fds = append(fds, rights...)
+		}
+	}
+
+	if len(fds) != n {
+		for _, fd := range fds {
+			syscall.Close(fd)
+		}
+
+		return nil, 0, fmt.Errorf("expected %d file descriptors from the datanode, got %d", n, len(fds))
+	}
+
+	files := make([]*os.File, n)
+	for i, fd := range fds {
+		files[i] = os.NewFile(uintptr(fd), conn.RemoteAddr().String())
+	}
+
+	return files, b[0], nil
+}
@@ -0,0 +1,246 @@
+//go:build unix
+
+package rpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	hdfs "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_hdfs"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// shortCircuitDatanode is a fake datanode that serves short-circuit requests
+// for a single replica over a domain socket.
+type shortCircuitDatanode struct {
+	t                  *testing.T
+	dataPath, metaPath string
+	shm                *os.File
+	released           chan int32
+
+	lock  sync.Mutex
+	slots []int32
+}
+
+func newShortCircuitDatanode(t *testing.T, socketPath, dataPath, metaPath string) *shortCircuitDatanode {
+	shm, err := os.CreateTemp(t.TempDir(), "shm")
+	require.NoError(t, err)
+	require.NoError(t, shm.Truncate(4096))
+
+	l, err := net.ListenUnix("unix", &net.UnixAddr{Name: socketPath, Net: "unix"})
+	require.NoError(t, err)
+
+	dn := &shortCircuitDatanode{
+		t:        t,
+		dataPath: dataPath,
+		metaPath: metaPath,
+		shm:      shm,
+		released: make(chan int32, 10),
+	}
+
+	t.Cleanup(func() {
+		l.Close()
+		shm.Close()
+	})
+
+	go func() {
+		for {
+			conn, err := l.AcceptUnix()
+			if err != nil {
+				return
+			}
+
+			go dn.handle(conn)
+		}
+	}()
+
+	return dn
+}
+
+func (dn *shortCircuitDatanode) handle(conn *net.UnixConn) {
+	defer conn.Close()
+
+	header := make([]byte, 3)
+	_, err := io.ReadFull(conn, header)
+	if err != nil {
+		return
+	}
+
+	switch header[2] {
+	case requestShortCircuitShmOp:
+		req := &hdfs.ShortCircuitShmRequestProto{}
+		require.NoError(dn.t, readUnbufferedPrefixedMessage(conn, req))
+		dn.respond(conn, &hdfs.ShortCircuitShmResponseProto{
+			Status: hdfs.Status_SUCCESS.Enum(),
+			Id:     &hdfs.ShortCircuitShmIdProto{Hi: proto.Int64(1), Lo: proto.Int64(2)},
+		})
+
+		dn.sendFiles(conn, 0, dn.shm)
+
+		// The segment is in use until the client closes the socket.
+		io.Copy(io.Discard, conn)
+	case requestShortCircuitFdsOp:
+		req := &hdfs.OpRequestShortCircuitAccessProto{}
+		require.NoError(dn.t, readUnbufferedPrefixedMessage(conn, req))
+		require.NotNil(dn.t, req.GetSlotId())
+
+		dn.lock.Lock()
+		dn.slots = append(dn.slots, req.GetSlotId().GetSlotIdx())
+		dn.lock.Unlock()
+
+		dn.respond(conn, &hdfs.BlockOpResponseProto{Status: hdfs.Status_SUCCESS.Enum()})
+		data, err := os.Open(dn.dataPath)
+		require.NoError(dn.t, err)
+		defer data.Close()
+		meta, err := os.Open(dn.metaPath)
+		require.NoError(dn.t, err)
+		defer meta.Close()
+
+		dn.sendFiles(conn, byte(hdfs.ShortCircuitFdResponse_USE_RECEIPT_VERIFICATION), data, meta)
+
+		receipt := make([]byte, 1)
+		_, err = io.ReadFull(conn, receipt)
+		assert.NoError(dn.t, err)
+	case releaseShortCircuitFdsOp:
+		req := &hdfs.ReleaseShortCircuitAccessRequestProto{}
+		require.NoError(dn.t, readUnbufferedPrefixedMessage(conn, req))
+		dn.respond(conn, &hdfs.ReleaseShortCircuitAccessResponseProto{Status: hdfs.Status_SUCCESS.Enum()})
+		dn.released <- req.GetSlotId().GetSlotIdx()
+	}
+}
+
+func (dn *shortCircuitDatanode) respond(conn *net.UnixConn, msg proto.Message) {
+	b, err := makePrefixedMessage(msg)
+	require.NoError(dn.t, err)
+	_, err = conn.Write(b)
+	require.NoError(dn.t, err)
+}
+
+func (dn *shortCircuitDatanode) sendFiles(conn *net.UnixConn, b byte, files ...*os.File) {
+	fds := make([]int, len(files))
+	for i, f := range files {
+		fds[i] = int(f.Fd())
+	}
+
+	_, _, err := conn.WriteMsgUnix([]byte{b}, syscall.UnixRights(fds...), nil)
+	require.NoError(dn.t, err)
+}
+
+func (dn *shortCircuitDatanode) requestedSlots() []int32 {
+	dn.lock.Lock()
+	defer dn.lock.Unlock()
+
+	return append([]int32{}, dn.slots...)
+}
+
+// invalidate clears the valid flag of a slot, like the datanode does when a
+// replica is deleted.
+func (dn *shortCircuitDatanode) invalidate(slot int32) {
+	_, err := dn.shm.WriteAt(make([]byte, 8), int64(slot)*shmSlotSize)
+	require.NoError(dn.t, err)
+}
+
+func setupShortCircuit(t *testing.T, data []byte) (*shortCircuitDatanode, *ShortCircuit, *hdfs.LocatedBlockProto) {
+	dataPath, metaPath := writeTestReplica(t, data, 512)
+	dir := t.TempDir()
+	dn := newShortCircuitDatanode(t, filepath.Join(dir, "dn.9866"), dataPath, metaPath)
+
+	sc := &ShortCircuit{SocketPath: filepath.Join(dir, "dn._PORT"), ClientName: "test"}
+	t.Cleanup(func() { sc.Close() })
+
+	block := testBlock("127.0.0.1")
+	block.B.NumBytes = proto.Uint64(uint64(len(data)))
+	return dn, sc, block
+}
+
+func TestShortCircuitRead(t *testing.T) {
+	data := make([]byte, 3*1024*1024+100)
+	rand.New(rand.NewSource(1)).Read(data)
+	dn, sc, block := setupShortCircuit(t, data)
+
+	br := &BlockReader{
+		Block:        block,
+		ShortCircuit: sc,
+		DialFunc: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			t.Errorf("unexpected connection to %s", addr)
+			return nil, errors.New("unexpected connection")
+		},
+	}
+
+	read, err := io.ReadAll(br)
+	require.NoError(t, err)
+	assert.Equal(t, data, read)
+	assert.Equal(t, []int32{0}, dn.requestedSlots())
+
+	br.Close()
+	select {
+	case slot := <-dn.released:
+		assert.Equal(t, int32(0), slot)
+	case <-time.After(5 * time.Second):
+		t.Fatal("slot wasn't released")
+	}
+}
+
+func TestShortCircuitEmptyRead(t *testing.T) {
+	data := make([]byte, 10000)
+	rand.New(rand.NewSource(1)).Read(data)
+	_, sc, block := setupShortCircuit(t, data)
+
+	br := &BlockReader{
+		Block:        block,
+		ShortCircuit: sc,
+		DialFunc: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			t.Errorf("unexpected connection to %s", addr)
+			return nil, errors.New("unexpected connection")
+		},
+	}
+	defer br.Close()
+
+	n, err := br.Read(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, n)
+
+	read, err := io.ReadAll(br)
+	require.NoError(t, err)
+	assert.Equal(t, data, read)
+}
+
+func TestShortCircuitInvalidated(t *testing.T) {
+	data := make([]byte, 10000)
+	rand.New(rand.NewSource(1)).Read(data)
+	dn, sc, block := setupShortCircuit(t, data)
+
+	var dialed bool
+	br := &BlockReader{
+		Block:        block,
+		ShortCircuit: sc,
+		DialFunc: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			dialed = true
+			return nil, errors.New("connection refused")
+		},
+	}
+	defer br.Close()
+
+	b := make([]byte, 100)
+	_, err := io.ReadFull(br, b)
+	require.NoError(t, err)
+	assert.Equal(t, data[:100], b)
+	assert.False(t, dialed)
+
+	// Once the datanode invalidates the replica, reads go over the network.
+	dn.invalidate(dn.requestedSlots()[0])
+	_, err = br.Read(b)
+	assert.Error(t, err)
+	assert.True(t, dialed)
+}
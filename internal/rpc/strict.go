@@ -0,0 +1,70 @@
+package rpc
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+)
+
+// These bound the lengths read from length prefixes in strict mode, so that a
+// corrupt prefix can't cause a huge allocation.
+const (
+	// maxRPCResponseLength is the same as the default for Hadoop's
+	// ipc.maximum.response.length.
+	maxRPCResponseLength = 128 * 1024 * 1024
+	// maxDataTransferMessageLength applies to op responses and acks, which are
+	// always small.
+	maxDataTransferMessageLength = 1024 * 1024
+	// maxPacketHeaderLength and maxPacketDataLength apply to packets read from
+	// datanodes. Hadoop limits whole packets to 16MB.
+	maxPacketHeaderLength = 4096
+	maxPacketDataLength   = 16 * 1024 * 1024
+)
+
+// A ProtocolError is returned in strict mode when a message from the namenode
+// or a datanode is malformed, truncated, or contains values that don't make
+// sense.
+type ProtocolError struct {
+	// Addr is the address of the namenode or datanode.
+	Addr string
+	// Message describes the message, like "packet header".
+	Message string
+	// Err describes what was wrong with it.
+	Err error
+}
+
+func (e *ProtocolError) Error() string {
+	return fmt.Sprintf("hdfs: invalid %s from %s: %s", e.Message, e.Addr, e.Err)
+}
+
+func (e *ProtocolError) Unwrap() error {
+	return e.Err
+}
+
+// lengthError is returned when a length prefix exceeds the maximum.
+type lengthError struct {
+	length uint64
+	max    uint64
+}
+
+func (e lengthError) Error() string {
+	return fmt.Sprintf("length prefix of %d bytes exceeds the maximum of %d", e.length, e.max)
+}
+
+// protocolError wraps err in a ProtocolError. Errors from the connection
+// itself, and io.EOF (meaning the connection was closed cleanly between
+// messages), are returned as they are.
+func protocolError(addr, message string, err error) error {
+	var netErr net.Error
+	var protoErr *ProtocolError
+	if err == nil || err == io.EOF || errors.As(err, &netErr) || errors.As(err, &protoErr) {
+		return err
+	}
+
+	if err == io.ErrUnexpectedEOF {
+		err = fmt.Errorf("message truncated: %w", err)
+	}
+
+	return &ProtocolError{Addr: addr, Message: message, Err: err}
+}
@@ -0,0 +1,176 @@
+package rpc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"io"
+	"net"
+	"testing"
+
+	hadoop "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_common"
+	hdfs "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_hdfs"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeTestPacket writes a read packet with the given header fields. If
+// payloadLength is nonzero, it's used in place of the correct one.
+func writeTestPacket(buf *bytes.Buffer, seqno, offset int64, data []byte, payloadLength uint32) {
+	header, _ := proto.Marshal(&hdfs.PacketHeaderProto{
+		OffsetInBlock:     proto.Int64(offset),
+		Seqno:             proto.Int64(seqno),
+		LastPacketInBlock: proto.Bool(len(data) == 0),
+		DataLen:           proto.Int32(int32(len(data))),
+	})
+
+	var checksums []byte
+	for i := 0; i < len(data); i += 512 {
+		end := i + 512
+		if end > len(data) {
+			end = len(data)
+		}
+
+		checksums = binary.BigEndian.AppendUint32(checksums, crc32.ChecksumIEEE(data[i:end]))
+	}
+
+	if payloadLength == 0 {
+		payloadLength = uint32(4 + len(checksums) + len(data))
+	}
+
+	binary.Write(buf, binary.BigEndian, payloadLength)
+	binary.Write(buf, binary.BigEndian, uint16(len(header)))
+	buf.Write(header)
+	buf.Write(checksums)
+	buf.Write(data)
+}
+
+func readStrict(buf *bytes.Buffer) ([]byte, error) {
+	s := newBlockReadStream(buf, 512, crc32.IEEETable)
+	s.strict = true
+	s.addr = "datanode:9866"
+
+	// Read less than a chunk at a time, so the buffer size doesn't matter.
+	var out []byte
+	b := make([]byte, 100)
+	for {
+		n, err := s.Read(b)
+		out = append(out, b[:n]...)
+		if err == io.EOF {
+			return out, nil
+		} else if err != nil {
+			return out, err
+		}
+	}
+}
+
+func TestStrictReadStream(t *testing.T) {
+	var buf bytes.Buffer
+	writeTestPacket(&buf, 0, 0, []byte("foo"), 0)
+	writeTestPacket(&buf, 1, 3, []byte("bar"), 0)
+	writeTestPacket(&buf, 2, 6, nil, 0)
+
+	b, err := readStrict(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, "foobar", string(b))
+}
+
+func TestStrictReadStreamInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		write func(buf *bytes.Buffer)
+	}{
+		{"payload length", func(buf *bytes.Buffer) {
+			writeTestPacket(buf, 0, 0, []byte("foo"), 1<<30)
+		}},
+		{"seqno", func(buf *bytes.Buffer) {
+			writeTestPacket(buf, 0, 0, []byte("foo"), 0)
+			writeTestPacket(buf, 2, 3, []byte("bar"), 0)
+		}},
+		{"offset", func(buf *bytes.Buffer) {
+			writeTestPacket(buf, 0, 0, []byte("foo"), 0)
+			writeTestPacket(buf, 1, 4, []byte("bar"), 0)
+		}},
+		{"truncated", func(buf *bytes.Buffer) {
+			writeTestPacket(buf, 0, 0, []byte("foo"), 0)
+			buf.Truncate(10)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			tt.write(&buf)
+
+			_, err := readStrict(&buf)
+			var protoErr *ProtocolError
+			require.True(t, errors.As(err, &protoErr), "expected a ProtocolError, got %v", err)
+			assert.Equal(t, "datanode:9866", protoErr.Addr)
+			assert.Equal(t, "packet header", protoErr.Message)
+		})
+	}
+}
+
+func TestStrictLengthLimit(t *testing.T) {
+	var buf bytes.Buffer
+	binary.Write(&buf, binary.BigEndian, uint32(1<<31))
+
+	_, err := readRPCPacketMax(&buf, maxRPCResponseLength, &hadoop.RpcResponseHeaderProto{})
+	assert.Equal(t, lengthError{1 << 31, maxRPCResponseLength}, err)
+
+	buf.Reset()
+	b := binary.AppendUvarint(nil, 1<<30)
+	buf.Write(b)
+
+	err = readPrefixedMessageMax(&buf, maxDataTransferMessageLength, &hdfs.PipelineAckProto{})
+	assert.Equal(t, lengthError{1 << 30, maxDataTransferMessageLength}, err)
+}
+
+func TestStrictNamenodeResponse(t *testing.T) {
+	c := &NamenodeConnection{ClientID: []byte("client"), currentRequestID: 5}
+
+	rrh := &hadoop.RpcResponseHeaderProto{
+		CallId:   proto.Uint32(5),
+		Status:   hadoop.RpcResponseHeaderProto_SUCCESS.Enum(),
+		ClientId: []byte("client"),
+	}
+	assert.NoError(t, c.validateResponse(rrh, 2))
+	assert.Error(t, c.validateResponse(rrh, 1))
+
+	rrh.ClientId = []byte("other")
+	assert.Error(t, c.validateResponse(rrh, 2))
+
+	rrh.ClientId = nil
+	rrh.CallId = proto.Uint32(4)
+	assert.Error(t, c.validateResponse(rrh, 2))
+
+	rrh.CallId = proto.Uint32(5)
+	rrh.Status = hadoop.RpcResponseHeaderProto_ERROR.Enum()
+	assert.Error(t, c.validateResponse(rrh, 1))
+
+	rrh.ExceptionClassName = proto.String("java.io.IOException")
+	assert.NoError(t, c.validateResponse(rrh, 1))
+}
+
+func TestStrictAcks(t *testing.T) {
+	client, server := net.Pipe()
+	go fakeDatanode(server, hdfs.Status_SUCCESS)
+
+	// The fake datanode only sends one reply with each ack, but there are two
+	// datanodes in the pipeline.
+	bws := newBlockWriteStream(client, 0, outboundChunkSize, outboundPacketSize)
+	bws.strict = true
+	bws.pipeline = []string{"dn1:9866", "dn2:9866"}
+
+	_, err := bws.Write(make([]byte, 10))
+	require.NoError(t, err)
+	require.NoError(t, bws.flush(true))
+
+	err = bws.waitForAcks()
+	var protoErr *ProtocolError
+	require.True(t, errors.As(err, &protoErr), "expected a ProtocolError, got %v", err)
+	assert.Equal(t, "dn1:9866", protoErr.Addr)
+	assert.Equal(t, "pipeline ack", protoErr.Message)
+}
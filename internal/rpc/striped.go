@@ -0,0 +1,127 @@
+package rpc
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	hdfs "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_hdfs"
+	"github.com/golang/protobuf/proto"
+)
+
+// stripedLayout describes how the data in a striped (erasure-coded) block
+// group is laid out. The data is split into cells of cellSize bytes, which are
+// written in round-robin order to the dataUnits internal data blocks; each
+// stripe of dataUnits cells also has parityUnits cells of parity, in the
+// remaining internal blocks.
+type stripedLayout struct {
+	codec       *rsCodec
+	cellSize    int64
+	dataUnits   int
+	parityUnits int
+}
+
+func newStripedLayout(policy *hdfs.ErasureCodingPolicyProto) (stripedLayout, error) {
+	schema := policy.GetSchema()
+	if codec := strings.ToLower(schema.GetCodecName()); codec != "rs" {
+		return stripedLayout{}, fmt.Errorf("unsupported erasure coding codec: %s", schema.GetCodecName())
+	}
+
+	dataUnits := int(schema.GetDataUnits())
+	parityUnits := int(schema.GetParityUnits())
+	codec, err := newRSCodec(dataUnits, parityUnits)
+	if err != nil {
+		return stripedLayout{}, err
+	} else if policy.GetCellSize() == 0 {
+		return stripedLayout{}, errors.New("invalid erasure coding policy: zero cell size")
+	}
+
+	return stripedLayout{
+		codec:       codec,
+		cellSize:    int64(policy.GetCellSize()),
+		dataUnits:   dataUnits,
+		parityUnits: parityUnits,
+	}, nil
+}
+
+func (l stripedLayout) numUnits() int {
+	return l.dataUnits + l.parityUnits
+}
+
+func (l stripedLayout) stripeSize() int64 {
+	return l.cellSize * int64(l.dataUnits)
+}
+
+// internalBlockLength returns the length of the internal block with the given
+// index, in a block group with size bytes of data, like
+// StripedBlockUtil.getInternalBlockLength.
+func (l stripedLayout) internalBlockLength(size int64, index int) int64 {
+	stripeSize := l.stripeSize()
+	lastStripe := size % stripeSize
+	if lastStripe == 0 {
+		return size / int64(l.dataUnits)
+	}
+
+	numStripes := (size-1)/stripeSize + 1
+	return (numStripes-1)*l.cellSize + l.cellLength(lastStripe, index)
+}
+
+// cellLength returns the length of the cell with the given index in a stripe
+// containing stripeLength bytes of data. Parity cells are always as long as
+// the first data cell.
+func (l stripedLayout) cellLength(stripeLength int64, index int) int64 {
+	if index < l.dataUnits {
+		stripeLength -= int64(index) * l.cellSize
+	}
+
+	if stripeLength < 0 {
+		return 0
+	} else if stripeLength > l.cellSize {
+		return l.cellSize
+	}
+
+	return stripeLength
+}
+
+// internalBlocks splits a block group into its internal blocks, by index. Each
+// location of the group holds the internal block with the corresponding index;
+// the same internal block may be on more than one datanode. An internal block
+// with no locations is nil.
+func (l stripedLayout) internalBlocks(group *hdfs.LocatedBlockProto) []*hdfs.LocatedBlockProto {
+	blocks := make([]*hdfs.LocatedBlockProto, l.numUnits())
+	indices := group.GetBlockIndices()
+	tokens := group.GetBlockTokens()
+	gb := group.GetB()
+	for i, loc := range group.GetLocs() {
+		if i >= len(indices) || int(indices[i]) >= len(blocks) {
+			continue
+		}
+
+		index := int(indices[i])
+		if blocks[index] != nil {
+			blocks[index].Locs = append(blocks[index].Locs, loc)
+			continue
+		}
+
+		token := group.GetBlockToken()
+		if i < len(tokens) {
+			token = tokens[i]
+		}
+
+		length := l.internalBlockLength(int64(gb.GetNumBytes()), index)
+		blocks[index] = &hdfs.LocatedBlockProto{
+			B: &hdfs.ExtendedBlockProto{
+				PoolId:          gb.PoolId,
+				BlockId:         proto.Uint64(gb.GetBlockId() + uint64(index)),
+				GenerationStamp: gb.GenerationStamp,
+				NumBytes:        proto.Uint64(uint64(length)),
+			},
+			Offset:     group.Offset,
+			Locs:       []*hdfs.DatanodeInfoProto{loc},
+			Corrupt:    group.Corrupt,
+			BlockToken: token,
+		}
+	}
+
+	return blocks
+}
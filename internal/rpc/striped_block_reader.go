@@ -0,0 +1,248 @@
+package rpc
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	hdfs "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_hdfs"
+)
+
+// stripedBlockReader reads a striped (erasure-coded) block group, a stripe at
+// a time. The data cells are read from their datanodes in parallel, and if any
+// of those fail, the parity cells are read too and the missing data is
+// reconstructed.
+type stripedBlockReader struct {
+	stripedLayout
+	br *BlockReader
+
+	blocks  []*hdfs.LocatedBlockProto
+	readers []*BlockReader
+	failed  []error
+
+	stripe       []byte
+	stripeOffset int64
+
+	// held is the memory acquired from br.Memory for the current stripe.
+	held int64
+}
+
+func newStripedBlockReader(br *BlockReader) (*stripedBlockReader, error) {
+	layout, err := newStripedLayout(br.ECPolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	return &stripedBlockReader{
+		stripedLayout: layout,
+		br:            br,
+		blocks:        layout.internalBlocks(br.Block),
+		readers:       make([]*BlockReader, layout.numUnits()),
+		failed:        make([]error, layout.numUnits()),
+	}, nil
+}
+
+// Read implements io.Reader, starting at the offset of the BlockReader.
+func (sr *stripedBlockReader) Read(b []byte) (int, error) {
+	off := sr.br.Offset
+	if off < sr.stripeOffset || off >= sr.stripeOffset+int64(len(sr.stripe)) {
+		err := sr.readStripe(off / sr.stripeSize())
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	n := copy(b, sr.stripe[off-sr.stripeOffset:])
+	return n, nil
+}
+
+// readStripe reads and, if necessary, reconstructs the data in the given
+// stripe.
+func (sr *stripedBlockReader) readStripe(stripe int64) error {
+	stripeOffset := stripe * sr.stripeSize()
+	stripeLength := int64(sr.br.Block.GetB().GetNumBytes()) - stripeOffset
+	if stripeLength > sr.stripeSize() {
+		stripeLength = sr.stripeSize()
+	}
+
+	// All the cells are padded with zeroes to the length of the first one, for
+	// the purposes of decoding.
+	shardLength := sr.cellLength(stripeLength, 0)
+	sr.releaseStripe()
+	sr.held = shardLength*int64(sr.numUnits()) + stripeLength
+	sr.br.Memory.AcquireOwned(sr.br.MemoryOwned, sr.held)
+
+	shards := make([][]byte, sr.dataUnits+sr.parityUnits)
+	available := make([]bool, len(shards))
+	for i := range shards {
+		shards[i] = make([]byte, shardLength)
+	}
+
+	// Cells past the end of the data are all zeroes, and are never stored.
+	var wanted []int
+	for i := 0; i < sr.dataUnits; i++ {
+		if sr.cellLength(stripeLength, i) == 0 {
+			available[i] = true
+		} else {
+			wanted = append(wanted, i)
+		}
+	}
+
+	nextParity := sr.dataUnits
+	for len(wanted) > 0 {
+		errs := sr.readCells(stripe, stripeLength, wanted, shards)
+
+		missing := 0
+		for i, index := range wanted {
+			if errs[i] == nil {
+				available[index] = true
+			} else {
+				sr.failed[index] = errs[i]
+				missing++
+			}
+		}
+
+		// Read one parity cell for each cell we couldn't read.
+		wanted = wanted[:0]
+		for ; missing > 0 && nextParity < len(shards); nextParity++ {
+			if sr.failed[nextParity] == nil {
+				wanted = append(wanted, nextParity)
+				missing--
+			}
+		}
+
+		if missing > 0 {
+			return sr.unavailableError()
+		}
+	}
+
+	err := sr.codec.reconstruct(shards, available)
+	if err != nil {
+		return err
+	}
+
+	buf := make([]byte, 0, stripeLength)
+	for i := 0; i < sr.dataUnits; i++ {
+		buf = append(buf, shards[i][:sr.cellLength(stripeLength, i)]...)
+	}
+
+	sr.stripe = buf
+	sr.stripeOffset = stripeOffset
+	return nil
+}
+
+// readCells reads the given cells of a stripe in parallel, each into the
+// corresponding shard, and returns the error for each.
+func (sr *stripedBlockReader) readCells(stripe, stripeLength int64, indices []int, shards [][]byte) []error {
+	errs := make([]error, len(indices))
+
+	var wg sync.WaitGroup
+	for i, index := range indices {
+		wg.Add(1)
+		go func(i, index int) {
+			defer wg.Done()
+			length := sr.cellLength(stripeLength, index)
+			errs[i] = sr.readCell(index, stripe*sr.cellSize, shards[index][:length])
+		}(i, index)
+	}
+
+	wg.Wait()
+	return errs
+}
+
+// readCell reads b from the internal block with the given index, at the given
+// offset.
+func (sr *stripedBlockReader) readCell(index int, offset int64, b []byte) error {
+	if sr.failed[index] != nil {
+		return sr.failed[index]
+	}
+
+	block := sr.blocks[index]
+	if block == nil {
+		return fmt.Errorf("no locations for internal block %d", index)
+	}
+
+	// The internal blocks are usually read sequentially, one cell per stripe,
+	// but we may have skipped some (parity) cells.
+	r := sr.readers[index]
+	if r != nil && r.Offset != offset {
+		r.Close()
+		r = nil
+	}
+
+	if r == nil {
+		r = &BlockReader{
+			ClientName:          sr.br.ClientName,
+			Block:               block,
+			Offset:              offset,
+			UseDatanodeHostname: sr.br.UseDatanodeHostname,
+			SkipChecksum:        sr.br.SkipChecksum,
+			DialFunc:            sr.br.DialFunc,
+			ConnectTimeout:      sr.br.ConnectTimeout,
+			CircuitBreaker:      sr.br.CircuitBreaker,
+			Stats:               sr.br.Stats,
+			WireLog:             sr.br.WireLog,
+			Strict:              sr.br.Strict,
+		}
+
+		r.SetDeadline(sr.br.deadline)
+		sr.readers[index] = r
+	}
+
+	_, err := io.ReadFull(r, b)
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+
+	if err != nil {
+		r.Close()
+		sr.readers[index] = nil
+	}
+
+	return err
+}
+
+func (sr *stripedBlockReader) unavailableError() error {
+	var lastErr error
+	numFailed := 0
+	for _, err := range sr.failed {
+		if err != nil {
+			lastErr = err
+			numFailed++
+		}
+	}
+
+	return fmt.Errorf("couldn't read striped block group (%d of %d internal blocks unavailable): %s",
+		numFailed, len(sr.failed), lastErr)
+}
+
+func (sr *stripedBlockReader) setDeadline(t time.Time) error {
+	for _, r := range sr.readers {
+		if r != nil {
+			r.SetDeadline(t)
+		}
+	}
+
+	return nil
+}
+
+// releaseStripe drops the current stripe, and gives back the memory held for
+// it.
+func (sr *stripedBlockReader) releaseStripe() {
+	sr.br.Memory.ReleaseOwned(sr.br.MemoryOwned, sr.held)
+	sr.held = 0
+	sr.stripe = nil
+	sr.stripeOffset = 0
+}
+
+func (sr *stripedBlockReader) Close() error {
+	sr.releaseStripe()
+	for _, r := range sr.readers {
+		if r != nil {
+			r.Close()
+		}
+	}
+
+	return nil
+}
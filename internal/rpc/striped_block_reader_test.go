@@ -0,0 +1,334 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	hdfs "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_hdfs"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testCellSize    = 64
+	testDataUnits   = 3
+	testParityUnits = 2
+)
+
+// fakeReadDatanode serves a single READ_BLOCK request on conn, with the
+// internal block data in blocks, keyed by block ID.
+func fakeReadDatanode(conn net.Conn, blocks map[uint64][]byte) {
+	defer conn.Close()
+
+	header := make([]byte, 3)
+	_, err := io.ReadFull(conn, header)
+	if err != nil {
+		return
+	}
+
+	op := &hdfs.OpReadBlockProto{}
+	err = readPrefixedMessage(conn, op)
+	if err != nil {
+		return
+	}
+
+	data := blocks[op.GetHeader().GetBaseHeader().GetBlock().GetBlockId()]
+	start, end := op.GetOffset(), op.GetOffset()+op.GetLen()
+	resp := &hdfs.BlockOpResponseProto{Status: hdfs.Status_SUCCESS.Enum()}
+	if end > uint64(len(data)) {
+		resp.Status = hdfs.Status_ERROR.Enum()
+	} else {
+		resp.ReadOpChecksumInfo = &hdfs.ReadOpChecksumInfoProto{
+			Checksum: &hdfs.ChecksumProto{
+				Type:             hdfs.ChecksumTypeProto_CHECKSUM_NULL.Enum(),
+				BytesPerChecksum: proto.Uint32(512),
+			},
+			ChunkOffset: proto.Uint64(start),
+		}
+	}
+
+	b, _ := makePrefixedMessage(resp)
+	_, err = conn.Write(b)
+	if err != nil || resp.GetStatus() != hdfs.Status_SUCCESS {
+		return
+	}
+
+	writeFakePacket(conn, start, 0, data[start:end], false)
+	writeFakePacket(conn, end, 1, nil, true)
+}
+
+func writeFakePacket(w io.Writer, offset uint64, seqno int64, data []byte, last bool) error {
+	header, _ := proto.Marshal(&hdfs.PacketHeaderProto{
+		OffsetInBlock:     proto.Int64(int64(offset)),
+		Seqno:             proto.Int64(seqno),
+		LastPacketInBlock: proto.Bool(last),
+		DataLen:           proto.Int32(int32(len(data))),
+	})
+
+	lengths := make([]byte, 6)
+	binary.BigEndian.PutUint32(lengths, uint32(len(data)+4))
+	binary.BigEndian.PutUint16(lengths[4:], uint16(len(header)))
+
+	_, err := w.Write(append(append(lengths, header...), data...))
+	return err
+}
+
+type testBlockGroup struct {
+	data   []byte
+	blocks map[uint64][]byte
+	group  *hdfs.LocatedBlockProto
+	policy *hdfs.ErasureCodingPolicyProto
+
+	mut    sync.Mutex
+	dialed map[int]int
+	down   map[int]bool
+}
+
+// newTestBlockGroup stripes size bytes of random data across internal blocks,
+// the same way a datanode would.
+func newTestBlockGroup(t *testing.T, host string, size int) *testBlockGroup {
+	rs, err := newRSCodec(testDataUnits, testParityUnits)
+	require.NoError(t, err)
+
+	data := make([]byte, size)
+	rand.Read(data)
+
+	internal := make([][]byte, testDataUnits+testParityUnits)
+	stripeSize := testCellSize * testDataUnits
+	for off := 0; off < size; off += stripeSize {
+		stripe := data[off:]
+		if len(stripe) > stripeSize {
+			stripe = stripe[:stripeSize]
+		}
+
+		shardLength := len(stripe)
+		if shardLength > testCellSize {
+			shardLength = testCellSize
+		}
+
+		shards := make([][]byte, len(internal))
+		for i := range shards {
+			shards[i] = make([]byte, shardLength)
+			if i < testDataUnits && i*testCellSize < len(stripe) {
+				cell := stripe[i*testCellSize:]
+				if len(cell) > testCellSize {
+					cell = cell[:testCellSize]
+				}
+
+				copy(shards[i], cell)
+				internal[i] = append(internal[i], cell...)
+			}
+		}
+
+		rs.encode(shards)
+		for i := testDataUnits; i < len(shards); i++ {
+			internal[i] = append(internal[i], shards[i]...)
+		}
+	}
+
+	bg := &testBlockGroup{
+		data:   data,
+		blocks: make(map[uint64][]byte),
+		dialed: make(map[int]int),
+		down:   make(map[int]bool),
+		policy: &hdfs.ErasureCodingPolicyProto{
+			Name: proto.String("RS-3-2-64"),
+			Schema: &hdfs.ECSchemaProto{
+				CodecName:   proto.String("rs"),
+				DataUnits:   proto.Uint32(testDataUnits),
+				ParityUnits: proto.Uint32(testParityUnits),
+			},
+			CellSize: proto.Uint32(testCellSize),
+			Id:       proto.Uint32(1),
+		},
+	}
+
+	group := testBlock()
+	group.B.BlockId = proto.Uint64(1000)
+	group.B.NumBytes = proto.Uint64(uint64(size))
+	for i, block := range internal {
+		if len(block) == 0 {
+			continue
+		}
+
+		bg.blocks[uint64(1000+i)] = block
+		group.BlockIndices = append(group.BlockIndices, byte(i))
+		group.Locs = append(group.Locs, &hdfs.DatanodeInfoProto{
+			Id: &hdfs.DatanodeIDProto{
+				IpAddr:       proto.String(fmt.Sprintf("%s.%d", host, i)),
+				HostName:     proto.String(fmt.Sprintf("%s.%d", host, i)),
+				DatanodeUuid: proto.String(fmt.Sprintf("%s.%d", host, i)),
+				XferPort:     proto.Uint32(9866),
+			},
+		})
+	}
+
+	bg.group = group
+	return bg
+}
+
+func (bg *testBlockGroup) dial(ctx context.Context, network, addr string) (net.Conn, error) {
+	host := strings.TrimSuffix(addr, ":9866")
+	index, _ := strconv.Atoi(host[strings.LastIndex(host, ".")+1:])
+
+	bg.mut.Lock()
+	defer bg.mut.Unlock()
+
+	bg.dialed[index]++
+	if bg.down[index] {
+		return nil, errors.New("connection refused")
+	}
+
+	client, server := net.Pipe()
+	go fakeReadDatanode(server, bg.blocks)
+	return client, nil
+}
+
+func (bg *testBlockGroup) reader(offset int64) *BlockReader {
+	return &BlockReader{
+		Block:    bg.group,
+		Offset:   offset,
+		DialFunc: bg.dial,
+		ECPolicy: bg.policy,
+	}
+}
+
+func TestStripedBlockReader(t *testing.T) {
+	bg := newTestBlockGroup(t, "10.2.0", testCellSize*testDataUnits*3+100)
+
+	br := bg.reader(0)
+	defer br.Close()
+
+	b, err := ioutil.ReadAll(br)
+	require.NoError(t, err)
+	assert.Equal(t, bg.data, b)
+
+	// The parity blocks shouldn't be read unless they're needed.
+	assert.Equal(t, 0, bg.dialed[3])
+	assert.Equal(t, 0, bg.dialed[4])
+}
+
+func TestStripedBlockReaderOffset(t *testing.T) {
+	bg := newTestBlockGroup(t, "10.2.1", testCellSize*testDataUnits*2+10)
+
+	for _, off := range []int64{1, 70, testCellSize * testDataUnits, int64(len(bg.data) - 1)} {
+		br := bg.reader(off)
+		b, err := ioutil.ReadAll(br)
+		br.Close()
+
+		require.NoError(t, err)
+		assert.Equal(t, bg.data[off:], b, "offset %d", off)
+	}
+}
+
+func TestStripedBlockReaderReconstruct(t *testing.T) {
+	bg := newTestBlockGroup(t, "10.2.2", testCellSize*testDataUnits*3+100)
+	bg.down[0] = true
+	bg.down[2] = true
+
+	br := bg.reader(0)
+	defer br.Close()
+
+	b, err := ioutil.ReadAll(br)
+	require.NoError(t, err)
+	assert.Equal(t, bg.data, b)
+}
+
+func TestStripedBlockReaderReconstructParityDown(t *testing.T) {
+	bg := newTestBlockGroup(t, "10.2.3", testCellSize*testDataUnits*2)
+	bg.down[1] = true
+	bg.down[3] = true
+
+	br := bg.reader(0)
+	defer br.Close()
+
+	b, err := ioutil.ReadAll(br)
+	require.NoError(t, err)
+	assert.Equal(t, bg.data, b)
+}
+
+func TestStripedBlockReaderTooManyFailures(t *testing.T) {
+	bg := newTestBlockGroup(t, "10.2.4", testCellSize*testDataUnits*2)
+	bg.down[0] = true
+	bg.down[1] = true
+	bg.down[4] = true
+
+	br := bg.reader(0)
+	defer br.Close()
+
+	_, err := ioutil.ReadAll(br)
+	assert.Error(t, err)
+}
+
+func TestStripedBlockReaderSmallFile(t *testing.T) {
+	// A file smaller than one cell only has one data block, and the parity.
+	bg := newTestBlockGroup(t, "10.2.5", 10)
+	bg.down[0] = true
+	require.Len(t, bg.group.GetLocs(), 1+testParityUnits)
+
+	br := bg.reader(0)
+	defer br.Close()
+
+	b, err := ioutil.ReadAll(br)
+	require.NoError(t, err)
+	assert.Equal(t, bg.data, b)
+}
+
+func TestStripedBlockReaderMemoryOwnedConcurrent(t *testing.T) {
+	bg := newTestBlockGroup(t, "10.2.4", testCellSize*testDataUnits*3+100)
+
+	// Like FileReader.WriteTo, each reader holds a buffer of its own while it
+	// reads stripes. There's room for both buffers, but not for a stripe on
+	// top of them.
+	bufSize := int64(100)
+	m := NewMemoryLimiter(2 * bufSize)
+
+	var holding sync.WaitGroup
+	holding.Add(2)
+	errs := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			var owned int64
+			m.AcquireOwned(&owned, bufSize)
+			defer m.ReleaseOwned(&owned, bufSize)
+			holding.Done()
+			holding.Wait()
+
+			br := bg.reader(0)
+			br.Memory = m
+			br.MemoryOwned = &owned
+			defer br.Close()
+
+			b, err := ioutil.ReadAll(br)
+			if err == nil && !bytes.Equal(bg.data, b) {
+				err = errors.New("wrong data")
+			}
+
+			errs <- err
+		}()
+	}
+
+	for i := 0; i < 2; i++ {
+		select {
+		case err := <-errs:
+			require.NoError(t, err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("readers deadlocked waiting for memory")
+		}
+	}
+
+	assert.EqualValues(t, 0, m.Stats().InUse)
+}
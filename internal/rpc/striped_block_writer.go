@@ -0,0 +1,264 @@
+package rpc
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// stripedBlockWriter writes a striped (erasure-coded) block group. Data is
+// buffered a stripe at a time; once a stripe is full (or the block group is
+// closed), the parity is computed, and the cells are written to the internal
+// blocks in parallel, each to a single datanode.
+//
+// Up to parityUnits of the internal blocks can fail (or have no datanode
+// allocated to them) before the write fails. The namenode reconstructs the
+// missing ones later.
+type stripedBlockWriter struct {
+	stripedLayout
+	bw *BlockWriter
+
+	writers []*BlockWriter
+	failed  []error
+
+	stripe       []byte
+	stripeLength int
+	parity       [][]byte
+}
+
+func newStripedBlockWriter(bw *BlockWriter) (*stripedBlockWriter, error) {
+	layout, err := newStripedLayout(bw.ECPolicy)
+	if err != nil {
+		return nil, err
+	} else if bw.BlockSize%layout.cellSize != 0 {
+		return nil, fmt.Errorf("block size (%d) must be a multiple of the cell size (%d)",
+			bw.BlockSize, layout.cellSize)
+	}
+
+	sw := &stripedBlockWriter{
+		stripedLayout: layout,
+		bw:            bw,
+		writers:       make([]*BlockWriter, layout.numUnits()),
+		failed:        make([]error, layout.numUnits()),
+		stripe:        make([]byte, layout.stripeSize()),
+		parity:        make([][]byte, layout.parityUnits),
+	}
+
+	for i := range sw.parity {
+		sw.parity[i] = make([]byte, layout.cellSize)
+	}
+
+	for i, block := range layout.internalBlocks(bw.Block) {
+		if block == nil {
+			sw.failed[i] = fmt.Errorf("no datanode allocated for internal block %d", i)
+			continue
+		}
+
+		sw.writers[i] = &BlockWriter{
+			ClientName:          bw.ClientName,
+			Block:               block,
+			BlockSize:           bw.BlockSize,
+			BytesPerChecksum:    bw.BytesPerChecksum,
+			ChecksumType:        bw.ChecksumType,
+			WritePacketSize:     bw.WritePacketSize,
+			MaxPacketsInFlight:  bw.MaxPacketsInFlight,
+			SyncBlock:           bw.SyncBlock,
+			UseDatanodeHostname: bw.UseDatanodeHostname,
+			DialFunc:            bw.DialFunc,
+			CircuitBreaker:      bw.CircuitBreaker,
+			HeartbeatInterval:   bw.HeartbeatInterval,
+			Hook:                bw.Hook,
+			WireLog:             bw.WireLog,
+			Strict:              bw.Strict,
+			Memory:              bw.Memory,
+		}
+
+		sw.writers[i].SetDeadline(bw.deadline)
+	}
+
+	return sw, sw.checkFailures()
+}
+
+// Write implements io.Writer. The BlockWriter limits writes to the size of the
+// block group. If writing out a stripe fails, the part of b in that stripe
+// isn't counted as written.
+func (sw *stripedBlockWriter) Write(b []byte) (int, error) {
+	var n int
+	for n < len(b) {
+		copied := copy(sw.stripe[sw.stripeLength:], b[n:])
+		sw.stripeLength += copied
+
+		if sw.stripeLength == len(sw.stripe) {
+			err := sw.writeStripe()
+			if err != nil {
+				return n, err
+			}
+		}
+
+		n += copied
+	}
+
+	return n, nil
+}
+
+// writeStripe encodes the buffered stripe, which may be partial if it's the
+// last one in the block group, and writes out the cells.
+func (sw *stripedBlockWriter) writeStripe() error {
+	length := int64(sw.stripeLength)
+	sw.stripeLength = 0
+	if length == 0 {
+		return nil
+	}
+
+	// The data in a partial stripe is padded with zeroes to the length of the
+	// first cell, for the purposes of encoding.
+	for i := length; i < int64(len(sw.stripe)); i++ {
+		sw.stripe[i] = 0
+	}
+
+	shardLength := sw.cellLength(length, 0)
+	shards := make([][]byte, sw.numUnits())
+	for i := 0; i < sw.dataUnits; i++ {
+		start := int64(i) * sw.cellSize
+		shards[i] = sw.stripe[start : start+shardLength]
+	}
+
+	for i := range sw.parity {
+		shards[sw.dataUnits+i] = sw.parity[i][:shardLength]
+	}
+
+	sw.codec.encode(shards)
+
+	var wg sync.WaitGroup
+	for i, w := range sw.writers {
+		cellLength := sw.cellLength(length, i)
+		if sw.failed[i] != nil || cellLength == 0 {
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, w *BlockWriter, cell []byte) {
+			defer wg.Done()
+			_, err := w.Write(cell)
+			if err != nil && err != ErrEndOfBlock {
+				w.Close()
+				sw.failed[i] = err
+			}
+		}(i, w, shards[i][:cellLength])
+	}
+
+	wg.Wait()
+	return sw.checkFailures()
+}
+
+// sync syncs the internal blocks in parallel. The buffered, partial stripe, if
+// there is one, isn't written out, since the parity can't be computed until
+// the stripe is full.
+func (sw *stripedBlockWriter) sync() error {
+	var wg sync.WaitGroup
+	for i, w := range sw.writers {
+		if sw.failed[i] != nil {
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, w *BlockWriter) {
+			defer wg.Done()
+			err := w.Sync()
+			if err != nil {
+				w.Close()
+				sw.failed[i] = err
+			}
+		}(i, w)
+	}
+
+	wg.Wait()
+	return sw.checkFailures()
+}
+
+// checkFailures returns an error if too many internal blocks have failed for
+// the block group to be reconstructed.
+func (sw *stripedBlockWriter) checkFailures() error {
+	var lastErr error
+	numFailed := 0
+	for _, err := range sw.failed {
+		if err != nil {
+			lastErr = err
+			numFailed++
+		}
+	}
+
+	if numFailed > sw.parityUnits {
+		return fmt.Errorf("couldn't write striped block group (%d of %d internal blocks failed): %s",
+			numFailed, len(sw.failed), lastErr)
+	}
+
+	return nil
+}
+
+// ackedOffset returns the offset in the block group up to which every full
+// stripe has been acknowledged by the datanodes.
+func (sw *stripedBlockWriter) ackedOffset() int64 {
+	acked := sw.bw.Offset - int64(sw.stripeLength)
+	for i, w := range sw.writers {
+		if sw.failed[i] != nil {
+			continue
+		}
+
+		if internalAcked := w.AckedOffset(); internalAcked < w.Offset {
+			stripes := internalAcked / sw.cellSize
+			if bound := stripes * sw.stripeSize(); bound < acked {
+				acked = bound
+			}
+		}
+	}
+
+	return acked
+}
+
+func (sw *stripedBlockWriter) setDeadline(t time.Time) error {
+	for _, w := range sw.writers {
+		if w != nil {
+			w.SetDeadline(t)
+		}
+	}
+
+	return nil
+}
+
+// Close writes out the last, partial stripe, if there is one, and then closes
+// the internal blocks in parallel.
+func (sw *stripedBlockWriter) Close() error {
+	err := sw.writeStripe()
+	if err != nil {
+		sw.closeWriters()
+		return err
+	}
+
+	var wg sync.WaitGroup
+	for i, w := range sw.writers {
+		if sw.failed[i] != nil {
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, w *BlockWriter) {
+			defer wg.Done()
+			err := w.Close()
+			if err != nil {
+				sw.failed[i] = err
+			}
+		}(i, w)
+	}
+
+	wg.Wait()
+	return sw.checkFailures()
+}
+
+func (sw *stripedBlockWriter) closeWriters() {
+	for i, w := range sw.writers {
+		if w != nil && sw.failed[i] == nil {
+			w.Close()
+		}
+	}
+}
@@ -0,0 +1,257 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net"
+	"sync"
+	"testing"
+
+	hdfs "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_hdfs"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testWriteCluster records the internal blocks written by a BlockWriter to
+// fake datanodes.
+type testWriteCluster struct {
+	mut    sync.Mutex
+	wg     sync.WaitGroup
+	blocks map[uint64][]byte
+	down   map[string]bool
+}
+
+func (wc *testWriteCluster) dial(ctx context.Context, network, addr string) (net.Conn, error) {
+	wc.mut.Lock()
+	defer wc.mut.Unlock()
+
+	if wc.down[addr] {
+		return nil, errors.New("connection refused")
+	}
+
+	client, server := net.Pipe()
+	wc.wg.Add(1)
+	go wc.serve(server)
+	return client, nil
+}
+
+func (wc *testWriteCluster) serve(conn net.Conn) {
+	defer wc.wg.Done()
+
+	header := make([]byte, 3)
+	_, err := io.ReadFull(conn, header)
+	if err != nil {
+		conn.Close()
+		return
+	}
+
+	op := &hdfs.OpWriteBlockProto{}
+	err = readPrefixedMessage(conn, op)
+	if err != nil {
+		conn.Close()
+		return
+	}
+
+	b, _ := makePrefixedMessage(&hdfs.BlockOpResponseProto{Status: hdfs.Status_SUCCESS.Enum()})
+	_, err = conn.Write(b)
+	if err != nil {
+		conn.Close()
+		return
+	}
+
+	packets := make(chan outboundPacket, 100)
+	go recordingDatanode(conn, hdfs.Status_SUCCESS, packets)
+
+	var data []byte
+	for p := range packets {
+		data = append(data, p.data...)
+	}
+
+	wc.mut.Lock()
+	wc.blocks[op.GetHeader().GetBaseHeader().GetBlock().GetBlockId()] = data
+	wc.mut.Unlock()
+}
+
+func testWriteGroup(host string) *hdfs.LocatedBlockProto {
+	group := testBlock()
+	group.B.BlockId = proto.Uint64(2000)
+	group.B.NumBytes = proto.Uint64(0)
+	for i := 0; i < testDataUnits+testParityUnits; i++ {
+		group.BlockIndices = append(group.BlockIndices, byte(i))
+		group.Locs = append(group.Locs, &hdfs.DatanodeInfoProto{
+			Id: &hdfs.DatanodeIDProto{
+				IpAddr:       proto.String(fmt.Sprintf("%s.%d", host, i)),
+				HostName:     proto.String(fmt.Sprintf("%s.%d", host, i)),
+				DatanodeUuid: proto.String(fmt.Sprintf("%s.%d", host, i)),
+				XferPort:     proto.Uint32(9866),
+			},
+		})
+	}
+
+	return group
+}
+
+// writeStriped writes data to a fake block group, and returns the block group
+// with the internal blocks that were written, ready to be read back.
+func writeStriped(t *testing.T, host string, data []byte, down ...int) (*testBlockGroup, error) {
+	wc := &testWriteCluster{blocks: make(map[uint64][]byte), down: make(map[string]bool)}
+	for _, i := range down {
+		wc.down[fmt.Sprintf("%s.%d:9866", host, i)] = true
+	}
+
+	bg := newTestBlockGroup(t, host, 0)
+	bw := &BlockWriter{
+		Block:     testWriteGroup(host),
+		BlockSize: testCellSize * 4,
+		DialFunc:  wc.dial,
+		ECPolicy:  bg.policy,
+	}
+
+	off := 0
+	for off < len(data) {
+		// Write in odd-sized pieces, so that they don't line up with the cells.
+		end := off + 100
+		if end > len(data) {
+			end = len(data)
+		}
+
+		n, err := bw.Write(data[off:end])
+		off += n
+		if err == ErrEndOfBlock {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+	}
+
+	err := bw.Close()
+	wc.wg.Wait()
+	if err != nil {
+		return nil, err
+	}
+
+	bg.data = data[:bw.Offset]
+	bg.blocks = wc.blocks
+	bg.group = testWriteGroup(host)
+	bg.group.B.NumBytes = proto.Uint64(uint64(bw.Offset))
+	return bg, nil
+}
+
+func TestStripedBlockWriter(t *testing.T) {
+	data := make([]byte, testCellSize*testDataUnits*3+100)
+	rand.Read(data)
+
+	bg, err := writeStriped(t, "10.3.0", data)
+	require.NoError(t, err)
+	assert.Len(t, bg.blocks, testDataUnits+testParityUnits)
+
+	// Read it back with missing data blocks, so that the parity is checked too.
+	bg.down[0] = true
+	bg.down[1] = true
+	br := bg.reader(0)
+	defer br.Close()
+
+	b, err := ioutil.ReadAll(br)
+	require.NoError(t, err)
+	assert.Equal(t, data, b)
+}
+
+func TestStripedBlockWriterEndOfBlock(t *testing.T) {
+	// The block group holds BlockSize times the number of data units.
+	data := make([]byte, testCellSize*4*testDataUnits+10)
+	rand.Read(data)
+
+	bg, err := writeStriped(t, "10.3.1", data)
+	require.NoError(t, err)
+	assert.Len(t, bg.data, testCellSize*4*testDataUnits)
+	for _, block := range bg.blocks {
+		assert.Len(t, block, testCellSize*4)
+	}
+
+	br := bg.reader(0)
+	defer br.Close()
+
+	b, err := ioutil.ReadAll(br)
+	require.NoError(t, err)
+	assert.Equal(t, bg.data, b)
+}
+
+func TestStripedBlockWriterFailures(t *testing.T) {
+	data := make([]byte, testCellSize*testDataUnits*2+10)
+	rand.Read(data)
+
+	bg, err := writeStriped(t, "10.3.2", data, 1, 3)
+	require.NoError(t, err)
+	assert.Len(t, bg.blocks, testDataUnits+testParityUnits-2)
+
+	br := bg.reader(0)
+	defer br.Close()
+
+	b, err := ioutil.ReadAll(br)
+	require.NoError(t, err)
+	assert.Equal(t, data, b)
+
+	_, err = writeStriped(t, "10.3.3", data, 0, 2, 4)
+	assert.Error(t, err)
+}
+
+func TestStripedBlockWriterFailedStripe(t *testing.T) {
+	host := "10.3.4"
+	wc := &testWriteCluster{blocks: make(map[uint64][]byte), down: make(map[string]bool)}
+	for _, i := range []int{0, 2, 4} {
+		wc.down[fmt.Sprintf("%s.%d:9866", host, i)] = true
+	}
+
+	bg := newTestBlockGroup(t, host, 0)
+	bw := &BlockWriter{
+		Block:     testWriteGroup(host),
+		BlockSize: testCellSize * 4,
+		DialFunc:  wc.dial,
+		ECPolicy:  bg.policy,
+	}
+
+	stripe := make([]byte, testCellSize*testDataUnits)
+	n, err := bw.Write(stripe[:100])
+	require.NoError(t, err)
+	assert.Equal(t, 100, n)
+
+	// Writing out the first stripe fails, so none of this counts.
+	n, err = bw.Write(stripe)
+	assert.Error(t, err)
+	assert.Equal(t, 0, n)
+	assert.EqualValues(t, 100, bw.Offset)
+
+	bw.Close()
+	wc.wg.Wait()
+}
+
+func TestStripedBlockWriterSync(t *testing.T) {
+	const host = "10.3.4"
+	wc := &testWriteCluster{blocks: make(map[uint64][]byte), down: make(map[string]bool)}
+	bg := newTestBlockGroup(t, host, 0)
+	bw := &BlockWriter{
+		Block:     testWriteGroup(host),
+		BlockSize: testCellSize * 4,
+		DialFunc:  wc.dial,
+		ECPolicy:  bg.policy,
+	}
+
+	data := make([]byte, testCellSize*testDataUnits*2+100)
+	rand.Read(data)
+	_, err := bw.Write(data)
+	require.NoError(t, err)
+
+	// Only the full stripes are synced, and counted in the length.
+	require.NoError(t, bw.Sync())
+	assert.EqualValues(t, testCellSize*testDataUnits*2, bw.SyncedLength())
+	assert.EqualValues(t, testCellSize*testDataUnits*2, bw.AckedOffset())
+
+	require.NoError(t, bw.Close())
+	wc.wg.Wait()
+	assert.EqualValues(t, len(data), bw.Offset)
+}
@@ -0,0 +1,113 @@
+package rpc
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	hadoop "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_common"
+	"github.com/golang/protobuf/proto"
+)
+
+const tokenStorageMagic = "HDTS"
+
+var errMalformedTokenStorage = errors.New("malformed token storage file")
+
+// ReadTokenStorage parses the tokens from a Hadoop credentials file, like the
+// one named by HADOOP_TOKEN_FILE_LOCATION in YARN containers, or written by
+// 'hdfs fetchdt'. Any secret keys in the file are ignored.
+//
+// The file starts with the magic "HDTS" and a version byte. Version 0 is the
+// Writable format that Hadoop writes by default:
+// +-----------------------------------------------------------+
+// |  vint number of tokens                                    |
+// +-----------------------------------------------------------+
+// |  for each token: vint length + alias, then vint length +  |
+// |  identifier, vint length + password, vint length + kind,  |
+// |  and vint length + service                                |
+// +-----------------------------------------------------------+
+// |  vint number of secret keys, and the keys                 |
+// +-----------------------------------------------------------+
+//
+// Version 1 is a single varint length-prefixed CredentialsProto.
+func ReadTokenStorage(b []byte) ([]*hadoop.TokenProto, error) {
+	if !bytes.HasPrefix(b, []byte(tokenStorageMagic)) || len(b) < len(tokenStorageMagic)+1 {
+		return nil, errMalformedTokenStorage
+	}
+
+	version := b[len(tokenStorageMagic)]
+	r := bytes.NewReader(b[len(tokenStorageMagic)+1:])
+	switch version {
+	case 0:
+		return readWritableTokens(r)
+	case 1:
+		creds := &credentialsProto{}
+		err := readPrefixedMessage(r, creds)
+		if err != nil {
+			return nil, errMalformedTokenStorage
+		}
+
+		var tokens []*hadoop.TokenProto
+		for _, kv := range creds.Tokens {
+			if kv.Token != nil {
+				tokens = append(tokens, kv.Token)
+			}
+		}
+
+		return tokens, nil
+	default:
+		return nil, fmt.Errorf("unsupported token storage version: %d", version)
+	}
+}
+
+func readWritableTokens(r *bytes.Reader) ([]*hadoop.TokenProto, error) {
+	n, err := readWritableVLong(r)
+	if err != nil || n < 0 {
+		return nil, errMalformedTokenStorage
+	}
+
+	tokens := make([]*hadoop.TokenProto, 0, n)
+	for i := int64(0); i < n; i++ {
+		var fields [5][]byte
+		for j := range fields {
+			fields[j], err = readWritableBytes(r)
+			if err != nil {
+				return nil, errMalformedTokenStorage
+			}
+		}
+
+		// The first field is the alias.
+		tokens = append(tokens, &hadoop.TokenProto{
+			Identifier: fields[1],
+			Password:   fields[2],
+			Kind:       proto.String(string(fields[3])),
+			Service:    proto.String(string(fields[4])),
+		})
+	}
+
+	return tokens, nil
+}
+
+// credentialsProto and credentialsKVProto are the CredentialsProto and
+// CredentialsKVProto messages from Security.proto, which were added in Hadoop
+// 3 and aren't in the generated code.
+type credentialsProto struct {
+	Tokens           []*credentialsKVProto `protobuf:"bytes,1,rep,name=tokens" json:"tokens,omitempty"`
+	Secrets          []*credentialsKVProto `protobuf:"bytes,2,rep,name=secrets" json:"secrets,omitempty"`
+	XXX_unrecognized []byte                `json:"-"`
+}
+
+func (m *credentialsProto) Reset()         { *m = credentialsProto{} }
+func (m *credentialsProto) String() string { return proto.CompactTextString(m) }
+func (*credentialsProto) ProtoMessage()    {}
+
+type credentialsKVProto struct {
+	Alias            *string            `protobuf:"bytes,1,req,name=alias" json:"alias,omitempty"`
+	Token            *hadoop.TokenProto `protobuf:"bytes,2,opt,name=token" json:"token,omitempty"`
+	Secret           []byte             `protobuf:"bytes,3,opt,name=secret" json:"secret,omitempty"`
+	XXX_unrecognized []byte             `json:"-"`
+}
+
+func (m *credentialsKVProto) Reset()         { *m = credentialsKVProto{} }
+func (m *credentialsKVProto) String() string { return proto.CompactTextString(m) }
+func (*credentialsKVProto) ProtoMessage()    {}
@@ -0,0 +1,129 @@
+package rpc
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+
+	hadoop "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_common"
+	hdfs "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_hdfs"
+	"github.com/golang/protobuf/proto"
+)
+
+// A Logger receives log messages. *log.Logger implements it.
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// A WireLogger logs the RPCs sent to the namenode and the data transfer
+// operations sent to datanodes, for debugging at the protocol level. A nil
+// *WireLogger is valid, and logs nothing.
+type WireLogger struct {
+	Logger Logger
+	// HexDumpBytes, if positive, is how many bytes of each message to include
+	// as a hex dump.
+	HexDumpBytes int
+}
+
+var opNames = map[uint8]string{
+	writeBlockOp:    "WRITE_BLOCK",
+	readBlockOp:     "READ_BLOCK",
+	replaceBlockOp:  "REPLACE_BLOCK",
+	copyBlockOp:     "COPY_BLOCK",
+	checksumBlockOp: "BLOCK_CHECKSUM",
+	checksumGroupOp: "BLOCK_GROUP_CHECKSUM",
+}
+
+func (wl *WireLogger) logRequest(addr, method string, callID int32, packet []byte) {
+	if wl == nil {
+		return
+	}
+
+	wl.Logger.Printf("hdfs: rpc -> %s call_id=%d method=%s size=%d%s",
+		addr, callID, method, len(packet), wl.dump(packet))
+}
+
+func (wl *WireLogger) logResponse(addr, method string, rrh *hadoop.RpcResponseHeaderProto, packet []byte, err error) {
+	if wl == nil {
+		return
+	}
+
+	status := rrh.GetStatus().String()
+	if err != nil {
+		status = fmt.Sprintf("error (%s)", err)
+	} else if rrh.GetExceptionClassName() != "" {
+		status += " " + rrh.GetExceptionClassName()
+	}
+
+	wl.Logger.Printf("hdfs: rpc <- %s call_id=%d method=%s status=%s size=%d%s",
+		addr, int32(rrh.GetCallId()), method, status, len(packet), wl.dump(packet))
+}
+
+// writeBlockOpRequest is like the writeBlockOpRequest function, but logs the
+// op header.
+func (wl *WireLogger) writeBlockOpRequest(w io.Writer, op uint8, msg proto.Message) error {
+	if wl == nil {
+		return writeBlockOpRequest(w, op, msg)
+	}
+
+	var buf bytes.Buffer
+	err := writeBlockOpRequest(&buf, op, msg)
+	if err != nil {
+		return err
+	}
+
+	wl.Logger.Printf("hdfs: data transfer -> %s op=%s(0x%x) size=%d header={%s}%s",
+		remoteAddr(w), opNames[op], op, buf.Len(), proto.CompactTextString(msg), wl.dump(buf.Bytes()))
+
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+// readBlockOpResponse is like the readBlockOpResponseMax function, but logs the
+// response.
+func (wl *WireLogger) readBlockOpResponse(r io.Reader, max uint64) (*hdfs.BlockOpResponseProto, error) {
+	if wl == nil {
+		return readBlockOpResponseMax(r, max)
+	}
+
+	var buf bytes.Buffer
+	resp, err := readBlockOpResponseMax(io.TeeReader(r, &buf), max)
+
+	status := resp.GetStatus().String()
+	if err != nil {
+		status = fmt.Sprintf("error (%s)", err)
+	} else if resp.GetMessage() != "" {
+		status += fmt.Sprintf(" (%s)", resp.GetMessage())
+	}
+
+	wl.Logger.Printf("hdfs: data transfer <- %s status=%s size=%d%s",
+		remoteAddr(r), status, buf.Len(), wl.dump(buf.Bytes()))
+	return resp, err
+}
+
+// dump returns a hex dump of the first HexDumpBytes of b, on its own lines.
+func (wl *WireLogger) dump(b []byte) string {
+	if wl.HexDumpBytes <= 0 || len(b) == 0 {
+		return ""
+	}
+
+	var truncated string
+	if len(b) > wl.HexDumpBytes {
+		truncated = fmt.Sprintf("... (%d more bytes)\n", len(b)-wl.HexDumpBytes)
+		b = b[:wl.HexDumpBytes]
+	}
+
+	return "\n" + hex.Dump(b) + truncated
+}
+
+// remoteAddr returns the address of the other end of the connection, if rw is
+// a connection.
+func remoteAddr(rw interface{}) string {
+	if conn, ok := rw.(net.Conn); ok && conn.RemoteAddr() != nil {
+		return conn.RemoteAddr().String()
+	}
+
+	return "?"
+}
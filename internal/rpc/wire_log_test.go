@@ -0,0 +1,122 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+
+	hdfs "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_hdfs"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testLogger struct {
+	lock  sync.Mutex
+	lines []string
+}
+
+func (tl *testLogger) Printf(format string, v ...interface{}) {
+	tl.lock.Lock()
+	defer tl.lock.Unlock()
+
+	tl.lines = append(tl.lines, fmt.Sprintf(format, v...))
+}
+
+func (tl *testLogger) matching(substr string) []string {
+	tl.lock.Lock()
+	defer tl.lock.Unlock()
+
+	var lines []string
+	for _, line := range tl.lines {
+		if strings.Contains(line, substr) {
+			lines = append(lines, line)
+		}
+	}
+
+	return lines
+}
+
+func TestWireLogNamenode(t *testing.T) {
+	logger := &testLogger{}
+	c, err := NewNamenodeConnection(NamenodeConnectionOptions{
+		Addresses: []string{"namenode:8020"},
+		User:      "gohdfs1",
+		WireLog:   &WireLogger{Logger: logger},
+		DialFunc: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			client, server := net.Pipe()
+			go fakeNamenode(server, false,
+				&hdfs.GetPreferredBlockSizeRequestProto{},
+				&hdfs.GetPreferredBlockSizeResponseProto{Bsize: proto.Uint64(1024)})
+			return client, nil
+		},
+	})
+	require.NoError(t, err)
+	defer c.Close()
+
+	req := &hdfs.GetPreferredBlockSizeRequestProto{Filename: proto.String("/foo")}
+	err = c.Execute("getPreferredBlockSize", req, &hdfs.GetPreferredBlockSizeResponseProto{})
+	require.NoError(t, err)
+
+	requests := logger.matching("rpc ->")
+	require.Len(t, requests, 1)
+	assert.Contains(t, requests[0], "call_id=1 method=getPreferredBlockSize")
+
+	responses := logger.matching("rpc <-")
+	require.Len(t, responses, 1)
+	assert.Contains(t, responses[0], "call_id=1 method=getPreferredBlockSize status=SUCCESS")
+}
+
+func TestWireLogBlockOp(t *testing.T) {
+	logger := &testLogger{}
+	wl := &WireLogger{Logger: logger, HexDumpBytes: 16}
+
+	var buf bytes.Buffer
+	op := &hdfs.OpReadBlockProto{
+		Header: &hdfs.ClientOperationHeaderProto{
+			BaseHeader: &hdfs.BaseHeaderProto{Block: testBlock().GetB()},
+			ClientName: proto.String("test"),
+		},
+		Offset: proto.Uint64(0),
+		Len:    proto.Uint64(1024),
+	}
+
+	require.NoError(t, wl.writeBlockOpRequest(&buf, readBlockOp, op))
+
+	var expected bytes.Buffer
+	require.NoError(t, writeBlockOpRequest(&expected, readBlockOp, op))
+	assert.Equal(t, expected.Bytes(), buf.Bytes())
+
+	require.Len(t, logger.lines, 1)
+	line := logger.lines[0]
+	assert.Contains(t, line, "op=READ_BLOCK(0x51)")
+	assert.Contains(t, line, fmt.Sprintf("size=%d", expected.Len()))
+	assert.Contains(t, line, `clientName:"test"`)
+	assert.Contains(t, line, "00000000  00 1c 51")
+	assert.Contains(t, line, fmt.Sprintf("... (%d more bytes)", expected.Len()-16))
+
+	resp, _ := makePrefixedMessage(&hdfs.BlockOpResponseProto{
+		Status:  hdfs.Status_ERROR_ACCESS_TOKEN.Enum(),
+		Message: proto.String("denied"),
+	})
+
+	r, err := wl.readBlockOpResponse(bytes.NewReader(resp), 0)
+	require.NoError(t, err)
+	assert.Equal(t, hdfs.Status_ERROR_ACCESS_TOKEN, r.GetStatus())
+
+	require.Len(t, logger.lines, 2)
+	assert.Contains(t, logger.lines[1], "status=ERROR_ACCESS_TOKEN (denied)")
+}
+
+func TestWireLogNil(t *testing.T) {
+	var wl *WireLogger
+	var buf bytes.Buffer
+	op := &hdfs.BlockOpResponseProto{Status: hdfs.Status_SUCCESS.Enum()}
+	require.NoError(t, wl.writeBlockOpRequest(&buf, readBlockOp, op))
+	assert.Equal(t, 6, buf.Len())
+	wl.logRequest("?", "foo", 0, nil)
+}
@@ -0,0 +1,99 @@
+package rpc
+
+import (
+	"time"
+
+	hdfs "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_hdfs"
+)
+
+// WriteEventType identifies what happened in a WriteEvent.
+type WriteEventType int
+
+const (
+	// PipelineBuilt means that the connection to the first datanode was made,
+	// and every datanode in the pipeline accepted the write request.
+	PipelineBuilt WriteEventType = iota
+	// PacketSent means that a data packet was written to the first datanode.
+	PacketSent
+	// AckReceived means that a packet was acknowledged by the whole pipeline.
+	AckReceived
+	// PipelineFailed means that the pipeline couldn't be built, or that a
+	// datanode in it failed. If BlockWriter can, it then recovers the pipeline
+	// without that datanode; otherwise, the write fails.
+	PipelineFailed
+	// HeartbeatFailed means that a heartbeat packet, sent while the writer was
+	// idle, couldn't be written to the first datanode.
+	HeartbeatFailed
+	// PacketResent means that a data packet which wasn't acknowledged before
+	// the pipeline failed was written again to the first datanode of the
+	// recovered pipeline.
+	PacketResent
+)
+
+// A WriteEvent describes something that happened while writing a block.
+type WriteEvent struct {
+	Type WriteEventType
+	// Block is the block being written.
+	Block *hdfs.ExtendedBlockProto
+	// Pipeline is the addresses of the datanodes in the pipeline, in order.
+	Pipeline []string
+	// Seqno, Offset, and Bytes describe the packet, for PacketSent,
+	// AckReceived, and PacketResent.
+	Seqno  int64
+	Offset int64
+	Bytes  int
+	// Latency is how long it took to build the pipeline, for PipelineBuilt, or
+	// the time between sending a packet and receiving the ack, for AckReceived.
+	Latency time.Duration
+	// DownstreamLatency is the part of Latency spent waiting for the datanodes
+	// after the first one, as reported by the first datanode.
+	DownstreamLatency time.Duration
+	// FailedDatanode is the address of the datanode which failed, for
+	// PipelineFailed, if it's known.
+	FailedDatanode string
+	// Err is the error that caused a PipelineFailed or HeartbeatFailed event.
+	Err error
+}
+
+// startEvents sets up the stream to send events to bw.Hook, if it's set. The
+// hook is captured here, so that changing it afterwards only affects the next
+// block.
+func (bw *BlockWriter) startEvents(s *blockWriteStream, pipeline []string) {
+	hook := bw.Hook
+	if hook == nil {
+		return
+	}
+
+	block := bw.Block.GetB()
+	s.events = func(ev WriteEvent) {
+		ev.Block = block
+		ev.Pipeline = pipeline
+		hook(ev)
+	}
+}
+
+// failedEvent sends a PipelineFailed event for err, if bw.Hook is set.
+func (bw *BlockWriter) failedEvent(pipeline []string, failed string, err error) {
+	if bw.Hook == nil {
+		return
+	}
+
+	bw.Hook(WriteEvent{
+		Type:           PipelineFailed,
+		Block:          bw.Block.GetB(),
+		Pipeline:       pipeline,
+		FailedDatanode: failed,
+		Err:            err,
+	})
+}
+
+// pipelineAddresses returns the addresses of the datanodes in the pipeline.
+func (bw *BlockWriter) pipelineAddresses() []string {
+	pipeline := bw.currentPipeline()
+	addrs := make([]string, len(pipeline))
+	for i, dn := range pipeline {
+		addrs[i] = getDatanodeAddress(dn.GetId(), bw.UseDatanodeHostname)
+	}
+
+	return addrs
+}
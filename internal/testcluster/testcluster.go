@@ -0,0 +1,79 @@
+// Package testcluster sets up clients for the tests of the packages built on
+// top of hdfs, against the same cluster and with the same credentials as the
+// tests of the hdfs package itself.
+package testcluster
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/colinmarc/hdfs/v2"
+	"github.com/colinmarc/hdfs/v2/hadoopconf"
+	krb "gopkg.in/jcmturner/gokrb5.v5/client"
+	"gopkg.in/jcmturner/gokrb5.v5/config"
+	"gopkg.in/jcmturner/gokrb5.v5/credentials"
+)
+
+var (
+	lock          sync.Mutex
+	cachedClients = make(map[string]*hdfs.Client)
+)
+
+// Client returns a client for the cluster in the ambient configuration,
+// acting as username. Clients are cached for each user, and shared by all the
+// tests in the package, so they shouldn't be closed.
+func Client(t testing.TB, username string) *hdfs.Client {
+	lock.Lock()
+	defer lock.Unlock()
+
+	if c, ok := cachedClients[username]; ok {
+		return c
+	}
+
+	conf, err := hadoopconf.LoadFromEnvironment()
+	if err != nil || conf == nil {
+		t.Fatal("Couldn't load ambient config", err)
+	}
+
+	options := hdfs.ClientOptionsFromConf(conf)
+	if options.Addresses == nil {
+		t.Fatal("Missing namenode addresses in ambient config")
+	}
+
+	if options.KerberosClient != nil {
+		options.KerberosClient = KerberosClient(t, username)
+	} else {
+		options.User = username
+	}
+
+	client, err := hdfs.NewClient(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cachedClients[username] = client
+	return client
+}
+
+// KerberosClient expects a ccache file for each user mentioned in the tests
+// to live at /tmp/krb5cc_gohdfs_<username>, and krb5.conf to live at
+// /etc/krb5.conf. The test is skipped if either is missing.
+func KerberosClient(t testing.TB, username string) *krb.Client {
+	cfg, err := config.Load("/etc/krb5.conf")
+	if err != nil {
+		t.Skip("Couldn't load krb config:", err)
+	}
+
+	ccache, err := credentials.LoadCCache(fmt.Sprintf("/tmp/krb5cc_gohdfs_%s", username))
+	if err != nil {
+		t.Skipf("Couldn't load keytab for user %s: %s", username, err)
+	}
+
+	client, err := krb.NewClientFromCCache(ccache)
+	if err != nil {
+		t.Fatal("Couldn't initialize krb client:", err)
+	}
+
+	return client.WithConfig(cfg)
+}
@@ -0,0 +1,121 @@
+// Package jmx fetches metrics from the JMX JSON servlet (/jmx) of the web UI
+// of a namenode or datanode, for monitoring integrations that need more than
+// the namenode RPCs provide.
+package jmx
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	krb "gopkg.in/jcmturner/gokrb5.v5/client"
+)
+
+// Client makes requests to the JMX servlet. The zero value is usable for
+// clusters without kerberos.
+type Client struct {
+	// HTTPClient is used to make requests. If nil, http.DefaultClient is used.
+	// For HTTPS, the TLS configuration can be set on its Transport.
+	HTTPClient *http.Client
+	// KerberosClient, if set, is used to authenticate with SPNEGO, for web UIs
+	// with hadoop.http.authentication.type set to kerberos.
+	KerberosClient *krb.Client
+	// KerberosServicePrincipleName is the SPN used for SPNEGO. If empty,
+	// HTTP/<host> is used, with the host from the address of each request.
+	KerberosServicePrincipleName string
+}
+
+// Query returns the beans matching the query from the web UI at address,
+// which can be a URL (for example, "https://nn1:9871") or just a host and
+// port, in which case HTTP is used. The query is a JMX object name pattern,
+// like "Hadoop:service=NameNode,name=FSNamesystem" or "java.lang:type=*". Each
+// bean is returned as the decoded JSON object.
+func (c *Client) Query(address, query string) ([]map[string]interface{}, error) {
+	var beans []map[string]interface{}
+	err := c.query(address, query, &beans)
+	if err != nil {
+		return nil, err
+	}
+
+	return beans, nil
+}
+
+// Get decodes the first bean matching the query into v, which should be a
+// pointer to a struct with fields named after the attributes of the bean (or
+// tagged with them, using encoding/json struct tags). It returns an error if
+// no beans match.
+func (c *Client) Get(address, query string, v interface{}) error {
+	var beans []json.RawMessage
+	err := c.query(address, query, &beans)
+	if err != nil {
+		return err
+	}
+
+	if len(beans) == 0 {
+		return fmt.Errorf("jmx: no beans matching %s", query)
+	}
+
+	return json.Unmarshal(beans[0], v)
+}
+
+func (c *Client) query(address, query string, beans interface{}) error {
+	if !strings.Contains(address, "://") {
+		address = "http://" + address
+	}
+
+	u, err := url.Parse(address)
+	if err != nil {
+		return err
+	}
+
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/jmx"
+	u.RawQuery = url.Values{"qry": {query}}.Encode()
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return err
+	}
+
+	if c.KerberosClient != nil {
+		spn := c.KerberosServicePrincipleName
+		if spn == "" {
+			spn = "HTTP/" + u.Hostname()
+		}
+
+		err = c.KerberosClient.SetSPNEGOHeader(req, spn)
+		if err != nil {
+			return err
+		}
+	}
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("jmx: unexpected status from %s: %s", u.Host, resp.Status)
+	}
+
+	var body struct {
+		Beans json.RawMessage `json:"beans"`
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(&body)
+	if err != nil {
+		return err
+	} else if body.Beans == nil {
+		return errors.New("jmx: response is missing beans")
+	}
+
+	return json.Unmarshal(body.Beans, beans)
+}
@@ -0,0 +1,145 @@
+package jmx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const fsNamesystemJSON = `{
+  "beans" : [ {
+    "name" : "Hadoop:service=NameNode,name=FSNamesystem",
+    "modelerType" : "FSNamesystem",
+    "tag.Context" : "dfs",
+    "tag.HAState" : "active",
+    "MissingBlocks" : 2,
+    "CapacityTotal" : 1000000,
+    "CapacityUsed" : 1000,
+    "CapacityRemaining" : 999000,
+    "FilesTotal" : 42,
+    "BlocksTotal" : 17,
+    "UnderReplicatedBlocks" : 3
+  } ]
+}`
+
+const rpcJSON = `{
+  "beans" : [ {
+    "name" : "Hadoop:service=NameNode,name=RpcActivityForPort9000",
+    "tag.port" : "9000",
+    "ReceivedBytes" : 100,
+    "RpcQueueTimeAvgTime" : 0.5,
+    "NumOpenConnections" : 4
+  }, {
+    "name" : "Hadoop:service=NameNode,name=RpcActivityForPort9001",
+    "tag.port" : "9001",
+    "ReceivedBytes" : 200
+  } ]
+}`
+
+const fsDatasetJSON = `{
+  "beans" : [ {
+    "name" : "Hadoop:service=DataNode,name=FSDatasetState",
+    "Capacity" : 5000,
+    "NumFailedVolumes" : 1,
+    "FailedStorageLocations" : [ "/data/2" ],
+    "EstimatedCapacityLostTotal" : 2500
+  } ]
+}`
+
+func newTestServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/jmx" {
+			http.NotFound(w, r)
+			return
+		}
+
+		switch r.URL.Query().Get("qry") {
+		case "Hadoop:service=NameNode,name=FSNamesystem":
+			w.Write([]byte(fsNamesystemJSON))
+		case "Hadoop:service=*,name=RpcActivityForPort*":
+			w.Write([]byte(rpcJSON))
+		case "Hadoop:service=DataNode,name=FSDatasetState*":
+			w.Write([]byte(fsDatasetJSON))
+		default:
+			w.Write([]byte(`{"beans": []}`))
+		}
+	}))
+}
+
+func TestFSNamesystem(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	c := &Client{}
+	m, err := c.FSNamesystem(server.URL)
+	require.NoError(t, err)
+	assert.Equal(t, FSNamesystem{
+		HAState:               "active",
+		CapacityTotal:         1000000,
+		CapacityUsed:          1000,
+		CapacityRemaining:     999000,
+		FilesTotal:            42,
+		BlocksTotal:           17,
+		MissingBlocks:         2,
+		UnderReplicatedBlocks: 3,
+	}, m)
+}
+
+func TestRPCMetrics(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	c := &Client{}
+	m, err := c.RPCMetrics(server.Listener.Addr().String())
+	require.NoError(t, err)
+	require.Len(t, m, 2)
+	assert.Equal(t, "9000", m[0].Port)
+	assert.EqualValues(t, 100, m[0].ReceivedBytes)
+	assert.Equal(t, 0.5, m[0].RpcQueueTimeAvgTime)
+	assert.EqualValues(t, 4, m[0].NumOpenConnections)
+	assert.Equal(t, "9001", m[1].Port)
+}
+
+func TestFSDatasetState(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	c := &Client{}
+	m, err := c.FSDatasetState(server.URL + "/")
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, m.NumFailedVolumes)
+	assert.Equal(t, []string{"/data/2"}, m.FailedStorageLocations)
+	assert.EqualValues(t, 2500, m.EstimatedCapacityLostTotal)
+}
+
+func TestQuery(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	c := &Client{HTTPClient: server.Client()}
+	beans, err := c.Query(server.URL, "Hadoop:service=NameNode,name=FSNamesystem")
+	require.NoError(t, err)
+	require.Len(t, beans, 1)
+	assert.Equal(t, "FSNamesystem", beans[0]["modelerType"])
+}
+
+func TestGetNoBeans(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	c := &Client{}
+	_, err := c.FSNamesystemState(server.URL)
+	assert.EqualError(t, err, "jmx: no beans matching Hadoop:service=NameNode,name=FSNamesystemState")
+}
+
+func TestQueryBadStatus(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	c := &Client{}
+	_, err := c.Query(server.URL, "java.lang:type=*")
+	assert.Error(t, err)
+}
@@ -0,0 +1,121 @@
+package jmx
+
+// FSNamesystem contains the namespace and block metrics of a namenode, from
+// the Hadoop:service=NameNode,name=FSNamesystem bean. Capacities are in
+// bytes.
+type FSNamesystem struct {
+	// HAState is "active", "standby", or "initializing".
+	HAState string `json:"tag.HAState"`
+
+	CapacityTotal     int64
+	CapacityUsed      int64
+	CapacityRemaining int64
+	FilesTotal        int64
+	BlocksTotal       int64
+	TotalLoad         int64
+
+	MissingBlocks            int64
+	CorruptBlocks            int64
+	UnderReplicatedBlocks    int64
+	PendingReplicationBlocks int64
+	PendingDeletionBlocks    int64
+	ExcessBlocks             int64
+
+	// StaleDataNodes is the number of datanodes that haven't sent a heartbeat
+	// recently.
+	StaleDataNodes int64
+	// LastCheckpointTime is when the namespace was last checkpointed, in
+	// milliseconds since the epoch.
+	LastCheckpointTime int64
+}
+
+// FSNamesystemState contains the state of the datanodes as seen by a
+// namenode, from the Hadoop:service=NameNode,name=FSNamesystemState bean.
+type FSNamesystemState struct {
+	// FSState is "Operational" or "safeMode".
+	FSState string
+
+	NumLiveDataNodes              int64
+	NumDeadDataNodes              int64
+	NumDecommissioningDataNodes   int64
+	NumStaleDataNodes             int64
+	NumInMaintenanceLiveDataNodes int64
+
+	// VolumeFailuresTotal is the number of failed volumes across all the
+	// datanodes, and EstimatedCapacityLostTotal their total capacity, in bytes.
+	VolumeFailuresTotal        int64
+	EstimatedCapacityLostTotal int64
+}
+
+// RPCMetrics contains the metrics of an RPC server, from the
+// Hadoop:service=<service>,name=RpcActivityForPort<port> beans. Times are in
+// milliseconds.
+type RPCMetrics struct {
+	Port string `json:"tag.port"`
+
+	ReceivedBytes int64
+	SentBytes     int64
+
+	RpcQueueTimeNumOps       int64
+	RpcQueueTimeAvgTime      float64
+	RpcProcessingTimeNumOps  int64
+	RpcProcessingTimeAvgTime float64
+
+	CallQueueLength           int64
+	NumOpenConnections        int64
+	RpcAuthenticationFailures int64
+	RpcAuthorizationFailures  int64
+	RpcSlowCalls              int64
+}
+
+// FSDatasetState contains the storage metrics of a datanode, including its
+// failed volumes, from the Hadoop:service=DataNode,name=FSDatasetState bean.
+// Capacities are in bytes.
+type FSDatasetState struct {
+	Capacity  int64
+	DfsUsed   int64
+	Remaining int64
+
+	NumFailedVolumes       int64
+	FailedStorageLocations []string
+	// LastVolumeFailureDate is when a volume last failed, in milliseconds
+	// since the epoch, or zero.
+	LastVolumeFailureDate int64
+	// EstimatedCapacityLostTotal is the capacity of the failed volumes.
+	EstimatedCapacityLostTotal int64
+
+	NumBlocksCached        int64
+	NumBlocksFailedToCache int64
+}
+
+// FSNamesystem returns the FSNamesystem metrics of the namenode at address.
+func (c *Client) FSNamesystem(address string) (FSNamesystem, error) {
+	var m FSNamesystem
+	err := c.Get(address, "Hadoop:service=NameNode,name=FSNamesystem", &m)
+	return m, err
+}
+
+// FSNamesystemState returns the FSNamesystemState metrics of the namenode at
+// address.
+func (c *Client) FSNamesystemState(address string) (FSNamesystemState, error) {
+	var m FSNamesystemState
+	err := c.Get(address, "Hadoop:service=NameNode,name=FSNamesystemState", &m)
+	return m, err
+}
+
+// RPCMetrics returns the metrics of each RPC server (there's one per port) of
+// the namenode or datanode at address.
+func (c *Client) RPCMetrics(address string) ([]RPCMetrics, error) {
+	var m []RPCMetrics
+	err := c.query(address, "Hadoop:service=*,name=RpcActivityForPort*", &m)
+	return m, err
+}
+
+// FSDatasetState returns the storage metrics of the datanode at address. Older
+// versions of Hadoop suffix the name of the bean with the storage ID, which is
+// also matched.
+func (c *Client) FSDatasetState(address string) (FSDatasetState, error) {
+	var m FSDatasetState
+	err := c.Get(address, "Hadoop:service=DataNode,name=FSDatasetState*", &m)
+	return m, err
+}
@@ -0,0 +1,185 @@
+package hdfs
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	hdfs "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_hdfs"
+	krb "gopkg.in/jcmturner/gokrb5.v5/client"
+)
+
+// errNoKeyProvider is returned when reading or writing a file in an
+// encryption zone without ClientOptions.KeyProviderURI set.
+var errNoKeyProvider = errors.New("file is in an encryption zone, but no key provider (KMS) is configured")
+
+// kmsClient talks to the Hadoop KMS over its REST API, to decrypt the
+// encrypted data encryption keys (EDEKs) of files in encryption zones.
+type kmsClient struct {
+	urls           []string
+	httpClient     *http.Client
+	user           string
+	kerberosClient *krb.Client
+}
+
+// newKMSClient returns a kmsClient for ClientOptions.KeyProviderURI, or nil if
+// it's not set.
+func newKMSClient(options ClientOptions, user string) (*kmsClient, error) {
+	if options.KeyProviderURI == "" {
+		return nil, nil
+	}
+
+	urls, err := parseKeyProviderURI(options.KeyProviderURI)
+	if err != nil {
+		return nil, err
+	}
+
+	kms := &kmsClient{
+		urls:       urls,
+		httpClient: options.KMSHTTPClient,
+		user:       user,
+	}
+
+	// An empty kerberos client, as set by ClientOptionsFromConf, can't be
+	// used for SPNEGO.
+	if options.KerberosClient != nil && options.KerberosClient.Credentials != nil {
+		kms.kerberosClient = options.KerberosClient
+	}
+
+	if kms.httpClient == nil {
+		kms.httpClient = http.DefaultClient
+	}
+
+	return kms, nil
+}
+
+// parseKeyProviderURI converts a key provider URI, like
+// "kms://https@kms1;kms2:9600/kms", to the URLs of the KMS instances it names,
+// like "https://kms1:9600/kms" and "https://kms2:9600/kms".
+func parseKeyProviderURI(uri string) ([]string, error) {
+	rest := strings.TrimPrefix(uri, "kms://")
+	if rest == uri {
+		return nil, fmt.Errorf("unsupported key provider: %s", uri)
+	}
+
+	scheme, rest, ok := strings.Cut(rest, "@")
+	if !ok || (scheme != "http" && scheme != "https") {
+		return nil, fmt.Errorf("invalid key provider URI: %s", uri)
+	}
+
+	hostport, path, _ := strings.Cut(rest, "/")
+	hosts, port, ok := strings.Cut(hostport, ":")
+	if ok {
+		port = ":" + port
+	}
+
+	var urls []string
+	for _, host := range strings.Split(hosts, ";") {
+		if host == "" {
+			return nil, fmt.Errorf("invalid key provider URI: %s", uri)
+		}
+
+		urls = append(urls, scheme+"://"+host+port+"/"+path)
+	}
+
+	return urls, nil
+}
+
+type kmsKeyVersion struct {
+	Name     string `json:"name"`
+	IV       string `json:"iv,omitempty"`
+	Material string `json:"material"`
+}
+
+// decryptEDEK asks the KMS to decrypt the file's EDEK with the encryption
+// zone's key, returning the file's data encryption key. Each KMS instance is
+// tried in turn, until one succeeds.
+func (k *kmsClient) decryptEDEK(info *hdfs.FileEncryptionInfoProto) ([]byte, error) {
+	body, err := json.Marshal(kmsKeyVersion{
+		Name:     info.GetKeyName(),
+		IV:       base64.StdEncoding.EncodeToString(info.GetIv()),
+		Material: base64.StdEncoding.EncodeToString(info.GetKey()),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, u := range k.urls {
+		var key []byte
+		key, err = k.decryptEDEKFrom(u, info.GetEzKeyVersionName(), body)
+		if err == nil {
+			return key, nil
+		}
+	}
+
+	return nil, err
+}
+
+func (k *kmsClient) decryptEDEKFrom(base, keyVersion string, body []byte) ([]byte, error) {
+	u, err := url.Parse(strings.TrimSuffix(base, "/") + "/v1/keyversion/" + url.PathEscape(keyVersion) + "/_eek")
+	if err != nil {
+		return nil, err
+	}
+
+	query := url.Values{"eek_op": {"decrypt"}}
+	if k.kerberosClient == nil && k.user != "" {
+		query.Set("user.name", k.user)
+	}
+
+	u.RawQuery = query.Encode()
+	req, err := http.NewRequest("POST", u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if k.kerberosClient != nil {
+		err = k.kerberosClient.SetSPNEGOHeader(req, "HTTP/"+u.Hostname())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := k.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		var kmsErr struct {
+			RemoteException struct {
+				Message string `json:"message"`
+			}
+		}
+
+		json.NewDecoder(resp.Body).Decode(&kmsErr)
+		if msg := kmsErr.RemoteException.Message; msg != "" {
+			return nil, fmt.Errorf("kms: decrypting key: %s: %s", resp.Status, msg)
+		}
+
+		return nil, fmt.Errorf("kms: decrypting key: %s", resp.Status)
+	}
+
+	var decrypted kmsKeyVersion
+	err = json.NewDecoder(resp.Body).Decode(&decrypted)
+	if err != nil {
+		return nil, fmt.Errorf("kms: decrypting key: %s", err)
+	}
+
+	return decodeKMSBase64(decrypted.Material)
+}
+
+// decodeKMSBase64 decodes key material from the KMS, which uses the URL-safe
+// alphabet without padding, as well as the standard one, depending on the
+// version.
+func decodeKMSBase64(s string) ([]byte, error) {
+	s = strings.TrimRight(s, "=")
+	s = strings.NewReplacer("-", "+", "_", "/").Replace(s)
+	return base64.RawStdEncoding.DecodeString(s)
+}
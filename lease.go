@@ -0,0 +1,142 @@
+package hdfs
+
+import (
+	"context"
+	"os"
+	"time"
+
+	hdfs "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_hdfs"
+	"github.com/golang/protobuf/proto"
+)
+
+const (
+	// closeWaitInterval is how often WaitForClose checks whether a file has
+	// been closed.
+	closeWaitInterval = 500 * time.Millisecond
+	// recoverLeaseTimeout is how long an append waits for the file to be
+	// closed, once the namenode is recovering its last block, either because
+	// of CreateOptions.RecoverLease, or because it already was.
+	recoverLeaseTimeout = time.Minute
+)
+
+// RecoverLease revokes the lease on the named file held by whichever client
+// has it open for writing, for example because that client died without
+// closing it. The namenode then recovers the last block, and closes the file.
+// RecoverLease returns true if the file is already closed; otherwise, recovery
+// completes in the background, and WaitForClose can be used to wait for it.
+//
+// If the client holding the lease is still alive, it won't be able to finish
+// writing the file.
+func (c *Client) RecoverLease(name string) (bool, error) {
+	closed, err := c.recoverLease(name)
+	if err != nil {
+		return false, &os.PathError{"recoverlease", name, interpretException(err)}
+	}
+
+	return closed, nil
+}
+
+// IsFileClosed returns true if the named file isn't open for writing, and
+// isn't being recovered.
+func (c *Client) IsFileClosed(name string) (bool, error) {
+	closed, err := c.isFileClosed(context.Background(), name)
+	if err != nil {
+		return false, &os.PathError{"isfileclosed", name, interpretException(err)}
+	}
+
+	return closed, nil
+}
+
+// WaitForClose waits until the named file is closed; for example, after
+// RecoverLease or Truncate has started block recovery on it. If ctx is done
+// first, it returns ctx.Err().
+func (c *Client) WaitForClose(ctx context.Context, name string) error {
+	err := c.waitForClose(ctx, name)
+	if err != nil && ctx.Err() == nil {
+		err = &os.PathError{"isfileclosed", name, interpretException(err)}
+	}
+
+	return err
+}
+
+func (c *Client) waitForClose(ctx context.Context, name string) error {
+	ticker := time.NewTicker(closeWaitInterval)
+	defer ticker.Stop()
+
+	for {
+		closed, err := c.isFileClosed(ctx, name)
+		if err != nil || closed {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// recoverLeaseAndWait recovers the lease on the named file, and waits up to
+// recoverLeaseTimeout for it to be closed.
+func (c *Client) recoverLeaseAndWait(name string) error {
+	closed, err := c.recoverLease(name)
+	if err != nil || closed {
+		return err
+	}
+
+	return c.waitForRecovery(name)
+}
+
+// waitForRecovery waits up to recoverLeaseTimeout for the named file to be
+// closed, once the namenode has started recovering its last block.
+func (c *Client) waitForRecovery(name string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), recoverLeaseTimeout)
+	defer cancel()
+
+	return c.waitForClose(ctx, name)
+}
+
+// recoverLease asks the namenode to revoke the lease on the named file, and
+// close it. It returns true if the file is already closed.
+func (c *Client) recoverLease(name string) (bool, error) {
+	req := &hdfs.RecoverLeaseRequestProto{
+		Src:        proto.String(name),
+		ClientName: proto.String(c.namenode.ClientName),
+	}
+	resp := &hdfs.RecoverLeaseResponseProto{}
+
+	err := c.namenode.Execute("recoverLease", req, resp)
+	if err != nil {
+		return false, err
+	}
+
+	return resp.GetResult(), nil
+}
+
+func (c *Client) isFileClosed(ctx context.Context, name string) (bool, error) {
+	req := &hdfs.IsFileClosedRequestProto{Src: proto.String(name)}
+	resp := &hdfs.IsFileClosedResponseProto{}
+
+	err := c.namenode.ExecuteContext(ctx, "isFileClosed", req, resp)
+	if err != nil {
+		return false, err
+	}
+
+	return resp.GetResult(), nil
+}
+
+// isRecoveryInProgress returns true if err is because the namenode is
+// recovering the last block of the file.
+func isRecoveryInProgress(err error) bool {
+	remoteErr, ok := underlying(err).(Error)
+	return ok && remoteErr.Exception() == recoveryInProgressException
+}
+
+// isLeaseHeld returns true if err is because the file is open for writing by
+// another client, or its lease is being recovered.
+func isLeaseHeld(err error) bool {
+	remoteErr, ok := underlying(err).(Error)
+	return ok && (remoteErr.Exception() == alreadyBeingCreatedException ||
+		remoteErr.Exception() == recoveryInProgressException)
+}
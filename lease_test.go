@@ -0,0 +1,82 @@
+package hdfs
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecoverLease(t *testing.T) {
+	client := getClient(t)
+	other := newClientForUser(t, "gohdfs1")
+	defer other.Close()
+
+	baleet(t, "/_test/recoverlease.txt")
+	w, err := other.Create("/_test/recoverlease.txt")
+	require.NoError(t, err)
+
+	_, err = w.Write([]byte("foo"))
+	require.NoError(t, err)
+	require.NoError(t, w.Flush())
+
+	closed, err := client.IsFileClosed("/_test/recoverlease.txt")
+	require.NoError(t, err)
+	assert.False(t, closed)
+
+	_, err = client.RecoverLease("/_test/recoverlease.txt")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	err = client.WaitForClose(ctx, "/_test/recoverlease.txt")
+	require.NoError(t, err)
+
+	closed, err = client.IsFileClosed("/_test/recoverlease.txt")
+	require.NoError(t, err)
+	assert.True(t, closed)
+
+	bytes, err := client.ReadFile("/_test/recoverlease.txt")
+	require.NoError(t, err)
+	assert.Equal(t, "foo", string(bytes))
+}
+
+func TestRecoverLeaseNonExistent(t *testing.T) {
+	client := getClient(t)
+
+	_, err := client.RecoverLease("/_test/nonexistent")
+	assertPathError(t, err, "recoverlease", "/_test/nonexistent", os.ErrNotExist)
+}
+
+func TestAppendRecoverLease(t *testing.T) {
+	client := getClient(t)
+	other := newClientForUser(t, "gohdfs1")
+	defer other.Close()
+
+	baleet(t, "/_test/appendrecoverlease.txt")
+	w, err := other.Create("/_test/appendrecoverlease.txt")
+	require.NoError(t, err)
+
+	_, err = w.Write([]byte("foo"))
+	require.NoError(t, err)
+	require.NoError(t, w.Flush())
+
+	// The other client still holds the lease.
+	_, err = client.Append("/_test/appendrecoverlease.txt")
+	assert.Error(t, err)
+
+	w, err = client.CreateWithOptions("/_test/appendrecoverlease.txt", CreateOptions{Append: true, RecoverLease: true})
+	require.NoError(t, err)
+
+	_, err = w.Write([]byte("bar"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	bytes, err := client.ReadFile("/_test/appendrecoverlease.txt")
+	require.NoError(t, err)
+	assert.Equal(t, "foobar", string(bytes))
+}
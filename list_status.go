@@ -0,0 +1,173 @@
+package hdfs
+
+import (
+	"context"
+	"io"
+	"os"
+
+	hdfs "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_hdfs"
+	"github.com/golang/protobuf/proto"
+)
+
+// ListStatusIterator iterates over the entries in a directory, as returned by
+// ListStatusIterator. The entries can be consumed one at a time, like with
+// LocatedStatusIterator:
+//
+//	it := client.ListStatusIterator("/foo")
+//	for it.Next() {
+//		fmt.Println(it.FileInfo().Name())
+//	}
+//
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+//
+// Or a batch at a time, as they're returned by the namenode, with NextBatch.
+type ListStatusIterator struct {
+	client  *Client
+	ctx     context.Context
+	dirname string
+
+	batch      []*hdfs.HdfsFileStatusProto
+	startAfter []byte
+	remaining  bool
+
+	info *FileInfo
+	last string
+	err  error
+}
+
+// ListStatusIterator returns an iterator over the entries in the named
+// directory. Unlike ReadDir, it doesn't read the whole directory into memory
+// at once; the entries are fetched from the namenode in batches (of up to
+// dfs.ls.limit entries, 1000 by default) as the iterator advances, and every
+// entry of each batch is used, so it's suitable for very large directories.
+// The entries are returned in the order the namenode lists them, which is by
+// name.
+//
+// If the directory is modified during iteration, entries that are added or
+// removed may or may not be returned.
+func (c *Client) ListStatusIterator(dirname string) *ListStatusIterator {
+	return c.ListStatusIteratorAfter(dirname, "")
+}
+
+// ListStatusIteratorAfter is like ListStatusIterator, but starts with the
+// first entry whose name sorts after startAfter. Together with StartAfter,
+// this can be used to resume a listing later, or to page through a directory
+// statelessly, like in a web UI.
+func (c *Client) ListStatusIteratorAfter(dirname, startAfter string) *ListStatusIterator {
+	return &ListStatusIterator{
+		client:     c,
+		ctx:        context.Background(),
+		dirname:    dirname,
+		startAfter: []byte(startAfter),
+		remaining:  true,
+		last:       startAfter,
+	}
+}
+
+// Next advances the iterator to the next entry, which is then available
+// through FileInfo. It returns false when there are no more entries, or if an
+// error occurred fetching them, in which case Err returns the error.
+func (it *ListStatusIterator) Next() bool {
+	it.info = nil
+	if !it.fill() {
+		return false
+	}
+
+	it.info = newFileInfo(it.batch[0], it.dirname)
+	it.batch = it.batch[1:]
+	it.last = it.info.Name()
+	return true
+}
+
+// NextBatch returns the rest of the current batch of entries, or fetches the
+// next one from the namenode. At the end of the directory, it returns io.EOF.
+func (it *ListStatusIterator) NextBatch() ([]os.FileInfo, error) {
+	it.info = nil
+	if !it.fill() {
+		if it.err != nil {
+			return nil, it.err
+		}
+
+		return nil, io.EOF
+	}
+
+	res := make([]os.FileInfo, len(it.batch))
+	for i, status := range it.batch {
+		res[i] = newFileInfo(status, it.dirname)
+	}
+
+	it.batch = nil
+	it.last = res[len(res)-1].Name()
+	return res, nil
+}
+
+// fill fetches the next batch, if the current one is used up. It returns false
+// if there are no more entries.
+func (it *ListStatusIterator) fill() bool {
+	if it.err != nil {
+		return false
+	}
+
+	for len(it.batch) == 0 {
+		if !it.remaining {
+			return false
+		}
+
+		it.batch, it.remaining, it.err = it.client.getListing(it.ctx, it.dirname, it.startAfter, false)
+		if it.err != nil {
+			it.err = &os.PathError{"liststatus", it.dirname, it.err}
+			return false
+		}
+
+		if len(it.batch) > 0 {
+			it.startAfter = it.batch[len(it.batch)-1].GetPath()
+		}
+	}
+
+	return true
+}
+
+// FileInfo returns the current entry.
+func (it *ListStatusIterator) FileInfo() *FileInfo {
+	return it.info
+}
+
+// StartAfter returns the name of the last entry returned, which can be passed
+// to ListStatusIteratorAfter to continue the listing from where it left off.
+func (it *ListStatusIterator) StartAfter() string {
+	return it.last
+}
+
+// Err returns the first error encountered by the iterator, if any.
+func (it *ListStatusIterator) Err() error {
+	return it.err
+}
+
+// getListing fetches the entries in dirname that sort after startAfter, up to
+// the namenode's limit, and whether there are any more. The error has already
+// been passed through interpretException.
+func (c *Client) getListing(ctx context.Context, dirname string, startAfter []byte, needLocation bool) ([]*hdfs.HdfsFileStatusProto, bool, error) {
+	if c.web != nil && !needLocation {
+		list, remaining, err := c.web.listStatus(ctx, dirname, startAfter)
+		return list, remaining, interpretException(err)
+	}
+
+	req := &hdfs.GetListingRequestProto{
+		Src:          proto.String(dirname),
+		StartAfter:   startAfter,
+		NeedLocation: proto.Bool(needLocation),
+	}
+	resp := &hdfs.GetListingResponseProto{}
+
+	err := c.namenode.ExecuteContext(ctx, "getListing", req, resp)
+	if err != nil {
+		return nil, false, interpretException(err)
+	} else if resp.GetDirList() == nil {
+		return nil, false, os.ErrNotExist
+	}
+
+	list := resp.GetDirList().GetPartialListing()
+	return list, resp.GetDirList().GetRemainingEntries() > 0 && len(list) > 0, nil
+}
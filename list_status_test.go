@@ -0,0 +1,92 @@
+package hdfs
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListStatusIterator(t *testing.T) {
+	client := getClient(t)
+
+	baleet(t, "/_test/liststatus")
+	mkdirp(t, "/_test/liststatus/dir")
+	touch(t, "/_test/liststatus/file")
+
+	it := client.ListStatusIterator("/_test/liststatus")
+	require.True(t, it.Next())
+	assert.Equal(t, "dir", it.FileInfo().Name())
+	assert.True(t, it.FileInfo().IsDir())
+	assert.Equal(t, "dir", it.StartAfter())
+
+	require.True(t, it.Next())
+	assert.Equal(t, "file", it.FileInfo().Name())
+	assert.False(t, it.FileInfo().IsDir())
+
+	assert.False(t, it.Next())
+	assert.NoError(t, it.Err())
+
+	it = client.ListStatusIteratorAfter("/_test/liststatus", "dir")
+	require.True(t, it.Next())
+	assert.Equal(t, "file", it.FileInfo().Name())
+	assert.False(t, it.Next())
+}
+
+func TestListStatusIteratorBatches(t *testing.T) {
+	client := getClient(t)
+
+	// The namenode returns 1000 entries per batch by default.
+	baleet(t, "/_test/liststatuslarge")
+	mkdirp(t, "/_test/liststatuslarge")
+	for i := 0; i < 1200; i++ {
+		touch(t, fmt.Sprintf("/_test/liststatuslarge/%04d", i))
+	}
+
+	it := client.ListStatusIterator("/_test/liststatuslarge")
+
+	// Take one entry, and then the rest of the batch.
+	require.True(t, it.Next())
+	assert.Equal(t, "0000", it.FileInfo().Name())
+
+	batch, err := it.NextBatch()
+	require.NoError(t, err)
+	require.Len(t, batch, 999)
+	assert.Equal(t, "0001", batch[0].Name())
+	assert.Equal(t, "0999", it.StartAfter())
+
+	// Resume from the cursor with a new iterator.
+	it = client.ListStatusIteratorAfter("/_test/liststatuslarge", it.StartAfter())
+	batch, err = it.NextBatch()
+	require.NoError(t, err)
+	require.Len(t, batch, 200)
+	assert.Equal(t, "1000", batch[0].Name())
+	assert.Equal(t, "1199", batch[199].Name())
+
+	_, err = it.NextBatch()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestListStatusIteratorFile(t *testing.T) {
+	client := getClient(t)
+
+	it := client.ListStatusIterator("/_test/foo.txt")
+	require.True(t, it.Next())
+	assert.Equal(t, "foo.txt", it.FileInfo().Name())
+	assert.False(t, it.Next())
+	assert.NoError(t, it.Err())
+}
+
+func TestListStatusIteratorNonExistent(t *testing.T) {
+	client := getClient(t)
+
+	it := client.ListStatusIterator("/_test/nonexistent")
+	assert.False(t, it.Next())
+	assertPathError(t, it.Err(), "liststatus", "/_test/nonexistent", os.ErrNotExist)
+
+	_, err := it.NextBatch()
+	assertPathError(t, err, "liststatus", "/_test/nonexistent", os.ErrNotExist)
+}
@@ -0,0 +1,111 @@
+package hdfs
+
+import (
+	"context"
+	"os"
+
+	hdfs "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_hdfs"
+)
+
+// LocatedStatusIterator iterates over the entries in a directory, along with
+// the block locations of each file, as returned by ListLocatedStatus. Its use
+// is similar to that of bufio.Scanner:
+//
+//	it := client.ListLocatedStatus("/foo")
+//	for it.Next() {
+//		fmt.Println(it.FileInfo().Name(), it.Locations())
+//	}
+//
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type LocatedStatusIterator struct {
+	client  *Client
+	dirname string
+
+	batch      []*hdfs.HdfsFileStatusProto
+	startAfter []byte
+	remaining  bool
+
+	info      *FileInfo
+	locations []BlockLocation
+	err       error
+}
+
+// ListLocatedStatus returns an iterator over the entries in the named
+// directory, which includes the block locations of every file. Unlike
+// ReadDir, it doesn't read the whole directory into memory at once; the
+// entries are fetched from the namenode in batches as the iterator advances,
+// so it's suitable for very large directories. The entries aren't sorted, but
+// are returned in the order the namenode lists them, which is by name.
+//
+// If the directory is modified during iteration, entries that are added or
+// removed may or may not be returned.
+func (c *Client) ListLocatedStatus(dirname string) *LocatedStatusIterator {
+	return &LocatedStatusIterator{client: c, dirname: dirname, remaining: true}
+}
+
+// Next advances the iterator to the next entry, which is then available
+// through FileInfo and Locations. It returns false when there are no more
+// entries, or if an error occurred fetching them, in which case Err returns
+// the error.
+func (it *LocatedStatusIterator) Next() bool {
+	it.info = nil
+	it.locations = nil
+	if it.err != nil {
+		return false
+	}
+
+	for len(it.batch) == 0 {
+		if !it.remaining {
+			return false
+		}
+
+		it.err = it.fetch()
+		if it.err != nil {
+			return false
+		}
+	}
+
+	status := it.batch[0]
+	it.batch = it.batch[1:]
+
+	// If the path is a file, the listing contains just that file, with an
+	// empty name.
+	it.info = newFileInfo(status, it.dirname)
+	for _, block := range status.GetLocations().GetBlocks() {
+		it.locations = append(it.locations, newBlockLocation(block))
+	}
+
+	return true
+}
+
+func (it *LocatedStatusIterator) fetch() error {
+	list, remaining, err := it.client.getListing(context.Background(), it.dirname, it.startAfter, true)
+	if err != nil {
+		return &os.PathError{"listlocatedstatus", it.dirname, err}
+	}
+
+	it.batch = list
+	it.remaining = remaining
+	if len(list) > 0 {
+		it.startAfter = list[len(list)-1].GetPath()
+	}
+
+	return nil
+}
+
+// FileInfo returns the current entry.
+func (it *LocatedStatusIterator) FileInfo() *FileInfo {
+	return it.info
+}
+
+// Locations returns the block locations of the current entry, if it's a file.
+func (it *LocatedStatusIterator) Locations() []BlockLocation {
+	return it.locations
+}
+
+// Err returns the first error encountered by the iterator, if any.
+func (it *LocatedStatusIterator) Err() error {
+	return it.err
+}
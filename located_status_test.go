@@ -0,0 +1,77 @@
+package hdfs
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListLocatedStatus(t *testing.T) {
+	client := getClient(t)
+
+	baleet(t, "/_test/locateddir")
+	mkdirp(t, "/_test/locateddir/dir")
+	writer, err := client.Create("/_test/locateddir/file")
+	require.NoError(t, err)
+	_, err = writer.Write([]byte("foo"))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	it := client.ListLocatedStatus("/_test/locateddir")
+	require.True(t, it.Next())
+	assert.Equal(t, "dir", it.FileInfo().Name())
+	assert.True(t, it.FileInfo().IsDir())
+	assert.Empty(t, it.Locations())
+
+	require.True(t, it.Next())
+	assert.Equal(t, "file", it.FileInfo().Name())
+	require.Len(t, it.Locations(), 1)
+	assert.EqualValues(t, 3, it.Locations()[0].Length)
+	assert.NotEmpty(t, it.Locations()[0].Hosts)
+
+	assert.False(t, it.Next())
+	assert.NoError(t, it.Err())
+}
+
+func TestListLocatedStatusLargeDir(t *testing.T) {
+	client := getClient(t)
+
+	// The namenode returns 1000 entries per batch by default.
+	baleet(t, "/_test/locatedlargedir")
+	mkdirp(t, "/_test/locatedlargedir")
+	for i := 0; i < 1200; i++ {
+		touch(t, fmt.Sprintf("/_test/locatedlargedir/%04d", i))
+	}
+
+	it := client.ListLocatedStatus("/_test/locatedlargedir")
+	i := 0
+	for it.Next() {
+		assert.Equal(t, fmt.Sprintf("%04d", i), it.FileInfo().Name())
+		i++
+	}
+
+	require.NoError(t, it.Err())
+	assert.Equal(t, 1200, i)
+}
+
+func TestListLocatedStatusFile(t *testing.T) {
+	client := getClient(t)
+
+	it := client.ListLocatedStatus("/_test/foo.txt")
+	require.True(t, it.Next())
+	assert.Equal(t, "foo.txt", it.FileInfo().Name())
+	assert.Len(t, it.Locations(), 1)
+	assert.False(t, it.Next())
+	assert.NoError(t, it.Err())
+}
+
+func TestListLocatedStatusNonExistent(t *testing.T) {
+	client := getClient(t)
+
+	it := client.ListLocatedStatus("/_test/nonexistent")
+	assert.False(t, it.Next())
+	assertPathError(t, it.Err(), "listlocatedstatus", "/_test/nonexistent", os.ErrNotExist)
+}
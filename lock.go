@@ -0,0 +1,246 @@
+package hdfs
+
+import (
+	"errors"
+	"os"
+	"sync"
+	"time"
+)
+
+// ErrLocked is returned (wrapped in an os.PathError) by TryLock if the lock is
+// already held.
+var ErrLocked = errors.New("already locked")
+
+var errLockBroken = errors.New("lock was broken by someone else")
+
+// LockOptions specifies how a lock is acquired and held, for
+// TryLockWithOptions.
+type LockOptions struct {
+	// StaleAfter is how long a lock can go without being refreshed before it's
+	// considered abandoned, and can be broken by another client. While a lock is
+	// held, it's refreshed in the background at a third of this interval. If
+	// it's zero, locks are never considered stale, and a lock file left behind
+	// by a crashed process has to be removed by hand.
+	StaleAfter time.Duration
+	// Perm specifies the permissions of the lock file. If zero, 0644 is used.
+	Perm os.FileMode
+}
+
+// A Lock is an exclusive lock on a path in HDFS, acquired with TryLock. It's
+// held by creating a lock file at the path with an exclusive create, and then
+// keeping the file open for writing, so that the namenode holds a lease on it
+// for the client.
+type Lock struct {
+	client     *Client
+	name       string
+	fileID     uint64
+	writer     *FileWriter
+	staleAfter time.Duration
+
+	stop chan struct{}
+	done chan struct{}
+
+	unlockOnce sync.Once
+	unlockErr  error
+}
+
+// TryLock attempts to acquire an exclusive lock on the named path, which must
+// not otherwise exist, and returns immediately. If the lock is held by someone
+// else, it returns an error wrapping ErrLocked. Locks acquired with TryLock are
+// never considered stale; see TryLockWithOptions.
+func (c *Client) TryLock(name string) (*Lock, error) {
+	return c.TryLockWithOptions(name, LockOptions{})
+}
+
+// TryLockWithOptions is like TryLock, but with the given options. If the lock
+// is held but it hasn't been refreshed for longer than options.StaleAfter, the
+// lease of the previous holder is recovered, and the lock file removed, before
+// trying again.
+//
+// Breaking a stale lock is inherently racy: if the previous holder is still
+// alive but wasn't able to refresh the lock in time (because of a long GC
+// pause, for example), both it and the new holder might briefly believe that
+// they hold the lock. Choose a StaleAfter that is much longer than any pause a
+// holder can experience.
+func (c *Client) TryLockWithOptions(name string, options LockOptions) (*Lock, error) {
+	l, err := c.createLock(name, options)
+	if !errors.Is(err, ErrLocked) || options.StaleAfter <= 0 {
+		return l, err
+	}
+
+	info, statErr := c.getFileInfo(name)
+	if statErr != nil {
+		statErr = interpretException(statErr)
+		if os.IsNotExist(statErr) {
+			// It was released in the meantime.
+			return c.createLock(name, options)
+		}
+
+		return nil, &os.PathError{"lock", name, statErr}
+	}
+
+	if time.Since(info.ModTime()) < options.StaleAfter {
+		return nil, err
+	}
+
+	// Revoke the lease of the previous holder, so that it can't keep writing to
+	// the lock file, and then make sure the file we're removing is the same
+	// one we saw was stale.
+	_, recoverErr := c.recoverLease(name)
+	if recoverErr != nil {
+		return nil, &os.PathError{"lock", name, interpretException(recoverErr)}
+	}
+
+	current, statErr := c.getFileInfo(name)
+	if statErr == nil {
+		if fileID(current) != fileID(info) {
+			return nil, err
+		}
+
+		removeErr := c.Remove(name)
+		if removeErr != nil && !os.IsNotExist(removeErr) {
+			return nil, removeErr
+		}
+	}
+
+	return c.createLock(name, options)
+}
+
+func (c *Client) createLock(name string, options LockOptions) (*Lock, error) {
+	w, err := c.CreateWithOptions(name, CreateOptions{Perm: options.Perm})
+	if err != nil {
+		if os.IsExist(err) || isAlreadyBeingCreated(err) {
+			return nil, &os.PathError{"lock", name, ErrLocked}
+		}
+
+		return nil, err
+	}
+
+	// Write our client name to the lock file, to help identify the holder.
+	_, err = w.Write([]byte(c.namenode.ClientName + "\n"))
+	if err == nil {
+		err = w.Flush()
+	}
+
+	var info os.FileInfo
+	if err == nil {
+		info, err = c.getFileInfo(name)
+		err = interpretException(err)
+	}
+
+	if err != nil {
+		w.Close()
+		c.Remove(name)
+		return nil, &os.PathError{"lock", name, err}
+	}
+
+	l := &Lock{
+		client:     c,
+		name:       name,
+		fileID:     fileID(info),
+		writer:     w,
+		staleAfter: options.StaleAfter,
+	}
+
+	if l.staleAfter > 0 {
+		l.stop = make(chan struct{})
+		l.done = make(chan struct{})
+		go l.refreshLoop()
+	}
+
+	return l, nil
+}
+
+// Name returns the path that the lock is on.
+func (l *Lock) Name() string {
+	return l.name
+}
+
+// Refresh updates the modification time of the lock file, so that it isn't
+// considered stale by other clients. It's called automatically in the
+// background if the lock was acquired with a StaleAfter.
+func (l *Lock) Refresh() error {
+	now := time.Now()
+	err := l.client.Chtimes(l.name, now, now)
+	if err != nil {
+		return &os.PathError{"refresh", l.name, underlying(err)}
+	}
+
+	return nil
+}
+
+func (l *Lock) refreshLoop() {
+	defer close(l.done)
+
+	ticker := time.NewTicker(l.staleAfter / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			// Errors are ignored, since it's fine to miss a refresh or two; if
+			// the lock is lost for good, Unlock reports it.
+			l.Refresh()
+		case <-l.stop:
+			return
+		}
+	}
+}
+
+// Unlock releases the lock, closing and removing the lock file. If the lock
+// was broken by someone else in the meantime, it leaves the new lock file
+// alone and returns an error.
+func (l *Lock) Unlock() error {
+	l.unlockOnce.Do(func() {
+		l.unlockErr = l.unlock()
+	})
+
+	return l.unlockErr
+}
+
+func (l *Lock) unlock() error {
+	if l.stop != nil {
+		close(l.stop)
+		<-l.done
+	}
+
+	// The writer may fail to close if our lease was recovered by someone
+	// breaking the lock, which is checked for below.
+	closeErr := l.writer.Close()
+
+	info, err := l.client.getFileInfo(l.name)
+	if err != nil {
+		err = interpretException(err)
+		if os.IsNotExist(err) {
+			return &os.PathError{"unlock", l.name, errLockBroken}
+		}
+
+		return &os.PathError{"unlock", l.name, err}
+	} else if fileID(info) != l.fileID {
+		return &os.PathError{"unlock", l.name, errLockBroken}
+	} else if closeErr != nil {
+		return closeErr
+	}
+
+	return l.client.Remove(l.name)
+}
+
+func fileID(info os.FileInfo) uint64 {
+	return info.(*FileInfo).status.GetFileId()
+}
+
+// isAlreadyBeingCreated returns true if err is because the file is open for
+// writing by another client.
+func isAlreadyBeingCreated(err error) bool {
+	remoteErr, ok := underlying(err).(Error)
+	return ok && remoteErr.Exception() == alreadyBeingCreatedException
+}
+
+// underlying returns the error wrapped by an os.PathError, or err itself.
+func underlying(err error) error {
+	if pathErr, ok := err.(*os.PathError); ok {
+		return pathErr.Err
+	}
+
+	return err
+}
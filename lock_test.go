@@ -0,0 +1,100 @@
+package hdfs
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTryLock(t *testing.T) {
+	client := getClient(t)
+
+	mkdirp(t, "/_test/lock")
+	lock, err := client.TryLock("/_test/lock/1.lock")
+	require.NoError(t, err)
+	assert.Equal(t, "/_test/lock/1.lock", lock.Name())
+
+	_, err = client.TryLock("/_test/lock/1.lock")
+	assertPathError(t, err, "lock", "/_test/lock/1.lock", ErrLocked)
+
+	err = lock.Unlock()
+	require.NoError(t, err)
+
+	_, err = client.Stat("/_test/lock/1.lock")
+	assertPathError(t, err, "stat", "/_test/lock/1.lock", os.ErrNotExist)
+
+	lock, err = client.TryLock("/_test/lock/1.lock")
+	require.NoError(t, err)
+
+	err = lock.Unlock()
+	require.NoError(t, err)
+}
+
+func TestTryLockStale(t *testing.T) {
+	client := getClient(t)
+
+	mkdirp(t, "/_test/lock")
+	touch(t, "/_test/lock/2.lock")
+
+	options := LockOptions{StaleAfter: time.Hour}
+	_, err := client.TryLockWithOptions("/_test/lock/2.lock", options)
+	assertPathError(t, err, "lock", "/_test/lock/2.lock", ErrLocked)
+
+	past := time.Now().Add(-2 * time.Hour)
+	err = client.Chtimes("/_test/lock/2.lock", past, past)
+	require.NoError(t, err)
+
+	lock, err := client.TryLockWithOptions("/_test/lock/2.lock", options)
+	require.NoError(t, err)
+
+	fi, err := client.Stat("/_test/lock/2.lock")
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now(), fi.ModTime(), 10*time.Minute)
+
+	err = lock.Unlock()
+	require.NoError(t, err)
+}
+
+func TestTryLockRefresh(t *testing.T) {
+	client := getClient(t)
+
+	mkdirp(t, "/_test/lock")
+	lock, err := client.TryLockWithOptions("/_test/lock/3.lock", LockOptions{StaleAfter: time.Hour})
+	require.NoError(t, err)
+
+	past := time.Now().Add(-2 * time.Hour)
+	err = client.Chtimes("/_test/lock/3.lock", past, past)
+	require.NoError(t, err)
+
+	err = lock.Refresh()
+	require.NoError(t, err)
+
+	fi, err := client.Stat("/_test/lock/3.lock")
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now(), fi.ModTime(), 10*time.Minute)
+
+	err = lock.Unlock()
+	require.NoError(t, err)
+}
+
+func TestUnlockBroken(t *testing.T) {
+	client := getClient(t)
+
+	mkdirp(t, "/_test/lock")
+	lock, err := client.TryLock("/_test/lock/4.lock")
+	require.NoError(t, err)
+
+	err = client.Remove("/_test/lock/4.lock")
+	require.NoError(t, err)
+
+	touch(t, "/_test/lock/4.lock")
+
+	err = lock.Unlock()
+	assertPathError(t, err, "unlock", "/_test/lock/4.lock", errLockBroken)
+
+	_, err = client.Stat("/_test/lock/4.lock")
+	require.NoError(t, err)
+}
@@ -0,0 +1,25 @@
+package hdfs
+
+import (
+	"log"
+
+	"github.com/colinmarc/hdfs/v2/internal/rpc"
+)
+
+// A Logger receives log messages from a Client. *log.Logger implements it.
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+func newWireLogger(options ClientOptions) *rpc.WireLogger {
+	if !options.DebugWire {
+		return nil
+	}
+
+	var logger Logger = log.Default()
+	if options.Logger != nil {
+		logger = options.Logger
+	}
+
+	return &rpc.WireLogger{Logger: logger, HexDumpBytes: options.DebugWireHexDumpBytes}
+}
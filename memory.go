@@ -0,0 +1,37 @@
+package hdfs
+
+import "github.com/colinmarc/hdfs/v2/internal/rpc"
+
+// MemoryStats describes the memory held by a client's readers and writers,
+// when ClientOptions.MemoryLimit is set.
+type MemoryStats struct {
+	// Limit is the configured limit, in bytes.
+	Limit int64
+	// InUse is the number of bytes currently held by buffers.
+	InUse int64
+	// Peak is the most bytes that have been held at once.
+	Peak int64
+	// Waits is the number of times a read or write had to wait for memory to
+	// be freed before it could continue.
+	Waits int
+}
+
+func newMemoryLimiter(options ClientOptions) *rpc.MemoryLimiter {
+	if options.MemoryLimit <= 0 {
+		return nil
+	}
+
+	return rpc.NewMemoryLimiter(options.MemoryLimit)
+}
+
+// MemoryStats returns the memory held by the client's readers and writers. It
+// returns the zero value if ClientOptions.MemoryLimit isn't set.
+func (c *Client) MemoryStats() MemoryStats {
+	stats := c.memory.Stats()
+	return MemoryStats{
+		Limit: stats.Limit,
+		InUse: stats.InUse,
+		Peak:  stats.Peak,
+		Waits: stats.Waits,
+	}
+}
@@ -0,0 +1,117 @@
+package hdfs
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/colinmarc/hdfs/v2/hadoopconf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryLimit(t *testing.T) {
+	conf, err := hadoopconf.LoadFromEnvironment()
+	if err != nil || conf == nil {
+		t.Fatal("Couldn't load ambient config", err)
+	}
+
+	options := ClientOptionsFromConf(conf)
+	if options.KerberosClient != nil {
+		options.KerberosClient = getKerberosClient(t, "gohdfs1")
+	} else {
+		options.User = "gohdfs1"
+	}
+
+	// Smaller than a single WriteTo buffer, or a couple of packets.
+	options.MemoryLimit = 100000
+
+	client, err := NewClient(options)
+	require.NoError(t, err)
+	defer client.Close()
+
+	mkdirp(t, "/_test/create")
+	baleet(t, "/_test/create/memory_limit.txt")
+
+	data := bytes.Repeat([]byte("foobar"), 1000000)
+	writer, err := client.Create("/_test/create/memory_limit.txt")
+	require.NoError(t, err)
+
+	n, err := writer.ReadFrom(bytes.NewReader(data))
+	require.NoError(t, err)
+	assert.EqualValues(t, len(data), n)
+	require.NoError(t, writer.Close())
+
+	reader, err := client.Open("/_test/create/memory_limit.txt")
+	require.NoError(t, err)
+	defer reader.Close()
+
+	b, err := ioutil.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Equal(t, data, b)
+
+	var buf bytes.Buffer
+	_, err = reader.Seek(0, 0)
+	require.NoError(t, err)
+	_, err = reader.WriteTo(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, data, buf.Bytes())
+
+	stats := client.MemoryStats()
+	assert.EqualValues(t, 100000, stats.Limit)
+	assert.EqualValues(t, 0, stats.InUse)
+	assert.True(t, stats.Peak > 0)
+}
+
+func TestMemoryLimitErasureCoded(t *testing.T) {
+	client := getClient(t)
+	policy := skipWithoutRSPolicy(t, client)
+
+	baleet(t, "/_test/ecmemory")
+	mkdirp(t, "/_test/ecmemory")
+	require.NoError(t, client.SetErasureCodingPolicy("/_test/ecmemory", policy.Name))
+
+	data := make([]byte, policy.CellSize*policy.DataUnits*3/2)
+	for i := range data {
+		data[i] = byte(i % 251)
+	}
+
+	w, err := client.Create("/_test/ecmemory/foo")
+	require.NoError(t, err)
+	_, err = w.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	// Smaller than the WriteTo buffer plus a stripe, which mustn't make the
+	// reader wait on itself.
+	limited := newClientWithOptions(t, "gohdfs1", func(options *ClientOptions) {
+		options.MemoryLimit = 100000
+	})
+	defer limited.Close()
+
+	reader, err := limited.Open("/_test/ecmemory/foo")
+	require.NoError(t, err)
+	defer reader.Close()
+
+	var buf bytes.Buffer
+	done := make(chan error, 1)
+	go func() {
+		_, err := reader.WriteTo(&buf)
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(time.Minute):
+		t.Fatal("WriteTo deadlocked")
+	}
+
+	assert.True(t, bytes.Equal(data, buf.Bytes()))
+	assert.EqualValues(t, 0, limited.MemoryStats().InUse)
+}
+
+func TestMemoryStatsDisabled(t *testing.T) {
+	assert.Equal(t, MemoryStats{}, getClient(t).MemoryStats())
+}
@@ -0,0 +1,179 @@
+package hdfs
+
+import (
+	"expvar"
+	"time"
+
+	"github.com/colinmarc/hdfs/v2/internal/rpc"
+)
+
+// Metrics receives measurements from a Client, so that they can be exported
+// to a monitoring system. It's set with ClientOptions.Metrics.
+//
+// The methods are called synchronously by whichever goroutine did the work,
+// including the client's background goroutines, so they must be safe for
+// concurrent use, and should return quickly. ExpvarMetrics is an
+// implementation that keeps running totals with the expvar package; others
+// can be written to record histograms for Prometheus, for example.
+type Metrics interface {
+	// NamenodeRequest is called after each request to the namenode, with its
+	// RPC method (for example, "getFileInfo"), how long it took, including
+	// any retries and failovers, and the error, if it failed.
+	NamenodeRequest(method string, latency time.Duration, err error)
+	// DatanodeRead is called after each successful read from a datanode, with
+	// its address, the number of bytes read, and how long the read took.
+	// Short-circuit reads from the local disk are included.
+	DatanodeRead(datanode string, n int, latency time.Duration)
+	// DatanodeWrite is called after each packet written is acknowledged by
+	// the whole pipeline, with the address of the first datanode, the number
+	// of bytes in the packet, and how long the ack took.
+	DatanodeWrite(datanode string, n int, latency time.Duration)
+	// DatanodeResend is called for each packet that's sent again after a
+	// write pipeline is recovered from a datanode failure, because the old
+	// pipeline didn't acknowledge it, with the address of the first datanode
+	// of the new pipeline and the number of bytes in the packet.
+	DatanodeResend(datanode string, n int)
+	// DatanodeError is called when an operation on a datanode fails. op is
+	// "read" for connecting to or reading from a datanode, "write" for
+	// setting up or writing to a pipeline, or "heartbeat" for the heartbeats
+	// sent to a pipeline while the writer is idle. datanode may be empty, if
+	// it's not known which datanode in a pipeline failed.
+	DatanodeError(op string, datanode string, err error)
+}
+
+func newNamenodeRequestHook(options ClientOptions) func(string, time.Duration, error) {
+	if options.Metrics == nil {
+		return nil
+	}
+
+	return options.Metrics.NamenodeRequest
+}
+
+// newMetricsReadStats returns the rpc.ReadStats for a client, which reports
+// to metrics, if it's set.
+func newMetricsReadStats(metrics Metrics) *rpc.ReadStats {
+	rs := rpc.NewReadStats()
+	if metrics != nil {
+		rs.OnRead = metrics.DatanodeRead
+		rs.OnError = func(address string, err error) {
+			metrics.DatanodeError("read", address, err)
+		}
+	}
+
+	return rs
+}
+
+func reportWriteEvent(metrics Metrics, ev rpc.WriteEvent) {
+	switch ev.Type {
+	case rpc.AckReceived:
+		var first string
+		if len(ev.Pipeline) > 0 {
+			first = ev.Pipeline[0]
+		}
+
+		metrics.DatanodeWrite(first, ev.Bytes, ev.Latency)
+	case rpc.PacketResent:
+		var first string
+		if len(ev.Pipeline) > 0 {
+			first = ev.Pipeline[0]
+		}
+
+		metrics.DatanodeResend(first, ev.Bytes)
+	case rpc.PipelineFailed:
+		metrics.DatanodeError("write", ev.FailedDatanode, ev.Err)
+	case rpc.HeartbeatFailed:
+		metrics.DatanodeError("heartbeat", ev.FailedDatanode, ev.Err)
+	}
+}
+
+// ExpvarMetrics is an implementation of Metrics that keeps running totals in
+// an expvar.Map, so that they're served as JSON at /debug/vars along with the
+// program's other variables:
+//
+//	options.Metrics = hdfs.NewExpvarMetrics("hdfs")
+//
+// The map has these keys:
+//
+//	namenode_requests        requests to the namenode, by method
+//	namenode_errors          failed requests to the namenode, by method
+//	namenode_latency_seconds total time spent on namenode requests, by method
+//	datanode_bytes_read      bytes read from datanodes
+//	datanode_reads           reads from datanodes
+//	datanode_bytes_written   bytes written to (and acknowledged by) datanodes
+//	datanode_packets_written packets written to datanodes
+//	datanode_packets_resent  packets sent again after a pipeline recovery
+//	datanode_errors          datanode failures, by op
+type ExpvarMetrics struct {
+	*expvar.Map
+
+	namenodeRequests *expvar.Map
+	namenodeErrors   *expvar.Map
+	namenodeLatency  *expvar.Map
+	bytesRead        *expvar.Int
+	reads            *expvar.Int
+	bytesWritten     *expvar.Int
+	packetsWritten   *expvar.Int
+	packetsResent    *expvar.Int
+	datanodeErrors   *expvar.Map
+}
+
+// NewExpvarMetrics returns an ExpvarMetrics published with the given name.
+// Like expvar.Publish, it panics if the name is already in use; to share the
+// totals between several clients, pass them the same ExpvarMetrics.
+func NewExpvarMetrics(name string) *ExpvarMetrics {
+	m := &ExpvarMetrics{
+		Map:              new(expvar.Map).Init(),
+		namenodeRequests: new(expvar.Map).Init(),
+		namenodeErrors:   new(expvar.Map).Init(),
+		namenodeLatency:  new(expvar.Map).Init(),
+		bytesRead:        new(expvar.Int),
+		reads:            new(expvar.Int),
+		bytesWritten:     new(expvar.Int),
+		packetsWritten:   new(expvar.Int),
+		packetsResent:    new(expvar.Int),
+		datanodeErrors:   new(expvar.Map).Init(),
+	}
+
+	m.Set("namenode_requests", m.namenodeRequests)
+	m.Set("namenode_errors", m.namenodeErrors)
+	m.Set("namenode_latency_seconds", m.namenodeLatency)
+	m.Set("datanode_bytes_read", m.bytesRead)
+	m.Set("datanode_reads", m.reads)
+	m.Set("datanode_bytes_written", m.bytesWritten)
+	m.Set("datanode_packets_written", m.packetsWritten)
+	m.Set("datanode_packets_resent", m.packetsResent)
+	m.Set("datanode_errors", m.datanodeErrors)
+	expvar.Publish(name, m.Map)
+	return m
+}
+
+// NamenodeRequest implements Metrics.
+func (m *ExpvarMetrics) NamenodeRequest(method string, latency time.Duration, err error) {
+	m.namenodeRequests.Add(method, 1)
+	m.namenodeLatency.AddFloat(method, latency.Seconds())
+	if err != nil {
+		m.namenodeErrors.Add(method, 1)
+	}
+}
+
+// DatanodeRead implements Metrics.
+func (m *ExpvarMetrics) DatanodeRead(datanode string, n int, latency time.Duration) {
+	m.bytesRead.Add(int64(n))
+	m.reads.Add(1)
+}
+
+// DatanodeWrite implements Metrics.
+func (m *ExpvarMetrics) DatanodeWrite(datanode string, n int, latency time.Duration) {
+	m.bytesWritten.Add(int64(n))
+	m.packetsWritten.Add(1)
+}
+
+// DatanodeResend implements Metrics.
+func (m *ExpvarMetrics) DatanodeResend(datanode string, n int) {
+	m.packetsResent.Add(1)
+}
+
+// DatanodeError implements Metrics.
+func (m *ExpvarMetrics) DatanodeError(op string, datanode string, err error) {
+	m.datanodeErrors.Add(op, 1)
+}
@@ -0,0 +1,130 @@
+package hdfs
+
+import (
+	"errors"
+	"expvar"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/colinmarc/hdfs/v2/hadoopconf"
+	"github.com/colinmarc/hdfs/v2/internal/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingMetrics counts what's reported to it.
+type recordingMetrics struct {
+	lock         sync.Mutex
+	requests     map[string]int
+	bytesRead    int
+	bytesWritten int
+	resent       int
+	errors       map[string]int
+}
+
+func newRecordingMetrics() *recordingMetrics {
+	return &recordingMetrics{requests: make(map[string]int), errors: make(map[string]int)}
+}
+
+func (m *recordingMetrics) NamenodeRequest(method string, latency time.Duration, err error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.requests[method]++
+}
+
+func (m *recordingMetrics) DatanodeRead(datanode string, n int, latency time.Duration) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.bytesRead += n
+}
+
+func (m *recordingMetrics) DatanodeWrite(datanode string, n int, latency time.Duration) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.bytesWritten += n
+}
+
+func (m *recordingMetrics) DatanodeResend(datanode string, n int) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.resent++
+}
+
+func (m *recordingMetrics) DatanodeError(op string, datanode string, err error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.errors[op]++
+}
+
+func TestMetrics(t *testing.T) {
+	conf, err := hadoopconf.LoadFromEnvironment()
+	if err != nil || conf == nil {
+		t.Fatal("Couldn't load ambient config", err)
+	}
+
+	metrics := newRecordingMetrics()
+	options := ClientOptionsFromConf(conf)
+	options.User = "gohdfs1"
+	options.Metrics = metrics
+
+	client, err := NewClient(options)
+	require.NoError(t, err)
+	defer client.Close()
+
+	baleet(t, "/_test/metrics.txt")
+	w, err := client.Create("/_test/metrics.txt")
+	require.NoError(t, err)
+	_, err = w.Write([]byte("foobar"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	b, err := client.ReadFile("/_test/metrics.txt")
+	require.NoError(t, err)
+	assert.Equal(t, "foobar", string(b))
+
+	metrics.lock.Lock()
+	defer metrics.lock.Unlock()
+	assert.Equal(t, 1, metrics.requests["create"])
+	assert.Equal(t, 1, metrics.requests["complete"])
+	assert.True(t, metrics.requests["getBlockLocations"] > 0)
+	assert.Equal(t, 6, metrics.bytesRead)
+	assert.Equal(t, 6, metrics.bytesWritten)
+	assert.Empty(t, metrics.errors)
+}
+
+func TestReportWriteEvent(t *testing.T) {
+	metrics := newRecordingMetrics()
+	hook := rpcWriteHook(nil, metrics)
+	require.NotNil(t, hook)
+
+	hook(rpc.WriteEvent{Type: rpc.PacketSent, Pipeline: []string{"dn1:9866"}, Bytes: 10})
+	hook(rpc.WriteEvent{Type: rpc.AckReceived, Pipeline: []string{"dn1:9866"}, Bytes: 10})
+	hook(rpc.WriteEvent{Type: rpc.PacketResent, Pipeline: []string{"dn2:9866"}, Bytes: 10})
+	hook(rpc.WriteEvent{Type: rpc.HeartbeatFailed, FailedDatanode: "dn1:9866", Err: errors.New("broken pipe")})
+	hook(rpc.WriteEvent{Type: rpc.PipelineFailed, FailedDatanode: "dn1:9866", Err: errors.New("broken pipe")})
+
+	assert.Equal(t, 10, metrics.bytesWritten)
+	assert.Equal(t, 1, metrics.resent)
+	assert.Equal(t, map[string]int{"heartbeat": 1, "write": 1}, metrics.errors)
+	assert.Nil(t, rpcWriteHook(nil, nil))
+}
+
+func TestExpvarMetrics(t *testing.T) {
+	m := NewExpvarMetrics("hdfs_test_metrics")
+	m.NamenodeRequest("getFileInfo", time.Second, nil)
+	m.NamenodeRequest("getFileInfo", time.Second, errors.New("standby"))
+	m.DatanodeRead("dn1:9866", 100, time.Millisecond)
+	m.DatanodeWrite("dn1:9866", 50, time.Millisecond)
+	m.DatanodeResend("dn2:9866", 50)
+	m.DatanodeError("read", "dn1:9866", errors.New("connection refused"))
+
+	assert.Equal(t, m.Map, expvar.Get("hdfs_test_metrics"))
+	assert.Equal(t, "2", m.namenodeRequests.Get("getFileInfo").String())
+	assert.Equal(t, "1", m.namenodeErrors.Get("getFileInfo").String())
+	assert.Equal(t, "2", m.namenodeLatency.Get("getFileInfo").String())
+	assert.Equal(t, "100", m.Get("datanode_bytes_read").String())
+	assert.Equal(t, "50", m.Get("datanode_bytes_written").String())
+	assert.Equal(t, "1", m.Get("datanode_packets_resent").String())
+	assert.Equal(t, "1", m.datanodeErrors.Get("read").String())
+}
@@ -21,7 +21,7 @@ if [ ! -f "$MINICLUSTER_JAR" ]; then
 fi
 
 echo "Starting minicluster..."
-$HADOOP_HOME/bin/hadoop jar $MINICLUSTER_JAR minicluster -nnport $NN_PORT -datanodes 3 -nomr -format "$@" > minicluster.log 2>&1 &
+$HADOOP_HOME/bin/hadoop jar $MINICLUSTER_JAR minicluster -nnport $NN_PORT -datanodes 3 -nomr -format -D dfs.namenode.acls.enabled=true "$@" > minicluster.log 2>&1 &
 
 export HADOOP_CONF_DIR=$(mktemp -d)
 cat > $HADOOP_CONF_DIR/core-site.xml <<EOF
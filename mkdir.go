@@ -3,12 +3,14 @@ package hdfs
 import (
 	"os"
 	"path"
+	"syscall"
 
 	hdfs "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_hdfs"
 	"github.com/golang/protobuf/proto"
 )
 
-// Mkdir creates a new directory with the specified name and permission bits.
+// Mkdir creates a new directory with the specified name and permission bits
+// (with the client's Umask applied).
 func (c *Client) Mkdir(dirname string, perm os.FileMode) error {
 	return c.mkdir(dirname, perm, false)
 }
@@ -21,6 +23,58 @@ func (c *Client) MkdirAll(dirname string, perm os.FileMode) error {
 	return c.mkdir(dirname, perm, true)
 }
 
+// MkdirAllWithPerm is like MkdirAll, but it creates each missing directory
+// along the way individually, so that every one of them gets the permission
+// bits perm (with the client's Umask applied), rather than just the last.
+// If dirname is already a directory, MkdirAllWithPerm does nothing and
+// returns nil. If it, or any of its parents, already exists as a file, the
+// returned error wraps syscall.ENOTDIR.
+func (c *Client) MkdirAllWithPerm(dirname string, perm os.FileMode) error {
+	dirname = path.Clean(dirname)
+
+	var components []string
+	for p := dirname; p != "/" && p != "."; p = path.Dir(p) {
+		components = append(components, p)
+	}
+
+	// Find the deepest component that already exists, checking that it's a
+	// directory.
+	i := 0
+	for ; i < len(components); i++ {
+		info, err := c.getFileInfo(components[i])
+		err = interpretException(err)
+		if err == nil {
+			if !info.IsDir() {
+				return &os.PathError{"mkdir", components[i], syscall.ENOTDIR}
+			}
+
+			break
+		} else if !os.IsNotExist(err) {
+			return &os.PathError{"mkdir", components[i], err}
+		}
+	}
+
+	// Then create the rest, from the top down.
+	for i--; i >= 0; i-- {
+		err := c.mkdir(components[i], perm, false)
+		if os.IsExist(err) {
+			// Someone else may have created it in the meantime.
+			info, statErr := c.getFileInfo(components[i])
+			if statErr == nil && info.IsDir() {
+				continue
+			} else if statErr == nil {
+				return &os.PathError{"mkdir", components[i], syscall.ENOTDIR}
+			}
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (c *Client) mkdir(dirname string, perm os.FileMode, createParent bool) error {
 	dirname = path.Clean(dirname)
 
@@ -36,9 +90,13 @@ func (c *Client) mkdir(dirname string, perm os.FileMode, createParent bool) erro
 		return &os.PathError{"mkdir", dirname, err}
 	}
 
+	if c.web != nil {
+		return c.mkdirWeb(dirname, perm, createParent)
+	}
+
 	req := &hdfs.MkdirsRequestProto{
 		Src:          proto.String(dirname),
-		Masked:       &hdfs.FsPermissionProto{Perm: proto.Uint32(uint32(perm))},
+		Masked:       &hdfs.FsPermissionProto{Perm: proto.Uint32(uint32(perm &^ c.options.Umask.Perm()))},
 		CreateParent: proto.Bool(createParent),
 	}
 	resp := &hdfs.MkdirsResponseProto{}
@@ -2,6 +2,7 @@ package hdfs
 
 import (
 	"os"
+	"syscall"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -77,6 +78,55 @@ func TestMkdirAllExists(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestMkdirAllWithPerm(t *testing.T) {
+	client := getClient(t)
+
+	baleet(t, "/_test/dir5")
+
+	err := client.MkdirAllWithPerm("/_test/dir5/foo/bar", 0750|os.ModeDir)
+	require.NoError(t, err)
+
+	for _, p := range []string{"/_test/dir5", "/_test/dir5/foo", "/_test/dir5/foo/bar"} {
+		fi, err := client.Stat(p)
+		require.NoError(t, err)
+		assert.True(t, fi.IsDir())
+		assert.EqualValues(t, 0750, fi.Mode().Perm(), p)
+	}
+
+	err = client.MkdirAllWithPerm("/_test/dir5/foo/bar", 0750|os.ModeDir)
+	require.NoError(t, err)
+}
+
+func TestMkdirAllWithPermUmask(t *testing.T) {
+	client := newClientWithOptions(t, "gohdfs1", func(options *ClientOptions) {
+		options.Umask = 027
+	})
+	defer client.Close()
+
+	baleet(t, "/_test/dir6")
+
+	err := client.MkdirAllWithPerm("/_test/dir6/foo", mode)
+	require.NoError(t, err)
+
+	fi, err := client.Stat("/_test/dir6/foo")
+	require.NoError(t, err)
+	assert.EqualValues(t, 0750, fi.Mode().Perm())
+}
+
+func TestMkdirAllWithPermFile(t *testing.T) {
+	client := getClient(t)
+
+	baleet(t, "/_test/dir7")
+	mkdirp(t, "/_test/dir7")
+	touch(t, "/_test/dir7/file")
+
+	err := client.MkdirAllWithPerm("/_test/dir7/file/foo", mode)
+	assertPathError(t, err, "mkdir", "/_test/dir7/file", syscall.ENOTDIR)
+
+	err = client.MkdirAllWithPerm("/_test/dir7/file", mode)
+	assertPathError(t, err, "mkdir", "/_test/dir7/file", syscall.ENOTDIR)
+}
+
 func TestMkdirWIthoutPermission(t *testing.T) {
 	client := getClient(t)
 	client2 := getClientForUser(t, "gohdfs2")
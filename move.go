@@ -0,0 +1,157 @@
+package hdfs
+
+import (
+	"errors"
+	"os"
+	"path"
+	"strings"
+	"syscall"
+)
+
+// ConflictPolicy specifies what MoveMerge does when a file being moved already
+// exists at the destination.
+type ConflictPolicy int
+
+const (
+	// ConflictFail makes MoveMerge return an error, without moving anything.
+	ConflictFail ConflictPolicy = iota
+	// ConflictOverwrite replaces the existing file with the one being moved.
+	ConflictOverwrite
+	// ConflictSkip leaves both files where they are.
+	ConflictSkip
+)
+
+// MoveResult describes what MoveMerge did with a single file or directory.
+type MoveResult struct {
+	Source string
+	Dest   string
+	// Overwritten is true if an existing file at Dest was replaced.
+	Overwritten bool
+	// Skipped is true if Source was left in place because of a conflict.
+	Skipped bool
+	// Err is set if moving Source failed.
+	Err error
+}
+
+type moveOp struct {
+	result MoveResult
+	// removeDest specifies that Dest should be removed (recursively) before
+	// moving Source there, because it's of a different type.
+	removeDest bool
+}
+
+// MoveMerge moves src to dst. If dst doesn't exist, that's a single rename.
+// Otherwise, if both are directories, the contents of src are merged into dst
+// recursively, and src is removed once it's empty. Files (or a file and a
+// directory) that exist at both are conflicts, which are handled according to
+// policy. With ConflictFail, the whole tree is checked for conflicts before
+// anything is moved.
+//
+// It returns a result for each entry that was moved (which may be a whole
+// subdirectory, if it didn't exist at the destination yet), skipped, or that
+// failed to move. Failing to move one entry doesn't stop the others from being
+// moved; the returned error is the first one encountered.
+func (c *Client) MoveMerge(src, dst string, policy ConflictPolicy) ([]MoveResult, error) {
+	src = path.Clean(src)
+	dst = path.Clean(dst)
+	if dst == src || strings.HasPrefix(dst, src+"/") {
+		return nil, &os.PathError{"move", dst, errors.New("can't move a directory into itself")}
+	}
+
+	srcInfo, err := c.Stat(src)
+	if err != nil {
+		return nil, err
+	}
+
+	var ops []moveOp
+	var dirs []string
+	err = c.planMove(src, dst, srcInfo, policy, &ops, &dirs)
+	if err != nil {
+		return nil, err
+	}
+
+	var firstErr error
+	results := make([]MoveResult, 0, len(ops))
+	for _, op := range ops {
+		if !op.result.Skipped {
+			op.result.Err = c.move(op)
+			if op.result.Err != nil && firstErr == nil {
+				firstErr = op.result.Err
+			}
+		}
+
+		results = append(results, op.result)
+	}
+
+	// Remove the source directories that are now empty, deepest first.
+	// Directories that still contain skipped or failed entries are left.
+	for i := len(dirs) - 1; i >= 0; i-- {
+		err = c.Remove(dirs[i])
+		if err != nil && !errors.Is(err, syscall.ENOTEMPTY) && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return results, firstErr
+}
+
+// planMove works out what needs to happen to move src to dst, appending the
+// renames to ops, and any source directories that are merged into existing
+// ones to dirs.
+func (c *Client) planMove(src, dst string, srcInfo os.FileInfo, policy ConflictPolicy, ops *[]moveOp, dirs *[]string) error {
+	dstInfo, err := c.getFileInfo(dst)
+	err = interpretException(err)
+	if os.IsNotExist(err) {
+		*ops = append(*ops, moveOp{result: MoveResult{Source: src, Dest: dst}})
+		return nil
+	} else if err != nil {
+		return &os.PathError{"move", dst, err}
+	}
+
+	if srcInfo.IsDir() && dstInfo.IsDir() {
+		children, err := c.ReadDir(src)
+		if err != nil {
+			return err
+		}
+
+		for _, child := range children {
+			err = c.planMove(path.Join(src, child.Name()), path.Join(dst, child.Name()), child, policy, ops, dirs)
+			if err != nil {
+				return err
+			}
+		}
+
+		*dirs = append(*dirs, src)
+		return nil
+	}
+
+	op := moveOp{result: MoveResult{Source: src, Dest: dst}}
+	switch policy {
+	case ConflictFail:
+		return &os.PathError{"move", dst, os.ErrExist}
+	case ConflictOverwrite:
+		op.result.Overwritten = true
+		op.removeDest = srcInfo.IsDir() || dstInfo.IsDir()
+	case ConflictSkip:
+		op.result.Skipped = true
+	}
+
+	*ops = append(*ops, op)
+	return nil
+}
+
+func (c *Client) move(op moveOp) error {
+	if op.removeDest {
+		err := c.RemoveAll(op.result.Dest)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := c.rename2(op.result.Source, op.result.Dest, op.result.Overwritten)
+	if err != nil {
+		return &os.PathError{"move", op.result.Source, err}
+	}
+
+	return nil
+}
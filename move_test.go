@@ -0,0 +1,105 @@
+package hdfs
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupMoveMerge(t *testing.T, client *Client) {
+	mkdirp(t, "/_test/movemerge/src/sub")
+	mkdirp(t, "/_test/movemerge/dst/sub")
+	writeConcatTestFile(t, client, "/_test/movemerge/src/a", "src")
+	writeConcatTestFile(t, client, "/_test/movemerge/src/sub/b", "src")
+	writeConcatTestFile(t, client, "/_test/movemerge/src/sub/c", "src")
+	writeConcatTestFile(t, client, "/_test/movemerge/dst/sub/c", "dst")
+	writeConcatTestFile(t, client, "/_test/movemerge/dst/d", "dst")
+}
+
+func assertContents(t *testing.T, client *Client, name, expected string) {
+	bytes, err := client.ReadFile(name)
+	require.NoError(t, err)
+	assert.Equal(t, expected, string(bytes))
+}
+
+func TestMoveMergeRename(t *testing.T) {
+	client := getClient(t)
+
+	mkdirp(t, "/_test/movemerge")
+	writeConcatTestFile(t, client, "/_test/movemerge/foo", "foo")
+
+	results, err := client.MoveMerge("/_test/movemerge/foo", "/_test/movemerge/bar", ConflictFail)
+	require.NoError(t, err)
+	assert.Equal(t, []MoveResult{{Source: "/_test/movemerge/foo", Dest: "/_test/movemerge/bar"}}, results)
+	assertContents(t, client, "/_test/movemerge/bar", "foo")
+}
+
+func TestMoveMergeConflictFail(t *testing.T) {
+	client := getClient(t)
+
+	baleet(t, "/_test/movemerge")
+	setupMoveMerge(t, client)
+
+	_, err := client.MoveMerge("/_test/movemerge/src", "/_test/movemerge/dst", ConflictFail)
+	assertPathError(t, err, "move", "/_test/movemerge/dst/sub/c", os.ErrExist)
+
+	// Nothing should have been moved.
+	assertContents(t, client, "/_test/movemerge/src/a", "src")
+	assertContents(t, client, "/_test/movemerge/src/sub/b", "src")
+}
+
+func TestMoveMergeConflictOverwrite(t *testing.T) {
+	client := getClient(t)
+
+	baleet(t, "/_test/movemerge")
+	setupMoveMerge(t, client)
+
+	results, err := client.MoveMerge("/_test/movemerge/src", "/_test/movemerge/dst", ConflictOverwrite)
+	require.NoError(t, err)
+	assert.Equal(t, []MoveResult{
+		{Source: "/_test/movemerge/src/a", Dest: "/_test/movemerge/dst/a"},
+		{Source: "/_test/movemerge/src/sub/b", Dest: "/_test/movemerge/dst/sub/b"},
+		{Source: "/_test/movemerge/src/sub/c", Dest: "/_test/movemerge/dst/sub/c", Overwritten: true},
+	}, results)
+
+	assertContents(t, client, "/_test/movemerge/dst/a", "src")
+	assertContents(t, client, "/_test/movemerge/dst/sub/b", "src")
+	assertContents(t, client, "/_test/movemerge/dst/sub/c", "src")
+	assertContents(t, client, "/_test/movemerge/dst/d", "dst")
+
+	_, err = client.Stat("/_test/movemerge/src")
+	assertPathError(t, err, "stat", "/_test/movemerge/src", os.ErrNotExist)
+}
+
+func TestMoveMergeConflictSkip(t *testing.T) {
+	client := getClient(t)
+
+	baleet(t, "/_test/movemerge")
+	setupMoveMerge(t, client)
+
+	results, err := client.MoveMerge("/_test/movemerge/src", "/_test/movemerge/dst", ConflictSkip)
+	require.NoError(t, err)
+	assert.Equal(t, []MoveResult{
+		{Source: "/_test/movemerge/src/a", Dest: "/_test/movemerge/dst/a"},
+		{Source: "/_test/movemerge/src/sub/b", Dest: "/_test/movemerge/dst/sub/b"},
+		{Source: "/_test/movemerge/src/sub/c", Dest: "/_test/movemerge/dst/sub/c", Skipped: true},
+	}, results)
+
+	assertContents(t, client, "/_test/movemerge/dst/a", "src")
+	assertContents(t, client, "/_test/movemerge/dst/sub/c", "dst")
+	assertContents(t, client, "/_test/movemerge/src/sub/c", "src")
+
+	_, err = client.Stat("/_test/movemerge/src/a")
+	assertPathError(t, err, "stat", "/_test/movemerge/src/a", os.ErrNotExist)
+}
+
+func TestMoveMergeIntoItself(t *testing.T) {
+	client := getClient(t)
+
+	mkdirp(t, "/_test/movemerge/src")
+
+	_, err := client.MoveMerge("/_test/movemerge", "/_test/movemerge/src", ConflictFail)
+	require.Error(t, err)
+}
@@ -0,0 +1,37 @@
+package hdfs
+
+import "time"
+
+// NamenodeLatency describes the latency measured for a single namenode, when
+// ClientOptions.NamenodeLatencyProbeInterval is set.
+type NamenodeLatency struct {
+	// Address is the address (<host>:<port>) of the namenode.
+	Address string
+	// Latency is the moving average of the round-trip time of the probes sent
+	// to the namenode. It's zero if the namenode hasn't answered one yet.
+	Latency time.Duration
+	// Err is the error returned by the last probe, if it failed. For a
+	// namenode in standby, it's a StandbyException.
+	Err error
+}
+
+// NamenodeLatencies returns the latency measured for each namenode, sorted by
+// address. It returns nil unless ClientOptions.NamenodeLatencyProbeInterval is
+// set, and there's more than one namenode.
+func (c *Client) NamenodeLatencies() []NamenodeLatency {
+	latencies := c.namenode.Latencies()
+	if latencies == nil {
+		return nil
+	}
+
+	res := make([]NamenodeLatency, len(latencies))
+	for i, l := range latencies {
+		res[i] = NamenodeLatency{
+			Address: l.Address,
+			Latency: l.Latency,
+			Err:     interpretException(l.Err),
+		}
+	}
+
+	return res
+}
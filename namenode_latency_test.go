@@ -0,0 +1,11 @@
+package hdfs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNamenodeLatenciesDisabled(t *testing.T) {
+	assert.Nil(t, getClient(t).NamenodeLatencies())
+}
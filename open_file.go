@@ -0,0 +1,172 @@
+package hdfs
+
+import (
+	"errors"
+	"io"
+	"os"
+)
+
+var (
+	errNotOpenForReading = errors.New("file not opened for reading")
+	errNotOpenForWriting = errors.New("file not opened for writing")
+	errWriteInPlace      = errors.New("can't write to an existing file without O_TRUNC or O_APPEND")
+)
+
+// File is a file opened with OpenFile. Like an *os.File, it has methods for
+// both reading and writing, but only the ones that match the flags it was
+// opened with work; the others return an error. Since HDFS files can't be read
+// and written at the same time, a File opened with O_WRONLY or O_RDWR can only
+// be written to.
+type File struct {
+	name   string
+	reader *FileReader
+	writer *FileWriter
+}
+
+// OpenFile is the generalized open call, modeled on os.OpenFile. It opens the
+// named file for reading with O_RDONLY, and for writing with O_WRONLY or
+// O_RDWR. O_CREATE creates the file with perm (before the umask) if it
+// doesn't exist, and O_EXCL, used with O_CREATE, requires that it doesn't.
+// O_TRUNC replaces the contents of an existing file, and O_APPEND appends to
+// it. Since HDFS files can't be modified in place, opening an existing file
+// for writing requires one of the two.
+func (c *Client) OpenFile(name string, flag int, perm os.FileMode) (*File, error) {
+	_, err := c.getFileInfo(name)
+	err = interpretException(err)
+	exists := err == nil
+	if err != nil && !os.IsNotExist(err) {
+		return nil, &os.PathError{"open", name, err}
+	}
+
+	create := flag&os.O_CREATE != 0
+	if exists && create && flag&os.O_EXCL != 0 {
+		return nil, &os.PathError{"open", name, os.ErrExist}
+	} else if !exists && !create {
+		return nil, &os.PathError{"open", name, os.ErrNotExist}
+	}
+
+	if flag&(os.O_WRONLY|os.O_RDWR) == 0 {
+		if !exists || flag&os.O_TRUNC != 0 {
+			w, err := c.CreateWithOptions(name, CreateOptions{Perm: perm, Overwrite: exists})
+			if err != nil {
+				return nil, err
+			}
+
+			err = w.Close()
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		r, err := c.Open(name)
+		if err != nil {
+			return nil, err
+		}
+
+		return &File{name: name, reader: r}, nil
+	}
+
+	var w *FileWriter
+	if !exists || flag&os.O_TRUNC != 0 {
+		w, err = c.CreateWithOptions(name, CreateOptions{Perm: perm, Overwrite: exists})
+	} else if flag&os.O_APPEND != 0 {
+		w, err = c.Append(name)
+	} else {
+		return nil, &os.PathError{"open", name, errWriteInPlace}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &File{name: name, writer: w}, nil
+}
+
+// Name returns the name of the file, as passed to OpenFile.
+func (f *File) Name() string {
+	return f.name
+}
+
+// Reader returns the underlying FileReader, or nil if the file was opened for
+// writing.
+func (f *File) Reader() *FileReader {
+	return f.reader
+}
+
+// Writer returns the underlying FileWriter, or nil if the file was opened for
+// reading.
+func (f *File) Writer() *FileWriter {
+	return f.writer
+}
+
+// Read implements io.Reader.
+func (f *File) Read(b []byte) (int, error) {
+	if f.reader == nil {
+		return 0, &os.PathError{"read", f.name, errNotOpenForReading}
+	}
+
+	return f.reader.Read(b)
+}
+
+// ReadAt implements io.ReaderAt.
+func (f *File) ReadAt(b []byte, off int64) (int, error) {
+	if f.reader == nil {
+		return 0, &os.PathError{"read", f.name, errNotOpenForReading}
+	}
+
+	return f.reader.ReadAt(b, off)
+}
+
+// Seek implements io.Seeker. Files opened for writing can only "seek" to
+// where they already are, as with FileWriter.Seek.
+func (f *File) Seek(offset int64, whence int) (int64, error) {
+	if f.writer != nil {
+		return f.writer.Seek(offset, whence)
+	}
+
+	return f.reader.Seek(offset, whence)
+}
+
+// Write implements io.Writer.
+func (f *File) Write(b []byte) (int, error) {
+	if f.writer == nil {
+		return 0, &os.PathError{"write", f.name, errNotOpenForWriting}
+	}
+
+	return f.writer.Write(b)
+}
+
+// WriteString is like Write, but writes the contents of s.
+func (f *File) WriteString(s string) (int, error) {
+	return f.Write([]byte(s))
+}
+
+// ReadFrom implements io.ReaderFrom.
+func (f *File) ReadFrom(r io.Reader) (int64, error) {
+	if f.writer == nil {
+		return 0, &os.PathError{"write", f.name, errNotOpenForWriting}
+	}
+
+	return f.writer.ReadFrom(r)
+}
+
+// Sync flushes any data written so far out to the datanodes, and has them sync
+// it to disk, as with FileWriter.Sync. For files opened for reading, it does
+// nothing.
+func (f *File) Sync() error {
+	if f.writer == nil {
+		return nil
+	}
+
+	return f.writer.Sync()
+}
+
+// Close closes the file. For files opened for writing, it is important that
+// Close is called after all data has been written.
+func (f *File) Close() error {
+	if f.writer != nil {
+		return f.writer.Close()
+	}
+
+	return f.reader.Close()
+}
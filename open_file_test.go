@@ -0,0 +1,126 @@
+package hdfs
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenFileReadOnly(t *testing.T) {
+	client := getClient(t)
+
+	f, err := client.OpenFile("/_test/foo.txt", os.O_RDONLY, 0)
+	require.NoError(t, err)
+	defer f.Close()
+
+	bytes, err := ioutil.ReadAll(f)
+	require.NoError(t, err)
+	assert.EqualValues(t, "bar\n", string(bytes))
+
+	_, err = f.Write([]byte("foo"))
+	assertPathError(t, err, "write", "/_test/foo.txt", errNotOpenForWriting)
+}
+
+func TestOpenFileCreate(t *testing.T) {
+	client := getClient(t)
+
+	mkdirp(t, "/_test/openfile")
+	f, err := client.OpenFile("/_test/openfile/1.txt", os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	require.NoError(t, err)
+
+	_, err = f.WriteString("foo")
+	require.NoError(t, err)
+
+	_, err = f.Read(make([]byte, 3))
+	assertPathError(t, err, "read", "/_test/openfile/1.txt", errNotOpenForReading)
+
+	err = f.Close()
+	require.NoError(t, err)
+
+	bytes, err := client.ReadFile("/_test/openfile/1.txt")
+	require.NoError(t, err)
+	assert.EqualValues(t, "foo", string(bytes))
+
+	fi, err := client.Stat("/_test/openfile/1.txt")
+	require.NoError(t, err)
+	assert.EqualValues(t, 0600, fi.Mode().Perm())
+
+	_, err = client.OpenFile("/_test/openfile/1.txt", os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	assertPathError(t, err, "open", "/_test/openfile/1.txt", os.ErrExist)
+}
+
+func TestOpenFileTruncate(t *testing.T) {
+	client := getClient(t)
+
+	mkdirp(t, "/_test/openfile")
+	writeConcatTestFile(t, client, "/_test/openfile/2.txt", "foobar")
+
+	// This is what os.Create does.
+	f, err := client.OpenFile("/_test/openfile/2.txt", os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0666)
+	require.NoError(t, err)
+
+	_, err = f.WriteString("baz")
+	require.NoError(t, err)
+
+	err = f.Close()
+	require.NoError(t, err)
+
+	bytes, err := client.ReadFile("/_test/openfile/2.txt")
+	require.NoError(t, err)
+	assert.EqualValues(t, "baz", string(bytes))
+}
+
+func TestOpenFileAppend(t *testing.T) {
+	client := getClient(t)
+
+	mkdirp(t, "/_test/openfile")
+	writeConcatTestFile(t, client, "/_test/openfile/3.txt", "foo")
+
+	f, err := client.OpenFile("/_test/openfile/3.txt", os.O_WRONLY|os.O_APPEND, 0)
+	require.NoError(t, err)
+
+	_, err = f.WriteString("bar")
+	require.NoError(t, err)
+
+	err = f.Close()
+	require.NoError(t, err)
+
+	bytes, err := client.ReadFile("/_test/openfile/3.txt")
+	require.NoError(t, err)
+	assert.EqualValues(t, "foobar", string(bytes))
+}
+
+func TestOpenFileWriteInPlace(t *testing.T) {
+	client := getClient(t)
+
+	mkdirp(t, "/_test/openfile")
+	writeConcatTestFile(t, client, "/_test/openfile/4.txt", "foo")
+
+	_, err := client.OpenFile("/_test/openfile/4.txt", os.O_WRONLY, 0)
+	assertPathError(t, err, "open", "/_test/openfile/4.txt", errWriteInPlace)
+}
+
+func TestOpenFileNonexistent(t *testing.T) {
+	client := getClient(t)
+
+	baleet(t, "/_test/nonexistent")
+
+	_, err := client.OpenFile("/_test/nonexistent", os.O_WRONLY|os.O_APPEND, 0)
+	assertPathError(t, err, "open", "/_test/nonexistent", os.ErrNotExist)
+}
+
+func TestOpenFileReadOnlyCreate(t *testing.T) {
+	client := getClient(t)
+
+	mkdirp(t, "/_test/openfile")
+	f, err := client.OpenFile("/_test/openfile/5.txt", os.O_RDONLY|os.O_CREATE, 0644)
+	require.NoError(t, err)
+	defer f.Close()
+
+	fi, err := client.Stat("/_test/openfile/5.txt")
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, fi.Size())
+}
@@ -0,0 +1,95 @@
+package hdfs
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	hdfs "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_hdfs"
+	"github.com/golang/protobuf/proto"
+)
+
+const standbyException = "org.apache.hadoop.ipc.StandbyException"
+
+// PingStatus describes the state of the namenode, as reported by Ping.
+type PingStatus int
+
+const (
+	// PingOK means the active namenode responded, and isn't in safe mode.
+	PingOK PingStatus = iota
+	// PingSafeMode means the active namenode responded, but is in safe mode,
+	// so the filesystem is read-only.
+	PingSafeMode
+	// PingStandby means that every namenode that responded is a standby, so
+	// requests can't be served until one of them becomes active.
+	PingStandby
+	// PingUnreachable means that no namenode could be reached, or the request
+	// failed for some other reason.
+	PingUnreachable
+)
+
+func (s PingStatus) String() string {
+	switch s {
+	case PingOK:
+		return "ok"
+	case PingSafeMode:
+		return "safemode"
+	case PingStandby:
+		return "standby"
+	default:
+		return "unreachable"
+	}
+}
+
+// PingResult is the result of a call to Ping.
+type PingResult struct {
+	Status PingStatus
+	// Latency is how long the round trip to the namenode took, including any
+	// failover.
+	Latency time.Duration
+	// Err is the error that caused a status other than PingOK or PingSafeMode.
+	Err error
+}
+
+// Ping makes a cheap request to the namenode, to check that the client can
+// reach it, as a readiness check for services that depend on the cluster. The
+// request goes through the client's own connection, failing over to another
+// namenode like any other request would.
+//
+// If ctx is done before the namenode responds, Ping returns PingUnreachable
+// with ctx.Err(). If the request had already been sent, the connection to the
+// namenode is closed, so that it doesn't hold up other requests; the next one
+// reconnects. Like the other Context methods, Ping doesn't stop waiting for a
+// request already in progress on the connection, though.
+func (c *Client) Ping(ctx context.Context) PingResult {
+	start := time.Now()
+	res := c.ping(ctx)
+	res.Latency = time.Since(start)
+	return res
+}
+
+func (c *Client) ping(ctx context.Context) PingResult {
+	// Getting the safe mode status with checked set is cheap, and is refused by
+	// standby namenodes.
+	req := &hdfs.SetSafeModeRequestProto{
+		Action:  hdfs.SafeModeActionProto_SAFEMODE_GET.Enum(),
+		Checked: proto.Bool(true),
+	}
+	resp := &hdfs.SetSafeModeResponseProto{}
+
+	err := c.namenode.ExecuteContext(ctx, "setSafeMode", req, resp)
+	if err != nil {
+		var remoteErr Error
+		if errors.As(err, &remoteErr) && remoteErr.Exception() == standbyException {
+			return PingResult{Status: PingStandby, Err: err}
+		}
+
+		return PingResult{Status: PingUnreachable, Err: interpretException(err)}
+	}
+
+	if resp.GetResult() {
+		return PingResult{Status: PingSafeMode}
+	}
+
+	return PingResult{Status: PingOK}
+}
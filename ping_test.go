@@ -0,0 +1,71 @@
+package hdfs
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPing(t *testing.T) {
+	client := getClient(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	res := client.Ping(ctx)
+	assert.Equal(t, PingOK, res.Status)
+	assert.NoError(t, res.Err)
+	assert.True(t, res.Latency > 0)
+}
+
+func TestPingClosed(t *testing.T) {
+	client := newClientForUser(t, "gohdfs1")
+	client.Close()
+
+	res := client.Ping(context.Background())
+	assert.Equal(t, PingUnreachable, res.Status)
+	assert.Equal(t, ErrClientClosed, res.Err)
+	assert.Equal(t, "unreachable", res.Status.String())
+}
+
+func TestPingTimeout(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skip("Couldn't listen on localhost:", err)
+	}
+	defer l.Close()
+
+	// The "namenode" accepts the connection, but never responds.
+	accepted := make(chan net.Conn, 1)
+	go func() {
+		conn, err := l.Accept()
+		if err == nil {
+			accepted <- conn
+		}
+	}()
+
+	client, err := NewClient(ClientOptions{Addresses: []string{l.Addr().String()}, User: "gohdfs1"})
+	require.NoError(t, err)
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	res := client.Ping(ctx)
+	assert.Equal(t, PingUnreachable, res.Status)
+	assert.True(t, errors.Is(res.Err, context.DeadlineExceeded), res.Err)
+
+	// The request was abandoned, rather than left blocking the connection.
+	conn := <-accepted
+	defer conn.Close()
+	conn.SetReadDeadline(time.Now().Add(10 * time.Second))
+	_, err = io.Copy(ioutil.Discard, conn)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,21 @@
+package hdfs
+
+// Progress describes how far along a read or write is. It is passed to the
+// function registered with FileReader.SetProgressFunc or
+// FileWriter.SetProgressFunc.
+type Progress struct {
+	// Bytes is the total number of bytes read or written so far. For writes,
+	// this includes data which is still buffered and hasn't been acknowledged
+	// by the datanodes yet; see FileWriter.VisibleLength.
+	Bytes int64
+	// BlockID is the ID of the block currently being read or written.
+	BlockID uint64
+	// Datanode is the address of the datanode currently being read from, or
+	// the first datanode in the pipeline for writes.
+	Datanode string
+}
+
+// ProgressFunc is called with the current Progress of a read or write, every
+// time data is read or written. It is called synchronously, so it should
+// return quickly.
+type ProgressFunc func(Progress)
@@ -0,0 +1,77 @@
+package hdfs
+
+import (
+	"errors"
+	"fmt"
+
+	hdfs "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_hdfs"
+)
+
+// ErrProvidedStorageAccess is returned (wrapped in a *ProvidedStorageError)
+// when a block on PROVIDED storage can't be read because the external store
+// refused access to it.
+var ErrProvidedStorageAccess = errors.New("access to provided storage denied")
+
+// ProvidedStorageError is returned when reading a block on PROVIDED storage
+// fails because the external store backing it (for example, an S3 bucket
+// mounted into HDFS) refused access. The datanodes read PROVIDED blocks from
+// the external store on the client's behalf, so this usually means they lack
+// the credentials the store requires. It matches ErrProvidedStorageAccess
+// with errors.Is.
+type ProvidedStorageError struct {
+	Name    string
+	BlockID uint64
+	// Err is the error returned by the datanode. It implements Error.
+	Err error
+}
+
+func (e *ProvidedStorageError) Error() string {
+	return fmt.Sprintf("reading block %d of %s from provided storage: %s", e.BlockID, e.Name, e.Err)
+}
+
+// Is implements errors.Is.
+func (e *ProvidedStorageError) Is(target error) bool {
+	return target == ErrProvidedStorageAccess
+}
+
+// Unwrap returns the error returned by the datanode.
+func (e *ProvidedStorageError) Unwrap() error {
+	return e.Err
+}
+
+// providedAccessExceptions are the exceptions the external stores used for
+// PROVIDED storage raise when they refuse access. Errors are only matched by
+// their class, since the messages of unrelated errors often mention access or
+// credentials too.
+var providedAccessExceptions = []string{
+	"java.nio.file.AccessDeniedException",
+	"org.apache.hadoop.fs.s3a.auth.NoAuthWithAWSException",
+	"org.apache.hadoop.security.AccessControlException",
+}
+
+// interpretProvidedError returns a *ProvidedStorageError if err was returned
+// by a datanode refused access to the external store backing a block on
+// PROVIDED storage. Otherwise, it returns err unchanged.
+func interpretProvidedError(name string, block *hdfs.LocatedBlockProto, err error) error {
+	provided := false
+	for _, t := range block.GetStorageTypes() {
+		if t == hdfs.StorageTypeProto_PROVIDED {
+			provided = true
+			break
+		}
+	}
+
+	var remoteErr Error
+	if !provided || !errors.As(err, &remoteErr) {
+		return err
+	}
+
+	exception := remoteErr.Exception()
+	for _, e := range providedAccessExceptions {
+		if exception == e {
+			return &ProvidedStorageError{Name: name, BlockID: block.GetB().GetBlockId(), Err: err}
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,55 @@
+package hdfs
+
+import (
+	"errors"
+	"testing"
+
+	hdfs "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_hdfs"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testRemoteError struct {
+	exception string
+	message   string
+}
+
+func (e testRemoteError) Method() string    { return "readBlock" }
+func (e testRemoteError) Desc() string      { return "ERROR" }
+func (e testRemoteError) Exception() string { return e.exception }
+func (e testRemoteError) Message() string   { return e.message }
+func (e testRemoteError) Error() string     { return e.message }
+
+func TestInterpretProvidedError(t *testing.T) {
+	block := testLocatedBlock()
+	block.B = &hdfs.ExtendedBlockProto{PoolId: proto.String("pool"), BlockId: proto.Uint64(1234)}
+	denied := testRemoteError{exception: "java.nio.file.AccessDeniedException", message: "s3a://bucket/key: 403"}
+
+	// The block isn't on PROVIDED storage.
+	assert.Equal(t, denied, interpretProvidedError("/foo", block, denied))
+
+	block.StorageTypes = []hdfs.StorageTypeProto{hdfs.StorageTypeProto_PROVIDED}
+	err := interpretProvidedError("/foo", block, denied)
+	require.IsType(t, &ProvidedStorageError{}, err)
+	assert.True(t, errors.Is(err, ErrProvidedStorageAccess))
+	assert.Equal(t, uint64(1234), err.(*ProvidedStorageError).BlockID)
+	assert.Equal(t, "/foo", err.(*ProvidedStorageError).Name)
+
+	var remoteErr Error
+	require.True(t, errors.As(err, &remoteErr))
+	assert.Equal(t, "java.nio.file.AccessDeniedException", remoteErr.Exception())
+
+	noCreds := testRemoteError{
+		exception: "org.apache.hadoop.fs.s3a.auth.NoAuthWithAWSException",
+		message:   "No AWS Credentials provided by any provider",
+	}
+	assert.True(t, errors.Is(interpretProvidedError("/foo", block, noCreds), ErrProvidedStorageAccess))
+
+	other := testRemoteError{exception: "java.io.IOException", message: "broken pipe"}
+	assert.Equal(t, other, interpretProvidedError("/foo", block, other))
+
+	// Only the exception class counts, not what the message says.
+	other = testRemoteError{exception: "java.io.IOException", message: "Permission denied: bad credentials cache"}
+	assert.Equal(t, other, interpretProvidedError("/foo", block, other))
+}
@@ -0,0 +1,206 @@
+package proxy
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+)
+
+// This implements just enough of the gRPC protocol over HTTP/2 to serve the
+// service, as described in doc/PROTOCOL-HTTP2.md in the gRPC repository.
+
+// Code is a gRPC status code.
+type Code int
+
+// These are the status codes the server returns.
+const (
+	OK                 Code = 0
+	Canceled           Code = 1
+	Unknown            Code = 2
+	InvalidArgument    Code = 3
+	DeadlineExceeded   Code = 4
+	NotFound           Code = 5
+	AlreadyExists      Code = 6
+	PermissionDenied   Code = 7
+	ResourceExhausted  Code = 8
+	FailedPrecondition Code = 9
+	OutOfRange         Code = 11
+	Unimplemented      Code = 12
+	Internal           Code = 13
+)
+
+const (
+	// maxMessageSize is the largest message accepted from a client, and the
+	// largest chunk size for reads.
+	maxMessageSize  = 4 * 1024 * 1024
+	grpcContentType = "application/grpc"
+)
+
+// Status is an error with a gRPC status code.
+type Status struct {
+	Code    Code
+	Message string
+}
+
+func (s *Status) Error() string {
+	return fmt.Sprintf("rpc error: code = %d desc = %s", s.Code, s.Message)
+}
+
+func statusf(code Code, format string, args ...interface{}) *Status {
+	return &Status{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+// stream is a single call.
+type stream struct {
+	w        http.ResponseWriter
+	r        *http.Request
+	gzip     bool
+	sentResp bool
+}
+
+// recv reads the next message from the client into m. It returns io.EOF once
+// the client has finished sending.
+func (s *stream) recv(m proto.Message) error {
+	var header [5]byte
+	_, err := io.ReadFull(s.r.Body, header[:])
+	if err == io.EOF {
+		return io.EOF
+	} else if err != nil {
+		return statusf(Internal, "reading request: %s", err)
+	}
+
+	length := binary.BigEndian.Uint32(header[1:])
+	if length > maxMessageSize {
+		return statusf(ResourceExhausted, "message is too large: %d bytes", length)
+	}
+
+	b := make([]byte, length)
+	_, err = io.ReadFull(s.r.Body, b)
+	if err != nil {
+		return statusf(Internal, "reading request: %s", err)
+	}
+
+	if header[0] == 1 {
+		if !s.gzip {
+			return statusf(Internal, "compressed message without grpc-encoding")
+		}
+
+		zr, err := gzip.NewReader(bytes.NewReader(b))
+		if err != nil {
+			return statusf(Internal, "decompressing request: %s", err)
+		}
+
+		b, err = ioutil.ReadAll(io.LimitReader(zr, maxMessageSize+1))
+		if err != nil {
+			return statusf(Internal, "decompressing request: %s", err)
+		} else if len(b) > maxMessageSize {
+			return statusf(ResourceExhausted, "message is too large")
+		}
+	}
+
+	err = proto.Unmarshal(b, m)
+	if err != nil {
+		return statusf(Internal, "parsing request: %s", err)
+	}
+
+	return nil
+}
+
+// send writes a message to the client, and flushes it.
+func (s *stream) send(m proto.Message) error {
+	b, err := proto.Marshal(m)
+	if err != nil {
+		return statusf(Internal, "encoding response: %s", err)
+	}
+
+	s.sentResp = true
+	header := make([]byte, 5, 5+len(b))
+	binary.BigEndian.PutUint32(header[1:], uint32(len(b)))
+	_, err = s.w.Write(append(header, b...))
+	if err != nil {
+		return err
+	}
+
+	if f, ok := s.w.(http.Flusher); ok {
+		f.Flush()
+	}
+
+	return nil
+}
+
+// finish sends the status for the call in the trailers.
+func (s *stream) finish(err error) {
+	code, msg := OK, ""
+	if err != nil {
+		var status *Status
+		if errors.As(err, &status) {
+			code, msg = status.Code, status.Message
+		} else {
+			code, msg = Unknown, err.Error()
+		}
+	}
+
+	h := s.w.Header()
+	h.Set(http.TrailerPrefix+"Grpc-Status", strconv.Itoa(int(code)))
+	if msg != "" {
+		h.Set(http.TrailerPrefix+"Grpc-Message", encodeGRPCMessage(msg))
+	}
+}
+
+// encodeGRPCMessage percent-encodes a status message, as the protocol
+// requires.
+func encodeGRPCMessage(msg string) string {
+	var b strings.Builder
+	for i := 0; i < len(msg); i++ {
+		c := msg[i]
+		if c >= ' ' && c <= '~' && c != '%' {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+
+	return b.String()
+}
+
+// parseTimeout parses the value of a grpc-timeout header, like "100m" or "5S".
+func parseTimeout(s string) (time.Duration, error) {
+	if len(s) < 2 || len(s) > 9 {
+		return 0, fmt.Errorf("invalid timeout: %q", s)
+	}
+
+	n, err := strconv.ParseInt(s[:len(s)-1], 10, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid timeout: %q", s)
+	}
+
+	var unit time.Duration
+	switch s[len(s)-1] {
+	case 'H':
+		unit = time.Hour
+	case 'M':
+		unit = time.Minute
+	case 'S':
+		unit = time.Second
+	case 'm':
+		unit = time.Millisecond
+	case 'u':
+		unit = time.Microsecond
+	case 'n':
+		unit = time.Nanosecond
+	default:
+		return 0, fmt.Errorf("invalid timeout unit: %q", s)
+	}
+
+	return time.Duration(n) * unit, nil
+}
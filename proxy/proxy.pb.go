@@ -0,0 +1,747 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: proxy.proto
+
+package proxy
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type StatRequest struct {
+	Path                 string   `protobuf:"bytes,1,opt,name=path" json:"path,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StatRequest) Reset()         { *m = StatRequest{} }
+func (m *StatRequest) String() string { return proto.CompactTextString(m) }
+func (*StatRequest) ProtoMessage()    {}
+func (*StatRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_proxy_e6f142e46daad524, []int{0}
+}
+func (m *StatRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StatRequest.Unmarshal(m, b)
+}
+func (m *StatRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StatRequest.Marshal(b, m, deterministic)
+}
+func (dst *StatRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StatRequest.Merge(dst, src)
+}
+func (m *StatRequest) XXX_Size() int {
+	return xxx_messageInfo_StatRequest.Size(m)
+}
+func (m *StatRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StatRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StatRequest proto.InternalMessageInfo
+
+func (m *StatRequest) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+type ListRequest struct {
+	Path                 string   `protobuf:"bytes,1,opt,name=path" json:"path,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListRequest) Reset()         { *m = ListRequest{} }
+func (m *ListRequest) String() string { return proto.CompactTextString(m) }
+func (*ListRequest) ProtoMessage()    {}
+func (*ListRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_proxy_e6f142e46daad524, []int{1}
+}
+func (m *ListRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListRequest.Unmarshal(m, b)
+}
+func (m *ListRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListRequest.Marshal(b, m, deterministic)
+}
+func (dst *ListRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListRequest.Merge(dst, src)
+}
+func (m *ListRequest) XXX_Size() int {
+	return xxx_messageInfo_ListRequest.Size(m)
+}
+func (m *ListRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListRequest proto.InternalMessageInfo
+
+func (m *ListRequest) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+// FileInfo describes a file or directory.
+type FileInfo struct {
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	// The size, in bytes. It's zero for directories.
+	Size int64 `protobuf:"varint,2,opt,name=size" json:"size,omitempty"`
+	// The permission bits, like 0644.
+	Permissions uint32 `protobuf:"varint,3,opt,name=permissions" json:"permissions,omitempty"`
+	IsDir       bool   `protobuf:"varint,4,opt,name=is_dir,json=isDir" json:"is_dir,omitempty"`
+	// Times are in milliseconds since the epoch.
+	ModificationTime int64  `protobuf:"varint,5,opt,name=modification_time,json=modificationTime" json:"modification_time,omitempty"`
+	AccessTime       int64  `protobuf:"varint,6,opt,name=access_time,json=accessTime" json:"access_time,omitempty"`
+	Owner            string `protobuf:"bytes,7,opt,name=owner" json:"owner,omitempty"`
+	Group            string `protobuf:"bytes,8,opt,name=group" json:"group,omitempty"`
+	// These are zero for directories.
+	Replication          int32    `protobuf:"varint,9,opt,name=replication" json:"replication,omitempty"`
+	BlockSize            int64    `protobuf:"varint,10,opt,name=block_size,json=blockSize" json:"block_size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FileInfo) Reset()         { *m = FileInfo{} }
+func (m *FileInfo) String() string { return proto.CompactTextString(m) }
+func (*FileInfo) ProtoMessage()    {}
+func (*FileInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_proxy_e6f142e46daad524, []int{2}
+}
+func (m *FileInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FileInfo.Unmarshal(m, b)
+}
+func (m *FileInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FileInfo.Marshal(b, m, deterministic)
+}
+func (dst *FileInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FileInfo.Merge(dst, src)
+}
+func (m *FileInfo) XXX_Size() int {
+	return xxx_messageInfo_FileInfo.Size(m)
+}
+func (m *FileInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_FileInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FileInfo proto.InternalMessageInfo
+
+func (m *FileInfo) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *FileInfo) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *FileInfo) GetPermissions() uint32 {
+	if m != nil {
+		return m.Permissions
+	}
+	return 0
+}
+
+func (m *FileInfo) GetIsDir() bool {
+	if m != nil {
+		return m.IsDir
+	}
+	return false
+}
+
+func (m *FileInfo) GetModificationTime() int64 {
+	if m != nil {
+		return m.ModificationTime
+	}
+	return 0
+}
+
+func (m *FileInfo) GetAccessTime() int64 {
+	if m != nil {
+		return m.AccessTime
+	}
+	return 0
+}
+
+func (m *FileInfo) GetOwner() string {
+	if m != nil {
+		return m.Owner
+	}
+	return ""
+}
+
+func (m *FileInfo) GetGroup() string {
+	if m != nil {
+		return m.Group
+	}
+	return ""
+}
+
+func (m *FileInfo) GetReplication() int32 {
+	if m != nil {
+		return m.Replication
+	}
+	return 0
+}
+
+func (m *FileInfo) GetBlockSize() int64 {
+	if m != nil {
+		return m.BlockSize
+	}
+	return 0
+}
+
+type ReadRequest struct {
+	Path   string `protobuf:"bytes,1,opt,name=path" json:"path,omitempty"`
+	Offset int64  `protobuf:"varint,2,opt,name=offset" json:"offset,omitempty"`
+	// The number of bytes to read. If it's zero or negative, the file is read
+	// until the end.
+	Length int64 `protobuf:"varint,3,opt,name=length" json:"length,omitempty"`
+	// The most data to send in each message. If it's zero, 1MiB is used. It
+	// can't be more than 4MiB.
+	ChunkSize            int32    `protobuf:"varint,4,opt,name=chunk_size,json=chunkSize" json:"chunk_size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReadRequest) Reset()         { *m = ReadRequest{} }
+func (m *ReadRequest) String() string { return proto.CompactTextString(m) }
+func (*ReadRequest) ProtoMessage()    {}
+func (*ReadRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_proxy_e6f142e46daad524, []int{3}
+}
+func (m *ReadRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReadRequest.Unmarshal(m, b)
+}
+func (m *ReadRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReadRequest.Marshal(b, m, deterministic)
+}
+func (dst *ReadRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadRequest.Merge(dst, src)
+}
+func (m *ReadRequest) XXX_Size() int {
+	return xxx_messageInfo_ReadRequest.Size(m)
+}
+func (m *ReadRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReadRequest proto.InternalMessageInfo
+
+func (m *ReadRequest) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *ReadRequest) GetOffset() int64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *ReadRequest) GetLength() int64 {
+	if m != nil {
+		return m.Length
+	}
+	return 0
+}
+
+func (m *ReadRequest) GetChunkSize() int32 {
+	if m != nil {
+		return m.ChunkSize
+	}
+	return 0
+}
+
+type ReadResponse struct {
+	Data                 []byte   `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReadResponse) Reset()         { *m = ReadResponse{} }
+func (m *ReadResponse) String() string { return proto.CompactTextString(m) }
+func (*ReadResponse) ProtoMessage()    {}
+func (*ReadResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_proxy_e6f142e46daad524, []int{4}
+}
+func (m *ReadResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReadResponse.Unmarshal(m, b)
+}
+func (m *ReadResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReadResponse.Marshal(b, m, deterministic)
+}
+func (dst *ReadResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadResponse.Merge(dst, src)
+}
+func (m *ReadResponse) XXX_Size() int {
+	return xxx_messageInfo_ReadResponse.Size(m)
+}
+func (m *ReadResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReadResponse proto.InternalMessageInfo
+
+func (m *ReadResponse) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type WriteRequest struct {
+	// The path and options are only read from the first message.
+	Path string `protobuf:"bytes,1,opt,name=path" json:"path,omitempty"`
+	// If set, the file is replaced if it exists. Otherwise, it's an error if
+	// the file exists, unless append is set.
+	Overwrite bool `protobuf:"varint,2,opt,name=overwrite" json:"overwrite,omitempty"`
+	// If set, the data is appended to the file, which must already exist.
+	Append bool `protobuf:"varint,3,opt,name=append" json:"append,omitempty"`
+	// These are used when creating a file. Zero means the default from the
+	// cluster configuration (or 0644 for the permissions).
+	Permissions          uint32   `protobuf:"varint,4,opt,name=permissions" json:"permissions,omitempty"`
+	Replication          int32    `protobuf:"varint,5,opt,name=replication" json:"replication,omitempty"`
+	BlockSize            int64    `protobuf:"varint,6,opt,name=block_size,json=blockSize" json:"block_size,omitempty"`
+	Data                 []byte   `protobuf:"bytes,7,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WriteRequest) Reset()         { *m = WriteRequest{} }
+func (m *WriteRequest) String() string { return proto.CompactTextString(m) }
+func (*WriteRequest) ProtoMessage()    {}
+func (*WriteRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_proxy_e6f142e46daad524, []int{5}
+}
+func (m *WriteRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WriteRequest.Unmarshal(m, b)
+}
+func (m *WriteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WriteRequest.Marshal(b, m, deterministic)
+}
+func (dst *WriteRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WriteRequest.Merge(dst, src)
+}
+func (m *WriteRequest) XXX_Size() int {
+	return xxx_messageInfo_WriteRequest.Size(m)
+}
+func (m *WriteRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WriteRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WriteRequest proto.InternalMessageInfo
+
+func (m *WriteRequest) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *WriteRequest) GetOverwrite() bool {
+	if m != nil {
+		return m.Overwrite
+	}
+	return false
+}
+
+func (m *WriteRequest) GetAppend() bool {
+	if m != nil {
+		return m.Append
+	}
+	return false
+}
+
+func (m *WriteRequest) GetPermissions() uint32 {
+	if m != nil {
+		return m.Permissions
+	}
+	return 0
+}
+
+func (m *WriteRequest) GetReplication() int32 {
+	if m != nil {
+		return m.Replication
+	}
+	return 0
+}
+
+func (m *WriteRequest) GetBlockSize() int64 {
+	if m != nil {
+		return m.BlockSize
+	}
+	return 0
+}
+
+func (m *WriteRequest) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type WriteResponse struct {
+	BytesWritten         int64    `protobuf:"varint,1,opt,name=bytes_written,json=bytesWritten" json:"bytes_written,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WriteResponse) Reset()         { *m = WriteResponse{} }
+func (m *WriteResponse) String() string { return proto.CompactTextString(m) }
+func (*WriteResponse) ProtoMessage()    {}
+func (*WriteResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_proxy_e6f142e46daad524, []int{6}
+}
+func (m *WriteResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WriteResponse.Unmarshal(m, b)
+}
+func (m *WriteResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WriteResponse.Marshal(b, m, deterministic)
+}
+func (dst *WriteResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WriteResponse.Merge(dst, src)
+}
+func (m *WriteResponse) XXX_Size() int {
+	return xxx_messageInfo_WriteResponse.Size(m)
+}
+func (m *WriteResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_WriteResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WriteResponse proto.InternalMessageInfo
+
+func (m *WriteResponse) GetBytesWritten() int64 {
+	if m != nil {
+		return m.BytesWritten
+	}
+	return 0
+}
+
+type MkdirRequest struct {
+	Path string `protobuf:"bytes,1,opt,name=path" json:"path,omitempty"`
+	// The permission bits for the new directory. Zero means 0755.
+	Permissions uint32 `protobuf:"varint,2,opt,name=permissions" json:"permissions,omitempty"`
+	// If set, any missing parents are created, and it's not an error if the
+	// directory already exists.
+	Parents              bool     `protobuf:"varint,3,opt,name=parents" json:"parents,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MkdirRequest) Reset()         { *m = MkdirRequest{} }
+func (m *MkdirRequest) String() string { return proto.CompactTextString(m) }
+func (*MkdirRequest) ProtoMessage()    {}
+func (*MkdirRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_proxy_e6f142e46daad524, []int{7}
+}
+func (m *MkdirRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MkdirRequest.Unmarshal(m, b)
+}
+func (m *MkdirRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MkdirRequest.Marshal(b, m, deterministic)
+}
+func (dst *MkdirRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MkdirRequest.Merge(dst, src)
+}
+func (m *MkdirRequest) XXX_Size() int {
+	return xxx_messageInfo_MkdirRequest.Size(m)
+}
+func (m *MkdirRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MkdirRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MkdirRequest proto.InternalMessageInfo
+
+func (m *MkdirRequest) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *MkdirRequest) GetPermissions() uint32 {
+	if m != nil {
+		return m.Permissions
+	}
+	return 0
+}
+
+func (m *MkdirRequest) GetParents() bool {
+	if m != nil {
+		return m.Parents
+	}
+	return false
+}
+
+type MkdirResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MkdirResponse) Reset()         { *m = MkdirResponse{} }
+func (m *MkdirResponse) String() string { return proto.CompactTextString(m) }
+func (*MkdirResponse) ProtoMessage()    {}
+func (*MkdirResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_proxy_e6f142e46daad524, []int{8}
+}
+func (m *MkdirResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MkdirResponse.Unmarshal(m, b)
+}
+func (m *MkdirResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MkdirResponse.Marshal(b, m, deterministic)
+}
+func (dst *MkdirResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MkdirResponse.Merge(dst, src)
+}
+func (m *MkdirResponse) XXX_Size() int {
+	return xxx_messageInfo_MkdirResponse.Size(m)
+}
+func (m *MkdirResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_MkdirResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MkdirResponse proto.InternalMessageInfo
+
+type RemoveRequest struct {
+	Path string `protobuf:"bytes,1,opt,name=path" json:"path,omitempty"`
+	// If set, directories are removed along with their contents.
+	Recursive            bool     `protobuf:"varint,2,opt,name=recursive" json:"recursive,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RemoveRequest) Reset()         { *m = RemoveRequest{} }
+func (m *RemoveRequest) String() string { return proto.CompactTextString(m) }
+func (*RemoveRequest) ProtoMessage()    {}
+func (*RemoveRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_proxy_e6f142e46daad524, []int{9}
+}
+func (m *RemoveRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RemoveRequest.Unmarshal(m, b)
+}
+func (m *RemoveRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RemoveRequest.Marshal(b, m, deterministic)
+}
+func (dst *RemoveRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemoveRequest.Merge(dst, src)
+}
+func (m *RemoveRequest) XXX_Size() int {
+	return xxx_messageInfo_RemoveRequest.Size(m)
+}
+func (m *RemoveRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemoveRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RemoveRequest proto.InternalMessageInfo
+
+func (m *RemoveRequest) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *RemoveRequest) GetRecursive() bool {
+	if m != nil {
+		return m.Recursive
+	}
+	return false
+}
+
+type RemoveResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RemoveResponse) Reset()         { *m = RemoveResponse{} }
+func (m *RemoveResponse) String() string { return proto.CompactTextString(m) }
+func (*RemoveResponse) ProtoMessage()    {}
+func (*RemoveResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_proxy_e6f142e46daad524, []int{10}
+}
+func (m *RemoveResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RemoveResponse.Unmarshal(m, b)
+}
+func (m *RemoveResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RemoveResponse.Marshal(b, m, deterministic)
+}
+func (dst *RemoveResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemoveResponse.Merge(dst, src)
+}
+func (m *RemoveResponse) XXX_Size() int {
+	return xxx_messageInfo_RemoveResponse.Size(m)
+}
+func (m *RemoveResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemoveResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RemoveResponse proto.InternalMessageInfo
+
+type RenameRequest struct {
+	OldPath              string   `protobuf:"bytes,1,opt,name=old_path,json=oldPath" json:"old_path,omitempty"`
+	NewPath              string   `protobuf:"bytes,2,opt,name=new_path,json=newPath" json:"new_path,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RenameRequest) Reset()         { *m = RenameRequest{} }
+func (m *RenameRequest) String() string { return proto.CompactTextString(m) }
+func (*RenameRequest) ProtoMessage()    {}
+func (*RenameRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_proxy_e6f142e46daad524, []int{11}
+}
+func (m *RenameRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RenameRequest.Unmarshal(m, b)
+}
+func (m *RenameRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RenameRequest.Marshal(b, m, deterministic)
+}
+func (dst *RenameRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RenameRequest.Merge(dst, src)
+}
+func (m *RenameRequest) XXX_Size() int {
+	return xxx_messageInfo_RenameRequest.Size(m)
+}
+func (m *RenameRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RenameRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RenameRequest proto.InternalMessageInfo
+
+func (m *RenameRequest) GetOldPath() string {
+	if m != nil {
+		return m.OldPath
+	}
+	return ""
+}
+
+func (m *RenameRequest) GetNewPath() string {
+	if m != nil {
+		return m.NewPath
+	}
+	return ""
+}
+
+type RenameResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RenameResponse) Reset()         { *m = RenameResponse{} }
+func (m *RenameResponse) String() string { return proto.CompactTextString(m) }
+func (*RenameResponse) ProtoMessage()    {}
+func (*RenameResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_proxy_e6f142e46daad524, []int{12}
+}
+func (m *RenameResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RenameResponse.Unmarshal(m, b)
+}
+func (m *RenameResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RenameResponse.Marshal(b, m, deterministic)
+}
+func (dst *RenameResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RenameResponse.Merge(dst, src)
+}
+func (m *RenameResponse) XXX_Size() int {
+	return xxx_messageInfo_RenameResponse.Size(m)
+}
+func (m *RenameResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RenameResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RenameResponse proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterType((*StatRequest)(nil), "gohdfs.proxy.StatRequest")
+	proto.RegisterType((*ListRequest)(nil), "gohdfs.proxy.ListRequest")
+	proto.RegisterType((*FileInfo)(nil), "gohdfs.proxy.FileInfo")
+	proto.RegisterType((*ReadRequest)(nil), "gohdfs.proxy.ReadRequest")
+	proto.RegisterType((*ReadResponse)(nil), "gohdfs.proxy.ReadResponse")
+	proto.RegisterType((*WriteRequest)(nil), "gohdfs.proxy.WriteRequest")
+	proto.RegisterType((*WriteResponse)(nil), "gohdfs.proxy.WriteResponse")
+	proto.RegisterType((*MkdirRequest)(nil), "gohdfs.proxy.MkdirRequest")
+	proto.RegisterType((*MkdirResponse)(nil), "gohdfs.proxy.MkdirResponse")
+	proto.RegisterType((*RemoveRequest)(nil), "gohdfs.proxy.RemoveRequest")
+	proto.RegisterType((*RemoveResponse)(nil), "gohdfs.proxy.RemoveResponse")
+	proto.RegisterType((*RenameRequest)(nil), "gohdfs.proxy.RenameRequest")
+	proto.RegisterType((*RenameResponse)(nil), "gohdfs.proxy.RenameResponse")
+}
+
+func init() { proto.RegisterFile("proxy.proto", fileDescriptor_proxy_e6f142e46daad524) }
+
+var fileDescriptor_proxy_e6f142e46daad524 = []byte{
+	// 654 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x55, 0xdd, 0x6e, 0xd3, 0x4c,
+	0x10, 0x95, 0x13, 0x3b, 0x71, 0x26, 0xc9, 0xf7, 0x95, 0x15, 0x54, 0xae, 0x5b, 0x44, 0x30, 0x37,
+	0x91, 0x90, 0xa2, 0x0a, 0xb8, 0x41, 0x5c, 0x00, 0xa5, 0x54, 0x20, 0x81, 0x84, 0x5c, 0xa4, 0x4a,
+	0x5c, 0x10, 0xb9, 0xf1, 0xa6, 0x59, 0x35, 0xd9, 0x35, 0xbb, 0x9b, 0x86, 0xf6, 0x81, 0x78, 0x9d,
+	0xbe, 0x12, 0xda, 0x59, 0x27, 0x2c, 0x4e, 0x48, 0xef, 0x76, 0xce, 0x8c, 0xcf, 0x9c, 0xf9, 0xc9,
+	0x04, 0xda, 0x85, 0x14, 0x3f, 0xaf, 0x07, 0x85, 0x14, 0x5a, 0x90, 0xce, 0x85, 0x98, 0xe4, 0x63,
+	0x35, 0x40, 0x2c, 0x79, 0x0c, 0xed, 0x53, 0x9d, 0xe9, 0x94, 0xfe, 0x98, 0x53, 0xa5, 0x09, 0x01,
+	0xbf, 0xc8, 0xf4, 0x24, 0xf2, 0x7a, 0x5e, 0xbf, 0x95, 0xe2, 0xdb, 0x84, 0x7c, 0x62, 0x6a, 0x6b,
+	0xc8, 0xaf, 0x1a, 0x84, 0x27, 0x6c, 0x4a, 0x3f, 0xf2, 0xb1, 0x30, 0x01, 0x3c, 0x9b, 0xd1, 0x65,
+	0x80, 0x79, 0x1b, 0x4c, 0xb1, 0x1b, 0x1a, 0xd5, 0x7a, 0x5e, 0xbf, 0x9e, 0xe2, 0x9b, 0xf4, 0xa0,
+	0x5d, 0x50, 0x39, 0x63, 0x4a, 0x31, 0xc1, 0x55, 0x54, 0xef, 0x79, 0xfd, 0x6e, 0xea, 0x42, 0xe4,
+	0x01, 0x34, 0x98, 0x1a, 0xe6, 0x4c, 0x46, 0x7e, 0xcf, 0xeb, 0x87, 0x69, 0xc0, 0xd4, 0x31, 0x93,
+	0xe4, 0x29, 0xdc, 0x9b, 0x89, 0x9c, 0x8d, 0xd9, 0x28, 0xd3, 0x4c, 0xf0, 0xa1, 0x66, 0x33, 0x1a,
+	0x05, 0xc8, 0xbc, 0xe3, 0x3a, 0xbe, 0xb2, 0x19, 0x25, 0x8f, 0xa0, 0x9d, 0x8d, 0x46, 0x54, 0x29,
+	0x1b, 0xd6, 0xc0, 0x30, 0xb0, 0x10, 0x06, 0xdc, 0x87, 0x40, 0x2c, 0x38, 0x95, 0x51, 0x13, 0xf5,
+	0x5a, 0xc3, 0xa0, 0x17, 0x52, 0xcc, 0x8b, 0x28, 0xb4, 0x28, 0x1a, 0x46, 0xb2, 0xa4, 0xc5, 0xb4,
+	0xe4, 0x8f, 0x5a, 0x3d, 0xaf, 0x1f, 0xa4, 0x2e, 0x44, 0x1e, 0x02, 0x9c, 0x4f, 0xc5, 0xe8, 0x72,
+	0x88, 0xe5, 0x02, 0x66, 0x6b, 0x21, 0x72, 0xca, 0x6e, 0x68, 0x52, 0x40, 0x3b, 0xa5, 0x59, 0xbe,
+	0xa5, 0x97, 0x64, 0x17, 0x1a, 0x62, 0x3c, 0x56, 0x54, 0x97, 0xcd, 0x2a, 0x2d, 0x83, 0x4f, 0x29,
+	0xbf, 0xd0, 0x13, 0xec, 0x54, 0x3d, 0x2d, 0x2d, 0x93, 0x71, 0x34, 0x99, 0xf3, 0x32, 0xa3, 0x8f,
+	0x92, 0x5a, 0x88, 0x60, 0xc6, 0x04, 0x3a, 0x36, 0xa3, 0x2a, 0x04, 0x57, 0x38, 0x89, 0x3c, 0xd3,
+	0x19, 0xa6, 0xec, 0xa4, 0xf8, 0x4e, 0x6e, 0x3d, 0xe8, 0x9c, 0x49, 0xa6, 0xe9, 0x36, 0x5d, 0x07,
+	0xd0, 0x12, 0x57, 0x54, 0x2e, 0x4c, 0x1c, 0x4a, 0x0b, 0xd3, 0x3f, 0x80, 0x51, 0x97, 0x15, 0x05,
+	0xe5, 0x39, 0xaa, 0x0b, 0xd3, 0xd2, 0xaa, 0x0e, 0xd9, 0x5f, 0x1f, 0x72, 0xa5, 0xa7, 0xc1, 0x5d,
+	0x3d, 0x6d, 0x54, 0x7a, 0xba, 0xaa, 0xa8, 0xe9, 0x54, 0xf4, 0x02, 0xba, 0x65, 0x41, 0x65, 0xd9,
+	0x4f, 0xa0, 0x7b, 0x7e, 0xad, 0xa9, 0x1a, 0x1a, 0xb9, 0x9a, 0x72, 0x2c, 0xad, 0x9e, 0x76, 0x10,
+	0x3c, 0xb3, 0x58, 0xf2, 0x1d, 0x3a, 0x9f, 0x2f, 0x73, 0x26, 0xb7, 0xb5, 0xa1, 0x52, 0x50, 0x6d,
+	0xbd, 0xa0, 0x08, 0x9a, 0x45, 0x26, 0x29, 0xd7, 0xaa, 0xec, 0xc5, 0xd2, 0x4c, 0xfe, 0x87, 0x6e,
+	0xc9, 0x6f, 0x55, 0x25, 0x6f, 0xa1, 0x9b, 0xd2, 0x99, 0xb8, 0xba, 0xab, 0xf1, 0x92, 0x8e, 0xe6,
+	0x52, 0xb1, 0xab, 0x55, 0xe3, 0x57, 0x40, 0xb2, 0x03, 0xff, 0x2d, 0x29, 0x4a, 0xd2, 0xf7, 0x86,
+	0xd4, 0xfc, 0xea, 0x96, 0xa4, 0x7b, 0x10, 0x8a, 0x69, 0x3e, 0x74, 0x88, 0x9b, 0x62, 0x9a, 0x7f,
+	0x31, 0xdc, 0x7b, 0x10, 0x72, 0xba, 0xb0, 0xae, 0x9a, 0x75, 0x71, 0xba, 0x30, 0x2e, 0x4b, 0x6c,
+	0x69, 0x2c, 0xf1, 0xb3, 0xdb, 0x3a, 0xf8, 0x1f, 0x8e, 0x4f, 0x4e, 0xc9, 0x4b, 0xf0, 0xcd, 0xd1,
+	0x20, 0x7b, 0x03, 0xf7, 0x96, 0x0c, 0x9c, 0x43, 0x12, 0xef, 0xfe, 0xed, 0x5a, 0x1d, 0x87, 0x57,
+	0xe0, 0x9b, 0x63, 0x52, 0xfd, 0xd4, 0x39, 0x30, 0xff, 0xfa, 0xf4, 0xd0, 0x23, 0xaf, 0xc1, 0x37,
+	0xbb, 0x5c, 0xfd, 0xd8, 0xf9, 0x45, 0xc5, 0xf1, 0x26, 0x97, 0xd5, 0x7f, 0xe8, 0x91, 0x23, 0x08,
+	0x70, 0x2d, 0x48, 0x25, 0xcc, 0x5d, 0xfe, 0x78, 0x7f, 0xa3, 0xcf, 0x72, 0xf4, 0x3d, 0xf2, 0x06,
+	0x02, 0x1c, 0x62, 0x95, 0xc3, 0xdd, 0x9c, 0x78, 0x7f, 0xa3, 0xaf, 0xdc, 0xc5, 0x77, 0xd0, 0xb0,
+	0x23, 0x23, 0xfb, 0x55, 0xb5, 0xce, 0x2e, 0xc4, 0x07, 0x9b, 0x9d, 0x2e, 0x09, 0xde, 0xd6, 0x35,
+	0x12, 0x67, 0xf6, 0xf1, 0xc1, 0x66, 0xa7, 0x25, 0x39, 0x6a, 0x7e, 0x0b, 0x10, 0x3f, 0x6f, 0xe0,
+	0x7f, 0xc3, 0xf3, 0xdf, 0x03, 0x00, 0x69, 0x70, 0x43, 0x1b, 0x2a, 0x06, 0x00, 0x00,
+}
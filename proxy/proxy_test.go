@@ -0,0 +1,340 @@
+package proxy
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/colinmarc/hdfs/v2"
+	"github.com/colinmarc/hdfs/v2/internal/testcluster"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testRoot = "/_test/proxy"
+
+func getClient(t *testing.T) *hdfs.Client {
+	return testcluster.Client(t, "gohdfs1")
+}
+
+// testClient is a minimal gRPC client, for calling the server over cleartext
+// HTTP/2.
+type testClient struct {
+	addr   string
+	client *http.Client
+	gzip   bool
+}
+
+func startServer(t *testing.T, client *hdfs.Client) *testClient {
+	l, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+
+	s := New(client)
+	go s.Serve(l)
+	t.Cleanup(func() { s.Close() })
+
+	protocols := new(http.Protocols)
+	protocols.SetUnencryptedHTTP2(true)
+	return &testClient{
+		addr:   l.Addr().String(),
+		client: &http.Client{Transport: &http.Transport{Protocols: protocols}},
+	}
+}
+
+func (c *testClient) frame(t *testing.T, m proto.Message) []byte {
+	b, err := proto.Marshal(m)
+	require.NoError(t, err)
+
+	flag := byte(0)
+	if c.gzip {
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		zw.Write(b)
+		zw.Close()
+		b = buf.Bytes()
+		flag = 1
+	}
+
+	header := make([]byte, 5)
+	header[0] = flag
+	binary.BigEndian.PutUint32(header[1:], uint32(len(b)))
+	return append(header, b...)
+}
+
+// call makes a call with the given request messages, and returns the raw
+// response messages, along with the status.
+func (c *testClient) call(t *testing.T, method string, header http.Header, reqs ...proto.Message) ([][]byte, Code, string) {
+	var body bytes.Buffer
+	for _, m := range reqs {
+		body.Write(c.frame(t, m))
+	}
+
+	req, err := http.NewRequest("POST", "http://"+c.addr+servicePrefix+method, &body)
+	require.NoError(t, err)
+
+	req.Header.Set("Content-Type", "application/grpc")
+	req.Header.Set("TE", "trailers")
+	if c.gzip {
+		req.Header.Set("Grpc-Encoding", "gzip")
+	}
+
+	for k, v := range header {
+		req.Header[k] = v
+	}
+
+	resp, err := c.client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/grpc", resp.Header.Get("Content-Type"))
+
+	var msgs [][]byte
+	for {
+		var h [5]byte
+		_, err := io.ReadFull(resp.Body, h[:])
+		if err == io.EOF {
+			break
+		}
+
+		require.NoError(t, err)
+		b := make([]byte, binary.BigEndian.Uint32(h[1:]))
+		_, err = io.ReadFull(resp.Body, b)
+		require.NoError(t, err)
+		msgs = append(msgs, b)
+	}
+
+	code, err := strconv.Atoi(resp.Trailer.Get("Grpc-Status"))
+	require.NoError(t, err, "missing grpc-status")
+
+	msg, err := url.PathUnescape(resp.Trailer.Get("Grpc-Message"))
+	require.NoError(t, err)
+
+	return msgs, Code(code), msg
+}
+
+// unary makes a call with a single request and response.
+func (c *testClient) unary(t *testing.T, method string, req, resp proto.Message) (Code, string) {
+	msgs, code, msg := c.call(t, method, nil, req)
+	if code == OK {
+		require.Len(t, msgs, 1)
+		require.NoError(t, proto.Unmarshal(msgs[0], resp))
+	}
+
+	return code, msg
+}
+
+func setupProxy(t *testing.T) (*testClient, *hdfs.Client) {
+	client := getClient(t)
+	err := client.RemoveAll(testRoot)
+	require.NoError(t, err)
+	err = client.MkdirAll(testRoot, 0755)
+	require.NoError(t, err)
+
+	return startServer(t, client), client
+}
+
+func TestParseTimeout(t *testing.T) {
+	for s, expected := range map[string]time.Duration{
+		"1H":        time.Hour,
+		"5M":        5 * time.Minute,
+		"30S":       30 * time.Second,
+		"100m":      100 * time.Millisecond,
+		"10u":       10 * time.Microsecond,
+		"99999999n": 99999999 * time.Nanosecond,
+	} {
+		d, err := parseTimeout(s)
+		require.NoError(t, err, s)
+		assert.Equal(t, expected, d, s)
+	}
+
+	for _, s := range []string{"", "S", "10", "10s", "-1S", "1000000000S"} {
+		_, err := parseTimeout(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestEncodeGRPCMessage(t *testing.T) {
+	msg := "open /foo: 100% not found\nüber"
+	encoded := encodeGRPCMessage(msg)
+	assert.Equal(t, "open /foo: 100%25 not found%0A%C3%BCber", encoded)
+
+	decoded, err := url.PathUnescape(encoded)
+	require.NoError(t, err)
+	assert.Equal(t, msg, decoded)
+}
+
+func TestToStatus(t *testing.T) {
+	for err, code := range map[error]Code{
+		&os.PathError{"open", "/foo", os.ErrNotExist}:   NotFound,
+		&os.PathError{"create", "/foo", os.ErrExist}:    AlreadyExists,
+		&os.PathError{"open", "/foo", os.ErrPermission}: PermissionDenied,
+		statusf(OutOfRange, "too far"):                  OutOfRange,
+		io.ErrUnexpectedEOF:                             Unknown,
+	} {
+		s := toStatus(err).(*Status)
+		assert.Equal(t, code, s.Code, err.Error())
+	}
+
+	assert.Nil(t, toStatus(nil))
+}
+
+// These don't need HDFS, because the requests never get as far as the client.
+func TestProtocolErrors(t *testing.T) {
+	c := startServer(t, nil)
+
+	_, code, _ := c.call(t, "Nope", nil)
+	assert.Equal(t, Unimplemented, code)
+
+	_, code, msg := c.call(t, "Stat", nil, &StatRequest{Path: "relative"})
+	assert.Equal(t, InvalidArgument, code)
+	assert.Contains(t, msg, "must be absolute")
+
+	_, code, _ = c.call(t, "Stat", nil)
+	assert.Equal(t, InvalidArgument, code)
+
+	_, code, _ = c.call(t, "Stat", http.Header{"Grpc-Encoding": {"snappy"}}, &StatRequest{Path: "/"})
+	assert.Equal(t, Unimplemented, code)
+
+	_, code, _ = c.call(t, "Stat", http.Header{"Grpc-Timeout": {"soon"}}, &StatRequest{Path: "/"})
+	assert.Equal(t, InvalidArgument, code)
+
+	c.gzip = true
+	_, code, _ = c.call(t, "Rename", nil, &RenameRequest{OldPath: "/foo", NewPath: "bar"})
+	assert.Equal(t, InvalidArgument, code)
+
+	resp, err := c.client.Post("http://"+c.addr+servicePrefix+"Stat", "text/plain", strings.NewReader("hi"))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusUnsupportedMediaType, resp.StatusCode)
+
+	resp, err = http.Post("http://"+c.addr+servicePrefix+"Stat", "application/grpc", strings.NewReader(""))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusHTTPVersionNotSupported, resp.StatusCode)
+}
+
+func TestWriteReadStat(t *testing.T) {
+	c, client := setupProxy(t)
+	name := testRoot + "/foo.txt"
+
+	resp := &WriteResponse{}
+	msgs, code, msg := c.call(t, "Write", nil,
+		&WriteRequest{Path: name, Permissions: 0600, Data: []byte("foo")},
+		&WriteRequest{Data: []byte("bar")},
+		&WriteRequest{},
+		&WriteRequest{Data: []byte("baz")})
+	require.Equal(t, OK, code, msg)
+	require.Len(t, msgs, 1)
+	require.NoError(t, proto.Unmarshal(msgs[0], resp))
+	assert.EqualValues(t, 9, resp.BytesWritten)
+
+	contents, err := client.ReadFile(name)
+	require.NoError(t, err)
+	assert.Equal(t, "foobarbaz", string(contents))
+
+	fi := &FileInfo{}
+	code, msg = c.unary(t, "Stat", &StatRequest{Path: name}, fi)
+	require.Equal(t, OK, code, msg)
+	assert.Equal(t, "foo.txt", fi.Name)
+	assert.EqualValues(t, 9, fi.Size)
+	assert.EqualValues(t, 0600, fi.Permissions)
+	assert.False(t, fi.IsDir)
+	assert.Equal(t, "gohdfs1", fi.Owner)
+	assert.NotZero(t, fi.ModificationTime)
+	assert.NotZero(t, fi.Replication)
+	assert.NotZero(t, fi.BlockSize)
+
+	msgs, code, msg = c.call(t, "Read", nil, &ReadRequest{Path: name, Offset: 2, Length: 5, ChunkSize: 2})
+	require.Equal(t, OK, code, msg)
+	var chunks []string
+	for _, b := range msgs {
+		m := &ReadResponse{}
+		require.NoError(t, proto.Unmarshal(b, m))
+		chunks = append(chunks, string(m.Data))
+	}
+	assert.Equal(t, []string{"ob", "ar", "b"}, chunks)
+
+	msgs, code, _ = c.call(t, "Read", nil, &ReadRequest{Path: name, Offset: 9})
+	assert.Equal(t, OK, code)
+	assert.Len(t, msgs, 0)
+
+	_, code, _ = c.call(t, "Read", nil, &ReadRequest{Path: name, Offset: 10})
+	assert.Equal(t, OutOfRange, code)
+
+	_, code, _ = c.call(t, "Write", nil, &WriteRequest{Path: name, Data: []byte("nope")})
+	assert.Equal(t, AlreadyExists, code)
+
+	code, msg = c.unary(t, "Write", &WriteRequest{Path: name, Append: true, Data: []byte("qux")}, resp)
+	require.Equal(t, OK, code, msg)
+	assert.EqualValues(t, 3, resp.BytesWritten)
+
+	c.gzip = true
+	code, msg = c.unary(t, "Write", &WriteRequest{Path: name, Overwrite: true, Data: []byte("replaced")}, resp)
+	require.Equal(t, OK, code, msg)
+
+	contents, err = client.ReadFile(name)
+	require.NoError(t, err)
+	assert.Equal(t, "replaced", string(contents))
+
+	code, _ = c.unary(t, "Stat", &StatRequest{Path: testRoot + "/nonexistent"}, fi)
+	assert.Equal(t, NotFound, code)
+}
+
+func TestListMkdirRemoveRename(t *testing.T) {
+	c, client := setupProxy(t)
+
+	code, msg := c.unary(t, "Mkdir", &MkdirRequest{Path: testRoot + "/a/b", Parents: true}, &MkdirResponse{})
+	require.Equal(t, OK, code, msg)
+
+	code, _ = c.unary(t, "Mkdir", &MkdirRequest{Path: testRoot + "/a"}, &MkdirResponse{})
+	assert.Equal(t, AlreadyExists, code)
+
+	err := client.WriteFileAtomic(testRoot+"/a/file", strings.NewReader("x"), hdfs.CreateOptions{})
+	require.NoError(t, err)
+
+	msgs, code, msg := c.call(t, "List", nil, &ListRequest{Path: testRoot + "/a"})
+	require.Equal(t, OK, code, msg)
+	var names []string
+	for _, b := range msgs {
+		fi := &FileInfo{}
+		require.NoError(t, proto.Unmarshal(b, fi))
+		names = append(names, fi.Name)
+		if fi.Name == "b" {
+			assert.True(t, fi.IsDir)
+			assert.EqualValues(t, 0755, fi.Permissions)
+		}
+	}
+	assert.Equal(t, []string{"b", "file"}, names)
+
+	_, code, _ = c.call(t, "List", nil, &ListRequest{Path: testRoot + "/a/file"})
+	assert.Equal(t, FailedPrecondition, code)
+
+	code, msg = c.unary(t, "Rename", &RenameRequest{OldPath: testRoot + "/a/file", NewPath: testRoot + "/a/b/file"}, &RenameResponse{})
+	require.Equal(t, OK, code, msg)
+
+	_, err = client.Stat(testRoot + "/a/b/file")
+	assert.NoError(t, err)
+
+	code, _ = c.unary(t, "Remove", &RemoveRequest{Path: testRoot + "/a"}, &RemoveResponse{})
+	assert.Equal(t, FailedPrecondition, code)
+
+	code, msg = c.unary(t, "Remove", &RemoveRequest{Path: testRoot + "/a", Recursive: true}, &RemoveResponse{})
+	require.Equal(t, OK, code, msg)
+
+	_, err = client.Stat(testRoot + "/a")
+	assert.True(t, os.IsNotExist(err))
+
+	code, _ = c.unary(t, "Remove", &RemoveRequest{Path: testRoot + "/a", Recursive: true}, &RemoveResponse{})
+	assert.Equal(t, NotFound, code)
+}
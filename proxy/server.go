@@ -0,0 +1,534 @@
+// Package proxy serves the operations of an hdfs.Client over gRPC, so that
+// programs written in other languages can use HDFS by way of a sidecar
+// process, without having to deal with HA namenodes, kerberos, or the HDFS
+// protocols themselves.
+//
+// The service is defined in proxy.proto, which can be used to generate
+// clients for any language gRPC supports. It's served with the standard
+// library's HTTP/2 implementation, rather than with grpc-go, and only
+// supports what the service needs: unary and streaming calls, gzip or
+// identity compression, and the grpc-timeout header. Cleartext HTTP/2
+// ("h2c") is used by Serve and ListenAndServe, which is what gRPC clients
+// expect when they're configured with insecure credentials.
+//
+// All requests are made by the client the Server was created with, and so as
+// its user. The service doesn't do any authentication of its own, so it
+// should only be reachable by trusted processes, such as other containers in
+// the same pod.
+package proxy
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/colinmarc/hdfs/v2"
+	"github.com/golang/protobuf/proto"
+)
+
+const (
+	servicePrefix = "/gohdfs.proxy.HDFS/"
+	// defaultChunkSize is the size of the messages Read sends, if the client
+	// doesn't specify one.
+	defaultChunkSize = 1024 * 1024
+	// listBatchSize is the number of directory entries fetched at once by
+	// List.
+	listBatchSize = 1000
+)
+
+// ErrServerClosed is returned by Serve and ListenAndServe after Close is called.
+var ErrServerClosed = http.ErrServerClosed
+
+// Server serves the HDFS service defined in proxy.proto. It implements
+// http.Handler, but must be served over HTTP/2; it can be used with an
+// http.Server configured for TLS, or with Serve or ListenAndServe for
+// cleartext HTTP/2.
+type Server struct {
+	client *hdfs.Client
+
+	lock       sync.Mutex
+	httpServer *http.Server
+	closed     bool
+}
+
+// New returns a Server that makes requests with the given client.
+func New(client *hdfs.Client) *Server {
+	return &Server{client: client}
+}
+
+// ListenAndServe listens on the TCP address addr, and serves cleartext HTTP/2
+// connections. It always returns a non-nil error.
+func (s *Server) ListenAndServe(addr string) error {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	return s.Serve(l)
+}
+
+// Serve serves cleartext HTTP/2 connections from the listener, until Close is
+// called. It always returns a non-nil error.
+func (s *Server) Serve(l net.Listener) error {
+	// HTTP/1 is accepted too, so that clients that don't speak HTTP/2 get a
+	// helpful error rather than a reset connection.
+	protocols := new(http.Protocols)
+	protocols.SetHTTP1(true)
+	protocols.SetUnencryptedHTTP2(true)
+	httpServer := &http.Server{Handler: s, Protocols: protocols}
+
+	s.lock.Lock()
+	if s.closed {
+		s.lock.Unlock()
+		l.Close()
+		return ErrServerClosed
+	}
+
+	s.httpServer = httpServer
+	s.lock.Unlock()
+
+	return httpServer.Serve(l)
+}
+
+// Close stops Serve or ListenAndServe, and closes any active connections. It
+// doesn't close the client.
+func (s *Server) Close() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.closed = true
+	if s.httpServer != nil {
+		return s.httpServer.Close()
+	}
+
+	return nil
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.ProtoMajor != 2 {
+		http.Error(w, "gRPC requires HTTP/2", http.StatusHTTPVersionNotSupported)
+		return
+	} else if r.Method != http.MethodPost {
+		http.Error(w, "gRPC requires POST", http.StatusMethodNotAllowed)
+		return
+	}
+
+	contentType := r.Header.Get("Content-Type")
+	if contentType != grpcContentType && !strings.HasPrefix(contentType, grpcContentType+"+proto") &&
+		!strings.HasPrefix(contentType, grpcContentType+";") {
+		http.Error(w, "unsupported content type", http.StatusUnsupportedMediaType)
+		return
+	}
+
+	w.Header().Set("Content-Type", grpcContentType)
+	w.Header().Set("Grpc-Accept-Encoding", "gzip")
+	w.WriteHeader(http.StatusOK)
+
+	st := &stream{w: w, r: r}
+	st.finish(s.serve(st))
+}
+
+func (s *Server) serve(st *stream) error {
+	switch encoding := st.r.Header.Get("Grpc-Encoding"); encoding {
+	case "", "identity":
+	case "gzip":
+		st.gzip = true
+	default:
+		return statusf(Unimplemented, "unsupported grpc-encoding: %q", encoding)
+	}
+
+	ctx := st.r.Context()
+	if t := st.r.Header.Get("Grpc-Timeout"); t != "" {
+		timeout, err := parseTimeout(t)
+		if err != nil {
+			return statusf(InvalidArgument, "%s", err)
+		}
+
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	var err error
+	switch strings.TrimPrefix(st.r.URL.Path, servicePrefix) {
+	case "Stat":
+		err = s.stat(st)
+	case "List":
+		err = s.list(ctx, st)
+	case "Read":
+		err = s.read(ctx, st)
+	case "Write":
+		err = s.write(ctx, st)
+	case "Mkdir":
+		err = s.mkdir(st)
+	case "Remove":
+		err = s.remove(st)
+	case "Rename":
+		err = s.rename(st)
+	default:
+		return statusf(Unimplemented, "unknown method: %s", st.r.URL.Path)
+	}
+
+	if err == nil && ctx.Err() != nil {
+		err = ctx.Err()
+	}
+
+	return toStatus(err)
+}
+
+// recvRequest reads the single request message of a unary or server-streaming
+// call.
+func recvRequest(st *stream, m proto.Message) error {
+	err := st.recv(m)
+	if err == io.EOF {
+		return statusf(InvalidArgument, "missing request message")
+	}
+
+	return err
+}
+
+// checkPath returns an error if p isn't an absolute path.
+func checkPath(p string) error {
+	if !path.IsAbs(p) {
+		return statusf(InvalidArgument, "path must be absolute: %q", p)
+	}
+
+	return nil
+}
+
+func (s *Server) stat(st *stream) error {
+	req := &StatRequest{}
+	err := recvRequest(st, req)
+	if err != nil {
+		return err
+	} else if err = checkPath(req.Path); err != nil {
+		return err
+	}
+
+	fi, err := s.client.Stat(req.Path)
+	if err != nil {
+		return err
+	}
+
+	return st.send(fileInfo(fi))
+}
+
+func (s *Server) list(ctx context.Context, st *stream) error {
+	req := &ListRequest{}
+	err := recvRequest(st, req)
+	if err != nil {
+		return err
+	} else if err = checkPath(req.Path); err != nil {
+		return err
+	}
+
+	dir, err := s.client.Open(req.Path)
+	if err != nil {
+		return err
+	}
+	defer dir.Close()
+
+	if !dir.Stat().IsDir() {
+		return statusf(FailedPrecondition, "not a directory: %s", req.Path)
+	}
+
+	// Entries are fetched and sent in batches, so that a large directory
+	// doesn't have to be held in memory.
+	for {
+		batch, err := dir.Readdir(listBatchSize)
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		for _, fi := range batch {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			err = st.send(fileInfo(fi))
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s *Server) read(ctx context.Context, st *stream) error {
+	req := &ReadRequest{}
+	err := recvRequest(st, req)
+	if err != nil {
+		return err
+	} else if err = checkPath(req.Path); err != nil {
+		return err
+	}
+
+	chunkSize := int(req.ChunkSize)
+	if chunkSize == 0 {
+		chunkSize = defaultChunkSize
+	} else if chunkSize < 0 || chunkSize > maxMessageSize {
+		return statusf(InvalidArgument, "invalid chunk size: %d", chunkSize)
+	}
+
+	f, err := s.client.Open(req.Path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if f.Stat().IsDir() {
+		return statusf(FailedPrecondition, "is a directory: %s", req.Path)
+	} else if req.Offset < 0 || req.Offset > f.Stat().Size() {
+		return statusf(OutOfRange, "offset %d is outside the file, which is %d bytes", req.Offset, f.Stat().Size())
+	}
+
+	_, err = f.Seek(req.Offset, io.SeekStart)
+	if err != nil {
+		return err
+	}
+
+	var r io.Reader = f
+	if req.Length > 0 {
+		r = io.LimitReader(f, req.Length)
+	}
+
+	buf := make([]byte, chunkSize)
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		n, err := io.ReadFull(r, buf)
+		if n > 0 {
+			sendErr := st.send(&ReadResponse{Data: buf[:n]})
+			if sendErr != nil {
+				return sendErr
+			}
+		}
+
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+}
+
+func (s *Server) write(ctx context.Context, st *stream) error {
+	req := &WriteRequest{}
+	err := recvRequest(st, req)
+	if err != nil {
+		return err
+	} else if err = checkPath(req.Path); err != nil {
+		return err
+	} else if req.Append && req.Overwrite {
+		return statusf(InvalidArgument, "append and overwrite can't both be set")
+	}
+
+	wr := &writeRequestReader{ctx: ctx, st: st, buf: req.Data}
+	if req.Append {
+		f, err := s.client.Append(req.Path)
+		if err != nil {
+			return err
+		}
+
+		_, err = io.Copy(f, wr)
+		if err != nil {
+			f.Close()
+			return err
+		}
+
+		err = f.Close()
+		if err != nil {
+			return err
+		}
+	} else {
+		// The file is written to a temporary file and then renamed into place,
+		// so that if the client goes away halfway through, nothing is left
+		// behind.
+		err = s.client.WriteFileAtomic(req.Path, wr, hdfs.CreateOptions{
+			Overwrite:   req.Overwrite,
+			Perm:        os.FileMode(req.Permissions) & os.ModePerm,
+			Replication: int(req.Replication),
+			BlockSize:   req.BlockSize,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return st.send(&WriteResponse{BytesWritten: wr.n})
+}
+
+// writeRequestReader is an io.Reader for the data in the stream of
+// WriteRequest messages from a client.
+type writeRequestReader struct {
+	ctx context.Context
+	st  *stream
+	buf []byte
+	n   int64
+}
+
+func (wr *writeRequestReader) Read(b []byte) (int, error) {
+	for len(wr.buf) == 0 {
+		if wr.ctx.Err() != nil {
+			return 0, wr.ctx.Err()
+		}
+
+		req := &WriteRequest{}
+		err := wr.st.recv(req)
+		if err != nil {
+			return 0, err
+		}
+
+		wr.buf = req.Data
+	}
+
+	n := copy(b, wr.buf)
+	wr.buf = wr.buf[n:]
+	wr.n += int64(n)
+	return n, nil
+}
+
+func (s *Server) mkdir(st *stream) error {
+	req := &MkdirRequest{}
+	err := recvRequest(st, req)
+	if err != nil {
+		return err
+	} else if err = checkPath(req.Path); err != nil {
+		return err
+	}
+
+	perm := os.FileMode(req.Permissions) & os.ModePerm
+	if perm == 0 {
+		perm = 0755
+	}
+
+	if req.Parents {
+		err = s.client.MkdirAll(req.Path, perm)
+	} else {
+		err = s.client.Mkdir(req.Path, perm)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return st.send(&MkdirResponse{})
+}
+
+func (s *Server) remove(st *stream) error {
+	req := &RemoveRequest{}
+	err := recvRequest(st, req)
+	if err != nil {
+		return err
+	} else if err = checkPath(req.Path); err != nil {
+		return err
+	} else if path.Clean(req.Path) == "/" {
+		return statusf(InvalidArgument, "refusing to remove /")
+	}
+
+	if req.Recursive {
+		// RemoveAll doesn't return an error if the path doesn't exist, but
+		// Remove does, so check first for consistency.
+		_, err = s.client.Stat(req.Path)
+		if err == nil {
+			err = s.client.RemoveAll(req.Path)
+		}
+	} else {
+		err = s.client.Remove(req.Path)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return st.send(&RemoveResponse{})
+}
+
+func (s *Server) rename(st *stream) error {
+	req := &RenameRequest{}
+	err := recvRequest(st, req)
+	if err != nil {
+		return err
+	} else if err = checkPath(req.OldPath); err != nil {
+		return err
+	} else if err = checkPath(req.NewPath); err != nil {
+		return err
+	}
+
+	err = s.client.Rename(req.OldPath, req.NewPath)
+	if err != nil {
+		return err
+	}
+
+	return st.send(&RenameResponse{})
+}
+
+// fileInfo converts an os.FileInfo returned by the client to a FileInfo
+// message.
+func fileInfo(fi os.FileInfo) *FileInfo {
+	m := &FileInfo{
+		Name:             fi.Name(),
+		Size:             fi.Size(),
+		Permissions:      uint32(fi.Mode() & os.ModePerm),
+		IsDir:            fi.IsDir(),
+		ModificationTime: timeMillis(fi.ModTime()),
+	}
+
+	if hfi, ok := fi.(*hdfs.FileInfo); ok {
+		m.AccessTime = timeMillis(hfi.AccessTime())
+		m.Owner = hfi.Owner()
+		m.Group = hfi.OwnerGroup()
+		if !fi.IsDir() {
+			m.Replication = int32(hfi.Replication())
+			m.BlockSize = hfi.BlockSize()
+		}
+	}
+
+	return m
+}
+
+func timeMillis(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+
+	return t.UnixNano() / int64(time.Millisecond)
+}
+
+// toStatus converts an error returned by the client to a *Status with the
+// appropriate code.
+func toStatus(err error) error {
+	var status *Status
+	switch {
+	case err == nil:
+		return nil
+	case errors.As(err, &status):
+		return status
+	case errors.Is(err, context.DeadlineExceeded):
+		return statusf(DeadlineExceeded, "%s", err)
+	case errors.Is(err, context.Canceled):
+		return statusf(Canceled, "%s", err)
+	case errors.Is(err, os.ErrNotExist):
+		return statusf(NotFound, "%s", err)
+	case errors.Is(err, os.ErrExist):
+		return statusf(AlreadyExists, "%s", err)
+	case errors.Is(err, os.ErrPermission):
+		return statusf(PermissionDenied, "%s", err)
+	case errors.Is(err, syscall.ENOTEMPTY), errors.Is(err, syscall.ENOTDIR), errors.Is(err, syscall.EISDIR):
+		return statusf(FailedPrecondition, "%s", err)
+	default:
+		return statusf(Unknown, "%s", err)
+	}
+}
@@ -0,0 +1,84 @@
+package hdfs
+
+import (
+	"errors"
+	"math"
+	"os"
+	"strings"
+
+	hdfs "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_hdfs"
+	"github.com/golang/protobuf/proto"
+)
+
+const (
+	// quotaDontSet tells the namenode to leave a quota as it is.
+	quotaDontSet = math.MaxInt64
+	// quotaReset tells the namenode to remove a quota.
+	quotaReset = -1
+)
+
+// StorageTypeQuota is the quota for one type of storage, for example "SSD",
+// on a directory, as returned by ContentSummary.StorageTypeQuotas.
+type StorageTypeQuota struct {
+	StorageType string
+	// Quota is the limit on the bytes stored on that type of storage,
+	// including replication, or -1 if there is none.
+	Quota int64
+	// Consumed is the number of bytes currently stored on it.
+	Consumed int64
+}
+
+// SetQuota sets the name quota for the named directory, which limits the
+// number of files and directories under it, including the directory itself.
+// If quota is negative, the quota is removed.
+func (c *Client) SetQuota(name string, quota int64) error {
+	if quota < 0 {
+		quota = quotaReset
+	}
+
+	return c.setQuota(name, quota, quotaDontSet, nil)
+}
+
+// SetSpaceQuota sets the space quota for the named directory, which limits
+// the total size of the files under it, counting every replica. If quota is
+// negative, the quota is removed.
+func (c *Client) SetSpaceQuota(name string, quota int64) error {
+	if quota < 0 {
+		quota = quotaReset
+	}
+
+	return c.setQuota(name, quotaDontSet, quota, nil)
+}
+
+// SetStorageTypeQuota is like SetSpaceQuota, but only limits the bytes stored
+// on one type of storage, for example "SSD" or "ARCHIVE".
+func (c *Client) SetStorageTypeQuota(name, storageType string, quota int64) error {
+	t, ok := hdfs.StorageTypeProto_value[strings.ToUpper(storageType)]
+	if !ok {
+		return &os.PathError{"setquota", name, errors.New("invalid storage type: " + storageType)}
+	}
+
+	if quota < 0 {
+		quota = quotaReset
+	}
+
+	st := hdfs.StorageTypeProto(t)
+	return c.setQuota(name, quotaDontSet, quota, &st)
+}
+
+func (c *Client) setQuota(name string, nameQuota, spaceQuota int64, storageType *hdfs.StorageTypeProto) error {
+	req := &hdfs.SetQuotaRequestProto{
+		Path:              proto.String(name),
+		NamespaceQuota:    proto.Uint64(uint64(nameQuota)),
+		StoragespaceQuota: proto.Uint64(uint64(spaceQuota)),
+		StorageType:       storageType,
+	}
+	resp := &hdfs.SetQuotaResponseProto{}
+
+	err := c.namenode.Execute("setQuota", req, resp)
+	if err != nil {
+		return &os.PathError{"setquota", name, interpretException(err)}
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package hdfs
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetQuota(t *testing.T) {
+	client := getClient(t)
+
+	baleet(t, "/_test/quota")
+	mkdirp(t, "/_test/quota/dir")
+	touch(t, "/_test/quota/foo")
+
+	err := client.SetQuota("/_test/quota", 10)
+	require.NoError(t, err)
+
+	err = client.SetSpaceQuota("/_test/quota", 1048576*100)
+	require.NoError(t, err)
+
+	cs, err := client.GetContentSummary("/_test/quota")
+	require.NoError(t, err)
+	assert.EqualValues(t, 10, cs.NameQuota())
+	assert.EqualValues(t, 1048576*100, cs.SpaceQuota())
+
+	err = client.SetStorageTypeQuota("/_test/quota", "ssd", 1048576)
+	require.NoError(t, err)
+
+	cs, err = client.GetContentSummary("/_test/quota")
+	require.NoError(t, err)
+	assert.Contains(t, cs.StorageTypeQuotas(), StorageTypeQuota{StorageType: "SSD", Quota: 1048576})
+
+	// The name quota counts the directory itself.
+	err = client.SetQuota("/_test/quota", 3)
+	require.NoError(t, err)
+
+	err = client.Mkdir("/_test/quota/dir2", 0777)
+	assert.Error(t, err)
+
+	err = client.SetQuota("/_test/quota", -1)
+	require.NoError(t, err)
+
+	err = client.SetSpaceQuota("/_test/quota", -1)
+	require.NoError(t, err)
+
+	cs, err = client.GetContentSummary("/_test/quota")
+	require.NoError(t, err)
+	assert.EqualValues(t, -1, cs.NameQuota())
+	assert.EqualValues(t, -1, cs.SpaceQuota())
+
+	err = client.Mkdir("/_test/quota/dir2", 0777)
+	assert.NoError(t, err)
+}
+
+func TestSetQuotaNonExistent(t *testing.T) {
+	client := getClient(t)
+
+	err := client.SetQuota("/_test/nonexistent", 10)
+	assertPathError(t, err, "setquota", "/_test/nonexistent", os.ErrNotExist)
+}
+
+func TestSetStorageTypeQuotaInvalid(t *testing.T) {
+	client := getClient(t)
+
+	err := client.SetStorageTypeQuota("/_test", "floppy", 10)
+	assert.Error(t, err)
+}
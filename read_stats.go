@@ -0,0 +1,45 @@
+package hdfs
+
+import "time"
+
+// DatanodeReadStats describes the reads a client has made from a single
+// datanode, for diagnosing problems like poor locality or overloaded nodes.
+type DatanodeReadStats struct {
+	// Address is the address (<host>:<port>) of the datanode.
+	Address string
+	// Bytes is the total number of bytes read from the datanode.
+	Bytes int64
+	// Reads is the number of successful reads from the datanode.
+	Reads int64
+	// Errors is the number of times connecting to or reading from the datanode
+	// failed. Each of those reads was retried on another datanode, if possible.
+	Errors int64
+	// LatencyP50, LatencyP90, and LatencyP99 are percentiles of the time each
+	// read from the datanode took, over the last 1024 reads. A read is a
+	// single call to the datanode by a FileReader, so its latency depends on the
+	// size of the buffers passed to Read as well as the datanode.
+	LatencyP50 time.Duration
+	LatencyP90 time.Duration
+	LatencyP99 time.Duration
+}
+
+// DatanodeReadStats returns statistics about the datanodes that the client has
+// read from, or tried to, sorted by address. Both block data and erasure-coded
+// internal blocks are counted, but not checksum requests.
+func (c *Client) DatanodeReadStats() []DatanodeReadStats {
+	stats := c.readStats.Stats()
+	result := make([]DatanodeReadStats, len(stats))
+	for i, s := range stats {
+		result[i] = DatanodeReadStats{
+			Address:    s.Address,
+			Bytes:      s.Bytes,
+			Reads:      s.Reads,
+			Errors:     s.Errors,
+			LatencyP50: s.LatencyP50,
+			LatencyP90: s.LatencyP90,
+			LatencyP99: s.LatencyP99,
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,26 @@
+package hdfs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatanodeReadStats(t *testing.T) {
+	client := newClientForUser(t, "gohdfs1")
+	defer client.Close()
+
+	assert.Empty(t, client.DatanodeReadStats())
+
+	_, err := client.ReadFile("/_test/foo.txt")
+	require.NoError(t, err)
+
+	stats := client.DatanodeReadStats()
+	require.Len(t, stats, 1)
+	assert.NotEmpty(t, stats[0].Address)
+	assert.EqualValues(t, 4, stats[0].Bytes)
+	assert.EqualValues(t, 0, stats[0].Errors)
+	assert.True(t, stats[0].Reads > 0)
+	assert.True(t, stats[0].LatencyP99 >= stats[0].LatencyP50)
+}
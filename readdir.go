@@ -1,12 +1,17 @@
 package hdfs
 
-import "os"
+import (
+	"context"
+	"os"
+)
 
 // ReadDir reads the directory named by dirname and returns a list of sorted
 // directory entries.
 //
 // The os.FileInfo values returned will not have block location attached to
-// the struct returned by Sys().
+// the struct returned by Sys(). To list very large directories
+// incrementally, use ListStatusIterator, or ListLocatedStatus to list the
+// block locations too.
 func (c *Client) ReadDir(dirname string) ([]os.FileInfo, error) {
 	f, err := c.Open(dirname)
 	if err != nil {
@@ -15,3 +20,14 @@ func (c *Client) ReadDir(dirname string) ([]os.FileInfo, error) {
 
 	return f.Readdir(0)
 }
+
+// ReadDirContext is like ReadDir, but gives up once ctx is done.
+func (c *Client) ReadDirContext(ctx context.Context, dirname string) ([]os.FileInfo, error) {
+	f, err := c.OpenContext(ctx, dirname)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return f.Readdir(0)
+}
@@ -0,0 +1,110 @@
+package hdfs
+
+import (
+	hadoop "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_common"
+	"github.com/colinmarc/hdfs/v2/internal/rpc"
+	"github.com/golang/protobuf/proto"
+)
+
+const (
+	refreshUserMappingsProtocol = "org.apache.hadoop.security.RefreshUserMappingsProtocol"
+	genericRefreshProtocol      = "org.apache.hadoop.ipc.GenericRefreshProtocol"
+)
+
+// RefreshResponse is the response of a single refresh handler on a namenode
+// to Refresh.
+type RefreshResponse struct {
+	// Address is the address of the namenode.
+	Address string
+	// Sender is the name of the handler.
+	Sender string
+	// ExitStatus is zero if the refresh succeeded.
+	ExitStatus int
+	Message    string
+}
+
+// RefreshUserToGroupsMappings makes the namenodes flush their caches of the
+// groups each user belongs to, like 'hdfs dfsadmin
+// -refreshUserToGroupsMappings'. Like all the refresh methods, it's sent to
+// each of the namenodes the client is configured with, and requires superuser
+// privileges.
+func (c *Client) RefreshUserToGroupsMappings() error {
+	req := &hadoop.RefreshUserToGroupsMappingsRequestProto{}
+	return c.executeOnNamenodes(refreshUserMappingsProtocol, "refreshUserToGroupsMappings", req,
+		func(string) proto.Message { return &hadoop.RefreshUserToGroupsMappingsResponseProto{} })
+}
+
+// RefreshSuperUserGroupsConfiguration makes the namenodes reload the proxy
+// user settings (hadoop.proxyuser.*) from their configuration, like 'hdfs
+// dfsadmin -refreshSuperUserGroupsConfiguration'.
+func (c *Client) RefreshSuperUserGroupsConfiguration() error {
+	req := &hadoop.RefreshSuperUserGroupsConfigurationRequestProto{}
+	return c.executeOnNamenodes(refreshUserMappingsProtocol, "refreshSuperUserGroupsConfiguration", req,
+		func(string) proto.Message { return &hadoop.RefreshSuperUserGroupsConfigurationResponseProto{} })
+}
+
+// Refresh calls the refresh handler registered on the namenodes with the given
+// identifier, like 'hdfs dfsadmin -refresh <host:port> <identifier> [args]'.
+// It returns the responses of every handler on every namenode. A handler
+// failing is reported by its ExitStatus, not as an error.
+func (c *Client) Refresh(identifier string, args ...string) ([]RefreshResponse, error) {
+	req := &hadoop.GenericRefreshRequestProto{
+		Identifier: proto.String(identifier),
+		Args:       args,
+	}
+
+	var responses []RefreshResponse
+	var collections []*hadoop.GenericRefreshResponseCollectionProto
+	var addresses []string
+	err := c.executeOnNamenodes(genericRefreshProtocol, "refresh", req, func(address string) proto.Message {
+		resp := &hadoop.GenericRefreshResponseCollectionProto{}
+		collections = append(collections, resp)
+		addresses = append(addresses, address)
+		return resp
+	})
+
+	for i, collection := range collections {
+		for _, r := range collection.GetResponses() {
+			responses = append(responses, RefreshResponse{
+				Address:    addresses[i],
+				Sender:     r.GetSenderName(),
+				ExitStatus: int(r.GetExitStatus()),
+				Message:    r.GetUserMessage(),
+			})
+		}
+	}
+
+	return responses, err
+}
+
+// executeOnNamenodes makes the same request to each namenode in turn, using the
+// given protocol, rather than to whichever one is active. newResp is called
+// with the address of each namenode to get a response to fill in. It returns
+// the first error, after trying every namenode.
+func (c *Client) executeOnNamenodes(protocol, method string, req proto.Message, newResp func(address string) proto.Message) error {
+	var firstErr error
+	for _, address := range c.options.Addresses {
+		conn, err := rpc.NewNamenodeConnection(rpc.NamenodeConnectionOptions{
+			Addresses:                    []string{address},
+			User:                         c.namenode.User,
+			DialFunc:                     newNamenodeDialFunc(c.options),
+			KerberosClient:               c.options.KerberosClient,
+			KerberosServicePrincipleName: c.options.KerberosServicePrincipleName,
+			Token:                        c.options.DelegationToken.proto(),
+			Protocol:                     protocol,
+			WireLog:                      c.wireLog,
+			Strict:                       c.options.StrictProtocol,
+		})
+
+		if err == nil {
+			err = conn.Execute(method, req, newResp(address))
+			conn.Close()
+		}
+
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
@@ -0,0 +1,38 @@
+package hdfs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRefreshUserToGroupsMappings(t *testing.T) {
+	client := getClientForSuperUser(t)
+
+	err := client.RefreshUserToGroupsMappings()
+	require.NoError(t, err)
+}
+
+func TestRefreshSuperUserGroupsConfiguration(t *testing.T) {
+	client := getClientForSuperUser(t)
+
+	err := client.RefreshSuperUserGroupsConfiguration()
+	require.NoError(t, err)
+}
+
+func TestRefreshWithoutPermission(t *testing.T) {
+	client := getClient(t)
+
+	err := client.RefreshUserToGroupsMappings()
+	require.Error(t, err)
+}
+
+func TestRefreshUnknownIdentifier(t *testing.T) {
+	client := getClientForSuperUser(t)
+
+	// The namenode rejects identifiers that don't have any handlers.
+	responses, err := client.Refresh("nonexistent")
+	assert.Error(t, err)
+	assert.Empty(t, responses)
+}
@@ -1,6 +1,7 @@
 package hdfs
 
 import (
+	"context"
 	"errors"
 	"os"
 
@@ -13,16 +14,38 @@ func (c *Client) Remove(name string) error {
 	return delete(c, name, false)
 }
 
-// RemoveAll removes path and any children it contains. It removes everything it
-// can but returns the first error it encounters. If the path does not exist,
-// RemoveAll returns nil (no error).
-func (c *Client) RemoveAll(name string) error {
-	err := delete(c, name, true)
-	if os.IsNotExist(err) {
-		return nil
+// RemoveResult describes the outcome of removing a single path with
+// RemoveMany.
+type RemoveResult struct {
+	Name string
+	// Err is set if removing Name failed. Like with Remove, it's an
+	// *os.PathError, wrapping os.ErrNotExist if Name didn't exist.
+	Err error
+}
+
+// RemoveMany removes each of the named files or (empty) directories, like
+// Remove, with up to concurrency delete requests in flight at once. Requests
+// to a single namenode connection are sequential, so this opens up to
+// concurrency-1 extra connections for the duration of the call. If concurrency
+// is less than two, the paths are removed one at a time.
+//
+// It returns a result for each path, in the same order, and the first error
+// encountered. Failing to remove one path doesn't stop the others from being
+// removed.
+func (c *Client) RemoveMany(names []string, concurrency int) ([]RemoveResult, error) {
+	results := make([]RemoveResult, len(names))
+	c.runBatch(len(names), concurrency, func(worker *Client, i int) bool {
+		results[i] = RemoveResult{Name: names[i], Err: worker.Remove(names[i])}
+		return true
+	})
+
+	for _, result := range results {
+		if result.Err != nil {
+			return results, result.Err
+		}
 	}
 
-	return err
+	return results, nil
 }
 
 func delete(c *Client, name string, recursive bool) error {
@@ -31,13 +54,27 @@ func delete(c *Client, name string, recursive bool) error {
 		return &os.PathError{"remove", name, err}
 	}
 
+	return c.deleteRPC(name, recursive)
+}
+
+// deleteRPC deletes name, without checking that it exists first. The namenode
+// reports a missing file by returning false, rather than with an error.
+func (c *Client) deleteRPC(name string, recursive bool) error {
 	req := &hdfs.DeleteRequestProto{
 		Src:       proto.String(name),
 		Recursive: proto.Bool(recursive),
 	}
 	resp := &hdfs.DeleteResponseProto{}
 
-	err = c.namenode.Execute("delete", req, resp)
+	var err error
+	if c.web != nil {
+		var ok bool
+		ok, err = c.web.delete(context.Background(), name, recursive)
+		resp.Result = proto.Bool(ok)
+	} else {
+		err = c.namenode.Execute("delete", req, resp)
+	}
+
 	if err != nil {
 		return &os.PathError{"remove", name, interpretException(err)}
 	} else if resp.Result == nil {
@@ -46,6 +83,8 @@ func delete(c *Client, name string, recursive bool) error {
 			name,
 			errors.New("unexpected empty response"),
 		}
+	} else if !resp.GetResult() {
+		return &os.PathError{"remove", name, os.ErrNotExist}
 	}
 
 	return nil
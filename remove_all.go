@@ -0,0 +1,307 @@
+package hdfs
+
+import (
+	"io"
+	"net"
+	"os"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/colinmarc/hdfs/v2/internal/rpc"
+)
+
+const (
+	retriableException = "org.apache.hadoop.ipc.RetriableException"
+	safeModeException  = "org.apache.hadoop.hdfs.server.namenode.SafeModeException"
+
+	// removeAllBatchSize is the number of directory entries listed at once by
+	// RemoveAll. Directories with fewer files than this are deleted with a
+	// single recursive delete, once their subdirectories are gone.
+	removeAllBatchSize = 1000
+)
+
+// RemoveAllOptions specifies how RemoveAllWithOptions deletes a tree.
+type RemoveAllOptions struct {
+	// Concurrency is the maximum number of requests in flight at once. Like
+	// RemoveMany, extra namenode connections are opened for them, but only
+	// once there's more than one thing to delete at a time. If zero, 8 is
+	// used.
+	Concurrency int
+	// Retries is the number of times each request is retried after a
+	// transient failure, like a timeout or the namenode being in safe mode. If
+	// zero, 3 is used; if negative, requests aren't retried.
+	Retries int
+	// RetryInterval is how long to wait before retrying a request. If zero,
+	// one second is used.
+	RetryInterval time.Duration
+}
+
+// RemoveAll removes path and any children it contains. It removes everything it
+// can but returns the first error it encounters. If the path does not exist,
+// RemoveAll returns nil (no error).
+//
+// Rather than deleting a whole directory tree with a single request, which can
+// take long enough for a large tree that the request times out, RemoveAll
+// deletes it bottom-up, a few subdirectories at a time. It's equivalent to
+// calling RemoveAllWithOptions with the default options.
+func (c *Client) RemoveAll(name string) error {
+	return c.RemoveAllWithOptions(name, RemoveAllOptions{})
+}
+
+// RemoveAllWithOptions is like RemoveAll, but with the given options.
+func (c *Client) RemoveAllWithOptions(name string, options RemoveAllOptions) error {
+	if options.Concurrency <= 0 {
+		options.Concurrency = 8
+	}
+
+	if options.Retries == 0 {
+		options.Retries = 3
+	}
+
+	if options.RetryInterval == 0 {
+		options.RetryInterval = time.Second
+	}
+
+	r := &treeRemover{
+		pool:    newClientPool(c, options.Concurrency),
+		sem:     make(chan struct{}, options.Concurrency-1),
+		options: options,
+	}
+
+	defer r.pool.close()
+
+	var info os.FileInfo
+	err := r.do(func(w *Client) error {
+		var err error
+		info, err = w.getFileInfo(name)
+		return interpretException(err)
+	})
+
+	if err == nil {
+		if info.IsDir() {
+			err = r.removeDir(name)
+		} else {
+			err = r.delete(name, false)
+		}
+	} else {
+		err = &os.PathError{"remove", name, err}
+	}
+
+	if os.IsNotExist(err) {
+		return nil
+	}
+
+	return err
+}
+
+type treeRemover struct {
+	pool    *clientPool
+	sem     chan struct{}
+	options RemoveAllOptions
+}
+
+// removeDir removes the subdirectories of name concurrently, and then name
+// itself. If name has a lot of files, they're deleted individually; otherwise
+// they're deleted along with name.
+func (r *treeRemover) removeDir(name string) error {
+	var f *FileReader
+	err := r.do(func(w *Client) error {
+		var err error
+		f, err = w.Open(name)
+		return underlying(err)
+	})
+
+	if err != nil {
+		return &os.PathError{"remove", name, err}
+	}
+
+	defer f.Close()
+
+	var errs firstError
+	var wg sync.WaitGroup
+	var files []string
+	for {
+		// The FileReader keeps using the connection it was opened with, which
+		// is fine, since connections are safe for concurrent use.
+		var page []os.FileInfo
+		err = r.do(func(*Client) error {
+			var err error
+			page, err = f.Readdir(removeAllBatchSize)
+			return underlying(err)
+		})
+
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			errs.set(&os.PathError{"remove", name, err})
+			break
+		}
+
+		for _, child := range page {
+			childName := path.Join(name, child.Name())
+			if child.IsDir() {
+				r.spawn(&wg, func() { errs.set(r.removeDir(childName)) })
+			} else {
+				files = append(files, childName)
+			}
+		}
+
+		if len(files) >= removeAllBatchSize {
+			for _, file := range files {
+				file := file
+				r.spawn(&wg, func() { errs.set(r.delete(file, false)) })
+			}
+
+			files = nil
+		}
+	}
+
+	wg.Wait()
+	if errs.err != nil {
+		return errs.err
+	}
+
+	return r.delete(name, true)
+}
+
+func (r *treeRemover) delete(name string, recursive bool) error {
+	err := r.do(func(w *Client) error {
+		return underlying(w.deleteRPC(name, recursive))
+	})
+
+	if err != nil {
+		return &os.PathError{"remove", name, err}
+	}
+
+	return nil
+}
+
+// spawn calls fn in a new goroutine if there are fewer than
+// options.Concurrency running, or otherwise in the current one. That bounds
+// the number of goroutines, without the risk of every goroutine waiting for
+// another one to start.
+func (r *treeRemover) spawn(wg *sync.WaitGroup, fn func()) {
+	select {
+	case r.sem <- struct{}{}:
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-r.sem
+				wg.Done()
+			}()
+
+			fn()
+		}()
+	default:
+		fn()
+	}
+}
+
+// do calls fn with a client from the pool, retrying transient failures.
+func (r *treeRemover) do(fn func(w *Client) error) error {
+	for attempt := 0; ; attempt++ {
+		w := r.pool.get()
+		err := fn(w)
+		r.pool.put(w)
+
+		if err == nil || attempt >= r.options.Retries || !isTransient(err) {
+			return err
+		}
+
+		time.Sleep(r.options.RetryInterval)
+	}
+}
+
+func isTransient(err error) bool {
+	if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+		return true
+	}
+
+	if remoteErr, ok := err.(Error); ok {
+		switch remoteErr.Exception() {
+		case retriableException, safeModeException:
+			return true
+		}
+	}
+
+	return false
+}
+
+// firstError records the first error (other than os.ErrNotExist) set on it
+// from any goroutine. Files that are already gone don't need to be deleted.
+type firstError struct {
+	lock sync.Mutex
+	err  error
+}
+
+func (e *firstError) set(err error) {
+	if err == nil || os.IsNotExist(err) {
+		return
+	}
+
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	if e.err == nil {
+		e.err = err
+	}
+}
+
+// clientPool hands out clients with their own namenode connections, so that
+// requests can be made concurrently. The first is the client itself, and up to
+// size-1 more are opened as they're needed.
+type clientPool struct {
+	client *Client
+	size   int
+	idle   chan *Client
+
+	lock   sync.Mutex
+	opened int
+	conns  []*rpc.NamenodeConnection
+}
+
+func newClientPool(c *Client, size int) *clientPool {
+	p := &clientPool{client: c, size: size, idle: make(chan *Client, size), opened: 1}
+	p.idle <- c
+	return p
+}
+
+func (p *clientPool) get() *Client {
+	select {
+	case w := <-p.idle:
+		return w
+	default:
+	}
+
+	p.lock.Lock()
+	open := p.opened < p.size
+	if open {
+		// If opening the connection fails, this still counts towards the size,
+		// so that it isn't retried over and over.
+		p.opened++
+	}
+
+	p.lock.Unlock()
+
+	if open {
+		namenode, err := newNamenodeConnection(p.client.options, p.client.conns)
+		if err == nil {
+			p.lock.Lock()
+			p.conns = append(p.conns, namenode)
+			p.lock.Unlock()
+			return &Client{namenode: namenode, options: p.client.options, web: p.client.web}
+		}
+	}
+
+	return <-p.idle
+}
+
+func (p *clientPool) put(w *Client) {
+	p.idle <- w
+}
+
+func (p *clientPool) close() {
+	for _, namenode := range p.conns {
+		namenode.Close()
+	}
+}
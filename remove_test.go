@@ -1,6 +1,7 @@
 package hdfs
 
 import (
+	"fmt"
 	"os"
 	"syscall"
 	"testing"
@@ -116,3 +117,89 @@ func TestRemoveAllWithoutPermission(t *testing.T) {
 	err := client.RemoveAll("/_test/accessdenied/foo")
 	assertPathError(t, err, "remove", "/_test/accessdenied/foo", os.ErrPermission)
 }
+
+func TestRemoveMany(t *testing.T) {
+	client := getClient(t)
+
+	mkdirp(t, "/_test/removemany")
+	var names []string
+	for i := 0; i < 20; i++ {
+		name := fmt.Sprintf("/_test/removemany/%d", i)
+		touch(t, name)
+		names = append(names, name)
+	}
+
+	names = append(names, "/_test/removemany/nonexistent")
+	results, err := client.RemoveMany(names, 4)
+	assertPathError(t, err, "remove", "/_test/removemany/nonexistent", os.ErrNotExist)
+	require.Len(t, results, len(names))
+
+	for i, result := range results {
+		assert.Equal(t, names[i], result.Name)
+		if i < 20 {
+			assert.NoError(t, result.Err)
+		}
+	}
+
+	children, err := client.ReadDir("/_test/removemany")
+	require.NoError(t, err)
+	assert.Empty(t, children)
+}
+
+func TestRemoveManyEmpty(t *testing.T) {
+	client := getClient(t)
+
+	results, err := client.RemoveMany(nil, 4)
+	require.NoError(t, err)
+	assert.Empty(t, results)
+}
+
+func setupRemoveAllTree(t *testing.T, name string) {
+	mkdirp(t, name)
+	for i := 0; i < 3; i++ {
+		dir := fmt.Sprintf("%s/%d", name, i)
+		mkdirp(t, dir+"/nested")
+		touch(t, dir+"/foo")
+		touch(t, dir+"/nested/bar")
+	}
+
+	touch(t, name+"/baz")
+}
+
+func TestRemoveAllTree(t *testing.T) {
+	client := getClient(t)
+
+	setupRemoveAllTree(t, "/_test/removealltree")
+	err := client.RemoveAll("/_test/removealltree")
+	require.NoError(t, err)
+
+	_, err = client.Stat("/_test/removealltree")
+	assertPathError(t, err, "stat", "/_test/removealltree", os.ErrNotExist)
+}
+
+func TestRemoveAllWithOptions(t *testing.T) {
+	client := getClient(t)
+
+	for _, concurrency := range []int{1, 4} {
+		setupRemoveAllTree(t, "/_test/removealltree")
+		err := client.RemoveAllWithOptions("/_test/removealltree", RemoveAllOptions{Concurrency: concurrency, Retries: -1})
+		require.NoError(t, err)
+
+		_, err = client.Stat("/_test/removealltree")
+		assertPathError(t, err, "stat", "/_test/removealltree", os.ErrNotExist)
+	}
+}
+
+func TestRemoveAllTreeWithoutPermission(t *testing.T) {
+	client := getClientForUser(t, "gohdfs2")
+
+	mkdirp(t, "/_test/accessdenied")
+	mkdirp(t, "/_test/accessdenied/dir")
+	touch(t, "/_test/accessdenied/dir/foo")
+
+	err := client.RemoveAll("/_test/accessdenied/dir")
+	assertPathError(t, err, "remove", "/_test/accessdenied/dir", os.ErrPermission)
+
+	_, err = getClient(t).Stat("/_test/accessdenied/dir/foo")
+	require.NoError(t, err)
+}
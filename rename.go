@@ -2,6 +2,7 @@ package hdfs
 
 import (
 	"os"
+	"path"
 
 	hdfs "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_hdfs"
 	"github.com/golang/protobuf/proto"
@@ -15,17 +16,100 @@ func (c *Client) Rename(oldpath, newpath string) error {
 		return &os.PathError{"rename", newpath, err}
 	}
 
+	err = c.rename2(oldpath, newpath, true)
+	if err != nil {
+		return &os.PathError{"rename", oldpath, err}
+	}
+
+	return nil
+}
+
+// rename2 renames oldpath to newpath, optionally replacing an existing file at
+// newpath. The returned error has already been passed through
+// interpretException.
+func (c *Client) rename2(oldpath, newpath string, overwrite bool) error {
+	if c.web != nil {
+		return c.renameWeb(oldpath, newpath, overwrite)
+	}
+
 	req := &hdfs.Rename2RequestProto{
 		Src:           proto.String(oldpath),
 		Dst:           proto.String(newpath),
-		OverwriteDest: proto.Bool(true),
+		OverwriteDest: proto.Bool(overwrite),
 	}
 	resp := &hdfs.Rename2ResponseProto{}
 
-	err = c.namenode.Execute("rename2", req, resp)
-	if err != nil {
-		return &os.PathError{"rename", oldpath, interpretException(err)}
+	err := c.namenode.Execute("rename2", req, resp)
+	return interpretException(err)
+}
+
+// RenameOptions specifies how RenameManyWithOptions renames files.
+type RenameOptions struct {
+	// Concurrency is the number of renames in flight at once. If less than two,
+	// the files are renamed one at a time.
+	Concurrency int
+	// StopOnError specifies that once a rename fails, no more should be
+	// started. Renames that were never attempted are marked as skipped.
+	StopOnError bool
+	// MaxPerDirectory, if set, limits the number of renames in flight into any
+	// one destination directory, to limit contention for the namenode's locks.
+	MaxPerDirectory int
+}
+
+// RenameResult describes the outcome of a single rename by RenameMany.
+type RenameResult struct {
+	Source string
+	Dest   string
+	// Skipped is true if the rename wasn't attempted, because an earlier one
+	// failed and RenameOptions.StopOnError was set.
+	Skipped bool
+	// Err is set if the rename failed.
+	Err error
+}
+
+// RenameMany renames each pair of paths, from the first to the second, like
+// Rename. It's equivalent to calling RenameManyWithOptions with the given
+// concurrency.
+func (c *Client) RenameMany(pairs [][2]string, concurrency int) ([]RenameResult, error) {
+	return c.RenameManyWithOptions(pairs, RenameOptions{Concurrency: concurrency})
+}
+
+// RenameManyWithOptions renames each pair of paths, from the first to the
+// second, like Rename, with the given options. Like RemoveMany, it opens extra
+// namenode connections so that several renames can be in flight at once. The
+// renames aren't atomic as a whole, and they aren't necessarily executed in
+// order.
+//
+// It returns a result for each pair, in the same order, and the first error
+// encountered.
+func (c *Client) RenameManyWithOptions(pairs [][2]string, options RenameOptions) ([]RenameResult, error) {
+	results := make([]RenameResult, len(pairs))
+	dirs := make(map[string]chan struct{})
+	for i, pair := range pairs {
+		results[i] = RenameResult{Source: pair[0], Dest: pair[1], Skipped: true}
+
+		dir := path.Dir(pair[1])
+		if _, ok := dirs[dir]; !ok && options.MaxPerDirectory > 0 {
+			dirs[dir] = make(chan struct{}, options.MaxPerDirectory)
+		}
 	}
 
-	return nil
+	c.runBatch(len(pairs), options.Concurrency, func(worker *Client, i int) bool {
+		if sem, ok := dirs[path.Dir(pairs[i][1])]; ok {
+			sem <- struct{}{}
+			defer func() { <-sem }()
+		}
+
+		results[i].Skipped = false
+		results[i].Err = worker.Rename(pairs[i][0], pairs[i][1])
+		return results[i].Err == nil || !options.StopOnError
+	})
+
+	for _, result := range results {
+		if result.Err != nil {
+			return results, result.Err
+		}
+	}
+
+	return results, nil
 }
@@ -1,6 +1,7 @@
 package hdfs
 
 import (
+	"fmt"
 	"os"
 	"testing"
 
@@ -66,3 +67,74 @@ func TestRenameWithoutPermissionForDest(t *testing.T) {
 	err = client2.Rename("/_test/ownedbyother2", "/_test/accessdenied/tomovedest4")
 	assertPathError(t, err, "rename", "/_test/accessdenied/tomovedest4", os.ErrPermission)
 }
+
+func TestRenameMany(t *testing.T) {
+	client := getClient(t)
+
+	mkdirp(t, "/_test/renamemany/src")
+	mkdirp(t, "/_test/renamemany/dst1")
+	mkdirp(t, "/_test/renamemany/dst2")
+
+	var pairs [][2]string
+	for i := 0; i < 10; i++ {
+		src := fmt.Sprintf("/_test/renamemany/src/%d", i)
+		touch(t, src)
+		pairs = append(pairs, [2]string{src, fmt.Sprintf("/_test/renamemany/dst%d/%d", i%2+1, i)})
+	}
+
+	options := RenameOptions{Concurrency: 4, MaxPerDirectory: 1}
+	results, err := client.RenameManyWithOptions(pairs, options)
+	require.NoError(t, err)
+	require.Len(t, results, len(pairs))
+
+	for i, result := range results {
+		assert.Equal(t, RenameResult{Source: pairs[i][0], Dest: pairs[i][1]}, result)
+
+		_, err = client.Stat(pairs[i][1])
+		assert.NoError(t, err)
+	}
+
+	children, err := client.ReadDir("/_test/renamemany/src")
+	require.NoError(t, err)
+	assert.Empty(t, children)
+}
+
+func TestRenameManyError(t *testing.T) {
+	client := getClient(t)
+
+	mkdirp(t, "/_test/renamemany2")
+	baleet(t, "/_test/nonexistent")
+	touch(t, "/_test/renamemany2/1")
+
+	pairs := [][2]string{
+		{"/_test/nonexistent", "/_test/renamemany2/nonexistent"},
+		{"/_test/renamemany2/1", "/_test/renamemany2/2"},
+	}
+
+	results, err := client.RenameMany(pairs, 2)
+	assertPathError(t, err, "rename", "/_test/nonexistent", os.ErrNotExist)
+	assert.Equal(t, err, results[0].Err)
+	assert.NoError(t, results[1].Err)
+	assert.False(t, results[1].Skipped)
+}
+
+func TestRenameManyStopOnError(t *testing.T) {
+	client := getClient(t)
+
+	mkdirp(t, "/_test/renamemany3")
+	baleet(t, "/_test/nonexistent")
+	touch(t, "/_test/renamemany3/1")
+
+	pairs := [][2]string{
+		{"/_test/nonexistent", "/_test/renamemany3/nonexistent"},
+		{"/_test/renamemany3/1", "/_test/renamemany3/2"},
+	}
+
+	results, err := client.RenameManyWithOptions(pairs, RenameOptions{StopOnError: true})
+	assertPathError(t, err, "rename", "/_test/nonexistent", os.ErrNotExist)
+	assert.True(t, results[1].Skipped)
+	assert.NoError(t, results[1].Err)
+
+	_, err = client.Stat("/_test/renamemany3/1")
+	assert.NoError(t, err)
+}
@@ -0,0 +1,139 @@
+package hdfs
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+
+	hdfs "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_hdfs"
+	"github.com/golang/protobuf/proto"
+)
+
+const (
+	reservedRawPrefix    = "/.reserved/raw"
+	reservedInodesPrefix = "/.reserved/.inodes"
+
+	// maxSymlinks is the number of symlinks ResolvePath follows before giving
+	// up, like the limit applied by the linux kernel.
+	maxSymlinks = 40
+)
+
+var errSymlinkLoop = errors.New("too many levels of symbolic links")
+
+// InodePath returns the reserved path that refers to the file or directory
+// with the given inode ID (as returned by FileInfo.FileID), for example
+// "/.reserved/.inodes/16386". The namenode accepts these paths wherever it
+// accepts a regular path, and they remain valid if the file is renamed.
+func InodePath(id uint64) string {
+	return reservedInodesPrefix + "/" + strconv.FormatUint(id, 10)
+}
+
+// OpenInode opens the file with the given inode ID for reading. It's
+// equivalent to calling Open with InodePath(id).
+func (c *Client) OpenInode(id uint64) (*FileReader, error) {
+	return c.Open(InodePath(id))
+}
+
+// ResolvePath returns the canonical form of the named path, with any symlinks
+// in it resolved, and the "/.reserved/raw" prefix (which refers to the raw,
+// encrypted contents of files in an encryption zone) removed. The path must
+// exist.
+//
+// A path under "/.reserved/.inodes/<id>" is resolved relative to the inode,
+// but is otherwise left in that form, since the namenode doesn't provide a way
+// to look up the full path of an inode.
+func (c *Client) ResolvePath(name string) (string, error) {
+	hops := 0
+	resolved, err := c.resolvePath(context.Background(), name, &hops)
+	if err != nil {
+		return "", &os.PathError{"resolvepath", name, interpretException(err)}
+	}
+
+	return resolved, nil
+}
+
+func (c *Client) resolvePath(ctx context.Context, name string, hops *int) (string, error) {
+	name = path.Clean(name)
+	if name == reservedRawPrefix || strings.HasPrefix(name, reservedRawPrefix+"/") {
+		name = path.Clean("/" + strings.TrimPrefix(name, reservedRawPrefix))
+	}
+
+	// In the common case that the path contains no symlinks, we can avoid
+	// checking each component of it separately.
+	status, err := c.getFileLinkInfoContext(ctx, name)
+	if err == nil && status.GetFileType() != hdfs.HdfsFileStatusProto_IS_SYMLINK {
+		return name, nil
+	}
+
+	resolved := "/"
+	rest := strings.TrimPrefix(name, "/")
+	if name == reservedInodesPrefix || strings.HasPrefix(name, reservedInodesPrefix+"/") {
+		parts := strings.SplitN(strings.TrimPrefix(name, reservedInodesPrefix+"/"), "/", 2)
+		resolved = reservedInodesPrefix + "/" + parts[0]
+		rest = ""
+		if len(parts) == 2 {
+			rest = parts[1]
+		}
+
+		if _, err := c.getFileLinkInfoContext(ctx, resolved); err != nil {
+			return "", err
+		}
+	}
+
+	for _, component := range strings.Split(rest, "/") {
+		if component == "" {
+			continue
+		}
+
+		candidate := path.Join(resolved, component)
+		status, err := c.getFileLinkInfoContext(ctx, candidate)
+		if err != nil {
+			return "", err
+		}
+
+		if status.GetFileType() != hdfs.HdfsFileStatusProto_IS_SYMLINK {
+			resolved = candidate
+			continue
+		}
+
+		*hops++
+		if *hops > maxSymlinks {
+			return "", errSymlinkLoop
+		}
+
+		target := string(status.GetSymlink())
+		if !path.IsAbs(target) {
+			target = path.Join(resolved, target)
+		}
+
+		resolved, err = c.resolvePath(ctx, target, hops)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return resolved, nil
+}
+
+// getFileLinkInfo is like getFileInfo, but returns the status of a symlink
+// itself, rather than its target.
+func (c *Client) getFileLinkInfo(name string) (*hdfs.HdfsFileStatusProto, error) {
+	return c.getFileLinkInfoContext(context.Background(), name)
+}
+
+func (c *Client) getFileLinkInfoContext(ctx context.Context, name string) (*hdfs.HdfsFileStatusProto, error) {
+	req := &hdfs.GetFileLinkInfoRequestProto{Src: proto.String(name)}
+	resp := &hdfs.GetFileLinkInfoResponseProto{}
+
+	err := c.namenode.ExecuteContext(ctx, "getFileLinkInfo", req, resp)
+	if err != nil {
+		return nil, err
+	} else if resp.GetFs() == nil {
+		return nil, os.ErrNotExist
+	}
+
+	return resp.GetFs(), nil
+}
@@ -0,0 +1,55 @@
+package hdfs
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolvePath(t *testing.T) {
+	client := getClient(t)
+
+	resolved, err := client.ResolvePath("/_test//foo.txt")
+	require.NoError(t, err)
+	assert.Equal(t, "/_test/foo.txt", resolved)
+
+	resolved, err = client.ResolvePath("/.reserved/raw/_test/foo.txt")
+	require.NoError(t, err)
+	assert.Equal(t, "/_test/foo.txt", resolved)
+}
+
+func TestResolvePathInode(t *testing.T) {
+	client := getClient(t)
+
+	fi, err := client.Stat("/_test")
+	require.NoError(t, err)
+
+	resolved, err := client.ResolvePath(InodePath(fi.(*FileInfo).FileID()) + "/foo.txt")
+	require.NoError(t, err)
+	assert.Equal(t, InodePath(fi.(*FileInfo).FileID())+"/foo.txt", resolved)
+}
+
+func TestResolvePathNonexistent(t *testing.T) {
+	client := getClient(t)
+
+	_, err := client.ResolvePath("/_test/nonexistent/foo")
+	assertPathError(t, err, "resolvepath", "/_test/nonexistent/foo", os.ErrNotExist)
+}
+
+func TestOpenInode(t *testing.T) {
+	client := getClient(t)
+
+	fi, err := client.Stat("/_test/foo.txt")
+	require.NoError(t, err)
+
+	file, err := client.OpenInode(fi.(*FileInfo).FileID())
+	require.NoError(t, err)
+	defer file.Close()
+
+	b, err := ioutil.ReadAll(file)
+	require.NoError(t, err)
+	assert.Equal(t, "bar\n", string(b))
+}
@@ -0,0 +1,295 @@
+package s3gateway
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	signatureAlgorithm = "AWS4-HMAC-SHA256"
+	amzDateFormat      = "20060102T150405Z"
+	unsignedPayload    = "UNSIGNED-PAYLOAD"
+	streamingPrefix    = "STREAMING-"
+	// maxClockSkew is how far the time a request was signed can be from the
+	// server's clock.
+	maxClockSkew = 15 * time.Minute
+	// maxPresignExpiry is the longest a presigned URL can be valid for.
+	maxPresignExpiry = 7 * 24 * time.Hour
+)
+
+// authenticate checks the signature of the request, which is either in the
+// Authorization header or in the query string, and returns the credential it
+// was signed with. If the payload is signed, the request body is replaced
+// with a reader that returns an error at EOF if the hash doesn't match.
+func (s *Server) authenticate(r *http.Request) (Credential, error) {
+	q := r.URL.Query()
+	auth := r.Header.Get("Authorization")
+	if auth == "" && q.Get("X-Amz-Algorithm") != "" {
+		return s.authenticatePresigned(r, q)
+	} else if auth == "" {
+		return Credential{}, errAccessDenied
+	} else if !strings.HasPrefix(auth, signatureAlgorithm+" ") {
+		return Credential{}, errInvalidRequest
+	}
+
+	var credential, signedHeaders, signature string
+	for _, field := range strings.Split(strings.TrimPrefix(auth, signatureAlgorithm+" "), ",") {
+		field = strings.TrimSpace(field)
+		if v := strings.TrimPrefix(field, "Credential="); v != field {
+			credential = v
+		} else if v := strings.TrimPrefix(field, "SignedHeaders="); v != field {
+			signedHeaders = v
+		} else if v := strings.TrimPrefix(field, "Signature="); v != field {
+			signature = v
+		}
+	}
+
+	if credential == "" || signedHeaders == "" || signature == "" {
+		return Credential{}, errInvalidRequest
+	}
+
+	amzDate := r.Header.Get("X-Amz-Date")
+	if amzDate == "" {
+		amzDate = r.Header.Get("Date")
+	}
+
+	t, err := time.Parse(amzDateFormat, amzDate)
+	if err != nil {
+		t, err = http.ParseTime(amzDate)
+		if err != nil {
+			return Credential{}, errAccessDenied
+		}
+
+		amzDate = t.UTC().Format(amzDateFormat)
+	}
+
+	skew := s.now().Sub(t)
+	if skew > maxClockSkew || skew < -maxClockSkew {
+		return Credential{}, errRequestTimeTooSkewed
+	}
+
+	payloadHash := r.Header.Get("X-Amz-Content-Sha256")
+	if payloadHash == "" {
+		return Credential{}, errMissingSecurityHeader
+	} else if strings.HasPrefix(payloadHash, streamingPrefix) {
+		return Credential{}, errUnsupportedPayload
+	} else if payloadHash != unsignedPayload {
+		expected, err := hex.DecodeString(payloadHash)
+		if err != nil || len(expected) != sha256.Size {
+			return Credential{}, errContentSHA256Mismatch
+		}
+
+		r.Body = &verifyingReader{r: r.Body, hash: sha256.New(), expected: expected,
+			err: errContentSHA256Mismatch}
+	}
+
+	cred, err := s.verify(r, q, credential, strings.Split(signedHeaders, ";"),
+		amzDate, payloadHash, signature)
+	if err != nil {
+		return Credential{}, err
+	}
+
+	return cred, nil
+}
+
+// authenticatePresigned checks the signature of a presigned URL.
+func (s *Server) authenticatePresigned(r *http.Request, q url.Values) (Credential, error) {
+	if q.Get("X-Amz-Algorithm") != signatureAlgorithm {
+		return Credential{}, errInvalidRequest
+	}
+
+	amzDate := q.Get("X-Amz-Date")
+	t, err := time.Parse(amzDateFormat, amzDate)
+	if err != nil {
+		return Credential{}, errAccessDenied
+	}
+
+	expires, err := strconv.Atoi(q.Get("X-Amz-Expires"))
+	if err != nil || expires < 0 || time.Duration(expires)*time.Second > maxPresignExpiry {
+		return Credential{}, errInvalidArgument
+	}
+
+	now := s.now()
+	if now.Before(t.Add(-maxClockSkew)) {
+		return Credential{}, errRequestTimeTooSkewed
+	} else if now.After(t.Add(time.Duration(expires) * time.Second)) {
+		return Credential{}, errExpiredPresignRequest
+	}
+
+	signature := q.Get("X-Amz-Signature")
+	q.Del("X-Amz-Signature")
+	return s.verify(r, q, q.Get("X-Amz-Credential"),
+		strings.Split(q.Get("X-Amz-SignedHeaders"), ";"), amzDate, unsignedPayload, signature)
+}
+
+// verify computes the signature for the request, and compares it to the one
+// the client sent.
+func (s *Server) verify(r *http.Request, q url.Values, credential string,
+	signedHeaders []string, amzDate, payloadHash, signature string) (Credential, error) {
+	// The credential is of the form <key>/<date>/<region>/<service>/aws4_request.
+	parts := strings.Split(credential, "/")
+	if len(parts) != 5 || parts[4] != "aws4_request" || parts[3] != "s3" {
+		return Credential{}, errInvalidRequest
+	} else if !strings.HasPrefix(amzDate, parts[1]) {
+		return Credential{}, errSignatureDoesNotMatch
+	}
+
+	cred, ok := s.keys[parts[0]]
+	if !ok {
+		return Credential{}, errInvalidAccessKeyID
+	}
+
+	scope := strings.Join(parts[1:], "/")
+	canonical := canonicalRequest(r, q, signedHeaders, payloadHash)
+	expected := computeSignature(cred.SecretAccessKey, parts[1], parts[2], parts[3],
+		stringToSign(amzDate, scope, canonical))
+
+	if subtle.ConstantTimeCompare([]byte(expected), []byte(signature)) != 1 {
+		return Credential{}, errSignatureDoesNotMatch
+	}
+
+	return cred, nil
+}
+
+// canonicalRequest returns the canonical form of the request, as described in
+// the documentation for Signature Version 4.
+func canonicalRequest(r *http.Request, q url.Values, signedHeaders []string, payloadHash string) string {
+	var b strings.Builder
+	b.WriteString(r.Method)
+	b.WriteByte('\n')
+	b.WriteString(uriEncode(r.URL.Path, false))
+	b.WriteByte('\n')
+	b.WriteString(canonicalQuery(q))
+	b.WriteByte('\n')
+
+	for _, name := range signedHeaders {
+		b.WriteString(name)
+		b.WriteByte(':')
+		b.WriteString(canonicalHeader(r, name))
+		b.WriteByte('\n')
+	}
+
+	b.WriteByte('\n')
+	b.WriteString(strings.Join(signedHeaders, ";"))
+	b.WriteByte('\n')
+	b.WriteString(payloadHash)
+	return b.String()
+}
+
+func canonicalQuery(q url.Values) string {
+	keys := make([]string, 0, len(q))
+	for k := range q {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+	var pairs []string
+	for _, k := range keys {
+		values := append([]string(nil), q[k]...)
+		sort.Strings(values)
+		for _, v := range values {
+			pairs = append(pairs, uriEncode(k, true)+"="+uriEncode(v, true))
+		}
+	}
+
+	return strings.Join(pairs, "&")
+}
+
+// canonicalHeader returns the value of a signed header, with whitespace
+// trimmed and multiple values joined by commas. The Go HTTP server removes
+// the Host and Content-Length headers from the map, so those are handled
+// separately.
+func canonicalHeader(r *http.Request, name string) string {
+	var values []string
+	switch name {
+	case "host":
+		values = []string{r.Host}
+	case "content-length":
+		values = r.Header.Values("Content-Length")
+		if len(values) == 0 && r.ContentLength >= 0 {
+			values = []string{strconv.FormatInt(r.ContentLength, 10)}
+		}
+	default:
+		values = r.Header.Values(name)
+	}
+
+	for i, v := range values {
+		values[i] = strings.Join(strings.Fields(v), " ")
+	}
+
+	return strings.Join(values, ",")
+}
+
+// uriEncode percent-encodes everything but the unreserved characters. Unless
+// encodeSlash is set, slashes are left alone.
+func uriEncode(s string, encodeSlash bool) string {
+	const hexDigits = "0123456789ABCDEF"
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') ||
+			c == '-' || c == '_' || c == '.' || c == '~' || (c == '/' && !encodeSlash) {
+			b.WriteByte(c)
+		} else {
+			b.WriteByte('%')
+			b.WriteByte(hexDigits[c>>4])
+			b.WriteByte(hexDigits[c&0xf])
+		}
+	}
+
+	return b.String()
+}
+
+func stringToSign(amzDate, scope, canonicalRequest string) string {
+	h := sha256.Sum256([]byte(canonicalRequest))
+	return signatureAlgorithm + "\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(h[:])
+}
+
+func computeSignature(secret, date, region, service, stringToSign string) string {
+	key := hmacSHA256([]byte("AWS4"+secret), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, service)
+	key = hmacSHA256(key, "aws4_request")
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+// verifyingReader checks the hash of a request body once it's been read all
+// the way through, returning err instead of io.EOF if it doesn't match.
+type verifyingReader struct {
+	r        io.ReadCloser
+	hash     hash.Hash
+	expected []byte
+	err      error
+}
+
+func (vr *verifyingReader) Read(b []byte) (int, error) {
+	n, err := vr.r.Read(b)
+	vr.hash.Write(b[:n])
+	if err == io.EOF && !bytes.Equal(vr.hash.Sum(nil), vr.expected) {
+		return n, vr.err
+	}
+
+	return n, err
+}
+
+func (vr *verifyingReader) Close() error {
+	return vr.r.Close()
+}
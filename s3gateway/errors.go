@@ -0,0 +1,127 @@
+package s3gateway
+
+import (
+	"encoding/xml"
+	"errors"
+	"log"
+	"net/http"
+	"os"
+)
+
+// s3Error is an error that's reported to the client with a specific S3 error
+// code.
+type s3Error struct {
+	Code       string
+	Message    string
+	StatusCode int
+}
+
+func (e *s3Error) Error() string {
+	return e.Code + ": " + e.Message
+}
+
+var (
+	errAccessDenied = &s3Error{"AccessDenied", "Access Denied.", http.StatusForbidden}
+	errBadDigest    = &s3Error{"BadDigest",
+		"The Content-MD5 you specified did not match what was received.", http.StatusBadRequest}
+	errBucketAlreadyExists = &s3Error{"BucketAlreadyOwnedByYou",
+		"The bucket you tried to create already exists.", http.StatusConflict}
+	errBucketNotEmpty = &s3Error{"BucketNotEmpty",
+		"The bucket you tried to delete is not empty.", http.StatusConflict}
+	errContentSHA256Mismatch = &s3Error{"XAmzContentSHA256Mismatch",
+		"The provided 'x-amz-content-sha256' header does not match what was computed.", http.StatusBadRequest}
+	errInternalError = &s3Error{"InternalError",
+		"We encountered an internal error. Please try again.", http.StatusInternalServerError}
+	errInvalidAccessKeyID = &s3Error{"InvalidAccessKeyId",
+		"The access key ID you provided does not exist in our records.", http.StatusForbidden}
+	errInvalidArgument   = &s3Error{"InvalidArgument", "Invalid argument.", http.StatusBadRequest}
+	errInvalidBucketName = &s3Error{"InvalidBucketName",
+		"The specified bucket is not valid.", http.StatusBadRequest}
+	errInvalidDigest = &s3Error{"InvalidDigest",
+		"The Content-MD5 you specified is not valid.", http.StatusBadRequest}
+	errInvalidKey = &s3Error{"InvalidArgument",
+		"The specified key is not valid.", http.StatusBadRequest}
+	errInvalidPart = &s3Error{"InvalidPart",
+		"One or more of the specified parts could not be found.", http.StatusBadRequest}
+	errInvalidPartOrder = &s3Error{"InvalidPartOrder",
+		"The list of parts was not in ascending order.", http.StatusBadRequest}
+	errInvalidRequest = &s3Error{"InvalidRequest", "Invalid request.", http.StatusBadRequest}
+	errMalformedXML   = &s3Error{"MalformedXML",
+		"The XML you provided was not well-formed or did not validate against our published schema.",
+		http.StatusBadRequest}
+	errMethodNotAllowed = &s3Error{"MethodNotAllowed",
+		"The specified method is not allowed against this resource.", http.StatusMethodNotAllowed}
+	errMissingSecurityHeader = &s3Error{"MissingSecurityHeader",
+		"Your request is missing a required header.", http.StatusBadRequest}
+	errNoSuchBucket = &s3Error{"NoSuchBucket",
+		"The specified bucket does not exist.", http.StatusNotFound}
+	errNoSuchKey = &s3Error{"NoSuchKey",
+		"The specified key does not exist.", http.StatusNotFound}
+	errNoSuchUpload = &s3Error{"NoSuchUpload",
+		"The specified multipart upload does not exist.", http.StatusNotFound}
+	errNotImplemented = &s3Error{"NotImplemented",
+		"A header or query you provided implies functionality that is not implemented.", http.StatusNotImplemented}
+	errRequestTimeTooSkewed = &s3Error{"RequestTimeTooSkewed",
+		"The difference between the request time and the server's time is too large.", http.StatusForbidden}
+	errSignatureDoesNotMatch = &s3Error{"SignatureDoesNotMatch",
+		"The request signature we calculated does not match the signature you provided.", http.StatusForbidden}
+	errUnsupportedPayload = &s3Error{"NotImplemented",
+		"Streaming (aws-chunked) payloads are not supported.", http.StatusNotImplemented}
+	errExpiredPresignRequest = &s3Error{"AccessDenied",
+		"Request has expired.", http.StatusForbidden}
+)
+
+type errorResponse struct {
+	XMLName   xml.Name `xml:"Error"`
+	Code      string   `xml:"Code"`
+	Message   string   `xml:"Message"`
+	Resource  string   `xml:"Resource,omitempty"`
+	RequestID string   `xml:"RequestId"`
+}
+
+// toS3Error converts an error returned by the client into the closest S3
+// error. By default, errors about a path that doesn't exist are reported as
+// missing keys; callers that know better should return a more specific error
+// themselves.
+func toS3Error(err error) *s3Error {
+	var s3err *s3Error
+	if errors.As(err, &s3err) {
+		return s3err
+	} else if errors.Is(err, os.ErrNotExist) {
+		return errNoSuchKey
+	} else if errors.Is(err, os.ErrPermission) {
+		return errAccessDenied
+	}
+
+	return errInternalError
+}
+
+// writeError writes an XML error response. Errors that don't map to a
+// specific S3 error are logged, since the client only sees InternalError.
+func (req *request) writeError(err error) {
+	s3err := toS3Error(err)
+	if s3err == errInternalError {
+		log.Printf("s3gateway: %s %s: %s", req.r.Method, req.r.URL.Path, err)
+	}
+
+	req.w.Header().Set("Content-Type", "application/xml")
+	req.w.WriteHeader(s3err.StatusCode)
+	if req.r.Method == http.MethodHead {
+		return
+	}
+
+	req.w.Write([]byte(xml.Header))
+	xml.NewEncoder(req.w).Encode(errorResponse{
+		Code:     s3err.Code,
+		Message:  s3err.Message,
+		Resource: req.r.URL.Path,
+	})
+}
+
+// writeXML writes a successful XML response.
+func (req *request) writeXML(v interface{}) error {
+	req.w.Header().Set("Content-Type", "application/xml")
+	req.w.WriteHeader(http.StatusOK)
+	req.w.Write([]byte(xml.Header))
+	return xml.NewEncoder(req.w).Encode(v)
+}
@@ -0,0 +1,225 @@
+package s3gateway
+
+import (
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/colinmarc/hdfs/v2"
+)
+
+const maxListKeys = 1000
+
+type listBucketResult struct {
+	XMLName     xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ ListBucketResult"`
+	Name        string   `xml:"Name"`
+	Prefix      string   `xml:"Prefix"`
+	Delimiter   string   `xml:"Delimiter,omitempty"`
+	MaxKeys     int      `xml:"MaxKeys"`
+	IsTruncated bool     `xml:"IsTruncated"`
+
+	// These are only used by ListObjects.
+	Marker     *string `xml:"Marker"`
+	NextMarker string  `xml:"NextMarker,omitempty"`
+
+	// These are only used by ListObjectsV2.
+	KeyCount              *int   `xml:"KeyCount"`
+	ContinuationToken     string `xml:"ContinuationToken,omitempty"`
+	NextContinuationToken string `xml:"NextContinuationToken,omitempty"`
+	StartAfter            string `xml:"StartAfter,omitempty"`
+
+	Contents       []object       `xml:"Contents"`
+	CommonPrefixes []commonPrefix `xml:"CommonPrefixes"`
+}
+
+type object struct {
+	Key          string `xml:"Key"`
+	LastModified string `xml:"LastModified"`
+	ETag         string `xml:"ETag"`
+	Size         int64  `xml:"Size"`
+	StorageClass string `xml:"StorageClass"`
+	Owner        *owner `xml:"Owner,omitempty"`
+}
+
+type commonPrefix struct {
+	Prefix string `xml:"Prefix"`
+}
+
+// listEntry is a candidate key for a listing.
+type listEntry struct {
+	key  string
+	info os.FileInfo
+}
+
+func (req *request) listObjects(v2 bool) error {
+	if err := req.checkBucket(); err != nil {
+		return err
+	}
+
+	q := req.r.URL.Query()
+	prefix := q.Get("prefix")
+	delimiter := q.Get("delimiter")
+	maxKeys := maxListKeys
+	if s := q.Get("max-keys"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 0 {
+			return errInvalidArgument
+		} else if n < maxKeys {
+			maxKeys = n
+		}
+	}
+
+	res := listBucketResult{
+		Name:      req.bucket,
+		Prefix:    prefix,
+		Delimiter: delimiter,
+		MaxKeys:   maxKeys,
+	}
+
+	var marker string
+	fetchOwner := !v2
+	if v2 {
+		res.StartAfter = q.Get("start-after")
+		res.ContinuationToken = q.Get("continuation-token")
+		marker = res.StartAfter
+		if res.ContinuationToken != "" {
+			b, err := base64.RawURLEncoding.DecodeString(res.ContinuationToken)
+			if err != nil {
+				return errInvalidArgument
+			}
+
+			marker = string(b)
+		}
+
+		fetchOwner = q.Get("fetch-owner") == "true"
+	} else {
+		marker = q.Get("marker")
+		res.Marker = &marker
+	}
+
+	entries, err := req.listEntries(prefix, delimiter == "/")
+	if err != nil {
+		return err
+	}
+
+	var last string
+	for _, entry := range entries {
+		key := entry.key
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+
+		// Keys with the delimiter after the prefix are rolled up into a
+		// common prefix, which is what's compared to the marker.
+		isPrefix := false
+		if delimiter != "" {
+			if i := strings.Index(key[len(prefix):], delimiter); i >= 0 {
+				key = key[:len(prefix)+i+len(delimiter)]
+				isPrefix = true
+			}
+		}
+
+		if key <= marker || (isPrefix && key == last) {
+			continue
+		} else if len(res.Contents)+len(res.CommonPrefixes) >= maxKeys {
+			res.IsTruncated = true
+			break
+		}
+
+		last = key
+		if isPrefix {
+			res.CommonPrefixes = append(res.CommonPrefixes, commonPrefix{key})
+			continue
+		}
+
+		obj := object{
+			Key:          key,
+			LastModified: s3Time(entry.info.ModTime()),
+			ETag:         etag(entry.info),
+			Size:         entry.info.Size(),
+			StorageClass: "STANDARD",
+		}
+
+		if fetchOwner {
+			fileOwner := entry.info.(*hdfs.FileInfo).Owner()
+			obj.Owner = &owner{ID: fileOwner, DisplayName: fileOwner}
+		}
+
+		res.Contents = append(res.Contents, obj)
+	}
+
+	if v2 {
+		keyCount := len(res.Contents) + len(res.CommonPrefixes)
+		res.KeyCount = &keyCount
+		if res.IsTruncated {
+			res.NextContinuationToken = base64.RawURLEncoding.EncodeToString([]byte(last))
+		}
+	} else if res.IsTruncated {
+		res.NextMarker = last
+	}
+
+	return req.writeXML(res)
+}
+
+// listEntries returns the candidate keys for a listing with the given prefix,
+// sorted. If shallow is set, only the directory that the prefix is in is
+// listed, and its subdirectories are returned as keys with a trailing slash.
+// Otherwise, that directory is walked recursively, and only empty
+// directories (folder markers) are returned alongside the files.
+func (req *request) listEntries(prefix string, shallow bool) ([]listEntry, error) {
+	dir := prefix[:strings.LastIndex(prefix, "/")+1]
+
+	var entries []listEntry
+	var walk func(dir string) error
+	walk = func(dir string) error {
+		infos, err := req.client.ReadDir(path.Join(req.bucketPath(), dir))
+		if errors.Is(err, os.ErrNotExist) || errors.Is(err, syscall.ENOTDIR) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		empty := true
+		for _, info := range infos {
+			if isTemporary(info.Name()) {
+				continue
+			}
+
+			empty = false
+			key := dir + info.Name()
+			if !info.IsDir() {
+				entries = append(entries, listEntry{key, info})
+			} else if shallow {
+				entries = append(entries, listEntry{key + "/", info})
+			} else if err := walk(key + "/"); err != nil {
+				return err
+			}
+		}
+
+		// The directory itself is a folder marker.
+		if empty && dir != "" {
+			info, err := req.client.Stat(path.Join(req.bucketPath(), dir))
+			if err != nil {
+				return err
+			}
+
+			entries = append(entries, listEntry{dir, info})
+		}
+
+		return nil
+	}
+
+	err := walk(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].key < entries[j].key })
+	return entries, nil
+}
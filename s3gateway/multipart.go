@@ -0,0 +1,275 @@
+package s3gateway
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/colinmarc/hdfs/v2"
+)
+
+const (
+	maxPartNumber  = 10000
+	uploadMetaFile = "upload.json"
+)
+
+// uploadMeta is stored alongside the parts of a multipart upload, to tie the
+// upload ID to the object being uploaded.
+type uploadMeta struct {
+	Bucket string `json:"bucket"`
+	Key    string `json:"key"`
+	User   string `json:"user"`
+}
+
+type initiateMultipartUploadResult struct {
+	XMLName  xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ InitiateMultipartUploadResult"`
+	Bucket   string   `xml:"Bucket"`
+	Key      string   `xml:"Key"`
+	UploadID string   `xml:"UploadId"`
+}
+
+type completeMultipartUpload struct {
+	Parts []completePart `xml:"Part"`
+}
+
+type completePart struct {
+	PartNumber int    `xml:"PartNumber"`
+	ETag       string `xml:"ETag"`
+}
+
+type completeMultipartUploadResult struct {
+	XMLName  xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ CompleteMultipartUploadResult"`
+	Location string   `xml:"Location"`
+	Bucket   string   `xml:"Bucket"`
+	Key      string   `xml:"Key"`
+	ETag     string   `xml:"ETag"`
+}
+
+// uploadPath returns the directory that holds the parts for an upload.
+func (req *request) uploadPath(id string) string {
+	return path.Join(req.root, uploadsDir, id)
+}
+
+func partName(n int) string {
+	return fmt.Sprintf("part-%05d", n)
+}
+
+func (req *request) createMultipartUpload() error {
+	if strings.HasSuffix(req.key, "/") {
+		return errInvalidKey
+	} else if err := req.checkBucket(); err != nil {
+		return err
+	}
+
+	// The directory for uploads is shared by every user, so, like /tmp, it's
+	// world-writable and sticky. Each upload gets its own private directory
+	// inside it.
+	uploads := path.Join(req.root, uploadsDir)
+	_, err := req.client.Stat(uploads)
+	if errors.Is(err, os.ErrNotExist) {
+		err = req.client.Mkdir(uploads, 0777)
+		if err == nil {
+			err = req.client.Chmod(uploads, 01777)
+		} else if errors.Is(err, os.ErrExist) {
+			err = nil
+		}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	b := make([]byte, 16)
+	_, err = rand.Read(b)
+	if err != nil {
+		return err
+	}
+
+	id := hex.EncodeToString(b)
+	err = req.client.Mkdir(req.uploadPath(id), 0700)
+	if err != nil {
+		return err
+	}
+
+	meta, err := json.Marshal(uploadMeta{Bucket: req.bucket, Key: req.key, User: req.user})
+	if err != nil {
+		return err
+	}
+
+	err = req.client.WriteFileAtomic(path.Join(req.uploadPath(id), uploadMetaFile),
+		strings.NewReader(string(meta)), hdfs.CreateOptions{})
+	if err != nil {
+		req.client.RemoveAll(req.uploadPath(id))
+		return err
+	}
+
+	return req.writeXML(initiateMultipartUploadResult{
+		Bucket:   req.bucket,
+		Key:      req.key,
+		UploadID: id,
+	})
+}
+
+// checkUpload returns the directory for the upload with the ID in the
+// request, or NoSuchUpload if there isn't one for the requested object.
+func (req *request) checkUpload() (string, error) {
+	id := req.r.URL.Query().Get("uploadId")
+	if b, err := hex.DecodeString(id); err != nil || len(b) != 16 {
+		return "", errNoSuchUpload
+	}
+
+	dir := req.uploadPath(id)
+	f, err := req.client.Open(path.Join(dir, uploadMetaFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return "", errNoSuchUpload
+	} else if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	b, err := ioutil.ReadAll(f)
+	if err != nil {
+		return "", err
+	}
+
+	var meta uploadMeta
+	err = json.Unmarshal(b, &meta)
+	if err != nil {
+		return "", err
+	} else if meta.Bucket != req.bucket || meta.Key != req.key || meta.User != req.user {
+		return "", errNoSuchUpload
+	}
+
+	return dir, nil
+}
+
+func (req *request) uploadPart() error {
+	n, err := strconv.Atoi(req.r.URL.Query().Get("partNumber"))
+	if err != nil || n < 1 || n > maxPartNumber {
+		return errInvalidArgument
+	}
+
+	dir, err := req.checkUpload()
+	if err != nil {
+		return err
+	}
+
+	body, err := req.body()
+	if err != nil {
+		return err
+	}
+
+	name := path.Join(dir, partName(n))
+	err = req.client.WriteFileAtomic(name, body, hdfs.CreateOptions{Overwrite: true})
+	if err != nil {
+		return err
+	}
+
+	info, err := req.client.Stat(name)
+	if err != nil {
+		return err
+	}
+
+	req.w.Header().Set("ETag", etag(info))
+	req.w.WriteHeader(http.StatusOK)
+	return nil
+}
+
+// completeMultipartUpload concatenates the parts into the first one, and then
+// moves it into place. Since the parts are all in the same directory and are
+// created with the same options, they always meet the requirements for
+// Concat.
+func (req *request) completeMultipartUpload() error {
+	dir, err := req.checkUpload()
+	if err != nil {
+		return err
+	}
+
+	var complete completeMultipartUpload
+	err = xml.NewDecoder(req.r.Body).Decode(&complete)
+	if err != nil || len(complete.Parts) == 0 {
+		return errMalformedXML
+	}
+
+	var target string
+	var sources []string
+	for i, part := range complete.Parts {
+		if i > 0 && part.PartNumber <= complete.Parts[i-1].PartNumber {
+			return errInvalidPartOrder
+		}
+
+		name := path.Join(dir, partName(part.PartNumber))
+		info, err := req.client.Stat(name)
+		if errors.Is(err, os.ErrNotExist) {
+			return errInvalidPart
+		} else if err != nil {
+			return err
+		} else if strings.Trim(part.ETag, `"`) != strings.Trim(etag(info), `"`) {
+			return errInvalidPart
+		}
+
+		// The namenode won't concatenate empty files, but there's no need
+		// to anyway.
+		if i == 0 {
+			target = name
+		} else if info.Size() > 0 {
+			sources = append(sources, name)
+		}
+	}
+
+	if len(sources) > 0 {
+		err = req.client.Concat(target, sources)
+		if err != nil {
+			return err
+		}
+	}
+
+	name := req.objectPath()
+	err = req.client.MkdirAll(path.Dir(name), 0755)
+	if err != nil {
+		return err
+	}
+
+	err = req.client.Rename(target, name)
+	if err != nil {
+		return err
+	}
+
+	req.client.RemoveAll(dir)
+
+	info, err := req.client.Stat(name)
+	if err != nil {
+		return err
+	}
+
+	return req.writeXML(completeMultipartUploadResult{
+		Location: "/" + req.bucket + "/" + req.key,
+		Bucket:   req.bucket,
+		Key:      req.key,
+		ETag:     etag(info),
+	})
+}
+
+func (req *request) abortMultipartUpload() error {
+	dir, err := req.checkUpload()
+	if err != nil {
+		return err
+	}
+
+	err = req.client.RemoveAll(dir)
+	if err != nil {
+		return err
+	}
+
+	req.w.WriteHeader(http.StatusNoContent)
+	return nil
+}
@@ -0,0 +1,276 @@
+package s3gateway
+
+import (
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/colinmarc/hdfs/v2"
+)
+
+// etag returns the synthetic ETag for a file. See the package documentation.
+func etag(info os.FileInfo) string {
+	sum := md5.Sum([]byte(fmt.Sprintf("%d-%d", info.Size(), info.ModTime().UnixNano())))
+	return fmt.Sprintf(`"%x-1"`, sum)
+}
+
+// isTemporary returns true for the temporary files created by
+// WriteFileAtomic, which are hidden from listings.
+func isTemporary(name string) bool {
+	return strings.HasPrefix(name, ".") && strings.HasSuffix(name, ".tmp")
+}
+
+// checkBucket returns NoSuchBucket if the bucket doesn't exist.
+func (req *request) checkBucket() error {
+	info, err := req.client.Stat(req.bucketPath())
+	if errors.Is(err, os.ErrNotExist) || (err == nil && !info.IsDir()) {
+		return errNoSuchBucket
+	}
+
+	return err
+}
+
+// notFound returns NoSuchBucket or NoSuchKey, depending on whether the bucket
+// exists.
+func (req *request) notFound() error {
+	if err := req.checkBucket(); err != nil {
+		return err
+	}
+
+	return errNoSuchKey
+}
+
+func (req *request) getObject() error {
+	name := req.objectPath()
+	info, err := req.client.Stat(name)
+	if errors.Is(err, os.ErrNotExist) {
+		return req.notFound()
+	} else if err != nil {
+		return err
+	}
+
+	// Directories are only objects if they're asked for with a trailing
+	// slash, in which case they're empty folder markers. Likewise, files
+	// aren't folder markers.
+	dirKey := strings.HasSuffix(req.key, "/")
+	if info.IsDir() != dirKey {
+		return errNoSuchKey
+	}
+
+	h := req.w.Header()
+	h.Set("ETag", etag(info))
+	h.Set("Content-Type", "application/octet-stream")
+	if info.IsDir() {
+		http.ServeContent(req.w, req.r, "", info.ModTime(), strings.NewReader(""))
+		return nil
+	}
+
+	f, err := req.client.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	http.ServeContent(req.w, req.r, "", info.ModTime(), f)
+	return nil
+}
+
+func (req *request) putObject() error {
+	if err := req.checkBucket(); err != nil {
+		return err
+	}
+
+	body, err := req.body()
+	if err != nil {
+		return err
+	}
+
+	name := req.objectPath()
+	if strings.HasSuffix(req.key, "/") {
+		// A folder marker, which has to be empty.
+		n, err := io.Copy(ioutil.Discard, body)
+		if err != nil {
+			return err
+		} else if n > 0 {
+			return errInvalidRequest
+		}
+
+		err = req.client.MkdirAll(name, 0755)
+		if err != nil {
+			return err
+		}
+	} else {
+		err = req.client.MkdirAll(path.Dir(name), 0755)
+		if err != nil {
+			return err
+		}
+
+		err = req.client.WriteFileAtomic(name, body, hdfs.CreateOptions{Overwrite: true})
+		if err != nil {
+			return err
+		}
+	}
+
+	info, err := req.client.Stat(name)
+	if err != nil {
+		return err
+	}
+
+	req.w.Header().Set("ETag", etag(info))
+	req.w.WriteHeader(http.StatusOK)
+	return nil
+}
+
+// body returns the request body. If the request has a Content-MD5 header, the
+// body returns an error at EOF if it doesn't match.
+func (req *request) body() (io.Reader, error) {
+	contentMD5 := req.r.Header.Get("Content-Md5")
+	if contentMD5 == "" {
+		return req.r.Body, nil
+	}
+
+	expected, err := base64.StdEncoding.DecodeString(contentMD5)
+	if err != nil || len(expected) != md5.Size {
+		return nil, errInvalidDigest
+	}
+
+	return &verifyingReader{r: req.r.Body, hash: md5.New(), expected: expected, err: errBadDigest}, nil
+}
+
+func (req *request) deleteObject() error {
+	name := req.objectPath()
+	info, err := req.client.Stat(name)
+	if errors.Is(err, os.ErrNotExist) {
+		// Deleting a key that doesn't exist succeeds, as long as the bucket
+		// does.
+		if err := req.checkBucket(); err != nil {
+			return err
+		}
+
+		req.w.WriteHeader(http.StatusNoContent)
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	// Only remove directories for folder markers, and only if they're empty;
+	// otherwise, the marker goes away in S3 but the objects under it stay,
+	// which is the same thing here.
+	if info.IsDir() == strings.HasSuffix(req.key, "/") {
+		err = req.client.Remove(name)
+		if err != nil && !errors.Is(err, syscall.ENOTEMPTY) && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+
+	req.w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+type listAllMyBucketsResult struct {
+	XMLName xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ ListAllMyBucketsResult"`
+	Owner   owner    `xml:"Owner"`
+	Buckets []bucket `xml:"Buckets>Bucket"`
+}
+
+type owner struct {
+	ID          string `xml:"ID"`
+	DisplayName string `xml:"DisplayName"`
+}
+
+type bucket struct {
+	Name         string `xml:"Name"`
+	CreationDate string `xml:"CreationDate"`
+}
+
+// s3Time formats a time the way S3 does in XML responses.
+func s3Time(t time.Time) string {
+	return t.UTC().Format("2006-01-02T15:04:05.000Z")
+}
+
+func (req *request) listBuckets() error {
+	infos, err := req.client.ReadDir(req.root)
+	if err != nil {
+		return err
+	}
+
+	res := listAllMyBucketsResult{Owner: owner{ID: req.user, DisplayName: req.user}}
+	for _, info := range infos {
+		if info.IsDir() && validBucketName(info.Name()) {
+			res.Buckets = append(res.Buckets, bucket{
+				Name:         info.Name(),
+				CreationDate: s3Time(info.ModTime()),
+			})
+		}
+	}
+
+	return req.writeXML(res)
+}
+
+func (req *request) createBucket() error {
+	io.Copy(ioutil.Discard, req.r.Body)
+	err := req.client.Mkdir(req.bucketPath(), 0755)
+	if errors.Is(err, os.ErrExist) {
+		return errBucketAlreadyExists
+	} else if err != nil {
+		return err
+	}
+
+	req.w.Header().Set("Location", "/"+req.bucket)
+	req.w.WriteHeader(http.StatusOK)
+	return nil
+}
+
+func (req *request) headBucket() error {
+	if err := req.checkBucket(); err != nil {
+		return err
+	}
+
+	req.w.WriteHeader(http.StatusOK)
+	return nil
+}
+
+func (req *request) deleteBucket() error {
+	if err := req.checkBucket(); err != nil {
+		return err
+	}
+
+	err := req.client.Remove(req.bucketPath())
+	if errors.Is(err, syscall.ENOTEMPTY) {
+		return errBucketNotEmpty
+	} else if err != nil {
+		return err
+	}
+
+	req.w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+type locationConstraint struct {
+	XMLName xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ LocationConstraint"`
+	Region  string   `xml:",chardata"`
+}
+
+func (req *request) getBucketLocation() error {
+	if err := req.checkBucket(); err != nil {
+		return err
+	}
+
+	// Like S3, an empty constraint means us-east-1.
+	res := locationConstraint{}
+	if req.region != defaultRegion {
+		res.Region = req.region
+	}
+
+	return req.writeXML(res)
+}
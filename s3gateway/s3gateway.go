@@ -0,0 +1,344 @@
+// Package s3gateway serves a directory in HDFS over a subset of the Amazon S3
+// REST API, so that tools which only speak S3 can read and write data stored
+// in HDFS.
+//
+// Each subdirectory of the root directory is a bucket, and the keys in a
+// bucket are paths relative to it, with directories created (and listed) as
+// needed. Buckets are addressed path-style, as in
+// http://gateway:9000/bucket/some/key.
+//
+// The supported operations are:
+//
+//   - ListBuckets, CreateBucket, HeadBucket, DeleteBucket, and
+//     GetBucketLocation
+//   - ListObjects and ListObjectsV2, with prefixes and delimiters
+//   - GetObject (including ranges), HeadObject, PutObject, and DeleteObject
+//   - CreateMultipartUpload, UploadPart, CompleteMultipartUpload, and
+//     AbortMultipartUpload. Parts are written to a temporary directory under
+//     the root, and concatenated (without copying any data) into place once
+//     the upload is completed.
+//
+// Anything else gets a NotImplemented error.
+//
+// Since keys are paths, a key can't be both an object and a prefix of other
+// objects (like "a" and "a/b"). An empty directory shows up in listings as a
+// folder marker, with a trailing slash, and deleting the last object in a
+// directory leaves one behind.
+//
+// Requests must be signed with AWS Signature Version 4, either in the
+// Authorization header or in the query string (a presigned URL). Each access
+// key maps to an HDFS user, and requests signed with it are made as that
+// user, so HDFS permissions apply as usual. Payloads signed with
+// STREAMING-AWS4-HMAC-SHA256-PAYLOAD (aws-chunked encoding) aren't supported;
+// most clients can be configured to use UNSIGNED-PAYLOAD or a payload hash
+// instead.
+//
+// HDFS doesn't have a cheap equivalent to the MD5 ETags S3 uses, so ETags are
+// derived from the size and modification time of each file instead. They're
+// always formatted like the ETags of multipart uploads, with a "-1" suffix, so
+// that clients don't mistake them for an MD5 of the content.
+package s3gateway
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/colinmarc/hdfs/v2"
+)
+
+const (
+	defaultRegion = "us-east-1"
+	// uploadsDir is the name of the directory under the root where the parts
+	// of multipart uploads are kept. It can't clash with a bucket, because
+	// bucket names can't start with a dot.
+	uploadsDir = ".s3gateway_uploads"
+)
+
+// Credential is an access key for the gateway.
+type Credential struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	// User is the HDFS user that requests signed with the key are made as.
+	User string
+}
+
+// Options represents the configurable options for a Server.
+type Options struct {
+	// Root is the directory in HDFS that's served. Each of its subdirectories
+	// is a bucket.
+	Root string
+	// Credentials are the access keys that requests can be signed with.
+	Credentials []Credential
+	// Client returns the client to use for requests made as the given HDFS
+	// user. It's called once for each user, the first time a request is
+	// signed with one of their keys, and the client is reused after that.
+	Client func(user string) (*hdfs.Client, error)
+	// Region is the region reported by GetBucketLocation. Requests are
+	// accepted no matter which region they're signed for. If empty,
+	// "us-east-1" is used.
+	Region string
+}
+
+// Server is an http.Handler that implements the S3 API.
+type Server struct {
+	root       string
+	region     string
+	keys       map[string]Credential
+	clientFunc func(user string) (*hdfs.Client, error)
+	now        func() time.Time
+
+	lock    sync.Mutex
+	clients map[string]*hdfs.Client
+}
+
+// New returns a Server with the given options.
+func New(options Options) (*Server, error) {
+	if !path.IsAbs(options.Root) {
+		return nil, fmt.Errorf("root must be an absolute path: %q", options.Root)
+	} else if options.Client == nil {
+		return nil, errors.New("no Client function specified")
+	} else if len(options.Credentials) == 0 {
+		return nil, errors.New("no credentials specified")
+	}
+
+	keys := make(map[string]Credential, len(options.Credentials))
+	for _, cred := range options.Credentials {
+		if cred.AccessKeyID == "" || cred.SecretAccessKey == "" || cred.User == "" {
+			return nil, fmt.Errorf("incomplete credential for access key %q", cred.AccessKeyID)
+		} else if _, ok := keys[cred.AccessKeyID]; ok {
+			return nil, fmt.Errorf("duplicate access key: %q", cred.AccessKeyID)
+		}
+
+		keys[cred.AccessKeyID] = cred
+	}
+
+	region := options.Region
+	if region == "" {
+		region = defaultRegion
+	}
+
+	return &Server{
+		root:       path.Clean(options.Root),
+		region:     region,
+		keys:       keys,
+		clientFunc: options.Client,
+		now:        time.Now,
+		clients:    make(map[string]*hdfs.Client),
+	}, nil
+}
+
+// request holds the state for a single request.
+type request struct {
+	*Server
+	w      http.ResponseWriter
+	r      *http.Request
+	client *hdfs.Client
+	user   string
+	bucket string
+	key    string
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	req := &request{Server: s, w: w, r: r}
+	err := req.serve()
+	if err != nil {
+		req.writeError(err)
+	}
+}
+
+func (req *request) serve() error {
+	cred, err := req.authenticate(req.r)
+	if err != nil {
+		return err
+	}
+
+	req.user = cred.User
+	req.client, err = req.getClient(cred.User)
+	if err != nil {
+		return err
+	}
+
+	req.bucket, req.key, err = splitPath(req.r.URL.Path)
+	if err != nil {
+		return err
+	}
+
+	q := req.r.URL.Query()
+	_, uploads := q["uploads"]
+	_, uploadID := q["uploadId"]
+
+	switch {
+	case req.bucket == "":
+		if req.r.Method == http.MethodGet {
+			return req.listBuckets()
+		}
+	case req.key == "":
+		switch req.r.Method {
+		case http.MethodGet:
+			if _, ok := q["location"]; ok {
+				return req.getBucketLocation()
+			} else if isSubresource(q) {
+				return errNotImplemented
+			} else if q.Get("list-type") == "2" {
+				return req.listObjects(true)
+			}
+
+			return req.listObjects(false)
+		case http.MethodHead:
+			return req.headBucket()
+		case http.MethodPut:
+			if isSubresource(q) {
+				return errNotImplemented
+			}
+
+			return req.createBucket()
+		case http.MethodDelete:
+			if isSubresource(q) {
+				return errNotImplemented
+			}
+
+			return req.deleteBucket()
+		}
+	default:
+		switch req.r.Method {
+		case http.MethodGet, http.MethodHead:
+			if isSubresource(q) {
+				return errNotImplemented
+			}
+
+			return req.getObject()
+		case http.MethodPut:
+			if uploadID {
+				return req.uploadPart()
+			} else if isSubresource(q) || req.r.Header.Get("X-Amz-Copy-Source") != "" {
+				return errNotImplemented
+			}
+
+			return req.putObject()
+		case http.MethodDelete:
+			if uploadID {
+				return req.abortMultipartUpload()
+			} else if isSubresource(q) {
+				return errNotImplemented
+			}
+
+			return req.deleteObject()
+		case http.MethodPost:
+			if uploads {
+				return req.createMultipartUpload()
+			} else if uploadID {
+				return req.completeMultipartUpload()
+			}
+
+			return errNotImplemented
+		}
+	}
+
+	return errMethodNotAllowed
+}
+
+// getClient returns the client for the given user, creating it if necessary.
+func (s *Server) getClient(user string) (*hdfs.Client, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if c, ok := s.clients[user]; ok {
+		return c, nil
+	}
+
+	c, err := s.clientFunc(user)
+	if err != nil {
+		return nil, err
+	}
+
+	s.clients[user] = c
+	return c, nil
+}
+
+// Close closes all the clients the server has created.
+func (s *Server) Close() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	var err error
+	for user, c := range s.clients {
+		if closeErr := c.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+
+		delete(s.clients, user)
+	}
+
+	return err
+}
+
+// splitPath splits the path of a request into a bucket and key, checking that
+// neither would escape the root.
+func splitPath(p string) (string, string, error) {
+	p = strings.TrimPrefix(p, "/")
+	if p == "" {
+		return "", "", nil
+	}
+
+	bucket, key := p, ""
+	if i := strings.IndexByte(p, '/'); i >= 0 {
+		bucket, key = p[:i], p[i+1:]
+	}
+
+	if !validBucketName(bucket) {
+		return "", "", errInvalidBucketName
+	}
+
+	// Keys map directly to paths, so they have to be clean (apart from a
+	// trailing slash, which marks a directory).
+	trimmed := strings.TrimSuffix(key, "/")
+	if key != "" && (trimmed == "" || path.Clean("/"+trimmed) != "/"+trimmed) {
+		return "", "", errInvalidKey
+	}
+
+	return bucket, key, nil
+}
+
+func validBucketName(name string) bool {
+	if name == "" || len(name) > 255 || name[0] == '.' {
+		return false
+	}
+
+	return !strings.ContainsAny(name, "/\\")
+}
+
+// isSubresource returns true if the query includes a subresource, like "acl"
+// or "tagging", that isn't otherwise handled. That way, a request for one
+// isn't mistaken for a plain object or bucket operation.
+func isSubresource(q map[string][]string) bool {
+	for _, name := range []string{
+		"acl", "tagging", "policy", "cors", "lifecycle", "versioning",
+		"versions", "website", "logging", "notification", "replication",
+		"encryption", "uploads", "uploadId", "delete", "restore", "select",
+		"torrent", "object-lock", "retention", "legal-hold", "attributes",
+		"accelerate", "requestPayment", "analytics", "inventory", "metrics",
+		"ownershipControls", "publicAccessBlock", "intelligent-tiering",
+	} {
+		if _, ok := q[name]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// bucketPath returns the path in HDFS of the bucket.
+func (req *request) bucketPath() string {
+	return path.Join(req.root, req.bucket)
+}
+
+// objectPath returns the path in HDFS of the object.
+func (req *request) objectPath() string {
+	return path.Join(req.root, req.bucket, req.key)
+}
@@ -0,0 +1,425 @@
+package s3gateway
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/colinmarc/hdfs/v2"
+	"github.com/colinmarc/hdfs/v2/internal/testcluster"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testAccessKey = "gohdfs1key"
+	testSecretKey = "gohdfs1secret"
+	testRoot      = "/_test/s3gateway"
+)
+
+func getClient(t *testing.T) *hdfs.Client {
+	return testcluster.Client(t, "gohdfs1")
+}
+
+// setupGateway returns a gateway serving a fresh root, with an empty bucket
+// called "bucket".
+func setupGateway(t *testing.T) (*Server, *hdfs.Client) {
+	client := getClient(t)
+
+	err := client.RemoveAll(testRoot)
+	if err != nil && !os.IsNotExist(err) {
+		t.Fatal(err)
+	}
+
+	require.NoError(t, client.MkdirAll(testRoot+"/bucket", 0755))
+
+	s, err := New(Options{
+		Root:        testRoot,
+		Credentials: []Credential{{testAccessKey, testSecretKey, "gohdfs1"}},
+		Client: func(user string) (*hdfs.Client, error) {
+			return client, nil
+		},
+	})
+	require.NoError(t, err)
+
+	return s, client
+}
+
+// do makes a signed request to the gateway.
+func do(t *testing.T, s *Server, method, target, body string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(method, target, strings.NewReader(body))
+	sign(r, body, testSecretKey)
+
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, r)
+	return w
+}
+
+func sign(r *http.Request, body, secret string) {
+	now := time.Now().UTC()
+	date := now.Format("20060102")
+	scope := date + "/us-east-1/s3/aws4_request"
+	payloadHash := sha256.Sum256([]byte(body))
+
+	r.Header.Set("X-Amz-Date", now.Format(amzDateFormat))
+	r.Header.Set("X-Amz-Content-Sha256", hex.EncodeToString(payloadHash[:]))
+
+	signedHeaders := []string{"host", "x-amz-content-sha256", "x-amz-date"}
+	canonical := canonicalRequest(r, r.URL.Query(), signedHeaders, hex.EncodeToString(payloadHash[:]))
+	signature := computeSignature(secret, date, "us-east-1", "s3",
+		stringToSign(now.Format(amzDateFormat), scope, canonical))
+
+	r.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		signatureAlgorithm, testAccessKey, scope, strings.Join(signedHeaders, ";"), signature))
+}
+
+func assertErrorCode(t *testing.T, w *httptest.ResponseRecorder, status int, code string) {
+	t.Helper()
+	assert.Equal(t, status, w.Code)
+
+	var res errorResponse
+	require.NoError(t, xml.Unmarshal(w.Body.Bytes(), &res), w.Body.String())
+	assert.Equal(t, code, res.Code)
+}
+
+func TestPutGetObject(t *testing.T) {
+	s, client := setupGateway(t)
+
+	w := do(t, s, "PUT", "/bucket/dir/foo.txt", "hello, gateway")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	etag := w.Header().Get("ETag")
+	assert.NotEmpty(t, etag)
+
+	b, err := client.ReadFile(testRoot + "/bucket/dir/foo.txt")
+	require.NoError(t, err)
+	assert.Equal(t, "hello, gateway", string(b))
+
+	w = do(t, s, "GET", "/bucket/dir/foo.txt", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "hello, gateway", w.Body.String())
+	assert.Equal(t, etag, w.Header().Get("ETag"))
+
+	r := httptest.NewRequest("GET", "/bucket/dir/foo.txt", nil)
+	r.Header.Set("Range", "bytes=7-13")
+	sign(r, "", testSecretKey)
+	w = httptest.NewRecorder()
+	s.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusPartialContent, w.Code)
+	assert.Equal(t, "gateway", w.Body.String())
+
+	w = do(t, s, "HEAD", "/bucket/dir/foo.txt", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "14", w.Header().Get("Content-Length"))
+
+	w = do(t, s, "PUT", "/bucket/dir/foo.txt", "overwritten")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.NotEqual(t, etag, w.Header().Get("ETag"))
+
+	w = do(t, s, "GET", "/bucket/dir/foo.txt", "")
+	assert.Equal(t, "overwritten", w.Body.String())
+}
+
+func TestGetObjectNotFound(t *testing.T) {
+	s, _ := setupGateway(t)
+
+	w := do(t, s, "GET", "/bucket/nonexistent", "")
+	assertErrorCode(t, w, http.StatusNotFound, "NoSuchKey")
+
+	w = do(t, s, "GET", "/nonexistent/foo", "")
+	assertErrorCode(t, w, http.StatusNotFound, "NoSuchBucket")
+
+	w = do(t, s, "PUT", "/bucket/dir/foo.txt", "foo")
+	require.Equal(t, http.StatusOK, w.Code)
+
+	// Directories aren't objects, unless they're asked for as folder markers.
+	w = do(t, s, "GET", "/bucket/dir", "")
+	assertErrorCode(t, w, http.StatusNotFound, "NoSuchKey")
+
+	w = do(t, s, "GET", "/bucket/dir/", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestPutObjectBadDigest(t *testing.T) {
+	s, client := setupGateway(t)
+
+	r := httptest.NewRequest("PUT", "/bucket/foo.txt", strings.NewReader("foo"))
+	r.Header.Set("Content-Md5", "rL0Y20zC+Fzt72VPzMSk2A==")
+	sign(r, "foo", testSecretKey)
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	r = httptest.NewRequest("PUT", "/bucket/bar.txt", strings.NewReader("bar"))
+	r.Header.Set("Content-Md5", "rL0Y20zC+Fzt72VPzMSk2A==")
+	sign(r, "bar", testSecretKey)
+	w = httptest.NewRecorder()
+	s.ServeHTTP(w, r)
+	assertErrorCode(t, w, http.StatusBadRequest, "BadDigest")
+
+	_, err := client.Stat(testRoot + "/bucket/bar.txt")
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestBadSignature(t *testing.T) {
+	s, client := setupGateway(t)
+
+	r := httptest.NewRequest("PUT", "/bucket/foo.txt", strings.NewReader("foo"))
+	sign(r, "foo", "wrongsecret")
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, r)
+	assertErrorCode(t, w, http.StatusForbidden, "SignatureDoesNotMatch")
+
+	// The payload is signed, so it can't be swapped out.
+	r = httptest.NewRequest("PUT", "/bucket/foo.txt", strings.NewReader("bar"))
+	sign(r, "foo", testSecretKey)
+	w = httptest.NewRecorder()
+	s.ServeHTTP(w, r)
+	assertErrorCode(t, w, http.StatusBadRequest, "XAmzContentSHA256Mismatch")
+
+	_, err := client.Stat(testRoot + "/bucket/foo.txt")
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestDeleteObject(t *testing.T) {
+	s, client := setupGateway(t)
+
+	do(t, s, "PUT", "/bucket/dir/foo.txt", "foo")
+	do(t, s, "PUT", "/bucket/empty/", "")
+
+	w := do(t, s, "DELETE", "/bucket/dir/foo.txt", "")
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	_, err := client.Stat(testRoot + "/bucket/dir/foo.txt")
+	assert.True(t, os.IsNotExist(err))
+
+	w = do(t, s, "DELETE", "/bucket/dir/foo.txt", "")
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	w = do(t, s, "DELETE", "/bucket/empty/", "")
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	_, err = client.Stat(testRoot + "/bucket/empty")
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestBuckets(t *testing.T) {
+	s, _ := setupGateway(t)
+
+	w := do(t, s, "PUT", "/bucket2", "")
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	w = do(t, s, "PUT", "/bucket2", "")
+	assertErrorCode(t, w, http.StatusConflict, "BucketAlreadyOwnedByYou")
+
+	w = do(t, s, "GET", "/", "")
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var res listAllMyBucketsResult
+	require.NoError(t, xml.Unmarshal(w.Body.Bytes(), &res))
+	require.Len(t, res.Buckets, 2)
+	assert.Equal(t, "bucket", res.Buckets[0].Name)
+	assert.Equal(t, "bucket2", res.Buckets[1].Name)
+
+	w = do(t, s, "HEAD", "/bucket2", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	do(t, s, "PUT", "/bucket2/foo", "foo")
+	w = do(t, s, "DELETE", "/bucket2", "")
+	assertErrorCode(t, w, http.StatusConflict, "BucketNotEmpty")
+
+	do(t, s, "DELETE", "/bucket2/foo", "")
+	w = do(t, s, "DELETE", "/bucket2", "")
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	w = do(t, s, "HEAD", "/bucket2", "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func listObjects(t *testing.T, s *Server, query string) listBucketResult {
+	w := do(t, s, "GET", "/bucket?"+query, "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var res listBucketResult
+	require.NoError(t, xml.Unmarshal(w.Body.Bytes(), &res))
+	return res
+}
+
+func keys(res listBucketResult) ([]string, []string) {
+	var keys, prefixes []string
+	for _, obj := range res.Contents {
+		keys = append(keys, obj.Key)
+	}
+
+	for _, p := range res.CommonPrefixes {
+		prefixes = append(prefixes, p.Prefix)
+	}
+
+	return keys, prefixes
+}
+
+func TestListObjects(t *testing.T) {
+	s, _ := setupGateway(t)
+
+	for _, key := range []string{"a", "b-c", "b/x", "d/e/f", "d/g", "i/"} {
+		body := ""
+		if !strings.HasSuffix(key, "/") {
+			body = key
+		}
+
+		w := do(t, s, "PUT", "/bucket/"+key, body)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	}
+
+	// Keys are sorted as keys, rather than as paths, so "b-c" is before
+	// "b/x".
+	res := listObjects(t, s, "list-type=2")
+	k, p := keys(res)
+	assert.Equal(t, []string{"a", "b-c", "b/x", "d/e/f", "d/g", "i/"}, k)
+	assert.Empty(t, p)
+	assert.Equal(t, int64(1), res.Contents[0].Size)
+	assert.False(t, res.IsTruncated)
+
+	res = listObjects(t, s, "list-type=2&delimiter=/")
+	k, p = keys(res)
+	assert.Equal(t, []string{"a", "b-c"}, k)
+	assert.Equal(t, []string{"b/", "d/", "i/"}, p)
+
+	res = listObjects(t, s, "list-type=2&delimiter=/&prefix=d/")
+	k, p = keys(res)
+	assert.Equal(t, []string{"d/g"}, k)
+	assert.Equal(t, []string{"d/e/"}, p)
+
+	res = listObjects(t, s, "list-type=2&prefix=d/e")
+	k, p = keys(res)
+	assert.Equal(t, []string{"d/e/f"}, k)
+	assert.Empty(t, p)
+
+	res = listObjects(t, s, "delimiter=/&prefix=i/")
+	k, p = keys(res)
+	assert.Equal(t, []string{"i/"}, k)
+	assert.Empty(t, p)
+}
+
+func TestListObjectsPagination(t *testing.T) {
+	s, _ := setupGateway(t)
+
+	for _, key := range []string{"a", "b/c", "b/d", "c", "d"} {
+		do(t, s, "PUT", "/bucket/"+key, key)
+	}
+
+	var all []string
+	query := "list-type=2&delimiter=/&max-keys=2"
+	for {
+		res := listObjects(t, s, query)
+		k, p := keys(res)
+		all = append(append(all, k...), p...)
+		assert.True(t, len(k)+len(p) <= 2)
+		if !res.IsTruncated {
+			break
+		}
+
+		query = "list-type=2&delimiter=/&max-keys=2&continuation-token=" + res.NextContinuationToken
+	}
+
+	assert.Equal(t, []string{"a", "b/", "c", "d"}, all)
+
+	all = nil
+	query = "max-keys=3"
+	for {
+		res := listObjects(t, s, query)
+		k, _ := keys(res)
+		all = append(all, k...)
+		if !res.IsTruncated {
+			break
+		}
+
+		query = "max-keys=3&marker=" + res.NextMarker
+	}
+
+	assert.Equal(t, []string{"a", "b/c", "b/d", "c", "d"}, all)
+}
+
+func TestMultipartUpload(t *testing.T) {
+	s, client := setupGateway(t)
+
+	w := do(t, s, "POST", "/bucket/dir/multipart.txt?uploads", "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var initiate initiateMultipartUploadResult
+	require.NoError(t, xml.Unmarshal(w.Body.Bytes(), &initiate))
+	id := initiate.UploadID
+	require.NotEmpty(t, id)
+
+	parts := []string{"foo", "bar", "", "baz"}
+	etags := make([]string, len(parts))
+	for i, part := range parts {
+		w = do(t, s, "PUT", fmt.Sprintf("/bucket/dir/multipart.txt?partNumber=%d&uploadId=%s", i+1, id), part)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		etags[i] = w.Header().Get("ETag")
+	}
+
+	// The parts have to be in order.
+	w = do(t, s, "POST", "/bucket/dir/multipart.txt?uploadId="+id, fmt.Sprintf(
+		`<CompleteMultipartUpload><Part><PartNumber>2</PartNumber><ETag>%s</ETag></Part>`+
+			`<Part><PartNumber>1</PartNumber><ETag>%s</ETag></Part></CompleteMultipartUpload>`,
+		etags[1], etags[0]))
+	assertErrorCode(t, w, http.StatusBadRequest, "InvalidPartOrder")
+
+	// The upload is for a specific key.
+	w = do(t, s, "PUT", "/bucket/other.txt?partNumber=1&uploadId="+id, "foo")
+	assertErrorCode(t, w, http.StatusNotFound, "NoSuchUpload")
+
+	var complete strings.Builder
+	complete.WriteString("<CompleteMultipartUpload>")
+	for i, etag := range etags {
+		fmt.Fprintf(&complete, "<Part><PartNumber>%d</PartNumber><ETag>%s</ETag></Part>", i+1, etag)
+	}
+	complete.WriteString("</CompleteMultipartUpload>")
+
+	w = do(t, s, "POST", "/bucket/dir/multipart.txt?uploadId="+id, complete.String())
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	b, err := client.ReadFile(testRoot + "/bucket/dir/multipart.txt")
+	require.NoError(t, err)
+	assert.Equal(t, "foobarbaz", string(b))
+
+	_, err = client.Stat(testRoot + "/" + uploadsDir + "/" + id)
+	assert.True(t, os.IsNotExist(err))
+
+	w = do(t, s, "POST", "/bucket/dir/multipart.txt?uploadId="+id, complete.String())
+	assertErrorCode(t, w, http.StatusNotFound, "NoSuchUpload")
+}
+
+func TestAbortMultipartUpload(t *testing.T) {
+	s, client := setupGateway(t)
+
+	w := do(t, s, "POST", "/bucket/aborted.txt?uploads", "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var initiate initiateMultipartUploadResult
+	require.NoError(t, xml.Unmarshal(w.Body.Bytes(), &initiate))
+
+	w = do(t, s, "PUT", "/bucket/aborted.txt?partNumber=1&uploadId="+initiate.UploadID, "foo")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	w = do(t, s, "DELETE", "/bucket/aborted.txt?uploadId="+initiate.UploadID, "")
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	_, err := client.Stat(testRoot + "/" + uploadsDir + "/" + initiate.UploadID)
+	assert.True(t, os.IsNotExist(err))
+
+	_, err = client.Stat(testRoot + "/bucket/aborted.txt")
+	assert.True(t, os.IsNotExist(err))
+
+	w = do(t, s, "GET", "/bucket/aborted.txt", "")
+	body, _ := ioutil.ReadAll(w.Body)
+	assert.Equal(t, http.StatusNotFound, w.Code, string(body))
+}
@@ -0,0 +1,140 @@
+package hdfs
+
+import (
+	"fmt"
+	"hash/crc32"
+	"strings"
+	"time"
+
+	hdfs "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_hdfs"
+)
+
+// serverDefaultsValidityPeriod is how long the cached server defaults are
+// used before being fetched again. This mirrors the Java client's
+// dfs.client.server-defaults.validity.period.ms.
+const serverDefaultsValidityPeriod = time.Hour
+
+// ServerDefaults represents the filesystem configuration stored on the
+// namenode. These are the values used for new files when no explicit
+// parameters are provided.
+type ServerDefaults struct {
+	// BlockSize is the default block size for new files, in bytes.
+	BlockSize int64
+	// BytesPerChecksum is the number of bytes covered by each checksum.
+	BytesPerChecksum int
+	// WritePacketSize is the default size of the packets sent to datanodes
+	// when writing.
+	WritePacketSize int
+	// Replication is the default replication factor for new files.
+	Replication int
+	// FileBufferSize is the configured size of the io buffers on the server.
+	FileBufferSize int
+	// EncryptDataTransfer indicates whether the cluster requires datanode
+	// connections to be encrypted.
+	EncryptDataTransfer bool
+	// TrashInterval is how long deleted files are kept in the trash. A zero
+	// value means the trash is disabled.
+	TrashInterval time.Duration
+	// ChecksumType is the checksum algorithm used for block data, for example
+	// "CRC32" or "CRC32C".
+	ChecksumType string
+}
+
+type cachedServerDefaults struct {
+	defaults  *hdfs.FsServerDefaultsProto
+	fetchedAt time.Time
+}
+
+// ServerDefaults returns the filesystem defaults configured on the namenode.
+// The result is cached by the client, and refreshed periodically.
+func (c *Client) ServerDefaults() (ServerDefaults, error) {
+	d, err := c.fetchDefaults()
+	if err != nil {
+		return ServerDefaults{}, err
+	}
+
+	return ServerDefaults{
+		BlockSize:           int64(d.GetBlockSize()),
+		BytesPerChecksum:    int(d.GetBytesPerChecksum()),
+		WritePacketSize:     int(d.GetWritePacketSize()),
+		Replication:         int(d.GetReplication()),
+		FileBufferSize:      int(d.GetFileBufferSize()),
+		EncryptDataTransfer: d.GetEncryptDataTransfer(),
+		TrashInterval:       time.Duration(d.GetTrashInterval()) * time.Minute,
+		ChecksumType:        strings.TrimPrefix(d.GetChecksumType().String(), "CHECKSUM_"),
+	}, nil
+}
+
+func (c *Client) fetchDefaults() (*hdfs.FsServerDefaultsProto, error) {
+	cached, ok := c.defaults.Load().(cachedServerDefaults)
+	if ok && time.Since(cached.fetchedAt) < serverDefaultsValidityPeriod {
+		return cached.defaults, nil
+	}
+
+	// WebHDFS has no equivalent, so the server's defaults are left to apply.
+	if c.web != nil {
+		return &hdfs.FsServerDefaultsProto{}, nil
+	}
+
+	req := &hdfs.GetServerDefaultsRequestProto{}
+	resp := &hdfs.GetServerDefaultsResponseProto{}
+
+	err := c.namenode.Execute("getServerDefaults", req, resp)
+	if err != nil {
+		// If we have stale defaults, they're better than nothing.
+		if ok {
+			return cached.defaults, nil
+		}
+
+		return nil, err
+	}
+
+	r := resp.GetServerDefaults()
+	c.defaults.Store(cachedServerDefaults{defaults: r, fetchedAt: time.Now()})
+	return r, nil
+}
+
+// parseChecksumType parses the name of a checksum type, like "CRC32C". An
+// empty name returns CHECKSUM_NULL.
+func parseChecksumType(name string) (hdfs.ChecksumTypeProto, error) {
+	switch strings.ToUpper(name) {
+	case "":
+		return hdfs.ChecksumTypeProto_CHECKSUM_NULL, nil
+	case "CRC32":
+		return hdfs.ChecksumTypeProto_CHECKSUM_CRC32, nil
+	case "CRC32C":
+		return hdfs.ChecksumTypeProto_CHECKSUM_CRC32C, nil
+	default:
+		return 0, fmt.Errorf("unsupported checksum type: %s", name)
+	}
+}
+
+// checksumType returns the type of checksum to use when writing, which is
+// ClientOptions.ChecksumType if it's set.
+func (c *Client) checksumType(defaults *hdfs.FsServerDefaultsProto) hdfs.ChecksumTypeProto {
+	if t, _ := parseChecksumType(c.options.ChecksumType); t != hdfs.ChecksumTypeProto_CHECKSUM_NULL {
+		return t
+	}
+
+	return defaults.GetChecksumType()
+}
+
+// checksumPoly returns the CRC polynomial for a checksum type, as used by
+// the BlockWriter.
+func checksumPoly(t hdfs.ChecksumTypeProto) uint32 {
+	if t == hdfs.ChecksumTypeProto_CHECKSUM_CRC32C {
+		return crc32.Castagnoli
+	}
+
+	return crc32.IEEE
+}
+
+// writePacketSize returns the size of the packets to send when writing, which
+// is ClientOptions.WritePacketSize if it's set.
+func (c *Client) writePacketSize(defaults *hdfs.FsServerDefaultsProto) int {
+	if c.options.WritePacketSize > 0 {
+		return c.options.WritePacketSize
+	}
+
+	return int(defaults.GetWritePacketSize())
+}
@@ -0,0 +1,35 @@
+package hdfs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerDefaults(t *testing.T) {
+	client := getClient(t)
+
+	sd, err := client.ServerDefaults()
+	require.NoError(t, err)
+
+	assert.True(t, sd.BlockSize > 0)
+	assert.True(t, sd.BytesPerChecksum > 0)
+	assert.True(t, sd.WritePacketSize > 0)
+	assert.True(t, sd.Replication > 0)
+	assert.Contains(t, []string{"CRC32", "CRC32C", "NULL"}, sd.ChecksumType)
+}
+
+func TestServerDefaultsCached(t *testing.T) {
+	client := getClient(t)
+
+	_, err := client.ServerDefaults()
+	require.NoError(t, err)
+
+	cached, err := client.fetchDefaults()
+	require.NoError(t, err)
+
+	again, err := client.fetchDefaults()
+	require.NoError(t, err)
+	assert.True(t, cached == again)
+}
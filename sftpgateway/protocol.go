@@ -0,0 +1,284 @@
+package sftpgateway
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// These are from version 3 of the protocol, as described in
+// draft-ietf-secsh-filexfer-02, which is the version OpenSSH implements.
+const (
+	sftpProtocolVersion = 3
+
+	fxpInit          = 1
+	fxpVersion       = 2
+	fxpOpen          = 3
+	fxpClose         = 4
+	fxpRead          = 5
+	fxpWrite         = 6
+	fxpLstat         = 7
+	fxpFstat         = 8
+	fxpSetstat       = 9
+	fxpFsetstat      = 10
+	fxpOpendir       = 11
+	fxpReaddir       = 12
+	fxpRemove        = 13
+	fxpMkdir         = 14
+	fxpRmdir         = 15
+	fxpRealpath      = 16
+	fxpStat          = 17
+	fxpRename        = 18
+	fxpReadlink      = 19
+	fxpSymlink       = 20
+	fxpStatus        = 101
+	fxpHandle        = 102
+	fxpData          = 103
+	fxpName          = 104
+	fxpAttrs         = 105
+	fxpExtended      = 200
+	fxpExtendedReply = 201
+
+	fxOK               = 0
+	fxEOF              = 1
+	fxNoSuchFile       = 2
+	fxPermissionDenied = 3
+	fxFailure          = 4
+	fxBadMessage       = 5
+	fxOpUnsupported    = 8
+
+	attrSize        = 0x00000001
+	attrUIDGID      = 0x00000002
+	attrPermissions = 0x00000004
+	attrACModTime   = 0x00000008
+	attrExtended    = 0x80000000
+
+	fxfRead   = 0x00000001
+	fxfWrite  = 0x00000002
+	fxfAppend = 0x00000004
+	fxfCreat  = 0x00000008
+	fxfTrunc  = 0x00000010
+	fxfExcl   = 0x00000020
+
+	// The permissions field includes the file type, like st_mode.
+	modeTypeMask = 0170000
+	modeDir      = 0040000
+	modeRegular  = 0100000
+
+	// maxPacketSize is the largest packet accepted from a client. It leaves
+	// plenty of room for the 32KiB writes most clients send, and the 256KiB
+	// that some can be configured to.
+	maxPacketSize = 1024 * 1024
+	// maxReadSize is the most data sent in response to a single read.
+	maxReadSize = 256 * 1024
+
+	posixRenameExtension = "posix-rename@openssh.com"
+)
+
+var errBadMessage = errors.New("bad message")
+
+// readPacket reads a single packet, returning its type and payload.
+func readPacket(r io.Reader) (byte, []byte, error) {
+	var header [4]byte
+	_, err := io.ReadFull(r, header[:])
+	if err != nil {
+		return 0, nil, err
+	}
+
+	length := binary.BigEndian.Uint32(header[:])
+	if length < 1 || length > maxPacketSize {
+		return 0, nil, fmt.Errorf("invalid packet length: %d", length)
+	}
+
+	b := make([]byte, length)
+	_, err = io.ReadFull(r, b)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return b[0], b[1:], nil
+}
+
+// decoder reads the fields of a packet. Once a read fails, because the packet
+// is too short, err is set and every read after it returns the zero value.
+type decoder struct {
+	b   []byte
+	err error
+}
+
+func (d *decoder) take(n int) []byte {
+	if d.err != nil || n < 0 || len(d.b) < n {
+		d.err = errBadMessage
+		return nil
+	}
+
+	b := d.b[:n]
+	d.b = d.b[n:]
+	return b
+}
+
+func (d *decoder) uint32() uint32 {
+	b := d.take(4)
+	if b == nil {
+		return 0
+	}
+
+	return binary.BigEndian.Uint32(b)
+}
+
+func (d *decoder) uint64() uint64 {
+	b := d.take(8)
+	if b == nil {
+		return 0
+	}
+
+	return binary.BigEndian.Uint64(b)
+}
+
+func (d *decoder) string() string {
+	n := d.uint32()
+	if d.err != nil || n > uint32(len(d.b)) {
+		d.err = errBadMessage
+		return ""
+	}
+
+	return string(d.take(int(n)))
+}
+
+// attrs reads a set of file attributes. Extended attributes are skipped.
+func (d *decoder) attrs() fileAttrs {
+	var a fileAttrs
+	a.flags = d.uint32()
+	if a.flags&attrSize != 0 {
+		a.size = d.uint64()
+	}
+
+	if a.flags&attrUIDGID != 0 {
+		d.uint32()
+		d.uint32()
+	}
+
+	if a.flags&attrPermissions != 0 {
+		a.permissions = d.uint32()
+	}
+
+	if a.flags&attrACModTime != 0 {
+		a.atime = d.uint32()
+		a.mtime = d.uint32()
+	}
+
+	if a.flags&attrExtended != 0 {
+		n := d.uint32()
+		for i := uint32(0); i < n && d.err == nil; i++ {
+			d.string()
+			d.string()
+		}
+	}
+
+	return a
+}
+
+// encoder builds a packet. The length is filled in by bytes.
+type encoder struct {
+	b []byte
+}
+
+func newPacket(packetType byte, id uint32) *encoder {
+	e := &encoder{b: make([]byte, 4, 64)}
+	e.b = append(e.b, packetType)
+	e.uint32(id)
+	return e
+}
+
+func (e *encoder) uint32(v uint32) {
+	e.b = append(e.b, byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+}
+
+func (e *encoder) uint64(v uint64) {
+	e.uint32(uint32(v >> 32))
+	e.uint32(uint32(v))
+}
+
+func (e *encoder) string(s string) {
+	e.uint32(uint32(len(s)))
+	e.b = append(e.b, s...)
+}
+
+func (e *encoder) attrs(a fileAttrs) {
+	e.uint32(a.flags)
+	if a.flags&attrSize != 0 {
+		e.uint64(a.size)
+	}
+
+	if a.flags&attrPermissions != 0 {
+		e.uint32(a.permissions)
+	}
+
+	if a.flags&attrACModTime != 0 {
+		e.uint32(a.atime)
+		e.uint32(a.mtime)
+	}
+}
+
+func (e *encoder) bytes() []byte {
+	binary.BigEndian.PutUint32(e.b, uint32(len(e.b)-4))
+	return e.b
+}
+
+// fileAttrs is the ATTRS structure from the protocol. The uid and gid are
+// never sent, since HDFS only has names for users and groups.
+type fileAttrs struct {
+	flags       uint32
+	size        uint64
+	permissions uint32
+	atime       uint32
+	mtime       uint32
+}
+
+func attrsFromFileInfo(info os.FileInfo) fileAttrs {
+	a := fileAttrs{
+		flags:       attrSize | attrPermissions | attrACModTime,
+		size:        uint64(info.Size()),
+		permissions: uint32(info.Mode().Perm()),
+		mtime:       uint32(info.ModTime().Unix()),
+	}
+
+	a.atime = a.mtime
+	if at, ok := info.(interface{ AccessTime() time.Time }); ok {
+		a.atime = uint32(at.AccessTime().Unix())
+	}
+
+	if info.IsDir() {
+		a.permissions |= modeDir
+	} else {
+		a.permissions |= modeRegular
+	}
+
+	return a
+}
+
+type fileOwner interface {
+	Owner() string
+	OwnerGroup() string
+}
+
+// longName formats a directory entry like "ls -l" does, which is what clients
+// show to users.
+func longName(info os.FileInfo) string {
+	owner, group := "-", "-"
+	if fo, ok := info.(fileOwner); ok {
+		owner, group = fo.Owner(), fo.OwnerGroup()
+	}
+
+	modTime := info.ModTime()
+	timeFormat := "Jan _2 15:04"
+	if time.Since(modTime) > 180*24*time.Hour {
+		timeFormat = "Jan _2  2006"
+	}
+
+	return fmt.Sprintf("%s 1 %-8s %-8s %8d %s %s", info.Mode().String(), owner, group,
+		info.Size(), modTime.Format(timeFormat), info.Name())
+}
@@ -0,0 +1,549 @@
+package sftpgateway
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/colinmarc/hdfs/v2"
+)
+
+// readdirBatchSize is how many entries are sent in response to each READDIR.
+const readdirBatchSize = 100
+
+var (
+	errInvalidHandle = errors.New("invalid handle")
+	errIsDir         = errors.New("is a directory")
+	errNotDir        = errors.New("not a directory")
+	errExists        = errors.New("file exists")
+	errNotWriter     = errors.New("handle isn't open for writing")
+	errNotReader     = errors.New("handle isn't open for reading")
+	errUnsupported   = errors.New("operation unsupported")
+	errSeek          = errors.New("files can only be written sequentially from the start or end")
+)
+
+// errStatus is an error with a specific status code.
+type errStatus struct {
+	code uint32
+	err  error
+}
+
+func (e errStatus) Error() string {
+	return e.err.Error()
+}
+
+// handle is an open file or directory.
+type handle struct {
+	name   string
+	reader *hdfs.FileReader
+	writer *hdfs.FileWriter
+	// offset is the offset of the next write.
+	offset int64
+	// pending is set by FSETSTAT on a handle that's being written, and
+	// applied once the file is closed, so that the times aren't overwritten
+	// when the file is completed.
+	pending *fileAttrs
+
+	dir     bool
+	entries []os.FileInfo
+	listed  bool
+}
+
+type session struct {
+	client     *hdfs.Client
+	root       string
+	rw         io.ReadWriter
+	handles    map[string]*handle
+	nextHandle uint64
+}
+
+func (s *session) serve() error {
+	packetType, payload, err := readPacket(s.rw)
+	if err != nil {
+		return err
+	} else if packetType != fxpInit {
+		return fmt.Errorf("expected SSH_FXP_INIT, got packet type %d", packetType)
+	}
+
+	d := decoder{b: payload}
+	if version := d.uint32(); version < sftpProtocolVersion || d.err != nil {
+		return fmt.Errorf("unsupported protocol version: %d", version)
+	}
+
+	// The version packet doesn't have an ID.
+	version := &encoder{b: make([]byte, 4)}
+	version.b = append(version.b, fxpVersion)
+	version.uint32(sftpProtocolVersion)
+	version.string(posixRenameExtension)
+	version.string("1")
+	_, err = s.rw.Write(version.bytes())
+	if err != nil {
+		return err
+	}
+
+	for {
+		packetType, payload, err := readPacket(s.rw)
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		d := &decoder{b: payload}
+		id := d.uint32()
+		resp := s.handle(packetType, id, d)
+		_, err = s.rw.Write(resp.bytes())
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// handle handles a single request, and returns the response.
+func (s *session) handle(packetType byte, id uint32, d *decoder) *encoder {
+	var resp *encoder
+	var err error
+	switch packetType {
+	case fxpOpen:
+		name, pflags, attrs := d.string(), d.uint32(), d.attrs()
+		if d.err == nil {
+			resp, err = s.open(id, s.resolve(name), pflags, attrs)
+		}
+	case fxpClose:
+		h := d.string()
+		if d.err == nil {
+			err = s.close(h)
+		}
+	case fxpRead:
+		h, offset, length := d.string(), d.uint64(), d.uint32()
+		if d.err == nil {
+			resp, err = s.read(id, h, int64(offset), length)
+		}
+	case fxpWrite:
+		h, offset, data := d.string(), d.uint64(), d.string()
+		if d.err == nil {
+			err = s.write(h, int64(offset), data)
+		}
+	case fxpStat, fxpLstat:
+		name := d.string()
+		if d.err == nil {
+			resp, err = s.stat(id, s.resolve(name))
+		}
+	case fxpFstat:
+		h := d.string()
+		if d.err == nil {
+			resp, err = s.fstat(id, h)
+		}
+	case fxpSetstat:
+		name, attrs := d.string(), d.attrs()
+		if d.err == nil {
+			err = s.setstat(s.resolve(name), attrs)
+		}
+	case fxpFsetstat:
+		h, attrs := d.string(), d.attrs()
+		if d.err == nil {
+			err = s.fsetstat(h, attrs)
+		}
+	case fxpOpendir:
+		name := d.string()
+		if d.err == nil {
+			resp, err = s.opendir(id, s.resolve(name))
+		}
+	case fxpReaddir:
+		h := d.string()
+		if d.err == nil {
+			resp, err = s.readdir(id, h)
+		}
+	case fxpRemove:
+		name := d.string()
+		if d.err == nil {
+			err = s.remove(s.resolve(name), false)
+		}
+	case fxpRmdir:
+		name := d.string()
+		if d.err == nil {
+			err = s.remove(s.resolve(name), true)
+		}
+	case fxpMkdir:
+		name, attrs := d.string(), d.attrs()
+		if d.err == nil {
+			err = s.client.Mkdir(s.resolve(name), permissions(attrs, 0755))
+		}
+	case fxpRealpath:
+		name := d.string()
+		if d.err == nil {
+			resp = newPacket(fxpName, id)
+			resp.uint32(1)
+			clean := path.Clean("/" + name)
+			resp.string(clean)
+			resp.string(clean)
+			resp.attrs(fileAttrs{})
+		}
+	case fxpRename:
+		oldName, newName := d.string(), d.string()
+		if d.err == nil {
+			err = s.rename(s.resolve(oldName), s.resolve(newName), false)
+		}
+	case fxpExtended:
+		extension := d.string()
+		if extension == posixRenameExtension {
+			oldName, newName := d.string(), d.string()
+			if d.err == nil {
+				err = s.rename(s.resolve(oldName), s.resolve(newName), true)
+			}
+		} else {
+			err = errStatus{fxOpUnsupported, errUnsupported}
+		}
+	default:
+		err = errStatus{fxOpUnsupported, errUnsupported}
+	}
+
+	if d.err != nil {
+		return statusPacket(id, errStatus{fxBadMessage, d.err})
+	} else if err != nil {
+		return statusPacket(id, err)
+	} else if resp == nil {
+		return statusPacket(id, nil)
+	}
+
+	return resp
+}
+
+// resolve returns the path in HDFS for a path from the client. Relative paths
+// are relative to the root, and nothing outside the root can be reached.
+func (s *session) resolve(name string) string {
+	return path.Join(s.root, path.Clean("/"+name))
+}
+
+// statusPacket returns a STATUS response for the error, or an OK status if
+// err is nil.
+func statusPacket(id uint32, err error) *encoder {
+	code := uint32(fxOK)
+	msg := "Success"
+
+	var status errStatus
+	var pathErr *os.PathError
+	if errors.As(err, &status) {
+		code, msg = status.code, status.err.Error()
+	} else if errors.Is(err, os.ErrNotExist) {
+		code, msg = fxNoSuchFile, "No such file"
+	} else if errors.Is(err, os.ErrPermission) {
+		code, msg = fxPermissionDenied, "Permission denied"
+	} else if errors.As(err, &pathErr) {
+		// The path would give away where the root is.
+		code, msg = fxFailure, pathErr.Err.Error()
+	} else if err != nil {
+		code, msg = fxFailure, err.Error()
+	}
+
+	resp := newPacket(fxpStatus, id)
+	resp.uint32(code)
+	resp.string(msg)
+	resp.string("")
+	return resp
+}
+
+func permissions(attrs fileAttrs, def os.FileMode) os.FileMode {
+	if attrs.flags&attrPermissions != 0 {
+		return os.FileMode(attrs.permissions & 0777)
+	}
+
+	return def
+}
+
+// handlePacket registers a newly opened handle, and returns the HANDLE
+// response for it.
+func (s *session) handlePacket(id uint32, h *handle) *encoder {
+	s.nextHandle++
+	name := strconv.FormatUint(s.nextHandle, 10)
+	s.handles[name] = h
+
+	resp := newPacket(fxpHandle, id)
+	resp.string(name)
+	return resp
+}
+
+func (s *session) open(id uint32, name string, pflags uint32, attrs fileAttrs) (*encoder, error) {
+	if pflags&(fxfWrite|fxfAppend) == 0 {
+		f, err := s.client.Open(name)
+		if err != nil {
+			return nil, err
+		} else if f.Stat().IsDir() {
+			f.Close()
+			return nil, errIsDir
+		}
+
+		return s.handlePacket(id, &handle{name: name, reader: f}), nil
+	}
+
+	info, err := s.client.Stat(name)
+	exists := err == nil
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	} else if exists && info.IsDir() {
+		return nil, errIsDir
+	} else if exists && pflags&fxfExcl != 0 {
+		return nil, errExists
+	} else if !exists && pflags&fxfCreat == 0 {
+		return nil, err
+	}
+
+	h := &handle{name: name}
+	if exists && pflags&fxfTrunc == 0 {
+		// Writes can only continue from the end.
+		h.writer, err = s.client.Append(name)
+		h.offset = info.Size()
+	} else {
+		h.writer, err = s.client.CreateWithOptions(name, hdfs.CreateOptions{
+			Perm:      permissions(attrs, 0644),
+			Overwrite: exists,
+		})
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return s.handlePacket(id, h), nil
+}
+
+func (s *session) close(name string) error {
+	h, ok := s.handles[name]
+	if !ok {
+		return errInvalidHandle
+	}
+
+	delete(s.handles, name)
+	return h.close(s.client)
+}
+
+func (h *handle) close(client *hdfs.Client) error {
+	var err error
+	if h.reader != nil {
+		err = h.reader.Close()
+	} else if h.writer != nil {
+		err = h.writer.Close()
+		if err == nil && h.pending != nil {
+			err = setAttrs(client, h.name, *h.pending)
+		}
+	}
+
+	return err
+}
+
+func (s *session) closeAll() {
+	for name, h := range s.handles {
+		h.close(s.client)
+		delete(s.handles, name)
+	}
+}
+
+func (s *session) read(id uint32, name string, offset int64, length uint32) (*encoder, error) {
+	h, ok := s.handles[name]
+	if !ok {
+		return nil, errInvalidHandle
+	} else if h.reader == nil {
+		return nil, errNotReader
+	}
+
+	if offset >= h.reader.Stat().Size() {
+		return nil, errStatus{fxEOF, io.EOF}
+	}
+
+	if length > maxReadSize {
+		length = maxReadSize
+	}
+
+	b := make([]byte, length)
+	n, err := h.reader.ReadAt(b, offset)
+	if n == 0 && err != nil {
+		return nil, err
+	}
+
+	resp := newPacket(fxpData, id)
+	resp.string(string(b[:n]))
+	return resp, nil
+}
+
+func (s *session) write(name string, offset int64, data string) error {
+	h, ok := s.handles[name]
+	if !ok {
+		return errInvalidHandle
+	} else if h.writer == nil {
+		return errNotWriter
+	} else if offset != h.offset {
+		return errSeek
+	}
+
+	n, err := h.writer.Write([]byte(data))
+	h.offset += int64(n)
+	return err
+}
+
+func (s *session) stat(id uint32, name string) (*encoder, error) {
+	info, err := s.client.Stat(name)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := newPacket(fxpAttrs, id)
+	resp.attrs(attrsFromFileInfo(info))
+	return resp, nil
+}
+
+func (s *session) fstat(id uint32, name string) (*encoder, error) {
+	h, ok := s.handles[name]
+	if !ok {
+		return nil, errInvalidHandle
+	}
+
+	var attrs fileAttrs
+	if h.reader != nil {
+		attrs = attrsFromFileInfo(h.reader.Stat())
+	} else {
+		info, err := s.client.Stat(h.name)
+		if err != nil {
+			return nil, err
+		}
+
+		// The namenode doesn't know how much has been written yet.
+		attrs = attrsFromFileInfo(info)
+		if h.writer != nil {
+			attrs.size = uint64(h.offset)
+		}
+	}
+
+	resp := newPacket(fxpAttrs, id)
+	resp.attrs(attrs)
+	return resp, nil
+}
+
+func (s *session) setstat(name string, attrs fileAttrs) error {
+	return setAttrs(s.client, name, attrs)
+}
+
+func (s *session) fsetstat(name string, attrs fileAttrs) error {
+	h, ok := s.handles[name]
+	if !ok {
+		return errInvalidHandle
+	} else if h.writer != nil {
+		if attrs.flags&attrSize != 0 && int64(attrs.size) != h.offset {
+			return errStatus{fxOpUnsupported, errors.New("files can't be resized while they're being written")}
+		}
+
+		h.pending = &attrs
+		return nil
+	}
+
+	return setAttrs(s.client, h.name, attrs)
+}
+
+// setAttrs applies the permissions and times in attrs to a file. Ownership
+// can't be changed, since the protocol only has numeric IDs for it, and
+// nor can the size, except to its current value.
+func setAttrs(client *hdfs.Client, name string, attrs fileAttrs) error {
+	if attrs.flags&attrSize != 0 {
+		info, err := client.Stat(name)
+		if err != nil {
+			return err
+		} else if info.Size() != int64(attrs.size) {
+			return errStatus{fxOpUnsupported, errors.New("files can't be resized")}
+		}
+	}
+
+	if attrs.flags&attrPermissions != 0 {
+		err := client.Chmod(name, os.FileMode(attrs.permissions&07777))
+		if err != nil {
+			return err
+		}
+	}
+
+	if attrs.flags&attrACModTime != 0 {
+		err := client.Chtimes(name, time.Unix(int64(attrs.atime), 0), time.Unix(int64(attrs.mtime), 0))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *session) opendir(id uint32, name string) (*encoder, error) {
+	info, err := s.client.Stat(name)
+	if err != nil {
+		return nil, err
+	} else if !info.IsDir() {
+		return nil, errNotDir
+	}
+
+	return s.handlePacket(id, &handle{name: name, dir: true}), nil
+}
+
+func (s *session) readdir(id uint32, name string) (*encoder, error) {
+	h, ok := s.handles[name]
+	if !ok || !h.dir {
+		return nil, errInvalidHandle
+	}
+
+	if !h.listed {
+		entries, err := s.client.ReadDir(h.name)
+		if err != nil {
+			return nil, err
+		}
+
+		h.entries = entries
+		h.listed = true
+	}
+
+	if len(h.entries) == 0 {
+		return nil, errStatus{fxEOF, io.EOF}
+	}
+
+	batch := h.entries
+	if len(batch) > readdirBatchSize {
+		batch = batch[:readdirBatchSize]
+	}
+
+	h.entries = h.entries[len(batch):]
+	resp := newPacket(fxpName, id)
+	resp.uint32(uint32(len(batch)))
+	for _, info := range batch {
+		resp.string(info.Name())
+		resp.string(longName(info))
+		resp.attrs(attrsFromFileInfo(info))
+	}
+
+	return resp, nil
+}
+
+func (s *session) remove(name string, dir bool) error {
+	info, err := s.client.Stat(name)
+	if err != nil {
+		return err
+	} else if info.IsDir() && !dir {
+		return errIsDir
+	} else if !info.IsDir() && dir {
+		return errNotDir
+	}
+
+	return s.client.Remove(name)
+}
+
+// rename renames a file. Unless overwrite is set, it fails if the destination
+// exists, which is what the protocol requires.
+func (s *session) rename(oldName, newName string, overwrite bool) error {
+	if !overwrite {
+		_, err := s.client.Stat(newName)
+		if err == nil {
+			return errExists
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+
+	return s.client.Rename(oldName, newName)
+}
@@ -0,0 +1,302 @@
+// Package sftpgateway serves a directory in HDFS over SFTP, so that files can
+// be transferred with any SFTP client, without a Hadoop client installed.
+//
+// Users log in with public keys. Each key is authorized for an SSH user name,
+// which is also the HDFS user that the session acts as, so HDFS permissions
+// apply as usual. Ed25519 and ECDSA keys work with any client, but RSA keys
+// only work with clients that still allow SHA-1 signatures, which recent
+// versions of OpenSSH don't by default. The root directory is the root of the filesystem that
+// clients see, and they can't see anything outside it.
+//
+// The server implements version 3 of the SFTP protocol, which is what
+// OpenSSH and most other clients use. HDFS files can't be modified in place,
+// so files can only be written sequentially, from the start (with the
+// truncate flag or a new file) or from the end (with the append flag, or when
+// resuming an upload). Writes at any other offset fail. Renames fail if the
+// destination exists, as the protocol requires, unless the client uses the
+// posix-rename@openssh.com extension. Symlinks aren't supported.
+package sftpgateway
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"path"
+	"sync"
+
+	"github.com/colinmarc/hdfs/v2"
+	"golang.org/x/crypto/ssh"
+)
+
+// AuthorizedKey allows the holder of a private key to log in as a user.
+type AuthorizedKey struct {
+	// User is both the SSH user name that the key can be used with and the
+	// HDFS user that sessions act as.
+	User      string
+	PublicKey ssh.PublicKey
+}
+
+// Options represents the configurable options for a Server.
+type Options struct {
+	// Root is the directory in HDFS that's served as "/".
+	Root string
+	// HostKeys are the private keys that identify the server. At least one
+	// is required.
+	HostKeys []ssh.Signer
+	// AuthorizedKeys are the public keys that users can log in with.
+	AuthorizedKeys []AuthorizedKey
+	// Client returns the client to use for sessions for the given HDFS user.
+	// It's called once for each user, the first time they log in, and the
+	// client is reused after that.
+	Client func(user string) (*hdfs.Client, error)
+}
+
+// Server is an SFTP server.
+type Server struct {
+	root       string
+	config     *ssh.ServerConfig
+	clientFunc func(user string) (*hdfs.Client, error)
+
+	lock      sync.Mutex
+	clients   map[string]*hdfs.Client
+	listeners map[net.Listener]bool
+	conns     map[net.Conn]bool
+	closed    bool
+}
+
+// ErrServerClosed is returned by Serve once the server has been closed.
+var ErrServerClosed = errors.New("sftpgateway: server closed")
+
+// New returns a Server with the given options.
+func New(options Options) (*Server, error) {
+	if !path.IsAbs(options.Root) {
+		return nil, fmt.Errorf("root must be an absolute path: %q", options.Root)
+	} else if options.Client == nil {
+		return nil, errors.New("no Client function specified")
+	} else if len(options.HostKeys) == 0 {
+		return nil, errors.New("no host keys specified")
+	}
+
+	keys := make(map[string][][]byte)
+	for _, key := range options.AuthorizedKeys {
+		if key.User == "" || key.PublicKey == nil {
+			return nil, errors.New("incomplete authorized key")
+		}
+
+		keys[key.User] = append(keys[key.User], key.PublicKey.Marshal())
+	}
+
+	s := &Server{
+		root:       path.Clean(options.Root),
+		clientFunc: options.Client,
+		clients:    make(map[string]*hdfs.Client),
+		listeners:  make(map[net.Listener]bool),
+		conns:      make(map[net.Conn]bool),
+	}
+
+	s.config = &ssh.ServerConfig{
+		PublicKeyCallback: func(meta ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			marshaled := key.Marshal()
+			for _, authorized := range keys[meta.User()] {
+				if bytes.Equal(authorized, marshaled) {
+					return &ssh.Permissions{}, nil
+				}
+			}
+
+			return nil, fmt.Errorf("unknown public key for %s", meta.User())
+		},
+	}
+
+	for _, key := range options.HostKeys {
+		s.config.AddHostKey(key)
+	}
+
+	return s, nil
+}
+
+// ListenAndServe listens on the TCP address addr, and then calls Serve.
+func (s *Server) ListenAndServe(addr string) error {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	return s.Serve(l)
+}
+
+// Serve accepts connections on the listener, serving each one in a new
+// goroutine. It blocks until the listener fails or the server is closed, and
+// then closes the listener.
+func (s *Server) Serve(l net.Listener) error {
+	defer l.Close()
+
+	s.lock.Lock()
+	if s.closed {
+		s.lock.Unlock()
+		return ErrServerClosed
+	}
+
+	s.listeners[l] = true
+	s.lock.Unlock()
+
+	defer func() {
+		s.lock.Lock()
+		delete(s.listeners, l)
+		s.lock.Unlock()
+	}()
+
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			s.lock.Lock()
+			closed := s.closed
+			s.lock.Unlock()
+			if closed {
+				return ErrServerClosed
+			}
+
+			return err
+		}
+
+		go s.serveConn(conn)
+	}
+}
+
+func (s *Server) serveConn(conn net.Conn) {
+	s.lock.Lock()
+	if s.closed {
+		s.lock.Unlock()
+		conn.Close()
+		return
+	}
+
+	s.conns[conn] = true
+	s.lock.Unlock()
+
+	defer func() {
+		conn.Close()
+		s.lock.Lock()
+		delete(s.conns, conn)
+		s.lock.Unlock()
+	}()
+
+	sshConn, chans, reqs, err := ssh.NewServerConn(conn, s.config)
+	if err != nil {
+		return
+	}
+	defer sshConn.Close()
+
+	go ssh.DiscardRequests(reqs)
+	for newChannel := range chans {
+		if newChannel.ChannelType() != "session" {
+			newChannel.Reject(ssh.UnknownChannelType, "only sessions are supported")
+			continue
+		}
+
+		channel, requests, err := newChannel.Accept()
+		if err != nil {
+			continue
+		}
+
+		go s.serveSession(sshConn.User(), channel, requests)
+	}
+}
+
+// serveSession waits for the client to ask for the sftp subsystem, and then
+// serves it. Nothing else, like a shell, is allowed.
+func (s *Server) serveSession(user string, channel ssh.Channel, requests <-chan *ssh.Request) {
+	defer channel.Close()
+
+	started := false
+	for req := range requests {
+		ok := false
+		if req.Type == "subsystem" && !started {
+			d := decoder{b: req.Payload}
+			ok = d.string() == "sftp" && d.err == nil
+		}
+
+		req.Reply(ok, nil)
+		if !ok {
+			continue
+		}
+
+		started = true
+		go func() {
+			err := s.serveSFTP(user, channel)
+			status := uint32(0)
+			if err != nil {
+				log.Printf("sftpgateway: session for %s: %s", user, err)
+				status = 1
+			}
+
+			channel.SendRequest("exit-status", false, ssh.Marshal(struct{ Status uint32 }{status}))
+			channel.Close()
+		}()
+	}
+}
+
+func (s *Server) serveSFTP(user string, channel io.ReadWriter) error {
+	client, err := s.getClient(user)
+	if err != nil {
+		return err
+	}
+
+	sess := &session{
+		client:  client,
+		root:    s.root,
+		rw:      channel,
+		handles: make(map[string]*handle),
+	}
+	defer sess.closeAll()
+
+	return sess.serve()
+}
+
+// getClient returns the client for the given user, creating it if necessary.
+func (s *Server) getClient(user string) (*hdfs.Client, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if c, ok := s.clients[user]; ok {
+		return c, nil
+	}
+
+	c, err := s.clientFunc(user)
+	if err != nil {
+		return nil, err
+	}
+
+	s.clients[user] = c
+	return c, nil
+}
+
+// Close stops the server, closing its listeners and any open connections,
+// and then closes all the clients it has created. Files being written by
+// clients that are still connected are closed, but may be incomplete.
+func (s *Server) Close() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.closed = true
+	for l := range s.listeners {
+		l.Close()
+	}
+
+	for conn := range s.conns {
+		conn.Close()
+	}
+
+	var err error
+	for user, c := range s.clients {
+		if closeErr := c.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+
+		delete(s.clients, user)
+	}
+
+	return err
+}
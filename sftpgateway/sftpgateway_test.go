@@ -0,0 +1,401 @@
+package sftpgateway
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"testing"
+
+	"github.com/colinmarc/hdfs/v2"
+	"github.com/colinmarc/hdfs/v2/internal/testcluster"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+const testRoot = "/_test/sftpgateway"
+
+func getClient(t *testing.T) *hdfs.Client {
+	return testcluster.Client(t, "gohdfs1")
+}
+
+func generateKey(t *testing.T) ssh.Signer {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	signer, err := ssh.NewSignerFromKey(key)
+	require.NoError(t, err)
+	return signer
+}
+
+// startServer starts a gateway on a local port, and returns its address and a
+// key authorized for gohdfs1.
+func startServer(t *testing.T, client func(string) (*hdfs.Client, error)) (string, ssh.Signer) {
+	userKey := generateKey(t)
+	s, err := New(Options{
+		Root:           testRoot,
+		HostKeys:       []ssh.Signer{generateKey(t)},
+		AuthorizedKeys: []AuthorizedKey{{"gohdfs1", userKey.PublicKey()}},
+		Client:         client,
+	})
+	require.NoError(t, err)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	go s.Serve(l)
+	t.Cleanup(func() { s.Close() })
+	return l.Addr().String(), userKey
+}
+
+func dial(addr, user string, key ssh.Signer) (*ssh.Client, error) {
+	return ssh.Dial("tcp", addr, &ssh.ClientConfig{
+		User:            user,
+		Auth:            []ssh.AuthMethod{ssh.PublicKeys(key)},
+		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+	})
+}
+
+// testClient is a minimal SFTP client, which sends one request at a time.
+type testClient struct {
+	t       *testing.T
+	session *ssh.Session
+	w       io.Writer
+	r       io.Reader
+	id      uint32
+}
+
+func newTestClient(t *testing.T, conn *ssh.Client) *testClient {
+	session, err := conn.NewSession()
+	require.NoError(t, err)
+
+	w, err := session.StdinPipe()
+	require.NoError(t, err)
+	r, err := session.StdoutPipe()
+	require.NoError(t, err)
+	require.NoError(t, session.RequestSubsystem("sftp"))
+
+	c := &testClient{t: t, session: session, w: w, r: r}
+	init := &encoder{b: make([]byte, 4)}
+	init.b = append(init.b, fxpInit)
+	init.uint32(sftpProtocolVersion)
+	_, err = w.Write(init.bytes())
+	require.NoError(t, err)
+
+	packetType, payload, err := readPacket(r)
+	require.NoError(t, err)
+	require.Equal(t, byte(fxpVersion), packetType)
+
+	d := decoder{b: payload}
+	assert.EqualValues(t, sftpProtocolVersion, d.uint32())
+	assert.Equal(t, posixRenameExtension, d.string())
+	return c
+}
+
+// call sends a request, with the fields added by build, and returns the type
+// and the rest of the response after the ID.
+func (c *testClient) call(packetType byte, build func(e *encoder)) (byte, *decoder) {
+	c.id++
+	e := newPacket(packetType, c.id)
+	if build != nil {
+		build(e)
+	}
+
+	_, err := c.w.Write(e.bytes())
+	require.NoError(c.t, err)
+
+	respType, payload, err := readPacket(c.r)
+	require.NoError(c.t, err)
+
+	d := &decoder{b: payload}
+	require.Equal(c.t, c.id, d.uint32())
+	return respType, d
+}
+
+// status makes a request that's expected to return a status, and returns the
+// code.
+func (c *testClient) status(packetType byte, build func(e *encoder)) uint32 {
+	respType, d := c.call(packetType, build)
+	require.Equal(c.t, byte(fxpStatus), respType)
+	return d.uint32()
+}
+
+func (c *testClient) open(name string, pflags uint32) string {
+	respType, d := c.call(fxpOpen, func(e *encoder) {
+		e.string(name)
+		e.uint32(pflags)
+		e.attrs(fileAttrs{})
+	})
+	require.Equal(c.t, byte(fxpHandle), respType, "opening %s", name)
+	return d.string()
+}
+
+func (c *testClient) write(h string, offset uint64, data string) uint32 {
+	return c.status(fxpWrite, func(e *encoder) {
+		e.string(h)
+		e.uint64(offset)
+		e.string(data)
+	})
+}
+
+func (c *testClient) read(h string, offset uint64, length uint32) (string, uint32) {
+	respType, d := c.call(fxpRead, func(e *encoder) {
+		e.string(h)
+		e.uint64(offset)
+		e.uint32(length)
+	})
+	if respType == fxpStatus {
+		return "", d.uint32()
+	}
+
+	require.Equal(c.t, byte(fxpData), respType)
+	return d.string(), fxOK
+}
+
+func (c *testClient) close(h string) uint32 {
+	return c.status(fxpClose, func(e *encoder) { e.string(h) })
+}
+
+func pathRequest(name string) func(e *encoder) {
+	return func(e *encoder) { e.string(name) }
+}
+
+func setupGateway(t *testing.T) (*testClient, *hdfs.Client) {
+	client := getClient(t)
+
+	err := client.RemoveAll(testRoot)
+	if err != nil && !os.IsNotExist(err) {
+		t.Fatal(err)
+	}
+
+	require.NoError(t, client.MkdirAll(testRoot, 0755))
+
+	addr, key := startServer(t, func(string) (*hdfs.Client, error) { return client, nil })
+	conn, err := dial(addr, "gohdfs1", key)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return newTestClient(t, conn), client
+}
+
+func TestEncodeDecode(t *testing.T) {
+	e := newPacket(fxpOpen, 7)
+	e.string("/foo")
+	e.uint32(fxfRead)
+	e.attrs(fileAttrs{flags: attrSize | attrPermissions, size: 1 << 40, permissions: 0644})
+
+	b := e.bytes()
+	p, payload, err := readPacket(bytes.NewReader(b))
+	require.NoError(t, err)
+	assert.Equal(t, byte(fxpOpen), p)
+
+	d := decoder{b: payload}
+	assert.EqualValues(t, 7, d.uint32())
+	assert.Equal(t, "/foo", d.string())
+	assert.EqualValues(t, fxfRead, d.uint32())
+
+	attrs := d.attrs()
+	require.NoError(t, d.err)
+	assert.EqualValues(t, 1<<40, attrs.size)
+	assert.EqualValues(t, 0644, attrs.permissions)
+	assert.Empty(t, d.b)
+
+	d.uint32()
+	assert.Equal(t, errBadMessage, d.err)
+
+	d = decoder{b: []byte{0, 0, 0, 10, 'a'}}
+	d.string()
+	assert.Equal(t, errBadMessage, d.err)
+}
+
+func TestResolve(t *testing.T) {
+	s := &session{root: "/foo"}
+	assert.Equal(t, "/foo", s.resolve(""))
+	assert.Equal(t, "/foo", s.resolve("."))
+	assert.Equal(t, "/foo", s.resolve("/"))
+	assert.Equal(t, "/foo", s.resolve("../.."))
+	assert.Equal(t, "/foo/bar", s.resolve("bar"))
+	assert.Equal(t, "/foo/bar", s.resolve("/../bar"))
+	assert.Equal(t, "/foo/bar/baz", s.resolve("bar//baz/"))
+}
+
+func TestAuthentication(t *testing.T) {
+	clientErr := errors.New("no client")
+	addr, key := startServer(t, func(string) (*hdfs.Client, error) { return nil, clientErr })
+
+	_, err := dial(addr, "gohdfs1", generateKey(t))
+	assert.Error(t, err)
+
+	_, err = dial(addr, "gohdfs2", key)
+	assert.Error(t, err)
+
+	conn, err := dial(addr, "gohdfs1", key)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	// Shells aren't allowed.
+	session, err := conn.NewSession()
+	require.NoError(t, err)
+	assert.Error(t, session.Shell())
+	session.Close()
+
+	// The session for the subsystem is closed straight away, since there's no
+	// client.
+	session, err = conn.NewSession()
+	require.NoError(t, err)
+	stdout, err := session.StdoutPipe()
+	require.NoError(t, err)
+	require.NoError(t, session.RequestSubsystem("sftp"))
+
+	b, err := ioutil.ReadAll(stdout)
+	assert.NoError(t, err)
+	assert.Empty(t, b)
+}
+
+func TestWriteReadFile(t *testing.T) {
+	c, client := setupGateway(t)
+
+	h := c.open("/foo.txt", fxfWrite|fxfCreat|fxfTrunc)
+	assert.EqualValues(t, fxOK, c.write(h, 0, "foo"))
+	assert.EqualValues(t, fxOK, c.write(h, 3, "bar"))
+	assert.EqualValues(t, fxFailure, c.write(h, 0, "baz"))
+	assert.EqualValues(t, fxOK, c.close(h))
+
+	b, err := client.ReadFile(testRoot + "/foo.txt")
+	require.NoError(t, err)
+	assert.Equal(t, "foobar", string(b))
+
+	// Without the truncate flag, writes continue from the end.
+	h = c.open("foo.txt", fxfWrite|fxfCreat)
+	assert.EqualValues(t, fxFailure, c.write(h, 0, "baz"))
+	assert.EqualValues(t, fxOK, c.write(h, 6, "baz"))
+	assert.EqualValues(t, fxOK, c.close(h))
+
+	h = c.open("/foo.txt", fxfRead)
+	data, status := c.read(h, 3, 100)
+	assert.EqualValues(t, fxOK, status)
+	assert.Equal(t, "barbaz", data)
+
+	_, status = c.read(h, 9, 100)
+	assert.EqualValues(t, fxEOF, status)
+	assert.EqualValues(t, fxOK, c.close(h))
+	assert.EqualValues(t, fxFailure, c.close(h))
+
+	respType, d := c.call(fxpOpen, func(e *encoder) {
+		e.string("/foo.txt")
+		e.uint32(fxfWrite | fxfCreat | fxfExcl)
+		e.attrs(fileAttrs{})
+	})
+	assert.Equal(t, byte(fxpStatus), respType)
+	assert.EqualValues(t, fxFailure, d.uint32())
+
+	respType, d = c.call(fxpOpen, func(e *encoder) {
+		e.string("/nonexistent")
+		e.uint32(fxfRead)
+		e.attrs(fileAttrs{})
+	})
+	assert.Equal(t, byte(fxpStatus), respType)
+	assert.EqualValues(t, fxNoSuchFile, d.uint32())
+}
+
+func TestStatAndSetstat(t *testing.T) {
+	c, client := setupGateway(t)
+
+	h := c.open("/foo.txt", fxfWrite|fxfCreat|fxfTrunc)
+	c.write(h, 0, "foo")
+	assert.EqualValues(t, fxOK, c.status(fxpFsetstat, func(e *encoder) {
+		e.string(h)
+		e.attrs(fileAttrs{flags: attrPermissions | attrACModTime, permissions: 0600, atime: 1000000000, mtime: 1000000000})
+	}))
+	assert.EqualValues(t, fxOK, c.close(h))
+
+	respType, d := c.call(fxpStat, pathRequest("/foo.txt"))
+	require.Equal(t, byte(fxpAttrs), respType)
+	attrs := d.attrs()
+	assert.EqualValues(t, 3, attrs.size)
+	assert.EqualValues(t, modeRegular|0600, attrs.permissions)
+	assert.EqualValues(t, 1000000000, attrs.mtime)
+
+	info, err := client.Stat(testRoot + "/foo.txt")
+	require.NoError(t, err)
+	assert.EqualValues(t, 0600, info.Mode().Perm())
+
+	respType, d = c.call(fxpRealpath, pathRequest("../foo/."))
+	require.Equal(t, byte(fxpName), respType)
+	assert.EqualValues(t, 1, d.uint32())
+	assert.Equal(t, "/foo", d.string())
+
+	assert.EqualValues(t, fxOpUnsupported, c.status(fxpSymlink, func(e *encoder) {
+		e.string("/foo.txt")
+		e.string("/bar.txt")
+	}))
+}
+
+func TestDirectories(t *testing.T) {
+	c, client := setupGateway(t)
+
+	assert.EqualValues(t, fxOK, c.status(fxpMkdir, func(e *encoder) {
+		e.string("/dir")
+		e.attrs(fileAttrs{})
+	}))
+
+	for i := 0; i < readdirBatchSize+5; i++ {
+		h := c.open(fmt.Sprintf("/dir/%03d", i), fxfWrite|fxfCreat)
+		c.close(h)
+	}
+
+	respType, d := c.call(fxpOpendir, pathRequest("/dir"))
+	require.Equal(t, byte(fxpHandle), respType)
+	h := d.string()
+
+	var names []string
+	for {
+		respType, d := c.call(fxpReaddir, pathRequest(h))
+		if respType == fxpStatus {
+			assert.EqualValues(t, fxEOF, d.uint32())
+			break
+		}
+
+		require.Equal(t, byte(fxpName), respType)
+		n := d.uint32()
+		for i := uint32(0); i < n; i++ {
+			names = append(names, d.string())
+			d.string()
+			d.attrs()
+		}
+	}
+
+	assert.Len(t, names, readdirBatchSize+5)
+	assert.Equal(t, "000", names[0])
+	c.close(h)
+
+	assert.EqualValues(t, fxFailure, c.status(fxpRmdir, pathRequest("/dir")))
+	assert.EqualValues(t, fxFailure, c.status(fxpRemove, pathRequest("/dir")))
+	assert.EqualValues(t, fxOK, c.status(fxpRemove, pathRequest("/dir/000")))
+
+	// Renames don't overwrite, unless they're posix renames.
+	rename := func(packetType byte, extension string) uint32 {
+		return c.status(packetType, func(e *encoder) {
+			if extension != "" {
+				e.string(extension)
+			}
+
+			e.string("/dir/001")
+			e.string("/dir/002")
+		})
+	}
+
+	assert.EqualValues(t, fxFailure, rename(fxpRename, ""))
+	assert.EqualValues(t, fxOK, rename(fxpExtended, posixRenameExtension))
+
+	_, err := client.Stat(testRoot + "/dir/001")
+	assert.True(t, os.IsNotExist(err))
+	assert.EqualValues(t, fxOpUnsupported, rename(fxpExtended, "frobnicate@example.com"))
+}
@@ -0,0 +1,16 @@
+package hdfs
+
+import "github.com/colinmarc/hdfs/v2/internal/rpc"
+
+// newShortCircuit returns the ShortCircuit used by the client's readers, or
+// nil if short-circuit reads aren't enabled.
+func newShortCircuit(options ClientOptions, clientName string) *rpc.ShortCircuit {
+	if !options.ShortCircuitReads || options.DomainSocketPath == "" {
+		return nil
+	}
+
+	return &rpc.ShortCircuit{
+		SocketPath: options.DomainSocketPath,
+		ClientName: clientName,
+	}
+}
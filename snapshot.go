@@ -0,0 +1,193 @@
+package hdfs
+
+import (
+	"os"
+
+	hdfs "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_hdfs"
+	"github.com/golang/protobuf/proto"
+)
+
+// SnapshotDiffType is the kind of change described by a SnapshotDiffEntry.
+type SnapshotDiffType int
+
+const (
+	// SnapshotDiffCreate means the file or directory was created. Its contents,
+	// if it's a directory, aren't listed separately.
+	SnapshotDiffCreate SnapshotDiffType = iota
+	// SnapshotDiffDelete means the file or directory was deleted.
+	SnapshotDiffDelete
+	// SnapshotDiffModify means the contents or attributes of the file or
+	// directory changed. For a directory, that includes its list of children.
+	SnapshotDiffModify
+	// SnapshotDiffRename means the file or directory was renamed (or moved) to
+	// the entry's Target.
+	SnapshotDiffRename
+)
+
+// String returns the label the namenode uses for the type of change, for
+// example "+" for SnapshotDiffCreate.
+func (t SnapshotDiffType) String() string {
+	switch t {
+	case SnapshotDiffCreate:
+		return "+"
+	case SnapshotDiffDelete:
+		return "-"
+	case SnapshotDiffModify:
+		return "M"
+	case SnapshotDiffRename:
+		return "R"
+	default:
+		return "?"
+	}
+}
+
+// SnapshotDiffEntry is a single change between two snapshots.
+type SnapshotDiffEntry struct {
+	Type SnapshotDiffType
+	// Path is the path of the file or directory, relative to the snapshot
+	// root. It's empty for the root itself.
+	Path string
+	// Target is the new path of a renamed file or directory, relative to the
+	// snapshot root.
+	Target string
+}
+
+// SnapshotDiffReport describes the changes to a snapshottable directory
+// between two of its snapshots, as returned by GetSnapshotDiffReport.
+type SnapshotDiffReport struct {
+	// Root is the snapshottable directory.
+	Root string
+	// From and To are the names of the snapshots compared. An empty name
+	// refers to the current state of the directory.
+	From, To string
+	Entries  []SnapshotDiffEntry
+}
+
+// AllowSnapshot allows snapshots to be taken of the named directory. It
+// requires superuser privileges.
+func (c *Client) AllowSnapshot(dir string) error {
+	req := &hdfs.AllowSnapshotRequestProto{SnapshotRoot: proto.String(dir)}
+	resp := &hdfs.AllowSnapshotResponseProto{}
+
+	err := c.namenode.Execute("allowSnapshot", req, resp)
+	if err != nil {
+		return &os.PathError{"allowsnapshot", dir, interpretException(err)}
+	}
+
+	return nil
+}
+
+// DisallowSnapshot stops snapshots from being taken of the named directory.
+// Any existing snapshots must be deleted first. It requires superuser
+// privileges.
+func (c *Client) DisallowSnapshot(dir string) error {
+	req := &hdfs.DisallowSnapshotRequestProto{SnapshotRoot: proto.String(dir)}
+	resp := &hdfs.DisallowSnapshotResponseProto{}
+
+	err := c.namenode.Execute("disallowSnapshot", req, resp)
+	if err != nil {
+		return &os.PathError{"disallowsnapshot", dir, interpretException(err)}
+	}
+
+	return nil
+}
+
+// CreateSnapshot takes a snapshot of the named directory, which must be
+// snapshottable, and returns the path of the snapshot, for example
+// "/foo/.snapshot/name". If name is empty, the namenode picks one based on
+// the current time.
+func (c *Client) CreateSnapshot(dir, name string) (string, error) {
+	req := &hdfs.CreateSnapshotRequestProto{SnapshotRoot: proto.String(dir)}
+	if name != "" {
+		req.SnapshotName = proto.String(name)
+	}
+	resp := &hdfs.CreateSnapshotResponseProto{}
+
+	err := c.namenode.Execute("createSnapshot", req, resp)
+	if err != nil {
+		return "", &os.PathError{"createsnapshot", dir, interpretException(err)}
+	}
+
+	return resp.GetSnapshotPath(), nil
+}
+
+// DeleteSnapshot deletes the named snapshot of dir.
+func (c *Client) DeleteSnapshot(dir, name string) error {
+	req := &hdfs.DeleteSnapshotRequestProto{
+		SnapshotRoot: proto.String(dir),
+		SnapshotName: proto.String(name),
+	}
+	resp := &hdfs.DeleteSnapshotResponseProto{}
+
+	err := c.namenode.Execute("deleteSnapshot", req, resp)
+	if err != nil {
+		return &os.PathError{"deletesnapshot", dir, interpretException(err)}
+	}
+
+	return nil
+}
+
+// RenameSnapshot renames the snapshot of dir named oldName to newName.
+func (c *Client) RenameSnapshot(dir, oldName, newName string) error {
+	req := &hdfs.RenameSnapshotRequestProto{
+		SnapshotRoot:    proto.String(dir),
+		SnapshotOldName: proto.String(oldName),
+		SnapshotNewName: proto.String(newName),
+	}
+	resp := &hdfs.RenameSnapshotResponseProto{}
+
+	err := c.namenode.Execute("renameSnapshot", req, resp)
+	if err != nil {
+		return &os.PathError{"renamesnapshot", dir, interpretException(err)}
+	}
+
+	return nil
+}
+
+// GetSnapshotDiffReport returns the changes made to dir between the snapshots
+// named from and to. Either can be empty, to refer to the current state of the
+// directory.
+func (c *Client) GetSnapshotDiffReport(dir, from, to string) (*SnapshotDiffReport, error) {
+	req := &hdfs.GetSnapshotDiffReportRequestProto{
+		SnapshotRoot: proto.String(dir),
+		FromSnapshot: proto.String(from),
+		ToSnapshot:   proto.String(to),
+	}
+	resp := &hdfs.GetSnapshotDiffReportResponseProto{}
+
+	err := c.namenode.Execute("getSnapshotDiffReport", req, resp)
+	if err != nil {
+		return nil, &os.PathError{"getsnapshotdiffreport", dir, interpretException(err)}
+	}
+
+	diff := resp.GetDiffReport()
+	report := &SnapshotDiffReport{
+		Root: diff.GetSnapshotRoot(),
+		From: diff.GetFromSnapshot(),
+		To:   diff.GetToSnapshot(),
+	}
+
+	for _, entry := range diff.GetDiffReportEntries() {
+		var t SnapshotDiffType
+		switch entry.GetModificationLabel() {
+		case "+":
+			t = SnapshotDiffCreate
+		case "-":
+			t = SnapshotDiffDelete
+		case "M":
+			t = SnapshotDiffModify
+		case "R":
+			t = SnapshotDiffRename
+		default:
+			continue
+		}
+
+		report.Entries = append(report.Entries, SnapshotDiffEntry{
+			Type:   t,
+			Path:   string(entry.GetFullpath()),
+			Target: string(entry.GetTargetPath()),
+		})
+	}
+
+	return report, nil
+}
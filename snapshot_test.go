@@ -0,0 +1,90 @@
+package hdfs
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshotDiffReport(t *testing.T) {
+	client := getClientForSuperUser(t)
+
+	if _, err := client.Stat("/_test/snapshots/.snapshot/s1"); err == nil {
+		require.NoError(t, client.DeleteSnapshot("/_test/snapshots", "s1"))
+	}
+
+	baleet(t, "/_test/snapshots")
+	mkdirp(t, "/_test/snapshots/dir")
+	touch(t, "/_test/snapshots/dir/foo")
+	touch(t, "/_test/snapshots/bar")
+	require.NoError(t, client.AllowSnapshot("/_test/snapshots"))
+
+	snapshot, err := client.CreateSnapshot("/_test/snapshots", "s1")
+	require.NoError(t, err)
+	assert.Equal(t, "/_test/snapshots/.snapshot/s1", snapshot)
+	defer client.DeleteSnapshot("/_test/snapshots", "s1")
+
+	touch(t, "/_test/snapshots/baz")
+	require.NoError(t, client.Remove("/_test/snapshots/bar"))
+	require.NoError(t, client.Rename("/_test/snapshots/dir/foo", "/_test/snapshots/dir/qux"))
+
+	report, err := client.GetSnapshotDiffReport("/_test/snapshots", "s1", "")
+	require.NoError(t, err)
+	assert.Equal(t, "s1", report.From)
+	assert.Equal(t, "", report.To)
+
+	entries := make(map[string]SnapshotDiffEntry)
+	for _, entry := range report.Entries {
+		entries[entry.Path] = entry
+	}
+
+	assert.Equal(t, SnapshotDiffCreate, entries["baz"].Type)
+	assert.Equal(t, SnapshotDiffDelete, entries["bar"].Type)
+	assert.Equal(t, SnapshotDiffRename, entries["dir/foo"].Type)
+	assert.Equal(t, "dir/qux", entries["dir/foo"].Target)
+
+	require.NoError(t, client.DeleteSnapshot("/_test/snapshots", "s1"))
+	_, err = client.Stat("/_test/snapshots/.snapshot/s1")
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestCreateSnapshotNotSnapshottable(t *testing.T) {
+	client := getClient(t)
+
+	mkdirp(t, "/_test/notsnapshottable")
+	_, err := client.CreateSnapshot("/_test/notsnapshottable", "s1")
+	assert.Error(t, err)
+}
+
+func TestRenameAndDisallowSnapshot(t *testing.T) {
+	client := getClientForSuperUser(t)
+
+	for _, name := range []string{"s1", "s2"} {
+		if _, err := client.Stat("/_test/snapshotrename/.snapshot/" + name); err == nil {
+			require.NoError(t, client.DeleteSnapshot("/_test/snapshotrename", name))
+		}
+	}
+
+	baleet(t, "/_test/snapshotrename")
+	mkdirp(t, "/_test/snapshotrename")
+	require.NoError(t, client.AllowSnapshot("/_test/snapshotrename"))
+
+	_, err := client.CreateSnapshot("/_test/snapshotrename", "s1")
+	require.NoError(t, err)
+
+	require.NoError(t, client.RenameSnapshot("/_test/snapshotrename", "s1", "s2"))
+	_, err = client.Stat("/_test/snapshotrename/.snapshot/s1")
+	assert.True(t, os.IsNotExist(err))
+	_, err = client.Stat("/_test/snapshotrename/.snapshot/s2")
+	require.NoError(t, err)
+
+	// The directory can't be made unsnapshottable while it has snapshots.
+	assert.Error(t, client.DisallowSnapshot("/_test/snapshotrename"))
+	require.NoError(t, client.DeleteSnapshot("/_test/snapshotrename", "s2"))
+	require.NoError(t, client.DisallowSnapshot("/_test/snapshotrename"))
+
+	_, err = client.CreateSnapshot("/_test/snapshotrename", "s3")
+	assert.Error(t, err)
+}
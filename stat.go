@@ -1,6 +1,7 @@
 package hdfs
 
 import (
+	"context"
 	"os"
 	"path"
 	"time"
@@ -14,11 +15,29 @@ import (
 type FileInfo struct {
 	name   string
 	status *hdfs.HdfsFileStatusProto
+	xattrs map[string]string
 }
 
 // Stat returns an os.FileInfo describing the named file or directory.
 func (c *Client) Stat(name string) (os.FileInfo, error) {
-	fi, err := c.getFileInfo(name)
+	return c.StatContext(context.Background(), name)
+}
+
+// StatContext is like Stat, but gives up once ctx is done.
+func (c *Client) StatContext(ctx context.Context, name string) (os.FileInfo, error) {
+	fi, err := c.getFileInfoContext(ctx, name)
+	if err != nil {
+		var resolved string
+		resolved, err = c.followSymlinks(ctx, name, err)
+		if err == nil {
+			fi, err = c.getFileInfoContext(ctx, resolved)
+		}
+
+		if err == nil {
+			fi.(*FileInfo).name = path.Base(name)
+		}
+	}
+
 	if err != nil {
 		err = &os.PathError{"stat", name, interpretException(err)}
 	}
@@ -27,10 +46,25 @@ func (c *Client) Stat(name string) (os.FileInfo, error) {
 }
 
 func (c *Client) getFileInfo(name string) (os.FileInfo, error) {
+	return c.getFileInfoContext(context.Background(), name)
+}
+
+func (c *Client) getFileInfoContext(ctx context.Context, name string) (os.FileInfo, error) {
+	// The namenode reports a missing file with an empty response, rather than
+	// an exception, but WebHDFS returns a FileNotFoundException.
+	if c.web != nil {
+		status, err := c.web.getFileStatus(ctx, name)
+		if err != nil {
+			return nil, interpretException(err)
+		}
+
+		return newFileInfo(status, name), nil
+	}
+
 	req := &hdfs.GetFileInfoRequestProto{Src: proto.String(name)}
 	resp := &hdfs.GetFileInfoResponseProto{}
 
-	err := c.namenode.Execute("getFileInfo", req, resp)
+	err := c.namenode.ExecuteContext(ctx, "getFileInfo", req, resp)
 	if err != nil {
 		return nil, err
 	}
@@ -68,6 +102,8 @@ func (fi *FileInfo) Mode() os.FileMode {
 	mode := os.FileMode(fi.status.GetPermission().GetPerm())
 	if fi.IsDir() {
 		mode |= os.ModeDir
+	} else if fi.status.GetFileType() == hdfs.HdfsFileStatusProto_IS_SYMLINK {
+		mode |= os.ModeSymlink
 	}
 
 	return mode
@@ -104,3 +140,60 @@ func (fi *FileInfo) OwnerGroup() string {
 func (fi *FileInfo) AccessTime() time.Time {
 	return time.Unix(int64(fi.status.GetAccessTime())/1000, 0)
 }
+
+// BlockSize returns the block size of the file, or zero for a directory. It's
+// not part of the os.FileInfo interface.
+func (fi *FileInfo) BlockSize() int64 {
+	return int64(fi.status.GetBlocksize())
+}
+
+// StoragePolicyID returns the ID of the storage policy set on the file, or
+// zero if it doesn't have one of its own, in which case it inherits the policy
+// of its parent directory. It's not part of the os.FileInfo interface.
+func (fi *FileInfo) StoragePolicyID() int {
+	return int(fi.status.GetStoragePolicy())
+}
+
+// Replication returns the replication factor of the file, or zero for a
+// directory. It's not part of the os.FileInfo interface.
+func (fi *FileInfo) Replication() int {
+	return int(fi.status.GetBlockReplication())
+}
+
+// FileID returns the inode ID the namenode assigned to the file or directory.
+// It's not part of the os.FileInfo interface.
+func (fi *FileInfo) FileID() uint64 {
+	return fi.status.GetFileId()
+}
+
+// ChildrenNum returns the number of entries in a directory, or -1 if the
+// namenode didn't include it. It's not part of the os.FileInfo interface.
+func (fi *FileInfo) ChildrenNum() int {
+	return int(fi.status.GetChildrenNum())
+}
+
+// SymlinkTarget returns the target of a symlink, or an empty string if the
+// file isn't one. It's not part of the os.FileInfo interface.
+func (fi *FileInfo) SymlinkTarget() string {
+	return string(fi.status.GetSymlink())
+}
+
+// Encrypted returns true if the file is in an encryption zone, and its
+// contents are encrypted. It's not part of the os.FileInfo interface.
+func (fi *FileInfo) Encrypted() bool {
+	return fi.status.GetFileEncryptionInfo() != nil
+}
+
+// XAttrs returns the extended attributes of the file, keyed by their full
+// names, if it was returned by Client.StatXAttrs. Otherwise, it returns nil.
+// It's not part of the os.FileInfo interface.
+func (fi *FileInfo) XAttrs() map[string]string {
+	return fi.xattrs
+}
+
+// ErasureCodingPolicy returns the name of the erasure coding policy of the
+// file, for example "RS-6-3-1024k", or an empty string if it's replicated.
+// It's not part of the os.FileInfo interface.
+func (fi *FileInfo) ErasureCodingPolicy() string {
+	return fi.status.GetEcPolicy().GetName()
+}
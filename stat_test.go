@@ -22,6 +22,48 @@ func TestStat(t *testing.T) {
 	assert.EqualValues(t, time.Now().Month(), resp.ModTime().Month())
 }
 
+func TestStatBlockSizeAndReplication(t *testing.T) {
+	client := getClient(t)
+
+	baleet(t, "/_test/blocksize.txt")
+	writer, err := client.CreateFile("/_test/blocksize.txt", 1, 1048576, 0644)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	resp, err := client.Stat("/_test/blocksize.txt")
+	require.NoError(t, err)
+
+	fi := resp.(*FileInfo)
+	assert.EqualValues(t, 1048576, fi.BlockSize())
+	assert.EqualValues(t, 1, fi.Replication())
+}
+
+func TestStatExtendedFields(t *testing.T) {
+	client := getClient(t)
+
+	baleet(t, "/_test/extended")
+	mkdirp(t, "/_test/extended")
+	touch(t, "/_test/extended/foo")
+	touch(t, "/_test/extended/bar")
+
+	resp, err := client.Stat("/_test/extended")
+	require.NoError(t, err)
+
+	dir := resp.(*FileInfo)
+	assert.NotZero(t, dir.FileID())
+	assert.Equal(t, 2, dir.ChildrenNum())
+
+	resp, err = client.Stat("/_test/extended/foo")
+	require.NoError(t, err)
+
+	fi := resp.(*FileInfo)
+	assert.NotZero(t, fi.FileID())
+	assert.NotEqual(t, dir.FileID(), fi.FileID())
+	assert.Equal(t, "", fi.SymlinkTarget())
+	assert.False(t, fi.Encrypted())
+	assert.Equal(t, "", fi.ErasureCodingPolicy())
+}
+
 func TestStatEmptyFile(t *testing.T) {
 	client := getClient(t)
 
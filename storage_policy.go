@@ -0,0 +1,124 @@
+package hdfs
+
+import (
+	"os"
+
+	hdfs "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_hdfs"
+	"github.com/golang/protobuf/proto"
+)
+
+// StoragePolicy describes a block storage policy, which determines the types
+// of storage (for example, "DISK" or "ARCHIVE") that the replicas of a file's
+// blocks are placed on.
+type StoragePolicy struct {
+	ID   int
+	Name string
+	// StorageTypes lists the storage type for each replica of a new block.
+	StorageTypes []string
+	// CreationFallbacks lists the storage types to use when creating a block if
+	// the preferred types are unavailable.
+	CreationFallbacks []string
+	// ReplicationFallbacks lists the storage types to use when re-replicating
+	// a block if the preferred types are unavailable.
+	ReplicationFallbacks []string
+}
+
+func newStoragePolicy(p *hdfs.BlockStoragePolicyProto) StoragePolicy {
+	return StoragePolicy{
+		ID:                   int(p.GetPolicyId()),
+		Name:                 p.GetName(),
+		StorageTypes:         storageTypeNames(p.GetCreationPolicy()),
+		CreationFallbacks:    storageTypeNames(p.GetCreationFallbackPolicy()),
+		ReplicationFallbacks: storageTypeNames(p.GetReplicationFallbackPolicy()),
+	}
+}
+
+func storageTypeNames(types *hdfs.StorageTypesProto) []string {
+	var names []string
+	for _, t := range types.GetStorageTypes() {
+		names = append(names, t.String())
+	}
+
+	return names
+}
+
+// GetStoragePolicies returns all the storage policies configured on the
+// cluster.
+func (c *Client) GetStoragePolicies() ([]StoragePolicy, error) {
+	req := &hdfs.GetStoragePoliciesRequestProto{}
+	resp := &hdfs.GetStoragePoliciesResponseProto{}
+
+	err := c.namenode.Execute("getStoragePolicies", req, resp)
+	if err != nil {
+		return nil, interpretException(err)
+	}
+
+	policies := make([]StoragePolicy, 0, len(resp.GetPolicies()))
+	for _, p := range resp.GetPolicies() {
+		policies = append(policies, newStoragePolicy(p))
+	}
+
+	return policies, nil
+}
+
+// GetStoragePolicy returns the storage policy that applies to the named file
+// or directory, which may be inherited from one of its parents.
+func (c *Client) GetStoragePolicy(name string) (StoragePolicy, error) {
+	req := &hdfs.GetStoragePolicyRequestProto{Path: proto.String(name)}
+	resp := &hdfs.GetStoragePolicyResponseProto{}
+
+	err := c.namenode.Execute("getStoragePolicy", req, resp)
+	if err != nil {
+		return StoragePolicy{}, &os.PathError{"getstoragepolicy", name, interpretException(err)}
+	}
+
+	return newStoragePolicy(resp.GetStoragePolicy()), nil
+}
+
+// SetStoragePolicy sets the storage policy for the named file or directory.
+// Existing blocks aren't moved to match the new policy until the mover or the
+// storage policy satisfier runs; see SatisfyStoragePolicy.
+func (c *Client) SetStoragePolicy(name string, policy string) error {
+	req := &hdfs.SetStoragePolicyRequestProto{
+		Src:        proto.String(name),
+		PolicyName: proto.String(policy),
+	}
+	resp := &hdfs.SetStoragePolicyResponseProto{}
+
+	err := c.namenode.Execute("setStoragePolicy", req, resp)
+	if err != nil {
+		return &os.PathError{"setstoragepolicy", name, interpretException(err)}
+	}
+
+	return nil
+}
+
+// UnsetStoragePolicy removes the storage policy from the named file or
+// directory, so that it inherits the policy of its parent.
+func (c *Client) UnsetStoragePolicy(name string) error {
+	req := &hdfs.UnsetStoragePolicyRequestProto{Src: proto.String(name)}
+	resp := &hdfs.UnsetStoragePolicyResponseProto{}
+
+	err := c.namenode.Execute("unsetStoragePolicy", req, resp)
+	if err != nil {
+		return &os.PathError{"unsetstoragepolicy", name, interpretException(err)}
+	}
+
+	return nil
+}
+
+// SatisfyStoragePolicy asks the namenode to move the blocks of the named file
+// or directory to match its storage policy, using the storage policy
+// satisfier. This requires Hadoop 3.2 or later, with the satisfier enabled on
+// the cluster.
+func (c *Client) SatisfyStoragePolicy(name string) error {
+	req := &hdfs.SatisfyStoragePolicyRequestProto{Src: proto.String(name)}
+	resp := &hdfs.SatisfyStoragePolicyResponseProto{}
+
+	err := c.namenode.Execute("satisfyStoragePolicy", req, resp)
+	if err != nil {
+		return &os.PathError{"satisfystoragepolicy", name, interpretException(err)}
+	}
+
+	return nil
+}
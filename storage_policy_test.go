@@ -0,0 +1,76 @@
+package hdfs
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetStoragePolicies(t *testing.T) {
+	client := getClient(t)
+
+	policies, err := client.GetStoragePolicies()
+	require.NoError(t, err)
+
+	var names []string
+	for _, p := range policies {
+		names = append(names, p.Name)
+	}
+
+	assert.Contains(t, names, "HOT")
+	assert.Contains(t, names, "COLD")
+}
+
+func TestSetStoragePolicy(t *testing.T) {
+	client := getClient(t)
+
+	baleet(t, "/_test/storagepolicy")
+	mkdirp(t, "/_test/storagepolicy")
+
+	err := client.SetStoragePolicy("/_test/storagepolicy", "COLD")
+	require.NoError(t, err)
+
+	policy, err := client.GetStoragePolicy("/_test/storagepolicy")
+	require.NoError(t, err)
+	assert.Equal(t, "COLD", policy.Name)
+	assert.Equal(t, []string{"ARCHIVE"}, policy.StorageTypes)
+
+	err = client.UnsetStoragePolicy("/_test/storagepolicy")
+	require.NoError(t, err)
+
+	policy, err = client.GetStoragePolicy("/_test/storagepolicy")
+	require.NoError(t, err)
+	assert.Equal(t, "HOT", policy.Name)
+}
+
+func TestGetStoragePolicyNonexistent(t *testing.T) {
+	client := getClient(t)
+
+	baleet(t, "/_test/nonexistent")
+
+	_, err := client.GetStoragePolicy("/_test/nonexistent")
+	assertPathError(t, err, "getstoragepolicy", "/_test/nonexistent", os.ErrNotExist)
+}
+
+func TestStatStoragePolicyID(t *testing.T) {
+	client := getClient(t)
+
+	baleet(t, "/_test/storagepolicy")
+	mkdirp(t, "/_test/storagepolicy")
+
+	fi, err := client.Stat("/_test/storagepolicy")
+	require.NoError(t, err)
+	assert.Equal(t, 0, fi.(*FileInfo).StoragePolicyID())
+
+	err = client.SetStoragePolicy("/_test/storagepolicy", "COLD")
+	require.NoError(t, err)
+
+	policy, err := client.GetStoragePolicy("/_test/storagepolicy")
+	require.NoError(t, err)
+
+	fi, err = client.Stat("/_test/storagepolicy")
+	require.NoError(t, err)
+	assert.Equal(t, policy.ID, fi.(*FileInfo).StoragePolicyID())
+}
@@ -0,0 +1,81 @@
+package hdfs
+
+import (
+	"context"
+	"os"
+
+	hdfs "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_hdfs"
+	"github.com/golang/protobuf/proto"
+)
+
+// CreateSymlink creates link as a symbolic link to target. If createParent is
+// true, any missing parent directories of link are created, as with MkdirAll.
+// The target doesn't have to exist.
+//
+// Symlinks are disabled by default in Hadoop 2 and later, in which case the
+// namenode returns an error.
+func (c *Client) CreateSymlink(target, link string, createParent bool) error {
+	req := &hdfs.CreateSymlinkRequestProto{
+		Target:       proto.String(target),
+		Link:         proto.String(link),
+		DirPerm:      &hdfs.FsPermissionProto{Perm: proto.Uint32(uint32(0777 &^ c.options.Umask.Perm()))},
+		CreateParent: proto.Bool(createParent),
+	}
+	resp := &hdfs.CreateSymlinkResponseProto{}
+
+	err := c.namenode.Execute("createSymlink", req, resp)
+	if err != nil {
+		return &os.PathError{"symlink", link, interpretException(err)}
+	}
+
+	return nil
+}
+
+// GetLinkTarget returns the target of the named symlink, which may be
+// relative to the directory containing it. Unlike ResolvePath, it only
+// resolves the last component.
+func (c *Client) GetLinkTarget(name string) (string, error) {
+	req := &hdfs.GetLinkTargetRequestProto{Path: proto.String(name)}
+	resp := &hdfs.GetLinkTargetResponseProto{}
+
+	err := c.namenode.Execute("getLinkTarget", req, resp)
+	if err != nil {
+		return "", &os.PathError{"readlink", name, interpretException(err)}
+	}
+
+	return resp.GetTargetPath(), nil
+}
+
+// Lstat is like Stat, but if the named file is a symlink, it describes the
+// link itself, rather than following it. Symlinks have the os.ModeSymlink bit
+// set in their Mode, and FileInfo.SymlinkTarget returns their target.
+func (c *Client) Lstat(name string) (os.FileInfo, error) {
+	status, err := c.getFileLinkInfo(name)
+	if err != nil {
+		return nil, &os.PathError{"lstat", name, interpretException(err)}
+	}
+
+	return newFileInfo(status, name), nil
+}
+
+// followSymlinks is called with the error from looking up name. If the error
+// is because name contains a symlink, and ClientOptions.FollowSymlinks is set,
+// it returns the path that name resolves to. If resolving it fails, because a
+// link is dangling or there are too many of them, it returns that error
+// instead. Otherwise, it returns err.
+func (c *Client) followSymlinks(ctx context.Context, name string, err error) (string, error) {
+	if !c.options.FollowSymlinks || !isUnresolvedLink(err) {
+		return "", err
+	}
+
+	hops := 0
+	return c.resolvePath(ctx, name, &hops)
+}
+
+// isUnresolvedLink returns true if err is because the namenode reached a
+// symlink, and expects the client to resolve it.
+func isUnresolvedLink(err error) bool {
+	remoteErr, ok := underlying(err).(Error)
+	return ok && (remoteErr.Exception() == unresolvedLinkException ||
+		remoteErr.Exception() == unresolvedPathException)
+}
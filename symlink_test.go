@@ -0,0 +1,88 @@
+package hdfs
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// symlinkOrSkip creates a symlink, or skips the test if the cluster doesn't
+// have symlinks enabled.
+func symlinkOrSkip(t *testing.T, client *Client, target, link string) {
+	err := client.CreateSymlink(target, link, true)
+	if err != nil && strings.Contains(err.Error(), "Symlinks not supported") {
+		t.Skip("symlinks aren't enabled on the cluster")
+	}
+
+	require.NoError(t, err)
+}
+
+func TestSymlink(t *testing.T) {
+	client := getClient(t)
+
+	baleet(t, "/_test/symlink")
+	mkdirp(t, "/_test/symlink/dir")
+	touch(t, "/_test/symlink/dir/foo")
+	symlinkOrSkip(t, client, "/_test/symlink/dir", "/_test/symlink/link")
+
+	target, err := client.GetLinkTarget("/_test/symlink/link")
+	require.NoError(t, err)
+	assert.Equal(t, "/_test/symlink/dir", target)
+
+	fi, err := client.Lstat("/_test/symlink/link")
+	require.NoError(t, err)
+	assert.Equal(t, "link", fi.Name())
+	assert.True(t, fi.Mode()&os.ModeSymlink != 0)
+	assert.Equal(t, "/_test/symlink/dir", fi.(*FileInfo).SymlinkTarget())
+
+	// Without FollowSymlinks, the namenode's error is returned.
+	_, err = client.Stat("/_test/symlink/link/foo")
+	assert.Error(t, err)
+
+	follower := newClientForUser(t, "gohdfs1")
+	defer follower.Close()
+	follower.options.FollowSymlinks = true
+
+	fi, err = follower.Stat("/_test/symlink/link")
+	require.NoError(t, err)
+	assert.Equal(t, "link", fi.Name())
+	assert.True(t, fi.IsDir())
+
+	fi, err = follower.Stat("/_test/symlink/link/foo")
+	require.NoError(t, err)
+	assert.Equal(t, "foo", fi.Name())
+	assert.False(t, fi.IsDir())
+
+	f, err := follower.Open("/_test/symlink/link/foo")
+	require.NoError(t, err)
+	f.Close()
+
+	// A dangling link is reported as the missing file, and a loop as a loop.
+	symlinkOrSkip(t, client, "/_test/symlink/missing", "/_test/symlink/dangling")
+	_, err = follower.Stat("/_test/symlink/dangling/foo")
+	assertPathError(t, err, "stat", "/_test/symlink/dangling/foo", os.ErrNotExist)
+
+	_, err = follower.Open("/_test/symlink/dangling/foo")
+	assertPathError(t, err, "open", "/_test/symlink/dangling/foo", os.ErrNotExist)
+
+	symlinkOrSkip(t, client, "/_test/symlink/loop", "/_test/symlink/loop")
+	_, err = follower.Stat("/_test/symlink/loop/foo")
+	assertPathError(t, err, "stat", "/_test/symlink/loop/foo", errSymlinkLoop)
+}
+
+func TestLstatNonexistent(t *testing.T) {
+	client := getClient(t)
+
+	_, err := client.Lstat("/_test/nonexistent")
+	assertPathError(t, err, "lstat", "/_test/nonexistent", os.ErrNotExist)
+}
+
+func TestGetLinkTargetNonexistent(t *testing.T) {
+	client := getClient(t)
+
+	_, err := client.GetLinkTarget("/_test/nonexistent")
+	assertPathError(t, err, "readlink", "/_test/nonexistent", os.ErrNotExist)
+}
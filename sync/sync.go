@@ -0,0 +1,346 @@
+// Package sync mirrors directory trees between HDFS clusters (or between two
+// paths on the same one), like rsync. It compares the source and destination
+// trees, plans the copies, directory creations and deletes needed to make the
+// destination match the source, and then executes them, copying several files
+// at once.
+package sync
+
+import (
+	"errors"
+	"os"
+	"path"
+
+	"github.com/colinmarc/hdfs/v2"
+)
+
+// CompareMode specifies how files that exist at both the source and the
+// destination are compared, to decide whether they need to be copied again.
+type CompareMode int
+
+const (
+	// CompareSizeAndTime treats files as the same if they have the same size
+	// and modification time (to the second). Since copies preserve the
+	// modification time of the source, this is the default.
+	CompareSizeAndTime CompareMode = iota
+	// CompareSize treats files as the same if they have the same size.
+	CompareSize
+	// CompareChecksum treats files as the same if they have the same size and
+	// the same contents, as determined by hdfs.ContentsDiffer. That compares
+	// block checksums where it can, and falls back to composite CRCs (or
+	// reading both files) when they were written with different block sizes.
+	CompareChecksum
+)
+
+// ActionType is the kind of change an Action makes to the destination.
+type ActionType int
+
+const (
+	// Mkdir creates a directory.
+	Mkdir ActionType = iota
+	// Copy copies a file, replacing any existing file at the destination.
+	Copy
+	// Delete removes a file or directory (recursively) from the destination,
+	// because it doesn't exist at the source.
+	Delete
+)
+
+func (t ActionType) String() string {
+	switch t {
+	case Mkdir:
+		return "mkdir"
+	case Copy:
+		return "copy"
+	case Delete:
+		return "delete"
+	default:
+		return "unknown"
+	}
+}
+
+// An Action is a single change to the destination.
+type Action struct {
+	Type ActionType
+	// Source is the source file or directory. It's empty for Delete.
+	Source string
+	// Dest is the destination path.
+	Dest string
+	// Size is the size of the source file, for Copy.
+	Size int64
+	// Replace specifies that Dest exists, but is a directory where the source
+	// is a file (or vice versa), and has to be removed first. This only happens
+	// with Options.Delete.
+	Replace bool
+	// Err is set by Execute if the action failed.
+	Err error
+}
+
+// Options specifies how a tree is mirrored.
+type Options struct {
+	// Compare specifies how existing files are compared.
+	Compare CompareMode
+	// Delete specifies that files and directories at the destination that
+	// don't exist at the source should be removed. Without it, a file at the
+	// destination that is a directory at the source, or vice versa, is an
+	// error.
+	Delete bool
+	// DryRun specifies that Sync should only plan the changes, without making
+	// them. Progress is still called for each Action.
+	DryRun bool
+	// Concurrency is the number of files copied at once. If zero, 4 is used.
+	Concurrency int
+	// Copy is passed to hdfs.CopyFileBetween for each file. Overwrite is
+	// always set, and so is PreserveTimes with CompareSizeAndTime, since
+	// otherwise every file would be copied again the next time.
+	Copy hdfs.CopyOptions
+	// Progress, if set, is called with each Action once it's been executed (or
+	// planned, for a dry run). It's always called from the goroutine that
+	// called Sync or Execute.
+	Progress func(Action)
+}
+
+// Sync makes the tree at dstPath (using dstClient) match the tree at srcPath
+// (using srcClient), by planning and then executing the needed changes. It
+// returns the actions, with Err set for any that failed, and the first error.
+func Sync(srcClient *hdfs.Client, srcPath string, dstClient *hdfs.Client, dstPath string, options Options) ([]Action, error) {
+	plan, err := Plan(srcClient, srcPath, dstClient, dstPath, options)
+	if err != nil {
+		return nil, err
+	}
+
+	if options.DryRun {
+		if options.Progress != nil {
+			for _, action := range plan {
+				options.Progress(action)
+			}
+		}
+
+		return plan, nil
+	}
+
+	return Execute(srcClient, dstClient, plan, options)
+}
+
+// Plan compares the tree at srcPath with the one at dstPath, and returns the
+// actions needed to make the destination match, without executing them.
+func Plan(srcClient *hdfs.Client, srcPath string, dstClient *hdfs.Client, dstPath string, options Options) ([]Action, error) {
+	srcInfo, err := srcClient.Stat(srcPath)
+	if err != nil {
+		return nil, err
+	}
+
+	dstInfo, err := dstClient.Stat(dstPath)
+	if os.IsNotExist(err) {
+		dstInfo = nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	p := &planner{src: srcClient, dst: dstClient, options: options}
+	err = p.plan(path.Clean(srcPath), path.Clean(dstPath), srcInfo, dstInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.actions, nil
+}
+
+type planner struct {
+	src, dst *hdfs.Client
+	options  Options
+	actions  []Action
+}
+
+// plan adds the actions needed to mirror a single file or directory. dstInfo
+// is nil if the destination doesn't exist.
+func (p *planner) plan(src, dst string, srcInfo, dstInfo os.FileInfo) error {
+	replace := dstInfo != nil && dstInfo.IsDir() != srcInfo.IsDir()
+	if replace && !p.options.Delete {
+		if dstInfo.IsDir() {
+			return &os.PathError{"sync", dst, errors.New("is a directory")}
+		}
+
+		return &os.PathError{"sync", dst, errors.New("not a directory")}
+	}
+
+	if !srcInfo.IsDir() {
+		same := false
+		if dstInfo != nil && !replace {
+			var err error
+			same, err = p.same(src, dst, srcInfo, dstInfo)
+			if err != nil {
+				return err
+			}
+		}
+
+		if !same {
+			p.actions = append(p.actions, Action{
+				Type:    Copy,
+				Source:  src,
+				Dest:    dst,
+				Size:    srcInfo.Size(),
+				Replace: replace,
+			})
+		}
+
+		return nil
+	}
+
+	var dstChildren []os.FileInfo
+	existing := make(map[string]os.FileInfo)
+	if dstInfo == nil || replace {
+		p.actions = append(p.actions, Action{Type: Mkdir, Source: src, Dest: dst, Replace: replace})
+	} else {
+		var err error
+		dstChildren, err = p.dst.ReadDir(dst)
+		if err != nil {
+			return err
+		}
+
+		for _, child := range dstChildren {
+			existing[child.Name()] = child
+		}
+	}
+
+	children, err := p.src.ReadDir(src)
+	if err != nil {
+		return err
+	}
+
+	for _, child := range children {
+		name := child.Name()
+		err = p.plan(path.Join(src, name), path.Join(dst, name), child, existing[name])
+		if err != nil {
+			return err
+		}
+
+		delete(existing, name)
+	}
+
+	if p.options.Delete {
+		for _, child := range dstChildren {
+			if _, ok := existing[child.Name()]; ok {
+				p.actions = append(p.actions, Action{Type: Delete, Dest: path.Join(dst, child.Name())})
+			}
+		}
+	}
+
+	return nil
+}
+
+// same compares two files according to the CompareMode.
+func (p *planner) same(src, dst string, srcInfo, dstInfo os.FileInfo) (bool, error) {
+	if srcInfo.Size() != dstInfo.Size() {
+		return false, nil
+	}
+
+	switch p.options.Compare {
+	case CompareSize:
+		return true, nil
+	case CompareChecksum:
+		differ, err := hdfs.ContentsDiffer(p.src, src, p.dst, dst)
+		return !differ, err
+	default:
+		return srcInfo.ModTime().Unix() == dstInfo.ModTime().Unix(), nil
+	}
+}
+
+// Execute executes the actions returned by Plan. Directories are created
+// first, then files are copied, options.Concurrency at a time, and finally
+// anything that no longer exists at the source is deleted. It returns the
+// actions with Err set for any that failed, and the first error. Failing
+// actions don't stop the rest from being executed.
+func Execute(srcClient *hdfs.Client, dstClient *hdfs.Client, plan []Action, options Options) ([]Action, error) {
+	actions := make([]Action, len(plan))
+	copy(actions, plan)
+
+	e := &executor{src: srcClient, dst: dstClient, options: options}
+	e.copyOptions = options.Copy
+	e.copyOptions.Overwrite = true
+	if options.Compare == CompareSizeAndTime {
+		e.copyOptions.PreserveTimes = true
+	}
+
+	e.run(actions, Mkdir, 1)
+
+	concurrency := options.Concurrency
+	if concurrency <= 0 {
+		concurrency = 4
+	}
+
+	e.run(actions, Copy, concurrency)
+	e.run(actions, Delete, 1)
+	return actions, e.firstErr
+}
+
+type executor struct {
+	src, dst    *hdfs.Client
+	options     Options
+	copyOptions hdfs.CopyOptions
+	firstErr    error
+}
+
+// run executes the actions of the given type, with concurrency at once.
+func (e *executor) run(actions []Action, actionType ActionType, concurrency int) {
+	jobs := make(chan int)
+	results := make(chan int)
+	for i := 0; i < concurrency; i++ {
+		go func() {
+			for i := range jobs {
+				actions[i].Err = e.execute(actions[i])
+				results <- i
+			}
+		}()
+	}
+
+	go func() {
+		defer close(jobs)
+		for i := range actions {
+			if actions[i].Type == actionType {
+				jobs <- i
+			}
+		}
+	}()
+
+	pending := 0
+	for i := range actions {
+		if actions[i].Type == actionType {
+			pending++
+		}
+	}
+
+	for ; pending > 0; pending-- {
+		i := <-results
+		if actions[i].Err != nil && e.firstErr == nil {
+			e.firstErr = actions[i].Err
+		}
+
+		if e.options.Progress != nil {
+			e.options.Progress(actions[i])
+		}
+	}
+}
+
+func (e *executor) execute(action Action) error {
+	if action.Replace {
+		err := e.dst.RemoveAll(action.Dest)
+		if err != nil {
+			return err
+		}
+	}
+
+	switch action.Type {
+	case Mkdir:
+		info, err := e.src.Stat(action.Source)
+		if err != nil {
+			return err
+		}
+
+		return e.dst.MkdirAll(action.Dest, info.Mode().Perm())
+	case Copy:
+		return hdfs.CopyFileBetween(e.src, action.Source, e.dst, action.Dest, e.copyOptions)
+	case Delete:
+		return e.dst.RemoveAll(action.Dest)
+	default:
+		return errors.New("unknown action")
+	}
+}
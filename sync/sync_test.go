@@ -0,0 +1,202 @@
+package sync
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/colinmarc/hdfs/v2"
+	"github.com/colinmarc/hdfs/v2/internal/testcluster"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getClient(t *testing.T) *hdfs.Client {
+	return testcluster.Client(t, "gohdfs1")
+}
+
+func writeFile(t *testing.T, client *hdfs.Client, name, contents string) {
+	w, err := client.CreateWithOptions(name, hdfs.CreateOptions{Overwrite: true})
+	require.NoError(t, err)
+
+	_, err = w.Write([]byte(contents))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+}
+
+func setupSync(t *testing.T, name string) (*hdfs.Client, string, string) {
+	client := getClient(t)
+
+	base := "/_test/sync/" + name
+	err := client.RemoveAll(base)
+	if err != nil && !os.IsNotExist(err) {
+		t.Fatal(err)
+	}
+
+	src := base + "/src"
+	require.NoError(t, client.MkdirAll(src+"/dir/nested", 0755))
+	writeFile(t, client, src+"/a.txt", "foo")
+	writeFile(t, client, src+"/dir/b.txt", "bar")
+	writeFile(t, client, src+"/dir/nested/c.txt", "baz")
+
+	return client, src, base + "/dst"
+}
+
+func assertContents(t *testing.T, client *hdfs.Client, name, contents string) {
+	b, err := client.ReadFile(name)
+	require.NoError(t, err)
+	assert.Equal(t, contents, string(b))
+}
+
+func types(t *testing.T, actions []Action) map[string]ActionType {
+	res := make(map[string]ActionType)
+	for _, action := range actions {
+		require.NoError(t, action.Err)
+		res[action.Dest] = action.Type
+	}
+
+	return res
+}
+
+func TestSync(t *testing.T) {
+	client, src, dst := setupSync(t, "1")
+
+	var progress []Action
+	options := Options{Progress: func(a Action) { progress = append(progress, a) }}
+	actions, err := Sync(client, src, client, dst, options)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, actions, progress)
+	assert.Equal(t, map[string]ActionType{
+		dst:                       Mkdir,
+		dst + "/dir":              Mkdir,
+		dst + "/dir/nested":       Mkdir,
+		dst + "/a.txt":            Copy,
+		dst + "/dir/b.txt":        Copy,
+		dst + "/dir/nested/c.txt": Copy,
+	}, types(t, actions))
+
+	assertContents(t, client, dst+"/a.txt", "foo")
+	assertContents(t, client, dst+"/dir/b.txt", "bar")
+	assertContents(t, client, dst+"/dir/nested/c.txt", "baz")
+
+	actions, err = Sync(client, src, client, dst, Options{})
+	require.NoError(t, err)
+	assert.Empty(t, actions)
+}
+
+func TestSyncChanged(t *testing.T) {
+	client, src, dst := setupSync(t, "2")
+
+	_, err := Sync(client, src, client, dst, Options{})
+	require.NoError(t, err)
+
+	writeFile(t, client, src+"/a.txt", "foobar")
+	writeFile(t, client, src+"/dir/b.txt", "qux")
+	past := time.Now().Add(-time.Hour)
+	require.NoError(t, client.Chtimes(src+"/dir/b.txt", past, past))
+
+	actions, err := Sync(client, src, client, dst, Options{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]ActionType{
+		dst + "/a.txt":     Copy,
+		dst + "/dir/b.txt": Copy,
+	}, types(t, actions))
+
+	assertContents(t, client, dst+"/a.txt", "foobar")
+	assertContents(t, client, dst+"/dir/b.txt", "qux")
+}
+
+func TestSyncCompareSize(t *testing.T) {
+	client, src, dst := setupSync(t, "3")
+
+	_, err := Sync(client, src, client, dst, Options{Compare: CompareSize})
+	require.NoError(t, err)
+
+	writeFile(t, client, src+"/a.txt", "bar")
+	actions, err := Sync(client, src, client, dst, Options{Compare: CompareSize})
+	require.NoError(t, err)
+	assert.Empty(t, actions)
+	assertContents(t, client, dst+"/a.txt", "foo")
+}
+
+func TestSyncCompareChecksum(t *testing.T) {
+	client, src, dst := setupSync(t, "4")
+
+	options := Options{Compare: CompareChecksum}
+	_, err := Sync(client, src, client, dst, options)
+	require.NoError(t, err)
+
+	actions, err := Sync(client, src, client, dst, options)
+	require.NoError(t, err)
+	assert.Empty(t, actions)
+
+	writeFile(t, client, src+"/a.txt", "bar")
+	actions, err = Sync(client, src, client, dst, options)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]ActionType{dst + "/a.txt": Copy}, types(t, actions))
+	assertContents(t, client, dst+"/a.txt", "bar")
+}
+
+func TestSyncDelete(t *testing.T) {
+	client, src, dst := setupSync(t, "5")
+
+	_, err := Sync(client, src, client, dst, Options{})
+	require.NoError(t, err)
+
+	writeFile(t, client, dst+"/extra.txt", "extra")
+	require.NoError(t, client.MkdirAll(dst+"/extra/dir", 0755))
+	require.NoError(t, client.RemoveAll(src+"/dir/nested"))
+
+	actions, err := Sync(client, src, client, dst, Options{})
+	require.NoError(t, err)
+	assert.Empty(t, actions)
+
+	actions, err = Sync(client, src, client, dst, Options{Delete: true})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]ActionType{
+		dst + "/extra":      Delete,
+		dst + "/extra.txt":  Delete,
+		dst + "/dir/nested": Delete,
+	}, types(t, actions))
+
+	for _, name := range []string{"/extra", "/extra.txt", "/dir/nested"} {
+		_, err = client.Stat(dst + name)
+		assert.True(t, os.IsNotExist(err))
+	}
+}
+
+func TestSyncTypeMismatch(t *testing.T) {
+	client, src, dst := setupSync(t, "6")
+
+	require.NoError(t, client.MkdirAll(dst+"/a.txt", 0755))
+	writeFile(t, client, dst+"/dir", "not a directory")
+
+	_, err := Sync(client, src, client, dst, Options{})
+	assert.Equal(t, &os.PathError{"sync", dst + "/a.txt", errors.New("is a directory")}, err)
+
+	actions, err := Sync(client, src, client, dst, Options{Delete: true})
+	require.NoError(t, err)
+	for _, action := range actions {
+		if action.Dest == dst+"/a.txt" || action.Dest == dst+"/dir" {
+			assert.True(t, action.Replace)
+		}
+	}
+
+	assertContents(t, client, dst+"/a.txt", "foo")
+	assertContents(t, client, dst+"/dir/b.txt", "bar")
+}
+
+func TestSyncDryRun(t *testing.T) {
+	client, src, dst := setupSync(t, "7")
+
+	var progress []Action
+	options := Options{DryRun: true, Progress: func(a Action) { progress = append(progress, a) }}
+	actions, err := Sync(client, src, client, dst, options)
+	require.NoError(t, err)
+	assert.Len(t, actions, 6)
+	assert.Equal(t, actions, progress)
+
+	_, err = client.Stat(dst)
+	assert.True(t, os.IsNotExist(err))
+}
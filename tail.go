@@ -0,0 +1,95 @@
+package hdfs
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// Tail returns the last n bytes of the named file, or all of it, if it's
+// shorter than that. For a file that's still being written to, that's up to
+// the end of whatever the writer has flushed so far (see
+// FileReader.VisibleLength). Only the end of the file is read from the
+// datanodes. A negative n is treated as zero.
+func (c *Client) Tail(name string, n int64) ([]byte, error) {
+	if n < 0 {
+		n = 0
+	}
+
+	f, err := c.Open(name)
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	length, err := f.VisibleLength()
+	if err != nil {
+		return nil, err
+	}
+
+	offset := length - n
+	if offset < 0 {
+		offset = 0
+	}
+
+	b := make([]byte, length-offset)
+	_, err = f.ReadAt(b, offset)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// Follow returns a reader that reads the rest of the file from the current
+// offset, and then keeps reading as the file grows, like tail -f. Instead of
+// returning io.EOF at the end of the file, Read calls Refresh every interval
+// until the writer has flushed more data, and then returns that. If the file
+// is truncated to less than what's already been read, reading starts again
+// from the beginning.
+//
+// Read only returns an error if refreshing or reading the file fails, or
+// ctx.Err() once ctx is done. The returned reader shouldn't be used at the
+// same time as f.
+func (f *FileReader) Follow(ctx context.Context, interval time.Duration) io.Reader {
+	return &followReader{f: f, ctx: ctx, interval: interval}
+}
+
+type followReader struct {
+	f        *FileReader
+	ctx      context.Context
+	interval time.Duration
+}
+
+func (r *followReader) Read(b []byte) (int, error) {
+	for {
+		n, err := r.f.Read(b)
+		if n > 0 || err != io.EOF {
+			return n, err
+		}
+
+		t := time.NewTimer(r.interval)
+		select {
+		case <-r.ctx.Done():
+			t.Stop()
+			return 0, r.ctx.Err()
+		case <-t.C:
+		}
+
+		err = r.f.Refresh()
+		if err != nil {
+			return 0, err
+		}
+
+		length, err := r.f.VisibleLength()
+		if err != nil {
+			return 0, err
+		} else if length < r.f.offset {
+			_, err = r.f.Seek(0, io.SeekStart)
+			if err != nil {
+				return 0, err
+			}
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package hdfs
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTail(t *testing.T) {
+	client := getClient(t)
+
+	mobydick, err := ioutil.ReadFile("testdata/mobydick.txt")
+	require.NoError(t, err)
+
+	b, err := client.Tail("/_test/mobydick.txt", 1000)
+	require.NoError(t, err)
+	assert.Equal(t, mobydick[len(mobydick)-1000:], b)
+
+	b, err = client.Tail("/_test/foo.txt", 1000)
+	require.NoError(t, err)
+	assert.Equal(t, "bar\n", string(b))
+
+	b, err = client.Tail("/_test/foo.txt", -1)
+	require.NoError(t, err)
+	assert.Empty(t, b)
+
+	_, err = client.Tail("/_test/nonexistent", 1000)
+	assertPathError(t, err, "open", "/_test/nonexistent", os.ErrNotExist)
+}
+
+func TestTailUnderConstruction(t *testing.T) {
+	client := getClient(t)
+
+	baleet(t, "/_test/tailunderconstruction.txt")
+	writer, err := client.Create("/_test/tailunderconstruction.txt")
+	require.NoError(t, err)
+	defer writer.Close()
+
+	_, err = writer.Write([]byte("foo bar baz"))
+	require.NoError(t, err)
+	require.NoError(t, writer.Flush())
+
+	b, err := client.Tail("/_test/tailunderconstruction.txt", 3)
+	require.NoError(t, err)
+	assert.Equal(t, "baz", string(b))
+}
+
+func TestFollowUnderConstruction(t *testing.T) {
+	client := getClient(t)
+
+	baleet(t, "/_test/follow.txt")
+	writer, err := client.Create("/_test/follow.txt")
+	require.NoError(t, err)
+	defer writer.Close()
+
+	_, err = writer.Write([]byte("foo"))
+	require.NoError(t, err)
+	require.NoError(t, writer.Flush())
+
+	file, err := client.Open("/_test/follow.txt")
+	require.NoError(t, err)
+	defer file.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	r := file.Follow(ctx, 50*time.Millisecond)
+	b := make([]byte, 3)
+	_, err = io.ReadFull(r, b)
+	require.NoError(t, err)
+	assert.Equal(t, "foo", string(b))
+
+	_, err = writer.Write([]byte("bar"))
+	require.NoError(t, err)
+	require.NoError(t, writer.Flush())
+
+	_, err = io.ReadFull(r, b)
+	require.NoError(t, err)
+	assert.Equal(t, "bar", string(b))
+}
+
+func TestFollowWebHDFS(t *testing.T) {
+	srv := newFakeWebHDFS(t)
+	client := newWebHDFSTestClient(t, srv.URL)
+
+	w, err := client.Create("/follow.txt")
+	require.NoError(t, err)
+	fmt.Fprint(w, "foo")
+	require.NoError(t, w.Close())
+
+	file, err := client.Open("/follow.txt")
+	require.NoError(t, err)
+	defer file.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r := file.Follow(ctx, 10*time.Millisecond)
+
+	b := make([]byte, 3)
+	_, err = io.ReadFull(r, b)
+	require.NoError(t, err)
+	assert.Equal(t, "foo", string(b))
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		w, err := client.Append("/follow.txt")
+		if err == nil {
+			fmt.Fprint(w, "bar")
+			w.Close()
+		}
+	}()
+
+	_, err = io.ReadFull(r, b)
+	require.NoError(t, err)
+	assert.Equal(t, "bar", string(b))
+
+	length, err := file.VisibleLength()
+	require.NoError(t, err)
+	assert.EqualValues(t, 6, length)
+
+	cancel()
+	_, err = r.Read(b)
+	assert.Equal(t, context.Canceled, err)
+
+	b, err = client.Tail("/follow.txt", 4)
+	require.NoError(t, err)
+	assert.Equal(t, "obar", string(b))
+}
+
+func TestTailWebHDFS(t *testing.T) {
+	srv := newFakeWebHDFS(t)
+	client := newWebHDFSTestClient(t, srv.URL)
+
+	w, err := client.Create("/tail.txt")
+	require.NoError(t, err)
+	fmt.Fprint(w, "foobar")
+	require.NoError(t, w.Close())
+
+	b, err := client.Tail("/tail.txt", 3)
+	require.NoError(t, err)
+	assert.Equal(t, "bar", string(b))
+
+	b, err = client.Tail("/tail.txt", 100)
+	require.NoError(t, err)
+	assert.Equal(t, "foobar", string(b))
+
+	b, err = client.Tail("/tail.txt", -3)
+	require.NoError(t, err)
+	assert.Empty(t, b)
+}
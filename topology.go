@@ -0,0 +1,218 @@
+package hdfs
+
+import (
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	hdfs "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_hdfs"
+)
+
+const defaultStaleDatanodeInterval = 30 * time.Second
+
+// ReplicaOrderFunc is used to decide the order in which the replicas of a
+// block are tried, when reading. It is passed the datanodes storing each
+// replica, in the order the namenode returned them, and returns them in the
+// order they should be tried. Any replicas left out are tried last.
+type ReplicaOrderFunc func(replicas []DatanodeInfo) []DatanodeInfo
+
+// topology holds what the client knows about where it is in the cluster.
+type topology struct {
+	hostname string
+	rack     string
+}
+
+func newTopology(options ClientOptions) (topology, error) {
+	hostname, rack := options.ClientHostname, options.ClientRack
+	if options.TopologyScript == "" || rack != "" {
+		return topology{hostname: hostname, rack: rack}, nil
+	}
+
+	if hostname == "" {
+		var err error
+		hostname, err = os.Hostname()
+		if err != nil {
+			return topology{}, err
+		}
+	}
+
+	rack, err := runTopologyScript(options.TopologyScript, hostname)
+	if err != nil {
+		return topology{}, err
+	}
+
+	return topology{hostname: hostname, rack: rack}, nil
+}
+
+// runTopologyScript runs a network topology script, like the one configured
+// by net.topology.script.file.name, to determine the rack of the given host.
+func runTopologyScript(script, host string) (string, error) {
+	out, err := exec.Command(script, host).Output()
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
+
+// distance returns how far away a datanode is: 0 for the same host, 1 for the
+// same rack, and 2 otherwise.
+func (t topology) distance(dn DatanodeInfo) int {
+	if t.hostname != "" && (dn.Hostname == t.hostname || dn.IPAddr == t.hostname) {
+		return 0
+	} else if t.rack != "" && dn.Location == t.rack {
+		return 1
+	}
+
+	return 2
+}
+
+// orderReplicas sorts the locations of the block according to the client's
+// ReplicaOrderFunc, or by distance from the client if there isn't one (with
+// replicas on PROVIDED storage last).
+// Replicas on unhealthy datanodes are moved to the end either way. The
+// per-replica fields of the block are kept in sync with the locations.
+func (c *Client) orderReplicas(block *hdfs.LocatedBlockProto) {
+	locs := block.GetLocs()
+	if len(locs) < 2 || len(block.GetBlockIndices()) > 0 {
+		// The locations of a striped block group correspond to the block
+		// indices, so they can't be reordered.
+		return
+	}
+
+	replicas := make([]DatanodeInfo, len(locs))
+	for i, loc := range locs {
+		replicas[i] = newDatanodeInfo(loc)
+	}
+
+	var order []int
+	if c.options.ReplicaOrderFunc != nil {
+		order = orderFromFunc(replicas, c.options.ReplicaOrderFunc(replicas))
+	} else {
+		order = make([]int, len(replicas))
+		for i := range order {
+			order[i] = i
+		}
+
+		if c.topology != (topology{}) {
+			sort.SliceStable(order, func(i, j int) bool {
+				return c.topology.distance(replicas[order[i]]) < c.topology.distance(replicas[order[j]])
+			})
+		}
+
+		// Reading a replica on PROVIDED storage means the datanode has to fetch
+		// it from the external store, so replicas on local storage come first.
+		if types := block.GetStorageTypes(); len(types) == len(order) {
+			sort.SliceStable(order, func(i, j int) bool {
+				return types[order[i]] != hdfs.StorageTypeProto_PROVIDED &&
+					types[order[j]] == hdfs.StorageTypeProto_PROVIDED
+			})
+		}
+	}
+
+	staleInterval := c.options.StaleDatanodeInterval
+	if staleInterval <= 0 {
+		staleInterval = defaultStaleDatanodeInterval
+	}
+
+	// The heartbeat times are by the namenode's clock, which may not agree
+	// with ours, so they're compared to the most recent heartbeat of any of
+	// the replicas rather than to the current time. That's all the ordering
+	// needs: a replica is only moved back if others are fresher.
+	var newest time.Time
+	for _, dn := range replicas {
+		if dn.LastUpdate.After(newest) {
+			newest = dn.LastUpdate
+		}
+	}
+
+	staleBefore := newest.Add(-staleInterval)
+	sort.SliceStable(order, func(i, j int) bool {
+		return replicaHealth(replicas[order[i]], staleBefore) < replicaHealth(replicas[order[j]], staleBefore)
+	})
+
+	block.Locs = permuteLocs(block.Locs, order)
+	if len(block.StorageTypes) == len(order) {
+		storageTypes := make([]hdfs.StorageTypeProto, len(order))
+		for i, j := range order {
+			storageTypes[i] = block.StorageTypes[j]
+		}
+
+		block.StorageTypes = storageTypes
+	}
+
+	if len(block.StorageIDs) == len(order) {
+		storageIDs := make([]string, len(order))
+		for i, j := range order {
+			storageIDs[i] = block.StorageIDs[j]
+		}
+
+		block.StorageIDs = storageIDs
+	}
+
+	if len(block.IsCached) == len(order) {
+		isCached := make([]bool, len(order))
+		for i, j := range order {
+			isCached[i] = block.IsCached[j]
+		}
+
+		block.IsCached = isCached
+	}
+}
+
+// replicaHealth ranks a replica by the state of its datanode, from 0 for a
+// healthy one to 3 for one that is decommissioned or in maintenance. Like the
+// namenode, we consider datanodes stale if they haven't sent a heartbeat
+// since staleBefore, which is by the namenode's clock.
+//
+// Corrupt replicas don't need to be handled here, because the namenode leaves
+// them out of the locations it returns unless every replica is corrupt.
+func replicaHealth(dn DatanodeInfo, staleBefore time.Time) int {
+	switch dn.info.GetAdminState() {
+	case hdfs.DatanodeInfoProto_DECOMMISSIONED, hdfs.DatanodeInfoProto_IN_MAINTENANCE:
+		return 3
+	case hdfs.DatanodeInfoProto_DECOMMISSION_INPROGRESS, hdfs.DatanodeInfoProto_ENTERING_MAINTENANCE:
+		return 2
+	}
+
+	if !dn.LastUpdate.IsZero() && dn.LastUpdate.Before(staleBefore) {
+		return 1
+	}
+
+	return 0
+}
+
+// orderFromFunc converts the replicas returned by a ReplicaOrderFunc into a
+// permutation of the original indices.
+func orderFromFunc(replicas, ordered []DatanodeInfo) []int {
+	order := make([]int, 0, len(replicas))
+	used := make([]bool, len(replicas))
+	for _, dn := range ordered {
+		for i, replica := range replicas {
+			if !used[i] && replica.UUID == dn.UUID {
+				order = append(order, i)
+				used[i] = true
+				break
+			}
+		}
+	}
+
+	for i := range replicas {
+		if !used[i] {
+			order = append(order, i)
+		}
+	}
+
+	return order
+}
+
+func permuteLocs(locs []*hdfs.DatanodeInfoProto, order []int) []*hdfs.DatanodeInfoProto {
+	permuted := make([]*hdfs.DatanodeInfoProto, len(order))
+	for i, j := range order {
+		permuted[i] = locs[j]
+	}
+
+	return permuted
+}
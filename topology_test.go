@@ -0,0 +1,150 @@
+package hdfs
+
+import (
+	"os/exec"
+	"testing"
+	"time"
+
+	hdfs "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_hdfs"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+func testLocatedBlock() *hdfs.LocatedBlockProto {
+	loc := func(uuid, host, rack string) *hdfs.DatanodeInfoProto {
+		return &hdfs.DatanodeInfoProto{
+			Id: &hdfs.DatanodeIDProto{
+				IpAddr:       proto.String("10.0.0." + uuid),
+				HostName:     proto.String(host),
+				DatanodeUuid: proto.String(uuid),
+				XferPort:     proto.Uint32(9866),
+				InfoPort:     proto.Uint32(9864),
+				IpcPort:      proto.Uint32(9867),
+			},
+			Location: proto.String(rack),
+		}
+	}
+
+	return &hdfs.LocatedBlockProto{
+		Locs: []*hdfs.DatanodeInfoProto{
+			loc("1", "dn1", "/rack2"),
+			loc("2", "dn2", "/rack1"),
+			loc("3", "dn3", "/rack1"),
+		},
+		StorageTypes: []hdfs.StorageTypeProto{hdfs.StorageTypeProto_DISK, hdfs.StorageTypeProto_SSD, hdfs.StorageTypeProto_ARCHIVE},
+		StorageIDs:   []string{"s1", "s2", "s3"},
+		IsCached:     []bool{false, true, false},
+	}
+}
+
+func locUUIDs(block *hdfs.LocatedBlockProto) []string {
+	var uuids []string
+	for _, loc := range block.GetLocs() {
+		uuids = append(uuids, loc.GetId().GetDatanodeUuid())
+	}
+
+	return uuids
+}
+
+func TestOrderReplicasTopology(t *testing.T) {
+	c := &Client{topology: topology{hostname: "dn3", rack: "/rack1"}}
+	block := testLocatedBlock()
+	c.orderReplicas(block)
+
+	assert.Equal(t, []string{"3", "2", "1"}, locUUIDs(block))
+	assert.Equal(t, []hdfs.StorageTypeProto{hdfs.StorageTypeProto_ARCHIVE, hdfs.StorageTypeProto_SSD, hdfs.StorageTypeProto_DISK}, block.StorageTypes)
+	assert.Equal(t, []string{"s3", "s2", "s1"}, block.StorageIDs)
+	assert.Equal(t, []bool{false, true, false}, block.IsCached)
+
+	c = &Client{topology: topology{hostname: "client", rack: "/rack1"}}
+	block = testLocatedBlock()
+	c.orderReplicas(block)
+	assert.Equal(t, []string{"2", "3", "1"}, locUUIDs(block))
+}
+
+func TestOrderReplicasFunc(t *testing.T) {
+	c := &Client{options: ClientOptions{
+		ReplicaOrderFunc: func(replicas []DatanodeInfo) []DatanodeInfo {
+			assert.Equal(t, "1", replicas[0].UUID)
+			return []DatanodeInfo{replicas[2]}
+		},
+	}}
+
+	block := testLocatedBlock()
+	c.orderReplicas(block)
+	assert.Equal(t, []string{"3", "1", "2"}, locUUIDs(block))
+	assert.Equal(t, []string{"s3", "s1", "s2"}, block.StorageIDs)
+}
+
+func TestOrderReplicasStriped(t *testing.T) {
+	c := &Client{topology: topology{hostname: "dn3"}}
+	block := testLocatedBlock()
+	block.BlockIndices = []byte{0, 1, 2}
+	c.orderReplicas(block)
+
+	assert.Equal(t, []string{"1", "2", "3"}, locUUIDs(block))
+}
+
+func TestOrderReplicasProvided(t *testing.T) {
+	c := &Client{topology: topology{hostname: "dn3", rack: "/rack1"}}
+	block := testLocatedBlock()
+	block.StorageTypes[2] = hdfs.StorageTypeProto_PROVIDED
+	c.orderReplicas(block)
+
+	assert.Equal(t, []string{"2", "1", "3"}, locUUIDs(block))
+	assert.Equal(t, []string{"s2", "s1", "s3"}, block.StorageIDs)
+}
+
+func TestOrderReplicasHealth(t *testing.T) {
+	c := &Client{topology: topology{hostname: "dn1"}}
+	block := testLocatedBlock()
+	block.Locs[0].AdminState = hdfs.DatanodeInfoProto_DECOMMISSION_INPROGRESS.Enum()
+	block.Locs[1].LastUpdate = proto.Uint64(uint64(time.Now().Add(-time.Minute).UnixNano() / int64(time.Millisecond)))
+	block.Locs[2].LastUpdate = proto.Uint64(uint64(time.Now().UnixNano() / int64(time.Millisecond)))
+	c.orderReplicas(block)
+
+	// The local replica is being decommissioned, and the second is stale.
+	assert.Equal(t, []string{"3", "2", "1"}, locUUIDs(block))
+
+	c.options.StaleDatanodeInterval = 2 * time.Minute
+	block = testLocatedBlock()
+	block.Locs[0].AdminState = hdfs.DatanodeInfoProto_DECOMMISSION_INPROGRESS.Enum()
+	block.Locs[1].LastUpdate = proto.Uint64(uint64(time.Now().Add(-time.Minute).UnixNano() / int64(time.Millisecond)))
+	block.Locs[2].AdminState = hdfs.DatanodeInfoProto_IN_MAINTENANCE.Enum()
+	c.orderReplicas(block)
+	assert.Equal(t, []string{"2", "1", "3"}, locUUIDs(block))
+
+	// The namenode's clock is an hour behind, which doesn't make every
+	// replica stale.
+	c.options.StaleDatanodeInterval = 0
+	behind := time.Now().Add(-time.Hour)
+	block = testLocatedBlock()
+	block.Locs[0].LastUpdate = proto.Uint64(uint64(behind.Add(-time.Minute).UnixNano() / int64(time.Millisecond)))
+	block.Locs[1].LastUpdate = proto.Uint64(uint64(behind.UnixNano() / int64(time.Millisecond)))
+	block.Locs[2].LastUpdate = proto.Uint64(uint64(behind.UnixNano() / int64(time.Millisecond)))
+	c.orderReplicas(block)
+	assert.Equal(t, []string{"2", "3", "1"}, locUUIDs(block))
+}
+
+func TestNewTopology(t *testing.T) {
+	topo, err := newTopology(ClientOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, topology{}, topo)
+
+	topo, err = newTopology(ClientOptions{ClientHostname: "client", ClientRack: "/rack1", TopologyScript: "false"})
+	assert.NoError(t, err)
+	assert.Equal(t, topology{hostname: "client", rack: "/rack1"}, topo)
+
+	topo, err = newTopology(ClientOptions{ClientHostname: "client"})
+	assert.NoError(t, err)
+	assert.Equal(t, topology{hostname: "client"}, topo)
+
+	if _, err := exec.LookPath("echo"); err != nil {
+		t.Skip("echo isn't available")
+	}
+
+	// The script is passed the configured hostname.
+	topo, err = newTopology(ClientOptions{ClientHostname: "/client", TopologyScript: "echo"})
+	assert.NoError(t, err)
+	assert.Equal(t, topology{hostname: "/client", rack: "/client"}, topo)
+}
@@ -0,0 +1,160 @@
+package hdfs
+
+import (
+	"context"
+	"net"
+	"sync"
+)
+
+// transferContext binds a FileReader or FileWriter to a context. It keeps
+// track of every datanode connection opened on behalf of the reader or writer,
+// so that they can all be closed as soon as the context is done, aborting any
+// transfers in progress.
+type transferContext struct {
+	ctx   context.Context
+	lock  sync.Mutex
+	conns []*trackedConn
+	stop  chan struct{}
+}
+
+func newTransferContext(ctx context.Context) *transferContext {
+	tc := &transferContext{ctx: ctx, stop: make(chan struct{})}
+	go func() {
+		select {
+		case <-ctx.Done():
+			tc.closeAll()
+		case <-tc.stop:
+		}
+	}()
+
+	return tc
+}
+
+// err returns the context's error, if it's done. It's safe to call on a nil
+// transferContext.
+func (tc *transferContext) err() error {
+	if tc == nil {
+		return nil
+	}
+
+	if tc.ctx.Err() == nil {
+		return nil
+	}
+
+	return context.Cause(tc.ctx)
+}
+
+// boundContext returns the bound context, or context.Background() if tc is
+// nil.
+func (tc *transferContext) boundContext() context.Context {
+	if tc == nil {
+		return context.Background()
+	}
+
+	return tc.ctx
+}
+
+// wrap returns a dialFunc which dials with both the passed and the bound
+// context, and tracks the resulting connections. If tc is nil, dial is
+// returned unchanged.
+func (tc *transferContext) wrap(dial dialFunc) dialFunc {
+	if tc == nil {
+		return dial
+	}
+
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		dialCtx, cancel := context.WithCancelCause(ctx)
+		defer cancel(nil)
+		stop := context.AfterFunc(tc.ctx, func() { cancel(context.Cause(tc.ctx)) })
+		defer stop()
+
+		conn, err := dial(dialCtx, network, addr)
+		if err != nil {
+			if ctxErr := tc.err(); ctxErr != nil {
+				return nil, ctxErr
+			}
+
+			return nil, err
+		}
+
+		tc.lock.Lock()
+		defer tc.lock.Unlock()
+
+		// The context may have been cancelled while we were dialing.
+		if err := tc.err(); err != nil {
+			conn.Close()
+			return nil, err
+		}
+
+		tracked := &trackedConn{Conn: conn, tc: tc}
+		tc.conns = append(tc.conns, tracked)
+		return tracked, nil
+	}
+}
+
+func (tc *transferContext) closeAll() {
+	tc.lock.Lock()
+	defer tc.lock.Unlock()
+
+	for _, conn := range tc.conns {
+		conn.Conn.Close()
+	}
+
+	tc.conns = nil
+}
+
+func (tc *transferContext) remove(conn *trackedConn) {
+	tc.lock.Lock()
+	defer tc.lock.Unlock()
+
+	for i, c := range tc.conns {
+		if c == conn {
+			tc.conns = append(tc.conns[:i], tc.conns[i+1:]...)
+			break
+		}
+	}
+}
+
+// close stops watching the context. It's safe to call on a nil
+// transferContext.
+func (tc *transferContext) close() {
+	if tc != nil {
+		close(tc.stop)
+	}
+}
+
+// trackedConn is a datanode connection opened by a transferContext. Once the
+// context is done, it returns the context's error in place of whatever error
+// the closed connection would have returned, so that callers (and the datanode
+// failover logic) can tell that the datanode isn't at fault.
+type trackedConn struct {
+	net.Conn
+	tc *transferContext
+}
+
+func (c *trackedConn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+	if err != nil {
+		if ctxErr := c.tc.err(); ctxErr != nil {
+			err = ctxErr
+		}
+	}
+
+	return n, err
+}
+
+func (c *trackedConn) Write(b []byte) (int, error) {
+	n, err := c.Conn.Write(b)
+	if err != nil {
+		if ctxErr := c.tc.err(); ctxErr != nil {
+			err = ctxErr
+		}
+	}
+
+	return n, err
+}
+
+func (c *trackedConn) Close() error {
+	c.tc.remove(c)
+	return c.Conn.Close()
+}
@@ -0,0 +1,65 @@
+package hdfs
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransferContextCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	tc := newTransferContext(ctx)
+	defer tc.close()
+
+	var server net.Conn
+	dial := tc.wrap(func(ctx context.Context, network, addr string) (net.Conn, error) {
+		var client net.Conn
+		client, server = net.Pipe()
+		return client, nil
+	})
+
+	conn, err := dial(context.Background(), "tcp", "datanode:9866")
+	require.NoError(t, err)
+	defer server.Close()
+
+	done := make(chan error)
+	go func() {
+		_, err := conn.Read(make([]byte, 1))
+		done <- err
+	}()
+
+	cancel()
+	assert.Equal(t, context.Canceled, <-done)
+	assert.Equal(t, context.Canceled, tc.err())
+
+	_, err = dial(context.Background(), "tcp", "datanode:9866")
+	assert.Equal(t, context.Canceled, err)
+}
+
+func TestTransferContextDialContext(t *testing.T) {
+	ctx, cancel := context.WithCancelCause(context.Background())
+	tc := newTransferContext(ctx)
+	defer tc.close()
+
+	dial := tc.wrap(func(ctx context.Context, network, addr string) (net.Conn, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+
+	// The passed context still applies.
+	dialCtx, dialCancel := context.WithCancel(context.Background())
+	dialCancel()
+	_, err := dial(dialCtx, "tcp", "datanode:9866")
+	assert.Equal(t, context.Canceled, err)
+	assert.NoError(t, tc.err())
+
+	// The bound context interrupts a dial in progress, with its cause.
+	errTest := errors.New("test")
+	go cancel(errTest)
+	_, err = dial(context.Background(), "tcp", "datanode:9866")
+	assert.Equal(t, errTest, err)
+}
@@ -0,0 +1,327 @@
+package hdfs
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	hdfs "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_hdfs"
+	"github.com/golang/protobuf/proto"
+)
+
+const (
+	trashCurrent = "Current"
+	// trashCheckpointFormat is the format of the names of trash checkpoints,
+	// like yyMMddHHmmss in the Java client's TrashPolicyDefault. Older versions
+	// of Hadoop used yyMMddHHmm.
+	trashCheckpointFormat    = "060102150405"
+	trashOldCheckpointFormat = "0601021504"
+)
+
+// TrashPolicy describes how long files moved to the trash are kept.
+//
+// Deleted files are moved to the Current directory in the trash, which is
+// periodically renamed to a checkpoint named after the time. Once a checkpoint
+// is older than the trash interval, it's deleted. On clusters with the trash
+// enabled, the namenode does this every CheckpointInterval; otherwise,
+// ExpungeTrash does it on demand.
+type TrashPolicy struct {
+	// Interval is how long files are kept in the trash. If zero, the trash is
+	// disabled.
+	Interval time.Duration
+	// CheckpointInterval is how often the trash is checkpointed.
+	CheckpointInterval time.Duration
+}
+
+// Enabled returns true if the trash is enabled.
+func (p TrashPolicy) Enabled() bool {
+	return p.Interval > 0
+}
+
+// TrashPolicy returns the trash policy in effect for the client. Like in the
+// Java client, the namenode's fs.trash.interval (as returned by
+// ServerDefaults) is used if it's set, and ClientOptions.TrashInterval
+// otherwise.
+func (c *Client) TrashPolicy() (TrashPolicy, error) {
+	defaults, err := c.ServerDefaults()
+	if err != nil {
+		return TrashPolicy{}, err
+	}
+
+	policy := TrashPolicy{
+		Interval:           defaults.TrashInterval,
+		CheckpointInterval: c.options.TrashCheckpointInterval,
+	}
+
+	if policy.Interval == 0 {
+		policy.Interval = c.options.TrashInterval
+	}
+
+	if policy.CheckpointInterval <= 0 || policy.CheckpointInterval > policy.Interval {
+		policy.CheckpointInterval = policy.Interval
+	}
+
+	return policy, nil
+}
+
+// GetTrashRoot returns the trash directory that the named file should be moved
+// to when it's deleted, for the user the client is acting as. That's
+// normally /user/<user>/.Trash, but files in an encryption zone can't be
+// renamed out of it, so they have a trash directory at the root of the zone
+// instead, at <zone>/.Trash/<user>.
+//
+// The namenode has no RPC for this; like the Java client, it's worked out from
+// the encryption zone of the file.
+func (c *Client) GetTrashRoot(name string) (string, error) {
+	req := &hdfs.GetEZForPathRequestProto{Src: proto.String(name)}
+	resp := &hdfs.GetEZForPathResponseProto{}
+
+	err := c.namenode.Execute("getEZForPath", req, resp)
+	if err != nil {
+		return "", &os.PathError{"gettrashroot", name, interpretException(err)}
+	}
+
+	if zone := resp.GetZone(); zone != nil {
+		return path.Join(zone.GetPath(), ".Trash", c.User()), nil
+	}
+
+	return c.trashRoot(), nil
+}
+
+// trashRoot returns the trash directory in the home directory of the user the
+// client is acting as.
+func (c *Client) trashRoot() string {
+	return path.Join("/user", c.User(), ".Trash")
+}
+
+// trashRoots returns every trash directory of the user the client is acting
+// as: the one in their home directory, and the one at the root of each
+// encryption zone, if it exists. Listing the encryption zones requires
+// superuser privileges; without them, like the Java client, only the trash in
+// the home directory is returned.
+func (c *Client) trashRoots() ([]string, error) {
+	roots := []string{c.trashRoot()}
+
+	var id int64
+	for {
+		req := &hdfs.ListEncryptionZonesRequestProto{Id: proto.Int64(id)}
+		resp := &hdfs.ListEncryptionZonesResponseProto{}
+
+		err := c.namenode.Execute("listEncryptionZones", req, resp)
+		if err != nil {
+			err = interpretException(err)
+			if os.IsPermission(err) {
+				return roots, nil
+			}
+
+			return nil, &os.PathError{"listencryptionzones", "/", err}
+		}
+
+		for _, zone := range resp.GetZones() {
+			root := path.Join(zone.GetPath(), ".Trash", c.User())
+			if _, err := c.getFileInfo(root); err == nil {
+				roots = append(roots, root)
+			}
+
+			id = zone.GetId()
+		}
+
+		if !resp.GetHasMore() || len(resp.GetZones()) == 0 {
+			return roots, nil
+		}
+	}
+}
+
+// MoveToTrash moves the named file or directory to the trash, like 'hadoop fs
+// -rm' does without -skipTrash, and returns the path it was moved to. It goes
+// in the Current directory of the trash root returned by GetTrashRoot, under
+// its full original path, and is deleted for good once the checkpoint that
+// Current becomes has expired (see TrashPolicy). If something with the same
+// name is already there, the current time in milliseconds is appended to the
+// name, like in the Java client.
+//
+// If the trash is disabled, or the file is already in the trash, MoveToTrash
+// does nothing and returns an empty string; callers that want the file gone
+// regardless should then call Remove or RemoveAll.
+func (c *Client) MoveToTrash(name string) (string, error) {
+	policy, err := c.TrashPolicy()
+	if err != nil {
+		return "", err
+	} else if !policy.Enabled() {
+		return "", nil
+	}
+
+	name = path.Clean(name)
+	_, err = c.getFileInfo(name)
+	if err != nil {
+		return "", &os.PathError{"remove", name, interpretException(err)}
+	}
+
+	root, err := c.GetTrashRoot(name)
+	if err != nil {
+		return "", err
+	}
+
+	return c.moveToTrash(name, root, time.Now())
+}
+
+func (c *Client) moveToTrash(name, root string, now time.Time) (string, error) {
+	if name == "/" {
+		return "", &os.PathError{"remove", name, errors.New("can't move the root directory to the trash")}
+	} else if name == root || strings.HasPrefix(name, root+"/") {
+		return "", nil
+	} else if strings.HasPrefix(root, name+"/") {
+		return "", &os.PathError{"remove", name, fmt.Errorf("can't move to the trash, since it contains the trash (%s)", root)}
+	}
+
+	// Like the Java client, the directories in the trash are only accessible
+	// to the user.
+	dst := path.Join(root, trashCurrent, name)
+	err := c.MkdirAll(path.Dir(dst), 0700)
+	if err != nil {
+		return "", err
+	}
+
+	err = c.rename2(name, dst, false)
+	if os.IsExist(err) {
+		dst = fmt.Sprintf("%s%d", dst, now.UnixMilli())
+		err = c.rename2(name, dst, false)
+	}
+
+	if err != nil {
+		return "", &os.PathError{"remove", name, err}
+	}
+
+	return dst, nil
+}
+
+// CheckpointTrash renames the Current directory in the trash to a new
+// checkpoint, named after the current time. It does nothing if there's
+// nothing in the trash. Like MoveToTrash, it covers the trash directories in
+// encryption zones as well as the one in the user's home directory; see
+// GetTrashRoot.
+func (c *Client) CheckpointTrash() error {
+	roots, err := c.trashRoots()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	var firstErr error
+	for _, root := range roots {
+		err = c.checkpointTrash(root, now)
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
+// ExpungeTrash deletes the trash checkpoints that are older than the trash
+// interval, and then checkpoints the current contents of the trash, like
+// 'hadoop fs -expunge'. If the trash is disabled, every existing checkpoint is
+// deleted. Like CheckpointTrash, it covers every trash directory of the user.
+func (c *Client) ExpungeTrash() error {
+	policy, err := c.TrashPolicy()
+	if err != nil {
+		return err
+	}
+
+	roots, err := c.trashRoots()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	var firstErr error
+	for _, root := range roots {
+		err = c.expungeTrash(root, policy.Interval, now)
+		if err == nil {
+			err = c.checkpointTrash(root, now)
+		}
+
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
+func (c *Client) checkpointTrash(root string, now time.Time) error {
+	current := path.Join(root, trashCurrent)
+	_, err := c.Stat(current)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	// If there's already a checkpoint for this second, add a suffix, like the
+	// Java client does.
+	base := path.Join(root, now.Format(trashCheckpointFormat))
+	checkpoint := base
+	for attempt := 1; ; attempt++ {
+		err = c.rename2(current, checkpoint, false)
+		if err == nil {
+			return nil
+		} else if !os.IsExist(err) || attempt > 1000 {
+			return &os.PathError{"checkpoint", current, err}
+		}
+
+		checkpoint = fmt.Sprintf("%s-%d", base, attempt)
+	}
+}
+
+// expungeTrash deletes the checkpoints in root that are older than interval.
+// Anything in root that isn't a checkpoint is left alone.
+func (c *Client) expungeTrash(root string, interval time.Duration, now time.Time) error {
+	children, err := c.ReadDir(root)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	var firstErr error
+	for _, child := range children {
+		if !child.IsDir() || child.Name() == trashCurrent {
+			continue
+		}
+
+		t, ok := parseTrashCheckpoint(child.Name())
+		if ok && now.Sub(t) > interval {
+			err = c.RemoveAll(path.Join(root, child.Name()))
+			if err != nil && firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+
+	return firstErr
+}
+
+// parseTrashCheckpoint returns the time a checkpoint was created, from its
+// name.
+func parseTrashCheckpoint(name string) (time.Time, bool) {
+	if i := strings.Index(name, "-"); i != -1 {
+		name = name[:i]
+	}
+
+	for _, format := range []string{trashCheckpointFormat, trashOldCheckpointFormat} {
+		if len(name) != len(format) {
+			continue
+		}
+
+		t, err := time.ParseInLocation(format, name, time.Local)
+		if err == nil {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
@@ -0,0 +1,170 @@
+package hdfs
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTrashCheckpoint(t *testing.T) {
+	expected := time.Date(2020, 3, 4, 5, 6, 7, 0, time.Local)
+
+	ts, ok := parseTrashCheckpoint("200304050607")
+	require.True(t, ok)
+	assert.True(t, expected.Equal(ts))
+
+	ts, ok = parseTrashCheckpoint("200304050607-2")
+	require.True(t, ok)
+	assert.True(t, expected.Equal(ts))
+
+	ts, ok = parseTrashCheckpoint("2003040506")
+	require.True(t, ok)
+	assert.True(t, expected.Truncate(time.Minute).Equal(ts))
+
+	for _, s := range []string{"Current", "foo", "20030405060", "209999999999"} {
+		_, ok = parseTrashCheckpoint(s)
+		assert.False(t, ok, s)
+	}
+}
+
+func TestTrashPolicy(t *testing.T) {
+	client := getClient(t)
+
+	policy, err := client.TrashPolicy()
+	require.NoError(t, err)
+
+	defaults, err := client.ServerDefaults()
+	require.NoError(t, err)
+
+	if defaults.TrashInterval > 0 {
+		assert.Equal(t, defaults.TrashInterval, policy.Interval)
+		assert.True(t, policy.Enabled())
+	}
+
+	assert.True(t, policy.CheckpointInterval <= policy.Interval)
+}
+
+func TestCheckpointTrash(t *testing.T) {
+	client := getClient(t)
+
+	mkdirp(t, "/_test/trash/1/Current")
+	touch(t, "/_test/trash/1/Current/foo")
+
+	now := time.Date(2020, 3, 4, 5, 6, 7, 0, time.Local)
+	err := client.checkpointTrash("/_test/trash/1", now)
+	require.NoError(t, err)
+
+	_, err = client.Stat("/_test/trash/1/200304050607/foo")
+	require.NoError(t, err)
+
+	_, err = client.Stat("/_test/trash/1/Current")
+	assertPathError(t, err, "stat", "/_test/trash/1/Current", os.ErrNotExist)
+
+	// Checkpointing again in the same second adds a suffix.
+	touch(t, "/_test/trash/1/Current/bar")
+	err = client.checkpointTrash("/_test/trash/1", now)
+	require.NoError(t, err)
+
+	_, err = client.Stat("/_test/trash/1/200304050607-1/bar")
+	require.NoError(t, err)
+
+	// With nothing in the trash, there's nothing to do.
+	err = client.checkpointTrash("/_test/trash/1", now)
+	require.NoError(t, err)
+}
+
+func TestExpungeTrash(t *testing.T) {
+	client := getClient(t)
+
+	now := time.Now()
+	recent := now.Add(-time.Hour).Format(trashCheckpointFormat)
+	old := now.Add(-48 * time.Hour).Format(trashCheckpointFormat)
+
+	mkdirp(t, "/_test/trash/2/Current")
+	mkdirp(t, "/_test/trash/2/"+recent)
+	mkdirp(t, "/_test/trash/2/"+old)
+	mkdirp(t, "/_test/trash/2/2003040506")
+	mkdirp(t, "/_test/trash/2/notacheckpoint")
+
+	err := client.expungeTrash("/_test/trash/2", 24*time.Hour, now)
+	require.NoError(t, err)
+
+	children, err := client.ReadDir("/_test/trash/2")
+	require.NoError(t, err)
+
+	var names []string
+	for _, child := range children {
+		names = append(names, child.Name())
+	}
+
+	assert.ElementsMatch(t, []string{"Current", recent, "notacheckpoint"}, names)
+}
+
+func TestGetTrashRoot(t *testing.T) {
+	client := getClient(t)
+
+	touch(t, "/_test/trashroot.txt")
+	root, err := client.GetTrashRoot("/_test/trashroot.txt")
+	require.NoError(t, err)
+	assert.Equal(t, "/user/gohdfs1/.Trash", root)
+}
+
+func TestMoveToTrash(t *testing.T) {
+	client := getClient(t)
+
+	root := "/_test/trash/3"
+	mkdirp(t, "/_test/trash/3files/dir")
+	touch(t, "/_test/trash/3files/foo")
+	touch(t, "/_test/trash/3files/dir/bar")
+
+	now := time.Date(2020, 3, 4, 5, 6, 7, 0, time.Local)
+	dst, err := client.moveToTrash("/_test/trash/3files/foo", root, now)
+	require.NoError(t, err)
+	assert.Equal(t, "/_test/trash/3/Current/_test/trash/3files/foo", dst)
+
+	_, err = client.Stat(dst)
+	require.NoError(t, err)
+
+	_, err = client.Stat("/_test/trash/3files/foo")
+	assertPathError(t, err, "stat", "/_test/trash/3files/foo", os.ErrNotExist)
+
+	// A second file with the same name gets a timestamp appended.
+	touch(t, "/_test/trash/3files/foo")
+	dst, err = client.moveToTrash("/_test/trash/3files/foo", root, now)
+	require.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf("/_test/trash/3/Current/_test/trash/3files/foo%d", now.UnixMilli()), dst)
+
+	dst, err = client.moveToTrash("/_test/trash/3files/dir", root, now)
+	require.NoError(t, err)
+
+	_, err = client.Stat(dst + "/bar")
+	require.NoError(t, err)
+
+	// Things that are already in the trash are left alone.
+	dst, err = client.moveToTrash("/_test/trash/3/Current/_test/trash/3files/foo", root, now)
+	require.NoError(t, err)
+	assert.Equal(t, "", dst)
+
+	_, err = client.moveToTrash("/_test", root, now)
+	assert.Error(t, err)
+
+	_, err = client.moveToTrash("/", root, now)
+	assert.Error(t, err)
+}
+
+func TestTrashRoots(t *testing.T) {
+	client := getClient(t)
+
+	roots, err := client.trashRoots()
+	require.NoError(t, err)
+	require.NotEmpty(t, roots)
+	assert.Equal(t, client.trashRoot(), roots[0])
+	for _, root := range roots[1:] {
+		assert.Equal(t, ".Trash", path.Base(path.Dir(root)))
+	}
+}
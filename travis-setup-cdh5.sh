@@ -128,6 +128,10 @@ sudo tee /etc/hadoop/conf.gohdfs/hdfs-site.xml <<EOF
    <name>dfs.permissions.superusergroup</name>
    <value>hadoop</value>
   </property>
+  <property>
+    <name>dfs.namenode.acls.enabled</name>
+    <value>true</value>
+  </property>
   <property>
     <name>dfs.safemode.extension</name>
     <value>0</value>
@@ -34,6 +34,10 @@ sudo tee /etc/hadoop/conf/hdfs-site.xml <<EOF
    <name>dfs.permissions.superusergroup</name>
    <value>hadoop</value>
   </property>
+  <property>
+    <name>dfs.namenode.acls.enabled</name>
+    <value>true</value>
+  </property>
 </configuration>
 EOF
 
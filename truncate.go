@@ -0,0 +1,56 @@
+package hdfs
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	hdfs "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_hdfs"
+	"github.com/golang/protobuf/proto"
+)
+
+// Truncate truncates the named file to the specified size, which must be no
+// larger than the current size of the file.
+//
+// If the new size falls on a block boundary, the file is truncated
+// immediately, and Truncate returns true. Otherwise, the namenode has to
+// recover the last block before the truncation is complete, and Truncate
+// returns false. In that case, the file can't be written to until recovery
+// finishes, at which point Stat will report the new size.
+func (c *Client) Truncate(name string, size int64) (bool, error) {
+	if size < 0 {
+		return false, &os.PathError{"truncate", name, errors.New("invalid length")}
+	}
+
+	req := &hdfs.TruncateRequestProto{
+		Src:        proto.String(name),
+		NewLength:  proto.Uint64(uint64(size)),
+		ClientName: proto.String(c.namenode.ClientName),
+	}
+	resp := &hdfs.TruncateResponseProto{}
+
+	err := c.namenode.Execute("truncate", req, resp)
+	if err != nil {
+		return false, &os.PathError{"truncate", name, interpretException(err)}
+	}
+
+	return resp.GetResult(), nil
+}
+
+// TruncateWait is like Truncate, but if the last block has to be recovered,
+// it waits for the recovery to finish, so that the file has its new size and
+// can be appended to once TruncateWait returns. If ctx is done first, it
+// returns ctx.Err(), but the truncation still completes in the background.
+func (c *Client) TruncateWait(ctx context.Context, name string, size int64) error {
+	done, err := c.Truncate(name, size)
+	if err != nil || done {
+		return err
+	}
+
+	err = c.waitForClose(ctx, name)
+	if err != nil && ctx.Err() == nil {
+		err = &os.PathError{"truncate", name, interpretException(err)}
+	}
+
+	return err
+}
@@ -0,0 +1,117 @@
+package hdfs
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTruncate(t *testing.T) {
+	client := getClient(t)
+
+	baleet(t, "/_test/totruncate.txt")
+	writer, err := client.Create("/_test/totruncate.txt")
+	require.NoError(t, err)
+
+	_, err = writer.Write([]byte("foobarbaz"))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	_, err = client.Truncate("/_test/totruncate.txt", 3)
+	require.NoError(t, err)
+
+	// Wait for block recovery to finish.
+	var fi os.FileInfo
+	for i := 0; i < 30; i++ {
+		fi, err = client.Stat("/_test/totruncate.txt")
+		require.NoError(t, err)
+		if fi.Size() == 3 {
+			break
+		}
+
+		time.Sleep(time.Second)
+	}
+
+	assert.EqualValues(t, 3, fi.Size())
+}
+
+func TestTruncateWait(t *testing.T) {
+	client := getClient(t)
+
+	baleet(t, "/_test/totruncate3.txt")
+	writer, err := client.Create("/_test/totruncate3.txt")
+	require.NoError(t, err)
+
+	_, err = writer.Write([]byte("foobarbaz"))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	err = client.TruncateWait(ctx, "/_test/totruncate3.txt", 3)
+	require.NoError(t, err)
+
+	fi, err := client.Stat("/_test/totruncate3.txt")
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, fi.Size())
+
+	// Once recovery has finished, the file can be appended to.
+	writer, err = client.Append("/_test/totruncate3.txt")
+	require.NoError(t, err)
+
+	_, err = writer.Write([]byte("qux"))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	bytes, err := client.ReadFile("/_test/totruncate3.txt")
+	require.NoError(t, err)
+	assert.Equal(t, "fooqux", string(bytes))
+}
+
+func TestTruncateBlockBoundary(t *testing.T) {
+	client := getClient(t)
+
+	baleet(t, "/_test/totruncate2.txt")
+	writer, err := client.Create("/_test/totruncate2.txt")
+	require.NoError(t, err)
+
+	_, err = writer.Write([]byte("foo"))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	done, err := client.Truncate("/_test/totruncate2.txt", 0)
+	require.NoError(t, err)
+	assert.True(t, done)
+
+	reader, err := client.Open("/_test/totruncate2.txt")
+	require.NoError(t, err)
+
+	bytes, err := ioutil.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Empty(t, bytes)
+}
+
+func TestTruncateNonexistent(t *testing.T) {
+	client := getClient(t)
+
+	baleet(t, "/_test/nonexistent")
+
+	_, err := client.Truncate("/_test/nonexistent", 0)
+	assertPathError(t, err, "truncate", "/_test/nonexistent", os.ErrNotExist)
+}
+
+func TestTruncateWithoutPermission(t *testing.T) {
+	client2 := getClientForUser(t, "gohdfs2")
+
+	mkdirpMask(t, "/_test/accessdenied", 0700)
+	touchMask(t, "/_test/accessdenied/totruncate", 0600)
+
+	_, err := client2.Truncate("/_test/accessdenied/totruncate", 0)
+	assertPathError(t, err, "truncate", "/_test/accessdenied/totruncate", os.ErrPermission)
+}
@@ -1,9 +1,13 @@
 package hdfs
 
 import (
+	"context"
+	"io"
 	"os"
+	"path"
 	"path/filepath"
 	"sort"
+	"sync"
 )
 
 // Walk walks the file tree rooted at root, calling walkFn for each file or
@@ -50,3 +54,230 @@ func (c *Client) walk(path string, walkFn filepath.WalkFunc) error {
 
 	return nil
 }
+
+// WalkOptions holds the options for WalkContext.
+type WalkOptions struct {
+	// Concurrency is the number of directories that are listed at once. If it
+	// is less than one, it's treated as one.
+	Concurrency int
+}
+
+// WalkContext is like Walk, but lists directories with batched listings, up
+// to options.Concurrency at a time, and uses the file information that comes
+// back with them instead of fetching it for each entry separately. This makes
+// it much faster for large trees, but the entries aren't visited in any
+// particular order, except that a directory is always visited before anything
+// in it. walkFn is never called concurrently, although it may be called from
+// different goroutines.
+//
+// As with filepath.Walk, if walkFn returns filepath.SkipDir for a directory,
+// its contents are skipped, and if it returns it for a file, the rest of the
+// directory containing it is skipped. If it returns filepath.SkipAll, the walk
+// stops and WalkContext returns nil. If ctx is done before the walk finishes,
+// WalkContext returns ctx.Err().
+func (c *Client) WalkContext(ctx context.Context, root string, options WalkOptions, walkFn filepath.WalkFunc) error {
+	info, err := c.getFileInfoContext(ctx, root)
+	if err != nil {
+		err = &os.PathError{"stat", root, interpretException(err)}
+	}
+
+	err = walkFn(root, info, err)
+	if err == filepath.SkipDir || err == filepath.SkipAll {
+		return nil
+	} else if err != nil || info == nil || !info.IsDir() {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	w := &walker{
+		client: c,
+		ctx:    ctx,
+		cancel: cancel,
+		walkFn: walkFn,
+	}
+
+	w.cond = sync.NewCond(&w.mu)
+	w.enqueue(root, info)
+
+	concurrency := options.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.work()
+		}()
+	}
+
+	wg.Wait()
+	return w.err
+}
+
+// walker is the state shared by the workers of a WalkContext. Directories
+// waiting to be listed are kept in queue; pending counts those, plus the ones
+// being listed, so that the workers know when the walk is over.
+type walker struct {
+	client *Client
+	ctx    context.Context
+	cancel context.CancelFunc
+	walkFn filepath.WalkFunc
+	// fnLock serializes the calls to walkFn.
+	fnLock sync.Mutex
+
+	mu      sync.Mutex
+	cond    *sync.Cond
+	queue   []walkerDir
+	pending int
+	stopped bool
+	err     error
+}
+
+type walkerDir struct {
+	name string
+	info os.FileInfo
+}
+
+func (w *walker) work() {
+	for {
+		dir, ok := w.next()
+		if !ok {
+			return
+		}
+
+		w.list(dir)
+		w.done()
+	}
+}
+
+// next waits for a directory to list. It returns false once there are none
+// left, or the walk has been stopped.
+func (w *walker) next() (walkerDir, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for len(w.queue) == 0 && w.pending > 0 && !w.stopped {
+		w.cond.Wait()
+	}
+
+	if w.stopped || len(w.queue) == 0 {
+		return walkerDir{}, false
+	}
+
+	// Taking the most recently found directory first keeps the queue short,
+	// since the walk goes deep before it goes wide.
+	dir := w.queue[len(w.queue)-1]
+	w.queue = w.queue[:len(w.queue)-1]
+	return dir, true
+}
+
+func (w *walker) enqueue(name string, info os.FileInfo) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.queue = append(w.queue, walkerDir{name, info})
+	w.pending++
+	w.cond.Signal()
+}
+
+func (w *walker) done() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.pending--
+	if w.pending == 0 {
+		w.cond.Broadcast()
+	}
+}
+
+// stop ends the walk, with err as the result, unless it's already been
+// stopped.
+func (w *walker) stop(err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if !w.stopped {
+		w.stopped = true
+		w.err = err
+		w.cancel()
+		w.cond.Broadcast()
+	}
+}
+
+// call calls walkFn, unless the walk has already been stopped, in which case
+// it returns filepath.SkipAll so that the caller gives up too.
+func (w *walker) call(name string, info os.FileInfo, err error) error {
+	w.fnLock.Lock()
+	defer w.fnLock.Unlock()
+
+	w.mu.Lock()
+	stopped := w.stopped
+	w.mu.Unlock()
+	if stopped {
+		return filepath.SkipAll
+	}
+
+	return w.walkFn(name, info, err)
+}
+
+// handle deals with the result of a call to walkFn, and returns true if the
+// listing of the current directory should continue.
+func (w *walker) handle(err error) bool {
+	switch err {
+	case nil:
+		return true
+	case filepath.SkipAll:
+		w.stop(nil)
+	default:
+		w.stop(err)
+	}
+
+	return false
+}
+
+func (w *walker) list(dir walkerDir) {
+	it := w.client.ListStatusIterator(dir.name)
+	it.ctx = w.ctx
+
+	for {
+		batch, err := it.NextBatch()
+		if err == io.EOF {
+			return
+		} else if err != nil {
+			if w.ctx.Err() != nil {
+				w.stop(w.ctx.Err())
+				return
+			}
+
+			err = w.call(dir.name, dir.info, err)
+			if err != filepath.SkipDir {
+				w.handle(err)
+			}
+
+			return
+		}
+
+		for _, info := range batch {
+			name := path.Join(dir.name, info.Name())
+			err := w.call(name, info, nil)
+			if err == filepath.SkipDir {
+				if info.IsDir() {
+					continue
+				}
+
+				return
+			} else if !w.handle(err) {
+				return
+			}
+
+			if info.IsDir() {
+				w.enqueue(name, info)
+			}
+		}
+	}
+}
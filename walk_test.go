@@ -1,11 +1,15 @@
 package hdfs
 
 import (
+	"context"
 	"os"
+	"path"
 	"path/filepath"
+	"sort"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestWalk(t *testing.T) {
@@ -46,6 +50,88 @@ func TestWalkError(t *testing.T) {
 	assert.Equal(t, 1, len(errors), "expected a single error")
 }
 
+func TestWalkContext(t *testing.T) {
+	c := getClient(t)
+
+	mkdirp(t, "/_test/walkcontext/dir/subdir")
+	mkdirp(t, "/_test/walkcontext/dir2")
+	touch(t, "/_test/walkcontext/walkfile")
+	touch(t, "/_test/walkcontext/dir/walkfile1")
+	touch(t, "/_test/walkcontext/dir/walkfile2")
+	touch(t, "/_test/walkcontext/dir/subdir/walkfile1")
+	touch(t, "/_test/walkcontext/dir2/walkfile1")
+
+	paths := make([]string, 0, 9)
+	err := c.WalkContext(context.Background(), "/_test/walkcontext", WalkOptions{Concurrency: 4}, walkFnTest(&paths))
+	require.NoError(t, err)
+
+	expected := []string{
+		"/_test/walkcontext",
+		"/_test/walkcontext/dir",
+		"/_test/walkcontext/dir/subdir",
+		"/_test/walkcontext/dir/subdir/walkfile1",
+		"/_test/walkcontext/dir/walkfile1",
+		"/_test/walkcontext/dir/walkfile2",
+		"/_test/walkcontext/dir2",
+		"/_test/walkcontext/dir2/walkfile1",
+		"/_test/walkcontext/walkfile"}
+
+	assert.ElementsMatch(t, expected, paths)
+	for i, p := range paths {
+		assert.Contains(t, paths[:i+1], path.Dir(p), "%s was visited before its parent", p)
+	}
+
+	paths = paths[:0]
+	err = c.WalkContext(context.Background(), "/_test/walkcontext", WalkOptions{},
+		func(p string, info os.FileInfo, err error) error {
+			if p == "/_test/walkcontext/dir" {
+				return filepath.SkipDir
+			}
+
+			paths = append(paths, p)
+			return nil
+		})
+	require.NoError(t, err)
+
+	sort.Strings(paths)
+	assert.Equal(t, []string{
+		"/_test/walkcontext",
+		"/_test/walkcontext/dir2",
+		"/_test/walkcontext/dir2/walkfile1",
+		"/_test/walkcontext/walkfile"}, paths)
+
+	count := 0
+	err = c.WalkContext(context.Background(), "/_test/walkcontext", WalkOptions{Concurrency: 4},
+		func(p string, info os.FileInfo, err error) error {
+			count++
+			return filepath.SkipAll
+		})
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = c.WalkContext(ctx, "/_test/walkcontext", WalkOptions{}, walkFnTest(&paths))
+	assert.Error(t, err)
+}
+
+func TestWalkContextError(t *testing.T) {
+	c := getClient(t)
+
+	var errs []error
+	err := c.WalkContext(context.Background(), "/_test/nonexistent", WalkOptions{}, walkErrorFn(&errs))
+	assert.NoError(t, err)
+	require.Equal(t, 1, len(errs))
+	assertPathError(t, errs[0], "stat", "/_test/nonexistent", os.ErrNotExist)
+
+	mkdirp(t, "/_test/walkcontexterror")
+	err = c.WalkContext(context.Background(), "/_test/walkcontexterror", WalkOptions{},
+		func(p string, info os.FileInfo, err error) error {
+			return os.ErrInvalid
+		})
+	assert.Equal(t, os.ErrInvalid, err)
+}
+
 func walkFnTest(encounteredPaths *[]string) filepath.WalkFunc {
 	return func(path string, info os.FileInfo, err error) error {
 		*encounteredPaths = append(*encounteredPaths, path)
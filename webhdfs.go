@@ -0,0 +1,641 @@
+package hdfs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	hdfs "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_hdfs"
+	"github.com/golang/protobuf/proto"
+	krb "gopkg.in/jcmturner/gokrb5.v5/client"
+)
+
+const illegalArgumentException = "java.lang.IllegalArgumentException"
+
+// errWebHDFSUnsupported is returned by operations that require a connection
+// to the namenode, for a client created with ClientOptions.WebHDFSAddress.
+var errWebHDFSUnsupported = errors.New("not supported over WebHDFS")
+
+// webhdfsClient makes requests to the WebHDFS REST API, served by the
+// namenode or by an HttpFS gateway, on behalf of a Client created with
+// ClientOptions.WebHDFSAddress.
+type webhdfsClient struct {
+	base           *url.URL
+	user           string
+	kerberosClient *krb.Client
+	httpClient     *http.Client
+	// noRedirect is a copy of httpClient that returns redirects instead of
+	// following them, for the first step of creating or appending to a file.
+	noRedirect *http.Client
+}
+
+// webhdfsFileStatus is a FileStatus object, as returned by GETFILESTATUS and
+// LISTSTATUS.
+type webhdfsFileStatus struct {
+	AccessTime       uint64 `json:"accessTime"`
+	BlockSize        uint64 `json:"blockSize"`
+	ChildrenNum      int32  `json:"childrenNum"`
+	FileID           uint64 `json:"fileId"`
+	Group            string `json:"group"`
+	Length           uint64 `json:"length"`
+	ModificationTime uint64 `json:"modificationTime"`
+	Owner            string `json:"owner"`
+	PathSuffix       string `json:"pathSuffix"`
+	Permission       string `json:"permission"`
+	Replication      uint32 `json:"replication"`
+	StoragePolicy    uint32 `json:"storagePolicy"`
+	Symlink          string `json:"symlink"`
+	Type             string `json:"type"`
+}
+
+type webhdfsFileStatuses struct {
+	FileStatuses struct {
+		FileStatus []webhdfsFileStatus `json:"FileStatus"`
+	} `json:"FileStatuses"`
+}
+
+// webhdfsError is a RemoteException returned by WebHDFS. Like the errors
+// from the namenode, it implements Error, so that interpretException works on
+// it.
+type webhdfsError struct {
+	op        string
+	status    string
+	exception string
+	message   string
+}
+
+func (err *webhdfsError) Method() string {
+	return err.op
+}
+
+func (err *webhdfsError) Desc() string {
+	return err.status
+}
+
+func (err *webhdfsError) Exception() string {
+	return err.exception
+}
+
+func (err *webhdfsError) Message() string {
+	return err.message
+}
+
+func (err *webhdfsError) Error() string {
+	s := fmt.Sprintf("webhdfs %s failed with %s", err.op, err.status)
+	if err.message != "" {
+		s += ": " + err.message
+	}
+
+	return s
+}
+
+func newWebHDFSClient(options ClientOptions, user string) (*webhdfsClient, error) {
+	base, err := parseWebHDFSAddress(options.WebHDFSAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &webhdfsClient{
+		base:       base,
+		user:       user,
+		httpClient: options.WebHDFSHTTPClient,
+	}
+
+	// An empty kerberos client, as set by ClientOptionsFromConf, can't be
+	// used for SPNEGO.
+	if options.KerberosClient != nil && options.KerberosClient.Credentials != nil {
+		w.kerberosClient = options.KerberosClient
+	}
+
+	if w.httpClient == nil {
+		w.httpClient = http.DefaultClient
+	}
+
+	noRedirect := *w.httpClient
+	noRedirect.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	w.noRedirect = &noRedirect
+	return w, nil
+}
+
+// parseWebHDFSAddress parses the address of a WebHDFS or HttpFS endpoint. The
+// webhdfs:// and swebhdfs:// schemes are the same as http:// and https://,
+// respectively, and a bare "host:port" is assumed to be http.
+func parseWebHDFSAddress(address string) (*url.URL, error) {
+	if !strings.Contains(address, "://") {
+		address = "http://" + address
+	}
+
+	u, err := url.Parse(address)
+	if err != nil {
+		return nil, err
+	}
+
+	switch u.Scheme {
+	case "http", "https":
+	case "webhdfs":
+		u.Scheme = "http"
+	case "swebhdfs":
+		u.Scheme = "https"
+	default:
+		return nil, fmt.Errorf("invalid WebHDFS address: %s", address)
+	}
+
+	if u.Host == "" {
+		return nil, fmt.Errorf("invalid WebHDFS address: %s", address)
+	}
+
+	u.Path = strings.TrimSuffix(u.Path, "/")
+	if !strings.HasSuffix(u.Path, "/webhdfs/v1") {
+		u.Path += "/webhdfs/v1"
+	}
+
+	u.RawQuery = ""
+	return u, nil
+}
+
+func (w *webhdfsClient) url(name, op string, params url.Values) *url.URL {
+	u := *w.base
+	u.Path += name
+
+	query := url.Values{"op": {op}}
+	for k, v := range params {
+		query[k] = v
+	}
+
+	if w.kerberosClient == nil && w.user != "" {
+		query.Set("user.name", w.user)
+	}
+
+	u.RawQuery = query.Encode()
+	return &u
+}
+
+// do makes a request to u, returning an error for any response that isn't a
+// success or, unless the client follows redirects, a redirect.
+func (w *webhdfsClient) do(ctx context.Context, client *http.Client, method, op string, u *url.URL, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/octet-stream")
+	}
+
+	// Requests redirected to a datanode are authenticated with the delegation
+	// token the namenode adds to the URL instead.
+	if w.kerberosClient != nil && u.Host == w.base.Host {
+		err = w.kerberosClient.SetSPNEGOHeader(req, "HTTP/"+u.Hostname())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode >= 400 || (resp.StatusCode >= 300 && client == w.httpClient) {
+		defer resp.Body.Close()
+		return nil, newWebHDFSError(op, resp)
+	}
+
+	return resp, nil
+}
+
+func newWebHDFSError(op string, resp *http.Response) error {
+	var remote struct {
+		RemoteException struct {
+			JavaClassName string `json:"javaClassName"`
+			Message       string `json:"message"`
+		}
+	}
+
+	json.NewDecoder(resp.Body).Decode(&remote)
+	return &webhdfsError{
+		op:        op,
+		status:    resp.Status,
+		exception: remote.RemoteException.JavaClassName,
+		message:   remote.RemoteException.Message,
+	}
+}
+
+// call makes a request for one of the operations that return a JSON object,
+// and decodes it into v.
+func (w *webhdfsClient) call(ctx context.Context, method, name, op string, params url.Values, v interface{}) error {
+	resp, err := w.do(ctx, w.httpClient, method, op, w.url(name, op, params), nil)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+	err = json.NewDecoder(resp.Body).Decode(v)
+	if err != nil {
+		return fmt.Errorf("webhdfs %s: invalid response: %s", op, err)
+	}
+
+	return nil
+}
+
+// callBoolean makes a request for one of the operations that return a
+// boolean.
+func (w *webhdfsClient) callBoolean(ctx context.Context, method, name, op string, params url.Values) (bool, error) {
+	var result struct {
+		Boolean bool `json:"boolean"`
+	}
+
+	err := w.call(ctx, method, name, op, params, &result)
+	return result.Boolean, err
+}
+
+func (w *webhdfsClient) getFileStatus(ctx context.Context, name string) (*hdfs.HdfsFileStatusProto, error) {
+	var result struct {
+		FileStatus *webhdfsFileStatus `json:"FileStatus"`
+	}
+
+	err := w.call(ctx, "GET", name, "GETFILESTATUS", nil, &result)
+	if err != nil {
+		return nil, err
+	} else if result.FileStatus == nil {
+		return nil, os.ErrNotExist
+	}
+
+	return result.FileStatus.proto(), nil
+}
+
+// listStatus is like Client.getListing. It uses LISTSTATUS_BATCH, which was
+// added in Hadoop 2.8, and falls back to listing the whole directory at once
+// with LISTSTATUS, if the server doesn't support it.
+func (w *webhdfsClient) listStatus(ctx context.Context, name string, startAfter []byte) ([]*hdfs.HdfsFileStatusProto, bool, error) {
+	var result struct {
+		DirectoryListing *struct {
+			PartialListing   webhdfsFileStatuses `json:"partialListing"`
+			RemainingEntries int                 `json:"remainingEntries"`
+		} `json:"DirectoryListing"`
+	}
+
+	var params url.Values
+	if len(startAfter) > 0 {
+		params = url.Values{"startAfter": {string(startAfter)}}
+	}
+
+	err := w.call(ctx, "GET", name, "LISTSTATUS_BATCH", params, &result)
+	if err, ok := err.(*webhdfsError); ok && err.exception == illegalArgumentException && len(startAfter) == 0 {
+		var all webhdfsFileStatuses
+		err := w.call(ctx, "GET", name, "LISTSTATUS", nil, &all)
+		if err != nil {
+			return nil, false, err
+		}
+
+		return protoStatuses(all), false, nil
+	} else if err != nil {
+		return nil, false, err
+	} else if result.DirectoryListing == nil {
+		return nil, false, os.ErrNotExist
+	}
+
+	list := protoStatuses(result.DirectoryListing.PartialListing)
+	return list, result.DirectoryListing.RemainingEntries > 0 && len(list) > 0, nil
+}
+
+func protoStatuses(statuses webhdfsFileStatuses) []*hdfs.HdfsFileStatusProto {
+	list := make([]*hdfs.HdfsFileStatusProto, len(statuses.FileStatuses.FileStatus))
+	for i, status := range statuses.FileStatuses.FileStatus {
+		list[i] = status.proto()
+	}
+
+	return list
+}
+
+func (w *webhdfsClient) mkdirs(ctx context.Context, name string, perm os.FileMode) error {
+	params := url.Values{"permission": {formatPermission(perm)}}
+	ok, err := w.callBoolean(ctx, "PUT", name, "MKDIRS", params)
+	if err == nil && !ok {
+		err = errors.New("webhdfs MKDIRS failed")
+	}
+
+	return err
+}
+
+// formatPermission formats perm in octal, as WebHDFS expects it. Only the
+// permission bits and the sticky bit mean anything to HDFS, and the namenode
+// rejects anything else, like os.ModeDir.
+func formatPermission(perm os.FileMode) string {
+	bits := uint64(perm.Perm())
+	if perm&os.ModeSticky != 0 {
+		bits |= 01000
+	}
+
+	return strconv.FormatUint(bits, 8)
+}
+
+func (w *webhdfsClient) rename(ctx context.Context, oldpath, newpath string) (bool, error) {
+	return w.callBoolean(ctx, "PUT", oldpath, "RENAME", url.Values{"destination": {newpath}})
+}
+
+func (w *webhdfsClient) delete(ctx context.Context, name string, recursive bool) (bool, error) {
+	return w.callBoolean(ctx, "DELETE", name, "DELETE", url.Values{"recursive": {strconv.FormatBool(recursive)}})
+}
+
+// open starts reading name from offset, up to length bytes, or to the end of
+// the file if length is negative. The namenode redirects the request to a
+// datanode, while HttpFS serves the data itself.
+func (w *webhdfsClient) open(ctx context.Context, name string, offset, length int64) (io.ReadCloser, error) {
+	params := url.Values{"offset": {strconv.FormatInt(offset, 10)}}
+	if length >= 0 {
+		params.Set("length", strconv.FormatInt(length, 10))
+	}
+
+	resp, err := w.do(ctx, w.httpClient, "GET", "OPEN", w.url(name, "OPEN", params), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Body, nil
+}
+
+// upload starts a CREATE or APPEND. That takes two requests: the first,
+// without any data, is redirected to where the data should be sent, which is
+// a datanode, or HttpFS itself. The second is then made in the background,
+// streaming whatever is written to the returned webhdfsUpload. Errors with
+// the first request, like the parent directory not existing, are returned
+// immediately; errors with the second only once the data has been written.
+func (w *webhdfsClient) upload(ctx context.Context, method, name, op string, params url.Values) (*webhdfsUpload, error) {
+	resp, err := w.do(ctx, w.noRedirect, method, op, w.url(name, op, params), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Body.Close()
+	location, err := resp.Location()
+	if err != nil {
+		return nil, fmt.Errorf("webhdfs %s: expected a redirect, got %s", op, resp.Status)
+	}
+
+	pr, pw := io.Pipe()
+	u := &webhdfsUpload{pw: pw, done: make(chan error, 1)}
+	go func() {
+		resp, err := w.do(ctx, w.httpClient, method, op, location, pr)
+		if err == nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		// If the request failed before all the data was sent, this makes
+		// subsequent writes fail with the same error.
+		pr.CloseWithError(err)
+		u.done <- err
+	}()
+
+	return u, nil
+}
+
+// webhdfsUpload streams the data written to a FileWriter to WebHDFS, for one
+// CREATE or APPEND request.
+type webhdfsUpload struct {
+	pw   *io.PipeWriter
+	done chan error
+}
+
+func (u *webhdfsUpload) Write(b []byte) (int, error) {
+	return u.pw.Write(b)
+}
+
+// close finishes the request, and waits for the response.
+func (u *webhdfsUpload) close() error {
+	u.pw.Close()
+	return <-u.done
+}
+
+// abort fails the request partway through with err, so that the server sees
+// that the data was cut short.
+func (u *webhdfsUpload) abort(err error) {
+	u.pw.CloseWithError(err)
+	<-u.done
+}
+
+func (status *webhdfsFileStatus) proto() *hdfs.HdfsFileStatusProto {
+	fileType := hdfs.HdfsFileStatusProto_IS_FILE
+	switch status.Type {
+	case "DIRECTORY":
+		fileType = hdfs.HdfsFileStatusProto_IS_DIR
+	case "SYMLINK":
+		fileType = hdfs.HdfsFileStatusProto_IS_SYMLINK
+	}
+
+	perm, _ := strconv.ParseUint(status.Permission, 8, 32)
+	p := &hdfs.HdfsFileStatusProto{
+		FileType:         &fileType,
+		Path:             []byte(status.PathSuffix),
+		Length:           proto.Uint64(status.Length),
+		Permission:       &hdfs.FsPermissionProto{Perm: proto.Uint32(uint32(perm))},
+		Owner:            proto.String(status.Owner),
+		Group:            proto.String(status.Group),
+		ModificationTime: proto.Uint64(status.ModificationTime),
+		AccessTime:       proto.Uint64(status.AccessTime),
+		BlockReplication: proto.Uint32(status.Replication),
+		Blocksize:        proto.Uint64(status.BlockSize),
+		FileId:           proto.Uint64(status.FileID),
+		ChildrenNum:      proto.Int32(status.ChildrenNum),
+		StoragePolicy:    proto.Uint32(status.StoragePolicy),
+	}
+
+	if status.Symlink != "" {
+		p.Symlink = []byte(status.Symlink)
+	}
+
+	return p
+}
+
+// renameWeb is the equivalent of rename2, for WebHDFS. RENAME never replaces
+// an existing file, so if overwrite is set, whatever is at newpath is deleted
+// first (as long as it's a file or an empty directory), which isn't atomic.
+// RENAME also doesn't say why it failed, so that has to be inferred.
+func (c *Client) renameWeb(oldpath, newpath string, overwrite bool) error {
+	ctx := context.Background()
+	if overwrite && path.Clean(oldpath) != path.Clean(newpath) {
+		_, err := c.web.getFileStatus(ctx, newpath)
+		err = interpretException(err)
+		if err == nil {
+			_, err = c.web.delete(ctx, newpath, false)
+			if err != nil {
+				return interpretException(err)
+			}
+		} else if !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	ok, err := c.web.rename(ctx, oldpath, newpath)
+	if err != nil {
+		return interpretException(err)
+	} else if !ok {
+		_, err := c.web.getFileStatus(ctx, oldpath)
+		if err != nil {
+			return interpretException(err)
+		}
+
+		return os.ErrExist
+	}
+
+	return nil
+}
+
+// mkdirWeb is the equivalent of mkdir, once it's checked that dirname doesn't
+// exist, for WebHDFS. MKDIRS always creates any missing parents, so if
+// createParent isn't set, the parent is checked first.
+func (c *Client) mkdirWeb(dirname string, perm os.FileMode, createParent bool) error {
+	ctx := context.Background()
+	if !createParent {
+		_, err := c.web.getFileStatus(ctx, path.Dir(dirname))
+		if err != nil {
+			return &os.PathError{"mkdir", dirname, interpretException(err)}
+		}
+	}
+
+	err := c.web.mkdirs(ctx, dirname, perm&^c.options.Umask.Perm())
+	if err != nil {
+		return &os.PathError{"mkdir", dirname, interpretException(err)}
+	}
+
+	return nil
+}
+
+// createWeb is the equivalent of create, for WebHDFS.
+func (c *Client) createWeb(name string, overwrite bool, replication int, blockSize int64, perm os.FileMode) (*FileWriter, error) {
+	params := url.Values{
+		"overwrite":  {strconv.FormatBool(overwrite)},
+		"permission": {formatPermission(perm &^ c.options.Umask.Perm())},
+	}
+
+	if replication > 0 {
+		params.Set("replication", strconv.Itoa(replication))
+	}
+
+	if blockSize > 0 {
+		params.Set("blocksize", strconv.FormatInt(blockSize, 10))
+	}
+
+	upload, err := c.web.upload(c.conns.boundContext(), "PUT", name, "CREATE", params)
+	if err != nil {
+		return nil, &os.PathError{"create", name, interpretException(err)}
+	}
+
+	atomic.AddUint64(&c.filesWOpen, 1)
+	return &FileWriter{
+		client:      c,
+		name:        name,
+		replication: replication,
+		blockSize:   blockSize,
+		web:         upload,
+	}, nil
+}
+
+// appendWeb is the equivalent of append, for WebHDFS.
+func (c *Client) appendWeb(name string) (*FileWriter, error) {
+	info, err := c.getFileInfo(name)
+	if err != nil {
+		return nil, &os.PathError{"append", name, interpretException(err)}
+	}
+
+	upload, err := c.web.upload(c.conns.boundContext(), "POST", name, "APPEND", nil)
+	if err != nil {
+		return nil, &os.PathError{"append", name, interpretException(err)}
+	}
+
+	fi := info.(*FileInfo)
+	atomic.AddUint64(&c.filesWOpen, 1)
+	return &FileWriter{
+		client:      c,
+		name:        name,
+		replication: fi.Replication(),
+		blockSize:   fi.BlockSize(),
+		blockOffset: fi.Size(),
+		web:         upload,
+	}, nil
+}
+
+// readWeb is the equivalent of Read, for WebHDFS. The file is read with a
+// single request from the current offset to the end, which is restarted
+// after a Seek.
+func (f *FileReader) readWeb(b []byte) (int, error) {
+	if f.offset >= f.length {
+		return 0, io.EOF
+	} else if len(b) == 0 {
+		return 0, nil
+	}
+
+	if f.webBody == nil {
+		body, err := f.client.web.open(f.tc.boundContext(), f.name, f.offset, -1)
+		if err != nil {
+			return 0, &os.PathError{"read", f.name, interpretException(err)}
+		}
+
+		f.webBody = body
+	}
+
+	n, err := f.webBody.Read(b)
+	f.offset += int64(n)
+	atomic.AddInt64(&f.bytesRead, int64(n))
+	if err == io.EOF && f.offset < f.length {
+		err = io.ErrUnexpectedEOF
+	}
+
+	if err != nil {
+		f.closeWebBody()
+		if n > 0 && err == io.EOF {
+			err = nil
+		}
+	}
+
+	return n, err
+}
+
+// closeWebBody closes the response being read from over WebHDFS, if there is
+// one, so that the next Read starts a new one.
+func (f *FileReader) closeWebBody() {
+	if f.webBody != nil {
+		f.webBody.Close()
+		f.webBody = nil
+	}
+}
+
+// readAtWeb is the equivalent of ReadAt, for WebHDFS. Each call makes its own
+// request, for just the range being read.
+func (f *FileReader) readAtWeb(b []byte, off int64) (int, error) {
+	length := int64(len(b))
+	if off >= f.length {
+		return 0, io.EOF
+	} else if off+length > f.length {
+		length = f.length - off
+	}
+
+	body, err := f.client.web.open(f.tc.boundContext(), f.name, off, length)
+	if err != nil {
+		return 0, &os.PathError{"readat", f.name, interpretException(err)}
+	}
+
+	defer body.Close()
+	n, err := io.ReadFull(body, b[:length])
+	atomic.AddInt64(&f.bytesRead, int64(n))
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	} else if err == nil && n < len(b) {
+		err = io.EOF
+	}
+
+	return n, err
+}
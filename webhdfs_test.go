@@ -0,0 +1,465 @@
+package hdfs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeWebHDFS is an in-memory implementation of the parts of the WebHDFS API
+// used by the client. Like the namenode, it redirects OPEN, CREATE, and
+// APPEND to a "datanode", which here is just another path on the same
+// server.
+type fakeWebHDFS struct {
+	lock  sync.Mutex
+	files map[string][]byte
+	dirs  map[string]bool
+	perms map[string]string
+}
+
+func newFakeWebHDFS(t *testing.T) *httptest.Server {
+	fake := &fakeWebHDFS{
+		files: make(map[string][]byte),
+		dirs:  map[string]bool{"/": true},
+		perms: make(map[string]string),
+	}
+
+	srv := httptest.NewServer(fake)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func (fake *fakeWebHDFS) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	fake.lock.Lock()
+	defer fake.lock.Unlock()
+
+	query := r.URL.Query()
+	if query.Get("user.name") != "gohdfs1" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	if strings.HasPrefix(r.URL.Path, "/datanode") {
+		fake.serveData(w, r, strings.TrimPrefix(r.URL.Path, "/datanode"))
+		return
+	}
+
+	name := path.Clean("/" + strings.TrimPrefix(r.URL.Path, "/webhdfs/v1"))
+	_, isFile := fake.files[name]
+	exists := isFile || fake.dirs[name]
+	op := query.Get("op")
+
+	if perm, ok := query["permission"]; ok {
+		bits, err := strconv.ParseUint(perm[0], 8, 32)
+		if err != nil || bits > 01777 {
+			w.WriteHeader(http.StatusBadRequest)
+			fake.reply(w, remoteException("java.lang.IllegalArgumentException", "Invalid value for webhdfs parameter \"permission\""))
+			return
+		}
+	}
+
+	switch op {
+	case "GETFILESTATUS":
+		if !exists {
+			fake.notFound(w, name)
+			return
+		}
+
+		fake.reply(w, map[string]interface{}{"FileStatus": fake.status(name, "")})
+	case "LISTSTATUS_BATCH":
+		if !exists {
+			fake.notFound(w, name)
+			return
+		}
+
+		var children []string
+		for child := range fake.files {
+			if path.Dir(child) == name && child != name {
+				children = append(children, path.Base(child))
+			}
+		}
+
+		for child := range fake.dirs {
+			if path.Dir(child) == name && child != name {
+				children = append(children, path.Base(child))
+			}
+		}
+
+		sort.Strings(children)
+		var statuses []interface{}
+		remaining := 0
+		for _, child := range children {
+			if child <= query.Get("startAfter") {
+				continue
+			} else if len(statuses) == 2 {
+				remaining++
+				continue
+			}
+
+			statuses = append(statuses, fake.status(path.Join(name, child), child))
+		}
+
+		fake.reply(w, map[string]interface{}{"DirectoryListing": map[string]interface{}{
+			"partialListing":   map[string]interface{}{"FileStatuses": map[string]interface{}{"FileStatus": statuses}},
+			"remainingEntries": remaining,
+		}})
+	case "MKDIRS":
+		for p := name; p != "/"; p = path.Dir(p) {
+			fake.dirs[p] = true
+		}
+
+		fake.perms[name] = query.Get("permission")
+		fake.reply(w, map[string]bool{"boolean": true})
+	case "RENAME":
+		dest := query.Get("destination")
+		_, destFile := fake.files[dest]
+		if !isFile || destFile || fake.dirs[dest] {
+			fake.reply(w, map[string]bool{"boolean": false})
+			return
+		}
+
+		fake.files[dest] = fake.files[name]
+		fake.remove(name)
+		fake.reply(w, map[string]bool{"boolean": true})
+	case "DELETE":
+		recursive := query.Get("recursive") == "true"
+		for p := range fake.files {
+			if strings.HasPrefix(p, name+"/") && !recursive {
+				w.WriteHeader(http.StatusForbidden)
+				fake.reply(w, remoteException("org.apache.hadoop.fs.PathIsNotEmptyDirectoryException", name))
+				return
+			}
+		}
+
+		fake.remove(name)
+		fake.reply(w, map[string]bool{"boolean": exists})
+	case "OPEN", "CREATE", "APPEND":
+		if op != "CREATE" && !exists {
+			fake.notFound(w, name)
+			return
+		}
+
+		http.Redirect(w, r, "/datanode"+name+"?"+r.URL.RawQuery, http.StatusTemporaryRedirect)
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		fake.reply(w, remoteException("java.lang.IllegalArgumentException", "Invalid value for webhdfs parameter \"op\""))
+	}
+}
+
+func (fake *fakeWebHDFS) serveData(w http.ResponseWriter, r *http.Request, name string) {
+	query := r.URL.Query()
+	switch query.Get("op") {
+	case "OPEN":
+		data := fake.files[name]
+		offset, _ := strconv.Atoi(query.Get("offset"))
+		end := len(data)
+		if length, err := strconv.Atoi(query.Get("length")); err == nil && offset+length < end {
+			end = offset + length
+		}
+
+		w.Write(data[offset:end])
+	case "CREATE":
+		if _, ok := fake.files[name]; ok && query.Get("overwrite") != "true" {
+			w.WriteHeader(http.StatusForbidden)
+			fake.reply(w, remoteException(fileAlreadyExistsException, name+" already exists"))
+			return
+		}
+
+		data, _ := ioutil.ReadAll(r.Body)
+		fake.files[name] = data
+		fake.perms[name] = query.Get("permission")
+		w.WriteHeader(http.StatusCreated)
+	case "APPEND":
+		data, _ := ioutil.ReadAll(r.Body)
+		fake.files[name] = append(fake.files[name], data...)
+	}
+}
+
+// remove removes name and everything under it. The builtin delete is shadowed
+// by the package's own.
+func (fake *fakeWebHDFS) remove(name string) {
+	files := make(map[string][]byte)
+	for p, data := range fake.files {
+		if p != name && !strings.HasPrefix(p, name+"/") {
+			files[p] = data
+		}
+	}
+
+	dirs := make(map[string]bool)
+	for p := range fake.dirs {
+		if p != name && !strings.HasPrefix(p, name+"/") {
+			dirs[p] = true
+		}
+	}
+
+	perms := make(map[string]string)
+	for p, perm := range fake.perms {
+		if p != name && !strings.HasPrefix(p, name+"/") {
+			perms[p] = perm
+		}
+	}
+
+	fake.files, fake.dirs, fake.perms = files, dirs, perms
+}
+
+func (fake *fakeWebHDFS) status(name, suffix string) map[string]interface{} {
+	status := map[string]interface{}{
+		"pathSuffix":       suffix,
+		"owner":            "gohdfs1",
+		"group":            "supergroup",
+		"modificationTime": 1500000000000,
+		"permission":       "755",
+		"type":             "DIRECTORY",
+		"length":           0,
+	}
+
+	if data, ok := fake.files[name]; ok {
+		status["type"] = "FILE"
+		status["permission"] = "644"
+		status["length"] = len(data)
+		status["replication"] = 3
+		status["blockSize"] = 134217728
+	}
+
+	if perm := fake.perms[name]; perm != "" {
+		status["permission"] = perm
+	}
+
+	return status
+}
+
+func (fake *fakeWebHDFS) notFound(w http.ResponseWriter, name string) {
+	w.WriteHeader(http.StatusNotFound)
+	fake.reply(w, remoteException(fileNotFoundException, "File does not exist: "+name))
+}
+
+func (fake *fakeWebHDFS) reply(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+func remoteException(className, message string) map[string]interface{} {
+	parts := strings.Split(className, ".")
+	return map[string]interface{}{"RemoteException": map[string]string{
+		"exception":     parts[len(parts)-1],
+		"javaClassName": className,
+		"message":       message,
+	}}
+}
+
+func newWebHDFSTestClient(t *testing.T, address string) *Client {
+	client, err := NewClient(ClientOptions{WebHDFSAddress: address, User: "gohdfs1", Umask: 022})
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func TestParseWebHDFSAddress(t *testing.T) {
+	for address, expected := range map[string]string{
+		"httpfs:14000":                     "http://httpfs:14000/webhdfs/v1",
+		"webhdfs://nn:9870":                "http://nn:9870/webhdfs/v1",
+		"swebhdfs://nn:9871/":              "https://nn:9871/webhdfs/v1",
+		"https://gateway/proxy/webhdfs/v1": "https://gateway/proxy/webhdfs/v1",
+	} {
+		u, err := parseWebHDFSAddress(address)
+		require.NoError(t, err, address)
+		assert.Equal(t, expected, u.String(), address)
+	}
+
+	for _, address := range []string{"hdfs://nn:9000", "http://"} {
+		_, err := parseWebHDFSAddress(address)
+		assert.Error(t, err, address)
+	}
+}
+
+func TestWebHDFSReadWrite(t *testing.T) {
+	srv := newFakeWebHDFS(t)
+	client := newWebHDFSTestClient(t, srv.URL)
+
+	err := client.MkdirAll("/_test/web", 0777)
+	require.NoError(t, err)
+
+	w, err := client.Create("/_test/web/foo.txt")
+	require.NoError(t, err)
+
+	_, err = fmt.Fprint(w, "foo bar baz")
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	_, err = client.Create("/_test/web/foo.txt")
+	assertPathError(t, err, "create", "/_test/web/foo.txt", os.ErrExist)
+
+	w, err = client.Append("/_test/web/foo.txt")
+	require.NoError(t, err)
+
+	_, err = fmt.Fprint(w, " qux")
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	info, err := client.Stat("/_test/web/foo.txt")
+	require.NoError(t, err)
+	assert.Equal(t, "foo.txt", info.Name())
+	assert.EqualValues(t, 15, info.Size())
+	assert.Equal(t, os.FileMode(0644), info.Mode())
+	assert.Equal(t, "gohdfs1", info.(*FileInfo).Owner())
+
+	r, err := client.Open("/_test/web/foo.txt")
+	require.NoError(t, err)
+	defer r.Close()
+
+	b := make([]byte, 3)
+	_, err = r.ReadAt(b, 4)
+	require.NoError(t, err)
+	assert.Equal(t, "bar", string(b))
+
+	_, err = r.Seek(8, io.SeekStart)
+	require.NoError(t, err)
+
+	rest, err := ioutil.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, "baz qux", string(rest))
+
+	_, err = client.Open("/_test/web/nonexistent")
+	assertPathError(t, err, "open", "/_test/web/nonexistent", os.ErrNotExist)
+}
+
+func TestWebHDFSWriterSeek(t *testing.T) {
+	srv := newFakeWebHDFS(t)
+	client := newWebHDFSTestClient(t, srv.URL)
+
+	f, err := client.OpenFile("/seek.txt", os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	require.NoError(t, err)
+
+	_, err = f.WriteString("foo")
+	require.NoError(t, err)
+
+	off, err := f.Seek(0, io.SeekCurrent)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, off)
+	require.NoError(t, f.Close())
+
+	// Opened again with O_APPEND, the file is appended to, starting at the
+	// end.
+	f, err = client.OpenFile("/seek.txt", os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	require.NoError(t, err)
+	defer f.Close()
+
+	off, err = f.Seek(0, io.SeekEnd)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, off)
+
+	_, err = f.Seek(-1, io.SeekEnd)
+	assertPathError(t, err, "seek", "/seek.txt", errSeekWriter)
+}
+
+func TestWebHDFSWriterSetContextAfterClose(t *testing.T) {
+	srv := newFakeWebHDFS(t)
+	client := newWebHDFSTestClient(t, srv.URL)
+
+	w, err := client.Create("/context.txt")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	w.SetContext(ctx)
+	require.NoError(t, w.Close())
+
+	// Close stopped watching the context, so this mustn't stop it again.
+	w.SetContext(nil)
+	assert.Equal(t, io.ErrClosedPipe, w.Close())
+}
+
+func TestWebHDFSCloseWithOpenWriter(t *testing.T) {
+	srv := newFakeWebHDFS(t)
+	client := newWebHDFSTestClient(t, srv.URL)
+
+	w, err := client.Create("/open.txt")
+	require.NoError(t, err)
+	defer w.Close()
+
+	// With no CloseTimeout, Close doesn't wait for the writer, and cancelling
+	// it isn't an error.
+	assert.NoError(t, client.Close())
+	assert.Equal(t, ErrClientClosed, client.Close())
+}
+
+func TestWebHDFSListRenameRemove(t *testing.T) {
+	srv := newFakeWebHDFS(t)
+	client := newWebHDFSTestClient(t, "webhdfs://"+strings.TrimPrefix(srv.URL, "http://"))
+
+	require.NoError(t, client.MkdirAll("/_test/web/dir", 0777))
+	for _, name := range []string{"a", "b", "c", "d"} {
+		require.NoError(t, client.CreateEmptyFile("/_test/web/"+name))
+	}
+
+	// The fake server only returns two entries at a time.
+	infos, err := client.ReadDir("/_test/web")
+	require.NoError(t, err)
+	var names []string
+	for _, info := range infos {
+		names = append(names, info.Name())
+	}
+
+	assert.Equal(t, []string{"a", "b", "c", "d", "dir"}, names)
+	assert.True(t, infos[4].IsDir())
+
+	err = client.Mkdir("/_test/nonexistent/dir", 0777)
+	assertPathError(t, err, "mkdir", "/_test/nonexistent/dir", os.ErrNotExist)
+
+	require.NoError(t, client.Rename("/_test/web/a", "/_test/web/dir/a"))
+	require.NoError(t, client.Rename("/_test/web/b", "/_test/web/c"))
+
+	_, err = client.Stat("/_test/web/b")
+	assertPathError(t, err, "stat", "/_test/web/b", os.ErrNotExist)
+
+	err = client.Remove("/_test/web/nonexistent")
+	assertPathError(t, err, "remove", "/_test/web/nonexistent", os.ErrNotExist)
+
+	require.NoError(t, client.Remove("/_test/web/c"))
+	require.NoError(t, client.RemoveAll("/_test/web"))
+
+	_, err = client.Stat("/_test/web")
+	assertPathError(t, err, "stat", "/_test/web", os.ErrNotExist)
+
+	_, err = client.StatFs()
+	assert.Error(t, err)
+}
+
+func TestWebHDFSPermissions(t *testing.T) {
+	srv := newFakeWebHDFS(t)
+	client := newWebHDFSTestClient(t, "webhdfs://"+strings.TrimPrefix(srv.URL, "http://"))
+
+	// Mode bits other than the permissions and the sticky bit aren't sent,
+	// since the namenode rejects them.
+	require.NoError(t, client.Mkdir("/dir", 0755|os.ModeDir))
+	info, err := client.Stat("/dir")
+	require.NoError(t, err)
+	assert.EqualValues(t, 0755, info.Mode().Perm())
+
+	require.NoError(t, client.MkdirAll("/dir/sticky", 0777|os.ModeDir|os.ModeSticky))
+	info, err = client.Stat("/dir/sticky")
+	require.NoError(t, err)
+	assert.EqualValues(t, 01755, info.(*FileInfo).status.GetPermission().GetPerm())
+
+	w, err := client.CreateFile("/dir/foo.txt", 0, 0, 0644|os.ModeDir)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	info, err = client.Stat("/dir/foo.txt")
+	require.NoError(t, err)
+	assert.EqualValues(t, 0644, info.Mode().Perm())
+}
@@ -0,0 +1,58 @@
+package hdfs
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"path"
+)
+
+// WriteFileAtomic writes the contents of r to the named file, such that
+// readers either see the complete file or nothing at all. It writes to a
+// hidden temporary file in the same directory first, and then renames it into
+// place once it has been closed (and all the data acknowledged by the
+// datanodes). If anything fails along the way, the temporary file is removed.
+//
+// The options are used to create the temporary file, except for Overwrite,
+// which specifies whether an existing file at name should be replaced. If it
+// is false and the file exists, an error wrapping os.ErrExist is returned.
+// Append isn't supported. To have the datanodes sync the data to disk before
+// the file is published, set SyncBlock.
+func (c *Client) WriteFileAtomic(name string, r io.Reader, options CreateOptions) error {
+	if options.Append {
+		return &os.PathError{"create", name, errors.New("can't append atomically")}
+	}
+
+	dir, base := path.Split(name)
+	tmp := path.Join(dir, fmt.Sprintf(".%s.%016x.tmp", base, rand.Uint64()))
+
+	overwrite := options.Overwrite
+	options.Overwrite = false
+	w, err := c.CreateWithOptions(tmp, options)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(w, r)
+	if err != nil {
+		w.Close()
+		c.Remove(tmp)
+		return err
+	}
+
+	err = w.Close()
+	if err != nil {
+		c.Remove(tmp)
+		return err
+	}
+
+	err = c.rename2(tmp, name, overwrite)
+	if err != nil {
+		c.Remove(tmp)
+		return &os.PathError{"rename", name, err}
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package hdfs
+
+import (
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type failingReader struct{}
+
+func (failingReader) Read(b []byte) (int, error) {
+	return 0, errors.New("read failed")
+}
+
+func assertOnlyFiles(t *testing.T, client *Client, dir string, names ...string) {
+	infos, err := client.ReadDir(dir)
+	require.NoError(t, err)
+
+	var found []string
+	for _, info := range infos {
+		found = append(found, info.Name())
+	}
+
+	assert.Equal(t, names, found)
+}
+
+func TestWriteFileAtomic(t *testing.T) {
+	client := getClient(t)
+
+	mkdirp(t, "/_test/atomic")
+	err := client.WriteFileAtomic("/_test/atomic/1.txt", strings.NewReader("foo"), CreateOptions{})
+	require.NoError(t, err)
+
+	bytes, err := client.ReadFile("/_test/atomic/1.txt")
+	require.NoError(t, err)
+	assert.Equal(t, "foo", string(bytes))
+	assertOnlyFiles(t, client, "/_test/atomic", "1.txt")
+}
+
+func TestWriteFileAtomicOverwrite(t *testing.T) {
+	client := getClient(t)
+
+	mkdirp(t, "/_test/atomic")
+	err := client.WriteFileAtomic("/_test/atomic/2.txt", strings.NewReader("foo"), CreateOptions{})
+	require.NoError(t, err)
+
+	err = client.WriteFileAtomic("/_test/atomic/2.txt", strings.NewReader("bar"), CreateOptions{})
+	assertPathError(t, err, "rename", "/_test/atomic/2.txt", os.ErrExist)
+
+	err = client.WriteFileAtomic("/_test/atomic/2.txt", strings.NewReader("baz"),
+		CreateOptions{Overwrite: true, Perm: 0600})
+	require.NoError(t, err)
+
+	bytes, err := client.ReadFile("/_test/atomic/2.txt")
+	require.NoError(t, err)
+	assert.Equal(t, "baz", string(bytes))
+
+	fi, err := client.Stat("/_test/atomic/2.txt")
+	require.NoError(t, err)
+	assert.EqualValues(t, 0600, fi.Mode().Perm())
+	assertOnlyFiles(t, client, "/_test/atomic", "2.txt")
+}
+
+func TestWriteFileAtomicReadError(t *testing.T) {
+	client := getClient(t)
+
+	mkdirp(t, "/_test/atomic")
+	r := io.MultiReader(strings.NewReader("foo"), failingReader{})
+	err := client.WriteFileAtomic("/_test/atomic/3.txt", r, CreateOptions{})
+	assert.EqualError(t, err, "read failed")
+
+	_, err = client.Stat("/_test/atomic/3.txt")
+	assertPathError(t, err, "stat", "/_test/atomic/3.txt", os.ErrNotExist)
+	assertOnlyFiles(t, client, "/_test/atomic")
+}
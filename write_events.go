@@ -0,0 +1,136 @@
+package hdfs
+
+import (
+	"time"
+
+	"github.com/colinmarc/hdfs/v2/internal/rpc"
+)
+
+// WriteEventType identifies what happened in a WriteEvent.
+type WriteEventType int
+
+const (
+	// WritePipelineBuilt means that a write pipeline was set up for a block:
+	// the first datanode was connected to, and every datanode in the pipeline
+	// accepted the write.
+	WritePipelineBuilt WriteEventType = iota
+	// WritePacketSent means that a packet of data was sent to the first
+	// datanode in the pipeline.
+	WritePacketSent
+	// WriteAckReceived means that a packet was acknowledged by every datanode
+	// in the pipeline.
+	WriteAckReceived
+	// WritePipelineFailed means that the pipeline couldn't be set up, or that a
+	// datanode in it failed. For replicated files, the pipeline is then set up
+	// again without that datanode, as long as there's one left, and the write
+	// carries on; otherwise, it fails.
+	WritePipelineFailed
+	// WriteHeartbeatFailed means that a heartbeat, sent to keep the pipeline
+	// open while the writer was idle, couldn't be sent to the first datanode.
+	// The next write will most likely fail, too.
+	WriteHeartbeatFailed
+	// WritePacketResent means that a packet which wasn't acknowledged before
+	// the pipeline failed was sent again, to the first datanode in the
+	// recovered pipeline.
+	WritePacketResent
+)
+
+func (t WriteEventType) String() string {
+	switch t {
+	case WritePipelineBuilt:
+		return "pipeline built"
+	case WritePacketSent:
+		return "packet sent"
+	case WriteAckReceived:
+		return "ack received"
+	case WritePipelineFailed:
+		return "pipeline failed"
+	case WriteHeartbeatFailed:
+		return "heartbeat failed"
+	case WritePacketResent:
+		return "packet resent"
+	default:
+		return "unknown"
+	}
+}
+
+// A WriteEvent describes something that happened in the write pipeline for a
+// block. It is passed to the WriteHook registered with FileWriter.SetWriteHook
+// or ClientOptions.WriteHook.
+type WriteEvent struct {
+	Type WriteEventType
+	// BlockID is the ID of the block being written. For erasure-coded files,
+	// it's the ID of the internal block.
+	BlockID uint64
+	// Pipeline is the addresses of the datanodes in the write pipeline, in the
+	// order the data flows through them.
+	Pipeline []string
+	// Seqno is the sequence number of the packet, and Offset and Bytes are its
+	// position in the block and its length, for WritePacketSent,
+	// WriteAckReceived, and WritePacketResent.
+	Seqno  int64
+	Offset int64
+	Bytes  int
+	// Latency is how long it took to set up the pipeline, for
+	// WritePipelineBuilt, or how long the packet took to be acknowledged after
+	// it was sent, for WriteAckReceived.
+	Latency time.Duration
+	// DownstreamLatency is the part of Latency that the first datanode spent
+	// waiting for the rest of the pipeline to acknowledge the packet, as
+	// reported by the first datanode. The rest is the time spent at the first
+	// datanode and on the network in between.
+	DownstreamLatency time.Duration
+	// FailedDatanode is the address of the datanode which failed, for
+	// WritePipelineFailed and WriteHeartbeatFailed, if it's known.
+	FailedDatanode string
+	// Err is the error that caused a WritePipelineFailed or
+	// WriteHeartbeatFailed event.
+	Err error
+}
+
+// A WriteHook is called with each WriteEvent for a file being written. Acks are
+// reported from a background goroutine, so a WriteHook must be safe to call
+// concurrently with writes, and should return quickly.
+type WriteHook func(WriteEvent)
+
+// SetWriteHook registers a hook to be called with events from the write
+// pipeline, overriding ClientOptions.WriteHook. Passing nil removes it. It
+// should be called before writing; otherwise, it only takes effect from the
+// next block.
+func (f *FileWriter) SetWriteHook(fn WriteHook) {
+	f.writeHook = rpcWriteHook(fn, f.client.options.Metrics)
+	if f.blockWriter != nil {
+		f.blockWriter.Hook = f.writeHook
+	}
+}
+
+// rpcWriteHook converts fn to a hook for rpc.BlockWriter, which also reports
+// to metrics, if it's set.
+func rpcWriteHook(fn WriteHook, metrics Metrics) func(rpc.WriteEvent) {
+	if fn == nil && metrics == nil {
+		return nil
+	}
+
+	return func(ev rpc.WriteEvent) {
+		if metrics != nil {
+			reportWriteEvent(metrics, ev)
+		}
+
+		if fn == nil {
+			return
+		}
+
+		fn(WriteEvent{
+			Type:              WriteEventType(ev.Type),
+			BlockID:           ev.Block.GetBlockId(),
+			Pipeline:          ev.Pipeline,
+			Seqno:             ev.Seqno,
+			Offset:            ev.Offset,
+			Bytes:             ev.Bytes,
+			Latency:           ev.Latency,
+			DownstreamLatency: ev.DownstreamLatency,
+			FailedDatanode:    ev.FailedDatanode,
+			Err:               ev.Err,
+		})
+	}
+}
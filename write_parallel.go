@@ -0,0 +1,174 @@
+package hdfs
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"path"
+	"sync"
+)
+
+// WriteFileParallel writes size bytes from r to the named file, writing up to
+// concurrency parts of it at once. Each part is a run of whole blocks, written
+// to its own hidden temporary file in the same directory (and so through its
+// own datanode pipeline). Once they're all closed, the parts are stitched
+// together with Concat, which just moves the blocks, and the result is
+// renamed into place. Like with WriteFileAtomic, readers never see a partial
+// file, and if anything fails, the temporary files are removed.
+//
+// A single FileWriter is limited by how fast one pipeline can write one block
+// at a time, so for large files on a cluster with plenty of datanodes, this
+// can be much faster. However, it requires support for Concat, which isn't
+// available in encryption zones.
+//
+// The options are used to create each part, except for Overwrite, which
+// specifies whether an existing file at name should be replaced. If it is
+// false and the file exists, an error wrapping os.ErrExist is returned. Append
+// isn't supported. With Verify, the checksum of each part is checked as it's
+// closed. If concurrency is less than one, it's treated as one.
+func (c *Client) WriteFileParallel(name string, r io.ReaderAt, size int64, concurrency int, options CreateOptions) error {
+	if options.Append {
+		return &os.PathError{"create", name, errors.New("can't append in parallel")}
+	} else if size < 0 {
+		return &os.PathError{"create", name, errors.New("negative size")}
+	}
+
+	if !options.Overwrite {
+		_, err := c.getFileInfo(name)
+		err = interpretException(err)
+		if err == nil {
+			return &os.PathError{"create", name, os.ErrExist}
+		} else if !os.IsNotExist(err) {
+			return &os.PathError{"create", name, err}
+		}
+	}
+
+	// Fix the block size, since it has to be the same for every part.
+	if options.BlockSize == 0 {
+		defaults, err := c.fetchDefaults()
+		if err != nil {
+			return err
+		}
+
+		options.BlockSize = int64(defaults.GetBlockSize())
+	}
+
+	parts := splitParts(size, options.BlockSize, concurrency)
+	dir, base := path.Split(name)
+	prefix := path.Join(dir, fmt.Sprintf(".%s.%016x", base, rand.Uint64()))
+	names := make([]string, len(parts))
+	for i := range parts {
+		names[i] = fmt.Sprintf("%s.part%d.tmp", prefix, i)
+	}
+
+	overwrite := options.Overwrite
+	options.Overwrite = false
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(parts))
+	for i, part := range parts {
+		wg.Add(1)
+		go func(i int, part [2]int64) {
+			defer wg.Done()
+			errs[i] = c.writePart(names[i], io.NewSectionReader(r, part[0], part[1]-part[0]), options)
+		}(i, part)
+	}
+
+	wg.Wait()
+
+	cleanup := func() {
+		for _, name := range names {
+			c.Remove(name)
+		}
+	}
+
+	for _, err := range errs {
+		if err != nil {
+			cleanup()
+			return err
+		}
+	}
+
+	if len(names) > 1 {
+		err := c.Concat(names[0], names[1:])
+		if err != nil {
+			cleanup()
+			return err
+		}
+	}
+
+	err := c.rename2(names[0], name, overwrite)
+	if err != nil {
+		c.Remove(names[0])
+		return &os.PathError{"rename", name, err}
+	}
+
+	return nil
+}
+
+// CopyToRemoteParallel is like CopyToRemoteWithOptions, but uploads the local
+// file with WriteFileParallel.
+func (c *Client) CopyToRemoteParallel(src, dst string, concurrency int, options CreateOptions) error {
+	local, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer local.Close()
+
+	info, err := local.Stat()
+	if err != nil {
+		return err
+	}
+
+	return c.WriteFileParallel(dst, local, info.Size(), concurrency, options)
+}
+
+func (c *Client) writePart(name string, r *io.SectionReader, options CreateOptions) error {
+	w, err := c.CreateWithOptions(name, options)
+	if err != nil {
+		return err
+	}
+
+	n, err := io.Copy(w, r)
+	if err == nil && n < r.Size() {
+		err = io.ErrUnexpectedEOF
+	}
+
+	if err != nil {
+		w.Close()
+		return err
+	}
+
+	return w.Close()
+}
+
+// splitParts divides size bytes into at most concurrency parts, as [start,
+// end) offsets. Every part but the last is a multiple of blockSize, since
+// Concat requires that on older versions of Hadoop. There's always at least
+// one part, even if size is zero.
+func splitParts(size, blockSize int64, concurrency int) [][2]int64 {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	blocks := (size + blockSize - 1) / blockSize
+	blocksPerPart := (blocks + int64(concurrency) - 1) / int64(concurrency)
+	if blocksPerPart < 1 {
+		blocksPerPart = 1
+	}
+
+	partSize := blocksPerPart * blockSize
+	parts := [][2]int64{}
+	for start := int64(0); start < size || len(parts) == 0; start += partSize {
+		end := start + partSize
+		if end > size {
+			end = size
+		}
+
+		parts = append(parts, [2]int64{start, end})
+	}
+
+	return parts
+}
@@ -0,0 +1,61 @@
+package hdfs
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitParts(t *testing.T) {
+	assert.Equal(t, [][2]int64{{0, 0}}, splitParts(0, 10, 4))
+	assert.Equal(t, [][2]int64{{0, 5}}, splitParts(5, 10, 4))
+	assert.Equal(t, [][2]int64{{0, 10}, {10, 20}, {20, 25}}, splitParts(25, 10, 4))
+	assert.Equal(t, [][2]int64{{0, 20}, {20, 40}, {40, 45}}, splitParts(45, 10, 3))
+	assert.Equal(t, [][2]int64{{0, 30}, {30, 45}}, splitParts(45, 10, 2))
+	assert.Equal(t, [][2]int64{{0, 45}}, splitParts(45, 10, 0))
+}
+
+func TestWriteFileParallel(t *testing.T) {
+	client := getClient(t)
+
+	expected, err := ioutil.ReadFile("testdata/mobydick.txt")
+	require.NoError(t, err)
+
+	mkdirp(t, "/_test/parallel")
+	err = client.WriteFileParallel("/_test/parallel/1.txt", bytes.NewReader(expected), int64(len(expected)), 3,
+		CreateOptions{BlockSize: 1048576, Verify: true})
+	require.NoError(t, err)
+
+	actual, err := client.ReadFile("/_test/parallel/1.txt")
+	require.NoError(t, err)
+	assert.Equal(t, expected, actual)
+	assertOnlyFiles(t, client, "/_test/parallel", "1.txt")
+
+	err = client.WriteFileParallel("/_test/parallel/1.txt", strings.NewReader("foo"), 3, 3, CreateOptions{})
+	assertPathError(t, err, "create", "/_test/parallel/1.txt", os.ErrExist)
+
+	err = client.WriteFileParallel("/_test/parallel/1.txt", strings.NewReader("foo"), 3, 3, CreateOptions{Overwrite: true})
+	require.NoError(t, err)
+
+	actual, err = client.ReadFile("/_test/parallel/1.txt")
+	require.NoError(t, err)
+	assert.Equal(t, "foo", string(actual))
+	assertOnlyFiles(t, client, "/_test/parallel", "1.txt")
+}
+
+func TestWriteFileParallelFailure(t *testing.T) {
+	client := getClient(t)
+
+	mkdirp(t, "/_test/parallel2")
+
+	// The reader is too short, so the last part fails.
+	err := client.WriteFileParallel("/_test/parallel2/1.txt", strings.NewReader("foo"), 3*1048576, 3,
+		CreateOptions{BlockSize: 1048576})
+	assert.Error(t, err)
+	assertOnlyFiles(t, client, "/_test/parallel2")
+}
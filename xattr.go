@@ -0,0 +1,147 @@
+package hdfs
+
+import (
+	"errors"
+	"os"
+	"sort"
+	"strings"
+
+	hdfs "github.com/colinmarc/hdfs/v2/internal/protocol/hadoop_hdfs"
+	"github.com/golang/protobuf/proto"
+)
+
+// GetXAttrs returns the extended attributes of the named file or directory,
+// keyed by their full names (for example, "user.checksum"). If no keys are
+// specified, all of the extended attributes that the user is allowed to see
+// are returned.
+func (c *Client) GetXAttrs(name string, keys ...string) (map[string]string, error) {
+	req := &hdfs.GetXAttrsRequestProto{Src: proto.String(name)}
+	for _, key := range keys {
+		xattr, err := newXAttr(key, nil)
+		if err != nil {
+			return nil, &os.PathError{"getxattrs", name, err}
+		}
+
+		req.XAttrs = append(req.XAttrs, xattr)
+	}
+	resp := &hdfs.GetXAttrsResponseProto{}
+
+	err := c.namenode.Execute("getXAttrs", req, resp)
+	if err != nil {
+		return nil, &os.PathError{"getxattrs", name, interpretException(err)}
+	}
+
+	xattrs := make(map[string]string, len(resp.GetXAttrs()))
+	for _, xattr := range resp.GetXAttrs() {
+		xattrs[xattrKey(xattr)] = string(xattr.GetValue())
+	}
+
+	return xattrs, nil
+}
+
+// ListXAttrs returns the names of the extended attributes of the named file or
+// directory that the user is allowed to see, sorted, without their values.
+func (c *Client) ListXAttrs(name string) ([]string, error) {
+	req := &hdfs.ListXAttrsRequestProto{Src: proto.String(name)}
+	resp := &hdfs.ListXAttrsResponseProto{}
+
+	err := c.namenode.Execute("listXAttrs", req, resp)
+	if err != nil {
+		return nil, &os.PathError{"listxattrs", name, interpretException(err)}
+	}
+
+	keys := make([]string, 0, len(resp.GetXAttrs()))
+	for _, xattr := range resp.GetXAttrs() {
+		keys = append(keys, xattrKey(xattr))
+	}
+
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// SetXAttr sets an extended attribute on the named file or directory,
+// replacing any existing value. The key must include the namespace, for
+// example "user.checksum".
+func (c *Client) SetXAttr(name, key, value string) error {
+	xattr, err := newXAttr(key, []byte(value))
+	if err != nil {
+		return &os.PathError{"setxattr", name, err}
+	}
+
+	req := &hdfs.SetXAttrRequestProto{
+		Src:   proto.String(name),
+		XAttr: xattr,
+		Flag:  proto.Uint32(uint32(hdfs.XAttrSetFlagProto_XATTR_CREATE | hdfs.XAttrSetFlagProto_XATTR_REPLACE)),
+	}
+	resp := &hdfs.SetXAttrResponseProto{}
+
+	err = c.namenode.Execute("setXAttr", req, resp)
+	if err != nil {
+		return &os.PathError{"setxattr", name, interpretException(err)}
+	}
+
+	return nil
+}
+
+// RemoveXAttr removes an extended attribute from the named file or directory.
+// It returns an error if the attribute doesn't exist.
+func (c *Client) RemoveXAttr(name, key string) error {
+	xattr, err := newXAttr(key, nil)
+	if err != nil {
+		return &os.PathError{"removexattr", name, err}
+	}
+
+	req := &hdfs.RemoveXAttrRequestProto{
+		Src:   proto.String(name),
+		XAttr: xattr,
+	}
+	resp := &hdfs.RemoveXAttrResponseProto{}
+
+	err = c.namenode.Execute("removeXAttr", req, resp)
+	if err != nil {
+		return &os.PathError{"removexattr", name, interpretException(err)}
+	}
+
+	return nil
+}
+
+// StatXAttrs is like Stat, but also fetches the extended attributes of the
+// file or directory, which are then available from FileInfo.XAttrs.
+func (c *Client) StatXAttrs(name string) (os.FileInfo, error) {
+	fi, err := c.Stat(name)
+	if err != nil {
+		return nil, err
+	}
+
+	xattrs, err := c.GetXAttrs(name)
+	if err != nil {
+		return nil, err
+	}
+
+	fi.(*FileInfo).xattrs = xattrs
+	return fi, nil
+}
+
+// xattrKey returns the full name of an extended attribute, including the
+// namespace, for example "user.checksum".
+func xattrKey(xattr *hdfs.XAttrProto) string {
+	return strings.ToLower(xattr.GetNamespace().String()) + "." + xattr.GetName()
+}
+
+func newXAttr(key string, value []byte) (*hdfs.XAttrProto, error) {
+	parts := strings.SplitN(key, ".", 2)
+	if len(parts) != 2 {
+		return nil, errors.New("invalid xattr name, which must include a namespace")
+	}
+
+	ns, ok := hdfs.XAttrProto_XAttrNamespaceProto_value[strings.ToUpper(parts[0])]
+	if !ok {
+		return nil, errors.New("invalid xattr namespace")
+	}
+
+	return &hdfs.XAttrProto{
+		Namespace: hdfs.XAttrProto_XAttrNamespaceProto(ns).Enum(),
+		Name:      proto.String(parts[1]),
+		Value:     value,
+	}, nil
+}
@@ -0,0 +1,91 @@
+package hdfs
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestXAttrs(t *testing.T) {
+	client := getClient(t)
+
+	baleet(t, "/_test/xattrs")
+	touch(t, "/_test/xattrs")
+
+	err := client.SetXAttr("/_test/xattrs", "user.foo", "bar")
+	require.NoError(t, err)
+
+	err = client.SetXAttr("/_test/xattrs", "user.baz", "qux")
+	require.NoError(t, err)
+
+	xattrs, err := client.GetXAttrs("/_test/xattrs")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"user.foo": "bar", "user.baz": "qux"}, xattrs)
+
+	xattrs, err = client.GetXAttrs("/_test/xattrs", "user.foo")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"user.foo": "bar"}, xattrs)
+
+	err = client.SetXAttr("/_test/xattrs", "user.foo", "bar2")
+	require.NoError(t, err)
+
+	xattrs, err = client.GetXAttrs("/_test/xattrs", "user.foo")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"user.foo": "bar2"}, xattrs)
+}
+
+func TestListAndRemoveXAttrs(t *testing.T) {
+	client := getClient(t)
+
+	baleet(t, "/_test/xattrsremove")
+	touch(t, "/_test/xattrsremove")
+
+	keys, err := client.ListXAttrs("/_test/xattrsremove")
+	require.NoError(t, err)
+	assert.Empty(t, keys)
+
+	require.NoError(t, client.SetXAttr("/_test/xattrsremove", "user.foo", "bar"))
+	require.NoError(t, client.SetXAttr("/_test/xattrsremove", "user.baz", "qux"))
+
+	keys, err = client.ListXAttrs("/_test/xattrsremove")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user.baz", "user.foo"}, keys)
+
+	fi, err := client.StatXAttrs("/_test/xattrsremove")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"user.foo": "bar", "user.baz": "qux"}, fi.(*FileInfo).XAttrs())
+
+	require.NoError(t, client.RemoveXAttr("/_test/xattrsremove", "user.foo"))
+	keys, err = client.ListXAttrs("/_test/xattrsremove")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user.baz"}, keys)
+
+	err = client.RemoveXAttr("/_test/xattrsremove", "user.foo")
+	assert.Error(t, err)
+
+	// Plain Stat doesn't fetch them.
+	fi, err = client.Stat("/_test/xattrsremove")
+	require.NoError(t, err)
+	assert.Nil(t, fi.(*FileInfo).XAttrs())
+}
+
+func TestXAttrsInvalidName(t *testing.T) {
+	client := getClient(t)
+
+	err := client.SetXAttr("/_test/foo.txt", "foo", "bar")
+	assert.Error(t, err)
+
+	err = client.SetXAttr("/_test/foo.txt", "bogus.foo", "bar")
+	assert.Error(t, err)
+}
+
+func TestXAttrsNonexistent(t *testing.T) {
+	client := getClient(t)
+
+	baleet(t, "/_test/nonexistent")
+
+	_, err := client.GetXAttrs("/_test/nonexistent")
+	assertPathError(t, err, "getxattrs", "/_test/nonexistent", os.ErrNotExist)
+}